@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/contract"
+	"github.com/spf13/cobra"
+)
+
+const verifyTimeout = 5 * time.Minute
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-contract",
+		Short: "Verify the KEB contract of a mothership reconciler",
+		Long: `Replay the request/response pairs which were recorded by a mothership reconciler started with '--record-contract'
+against the given mothership reconciler (e.g. a new build). The command fails if a status code differs or if a response
+doesn't match the structure of the recorded response.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return Run(o)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.Dir, "dir", "", "Directory containing the recorded golden files")
+	cmd.Flags().StringVar(&o.Target, "target", "http://localhost:8080", "URL of the mothership reconciler which is verified")
+	cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "Path to the kubeconfig file which replaces the redacted kubeconfigs of the recorded requests")
+
+	return cmd
+}
+
+func Run(o *Options) error {
+	interactions, err := contract.Load(o.Dir)
+	if err != nil {
+		return err
+	}
+	if len(interactions) == 0 {
+		return fmt.Errorf("no recorded interactions found in directory '%s'", o.Dir)
+	}
+
+	var kubeconfig []byte
+	if o.Kubeconfig != "" {
+		if kubeconfig, err = ioutil.ReadFile(o.Kubeconfig); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+	mismatches, err := contract.NewVerifier(o.Target, string(kubeconfig), nil).Verify(ctx, interactions)
+	if err != nil {
+		return err
+	}
+	for _, mismatch := range mismatches {
+		o.Logger().Errorf("Contract violated: %s", mismatch)
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%d of %d recorded interactions violate the contract", len(mismatches), len(interactions))
+	}
+	o.Logger().Infof("All %d recorded interactions fulfil the contract", len(interactions))
+	return nil
+}
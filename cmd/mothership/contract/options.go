@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	file "github.com/kyma-incubator/reconciler/pkg/files"
+)
+
+type Options struct {
+	*cli.Options
+	Dir        string
+	Target     string
+	Kubeconfig string
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o,
+		"", //Dir
+		"", //Target
+		"", //Kubeconfig
+	}
+}
+
+func (o *Options) Validate() error {
+	if o.Dir == "" {
+		return fmt.Errorf("directory of the golden files is undefined")
+	}
+	if !file.DirExists(o.Dir) {
+		return fmt.Errorf("directory '%s' of the golden files does not exist", o.Dir)
+	}
+	if _, err := url.ParseRequestURI(o.Target); err != nil {
+		return fmt.Errorf("target '%s' is not a valid URL: %s", o.Target, err)
+	}
+	if o.Kubeconfig != "" && !file.Exists(o.Kubeconfig) {
+		return fmt.Errorf("kubeconfig file '%s' does not exist", o.Kubeconfig)
+	}
+	return nil
+}
@@ -7,8 +7,12 @@ import (
 	"strings"
 
 	cfgCmd "github.com/kyma-incubator/reconciler/cmd/mothership/config"
+	contractCmd "github.com/kyma-incubator/reconciler/cmd/mothership/contract"
 	localCmd "github.com/kyma-incubator/reconciler/cmd/mothership/local"
 	msCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership"
+	replayCmd "github.com/kyma-incubator/reconciler/cmd/mothership/replay"
+	rotateKeysCmd "github.com/kyma-incubator/reconciler/cmd/mothership/rotatekeys"
+	simulateCmd "github.com/kyma-incubator/reconciler/cmd/mothership/simulate"
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	file "github.com/kyma-incubator/reconciler/pkg/files"
 	"github.com/spf13/cobra"
@@ -32,6 +36,10 @@ func main() {
 	cmd.AddCommand(cfgCmd.NewCmd(o))
 	cmd.AddCommand(msCmd.NewCmd(o))
 	cmd.AddCommand(localCmd.NewCmd(localCmd.NewOptions(o)))
+	cmd.AddCommand(rotateKeysCmd.NewCmd(rotateKeysCmd.NewOptions(o)))
+	cmd.AddCommand(replayCmd.NewCmd(replayCmd.NewOptions(o)))
+	cmd.AddCommand(contractCmd.NewCmd(contractCmd.NewOptions(o)))
+	cmd.AddCommand(simulateCmd.NewCmd(simulateCmd.NewOptions(o)))
 
 	if err := cmd.Execute(); err != nil {
 		os.Exit(1)
@@ -48,6 +56,9 @@ func newCmd(o *cli.Options, name, shortDesc, longDesc string) *cobra.Command {
 			if err := o.Validate(); err != nil {
 				return err
 			}
+			if err := o.ConfigureRedaction(); err != nil {
+				return err
+			}
 			return o.InitApplicationRegistry(false)
 		},
 		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
@@ -65,6 +76,10 @@ func newCmd(o *cli.Options, name, shortDesc, longDesc string) *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(&o.Verbose, "verbose", "v", false, "Show detailed information about the executed command actions")
 	cmd.PersistentFlags().BoolVar(&o.NonInteractive, "non-interactive", false, "Enables the non-interactive shell mode")
 	cmd.PersistentFlags().BoolVarP(&o.InitRegistry, "init-registry", "r", false, "Auto-initialize application registry ")
+	cmd.PersistentFlags().StringSliceVar(&o.RedactKeys, "redact-keys", nil,
+		"Additional keys whose values are masked in logs and callbacks (e.g. 'dbPassword,apiCert')")
+	cmd.PersistentFlags().StringSliceVar(&o.RedactPatterns, "redact-patterns", nil,
+		"Additional regular expressions of sensitive data which is masked in logs and callbacks")
 	cmd.PersistentFlags().BoolP("help", "h", false, "Command help")
 	return cmd
 }
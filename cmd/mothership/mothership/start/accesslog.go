@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const headerRequestID = "X-Request-ID"
+
+//requestIDPattern restricts request IDs sent by clients: they are written to the logs and into response headers
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type requestIDContextKey struct{}
+
+//requestID returns the ID of the request or an empty string if the request didn't pass the access log middleware
+func requestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+//forRequest returns the options for a handler: its logger adds the request ID to all log entries, which includes the
+//logs of the inventory and scheduler operations executed by the handler
+func (o *Options) forRequest(r *http.Request) *Options {
+	id := requestID(r.Context())
+	if id == "" {
+		return o
+	}
+	requestOptions := *o
+	requestOptions.Options = o.Options.WithLogger(o.Logger().With("requestID", id))
+	return &requestOptions
+}
+
+//accessLogWriter captures the status and size of the response
+type accessLogWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *accessLogWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *accessLogWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	return n, err
+}
+
+//Flush supports streamed responses (e.g. server-sent events)
+func (w *accessLogWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+//isProbe returns true for requests of the monitoring (health probes and metrics scrapes): they are only logged in
+//debug mode to keep the access log readable
+func isProbe(path string) bool {
+	return path == "/metrics" || path == "/healthz" || path == "/readyz" || strings.HasPrefix(path, "/health/")
+}
+
+//newAccessLogMiddleware logs each request with its method, path, status and latency. The request ID is taken from the
+//X-Request-ID header of the request (a new ID is generated if the header is missing or invalid): it's returned in the
+//response and added to the logs of the handler to trace a single API call through the mothership logs.
+func newAccessLogMiddleware(logger *zap.SugaredLogger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			id := r.Header.Get(headerRequestID)
+			if !requestIDPattern.MatchString(id) {
+				id = uuid.NewString()
+			}
+			w.Header().Set(headerRequestID, id)
+
+			accessLogW := &accessLogWriter{ResponseWriter: w}
+			defer func() { //panics are logged as well: they are re-raised by the HTTP server or the recovery middleware
+				status := accessLogW.status
+				if status == 0 {
+					status = http.StatusOK
+				}
+				requestLogger := logger.With("requestID", id, "method", r.Method, "path", r.URL.Path,
+					"status", status, "latencyMs", time.Since(start).Milliseconds(), "size", accessLogW.size)
+				if isProbe(r.URL.Path) {
+					requestLogger.Debugf("HTTP request '%s %s' answered with status %d", r.Method, r.URL.Path, status)
+				} else {
+					requestLogger.Infof("HTTP request '%s %s' answered with status %d", r.Method, r.URL.Path, status)
+				}
+			}()
+			next.ServeHTTP(accessLogW, r.WithContext(context.WithValue(r.Context(), requestIDContextKey{}, id)))
+		})
+	}
+}
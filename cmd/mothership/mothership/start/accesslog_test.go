@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAccessLogMiddleware(t *testing.T) {
+	newRouter := func() (*mux.Router, *observer.ObservedLogs) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		o := &Options{Options: (&cli.Options{}).WithLogger(zap.New(core).Sugar())}
+
+		router := mux.NewRouter()
+		router.Use(newAccessLogMiddleware(o.Logger()))
+		router.HandleFunc("/v1/clusters/{runtimeID}", callHandler(o, func(o *Options, w http.ResponseWriter, r *http.Request) {
+			o.Logger().Info("Cluster deleted")
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		router.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		})
+		return router, logs
+	}
+	serve := func(router *mux.Router, path, id string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, path, nil)
+		if id != "" {
+			req.Header.Set(headerRequestID, id)
+		}
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Request ID is generated", func(t *testing.T) {
+		router, logs := newRouter()
+		recorder := serve(router, "/v1/clusters/abc", "")
+		require.Equal(t, http.StatusAccepted, recorder.Code)
+		id := recorder.Header().Get(headerRequestID)
+		_, err := uuid.Parse(id)
+		require.NoError(t, err)
+
+		entries := logs.All()
+		require.Len(t, entries, 2)
+		//handler logs contain the request ID
+		require.Equal(t, "Cluster deleted", entries[0].Message)
+		require.Equal(t, id, entries[0].ContextMap()["requestID"])
+		//access log
+		require.Equal(t, zapcore.InfoLevel, entries[1].Level)
+		fields := entries[1].ContextMap()
+		require.Equal(t, id, fields["requestID"])
+		require.Equal(t, http.MethodDelete, fields["method"])
+		require.Equal(t, "/v1/clusters/abc", fields["path"])
+		require.EqualValues(t, http.StatusAccepted, fields["status"])
+		require.Contains(t, fields, "latencyMs")
+	})
+
+	t.Run("Request ID of client is used", func(t *testing.T) {
+		router, logs := newRouter()
+		recorder := serve(router, "/v1/clusters/abc", "keb-123.4")
+		require.Equal(t, "keb-123.4", recorder.Header().Get(headerRequestID))
+		for _, entry := range logs.All() {
+			require.Equal(t, "keb-123.4", entry.ContextMap()["requestID"])
+		}
+	})
+
+	t.Run("Invalid request ID of client is replaced", func(t *testing.T) {
+		router, _ := newRouter()
+		recorder := serve(router, "/v1/clusters/abc", "abc\ndef")
+		_, err := uuid.Parse(recorder.Header().Get(headerRequestID))
+		require.NoError(t, err)
+	})
+
+	t.Run("Probes are logged in debug mode", func(t *testing.T) {
+		router, logs := newRouter()
+		recorder := serve(router, "/healthz", "")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.NotEmpty(t, recorder.Header().Get(headerRequestID))
+		require.Equal(t, 1, logs.FilterLevelExact(zapcore.DebugLevel).Len())
+		require.Equal(t, 0, logs.FilterLevelExact(zapcore.InfoLevel).Len())
+	})
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/policy"
+	"github.com/kyma-incubator/reconciler/pkg/validation"
+)
+
+//admitCluster evaluates the policies and calls the validation webhook for a cluster configuration. It returns the
+//warnings of the webhook or the rejection of the policy engine or webhook (see isAdmissionRejectionError).
+func admitCluster(ctx context.Context, o *Options, op policy.Operation, contractVersion int64,
+	clusterModel *keb.Cluster) ([]string, error) {
+	if _, err := o.PolicyEngine.Admit(ctx, op, contractVersion, clusterModel); err != nil {
+		return nil, err
+	}
+	return o.ValidationWebhook.Validate(ctx, contractVersion, clusterModel)
+}
+
+func isAdmissionRejectionError(err error) bool {
+	return policy.IsRejectionError(err) || validation.IsRejectionError(err)
+}
+
+//updateConfigWarnings stores the warnings of the validation webhook for the configuration version: they always
+//reflect the latest validation of the configuration version
+func updateConfigWarnings(o *Options, runtimeID string, configVersion int64, warnings []string) error {
+	if o.ValidationWebhook == nil {
+		return nil
+	}
+	return o.Registry.Inventory().UpdateConfigWarnings(runtimeID, configVersion, warnings)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/redact"
 	"github.com/kyma-incubator/reconciler/pkg/server"
 
 	"github.com/google/uuid"
@@ -131,7 +132,8 @@ func auditLogRequest(w http.ResponseWriter, r *http.Request, l *zap.Logger, o *O
 			return
 		}
 		r.Body = ioutil.NopCloser(bytes.NewBuffer(reqBody))
-		logData.RequestBody = string(reqBody)
+		//credentials (e.g. kubeconfigs) must never be written to the audit log
+		logData.RequestBody = string(redact.JSON(reqBody))
 	}
 
 	data, err := json.Marshal(logData)
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+)
+
+//unauthenticatedRoutes are called by the component reconcilers which don't have a bearer token
+var unauthenticatedRoutes = map[string]bool{
+	fmt.Sprintf("/v{%s}/operations/{%s}/callback/{%s}", paramContractVersion, paramSchedulingID, paramCorrelationID): true,
+	fmt.Sprintf("/v{%s}/operations/callbacks", paramContractVersion):                                                 true,
+}
+
+//newAuthMiddleware rejects unauthenticated requests of all API routes except the operation callbacks. Callers which
+//presented a verified client certificate are authenticated already.
+func newAuthMiddleware(authenticator *auth.Authenticator) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		authenticated := authenticator.Middleware(h)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path, err := mux.CurrentRoute(r).GetPathTemplate()
+			if (err == nil && unauthenticatedRoutes[path]) || clientCertSubject(r) != "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+			authenticated.ServeHTTP(w, r)
+		})
+	}
+}
+
+//clientCertSubject returns the CN of the client certificate which was verified during the TLS handshake
+func clientCertSubject(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return r.TLS.VerifiedChains[0][0].Subject.CommonName
+}
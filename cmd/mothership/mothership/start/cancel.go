@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/service"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+const paramReason = "reason"
+
+//cancelReconciliation aborts a running reconciliation of a cluster: operations which aren't finished yet are
+//marked as aborted and component reconcilers stop processing them with their next callback
+func cancelReconciliation(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	reason, err := params.String(paramReason)
+	if err != nil || reason == "" {
+		reason = fmt.Sprintf("reconciliation cancelled by '%s'", requestUser(r))
+	}
+
+	recon, err := o.Registry.ReconciliationRepository().GetReconciliation(schedulingID)
+	if err == nil && recon.RuntimeID != runtimeID {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("Cluster '%s' has no reconciliation with schedulingID '%s'", runtimeID, schedulingID),
+		})
+		return
+	}
+	if err == nil {
+		transition := service.NewClusterStatusTransition(o.Registry.Connection(), o.Registry.Inventory(),
+			o.Registry.ReconciliationRepository(), o.Logger())
+		err = transition.AbortReconciliation(schedulingID, reason)
+	}
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		} else if reconciliation.IsFinishedReconciliationError(err) {
+			httpCode = http.StatusConflict
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to cancel reconciliation").Error(),
+		})
+		return
+	}
+
+	o.Logger().Warnf("Reconciliation of cluster '%s' (schedulingID:%s) cancelled: %s", runtimeID, schedulingID, reason)
+
+	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to retrieve cluster state").Error(),
+		})
+		return
+	}
+	sendResponse(w, r, clusterState, o)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/kyma-incubator/reconciler/pkg/version"
+	"github.com/pkg/errors"
+)
+
+//features of the mothership which can be detected by clients (features which aren't supported by this build,
+//like dry-runs, are never listed)
+const (
+	featureDeleteReconciliation  = "deleteReconciliation"
+	featureBulkCallbacks         = "bulkCallbacks"
+	featureKubeconfigRollback    = "kubeconfigRollback"
+	featureOperationLogs         = "operationLogs"
+	featureConfigTemplates       = "configTemplates"
+	featureQuery                 = "query"
+	featureChangeFeed            = "changeFeed"
+	featurePause                 = "pause"
+	featureSLOs                  = "slo"
+	featureDeadLetter            = "deadLetter"
+	featurePolicyAdmission       = "policyAdmission"
+	featureValidationWebhook     = "validationWebhook"
+	featureAuditLog              = "auditLog"
+	featureFlakiness             = "flakiness"
+	featureHealthScores          = "healthScores"
+	featureUpdateRateLimit       = "updateRateLimit"
+	featureCancelReconciliation  = "cancelReconciliation"
+	featureConfigRollback        = "configRollback"
+	featureOperationDetails      = "operationDetails"
+	featureUninstallConfirmation = "uninstallConfirmation"
+	featureClientRateLimit       = "clientRateLimit"
+	featureKubeconfigVerify      = "kubeconfigVerification"
+	featureStatusStream          = "statusStream" //server-sent events of '/clusters/{id}/status/stream'
+)
+
+const (
+	authModeNone = "none"
+	authModeJWT  = "jwt"
+	authModeMTLS = "mtls"
+)
+
+//getCapabilities returns the features supported by this mothership so that clients can feature-detect
+//instead of relying on version parsing
+func getCapabilities(o *Options, w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(newCapabilitiesResponse(o)); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "failed to encode capabilities response").Error(),
+		})
+	}
+}
+
+func newCapabilitiesResponse(o *Options) *keb.HTTPCapabilitiesResponse {
+	deprecated := make([]int64, 0, len(deprecatedContractVersions))
+	for contractV := range deprecatedContractVersions {
+		deprecated = append(deprecated, contractV)
+	}
+	sort.Slice(deprecated, func(i, j int) bool { return deprecated[i] < deprecated[j] })
+
+	features := []string{
+		featureDeleteReconciliation,
+		featureBulkCallbacks,
+		featureKubeconfigRollback,
+		featureOperationLogs,
+		featureConfigTemplates,
+		featureQuery,
+		featureChangeFeed,
+		featurePause,
+		featureSLOs,
+		featureCancelReconciliation,
+		featureConfigRollback,
+		featureOperationDetails,
+		featureUninstallConfirmation,
+		featureStatusStream,
+	}
+	//optional features are only listed if they are enabled
+	if o.Config != nil && o.Config.Scheduler.DeadLetter.Enabled {
+		features = append(features, featureDeadLetter)
+	}
+	if o.PolicyEngine != nil {
+		features = append(features, featurePolicyAdmission)
+	}
+	if o.ValidationWebhook != nil {
+		features = append(features, featureValidationWebhook)
+	}
+	if o.FlakinessClassifier != nil {
+		features = append(features, featureFlakiness)
+	}
+	if o.HealthScorer != nil {
+		features = append(features, featureHealthScores)
+	}
+	if o.UpdateLimiter != nil {
+		features = append(features, featureUpdateRateLimit)
+	}
+	if o.ClientLimiter != nil {
+		features = append(features, featureClientRateLimit)
+	}
+	if o.AuditLog {
+		features = append(features, featureAuditLog)
+	}
+	if o.VerifyKubeconfig {
+		features = append(features, featureKubeconfigVerify)
+	}
+
+	var authModes []string
+	if o.Auth.Enabled() {
+		authModes = append(authModes, authModeJWT)
+	}
+	if o.ClientAuth.Enabled() {
+		authModes = append(authModes, authModeMTLS)
+	}
+	if len(authModes) == 0 {
+		authModes = []string{authModeNone}
+	}
+
+	return &keb.HTTPCapabilitiesResponse{
+		AuthModes:                  authModes,
+		ContractVersions:           version.Get().ContractVersions,
+		DeprecatedContractVersions: deprecated,
+		Features:                   features,
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/policy"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/ssl"
+	"github.com/kyma-incubator/reconciler/pkg/version"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities(t *testing.T) {
+	getCapabilitiesResponse := func(t *testing.T, o *Options) *keb.HTTPCapabilitiesResponse {
+		w := httptest.NewRecorder()
+		getCapabilities(o, w, httptest.NewRequest(http.MethodGet, "/v1/capabilities", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		resp := &keb.HTTPCapabilitiesResponse{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+		return resp
+	}
+
+	t.Run("Default capabilities", func(t *testing.T) {
+		resp := getCapabilitiesResponse(t, &Options{Config: &config.Config{}})
+		require.Equal(t, version.ContractVersions, resp.ContractVersions)
+		require.Equal(t, []int64{1}, resp.DeprecatedContractVersions)
+		require.Equal(t, []string{authModeNone}, resp.AuthModes)
+		require.Contains(t, resp.Features, featureDeleteReconciliation)
+		require.Contains(t, resp.Features, featureBulkCallbacks)
+		require.Contains(t, resp.Features, featureCancelReconciliation)
+		require.Contains(t, resp.Features, featureConfigRollback)
+		require.Contains(t, resp.Features, featureOperationDetails)
+		require.Contains(t, resp.Features, featureUninstallConfirmation)
+		require.Contains(t, resp.Features, featureStatusStream)
+		require.NotContains(t, resp.Features, featureDeadLetter)
+		require.NotContains(t, resp.Features, featurePolicyAdmission)
+		require.NotContains(t, resp.Features, featureValidationWebhook)
+		require.NotContains(t, resp.Features, featureFlakiness)
+		require.NotContains(t, resp.Features, featureHealthScores)
+		require.NotContains(t, resp.Features, featureUpdateRateLimit)
+		require.NotContains(t, resp.Features, featureClientRateLimit)
+		require.NotContains(t, resp.Features, featureKubeconfigVerify)
+	})
+
+	t.Run("Enabled optional features", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Scheduler.DeadLetter.Enabled = true
+		resp := getCapabilitiesResponse(t, &Options{Config: cfg, PolicyEngine: &policy.Engine{}, AuditLog: true,
+			VerifyKubeconfig: true})
+		require.Contains(t, resp.Features, featureDeadLetter)
+		require.Contains(t, resp.Features, featurePolicyAdmission)
+		require.Contains(t, resp.Features, featureAuditLog)
+		require.Contains(t, resp.Features, featureKubeconfigVerify)
+		require.NotContains(t, resp.Features, featureValidationWebhook)
+	})
+
+	t.Run("JWT authentication", func(t *testing.T) {
+		resp := getCapabilitiesResponse(t, &Options{
+			Config: &config.Config{},
+			Auth:   auth.Config{JWKSURL: "https://issuer/keys"},
+		})
+		require.Equal(t, []string{authModeJWT}, resp.AuthModes)
+	})
+
+	t.Run("Client certificate authentication", func(t *testing.T) {
+		resp := getCapabilitiesResponse(t, &Options{
+			Config:     &config.Config{},
+			Auth:       auth.Config{JWKSURL: "https://issuer/keys"},
+			ClientAuth: ssl.ClientAuthConfig{CAFile: "ca.crt"},
+		})
+		require.Equal(t, []string{authModeJWT, authModeMTLS}, resp.AuthModes)
+	})
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/kyma-incubator/reconciler/pkg/changes"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+const (
+	paramCursor = "cursor"
+	paramLimit  = "limit"
+
+	defaultChangesLimit = 100
+	maxChangesLimit     = 1000
+)
+
+//getChanges returns the change feed after the cursor. Consumers mirror the inventory and reconciliation
+//states by passing the cursor of the last processed change with each request.
+func getChanges(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+
+	var cursor int64
+	if cursorParam, err := params.String(paramCursor); err == nil && cursorParam != "" {
+		if cursor, err = strconv.ParseInt(cursorParam, 10, 64); err != nil || cursor < 0 {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+				Error: fmt.Sprintf("Cursor '%s' is invalid", cursorParam),
+			})
+			return
+		}
+	}
+	limit := defaultChangesLimit
+	if limitParam, err := params.Int(paramLimit); err == nil {
+		if limitParam <= 0 || limitParam > maxChangesLimit {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+				Error: fmt.Sprintf("Limit has to be between 1 and %d", maxChangesLimit),
+			})
+			return
+		}
+		limit = limitParam
+	}
+
+	changeEntities, err := o.Registry.ChangeRepository().Changes(cursor, limit)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if changes.IsExpiredCursorError(err) {
+			httpCode = http.StatusGone
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to retrieve changes").Error(),
+		})
+		return
+	}
+
+	//the cursor stays the same if no new changes exist
+	resp := keb.HTTPChangesResponse{
+		Changes: []keb.Change{},
+		Cursor:  strconv.FormatInt(cursor, 10),
+	}
+	for _, change := range changeEntities {
+		resp.Changes = append(resp.Changes, newChangeResponse(change))
+		resp.Cursor = strconv.FormatInt(change.FeedPosition, 10)
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode changes response").Error(),
+		})
+	}
+}
+
+func newChangeResponse(change *model.ChangeEntity) keb.Change {
+	resp := keb.Change{
+		ConfigVersion: change.ConfigVersion,
+		Created:       change.Created,
+		Cursor:        strconv.FormatInt(change.FeedPosition, 10),
+		Kind:          keb.ChangeKind(change.Kind),
+		RuntimeID:     change.RuntimeID,
+		Status:        keb.Status(change.Status),
+	}
+	if change.SchedulingID != "" {
+		schedulingID := change.SchedulingID
+		resp.SchedulingID = &schedulingID
+	}
+	return resp
+}
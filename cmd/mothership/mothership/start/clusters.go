@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/health"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+const (
+	paramLabel = "label"
+	paramSort  = "sort"
+
+	sortByRuntimeID = "runtimeID"
+	sortByHealth    = "health"
+
+	defaultClustersLimit = 100
+	maxClustersLimit     = 1000
+)
+
+//listClusters returns the registered clusters matching the filters (status, runtime ID and labels) page by page
+func listClusters(o *Options, w http.ResponseWriter, r *http.Request) {
+	filter, err := newClusterListFilter(server.NewParams(r), o.HealthScorer)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	states, total, err := o.Registry.Inventory().List(filter)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to list clusters").Error(),
+		})
+		return
+	}
+
+	resp := keb.HTTPClustersResponse{
+		Clusters: []keb.ClusterSummary{},
+		Limit:    int64(filter.Limit),
+		Offset:   int64(filter.Offset),
+		Total:    int64(total),
+	}
+	//warnings of all listed configuration versions are loaded with one query
+	configVersions := make([]int64, 0, len(states))
+	for _, state := range states {
+		configVersions = append(configVersions, state.Configuration.Version)
+	}
+	configWarnings, err := o.Registry.Inventory().GetConfigWarningsBatch(configVersions)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to load configuration warnings").Error(),
+		})
+		return
+	}
+	//same for the deletion protections of the listed clusters
+	runtimeIDs := make([]string, 0, len(states))
+	for _, state := range states {
+		runtimeIDs = append(runtimeIDs, state.Cluster.RuntimeID)
+	}
+	deletionProtections, err := o.Registry.Inventory().GetDeletionProtections(runtimeIDs)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to load deletion protections").Error(),
+		})
+		return
+	}
+
+	apiVersion := strings.Split(r.URL.RequestURI(), "/")[1]
+	for _, state := range states {
+		summary, err := newClusterSummary(o, apiVersion, state, configWarnings[state.Configuration.Version],
+			deletionProtections[state.Cluster.RuntimeID])
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, "Failed to generate cluster list response").Error(),
+			})
+			return
+		}
+		resp.Clusters = append(resp.Clusters, summary)
+	}
+	if filter.Less == nil && len(states) > 0 && len(states) == filter.Limit {
+		//a full page: further clusters are returned after the last runtime ID
+		next := states[len(states)-1].Cluster.RuntimeID
+		resp.Next = &next
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode cluster list response").Error(),
+		})
+	}
+}
+
+func newClusterListFilter(params *server.Params, scorer *health.Scorer) (*cluster.ListFilter, error) {
+	filter := &cluster.ListFilter{
+		Limit: defaultClustersLimit,
+	}
+	if statuses, err := params.StrSlice(paramStatus); err == nil {
+		for _, status := range statuses {
+			if _, err := model.NewClusterStatus(model.Status(status)); err != nil {
+				return nil, err
+			}
+			filter.Statuses = append(filter.Statuses, model.Status(status))
+		}
+	}
+	if runtimeIDs, err := params.StrSlice(paramRuntimeIDs); err == nil {
+		for _, runtimeID := range runtimeIDs {
+			if err := cluster.ValidateRuntimeID(runtimeID); err != nil {
+				return nil, err
+			}
+			filter.RuntimeIDs = append(filter.RuntimeIDs, runtimeID)
+		}
+	}
+	if labels, err := params.StrSlice(paramLabel); err == nil {
+		filter.Labels = make(map[string]string, len(labels))
+		for _, label := range labels {
+			keyValue := strings.SplitN(label, "=", 2)
+			if len(keyValue) != 2 || keyValue[0] == "" {
+				return nil, fmt.Errorf("label '%s' is invalid: it has to be defined as 'key=value'", label)
+			}
+			filter.Labels[keyValue[0]] = keyValue[1]
+		}
+	}
+	if after, err := params.String(paramAfter); err == nil && after != "" {
+		if err := cluster.ValidateRuntimeID(after); err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+	if offset, err := params.Int(paramOffset); err == nil {
+		if offset < 0 {
+			return nil, errors.New("offset cannot be negative")
+		}
+		filter.Offset = offset
+	}
+	if limit, err := params.Int(paramLimit); err == nil {
+		if limit <= 0 || limit > maxClustersLimit {
+			return nil, fmt.Errorf("limit has to be between 1 and %d", maxClustersLimit)
+		}
+		filter.Limit = limit
+	}
+	if sortBy, err := params.String(paramSort); err == nil {
+		switch sortBy {
+		case sortByRuntimeID:
+		case sortByHealth:
+			if scorer == nil {
+				return nil, errors.New("clusters cannot be sorted by health: health scoring is disabled")
+			}
+			if filter.After != "" {
+				return nil, fmt.Errorf("parameter '%s' is only supported if the clusters are sorted by '%s'",
+					paramAfter, sortByRuntimeID)
+			}
+			filter.Less = lessHealthy(scorer)
+		default:
+			return nil, fmt.Errorf("sort order '%s' is not supported: supported are '%s' and '%s'",
+				sortBy, sortByRuntimeID, sortByHealth)
+		}
+	}
+	return filter, nil
+}
+
+//lessHealthy orders the clusters by their health score (least healthy clusters first): clusters without score
+//(no reconciliation finished within the scoring window) are treated as healthy
+func lessHealthy(scorer *health.Scorer) func(a, b *cluster.State) bool {
+	healthScore := func(state *cluster.State) int {
+		if score, ok := scorer.Get(state.Cluster.RuntimeID); ok {
+			return score.Score
+		}
+		return health.MaxScore
+	}
+	return func(a, b *cluster.State) bool {
+		scoreA, scoreB := healthScore(a), healthScore(b)
+		if scoreA == scoreB {
+			return a.Cluster.RuntimeID < b.Cluster.RuntimeID
+		}
+		return scoreA < scoreB
+	}
+}
+
+func newClusterSummary(o *Options, apiVersion string, state *cluster.State,
+	warnings []string, deletionProtection bool) (keb.ClusterSummary, error) {
+	kebStatus, err := state.Status.GetKEBClusterStatus()
+	if err != nil {
+		return keb.ClusterSummary{}, err
+	}
+	summary := keb.ClusterSummary{
+		ClusterVersion:       state.Cluster.Version,
+		ConfigurationVersion: state.Configuration.Version,
+		DeletionProtection:   &deletionProtection,
+		KymaProfile:          state.Configuration.KymaProfile,
+		KymaVersion:          state.Configuration.KymaVersion,
+		RuntimeID:            state.Cluster.RuntimeID,
+		Status:               kebStatus,
+		StatusURL: (&url.URL{
+			Scheme: o.Config.Scheme,
+			Host:   fmt.Sprintf("%s:%d", o.Config.Host, o.Config.Port),
+			Path: fmt.Sprintf("%s/clusters/%s/configs/%d/status", apiVersion,
+				state.Cluster.RuntimeID, state.Configuration.Version),
+		}).String(),
+		Updated: state.Status.Created,
+	}
+	if state.Cluster.Metadata != nil {
+		summary.Labels = state.Cluster.Metadata.Labels
+	}
+	if score, ok := o.HealthScorer.Get(state.Cluster.RuntimeID); ok {
+		healthScore := int64(score.Score)
+		summary.HealthScore = &healthScore
+	}
+	if len(warnings) > 0 {
+		summary.Warnings = &warnings
+	}
+	return summary, nil
+}
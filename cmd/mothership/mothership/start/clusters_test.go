@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/health"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestClusterListFilter(t *testing.T) {
+	newParams := func(query string) *server.Params {
+		return server.NewParams(httptest.NewRequest("GET", "/v1/clusters?"+query, nil))
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		filter, err := newClusterListFilter(newParams(""), nil)
+		require.NoError(t, err)
+		require.Equal(t, defaultClustersLimit, filter.Limit)
+		require.Equal(t, 0, filter.Offset)
+		require.Empty(t, filter.Statuses)
+		require.Empty(t, filter.RuntimeIDs)
+		require.Empty(t, filter.Labels)
+	})
+
+	t.Run("All filters", func(t *testing.T) {
+		filter, err := newClusterListFilter(newParams(
+			"status=ready&status=error&runtimeID=abc&label=env=prod&label=region=eu&after=abc&offset=10&limit=5&sort=runtimeID"), nil)
+		require.NoError(t, err)
+		require.Equal(t, []model.Status{model.ClusterStatusReady, model.ClusterStatusReconcileError}, filter.Statuses)
+		require.Equal(t, []string{"abc"}, filter.RuntimeIDs)
+		require.Equal(t, map[string]string{"env": "prod", "region": "eu"}, filter.Labels)
+		require.Equal(t, "abc", filter.After)
+		require.Equal(t, 10, filter.Offset)
+		require.Equal(t, 5, filter.Limit)
+		require.Nil(t, filter.Less)
+	})
+
+	t.Run("Sort by health", func(t *testing.T) {
+		now := time.Now().UTC()
+		scorer, err := health.NewScorer(config.HealthConfig{}, &reconciliation.MockRepository{
+			GetReconciliationsResult: []*model.ReconciliationEntity{
+				{RuntimeID: "healthy", Finished: true, Status: model.ClusterStatusReady, Updated: now},
+				{RuntimeID: "broken", Finished: true, Status: model.ClusterStatusReconcileError, Updated: now},
+			},
+		}, zap.NewNop().Sugar())
+		require.NoError(t, err)
+		require.NoError(t, scorer.Score(now))
+
+		filter, err := newClusterListFilter(newParams("sort=health"), scorer)
+		require.NoError(t, err)
+		newState := func(runtimeID string) *cluster.State {
+			return &cluster.State{Cluster: &model.ClusterEntity{RuntimeID: runtimeID}}
+		}
+		//clusters without score are treated as healthy
+		require.True(t, filter.Less(newState("broken"), newState("unscored")))
+		require.True(t, filter.Less(newState("healthy"), newState("unscored")))
+		require.False(t, filter.Less(newState("healthy"), newState("broken")))
+
+		//keyset pagination requires the order by runtime ID
+		_, err = newClusterListFilter(newParams("sort=health&after=abc"), scorer)
+		require.Error(t, err)
+	})
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		for _, query := range []string{
+			"status=foo",
+			"runtimeID=abc'",
+			"after=abc'",
+			"label=env",
+			"label==prod",
+			"offset=-1",
+			"limit=0",
+			"limit=1001",
+			"sort=foo",
+			"sort=health", //health scoring is disabled
+		} {
+			_, err := newClusterListFilter(newParams(query), nil)
+			require.Error(t, err, query)
+		}
+	})
+}
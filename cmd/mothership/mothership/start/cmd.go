@@ -2,11 +2,31 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/configtemplate"
+	"github.com/kyma-incubator/reconciler/pkg/cors"
 	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/kubeconfigref"
+	"github.com/kyma-incubator/reconciler/pkg/ownership"
+	"github.com/kyma-incubator/reconciler/pkg/policy"
+	"github.com/kyma-incubator/reconciler/pkg/ratelimit"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/anomaly"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/backpressure"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/cohort"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/flaky"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/health"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/slo"
+	"github.com/kyma-incubator/reconciler/pkg/snapshot"
+	"github.com/kyma-incubator/reconciler/pkg/ssl"
+	"github.com/kyma-incubator/reconciler/pkg/subscription"
+	"github.com/kyma-incubator/reconciler/pkg/validation"
 
 	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -41,8 +61,29 @@ func NewCmd(o *Options) *cobra.Command {
 		},
 	}
 	cmd.Flags().IntVar(&o.Port, "server-port", 8080, "Webserver port")
+	cmd.Flags().IntVar(&o.GRPCPort, "grpc-port", 0, "Port of the gRPC API (0 disables the gRPC API)")
+	cmd.Flags().DurationVar(&o.GRPCWatchInterval, "grpc-watch-interval", 5*time.Second, "Interval for polling new status changes which are streamed to gRPC clients")
+	cmd.Flags().IntVar(&o.DebugPort, "debug-port", 0, "Port of the pprof, expvar and goroutine dump endpoints (0 disables the debug endpoints): it must not be exposed publicly")
+	cmd.Flags().StringVar(&o.DebugHost, "debug-host", "127.0.0.1", "Interface the debug endpoints are listening on (empty to listen on all interfaces)")
+	cmd.Flags().BoolVar(&o.ServerPprof, "server-pprof", true, "Serve the pprof endpoints on the webserver port (deprecated: use --debug-port)")
 	cmd.Flags().StringVar(&o.SSLCrt, "server-crt", "", "Path to SSL certificate file")
 	cmd.Flags().StringVar(&o.SSLKey, "server-key", "", "Path to SSL key file")
+	cmd.Flags().DurationVar(&o.CertReloadInterval, "server-cert-reload-interval", 30*time.Second, "Interval for checking the SSL certificate and key file for changes: renewed certificates are used without a restart (0 disables the reload)")
+	cmd.Flags().StringVar(&o.TLS.MinVersion, "server-tls-min-version", ssl.DefaultMinVersion, "Min. TLS version accepted by the webserver and gRPC server ('1.2' or '1.3')")
+	cmd.Flags().StringSliceVar(&o.TLS.CipherSuites, "server-tls-cipher-suite", nil, fmt.Sprintf("TLS 1.2 cipher suite accepted by the webserver and gRPC server (repeatable, default are all of: %s)", strings.Join(ssl.SupportedCipherSuites(), ", ")))
+	cmd.Flags().DurationVar(&o.ServerLimits.ReadHeaderTimeout, "server-read-header-timeout", 10*time.Second, "Max. time to read the headers of a request (0 disables the timeout)")
+	cmd.Flags().DurationVar(&o.ServerLimits.ReadTimeout, "server-read-timeout", 30*time.Second, "Max. time to read an entire request including its body (0 disables the timeout)")
+	cmd.Flags().DurationVar(&o.ServerLimits.WriteTimeout, "server-write-timeout", 2*time.Minute, "Max. time to write the response of a request (0 disables the timeout): status streams are exempted")
+	cmd.Flags().DurationVar(&o.ServerLimits.IdleTimeout, "server-idle-timeout", 2*time.Minute, "Max. time a keep-alive connection waits for the next request (0 disables the timeout)")
+	cmd.Flags().Int64Var(&o.ServerLimits.MaxBodyBytes, "server-max-body-size", 5<<20, "Max. size in bytes of a request body: larger requests are rejected with HTTP 413 (0 disables the limit)")
+	cmd.Flags().StringVar(&o.ClientAuth.CAFile, "client-ca", "", "Path to CA certificate file: if set, client certificates are verified against it (requires SSL certificate and key)")
+	cmd.Flags().BoolVar(&o.ClientAuth.Required, "require-client-cert", false, "Reject TLS connections of clients without a valid certificate")
+	cmd.Flags().StringSliceVar(&o.ClientAuth.AllowedCNs, "client-allowed-cn", nil, "Common name of a client certificate which is allowed to call the API (repeatable)")
+	cmd.Flags().StringSliceVar(&o.ClientAuth.AllowedSANs, "client-allowed-san", nil, "Subject alternative name (DNS, IP, email or URI) of a client certificate which is allowed to call the API (repeatable)")
+	cmd.Flags().StringSliceVar(&o.CORS.AllowedOrigins, "cors-allowed-origin", nil, "Origin (scheme, host and optional port) of a browser-based dashboard which is allowed to call the API, '*' allows any origin (repeatable, CORS is disabled if none is set)")
+	cmd.Flags().StringSliceVar(&o.CORS.AllowedMethods, "cors-allowed-method", nil, fmt.Sprintf("HTTP method which allowed origins can use (repeatable, default: %s)", strings.Join(cors.DefaultAllowedMethods, ", ")))
+	cmd.Flags().StringSliceVar(&o.CORS.AllowedHeaders, "cors-allowed-header", nil, fmt.Sprintf("Request header which allowed origins can send (repeatable, default: %s)", strings.Join(cors.DefaultAllowedHeaders, ", ")))
+	cmd.Flags().DurationVar(&o.CORS.MaxAge, "cors-max-age", 10*time.Minute, "Duration browsers can cache the result of a CORS preflight request")
 	cmd.Flags().IntVarP(&o.MaxParallelOperations, "max-parallel", "", 0, "Maximal parallel reconciled components per cluster, 0 means unlimited")
 	cmd.Flags().IntVarP(&o.Workers, "worker-count", "", 50, "Size of the reconciler worker pool")
 	cmd.Flags().DurationVarP(&o.OrphanOperationTimeout, "orphan-timeout", "", 10*time.Minute, "Timeout until a processed operation which hasn't received status updates from its worker will be restarted")
@@ -53,6 +94,7 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd.Flags().IntVar(&o.ReconciliationsMaxAgeDays, "recon-max-age-days", 0, "Defines the number of days for which the cleaner keeps reconciliations before removal")         //It's set to zero to disable it by default. Change to a proper value once this mechanism is enabled in the environments.
 	cmd.Flags().IntVar(&o.InventoryMaxAgeDays, "inventory-max-age-days", 0, "Defines the number of days for which the cleaner keeps inventory records before removal")         //It's set to zero to disable it by default. Change to a proper value once this mechanism is enabled in the environments.
 	cmd.Flags().IntVar(&o.StatusCleanupBatchSize, "status-cleanup-batch-size", 200, "Defines the batch size for cluster status cleanup")                                       //It's set to zero to disable it by default. Change to a proper value once this mechanism is enabled in the environments.
+	cmd.Flags().BoolVar(&o.KeepDeletedClusters, "keep-deleted-clusters", false, "Keep deleted clusters for audits: the cleaner doesn't remove them, they have to be purged explicitly")
 	cmd.Flags().DurationVar(&o.CleanerInterval, "cleaner-interval", 14*time.Hour, "Define the time interval when the cleaner will be looking for reconciliation entities to remove")
 	cmd.Flags().BoolVar(&o.CreateEncyptionKey, "create-encryption-key", false, "Create new encryption key file during startup")
 	cmd.Flags().BoolVar(&o.Migrate, "migrate-database", false, "Migrate database to the latest release")
@@ -60,6 +102,19 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd.Flags().StringVar(&o.AuditLogFile, "audit-log-file", "/var/log/auditlog/mothership-audit.log", "Path for mothership audit log file")
 	cmd.Flags().StringVar(&o.AuditLogTenantID, "audit-log-tenant-id", "", "tenant id for audit logging")
 	cmd.Flags().BoolVar(&o.StopAfterMigration, "stop-after-migrate", false, "Stop mothership after database migration to the latest release")
+	cmd.Flags().BoolVar(&o.PersistPayloads, "persist-payloads", false, "Store the payloads of accepted cluster updates to be able to replay them")
+	cmd.Flags().IntVar(&o.PayloadsMaxAgeDays, "payloads-max-age-days", 7, "Defines the number of days for which the cleaner keeps stored payloads before removal")
+	cmd.Flags().StringVar(&o.RecordContract, "record-contract", "", "Directory where sanitized request/response pairs of all API routes are stored as golden files for contract tests")
+	cmd.Flags().IntVar(&o.CompressionMinSize, "compression-min-size", 1024, "Minimal size in bytes of cluster list, status changes and operations responses which are compressed (if the client accepts a supported encoding)")
+	cmd.Flags().BoolVar(&o.CompressionBrotli, "compression-brotli", false, "Compress responses with brotli if the client prefers it over gzip")
+	cmd.Flags().BoolVar(&o.UI, "ui", false, "Serve the read-only web UI for the inspection of clusters and reconciliations at /ui")
+	cmd.Flags().BoolVar(&o.VerifyKubeconfig, "verify-kubeconfig", false, "Verify the kubeconfig of created or updated clusters with an authenticated call and reject clusters which aren't accessible with HTTP 422")
+	cmd.Flags().StringVar(&o.Auth.JWKSURL, "auth-jwks-url", "", "JWKS endpoint of the token issuer: if set, API calls require a JWT bearer token signed by one of its keys")
+	cmd.Flags().StringVar(&o.Auth.Issuer, "auth-issuer", "", "Issuer which has to match the 'iss' claim of the JWT bearer tokens")
+	cmd.Flags().StringSliceVar(&o.Auth.Audiences, "auth-audience", nil, "Audience which has to be contained in the 'aud' claim of the JWT bearer tokens (repeatable)")
+	cmd.Flags().DurationVar(&o.Auth.RefreshInterval, "auth-jwks-refresh-interval", time.Hour, "Interval for refreshing the cached keys of the JWKS endpoint")
+	cmd.Flags().BoolVar(&o.Auth.ProtectMetrics, "auth-protect-metrics", false, "Require a JWT bearer token for the metrics endpoint")
+	cmd.Flags().BoolVar(&o.Auth.ProtectHealth, "auth-protect-health", false, "Require a JWT bearer token for the liveness and readiness endpoints")
 	return cmd
 }
 
@@ -70,12 +125,97 @@ func Run(ctx context.Context, o *Options) error {
 	}
 	//passing config value to be used by metrics collectors and trackers
 	o.Config = schedulerCfg
+	if o.PolicyEngine, err = policy.NewEngine(schedulerCfg.Policy, o.Logger()); err != nil {
+		return err
+	}
+	if o.ValidationWebhook, err = validation.NewWebhook(schedulerCfg.Validation, o.Logger()); err != nil {
+		return err
+	}
+	if o.Cohorts, err = cohort.NewResolver(schedulerCfg.Scheduler.Cohorts); err != nil {
+		return err
+	}
+	if o.SLOs, err = slo.NewObjectives(schedulerCfg.Scheduler.SLOs); err != nil {
+		return errors.Wrap(err, "failed to parse SLOs")
+	}
+	if o.UpdateLimiter, err = ratelimit.NewUpdateLimiter(schedulerCfg.UpdateRateLimit); err != nil {
+		return err
+	}
+	if o.ClientLimiter, err = ratelimit.NewClientLimiter(schedulerCfg.ClientRateLimit); err != nil {
+		return err
+	}
+	if o.KubeconfigIssuer, err = kubeconfigref.NewIssuer(schedulerCfg.Scheduler.KubeconfigDelivery); err != nil {
+		return err
+	}
+	if o.KubeconfigIssuer != nil && !o.ClientAuth.Enabled() {
+		return errors.New("client certificates have to be verified (client CA) if kubeconfigs are delivered by reference")
+	}
+	o.FlakinessClassifier, err = flaky.NewClassifier(schedulerCfg.Scheduler.Flakiness,
+		o.Registry.ReconciliationRepository(), o.Logger())
+	if err != nil {
+		return err
+	}
+	o.FlakinessClassifier.Run(ctx)
+	o.HealthScorer, err = health.NewScorer(schedulerCfg.Scheduler.Health,
+		o.Registry.ReconciliationRepository(), o.Logger())
+	if err != nil {
+		return err
+	}
+	o.HealthScorer.Run(ctx)
+	o.StatusNotifier, err = subscription.NewNotifier(schedulerCfg.Subscriptions,
+		o.Registry.SubscriptionRepository(), o.Logger())
+	if err != nil {
+		return err
+	}
+	o.Registry.StatusListeners().Add(o.StatusNotifier)
+	go o.StatusNotifier.Run(ctx)
+	anomalyDetector, err := anomaly.NewDetector(schedulerCfg.Scheduler.Anomalies,
+		o.Registry.ReconciliationRepository(), func(runtimeID string, configVersion int64) (string, error) {
+			state, err := o.Registry.Inventory().Get(runtimeID, configVersion)
+			if err != nil {
+				return "", err
+			}
+			return state.Configuration.KymaVersion, nil
+		}, o.StatusNotifier, o.Logger())
+	if err != nil {
+		return err
+	}
+	anomalyDetector.Run(ctx)
+	o.TemplateFanOut = configtemplate.NewFanOut(ctx, func(ctx context.Context, name string) error {
+		template, err := o.Registry.ConfigTemplateRepository().Get(name)
+		if err != nil {
+			return err
+		}
+		_, err = fanOutConfigTemplate(ctx, o, template)
+		return err
+	}, o.Logger())
+	o.StatusBroadcaster = cluster.NewStatusBroadcaster()
+	o.Registry.StatusListeners().Add(o.StatusBroadcaster)
+	o.SnapshotRecorder, err = snapshot.NewRecorder(schedulerCfg.Snapshots, o.Registry.Inventory(), o.Logger())
+	if err != nil {
+		return err
+	}
+	if o.SnapshotRecorder != nil {
+		o.Registry.StatusListeners().Add(o.SnapshotRecorder)
+		go o.SnapshotRecorder.Run(ctx)
+	}
+	if o.TakeoverGuard, err = ownership.NewGuard(schedulerCfg.TakeoverProtection, o.Registry.Inventory(), o.Logger()); err != nil {
+		return err
+	}
+	o.Backpressure, err = backpressure.NewController(schedulerCfg.Scheduler.Backpressure,
+		o.Registry.Connection().Ping, o.Logger())
+	if err != nil {
+		return err
+	}
+	o.Backpressure.Run(ctx)
 	go func(ctx context.Context, o *Options) {
 		err := startScheduler(ctx, o)
 		if err != nil {
 			panic(err)
 		}
 	}(ctx, o)
+	if o.PersistPayloads {
+		go startPayloadCleaner(ctx, o)
+	}
 
 	return startWebserver(ctx, o)
 }
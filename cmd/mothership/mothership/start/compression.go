@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gorilla/mux"
+)
+
+const (
+	encodingGzip   = "gzip"
+	encodingBrotli = "br"
+
+	headerAcceptEncoding  = "Accept-Encoding"
+	headerContentEncoding = "Content-Encoding"
+)
+
+//compressedRoutes are the routes which can return very large JSON arrays for busy landscapes
+var compressedRoutes = []string{
+	fmt.Sprintf("/v{%s}/clusters", paramContractVersion),
+	fmt.Sprintf("/v{%s}/clusters/{%s}/statusChanges", paramContractVersion, paramRuntimeID),
+	fmt.Sprintf("/v{%s}/clusters/{%s}/reconciliations/{%s}/operations", paramContractVersion, paramRuntimeID, paramSchedulingID),
+}
+
+func isCompressedRoute(route string) bool {
+	for _, compressedRoute := range compressedRoutes {
+		if compressedRoute == route {
+			return true
+		}
+	}
+	return false
+}
+
+//negotiateEncoding returns the supported encoding with the highest quality value of the Accept-Encoding header
+//(brotli is preferred if both encodings are accepted with the same quality) or an empty string if the response
+//has to be sent uncompressed
+func negotiateEncoding(acceptEncoding string, brotliEnabled bool) string {
+	supported := []string{encodingGzip}
+	if brotliEnabled {
+		supported = []string{encodingBrotli, encodingGzip}
+	}
+	qualities := make(map[string]float64)
+	wildcard := -1.0
+	for _, entry := range strings.Split(acceptEncoding, ",") {
+		parts := strings.Split(entry, ";")
+		coding := strings.ToLower(strings.TrimSpace(parts[0]))
+		if coding == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			if err != nil {
+				q = 0
+			}
+			quality = q
+		}
+		if coding == "*" {
+			wildcard = quality
+			continue
+		}
+		qualities[coding] = quality
+	}
+
+	result := ""
+	bestQuality := 0.0
+	for _, encoding := range supported {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality = wildcard
+		}
+		if quality > bestQuality {
+			result = encoding
+			bestQuality = quality
+		}
+	}
+	return result
+}
+
+//compressionWriter buffers the response until it exceeds the min. size: smaller responses are sent uncompressed
+//as compressing them doesn't pay off
+type compressionWriter struct {
+	http.ResponseWriter
+	encoding   string
+	minSize    int
+	buffer     bytes.Buffer
+	status     int
+	compressor io.WriteCloser
+	started    bool
+}
+
+func (w *compressionWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = status
+}
+
+func (w *compressionWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.started {
+		if w.compressor != nil {
+			return w.compressor.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+	n, _ := w.buffer.Write(b)
+	if w.buffer.Len() >= w.minSize {
+		if err := w.start(true); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+//Flush supports streamed responses (e.g. server-sent events): buffered data is sent immediately
+func (w *compressionWriter) Flush() {
+	if !w.started {
+		if err := w.start(w.buffer.Len() >= w.minSize); err != nil {
+			return
+		}
+	}
+	if flusher, ok := w.compressor.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+//start sends the header and the buffered data: only successful JSON responses are compressed (streams and
+//responses which are already encoded are passed through)
+func (w *compressionWriter) start(compress bool) error {
+	w.started = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if compress && w.status == http.StatusOK && w.Header().Get(headerContentEncoding) == "" &&
+		isJSONContentType(w.Header().Get("content-type")) {
+		w.Header().Set(headerContentEncoding, w.encoding)
+		w.Header().Del("Content-Length")
+		if w.encoding == encodingBrotli {
+			w.compressor = brotli.NewWriter(w.ResponseWriter)
+		} else {
+			w.compressor = gzip.NewWriter(w.ResponseWriter)
+		}
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+	var err error
+	if w.compressor != nil {
+		_, err = w.compressor.Write(w.buffer.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buffer.Bytes())
+	}
+	w.buffer.Reset()
+	return err
+}
+
+//finish sends the remaining response
+func (w *compressionWriter) finish() error {
+	if !w.started {
+		if w.status == 0 { //handler didn't write anything
+			w.status = http.StatusOK
+		}
+		return w.start(false)
+	}
+	if w.compressor != nil {
+		return w.compressor.Close()
+	}
+	return nil
+}
+
+//newCompressionMiddleware compresses the responses of the compressed routes with the encoding negotiated by the
+//Accept-Encoding header of the request. The middleware has to wrap the deprecation middleware: the 'deprecations'
+//array is added to the JSON payload before it gets compressed.
+func newCompressionMiddleware(minSize int, brotliEnabled bool) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, err := mux.CurrentRoute(r).GetPathTemplate()
+			if err != nil || !isCompressedRoute(route) || r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+			//caches must not serve compressed responses to clients which don't support the encoding
+			vary(w, headerAcceptEncoding)
+			encoding := negotiateEncoding(r.Header.Get(headerAcceptEncoding), brotliEnabled)
+			if encoding == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			compressionW := &compressionWriter{ResponseWriter: w, encoding: encoding, minSize: minSize}
+			next.ServeHTTP(compressionW, r)
+			_ = compressionW.finish()
+		})
+	}
+}
+
+//vary adds the request header to the Vary header of the response unless it's already listed
+func vary(w http.ResponseWriter, header string) {
+	for _, value := range w.Header().Values("Vary") {
+		if value == header {
+			return
+		}
+	}
+	w.Header().Add("Vary", header)
+}
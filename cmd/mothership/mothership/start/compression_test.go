@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	testCases := []struct {
+		acceptEncoding string
+		brotli         bool
+		expected       string
+	}{
+		{acceptEncoding: "", expected: ""},
+		{acceptEncoding: "identity", expected: ""},
+		{acceptEncoding: "gzip", expected: encodingGzip},
+		{acceptEncoding: "deflate, GZIP", expected: encodingGzip},
+		{acceptEncoding: "gzip;q=0", expected: ""},
+		{acceptEncoding: "*", expected: encodingGzip},
+		{acceptEncoding: "*;q=0.5, gzip;q=0", expected: ""},
+		{acceptEncoding: "gzip, deflate, br", expected: encodingGzip},
+		{acceptEncoding: "gzip, deflate, br", brotli: true, expected: encodingBrotli},
+		{acceptEncoding: "gzip;q=1.0, br;q=0.5", brotli: true, expected: encodingGzip},
+		{acceptEncoding: "*", brotli: true, expected: encodingBrotli},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, negotiateEncoding(tc.acceptEncoding, tc.brotli),
+			"Accept-Encoding '%s' (brotli: %t)", tc.acceptEncoding, tc.brotli)
+	}
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	largePayload := strings.Repeat(`{"runtimeID":"abc","status":"ready"},`, 100)
+	largePayload = fmt.Sprintf(`{"clusters":[%s]}`, strings.TrimSuffix(largePayload, ","))
+
+	newRouter := func(brotliEnabled bool, registry []*deprecation) *mux.Router {
+		router := mux.NewRouter()
+		router.Use(newCompressionMiddleware(1024, brotliEnabled))
+		router.Use(newDeprecationMiddleware(registry))
+		router.HandleFunc(fmt.Sprintf("/v{%s}/clusters", paramContractVersion), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			_, _ = w.Write([]byte(largePayload))
+		}).Methods(http.MethodGet)
+		router.HandleFunc(fmt.Sprintf("/v{%s}/clusters/{%s}/statusChanges", paramContractVersion, paramRuntimeID), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			_, _ = w.Write([]byte(`{"statusChanges":[]}`))
+		}).Methods(http.MethodGet)
+		router.HandleFunc(fmt.Sprintf("/v{%s}/clusters/{%s}/reconciliations/{%s}/operations", paramContractVersion, paramRuntimeID, paramSchedulingID), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(largePayload))
+		}).Methods(http.MethodGet)
+		router.HandleFunc(fmt.Sprintf("/v{%s}/clusters/{%s}/status", paramContractVersion, paramRuntimeID), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("content-type", "application/json")
+			_, _ = w.Write([]byte(largePayload))
+		}).Methods(http.MethodGet)
+		return router
+	}
+	serve := func(router *mux.Router, path, acceptEncoding string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set(headerAcceptEncoding, acceptEncoding)
+		}
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Gzip", func(t *testing.T) {
+		recorder := serve(newRouter(false, nil), "/v2/clusters", "gzip, br")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, encodingGzip, recorder.Header().Get(headerContentEncoding))
+		require.Equal(t, []string{headerAcceptEncoding}, recorder.Header().Values("Vary"))
+		require.Less(t, recorder.Body.Len(), len(largePayload))
+
+		reader, err := gzip.NewReader(recorder.Body)
+		require.NoError(t, err)
+		payload, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		require.Equal(t, largePayload, string(payload))
+	})
+
+	t.Run("Brotli", func(t *testing.T) {
+		recorder := serve(newRouter(true, nil), "/v2/clusters", "gzip, br")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, encodingBrotli, recorder.Header().Get(headerContentEncoding))
+
+		payload, err := ioutil.ReadAll(brotli.NewReader(recorder.Body))
+		require.NoError(t, err)
+		require.Equal(t, largePayload, string(payload))
+	})
+
+	t.Run("Encoding not accepted", func(t *testing.T) {
+		recorder := serve(newRouter(true, nil), "/v2/clusters", "")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Empty(t, recorder.Header().Get(headerContentEncoding))
+		require.Equal(t, []string{headerAcceptEncoding}, recorder.Header().Values("Vary"))
+		require.Equal(t, largePayload, recorder.Body.String())
+	})
+
+	t.Run("Small, failed and other responses are not compressed", func(t *testing.T) {
+		router := newRouter(false, nil)
+
+		recorder := serve(router, "/v2/clusters/abc/statusChanges", "gzip")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Empty(t, recorder.Header().Get(headerContentEncoding))
+		require.Equal(t, `{"statusChanges":[]}`, recorder.Body.String())
+
+		recorder = serve(router, "/v2/clusters/abc/reconciliations/123/operations", "gzip")
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+		require.Empty(t, recorder.Header().Get(headerContentEncoding))
+		require.Equal(t, largePayload, recorder.Body.String())
+
+		recorder = serve(router, "/v2/clusters/abc/status", "gzip")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Empty(t, recorder.Header().Get(headerContentEncoding))
+		require.Empty(t, recorder.Header().Values("Vary"))
+	})
+
+	t.Run("Deprecations are compressed", func(t *testing.T) {
+		router := newRouter(false, []*deprecation{
+			{
+				kind:        keb.DeprecationKindContractVersion,
+				name:        "v1",
+				replacement: "v2",
+				sunset:      time.Date(2027, time.March, 31, 0, 0, 0, 0, time.UTC),
+			},
+		})
+		recorder := serve(router, "/v1/clusters", "gzip")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Equal(t, encodingGzip, recorder.Header().Get(headerContentEncoding))
+		require.Len(t, recorder.Header().Values("Warning"), 1)
+
+		reader, err := gzip.NewReader(recorder.Body)
+		require.NoError(t, err)
+		resp := &struct {
+			Clusters     []interface{}     `json:"clusters"`
+			Deprecations []keb.Deprecation `json:"deprecations"`
+		}{}
+		require.NoError(t, json.NewDecoder(reader).Decode(resp))
+		require.Len(t, resp.Clusters, 100)
+		require.Len(t, resp.Deprecations, 1)
+	})
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+//conditionsHistory is the max. number of statuses which are considered to find the last transition of a condition:
+//the oldest considered status is used if the condition didn't change within them
+const conditionsHistory = 100
+
+//newConditions derives Kubernetes-style conditions from the cluster state: the legacy status string is still
+//returned by the API but the conditions give consumers a more detailed view of the cluster. The last transition of a
+//condition is the oldest status of the history since which the condition has its current status. Only the kubeconfig
+//of the current cluster version is validated: its condition changes at the earliest with the cluster version.
+func newConditions(clusterState *cluster.State, history []*model.ClusterStatusEntity,
+	failures []keb.Failure) []keb.Condition {
+	status := clusterState.Status.Status
+	reason := conditionReason(status)
+
+	ready := keb.Condition{
+		Type:   keb.ConditionTypeReady,
+		Status: readyStatus(status),
+		Reason: reason,
+	}
+	ready.LastTransitionTime = statusTransition(clusterState.Status, history, ready.Status, readyStatus)
+
+	reconciling := keb.Condition{
+		Type:   keb.ConditionTypeReconciling,
+		Status: reconcilingStatus(status),
+		Reason: reason,
+	}
+	reconciling.LastTransitionTime = statusTransition(clusterState.Status, history, reconciling.Status, reconcilingStatus)
+
+	kubeconfigValid := keb.Condition{
+		Type:   keb.ConditionTypeKubeconfigValid,
+		Status: keb.ConditionStatusTrue,
+		Reason: "KubeconfigProvided",
+		//a new cluster version is created whenever a kubeconfig is provided
+		LastTransitionTime: clusterState.Cluster.Created,
+	}
+	switch err := kubernetes.ValidateKubeconfig(clusterState.Cluster.Kubeconfig); {
+	case strings.TrimSpace(clusterState.Cluster.Kubeconfig) == "":
+		kubeconfigValid.Status = keb.ConditionStatusFalse
+		kubeconfigValid.Reason = "KubeconfigMissing"
+	case err != nil:
+		kubeconfigValid.Status = keb.ConditionStatusFalse
+		kubeconfigValid.Reason = "KubeconfigInvalid"
+		kubeconfigValid.Message = toStrPtr(err.Error())
+	}
+
+	componentsHealthy := keb.Condition{
+		Type:   keb.ConditionTypeComponentsHealthy,
+		Status: componentsHealthyStatus(status),
+		Reason: reason,
+	}
+	if len(failures) > 0 {
+		componentsHealthy.Status = keb.ConditionStatusFalse
+		componentsHealthy.Reason = "ComponentsFailed"
+		componentsHealthy.Message = toStrPtr(failuresMessage(failures))
+	}
+	componentsHealthy.LastTransitionTime = statusTransition(clusterState.Status, history, componentsHealthy.Status,
+		componentsHealthyStatus)
+
+	return []keb.Condition{ready, reconciling, kubeconfigValid, componentsHealthy}
+}
+
+func readyStatus(status model.Status) keb.ConditionStatus {
+	return toConditionStatus(status == model.ClusterStatusReady)
+}
+
+func reconcilingStatus(status model.Status) keb.ConditionStatus {
+	return toConditionStatus(status.IsInProgress() ||
+		status == model.ClusterStatusReconcilePending || status == model.ClusterStatusDeletePending)
+}
+
+//componentsHealthyStatus derives the health of the components from a cluster status: the failures of the components
+//are only loaded for the current status, errors of older statuses are assumed to be caused by failed components
+func componentsHealthyStatus(status model.Status) keb.ConditionStatus {
+	switch status {
+	case model.ClusterStatusReady:
+		return keb.ConditionStatusTrue
+	case model.ClusterStatusReconcileError, model.ClusterStatusReconcileErrorRetryable,
+		model.ClusterStatusDeleteError, model.ClusterStatusDeleteErrorRetryable:
+		return keb.ConditionStatusFalse
+	}
+	return keb.ConditionStatusUnknown
+}
+
+//statusTransition returns the creation time of the oldest status since which the condition has its current status
+//(statuses newer than the current status are ignored)
+func statusTransition(current *model.ClusterStatusEntity, history []*model.ClusterStatusEntity,
+	conditionStatus keb.ConditionStatus, statusOf func(model.Status) keb.ConditionStatus) time.Time {
+	transition := current.Created
+	for _, status := range history {
+		if status.ID >= current.ID {
+			continue
+		}
+		if statusOf(status.Status) != conditionStatus {
+			break
+		}
+		transition = status.Created
+	}
+	return transition
+}
+
+func toConditionStatus(value bool) keb.ConditionStatus {
+	if value {
+		return keb.ConditionStatusTrue
+	}
+	return keb.ConditionStatusFalse
+}
+
+//conditionReason converts a cluster status into a CamelCase reason (e.g. 'reconcile_pending' => 'ReconcilePending')
+func conditionReason(status model.Status) string {
+	var reason strings.Builder
+	for _, part := range strings.Split(string(status), "_") {
+		if part == "" {
+			continue
+		}
+		reason.WriteString(strings.ToUpper(part[:1]))
+		reason.WriteString(part[1:])
+	}
+	return reason.String()
+}
+
+func failuresMessage(failures []keb.Failure) string {
+	messages := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		messages = append(messages, fmt.Sprintf("%s: %s", failure.Component, failure.Reason))
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+const conditionsKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://localhost:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: token
+`
+
+func TestConditions(t *testing.T) {
+	created := time.Now().Add(-1 * time.Hour)
+	statusChanged := time.Now()
+
+	newState := func(status model.Status) *cluster.State {
+		return &cluster.State{
+			Cluster:       &model.ClusterEntity{Version: 2, Kubeconfig: conditionsKubeconfig, Created: created},
+			Configuration: &model.ClusterConfigurationEntity{},
+			Status:        &model.ClusterStatusEntity{ID: 10, Status: status, Created: statusChanged},
+		}
+	}
+	var noHistory []*model.ClusterStatusEntity
+	byType := func(conditions []keb.Condition) map[keb.ConditionType]keb.Condition {
+		result := make(map[keb.ConditionType]keb.Condition)
+		for _, condition := range conditions {
+			result[condition.Type] = condition
+		}
+		return result
+	}
+
+	t.Run("Ready cluster", func(t *testing.T) {
+		conditions := byType(newConditions(newState(model.ClusterStatusReady), noHistory, nil))
+		require.Len(t, conditions, 4)
+		require.Equal(t, keb.ConditionStatusTrue, conditions[keb.ConditionTypeReady].Status)
+		require.Equal(t, "Ready", conditions[keb.ConditionTypeReady].Reason)
+		require.Equal(t, statusChanged, conditions[keb.ConditionTypeReady].LastTransitionTime)
+		require.Equal(t, keb.ConditionStatusFalse, conditions[keb.ConditionTypeReconciling].Status)
+		require.Equal(t, keb.ConditionStatusTrue, conditions[keb.ConditionTypeKubeconfigValid].Status)
+		require.Equal(t, created, conditions[keb.ConditionTypeKubeconfigValid].LastTransitionTime)
+		require.Equal(t, keb.ConditionStatusTrue, conditions[keb.ConditionTypeComponentsHealthy].Status)
+	})
+
+	t.Run("Pending cluster", func(t *testing.T) {
+		conditions := byType(newConditions(newState(model.ClusterStatusReconcilePending), noHistory, nil))
+		require.Equal(t, keb.ConditionStatusFalse, conditions[keb.ConditionTypeReady].Status)
+		require.Equal(t, keb.ConditionStatusTrue, conditions[keb.ConditionTypeReconciling].Status)
+		require.Equal(t, "ReconcilePending", conditions[keb.ConditionTypeReconciling].Reason)
+		require.Equal(t, keb.ConditionStatusUnknown, conditions[keb.ConditionTypeComponentsHealthy].Status)
+	})
+
+	t.Run("Failed components", func(t *testing.T) {
+		failures := []keb.Failure{{Component: "comp1", Reason: "failed"}, {Component: "comp2", Reason: "timeout"}}
+		conditions := byType(newConditions(newState(model.ClusterStatusReconcileError), noHistory, failures))
+		require.Equal(t, keb.ConditionStatusFalse, conditions[keb.ConditionTypeReady].Status)
+		require.Equal(t, "Error", conditions[keb.ConditionTypeReady].Reason)
+		healthy := conditions[keb.ConditionTypeComponentsHealthy]
+		require.Equal(t, keb.ConditionStatusFalse, healthy.Status)
+		require.Equal(t, "ComponentsFailed", healthy.Reason)
+		require.Equal(t, "comp1: failed; comp2: timeout", *healthy.Message)
+	})
+
+	t.Run("Missing kubeconfig", func(t *testing.T) {
+		state := newState(model.ClusterStatusReady)
+		state.Cluster.Kubeconfig = ""
+		conditions := byType(newConditions(state, noHistory, nil))
+		require.Equal(t, keb.ConditionStatusFalse, conditions[keb.ConditionTypeKubeconfigValid].Status)
+		require.Equal(t, "KubeconfigMissing", conditions[keb.ConditionTypeKubeconfigValid].Reason)
+	})
+
+	t.Run("Invalid kubeconfig", func(t *testing.T) {
+		state := newState(model.ClusterStatusReady)
+		state.Cluster.Kubeconfig = "kubeconfig"
+		kubeconfigValid := byType(newConditions(state, noHistory, nil))[keb.ConditionTypeKubeconfigValid]
+		require.Equal(t, keb.ConditionStatusFalse, kubeconfigValid.Status)
+		require.Equal(t, "KubeconfigInvalid", kubeconfigValid.Reason)
+		require.NotNil(t, kubeconfigValid.Message)
+	})
+
+	t.Run("Transition times change only with the condition status", func(t *testing.T) {
+		state := newState(model.ClusterStatusReconciling)
+		pendingSince := statusChanged.Add(-10 * time.Minute)
+		history := []*model.ClusterStatusEntity{
+			{ID: 11, Status: model.ClusterStatusReady, Created: statusChanged.Add(time.Minute)}, //newer than the state
+			state.Status,
+			{ID: 9, Status: model.ClusterStatusReconcilePending, Created: pendingSince},
+			{ID: 8, Status: model.ClusterStatusReady, Created: statusChanged.Add(-20 * time.Minute)},
+		}
+		conditions := byType(newConditions(state, history, nil))
+		require.Equal(t, pendingSince, conditions[keb.ConditionTypeReady].LastTransitionTime)
+		require.Equal(t, pendingSince, conditions[keb.ConditionTypeReconciling].LastTransitionTime)
+		require.Equal(t, pendingSince, conditions[keb.ConditionTypeComponentsHealthy].LastTransitionTime)
+		require.Equal(t, created, conditions[keb.ConditionTypeKubeconfigValid].LastTransitionTime)
+	})
+}
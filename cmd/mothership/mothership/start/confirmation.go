@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//updateOperationPendingDeletion stores the stateful resources which a deletion would remove: the operation waits
+//until an operator confirms the deletion
+func updateOperationPendingDeletion(o *Options, reconRepo reconciliation.Repository, schedulingID, correlationID string, body *reconciler.CallbackMessage) error {
+	var resources []string
+	if body.PendingDeletion != nil {
+		resources = *body.PendingDeletion
+	}
+	err := reconRepo.UpdateOperationPendingDeletion(schedulingID, correlationID, resources, body.Error)
+	if err != nil {
+		o.Logger().Errorf("REST endpoint failed to update operation (schedulingID:%s/correlationID:%s) "+
+			"to state '%s': %s", schedulingID, correlationID, model.OperationStatePendingConfirmation, err)
+		return err
+	}
+	err = reconRepo.UpdateOperationRetryID(schedulingID, correlationID, body.RetryID)
+	if err != nil {
+		o.Logger().Errorf("REST endpoint failed to update operation (schedulingID:%s/correlationID:%s) "+
+			"retryID '%s': %s", schedulingID, correlationID, body.RetryID, err)
+	}
+	return err
+}
+
+//listPendingConfirmations returns the operations of a cluster whose deletion waits for a confirmation
+func listPendingConfirmations(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	opEntities, err := o.Registry.ReconciliationRepository().GetOperations(&operation.FilterMixer{
+		Filters: []operation.Filter{
+			&operation.WithRuntimeID{RuntimeID: runtimeID},
+			&operation.WithStates{States: []model.OperationState{model.OperationStatePendingConfirmation}},
+		},
+	})
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrapf(err, "Failed to retrieve pending confirmations of cluster '%s'", runtimeID).Error(),
+		})
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(newOperationsResponse(opEntities)); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to encode operations response").Error(),
+		})
+	}
+}
+
+//confirmOperationDeletion allows a deletion to remove the stateful resources it reported: the operation is
+//processed again
+func confirmOperationDeletion(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	reconRepo := o.Registry.ReconciliationRepository()
+	op, err := reconRepo.GetOperation(schedulingID, correlationID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	if op.State != model.OperationStatePendingConfirmation {
+		server.SendHTTPError(w, http.StatusConflict, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("Operation is in state '%s' but has to be in state '%s' to confirm its deletion",
+				op.State, model.OperationStatePendingConfirmation),
+		})
+		return
+	}
+	if err := reconRepo.ConfirmOperationDeletion(schedulingID, correlationID); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to confirm deletion of operation").Error(),
+		})
+		return
+	}
+	o.Logger().Infof("User '%s' confirmed deletion of component '%s' (schedulingID:%s/correlationID:%s) which "+
+		"removes the stateful resources: %s", requestUser(r), op.Component, schedulingID, correlationID, op.PendingDeletion)
+
+	op, err = reconRepo.GetOperation(schedulingID, correlationID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertOperation(op)); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to encode operation response").Error(),
+		})
+	}
+}
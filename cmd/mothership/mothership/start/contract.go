@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/kyma-incubator/reconciler/pkg/version"
+	"github.com/pkg/errors"
+)
+
+const unsupportedContractVersion = "unsupported"
+
+//deprecatedContractVersions maps deprecated contract versions to their successor: deprecated versions are still
+//served by the same handlers but KEB gets informed by deprecation headers that it has to migrate
+var deprecatedContractVersions = map[int64]int64{
+	1: 2,
+}
+
+//statusRecorder remembers the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+//Flush supports streamed responses (e.g. server-sent events)
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+//newContractVersionMiddleware rejects requests of unsupported contract versions, flags responses of deprecated
+//contract versions and tracks the requests per contract version
+func newContractVersionMiddleware(apiRequestsMetric *metrics.APIRequestsMetric) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			startTime := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			contractV, supported := requestedContractVersion(r)
+			metricLabel := unsupportedContractVersion
+			if supported {
+				metricLabel = fmt.Sprintf("v%d", contractV)
+			}
+			route, err := mux.CurrentRoute(r).GetPathTemplate()
+			if err != nil {
+				route = r.URL.Path
+			}
+			defer func() {
+				apiRequestsMetric.ExposeRequest(metricLabel, route, r.Method, recorder.status, time.Since(startTime))
+			}()
+
+			if !supported {
+				sendUnsupportedContractVersion(recorder, fmt.Sprintf("Contract version 'v%s' is not supported",
+					mux.Vars(r)[paramContractVersion]))
+				return
+			}
+			if successor, deprecated := deprecatedContractVersions[contractV]; deprecated {
+				setDeprecationHeaders(recorder.Header(), r, contractV, successor)
+			}
+			next.ServeHTTP(recorder, r)
+		})
+	}
+}
+
+//sendModelFactoryError rejects a payload which couldn't be converted to the model of its contract version
+func sendModelFactoryError(w http.ResponseWriter, err error) {
+	if keb.IsUnsupportedContractVersionError(err) {
+		sendUnsupportedContractVersion(w, err.Error())
+		return
+	}
+	server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+		Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+	})
+}
+
+//sendUnsupportedContractVersion rejects a request of a contract version which isn't served
+func sendUnsupportedContractVersion(w http.ResponseWriter, msg string) {
+	code := keb.ErrorCodeUnsupportedContractVersion
+	server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+		Error: fmt.Sprintf("%s (supported versions: %s)", msg, version.Get().ContractVersionsString()),
+		Code:  &code,
+	})
+}
+
+func requestedContractVersion(r *http.Request) (int64, bool) {
+	contractV, err := strconv.ParseInt(mux.Vars(r)[paramContractVersion], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	for _, supportedV := range version.ContractVersions {
+		if supportedV == contractV {
+			return contractV, true
+		}
+	}
+	return 0, false
+}
+
+//setDeprecationHeaders adds the deprecation header and a link to the same resource in the successor contract version
+func setDeprecationHeaders(header http.Header, r *http.Request, contractV, successor int64) {
+	header.Set("Deprecation", "true")
+	successorPath := strings.Replace(r.URL.Path, fmt.Sprintf("/v%d/", contractV), fmt.Sprintf("/v%d/", successor), 1)
+	header.Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successorPath))
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/callback/client"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractVersionMiddleware(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(newContractVersionMiddleware(metrics.NewAPIRequestsMetric(logger.NewLogger(true))))
+	router.HandleFunc(fmt.Sprintf("/v{%s}/clusters/{%s}/status", paramContractVersion, paramRuntimeID),
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+	tests := []struct {
+		name               string
+		path               string
+		expectedStatus     int
+		expectedDeprecated bool
+		expectedLink       string
+	}{
+		{
+			name:               "Deprecated contract version",
+			path:               "/v1/clusters/abc/status",
+			expectedStatus:     http.StatusOK,
+			expectedDeprecated: true,
+			expectedLink:       `</v2/clusters/abc/status>; rel="successor-version"`,
+		},
+		{
+			name:           "Current contract version",
+			path:           "/v2/clusters/abc/status",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unsupported contract version",
+			path:           "/v99/clusters/abc/status",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid contract version",
+			path:           "/vX/clusters/abc/status",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			require.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedDeprecated {
+				require.Equal(t, "true", recorder.Header().Get("Deprecation"))
+			} else {
+				require.Empty(t, recorder.Header().Get("Deprecation"))
+			}
+			require.Equal(t, tt.expectedLink, recorder.Header().Get("Link"))
+		})
+	}
+}
+
+func TestSendModelFactoryError(t *testing.T) {
+	_, err := keb.NewModelFactory(99).Cluster(strings.NewReader(`{}`))
+	recorder := httptest.NewRecorder()
+	sendModelFactoryError(recorder, err)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "supported versions: ")
+	require.Contains(t, recorder.Body.String(), `"code":"unsupportedContractVersion"`)
+
+	_, err = keb.NewModelFactory(2).Cluster(strings.NewReader(`{"kymaConfig": []}`))
+	recorder = httptest.NewRecorder()
+	sendModelFactoryError(recorder, err)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `"code":"badRequest"`)
+}
+
+func TestCheckCallbackPayloadVersion(t *testing.T) {
+	for declared, accepted := range map[string]bool{
+		"":                    true,
+		client.PayloadVersion: true,
+		"99":                  false,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/operations/1/callback/2", nil)
+		if declared != "" {
+			req.Header.Set(client.PayloadVersionHeader, declared)
+		}
+		w := httptest.NewRecorder()
+		require.Equal(t, accepted, checkCallbackPayloadVersion(w, req), declared)
+		if !accepted {
+			require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+func clusterCost(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	offset, err := params.String(paramOffset)
+	if err != nil {
+		offset = fmt.Sprintf("%dh", 24*7) //default offset is 1 week
+	}
+	duration, err := time.ParseDuration(offset)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster").Error(),
+		})
+		return
+	}
+
+	now := time.Now().UTC()
+	total, reconCosts, err := reconciliation.ClusterCost(o.Registry.ReconciliationRepository(), runtimeID, now.Add(-duration), now)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cost of cluster").Error(),
+		})
+		return
+	}
+
+	resp := keb.HTTPClusterCostResponse{
+		Cluster:         runtimeID,
+		Total:           newCostResponse(total),
+		Reconciliations: []keb.ReconciliationCost{},
+	}
+	if clusterState.Cluster.Metadata != nil && clusterState.Cluster.Metadata.GlobalAccountID != "" {
+		resp.GlobalAccountID = &clusterState.Cluster.Metadata.GlobalAccountID
+	}
+	for _, reconCost := range reconCosts {
+		resp.Reconciliations = append(resp.Reconciliations, keb.ReconciliationCost{
+			SchedulingID: reconCost.SchedulingID,
+			Created:      reconCost.Created,
+			Cost:         newCostResponse(&reconCost.Cost),
+		})
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode cluster cost response").Error(),
+		})
+	}
+}
+
+func newCostResponse(cost *reconciliation.Cost) keb.Cost {
+	return keb.Cost{
+		Reconciliations: cost.Reconciliations,
+		Operations:      cost.Operations,
+		WallTime:        int64(cost.WallTime.Seconds()),
+		ProcessingTime:  int64(cost.ProcessingTime.Seconds()),
+		ApiCalls:        cost.APICalls,
+		ManifestBytes:   cost.ManifestBytes,
+	}
+}
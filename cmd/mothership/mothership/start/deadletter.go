@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/deadletter"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+const paramDeadLetterState = "state"
+
+func getDeadLetters(o *Options, w http.ResponseWriter, r *http.Request) {
+	repo := deadLetterRepository(o, w)
+	if repo == nil {
+		return
+	}
+
+	params := server.NewParams(r)
+	var state model.DeadLetterState
+	if stateParam, err := params.String(paramDeadLetterState); err == nil && stateParam != "" {
+		if state, err = model.NewDeadLetterState(stateParam); err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+			return
+		}
+	}
+	runtimeID, _ := params.String(paramRuntimeID)
+
+	deadLetters, err := repo.List(state, runtimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to retrieve dead letters").Error(),
+		})
+		return
+	}
+	sendDeadLetterResponse(w, deadLetters)
+}
+
+func requeueDeadLetters(o *Options, w http.ResponseWriter, r *http.Request) {
+	updateDeadLetters(o, w, r, model.DeadLetterStateRequeued, func(tx *db.TxConnection,
+		deadLetters []*model.DeadLetterEntity) error {
+		inventory, err := o.Registry.Inventory().WithTx(tx)
+		if err != nil {
+			return err
+		}
+		//trigger a new reconciliation (or deletion) once per cluster
+		requeued := make(map[string]bool)
+		for _, deadLetter := range deadLetters {
+			if requeued[deadLetter.RuntimeID] {
+				continue
+			}
+			requeued[deadLetter.RuntimeID] = true
+
+			clusterState, err := inventory.GetLatest(deadLetter.RuntimeID)
+			if err != nil {
+				return err
+			}
+			if clusterState.Status.Status.IsInProgress() || clusterState.Status.Status == model.ClusterStatusReconcilePending ||
+				clusterState.Status.Status == model.ClusterStatusDeletePending {
+				o.Logger().Infof("Skipping requeue of cluster '%s' for dead letter '%d': cluster is already in status '%s'",
+					deadLetter.RuntimeID, deadLetter.ID, clusterState.Status.Status)
+				continue
+			}
+			status := model.ClusterStatusReconcilePending
+			if deadLetter.Type == model.OperationTypeDelete {
+				status = model.ClusterStatusDeletePending
+			}
+			if _, err := inventory.UpdateStatus(clusterState, status); err != nil {
+				return err
+			}
+			o.Logger().Infof("Requeued cluster '%s' with status '%s' for dead letter '%d'",
+				deadLetter.RuntimeID, status, deadLetter.ID)
+		}
+		return nil
+	})
+}
+
+func acknowledgeDeadLetters(o *Options, w http.ResponseWriter, r *http.Request) {
+	updateDeadLetters(o, w, r, model.DeadLetterStateAcknowledged, func(_ *db.TxConnection,
+		_ []*model.DeadLetterEntity) error {
+		return nil
+	})
+}
+
+//updateDeadLetters moves open dead letters to the given state after the action was applied to them (both happen
+//within one transaction)
+func updateDeadLetters(o *Options, w http.ResponseWriter, r *http.Request, state model.DeadLetterState,
+	action func(tx *db.TxConnection, deadLetters []*model.DeadLetterEntity) error) {
+	repo := deadLetterRepository(o, w)
+	if repo == nil {
+		return
+	}
+
+	var update keb.DeadLetterUpdate
+	bodyLimited := http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)
+	reqBody, err := ioutil.ReadAll(bodyLimited)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	if err := json.Unmarshal(reqBody, &update); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	if len(update.Ids) == 0 {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: "IDs of dead letters are undefined",
+		})
+		return
+	}
+
+	deadLetters, err := repo.Resolve(update.Ids, state, action)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		} else if deadletter.IsNotOpenError(err) {
+			httpCode = http.StatusConflict
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Failed to update dead letters to state '%s'", state)).Error(),
+		})
+		return
+	}
+	sendDeadLetterResponse(w, deadLetters)
+}
+
+func deadLetterRepository(o *Options, w http.ResponseWriter) *deadletter.Repository {
+	if !o.Config.Scheduler.DeadLetter.Enabled {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: "Dead-letter queue is disabled",
+		})
+		return nil
+	}
+	return o.Registry.DeadLetterRepository()
+}
+
+func sendDeadLetterResponse(w http.ResponseWriter, deadLetters []*model.DeadLetterEntity) {
+	resp := keb.HTTPDeadLetterResponse{}
+	for _, deadLetter := range deadLetters {
+		resp = append(resp, newDeadLetterResponse(deadLetter))
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode dead-letter response").Error(),
+		})
+	}
+}
+
+func newDeadLetterResponse(deadLetter *model.DeadLetterEntity) keb.DeadLetter {
+	return keb.DeadLetter{
+		ClusterConfig: deadLetter.ClusterConfig,
+		Component:     deadLetter.Component,
+		CorrelationID: deadLetter.CorrelationID,
+		Created:       deadLetter.Created,
+		Id:            deadLetter.ID,
+		Reason:        deadLetter.Reason,
+		Retries:       deadLetter.Retries,
+		RuntimeID:     deadLetter.RuntimeID,
+		SchedulingID:  deadLetter.SchedulingID,
+		State:         keb.DeadLetterState(deadLetter.State),
+		Type:          string(deadLetter.Type),
+		Updated:       deadLetter.Updated,
+	}
+}
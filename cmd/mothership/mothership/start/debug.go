@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+var processStart = time.Now()
+
+//newDebugRouter returns the routes of the debug endpoints:
+// - /debug/pprof/ - CPU, memory and other runtime profiles (e.g. 'go tool pprof http://localhost:<port>/debug/pprof/heap')
+// - /debug/vars - runtime variables (memory statistics, goroutines and uptime) provided by expvar
+// - /debug/goroutines - stack traces of all goroutines in plain text
+func newDebugRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	router.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	router.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	router.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	router.PathPrefix("/debug/pprof/").HandlerFunc(pprof.Index) //index and named profiles (heap, goroutine, etc.)
+	router.Handle("/debug/vars", expvar.Handler())
+	router.HandleFunc("/debug/goroutines", goroutineDump)
+	return router
+}
+
+func init() {
+	expvar.Publish("goroutines", expvar.Func(func() interface{} {
+		return runtime.NumGoroutine()
+	}))
+	expvar.Publish("uptimeSeconds", expvar.Func(func() interface{} {
+		return int64(time.Since(processStart).Seconds())
+	}))
+}
+
+//goroutineDump writes the stack traces of all goroutines (same format as the dump of a panicking process)
+func goroutineDump(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("content-type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if err := runtimepprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to dump goroutines: %s", err), http.StatusInternalServerError)
+	}
+}
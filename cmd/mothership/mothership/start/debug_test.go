@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugRouter(t *testing.T) {
+	router := newDebugRouter()
+	serve := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	t.Run("Profiles", func(t *testing.T) {
+		recorder := serve("/debug/pprof/")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Contains(t, recorder.Body.String(), "heap")
+
+		recorder = serve("/debug/pprof/heap?debug=1")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Contains(t, recorder.Body.String(), "heap profile")
+	})
+
+	t.Run("Variables", func(t *testing.T) {
+		recorder := serve("/debug/vars")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		vars := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &vars))
+		require.Contains(t, vars, "memstats")
+		require.Contains(t, vars, "goroutines")
+		require.Contains(t, vars, "uptimeSeconds")
+	})
+
+	t.Run("Goroutine dump", func(t *testing.T) {
+		recorder := serve("/debug/goroutines")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Contains(t, recorder.Body.String(), "goroutine ")
+		require.Contains(t, recorder.Body.String(), "TestDebugRouter")
+	})
+}
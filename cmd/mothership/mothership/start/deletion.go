@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//deletionNotFoundError indicates that no deletion was requested for a cluster
+type deletionNotFoundError struct {
+	runtimeID string
+}
+
+func (e *deletionNotFoundError) Error() string {
+	return fmt.Sprintf("no deletion of cluster '%s' was requested", e.runtimeID)
+}
+
+func isDeletionStatus(status model.Status) bool {
+	switch status {
+	case model.ClusterStatusDeletePending, model.ClusterStatusDeleting, model.ClusterStatusDeleteErrorRetryable,
+		model.ClusterStatusDeleteError, model.ClusterStatusDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+//deletionContext bounds the deletion request by the configured write timeout of the webserver: colliding
+//transactions aren't retried anymore if the response couldn't be sent in time
+func deletionContext(r *http.Request, o *Options) (context.Context, context.CancelFunc) {
+	if o.ServerLimits.WriteTimeout <= 0 {
+		return context.WithCancel(r.Context())
+	}
+	return context.WithTimeout(r.Context(), o.ServerLimits.WriteTimeout)
+}
+
+//isDeletionAborted returns true if the deletion failed because the request was cancelled or timed out
+func isDeletionAborted(ctx context.Context, err error) bool {
+	return ctx.Err() != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded))
+}
+
+//latestReconciliation returns the most recently created reconciliation which matches the filter function
+func latestReconciliation(reconciliations []*model.ReconciliationEntity,
+	match func(recon *model.ReconciliationEntity) bool) *model.ReconciliationEntity {
+	var latest *model.ReconciliationEntity
+	for _, recon := range reconciliations {
+		if match(recon) && (latest == nil || recon.Created.After(latest.Created)) {
+			latest = recon
+		}
+	}
+	return latest
+}
+
+//deleteOperations returns the operations which uninstall a component
+func deleteOperations(operations []*model.OperationEntity) []*model.OperationEntity {
+	var result []*model.OperationEntity
+	for _, op := range operations {
+		if op.Type == model.OperationTypeDelete {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
+//sendDeletionResponse confirms an accepted deletion: the cluster gets deprovisioned asynchronously
+//and KEB can follow the progress by polling the deletion status URL
+func sendDeletionResponse(w http.ResponseWriter, r *http.Request, clusterState *cluster.State, o *Options) {
+	respModel, err := newClusterResponse(r, clusterState, o)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "failed to generate cluster response model").Error(),
+		})
+		return
+	}
+
+	deletionID := clusterState.Configuration.Version
+	deletionStatusURL := (&url.URL{
+		Scheme: o.Config.Scheme,
+		Host:   fmt.Sprintf("%s:%d", o.Config.Host, o.Config.Port),
+		Path: func() string {
+			apiVersion := strings.Split(r.URL.RequestURI(), "/")[1]
+			return fmt.Sprintf("%s/clusters/%s/deletionStatus", apiVersion, clusterState.Cluster.RuntimeID)
+		}(),
+	}).String()
+	respModel.DeletionID = &deletionID
+	respModel.DeletionStatusURL = &deletionStatusURL
+
+	w.Header().Set("content-type", "application/json")
+	w.Header().Set("Location", deletionStatusURL)
+	w.Header().Set("ETag", fmt.Sprintf(`"%d"`, respModel.ConfigurationVersion))
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(respModel); err != nil {
+		o.Logger().Warnf("Failed to encode deletion response of cluster '%s': %s", clusterState.Cluster.RuntimeID, err)
+	}
+}
+
+func getDeletionStatus(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	respModel, err := newDeletionStatusResponse(o, runtimeID)
+	if err != nil {
+		var notFoundErr *deletionNotFoundError
+		if errors.As(err, &notFoundErr) {
+			server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Failed to retrieve deletion status of cluster '%s'", runtimeID)).Error(),
+		})
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(respModel); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode deletion status response").Error(),
+		})
+	}
+}
+
+func newDeletionStatusResponse(o *Options, runtimeID string) (*keb.HTTPClusterDeletionStatusResponse, error) {
+	reconciliationRepository := o.Registry.ReconciliationRepository()
+
+	reconciliations, err := reconciliationRepository.GetReconciliations(&reconciliation.WithRuntimeID{RuntimeID: runtimeID})
+	if err != nil {
+		return nil, err
+	}
+
+	var status model.Status
+	var deletionID int64
+	state, err := o.Registry.Inventory().GetLatest(runtimeID)
+	if err == nil {
+		if !isDeletionStatus(state.Status.Status) {
+			return nil, &deletionNotFoundError{runtimeID: runtimeID}
+		}
+		status = state.Status.Status
+		deletionID = state.Configuration.Version
+	} else {
+		if !repository.IsNotFoundError(err) {
+			return nil, err
+		}
+		//clusters are removed from the inventory when the deletion has finished:
+		//the latest deleting reconciliation of the runtime is still tracked
+		recon := latestReconciliation(reconciliations, func(recon *model.ReconciliationEntity) bool {
+			return isDeletionStatus(recon.Status)
+		})
+		if recon == nil {
+			return nil, &deletionNotFoundError{runtimeID: runtimeID}
+		}
+		status = recon.Status
+		deletionID = recon.ClusterConfig
+	}
+
+	kebStatus, err := (&model.ClusterStatusEntity{Status: status}).GetKEBClusterStatus()
+	if err != nil {
+		return nil, err
+	}
+	resp := &keb.HTTPClusterDeletionStatusResponse{
+		Cluster:    runtimeID,
+		DeletionID: deletionID,
+		Status:     kebStatus,
+		Finished:   status == model.ClusterStatusDeleted || status == model.ClusterStatusDeleteError,
+	}
+
+	//the deleting reconciliation is the latest one of the config version (undefined until it was scheduled)
+	recon := latestReconciliation(reconciliations, func(recon *model.ReconciliationEntity) bool {
+		return recon.ClusterConfig == deletionID
+	})
+	if recon == nil {
+		return resp, nil
+	}
+	operations, err := reconciliationRepository.GetOperations(&operation.WithSchedulingID{
+		SchedulingID: recon.SchedulingID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	operations = deleteOperations(operations)
+	if len(operations) == 0 {
+		return resp, nil
+	}
+
+	progress := keb.DeletionProgress{Total: len(operations)}
+	failures := []keb.Failure{}
+	for _, op := range operations {
+		switch {
+		case op.State == model.OperationStateDone || op.State == model.OperationStateSkipped:
+			progress.Deleted++
+		case op.State.IsError():
+			progress.Failed++
+			failures = append(failures, keb.Failure{
+				Component: op.Component,
+				Reason:    op.Reason,
+			})
+		}
+	}
+	schedulingID := recon.SchedulingID
+	resp.SchedulingID = &schedulingID
+	resp.Progress = &progress
+	resp.Failures = &failures
+	return resp, nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDeletionStatus(t *testing.T) {
+	for _, status := range []model.Status{
+		model.ClusterStatusDeletePending,
+		model.ClusterStatusDeleting,
+		model.ClusterStatusDeleteErrorRetryable,
+		model.ClusterStatusDeleteError,
+		model.ClusterStatusDeleted,
+	} {
+		require.True(t, isDeletionStatus(status), status)
+	}
+	for _, status := range []model.Status{
+		model.ClusterStatusReconcilePending,
+		model.ClusterStatusReconciling,
+		model.ClusterStatusReady,
+		model.ClusterStatusReconcileError,
+	} {
+		require.False(t, isDeletionStatus(status), status)
+	}
+}
+
+func TestDeletionContext(t *testing.T) {
+	t.Run("Timeout", func(t *testing.T) {
+		o := &Options{ServerLimits: server.Limits{WriteTimeout: 10 * time.Millisecond}}
+		req := httptest.NewRequest(http.MethodDelete, "/v1/clusters/abc", nil)
+		ctx, cancel := deletionContext(req, o)
+		defer cancel()
+
+		<-ctx.Done()
+		err := errors.Wrap(ctx.Err(), "deletion request was aborted")
+		require.True(t, isDeletionAborted(ctx, err))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		reqCtx, cancelReq := context.WithCancel(context.Background())
+		req := httptest.NewRequest(http.MethodDelete, "/v1/clusters/abc", nil).WithContext(reqCtx)
+		ctx, cancel := deletionContext(req, &Options{})
+		defer cancel()
+
+		require.NoError(t, ctx.Err())
+		require.False(t, isDeletionAborted(ctx, errors.New("any error")))
+		cancelReq()
+		require.ErrorIs(t, ctx.Err(), context.Canceled)
+		require.True(t, isDeletionAborted(ctx, errors.Wrap(ctx.Err(), "deletion request was aborted")))
+		require.False(t, isDeletionAborted(ctx, errors.New("any error")))
+	})
+}
+
+func TestLatestReconciliation(t *testing.T) {
+	now := time.Now()
+	reconciliations := []*model.ReconciliationEntity{
+		{SchedulingID: "1", ClusterConfig: 2, Created: now.Add(-2 * time.Minute), Status: model.ClusterStatusDeleteErrorRetryable},
+		{SchedulingID: "2", ClusterConfig: 2, Created: now, Status: model.ClusterStatusDeleted},
+		{SchedulingID: "3", ClusterConfig: 1, Created: now.Add(-time.Minute), Status: model.ClusterStatusReady},
+	}
+
+	recon := latestReconciliation(reconciliations, func(recon *model.ReconciliationEntity) bool {
+		return isDeletionStatus(recon.Status)
+	})
+	require.Equal(t, "2", recon.SchedulingID)
+
+	recon = latestReconciliation(reconciliations, func(recon *model.ReconciliationEntity) bool {
+		return recon.ClusterConfig == 1
+	})
+	require.Equal(t, "3", recon.SchedulingID)
+
+	require.Nil(t, latestReconciliation(reconciliations, func(recon *model.ReconciliationEntity) bool {
+		return recon.ClusterConfig == 3
+	}))
+}
+
+func TestDeleteOperations(t *testing.T) {
+	operations := deleteOperations([]*model.OperationEntity{
+		{Component: "cleaner", Type: model.OperationTypeReconcile},
+		{Component: "istio", Type: model.OperationTypeDelete},
+		{Component: "logging", Type: model.OperationTypeDelete},
+	})
+	require.Len(t, operations, 2)
+	require.Equal(t, "istio", operations[0].Component)
+	require.Equal(t, "logging", operations[1].Component)
+}
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+//deprecation describes a contract version, route or payload field which will be removed
+type deprecation struct {
+	kind keb.DeprecationKind
+	//name is the contract version (e.g. "v1"), the route (method and path template without contract version,
+	//e.g. "DELETE /reconciliations/cluster/{runtimeID}") or the JSON path of the field (e.g. "metadata.labels")
+	name string
+	//routes which accept the deprecated field in their request payload (only used by fields)
+	routes []string
+	//contractVersions in which the deprecation applies (all contract versions if empty)
+	contractVersions []int64
+	replacement      string
+	sunset           time.Time
+}
+
+//deprecations is the central registry of deprecated elements of the API: clients using any of them get informed by
+//'Warning' headers and by the 'deprecations' array of JSON object responses
+var deprecations = []*deprecation{
+	{
+		kind:        keb.DeprecationKindContractVersion,
+		name:        "v1",
+		replacement: "v2",
+		sunset:      time.Date(2027, time.March, 31, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		kind:             keb.DeprecationKindField,
+		name:             "metadata.labels",
+		routes:           []string{"POST /clusters", "PUT /clusters"},
+		contractVersions: []int64{2},
+		replacement:      "labels",
+		sunset:           time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+func (d *deprecation) appliesTo(contractV int64) bool {
+	if len(d.contractVersions) == 0 {
+		return true
+	}
+	for _, deprecatedV := range d.contractVersions {
+		if deprecatedV == contractV {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *deprecation) acceptedBy(route string) bool {
+	for _, acceptingRoute := range d.routes {
+		if acceptingRoute == route {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *deprecation) message() string {
+	msg := fmt.Sprintf("%s '%s' is deprecated and will be removed after %s",
+		map[keb.DeprecationKind]string{
+			keb.DeprecationKindContractVersion: "Contract version",
+			keb.DeprecationKindRoute:           "Route",
+			keb.DeprecationKindField:           "Field",
+		}[d.kind], d.name, d.sunset.Format("2006-01-02"))
+	if d.replacement != "" {
+		msg = fmt.Sprintf("%s: use '%s' instead", msg, d.replacement)
+	}
+	return msg
+}
+
+func (d *deprecation) toModel() keb.Deprecation {
+	model := keb.Deprecation{
+		Kind:    d.kind,
+		Message: d.message(),
+		Name:    d.name,
+		Sunset:  d.sunset,
+	}
+	if d.replacement != "" {
+		replacement := d.replacement
+		model.Replacement = &replacement
+	}
+	return model
+}
+
+//usedDeprecations returns the deprecations of the registry which apply to the request: the payload is only read if
+//a deprecated field is accepted by the route (the body of the request stays readable for the handler)
+func usedDeprecations(registry []*deprecation, r *http.Request, contractV int64, route string) ([]*deprecation, error) {
+	var result []*deprecation
+	var payload interface{}
+	payloadRead := false
+	for _, d := range registry {
+		if !d.appliesTo(contractV) {
+			continue
+		}
+		switch d.kind {
+		case keb.DeprecationKindContractVersion:
+			if d.name == fmt.Sprintf("v%d", contractV) {
+				result = append(result, d)
+			}
+		case keb.DeprecationKindRoute:
+			if d.name == route {
+				result = append(result, d)
+			}
+		case keb.DeprecationKindField:
+			if !d.acceptedBy(route) {
+				continue
+			}
+			if !payloadRead {
+				var err error
+				if payload, err = peekJSONPayload(r); err != nil {
+					return nil, err
+				}
+				payloadRead = true
+			}
+			if hasJSONPath(payload, strings.Split(d.name, ".")) {
+				result = append(result, d)
+			}
+		}
+	}
+	return result, nil
+}
+
+//peekJSONPayload decodes the request body and restores it afterwards: invalid JSON is ignored as it gets rejected
+//by the handler
+func peekJSONPayload(r *http.Request) (interface{}, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, bodyRequestLimitBytes))
+	if err != nil {
+		return nil, err
+	}
+	//the remaining body (if any) is kept to let the handler detect payloads which exceed the limit
+	r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(data), r.Body))
+	var payload interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, nil
+	}
+	return payload, nil
+}
+
+//hasJSONPath checks whether the path is set in the payload: arrays match if any of their entries matches
+func hasJSONPath(payload interface{}, path []string) bool {
+	switch value := payload.(type) {
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return true
+		}
+		nested, ok := value[path[0]]
+		if !ok || nested == nil {
+			return false
+		}
+		return hasJSONPath(nested, path[1:])
+	case []interface{}:
+		for _, entry := range value {
+			if hasJSONPath(entry, path) {
+				return true
+			}
+		}
+		return false
+	default:
+		return len(path) == 0
+	}
+}
+
+//deprecationWriter adds the deprecations to the response: JSON object responses are buffered to extend them by the
+//'deprecations' array
+type deprecationWriter struct {
+	http.ResponseWriter
+	deprecations []*deprecation
+	buffer       *bytes.Buffer
+	status       int
+	wroteHeader  bool
+}
+
+func (w *deprecationWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	for _, d := range w.deprecations {
+		w.Header().Add("Warning", fmt.Sprintf(`299 - "%s"`, strings.ReplaceAll(d.message(), `"`, `'`)))
+	}
+	if sunset, ok := earliestSunset(w.deprecations); ok {
+		w.Header().Set("Sunset", sunset.Format(http.TimeFormat))
+	}
+	//streams (e.g. server-sent events) define their content type upfront and are never buffered
+	if contentType := w.Header().Get("content-type"); contentType == "" || isJSONContentType(contentType) {
+		w.buffer = &bytes.Buffer{}
+		w.status = status
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *deprecationWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffer != nil {
+		return w.buffer.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+//Flush supports streamed responses (e.g. server-sent events)
+func (w *deprecationWriter) Flush() {
+	if w.buffer != nil {
+		return
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+//finish sends the buffered response
+func (w *deprecationWriter) finish() error {
+	if w.buffer == nil {
+		return nil
+	}
+	body := w.buffer.Bytes()
+	if isJSONContentType(w.Header().Get("content-type")) {
+		body = appendDeprecations(body, w.deprecations)
+	}
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	_, err := w.ResponseWriter.Write(body)
+	return err
+}
+
+//appendDeprecations adds the 'deprecations' array to a JSON object (other payloads are returned unchanged)
+func appendDeprecations(body []byte, deprecations []*deprecation) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) < 2 || trimmed[0] != '{' || trimmed[len(trimmed)-1] != '}' {
+		return body
+	}
+	var existing struct {
+		Deprecations json.RawMessage `json:"deprecations"`
+	}
+	if err := json.Unmarshal(trimmed, &existing); err != nil || existing.Deprecations != nil {
+		return body
+	}
+	models := make([]keb.Deprecation, 0, len(deprecations))
+	for _, d := range deprecations {
+		models = append(models, d.toModel())
+	}
+	encoded, err := json.Marshal(models)
+	if err != nil {
+		return body
+	}
+
+	result := bytes.NewBuffer(make([]byte, 0, len(trimmed)+len(encoded)+20))
+	result.Write(trimmed[:len(trimmed)-1])
+	if len(bytes.TrimSpace(trimmed[1:len(trimmed)-1])) > 0 {
+		result.WriteByte(',')
+	}
+	result.WriteString(`"deprecations":`)
+	result.Write(encoded)
+	result.WriteString("}\n")
+	return result.Bytes()
+}
+
+//earliestSunset returns the first sunset of a deprecated contract version or route (fields don't sunset a resource)
+func earliestSunset(deprecations []*deprecation) (time.Time, bool) {
+	var result time.Time
+	for _, d := range deprecations {
+		if d.kind == keb.DeprecationKindField {
+			continue
+		}
+		if result.IsZero() || d.sunset.Before(result) {
+			result = d.sunset
+		}
+	}
+	return result, !result.IsZero()
+}
+
+//isJSONContentType accepts JSON and JSON based media types (e.g. application/problem+json)
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+//newDeprecationMiddleware informs clients about the deprecated contract versions, routes and fields of the registry
+//which are used by their requests
+func newDeprecationMiddleware(registry []*deprecation) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contractV, supported := requestedContractVersion(r)
+			if !supported {
+				next.ServeHTTP(w, r)
+				return
+			}
+			route := r.URL.Path
+			if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+				if tpl, err := currentRoute.GetPathTemplate(); err == nil {
+					route = tpl
+				}
+			}
+			route = fmt.Sprintf("%s %s", r.Method, strings.TrimPrefix(route, fmt.Sprintf("/v{%s}", paramContractVersion)))
+
+			used, err := usedDeprecations(registry, r, contractV, route)
+			if err != nil || len(used) == 0 { //unreadable payloads are rejected by the handler
+				next.ServeHTTP(w, r)
+				return
+			}
+			deprecationW := &deprecationWriter{ResponseWriter: w, deprecations: used}
+			next.ServeHTTP(deprecationW, r)
+			if !deprecationW.wroteHeader { //handler didn't write anything
+				deprecationW.WriteHeader(http.StatusOK)
+			}
+			_ = deprecationW.finish()
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecationMiddleware(t *testing.T) {
+	sunset := time.Date(2027, time.March, 31, 0, 0, 0, 0, time.UTC)
+	registry := []*deprecation{
+		{kind: keb.DeprecationKindContractVersion, name: "v1", replacement: "v2", sunset: sunset},
+		{kind: keb.DeprecationKindRoute, name: "GET /clusters/state", sunset: sunset.AddDate(0, -1, 0)},
+		{
+			kind:             keb.DeprecationKindField,
+			name:             "kymaConfig.components.URL",
+			routes:           []string{"POST /clusters"},
+			contractVersions: []int64{2},
+			replacement:      "kymaConfig.components.repository",
+			sunset:           sunset,
+		},
+	}
+
+	router := mux.NewRouter()
+	router.Use(newDeprecationMiddleware(registry))
+	router.HandleFunc(fmt.Sprintf("/v{%s}/clusters", paramContractVersion), func(w http.ResponseWriter, r *http.Request) {
+		payload, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		w.Header().Set("content-type", "application/json")
+		_ = json.NewEncoder(w).Encode(&keb.HTTPClusterResponse{Cluster: string(payload)})
+	}).Methods(http.MethodPost)
+	router.HandleFunc(fmt.Sprintf("/v{%s}/clusters/state", paramContractVersion), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write([]byte(`{"error":"not found"}`))
+	}).Methods(http.MethodGet)
+	router.HandleFunc(fmt.Sprintf("/v{%s}/clusters/{%s}/status/stream", paramContractVersion, paramRuntimeID), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("data: {}\n\n"))
+	}).Methods(http.MethodGet)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return recorder
+	}
+	decodeDeprecations := func(t *testing.T, recorder *httptest.ResponseRecorder) []keb.Deprecation {
+		resp := &struct {
+			Deprecations []keb.Deprecation `json:"deprecations"`
+		}{}
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(resp))
+		return resp.Deprecations
+	}
+
+	t.Run("No deprecation used", func(t *testing.T) {
+		payload := `{"kymaConfig":{"components":[{"component":"istio"}]}}`
+		recorder := serve(http.MethodPost, "/v2/clusters", payload)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Empty(t, recorder.Header().Values("Warning"))
+		resp := &keb.HTTPClusterResponse{}
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(resp))
+		require.Equal(t, payload, resp.Cluster, "handler has to receive the payload")
+		require.Nil(t, resp.Deprecations)
+	})
+
+	t.Run("Deprecated field", func(t *testing.T) {
+		payload := `{"kymaConfig":{"components":[{"component":"istio"},{"component":"serverless","URL":"https://charts.kyma.io"}]}}`
+		recorder := serve(http.MethodPost, "/v2/clusters", payload)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Len(t, recorder.Header().Values("Warning"), 1)
+		require.Contains(t, recorder.Header().Get("Warning"), `299 - "Field 'kymaConfig.components.URL' is deprecated`)
+		require.Empty(t, recorder.Header().Get("Sunset"), "fields don't sunset the resource")
+
+		resp := &keb.HTTPClusterResponse{}
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(resp))
+		require.Equal(t, payload, resp.Cluster, "handler has to receive the payload")
+		require.NotNil(t, resp.Deprecations)
+		require.Len(t, *resp.Deprecations, 1)
+		deprecation := (*resp.Deprecations)[0]
+		require.Equal(t, keb.DeprecationKindField, deprecation.Kind)
+		require.Equal(t, "kymaConfig.components.repository", *deprecation.Replacement)
+		require.True(t, sunset.Equal(deprecation.Sunset))
+	})
+
+	t.Run("Deprecated field of other contract version", func(t *testing.T) {
+		recorder := serve(http.MethodPost, "/v1/clusters", `{"kymaConfig":{"components":[{"URL":"https://charts.kyma.io"}]}}`)
+		deprecations := decodeDeprecations(t, recorder)
+		require.Len(t, deprecations, 1)
+		require.Equal(t, keb.DeprecationKindContractVersion, deprecations[0].Kind)
+	})
+
+	t.Run("Deprecated route and contract version", func(t *testing.T) {
+		recorder := serve(http.MethodGet, "/v1/clusters/state", "")
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+		require.Len(t, recorder.Header().Values("Warning"), 2)
+		require.Equal(t, sunset.AddDate(0, -1, 0).Format(http.TimeFormat), recorder.Header().Get("Sunset"))
+		deprecations := decodeDeprecations(t, recorder)
+		require.Len(t, deprecations, 2)
+		require.Equal(t, "v1", deprecations[0].Name)
+		require.Equal(t, "GET /clusters/state", deprecations[1].Name)
+	})
+
+	t.Run("Streams are not buffered", func(t *testing.T) {
+		recorder := serve(http.MethodGet, "/v1/clusters/abc/status/stream", "")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Len(t, recorder.Header().Values("Warning"), 1)
+		require.Equal(t, "data: {}\n\n", recorder.Body.String())
+	})
+}
+
+func TestDeprecationRegistry(t *testing.T) {
+	t.Run("Deprecated contract versions are registered", func(t *testing.T) {
+		for contractV := range deprecatedContractVersions {
+			var registered bool
+			for _, d := range deprecations {
+				registered = registered || (d.kind == keb.DeprecationKindContractVersion && d.name == fmt.Sprintf("v%d", contractV))
+			}
+			require.True(t, registered, "contract version v%d is missing in the deprecation registry", contractV)
+		}
+	})
+
+	t.Run("Deprecated fields are accepted by routes", func(t *testing.T) {
+		for _, d := range deprecations {
+			if d.kind == keb.DeprecationKindField {
+				require.NotEmpty(t, d.routes, "field '%s' isn't accepted by any route", d.name)
+			}
+		}
+	})
+}
+
+func TestAppendDeprecations(t *testing.T) {
+	deprecations := []*deprecation{{kind: keb.DeprecationKindContractVersion, name: "v1", sunset: time.Now()}}
+
+	t.Run("Empty object", func(t *testing.T) {
+		require.Contains(t, string(appendDeprecations([]byte("{}\n"), deprecations)), `{"deprecations":[{"kind":"contractVersion"`)
+	})
+
+	t.Run("Arrays are not changed", func(t *testing.T) {
+		require.Equal(t, "[]\n", string(appendDeprecations([]byte("[]\n"), deprecations)))
+	})
+
+	t.Run("Existing deprecations are not changed", func(t *testing.T) {
+		require.Equal(t, `{"deprecations":[]}`, string(appendDeprecations([]byte(`{"deprecations":[]}`), deprecations)))
+	})
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/explain"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//explainHistory is the number of previous reconciliations whose failures are classified
+const explainHistory = 5
+
+//explainCluster runs the diagnostic checks of a cluster and returns a ranked explanation of what is holding it back
+func explainCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster").Error(),
+		})
+		return
+	}
+
+	input, err := newExplainInput(o, clusterState)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrapf(err, "Failed to collect diagnostic data of cluster '%s'", runtimeID).Error(),
+		})
+		return
+	}
+	explanation := explain.Explain(input, time.Now().UTC())
+
+	kebStatus, err := clusterState.Status.GetKEBClusterStatus()
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to convert cluster status").Error(),
+		})
+		return
+	}
+	resp := keb.HTTPClusterExplainResponse{
+		Cluster:  runtimeID,
+		Status:   kebStatus,
+		Ready:    explanation.Ready,
+		Summary:  explanation.Summary,
+		Findings: []keb.ExplanationFinding{},
+	}
+	for _, finding := range explanation.Findings {
+		findingResp := keb.ExplanationFinding{
+			Check:    finding.Check,
+			Severity: keb.ExplanationFindingSeverity(finding.Severity.String()),
+			Message:  finding.Message,
+		}
+		if finding.Component != "" {
+			component := finding.Component
+			findingResp.Component = &component
+		}
+		resp.Findings = append(resp.Findings, findingResp)
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode cluster explanation response").Error(),
+		})
+	}
+}
+
+//newExplainInput collects the diagnostic data of a cluster: the operations of its latest reconciliations, its open
+//dead letters, the scheduler pause and the cohort of the cluster
+func newExplainInput(o *Options, clusterState *cluster.State) (*explain.Input, error) {
+	input := &explain.Input{
+		Cluster: clusterState.Cluster,
+		Status:  clusterState.Status,
+	}
+	if o.FlakinessClassifier != nil {
+		input.Quarantined = o.FlakinessClassifier.Quarantined
+	}
+
+	reconRepo := o.Registry.ReconciliationRepository()
+	reconciliations, err := reconRepo.GetReconciliations(&reconciliation.WithRuntimeID{
+		RuntimeID: clusterState.Cluster.RuntimeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, recon := range reconciliations { //latest reconciliation first
+		if i > explainHistory {
+			break
+		}
+		ops, err := reconRepo.GetOperations(&operation.WithSchedulingID{SchedulingID: recon.SchedulingID})
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			input.Operations = ops
+		} else {
+			input.History = append(input.History, ops...)
+		}
+	}
+
+	if input.DeadLetters, err = o.Registry.DeadLetterRepository().List(model.DeadLetterStateOpen,
+		clusterState.Cluster.RuntimeID); err != nil {
+		return nil, err
+	}
+	if input.Pause, err = o.Registry.PauseRepository().Active(); err != nil {
+		return nil, err
+	}
+	input.Cohort = o.Cohorts.Resolve(clusterState.Cluster.Metadata)
+	return input, nil
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/flaky"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//getFlakiness returns the flakiness of the components and whether they are quarantined
+func getFlakiness(o *Options, w http.ResponseWriter, _ *http.Request) {
+	resp := keb.HTTPFlakinessResponse{}
+	for _, classification := range o.FlakinessClassifier.Classifications() {
+		resp = append(resp, newComponentFlakinessResponse(classification))
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode flakiness response").Error(),
+		})
+	}
+}
+
+func newComponentFlakinessResponse(classification *flaky.Classification) keb.ComponentFlakiness {
+	resp := keb.ComponentFlakiness{
+		Component:       classification.Component,
+		FlakyOperations: int64(classification.FlakyOperations),
+		Operations:      int64(classification.Operations),
+		Quarantined:     classification.Quarantined,
+		Ratio:           classification.Ratio,
+	}
+	if classification.Quarantined {
+		since := classification.Since
+		resp.QuarantinedSince = &since
+	}
+	return resp
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/grpcapi"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/ssl"
+	"github.com/kyma-incubator/reconciler/pkg/version"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+//grpcService implements the gRPC API by dispatching the calls to the REST API routes: authentication, rate limits,
+//audit logging and validations are applied to both APIs in the same way
+type grpcService struct {
+	apiRouter     http.Handler
+	watchInterval time.Duration
+}
+
+//startGRPCServer serves the gRPC API on the gRPC port until the context gets closed
+func startGRPCServer(ctx context.Context, o *Options, apiRouter http.Handler, tlsConfig *tls.Config) error {
+	var serverOpts []grpc.ServerOption
+	if o.SSLCrt != "" && o.SSLKey != "" {
+		certReloader, err := ssl.NewCertReloader(o.SSLCrt, o.SSLKey, o.Logger())
+		if err != nil {
+			return errors.Wrap(err, "failed to load SSL key pair of gRPC server")
+		}
+		if o.CertReloadInterval > 0 {
+			go certReloader.Watch(ctx, o.CertReloadInterval)
+		}
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		} else {
+			tlsConfig = tlsConfig.Clone()
+		}
+		tlsConfig.GetCertificate = certReloader.GetCertificate
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", o.GRPCPort))
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen on gRPC port %d", o.GRPCPort)
+	}
+	srv := grpc.NewServer(serverOpts...)
+	grpcapi.RegisterMothershipServer(srv, &grpcService{
+		apiRouter:     apiRouter,
+		watchInterval: o.GRPCWatchInterval,
+	})
+
+	go func() {
+		<-ctx.Done()
+		o.Logger().Info("gRPC server stopping (context got closed)")
+		srv.GracefulStop()
+	}()
+	o.Logger().Infof("gRPC server starting and listening on port %d", o.GRPCPort)
+	return srv.Serve(listener)
+}
+
+func (s *grpcService) RegisterCluster(ctx context.Context, req *grpcapi.RegisterClusterRequest) (*grpcapi.ClusterStatus, error) {
+	contractV := req.ContractVersion
+	if contractV == 0 {
+		contractV = latestContractVersion()
+	}
+	resp := &keb.HTTPClusterResponse{}
+	if err := s.call(ctx, http.MethodPost, fmt.Sprintf("/v%d/clusters", contractV), req.Cluster, resp); err != nil {
+		return nil, err
+	}
+	return newGRPCClusterStatus(resp), nil
+}
+
+func (s *grpcService) GetClusterStatus(ctx context.Context, req *grpcapi.GetClusterStatusRequest) (*grpcapi.ClusterStatus, error) {
+	if req.RuntimeID == "" {
+		return nil, status.Error(codes.InvalidArgument, "runtimeID is undefined")
+	}
+	path := fmt.Sprintf("/v%d/clusters/%s/status", latestContractVersion(), url.PathEscape(req.RuntimeID))
+	if req.ConfigVersion > 0 {
+		path = fmt.Sprintf("/v%d/clusters/%s/configs/%d/status",
+			latestContractVersion(), url.PathEscape(req.RuntimeID), req.ConfigVersion)
+	}
+	resp := &keb.HTTPClusterResponse{}
+	if err := s.call(ctx, http.MethodGet, path, nil, resp); err != nil {
+		return nil, err
+	}
+	return newGRPCClusterStatus(resp), nil
+}
+
+//WatchStatusChanges streams the change feed: it's polled in the watch interval and filtered by the runtime ID
+func (s *grpcService) WatchStatusChanges(req *grpcapi.WatchStatusChangesRequest, stream grpcapi.StatusChangeSender) error {
+	ctx := stream.Context()
+
+	cursor := req.Cursor
+	if cursor == "" {
+		//skip the history: only changes after the subscription are streamed
+		var err error
+		if cursor, err = s.drainChanges(ctx, "0", nil); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+	for {
+		var err error
+		cursor, err = s.drainChanges(ctx, cursor, func(change keb.Change) error {
+			if req.RuntimeID != "" && change.RuntimeID != req.RuntimeID {
+				return nil
+			}
+			return stream.Send(newGRPCStatusChange(change))
+		})
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+//drainChanges passes all changes after the cursor to the consumer and returns the cursor of the last change
+func (s *grpcService) drainChanges(ctx context.Context, cursor string, consume func(change keb.Change) error) (string, error) {
+	for {
+		resp := &keb.HTTPChangesResponse{}
+		path := fmt.Sprintf("/v%d/changes?%s=%s&%s=%d",
+			latestContractVersion(), paramCursor, url.QueryEscape(cursor), paramLimit, maxChangesLimit)
+		if err := s.call(ctx, http.MethodGet, path, nil, resp); err != nil {
+			return cursor, err
+		}
+		if consume != nil {
+			for _, change := range resp.Changes {
+				if err := consume(change); err != nil {
+					return cursor, err
+				}
+			}
+		}
+		cursor = resp.Cursor
+		if len(resp.Changes) < maxChangesLimit {
+			return cursor, nil
+		}
+	}
+}
+
+//call dispatches the request to the REST API route and decodes the JSON response
+func (s *grpcService) call(ctx context.Context, method, path string, body []byte, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, path, bytes.NewReader(body))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	req.Header.Set("content-type", "application/json")
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, authorization := range md.Get("authorization") {
+			req.Header.Add("Authorization", authorization)
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		req.RemoteAddr = p.Addr.String()
+		//client certificates are verified by the TLS handshake of the gRPC server
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			req.TLS = &tlsInfo.State
+		}
+	}
+
+	resp := &bufferedResponse{header: http.Header{}, status: http.StatusOK}
+	s.apiRouter.ServeHTTP(resp, req)
+	if resp.status >= http.StatusBadRequest {
+		errResp := &keb.HTTPErrorResponse{}
+		if err := json.Unmarshal(resp.body.Bytes(), errResp); err != nil || errResp.Error == "" {
+			errResp.Error = http.StatusText(resp.status)
+		}
+		return status.Error(grpcCode(resp.status), errResp.Error)
+	}
+	if err := json.Unmarshal(resp.body.Bytes(), result); err != nil {
+		return status.Error(codes.Internal, errors.Wrap(err, "failed to decode response").Error())
+	}
+	return nil
+}
+
+//bufferedResponse captures the response of a REST API route
+type bufferedResponse struct {
+	header http.Header
+	body   bytes.Buffer
+	status int
+}
+
+func (r *bufferedResponse) Header() http.Header {
+	return r.header
+}
+
+func (r *bufferedResponse) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *bufferedResponse) WriteHeader(code int) {
+	r.status = code
+}
+
+//grpcCode maps the HTTP status code of a REST API response to the gRPC status code
+func grpcCode(httpCode int) codes.Code {
+	switch httpCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusGone:
+		return codes.OutOfRange
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	}
+	if httpCode >= http.StatusInternalServerError {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+func latestContractVersion() int64 {
+	return version.ContractVersions[len(version.ContractVersions)-1]
+}
+
+func newGRPCClusterStatus(resp *keb.HTTPClusterResponse) *grpcapi.ClusterStatus {
+	clusterStatus := &grpcapi.ClusterStatus{
+		RuntimeID:      resp.Cluster,
+		ClusterVersion: resp.ClusterVersion,
+		ConfigVersion:  resp.ConfigurationVersion,
+		Status:         string(resp.Status),
+		StatusURL:      resp.StatusURL,
+	}
+	if resp.Failures != nil {
+		for _, failure := range *resp.Failures {
+			clusterStatus.Failures = append(clusterStatus.Failures, &grpcapi.Failure{
+				Component: failure.Component,
+				Reason:    failure.Reason,
+			})
+		}
+	}
+	return clusterStatus
+}
+
+func newGRPCStatusChange(change keb.Change) *grpcapi.StatusChange {
+	statusChange := &grpcapi.StatusChange{
+		RuntimeID:     change.RuntimeID,
+		ConfigVersion: change.ConfigVersion,
+		Kind:          string(change.Kind),
+		Status:        string(change.Status),
+		Created:       change.Created.UnixNano() / int64(time.Millisecond),
+		Cursor:        change.Cursor,
+	}
+	if change.SchedulingID != nil {
+		statusChange.SchedulingID = *change.SchedulingID
+	}
+	return statusChange
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/grpcapi"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testStatusChangeSender struct {
+	grpc.ServerStream
+	ctx     context.Context
+	cancel  context.CancelFunc
+	changes []*grpcapi.StatusChange
+	expect  int
+}
+
+func (s *testStatusChangeSender) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testStatusChangeSender) Send(change *grpcapi.StatusChange) error {
+	s.changes = append(s.changes, change)
+	if len(s.changes) == s.expect {
+		s.cancel()
+	}
+	return nil
+}
+
+func TestGRPCService(t *testing.T) {
+	//fake REST API: changes are created with ascending cursors
+	var changes []keb.Change
+	var changesMu sync.Mutex
+	addChange := func(runtimeID string, status keb.Status) {
+		changesMu.Lock()
+		defer changesMu.Unlock()
+		changes = append(changes, keb.Change{
+			Cursor:    strconv.Itoa(len(changes) + 1),
+			Kind:      keb.ChangeKindCluster,
+			RuntimeID: runtimeID,
+			Status:    status,
+			Created:   time.Unix(1650000000, 0),
+		})
+	}
+	addChange("abc", keb.StatusReconcilePending)
+	addChange("xyz", keb.StatusReconcilePending)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/v{version}/clusters", func(w http.ResponseWriter, r *http.Request) {
+		cluster := &keb.Cluster{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(cluster))
+		require.NoError(t, json.NewEncoder(w).Encode(&keb.HTTPClusterResponse{
+			Cluster:              cluster.RuntimeID,
+			ConfigurationVersion: 1,
+			Status:               keb.StatusReconcilePending,
+			StatusURL:            fmt.Sprintf("/v%s/clusters/%s/configs/1/status", mux.Vars(r)["version"], cluster.RuntimeID),
+		}))
+	}).Methods(http.MethodPost)
+	router.HandleFunc("/v{version}/clusters/{runtimeID}/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if mux.Vars(r)["runtimeID"] != "abc" {
+			w.WriteHeader(http.StatusNotFound)
+			require.NoError(t, json.NewEncoder(w).Encode(&keb.HTTPErrorResponse{Error: "cluster not found"}))
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(&keb.HTTPClusterResponse{
+			Cluster:  "abc",
+			Status:   keb.StatusError,
+			Failures: &[]keb.Failure{{Component: "istio", Reason: "timeout"}},
+		}))
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/v{version}/changes", func(w http.ResponseWriter, r *http.Request) {
+		cursor, err := strconv.Atoi(r.URL.Query().Get(paramCursor))
+		require.NoError(t, err)
+		changesMu.Lock()
+		defer changesMu.Unlock()
+		resp := &keb.HTTPChangesResponse{Changes: []keb.Change{}, Cursor: strconv.Itoa(cursor)}
+		if cursor < len(changes) {
+			resp.Changes = changes[cursor:]
+			resp.Cursor = changes[len(changes)-1].Cursor
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}).Methods(http.MethodGet)
+
+	svc := &grpcService{apiRouter: router, watchInterval: 10 * time.Millisecond}
+	authCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+
+	t.Run("Register cluster", func(t *testing.T) {
+		resp, err := svc.RegisterCluster(context.Background(), &grpcapi.RegisterClusterRequest{
+			Cluster: []byte(`{"runtimeID":"abc"}`),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "abc", resp.RuntimeID)
+		require.Equal(t, string(keb.StatusReconcilePending), resp.Status)
+		require.Equal(t, fmt.Sprintf("/v%d/clusters/abc/configs/1/status", latestContractVersion()), resp.StatusURL)
+	})
+
+	t.Run("Get cluster status", func(t *testing.T) {
+		resp, err := svc.GetClusterStatus(authCtx, &grpcapi.GetClusterStatusRequest{RuntimeID: "abc"})
+		require.NoError(t, err)
+		require.Equal(t, string(keb.StatusError), resp.Status)
+		require.Equal(t, []*grpcapi.Failure{{Component: "istio", Reason: "timeout"}}, resp.Failures)
+
+		_, err = svc.GetClusterStatus(authCtx, &grpcapi.GetClusterStatusRequest{RuntimeID: "xyz"})
+		require.Equal(t, codes.NotFound, status.Code(err))
+		require.Equal(t, "cluster not found", status.Convert(err).Message())
+
+		_, err = svc.GetClusterStatus(context.Background(), &grpcapi.GetClusterStatusRequest{RuntimeID: "abc"})
+		require.Equal(t, codes.Unauthenticated, status.Code(err), "authorization metadata is forwarded")
+
+		_, err = svc.GetClusterStatus(authCtx, &grpcapi.GetClusterStatusRequest{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Watch status changes", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stream := &testStatusChangeSender{ctx: ctx, cancel: cancel, expect: 2}
+
+		go func() {
+			//changes which are created before the subscription are skipped
+			time.Sleep(50 * time.Millisecond)
+			addChange("xyz", keb.StatusReady)
+			addChange("abc", keb.StatusReconciling)
+			addChange("abc", keb.StatusReady)
+		}()
+		require.NoError(t, svc.WatchStatusChanges(&grpcapi.WatchStatusChangesRequest{RuntimeID: "abc"}, stream))
+		require.Len(t, stream.changes, 2)
+		require.Equal(t, "4", stream.changes[0].Cursor)
+		require.Equal(t, string(keb.StatusReady), stream.changes[1].Status)
+		require.Equal(t, int64(1650000000000), stream.changes[1].Created)
+	})
+
+	t.Run("Watch status changes after cursor", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stream := &testStatusChangeSender{ctx: ctx, cancel: cancel, expect: 4}
+
+		require.NoError(t, svc.WatchStatusChanges(&grpcapi.WatchStatusChangesRequest{Cursor: "1"}, stream))
+		require.Len(t, stream.changes, 4)
+		require.Equal(t, "xyz", stream.changes[0].RuntimeID)
+	})
+}
@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 )
@@ -27,3 +32,21 @@ func validateStatuses(statuses []string) error {
 	}
 	return nil
 }
+
+//expectedConfigVersion returns the configuration version the caller expects to be the latest one:
+//it's either defined by the If-Match header (ETag of the cluster status) or the query parameter.
+//0 is returned if no expectation was defined.
+func expectedConfigVersion(r *http.Request) (int64, error) {
+	expected := strings.Trim(strings.TrimPrefix(strings.TrimSpace(r.Header.Get(headerIfMatch)), "W/"), `"`)
+	if expected == "" || expected == "*" {
+		expected = r.URL.Query().Get(paramExpectedConfigVersion)
+	}
+	if expected == "" {
+		return 0, nil
+	}
+	version, err := strconv.ParseInt(expected, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("expected configuration version '%s' is invalid: positive number required", expected)
+	}
+	return version, nil
+}
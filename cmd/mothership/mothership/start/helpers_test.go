@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"testing"
 
@@ -95,3 +98,68 @@ func Test_validateStatuses(t *testing.T) {
 		})
 	}
 }
+
+func Test_expectedConfigVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		ifMatch  string
+		query    string
+		expected int64
+		wantErr  bool
+	}{
+		{
+			name:     "no expectation",
+			expected: 0,
+		},
+		{
+			name:     "ETag in If-Match header",
+			ifMatch:  `"3"`,
+			expected: 3,
+		},
+		{
+			name:     "weak ETag in If-Match header",
+			ifMatch:  `W/"4"`,
+			expected: 4,
+		},
+		{
+			name:     "wildcard in If-Match header falls back to query parameter",
+			ifMatch:  "*",
+			query:    "5",
+			expected: 5,
+		},
+		{
+			name:     "query parameter",
+			query:    "6",
+			expected: 6,
+		},
+		{
+			name:    "invalid version",
+			query:   "abc",
+			wantErr: true,
+		},
+		{
+			name:    "non-positive version",
+			ifMatch: `"0"`,
+			wantErr: true,
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodDelete, "/v1/clusters/abc?"+url.Values{paramExpectedConfigVersion: []string{tt.query}}.Encode(), nil)
+			if tt.query == "" {
+				r.URL.RawQuery = ""
+			}
+			if tt.ifMatch != "" {
+				r.Header.Set(headerIfMatch, tt.ifMatch)
+			}
+			got, err := expectedConfigVersion(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expectedConfigVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("expectedConfigVersion() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//getClusterConfigAt returns the configuration and status which were active at the given time
+//(e.g. to analyse what was deployed on a cluster when an incident happened)
+func getClusterConfigAt(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	at, err := params.String(paramAt)
+	if err != nil || at == "" {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: "Query parameter 'at' is required",
+		})
+		return
+	}
+	timestamp, err := time.Parse(paramTimeFormat, at)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Query parameter 'at' is invalid").Error(),
+		})
+		return
+	}
+
+	clusterState, err := o.Registry.Inventory().GetAt(runtimeID, timestamp)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrapf(err, "Could not retrieve configuration of cluster '%s' at %s", runtimeID, at).Error(),
+		})
+		return
+	}
+	sendClusterStateResponse(w, clusterState, o)
+}
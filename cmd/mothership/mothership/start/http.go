@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,16 +18,27 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/features"
 
 	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/openapi"
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/kyma-incubator/reconciler/pkg/callback/client"
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/contract"
+	"github.com/kyma-incubator/reconciler/pkg/cors"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/kubernetes"
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/policy"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/redact"
 	"github.com/kyma-incubator/reconciler/pkg/repository"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/service"
 	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/kyma-incubator/reconciler/pkg/ssl"
+	"github.com/kyma-incubator/reconciler/pkg/version"
+	"github.com/kyma-incubator/reconciler/ui"
 	"github.com/pkg/errors"
 
 	"github.com/gorilla/mux"
@@ -47,6 +61,19 @@ const (
 	paramLast       = "last"
 	paramTimeFormat = time.RFC3339
 	paramPoolID     = "poolID"
+	paramEventType  = "type"
+	paramComponent  = "component"
+	paramAt         = "at"
+	paramOrder      = "order"
+
+	orderAscending  = "asc"
+	orderDescending = "desc"
+
+	defaultStatusChangesLimit = 100
+	maxStatusChangesLimit     = 1000
+
+	paramExpectedConfigVersion = "expectedConfigVersion"
+	headerIfMatch              = "If-Match"
 
 	// Limit Request Bodies to 50KB
 	bodyRequestLimitBytes = 50000
@@ -63,6 +90,51 @@ var (
 			http.MethodPatch,
 			http.MethodDelete,
 		},
+		fmt.Sprintf("/v{%s}/clusters/{%s}/kubeconfig", paramContractVersion, paramRuntimeID): {
+			http.MethodPut,
+		},
+		fmt.Sprintf("/v{%s}/clusters/{%s}/kubeconfig/rollback", paramContractVersion, paramRuntimeID): {
+			http.MethodPost,
+		},
+		//the URI contains the restored configuration version
+		fmt.Sprintf("/v{%s}/clusters/{%s}/rollback", paramContractVersion, paramRuntimeID): {
+			http.MethodPost,
+		},
+		//the URI contains the reason of the cancellation
+		fmt.Sprintf("/v{%s}/clusters/{%s}/reconciliations/{%s}", paramContractVersion, paramRuntimeID, paramSchedulingID): {
+			http.MethodDelete,
+		},
+		//the URI contains the pause window
+		fmt.Sprintf("/v{%s}/admin/pause", paramContractVersion): {
+			http.MethodPost,
+		},
+		fmt.Sprintf("/v{%s}/admin/resume", paramContractVersion): {
+			http.MethodPost,
+		},
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/confirm", paramContractVersion, paramSchedulingID, paramCorrelationID): {
+			http.MethodPost,
+		},
+		//credentials of a cluster are handed out
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/kubeconfig", paramContractVersion, paramSchedulingID, paramCorrelationID): {
+			http.MethodGet,
+		},
+		fmt.Sprintf("/v{%s}/admin/templates/{%s}", paramContractVersion, paramTemplateName): {
+			http.MethodPut,
+			http.MethodDelete,
+		},
+		//inventory entities and reconciliations are removed physically
+		fmt.Sprintf("/v{%s}/clusters/{%s}/purge", paramContractVersion, paramRuntimeID): {
+			http.MethodDelete,
+		},
+		fmt.Sprintf("/v{%s}/admin/warmup", paramContractVersion): {
+			http.MethodPost,
+		},
+		fmt.Sprintf("/v{%s}/subscriptions", paramContractVersion): {
+			http.MethodPost,
+		},
+		fmt.Sprintf("/v{%s}/subscriptions/{%s}", paramContractVersion, paramSubscriptionID): {
+			http.MethodDelete,
+		},
 	}
 )
 
@@ -82,8 +154,69 @@ func (r AuditRegistry) mustAudit(path, method string) bool {
 func startWebserver(ctx context.Context, o *Options) error {
 	//routing
 	mainRouter := mux.NewRouter()
+
+	//browsers send preflight requests without credentials: they have to be answered before the API routes
+	//(and their authentication) are matched
+	corsHandler, err := cors.NewHandler(o.CORS)
+	if err != nil {
+		return err
+	}
+	if corsHandler != nil {
+		mainRouter.Methods(http.MethodOptions).HandlerFunc(corsHandler.Preflight)
+	}
 	apiRouter := mainRouter.PathPrefix("/").Subrouter()
 
+	//panics of handlers and middlewares are answered with an internal server error
+	panicsMetric, err := metrics.RegisterPanics(o.Logger())
+	if err != nil {
+		return err
+	}
+	recoveryMiddleware := newRecoveryMiddleware(panicsMetric, o.Logger())
+	//the access log wraps the recovery to log the internal server error of a panicking handler
+	mainRouter.Use(newAccessLogMiddleware(o.Logger()))
+	mainRouter.Use(recoveryMiddleware)
+	if corsHandler != nil {
+		o.Logger().Infof("Allowing cross-origin requests of origins [%s]", strings.Join(o.CORS.AllowedOrigins, ","))
+		mainRouter.Use(corsHandler.Middleware)
+	}
+
+	//all contract versions are served by the same handlers
+	apiRequestsMetric, err := metrics.RegisterAPIRequests(o.Logger())
+	if err != nil {
+		return err
+	}
+	apiRouter.Use(newContractVersionMiddleware(apiRequestsMetric))
+	//compression wraps the deprecation middleware to compress the payload after the deprecations were added
+	apiRouter.Use(newCompressionMiddleware(o.CompressionMinSize, o.CompressionBrotli))
+	apiRouter.Use(newDeprecationMiddleware(deprecations))
+
+	authenticator, err := auth.NewAuthenticator(o.Auth, o.Logger())
+	if err != nil {
+		return err
+	}
+	if authenticator != nil {
+		o.Logger().Infof("Requiring JWT bearer tokens issued by '%s' (JWKS endpoint: '%s')", o.Auth.Issuer, o.Auth.JWKSURL)
+		apiRouter.Use(newAuthMiddleware(authenticator))
+	}
+	if o.ClientLimiter != nil {
+		apiRouter.Use(newClientRateLimitMiddleware(o.ClientLimiter, o.Config.ClientRateLimit.ForwardedForHops(), o.Logger()))
+	}
+
+	ignoredCallbacksMetric, err := metrics.RegisterIgnoredCallbacks(o.Logger())
+	if err != nil {
+		return err
+	}
+
+	if o.RecordContract != "" {
+		recorder, err := contract.NewRecorder(o.RecordContract, o.Logger())
+		if err != nil {
+			return err
+		}
+		o.Logger().Infof("Recording API interactions as golden files in directory '%s'", o.RecordContract)
+		recorder.Run(ctx)
+		apiRouter.Use(recorder.Middleware)
+	}
+
 	if o.AuditLog && o.AuditLogFile != "" && o.AuditLogTenantID != "" {
 		for auditedPath, auditedMethods := range auditRegistry {
 			o.Logger().Infof("Auditing %s for methods [%s]", auditedPath, strings.Join(auditedMethods, ","))
@@ -111,13 +244,203 @@ func startWebserver(ctx context.Context, o *Options) error {
 
 	metricsRouter := mainRouter.Path("/metrics").Subrouter()
 	healthRouter := mainRouter.PathPrefix("/health").Subrouter()
-	mainRouter.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux)
+	healthzRouter := mainRouter.Path("/healthz").Subrouter()
+	readyzRouter := mainRouter.Path("/readyz").Subrouter()
+	if o.ServerPprof {
+		o.Logger().Warn("Serving pprof endpoints on the webserver port is deprecated: " +
+			"use the debug port instead (flags --debug-port and --server-pprof=false)")
+	}
+	if authenticator != nil {
+		if o.ServerPprof {
+			mainRouter.PathPrefix("/debug/pprof/").Handler(authenticator.Middleware(http.DefaultServeMux))
+		}
+		if o.Auth.ProtectMetrics {
+			metricsRouter.Use(authenticator.Middleware)
+		}
+		if o.Auth.ProtectHealth {
+			healthRouter.Use(authenticator.Middleware)
+			healthzRouter.Use(authenticator.Middleware)
+			readyzRouter.Use(authenticator.Middleware)
+		}
+	} else if o.ServerPprof {
+		mainRouter.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux)
+	}
+
+	//innermost recovery per handler: the API metrics track the internal server error and gRPC calls, which are
+	//dispatched to the API router, are covered as well
+	apiRouter.Use(recoveryMiddleware)
+	registerAPIRoutes(apiRouter, o, ignoredCallbacksMetric)
+
+	//OpenAPI specification of all API routes
+	mainRouter.HandleFunc("/openapi.json", serveOpenAPISpec).Methods(http.MethodGet)
+
+	//read-only web UI: it calls the API routes (incl. their authentication) from the browser
+	if o.UI {
+		o.Logger().Info("Serving web UI at /ui")
+		mainRouter.Handle("/ui", http.RedirectHandler("/ui/", http.StatusMovedPermanently))
+		mainRouter.PathPrefix("/ui/").Handler(http.StripPrefix("/ui/", ui.Handler())).
+			Methods(http.MethodGet, http.MethodHead)
+	}
+
+	//metrics endpoint
+	metricErr := metrics.RegisterOccupancy(o.Registry.OccupancyRepository(), o.Config.Scheduler.Reconcilers, o.Logger())
+	if metricErr != nil {
+		return metricErr
+	}
+	metricErr = metrics.RegisterProcessingDuration(o.Registry.ReconciliationRepository(), o.Logger())
+	if metricErr != nil {
+		return metricErr
+	}
+	metricErr = metrics.RegisterWaitingAndNotReadyReconciliations(o.Registry.Inventory(), o.Logger())
+	if metricErr != nil {
+		return metricErr
+	}
+	metricErr = metrics.RegisterOperationResults(o.Registry.ReconciliationRepository(), o.Logger())
+	if metricErr != nil {
+		return metricErr
+	}
+	metricErr = metrics.RegisterReconciliationETA(o.Registry.ReconciliationRepository(), o.Logger())
+	if metricErr != nil {
+		return metricErr
+	}
+	metricErr = metrics.RegisterClusterCost(o.Registry.ReconciliationRepository(), o.Registry.Inventory(), o.Logger())
+	if metricErr != nil {
+		return metricErr
+	}
+	if o.Config.Scheduler.DeadLetter.Enabled {
+		metricErr = metrics.RegisterDeadLetters(o.Registry.DeadLetterRepository(), o.Logger())
+		if metricErr != nil {
+			return metricErr
+		}
+	}
+	if len(o.SLOs) > 0 {
+		metricErr = metrics.RegisterSLOs(o.Registry.ReconciliationRepository(), o.SLOs, o.Logger())
+		if metricErr != nil {
+			return metricErr
+		}
+	}
+	if o.FlakinessClassifier != nil {
+		metricErr = metrics.RegisterFlakiness(o.FlakinessClassifier, o.Logger())
+		if metricErr != nil {
+			return metricErr
+		}
+	}
+	if o.HealthScorer != nil {
+		metricErr = metrics.RegisterHealth(o.HealthScorer, o.Logger())
+		if metricErr != nil {
+			return metricErr
+		}
+	}
+	if o.UpdateLimiter != nil {
+		metricErr = metrics.RegisterUpdateRateLimit(o.UpdateLimiter, o.Logger())
+		if metricErr != nil {
+			return metricErr
+		}
+	}
+	if o.Backpressure != nil {
+		metricErr = metrics.RegisterBackpressure(o.Backpressure, o.Logger())
+		if metricErr != nil {
+			return metricErr
+		}
+	}
+	if o.StatusNotifier != nil {
+		metricErr = metrics.RegisterSubscriptions(o.StatusNotifier, o.Logger())
+		if metricErr != nil {
+			return metricErr
+		}
+	}
+	metricErr = metrics.RegisterDbPool(o.Registry.Connection(), o.Logger())
+	if metricErr != nil {
+		return metricErr
+	}
+	metricErr = metrics.RegisterBuildInfo(o.Logger())
+	if metricErr != nil {
+		return metricErr
+	}
+
+	metricsRouter.Handle("", promhttp.Handler())
+
+	//liveness and readiness checks
+	healthRouter.HandleFunc("/live", live)
+	healthRouter.HandleFunc("/ready", ready(o))
+
+	//Kubernetes probes: the readiness probe verifies the dependencies (database, migrations and scheduler)
+	healthzRouter.HandleFunc("", healthz).Methods(http.MethodGet)
+	readyzRouter.HandleFunc("", readyz(o)).Methods(http.MethodGet)
+
+	clientAuthConfig, err := ssl.NewServerTLSConfig(o.ClientAuth)
+	if err != nil {
+		return err
+	}
+	if clientAuthConfig != nil {
+		o.Logger().Infof("Verifying client certificates issued by CA '%s' (required: %t, allowed CNs: [%s], "+
+			"allowed SANs: [%s])", o.ClientAuth.CAFile, o.ClientAuth.Required,
+			strings.Join(o.ClientAuth.AllowedCNs, ","), strings.Join(o.ClientAuth.AllowedSANs, ","))
+	}
+	tlsConfig, err := o.TLS.Apply(clientAuthConfig)
+	if err != nil {
+		return err
+	}
+
+	//gRPC API is served on its own port and dispatches the calls to the REST API routes
+	if o.GRPCPort > 0 {
+		go func() {
+			if err := startGRPCServer(ctx, o, apiRouter, tlsConfig); err != nil {
+				o.Logger().Errorf("gRPC server startup failed: %s", err)
+			}
+		}()
+	}
+
+	//debug endpoints are served on their own port: profiling doesn't compete with the API for the webserver port
+	if o.DebugPort > 0 {
+		debugRouter := newDebugRouter()
+		if authenticator != nil {
+			debugRouter.Use(authenticator.Middleware)
+		}
+		go func() {
+			debugSrv := &server.Webserver{
+				Logger: o.Logger(),
+				Host:   o.DebugHost,
+				Port:   o.DebugPort,
+				Limits: server.Limits{ //profiles and traces are recorded for a requested duration: no write timeout
+					ReadHeaderTimeout: o.ServerLimits.ReadHeaderTimeout,
+					IdleTimeout:       o.ServerLimits.IdleTimeout,
+				},
+				Router: debugRouter,
+			}
+			if err := debugSrv.Start(ctx); err != nil {
+				o.Logger().Errorf("Debug webserver failed: %s", err)
+			}
+		}()
+	}
+
+	//start server process
+	srv := &server.Webserver{
+		Logger:             o.Logger(),
+		Port:               o.Port,
+		SSLCrtFile:         o.SSLCrt,
+		SSLKeyFile:         o.SSLKey,
+		TLSConfig:          tlsConfig,
+		CertReloadInterval: o.CertReloadInterval,
+		Limits:             o.ServerLimits,
+		Router:             mainRouter,
+	}
+	return srv.Start(ctx) //blocking call
+}
 
+//registerAPIRoutes adds the handlers of all API routes: the routes have to be documented in the OpenAPI
+//specification (verified by unit test)
+func registerAPIRoutes(apiRouter *mux.Router, o *Options, ignoredCallbacksMetric *metrics.IgnoredCallbacksMetric) {
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/stop", paramContractVersion, paramSchedulingID, paramCorrelationID),
 		callHandler(o, updateOperationStatus)).
 		Methods(http.MethodPost)
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/confirm", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, confirmOperationDeletion)).
+		Methods(http.MethodPost)
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/reconciliations/cluster/{%s}", paramContractVersion, paramRuntimeID),
 		callHandler(o, deleteReconciliationsByCluster)).
@@ -125,12 +448,17 @@ func startWebserver(ctx context.Context, o *Options) error {
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters", paramContractVersion),
-		callHandler(o, createOrUpdateCluster)).
+		callHandler(o, withIdempotencyKey(createOrUpdateCluster))).
 		Methods(http.MethodPost, http.MethodPut)
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters", paramContractVersion),
-		callHandler(o, createOrUpdateCluster)).
+		callHandler(o, listClusters)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters", paramContractVersion),
+		callHandler(o, withIdempotencyKey(createOrUpdateCluster))).
 		Methods(http.MethodPost, http.MethodPut)
 
 	apiRouter.HandleFunc(
@@ -138,6 +466,36 @@ func startWebserver(ctx context.Context, o *Options) error {
 		callHandler(o, deleteCluster)).
 		Methods(http.MethodDelete)
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}", paramContractVersion, paramRuntimeID),
+		callHandler(o, patchCluster)).
+		Methods(http.MethodPatch)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/images", paramContractVersion, paramRuntimeID),
+		callHandler(o, clusterImages)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/snapshots", paramContractVersion, paramRuntimeID),
+		callHandler(o, clusterSnapshots)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/kubeconfig", paramContractVersion, paramRuntimeID),
+		callHandler(o, rotateKubeconfig)).
+		Methods(http.MethodPut)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/kubeconfig/rollback", paramContractVersion, paramRuntimeID),
+		callHandler(o, rollbackKubeconfig)).
+		Methods(http.MethodPost)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/rollback", paramContractVersion, paramRuntimeID), //requires toVersion-param
+		callHandler(o, rollbackCluster)).
+		Methods(http.MethodPost)
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%v}/clusters/state", paramContractVersion),
 		callHandler(o, getClustersState)).
@@ -148,6 +506,11 @@ func startWebserver(ctx context.Context, o *Options) error {
 		callHandler(o, getCluster)).
 		Methods(http.MethodGet)
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/configs", paramContractVersion, paramRuntimeID), //requires at-param
+		callHandler(o, getClusterConfigAt)).
+		Methods(http.MethodGet)
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/status", paramContractVersion, paramRuntimeID),
 		callHandler(o, getLatestCluster)).
@@ -159,13 +522,68 @@ func startWebserver(ctx context.Context, o *Options) error {
 		Methods(http.MethodPut)
 
 	apiRouter.HandleFunc(
-		fmt.Sprintf("/v{%s}/clusters/{%s}/statusChanges", paramContractVersion, paramRuntimeID), //supports offset-param
+		fmt.Sprintf("/v{%s}/clusters/{%s}/status/stream", paramContractVersion, paramRuntimeID),
+		callHandler(o, streamClusterStatus)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/statusChanges", paramContractVersion, paramRuntimeID), //supports offset, status, cursor, limit and order params
 		callHandler(o, statusChanges)).
 		Methods(http.MethodGet)
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/timeline", paramContractVersion, paramRuntimeID), //supports offset-param
+		callHandler(o, clusterTimeline)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/costs", paramContractVersion, paramRuntimeID), //supports offset-param
+		callHandler(o, clusterCost)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/explain", paramContractVersion, paramRuntimeID),
+		callHandler(o, explainCluster)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/deletionStatus", paramContractVersion, paramRuntimeID),
+		callHandler(o, getDeletionStatus)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/purge", paramContractVersion, paramRuntimeID), //supports retention-param
+		callHandler(o, purgeCluster)).
+		Methods(http.MethodDelete)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/reconciliations", paramContractVersion, paramRuntimeID), //supports status- and last-param
+		callHandler(o, listClusterReconciliations)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/reconciliations/{%s}", paramContractVersion, paramRuntimeID, paramSchedulingID),
+		callHandler(o, cancelReconciliation)).
+		Methods(http.MethodDelete)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/reconciliations/{%s}/operations", paramContractVersion, paramRuntimeID, paramSchedulingID),
+		callHandler(o, listReconciliationOperations)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/confirmations", paramContractVersion, paramRuntimeID),
+		callHandler(o, listPendingConfirmations)).
+		Methods(http.MethodGet)
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/operations/{%s}/callback/{%s}", paramContractVersion, paramSchedulingID, paramCorrelationID),
-		callHandler(o, operationCallback)).
+		callHandler(o, operationCallback(ignoredCallbacksMetric))).
+		Methods(http.MethodPost)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/callbacks", paramContractVersion),
+		callHandler(o, operationCallbacks(ignoredCallbacksMetric))).
 		Methods(http.MethodPost)
 
 	apiRouter.HandleFunc(
@@ -173,6 +591,21 @@ func startWebserver(ctx context.Context, o *Options) error {
 		callHandler(o, getReconciliations)).
 		Methods(http.MethodGet)
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/kubeconfig", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, getOperationKubeconfig)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/trace", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, getOperationTrace)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/logs", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, getOperationLogs)).
+		Methods(http.MethodGet)
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/reconciliations/{%s}/info", paramContractVersion, paramSchedulingID),
 		callHandler(o, getReconciliationInfo)).
@@ -192,6 +625,94 @@ func startWebserver(ctx context.Context, o *Options) error {
 		fmt.Sprintf("/v{%s}/clusters/{%s}/config/{%s}", paramContractVersion, paramRuntimeID, paramConfigVersion),
 		callHandler(o, getKymaConfig)).Methods(http.MethodGet)
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/deadletter", paramContractVersion),
+		callHandler(o, getDeadLetters)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/deadletter/requeue", paramContractVersion),
+		callHandler(o, requeueDeadLetters)).
+		Methods(http.MethodPost)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/deadletter/acknowledge", paramContractVersion),
+		callHandler(o, acknowledgeDeadLetters)).
+		Methods(http.MethodPost)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/query", paramContractVersion),
+		callHandler(o, queryData)).
+		Methods(http.MethodPost)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/changes", paramContractVersion),
+		callHandler(o, getChanges)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/admin/pause", paramContractVersion),
+		callHandler(o, getSchedulerPause)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/admin/pause", paramContractVersion),
+		callHandler(o, pauseScheduler)).
+		Methods(http.MethodPost)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/admin/resume", paramContractVersion),
+		callHandler(o, resumeScheduler)).
+		Methods(http.MethodPost)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/admin/templates", paramContractVersion),
+		callHandler(o, getConfigTemplates)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/admin/templates/{%s}", paramContractVersion, paramTemplateName),
+		callHandler(o, getConfigTemplate)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/admin/templates/{%s}", paramContractVersion, paramTemplateName),
+		callHandler(o, putConfigTemplate)).
+		Methods(http.MethodPut)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/admin/templates/{%s}", paramContractVersion, paramTemplateName),
+		callHandler(o, deleteConfigTemplate)).
+		Methods(http.MethodDelete)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/admin/warmup", paramContractVersion),
+		callHandler(o, warmupComponentReconcilers)).
+		Methods(http.MethodPost)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/subscriptions", paramContractVersion),
+		callHandler(o, getSubscriptions)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/subscriptions", paramContractVersion),
+		callHandler(o, postSubscription)).
+		Methods(http.MethodPost)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/subscriptions/{%s}", paramContractVersion, paramSubscriptionID),
+		callHandler(o, deleteSubscription)).
+		Methods(http.MethodDelete)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/version", paramContractVersion),
+		callHandler(o, getVersion)).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/capabilities", paramContractVersion),
+		callHandler(o, getCapabilities)).Methods(http.MethodGet)
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/occupancy/{%s}", paramContractVersion, paramPoolID),
 		callHandler(o, deleteComponentWorkerPoolOccupancy)).Methods(http.MethodDelete)
@@ -200,39 +721,13 @@ func startWebserver(ctx context.Context, o *Options) error {
 		fmt.Sprintf("/v{%s}/occupancy/{%s}", paramContractVersion, paramPoolID),
 		callHandler(o, createOrUpdateComponentWorkerPoolOccupancy)).Methods(http.MethodPost)
 
-	//metrics endpoint
-	metricErr := metrics.RegisterOccupancy(o.Registry.OccupancyRepository(), o.Config.Scheduler.Reconcilers, o.Logger())
-	if metricErr != nil {
-		return metricErr
-	}
-	metricErr = metrics.RegisterProcessingDuration(o.Registry.ReconciliationRepository(), o.Logger())
-	if metricErr != nil {
-		return metricErr
-	}
-	metricErr = metrics.RegisterWaitingAndNotReadyReconciliations(o.Registry.Inventory(), o.Logger())
-	if metricErr != nil {
-		return metricErr
-	}
-	metricErr = metrics.RegisterDbPool(o.Registry.Connection(), o.Logger())
-	if metricErr != nil {
-		return metricErr
-	}
-
-	metricsRouter.Handle("", promhttp.Handler())
-
-	//liveness and readiness checks
-	healthRouter.HandleFunc("/live", live)
-	healthRouter.HandleFunc("/ready", ready(o))
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/slo", paramContractVersion),
+		callHandler(o, getSLOs)).Methods(http.MethodGet)
 
-	//start server process
-	srv := &server.Webserver{
-		Logger:     o.Logger(),
-		Port:       o.Port,
-		SSLCrtFile: o.SSLCrt,
-		SSLKeyFile: o.SSLKey,
-		Router:     mainRouter,
-	}
-	return srv.Start(ctx) //blocking call
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/flakiness", paramContractVersion),
+		callHandler(o, getFlakiness)).Methods(http.MethodGet)
 }
 
 func enableReconciliationDebugLogging(o *Options, w http.ResponseWriter, r *http.Request) {
@@ -283,6 +778,37 @@ func enableOperationDebugLogging(o *Options, w http.ResponseWriter, r *http.Requ
 
 }
 
+func getVersion(_ *Options, w http.ResponseWriter, _ *http.Request) {
+	info := version.Get()
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(&keb.HTTPVersionResponse{
+		BuildDate:        info.BuildDate,
+		ContractVersions: info.ContractVersions,
+		GitCommit:        info.GitCommit,
+		GoVersion:        info.GoVersion,
+	}); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "failed to encode version response").Error(),
+		})
+	}
+}
+
+func serveOpenAPISpec(w http.ResponseWriter, _ *http.Request) {
+	spec, err := openapi.JSON()
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "failed to load OpenAPI specification").Error(),
+		})
+		return
+	}
+	w.Header().Set("content-type", "application/json")
+	if _, err := w.Write(spec); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "failed to send OpenAPI specification").Error(),
+		})
+	}
+}
+
 func live(w http.ResponseWriter, _ *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
@@ -293,13 +819,19 @@ func ready(o *Options) http.HandlerFunc {
 			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
 			return
 		}
-		w.WriteHeader(http.StatusOK)
+		//a paused scheduler is still ready (otherwise it couldn't be resumed): the pause window is just reported
+		pause, err := o.Registry.PauseRepository().Active()
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+		sendSchedulerPauseResponse(w, pause)
 	}
 }
 
 func callHandler(o *Options, handler func(o *Options, w http.ResponseWriter, r *http.Request)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		handler(o, w, r)
+		handler(o.forRequest(r), w, r)
 	}
 }
 
@@ -344,19 +876,72 @@ func createOrUpdateCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	bodyLimited := http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)
-	clusterModel, err := keb.NewModelFactory(contractV).Cluster(bodyLimited)
+	payload, err := ioutil.ReadAll(bodyLimited)
 	if err != nil {
 		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	clusterModel, err := keb.NewModelFactory(contractV).Cluster(bytes.NewReader(payload))
+	if err != nil {
+		sendModelFactoryError(w, err)
+		return
+	}
+	clusterModel.RuntimeID = strings.TrimSpace(clusterModel.RuntimeID)
+	if err := cluster.ValidateRuntimeID(clusterModel.RuntimeID); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
 		})
 		return
 	}
-	if _, err := kubernetes.NewClientBuilder().WithLogger(o.Logger()).WithString(clusterModel.Kubeconfig).Build(r.Context(), true); err != nil {
+	if err := keb.ValidateExecutionHints(clusterModel.KymaConfig.Components); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	if allowed, retryAfter := o.UpdateLimiter.Allow(clusterModel.RuntimeID, time.Now()); !allowed {
+		retryAfterSecs := int(math.Ceil(retryAfter.Seconds()))
+		o.Logger().Warnf("Configuration update of cluster '%s' rejected: update rate limit exceeded", clusterModel.RuntimeID)
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSecs))
+		server.SendHTTPError(w, http.StatusTooManyRequests, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("Cluster '%s' exceeded its configuration update rate limit: retry in %d secs",
+				clusterModel.RuntimeID, retryAfterSecs),
+		})
+		return
+	}
+	if o.VerifyKubeconfig {
+		if err := verifyKubeconfig(clusterModel.Kubeconfig); err != nil {
+			sendKubeconfigVerificationError(w, err)
+			return
+		}
+	} else if _, err := kubernetes.NewClientBuilder().WithLogger(o.Logger()).WithString(clusterModel.Kubeconfig).Build(r.Context(), true); err != nil {
 		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
 			Error: errors.Wrap(err, "kubeconfig not accepted").Error(),
 		})
 		return
 	}
+	if !acceptTargetKubeconfigs(o, w, r, clusterModel) {
+		return
+	}
+	templateOverrides, err := resolveConfigTemplate(o, clusterModel)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusBadRequest
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrapf(err, "Failed to resolve configuration template '%s'", *clusterModel.KymaConfig.Template).Error(),
+		})
+		return
+	}
+	if err := clusterModel.ValidateTargets(); err != nil { //verified after merging the components of the template
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
 
 	clusterStateOld, err := o.Registry.Inventory().GetLatest(clusterModel.RuntimeID)
 	if err != nil && !repository.IsNotFoundError(err) {
@@ -366,13 +951,60 @@ func createOrUpdateCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	admissionOp := policy.OperationUpdate
+	if clusterStateOld == nil {
+		admissionOp = policy.OperationCreate
+	}
+	validationWarnings, err := admitCluster(r.Context(), o, admissionOp, contractV, clusterModel)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if isAdmissionRejectionError(err) {
+			httpCode = http.StatusBadRequest
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
 	clusterStateNew, err := o.Registry.Inventory().CreateOrUpdate(contractV, clusterModel)
 	if err != nil {
+		if cluster.IsRuntimeIDConflictError(err) {
+			server.SendHTTPError(w, http.StatusConflict, &keb.HTTPErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
 		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
 			Error: errors.Wrap(err, "Failed to create or update cluster entity").Error(),
 		})
 		return
 	}
+	if clusterStateOld == nil || clusterStateOld.Configuration.Version != clusterStateNew.Configuration.Version {
+		o.UpdateLimiter.Record(clusterModel.RuntimeID, time.Now())
+	}
+	if err := updateConfigTemplateRef(o, clusterModel, templateOverrides); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to update configuration template reference of cluster").Error(),
+		})
+		return
+	}
+	if err := updateConfigWarnings(o, clusterModel.RuntimeID, clusterStateNew.Configuration.Version,
+		validationWarnings); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to store validation warnings of cluster").Error(),
+		})
+		return
+	}
+
+	if o.PersistPayloads {
+		//payloads are only stored to reproduce issues: failures aren't reported to KEB
+		if _, err := o.Registry.PayloadRepository().Add(clusterModel.RuntimeID,
+			clusterStateNew.Configuration.Version, contractV, payload); err != nil {
+			o.Logger().Warnf("Failed to store payload of cluster '%s' (config version: %d): %s",
+				clusterModel.RuntimeID, clusterStateNew.Configuration.Version, err)
+		}
+	}
 
 	if clusterStateOld != nil && clusterStateOld.Status.Status.IsDisabled() {
 		if clusterStateNew, err = o.Registry.Inventory().UpdateStatus(clusterStateNew, model.ClusterStatusReconcileDisabled); err != nil {
@@ -399,7 +1031,7 @@ func getClustersState(o *Options, w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		sendClusterStateResponse(w, state)
+		sendClusterStateResponse(w, state, o)
 		return
 	}
 
@@ -441,7 +1073,7 @@ func getClustersState(o *Options, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sendClusterStateResponse(w, state)
+	sendClusterStateResponse(w, state, o)
 }
 
 func getCluster(o *Options, w http.ResponseWriter, r *http.Request) {
@@ -495,9 +1127,7 @@ func updateLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 	bodyLimited := http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)
 	status, err := keb.NewModelFactory(contractV).Status(bodyLimited)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
-		})
+		sendModelFactoryError(w, err)
 		return
 	}
 
@@ -528,6 +1158,70 @@ func updateLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 	sendResponse(w, r, clusterState, o)
 }
 
+//patchCluster updates settings of a cluster which don't require a reconciliation (e.g. the deletion protection or
+//the forced takeover from another mothership)
+func patchCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	var patch keb.ClusterPatch
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)).Decode(&patch); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	if patch.DeletionProtection == nil && patch.ForceTakeover == nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: "Patch doesn't contain any setting of the cluster",
+		})
+		return
+	}
+
+	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not patch cluster").Error(),
+		})
+		return
+	}
+	if patch.DeletionProtection != nil {
+		if err := o.Registry.Inventory().SetDeletionProtection(runtimeID, *patch.DeletionProtection); err != nil {
+			sendPatchError(w, err, "Could not update deletion protection of cluster")
+			return
+		}
+		o.Logger().Infof("Deletion protection of cluster '%s' set to '%t'", runtimeID, *patch.DeletionProtection)
+	}
+	if patch.ForceTakeover != nil {
+		if err := o.Registry.Inventory().SetForceTakeover(runtimeID, *patch.ForceTakeover); err != nil {
+			sendPatchError(w, err, "Could not update forced takeover of cluster")
+			return
+		}
+		o.Logger().Infof("Forced takeover of cluster '%s' set to '%t'", runtimeID, *patch.ForceTakeover)
+	}
+
+	sendResponse(w, r, clusterState, o)
+}
+
+func sendPatchError(w http.ResponseWriter, err error, msg string) {
+	httpCode := http.StatusInternalServerError
+	if repository.IsNotFoundError(err) {
+		httpCode = http.StatusNotFound
+	}
+	server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+		Error: errors.Wrap(err, msg).Error(),
+	})
+}
+
 func getReconciliations(o *Options, w http.ResponseWriter, r *http.Request) {
 	// define variables
 	var filters []reconciliation.Filter
@@ -642,35 +1336,136 @@ func getReconciliationInfo(o *Options, w http.ResponseWriter, r *http.Request) {
 		server.SendHTTPErrorMap(w, err)
 		return
 	}
+	if !reconciliationEntity.Finished {
+		remaining, err := reconciliation.NewETAEstimator(o.Registry.ReconciliationRepository()).Estimate(operations, time.Now().UTC())
+		if err != nil {
+			server.SendHTTPErrorMap(w, err)
+			return
+		}
+		etaSecs := int64(remaining.Seconds())
+		result.EstimatedTimeRemaining = &etaSecs
+	}
+	//report the policy which is used by the bookkeeper to evaluate the reconciliation
+	if aggregationPolicy, err := service.NewAggregationPolicy(o.Config.Scheduler.Aggregation); err == nil {
+		policy := aggregationPolicy.String()
+		result.AggregationPolicy = &policy
+	}
 
 	//respond
 	w.Header().Set("content-type", "application/json")
 	if err := json.NewEncoder(w).Encode(keb.ReconciliationInfoOKResponse(result)); err != nil {
 		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode cluster list response"))
 	}
-}
+}
+
+func getLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster state").Error(),
+		})
+		return
+	}
+	sendResponse(w, r, clusterState, o)
+}
+
+func clusterTimeline(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	offset, err := params.String(paramOffset)
+	if err != nil {
+		offset = fmt.Sprintf("%dh", 24*7) //default offset is 1 week
+	}
+	duration, err := time.ParseDuration(offset)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	eventTypes, _ := params.StrSlice(paramEventType)
+	component, _ := params.String(paramComponent)
+	filter, err := newTimelineFilter(eventTypes, component)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	statusChanges, err := o.Registry.Inventory().StatusChanges(runtimeID, duration)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster statusChanges").Error(),
+		})
+		return
+	}
+
+	reconRepo := o.Registry.ReconciliationRepository()
+	recons, err := reconRepo.GetReconciliations(&reconciliation.FilterMixer{Filters: []reconciliation.Filter{
+		&reconciliation.WithRuntimeID{RuntimeID: runtimeID},
+		&reconciliation.WithCreationDateAfter{Time: time.Now().UTC().Add(-duration)},
+	}})
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve reconciliations of cluster").Error(),
+		})
+		return
+	}
 
-func getLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
-	params := server.NewParams(r)
-	runtimeID, err := params.String(paramRuntimeID)
+	var ops []*model.OperationEntity
+	for _, recon := range recons {
+		reconOps, err := reconRepo.GetOperations(&operation.WithSchedulingID{SchedulingID: recon.SchedulingID})
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrapf(err, "Could not retrieve operations of reconciliation '%s'", recon.SchedulingID).Error(),
+			})
+			return
+		}
+		ops = append(ops, reconOps...)
+	}
+
+	events, err := newTimeline(statusChanges, recons, ops, filter)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: err.Error(),
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to create timeline of cluster").Error(),
 		})
 		return
 	}
-	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
-	if err != nil {
-		httpCode := http.StatusInternalServerError
-		if repository.IsNotFoundError(err) {
-			httpCode = http.StatusNotFound
-		}
-		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Could not retrieve cluster state").Error(),
+
+	//respond
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.HTTPClusterTimelineResponse{Events: events}); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode cluster timeline response").Error(),
 		})
 		return
 	}
-	sendResponse(w, r, clusterState, o)
 }
 
 func statusChanges(o *Options, w http.ResponseWriter, r *http.Request) {
@@ -699,7 +1494,15 @@ func statusChanges(o *Options, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	statusChanges, err := o.Registry.Inventory().StatusChanges(runtimeID, duration)
+	filter, err := newStatusChangeFilter(params)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	page, err := o.Registry.Inventory().ListStatusChanges(runtimeID, duration, filter)
 	if err != nil {
 		httpCode := http.StatusInternalServerError
 		if repository.IsNotFoundError(err) {
@@ -711,8 +1514,15 @@ func statusChanges(o *Options, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := keb.HTTPClusterStatusResponse{}
-	for _, statusChange := range statusChanges {
+	resp := keb.HTTPClusterStatusResponse{
+		StatusChanges: []keb.StatusChange{},
+		Total:         int64(page.Total),
+	}
+	if page.Cursor > 0 {
+		cursor := strconv.FormatInt(page.Cursor, 10)
+		resp.Cursor = &cursor
+	}
+	for _, statusChange := range page.StatusChanges {
 		kebClusterStatus, err := statusChange.Status.GetKEBClusterStatus()
 		if err != nil {
 			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
@@ -737,6 +1547,44 @@ func statusChanges(o *Options, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+//newStatusChangeFilter creates the filter of the status changes: the page size is limited to avoid unbounded
+//responses for clusters with many status changes
+func newStatusChangeFilter(params *server.Params) (*cluster.StatusChangeFilter, error) {
+	filter := &cluster.StatusChangeFilter{
+		Limit: defaultStatusChangesLimit,
+	}
+	if statuses, err := params.StrSlice(paramStatus); err == nil {
+		for _, status := range statuses {
+			if _, err := model.NewClusterStatus(model.Status(status)); err != nil {
+				return nil, err
+			}
+			filter.Statuses = append(filter.Statuses, model.Status(status))
+		}
+	}
+	if cursor, err := params.String(paramCursor); err == nil && cursor != "" {
+		if filter.Cursor, err = strconv.ParseInt(cursor, 10, 64); err != nil || filter.Cursor < 0 {
+			return nil, fmt.Errorf("cursor '%s' is invalid", cursor)
+		}
+	}
+	if limit, err := params.Int(paramLimit); err == nil {
+		if limit <= 0 || limit > maxStatusChangesLimit {
+			return nil, fmt.Errorf("limit has to be between 1 and %d", maxStatusChangesLimit)
+		}
+		filter.Limit = limit
+	}
+	if order, err := params.String(paramOrder); err == nil {
+		switch order {
+		case orderAscending:
+			filter.Ascending = true
+		case orderDescending:
+		default:
+			return nil, fmt.Errorf("order '%s' is not supported: supported are '%s' and '%s'",
+				order, orderAscending, orderDescending)
+		}
+	}
+	return filter, nil
+}
+
 func deleteCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
 	runtimeID, err := params.String(paramRuntimeID)
@@ -746,20 +1594,91 @@ func deleteCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
-	if _, err := o.Registry.Inventory().GetLatest(runtimeID); repository.IsNotFoundError(err) {
-		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, fmt.Sprintf("Deletion impossible: Cluster '%s' not found", runtimeID)).Error(),
+	expectedConfigVersion, err := expectedConfigVersion(r)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
 		})
 		return
 	}
-	state, err := o.Registry.Inventory().MarkForDeletion(runtimeID)
+
+	ctx, cancel := deletionContext(r, o)
+	defer cancel()
+
+	//verify the expected config version and mark the cluster for deletion within one transaction
+	dbOp := func(tx *db.TxConnection) (interface{}, error) {
+		inventory, err := o.Registry.Inventory().WithTx(tx)
+		if err != nil {
+			return nil, err
+		}
+		state, err := inventory.GetLatest(runtimeID)
+		if err != nil {
+			return nil, err
+		}
+		protected, err := inventory.IsDeletionProtected(runtimeID)
+		if err != nil {
+			return nil, err
+		}
+		if protected {
+			return nil, &cluster.DeletionProtectedError{RuntimeID: runtimeID}
+		}
+		if expectedConfigVersion > 0 && state.Configuration.Version != expectedConfigVersion {
+			return nil, &configVersionConflictError{
+				runtimeID:       runtimeID,
+				expectedVersion: expectedConfigVersion,
+				currentVersion:  state.Configuration.Version,
+			}
+		}
+		//a cancelled or timed out request has to roll back: KEB retries the deletion
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Wrap(err, "deletion request was aborted")
+		}
+		return inventory.UpdateStatus(state, model.ClusterStatusDeletePending)
+	}
+	state, err := db.TransactionResultWithContext(ctx, o.Registry.Connection(), dbOp, o.Logger())
 	if err != nil {
+		if isDeletionAborted(ctx, err) {
+			server.SendHTTPError(w, http.StatusServiceUnavailable, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, fmt.Sprintf("Deletion of cluster '%s' was not accepted", runtimeID)).Error(),
+			})
+			return
+		}
+		if repository.IsNotFoundError(err) {
+			server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, fmt.Sprintf("Deletion impossible: Cluster '%s' not found", runtimeID)).Error(),
+			})
+			return
+		}
+		if cluster.IsDeletionProtectedError(err) {
+			server.SendHTTPError(w, http.StatusLocked, &keb.HTTPErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		var conflictErr *configVersionConflictError
+		if errors.As(err, &conflictErr) {
+			server.SendHTTPError(w, http.StatusConflict, &keb.HTTPErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
 		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
 			Error: errors.Wrap(err, fmt.Sprintf("Failed to delete cluster '%s'", runtimeID)).Error(),
 		})
 		return
 	}
-	sendResponse(w, r, state, o)
+	sendDeletionResponse(w, r, state.(*cluster.State), o)
+}
+
+type configVersionConflictError struct {
+	runtimeID       string
+	expectedVersion int64
+	currentVersion  int64
+}
+
+func (e *configVersionConflictError) Error() string {
+	return fmt.Sprintf("Deletion rejected: expected configuration version of cluster '%s' is %d "+
+		"but current configuration version is %d", e.runtimeID, e.expectedVersion, e.currentVersion)
 }
 
 func updateOperationStatus(o *Options, w http.ResponseWriter, r *http.Request) {
@@ -819,7 +1738,7 @@ func updateOperationStatus(o *Options, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = updateOperationState(o, schedulingID, correlationID, model.OperationStateDone, stopOperation.Reason)
+	err = updateOperationState(o, o.Registry.ReconciliationRepository(), schedulingID, correlationID, model.OperationStateDone, stopOperation.Reason)
 	if err != nil {
 		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
 			Error: errors.Wrap(err, "while updating operation status").Error(),
@@ -830,7 +1749,30 @@ func updateOperationStatus(o *Options, w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-func operationCallback(o *Options, w http.ResponseWriter, r *http.Request) {
+func operationCallback(ignoredCallbacksMetric *metrics.IgnoredCallbacksMetric) func(o *Options, w http.ResponseWriter, r *http.Request) {
+	return func(o *Options, w http.ResponseWriter, r *http.Request) {
+		processSingleOperationCallback(o, ignoredCallbacksMetric, w, r)
+	}
+}
+
+//checkCallbackPayloadVersion rejects callbacks which declare a payload version the mothership can't process:
+//callbacks without declared version are processed as the current version
+func checkCallbackPayloadVersion(w http.ResponseWriter, r *http.Request) bool {
+	declared := r.Header.Get(client.PayloadVersionHeader)
+	if declared == "" || declared == client.PayloadVersion {
+		return true
+	}
+	server.SendHTTPError(w, http.StatusUnprocessableEntity, &reconciler.HTTPErrorResponse{
+		Error: fmt.Sprintf("callback payload version '%s' is not supported (supported: %s)",
+			declared, client.PayloadVersion),
+	})
+	return false
+}
+
+func processSingleOperationCallback(o *Options, ignoredCallbacksMetric *metrics.IgnoredCallbacksMetric, w http.ResponseWriter, r *http.Request) {
+	if !checkCallbackPayloadVersion(w, r) {
+		return
+	}
 	params := server.NewParams(r)
 	schedulingID, err := params.String(paramSchedulingID)
 	if err != nil {
@@ -865,37 +1807,254 @@ func operationCallback(o *Options, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if body.Manifest != nil {
-		logger.NewLogger(true).Debugf("Dry run (correlationID: %s)\n, %s", *body.Manifest)
+	if httpCode, err := processOperationCallback(o, ignoredCallbacksMetric, schedulingID, correlationID, &body); err != nil {
+		server.SendHTTPError(w, httpCode, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+	}
+}
+
+//operationCallbacks processes a batch of callbacks: the result of each callback is reported in the response
+func operationCallbacks(ignoredCallbacksMetric *metrics.IgnoredCallbacksMetric) func(o *Options, w http.ResponseWriter, r *http.Request) {
+	return func(o *Options, w http.ResponseWriter, r *http.Request) {
+		processBatchedOperationCallbacks(o, ignoredCallbacksMetric, w, r)
 	}
+}
 
-	if body.Status == "" {
+func processBatchedOperationCallbacks(o *Options, ignoredCallbacksMetric *metrics.IgnoredCallbacksMetric, w http.ResponseWriter, r *http.Request) {
+	if !checkCallbackPayloadVersion(w, r) {
+		return
+	}
+	var batch reconciler.CallbackBatch
+	bodyLimited := http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)
+	reqBody, err := ioutil.ReadAll(bodyLimited)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	if err := json.Unmarshal(reqBody, &batch); err != nil {
 		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
-			Error: fmt.Errorf("status not provided in payload").Error(),
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
 		})
 		return
 	}
 
-	switch body.Status {
-	case reconciler.StatusNotstarted, reconciler.StatusRunning:
-		err = updateOperationStateAndRetryID(o, schedulingID, correlationID, body.RetryID, model.OperationStateInProgress)
-	case reconciler.StatusFailed:
-		err = updateOperationStateAndRetryID(o, schedulingID, correlationID, body.RetryID, model.OperationStateFailed, body.Error)
-	case reconciler.StatusSuccess:
-		err = updateOperationStateAndRetryIDAndProcessingDuration(o, schedulingID, correlationID, body.RetryID, model.OperationStateDone, body.ProcessingDuration)
-	case reconciler.StatusError:
-		err = updateOperationStateAndRetryIDAndProcessingDuration(o, schedulingID, correlationID, body.RetryID, model.OperationStateError, body.ProcessingDuration, body.Error)
+	resp := reconciler.CallbackBatchResponse{Results: []reconciler.CallbackResult{}}
+	for i := range batch.Callbacks {
+		callback := batch.Callbacks[i]
+		result := reconciler.CallbackResult{
+			CorrelationID: callback.CorrelationID,
+			SchedulingID:  callback.SchedulingID,
+			StatusCode:    http.StatusOK,
+		}
+		if httpCode, err := processOperationCallback(o, ignoredCallbacksMetric, callback.SchedulingID, callback.CorrelationID, &callback.Message); err != nil {
+			errMsg := err.Error()
+			result.StatusCode = httpCode
+			result.Error = &errMsg
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode callback batch response").Error(),
+		})
+	}
+}
+
+//processOperationCallback applies the status of a callback to the operation and returns the HTTP status code
+//which reflects the result. Duplicated or outdated callbacks (e.g. retries which arrive after a newer callback) and
+//callbacks which would change the final state of an operation are acknowledged but ignored.
+func processOperationCallback(o *Options, ignoredCallbacksMetric *metrics.IgnoredCallbacksMetric,
+	schedulingID, correlationID string, body *reconciler.CallbackMessage) (int, error) {
+	//component reconcilers of older versions don't redact their error messages
+	body.Error = redact.String(body.Error)
+
+	if body.Manifest != nil {
+		logger.NewLogger(true).Debugf("Dry run (correlationID: %s)\n, %s", *body.Manifest)
+	}
+
+	if body.Status == "" {
+		return http.StatusBadRequest, fmt.Errorf("status not provided in payload")
+	}
+
+	op, ignored, err := ignoreOperationCallback(o, ignoredCallbacksMetric, schedulingID, correlationID, body)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		} else if errors.Is(err, errOperationAborted) { //the component reconciler has to stop the operation
+			httpCode = http.StatusGone
+		}
+		return httpCode, err
+	}
+	if ignored {
+		return http.StatusOK, nil
+	}
+
+	start := time.Now()
+	var stale bool
+	dbOps := func(tx *db.TxConnection) error {
+		reconRepo, err := o.Registry.ReconciliationRepository().WithTx(tx)
+		if err != nil {
+			return err
+		}
+		//the sequence is stored together with the status: a callback whose status update failed is processed
+		//again when it gets retried (component reconcilers of older versions don't send a sequence)
+		if body.Sequence != nil {
+			updated, err := reconRepo.UpdateOperationCallbackSequence(schedulingID, correlationID, *body.Sequence)
+			if err != nil {
+				return err
+			}
+			if !updated { //a newer callback was processed in the meantime
+				stale = true
+				return nil
+			}
+		}
+		return updateOperationFromCallback(o, reconRepo, schedulingID, correlationID, body)
+	}
+	err = db.Transaction(o.Registry.Connection(), dbOps, o.Logger())
+	if !repository.IsNotFoundError(err) { //status writes are the most frequent database operations of the mothership
+		o.Backpressure.Observe(time.Since(start), err)
 	}
 	if err != nil {
 		httpCode := http.StatusBadRequest
 		if repository.IsNotFoundError(err) {
 			httpCode = http.StatusNotFound
 		}
-		server.SendHTTPError(w, httpCode, &reconciler.HTTPErrorResponse{
-			Error: err.Error(),
-		})
-		return
+		return httpCode, err
+	}
+	if stale {
+		exposeIgnoredCallback(o, ignoredCallbacksMetric, op, body, metrics.CallbackIgnoredStale)
+		return http.StatusOK, nil
+	}
+	//component reconcilers of older versions don't report images: failures don't fail the callback because
+	//the operation is already finished and a repeated callback would be ignored
+	if body.Images != nil && body.Status == reconciler.StatusSuccess {
+		if imagesErr := updateComponentImages(o, schedulingID, correlationID, *body.Images); imagesErr != nil {
+			o.Logger().Errorf("REST endpoint failed to update images of operation (schedulingID:%s/correlationID:%s): %s",
+				schedulingID, correlationID, imagesErr)
+		}
+	}
+	//slow operations are reported with their trace: failures don't fail the callback because the operation is
+	//already finished and a repeated callback would be ignored
+	if body.Trace != nil && (body.Status == reconciler.StatusSuccess || body.Status == reconciler.StatusError) {
+		if traceErr := updateOperationTrace(o, schedulingID, correlationID, body.Trace); traceErr != nil {
+			o.Logger().Errorf("REST endpoint failed to update trace of operation (schedulingID:%s/correlationID:%s): %s",
+				schedulingID, correlationID, traceErr)
+		}
+	}
+	//the latest logs are reported with the final status (same as for traces, failures don't fail the callback)
+	if body.Logs != nil && (body.Status == reconciler.StatusSuccess || body.Status == reconciler.StatusError) {
+		if logsErr := updateOperationLogs(o, schedulingID, correlationID, body.Logs); logsErr != nil {
+			o.Logger().Errorf("REST endpoint failed to update logs of operation (schedulingID:%s/correlationID:%s): %s",
+				schedulingID, correlationID, logsErr)
+		}
+	}
+	return http.StatusOK, nil
+}
+
+//errOperationAborted is returned for callbacks of operations whose reconciliation was cancelled
+var errOperationAborted = errors.New("operation was aborted because its reconciliation was cancelled")
+
+//ignoreOperationCallback verifies whether a callback is outdated or would regress the final state of the operation
+func ignoreOperationCallback(o *Options, ignoredCallbacksMetric *metrics.IgnoredCallbacksMetric,
+	schedulingID, correlationID string, body *reconciler.CallbackMessage) (*model.OperationEntity, bool, error) {
+	op, err := o.Registry.ReconciliationRepository().GetOperation(schedulingID, correlationID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var reason string
+	switch {
+	case op.State == model.OperationStateAborted:
+		reason = metrics.CallbackIgnoredAborted
+	case body.Sequence != nil && *body.Sequence == op.CallbackSequence:
+		reason = metrics.CallbackIgnoredDuplicate
+	case body.Sequence != nil && *body.Sequence < op.CallbackSequence:
+		reason = metrics.CallbackIgnoredStale
+	case op.State.IsFinal() && op.State == callbackOperationState(body.Status):
+		reason = metrics.CallbackIgnoredDuplicate
+	case op.State.IsFinal():
+		reason = metrics.CallbackIgnoredTerminalState
+	}
+
+	//while the database is slow or failing, unchanged interim statuses of running operations are written less often
+	if reason == "" && op.State == model.OperationStateInProgress && op.RetryID == body.RetryID && body.Warning == nil &&
+		(body.Status == reconciler.StatusRunning || body.Status == reconciler.StatusNotstarted) &&
+		o.Backpressure.SkipStatusWrite(op.Updated, time.Now()) {
+		reason = metrics.CallbackIgnoredThrottled
+	}
+
+	if reason == "" {
+		return op, false, nil
+	}
+	exposeIgnoredCallback(o, ignoredCallbacksMetric, op, body, reason)
+	if reason == metrics.CallbackIgnoredAborted {
+		return op, true, errOperationAborted
+	}
+	return op, true, nil
+}
+
+//exposeIgnoredCallback logs and counts a callback which isn't applied to the operation
+func exposeIgnoredCallback(o *Options, ignoredCallbacksMetric *metrics.IgnoredCallbacksMetric,
+	op *model.OperationEntity, body *reconciler.CallbackMessage, reason string) {
+	o.Logger().Infof("REST endpoint ignores callback with status '%s' for operation (schedulingID:%s/correlationID:%s) "+
+		"in state '%s': callback is %s", body.Status, op.SchedulingID, op.CorrelationID, op.State, strings.ReplaceAll(reason, "_", " "))
+	ignoredCallbacksMetric.ExposeIgnoredCallback(op.Component, reason)
+}
+
+//updateOperationFromCallback stores the status of the callback in the operation
+func updateOperationFromCallback(o *Options, reconRepo reconciliation.Repository, schedulingID, correlationID string,
+	body *reconciler.CallbackMessage) error {
+	var err error
+	switch body.Status {
+	case reconciler.StatusNotstarted, reconciler.StatusRunning:
+		if body.Warning != nil { //early signal of the running attempt (e.g. upcoming progress timeout) becomes the reason of the operation
+			o.Logger().Warnf("REST endpoint received warning for operation (schedulingID:%s/correlationID:%s): %s",
+				schedulingID, correlationID, *body.Warning)
+			err = updateOperationStateAndRetryID(o, reconRepo, schedulingID, correlationID, body.RetryID, model.OperationStateInProgress, *body.Warning)
+		} else {
+			err = updateOperationStateAndRetryID(o, reconRepo, schedulingID, correlationID, body.RetryID, model.OperationStateInProgress)
+		}
+	case reconciler.StatusFailed:
+		err = updateOperationStateAndRetryID(o, reconRepo, schedulingID, correlationID, body.RetryID, model.OperationStateFailed, body.Error)
+	case reconciler.StatusSuccess:
+		err = updateOperationStateAndRetryIDAndProcessingDuration(o, reconRepo, schedulingID, correlationID, body.RetryID, model.OperationStateDone, body.ProcessingDuration, body.ReconcilerVersion, body.Usage, body.TimedOut)
+	case reconciler.StatusError:
+		err = updateOperationStateAndRetryIDAndProcessingDuration(o, reconRepo, schedulingID, correlationID, body.RetryID, model.OperationStateError, body.ProcessingDuration, body.ReconcilerVersion, body.Usage, body.TimedOut, body.Error)
+	case reconciler.StatusSkipped: //the error field contains the reason why the component was skipped
+		err = updateOperationStateAndRetryIDAndProcessingDuration(o, reconRepo, schedulingID, correlationID, body.RetryID, model.OperationStateSkipped, body.ProcessingDuration, body.ReconcilerVersion, body.Usage, body.TimedOut, body.Error)
+	case reconciler.StatusWaiting: //the error field contains the holder of the conflicting lease
+		err = updateOperationStateAndRetryID(o, reconRepo, schedulingID, correlationID, body.RetryID, model.OperationStateWaiting, body.Error)
+	case reconciler.StatusPendingConfirmation: //the deletion proceeds after an operator confirmed it
+		err = updateOperationPendingDeletion(o, reconRepo, schedulingID, correlationID, body)
 	}
+	return err
+}
+
+//callbackOperationState returns the operation state which corresponds to the status of a callback
+func callbackOperationState(status reconciler.Status) model.OperationState {
+	switch status {
+	case reconciler.StatusNotstarted, reconciler.StatusRunning:
+		return model.OperationStateInProgress
+	case reconciler.StatusFailed:
+		return model.OperationStateFailed
+	case reconciler.StatusSuccess:
+		return model.OperationStateDone
+	case reconciler.StatusError:
+		return model.OperationStateError
+	case reconciler.StatusSkipped:
+		return model.OperationStateSkipped
+	case reconciler.StatusWaiting:
+		return model.OperationStateWaiting
+	case reconciler.StatusPendingConfirmation:
+		return model.OperationStatePendingConfirmation
+	}
+	return ""
 }
 
 func getKymaConfig(o *Options, w http.ResponseWriter, r *http.Request) {
@@ -999,8 +2158,8 @@ func deleteComponentWorkerPoolOccupancy(o *Options, w http.ResponseWriter, r *ht
 	w.WriteHeader(http.StatusOK)
 }
 
-func updateOperationState(o *Options, schedulingID, correlationID string, state model.OperationState, reason ...string) error {
-	err := o.Registry.ReconciliationRepository().UpdateOperationState(schedulingID, correlationID, state, true, strings.Join(reason, ", "))
+func updateOperationState(o *Options, reconRepo reconciliation.Repository, schedulingID, correlationID string, state model.OperationState, reason ...string) error {
+	err := reconRepo.UpdateOperationState(schedulingID, correlationID, state, true, strings.Join(reason, ", "))
 	if err != nil {
 		o.Logger().Errorf("REST endpoint failed to update operation (schedulingID:%s/correlationID:%s) "+
 			"to state '%s': %s", schedulingID, correlationID, state, err)
@@ -1008,12 +2167,12 @@ func updateOperationState(o *Options, schedulingID, correlationID string, state
 	return err
 }
 
-func updateOperationStateAndRetryID(o *Options, schedulingID, correlationID, retryID string, state model.OperationState, reason ...string) error {
-	err := updateOperationState(o, schedulingID, correlationID, state, reason...)
+func updateOperationStateAndRetryID(o *Options, reconRepo reconciliation.Repository, schedulingID, correlationID, retryID string, state model.OperationState, reason ...string) error {
+	err := updateOperationState(o, reconRepo, schedulingID, correlationID, state, reason...)
 	if err != nil {
 		return err
 	}
-	err = o.Registry.ReconciliationRepository().UpdateOperationRetryID(schedulingID, correlationID, retryID)
+	err = reconRepo.UpdateOperationRetryID(schedulingID, correlationID, retryID)
 	if err != nil {
 		o.Logger().Errorf("REST endpoint failed to update operation (schedulingID:%s/correlationID:%s) "+
 			"retryID '%s': %s", schedulingID, correlationID, retryID, err)
@@ -1021,35 +2180,55 @@ func updateOperationStateAndRetryID(o *Options, schedulingID, correlationID, ret
 	return err
 }
 
-func updateOperationStateAndRetryIDAndProcessingDuration(o *Options, schedulingID, correlationID, retryID string, state model.OperationState, processingDuration int, reason ...string) error {
-	dbOps := func(tx *db.TxConnection) error {
-		rTx, err := o.Registry.ReconciliationRepository().WithTx(tx)
-		if err != nil {
-			return err
-		}
+func updateOperationStateAndRetryIDAndProcessingDuration(o *Options, reconRepo reconciliation.Repository, schedulingID, correlationID, retryID string, state model.OperationState, processingDuration int, reconcilerVersion *string, usage *reconciler.OperationUsage, timedOut *bool, reason ...string) error {
+	err := reconRepo.UpdateOperationRetryID(schedulingID, correlationID, retryID)
+	if err != nil {
+		o.Logger().Errorf("REST endpoint failed to update operation (schedulingID:%s/correlationID:%s) "+
+			"retryID '%s': %s", schedulingID, correlationID, retryID, err)
+		return err
+	}
+
+	err = reconRepo.UpdateOperationState(schedulingID, correlationID, state, true, strings.Join(reason, ", "))
+	if err != nil {
+		o.Logger().Errorf("REST endpoint failed to update operation (schedulingID:%s/correlationID:%s) "+
+			"to state '%s': %s", schedulingID, correlationID, state, err)
+		return err
+	}
 
-		err = rTx.UpdateOperationRetryID(schedulingID, correlationID, retryID)
+	err = reconRepo.UpdateComponentOperationProcessingDuration(schedulingID, correlationID, processingDuration)
+	if err != nil {
+		o.Logger().Errorf("REST endpoint failed to update operation processingDuration (schedulingID:%s/correlationID:%s) "+
+			"to '%s': %s", schedulingID, correlationID, state, err)
+		return err
+	}
+
+	//component reconcilers of older versions don't report their build
+	if reconcilerVersion != nil && *reconcilerVersion != "" {
+		err = reconRepo.UpdateOperationReconcilerVersion(schedulingID, correlationID, *reconcilerVersion)
 		if err != nil {
-			o.Logger().Errorf("REST endpoint failed to update operation (schedulingID:%s/correlationID:%s) "+
-				"retryID '%s': %s", schedulingID, correlationID, retryID, err)
+			o.Logger().Errorf("REST endpoint failed to update operation reconcilerVersion (schedulingID:%s/correlationID:%s) "+
+				"to '%s': %s", schedulingID, correlationID, *reconcilerVersion, err)
 			return err
 		}
+	}
 
-		err = rTx.UpdateOperationState(schedulingID, correlationID, state, true, strings.Join(reason, ", "))
+	//component reconcilers of older versions don't report their usage
+	if usage != nil {
+		err = reconRepo.UpdateOperationUsage(schedulingID, correlationID, usage.ApiCalls, usage.ManifestBytes)
 		if err != nil {
-			o.Logger().Errorf("REST endpoint failed to update operation (schedulingID:%s/correlationID:%s) "+
-				"to state '%s': %s", schedulingID, correlationID, state, err)
+			o.Logger().Errorf("REST endpoint failed to update operation usage (schedulingID:%s/correlationID:%s): %s",
+				schedulingID, correlationID, err)
 			return err
 		}
+	}
 
-		err = rTx.UpdateComponentOperationProcessingDuration(schedulingID, correlationID, processingDuration)
-		if err != nil {
-			o.Logger().Errorf("REST endpoint failed to update operation processingDuration (schedulingID:%s/correlationID:%s) "+
-				"to '%s': %s", schedulingID, correlationID, state, err)
-		}
-		return err
+	//the flag of a previous attempt is reset (component reconcilers of older versions don't report timeouts)
+	err = reconRepo.UpdateOperationTimedOut(schedulingID, correlationID, timedOut != nil && *timedOut)
+	if err != nil {
+		o.Logger().Errorf("REST endpoint failed to update operation timeout flag (schedulingID:%s/correlationID:%s): %s",
+			schedulingID, correlationID, err)
 	}
-	return db.Transaction(o.Registry.Connection(), dbOps, o.Logger())
+	return err
 }
 
 func getOperationStatus(o *Options, schedulingID, correlationID string) (*model.OperationEntity, error) {
@@ -1070,6 +2249,8 @@ func sendResponse(w http.ResponseWriter, r *http.Request, clusterState *cluster.
 	}
 
 	w.Header().Set("content-type", "application/json")
+	//the config version can be used as precondition when deleting the cluster (If-Match header)
+	w.Header().Set("ETag", fmt.Sprintf(`"%d"`, respModel.ConfigurationVersion))
 	if err := json.NewEncoder(w).Encode(respModel); err != nil {
 		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
 			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
@@ -1077,7 +2258,7 @@ func sendResponse(w http.ResponseWriter, r *http.Request, clusterState *cluster.
 	}
 }
 
-func sendClusterStateResponse(w http.ResponseWriter, state *cluster.State) {
+func sendClusterStateResponse(w http.ResponseWriter, state *cluster.State, o *Options) {
 	respModel, err := newClusterStateResponse(state)
 	if err != nil {
 		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
@@ -1085,6 +2266,9 @@ func sendClusterStateResponse(w http.ResponseWriter, state *cluster.State) {
 		})
 		return
 	}
+	if clusterCohort := o.Cohorts.Resolve(state.Cluster.Metadata); clusterCohort != nil {
+		respModel.Cluster.Cohort = &clusterCohort.Name
+	}
 
 	w.Header().Set("content-type", "application/json")
 	if err := json.NewEncoder(w).Encode(respModel); err != nil {
@@ -1102,8 +2286,11 @@ func newClusterResponse(r *http.Request, clusterState *cluster.State, o *Options
 	}
 
 	var failures []keb.Failure
+	var skipped []keb.SkippedComponent
+	var eta *int64
 	if clusterState.Status.Status == model.ClusterStatusReconcileError || clusterState.Status.Status == model.ClusterStatusDeleteError ||
-		clusterState.Status.Status == model.ClusterStatusReconciling || clusterState.Status.Status == model.ClusterStatusDeleting {
+		clusterState.Status.Status == model.ClusterStatusReconciling || clusterState.Status.Status == model.ClusterStatusDeleting ||
+		clusterState.Status.Status == model.ClusterStatusReady {
 		reconciliations, err := reconciliationRepository.GetReconciliations(&reconciliation.WithClusterConfigStatus{ClusterConfigStatus: clusterState.Status.ID})
 		if err != nil {
 			return nil, err
@@ -1116,6 +2303,15 @@ func newClusterResponse(r *http.Request, clusterState *cluster.State, o *Options
 				return nil, err
 			}
 
+			if clusterState.Status.Status.IsInProgress() {
+				remaining, err := reconciliation.NewETAEstimator(reconciliationRepository).Estimate(operations, time.Now().UTC())
+				if err != nil {
+					return nil, err
+				}
+				etaSecs := int64(remaining.Seconds())
+				eta = &etaSecs
+			}
+
 			for _, operation := range operations {
 				if operation.State.IsError() {
 					failures = append(failures, keb.Failure{
@@ -1123,16 +2319,53 @@ func newClusterResponse(r *http.Request, clusterState *cluster.State, o *Options
 						Reason:    operation.Reason,
 					})
 				}
+				if operation.State == model.OperationStateSkipped {
+					skipped = append(skipped, keb.SkippedComponent{
+						Component: operation.Component,
+						Reason:    operation.Reason,
+					})
+				}
 			}
 		}
 	}
 
+	history, err := o.Registry.Inventory().StatusHistory(clusterState.Cluster.RuntimeID, conditionsHistory)
+	if err != nil {
+		return nil, err
+	}
+	conditions := newConditions(clusterState, history, failures)
+
+	deletionProtection, err := o.Registry.Inventory().IsDeletionProtected(clusterState.Cluster.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings *[]string
+	configWarnings, err := o.Registry.Inventory().GetConfigWarnings(clusterState.Configuration.Version)
+	if err != nil {
+		return nil, err
+	}
+	if len(configWarnings) > 0 {
+		warnings = &configWarnings
+	}
+
+	configHash, err := clusterState.Configuration.Hash()
+	if err != nil {
+		return nil, err
+	}
+
 	return &keb.HTTPClusterResponse{
-		Cluster:              clusterState.Cluster.RuntimeID,
-		ClusterVersion:       clusterState.Cluster.Version,
-		ConfigurationVersion: clusterState.Configuration.Version,
-		Status:               kebStatus,
-		Failures:             &failures,
+		Cluster:                clusterState.Cluster.RuntimeID,
+		ClusterVersion:         clusterState.Cluster.Version,
+		ConfigurationHash:      &configHash,
+		ConfigurationVersion:   clusterState.Configuration.Version,
+		DeletionProtection:     &deletionProtection,
+		Status:                 kebStatus,
+		EstimatedTimeRemaining: eta,
+		Conditions:             &conditions,
+		Failures:               &failures,
+		Skipped:                &skipped,
+		Warnings:               warnings,
 		StatusURL: (&url.URL{
 			Scheme: o.Config.Scheme,
 			Host:   fmt.Sprintf("%s:%d", o.Config.Host, o.Config.Port),
@@ -1151,6 +2384,7 @@ func newClusterStateResponse(state *cluster.State) (*keb.HTTPClusterStateRespons
 		metadata = keb.Metadata{
 			GlobalAccountID: state.Cluster.Metadata.GlobalAccountID,
 			InstanceID:      state.Cluster.Metadata.InstanceID,
+			Labels:          state.Cluster.Metadata.Labels,
 			Region:          state.Cluster.Metadata.Region,
 			ServiceID:       state.Cluster.Metadata.ServiceID,
 			ServicePlanID:   state.Cluster.Metadata.ServicePlanID,
@@ -1181,11 +2415,12 @@ func newClusterStateResponse(state *cluster.State) (*keb.HTTPClusterStateRespons
 		}
 
 		components = append(components, keb.Component{
-			URL:           comp.URL,
-			Component:     comp.Component,
-			Configuration: configs,
-			Namespace:     comp.Namespace,
-			Version:       comp.Version,
+			URL:            comp.URL,
+			Component:      comp.Component,
+			Configuration:  configs,
+			ExecutionHints: comp.ExecutionHints,
+			Namespace:      comp.Namespace,
+			Version:        comp.Version,
 		})
 	}
 
@@ -1194,6 +2429,11 @@ func newClusterStateResponse(state *cluster.State) (*keb.HTTPClusterStateRespons
 		return nil, err
 	}
 
+	configHash, err := state.Configuration.Hash()
+	if err != nil {
+		return nil, err
+	}
+
 	return &keb.HTTPClusterStateResponse{
 		Cluster: keb.ClusterState{
 			Contract:  &state.Cluster.Contract,
@@ -1210,6 +2450,7 @@ func newClusterStateResponse(state *cluster.State) (*keb.HTTPClusterStateRespons
 			Contract:       &state.Configuration.Contract,
 			Created:        &state.Configuration.Created,
 			Deleted:        &state.Configuration.Deleted,
+			Hash:           &configHash,
 			KymaProfile:    &state.Configuration.KymaProfile,
 			KymaVersion:    &state.Configuration.KymaVersion,
 			RuntimeID:      &state.Configuration.RuntimeID,
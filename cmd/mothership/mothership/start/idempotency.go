@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	headerIdempotencyKey      = "Idempotency-Key"
+	headerIdempotentReplayed  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyRetryAfterSecs = "1"
+)
+
+//idempotencyStore persists the responses per idempotency key (implemented by the idempotency repository)
+type idempotencyStore interface {
+	Reserve(key, fingerprint string) (*model.IdempotencyKeyEntity, bool, error)
+	Complete(key string, statusCode int, response []byte) error
+	Release(key string) error
+}
+
+//withIdempotencyKey processes a cluster mutation only once per 'Idempotency-Key' header: retries of a
+//successful request get the stored response (e.g. if the response of the first request was lost)
+func withIdempotencyKey(handler func(o *Options, w http.ResponseWriter, r *http.Request)) func(o *Options, w http.ResponseWriter, r *http.Request) {
+	return func(o *Options, w http.ResponseWriter, r *http.Request) {
+		serveIdempotent(o.Registry.IdempotencyRepository(), o.Logger(), w, r, func(w http.ResponseWriter, r *http.Request) {
+			handler(o, w, r)
+		})
+	}
+}
+
+func serveIdempotent(store idempotencyStore, logger *zap.SugaredLogger, w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	key := r.Header.Get(headerIdempotencyKey)
+	if key == "" {
+		next(w, r)
+		return
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("%s header exceeds the max. length of %d characters", headerIdempotencyKey, maxIdempotencyKeyLength),
+		})
+		return
+	}
+	fingerprint, err := requestFingerprint(r)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+
+	entity, reserved, err := store.Reserve(key, fingerprint)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrapf(err, "Failed to reserve idempotency key '%s'", key).Error(),
+		})
+		return
+	}
+	if !reserved {
+		replayIdempotent(w, entity, fingerprint)
+		return
+	}
+
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		//failed requests aren't stored: a retry with the same key is processed again (the release is deferred to
+		//run also if the handler panics, the panic is passed on to the recovery middleware)
+		if err := store.Release(key); err != nil {
+			logger.Warnf("Failed to release idempotency key '%s': %s", key, err)
+		}
+	}()
+	recorder := &idempotencyWriter{ResponseWriter: w}
+	next(recorder, r)
+	if recorder.status >= 200 && recorder.status < 300 {
+		//the request was processed: if the response can't be stored, retries are rejected until the reservation expires
+		completed = true
+		if err := store.Complete(key, recorder.status, recorder.body.Bytes()); err != nil {
+			logger.Warnf("Failed to store response for idempotency key '%s': %s", key, err)
+		}
+	}
+}
+
+//replayIdempotent answers a request whose idempotency key was already used
+func replayIdempotent(w http.ResponseWriter, entity *model.IdempotencyKeyEntity, fingerprint string) {
+	code := keb.ErrorCodeIdempotencyKeyReused
+	if entity.Fingerprint != fingerprint {
+		server.SendHTTPError(w, http.StatusUnprocessableEntity, &keb.HTTPErrorResponse{
+			Code:  &code,
+			Error: fmt.Sprintf("%s '%s' was already used by a different request", headerIdempotencyKey, entity.Key),
+		})
+		return
+	}
+	if !entity.Completed() {
+		code = keb.ErrorCodeIdempotencyKeyInProgress
+		w.Header().Set("Retry-After", idempotencyRetryAfterSecs)
+		server.SendHTTPError(w, http.StatusConflict, &keb.HTTPErrorResponse{
+			Code:  &code,
+			Error: fmt.Sprintf("Request with %s '%s' is still processed", headerIdempotencyKey, entity.Key),
+		})
+		return
+	}
+	w.Header().Set("content-type", "application/json")
+	w.Header().Set(headerIdempotentReplayed, "true")
+	w.WriteHeader(int(entity.StatusCode))
+	_, _ = w.Write([]byte(entity.Response))
+}
+
+//requestFingerprint hashes the method, path and payload of the request (the body stays readable for the handler)
+func requestFingerprint(r *http.Request) (string, error) {
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s %s\n", r.Method, r.URL.Path)
+	if r.Body != nil {
+		payload, err := ioutil.ReadAll(io.LimitReader(r.Body, bodyRequestLimitBytes))
+		if err != nil {
+			return "", err
+		}
+		//the remaining body (if any) is kept to let the handler detect payloads which exceed the limit
+		r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(payload), r.Body))
+		_, _ = hash.Write(payload)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+//idempotencyWriter records the response while it's sent to the client
+type idempotencyWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *idempotencyWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+//Flush sends buffered data to the client if the underlying writer supports it
+func (w *idempotencyWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
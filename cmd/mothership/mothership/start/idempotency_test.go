@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+type testIdempotencyStore struct {
+	sync.Mutex
+	entities map[string]*model.IdempotencyKeyEntity
+}
+
+func (s *testIdempotencyStore) Reserve(key, fingerprint string) (*model.IdempotencyKeyEntity, bool, error) {
+	s.Lock()
+	defer s.Unlock()
+	if entity, ok := s.entities[key]; ok {
+		return entity, false, nil
+	}
+	s.entities[key] = &model.IdempotencyKeyEntity{Key: key, Fingerprint: fingerprint}
+	return s.entities[key], true, nil
+}
+
+func (s *testIdempotencyStore) Complete(key string, statusCode int, response []byte) error {
+	s.Lock()
+	defer s.Unlock()
+	s.entities[key].StatusCode = int64(statusCode)
+	s.entities[key].Response = string(response)
+	return nil
+}
+
+func (s *testIdempotencyStore) Release(key string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.entities, key)
+	return nil
+}
+
+func TestServeIdempotent(t *testing.T) {
+	store := &testIdempotencyStore{entities: make(map[string]*model.IdempotencyKeyEntity)}
+	var configVersion int64
+	var failing bool
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		_, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err, "handler has to receive the payload")
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		configVersion++
+		w.Header().Set("content-type", "application/json")
+		_ = json.NewEncoder(w).Encode(&keb.HTTPClusterResponse{ConfigurationVersion: configVersion})
+	}
+	serve := func(key, payload string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/v1/clusters", strings.NewReader(payload))
+		if key != "" {
+			req.Header.Set(headerIdempotencyKey, key)
+		}
+		recorder := httptest.NewRecorder()
+		serveIdempotent(store, logger.NewLogger(true), recorder, req, handler)
+		return recorder
+	}
+	decodeConfigVersion := func(t *testing.T, recorder *httptest.ResponseRecorder) int64 {
+		resp := &keb.HTTPClusterResponse{}
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(resp))
+		return resp.ConfigurationVersion
+	}
+
+	t.Run("Requests without key are always processed", func(t *testing.T) {
+		require.Equal(t, int64(1), decodeConfigVersion(t, serve("", `{}`)))
+		require.Equal(t, int64(2), decodeConfigVersion(t, serve("", `{}`)))
+	})
+
+	t.Run("Retries get the stored response", func(t *testing.T) {
+		first := serve("key1", `{"runtimeID":"abc"}`)
+		require.Equal(t, http.StatusOK, first.Code)
+		require.Empty(t, first.Header().Get(headerIdempotentReplayed))
+		require.Equal(t, int64(3), decodeConfigVersion(t, first))
+
+		retry := serve("key1", `{"runtimeID":"abc"}`)
+		require.Equal(t, http.StatusOK, retry.Code)
+		require.Equal(t, "true", retry.Header().Get(headerIdempotentReplayed))
+		require.Equal(t, int64(3), decodeConfigVersion(t, retry), "no new configuration version is created")
+	})
+
+	t.Run("Key reused for a different payload", func(t *testing.T) {
+		recorder := serve("key1", `{"runtimeID":"xyz"}`)
+		require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		require.Contains(t, recorder.Body.String(), fmt.Sprintf(`"code":"%s"`, keb.ErrorCodeIdempotencyKeyReused))
+	})
+
+	t.Run("Key of a request in progress", func(t *testing.T) {
+		_, reserved, err := store.Reserve("key2", "")
+		require.NoError(t, err)
+		require.True(t, reserved)
+		store.entities["key2"].Fingerprint, err = requestFingerprint(
+			httptest.NewRequest(http.MethodPut, "/v1/clusters", strings.NewReader(`{}`)))
+		require.NoError(t, err)
+
+		recorder := serve("key2", `{}`)
+		require.Equal(t, http.StatusConflict, recorder.Code)
+		require.NotEmpty(t, recorder.Header().Get("Retry-After"))
+		require.Contains(t, recorder.Body.String(), fmt.Sprintf(`"code":"%s"`, keb.ErrorCodeIdempotencyKeyInProgress))
+	})
+
+	t.Run("Failed requests are processed again", func(t *testing.T) {
+		failing = true
+		require.Equal(t, http.StatusInternalServerError, serve("key3", `{}`).Code)
+		failing = false
+		recorder := serve("key3", `{}`)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Empty(t, recorder.Header().Get(headerIdempotentReplayed))
+	})
+
+	t.Run("Key exceeding max. length", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, serve(strings.Repeat("a", maxIdempotencyKeyLength+1), `{}`).Code)
+	})
+
+	t.Run("Key is released if the handler panics", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/clusters", strings.NewReader(`{}`))
+		req.Header.Set(headerIdempotencyKey, "key4")
+		require.Panics(t, func() {
+			serveIdempotent(store, logger.NewLogger(true), httptest.NewRecorder(), req,
+				func(w http.ResponseWriter, r *http.Request) {
+					panic("handler failed")
+				})
+		})
+		store.Lock()
+		defer store.Unlock()
+		require.NotContains(t, store.entities, "key4")
+	})
+
+	t.Run("Responses can be flushed", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		var writer http.ResponseWriter = &idempotencyWriter{ResponseWriter: recorder}
+		flusher, ok := writer.(http.Flusher)
+		require.True(t, ok)
+		flusher.Flush()
+		require.True(t, recorder.Flushed)
+	})
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//clusterImages returns the container images running for the components of a cluster (bill of materials)
+func clusterImages(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if _, err := o.Registry.Inventory().GetLatest(runtimeID); err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster").Error(),
+		})
+		return
+	}
+
+	componentImages, err := o.Registry.Inventory().GetComponentImages(runtimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve images of cluster").Error(),
+		})
+		return
+	}
+
+	resp := keb.HTTPClusterImagesResponse{
+		Cluster:    runtimeID,
+		Components: []keb.ComponentImages{},
+	}
+	for _, entity := range componentImages {
+		images := []keb.ContainerImage{}
+		for _, image := range entity.Images {
+			images = append(images, *image)
+		}
+		resp.Components = append(resp.Components, keb.ComponentImages{
+			Component:     entity.Component,
+			ConfigVersion: entity.ClusterConfig,
+			SchedulingID:  entity.SchedulingID,
+			Reported:      entity.Created,
+			Images:        images,
+		})
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode cluster images response").Error(),
+		})
+	}
+}
+
+//updateComponentImages stores the container images which were reported by a successful reconciliation of a component
+func updateComponentImages(o *Options, schedulingID, correlationID string, images []reconciler.ContainerImage) error {
+	op, err := o.Registry.ReconciliationRepository().GetOperation(schedulingID, correlationID)
+	if err != nil {
+		return err
+	}
+	if op.Type != model.OperationTypeReconcile {
+		return nil
+	}
+	entity := &model.ComponentImagesEntity{
+		RuntimeID:     op.RuntimeID,
+		Component:     op.Component,
+		ClusterConfig: op.ClusterConfig,
+		SchedulingID:  schedulingID,
+		CorrelationID: correlationID,
+		Images:        []*keb.ContainerImage{},
+	}
+	for _, image := range images {
+		entity.Images = append(entity.Images, &keb.ContainerImage{
+			Kind:      image.Kind,
+			Namespace: image.Namespace,
+			Name:      image.Name,
+			Container: image.Container,
+			Image:     image.Image,
+			Digest:    image.Digest,
+		})
+	}
+	return o.Registry.Inventory().UpdateComponentImages(entity)
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/kubeconfigref"
+	"github.com/kyma-incubator/reconciler/pkg/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/redact"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const kubeconfigValidationTimeout = 30 * time.Second
+
+//validateKubeconfig verifies that the credentials of a kubeconfig grant access to the cluster
+//(can be replaced in tests)
+var validateKubeconfig = func(ctx context.Context, kubeconfig string, logger *zap.SugaredLogger) error {
+	ctx, cancel := context.WithTimeout(ctx, kubeconfigValidationTimeout)
+	defer cancel()
+	_, err := kubernetes.NewClientBuilder().WithLogger(logger).WithString(kubeconfig).Build(ctx, true)
+	return err
+}
+
+//verifyKubeconfig performs a single authenticated call with the credentials of a kubeconfig when a cluster gets
+//registered (can be replaced in tests)
+var verifyKubeconfig = func(kubeconfig string) error {
+	return kubernetes.VerifyKubeconfig(kubeconfig, kubeconfigValidationTimeout)
+}
+
+//rotateKubeconfig replaces the kubeconfig of a cluster without creating a new configuration version. The new
+//kubeconfig is only accepted if its credentials are valid.
+func rotateKubeconfig(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	var rotation keb.KubeconfigRotation
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)).Decode(&rotation); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	if rotation.Kubeconfig == "" {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: "Kubeconfig is required",
+		})
+		return
+	}
+
+	//verify the cluster exists before connecting to it
+	if _, err := o.Registry.Inventory().GetLatest(runtimeID); err != nil {
+		sendKubeconfigError(w, err, "Could not rotate kubeconfig of cluster")
+		return
+	}
+	if err := validateKubeconfig(r.Context(), rotation.Kubeconfig, o.Logger()); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(redact.Error(err), "Kubeconfig was rejected: cluster is not accessible with its credentials").Error(),
+		})
+		return
+	}
+
+	clusterState, err := o.Registry.Inventory().RotateKubeconfig(runtimeID, rotation.Kubeconfig)
+	if err != nil {
+		sendKubeconfigError(w, err, "Could not rotate kubeconfig of cluster")
+		return
+	}
+	o.Logger().Infof("Kubeconfig of cluster '%s' rotated (clusterVersion:%d/configVersion:%d)",
+		runtimeID, clusterState.Cluster.Version, clusterState.Configuration.Version)
+
+	sendResponse(w, r, clusterState, o)
+}
+
+//rollbackKubeconfig restores the kubeconfig which was replaced by the last rotation: possible until the
+//cluster was successfully reconciled with the new kubeconfig
+func rollbackKubeconfig(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	clusterState, err := o.Registry.Inventory().RollbackKubeconfig(runtimeID)
+	if err != nil {
+		sendKubeconfigError(w, err, "Could not roll back kubeconfig of cluster")
+		return
+	}
+	o.Logger().Infof("Kubeconfig of cluster '%s' rolled back (clusterVersion:%d/configVersion:%d)",
+		runtimeID, clusterState.Cluster.Version, clusterState.Configuration.Version)
+
+	sendResponse(w, r, clusterState, o)
+}
+
+//getOperationKubeconfig hands out the kubeconfig of a task which references it instead of embedding it: the caller
+//has to authenticate with a client certificate and present the token of the reference. The kubeconfig is only
+//returned while the operation is processed.
+func getOperationKubeconfig(o *Options, w http.ResponseWriter, r *http.Request) {
+	if o.KubeconfigIssuer == nil {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: "Kubeconfigs are not delivered by reference",
+		})
+		return
+	}
+	if clientCertSubject(r) == "" {
+		server.SendHTTPError(w, http.StatusForbidden, &keb.HTTPErrorResponse{
+			Error: "Kubeconfigs are only handed out to callers with a valid client certificate",
+		})
+		return
+	}
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	claims, err := o.KubeconfigIssuer.Verify(r.Header.Get(kubeconfigref.TokenHeader))
+	if err != nil {
+		server.SendHTTPError(w, http.StatusUnauthorized, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	if claims.SchedulingID != schedulingID || claims.CorrelationID != correlationID {
+		server.SendHTTPError(w, http.StatusForbidden, &keb.HTTPErrorResponse{
+			Error: "Kubeconfig token was issued for another operation",
+		})
+		return
+	}
+
+	op, err := o.Registry.ReconciliationRepository().GetOperation(schedulingID, correlationID)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve operation").Error(),
+		})
+		return
+	}
+	if op == nil {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("Operation with schedulingID '%s' and correlationID '%s' not found", schedulingID, correlationID),
+		})
+		return
+	}
+	if op.State.IsFinal() {
+		server.SendHTTPError(w, http.StatusGone, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("Operation is already finished with state '%s'", op.State),
+		})
+		return
+	}
+
+	clusterState, err := o.Registry.Inventory().Get(claims.RuntimeID, claims.ConfigVersion)
+	if err != nil {
+		sendKubeconfigError(w, err, "Could not retrieve kubeconfig of cluster")
+		return
+	}
+	kubeconfig, err := clusterState.ComponentKubeconfig(op.Component)
+	if err != nil {
+		sendKubeconfigError(w, err, "Could not retrieve kubeconfig of component target")
+		return
+	}
+	o.Logger().Debugf("Kubeconfig of cluster '%s' handed out to '%s' (schedulingID:%s/correlationID:%s)",
+		claims.RuntimeID, clientCertSubject(r), schedulingID, correlationID)
+
+	w.Header().Set("content-type", "application/json")
+	w.Header().Set("cache-control", "no-store")
+	if err := json.NewEncoder(w).Encode(&reconciler.KubeconfigResponse{Kubeconfig: kubeconfig}); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode kubeconfig response").Error(),
+		})
+	}
+}
+
+func sendKubeconfigError(w http.ResponseWriter, err error, msg string) {
+	httpCode := http.StatusInternalServerError
+	if repository.IsNotFoundError(err) {
+		httpCode = http.StatusNotFound
+	} else if cluster.IsNoPreviousKubeconfigError(err) {
+		httpCode = http.StatusConflict
+	}
+	server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+		Error: errors.Wrap(err, msg).Error(),
+	})
+}
+
+//acceptTargetKubeconfigs checks the further kubeconfigs of the cluster like its main kubeconfig: it sends an error
+//response and returns false if one of them isn't accepted
+func acceptTargetKubeconfigs(o *Options, w http.ResponseWriter, r *http.Request, clusterModel *keb.Cluster) bool {
+	kubeconfigs := clusterModel.GetKubeconfigs()
+	names := make([]string, 0, len(kubeconfigs))
+	for name := range kubeconfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if o.VerifyKubeconfig {
+			if err := verifyKubeconfig(kubeconfigs[name]); err != nil {
+				o.Logger().Warnf("Kubeconfig '%s' of cluster '%s' was rejected: %s",
+					name, clusterModel.RuntimeID, redact.Error(err))
+				sendKubeconfigVerificationError(w, err)
+				return false
+			}
+			continue
+		}
+		if _, err := kubernetes.NewClientBuilder().WithLogger(o.Logger()).WithString(kubeconfigs[name]).Build(r.Context(), true); err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+				Error: errors.Wrapf(err, "kubeconfig '%s' not accepted", name).Error(),
+			})
+			return false
+		}
+	}
+	return true
+}
+
+//sendKubeconfigVerificationError rejects a kubeconfig with the reason of the failed verification
+func sendKubeconfigVerificationError(w http.ResponseWriter, err error) {
+	kubeconfigErr, ok := err.(*kubernetes.KubeconfigError)
+	if !ok {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to verify kubeconfig").Error(),
+		})
+		return
+	}
+	code := keb.ErrorCodeKubeconfigRejected
+	server.SendHTTPError(w, http.StatusUnprocessableEntity, &keb.HTTPKubeconfigErrorResponse{
+		Error:  errors.Wrap(redact.Error(err), "Kubeconfig was rejected").Error(),
+		Reason: keb.HTTPKubeconfigErrorResponseReason(kubeconfigErr.Reason),
+		Code:   &code,
+	})
+}
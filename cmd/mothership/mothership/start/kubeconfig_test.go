@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/kubernetes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendKubeconfigVerificationError(t *testing.T) {
+	t.Run("Rejected kubeconfig", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := kubernetes.VerifyKubeconfig("not a kubeconfig", kubeconfigValidationTimeout)
+		sendKubeconfigVerificationError(w, err)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		resp := &keb.HTTPKubeconfigErrorResponse{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+		require.Equal(t, keb.HTTPKubeconfigErrorResponseReasonInvalid, resp.Reason)
+		require.Contains(t, resp.Error, "Kubeconfig was rejected")
+		require.Equal(t, keb.ErrorCodeKubeconfigRejected, *resp.Code)
+	})
+
+	t.Run("Unexpected error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		sendKubeconfigVerificationError(w, errors.New("unexpected"))
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//getOperationLogs returns the latest log entries which the component reconciler reported with the final
+//status of an operation
+func getOperationLogs(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	entity, logs, err := o.Registry.OperationLogRepository().Get(schedulingID, correlationID)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve logs of operation").Error(),
+		})
+		return
+	}
+
+	resp := keb.HTTPOperationLogsResponse{
+		SchedulingID:  entity.SchedulingID,
+		CorrelationID: entity.CorrelationID,
+		RuntimeID:     entity.RuntimeID,
+		Component:     entity.Component,
+		Created:       entity.Created,
+		Logs:          *logs,
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode operation logs response").Error(),
+		})
+	}
+}
+
+//updateOperationLogs stores the log entries which were reported with the final status of an operation
+func updateOperationLogs(o *Options, schedulingID, correlationID string, logs *reconciler.OperationLogs) error {
+	op, err := o.Registry.ReconciliationRepository().GetOperation(schedulingID, correlationID)
+	if err != nil {
+		return err
+	}
+	entries := make([]keb.OperationLogEntry, 0, len(logs.Entries))
+	for _, entry := range logs.Entries {
+		entries = append(entries, keb.OperationLogEntry{
+			Time:    entry.Time,
+			Level:   entry.Level,
+			Message: entry.Message,
+			Fields:  entry.Fields,
+		})
+	}
+	return o.Registry.OperationLogRepository().Store(op, &keb.OperationLogs{
+		Entries:   entries,
+		Truncated: logs.Truncated,
+	})
+}
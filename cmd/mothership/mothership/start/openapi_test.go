@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRoutesAreDocumented(t *testing.T) {
+	documented, err := openapi.Operations()
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	registerAPIRoutes(router, &Options{}, nil)
+
+	served := make(map[string]bool)
+	err = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		require.NoError(t, err)
+		methods, err := route.GetMethods()
+		require.NoError(t, err)
+
+		//the contract version is part of the server URL in the specification
+		path := strings.TrimPrefix(openapi.NormalizePath(template), "/v{}")
+		for _, method := range methods {
+			require.Contains(t, documented[path], method, "route %s '%s' is not documented", method, template)
+			served[method+" "+path] = true
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	for path, methods := range documented {
+		for _, method := range methods {
+			require.True(t, served[method+" "+path], "documented route %s '%s' is not served", method, path)
+		}
+	}
+}
+
+func TestServeOpenAPISpec(t *testing.T) {
+	rec := httptest.NewRecorder()
+	serveOpenAPISpec(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("content-type"))
+	require.Contains(t, rec.Body.String(), `"openapi":"3.0.0"`)
+}
@@ -4,7 +4,25 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/configtemplate"
+	"github.com/kyma-incubator/reconciler/pkg/cors"
+	"github.com/kyma-incubator/reconciler/pkg/kubeconfigref"
+	"github.com/kyma-incubator/reconciler/pkg/ownership"
+	"github.com/kyma-incubator/reconciler/pkg/policy"
+	"github.com/kyma-incubator/reconciler/pkg/ratelimit"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/backpressure"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/cohort"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/flaky"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/health"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/service"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/slo"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/kyma-incubator/reconciler/pkg/snapshot"
+	"github.com/kyma-incubator/reconciler/pkg/subscription"
+	"github.com/kyma-incubator/reconciler/pkg/validation"
 
 	"github.com/pkg/errors"
 
@@ -15,8 +33,16 @@ import (
 type Options struct {
 	*cli.Options
 	Port                           int
+	GRPCPort                       int
+	GRPCWatchInterval              time.Duration
+	DebugPort                      int
+	DebugHost                      string
+	ServerPprof                    bool
 	SSLCrt                         string
 	SSLKey                         string
+	TLS                            ssl.ProtocolConfig
+	CertReloadInterval             time.Duration
+	ServerLimits                   server.Limits
 	Workers                        int
 	WatchInterval                  time.Duration
 	OrphanOperationTimeout         time.Duration
@@ -28,38 +54,100 @@ type Options struct {
 	ReconciliationsMaxAgeDays      int
 	InventoryMaxAgeDays            int
 	StatusCleanupBatchSize         int
+	KeepDeletedClusters            bool
 	CreateEncyptionKey             bool
 	MaxParallelOperations          int
 	AuditLog                       bool
 	AuditLogFile                   string
 	AuditLogTenantID               string
 	StopAfterMigration             bool
+	PersistPayloads                bool
+	PayloadsMaxAgeDays             int
+	RecordContract                 string
+	VerifyKubeconfig               bool
+	CompressionMinSize             int
+	CompressionBrotli              bool
+	UI                             bool
+	Auth                           auth.Config
+	ClientAuth                     ssl.ClientAuthConfig
+	CORS                           cors.Config
 	Config                         *config.Config
+	PolicyEngine                   *policy.Engine
+	ValidationWebhook              *validation.Webhook
+	TemplateFanOut                 *configtemplate.FanOut
+	FlakinessClassifier            *flaky.Classifier
+	HealthScorer                   *health.Scorer
+	UpdateLimiter                  *ratelimit.UpdateLimiter
+	ClientLimiter                  *ratelimit.ClientLimiter
+	KubeconfigIssuer               *kubeconfigref.Issuer
+	StatusNotifier                 *subscription.Notifier
+	StatusBroadcaster              *cluster.StatusBroadcaster
+	SnapshotRecorder               *snapshot.Recorder
+	TakeoverGuard                  *ownership.Guard
+	Backpressure                   *backpressure.Controller
+	Cohorts                        *cohort.Resolver
+	SLOs                           []*slo.Objective
+	SchedulerHeartbeat             *service.Heartbeat
 }
 
 func NewOptions(o *cli.Options) *Options {
 	return &Options{o,
-		0,                //Port
-		"",               //SSLCrt
-		"",               //SSLKey
-		0,                //Workers
-		0 * time.Second,  //WatchInterval
-		0 * time.Minute,  //Orphan timeout
-		0 * time.Second,  //ClusterReconcileInterval
-		0 * time.Minute,  //PurgeEntitiesOlderThan
-		0 * time.Minute,  //CleanerInterval
-		45 * time.Second, //BookkeeperWatchInterval
-		0,                //ReconciliationsKeepLatestCount
-		0,                //ReconciliationsMaxAgeDays
-		0,                //InventoryMaxAgeDays
-		0,                // StatusCleanupBatchSize
-		false,            //CreateEncyptionKey
-		0,                //MaxParallelOperations
-		false,            //AuditLog
-		"",               //AuditLogFile
-		"",               //AuditLogTenant
-		false,            //StopAfterMigration
-		&config.Config{}, //Config
+		0,                      //Port
+		0,                      //GRPCPort
+		0 * time.Second,        //GRPCWatchInterval
+		0,                      //DebugPort
+		"",                     //DebugHost
+		false,                  //ServerPprof
+		"",                     //SSLCrt
+		"",                     //SSLKey
+		ssl.ProtocolConfig{},   //TLS
+		0 * time.Second,        //CertReloadInterval
+		server.Limits{},        //ServerLimits
+		0,                      //Workers
+		0 * time.Second,        //WatchInterval
+		0 * time.Minute,        //Orphan timeout
+		0 * time.Second,        //ClusterReconcileInterval
+		0 * time.Minute,        //PurgeEntitiesOlderThan
+		0 * time.Minute,        //CleanerInterval
+		45 * time.Second,       //BookkeeperWatchInterval
+		0,                      //ReconciliationsKeepLatestCount
+		0,                      //ReconciliationsMaxAgeDays
+		0,                      //InventoryMaxAgeDays
+		0,                      // StatusCleanupBatchSize
+		false,                  //KeepDeletedClusters
+		false,                  //CreateEncyptionKey
+		0,                      //MaxParallelOperations
+		false,                  //AuditLog
+		"",                     //AuditLogFile
+		"",                     //AuditLogTenant
+		false,                  //StopAfterMigration
+		false,                  //PersistPayloads
+		0,                      //PayloadsMaxAgeDays
+		"",                     //RecordContract
+		false,                  //VerifyKubeconfig
+		0,                      //CompressionMinSize
+		false,                  //CompressionBrotli
+		false,                  //UI
+		auth.Config{},          //Auth
+		ssl.ClientAuthConfig{}, //ClientAuth
+		cors.Config{},          //CORS
+		&config.Config{},       //Config
+		nil,                    //PolicyEngine
+		nil,                    //ValidationWebhook
+		nil,                    //TemplateFanOut
+		nil,                    //FlakinessClassifier
+		nil,                    //HealthScorer
+		nil,                    //UpdateLimiter
+		nil,                    //ClientLimiter
+		nil,                    //KubeconfigIssuer
+		nil,                    //StatusNotifier
+		nil,                    //StatusBroadcaster
+		nil,                    //SnapshotRecorder
+		nil,                    //TakeoverGuard
+		nil,                    //Backpressure
+		nil,                    //Cohorts
+		nil,                    //SLOs
+		service.NewHeartbeat(), //SchedulerHeartbeat
 	}
 }
 
@@ -67,6 +155,32 @@ func (o *Options) Validate() error {
 	if o.Port <= 0 || o.Port > 65535 {
 		return fmt.Errorf("port %d is out of range 1-65535", o.Port)
 	}
+	if o.GRPCPort < 0 || o.GRPCPort > 65535 {
+		return fmt.Errorf("gRPC port %d is out of range 1-65535", o.GRPCPort)
+	}
+	if o.GRPCPort > 0 {
+		if o.GRPCPort == o.Port {
+			return fmt.Errorf("gRPC port %d cannot be the same as the webserver port", o.GRPCPort)
+		}
+		if o.GRPCWatchInterval <= 0 {
+			return errors.New("gRPC watch interval cannot be <= 0")
+		}
+	}
+	if o.DebugPort < 0 || o.DebugPort > 65535 {
+		return fmt.Errorf("debug port %d is out of range 1-65535", o.DebugPort)
+	}
+	if o.DebugPort > 0 && (o.DebugPort == o.Port || o.DebugPort == o.GRPCPort) {
+		return fmt.Errorf("debug port %d cannot be the same as the webserver or gRPC port", o.DebugPort)
+	}
+	if err := o.TLS.Validate(); err != nil {
+		return err
+	}
+	if o.CertReloadInterval < 0 {
+		return errors.New("SSL certificate reload interval cannot be < 0")
+	}
+	if err := o.ServerLimits.Validate(); err != nil {
+		return err
+	}
 	if o.Workers <= 0 {
 		return errors.New("amount of workers cannot be <= 0")
 	}
@@ -91,9 +205,15 @@ func (o *Options) Validate() error {
 	if o.StatusCleanupBatchSize < 100 {
 		return errors.New("cluster status cleaner batch size cannot be < 100")
 	}
+	if o.PayloadsMaxAgeDays < 0 {
+		return errors.New("cleaner count of days to keep KEB payloads cannot be < 0")
+	}
 	if o.MaxParallelOperations < 0 {
 		return errors.New("maximal parallel reconciled components per cluster cannot be < 0")
 	}
+	if o.CompressionMinSize < 0 {
+		return errors.New("min. size of compressed responses cannot be < 0")
+	}
 	if o.AuditLog {
 		if o.AuditLogFile == "" {
 			return errors.New("audit log file must be set if audit logging is enable")
@@ -103,5 +223,14 @@ func (o *Options) Validate() error {
 
 		}
 	}
+	if !o.Auth.Enabled() && (o.Auth.Issuer != "" || len(o.Auth.Audiences) > 0 || o.Auth.ProtectMetrics || o.Auth.ProtectHealth) {
+		return errors.New("JWKS URL must be set if issuer, audiences or protected endpoints of the authentication are defined")
+	}
+	if err := o.ClientAuth.Validate(o.SSLCrt, o.SSLKey); err != nil {
+		return err
+	}
+	if err := o.CORS.Validate(); err != nil {
+		return err
+	}
 	return ssl.VerifyKeyPair(o.SSLCrt, o.SSLKey)
 }
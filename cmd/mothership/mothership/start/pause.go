@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+const paramPauseFor = "for"
+
+func getSchedulerPause(o *Options, w http.ResponseWriter, _ *http.Request) {
+	pause, err := o.Registry.PauseRepository().Active()
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to retrieve pause of the scheduler").Error(),
+		})
+		return
+	}
+	sendSchedulerPauseResponse(w, pause)
+}
+
+//pauseScheduler stops the scheduling and dispatching of reconciliations fleet-wide. If a duration is
+//defined, the scheduler resumes automatically when it's over.
+func pauseScheduler(o *Options, w http.ResponseWriter, r *http.Request) {
+	var duration time.Duration
+	params := server.NewParams(r)
+	if forParam, err := params.String(paramPauseFor); err == nil && forParam != "" {
+		if duration, err = time.ParseDuration(forParam); err != nil || duration < time.Second {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+				Error: fmt.Sprintf("Parameter '%s' has to be a duration of at least 1s (e.g. 2h) but was '%s'",
+					paramPauseFor, forParam),
+			})
+			return
+		}
+	}
+
+	pause, err := o.Registry.PauseRepository().Pause(duration, requestUser(r))
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to pause the scheduler").Error(),
+		})
+		return
+	}
+	if until := pause.Until(); until.IsZero() {
+		o.Logger().Warnf("Scheduler paused by '%s' until it gets resumed", pause.PausedBy)
+	} else {
+		o.Logger().Warnf("Scheduler paused by '%s' from %s until %s", pause.PausedBy,
+			pause.Started.Format(time.RFC3339), until.Format(time.RFC3339))
+	}
+	sendSchedulerPauseResponse(w, pause)
+}
+
+func resumeScheduler(o *Options, w http.ResponseWriter, r *http.Request) {
+	pause, err := o.Registry.PauseRepository().Resume()
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to resume the scheduler").Error(),
+		})
+		return
+	}
+	if pause != nil {
+		o.Logger().Infof("Scheduler resumed by '%s' (pause started at %s by '%s')",
+			requestUser(r), pause.Started.Format(time.RFC3339), pause.PausedBy)
+	}
+	sendSchedulerPauseResponse(w, nil)
+}
+
+//requestUser returns the user who sent the request (subject of the bearer token or same as written to the audit log)
+func requestUser(r *http.Request) string {
+	if claims, ok := auth.FromContext(r.Context()); ok && claims.Subject != "" {
+		return claims.Subject
+	}
+	if subject := clientCertSubject(r); subject != "" {
+		return subject
+	}
+	jwtPayload, err := getJWTPayload(r)
+	if err != nil {
+		return "UNKNOWN_USER"
+	}
+	user, err := getJWTPayloadSub(jwtPayload)
+	if err != nil || user == "" {
+		return "UNKNOWN_USER"
+	}
+	return user
+}
+
+func newSchedulerPauseResponse(pause *model.SchedulerPauseEntity) keb.HTTPSchedulerPauseResponse {
+	if pause == nil {
+		return keb.HTTPSchedulerPauseResponse{}
+	}
+	resp := keb.HTTPSchedulerPauseResponse{
+		Paused:   true,
+		PausedBy: &pause.PausedBy,
+		Started:  &pause.Started,
+	}
+	if until := pause.Until(); !until.IsZero() {
+		resp.Until = &until
+	}
+	return resp
+}
+
+func sendSchedulerPauseResponse(w http.ResponseWriter, pause *model.SchedulerPauseEntity) {
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(newSchedulerPauseResponse(pause)); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode scheduler pause response").Error(),
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"context"
+	"time"
+)
+
+//startPayloadCleaner removes stored KEB payloads which exceeded the configured retention period
+func startPayloadCleaner(ctx context.Context, o *Options) {
+	if o.PayloadsMaxAgeDays == 0 {
+		o.Logger().Info("Payload cleaner is disabled: stored payloads will not be removed")
+		return
+	}
+	ticker := time.NewTicker(o.CleanerInterval)
+	defer ticker.Stop()
+	for {
+		deadline := time.Now().UTC().AddDate(0, 0, -o.PayloadsMaxAgeDays)
+		deleted, err := o.Registry.PayloadRepository().DeleteOlderThan(deadline)
+		if err != nil {
+			o.Logger().Warnf("Payload cleaner failed to remove payloads older than %s: %s", deadline, err)
+		} else {
+			o.Logger().Debugf("Payload cleaner removed %d payloads older than %s", deleted, deadline)
+		}
+		select {
+		case <-ctx.Done():
+			o.Logger().Info("Stopping payload cleaner because parent context got closed")
+			return
+		case <-ticker.C:
+		}
+	}
+}
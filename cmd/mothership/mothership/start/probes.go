@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+//readinessCheck verifies a dependency of the mothership: an error marks the mothership as not ready
+type readinessCheck struct {
+	name  string
+	check func() error
+}
+
+//readinessChecks returns the dependencies which have to be available before the mothership receives traffic
+func readinessChecks(o *Options) []readinessCheck {
+	checks := []readinessCheck{
+		{
+			name: "database",
+			check: func() error {
+				return o.Registry.Connection().Ping()
+			},
+		},
+	}
+	//only Postgres databases are migrated (SQLite databases get their schema deployed at startup)
+	if o.Registry.Connection().Type() == db.Postgres {
+		migrationsDir := db.MigrationsDir()
+		checks = append(checks, readinessCheck{
+			name: "migrations",
+			check: func() error {
+				status, err := db.GetMigrationStatus(o.Registry.Connection(), migrationsDir)
+				if err != nil {
+					return err
+				}
+				if status.Pending() {
+					return fmt.Errorf("database schema version is %d (dirty: %t) but latest migration is %d",
+						status.Version, status.Dirty, status.Latest)
+				}
+				return nil
+			},
+		})
+	}
+	if o.SchedulerHeartbeat != nil {
+		checks = append(checks, readinessCheck{
+			name: "scheduler",
+			check: func() error {
+				return o.SchedulerHeartbeat.Check(time.Now())
+			},
+		})
+	}
+	return checks
+}
+
+//healthz is the liveness probe: the mothership is alive as long as it answers requests
+func healthz(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("content-type", "text/plain")
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+//readyz is the readiness probe: it reports the result of each dependency check and fails if one of them failed
+func readyz(o *Options) http.HandlerFunc {
+	checks := readinessChecks(o)
+	return func(w http.ResponseWriter, _ *http.Request) {
+		var report strings.Builder
+		var failed []string
+		for _, readinessCheck := range checks {
+			if err := readinessCheck.check(); err != nil {
+				failed = append(failed, readinessCheck.name)
+				fmt.Fprintf(&report, "[-]%s failed: %s\n", readinessCheck.name, err)
+				continue
+			}
+			fmt.Fprintf(&report, "[+]%s ok\n", readinessCheck.name)
+		}
+
+		w.Header().Set("content-type", "text/plain")
+		w.Header().Set("cache-control", "no-store")
+		if len(failed) > 0 {
+			o.Logger().Warnf("Readiness check failed for: %s", strings.Join(failed, ", "))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(&report, "readyz check failed\n")
+		} else {
+			fmt.Fprintf(&report, "readyz check passed\n")
+		}
+		_, _ = w.Write([]byte(report.String()))
+	}
+}
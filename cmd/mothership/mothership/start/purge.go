@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+const (
+	paramRetention = "retention"
+	//defaultPurgeRetention is the time deleted clusters are kept for audits before they can be purged
+	defaultPurgeRetention = 30 * 24 * time.Hour
+)
+
+//purgeCluster physically removes the deleted clusters which used the runtime ID (incl. their reconciliations):
+//only clusters which were deleted before the retention period are purged, the others are kept for audits
+func purgeCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	retention := defaultPurgeRetention
+	if retentionParam, err := params.String(paramRetention); err == nil && retentionParam != "" {
+		if retention, err = time.ParseDuration(retentionParam); err != nil || retention < 0 {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+				Error: fmt.Sprintf("Parameter '%s' has to be a duration (e.g. 720h) but was '%s'",
+					paramRetention, retentionParam),
+			})
+			return
+		}
+	}
+
+	deletedClusters, err := o.Registry.Inventory().GetDeleted(runtimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrapf(err, "Failed to retrieve deleted clusters with runtime ID '%s'", runtimeID).Error(),
+		})
+		return
+	}
+	if len(deletedClusters) == 0 {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("No deleted cluster with runtime ID '%s' found", runtimeID),
+		})
+		return
+	}
+
+	resp := keb.HTTPClusterPurgeResponse{
+		Purged:   []keb.DeletedCluster{},
+		Retained: []keb.DeletedCluster{},
+	}
+	deadline := time.Now().Add(-retention)
+	for _, deletedCluster := range deletedClusters {
+		if deletedCluster.Deleted.After(deadline) {
+			resp.Retained = append(resp.Retained, newDeletedClusterResponse(deletedCluster))
+			continue
+		}
+		if err := purgeDeletedCluster(o, deletedCluster); err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrapf(err, "Failed to purge deleted cluster '%s'", deletedCluster.RuntimeID).Error(),
+			})
+			return
+		}
+		o.Logger().Infof("Purged deleted cluster '%s' (deleted at %s) by '%s'", deletedCluster.RuntimeID,
+			deletedCluster.Deleted.Format(time.RFC3339), requestUser(r))
+		resp.Purged = append(resp.Purged, newDeletedClusterResponse(deletedCluster))
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode purge response").Error(),
+		})
+	}
+}
+
+//purgeDeletedCluster removes the reconciliations (incl. operations) and the inventory entities of a deleted cluster
+//within one transaction
+func purgeDeletedCluster(o *Options, deletedCluster *cluster.DeletedCluster) error {
+	dbOp := func(tx *db.TxConnection) error {
+		reconRepo, err := o.Registry.ReconciliationRepository().WithTx(tx)
+		if err != nil {
+			return err
+		}
+		//the reconciliations keep the original runtime ID (which can be re-used by a new cluster): they are
+		//identified by the configurations of the deleted cluster
+		if len(deletedCluster.ConfigVersions) > 0 {
+			reconciliations, err := reconRepo.GetReconciliations(&reconciliation.WithClusterConfigs{
+				ClusterConfigs: deletedCluster.ConfigVersions,
+			})
+			if err != nil {
+				return err
+			}
+			var schedulingIDs []interface{}
+			for _, recon := range reconciliations {
+				schedulingIDs = append(schedulingIDs, recon.SchedulingID)
+			}
+			if len(schedulingIDs) > 0 {
+				if err := reconRepo.RemoveReconciliationsBySchedulingID(schedulingIDs); err != nil {
+					return err
+				}
+			}
+		}
+		inventory, err := o.Registry.Inventory().WithTx(tx)
+		if err != nil {
+			return err
+		}
+		return inventory.Purge(deletedCluster)
+	}
+	return db.Transaction(o.Registry.Connection(), dbOp, o.Logger())
+}
+
+func newDeletedClusterResponse(deletedCluster *cluster.DeletedCluster) keb.DeletedCluster {
+	return keb.DeletedCluster{
+		Deleted: deletedCluster.Deleted,
+		Id:      deletedCluster.RuntimeID,
+	}
+}
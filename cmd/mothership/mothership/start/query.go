@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/query"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//queryData executes a read-only query over the inventory and reconciliation data
+func queryData(o *Options, w http.ResponseWriter, r *http.Request) {
+	var kebQuery keb.Query
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&kebQuery); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+
+	q := &query.Query{
+		Resource: query.Resource(kebQuery.Resource),
+		Filter:   newQueryFilter(kebQuery.Filter),
+	}
+	if kebQuery.Limit != nil {
+		q.Limit = *kebQuery.Limit
+	}
+	items, err := o.Registry.QueryRepository().Execute(q)
+	if err != nil {
+		if query.IsInvalidQueryError(err) {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+			return
+		}
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to execute query").Error(),
+		})
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.HTTPQueryResponse{
+		Resource: kebQuery.Resource,
+		Items:    items,
+	}); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode query response").Error(),
+		})
+	}
+}
+
+func newQueryFilter(kebFilter *keb.QueryFilter) *query.Filter {
+	if kebFilter == nil {
+		return nil
+	}
+	filter := &query.Filter{}
+	if kebFilter.Field != nil {
+		filter.Field = *kebFilter.Field
+	}
+	if kebFilter.Op != nil {
+		filter.Op = query.Operator(*kebFilter.Op)
+	}
+	if kebFilter.Value != nil {
+		filter.Value = *kebFilter.Value
+	}
+	if kebFilter.And != nil {
+		for idx := range *kebFilter.And {
+			filter.And = append(filter.And, newQueryFilter(&(*kebFilter.And)[idx]))
+		}
+	}
+	if kebFilter.Or != nil {
+		for idx := range *kebFilter.Or {
+			filter.Or = append(filter.Or, newQueryFilter(&(*kebFilter.Or)[idx]))
+		}
+	}
+	return filter
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/ratelimit"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"go.uber.org/zap"
+)
+
+//newClientRateLimitMiddleware rejects requests of callers which exceeded their rate limit. The operation callbacks
+//are not limited: the component reconcilers throttle them on their own.
+func newClientRateLimitMiddleware(limiter *ratelimit.ClientLimiter, forwardedForHops int,
+	logger *zap.SugaredLogger) mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path, err := mux.CurrentRoute(r).GetPathTemplate()
+			if err == nil && unauthenticatedRoutes[path] {
+				h.ServeHTTP(w, r)
+				return
+			}
+			client, authenticated := rateLimitedClient(r, forwardedForHops)
+			if allowed, retryAfter := limiter.Allow(client, authenticated, time.Now()); !allowed {
+				retryAfterSecs := int(math.Ceil(retryAfter.Seconds()))
+				logger.Warnf("Request %s '%s' of client '%s' rejected: client rate limit exceeded",
+					r.Method, r.URL.Path, client)
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfterSecs))
+				server.SendHTTPError(w, http.StatusTooManyRequests, &keb.HTTPErrorResponse{
+					Error: fmt.Sprintf("Client '%s' exceeded its request rate limit: retry in %d secs",
+						client, retryAfterSecs),
+				})
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+//rateLimitedClient returns the identity of the caller (JWT subject or client certificate CN) or its source IP. The
+//source IP is taken from the X-Forwarded-For header if it's trusted: the entries appended by the trusted proxies are
+//counted from the right because the client controls all entries left of them. The returned flag is true if the
+//caller was authenticated.
+func rateLimitedClient(r *http.Request, forwardedForHops int) (string, bool) {
+	if claims, ok := auth.FromContext(r.Context()); ok && claims.Subject != "" {
+		return claims.Subject, true
+	}
+	if subject := clientCertSubject(r); subject != "" {
+		return subject, true
+	}
+	if forwardedForHops > 0 {
+		var forwardedFor []string
+		for _, header := range r.Header.Values("X-Forwarded-For") { //proxies can also append own headers
+			forwardedFor = append(forwardedFor, strings.Split(header, ",")...)
+		}
+		if len(forwardedFor) >= forwardedForHops {
+			if addr := strings.TrimSpace(forwardedFor[len(forwardedFor)-forwardedForHops]); addr != "" {
+				return addr, false
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr, false
+	}
+	return host, false
+}
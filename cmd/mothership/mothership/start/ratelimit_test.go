@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/ratelimit"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRateLimitMiddleware(t *testing.T) {
+	limiter, err := ratelimit.NewClientLimiter(config.ClientRateLimitConfig{RequestsPerSecond: 0.1})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.Use(newClientRateLimitMiddleware(limiter, 1, logger.NewLogger(true)))
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	router.HandleFunc(fmt.Sprintf("/v{%s}/clusters", paramContractVersion), handler)
+	router.HandleFunc(fmt.Sprintf("/v{%s}/operations/callbacks", paramContractVersion), handler)
+
+	send := func(path, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusOK, send("/v1/clusters", "10.0.0.1:1234", "").Code)
+	rec := send("/v1/clusters", "10.0.0.1:5678", "")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "10", rec.Header().Get("Retry-After"))
+
+	//callers are distinguished by the source IP which the trusted proxy appended
+	require.Equal(t, http.StatusOK, send("/v1/clusters", "10.0.0.1:1234", "192.168.0.1").Code)
+	require.Equal(t, http.StatusTooManyRequests, send("/v1/clusters", "10.0.0.2:1234", "192.168.0.1").Code)
+
+	//entries added by the client are ignored
+	require.Equal(t, http.StatusTooManyRequests, send("/v1/clusters", "10.0.0.1:1234", "172.16.0.1, 192.168.0.1").Code)
+	require.Equal(t, http.StatusOK, send("/v1/clusters", "10.0.0.1:1234", "192.168.0.1, 172.16.0.2").Code)
+
+	//callbacks are not limited
+	require.Equal(t, http.StatusOK, send("/v1/operations/callbacks", "10.0.0.1:1234", "").Code)
+}
+
+func TestRateLimitedClient(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/clusters", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", "172.16.0.1, 192.168.0.1, 10.0.0.2")
+
+	for hops, expected := range map[int]string{0: "10.0.0.1", 1: "10.0.0.2", 2: "192.168.0.1", 4: "10.0.0.1"} {
+		client, authenticated := rateLimitedClient(req, hops)
+		require.Equal(t, expected, client, "hops: %d", hops)
+		require.False(t, authenticated)
+	}
+}
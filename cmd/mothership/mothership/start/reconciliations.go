@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//listClusterReconciliations returns the reconciliations of a cluster (latest reconciliations first)
+func listClusterReconciliations(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	filters := []reconciliation.Filter{&reconciliation.WithRuntimeID{RuntimeID: runtimeID}}
+	if statuses, err := params.StrSlice(paramStatus); err == nil {
+		if err := validateStatuses(statuses); err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+			return
+		}
+		filters = append(filters, &reconciliation.WithStatuses{Statuses: statuses})
+	}
+	if _, err := params.String(paramLast); err == nil {
+		last, err := params.Int(paramLast)
+		if err != nil || last <= 0 {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+				Error: fmt.Sprintf("Parameter '%s' has to be a number > 0", paramLast),
+			})
+			return
+		}
+		filters = append(filters, &reconciliation.Limit{Count: last})
+	}
+
+	reconEntities, err := o.Registry.ReconciliationRepository().GetReconciliations(
+		&reconciliation.FilterMixer{Filters: filters})
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrapf(err, "Failed to retrieve reconciliations of cluster '%s'", runtimeID).Error(),
+		})
+		return
+	}
+	sort.Slice(reconEntities, func(i, j int) bool {
+		return reconEntities[i].Created.After(reconEntities[j].Created)
+	})
+
+	results := keb.ReconcilationsOKResponse{}
+	for _, reconEntity := range reconEntities {
+		results = append(results, keb.Reconciliation{
+			Created:      reconEntity.Created,
+			Finished:     reconEntity.Finished,
+			Lock:         reconEntity.Lock,
+			RuntimeID:    reconEntity.RuntimeID,
+			SchedulingID: reconEntity.SchedulingID,
+			Status:       keb.Status(reconEntity.Status),
+			Updated:      reconEntity.Updated,
+		})
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to encode reconciliations response").Error(),
+		})
+	}
+}
+
+//listReconciliationOperations returns the component operations of a reconciliation (ordered by their priority) incl.
+//their retries, timings and error messages: it shows which components block a cluster in status 'reconciling'
+func listReconciliationOperations(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	reconEntity, err := o.Registry.ReconciliationRepository().GetReconciliation(schedulingID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	if reconEntity.RuntimeID != runtimeID {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("Cluster '%s' has no reconciliation with schedulingID '%s'", runtimeID, schedulingID),
+		})
+		return
+	}
+	opEntities, err := o.Registry.ReconciliationRepository().GetOperations(&operation.WithSchedulingID{
+		SchedulingID: schedulingID,
+	})
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(newOperationsResponse(opEntities)); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to encode operations response").Error(),
+		})
+	}
+}
+
+func newOperationsResponse(opEntities []*model.OperationEntity) keb.HTTPOperationsResponse {
+	sort.Slice(opEntities, func(i, j int) bool {
+		if opEntities[i].Priority == opEntities[j].Priority {
+			return opEntities[i].Component < opEntities[j].Component
+		}
+		return opEntities[i].Priority < opEntities[j].Priority
+	})
+	resp := keb.HTTPOperationsResponse{}
+	for _, opEntity := range opEntities {
+		resp = append(resp, converters.ConvertOperation(opEntity))
+	}
+	return resp
+}
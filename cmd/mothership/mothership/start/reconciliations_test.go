@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationsResponse(t *testing.T) {
+	resp := newOperationsResponse([]*model.OperationEntity{
+		{Component: "serverless", Priority: 2, State: model.OperationStateInProgress, Retries: 3},
+		{Component: "istio", Priority: 1, State: model.OperationStateDone, Retries: 1},
+		{Component: "eventing", Priority: 2, State: model.OperationStateError, Reason: "timeout", Retries: 5},
+	})
+	require.Len(t, resp, 3)
+	//ordered by priority and component
+	require.Equal(t, "istio", resp[0].Component)
+	require.Equal(t, "eventing", resp[1].Component)
+	require.Equal(t, "serverless", resp[2].Component)
+
+	require.Equal(t, "timeout", resp[1].Reason)
+	require.Equal(t, int64(5), resp[1].Retries)
+	require.NotNil(t, resp[1].Finished)
+	require.Nil(t, resp[2].Finished)
+
+	require.Empty(t, newOperationsResponse(nil))
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"go.uber.org/zap"
+)
+
+//recoveryWriter remembers whether a handler already started its response
+type recoveryWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *recoveryWriter) WriteHeader(status int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recoveryWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+//Flush supports streamed responses (e.g. server-sent events)
+func (w *recoveryWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+//newRecoveryMiddleware converts panics of handlers into an internal server error which contains an incident ID:
+//the stack trace is logged with the same ID to correlate it with the failed request
+func newRecoveryMiddleware(panicsMetric *metrics.PanicsMetric, logger *zap.SugaredLogger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			recoveryW := &recoveryWriter{ResponseWriter: w}
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if p == http.ErrAbortHandler { //deliberate abort of the response: handled by the HTTP server
+					panic(p)
+				}
+
+				route := r.URL.Path
+				if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+					if tpl, err := currentRoute.GetPathTemplate(); err == nil {
+						route = tpl
+					}
+				}
+				incidentID := uuid.NewString()
+				logger.Errorf("Handler of route '%s %s' panicked (incidentID:%s/requestID:%s): %v\n%s",
+					r.Method, route, incidentID, requestID(r.Context()), p, debug.Stack())
+				panicsMetric.ExposePanic(route, r.Method)
+
+				if recoveryW.written { //response is already started and can't be replaced
+					return
+				}
+				server.SendHTTPError(recoveryW, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+					Error:      fmt.Sprintf("Internal error occurred: please report the incident ID '%s'", incidentID),
+					IncidentID: &incidentID,
+				})
+			}()
+			next.ServeHTTP(recoveryW, r)
+		})
+	}
+}
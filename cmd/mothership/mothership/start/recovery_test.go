@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(newRecoveryMiddleware(metrics.NewPanicsMetric(zap.NewNop().Sugar()), zap.NewNop().Sugar()))
+	router.HandleFunc("/clusters/{runtimeID}/status", func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map")
+	})
+	router.HandleFunc("/clusters/{runtimeID}/stream", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		panic("connection lost")
+	})
+	router.HandleFunc("/clusters/{runtimeID}/abort", func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	t.Run("Panic is answered with incident ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/clusters/abc/status", nil))
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		resp := &keb.HTTPErrorResponse{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+		require.NotNil(t, resp.IncidentID)
+		require.NotEmpty(t, *resp.IncidentID)
+		require.Contains(t, resp.Error, *resp.IncidentID)
+		require.Equal(t, "urn:uuid:"+*resp.IncidentID, *resp.Instance)
+	})
+
+	t.Run("Started response is not replaced", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/clusters/abc/stream", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Body.String())
+	})
+
+	t.Run("Aborted handler is not recovered", func(t *testing.T) {
+		require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/clusters/abc/abort", nil))
+		})
+	})
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/policy"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+const paramToVersion = "toVersion"
+
+//rollbackCluster creates a new configuration version of a cluster which is cloned from an older configuration
+//version: the new version gets reconciled like any other configuration change
+func rollbackCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	contractV, err := params.Int64(paramContractVersion)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Contract version undefined").Error(),
+		})
+		return
+	}
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	toVersion, err := params.Int64(paramToVersion)
+	if err != nil || toVersion <= 0 {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("Parameter '%s' has to be a configuration version > 0", paramToVersion),
+		})
+		return
+	}
+
+	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
+	if err != nil {
+		sendRollbackError(w, err, "Could not retrieve latest state of cluster")
+		return
+	}
+	if toVersion >= clusterState.Configuration.Version {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("Configuration version %d is not older than the current configuration version %d",
+				toVersion, clusterState.Configuration.Version),
+		})
+		return
+	}
+	targetState, err := o.Registry.Inventory().Get(runtimeID, toVersion)
+	if err != nil {
+		sendRollbackError(w, err, fmt.Sprintf("Could not retrieve configuration version %d of cluster", toVersion))
+		return
+	}
+	if err := validateRollback(contractV, clusterState, targetState); err != nil {
+		server.SendHTTPError(w, http.StatusConflict, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	//the restored configuration has to pass the same checks as any configuration sent by KEB
+	clusterModel := newRollbackCluster(clusterState, targetState)
+	validationWarnings, err := admitCluster(r.Context(), o, policy.OperationUpdate, contractV, clusterModel)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if isAdmissionRejectionError(err) {
+			httpCode = http.StatusBadRequest
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	clusterStateNew, err := o.Registry.Inventory().CreateOrUpdate(contractV, clusterModel)
+	if err != nil {
+		sendRollbackError(w, err, "Failed to create configuration version of cluster")
+		return
+	}
+	if err := updateConfigWarnings(o, runtimeID, clusterStateNew.Configuration.Version, validationWarnings); err != nil {
+		sendRollbackError(w, err, "Failed to store validation warnings of cluster")
+		return
+	}
+	if clusterState.Status.Status.IsDisabled() {
+		if clusterStateNew, err = o.Registry.Inventory().UpdateStatus(clusterStateNew, model.ClusterStatusReconcileDisabled); err != nil {
+			sendRollbackError(w, err, "Failed to disable cluster after the rollback")
+			return
+		}
+	}
+	o.Logger().Warnf("Configuration of cluster '%s' rolled back to configuration version %d by '%s' "+
+		"(new configVersion:%d)", runtimeID, toVersion, requestUser(r), clusterStateNew.Configuration.Version)
+
+	sendResponse(w, r, clusterStateNew, o)
+}
+
+//validateRollback verifies the guardrails of a rollback from the latest to an older configuration version
+func validateRollback(contractVersion int64, latest, target *cluster.State) error {
+	if status := latest.Status.Status; status.IsDeleteCandidate() || status.IsDeletionInProgress() ||
+		status == model.ClusterStatusDeleteError {
+		return fmt.Errorf("cluster '%s' cannot be rolled back because it is in status '%s'",
+			latest.Cluster.RuntimeID, latest.Status.Status)
+	}
+	if target.Configuration.Contract != latest.Configuration.Contract || target.Configuration.Contract != contractVersion {
+		return fmt.Errorf("configuration version %d was created with contract version %d and cannot be restored "+
+			"with contract version %d: rollbacks across contract versions are not supported",
+			target.Configuration.Version, target.Configuration.Contract, contractVersion)
+	}
+	latestHash, err := latest.Configuration.Hash()
+	if err != nil {
+		return err
+	}
+	targetHash, err := target.Configuration.Hash()
+	if err != nil {
+		return err
+	}
+	if latestHash == targetHash {
+		return fmt.Errorf("configuration version %d is identical to the current configuration version %d",
+			target.Configuration.Version, latest.Configuration.Version)
+	}
+	return nil
+}
+
+//newRollbackCluster rebuilds the cluster model of the latest cluster state with the Kyma configuration of
+//the target configuration version (the kubeconfig and metadata of the cluster are kept)
+func newRollbackCluster(latest, target *cluster.State) *keb.Cluster {
+	components := make([]keb.Component, 0, len(target.Configuration.Components))
+	for _, comp := range target.Configuration.Components {
+		if comp != nil {
+			components = append(components, *comp)
+		}
+	}
+	return &keb.Cluster{
+		Kubeconfig:  latest.Cluster.Kubeconfig,
+		Kubeconfigs: clusterKubeconfigs(latest),
+		KymaConfig: keb.KymaConfig{
+			Administrators: target.Configuration.Administrators,
+			Components:     components,
+			Profile:        target.Configuration.KymaProfile,
+			Version:        target.Configuration.KymaVersion,
+		},
+		Metadata:     *latest.Cluster.Metadata,
+		RuntimeID:    latest.Cluster.RuntimeID,
+		RuntimeInput: *latest.Cluster.Runtime,
+	}
+}
+
+//clusterKubeconfigs returns the further kubeconfigs of the cluster state in the representation of the cluster model
+func clusterKubeconfigs(clusterState *cluster.State) *map[string]string {
+	if len(clusterState.Cluster.Kubeconfigs) == 0 {
+		return nil
+	}
+	kubeconfigs := make(map[string]string, len(clusterState.Cluster.Kubeconfigs))
+	for name, kubeconfig := range clusterState.Cluster.Kubeconfigs {
+		kubeconfigs[name] = kubeconfig
+	}
+	return &kubeconfigs
+}
+
+func sendRollbackError(w http.ResponseWriter, err error, msg string) {
+	httpCode := http.StatusInternalServerError
+	if repository.IsNotFoundError(err) {
+		httpCode = http.StatusNotFound
+	}
+	server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+		Error: errors.Wrap(err, msg).Error(),
+	})
+}
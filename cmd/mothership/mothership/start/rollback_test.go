@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollback(t *testing.T) {
+	newState := func(configVersion, contract int64, kymaVersion string, status model.Status) *cluster.State {
+		return &cluster.State{
+			Cluster: &model.ClusterEntity{
+				RuntimeID:  "abc",
+				Version:    configVersion,
+				Kubeconfig: "kubeconfig" + kymaVersion,
+				Metadata:   &keb.Metadata{GlobalAccountID: "ga"},
+				Runtime:    &keb.RuntimeInput{Name: "runtime"},
+				Contract:   contract,
+			},
+			Configuration: &model.ClusterConfigurationEntity{
+				RuntimeID:      "abc",
+				Version:        configVersion,
+				KymaVersion:    kymaVersion,
+				KymaProfile:    "evaluation",
+				Components:     []*keb.Component{{Component: "istio", Version: kymaVersion}},
+				Administrators: []string{"admin"},
+				Contract:       contract,
+			},
+			Status: &model.ClusterStatusEntity{
+				RuntimeID: "abc",
+				Status:    status,
+			},
+		}
+	}
+
+	t.Run("Validate rollback", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			latest  *cluster.State
+			target  *cluster.State
+			wantErr bool
+		}{
+			{
+				name:   "Rollback to different configuration",
+				latest: newState(2, 1, "2.0.0", model.ClusterStatusReconcileError),
+				target: newState(1, 1, "1.0.0", model.ClusterStatusReady),
+			},
+			{
+				name:    "Rollback across contract versions",
+				latest:  newState(2, 2, "2.0.0", model.ClusterStatusReady),
+				target:  newState(1, 1, "1.0.0", model.ClusterStatusReady),
+				wantErr: true,
+			},
+			{
+				name:    "Rollback to identical configuration",
+				latest:  newState(2, 1, "1.0.0", model.ClusterStatusReady),
+				target:  newState(1, 1, "1.0.0", model.ClusterStatusReady),
+				wantErr: true,
+			},
+			{
+				name:    "Rollback of deleting cluster",
+				latest:  newState(2, 1, "2.0.0", model.ClusterStatusDeleting),
+				target:  newState(1, 1, "1.0.0", model.ClusterStatusReady),
+				wantErr: true,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := validateRollback(1, tt.latest, tt.target)
+				if tt.wantErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+			})
+		}
+	})
+
+	t.Run("Rollback keeps cluster data", func(t *testing.T) {
+		latest := newState(2, 1, "2.0.0", model.ClusterStatusReady)
+		target := newState(1, 1, "1.0.0", model.ClusterStatusReady)
+		rollback := newRollbackCluster(latest, target)
+		require.Equal(t, "abc", rollback.RuntimeID)
+		require.Equal(t, "kubeconfig2.0.0", rollback.Kubeconfig)
+		require.Equal(t, *latest.Cluster.Metadata, rollback.Metadata)
+		require.Equal(t, *latest.Cluster.Runtime, rollback.RuntimeInput)
+		require.Equal(t, "1.0.0", rollback.KymaConfig.Version)
+		require.Equal(t, "evaluation", rollback.KymaConfig.Profile)
+		require.Equal(t, []string{"admin"}, rollback.KymaConfig.Administrators)
+		require.Equal(t, []keb.Component{{Component: "istio", Version: "1.0.0"}}, rollback.KymaConfig.Components)
+	})
+}
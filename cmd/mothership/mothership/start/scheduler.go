@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/service"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/worker"
 	"github.com/spf13/viper"
@@ -19,9 +20,27 @@ func startScheduler(ctx context.Context, o *Options) error {
 	if err != nil {
 		return err
 	}
+	aggregationPolicy, err := service.NewAggregationPolicy(o.Config.Scheduler.Aggregation)
+	if err != nil {
+		return err
+	}
+	weights, err := reconciliation.NewComponentWeights(o.Config.Scheduler.ComponentWeights)
+	if err != nil {
+		return err
+	}
 
-	return runtimeBuilder.
+	runRemote := runtimeBuilder.
 		RunRemote(o.Registry.Connection(), o.Registry.Inventory(), o.Registry.OccupancyRepository(), o.Config).
+		WithPauseRepository(o.Registry.PauseRepository()).
+		WithFlakinessClassifier(o.FlakinessClassifier).
+		WithKubeconfigIssuer(o.KubeconfigIssuer).
+		WithTakeoverGuard(o.TakeoverGuard).
+		WithBackpressure(o.Backpressure)
+	if o.Config.Scheduler.DeadLetter.Enabled {
+		runRemote.WithDeadLetterRepository(o.Registry.DeadLetterRepository())
+	}
+
+	return runRemote.
 		WithWorkerPoolConfig(&worker.Config{
 			MaxParallelOperations: o.MaxParallelOperations,
 			PoolSize:              o.Workers,
@@ -30,6 +49,7 @@ func startScheduler(ctx context.Context, o *Options) error {
 			OperationCheckInterval: 30 * time.Second,
 			InvokerMaxRetries:      2,
 			InvokerRetryDelay:      10 * time.Second,
+			ComponentWeights:       weights,
 		}).
 		WithSchedulerConfig(
 			&service.SchedulerConfig{
@@ -38,10 +58,14 @@ func startScheduler(ctx context.Context, o *Options) error {
 				ClusterQueueSize:         10,
 				DeleteStrategy:           ds,
 				PreComponents:            o.Config.Scheduler.PreComponents,
+				Cohorts:                  o.Cohorts,
+				Heartbeat:                o.SchedulerHeartbeat,
 			}).
 		WithBookkeeperConfig(&service.BookkeeperConfig{
-			OperationsWatchInterval: o.BookkeeperWatchInterval,
-			OrphanOperationTimeout:  o.OrphanOperationTimeout,
+			OperationsWatchInterval:  o.BookkeeperWatchInterval,
+			OrphanOperationTimeout:   o.OrphanOperationTimeout,
+			AggregationPolicy:        aggregationPolicy,
+			DeadLetterAlertThreshold: o.Config.Scheduler.DeadLetter.AlertThreshold,
 		}).
 		WithCleanerConfig(&service.CleanerConfig{
 			PurgeEntitiesOlderThan:     o.PurgeEntitiesOlderThan,
@@ -50,6 +74,7 @@ func startScheduler(ctx context.Context, o *Options) error {
 			MaxReconciliationsAgeDays:  uintOrDie(o.ReconciliationsMaxAgeDays),
 			MaxInventoryAgeDays:        uintOrDie(o.InventoryMaxAgeDays),
 			StatusCleanupBatchSize:     uintOrDie(o.StatusCleanupBatchSize),
+			KeepDeletedClusters:        o.KeepDeletedClusters,
 		}).
 		Run(ctx)
 }
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/slo"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//getSLOs returns the compliance of the components with their service level objectives
+func getSLOs(o *Options, w http.ResponseWriter, _ *http.Request) {
+	reports, err := slo.EvaluateAll(o.SLOs, o.Registry.ReconciliationRepository(), time.Now().UTC())
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to evaluate SLOs").Error(),
+		})
+		return
+	}
+
+	resp := keb.HTTPSLOResponse{}
+	for _, report := range reports {
+		resp = append(resp, newComponentSLOResponse(report))
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode SLO response").Error(),
+		})
+	}
+}
+
+func newComponentSLOResponse(report *slo.Report) keb.ComponentSLO {
+	return keb.ComponentSLO{
+		BurnRate:             report.BurnRate,
+		Compliance:           report.Compliance,
+		Component:            report.Component,
+		ErrorBudgetRemaining: report.ErrorBudgetRemaining,
+		GoodOperations:       int64(report.GoodOperations),
+		LatencySeconds:       int64(report.Latency.Seconds()),
+		Operations:           int64(report.Operations),
+		Target:               report.Target,
+		WindowSeconds:        int64(report.Window.Seconds()),
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//clusterSnapshots returns the snapshots of the facts of a cluster which were captured after its reconciliations
+func clusterSnapshots(o *Options, w http.ResponseWriter, r *http.Request) {
+	runtimeID, err := server.NewParams(r).String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if _, err := o.Registry.Inventory().GetLatest(runtimeID); err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster").Error(),
+		})
+		return
+	}
+
+	snapshots, err := o.Registry.Inventory().GetSnapshots(runtimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve snapshots of cluster").Error(),
+		})
+		return
+	}
+
+	resp := keb.HTTPClusterSnapshotsResponse{
+		Cluster:   runtimeID,
+		Snapshots: []keb.ClusterSnapshot{},
+	}
+	for _, entity := range snapshots {
+		changes := []keb.FactChange{}
+		for _, change := range entity.Changes {
+			changes = append(changes, *change)
+		}
+		kymaCRDs := entity.KymaCrds
+		if kymaCRDs == nil {
+			kymaCRDs = []string{}
+		}
+		resp.Snapshots = append(resp.Snapshots, keb.ClusterSnapshot{
+			ConfigVersion:     entity.ClusterConfig,
+			Created:           entity.Created,
+			NodeCount:         entity.NodeCount,
+			KubernetesVersion: entity.KubernetesVersion,
+			KymaCRDs:          kymaCRDs,
+			Changes:           changes,
+		})
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode cluster snapshots response").Error(),
+		})
+	}
+}
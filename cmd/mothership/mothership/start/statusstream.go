@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+const (
+	//statusStreamHeartbeat is the interval of the keep-alive comments: the inventory is checked for status updates
+	//of other mothership replicas at the same interval
+	statusStreamHeartbeat = 30 * time.Second
+	statusStreamEvent     = "status"
+	headerLastEventID     = "Last-Event-ID"
+
+	//statusStreamCloseMargin is the time left to close a status stream before the write timeout is reached
+	statusStreamCloseMargin = 5 * time.Second
+)
+
+//streamClusterStatus pushes the status transitions of a cluster as server-sent events until the client disconnects
+//or the cluster gets deleted. The first event is the current status of the cluster.
+func streamClusterStatus(o *Options, w http.ResponseWriter, r *http.Request) {
+	runtimeID, err := server.NewParams(r).String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok || o.StatusBroadcaster == nil {
+		server.SendHTTPError(w, http.StatusNotImplemented, &keb.HTTPErrorResponse{
+			Error: "Streaming of status changes is not supported",
+		})
+		return
+	}
+
+	//subscribe before the current status is retrieved to avoid missing updates in between
+	updates, cancel := o.StatusBroadcaster.Subscribe(runtimeID)
+	defer cancel()
+
+	latest := func() (*cluster.State, error) {
+		return o.Registry.Inventory().GetLatest(runtimeID)
+	}
+	clusterState, err := latest()
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster state").Error(),
+		})
+		return
+	}
+
+	var lastID int64
+	if lastEventID := r.Header.Get(headerLastEventID); lastEventID != "" { //client reconnected
+		lastID, _ = strconv.ParseInt(lastEventID, 10, 64)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") //disable buffering of reverse proxies
+	w.WriteHeader(http.StatusOK)
+
+	ctx := r.Context()
+	if !server.DisableTimeouts(r) && o.ServerLimits.WriteTimeout > 0 {
+		//the stream can't be exempted from the write timeout (HTTP/2): close it in time, the client
+		//resumes it by reconnecting with the last event ID
+		var cancelStream context.CancelFunc
+		ctx, cancelStream = context.WithTimeout(ctx, streamDuration(o.ServerLimits.WriteTimeout))
+		defer cancelStream()
+	}
+
+	stream := &statusStream{w: w, flusher: flusher, lastID: lastID}
+	if err := stream.run(ctx, clusterState, updates, latest, statusStreamHeartbeat); err != nil {
+		o.Logger().Warnf("Status stream of cluster '%s' closed: %s", runtimeID, err)
+	}
+}
+
+//streamDuration returns how long a status stream is kept open if it's bound to the write timeout of the webserver
+func streamDuration(writeTimeout time.Duration) time.Duration {
+	if writeTimeout > 2*statusStreamCloseMargin {
+		return writeTimeout - statusStreamCloseMargin
+	}
+	return writeTimeout / 2
+}
+
+type statusStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	lastID  int64
+}
+
+func (s *statusStream) run(ctx context.Context, current *cluster.State, updates <-chan *cluster.State,
+	latest func() (*cluster.State, error), heartbeat time.Duration) error {
+	if done, err := s.send(current); done || err != nil {
+		return err
+	}
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if done, err := s.send(update); done || err != nil {
+				return err
+			}
+		case <-ticker.C:
+			//updates of other replicas are not broadcasted locally
+			state, err := latest()
+			if err != nil {
+				if repository.IsNotFoundError(err) {
+					return nil
+				}
+				return err
+			}
+			if done, err := s.send(state); done || err != nil {
+				return err
+			}
+			if _, err := fmt.Fprint(s.w, ": keep-alive\n\n"); err != nil {
+				return err
+			}
+			s.flusher.Flush()
+		}
+	}
+}
+
+//send writes the status as event if it's newer than the last sent status. It returns true if the stream is
+//finished because the cluster was deleted.
+func (s *statusStream) send(state *cluster.State) (bool, error) {
+	if state == nil || state.Status == nil || state.Status.ID <= s.lastID {
+		return false, nil
+	}
+	status, err := state.Status.GetKEBClusterStatus()
+	if err != nil {
+		return false, err
+	}
+	data, err := json.Marshal(&keb.ClusterStatusEvent{
+		RuntimeID:      state.Status.RuntimeID,
+		ClusterVersion: state.Status.ClusterVersion,
+		ConfigVersion:  state.Status.ConfigVersion,
+		Status:         status,
+		Created:        state.Status.Created,
+	})
+	if err != nil {
+		return false, err
+	}
+	if _, err := fmt.Fprintf(s.w, "id: %d\nevent: %s\ndata: %s\n\n", state.Status.ID, statusStreamEvent, data); err != nil {
+		return false, err
+	}
+	s.flusher.Flush()
+	s.lastID = state.Status.ID
+	return state.Status.Status == model.ClusterStatusDeleted, nil
+}
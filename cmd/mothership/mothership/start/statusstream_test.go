@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusStream(t *testing.T) {
+	newState := func(id int64, status model.Status) *cluster.State {
+		return &cluster.State{Status: &model.ClusterStatusEntity{
+			ID:        id,
+			RuntimeID: "abc",
+			Status:    status,
+			Created:   time.Unix(1650000000, 0).UTC(),
+		}}
+	}
+	events := func(body string) []string {
+		return strings.Split(strings.TrimSpace(body), "\n\n")
+	}
+
+	t.Run("Stream status transitions until cluster is deleted", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		stream := &statusStream{w: recorder, flusher: recorder}
+		updates := make(chan *cluster.State, 4)
+		updates <- newState(1, model.ClusterStatusReconcilePending) //duplicate of the current status
+		updates <- newState(2, model.ClusterStatusReady)
+		updates <- newState(3, model.ClusterStatusDeleted)
+
+		err := stream.run(context.Background(), newState(1, model.ClusterStatusReconcilePending), updates, nil, time.Hour)
+		require.NoError(t, err)
+		sent := events(recorder.Body.String())
+		require.Len(t, sent, 3)
+		require.Equal(t, "id: 1\nevent: status\ndata: {\"clusterVersion\":0,\"configVersion\":0,"+
+			"\"created\":\"2022-04-15T05:20:00Z\",\"runtimeID\":\"abc\",\"status\":\"reconcile_pending\"}", sent[0])
+		require.Contains(t, sent[2], "\"status\":\"deleted\"")
+		require.True(t, recorder.Flushed)
+	})
+
+	t.Run("Resume after last event ID", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		stream := &statusStream{w: recorder, flusher: recorder, lastID: 2}
+		updates := make(chan *cluster.State, 1)
+		updates <- newState(3, model.ClusterStatusDeleted)
+
+		require.NoError(t, stream.run(context.Background(), newState(2, model.ClusterStatusReady), updates, nil, time.Hour))
+		sent := events(recorder.Body.String())
+		require.Len(t, sent, 1)
+		require.True(t, strings.HasPrefix(sent[0], "id: 3\n"))
+	})
+
+	t.Run("Poll inventory for updates of other replicas", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		stream := &statusStream{w: recorder, flusher: recorder}
+		ctx, cancel := context.WithCancel(context.Background())
+		polls := 0
+		latest := func() (*cluster.State, error) {
+			polls++
+			if polls == 2 {
+				cancel()
+			}
+			return newState(2, model.ClusterStatusReconciling), nil
+		}
+
+		err := stream.run(ctx, newState(1, model.ClusterStatusReconcilePending), make(chan *cluster.State), latest,
+			time.Millisecond)
+		require.NoError(t, err)
+		sent := events(recorder.Body.String())
+		require.GreaterOrEqual(t, len(sent), 3)
+		require.Contains(t, sent[1], "\"status\":\"reconciling\"")
+		require.Equal(t, ": keep-alive", sent[2])
+	})
+
+	t.Run("Stream is closed before the write timeout", func(t *testing.T) {
+		require.Equal(t, 2*time.Minute-statusStreamCloseMargin, streamDuration(2*time.Minute))
+		require.Equal(t, 4*time.Second, streamDuration(8*time.Second))
+	})
+}
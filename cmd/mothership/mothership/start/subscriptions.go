@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/kyma-incubator/reconciler/pkg/subscription"
+	"github.com/pkg/errors"
+)
+
+const paramSubscriptionID = "subscriptionID"
+
+func getSubscriptions(o *Options, w http.ResponseWriter, _ *http.Request) {
+	subscriptions, err := o.Registry.SubscriptionRepository().GetAll()
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to retrieve subscriptions").Error(),
+		})
+		return
+	}
+	resp := keb.HTTPSubscriptionsResponse{}
+	for _, entity := range subscriptions {
+		resp = append(resp, newSubscriptionResponse(entity))
+	}
+	sendSubscriptionResponse(w, http.StatusOK, resp)
+}
+
+//postSubscription registers a webhook which receives the status changes of clusters
+func postSubscription(o *Options, w http.ResponseWriter, r *http.Request) {
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes))
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	var body keb.Subscription
+	if err := json.Unmarshal(payload, &body); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	var runtimeID string
+	if body.RuntimeID != nil {
+		runtimeID = *body.RuntimeID
+	}
+	entity, err := o.Registry.SubscriptionRepository().Create(body.Url, body.Secret, runtimeID)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if subscription.IsInvalidSubscriptionError(err) {
+			httpCode = http.StatusBadRequest
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to create subscription").Error(),
+		})
+		return
+	}
+	invalidateSubscriptions(o)
+	o.Logger().Infof("Subscription '%s' for URL '%s' created by '%s'", entity.ID, entity.URL, requestUser(r))
+	sendSubscriptionResponse(w, http.StatusCreated, newSubscriptionResponse(entity))
+}
+
+func deleteSubscription(o *Options, w http.ResponseWriter, r *http.Request) {
+	id, err := server.NewParams(r).String(paramSubscriptionID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Subscription ID undefined").Error(),
+		})
+		return
+	}
+	if err := o.Registry.SubscriptionRepository().Delete(id); err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrapf(err, "Failed to delete subscription '%s'", id).Error(),
+		})
+		return
+	}
+	invalidateSubscriptions(o)
+	o.Logger().Infof("Subscription '%s' deleted by '%s'", id, requestUser(r))
+	w.WriteHeader(http.StatusOK)
+}
+
+//invalidateSubscriptions lets the notifier of this replica pick up the changed subscriptions immediately
+func invalidateSubscriptions(o *Options) {
+	if o.StatusNotifier != nil {
+		o.StatusNotifier.InvalidateSubscriptions()
+	}
+}
+
+//newSubscriptionResponse converts the subscription: the secret is never returned
+func newSubscriptionResponse(entity *model.SubscriptionEntity) keb.HTTPSubscriptionResponse {
+	resp := keb.HTTPSubscriptionResponse{
+		Id:      entity.ID,
+		Url:     entity.URL,
+		Created: entity.Created,
+	}
+	if entity.RuntimeID != "" {
+		runtimeID := entity.RuntimeID
+		resp.RuntimeID = &runtimeID
+	}
+	return resp
+}
+
+func sendSubscriptionResponse(w http.ResponseWriter, httpCode int, resp interface{}) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(httpCode)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode subscription response").Error(),
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/configtemplate"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/policy"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+const (
+	paramTemplateName = "name"
+	paramFanOut       = "fanOut"
+)
+
+func getConfigTemplates(o *Options, w http.ResponseWriter, _ *http.Request) {
+	templates, err := o.Registry.ConfigTemplateRepository().GetAll()
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to retrieve configuration templates").Error(),
+		})
+		return
+	}
+	resp := keb.HTTPConfigTemplatesResponse{}
+	for _, template := range templates {
+		templateResp, err := newConfigTemplateResponse(o, template)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrapf(err, "Failed to retrieve clusters referencing configuration template '%s'",
+					template.Name).Error(),
+			})
+			return
+		}
+		resp = append(resp, templateResp)
+	}
+	sendConfigTemplateResponse(w, http.StatusOK, resp)
+}
+
+func getConfigTemplate(o *Options, w http.ResponseWriter, r *http.Request) {
+	name, err := server.NewParams(r).String(paramTemplateName)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Template name undefined").Error(),
+		})
+		return
+	}
+	template, err := o.Registry.ConfigTemplateRepository().Get(name)
+	if err != nil {
+		sendConfigTemplateError(w, err, fmt.Sprintf("Failed to retrieve configuration template '%s'", name))
+		return
+	}
+	resp, err := newConfigTemplateResponse(o, template)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrapf(err, "Failed to retrieve clusters referencing configuration template '%s'", name).Error(),
+		})
+		return
+	}
+	sendConfigTemplateResponse(w, http.StatusOK, resp)
+}
+
+//putConfigTemplate creates or updates a template. With the fan-out parameter, all clusters referencing the
+//template get a new configuration version which merges the updated template with their overrides: the fan-out
+//runs in the background and the update is answered with 202.
+func putConfigTemplate(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	name, err := params.String(paramTemplateName)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Template name undefined").Error(),
+		})
+		return
+	}
+	var fanOut bool
+	if fanOutParam, err := params.String(paramFanOut); err == nil && fanOutParam != "" {
+		if fanOut, err = strconv.ParseBool(fanOutParam); err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+				Error: fmt.Sprintf("Parameter '%s' has to be a boolean but was '%s'", paramFanOut, fanOutParam),
+			})
+			return
+		}
+	}
+
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes))
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	var configTemplate keb.ConfigTemplate
+	if err := json.Unmarshal(payload, &configTemplate); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	if len(configTemplate.Components) == 0 {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("Configuration template '%s' has no components", name),
+		})
+		return
+	}
+	if err := keb.ValidateExecutionHints(configTemplate.Components); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	components := make([]*keb.Component, 0, len(configTemplate.Components))
+	for idx := range configTemplate.Components {
+		components = append(components, &configTemplate.Components[idx])
+	}
+	template, err := o.Registry.ConfigTemplateRepository().CreateOrUpdate(name, components)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrapf(err, "Failed to store configuration template '%s'", name).Error(),
+		})
+		return
+	}
+	o.Logger().Infof("Configuration template '%s' updated by '%s'", name, requestUser(r))
+
+	resp, err := newConfigTemplateResponse(o, template)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrapf(err, "Failed to retrieve clusters referencing configuration template '%s'", name).Error(),
+		})
+		return
+	}
+	httpCode := http.StatusOK
+	if fanOut {
+		o.TemplateFanOut.Trigger(name)
+		httpCode = http.StatusAccepted
+	}
+	sendConfigTemplateResponse(w, httpCode, resp)
+}
+
+func deleteConfigTemplate(o *Options, w http.ResponseWriter, r *http.Request) {
+	name, err := server.NewParams(r).String(paramTemplateName)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Template name undefined").Error(),
+		})
+		return
+	}
+	if err := o.Registry.ConfigTemplateRepository().Delete(name); err != nil {
+		sendConfigTemplateError(w, err, fmt.Sprintf("Failed to delete configuration template '%s'", name))
+		return
+	}
+	o.Logger().Infof("Configuration template '%s' deleted by '%s'", name, requestUser(r))
+	w.WriteHeader(http.StatusOK)
+}
+
+//resolveConfigTemplate merges the template referenced by the cluster with the components of the cluster and
+//returns the components sent by KEB (overrides) which have to be stored as reference after the update
+func resolveConfigTemplate(o *Options, clusterModel *keb.Cluster) ([]*keb.Component, error) {
+	if clusterModel.KymaConfig.Template == nil || *clusterModel.KymaConfig.Template == "" {
+		return nil, nil
+	}
+	template, err := o.Registry.ConfigTemplateRepository().Get(*clusterModel.KymaConfig.Template)
+	if err != nil {
+		return nil, err
+	}
+	overrides := make([]*keb.Component, 0, len(clusterModel.KymaConfig.Components))
+	for idx := range clusterModel.KymaConfig.Components {
+		comp := clusterModel.KymaConfig.Components[idx]
+		overrides = append(overrides, &comp)
+	}
+	clusterModel.KymaConfig.Components = configtemplate.Merge(template.Components, clusterModel.KymaConfig.Components)
+	return overrides, nil
+}
+
+//updateConfigTemplateRef keeps the reference of a cluster to its template in sync with the last update of the cluster
+func updateConfigTemplateRef(o *Options, clusterModel *keb.Cluster, overrides []*keb.Component) error {
+	if clusterModel.KymaConfig.Template == nil || *clusterModel.KymaConfig.Template == "" {
+		return o.Registry.ConfigTemplateRepository().Unassign(clusterModel.RuntimeID)
+	}
+	return o.Registry.ConfigTemplateRepository().Assign(clusterModel.RuntimeID, *clusterModel.KymaConfig.Template, overrides)
+}
+
+//fanOutConfigTemplate creates a new configuration version for each cluster referencing the template and
+//returns the runtime IDs of the updated clusters (clusters with an unchanged configuration or whose configuration
+//is rejected by the policies or the validation webhook are not updated)
+func fanOutConfigTemplate(ctx context.Context, o *Options, template *model.ConfigTemplateEntity) ([]string, error) {
+	refs, err := o.Registry.ConfigTemplateRepository().References(template.Name)
+	if err != nil {
+		return nil, err
+	}
+	updated := []string{}
+	for _, ref := range refs {
+		clusterState, err := o.Registry.Inventory().GetLatest(ref.RuntimeID)
+		if err != nil {
+			if repository.IsNotFoundError(err) { //cluster was deleted in the meantime
+				if err := o.Registry.ConfigTemplateRepository().Unassign(ref.RuntimeID); err != nil {
+					return updated, err
+				}
+				continue
+			}
+			return updated, err
+		}
+		if clusterState.Status.Status.IsDeletionInProgress() || clusterState.Status.Status.IsDeleteCandidate() {
+			o.Logger().Infof("Skipping fan-out of configuration template '%s' to cluster '%s' (status: %s)",
+				template.Name, ref.RuntimeID, clusterState.Status.Status)
+			continue
+		}
+
+		clusterModel := templateCluster(clusterState, template, ref)
+		if err := clusterModel.ValidateTargets(); err != nil {
+			o.Logger().Warnf("Skipping fan-out of configuration template '%s' to cluster '%s': %s",
+				template.Name, ref.RuntimeID, err)
+			continue
+		}
+		warnings, err := admitCluster(ctx, o, policy.OperationUpdate, clusterState.Cluster.Contract, clusterModel)
+		if err != nil {
+			if isAdmissionRejectionError(err) {
+				o.Logger().Warnf("Skipping fan-out of configuration template '%s' to cluster '%s': %s",
+					template.Name, ref.RuntimeID, err)
+				continue
+			}
+			return updated, err
+		}
+		clusterStateNew, err := o.Registry.Inventory().CreateOrUpdate(clusterState.Cluster.Contract, clusterModel)
+		if err != nil {
+			return updated, err
+		}
+		if err := updateConfigWarnings(o, ref.RuntimeID, clusterStateNew.Configuration.Version, warnings); err != nil {
+			return updated, err
+		}
+		if clusterStateNew.Configuration.Version == clusterState.Configuration.Version {
+			continue
+		}
+		if clusterState.Status.Status.IsDisabled() {
+			if _, err := o.Registry.Inventory().UpdateStatus(clusterStateNew, model.ClusterStatusReconcileDisabled); err != nil {
+				return updated, err
+			}
+		}
+		updated = append(updated, ref.RuntimeID)
+	}
+	o.Logger().Infof("Fan-out of configuration template '%s' created new configuration versions for %d of %d clusters",
+		template.Name, len(updated), len(refs))
+	return updated, nil
+}
+
+//templateCluster rebuilds the cluster model of the latest cluster state with the merged template components
+func templateCluster(clusterState *cluster.State, template *model.ConfigTemplateEntity,
+	ref *model.ConfigTemplateRefEntity) *keb.Cluster {
+	overrides := make([]keb.Component, 0, len(ref.Overrides))
+	for _, override := range ref.Overrides {
+		if override != nil {
+			overrides = append(overrides, *override)
+		}
+	}
+	templateName := template.Name
+	return &keb.Cluster{
+		Kubeconfig:  clusterState.Cluster.Kubeconfig,
+		Kubeconfigs: clusterKubeconfigs(clusterState),
+		KymaConfig: keb.KymaConfig{
+			Administrators: clusterState.Configuration.Administrators,
+			Components:     configtemplate.Merge(template.Components, overrides),
+			Profile:        clusterState.Configuration.KymaProfile,
+			Template:       &templateName,
+			Version:        clusterState.Configuration.KymaVersion,
+		},
+		Metadata:     *clusterState.Cluster.Metadata,
+		RuntimeID:    clusterState.Cluster.RuntimeID,
+		RuntimeInput: *clusterState.Cluster.Runtime,
+	}
+}
+
+func newConfigTemplateResponse(o *Options, template *model.ConfigTemplateEntity) (keb.HTTPConfigTemplateResponse, error) {
+	refs, err := o.Registry.ConfigTemplateRepository().References(template.Name)
+	if err != nil {
+		return keb.HTTPConfigTemplateResponse{}, err
+	}
+	resp := keb.HTTPConfigTemplateResponse{
+		Name:       template.Name,
+		Components: []keb.Component{},
+		Clusters:   []string{},
+		Created:    template.Created,
+		Updated:    template.Updated,
+	}
+	for _, comp := range template.Components {
+		if comp != nil {
+			resp.Components = append(resp.Components, *comp)
+		}
+	}
+	for _, ref := range refs {
+		resp.Clusters = append(resp.Clusters, ref.RuntimeID)
+	}
+	return resp, nil
+}
+
+func sendConfigTemplateError(w http.ResponseWriter, err error, msg string) {
+	httpCode := http.StatusInternalServerError
+	if repository.IsNotFoundError(err) {
+		httpCode = http.StatusNotFound
+	} else if configtemplate.IsInUseError(err) {
+		httpCode = http.StatusConflict
+	}
+	server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+		Error: errors.Wrap(err, msg).Error(),
+	})
+}
+
+func sendConfigTemplateResponse(w http.ResponseWriter, httpCode int, resp interface{}) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(httpCode)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode configuration template response").Error(),
+		})
+	}
+}
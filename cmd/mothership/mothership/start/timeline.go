@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+var timelineEventTypes = []keb.TimelineEventType{
+	keb.TimelineEventTypeStatusChange,
+	keb.TimelineEventTypeReconciliationStarted,
+	keb.TimelineEventTypeReconciliationFinished,
+	keb.TimelineEventTypeOperationCreated,
+	keb.TimelineEventTypeOperationPickedUp,
+	keb.TimelineEventTypeOperationCallback,
+}
+
+//timelineFilter restricts the events of a cluster timeline to particular event types and/or a component
+type timelineFilter struct {
+	types     []keb.TimelineEventType
+	component string
+}
+
+func newTimelineFilter(types []string, component string) (*timelineFilter, error) {
+	filter := &timelineFilter{component: component}
+	for _, eventType := range types {
+		found := false
+		for _, knownType := range timelineEventTypes {
+			if string(knownType) == eventType {
+				filter.types = append(filter.types, knownType)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("timeline event type '%s' is not supported (supported types are %v)",
+				eventType, timelineEventTypes)
+		}
+	}
+	return filter, nil
+}
+
+func (f *timelineFilter) matches(event keb.TimelineEvent) bool {
+	if f.component != "" && (event.Component == nil || *event.Component != f.component) {
+		return false
+	}
+	if len(f.types) == 0 {
+		return true
+	}
+	for _, eventType := range f.types {
+		if event.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+//newTimeline merges cluster status changes, reconciliations and their operations into one chronological event list
+func newTimeline(statusChanges []*cluster.StatusChange, recons []*model.ReconciliationEntity,
+	ops []*model.OperationEntity, filter *timelineFilter) ([]keb.TimelineEvent, error) {
+	events := []keb.TimelineEvent{}
+	add := func(event keb.TimelineEvent) {
+		if filter == nil || filter.matches(event) {
+			events = append(events, event)
+		}
+	}
+
+	for _, statusChange := range statusChanges {
+		kebClusterStatus, err := statusChange.Status.GetKEBClusterStatus()
+		if err != nil {
+			return nil, err
+		}
+		add(keb.TimelineEvent{
+			Time:  statusChange.Status.Created,
+			Type:  keb.TimelineEventTypeStatusChange,
+			State: toStrPtr(string(kebClusterStatus)),
+		})
+	}
+
+	for _, recon := range recons {
+		schedulingID := recon.SchedulingID
+		add(keb.TimelineEvent{
+			Time:         recon.Created,
+			Type:         keb.TimelineEventTypeReconciliationStarted,
+			SchedulingID: &schedulingID,
+		})
+		if recon.Finished {
+			add(keb.TimelineEvent{
+				Time:         recon.Updated,
+				Type:         keb.TimelineEventTypeReconciliationFinished,
+				State:        toStrPtr(string(recon.Status)),
+				SchedulingID: &schedulingID,
+			})
+		}
+	}
+
+	for _, op := range ops {
+		schedulingID := op.SchedulingID
+		correlationID := op.CorrelationID
+		component := op.Component
+		add(keb.TimelineEvent{
+			Time:          op.Created,
+			Type:          keb.TimelineEventTypeOperationCreated,
+			State:         toStrPtr(string(model.OperationStateNew)),
+			SchedulingID:  &schedulingID,
+			CorrelationID: &correlationID,
+			Component:     &component,
+		})
+		if !op.PickedUp.IsZero() {
+			add(keb.TimelineEvent{
+				Time:          op.PickedUp,
+				Type:          keb.TimelineEventTypeOperationPickedUp,
+				State:         toStrPtr(string(model.OperationStateInProgress)),
+				SchedulingID:  &schedulingID,
+				CorrelationID: &correlationID,
+				Component:     &component,
+			})
+		}
+		if op.State != model.OperationStateNew {
+			//the latest state of an operation is reported by the component reconciler through the callback endpoint
+			event := keb.TimelineEvent{
+				Time:          op.Updated,
+				Type:          keb.TimelineEventTypeOperationCallback,
+				State:         toStrPtr(string(op.State)),
+				SchedulingID:  &schedulingID,
+				CorrelationID: &correlationID,
+				Component:     &component,
+			}
+			if op.Reason != "" {
+				event.Reason = toStrPtr(op.Reason)
+			}
+			add(event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events, nil
+}
+
+func toStrPtr(value string) *string {
+	return &value
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeline(t *testing.T) {
+	now := time.Now().UTC()
+
+	statusChanges := []*cluster.StatusChange{
+		{Status: &model.ClusterStatusEntity{Status: model.ClusterStatusReconcilePending, Created: now.Add(-10 * time.Minute)}},
+		{Status: &model.ClusterStatusEntity{Status: model.ClusterStatusReady, Created: now.Add(-1 * time.Minute)}},
+	}
+	recons := []*model.ReconciliationEntity{
+		{SchedulingID: "s1", Created: now.Add(-9 * time.Minute), Updated: now.Add(-2 * time.Minute), Finished: true, Status: model.ClusterStatusReady},
+	}
+	ops := []*model.OperationEntity{
+		{SchedulingID: "s1", CorrelationID: "c1", Component: "comp1", State: model.OperationStateDone,
+			Created: now.Add(-8 * time.Minute), PickedUp: now.Add(-7 * time.Minute), Updated: now.Add(-5 * time.Minute)},
+		{SchedulingID: "s1", CorrelationID: "c2", Component: "comp2", State: model.OperationStateNew,
+			Created: now.Add(-8 * time.Minute)},
+	}
+
+	t.Run("Events are merged in chronological order", func(t *testing.T) {
+		events, err := newTimeline(statusChanges, recons, ops, nil)
+		require.NoError(t, err)
+		require.Len(t, events, 8)
+		for i := 1; i < len(events); i++ {
+			require.False(t, events[i].Time.Before(events[i-1].Time))
+		}
+		require.Equal(t, keb.TimelineEventTypeStatusChange, events[0].Type)
+		require.Equal(t, keb.TimelineEventTypeReconciliationStarted, events[1].Type)
+		require.Equal(t, keb.TimelineEventTypeStatusChange, events[len(events)-1].Type)
+	})
+
+	t.Run("Filter by event type and component", func(t *testing.T) {
+		filter, err := newTimelineFilter([]string{string(keb.TimelineEventTypeOperationCallback)}, "comp1")
+		require.NoError(t, err)
+		events, err := newTimeline(statusChanges, recons, ops, filter)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, "c1", *events[0].CorrelationID)
+		require.Equal(t, string(model.OperationStateDone), *events[0].State)
+	})
+
+	t.Run("Unsupported event type", func(t *testing.T) {
+		_, err := newTimelineFilter([]string{"foo"}, "")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//getOperationTrace returns the detailed capture of an operation which exceeded the latency threshold of its
+//component reconciler
+func getOperationTrace(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	entity, err := o.Registry.TraceRepository().Get(schedulingID, correlationID)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve trace of operation").Error(),
+		})
+		return
+	}
+
+	resp := keb.HTTPOperationTraceResponse{
+		SchedulingID:  entity.SchedulingID,
+		CorrelationID: entity.CorrelationID,
+		RuntimeID:     entity.RuntimeID,
+		Component:     entity.Component,
+		Created:       entity.Created,
+		Trace:         *entity.Trace,
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode operation trace response").Error(),
+		})
+	}
+}
+
+//updateOperationTrace stores the detailed capture which was reported with the final status of an operation
+func updateOperationTrace(o *Options, schedulingID, correlationID string, trace *reconciler.OperationTrace) error {
+	op, err := o.Registry.ReconciliationRepository().GetOperation(schedulingID, correlationID)
+	if err != nil {
+		return err
+	}
+	entity := &model.OperationTraceEntity{
+		SchedulingID:  schedulingID,
+		CorrelationID: correlationID,
+		RuntimeID:     op.RuntimeID,
+		Component:     op.Component,
+		Trace: &keb.OperationTrace{
+			Threshold: trace.Threshold,
+			Phases:    []keb.TracePhase{},
+			ApiCalls:  []keb.TraceAPICall{},
+			Logs:      []keb.TraceLog{},
+			Truncated: trace.Truncated,
+		},
+	}
+	for _, phase := range trace.Phases {
+		entity.Trace.Phases = append(entity.Trace.Phases, keb.TracePhase{
+			Name:     phase.Name,
+			Started:  phase.Started,
+			Duration: phase.Duration,
+			Error:    phase.Error,
+		})
+	}
+	for _, call := range trace.ApiCalls {
+		entity.Trace.ApiCalls = append(entity.Trace.ApiCalls, keb.TraceAPICall{
+			Method:     call.Method,
+			Path:       call.Path,
+			StatusCode: call.StatusCode,
+			Started:    call.Started,
+			Duration:   call.Duration,
+		})
+	}
+	for _, log := range trace.Logs {
+		entity.Trace.Logs = append(entity.Trace.Logs, keb.TraceLog{
+			Time:    log.Time,
+			Level:   log.Level,
+			Message: log.Message,
+		})
+	}
+	if trace.Stacks != nil {
+		stacks := make([]keb.TraceStack, 0, len(*trace.Stacks))
+		for _, stack := range *trace.Stacks {
+			stacks = append(stacks, keb.TraceStack{
+				Sampled:    stack.Sampled,
+				Running:    stack.Running,
+				Goroutines: stack.Goroutines,
+				Stack:      stack.Stack,
+			})
+		}
+		entity.Trace.Stacks = &stacks
+	}
+	return o.Registry.TraceRepository().Store(entity)
+}
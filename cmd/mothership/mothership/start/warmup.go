@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/warmup"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+//warmupComponentReconcilers announces the Kyma versions of a planned rollout to the component reconcilers: they
+//pre-fetch the charts before the first operations of the rollout arrive
+func warmupComponentReconcilers(o *Options, w http.ResponseWriter, r *http.Request) {
+	warmupReq := &keb.Warmup{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)).Decode(warmupReq); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	var versions []string
+	for _, version := range warmupReq.KymaVersions {
+		if version = strings.TrimSpace(version); version != "" {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: "At least one Kyma version is required for the warm-up",
+		})
+		return
+	}
+
+	results := warmup.NewNotifier(o.Config.Scheduler.Reconcilers, o.Logger()).Notify(r.Context(), versions)
+	resp := keb.HTTPWarmupResponse{}
+	for _, result := range results {
+		notification := keb.WarmupNotification{
+			Component: result.Component,
+			Notified:  result.Err == nil,
+			Url:       result.URL,
+		}
+		if result.Err != nil {
+			errMsg := result.Err.Error()
+			notification.Error = &errMsg
+		}
+		resp = append(resp, notification)
+	}
+	o.Logger().Infof("Announced warm-up of Kyma versions %v to %d component reconcilers", versions, len(results))
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode warm-up response").Error(),
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/payload"
+	"github.com/kyma-incubator/reconciler/pkg/redact"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const replayTimeout = 1 * time.Minute
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay a stored KEB payload",
+		Long: `Load a KEB payload which was stored by a mothership reconciler started with '--persist-payloads'.
+Without a target, the payload is converted into the cluster model and printed to reproduce issues of the model conversion.
+With a target, the original payload is sent to the cluster API of the given mothership reconciler (e.g. a local instance).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.InitApplicationRegistry(true); err != nil {
+				return err
+			}
+			return Run(o)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.RuntimeID, "runtime-id", "", "Runtime ID of the cluster")
+	cmd.Flags().Int64Var(&o.ConfigVersion, "config-version", 0, "Configuration version the payload led to, 0 means the latest payload of the cluster")
+	cmd.Flags().StringVar(&o.Target, "target", "", "URL of the mothership reconciler which receives the payload (e.g. 'http://localhost:8080')")
+	cmd.Flags().BoolVar(&o.Raw, "raw", false, "Print the original payload instead of the converted cluster model")
+	cmd.Flags().BoolVar(&o.Unredacted, "unredacted", false, "Print sensitive data like kubeconfigs in plain text")
+
+	return cmd
+}
+
+func Run(o *Options) error {
+	entity, err := o.Registry.PayloadRepository().Get(o.RuntimeID, o.ConfigVersion)
+	if err != nil {
+		return err
+	}
+	data, err := payload.Decompress(entity.Payload)
+	if err != nil {
+		return err
+	}
+	o.Logger().Infof("Loaded payload of cluster '%s' (config version: %d, contract version: %d, received: %s)",
+		entity.RuntimeID, entity.ConfigVersion, entity.Contract, entity.Created)
+
+	if o.Target != "" {
+		return send(o, entity.Contract, data)
+	}
+
+	if !o.Raw {
+		clusterModel, err := keb.NewModelFactory(entity.Contract).Cluster(bytes.NewReader(data))
+		if err != nil {
+			return errors.Wrap(err, "failed to convert payload into cluster model")
+		}
+		if data, err = json.MarshalIndent(clusterModel, "", "  "); err != nil {
+			return err
+		}
+	}
+	if !o.Unredacted {
+		data = redact.JSON(data)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func send(o *Options, contractV int64, data []byte) error {
+	ctx, cancel := context.WithTimeout(cli.NewContext(), replayTimeout)
+	defer cancel()
+
+	targetURL := fmt.Sprintf("%s/v%d/clusters", strings.TrimSuffix(o.Target, "/"), contractV)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to send payload to '%s'", targetURL)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			o.Logger().Warnf("Failed to close response body: %s", err)
+		}
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read response of '%s'", targetURL)
+	}
+	fmt.Printf("Payload replayed to '%s': %s\n%s\n", targetURL, resp.Status, string(body))
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("target '%s' rejected the payload with status %d", targetURL, resp.StatusCode)
+	}
+	return nil
+}
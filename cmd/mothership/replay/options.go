@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+)
+
+type Options struct {
+	*cli.Options
+	RuntimeID     string
+	ConfigVersion int64
+	Target        string
+	Raw           bool
+	Unredacted    bool
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o,
+		"",    //RuntimeID
+		0,     //ConfigVersion
+		"",    //Target
+		false, //Raw
+		false, //Unredacted
+	}
+}
+
+func (o *Options) Validate() error {
+	if o.RuntimeID == "" {
+		return fmt.Errorf("runtime ID is undefined")
+	}
+	if o.ConfigVersion < 0 {
+		return fmt.Errorf("config version cannot be < 0")
+	}
+	if o.Target != "" {
+		if _, err := url.ParseRequestURI(o.Target); err != nil {
+			return fmt.Errorf("target '%s' is not a valid URL: %s", o.Target, err)
+		}
+	}
+	return nil
+}
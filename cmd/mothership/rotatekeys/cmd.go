@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-keys",
+		Short: "Re-encrypt kubeconfigs and KEB payloads with the current encryption key",
+		Long: `Re-encrypt the kubeconfigs and stored KEB payloads of all clusters with the encryption key configured in
+'db.encryption.keyFile'. The keys used so far have to be listed in 'db.encryption.previousKeyFiles' to decrypt
+existing kubeconfigs and payloads.
+The rotation runs online: mothership reconcilers which are configured with the same key files keep working
+during the rotation. Afterwards, the previous key files can be removed from the configuration.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.InitApplicationRegistry(true); err != nil {
+				return err
+			}
+			return Run(o)
+		},
+	}
+
+	cmd.Flags().IntVar(&o.BatchSize, "batch-size", 100, "Number of kubeconfigs which are loaded and re-encrypted at once")
+
+	return cmd
+}
+
+func Run(o *Options) error {
+	rotator := cluster.NewKubeconfigKeyRotator(o.Registry.Connection(), o.BatchSize, o.Logger())
+	result, err := rotator.Rotate(cli.NewContext())
+	if result != nil {
+		fmt.Printf("Kubeconfigs re-encrypted with key '%s': %d (failed: %d)\n",
+			o.Registry.Connection().Encryptor().KeyID(), result.Rotated, result.Failed)
+		fmt.Printf("Payloads re-encrypted with key '%s': %d (failed: %d)\n",
+			o.Registry.Connection().Encryptor().KeyID(), result.RotatedPayloads, result.FailedPayloads)
+	}
+	if err != nil {
+		return err
+	}
+	if result.Failed > 0 || result.FailedPayloads > 0 {
+		return fmt.Errorf("%d kubeconfigs and %d payloads could not be re-encrypted: verify that all former keys "+
+			"are configured as previous keys", result.Failed, result.FailedPayloads)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/cohort"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/simulation"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+//maxListedClusters limits the conflicts and missed intervals which are printed in the text output
+const maxListedClusters = 20
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate the reconciliation schedule of the fleet",
+		Long: `Simulate the scheduling of all clusters in the inventory for the given horizon (e.g. the next 24h).
+The simulation considers the reconcile interval, the maintenance windows and pauses of the cohorts ('mothership.scheduler.cohorts')
+and the capacity of the worker pool. The durations of the reconciliations are estimated by the finished reconciliations of each cluster.
+The report contains the expected load peaks, reconciliations conflicting with maintenance windows and clusters which will miss
+their interval. Use '--additional-clusters' to plan the capacity before onboarding new clusters (e.g. a new region).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.InitApplicationRegistry(true); err != nil {
+				return err
+			}
+			return Run(o)
+		},
+	}
+
+	cmd.Flags().DurationVar(&o.Horizon, "horizon", 24*time.Hour, "Simulated period")
+	cmd.Flags().DurationVar(&o.ReconcileInterval, "reconcile-interval", 5*time.Minute, "Time after which a cluster is reconciled again since its last reconciliation")
+	cmd.Flags().DurationVar(&o.WatchInterval, "watch-interval", 1*time.Minute, "Interval of the inventory watcher which picks up the due clusters")
+	cmd.Flags().DurationVar(&o.Tolerance, "tolerance", 0, "Delay after which a cluster counts as missing its interval, 0 means the watch interval")
+	cmd.Flags().IntVar(&o.Workers, "worker-count", 50, "Size of the reconciler worker pool")
+	cmd.Flags().IntVar(&o.MaxParallelOps, "max-parallel", 0, "Maximal parallel reconciled components per cluster, 0 means unlimited")
+	cmd.Flags().DurationVar(&o.History, "history", 7*24*time.Hour, "Period of finished reconciliations which are used to estimate the durations")
+	cmd.Flags().DurationVar(&o.DefaultDuration, "default-duration", 10*time.Minute, "Estimated duration of reconciliations if no cluster has finished reconciliations")
+	cmd.Flags().IntVar(&o.AdditionalClusters, "additional-clusters", 0, "Number of clusters which are onboarded at the start of the simulation")
+	cmd.Flags().StringToStringVar(&o.AdditionalLabels, "additional-labels", map[string]string{}, "Labels of the additional clusters which assign them to a cohort (e.g. 'region=eu-west-2')")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", outputText, "Output format ('text' or 'json')")
+
+	return cmd
+}
+
+func Run(o *Options) error {
+	schedulerCfg := &config.Config{}
+	if err := viper.UnmarshalKey("mothership", schedulerCfg); err != nil {
+		return err
+	}
+	cohorts, err := cohort.NewResolver(schedulerCfg.Scheduler.Cohorts)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC().Truncate(o.WatchInterval)
+	durations, err := meanDurations(o, now)
+	if err != nil {
+		return err
+	}
+	clusters, err := fleet(o, cohorts, durations, now)
+	if err != nil {
+		return err
+	}
+	report, err := simulation.Run(simulation.Config{
+		Start:     now,
+		Horizon:   o.Horizon,
+		Interval:  o.ReconcileInterval,
+		Workers:   o.Workers,
+		Step:      o.WatchInterval,
+		Tolerance: o.Tolerance,
+	}, clusters)
+	if err != nil {
+		return err
+	}
+
+	if o.Output == outputJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	printReport(report)
+	return nil
+}
+
+//meanDurations returns the mean duration of the finished reconciliations per runtime ID
+func meanDurations(o *Options, now time.Time) (map[string]time.Duration, error) {
+	recons, err := o.Registry.ReconciliationRepository().GetReconciliations(
+		&reconciliation.WithCreationDateAfter{Time: now.Add(-o.History)})
+	if err != nil {
+		return nil, err
+	}
+	totals := make(map[string]time.Duration)
+	counts := make(map[string]int64)
+	for _, recon := range recons {
+		if !recon.Finished || recon.Updated.Before(recon.Created) {
+			continue
+		}
+		totals[recon.RuntimeID] += recon.Updated.Sub(recon.Created)
+		counts[recon.RuntimeID]++
+	}
+	for runtimeID, total := range totals {
+		totals[runtimeID] = total / time.Duration(counts[runtimeID])
+	}
+	return totals, nil
+}
+
+//fleet converts the clusters of the inventory (and the additional clusters) into the simulated clusters: only
+//clusters which are reconciled periodically are considered
+func fleet(o *Options, cohorts *cohort.Resolver, durations map[string]time.Duration,
+	now time.Time) ([]*simulation.Cluster, error) {
+	states, err := o.Registry.Inventory().GetAll()
+	if err != nil {
+		return nil, err
+	}
+	var result []*simulation.Cluster
+	var totalDuration time.Duration
+	var totalWorkers int
+	for _, state := range states {
+		duration, ok := durations[state.Cluster.RuntimeID]
+		if !ok {
+			duration = o.DefaultDuration
+		}
+		var due time.Time
+		switch state.Status.Status {
+		case model.ClusterStatusReconcilePending:
+			due = now
+		case model.ClusterStatusReady, model.ClusterStatusReconcileErrorRetryable:
+			due = state.Status.Created.Add(o.ReconcileInterval)
+		case model.ClusterStatusReconciling: //running reconciliation is expected to take the usual time
+			due = state.Status.Created.Add(duration).Add(o.ReconcileInterval)
+		default:
+			continue
+		}
+		simCluster := newCluster(o, cohorts, state.Cluster.RuntimeID, state.Cluster.Metadata,
+			len(state.Configuration.Components))
+		simCluster.Due = due
+		simCluster.Duration = duration
+		result = append(result, simCluster)
+		totalDuration += duration
+		totalWorkers += simCluster.Workers
+	}
+
+	//additional clusters are expected to behave like the average cluster of the fleet
+	duration, workers := o.DefaultDuration, 1
+	if len(result) > 0 {
+		duration = totalDuration / time.Duration(len(result))
+		workers = totalWorkers / len(result)
+	}
+	labels := o.AdditionalLabels
+	for i := 0; i < o.AdditionalClusters; i++ {
+		simCluster := newCluster(o, cohorts, fmt.Sprintf("additional-%d", i+1), &keb.Metadata{Labels: &labels}, workers)
+		simCluster.Due = now
+		simCluster.Duration = duration
+		result = append(result, simCluster)
+	}
+	return result, nil
+}
+
+func newCluster(o *Options, cohorts *cohort.Resolver, runtimeID string, metadata *keb.Metadata,
+	components int) *simulation.Cluster {
+	//each component is processed by a worker, the parallel components are limited per cluster
+	workers := components
+	if o.MaxParallelOps > 0 && workers > o.MaxParallelOps {
+		workers = o.MaxParallelOps
+	}
+	if workers > o.Workers {
+		workers = o.Workers
+	}
+	simCluster := &simulation.Cluster{RuntimeID: runtimeID, Workers: workers}
+	if clusterCohort := cohorts.Resolve(metadata); clusterCohort != nil {
+		simCluster.Cohort = clusterCohort.Name
+		simCluster.Gate = clusterCohort
+	}
+	return simCluster
+}
+
+func printReport(report *simulation.Report) {
+	fmt.Printf("Simulated %d clusters with %d workers from %s to %s: %d reconciliations\n",
+		report.Clusters, report.Workers, report.Start.Format(time.RFC3339), report.End.Format(time.RFC3339),
+		report.Reconciliations)
+	fmt.Printf("Peak load at %s: %d busy workers, %d clusters waiting for workers, %d clusters waiting for their maintenance window\n\n",
+		report.Peak.Time.Format(time.RFC3339), report.Peak.BusyWorkers, report.Peak.Queued, report.Peak.Deferred)
+
+	fmt.Printf("%-22s %8s %12s %8s %9s\n", "HOUR", "STARTED", "BUSY WORKERS", "QUEUED", "DEFERRED")
+	for _, hour := range report.Hours {
+		fmt.Printf("%-22s %8d %12d %8d %9d\n",
+			hour.Time.Format(time.RFC3339), hour.Started, hour.BusyWorkers, hour.Queued, hour.Deferred)
+	}
+
+	fmt.Printf("\nConflicts with maintenance windows: %d\n", len(report.Conflicts))
+	for idx, conflict := range report.Conflicts {
+		if idx == maxListedClusters {
+			fmt.Printf("  ... (use '--output json' to list all conflicts)\n")
+			break
+		}
+		fmt.Printf("  %s (cohort '%s', due %s): %s\n",
+			conflict.RuntimeID, conflict.Cohort, conflict.Due.Format(time.RFC3339), conflict.Reason)
+	}
+
+	fmt.Printf("\nReconciliations missing their interval: %d\n", len(report.Missed))
+	for idx, miss := range report.Missed {
+		if idx == maxListedClusters {
+			fmt.Printf("  ... (use '--output json' to list all missed intervals)\n")
+			break
+		}
+		fmt.Printf("  %s (due %s): delayed by %s\n", miss.RuntimeID, miss.Due.Format(time.RFC3339), miss.Delay)
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+type Options struct {
+	*cli.Options
+	Horizon            time.Duration
+	ReconcileInterval  time.Duration
+	WatchInterval      time.Duration
+	Tolerance          time.Duration
+	Workers            int
+	MaxParallelOps     int
+	History            time.Duration
+	DefaultDuration    time.Duration
+	AdditionalClusters int
+	AdditionalLabels   map[string]string
+	Output             string
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o,
+		0 * time.Hour,       //Horizon
+		0 * time.Minute,     //ReconcileInterval
+		0 * time.Minute,     //WatchInterval
+		0 * time.Minute,     //Tolerance
+		0,                   //Workers
+		0,                   //MaxParallelOps
+		0 * time.Hour,       //History
+		0 * time.Minute,     //DefaultDuration
+		0,                   //AdditionalClusters
+		map[string]string{}, //AdditionalLabels
+		outputText,          //Output
+	}
+}
+
+func (o *Options) Validate() error {
+	if o.Horizon <= 0 {
+		return fmt.Errorf("horizon has to be > 0")
+	}
+	if o.ReconcileInterval <= 0 || o.WatchInterval <= 0 {
+		return fmt.Errorf("reconcile interval and watch interval have to be > 0")
+	}
+	if o.Tolerance < 0 {
+		return fmt.Errorf("tolerance cannot be < 0")
+	}
+	if o.Workers <= 0 {
+		return fmt.Errorf("worker count has to be > 0")
+	}
+	if o.MaxParallelOps < 0 {
+		return fmt.Errorf("max parallel operations cannot be < 0")
+	}
+	if o.History <= 0 || o.DefaultDuration <= 0 {
+		return fmt.Errorf("history and default duration have to be > 0")
+	}
+	if o.AdditionalClusters < 0 {
+		return fmt.Errorf("additional clusters cannot be < 0")
+	}
+	if o.Output != outputText && o.Output != outputJSON {
+		return fmt.Errorf("output '%s' is not supported: use '%s' or '%s'", o.Output, outputText, outputJSON)
+	}
+	return nil
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	scaffoldCmd "github.com/kyma-incubator/reconciler/cmd/reconciler/scaffold"
 	startCmd "github.com/kyma-incubator/reconciler/cmd/reconciler/start"
 	startSvcCmd "github.com/kyma-incubator/reconciler/cmd/reconciler/start/service"
 	testCmd "github.com/kyma-incubator/reconciler/cmd/reconciler/test"
@@ -11,6 +14,7 @@ import (
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	"github.com/kyma-incubator/reconciler/internal/cli/reconciler"
 	reconcilerRegistry "github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+	"github.com/kyma-incubator/reconciler/pkg/ssl"
 	"github.com/spf13/cobra"
 
 	//imports loader.go which ensures that all available component reconcilers are added to the reconciler registry:
@@ -41,6 +45,12 @@ func newCmd(o *cli.Options) *cobra.Command {
 		"Number of in parallel running reconciliation workers")
 	cmd.PersistentFlags().DurationVar(&reconcilerOpts.WorkerConfig.Timeout, "worker-timeout", defaultTimeout,
 		"Maximal time a worker will run before a reconciliation will be stopped")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.WorkerConfig.TraceThreshold, "trace-threshold", 0,
+		"Latency after which an operation is captured in detail (debug logs, phase timings and API calls) "+
+			"and the capture is reported to the mothership reconciler (disabled if 0)")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.WorkerConfig.StuckThreshold, "stuck-threshold", 0,
+		"Runtime after which an operation is suspected to be stuck and its goroutine stacks are sampled "+
+			"(default is the worker timeout)")
 
 	//REST API configuration
 	cmd.PersistentFlags().IntVar(&reconcilerOpts.ServerConfig.Port, "server-port", 8080,
@@ -49,6 +59,22 @@ func newCmd(o *cli.Options) *cobra.Command {
 		"Path to SSL certificate file used for secure REST API communication")
 	cmd.PersistentFlags().StringVar(&reconcilerOpts.ServerConfig.SSLKeyFile, "server-key", "",
 		"Path to SSL key file used for secure REST API communication")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.ServerConfig.CertReloadInterval, "server-cert-reload-interval", 30*time.Second,
+		"Interval for checking the SSL certificate and key file for changes: renewed certificates are used without a restart (0 disables the reload)")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.ServerConfig.TLS.MinVersion, "server-tls-min-version", ssl.DefaultMinVersion,
+		"Min. TLS version accepted by the REST API ('1.2' or '1.3')")
+	cmd.PersistentFlags().StringSliceVar(&reconcilerOpts.ServerConfig.TLS.CipherSuites, "server-tls-cipher-suite", nil,
+		fmt.Sprintf("TLS 1.2 cipher suite accepted by the REST API (repeatable, default are all of: %s)", strings.Join(ssl.SupportedCipherSuites(), ", ")))
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.ServerConfig.Limits.ReadHeaderTimeout, "server-read-header-timeout", 10*time.Second,
+		"Max. time to read the headers of a request (0 disables the timeout)")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.ServerConfig.Limits.ReadTimeout, "server-read-timeout", 30*time.Second,
+		"Max. time to read an entire request including its body (0 disables the timeout)")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.ServerConfig.Limits.WriteTimeout, "server-write-timeout", time.Minute,
+		"Max. time to write the response of a request (0 disables the timeout)")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.ServerConfig.Limits.IdleTimeout, "server-idle-timeout", 2*time.Minute,
+		"Max. time a keep-alive connection waits for the next request (0 disables the timeout)")
+	cmd.PersistentFlags().Int64Var(&reconcilerOpts.ServerConfig.Limits.MaxBodyBytes, "server-max-body-size", 5<<20,
+		"Max. size in bytes of a request body: larger requests are rejected with HTTP 413 (0 disables the limit)")
 
 	//retry configuration
 	cmd.PersistentFlags().IntVar(&reconcilerOpts.RetryConfig.MaxRetries, "retries-max", 5,
@@ -59,12 +85,55 @@ func newCmd(o *cli.Options) *cobra.Command {
 	//heartbeat-sender configuration
 	cmd.PersistentFlags().DurationVar(&reconcilerOpts.HeartbeatSenderConfig.Interval, "status-interval", 30*time.Second,
 		"Interval to report the latest reconciliation process status to the mothership reconciler")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.HeartbeatSenderConfig.MinInterval, "status-min-interval", 0,
+		"Shortest interval to report the status if the reconcile-timeout comes closer (enables adaptive status intervals)")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.HeartbeatSenderConfig.MaxInterval, "status-max-interval", 0,
+		"Longest interval to report the status during long stable waits (enables adaptive status intervals)")
 	reconcilerOpts.HeartbeatSenderConfig.Timeout = reconcilerOpts.WorkerConfig.Timeout //coupled to reconcile-timeout
 
+	//callback throttling
+	cmd.PersistentFlags().BoolVar(&reconcilerOpts.CallbackConfig.Batching, "callback-batching", false,
+		"Send status updates throttled and combined in batches to the mothership reconciler")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.CallbackConfig.BatchInterval, "callback-batch-interval", 500*time.Millisecond,
+		"Interval in which collected status updates are sent to the mothership reconciler")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.CallbackConfig.MaxBatchSize, "callback-batch-size", 50,
+		"Maximal number of status updates sent in one request")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.CallbackConfig.RequestsPerSecond, "callback-rate-limit", 10,
+		"Maximal number of requests per second sent to the mothership reconciler")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.CallbackConfig.MaxQueueSize, "callback-queue-size", 1000,
+		"Maximal number of waiting status updates: further status updates are rejected and retried with the next heartbeat")
+
+	//mothership client
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.CallbackConfig.Timeout, "callback-timeout", 30*time.Second,
+		"Timeout of a single request sent to the mothership reconciler")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.CallbackConfig.MaxRetries, "callback-retries", 3,
+		"Number of retries of requests which failed with a network error or a temporary error of the mothership reconciler")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.CallbackConfig.FailureThreshold, "callback-circuit-threshold", 5,
+		"Number of consecutive failed requests after which the mothership reconciler isn't called for a while")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.CallbackConfig.OpenTimeout, "callback-circuit-timeout", 30*time.Second,
+		"Duration the mothership reconciler isn't called after too many consecutive requests failed")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.CallbackConfig.TokenFile, "callback-token-file", "",
+		"Path to a file with a bearer token sent to the mothership reconciler (re-read per request)")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.CallbackConfig.CertFile, "callback-client-crt", "",
+		"Path to the client certificate file used to authenticate at the mothership reconciler")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.CallbackConfig.KeyFile, "callback-client-key", "",
+		"Path to the client key file used to authenticate at the mothership reconciler")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.CallbackConfig.CAFile, "callback-ca", "",
+		"Path to the CA file used to verify the server certificate of the mothership reconciler")
+
 	//progress-tracker configuration
 	cmd.PersistentFlags().DurationVar(&reconcilerOpts.ProgressTrackerConfig.Interval, "progress-interval", 15*time.Second,
 		"Interval to verify the installation progress of a deployed Kubernetes resource")
 	reconcilerOpts.ProgressTrackerConfig.Timeout = reconcilerOpts.WorkerConfig.Timeout //coupled to reconcile-timeout
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.EscalationConfig.Steps, "progress-escalations", "0.5=warn,0.8=notify",
+		"Escalation steps applied before the progress timeout is reached as 'threshold=action' pairs "+
+			"('warn' logs the unready resources, 'notify' sends a warning to the mothership reconciler)")
+
+	//runtime tuning
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.TuningConfig.ConfigMap, "tuning-configmap", "",
+		"Name of a ConfigMap which is watched to tune the reconciler at runtime (e.g. worker count, timeouts, feature flags)")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.TuningConfig.Namespace, "tuning-namespace", "",
+		"Namespace of the tuning ConfigMap (default is the namespace the reconciler is running in)")
 
 	//file cache for Kyma sources
 	cmd.PersistentFlags().StringVar(&reconcilerOpts.Workspace, "workspace", ".",
@@ -72,6 +141,10 @@ func newCmd(o *cli.Options) *cobra.Command {
 
 	cmd.PersistentFlags().BoolVarP(&reconcilerOpts.Verbose, "verbose", "v", false, "Show detailed information about the executed command actions")
 	cmd.PersistentFlags().BoolVar(&reconcilerOpts.NonInteractive, "non-interactive", false, "Enables the non-interactive shell mode")
+	cmd.PersistentFlags().StringSliceVar(&reconcilerOpts.RedactKeys, "redact-keys", nil,
+		"Additional keys whose values are masked in logs and callbacks (e.g. 'dbPassword,apiCert')")
+	cmd.PersistentFlags().StringSliceVar(&reconcilerOpts.RedactPatterns, "redact-patterns", nil,
+		"Additional regular expressions of sensitive data which is masked in logs and callbacks")
 
 	startCommand := startCmd.NewCmd(reconcilerOpts)
 	cmd.AddCommand(startCommand)
@@ -87,5 +160,7 @@ func newCmd(o *cli.Options) *cobra.Command {
 		testCommand.AddCommand(testSvcCmd.NewCmd(testSvcCmd.NewOptions(reconcilerOpts), reconcilerName))
 	}
 
+	cmd.AddCommand(scaffoldCmd.NewCmd())
+
 	return cmd
 }
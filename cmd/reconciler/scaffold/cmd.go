@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/scaffold"
+	"github.com/spf13/cobra"
+)
+
+const defaultInstancesDir = "pkg/reconciler/instances"
+
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scaffold",
+		Short: "Generate code for Kyma component reconcilers",
+		Long:  "CLI tool to generate the boilerplate code of new Kyma component reconcilers",
+	}
+
+	cmd.AddCommand(newComponentCmd())
+
+	return cmd
+}
+
+func newComponentCmd() *cobra.Command {
+	var instancesDir string
+	var skipLoader bool
+
+	cmd := &cobra.Command{
+		Use:   "component <name>",
+		Short: "Generate a new component reconciler",
+		Long: "Generate the package of a new component reconciler (registration, actions, tests and Dockerfile) " +
+			"and add it to the loader of the component reconcilers",
+		Example: "reconciler scaffold component cluster-essentials",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			component, err := scaffold.NewComponent(args[0])
+			if err != nil {
+				return err
+			}
+			files, err := component.Generate(instancesDir)
+			if err != nil {
+				return err
+			}
+			for _, file := range files {
+				fmt.Fprintf(cmd.OutOrStdout(), "Created %s\n", file)
+			}
+			if !skipLoader {
+				if err := scaffold.UpdateLoader(instancesDir); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Registered component reconciler '%s' in loader\n", component.Name)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "\nEdit the files in package '%s': inject your reconciliation logic "+
+				"by implementing the custom actions.\n", component.Package)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&instancesDir, "dir", defaultInstancesDir,
+		"Directory of the component reconciler packages (relative to the repository root)")
+	cmd.Flags().BoolVar(&skipLoader, "skip-loader", false,
+		"Don't add the new component reconciler to the generated loader")
+
+	return cmd
+}
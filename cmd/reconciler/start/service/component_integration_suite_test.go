@@ -203,7 +203,7 @@ func (s *reconcilerIntegrationTestSuite) startAndWaitForComponentReconciler(sett
 	go func() {
 		// This is necessary in case the next test starts faster than Prometheus can garbage collect the Registration
 		s.T().Cleanup(func() { prometheus.Unregister(recon.Collector()) })
-		s.NoError(StartWebserver(componentReconcilerServerContext, s.options, workerPool, tracker))
+		s.NoError(StartWebserver(componentReconcilerServerContext, s.options, settings.name, workerPool, tracker))
 	}()
 
 	cliTest.WaitForTCPSocket(s.T(), s.reconcilerHost, s.reconcilerPort, 5*time.Second)
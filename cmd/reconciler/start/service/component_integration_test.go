@@ -60,9 +60,9 @@ func (s *reconcilerIntegrationTestSuite) responseOkParser() responseParser {
 	}
 }
 
-func (s *reconcilerIntegrationTestSuite) responseBadRequestParser() responseParser {
+func (s *reconcilerIntegrationTestSuite) responseUnprocessableEntityParser() responseParser {
 	return func(response *http.Response) interface{} {
-		return s.responseCheck(http.StatusBadRequest, &reconciler.HTTPErrorResponse{}, response)
+		return s.responseCheck(http.StatusUnprocessableEntity, &reconciler.HTTPErrorResponse{}, response)
 	}
 }
 
@@ -194,7 +194,7 @@ func (s *reconcilerIntegrationTestSuite) TestRun() {
 				ComponentConfiguration: reconciler.ComponentConfiguration{MaxRetries: 1},
 				CallbackFunc:           nil,
 			},
-			responseParser: s.responseBadRequestParser(),
+			responseParser: s.responseUnprocessableEntityParser(),
 		},
 		{
 			name: "Install component from scratch",
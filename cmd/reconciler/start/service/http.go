@@ -5,41 +5,57 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"sync"
 
 	"github.com/gorilla/mux"
 	reconCli "github.com/kyma-incubator/reconciler/internal/cli/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/callback/client"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"github.com/kyma-incubator/reconciler/pkg/server"
 	"github.com/pkg/errors"
 )
 
 const (
-	paramContractVersion = "version"
+	paramContractVersion    = "version"
+	warmupRequestLimitBytes = 1 << 16
 )
 
-func StartWebserver(ctx context.Context, o *reconCli.Options, workerPool *service.WorkerPool, tracker *service.OccupancyTracker) error {
+func StartWebserver(ctx context.Context, o *reconCli.Options, reconcilerName string, workerPool *service.WorkerPool, tracker *service.OccupancyTracker) error {
+	tlsConfig, err := o.ServerConfig.TLS.Apply(nil)
+	if err != nil {
+		return err
+	}
 	srv := server.Webserver{
-		Logger:     o.Logger(),
-		Port:       o.ServerConfig.Port,
-		SSLCrtFile: o.ServerConfig.SSLCrtFile,
-		SSLKeyFile: o.ServerConfig.SSLKeyFile,
-		Router:     newRouter(ctx, o, workerPool, tracker),
+		Logger:             o.Logger(),
+		Port:               o.ServerConfig.Port,
+		SSLCrtFile:         o.ServerConfig.SSLCrtFile,
+		SSLKeyFile:         o.ServerConfig.SSLKeyFile,
+		TLSConfig:          tlsConfig,
+		CertReloadInterval: o.ServerConfig.CertReloadInterval,
+		Limits:             o.ServerConfig.Limits,
+		Router:             newRouter(ctx, o, reconcilerName, workerPool, tracker),
 	}
 	return srv.Start(ctx) //blocking until ctx gets closed
 }
 
-func newRouter(ctx context.Context, o *reconCli.Options, workerPool *service.WorkerPool, tracker *service.OccupancyTracker) *mux.Router {
+func newRouter(ctx context.Context, o *reconCli.Options, reconcilerName string, workerPool *service.WorkerPool, tracker *service.OccupancyTracker) *mux.Router {
 	router := mux.NewRouter()
 	router.HandleFunc(
 		fmt.Sprintf("/v{%s}/run", paramContractVersion),
 		func(w http.ResponseWriter, r *http.Request) { //just an adapter for the reconcile-fct call
-			reconcile(ctx, w, r, o, workerPool, tracker)
+			reconcile(ctx, w, r, o, reconcilerName, workerPool, tracker)
 		},
 	).Methods("PUT", "POST")
+	router.HandleFunc(
+		fmt.Sprintf("/v{%s}/warmup", paramContractVersion),
+		func(w http.ResponseWriter, r *http.Request) {
+			warmup(ctx, w, r, o, reconcilerName)
+		},
+	).Methods("POST")
 	metricsRouter := router.Path("/metrics").Subrouter()
 	metricsRouter.Handle("", promhttp.Handler())
 
@@ -64,51 +80,53 @@ func ready(workerPool *service.WorkerPool) http.HandlerFunc {
 	}
 }
 
-func newModel(req *http.Request) (*reconciler.Task, error) {
+func newModel(req *http.Request, reconcilerName string, maxBodyBytes int64) (*reconciler.Task, error) {
 	params := server.NewParams(req)
 	contractVersion, err := params.String(paramContractVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	b, err := ioutil.ReadAll(req.Body)
+	schema, err := reconciler.PayloadSchemaForVersion(contractVersion, req.Header.Get(client.PayloadVersionHeader))
 	if err != nil {
 		return nil, err
 	}
-
-	model, err := modelForVersion(contractVersion)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(b, model)
+	model, err := readPayload(req, schema, maxBodyBytes)
 	if err != nil {
 		return nil, err
 	}
 
-	if model.Configuration == nil {
-		model.Configuration = map[string]interface{}{}
+	//dedicated component reconcilers are only called for their own component
+	if reconcilerName != config.FallbackComponentReconciler && model.Component != reconcilerName {
+		return nil, &reconciler.SchemaViolationError{
+			Version: contractVersion,
+			Violations: []string{fmt.Sprintf("component '%s' is unknown to component reconciler '%s'",
+				model.Component, reconcilerName)},
+		}
 	}
 
 	return model, err
 }
 
-func modelForVersion(contractVersion string) (*reconciler.Task, error) {
-	if contractVersion == "" {
-		return nil, fmt.Errorf("contract version cannot be empty")
-	}
-	return &reconciler.Task{}, nil //change this function if multiple contract versions have to be supported
-}
-
 var reconcileSubmissionMutex = sync.Mutex{}
 
-func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o *reconCli.Options, workerPool *service.WorkerPool, tracker *service.OccupancyTracker) {
+func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o *reconCli.Options, reconcilerName string, workerPool *service.WorkerPool, tracker *service.OccupancyTracker) {
 	o.Logger().Debug("Start processing reconciliation request")
+	announceAcceptedEncodings(w)
 
 	//marshal model
-	model, err := newModel(req)
+	model, err := newModel(req, reconcilerName, o.ServerConfig.Limits.MaxBodyBytes)
 	if err != nil {
 		o.Logger().Warnf("Unmarshalling of model failed: %s", err)
-		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+		statusCode := http.StatusInternalServerError
+		if reconciler.IsSchemaViolationError(err) || reconciler.IsUnsupportedPayloadVersionError(err) {
+			statusCode = http.StatusUnprocessableEntity
+		} else if isUnsupportedMediaTypeError(err) {
+			statusCode = http.StatusUnsupportedMediaType
+		} else if isPayloadTooLargeError(err) {
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		server.SendHTTPError(w, statusCode, &reconciler.HTTPErrorResponse{
 			Error: err.Error(),
 		})
 		return
@@ -150,6 +168,38 @@ func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o
 	sendResponse(w)
 }
 
+//warmup pre-fetches the charts of upcoming Kyma versions (announced by the mothership before a rollout starts)
+func warmup(ctx context.Context, w http.ResponseWriter, req *http.Request, o *reconCli.Options, reconcilerName string) {
+	warmupReq := &reconciler.HTTPWarmupRequest{}
+	if err := json.NewDecoder(io.LimitReader(req.Body, warmupRequestLimitBytes)).Decode(warmupReq); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to decode warm-up request").Error(),
+		})
+		return
+	}
+	if len(warmupReq.Versions) == 0 {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: "Warm-up request contains no Kyma versions",
+		})
+		return
+	}
+	recon, err := service.GetReconciler(reconcilerName)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	scheduled := recon.Warmup(ctx, warmupReq.Versions)
+	o.Logger().Infof("Component reconciler '%s' scheduled warm-up of Kyma versions %v", reconcilerName, scheduled)
+
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(&reconciler.HTTPWarmupResponse{Versions: scheduled}); err != nil {
+		o.Logger().Warnf("Failed to encode warm-up response: %s", err)
+	}
+}
+
 func sendResponse(w http.ResponseWriter) {
 	w.Header().Set("content-type", "application/json")
 	if err := json.NewEncoder(w).Encode(&reconciler.HTTPReconciliationResponse{}); err != nil {
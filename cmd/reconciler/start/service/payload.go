@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/pkg/errors"
+)
+
+//acceptedContentTypes are announced to the mothership which uses the most efficient encoding for the next tasks
+var acceptedContentTypes = []string{reconciler.ContentTypeJSON, reconciler.ContentTypeProtobuf}
+
+//unsupportedMediaTypeError is returned if the payload uses an unknown content type or content encoding
+type unsupportedMediaTypeError struct {
+	reason string
+}
+
+func (e *unsupportedMediaTypeError) Error() string {
+	return e.reason
+}
+
+func isUnsupportedMediaTypeError(err error) bool {
+	var mediaTypeErr *unsupportedMediaTypeError
+	return errors.As(err, &mediaTypeErr)
+}
+
+//payloadTooLargeError is returned if the (decompressed) payload exceeds the max. body size
+type payloadTooLargeError struct {
+	maxBytes int64
+}
+
+func (e *payloadTooLargeError) Error() string {
+	return fmt.Sprintf("payload exceeds the max. size of %d bytes", e.maxBytes)
+}
+
+func isPayloadTooLargeError(err error) bool {
+	var tooLargeErr *payloadTooLargeError
+	return errors.As(err, &tooLargeErr)
+}
+
+//announceAcceptedEncodings tells the mothership which content types and encodings are accepted for tasks
+func announceAcceptedEncodings(w http.ResponseWriter) {
+	w.Header().Set("Accept-Encoding", "gzip")
+	w.Header().Set("Accept-Post", strings.Join(acceptedContentTypes, ", "))
+}
+
+//readPayload decodes the task payload and validates it against the schema. Compressed payloads are decompressed
+//up to the max. body size (0 disables the limit): the limit of the webserver only applies to the compressed body.
+func readPayload(req *http.Request, schema *reconciler.PayloadSchema, maxBytes int64) (*reconciler.Task, error) {
+	var body io.Reader = req.Body
+	switch encoding := strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+	case "gzip":
+		gzipReader, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, &reconciler.SchemaViolationError{
+				Version:    schema.Version,
+				Violations: []string{fmt.Sprintf("payload is not gzip compressed: %s", err)},
+			}
+		}
+		defer func() {
+			_ = gzipReader.Close()
+		}()
+		body = gzipReader
+	default:
+		return nil, &unsupportedMediaTypeError{reason: fmt.Sprintf("content encoding '%s' is not supported", encoding)}
+	}
+	if maxBytes > 0 {
+		body = io.LimitReader(body, maxBytes+1)
+	}
+
+	payload, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if maxBytes > 0 && int64(len(payload)) > maxBytes {
+		return nil, &payloadTooLargeError{maxBytes: maxBytes}
+	}
+
+	contentType := reconciler.ContentTypeJSON
+	if header := req.Header.Get("Content-Type"); header != "" {
+		if contentType, _, err = mime.ParseMediaType(header); err != nil {
+			return nil, &unsupportedMediaTypeError{reason: fmt.Sprintf("content type '%s' is invalid: %s", header, err)}
+		}
+	}
+	switch contentType {
+	case reconciler.ContentTypeJSON:
+		return schema.Decode(payload)
+	case reconciler.ContentTypeProtobuf:
+		return schema.DecodeProto(payload)
+	default:
+		return nil, &unsupportedMediaTypeError{reason: fmt.Sprintf("content type '%s' is not supported (supported: %s)",
+			contentType, strings.Join(acceptedContentTypes, ", "))}
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPayload(t *testing.T) {
+	task := &reconciler.Task{
+		Component:     "istio",
+		Namespace:     "istio-system",
+		Kubeconfig:    "abc",
+		CallbackURL:   "https://mothership/v1/operations/1/callback/2",
+		CorrelationID: "2",
+		Type:          model.OperationTypeReconcile,
+	}
+	jsonPayload, err := json.Marshal(task)
+	require.NoError(t, err)
+	protoPayload, err := task.MarshalProto()
+	require.NoError(t, err)
+	schema, err := reconciler.PayloadSchemaForVersion("1", "")
+	require.NoError(t, err)
+
+	newRequest := func(body []byte, contentType, contentEncoding string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/v1/run", bytes.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if contentEncoding != "" {
+			req.Header.Set("Content-Encoding", contentEncoding)
+		}
+		return req
+	}
+	compress := func(data []byte) []byte {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		_, err := writer.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		return buf.Bytes()
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		decoded, err := readPayload(newRequest(jsonPayload, "application/json; charset=utf-8", ""), schema, 0)
+		require.NoError(t, err)
+		require.Equal(t, "istio", decoded.Component)
+
+		//JSON is the default content type
+		decoded, err = readPayload(newRequest(jsonPayload, "", ""), schema, 0)
+		require.NoError(t, err)
+		require.Equal(t, "istio", decoded.Component)
+	})
+
+	t.Run("Gzip compressed JSON", func(t *testing.T) {
+		decoded, err := readPayload(newRequest(compress(jsonPayload), reconciler.ContentTypeJSON, "gzip"), schema, 0)
+		require.NoError(t, err)
+		require.Equal(t, "istio", decoded.Component)
+	})
+
+	t.Run("Gzip compressed protobuf", func(t *testing.T) {
+		decoded, err := readPayload(newRequest(compress(protoPayload), reconciler.ContentTypeProtobuf, "gzip"), schema, 0)
+		require.NoError(t, err)
+		require.Equal(t, "istio-system", decoded.Namespace)
+		require.Equal(t, model.OperationTypeReconcile, decoded.Type)
+	})
+
+	t.Run("Invalid payloads", func(t *testing.T) {
+		_, err := readPayload(newRequest(jsonPayload, reconciler.ContentTypeJSON, "gzip"), schema, 0)
+		require.True(t, reconciler.IsSchemaViolationError(err))
+
+		_, err = readPayload(newRequest([]byte{0xff}, reconciler.ContentTypeProtobuf, ""), schema, 0)
+		require.True(t, reconciler.IsSchemaViolationError(err))
+
+		//protobuf messages are validated against the schema
+		incomplete, err := (&reconciler.Task{Component: "istio"}).MarshalProto()
+		require.NoError(t, err)
+		_, err = readPayload(newRequest(incomplete, reconciler.ContentTypeProtobuf, ""), schema, 0)
+		require.True(t, reconciler.IsSchemaViolationError(err))
+	})
+
+	t.Run("Payload too large", func(t *testing.T) {
+		maxBytes := int64(len(jsonPayload))
+		_, err := readPayload(newRequest(compress(jsonPayload), reconciler.ContentTypeJSON, "gzip"), schema, maxBytes)
+		require.NoError(t, err)
+
+		//the limit applies to the decompressed payload
+		large := compress(bytes.Repeat([]byte(" "), 10*len(jsonPayload)))
+		require.Less(t, int64(len(large)), maxBytes)
+		_, err = readPayload(newRequest(large, reconciler.ContentTypeJSON, "gzip"), schema, maxBytes)
+		require.True(t, isPayloadTooLargeError(err))
+	})
+
+	t.Run("Unsupported media types", func(t *testing.T) {
+		_, err := readPayload(newRequest(jsonPayload, "application/xml", ""), schema, 0)
+		require.True(t, isUnsupportedMediaTypeError(err))
+
+		_, err = readPayload(newRequest(jsonPayload, reconciler.ContentTypeJSON, "br"), schema, 0)
+		require.True(t, isUnsupportedMediaTypeError(err))
+	})
+}
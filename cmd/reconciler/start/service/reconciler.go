@@ -2,13 +2,21 @@ package cmd
 
 import (
 	"context"
+	"time"
+
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/file"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/watchdog"
 	"github.com/prometheus/client_golang/prometheus"
 
 	reconCli "github.com/kyma-incubator/reconciler/internal/cli/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 )
 
+//leakedSandboxAge is the minimal age of a sandbox of another running process (or of an unknown owner) before
+//it's treated as leaked
+const leakedSandboxAge = 24 * time.Hour
+
 func StartComponentReconciler(ctx context.Context, o *reconCli.Options, reconcilerName string) (*service.WorkerPool, *service.OccupancyTracker, error) {
 	if o.DryRun {
 		service.EnableReconcilerDryRun()
@@ -19,12 +27,55 @@ func StartComponentReconciler(ctx context.Context, o *reconCli.Options, reconcil
 	if err != nil {
 		return nil, nil, err
 	}
-	reconcilerMetricsSet := metrics.NewReconcilerMetricsSet(durationMetric)
+	throttledMetric := metrics.NewThrottledClustersMetric(o.Logger())
+	if err := prometheus.Register(throttledMetric.Collector); err != nil {
+		alreadyRegisteredErr, ok := err.(prometheus.AlreadyRegisteredError)
+		if !ok {
+			return nil, nil, err
+		}
+		throttledMetric.Collector = alreadyRegisteredErr.ExistingCollector.(*prometheus.GaugeVec)
+	}
+	reconcilerMetricsSet := metrics.NewReconcilerMetricsSet(durationMetric, throttledMetric)
+	if err := metrics.RegisterSandboxes(o.Logger()); err != nil {
+		return nil, nil, err
+	}
+	//sandboxes of operations which were running when the previous process terminated are leaked: all sandboxes
+	//which aren't owned by a running process are removed
+	if removed, err := file.RemoveLeakedSandboxes("", leakedSandboxAge); err != nil {
+		o.Logger().Warnf("Failed to remove leaked sandboxes: %s", err)
+	} else if removed > 0 {
+		o.Logger().Infof("Removed %d leaked sandboxes", removed)
+	}
+	//detect operations which are running far beyond their expected duration
+	stuckDetector, err := watchdog.New(o.WorkerConfig.StuckThreshold, o.Logger())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := metrics.RegisterStuckOperations(stuckDetector, o.Logger()); err != nil {
+		return nil, nil, err
+	}
 	recon, err := reconCli.NewComponentReconciler(o, reconcilerName, reconcilerMetricsSet)
 	if err != nil {
 		return nil, nil, err
 	}
+	recon.WithWatchdog(stuckDetector)
 
 	o.Logger().Infof("Starting component reconciler '%s'", reconcilerName)
-	return recon.StartRemote(ctx, reconcilerName)
+	workerPool, tracker, err := recon.StartRemote(ctx, reconcilerName)
+	if err != nil {
+		return nil, nil, err
+	}
+	stuckDetector.Run(ctx)
+	watchdog.DumpOnSignal(ctx, o.Logger())
+
+	if o.TuningConfig.Enabled() {
+		tuningWatcher, err := service.NewInClusterTuningWatcher(o.TuningConfig.Namespace, o.TuningConfig.ConfigMap,
+			recon, workerPool, o.Logger())
+		if err != nil {
+			return nil, nil, err
+		}
+		tuningWatcher.Run(ctx)
+	}
+
+	return workerPool, tracker, nil
 }
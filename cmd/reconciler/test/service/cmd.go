@@ -51,7 +51,7 @@ func Run(o *Options, reconcilerName string) error {
 	if err != nil {
 		return err
 	}
-	return startSvcCmd.StartWebserver(ctx, o.Options, workerPool, tracker)
+	return startSvcCmd.StartWebserver(ctx, o.Options, reconcilerName, workerPool, tracker)
 }
 
 func showCurl(o *Options) error {
@@ -0,0 +1 @@
+ALTER TABLE inventory_clusters DROP COLUMN "kubeconfig_key_id";
@@ -0,0 +1,2 @@
+ALTER TABLE inventory_clusters
+    ADD COLUMN "kubeconfig_key_id" text;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS inventory_payloads (
+	"id" SERIAL UNIQUE,
+	"runtime_id" text NOT NULL,
+	"config_version" int NOT NULL,
+	"contract" int NOT NULL,
+	"payload" text NOT NULL, --compressed and encrypted: contains the kubeconfig
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT inventory_payloads_pk PRIMARY KEY ("id"),
+	FOREIGN KEY ("config_version") REFERENCES inventory_cluster_configs ("version") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS inventory_payloads_idx_runtime_id_config_version ON inventory_payloads ("runtime_id", "config_version");
+CREATE INDEX IF NOT EXISTS inventory_payloads_idx_created ON inventory_payloads ("created");
@@ -0,0 +1 @@
+ALTER TABLE scheduler_operations DROP COLUMN "reconciler_version";
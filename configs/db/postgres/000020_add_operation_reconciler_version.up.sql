@@ -0,0 +1,2 @@
+ALTER TABLE scheduler_operations
+    ADD COLUMN "reconciler_version" text DEFAULT '';
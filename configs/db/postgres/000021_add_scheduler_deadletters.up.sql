@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS scheduler_deadletters (
+	"id" SERIAL UNIQUE,
+	"scheduling_id" text NOT NULL,
+	"correlation_id" text NOT NULL,
+	"runtime_id" text NOT NULL,
+	"cluster_config" int NOT NULL,
+	"component" text NOT NULL,
+	"type" text NOT NULL,
+	"reason" text,
+	"retries" int NOT NULL,
+	"state" text NOT NULL, --open, acknowledged or requeued
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	"updated" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT scheduler_deadletters_pk PRIMARY KEY ("id"),
+	CONSTRAINT scheduler_deadletters_operation UNIQUE ("scheduling_id", "correlation_id")
+);
+
+CREATE INDEX IF NOT EXISTS scheduler_deadletters_idx_state ON scheduler_deadletters ("state");
+CREATE INDEX IF NOT EXISTS scheduler_deadletters_idx_runtime_id ON scheduler_deadletters ("runtime_id");
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS inventory_runtime_ids (
+	"normalized_runtime_id" text NOT NULL, --lower case without surrounding whitespaces
+	"runtime_id" text NOT NULL,
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT inventory_runtime_ids_pk PRIMARY KEY ("normalized_runtime_id"),
+	CONSTRAINT inventory_runtime_ids_runtime_id UNIQUE ("runtime_id")
+);
+
+--reserve the IDs of existing clusters (if IDs differ only by case, the latest cluster wins)
+INSERT INTO inventory_runtime_ids ("normalized_runtime_id", "runtime_id")
+SELECT DISTINCT ON (LOWER(BTRIM("runtime_id"))) LOWER(BTRIM("runtime_id")), "runtime_id"
+FROM inventory_clusters
+WHERE "deleted" = FALSE
+ORDER BY LOWER(BTRIM("runtime_id")), "version" DESC
+ON CONFLICT DO NOTHING;
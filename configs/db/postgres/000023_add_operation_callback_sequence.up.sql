@@ -0,0 +1,2 @@
+ALTER TABLE scheduler_operations
+    ADD COLUMN "callback_sequence" bigint DEFAULT 0;
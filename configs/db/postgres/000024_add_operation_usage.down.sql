@@ -0,0 +1,3 @@
+ALTER TABLE scheduler_operations
+    DROP COLUMN "api_calls",
+    DROP COLUMN "manifest_bytes";
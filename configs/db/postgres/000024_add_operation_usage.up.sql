@@ -0,0 +1,3 @@
+ALTER TABLE scheduler_operations
+    ADD COLUMN "api_calls" bigint DEFAULT 0,
+    ADD COLUMN "manifest_bytes" bigint DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE inventory_runtime_ids
+    ADD COLUMN "deletion_protection" boolean NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE inventory_clusters
+    ADD COLUMN "previous_kubeconfig" text NOT NULL DEFAULT '';
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS inventory_component_images (
+	"runtime_id" text NOT NULL,
+	"component" text NOT NULL,
+	"cluster_config" int NOT NULL,
+	"scheduling_id" text NOT NULL,
+	"correlation_id" text NOT NULL,
+	"images" text NOT NULL, --JSON list of the container images (and digests) running for the workloads of the component
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT inventory_component_images_pk PRIMARY KEY ("runtime_id", "component")
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS scheduler_pauses (
+	"id" SERIAL UNIQUE,
+	"paused_by" text NOT NULL,
+	"started" TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+	"duration" int NOT NULL, --in seconds, 0 = paused until it gets resumed
+	"resumed" boolean NOT NULL DEFAULT FALSE,
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	"updated" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT scheduler_pauses_pk PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS scheduler_pauses_idx_resumed ON scheduler_pauses ("resumed");
@@ -0,0 +1,13 @@
+--change feed of the inventory and reconciliation states (the ID is used as cursor by consumers)
+CREATE TABLE IF NOT EXISTS inventory_changes (
+	"id" SERIAL UNIQUE,
+	"kind" text NOT NULL, --cluster or reconciliation
+	"runtime_id" text NOT NULL,
+	"config_version" int NOT NULL,
+	"scheduling_id" text,
+	"status" text NOT NULL,
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT inventory_changes_pk PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS inventory_changes_idx_created ON inventory_changes ("created");
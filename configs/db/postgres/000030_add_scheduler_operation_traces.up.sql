@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS scheduler_operation_traces (
+	"scheduling_id" text NOT NULL,
+	"correlation_id" text NOT NULL,
+	"runtime_id" text NOT NULL,
+	"component" text NOT NULL,
+	"trace" text NOT NULL, --JSON capture (phases, API calls and debug logs) of an operation which exceeded the latency threshold
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT scheduler_operation_traces_pk PRIMARY KEY ("scheduling_id", "correlation_id")
+);
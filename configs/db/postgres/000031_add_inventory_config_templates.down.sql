@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS inventory_config_template_refs;
+DROP TABLE IF EXISTS inventory_config_templates;
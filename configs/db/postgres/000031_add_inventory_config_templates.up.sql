@@ -0,0 +1,20 @@
+--configuration templates: named component sets with default values shared by clusters
+CREATE TABLE IF NOT EXISTS inventory_config_templates (
+	"name" text NOT NULL,
+	"components" text NOT NULL,
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	"updated" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT inventory_config_templates_pk PRIMARY KEY ("name")
+);
+
+--clusters referencing a template: the overrides are the components sent by KEB (merged with the template)
+CREATE TABLE IF NOT EXISTS inventory_config_template_refs (
+	"runtime_id" text NOT NULL,
+	"template" text NOT NULL,
+	"overrides" text NOT NULL,
+	"updated" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT inventory_config_template_refs_pk PRIMARY KEY ("runtime_id"),
+	FOREIGN KEY ("template") REFERENCES inventory_config_templates ("name") ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS inventory_config_template_refs_idx_template ON inventory_config_template_refs ("template");
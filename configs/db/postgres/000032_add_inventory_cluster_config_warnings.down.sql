@@ -0,0 +1 @@
+DROP TABLE IF EXISTS inventory_cluster_config_warnings;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS inventory_cluster_config_warnings (
+	"config_version" int NOT NULL,
+	"runtime_id" text NOT NULL,
+	"warnings" text NOT NULL, --JSON list of the warnings returned by the validation webhook
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT inventory_cluster_config_warnings_pk PRIMARY KEY ("config_version"),
+	FOREIGN KEY ("config_version") REFERENCES inventory_cluster_configs ("version") ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS scheduler_operation_logs (
+	"scheduling_id" text NOT NULL,
+	"correlation_id" text NOT NULL,
+	"runtime_id" text NOT NULL,
+	"component" text NOT NULL,
+	"logs" text NOT NULL, --compressed JSON of the latest log entries reported by the component reconciler
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT scheduler_operation_logs_pk PRIMARY KEY ("scheduling_id", "correlation_id")
+);
@@ -0,0 +1,3 @@
+ALTER TABLE scheduler_operations
+    DROP COLUMN "pending_deletion",
+    DROP COLUMN "deletion_confirmed";
@@ -0,0 +1,3 @@
+ALTER TABLE scheduler_operations
+    ADD COLUMN "pending_deletion" text DEFAULT '', --JSON list of the stateful resources which the deletion would remove
+    ADD COLUMN "deletion_confirmed" boolean NOT NULL DEFAULT false;
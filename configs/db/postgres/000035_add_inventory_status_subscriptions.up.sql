@@ -0,0 +1,9 @@
+--webhooks which receive the status changes of clusters (an empty runtime ID subscribes to all clusters)
+CREATE TABLE IF NOT EXISTS inventory_status_subscriptions (
+	"id" text NOT NULL,
+	"url" text NOT NULL,
+	"secret" text NOT NULL,
+	"runtime_id" text NOT NULL DEFAULT '',
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT inventory_status_subscriptions_pk PRIMARY KEY ("id")
+);
@@ -0,0 +1,13 @@
+--key facts of target clusters captured after reconciliations (only the latest snapshots per cluster are retained)
+CREATE TABLE IF NOT EXISTS inventory_cluster_snapshots (
+	"id" SERIAL UNIQUE,
+	"runtime_id" text NOT NULL,
+	"cluster_config" int NOT NULL,
+	"node_count" int NOT NULL,
+	"kubernetes_version" text NOT NULL,
+	"kyma_crds" text NOT NULL, --JSON list of the installed CRDs of Kyma API groups
+	"changes" text NOT NULL, --JSON list of the fact changes compared to the previous snapshot
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT inventory_cluster_snapshots_pk PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS inventory_cluster_snapshots_idx_runtime_id ON inventory_cluster_snapshots ("runtime_id");
@@ -0,0 +1,2 @@
+ALTER TABLE inventory_runtime_ids
+    ADD COLUMN "force_takeover" boolean NOT NULL DEFAULT FALSE;
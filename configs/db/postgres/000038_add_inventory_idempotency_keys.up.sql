@@ -0,0 +1,10 @@
+--responses of cluster mutations which were sent with an 'Idempotency-Key' header (status code 0 while in progress)
+CREATE TABLE IF NOT EXISTS inventory_idempotency_keys (
+	"key" text NOT NULL,
+	"fingerprint" text NOT NULL,
+	"status_code" int NOT NULL DEFAULT 0,
+	"response" text NOT NULL DEFAULT '',
+	"created" TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+	CONSTRAINT inventory_idempotency_keys_pk PRIMARY KEY ("key")
+);
+CREATE INDEX IF NOT EXISTS inventory_idempotency_keys_idx_created ON inventory_idempotency_keys ("created");
@@ -0,0 +1,2 @@
+ALTER TABLE inventory_clusters
+    DROP COLUMN "kubeconfigs";
@@ -0,0 +1,2 @@
+ALTER TABLE inventory_clusters
+    ADD COLUMN "kubeconfigs" text NOT NULL DEFAULT '';
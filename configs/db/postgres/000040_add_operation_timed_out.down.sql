@@ -0,0 +1,2 @@
+ALTER TABLE scheduler_operations
+    DROP COLUMN "timed_out";
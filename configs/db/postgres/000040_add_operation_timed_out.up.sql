@@ -0,0 +1,2 @@
+ALTER TABLE scheduler_operations
+    ADD COLUMN "timed_out" boolean NOT NULL DEFAULT false;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS inventory_changes_retention;
+DROP INDEX IF EXISTS inventory_changes_idx_unpublished;
+DROP SEQUENCE IF EXISTS inventory_changes_feed_position_seq;
+ALTER TABLE inventory_changes
+    DROP COLUMN "feed_position",
+    DROP COLUMN "txid";
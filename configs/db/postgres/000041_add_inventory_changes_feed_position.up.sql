@@ -0,0 +1,17 @@
+--changes are published in commit order: the feed position is assigned after the recording transaction
+--committed (see pkg/changes). Existing changes keep their ID as position to keep the cursors of consumers valid.
+ALTER TABLE inventory_changes
+    ADD COLUMN "txid" bigint DEFAULT txid_current(),
+    ADD COLUMN "feed_position" bigint UNIQUE;
+UPDATE inventory_changes SET "feed_position" = "id";
+
+CREATE SEQUENCE IF NOT EXISTS inventory_changes_feed_position_seq;
+SELECT setval('inventory_changes_feed_position_seq', COALESCE((SELECT MAX("id") FROM inventory_changes), 0) + 1, false);
+
+CREATE INDEX IF NOT EXISTS inventory_changes_idx_unpublished ON inventory_changes ("txid", "id") WHERE "feed_position" IS NULL;
+
+--retention boundary of the change feed: the highest feed position of the purged changes
+CREATE TABLE IF NOT EXISTS inventory_changes_retention (
+	"id" int PRIMARY KEY,
+	"purged_position" bigint NOT NULL
+);
@@ -0,0 +1,4 @@
+--references of deleted clusters were kept and blocked the deletion of their templates
+DELETE FROM inventory_config_template_refs WHERE "runtime_id" NOT IN (
+	SELECT "runtime_id" FROM inventory_clusters WHERE "deleted" = FALSE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE inventory_clusters
+    ADD COLUMN "kubeconfig_rotated" bigint NOT NULL DEFAULT 0;
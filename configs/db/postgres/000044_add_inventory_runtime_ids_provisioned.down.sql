@@ -0,0 +1,2 @@
+ALTER TABLE inventory_runtime_ids
+    DROP COLUMN "provisioned";
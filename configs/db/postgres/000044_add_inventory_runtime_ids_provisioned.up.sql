@@ -0,0 +1,4 @@
+ALTER TABLE inventory_runtime_ids
+    ADD COLUMN "provisioned" boolean NOT NULL DEFAULT FALSE;
+--existing clusters are considered as provisioned: their statuses could already be cleaned up
+UPDATE inventory_runtime_ids SET "provisioned" = TRUE;
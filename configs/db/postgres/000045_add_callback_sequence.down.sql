@@ -0,0 +1 @@
+DROP SEQUENCE IF EXISTS scheduler_callback_sequence;
@@ -0,0 +1 @@
+CREATE SEQUENCE IF NOT EXISTS scheduler_callback_sequence;
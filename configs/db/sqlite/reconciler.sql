@@ -60,12 +60,104 @@ CREATE TABLE IF NOT EXISTS inventory_clusters (
 	"runtime" text NOT NULL,
 	"metadata" text NOT NULL,
 	"kubeconfig" text NOT NULL,
+	"kubeconfig_key_id" text,
+	"previous_kubeconfig" text NOT NULL DEFAULT '', --kept for a rollback until the next successful reconciliation after a credential rotation
+	"kubeconfig_rotated" integer NOT NULL DEFAULT 0, --ID of the latest cluster status when the kubeconfig was rotated
+	"kubeconfigs" text NOT NULL DEFAULT '', --named kubeconfigs of further clusters of the runtime (JSON, envelope encrypted)
 	"contract" int NOT NULL,
 	"deleted" boolean DEFAULT FALSE,
 	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	CONSTRAINT inventory_clusters_pk UNIQUE ("runtime_id", "version")
 );
 
+--DDL for reserved runtime IDs (guarantees case-insensitive uniqueness of runtime IDs):
+CREATE TABLE IF NOT EXISTS inventory_runtime_ids (
+	"normalized_runtime_id" text NOT NULL PRIMARY KEY,
+	"runtime_id" text NOT NULL UNIQUE,
+	"deletion_protection" boolean NOT NULL DEFAULT FALSE,
+	"force_takeover" boolean NOT NULL DEFAULT FALSE,
+	"provisioned" boolean NOT NULL DEFAULT FALSE, --set after the first successful reconciliation
+	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+--DDL for the container images which are running for the components of a cluster (bill of materials):
+CREATE TABLE IF NOT EXISTS inventory_component_images (
+	"runtime_id" text NOT NULL,
+	"component" text NOT NULL,
+	"cluster_config" int NOT NULL,
+	"scheduling_id" text NOT NULL,
+	"correlation_id" text NOT NULL,
+	"images" text NOT NULL,
+	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY ("runtime_id", "component")
+);
+
+--DDL for the change feed of the inventory and reconciliation states:
+CREATE TABLE IF NOT EXISTS inventory_changes (
+	"id" integer PRIMARY KEY AUTOINCREMENT,
+	"kind" text NOT NULL,
+	"runtime_id" text NOT NULL,
+	"config_version" int NOT NULL,
+	"scheduling_id" text,
+	"status" text NOT NULL,
+	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	"feed_position" integer UNIQUE --SQLite serializes all writes: the position is the ID
+);
+
+CREATE TABLE IF NOT EXISTS inventory_changes_retention (
+	"id" int PRIMARY KEY,
+	"purged_position" integer NOT NULL
+);
+
+--DDL for the configuration templates shared by clusters:
+CREATE TABLE IF NOT EXISTS inventory_config_templates (
+	"name" text PRIMARY KEY,
+	"components" text NOT NULL,
+	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	"updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS inventory_config_template_refs (
+	"runtime_id" text PRIMARY KEY,
+	"template" text NOT NULL,
+	"overrides" text NOT NULL,
+	"updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY("template") REFERENCES inventory_config_templates("name") ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS inventory_config_template_refs_idx_template ON inventory_config_template_refs ("template");
+
+--DDL for the webhooks which receive the status changes of clusters:
+CREATE TABLE IF NOT EXISTS inventory_status_subscriptions (
+	"id" text PRIMARY KEY,
+	"url" text NOT NULL,
+	"secret" text NOT NULL,
+	"runtime_id" text NOT NULL DEFAULT '',
+	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS inventory_cluster_snapshots (
+	"id" integer PRIMARY KEY AUTOINCREMENT,
+	"runtime_id" text NOT NULL,
+	"cluster_config" int NOT NULL,
+	"node_count" int NOT NULL,
+	"kubernetes_version" text NOT NULL,
+	"kyma_crds" text NOT NULL,
+	"changes" text NOT NULL,
+	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS inventory_cluster_snapshots_idx_runtime_id ON inventory_cluster_snapshots ("runtime_id");
+
+--DDL for the responses of cluster mutations which were sent with an 'Idempotency-Key' header:
+CREATE TABLE IF NOT EXISTS inventory_idempotency_keys (
+	"key" text PRIMARY KEY,
+	"fingerprint" text NOT NULL,
+	"status_code" int NOT NULL DEFAULT 0,
+	"response" text NOT NULL DEFAULT '',
+	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS inventory_idempotency_keys_idx_created ON inventory_idempotency_keys ("created");
+
 CREATE TABLE IF NOT EXISTS inventory_cluster_configs (
 	"version" integer PRIMARY KEY AUTOINCREMENT, --can also be used as unique identifier for a cluster config
 	"runtime_id" text NOT NULL,
@@ -81,6 +173,14 @@ CREATE TABLE IF NOT EXISTS inventory_cluster_configs (
 	FOREIGN KEY("runtime_id", "cluster_version") REFERENCES inventory_clusters("runtime_id", "version") ON UPDATE CASCADE ON DELETE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS inventory_cluster_config_warnings (
+	"config_version" integer PRIMARY KEY,
+	"runtime_id" text NOT NULL,
+	"warnings" text NOT NULL,
+	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY("config_version") REFERENCES inventory_cluster_configs("version") ON DELETE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS inventory_cluster_config_statuses (
 	"id" integer PRIMARY KEY AUTOINCREMENT,
 	"runtime_id" text NOT NULL,
@@ -92,6 +192,19 @@ CREATE TABLE IF NOT EXISTS inventory_cluster_config_statuses (
 	FOREIGN KEY("runtime_id", "cluster_version", "config_version") REFERENCES inventory_cluster_configs("runtime_id", "cluster_version", "version") ON UPDATE CASCADE ON DELETE CASCADE
 );
 
+--DDL for KEB payloads which led to a cluster configuration:
+CREATE TABLE IF NOT EXISTS inventory_payloads (
+	"id" integer PRIMARY KEY AUTOINCREMENT,
+	"runtime_id" text NOT NULL,
+	"config_version" int NOT NULL,
+	"contract" int NOT NULL,
+	"payload" text NOT NULL,
+	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY("config_version") REFERENCES inventory_cluster_configs("version") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS inventory_payloads_idx_runtime_id_config_version ON inventory_payloads ("runtime_id", "config_version");
+
 CREATE TABLE IF NOT EXISTS scheduler_reconciliations (
     "scheduling_id" text NOT NULL PRIMARY KEY,
     "lock" text UNIQUE, --make sure just one cluster can be reconciled at the same time
@@ -125,12 +238,78 @@ CREATE TABLE IF NOT EXISTS scheduler_operations(
     "updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     "picked_up" TIMESTAMP,
     "processing_duration" int,
+    "reconciler_version" text DEFAULT '',
+    "callback_sequence" bigint DEFAULT 0,
+    "api_calls" bigint DEFAULT 0,
+    "manifest_bytes" bigint DEFAULT 0,
+    "pending_deletion" text DEFAULT '',
+    "deletion_confirmed" boolean NOT NULL DEFAULT false,
+    "timed_out" boolean NOT NULL DEFAULT false,
     CONSTRAINT scheduler_operations_pk UNIQUE ("scheduling_id", "correlation_id"),
     FOREIGN KEY("scheduling_id") REFERENCES scheduler_reconciliations("scheduling_id") ON UPDATE CASCADE ON DELETE CASCADE,
     FOREIGN KEY("runtime_id") REFERENCES inventory_clusters("runtime_id") ON UPDATE CASCADE,
     FOREIGN KEY("cluster_config") REFERENCES inventory_cluster_configs("version")
 );
 
+--DDL for the sequence of the callbacks (SQLite doesn't support sequences: the latest auto-incremented ID is the sequence value):
+CREATE TABLE IF NOT EXISTS scheduler_callback_sequence (
+    "id" integer PRIMARY KEY AUTOINCREMENT
+);
+
+--DDL for operations which failed permanently (dead-letter queue):
+CREATE TABLE IF NOT EXISTS scheduler_deadletters (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "scheduling_id" text NOT NULL,
+    "correlation_id" text NOT NULL,
+    "runtime_id" text NOT NULL,
+    "cluster_config" int NOT NULL,
+    "component" text NOT NULL,
+    "type" text NOT NULL,
+    "reason" text,
+    "retries" int NOT NULL,
+    "state" text NOT NULL,
+    "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scheduler_deadletters_operation UNIQUE ("scheduling_id", "correlation_id")
+);
+
+CREATE INDEX IF NOT EXISTS scheduler_deadletters_idx_state ON scheduler_deadletters ("state");
+CREATE INDEX IF NOT EXISTS scheduler_deadletters_idx_runtime_id ON scheduler_deadletters ("runtime_id");
+
+CREATE TABLE IF NOT EXISTS scheduler_pauses (
+    "id" integer PRIMARY KEY AUTOINCREMENT,
+    "paused_by" text NOT NULL,
+    "started" TIMESTAMP NOT NULL,
+    "duration" int NOT NULL,
+    "resumed" boolean NOT NULL DEFAULT FALSE,
+    "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS scheduler_pauses_idx_resumed ON scheduler_pauses ("resumed");
+
+--DDL for the detailed capture of slow operations:
+CREATE TABLE IF NOT EXISTS scheduler_operation_traces (
+    "scheduling_id" text NOT NULL,
+    "correlation_id" text NOT NULL,
+    "runtime_id" text NOT NULL,
+    "component" text NOT NULL,
+    "trace" text NOT NULL,
+    "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("scheduling_id", "correlation_id")
+);
+
+--DDL for the logs reported by component reconcilers:
+CREATE TABLE IF NOT EXISTS scheduler_operation_logs (
+    "scheduling_id" text NOT NULL,
+    "correlation_id" text NOT NULL,
+    "runtime_id" text NOT NULL,
+    "component" text NOT NULL,
+    "logs" text NOT NULL,
+    "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("scheduling_id", "correlation_id")
+);
+
 CREATE TABLE IF NOT EXISTS worker_pool_occupancy
 (
     "worker_pool_id"       text NOT NULL PRIMARY KEY,
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/redact"
 	"github.com/spf13/viper"
 
 	"github.com/kyma-incubator/reconciler/pkg/logger"
@@ -18,6 +19,8 @@ type Options struct {
 	InitRegistry   bool
 	NonInteractive bool
 	OutputFormat   string
+	RedactKeys     []string //additional keys whose values are masked in logs and callbacks
+	RedactPatterns []string //additional regular expressions of sensitive data which is masked in logs and callbacks
 	logger         *zap.SugaredLogger
 	Registry       *persistency.Registry //will be initialized during CLI bootstrap in main.go
 }
@@ -36,6 +39,11 @@ func (o *Options) Validate() error {
 	return fmt.Errorf("Output format '%s' not supported - choose between '%s'", o.OutputFormat, strings.Join(SupportedOutputFormats, "', '"))
 }
 
+//ConfigureRedaction extends the built-in redaction rules by the configured keys and patterns
+func (o *Options) ConfigureRedaction() error {
+	return redact.Configure(o.RedactKeys, o.RedactPatterns)
+}
+
 func (o *Options) Logger() *zap.SugaredLogger {
 	if o.logger == nil {
 		o.logger = logger.NewLogger(o.Verbose)
@@ -43,6 +51,13 @@ func (o *Options) Logger() *zap.SugaredLogger {
 	return o.logger
 }
 
+//WithLogger returns a copy of the options which uses the given logger (e.g. a logger with request-scoped fields)
+func (o *Options) WithLogger(log *zap.SugaredLogger) *Options {
+	copied := *o
+	copied.logger = log
+	return &copied
+}
+
 func (o *Options) InitApplicationRegistry(forceInitialization bool) error {
 	if forceInitialization || o.InitRegistry {
 		dbConnFact, err := db.NewConnectionFactory(viper.ConfigFileUsed(), o.Migrate, o.Verbose)
@@ -0,0 +1,57 @@
+package reconciler
+
+import (
+	"fmt"
+	"time"
+)
+
+type CallbackConfig struct {
+	Batching          bool
+	BatchInterval     time.Duration
+	MaxBatchSize      int
+	RequestsPerSecond int
+	MaxQueueSize      int
+	//client used to call the mothership
+	Timeout          time.Duration
+	MaxRetries       int
+	FailureThreshold int
+	OpenTimeout      time.Duration
+	TokenFile        string
+	CertFile         string
+	KeyFile          string
+	CAFile           string
+}
+
+func (c *CallbackConfig) validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("callback timeout cannot be < 0")
+	}
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("callback retries cannot be < 0")
+	}
+	if c.FailureThreshold < 0 {
+		return fmt.Errorf("callback circuit breaker threshold cannot be < 0")
+	}
+	if c.OpenTimeout < 0 {
+		return fmt.Errorf("callback circuit breaker timeout cannot be < 0")
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("callback client certificate and key have to be set together")
+	}
+	if !c.Batching {
+		return nil
+	}
+	if c.BatchInterval <= 0 {
+		return fmt.Errorf("callback batch interval has to be > 0")
+	}
+	if c.MaxBatchSize <= 0 {
+		return fmt.Errorf("callback batch size has to be > 0")
+	}
+	if c.RequestsPerSecond <= 0 {
+		return fmt.Errorf("callback requests per second have to be > 0")
+	}
+	if c.MaxQueueSize <= 0 {
+		return fmt.Errorf("callback queue size has to be > 0")
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package reconciler
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
+)
+
+type ProgressEscalationConfig struct {
+	Steps       string
+	escalations []progress.Escalation
+}
+
+func (c *ProgressEscalationConfig) validate() error {
+	escalations, err := progress.ParseEscalations(c.Steps)
+	if err != nil {
+		return err
+	}
+	c.escalations = escalations
+	return nil
+}
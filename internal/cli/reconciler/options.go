@@ -10,8 +10,11 @@ type Options struct {
 	ServerConfig          *ServerConfig
 	WorkerConfig          *WorkerConfig
 	RetryConfig           *RetryConfig
-	HeartbeatSenderConfig *RecurringTaskConfig
+	HeartbeatSenderConfig *HeartbeatConfig
 	ProgressTrackerConfig *RecurringTaskConfig
+	EscalationConfig      *ProgressEscalationConfig
+	TuningConfig          *TuningConfig
+	CallbackConfig        *CallbackConfig
 	DryRun                bool
 }
 
@@ -22,8 +25,11 @@ func NewOptions(o *cli.Options) *Options {
 		&ServerConfig{},
 		&WorkerConfig{},
 		&RetryConfig{},
+		&HeartbeatConfig{},
 		&RecurringTaskConfig{},
-		&RecurringTaskConfig{},
+		&ProgressEscalationConfig{},
+		&TuningConfig{},
+		&CallbackConfig{},
 		false,
 	}
 }
@@ -47,5 +53,14 @@ func (o *Options) Validate() error {
 	if err := o.ProgressTrackerConfig.validate(); err != nil {
 		return err
 	}
-	return nil
+	if err := o.EscalationConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.TuningConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.CallbackConfig.validate(); err != nil {
+		return err
+	}
+	return o.ConfigureRedaction()
 }
@@ -19,3 +19,33 @@ func (c *RecurringTaskConfig) validate() error {
 	}
 	return nil
 }
+
+//HeartbeatConfig adds the bounds of adaptive intervals to the heartbeat interval: adaptive intervals are disabled if
+//neither MinInterval nor MaxInterval is set
+type HeartbeatConfig struct {
+	RecurringTaskConfig
+	MinInterval time.Duration
+	MaxInterval time.Duration
+}
+
+func (c *HeartbeatConfig) validate() error {
+	if err := c.RecurringTaskConfig.validate(); err != nil {
+		return err
+	}
+	if c.MinInterval < 0 || c.MaxInterval < 0 {
+		return fmt.Errorf("min. and max. interval cannot be < 0")
+	}
+	if c.MinInterval > c.Interval {
+		return fmt.Errorf("min. interval cannot be > interval (%.1f secs > %.1f secs)",
+			c.MinInterval.Seconds(), c.Interval.Seconds())
+	}
+	if c.MaxInterval > 0 && c.MaxInterval < c.Interval {
+		return fmt.Errorf("max. interval cannot be < interval (%.1f secs < %.1f secs)",
+			c.MaxInterval.Seconds(), c.Interval.Seconds())
+	}
+	if c.MaxInterval >= c.Timeout {
+		return fmt.Errorf("max. interval cannot be >= timeout (%.1f secs >= %.1f secs)",
+			c.MaxInterval.Seconds(), c.Timeout.Seconds())
+	}
+	return nil
+}
@@ -2,18 +2,33 @@ package reconciler
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/server"
 	"github.com/kyma-incubator/reconciler/pkg/ssl"
 )
 
 type ServerConfig struct {
-	Port       int
-	SSLCrtFile string
-	SSLKeyFile string
+	Port               int
+	SSLCrtFile         string
+	SSLKeyFile         string
+	TLS                ssl.ProtocolConfig
+	CertReloadInterval time.Duration
+	Limits             server.Limits
 }
 
 func (c *ServerConfig) validate() error {
 	if c.Port <= 0 || c.Port > 65535 {
 		return fmt.Errorf("port %d is out of range 1-65535", c.Port)
 	}
+	if err := c.TLS.Validate(); err != nil {
+		return err
+	}
+	if c.CertReloadInterval < 0 {
+		return fmt.Errorf("SSL certificate reload interval cannot be < 0")
+	}
+	if err := c.Limits.Validate(); err != nil {
+		return err
+	}
 	return ssl.VerifyKeyPair(c.SSLCrtFile, c.SSLKeyFile)
 }
@@ -1,7 +1,9 @@
 package reconciler
 
 import (
+	"github.com/kyma-incubator/reconciler/pkg/callback/client"
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 )
 
@@ -19,11 +21,37 @@ func NewComponentReconciler(o *Options, reconcilerName string, reconcilerMetrics
 		//configure reconciliation worker pool + retry-behaviour
 		WithWorkers(o.WorkerConfig.Workers, o.WorkerConfig.Timeout).
 		WithRetryDelay(o.RetryConfig.RetryDelay).
+		//capture slow operations in detail
+		WithTraceThreshold(o.WorkerConfig.TraceThreshold).
 		//configure status updates send to mothership reconciler
 		WithHeartbeatSenderConfig(o.HeartbeatSenderConfig.Interval, o.HeartbeatSenderConfig.Timeout).
+		WithAdaptiveHeartbeats(o.HeartbeatSenderConfig.MinInterval, o.HeartbeatSenderConfig.MaxInterval).
 		//configure reconciliation progress-checks applied on target K8s cluster
 		WithProgressTrackerConfig(o.ProgressTrackerConfig.Interval, o.ProgressTrackerConfig.Timeout).
+		WithProgressEscalations(o.EscalationConfig.escalations).
 		WithReconcilerMetricsSet(reconcilerMetricsSet)
 
+	//authentication, retries and circuit breaking of requests send to mothership reconciler
+	recon.WithCallbackClientConfig(client.Config{
+		Timeout:          o.CallbackConfig.Timeout,
+		MaxRetries:       &o.CallbackConfig.MaxRetries,
+		FailureThreshold: o.CallbackConfig.FailureThreshold,
+		OpenTimeout:      o.CallbackConfig.OpenTimeout,
+		TokenFile:        o.CallbackConfig.TokenFile,
+		CertFile:         o.CallbackConfig.CertFile,
+		KeyFile:          o.CallbackConfig.KeyFile,
+		CAFile:           o.CallbackConfig.CAFile,
+	})
+
+	//throttle and batch status updates send to mothership reconciler
+	if o.CallbackConfig.Batching {
+		recon.WithCallbackDispatcherConfig(callback.DispatcherConfig{
+			BatchInterval:     o.CallbackConfig.BatchInterval,
+			MaxBatchSize:      o.CallbackConfig.MaxBatchSize,
+			RequestsPerSecond: o.CallbackConfig.RequestsPerSecond,
+			MaxQueueSize:      o.CallbackConfig.MaxQueueSize,
+		})
+	}
+
 	return recon, nil
 }
@@ -0,0 +1,32 @@
+package reconciler
+
+import (
+	"fmt"
+	"os"
+)
+
+//podNamespaceEnv is expected to be injected by the downward API of the component reconciler deployment
+const podNamespaceEnv = "POD_NAMESPACE"
+
+type TuningConfig struct {
+	ConfigMap string
+	Namespace string
+}
+
+func (c *TuningConfig) Enabled() bool {
+	return c.ConfigMap != ""
+}
+
+func (c *TuningConfig) validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.Namespace == "" {
+		c.Namespace = os.Getenv(podNamespaceEnv)
+	}
+	if c.Namespace == "" {
+		return fmt.Errorf("namespace of tuning ConfigMap '%s' is undefined: "+
+			"set it explicitly or provide env var '%s'", c.ConfigMap, podNamespaceEnv)
+	}
+	return nil
+}
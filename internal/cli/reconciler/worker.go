@@ -6,8 +6,10 @@ import (
 )
 
 type WorkerConfig struct {
-	Workers int
-	Timeout time.Duration
+	Workers        int
+	Timeout        time.Duration
+	TraceThreshold time.Duration
+	StuckThreshold time.Duration
 }
 
 func (c *WorkerConfig) validate() error {
@@ -17,5 +19,15 @@ func (c *WorkerConfig) validate() error {
 	if c.Timeout <= 0 {
 		return fmt.Errorf("timeout for workers cannot be set to < 0")
 	}
+	if c.TraceThreshold < 0 {
+		return fmt.Errorf("trace threshold cannot be set to < 0")
+	}
+	if c.StuckThreshold < 0 {
+		return fmt.Errorf("stuck threshold cannot be set to < 0")
+	}
+	if c.StuckThreshold == 0 {
+		//operations running longer than their timeout ignore the cancellation of their context
+		c.StuckThreshold = c.Timeout
+	}
 	return nil
 }
@@ -1,6 +1,8 @@
 package converters
 
 import (
+	"time"
+
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/pkg/errors"
@@ -36,15 +38,40 @@ func ConvertOperation(operation *model.OperationEntity) keb.Operation {
 	if operation == nil {
 		return keb.Operation{}
 	}
+	var reconcilerVersion *string
+	if operation.ReconcilerVersion != "" {
+		reconcilerVersion = &operation.ReconcilerVersion
+	}
+	var started, finished *time.Time
+	if !operation.PickedUp.IsZero() {
+		started = &operation.PickedUp
+	}
+	if operation.State.IsFinal() {
+		finished = &operation.Updated
+	}
+	var pendingDeletion *[]string
+	if resources := operation.PendingDeletionResources(); len(resources) > 0 {
+		pendingDeletion = &resources
+	}
+	var deletionConfirmed *bool
+	if operation.DeletionConfirmed {
+		deletionConfirmed = &operation.DeletionConfirmed
+	}
 	return keb.Operation{
-		Component:     operation.Component,
-		CorrelationID: operation.CorrelationID,
-		Created:       operation.Created,
-		Priority:      operation.Priority,
-		Reason:        operation.Reason,
-		SchedulingID:  operation.SchedulingID,
-		State:         string(operation.State),
-		Updated:       operation.Updated,
-		Type:          string(operation.Type),
+		Component:         operation.Component,
+		CorrelationID:     operation.CorrelationID,
+		Created:           operation.Created,
+		DeletionConfirmed: deletionConfirmed,
+		Finished:          finished,
+		PendingDeletion:   pendingDeletion,
+		Priority:          operation.Priority,
+		Reason:            operation.Reason,
+		ReconcilerVersion: reconcilerVersion,
+		Retries:           operation.Retries,
+		SchedulingID:      operation.SchedulingID,
+		Started:           started,
+		State:             string(operation.State),
+		Updated:           operation.Updated,
+		Type:              string(operation.Type),
 	}
 }
@@ -89,3 +89,52 @@ func assertOperation(t *testing.T, input *model.OperationEntity, output keb.Oper
 	assert.Equal(t, string(input.State), output.State)
 	assert.Equal(t, input.Updated, output.Updated)
 }
+
+func TestConvertOperationTimings(t *testing.T) {
+	pickedUp := time.Unix(20, 0)
+	updated := time.Unix(80, 0)
+
+	t.Run("Running operation", func(t *testing.T) {
+		output := converters.ConvertOperation(&model.OperationEntity{
+			State:    model.OperationStateInProgress,
+			PickedUp: pickedUp,
+			Updated:  updated,
+			Retries:  2,
+		})
+		require.Equal(t, &pickedUp, output.Started)
+		require.Nil(t, output.Finished)
+		require.Equal(t, int64(2), output.Retries)
+	})
+
+	t.Run("Failed operation", func(t *testing.T) {
+		output := converters.ConvertOperation(&model.OperationEntity{
+			State:    model.OperationStateError,
+			Reason:   "timeout",
+			PickedUp: pickedUp,
+			Updated:  updated,
+			Retries:  5,
+		})
+		require.Equal(t, &pickedUp, output.Started)
+		require.Equal(t, &updated, output.Finished)
+		require.Equal(t, "timeout", output.Reason)
+	})
+
+	t.Run("Operation not picked up", func(t *testing.T) {
+		output := converters.ConvertOperation(&model.OperationEntity{State: model.OperationStateNew, Updated: updated})
+		require.Nil(t, output.Started)
+		require.Nil(t, output.Finished)
+		require.Nil(t, output.PendingDeletion)
+		require.Nil(t, output.DeletionConfirmed)
+	})
+}
+
+func TestConvertOperationPendingDeletion(t *testing.T) {
+	output := converters.ConvertOperation(&model.OperationEntity{
+		State:           model.OperationStatePendingConfirmation,
+		Type:            model.OperationTypeDelete,
+		PendingDeletion: `["Namespace/monitoring","PersistentVolumeClaim/kyma-system/storage"]`,
+	})
+	require.Equal(t, &[]string{"Namespace/monitoring", "PersistentVolumeClaim/kyma-system/storage"}, output.PendingDeletion)
+	require.Nil(t, output.Finished)
+	require.Nil(t, output.DeletionConfirmed)
+}
@@ -1,26 +1,47 @@
 package persistency
 
 import (
+	"github.com/kyma-incubator/reconciler/pkg/changes"
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/configtemplate"
 	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/deadletter"
 	"github.com/kyma-incubator/reconciler/pkg/features"
+	"github.com/kyma-incubator/reconciler/pkg/idempotency"
 	"github.com/kyma-incubator/reconciler/pkg/kv"
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/kyma-incubator/reconciler/pkg/payload"
+	"github.com/kyma-incubator/reconciler/pkg/query"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/occupancy"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/oplog"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/pause"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/trace"
+	"github.com/kyma-incubator/reconciler/pkg/subscription"
 	"go.uber.org/zap"
 )
 
 type Registry struct {
-	debug           bool
-	logger          *zap.SugaredLogger
-	connection      db.Connection
-	inventory       cluster.Inventory
-	kvRepository    *kv.Repository
-	reconRepository reconciliation.Repository
-	occupancyRepo   occupancy.Repository
-	initialized     bool
+	debug            bool
+	logger           *zap.SugaredLogger
+	connection       db.Connection
+	inventory        cluster.Inventory
+	statusListeners  *cluster.StatusListeners
+	kvRepository     *kv.Repository
+	reconRepository  reconciliation.Repository
+	occupancyRepo    occupancy.Repository
+	payloadRepo      *payload.Repository
+	deadLetterRepo   *deadletter.Repository
+	queryRepo        *query.Repository
+	pauseRepo        *pause.Repository
+	changeRepo       *changes.Repository
+	traceRepo        *trace.Repository
+	opLogRepo        *oplog.Repository
+	templateRepo     *configtemplate.Repository
+	subscriptionRepo *subscription.Repository
+	idempotencyRepo  *idempotency.Repository
+	initialized      bool
 }
 
 func NewRegistry(cf db.ConnectionFactory, debug bool) (*Registry, error) {
@@ -54,6 +75,9 @@ func (or *Registry) init() error {
 	if or.occupancyRepo, err = or.initOccupancyRepository(); err != nil {
 		return err
 	}
+	if err = or.initRepositories(); err != nil {
+		return err
+	}
 
 	or.initialized = true
 
@@ -75,6 +99,11 @@ func (or *Registry) Inventory() cluster.Inventory {
 	return or.inventory
 }
 
+//StatusListeners allows to register listeners which are notified about new statuses of clusters
+func (or *Registry) StatusListeners() *cluster.StatusListeners {
+	return or.statusListeners
+}
+
 func (or *Registry) KVRepository() *kv.Repository {
 	return or.kvRepository
 }
@@ -87,6 +116,46 @@ func (or *Registry) OccupancyRepository() occupancy.Repository {
 	return or.occupancyRepo
 }
 
+func (or *Registry) PayloadRepository() *payload.Repository {
+	return or.payloadRepo
+}
+
+func (or *Registry) DeadLetterRepository() *deadletter.Repository {
+	return or.deadLetterRepo
+}
+
+func (or *Registry) QueryRepository() *query.Repository {
+	return or.queryRepo
+}
+
+func (or *Registry) PauseRepository() *pause.Repository {
+	return or.pauseRepo
+}
+
+func (or *Registry) ChangeRepository() *changes.Repository {
+	return or.changeRepo
+}
+
+func (or *Registry) TraceRepository() *trace.Repository {
+	return or.traceRepo
+}
+
+func (or *Registry) OperationLogRepository() *oplog.Repository {
+	return or.opLogRepo
+}
+
+func (or *Registry) ConfigTemplateRepository() *configtemplate.Repository {
+	return or.templateRepo
+}
+
+func (or *Registry) SubscriptionRepository() *subscription.Repository {
+	return or.subscriptionRepo
+}
+
+func (or *Registry) IdempotencyRepository() *idempotency.Repository {
+	return or.idempotencyRepo
+}
+
 func (or *Registry) initRepository() (*kv.Repository, error) {
 	repository, err := kv.NewRepository(or.connection, or.debug)
 	if err != nil {
@@ -96,8 +165,8 @@ func (or *Registry) initRepository() (*kv.Repository, error) {
 }
 
 func (or *Registry) initInventory() (cluster.Inventory, error) {
-	collector := metrics.NewReconciliationStatusCollector(or.logger)
-	inventory, err := cluster.NewInventory(or.connection, or.debug, collector)
+	or.statusListeners = cluster.NewStatusListeners(metrics.NewReconciliationStatusCollector(or.logger))
+	inventory, err := cluster.NewInventory(or.connection, or.debug, or.statusListeners)
 	if err != nil {
 		or.logger.Errorf("Failed to create cluster inventory: %s", err)
 	}
@@ -122,3 +191,59 @@ func (or *Registry) initOccupancyRepository() (occupancy.Repository, error) {
 	}
 	return occupancyRepo, err
 }
+
+//initRepositories creates the repositories which only depend on the database connection
+func (or *Registry) initRepositories() error {
+	repositories := []struct {
+		name string
+		init func() error
+	}{
+		{name: "payload", init: func() (err error) {
+			or.payloadRepo, err = payload.NewRepository(or.connection, or.debug)
+			return err
+		}},
+		{name: "dead-letter", init: func() (err error) {
+			or.deadLetterRepo, err = deadletter.NewRepository(or.connection, or.debug)
+			return err
+		}},
+		{name: "query", init: func() (err error) {
+			or.queryRepo, err = query.NewRepository(or.connection, or.debug)
+			return err
+		}},
+		{name: "pause", init: func() (err error) {
+			or.pauseRepo, err = pause.NewRepository(or.connection, or.debug)
+			return err
+		}},
+		{name: "change", init: func() (err error) {
+			or.changeRepo, err = changes.NewRepository(or.connection, or.debug)
+			return err
+		}},
+		{name: "trace", init: func() (err error) {
+			or.traceRepo, err = trace.NewRepository(or.connection, or.debug)
+			return err
+		}},
+		{name: "operation log", init: func() (err error) {
+			or.opLogRepo, err = oplog.NewRepository(or.connection, or.debug)
+			return err
+		}},
+		{name: "configuration template", init: func() (err error) {
+			or.templateRepo, err = configtemplate.NewRepository(or.connection, or.debug)
+			return err
+		}},
+		{name: "subscription", init: func() (err error) {
+			or.subscriptionRepo, err = subscription.NewRepository(or.connection, or.debug)
+			return err
+		}},
+		{name: "idempotency", init: func() (err error) {
+			or.idempotencyRepo, err = idempotency.NewRepository(or.connection, or.debug)
+			return err
+		}},
+	}
+	for _, repository := range repositories {
+		if err := repository.init(); err != nil {
+			or.logger.Errorf("Failed to create %s repository: %s", repository.name, err)
+			return err
+		}
+	}
+	return nil
+}
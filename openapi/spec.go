@@ -0,0 +1,190 @@
+//Package openapi embeds the OpenAPI definitions of the mothership API and merges them into a single document which
+//is served by the mothership (clients can be generated from it).
+package openapi
+
+import (
+	_ "embed" //required to embed the specification files
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	//go:embed external_api.yaml
+	externalAPI []byte
+	//go:embed internal_api.yaml
+	internalAPI []byte
+)
+
+//externalRefPrefix is used in the internal API to reference definitions of the external API
+const externalRefPrefix = "./external_api.yaml#"
+
+var pathParamRegex = regexp.MustCompile(`{[^}]+}`)
+
+var httpMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true, "trace": true,
+}
+
+var merged = struct {
+	sync.Once
+	spec map[string]interface{}
+	json []byte
+	err  error
+}{}
+
+//JSON returns the merged OpenAPI document of the external and internal mothership API
+func JSON() ([]byte, error) {
+	load()
+	return merged.json, merged.err
+}
+
+//Operations returns the documented HTTP methods per path. Path parameters are replaced by '{}' to make the paths
+//comparable with the route templates of the HTTP router.
+func Operations() (map[string][]string, error) {
+	load()
+	if merged.err != nil {
+		return nil, merged.err
+	}
+	paths, _ := merged.spec["paths"].(map[string]interface{})
+	result := make(map[string][]string, len(paths))
+	for path, item := range paths {
+		operations, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("path '%s' of OpenAPI specification is not an object", path)
+		}
+		var methods []string
+		for method := range operations {
+			if httpMethods[method] {
+				methods = append(methods, strings.ToUpper(method))
+			}
+		}
+		sort.Strings(methods)
+		normalized := NormalizePath(path)
+		result[normalized] = append(result[normalized], methods...)
+	}
+	return result, nil
+}
+
+//NormalizePath replaces the names of path parameters by '{}'
+func NormalizePath(path string) string {
+	return pathParamRegex.ReplaceAllString(path, "{}")
+}
+
+func load() {
+	merged.Do(func() {
+		merged.spec, merged.err = merge(externalAPI, internalAPI)
+		if merged.err == nil {
+			merged.json, merged.err = json.Marshal(merged.spec)
+		}
+	})
+}
+
+//merge adds the paths and components of the internal API to the external API. Components which are defined in both
+//specifications are only added once if they are equal, otherwise the internal component is renamed.
+func merge(external, internal []byte) (map[string]interface{}, error) {
+	var result, internalSpec map[string]interface{}
+	if err := yaml.Unmarshal(external, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse external API specification: %s", err)
+	}
+	if err := yaml.Unmarshal(internal, &internalSpec); err != nil {
+		return nil, fmt.Errorf("failed to parse internal API specification: %s", err)
+	}
+
+	components, _ := result["components"].(map[string]interface{})
+	if components == nil {
+		components = make(map[string]interface{})
+		result["components"] = components
+	}
+	internalComponents, _ := internalSpec["components"].(map[string]interface{})
+	renamed := renameConflictingComponents(components, internalComponents)
+	rewriteRefs(internalSpec, func(ref string) string {
+		if strings.HasPrefix(ref, externalRefPrefix) {
+			return "#" + strings.TrimPrefix(ref, externalRefPrefix)
+		}
+		if newRef, ok := renamed[ref]; ok {
+			return newRef
+		}
+		return ref
+	})
+
+	if err := mergeSection(result, internalSpec, "paths"); err != nil {
+		return nil, err
+	}
+	for kind := range internalComponents {
+		if err := mergeSection(components, internalComponents, kind); err != nil {
+			return nil, err
+		}
+	}
+	if info, ok := result["info"].(map[string]interface{}); ok {
+		info["title"] = "Reconciler mothership API"
+		info["description"] = "API of the mothership component used by external clients and component reconcilers"
+	}
+	return result, nil
+}
+
+//renameConflictingComponents drops internal components which are equally defined in the external API and renames
+//the internal components which conflict with an external component. The renamed references are returned.
+func renameConflictingComponents(components, internalComponents map[string]interface{}) map[string]string {
+	renamed := make(map[string]string)
+	for kind, entries := range internalComponents {
+		internalEntries, _ := entries.(map[string]interface{})
+		externalEntries, _ := components[kind].(map[string]interface{})
+		var conflicts []string
+		for name, definition := range internalEntries {
+			if externalDefinition, ok := externalEntries[name]; ok {
+				if reflect.DeepEqual(externalDefinition, definition) {
+					delete(internalEntries, name)
+					continue
+				}
+				conflicts = append(conflicts, name)
+			}
+		}
+		for _, name := range conflicts {
+			newName := "internal" + strings.ToUpper(name[:1]) + name[1:]
+			internalEntries[newName] = internalEntries[name]
+			delete(internalEntries, name)
+			renamed[fmt.Sprintf("#/components/%s/%s", kind, name)] = fmt.Sprintf("#/components/%s/%s", kind, newName)
+		}
+	}
+	return renamed
+}
+
+func mergeSection(target, source map[string]interface{}, section string) error {
+	sourceEntries, _ := source[section].(map[string]interface{})
+	targetEntries, _ := target[section].(map[string]interface{})
+	if targetEntries == nil {
+		targetEntries = make(map[string]interface{}, len(sourceEntries))
+		target[section] = targetEntries
+	}
+	for name, entry := range sourceEntries {
+		if _, exists := targetEntries[name]; exists {
+			return fmt.Errorf("'%s' is defined in external and internal API specification (section '%s')",
+				name, section)
+		}
+		targetEntries[name] = entry
+	}
+	return nil
+}
+
+func rewriteRefs(node interface{}, rewrite func(ref string) string) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				value[key] = rewrite(ref)
+				continue
+			}
+			rewriteRefs(child, rewrite)
+		}
+	case []interface{}:
+		for _, child := range value {
+			rewriteRefs(child, rewrite)
+		}
+	}
+}
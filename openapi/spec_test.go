@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	data, err := JSON()
+	require.NoError(t, err)
+	require.NotContains(t, string(data), externalRefPrefix)
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &spec))
+	require.Equal(t, "3.0.0", spec["openapi"])
+
+	paths := spec["paths"].(map[string]interface{})
+	require.Contains(t, paths, "/clusters")
+	require.Contains(t, paths, "/operations/callbacks")
+
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	require.Contains(t, schemas, "cluster")
+	require.Contains(t, schemas, "callbackMessage")
+	require.Contains(t, schemas, "status")
+	require.Contains(t, schemas, "internalStatus")
+}
+
+func TestOperations(t *testing.T) {
+	operations, err := Operations()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"GET", "POST", "PUT"}, operations["/clusters"])
+	require.Equal(t, []string{"POST"}, operations["/operations/{}/callback/{}"])
+}
+
+func TestMerge(t *testing.T) {
+	_, err := merge([]byte(`{"paths": {"/a": {}}}`), []byte(`{"paths": {"/a": {}}}`))
+	require.Error(t, err)
+
+	spec, err := merge(
+		[]byte(`{"paths": {"/a": {}}, "components": {"schemas": {"x": {"type": "string"}, "y": {"type": "string"}}}}`),
+		[]byte(`{"paths": {"/b": {"post": {"$ref": "./external_api.yaml#/components/schemas/x"}},
+			"/c": {"post": {"$ref": "#/components/schemas/y"}}},
+			"components": {"schemas": {"x": {"type": "string"}, "y": {"type": "integer"}}}}`))
+	require.NoError(t, err)
+	paths := spec["paths"].(map[string]interface{})
+	require.Equal(t, "#/components/schemas/x",
+		paths["/b"].(map[string]interface{})["post"].(map[string]interface{})["$ref"])
+	require.Equal(t, "#/components/schemas/internalY",
+		paths["/c"].(map[string]interface{})["post"].(map[string]interface{})["$ref"])
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	require.Len(t, schemas, 3)
+}
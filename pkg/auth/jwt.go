@@ -0,0 +1,272 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+	"github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRefreshInterval = time.Hour
+	//min. time between two JWKS downloads triggered by tokens with an unknown key ID or after a failed download
+	minRefreshInterval = 10 * time.Second
+	jwksTimeout        = 10 * time.Second
+	maxJWKSBytes       = 1024 * 1024
+
+	bearerPrefix = "Bearer "
+)
+
+type contextKey struct{}
+
+//Config of the JWT authentication: the authentication is disabled if no JWKS URL is defined
+type Config struct {
+	//JWKSURL is the endpoint which provides the public keys of the token issuer
+	JWKSURL string
+	//Issuer has to match the 'iss' claim of the tokens (not verified if empty)
+	Issuer string
+	//Audiences have to be contained in the 'aud' claim of the tokens (not verified if empty)
+	Audiences []string
+	//RefreshInterval of the cached JWKS (default is 1h)
+	RefreshInterval time.Duration
+	//ProtectMetrics requires authentication for the metrics endpoint
+	ProtectMetrics bool
+	//ProtectHealth requires authentication for the liveness and readiness endpoints
+	ProtectHealth bool
+}
+
+func (c Config) Enabled() bool {
+	return c.JWKSURL != ""
+}
+
+//Claims of an authenticated request
+type Claims struct {
+	jwt.Claims
+	//Scope is the space separated list of scopes granted to the caller (if provided by the issuer)
+	Scope string `json:"scope,omitempty"`
+}
+
+//Authenticator verifies the JWT bearer tokens of requests against the keys provided by a JWKS endpoint
+type Authenticator struct {
+	cfg    Config
+	client *http.Client
+	logger *zap.SugaredLogger
+
+	m         sync.Mutex
+	keys      *jose.JSONWebKeySet
+	refreshed time.Time
+	attempted time.Time
+	download  *jwksDownload //running download which is shared by all requests waiting for the keys
+}
+
+type jwksDownload struct {
+	done chan struct{}
+	err  error
+}
+
+//NewAuthenticator returns the authenticator or nil if the authentication is disabled
+func NewAuthenticator(cfg Config, logger *zap.SugaredLogger) (*Authenticator, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	if !strings.HasPrefix(cfg.JWKSURL, "https://") && !strings.HasPrefix(cfg.JWKSURL, "http://") {
+		return nil, fmt.Errorf("JWKS URL '%s' is not a HTTP(S) URL", cfg.JWKSURL)
+	}
+	if cfg.RefreshInterval < 0 {
+		return nil, fmt.Errorf("JWKS refresh interval cannot be < 0 but was %s", cfg.RefreshInterval)
+	}
+	if cfg.RefreshInterval == 0 {
+		cfg.RefreshInterval = defaultRefreshInterval
+	}
+	return &Authenticator{
+		cfg:    cfg,
+		client: &http.Client{Timeout: jwksTimeout},
+		logger: logger,
+	}, nil
+}
+
+//Authenticate verifies the bearer token of the request and returns its claims
+func (a *Authenticator) Authenticate(r *http.Request) (*Claims, error) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return nil, errors.New("bearer token missing")
+	}
+	token, err := jwt.ParseSigned(strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix)))
+	if err != nil {
+		return nil, errors.Wrap(err, "bearer token is not a signed JWT")
+	}
+	var keyID string
+	for _, header := range token.Headers {
+		if header.KeyID != "" {
+			keyID = header.KeyID
+			break
+		}
+	}
+	key, err := a.key(r.Context(), keyID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{}
+	if err := token.Claims(key, claims); err != nil {
+		return nil, errors.Wrap(err, "signature of bearer token is invalid")
+	}
+	if claims.Expiry == nil {
+		return nil, errors.New("bearer token has no expiry")
+	}
+	if err := claims.Validate(jwt.Expected{
+		Issuer:   a.cfg.Issuer,
+		Audience: a.cfg.Audiences,
+		Time:     time.Now(),
+	}); err != nil {
+		return nil, errors.Wrap(err, "bearer token is not accepted")
+	}
+	return claims, nil
+}
+
+//key returns the public key of the key ID: the JWKS is downloaded again if it's outdated or doesn't contain the key.
+//If the download fails, the cached keys are used until the next download succeeds.
+func (a *Authenticator) key(ctx context.Context, keyID string, now time.Time) (*jose.JSONWebKey, error) {
+	keys, refreshed, attempted := a.cached()
+	if keys == nil || (now.Sub(refreshed) > a.cfg.RefreshInterval && now.Sub(attempted) > minRefreshInterval) {
+		if err := a.refresh(ctx, refreshed, now); err != nil {
+			if keys == nil {
+				return nil, err
+			}
+			a.logger.Warnf("Failed to refresh JWKS (using cached keys): %s", err)
+		}
+		keys, refreshed, attempted = a.cached()
+	}
+	key, ok := lookup(keys, keyID)
+	if !ok && now.Sub(attempted) > minRefreshInterval {
+		//the issuer could have rotated its keys
+		if err := a.refresh(ctx, refreshed, now); err != nil {
+			return nil, err
+		}
+		keys, _, _ = a.cached()
+		key, ok = lookup(keys, keyID)
+	}
+	if !ok {
+		return nil, fmt.Errorf("key '%s' of bearer token is unknown", keyID)
+	}
+	return key, nil
+}
+
+func (a *Authenticator) cached() (*jose.JSONWebKeySet, time.Time, time.Time) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	return a.keys, a.refreshed, a.attempted
+}
+
+func lookup(keys *jose.JSONWebKeySet, keyID string) (*jose.JSONWebKey, bool) {
+	if keyID == "" {
+		//tokens without key ID are only accepted if the issuer provides a single key
+		if len(keys.Keys) == 1 {
+			return &keys.Keys[0], true
+		}
+		return nil, false
+	}
+	matches := keys.Key(keyID)
+	if len(matches) == 0 {
+		return nil, false
+	}
+	return &matches[0], true
+}
+
+//refresh waits until the JWKS is downloaded unless it was refreshed since the given time: concurrent requests share
+//the same download which isn't bound to the context of a single request (it's limited by the timeout of the client)
+func (a *Authenticator) refresh(ctx context.Context, since, now time.Time) error {
+	a.m.Lock()
+	if a.refreshed.After(since) {
+		a.m.Unlock()
+		return nil
+	}
+	download := a.download
+	if download == nil {
+		download = &jwksDownload{done: make(chan struct{})}
+		a.download = download
+		a.attempted = now
+		go a.downloadKeys(download, now)
+	}
+	a.m.Unlock()
+
+	select {
+	case <-download.done:
+		return download.err
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "request cancelled while waiting for JWKS")
+	}
+}
+
+func (a *Authenticator) downloadKeys(download *jwksDownload, now time.Time) {
+	keys, err := a.fetchKeys()
+	a.m.Lock()
+	if err == nil {
+		a.keys = keys
+		a.refreshed = now
+	}
+	a.download = nil
+	a.m.Unlock()
+	download.err = err
+	close(download.done)
+}
+
+func (a *Authenticator) fetchKeys() (*jose.JSONWebKeySet, error) {
+	resp, err := a.client.Get(a.cfg.JWKSURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to download JWKS")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			a.logger.Warnf("Failed to close response body of JWKS endpoint: %s", err)
+		}
+	}()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxJWKSBytes))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS endpoint responded with HTTP code %d: %s", resp.StatusCode, body)
+	}
+	keys := &jose.JSONWebKeySet{}
+	if err := json.Unmarshal(body, keys); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal JWKS")
+	}
+	a.logger.Debugf("Downloaded %d keys from JWKS endpoint '%s'", len(keys.Keys), a.cfg.JWKSURL)
+	return keys, nil
+}
+
+//Middleware rejects requests without valid bearer token with HTTP 401 and passes the claims of authenticated
+//requests via the request context
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := a.Authenticate(r)
+		if err != nil {
+			a.logger.Debugf("Rejecting unauthenticated request %s %s: %s", r.Method, r.URL.Path, err)
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			server.SendHTTPError(w, http.StatusUnauthorized, &keb.HTTPErrorResponse{
+				Error: fmt.Sprintf("Request is not authenticated: %s", err),
+			})
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, claims)))
+	})
+}
+
+//FromContext returns the claims of an authenticated request
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newSigner(t *testing.T, keyID string) (jose.Signer, jose.JSONWebKey) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: privateKey},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", keyID))
+	require.NoError(t, err)
+	return signer, jose.JSONWebKey{Key: &privateKey.PublicKey, KeyID: keyID, Algorithm: string(jose.RS256), Use: "sig"}
+}
+
+func newToken(t *testing.T, signer jose.Signer, claims jwt.Claims) string {
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	authenticator, err := NewAuthenticator(Config{}, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	require.Nil(t, authenticator)
+
+	_, err = NewAuthenticator(Config{JWKSURL: "ftp://issuer/keys"}, zap.NewNop().Sugar())
+	require.Error(t, err)
+
+	_, err = NewAuthenticator(Config{JWKSURL: "https://issuer/keys", RefreshInterval: -time.Second}, zap.NewNop().Sugar())
+	require.Error(t, err)
+}
+
+func TestAuthenticator(t *testing.T) {
+	signer, publicKey := newSigner(t, "key1")
+	rotatedSigner, rotatedPublicKey := newSigner(t, "key2")
+	unknownSigner, _ := newSigner(t, "key3")
+
+	var downloads int32
+	var keys atomic.Value
+	keys.Store(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{publicKey}})
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&downloads, 1)
+		require.NoError(t, json.NewEncoder(w).Encode(keys.Load()))
+	}))
+	defer jwksServer.Close()
+
+	authenticator, err := NewAuthenticator(Config{
+		JWKSURL:   jwksServer.URL,
+		Issuer:    "https://issuer",
+		Audiences: []string{"reconciler"},
+	}, zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	now := time.Now()
+	validClaims := jwt.Claims{
+		Issuer:   "https://issuer",
+		Subject:  "keb",
+		Audience: jwt.Audience{"reconciler"},
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+		IssuedAt: jwt.NewNumericDate(now),
+	}
+	newRequest := func(token string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/v1/clusters", nil)
+		if token != "" {
+			req.Header.Set("Authorization", bearerPrefix+token)
+		}
+		return req
+	}
+
+	t.Run("Valid token", func(t *testing.T) {
+		claims, err := authenticator.Authenticate(newRequest(newToken(t, signer, validClaims)))
+		require.NoError(t, err)
+		require.Equal(t, "keb", claims.Subject)
+		require.Equal(t, int32(1), atomic.LoadInt32(&downloads))
+	})
+
+	t.Run("Invalid tokens", func(t *testing.T) {
+		wrongIssuer := validClaims
+		wrongIssuer.Issuer = "https://other-issuer"
+		wrongAudience := validClaims
+		wrongAudience.Audience = jwt.Audience{"other"}
+		expired := validClaims
+		expired.Expiry = jwt.NewNumericDate(now.Add(-time.Hour))
+		noExpiry := validClaims
+		noExpiry.Expiry = nil
+
+		for name, token := range map[string]string{
+			"no token":       "",
+			"malformed":      "abc",
+			"wrong issuer":   newToken(t, signer, wrongIssuer),
+			"wrong audience": newToken(t, signer, wrongAudience),
+			"expired":        newToken(t, signer, expired),
+			"no expiry":      newToken(t, signer, noExpiry),
+		} {
+			_, err := authenticator.Authenticate(newRequest(token))
+			require.Error(t, err, name)
+		}
+	})
+
+	t.Run("Rotated keys are downloaded", func(t *testing.T) {
+		keys.Store(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{publicKey, rotatedPublicKey}})
+		//keys are not refreshed more often than the min. refresh interval
+		_, err := authenticator.Authenticate(newRequest(newToken(t, rotatedSigner, validClaims)))
+		require.Error(t, err)
+
+		authenticator.attempted = authenticator.attempted.Add(-minRefreshInterval - time.Second)
+		_, err = authenticator.Authenticate(newRequest(newToken(t, rotatedSigner, validClaims)))
+		require.NoError(t, err)
+
+		_, err = authenticator.Authenticate(newRequest(newToken(t, unknownSigner, validClaims)))
+		require.Error(t, err)
+	})
+
+	t.Run("Middleware", func(t *testing.T) {
+		handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := FromContext(r.Context())
+			require.True(t, ok)
+			_, _ = w.Write([]byte(claims.Subject))
+		}))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newRequest(""))
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, newRequest(newToken(t, signer, validClaims)))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "keb", rec.Body.String())
+	})
+}
+
+func TestAuthenticatorRefresh(t *testing.T) {
+	signer, publicKey := newSigner(t, "key1")
+
+	var downloads int32
+	var failing atomic.Value
+	failing.Store(false)
+	release := make(chan struct{})
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&downloads, 1)
+		<-release
+		if failing.Load().(bool) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{publicKey}}))
+	}))
+	defer jwksServer.Close()
+
+	authenticator, err := NewAuthenticator(Config{JWKSURL: jwksServer.URL}, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	token := newToken(t, signer, jwt.Claims{Subject: "keb", Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour))})
+	authenticate := func() error {
+		req := httptest.NewRequest(http.MethodGet, "/v1/clusters", nil)
+		req.Header.Set("Authorization", bearerPrefix+token)
+		_, err := authenticator.Authenticate(req)
+		return err
+	}
+
+	t.Run("Concurrent requests share the download", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan error, 5)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- authenticate()
+			}()
+		}
+		require.Eventually(t, func() bool {
+			authenticator.m.Lock()
+			defer authenticator.m.Unlock()
+			return authenticator.download != nil
+		}, time.Second, 10*time.Millisecond)
+		close(release)
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		require.Equal(t, int32(1), atomic.LoadInt32(&downloads))
+	})
+
+	t.Run("Cached keys are used if the refresh fails", func(t *testing.T) {
+		failing.Store(true)
+		authenticator.m.Lock()
+		authenticator.refreshed = time.Now().Add(-2 * defaultRefreshInterval)
+		authenticator.attempted = authenticator.refreshed
+		authenticator.m.Unlock()
+
+		require.NoError(t, authenticate())
+		require.Equal(t, int32(2), atomic.LoadInt32(&downloads))
+
+		//failed downloads are not retried before the min. refresh interval passed
+		require.NoError(t, authenticate())
+		require.Equal(t, int32(2), atomic.LoadInt32(&downloads))
+	})
+}
@@ -0,0 +1,400 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	//PayloadVersionHeader declares the payload version of the requests between the mothership and the component
+	//reconcilers: the tasks sent by the mothership and the callbacks sent by the component reconcilers
+	PayloadVersionHeader = "X-Reconciler-Payload-Version"
+	//PayloadVersion has to be increased if the payloads sent to the mothership change incompatibly
+	PayloadVersion = "1"
+
+	defaultTimeout          = 30 * time.Second
+	defaultMaxRetries       = 3
+	defaultMinBackoff       = 200 * time.Millisecond
+	defaultMaxBackoff       = 5 * time.Second
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+)
+
+//CircuitOpenError is returned without calling the mothership while its circuit breaker is open
+type CircuitOpenError struct {
+	target string
+	until  time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("mothership '%s' is not called until %s: too many consecutive requests failed",
+		e.target, e.until.Format(time.RFC3339))
+}
+
+func IsCircuitOpenError(err error) bool {
+	var circuitErr *CircuitOpenError
+	return errors.As(err, &circuitErr)
+}
+
+type Config struct {
+	//Timeout of a single request
+	Timeout time.Duration
+	//MaxRetries of requests which failed with a network error or a temporary server error (5xx, 429): nil uses the
+	//default of 3 retries, 0 disables retries
+	MaxRetries *int
+	//MinBackoff and MaxBackoff limit the jittered exponential delay between retries
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	//FailureThreshold of consecutive requests to a mothership which failed because it's unavailable (network errors,
+	//HTTP 429 and 502-504) and open its circuit breaker for the OpenTimeout
+	FailureThreshold int
+	OpenTimeout      time.Duration
+	//TokenFile contains a bearer token: it's read per request to pick up rotated tokens
+	TokenFile string
+	//CertFile and KeyFile contain the client certificate, CAFile the CA of the mothership's server certificate
+	CertFile string
+	KeyFile  string
+	CAFile   string
+}
+
+func (c *Config) validate() error {
+	switch {
+	case c.Timeout < 0:
+		return fmt.Errorf("mothership client timeout cannot be < 0 (got %s)", c.Timeout)
+	case c.MaxRetries != nil && *c.MaxRetries < 0:
+		return fmt.Errorf("mothership client retries cannot be < 0 (got %d)", *c.MaxRetries)
+	case c.MinBackoff < 0 || c.MaxBackoff < 0:
+		return fmt.Errorf("mothership client backoff cannot be < 0 (got %s-%s)", c.MinBackoff, c.MaxBackoff)
+	case c.FailureThreshold < 0:
+		return fmt.Errorf("mothership client failure threshold cannot be < 0 (got %d)", c.FailureThreshold)
+	case c.OpenTimeout < 0:
+		return fmt.Errorf("mothership client circuit breaker timeout cannot be < 0 (got %s)", c.OpenTimeout)
+	case (c.CertFile == "") != (c.KeyFile == ""):
+		return errors.New("mothership client requires both, certificate and key file, for client certificates")
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.MaxRetries == nil {
+		maxRetries := defaultMaxRetries
+		c.MaxRetries = &maxRetries
+	}
+	if c.MinBackoff == 0 {
+		c.MinBackoff = defaultMinBackoff
+	}
+	if c.MaxBackoff == 0 {
+		c.MaxBackoff = defaultMaxBackoff
+	}
+	if c.MaxBackoff < c.MinBackoff {
+		c.MaxBackoff = c.MinBackoff
+	}
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = defaultFailureThreshold
+	}
+	if c.OpenTimeout == 0 {
+		c.OpenTimeout = defaultOpenTimeout
+	}
+	return nil
+}
+
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	if c.CertFile == "" && c.CAFile == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load client certificate '%s'", c.CertFile)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if c.CAFile != "" {
+		caPEM, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read CA file '%s'", c.CAFile)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("CA file '%s' doesn't contain any PEM encoded certificate", c.CAFile)
+		}
+	}
+	return tlsConfig, nil
+}
+
+//Response of the mothership: the body is read completely to allow retries and connection reuse
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+//Decode unmarshals the JSON body of the response
+func (r *Response) Decode(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+//Client is used by component reconcilers to call the mothership: it authenticates the requests, retries temporary
+//failures with a jittered exponential backoff and stops calling a mothership for a while (circuit breaker) if
+//too many requests failed in a row because it was unavailable.
+type Client struct {
+	config     Config
+	logger     *zap.SugaredLogger
+	httpClient *http.Client
+	mutex      sync.Mutex
+	breakers   map[string]*breaker //circuit breakers per mothership (scheme and host of the URL)
+	rand       *rand.Rand
+}
+
+type breaker struct {
+	failures  int
+	openUntil time.Time
+}
+
+func NewClient(config Config, logger *zap.SugaredLogger) (*Client, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	if logger == nil {
+		logger = zap.NewNop().Sugar()
+	}
+	tlsConfig, err := config.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &Client{
+		config:     config,
+		logger:     logger,
+		httpClient: &http.Client{Timeout: config.Timeout, Transport: transport},
+		breakers:   make(map[string]*breaker),
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec //jitter isn't security relevant
+	}, nil
+}
+
+var defaultClient = struct {
+	sync.Once
+	client *Client
+}{}
+
+//Default returns a client with the default configuration which is shared by all callers: the circuit breakers are
+//kept per mothership and only count requests which failed because the mothership was unavailable
+func Default() *Client {
+	defaultClient.Do(func() {
+		client, err := NewClient(Config{}, nil)
+		if err != nil { //the default configuration is always valid
+			panic(err)
+		}
+		defaultClient.client = client
+	})
+	return defaultClient.client
+}
+
+//Get sends a GET request with additional headers to the mothership
+func (c *Client) Get(ctx context.Context, url string, header http.Header) (*Response, error) {
+	return c.do(ctx, http.MethodGet, url, nil, header)
+}
+
+//Post sends the payload as JSON to the mothership
+func (c *Client) Post(ctx context.Context, url string, payload interface{}) (*Response, error) {
+	return c.Do(ctx, http.MethodPost, url, payload)
+}
+
+//Delete sends a DELETE request to the mothership
+func (c *Client) Delete(ctx context.Context, url string) (*Response, error) {
+	return c.Do(ctx, http.MethodDelete, url, nil)
+}
+
+//Do sends a request to the mothership and retries it on temporary failures. An error is only returned if no
+//response was received: callers have to verify the status code of the response.
+func (c *Client) Do(ctx context.Context, method, url string, payload interface{}) (*Response, error) {
+	return c.do(ctx, method, url, payload, nil)
+}
+
+func (c *Client) do(ctx context.Context, method, url string, payload interface{}, header http.Header) (*Response, error) {
+	target := breakerTarget(url)
+	if err := c.allow(target); err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, errors.Wrap(err, "failed to marshal payload")
+		}
+	}
+
+	var resp *Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		resp, err = c.send(ctx, method, url, body, header)
+		if !retryable(resp, err) || attempt >= *c.config.MaxRetries {
+			break
+		}
+		delay := c.backoff(attempt, resp)
+		c.logger.Debugf("Request %s '%s' failed temporarily (%s): retrying in %s", method, url, describe(resp, err), delay)
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "request %s '%s' cancelled while waiting for retry", method, url)
+		case <-time.After(delay):
+		}
+	}
+	c.record(target, !unavailable(resp, err))
+	return resp, err
+}
+
+func (c *Client) send(ctx context.Context, method, url string, body []byte, header http.Header) (*Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(PayloadVersionHeader, PayloadVersion)
+	if c.config.TokenFile != "" {
+		token, err := ioutil.ReadFile(c.config.TokenFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read bearer token file '%s'", c.config.TokenFile)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := httpResp.Body.Close(); err != nil {
+			c.logger.Warnf("Failed to close response body of request %s '%s': %s", method, url, err)
+		}
+	}()
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read response body of request %s '%s'", method, url)
+	}
+	return &Response{StatusCode: httpResp.StatusCode, Header: httpResp.Header, Body: respBody}, nil
+}
+
+//retryable returns true for network errors and temporary server errors
+func retryable(resp *Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+//unavailable returns true if the request failed because the mothership couldn't process any requests (network errors,
+//overload and gateway errors): errors of a particular request (e.g. HTTP 500) don't open the circuit breaker
+func unavailable(resp *Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+//backoff returns the delay before the next retry: a Retry-After header of the mothership is respected,
+//otherwise the delay grows exponentially with full jitter
+func (c *Client) backoff(attempt int, resp *Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			delay := time.Duration(seconds) * time.Second
+			if delay > c.config.MaxBackoff {
+				delay = c.config.MaxBackoff
+			}
+			return delay
+		}
+	}
+	maxDelay := c.config.MinBackoff << uint(attempt)
+	if maxDelay > c.config.MaxBackoff || maxDelay <= 0 { //<= 0 if shifting overflowed
+		maxDelay = c.config.MaxBackoff
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.config.MinBackoff + time.Duration(c.rand.Int63n(int64(maxDelay-c.config.MinBackoff)+1))
+}
+
+//breakerTarget returns the mothership which is called by the URL
+func breakerTarget(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+//allow rejects requests while the circuit breaker of the mothership is open. After the timeout, requests are sent
+//again: if the next one fails, the circuit breaker opens again.
+func (c *Client) allow(target string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if b, ok := c.breakers[target]; ok && time.Now().Before(b.openUntil) {
+		return &CircuitOpenError{target: target, until: b.openUntil}
+	}
+	return nil
+}
+
+func (c *Client) record(target string, success bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if success {
+		delete(c.breakers, target)
+		return
+	}
+	b, ok := c.breakers[target]
+	if !ok {
+		b = &breaker{}
+		c.breakers[target] = b
+	}
+	b.failures++
+	if b.failures >= c.config.FailureThreshold {
+		b.openUntil = time.Now().Add(c.config.OpenTimeout)
+		c.logger.Warnf("Mothership client stops sending requests to '%s' for %s: %d requests failed in a row",
+			target, c.config.OpenTimeout, b.failures)
+	}
+}
+
+func describe(resp *Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("HTTP response code: %d", resp.StatusCode)
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, config Config) *Client {
+	config.MinBackoff = time.Millisecond
+	config.MaxBackoff = 5 * time.Millisecond
+	client, err := NewClient(config, nil)
+	require.NoError(t, err)
+	return client
+}
+
+//newTestServer responds with the status codes in the given order (the last one is repeated)
+func newTestServer(t *testing.T, statusCodes ...int) (*httptest.Server, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(&calls, 1))
+		if call > len(statusCodes) {
+			call = len(statusCodes)
+		}
+		w.WriteHeader(statusCodes[call-1])
+		_, _ = w.Write([]byte(`{"error":"test"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func retries(maxRetries int) *int {
+	return &maxRetries
+}
+
+func TestConfig(t *testing.T) {
+	config := Config{}
+	require.NoError(t, config.validate())
+	require.Equal(t, defaultTimeout, config.Timeout)
+	require.Equal(t, defaultMaxRetries, *config.MaxRetries)
+	require.Equal(t, defaultFailureThreshold, config.FailureThreshold)
+
+	for name, invalid := range map[string]Config{
+		"negative timeout":   {Timeout: -time.Second},
+		"negative retries":   {MaxRetries: retries(-1)},
+		"negative backoff":   {MinBackoff: -time.Second},
+		"negative threshold": {FailureThreshold: -1},
+		"certificate only":   {CertFile: "client.crt"},
+	} {
+		require.Error(t, invalid.validate(), name)
+	}
+
+	_, err := NewClient(Config{CAFile: "/does/not/exist"}, nil)
+	require.Error(t, err)
+	require.Same(t, Default(), Default())
+}
+
+func TestClient(t *testing.T) {
+	t.Run("Request headers", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, ioutil.WriteFile(tokenFile, []byte("abc\n"), 0600))
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "Bearer abc", r.Header.Get("Authorization"))
+			require.Equal(t, PayloadVersion, r.Header.Get(PayloadVersionHeader))
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			_, _ = w.Write(body)
+		}))
+		defer srv.Close()
+
+		resp, err := newTestClient(t, Config{TokenFile: tokenFile}).Post(context.Background(), srv.URL,
+			map[string]string{"status": "success"})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var payload map[string]string
+		require.NoError(t, resp.Decode(&payload))
+		require.Equal(t, "success", payload["status"])
+	})
+
+	t.Run("Additional headers", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			require.Equal(t, "abc", r.Header.Get("X-Test"))
+			require.Empty(t, r.Header.Get("Content-Type"))
+		}))
+		defer srv.Close()
+
+		resp, err := newTestClient(t, Config{}).Get(context.Background(), srv.URL, http.Header{"X-Test": {"abc"}})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Temporary failures are retried", func(t *testing.T) {
+		srv, calls := newTestServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+		resp, err := newTestClient(t, Config{}).Delete(context.Background(), srv.URL)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Client errors are not retried", func(t *testing.T) {
+		srv, calls := newTestServer(t, http.StatusGone)
+		resp, err := newTestClient(t, Config{}).Post(context.Background(), srv.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusGone, resp.StatusCode)
+		require.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Retries are limited", func(t *testing.T) {
+		srv, calls := newTestServer(t, http.StatusInternalServerError)
+		resp, err := newTestClient(t, Config{MaxRetries: retries(2)}).Post(context.Background(), srv.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		require.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Retries can be disabled", func(t *testing.T) {
+		srv, calls := newTestServer(t, http.StatusServiceUnavailable)
+		resp, err := newTestClient(t, Config{MaxRetries: retries(0)}).Post(context.Background(), srv.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		require.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Circuit breaker", func(t *testing.T) {
+		srv, calls := newTestServer(t, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK)
+		otherSrv, otherCalls := newTestServer(t, http.StatusOK)
+		//retries are disabled to count single requests
+		client := newTestClient(t, Config{MaxRetries: retries(0), FailureThreshold: 2, OpenTimeout: 50 * time.Millisecond})
+
+		for i := 0; i < 2; i++ {
+			_, err := client.Post(context.Background(), srv.URL, nil)
+			require.NoError(t, err)
+		}
+		_, err := client.Post(context.Background(), srv.URL, nil)
+		require.True(t, IsCircuitOpenError(err))
+		require.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+		//other motherships are still called
+		resp, err := client.Post(context.Background(), otherSrv.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, int32(1), atomic.LoadInt32(otherCalls))
+
+		time.Sleep(60 * time.Millisecond)
+		resp, err = client.Post(context.Background(), srv.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Empty(t, client.breakers)
+	})
+
+	t.Run("Failed requests don't open the circuit breaker", func(t *testing.T) {
+		srv, calls := newTestServer(t, http.StatusInternalServerError)
+		client := newTestClient(t, Config{MaxRetries: retries(0), FailureThreshold: 2})
+		for i := 0; i < 3; i++ {
+			resp, err := client.Post(context.Background(), srv.URL, nil)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		}
+		require.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Cancelled context stops retries", func(t *testing.T) {
+		srv, _ := newTestServer(t, http.StatusServiceUnavailable)
+		client := newTestClient(t, Config{})
+		client.config.MinBackoff = time.Hour
+		client.config.MaxBackoff = time.Hour
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.Post(ctx, srv.URL, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	client := newTestClient(t, Config{})
+	for attempt := 0; attempt < 100; attempt++ {
+		delay := client.backoff(attempt, nil)
+		require.GreaterOrEqual(t, delay, client.config.MinBackoff)
+		require.LessOrEqual(t, delay, client.config.MaxBackoff)
+	}
+	require.Zero(t, client.backoff(0, &Response{Header: http.Header{"Retry-After": []string{"0"}}}))
+	require.Equal(t, client.config.MaxBackoff, client.backoff(0, &Response{Header: http.Header{"Retry-After": []string{"60"}}}))
+}
@@ -0,0 +1,230 @@
+package changes
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	tblRetention = "inventory_changes_retention"
+	//sequenceLockID is the ID of the advisory lock which serializes the assignment of feed positions
+	sequenceLockID = 6893124
+)
+
+//ExpiredCursorError indicates that changes after the cursor were already purged: the consumer
+//has to resync the full state and continue with a new cursor
+type ExpiredCursorError struct {
+	Cursor int64
+}
+
+func (e *ExpiredCursorError) Error() string {
+	return fmt.Sprintf("cursor '%d' is expired: the changes after it were already purged", e.Cursor)
+}
+
+func IsExpiredCursorError(err error) bool {
+	_, ok := err.(*ExpiredCursorError)
+	return ok
+}
+
+//Record adds a change to the feed. It has to be called with the transaction which applies the change
+//to ensure the feed contains only committed changes.
+func Record(conn db.Connection, change *model.ChangeEntity, logger *zap.SugaredLogger) error {
+	q, err := db.NewQuery(conn, change, logger)
+	if err != nil {
+		return err
+	}
+	if err := q.Insert().Exec(); err != nil {
+		logger.Errorf("Failed to record change '%s' in change feed: %s", change, err)
+		return err
+	}
+	return nil
+}
+
+//Repository reads the change feed of the inventory and reconciliation states. Consumers poll the
+//changes after the cursor (the ID of the last change they processed) to mirror the states.
+type Repository struct {
+	*repository.Repository
+}
+
+func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{repo}, nil
+}
+
+//Changes returns up to limit changes after the cursor (oldest first). The feed starts with the
+//oldest retained change if the cursor is 0.
+func (cr *Repository) Changes(cursor int64, limit int) ([]*model.ChangeEntity, error) {
+	if cursor > 0 {
+		if err := cr.checkCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+	if err := cr.publish(); err != nil {
+		return nil, err
+	}
+
+	colHandler, err := db.NewColumnHandler(&model.ChangeEntity{}, cr.Conn, cr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	positionCol, err := colHandler.ColumnName("FeedPosition")
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := db.NewQuery(cr.Conn, &model.ChangeEntity{}, cr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	selectQ := q.Select()
+	selectQ.WhereRaw(fmt.Sprintf("%s>$%d", positionCol, selectQ.NextPlaceholderCount()), cursor)
+	entities, err := selectQ.
+		OrderBy(map[string]string{"FeedPosition": "ASC"}).
+		Limit(limit).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.ChangeEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.ChangeEntity))
+	}
+	return result, nil
+}
+
+//publish assigns the feed positions of the changes whose recording transactions are committed. IDs are assigned
+//when a transaction writes a change but transactions commit in a different order: a cursor based on IDs would
+//skip changes of transactions which commit late.
+func (cr *Repository) publish() error {
+	colHandler, err := db.NewColumnHandler(&model.ChangeEntity{}, cr.Conn, cr.Logger)
+	if err != nil {
+		return err
+	}
+	idCol, err := colHandler.ColumnName("ID")
+	if err != nil {
+		return err
+	}
+	positionCol, err := colHandler.ColumnName("FeedPosition")
+	if err != nil {
+		return err
+	}
+	tblChanges := (&model.ChangeEntity{}).Table()
+
+	switch cr.Conn.Type() {
+	case db.Postgres:
+		return db.Transaction(cr.Conn, func(tx *db.TxConnection) error {
+			//positions are assigned by one mothership replica at a time
+			if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", sequenceLockID); err != nil {
+				return err
+			}
+			//all transactions below the xmin watermark of the snapshot are finished: their changes are final
+			//and get positions in the order of the transaction IDs
+			_, err := tx.Exec(fmt.Sprintf(`UPDATE %[1]s SET %[3]s=published.position FROM (
+				SELECT %[2]s, nextval('%[1]s_%[3]s_seq') AS position FROM (
+					SELECT %[2]s FROM %[1]s WHERE %[3]s IS NULL AND txid < txid_snapshot_xmin(txid_current_snapshot())
+					ORDER BY txid, %[2]s
+				) AS committed
+			) AS published WHERE %[1]s.%[2]s=published.%[2]s`, tblChanges, idCol, positionCol))
+			return err
+		}, cr.Logger)
+	case db.SQLite:
+		//SQLite serializes all writes: the IDs are assigned in commit order
+		_, err := cr.Conn.Exec(fmt.Sprintf("UPDATE %s SET %s=%s WHERE %s IS NULL",
+			tblChanges, positionCol, idCol, positionCol))
+		return err
+	default:
+		return fmt.Errorf("database type '%s' is not supported by the change feed", cr.Conn.Type())
+	}
+}
+
+//checkCursor verifies that the changes following the cursor weren't purged yet
+func (cr *Repository) checkCursor(cursor int64) error {
+	purgedPosition, err := cr.purgedPosition(cr.Conn)
+	if err != nil {
+		return err
+	}
+	if cursor < purgedPosition {
+		return &ExpiredCursorError{Cursor: cursor}
+	}
+	return nil
+}
+
+//purgedPosition returns the retention boundary of the feed: the highest position of the purged changes
+func (cr *Repository) purgedPosition(conn db.Connection) (int64, error) {
+	row, err := conn.QueryRow(fmt.Sprintf("SELECT purged_position FROM %s WHERE id=$1", tblRetention), 1)
+	if err != nil {
+		return 0, err
+	}
+	var purgedPosition int64
+	if err := row.Scan(&purgedPosition); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return purgedPosition, nil
+}
+
+//RemoveChangesOlderThan purges the published changes which were recorded before the deadline and moves the
+//retention boundary of the feed
+func (cr *Repository) RemoveChangesOlderThan(deadline time.Time) (int64, error) {
+	colHandler, err := db.NewColumnHandler(&model.ChangeEntity{}, cr.Conn, cr.Logger)
+	if err != nil {
+		return 0, err
+	}
+	createdCol, err := colHandler.ColumnName("Created")
+	if err != nil {
+		return 0, err
+	}
+	positionCol, err := colHandler.ColumnName("FeedPosition")
+	if err != nil {
+		return 0, err
+	}
+	tblChanges := (&model.ChangeEntity{}).Table()
+
+	dbOp := func(tx *db.TxConnection) (interface{}, error) {
+		row, err := tx.QueryRow(fmt.Sprintf("SELECT MAX(%s) FROM %s WHERE %s<$1",
+			positionCol, tblChanges, createdCol), deadline.Format(db.TimeFormat))
+		if err != nil {
+			return int64(0), err
+		}
+		var boundary sql.NullInt64
+		if err := row.Scan(&boundary); err != nil {
+			return int64(0), err
+		}
+		if !boundary.Valid {
+			return int64(0), nil
+		}
+
+		q, err := db.NewQuery(tx, &model.ChangeEntity{}, cr.Logger)
+		if err != nil {
+			return int64(0), err
+		}
+		deleteQ := q.Delete()
+		deleted, err := deleteQ.
+			WhereRaw(fmt.Sprintf("%s<=$%d", positionCol, deleteQ.NextPlaceholderCount()), boundary.Int64).
+			Exec()
+		if err != nil {
+			return int64(0), err
+		}
+		_, err = tx.Exec(fmt.Sprintf("INSERT INTO %s (id, purged_position) VALUES ($1, $2) "+
+			"ON CONFLICT (id) DO UPDATE SET purged_position=excluded.purged_position", tblRetention), 1, boundary.Int64)
+		return deleted, err
+	}
+	deleted, err := db.TransactionResult(cr.Conn, dbOp, cr.Logger)
+	if err != nil {
+		return 0, err
+	}
+	return deleted.(int64), nil
+}
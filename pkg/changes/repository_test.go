@@ -0,0 +1,117 @@
+package changes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repo, err := NewRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+
+	runtimeID := uuid.NewString()
+	defer func() {
+		q, err := db.NewQuery(repo.Conn, &model.ChangeEntity{}, repo.Logger)
+		require.NoError(t, err)
+		_, err = q.Delete().Where(map[string]interface{}{"RuntimeID": runtimeID}).Exec()
+		require.NoError(t, err)
+	}()
+
+	//returns the changes of the test runtime which follow the cursor
+	runtimeChanges := func(cursor int64) []*model.ChangeEntity {
+		entities, err := repo.Changes(cursor, 1000)
+		require.NoError(t, err)
+		var result []*model.ChangeEntity
+		for _, entity := range entities {
+			if entity.RuntimeID == runtimeID {
+				result = append(result, entity)
+			}
+		}
+		return result
+	}
+
+	require.NoError(t, Record(repo.Conn, &model.ChangeEntity{
+		Kind:          model.ChangeKindCluster,
+		RuntimeID:     runtimeID,
+		ConfigVersion: 1,
+		Status:        model.ClusterStatusReconcilePending,
+	}, repo.Logger))
+	require.NoError(t, Record(repo.Conn, &model.ChangeEntity{
+		Kind:          model.ChangeKindReconciliation,
+		RuntimeID:     runtimeID,
+		ConfigVersion: 1,
+		SchedulingID:  "scheduling-1",
+		Status:        model.ClusterStatusReconciling,
+	}, repo.Logger))
+
+	t.Run("Changes are ordered and resumable", func(t *testing.T) {
+		changes := runtimeChanges(0)
+		require.Len(t, changes, 2)
+		require.Equal(t, model.ChangeKindCluster, changes[0].Kind)
+		require.Empty(t, changes[0].SchedulingID)
+		require.Equal(t, model.ChangeKindReconciliation, changes[1].Kind)
+		require.Equal(t, "scheduling-1", changes[1].SchedulingID)
+		require.True(t, changes[0].FeedPosition < changes[1].FeedPosition)
+
+		//resume after the first change
+		resumed := runtimeChanges(changes[0].FeedPosition)
+		require.Len(t, resumed, 1)
+		require.Equal(t, changes[1].ID, resumed[0].ID)
+
+		require.Empty(t, runtimeChanges(changes[1].FeedPosition))
+	})
+
+	t.Run("Changes of late committing transactions are not skipped", func(t *testing.T) {
+		if repo.Conn.Type() != db.Postgres {
+			t.Skip("SQLite serializes all write transactions")
+		}
+		cursor := runtimeChanges(0)[1].FeedPosition
+
+		//the change of the first transaction gets a lower ID but is committed after the second change
+		tx, err := repo.Conn.Begin()
+		require.NoError(t, err)
+		require.NoError(t, Record(tx, &model.ChangeEntity{
+			Kind:          model.ChangeKindCluster,
+			RuntimeID:     runtimeID,
+			ConfigVersion: 2,
+			Status:        model.ClusterStatusReconcilePending,
+		}, repo.Logger))
+		require.NoError(t, Record(repo.Conn, &model.ChangeEntity{
+			Kind:          model.ChangeKindCluster,
+			RuntimeID:     runtimeID,
+			ConfigVersion: 3,
+			Status:        model.ClusterStatusReconcilePending,
+		}, repo.Logger))
+		require.Empty(t, runtimeChanges(cursor))
+
+		require.NoError(t, tx.GetTx().Commit())
+		changes := runtimeChanges(cursor)
+		require.Len(t, changes, 2)
+		require.Equal(t, int64(2), changes[0].ConfigVersion)
+		require.Equal(t, int64(3), changes[1].ConfigVersion)
+	})
+
+	t.Run("Remove changes older than deadline", func(t *testing.T) {
+		changes := runtimeChanges(0)
+		require.NotEmpty(t, changes)
+
+		_, err := repo.RemoveChangesOlderThan(time.Now().UTC().Add(-24 * time.Hour))
+		require.NoError(t, err)
+		require.Len(t, runtimeChanges(0), len(changes))
+
+		_, err = repo.RemoveChangesOlderThan(time.Now().UTC().Add(time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, runtimeChanges(0))
+
+		//cursors before the retention boundary are expired
+		_, err = repo.Changes(changes[0].FeedPosition-1, 1000)
+		require.True(t, IsExpiredCursorError(err))
+		_, err = repo.Changes(changes[len(changes)-1].FeedPosition, 1000)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,192 @@
+package cluster
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+//maxBatchSize limits the number of entities which are fetched with one query (SQLite supports only 999
+//placeholders per statement)
+const maxBatchSize = 500
+
+//states resolves the configurations and clusters of the statuses in batches instead of fetching them status
+//by status
+func (i *DefaultInventory) states(statuses []*model.ClusterStatusEntity) ([]*State, error) {
+	var configVersions []int64
+	for _, status := range statuses {
+		configVersions = append(configVersions, status.ConfigVersion)
+	}
+	configs, err := i.configsByVersion(configVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusterVersions []int64
+	for _, config := range configs {
+		clusterVersions = append(clusterVersions, config.ClusterVersion)
+	}
+	clusters, err := i.clustersByVersion(clusterVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*State
+	for _, status := range statuses {
+		config, ok := configs[status.ConfigVersion]
+		if !ok {
+			return nil, i.NewNotFoundError(
+				fmt.Errorf("configuration '%d' of cluster '%s' not found", status.ConfigVersion, status.RuntimeID),
+				&model.ClusterConfigurationEntity{},
+				map[string]interface{}{"Version": status.ConfigVersion})
+		}
+		cluster, ok := clusters[config.ClusterVersion]
+		if !ok {
+			return nil, i.NewNotFoundError(
+				fmt.Errorf("cluster '%s' with version '%d' not found", status.RuntimeID, config.ClusterVersion),
+				&model.ClusterEntity{},
+				map[string]interface{}{"Version": config.ClusterVersion, "Deleted": false})
+		}
+		result = append(result, &State{
+			Cluster:       cluster,
+			Configuration: config,
+			Status:        status,
+		})
+	}
+	return result, nil
+}
+
+func (i *DefaultInventory) configsByVersion(versions []int64) (map[int64]*model.ClusterConfigurationEntity, error) {
+	result := make(map[int64]*model.ClusterConfigurationEntity, len(versions))
+	for _, batch := range splitVersions(versions, maxBatchSize) {
+		q, err := db.NewQuery(i.Conn, &model.ClusterConfigurationEntity{}, i.Logger)
+		if err != nil {
+			return nil, err
+		}
+		placeholders, args := inCondition(batch, 1)
+		entities, err := q.Select().
+			WhereIn("Version", placeholders, args...).
+			GetMany()
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range entities {
+			configEntity := entity.(*model.ClusterConfigurationEntity)
+			result[configEntity.Version] = configEntity
+		}
+	}
+	return result, nil
+}
+
+func (i *DefaultInventory) clustersByVersion(versions []int64) (map[int64]*model.ClusterEntity, error) {
+	result := make(map[int64]*model.ClusterEntity, len(versions))
+	for _, batch := range splitVersions(versions, maxBatchSize) {
+		q, err := db.NewQuery(i.Conn, &model.ClusterEntity{}, i.Logger)
+		if err != nil {
+			return nil, err
+		}
+		selectQ := q.Select().Where(map[string]interface{}{"Deleted": false})
+		placeholders, args := inCondition(batch, selectQ.NextPlaceholderCount())
+		entities, err := selectQ.
+			WhereIn("Version", placeholders, args...).
+			GetMany()
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range entities {
+			clusterEntity := entity.(*model.ClusterEntity)
+			result[clusterEntity.Version] = clusterEntity
+		}
+	}
+	return result, nil
+}
+
+//runtimeIDEntities returns the reservations of the runtime IDs in batches (runtime IDs without reservation are
+//not included)
+func (i *DefaultInventory) runtimeIDEntities(runtimeIDs []string) (map[string]*model.RuntimeIDEntity, error) {
+	result := make(map[string]*model.RuntimeIDEntity, len(runtimeIDs))
+	for _, batch := range splitRuntimeIDs(runtimeIDs, maxBatchSize) {
+		q, err := db.NewQuery(i.Conn, &model.RuntimeIDEntity{}, i.Logger)
+		if err != nil {
+			return nil, err
+		}
+		entities, err := q.Select().
+			WhereIn("RuntimeID", placeholders(len(batch), 1), batch...).
+			GetMany()
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range entities {
+			runtimeIDEntity := entity.(*model.RuntimeIDEntity)
+			result[runtimeIDEntity.RuntimeID] = runtimeIDEntity
+		}
+	}
+	return result, nil
+}
+
+//inCondition returns the placeholders (starting with the offset) and arguments of an IN condition
+func inCondition(versions []int64, offset int) (string, []interface{}) {
+	args := make([]interface{}, 0, len(versions))
+	for _, version := range versions {
+		args = append(args, version)
+	}
+	return placeholders(len(versions), offset), args
+}
+
+//placeholders returns the given number of comma separated placeholders starting with the offset
+func placeholders(count, offset int) string {
+	var result bytes.Buffer
+	for idx := 0; idx < count; idx++ {
+		if result.Len() > 0 {
+			result.WriteRune(',')
+		}
+		result.WriteString(fmt.Sprintf("$%d", idx+offset))
+	}
+	return result.String()
+}
+
+//splitVersions returns the unique versions in batches of the given size
+func splitVersions(versions []int64, size int) [][]int64 {
+	var batches [][]int64
+	var batch []int64
+	unique := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		if unique[version] {
+			continue
+		}
+		unique[version] = true
+		batch = append(batch, version)
+		if len(batch) == size {
+			batches = append(batches, batch)
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+//splitRuntimeIDs returns the unique runtime IDs in batches of the given size (as arguments of an IN condition)
+func splitRuntimeIDs(runtimeIDs []string, size int) [][]interface{} {
+	var batches [][]interface{}
+	var batch []interface{}
+	unique := make(map[string]bool, len(runtimeIDs))
+	for _, runtimeID := range runtimeIDs {
+		if unique[runtimeID] {
+			continue
+		}
+		unique[runtimeID] = true
+		batch = append(batch, runtimeID)
+		if len(batch) == size {
+			batches = append(batches, batch)
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
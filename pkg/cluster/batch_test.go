@@ -0,0 +1,21 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatches(t *testing.T) {
+	t.Run("Split versions", func(t *testing.T) {
+		require.Empty(t, splitVersions(nil, 2))
+		require.Equal(t, [][]int64{{1, 2}, {3}}, splitVersions([]int64{1, 2, 2, 3, 1}, 2))
+		require.Equal(t, [][]int64{{1, 2}}, splitVersions([]int64{1, 2}, 2))
+	})
+
+	t.Run("IN condition", func(t *testing.T) {
+		placeholders, args := inCondition([]int64{5, 6, 7}, 2)
+		require.Equal(t, "$2,$3,$4", placeholders)
+		require.Equal(t, []interface{}{int64(5), int64(6), int64(7)}, args)
+	})
+}
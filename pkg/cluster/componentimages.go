@@ -0,0 +1,52 @@
+package cluster
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+//UpdateComponentImages replaces the container images which were reported for a component of a cluster
+func (i *DefaultInventory) UpdateComponentImages(images *model.ComponentImagesEntity) error {
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, &model.ComponentImagesEntity{}, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := q.Delete().
+			Where(map[string]interface{}{"RuntimeID": images.RuntimeID, "Component": images.Component}).
+			Exec(); err != nil {
+			return err
+		}
+		q, err = db.NewQuery(tx, images, i.Logger)
+		if err != nil {
+			return err
+		}
+		return q.Insert().Exec()
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return err
+	}
+	i.Logger.Debugf("Inventory stored %d container images of component '%s' of cluster '%s' (configVersion:%d)",
+		len(images.Images), images.Component, images.RuntimeID, images.ClusterConfig)
+	return nil
+}
+
+//GetComponentImages returns the latest reported container images of all components of a cluster
+func (i *DefaultInventory) GetComponentImages(runtimeID string) ([]*model.ComponentImagesEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.ComponentImagesEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(map[string]interface{}{"RuntimeID": runtimeID}).
+		OrderBy(map[string]string{"Component": "ASC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.ComponentImagesEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.ComponentImagesEntity))
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package cluster
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+//UpdateConfigWarnings replaces the warnings of the validation webhook which are stored for a configuration version
+func (i *DefaultInventory) UpdateConfigWarnings(runtimeID string, configVersion int64, warnings []string) error {
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, &model.ClusterConfigWarningsEntity{}, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := q.Delete().
+			Where(map[string]interface{}{"ConfigVersion": configVersion}).
+			Exec(); err != nil {
+			return err
+		}
+		if len(warnings) == 0 {
+			return nil
+		}
+		q, err = db.NewQuery(tx, &model.ClusterConfigWarningsEntity{
+			ConfigVersion: configVersion,
+			RuntimeID:     runtimeID,
+			Warnings:      warnings,
+		}, i.Logger)
+		if err != nil {
+			return err
+		}
+		return q.Insert().Exec()
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return err
+	}
+	i.Logger.Debugf("Inventory stored %d warnings of cluster '%s' (configVersion:%d)",
+		len(warnings), runtimeID, configVersion)
+	return nil
+}
+
+//GetConfigWarnings returns the warnings of the validation webhook for a configuration version
+func (i *DefaultInventory) GetConfigWarnings(configVersion int64) ([]string, error) {
+	warnings, err := i.GetConfigWarningsBatch([]int64{configVersion})
+	if err != nil {
+		return nil, err
+	}
+	return warnings[configVersion], nil
+}
+
+//GetConfigWarningsBatch returns the warnings of the validation webhook for multiple configuration versions in
+//batches (configuration versions without warnings are not included)
+func (i *DefaultInventory) GetConfigWarningsBatch(configVersions []int64) (map[int64][]string, error) {
+	result := make(map[int64][]string, len(configVersions))
+	if len(configVersions) == 0 {
+		return result, nil
+	}
+	for _, batch := range splitVersions(configVersions, maxBatchSize) {
+		q, err := db.NewQuery(i.Conn, &model.ClusterConfigWarningsEntity{}, i.Logger)
+		if err != nil {
+			return nil, err
+		}
+		placeholders, args := inCondition(batch, 1)
+		entities, err := q.Select().
+			WhereIn("ConfigVersion", placeholders, args...).
+			GetMany()
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range entities {
+			warningsEntity := entity.(*model.ClusterConfigWarningsEntity)
+			result[warningsEntity.ConfigVersion] = warningsEntity.Warnings
+		}
+	}
+	return result, nil
+}
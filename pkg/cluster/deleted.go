@@ -0,0 +1,135 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+const deletedRuntimeIDPrefix = "deleted_"
+
+//DeletedCluster is a deleted cluster whose entities are kept for audits until they get purged
+type DeletedCluster struct {
+	//RuntimeID the entities of the cluster were renamed to when it was deleted (releases the runtime ID for re-use)
+	RuntimeID string
+	Deleted   time.Time
+	//ConfigVersions are the versions of the configurations of the deleted cluster: its reconciliations reference
+	//them (the reconciliations keep the original runtime ID)
+	ConfigVersions []int64
+}
+
+func (d *DeletedCluster) String() string {
+	return fmt.Sprintf("DeletedCluster [RuntimeID=%s,Deleted=%s]", d.RuntimeID, d.Deleted.Format(time.RFC3339))
+}
+
+func deletedRuntimeID(runtimeID string, deleted time.Time) string {
+	return fmt.Sprintf("%s%d_%s", deletedRuntimeIDPrefix, deleted.Unix(), runtimeID)
+}
+
+//parseDeletedRuntimeID returns the original runtime ID and the deletion time of a deleted cluster
+func parseDeletedRuntimeID(deletedID string) (string, time.Time, bool) {
+	if !strings.HasPrefix(deletedID, deletedRuntimeIDPrefix) {
+		return "", time.Time{}, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(deletedID, deletedRuntimeIDPrefix), "_", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", time.Time{}, false
+	}
+	unix, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return parts[1], time.Unix(unix, 0).UTC(), true
+}
+
+//GetDeleted returns the deleted clusters which used the runtime ID (the oldest deletion first)
+func (i *DefaultInventory) GetDeleted(runtimeID string) ([]*DeletedCluster, error) {
+	q, err := db.NewQuery(i.Conn, &model.ClusterEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	colHandler, err := db.NewColumnHandler(&model.ClusterEntity{}, i.Conn, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	runtimeIDCol, err := colHandler.ColumnName("RuntimeID")
+	if err != nil {
+		return nil, err
+	}
+	selectQ := q.Select().Where(map[string]interface{}{"Deleted": true})
+	clusterEntities, err := selectQ.
+		WhereRaw(fmt.Sprintf("%s LIKE $%d", runtimeIDCol, selectQ.NextPlaceholderCount()),
+			fmt.Sprintf("%s%%_%s", deletedRuntimeIDPrefix, runtimeID)).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*DeletedCluster)
+	for _, clusterEntity := range clusterEntities {
+		deletedID := clusterEntity.(*model.ClusterEntity).RuntimeID
+		//LIKE treats underscores of the runtime ID as wildcards: the exact runtime ID has to be verified
+		origRuntimeID, deleted, ok := parseDeletedRuntimeID(deletedID)
+		if !ok || origRuntimeID != runtimeID {
+			continue
+		}
+		byID[deletedID] = &DeletedCluster{RuntimeID: deletedID, Deleted: deleted}
+	}
+	result := make([]*DeletedCluster, 0, len(byID))
+	for _, deletedCluster := range byID {
+		if deletedCluster.ConfigVersions, err = i.configVersions(deletedCluster.RuntimeID); err != nil {
+			return nil, err
+		}
+		result = append(result, deletedCluster)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Deleted.Before(result[j].Deleted)
+	})
+	return result, nil
+}
+
+func (i *DefaultInventory) configVersions(runtimeID string) ([]int64, error) {
+	q, err := db.NewQuery(i.Conn, &model.ClusterConfigurationEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	configEntities, err := q.Select().
+		Where(map[string]interface{}{"RuntimeID": runtimeID}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	var result []int64
+	for _, configEntity := range configEntities {
+		result = append(result, configEntity.(*model.ClusterConfigurationEntity).Version)
+	}
+	return result, nil
+}
+
+//Purge removes the entities of a deleted cluster physically (its configurations and statuses are removed by the
+//database). The reconciliations of the cluster have to be removed before.
+func (i *DefaultInventory) Purge(deletedCluster *DeletedCluster) error {
+	if _, _, ok := parseDeletedRuntimeID(deletedCluster.RuntimeID); !ok {
+		return fmt.Errorf("cluster '%s' cannot be purged because it isn't deleted", deletedCluster.RuntimeID)
+	}
+	q, err := db.NewQuery(i.Conn, &model.ClusterEntity{}, i.Logger)
+	if err != nil {
+		return err
+	}
+	purged, err := q.Delete().
+		Where(map[string]interface{}{
+			"RuntimeID": deletedCluster.RuntimeID,
+			"Deleted":   true,
+		}).
+		Exec()
+	if err != nil {
+		return err
+	}
+	i.Logger.Infof("Inventory purged %d entities of deleted cluster '%s'", purged, deletedCluster.RuntimeID)
+	return nil
+}
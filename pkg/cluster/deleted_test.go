@@ -0,0 +1,29 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletedRuntimeID(t *testing.T) {
+	deleted := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Round trip", func(t *testing.T) {
+		deletedID := deletedRuntimeID("my_runtime_id", deleted)
+		require.Equal(t, "deleted_1646128800_my_runtime_id", deletedID)
+
+		runtimeID, deletedAt, ok := parseDeletedRuntimeID(deletedID)
+		require.True(t, ok)
+		require.Equal(t, "my_runtime_id", runtimeID)
+		require.Equal(t, deleted, deletedAt)
+	})
+
+	t.Run("Runtime IDs of active clusters", func(t *testing.T) {
+		for _, id := range []string{"abc", "deleted_", "deleted_abc_def", "deleted_1646128800_"} {
+			_, _, ok := parseDeletedRuntimeID(id)
+			require.False(t, ok, id)
+		}
+	})
+}
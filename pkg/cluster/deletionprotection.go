@@ -0,0 +1,22 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+//DeletionProtectedError indicates that a cluster can't be deleted because its deletion protection is enabled
+type DeletionProtectedError struct {
+	RuntimeID string
+}
+
+func (e *DeletionProtectedError) Error() string {
+	return fmt.Sprintf("cluster '%s' is protected against deletion: "+
+		"the deletion protection has to be removed before the cluster can be deleted", e.RuntimeID)
+}
+
+func IsDeletionProtectedError(err error) bool {
+	var protectedErr *DeletionProtectedError
+	return errors.As(err, &protectedErr)
+}
@@ -0,0 +1,28 @@
+package cluster
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+//StatusHistory returns the latest statuses of a cluster (latest first): at most limit statuses are returned
+func (i *DefaultInventory) StatusHistory(runtimeID string, limit int) ([]*model.ClusterStatusEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.ClusterStatusEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	statusEntities, err := q.Select().
+		Where(map[string]interface{}{"RuntimeID": runtimeID, "Deleted": false}).
+		OrderBy(map[string]string{"ID": "DESC"}).
+		Limit(limit).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*model.ClusterStatusEntity, 0, len(statusEntities))
+	for _, entity := range statusEntities {
+		statuses = append(statuses, entity.(*model.ClusterStatusEntity))
+	}
+	return statuses, nil
+}
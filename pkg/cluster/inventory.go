@@ -9,27 +9,54 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/kyma-incubator/reconciler/pkg/changes"
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/repository"
 )
 
+//createOrUpdateMaxAttempts limits the attempts to create/update a cluster which is concurrently created
+const createOrUpdateMaxAttempts = 3
+
 type Inventory interface {
 	CreateOrUpdate(contractVersion int64, cluster *keb.Cluster) (*State, error)
 	UpdateStatus(State *State, status model.Status) (*State, error)
 	MarkForDeletion(runtimeID string) (*State, error)
+	SetDeletionProtection(runtimeID string, protected bool) error
+	IsDeletionProtected(runtimeID string) (bool, error)
+	GetDeletionProtections(runtimeIDs []string) (map[string]bool, error)
+	SetForceTakeover(runtimeID string, force bool) error
+	IsForceTakeover(runtimeID string) (bool, error)
+	SetProvisioned(runtimeID string) error
+	GetProvisioned(runtimeIDs []string) (map[string]bool, error)
+	RotateKubeconfig(runtimeID, kubeconfig string) (*State, error)
+	RollbackKubeconfig(runtimeID string) (*State, error)
+	ReleasePreviousKubeconfig(runtimeID string, statusID int64) error
+	UpdateComponentImages(images *model.ComponentImagesEntity) error
+	GetComponentImages(runtimeID string) ([]*model.ComponentImagesEntity, error)
+	AddSnapshot(snapshot *model.ClusterSnapshotEntity, retention int) error
+	GetSnapshots(runtimeID string) ([]*model.ClusterSnapshotEntity, error)
+	UpdateConfigWarnings(runtimeID string, configVersion int64, warnings []string) error
+	GetConfigWarnings(configVersion int64) ([]string, error)
+	GetConfigWarningsBatch(configVersions []int64) (map[int64][]string, error)
 	Delete(runtimeID string) error
 	Get(runtimeID string, configVersion int64) (*State, error)
 	GetLatest(runtimeID string) (*State, error)
+	GetAt(runtimeID string, timestamp time.Time) (*State, error)
 	GetAll() ([]*State, error)
+	List(filter *ListFilter) ([]*State, int, error)
 	StatusChanges(runtimeID string, offset time.Duration) ([]*StatusChange, error)
+	ListStatusChanges(runtimeID string, offset time.Duration, filter *StatusChangeFilter) (*StatusChangePage, error)
+	StatusHistory(runtimeID string, limit int) ([]*model.ClusterStatusEntity, error)
 	ClustersToReconcile(reconcileInterval time.Duration) ([]*State, error)
 	ClustersNotReady() ([]*State, error)
 	CountRetries(runtimeID string, configVersion int64, maxRetries int, errorStatus ...model.Status) (int, error)
 	WithTx(tx *db.TxConnection) (Inventory, error)
 	RemoveStatusesWithoutReconciliations(timeout time.Duration, statusCleanupBatchSize int) (int, error)
 	RemoveDeletedClustersOlderThan(deadline time.Time) (int, error)
+	GetDeleted(runtimeID string) ([]*DeletedCluster, error)
+	Purge(deletedCluster *DeletedCluster) error
 }
 
 type DefaultInventory struct {
@@ -134,10 +161,30 @@ func (i *DefaultInventory) CreateOrUpdate(contractVersion int64, cluster *keb.Cl
 		if err != nil {
 			return nil, err
 		}
+		//the deletion protection can be enabled by create/update requests but only removed by an explicit PATCH request
+		if cluster.DeletionProtection != nil && *cluster.DeletionProtection {
+			if err := iTx.SetDeletionProtection(cluster.RuntimeID, true); err != nil {
+				return nil, err
+			}
+		}
 		clusterConfigurationEntity, err := iTx.createConfiguration(contractVersion, cluster, clusterEntity)
 		if err != nil {
 			return nil, err
 		}
+		//an unchanged configuration doesn't require a new reconciliation
+		latestStatusEntity, noOp, err := iTx.noOpStatus(clusterConfigurationEntity)
+		if err != nil {
+			return nil, err
+		}
+		if noOp {
+			i.Logger.Infof("Inventory detected unchanged configuration for cluster '%s': keeping configVersion %d "+
+				"with status '%s'", cluster.RuntimeID, clusterConfigurationEntity.Version, latestStatusEntity.Status)
+			return &State{
+				Cluster:       clusterEntity,
+				Configuration: clusterConfigurationEntity,
+				Status:        latestStatusEntity,
+			}, nil
+		}
 		clusterStatusEntity, err := iTx.createStatus(clusterConfigurationEntity, model.ClusterStatusReconcilePending)
 		if err != nil {
 			return nil, err
@@ -150,7 +197,17 @@ func (i *DefaultInventory) CreateOrUpdate(contractVersion int64, cluster *keb.Cl
 
 	}
 
-	state, err := db.TransactionResult(i.Conn, dbOps, i.Logger)
+	var state interface{}
+	var err error
+	for attempt := 1; ; attempt++ {
+		state, err = db.TransactionResult(i.Conn, dbOps, i.Logger)
+		if err == nil || !i.retryCreateOrUpdate(err, attempt) {
+			break
+		}
+		//a concurrent request created the cluster in the meantime: the retry reuses its entities
+		i.Logger.Infof("Inventory detected concurrent creation of cluster '%s' and retries create/update "+
+			"(attempt %d/%d)", cluster.RuntimeID, attempt, createOrUpdateMaxAttempts)
+	}
 	if err != nil {
 		i.Logger.Errorf("Inventory failed to create/update cluster with runtimeID '%s': %s", cluster.RuntimeID, err)
 		return nil, err
@@ -170,13 +227,28 @@ func (i *DefaultInventory) CreateOrUpdate(contractVersion int64, cluster *keb.Cl
 	return stateEntity, nil
 }
 
+//retryCreateOrUpdate returns true if a failed create/update collided with a concurrent request and can be retried.
+//Retries are only possible if the inventory owns the transaction: an outer transaction is already rolled back.
+func (i *DefaultInventory) retryCreateOrUpdate(err error, attempt int) bool {
+	if _, isTx := i.Conn.(*db.TxConnection); isTx {
+		return false
+	}
+	return attempt < createOrUpdateMaxAttempts && db.IsUniqueConstraintError(err)
+}
+
 func (i *DefaultInventory) createCluster(contractVersion int64, cluster *keb.Cluster) (*model.ClusterEntity, error) {
+	if err := i.reserveRuntimeID(cluster.RuntimeID); err != nil {
+		return nil, err
+	}
+
 	newClusterEntity := &model.ClusterEntity{
-		RuntimeID:  cluster.RuntimeID,
-		Runtime:    &cluster.RuntimeInput,
-		Metadata:   &cluster.Metadata,
-		Kubeconfig: cluster.Kubeconfig,
-		Contract:   contractVersion,
+		RuntimeID:       cluster.RuntimeID,
+		Runtime:         &cluster.RuntimeInput,
+		Metadata:        &cluster.Metadata,
+		Kubeconfig:      cluster.Kubeconfig,
+		KubeconfigKeyID: i.Conn.Encryptor().KeyID(),
+		Kubeconfigs:     cluster.GetKubeconfigs(),
+		Contract:        contractVersion,
 	}
 
 	//check if a new version is required
@@ -203,6 +275,205 @@ func (i *DefaultInventory) createCluster(contractVersion int64, cluster *keb.Clu
 	return newDbEntity.(*model.ClusterEntity), nil
 }
 
+//reserveRuntimeID ensures that no other cluster uses a runtime ID which differs only by case or whitespaces
+func (i *DefaultInventory) reserveRuntimeID(runtimeID string) error {
+	normalizedRuntimeID := NormalizeRuntimeID(runtimeID)
+
+	//the no-op update locks an existing reservation until the transaction ends before it's read: concurrent updates
+	//of the same cluster are serialized and the colliding transaction is retried with the committed entities. The
+	//settings of the reservation (e.g. the deletion protection) aren't written and concurrent changes are kept.
+	locked, err := i.lockRuntimeID(normalizedRuntimeID)
+	if err != nil {
+		return err
+	}
+	if locked {
+		q, err := db.NewQuery(i.Conn, &model.RuntimeIDEntity{}, i.Logger)
+		if err != nil {
+			return err
+		}
+		entity, err := q.Select().
+			Where(map[string]interface{}{"NormalizedRuntimeID": normalizedRuntimeID}).
+			GetOne()
+		if err != nil {
+			return err
+		}
+		reserved := entity.(*model.RuntimeIDEntity)
+		if reserved.RuntimeID != runtimeID {
+			return &RuntimeIDConflictError{RuntimeID: runtimeID, ExistingRuntimeID: reserved.RuntimeID}
+		}
+		return nil
+	}
+
+	//the primary key of the normalized runtime ID rejects concurrent reservations
+	q, err := db.NewQuery(i.Conn, &model.RuntimeIDEntity{
+		NormalizedRuntimeID: normalizedRuntimeID,
+		RuntimeID:           runtimeID,
+	}, i.Logger)
+	if err != nil {
+		return err
+	}
+	return q.Insert().Exec()
+}
+
+//lockRuntimeID locks the reservation of the normalized runtime ID and returns false if no reservation exists
+func (i *DefaultInventory) lockRuntimeID(normalizedRuntimeID string) (bool, error) {
+	colHdlr, err := db.NewColumnHandler(&model.RuntimeIDEntity{}, i.Conn, i.Logger)
+	if err != nil {
+		return false, err
+	}
+	colName, err := colHdlr.ColumnName("NormalizedRuntimeID")
+	if err != nil {
+		return false, err
+	}
+	res, err := i.Conn.Exec(fmt.Sprintf("UPDATE %s SET %s=%s WHERE %s=$1",
+		(&model.RuntimeIDEntity{}).Table(), colName, colName, colName), normalizedRuntimeID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+//SetDeletionProtection enables or removes the deletion protection of a cluster
+func (i *DefaultInventory) SetDeletionProtection(runtimeID string, protected bool) error {
+	updated, err := i.updateRuntimeIDEntity(runtimeID, func(entity *model.RuntimeIDEntity) bool {
+		if entity.DeletionProtection == protected {
+			return false
+		}
+		entity.DeletionProtection = protected
+		return true
+	})
+	if err == nil && updated {
+		i.Logger.Infof("Inventory set deletion protection of cluster '%s' to '%t'", runtimeID, protected)
+	}
+	return err
+}
+
+//IsDeletionProtected returns true if deletions of the cluster have to be rejected
+func (i *DefaultInventory) IsDeletionProtected(runtimeID string) (bool, error) {
+	entity, err := i.getRuntimeIDEntity(runtimeID)
+	if err != nil || entity == nil {
+		return false, err
+	}
+	return entity.DeletionProtection, nil
+}
+
+//GetDeletionProtections returns for each of the clusters whether its deletion protection is enabled (unknown
+//clusters aren't included)
+func (i *DefaultInventory) GetDeletionProtections(runtimeIDs []string) (map[string]bool, error) {
+	entities, err := i.runtimeIDEntities(runtimeIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool, len(entities))
+	for runtimeID, entity := range entities {
+		result[runtimeID] = entity.DeletionProtection
+	}
+	return result, nil
+}
+
+//SetForceTakeover allows (or disallows) the mothership to take over the cluster once from another mothership
+func (i *DefaultInventory) SetForceTakeover(runtimeID string, force bool) error {
+	updated, err := i.updateRuntimeIDEntity(runtimeID, func(entity *model.RuntimeIDEntity) bool {
+		if entity.ForceTakeover == force {
+			return false
+		}
+		entity.ForceTakeover = force
+		return true
+	})
+	if err == nil && updated {
+		i.Logger.Infof("Inventory set forced takeover of cluster '%s' to '%t'", runtimeID, force)
+	}
+	return err
+}
+
+//IsForceTakeover returns true if the mothership is allowed to take over the cluster from another mothership
+func (i *DefaultInventory) IsForceTakeover(runtimeID string) (bool, error) {
+	entity, err := i.getRuntimeIDEntity(runtimeID)
+	if err != nil || entity == nil {
+		return false, err
+	}
+	return entity.ForceTakeover, nil
+}
+
+//SetProvisioned marks the cluster as provisioned (called after its first successful reconciliation)
+func (i *DefaultInventory) SetProvisioned(runtimeID string) error {
+	updated, err := i.updateRuntimeIDEntity(runtimeID, func(entity *model.RuntimeIDEntity) bool {
+		if entity.Provisioned {
+			return false
+		}
+		entity.Provisioned = true
+		return true
+	})
+	if err == nil && updated {
+		i.Logger.Infof("Inventory marked cluster '%s' as provisioned", runtimeID)
+	}
+	return err
+}
+
+//GetProvisioned returns for each of the clusters whether it was already provisioned (unknown clusters aren't
+//included)
+func (i *DefaultInventory) GetProvisioned(runtimeIDs []string) (map[string]bool, error) {
+	entities, err := i.runtimeIDEntities(runtimeIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool, len(entities))
+	for runtimeID, entity := range entities {
+		result[runtimeID] = entity.Provisioned
+	}
+	return result, nil
+}
+
+//getRuntimeIDEntity returns nil if the runtime ID isn't reserved
+func (i *DefaultInventory) getRuntimeIDEntity(runtimeID string) (*model.RuntimeIDEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.RuntimeIDEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(map[string]interface{}{"RuntimeID": runtimeID}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return nil, nil
+	}
+	return entities[0].(*model.RuntimeIDEntity), nil
+}
+
+//updateRuntimeIDEntity stores the settings of the cluster if the update function changed them
+func (i *DefaultInventory) updateRuntimeIDEntity(runtimeID string, update func(entity *model.RuntimeIDEntity) bool) (bool, error) {
+	q, err := db.NewQuery(i.Conn, &model.RuntimeIDEntity{}, i.Logger)
+	if err != nil {
+		return false, err
+	}
+	entity, err := q.Select().
+		Where(map[string]interface{}{"RuntimeID": runtimeID}).
+		GetOne()
+	if err != nil {
+		return false, i.MapError(err, &model.RuntimeIDEntity{}, map[string]interface{}{"RuntimeID": runtimeID})
+	}
+	runtimeIDEntity := entity.(*model.RuntimeIDEntity)
+	if !update(runtimeIDEntity) {
+		return false, nil
+	}
+	q, err = db.NewQuery(i.Conn, runtimeIDEntity, i.Logger)
+	if err != nil {
+		return false, err
+	}
+	if err := q.Update().
+		Where(map[string]interface{}{"NormalizedRuntimeID": runtimeIDEntity.NormalizedRuntimeID}).
+		Exec(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (i *DefaultInventory) createConfiguration(contractVersion int64, cluster *keb.Cluster, clusterEntity *model.ClusterEntity) (*model.ClusterConfigurationEntity, error) {
 	newConfigEntity := &model.ClusterConfigurationEntity{
 		RuntimeID:      clusterEntity.RuntimeID,
@@ -219,11 +490,13 @@ func (i *DefaultInventory) createConfiguration(contractVersion int64, cluster *k
 		Administrators: cluster.KymaConfig.Administrators,
 		Contract:       contractVersion,
 	}
+	//configurations are stored in canonical order: the order of the payload doesn't affect the stored JSON
+	newConfigEntity.Normalize()
 
 	//check if a new version is required
 	oldConfigEntity, err := i.latestConfig(clusterEntity.Version)
 	if err == nil {
-		if oldConfigEntity.Equal(newConfigEntity) { //reuse existing config entity
+		if oldConfigEntity.Equal(newConfigEntity) || oldConfigEntity.SemanticallyEqual(newConfigEntity) { //reuse existing config entity
 			i.Logger.Debugf("No differences found for configuration of cluster '%s': not creating new database entity", cluster.RuntimeID)
 			return oldConfigEntity, nil
 		}
@@ -244,6 +517,25 @@ func (i *DefaultInventory) createConfiguration(contractVersion int64, cluster *k
 	return newDbEntity.(*model.ClusterConfigurationEntity), nil
 }
 
+//noOpStatus returns the latest status of an existing configuration if it's already reconciled or about
+//to be reconciled: failed and deleting clusters get a new reconciliation
+func (i *DefaultInventory) noOpStatus(configEntity *model.ClusterConfigurationEntity) (*model.ClusterStatusEntity, bool, error) {
+	statusEntity, err := i.latestStatus(configEntity.Version)
+	if err != nil {
+		if repository.IsNotFoundError(err) { //new configurations have no status yet
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	switch statusEntity.Status {
+	case model.ClusterStatusReady, model.ClusterStatusReconcilePending, model.ClusterStatusReconciling,
+		model.ClusterStatusReconcileDisabled:
+		return statusEntity, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
 func (i *DefaultInventory) createStatus(configEntity *model.ClusterConfigurationEntity, status model.Status) (*model.ClusterStatusEntity, error) {
 	newStatusEntity := &model.ClusterStatusEntity{
 		RuntimeID:      configEntity.RuntimeID,
@@ -264,12 +556,24 @@ func (i *DefaultInventory) createStatus(configEntity *model.ClusterConfiguration
 		return nil, err
 	}
 
-	//create new status
-	q, err := db.NewQueryGorm(i.Conn, newStatusEntity, i.Logger)
-	if err != nil {
-		return nil, err
-	}
-	newDbEntity, err := q.Insert(inventoryClusterConfigStatus{})
+	//create new status and add it to the change feed
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		q, err := db.NewQueryGorm(tx, newStatusEntity, i.Logger)
+		if err != nil {
+			return nil, err
+		}
+		newDbEntity, err := q.Insert(inventoryClusterConfigStatus{})
+		if err != nil {
+			return nil, err
+		}
+		return newDbEntity, changes.Record(tx, &model.ChangeEntity{
+			Kind:          model.ChangeKindCluster,
+			RuntimeID:     newStatusEntity.RuntimeID,
+			ConfigVersion: newStatusEntity.ConfigVersion,
+			Status:        newStatusEntity.Status,
+		}, i.Logger)
+	}
+	newDbEntity, err := i.TransactionalResult(dbOps)
 	if err != nil {
 		return nil, err
 	}
@@ -291,6 +595,13 @@ func (i *DefaultInventory) UpdateStatus(state *State, status model.Status) (*Sta
 }
 
 func (i *DefaultInventory) MarkForDeletion(runtimeID string) (*State, error) {
+	protected, err := i.IsDeletionProtected(runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	if protected {
+		return nil, &DeletionProtectedError{RuntimeID: runtimeID}
+	}
 	clusterState, err := i.GetLatest(runtimeID)
 	if err != nil {
 		return nil, err
@@ -300,7 +611,7 @@ func (i *DefaultInventory) MarkForDeletion(runtimeID string) (*State, error) {
 
 func (i *DefaultInventory) Delete(runtimeID string) error {
 	dbOps := func(tx *db.TxConnection) error {
-		newClusterName := fmt.Sprintf("deleted_%d_%s", time.Now().Unix(), runtimeID)
+		newClusterName := deletedRuntimeID(runtimeID, time.Now())
 		// TODO: Rewrite with gorm to stay consistend
 		updateSQLTpl := "UPDATE %s SET %s=$1, %s=$2 WHERE %s=$3 OR %s=$4" //OR condition required for Postgres: new cluster-name is automatically cascaded to config-status table
 		//update name of all cluster entities
@@ -360,6 +671,42 @@ func (i *DefaultInventory) Delete(runtimeID string) error {
 			return err
 		}
 
+		//images of deleted clusters are no longer relevant
+		imagesQuery, err := db.NewQuery(tx, &model.ComponentImagesEntity{}, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := imagesQuery.Delete().Where(map[string]interface{}{"RuntimeID": runtimeID}).Exec(); err != nil {
+			return err
+		}
+
+		//snapshots of deleted clusters are no longer relevant
+		snapshotsQuery, err := db.NewQuery(tx, &model.ClusterSnapshotEntity{}, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := snapshotsQuery.Delete().Where(map[string]interface{}{"RuntimeID": runtimeID}).Exec(); err != nil {
+			return err
+		}
+
+		//deleted clusters don't reference their configuration template anymore
+		templateRefQuery, err := db.NewQuery(tx, &model.ConfigTemplateRefEntity{}, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := templateRefQuery.Delete().Where(map[string]interface{}{"RuntimeID": runtimeID}).Exec(); err != nil {
+			return err
+		}
+
+		//release the runtime ID to allow its re-use
+		runtimeIDQuery, err := db.NewQuery(tx, &model.RuntimeIDEntity{}, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := runtimeIDQuery.Delete().Where(map[string]interface{}{"RuntimeID": runtimeID}).Exec(); err != nil {
+			return err
+		}
+
 		//done
 		return nil
 	}
@@ -413,10 +760,104 @@ func (i *DefaultInventory) GetLatest(runtimeID string) (*State, error) {
 	}, nil
 }
 
+//GetAt returns the state of the cluster which was active at the given time: the configuration and status
+//of the latest status change which happened before the timestamp
+func (i *DefaultInventory) GetAt(runtimeID string, timestamp time.Time) (*State, error) {
+	statusEntity := &model.ClusterStatusEntity{}
+	statusColHandler, err := db.NewColumnHandler(statusEntity, i.Conn, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	createdColName, err := statusColHandler.ColumnName("Created")
+	if err != nil {
+		return nil, err
+	}
+	q, err := db.NewQuery(i.Conn, statusEntity, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	selectQ := q.Select().Where(map[string]interface{}{"RuntimeID": runtimeID})
+	entities, err := selectQ.
+		WhereRaw(fmt.Sprintf("%s<=$%d", createdColName, selectQ.NextPlaceholderCount()), timestamp.UTC().Format("2006-01-02 15:04:05.000000")).
+		OrderBy(map[string]string{"ID": "DESC"}).
+		Limit(1).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return nil, i.NewNotFoundError(
+			fmt.Errorf("cluster '%s' had no status at %s", runtimeID, timestamp.UTC().Format(time.RFC3339)),
+			statusEntity,
+			map[string]interface{}{
+				"RuntimeID": runtimeID,
+				"Created":   timestamp,
+			})
+	}
+	statusEntity = entities[0].(*model.ClusterStatusEntity)
+
+	configEntity, err := i.config(runtimeID, statusEntity.ConfigVersion)
+	if err != nil {
+		return nil, err
+	}
+	clusterEntity, err := i.cluster(configEntity.ClusterVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &State{
+		Cluster:       clusterEntity,
+		Configuration: configEntity,
+		Status:        statusEntity,
+	}, nil
+}
+
 func (i *DefaultInventory) GetAll() ([]*State, error) {
 	return i.filterClusters()
 }
 
+//List returns the latest states of the clusters matching the filter (ordered by runtime ID) and the total
+//number of matching clusters
+func (i *DefaultInventory) List(filter *ListFilter) ([]*State, int, error) {
+	if filter == nil {
+		filter = &ListFilter{}
+	}
+	if filter.Less != nil && filter.After != "" {
+		return nil, 0, errors.New("keyset pagination is only supported if the clusters are ordered by runtime ID")
+	}
+	lq, err := i.newListQuery(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Less != nil {
+		//custom orders (e.g. by health score) aren't known by the database: all matching clusters are ordered in memory
+		statuses, err := i.selectList(lq, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		states, err := i.states(statuses)
+		if err != nil {
+			return nil, 0, err
+		}
+		result, total := filter.apply(states)
+		return result, total, nil
+	}
+
+	total, err := i.countList(lq)
+	if err != nil {
+		return nil, 0, err
+	}
+	statuses, err := i.selectList(lq, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	result, err := i.states(statuses)
+	if err != nil {
+		return nil, 0, err
+	}
+	return result, total, nil
+}
+
 func (i *DefaultInventory) latestStatus(configVersion int64) (*model.ClusterStatusEntity, error) {
 	whereCond := map[string]interface{}{
 		"config_version": configVersion,
@@ -582,9 +1023,9 @@ func (i *DefaultInventory) filterClusters(filters ...statusSQLFilter) ([]*State,
 	if err != nil {
 		return nil, err
 	}
-	var clusterStatuses []model.ClusterStatusEntity
+	var clusterStatuses []*model.ClusterStatusEntity
 	for dataRows.Next() {
-		var clusterStatusEntity model.ClusterStatusEntity
+		clusterStatusEntity := &model.ClusterStatusEntity{}
 		if err := dataRows.Scan(&clusterStatusEntity.ID,
 			&clusterStatusEntity.RuntimeID,
 			&clusterStatusEntity.ClusterVersion,
@@ -597,17 +1038,8 @@ func (i *DefaultInventory) filterClusters(filters ...statusSQLFilter) ([]*State,
 		clusterStatuses = append(clusterStatuses, clusterStatusEntity)
 	}
 
-	//retrieve clusters which require a reconciliation
-	var result []*State
-	for _, clusterStatus := range clusterStatuses {
-		state, err := i.Get(clusterStatus.RuntimeID, clusterStatus.ConfigVersion)
-		if err != nil {
-			return nil, err
-		}
-		result = append(result, state)
-	}
-
-	return result, nil
+	//retrieve the configurations and clusters of the statuses in batches
+	return i.states(clusterStatuses)
 }
 
 func (i *DefaultInventory) buildLatestStatusIdsSQL(columnMap map[string]string, clusterStatusEntity *model.ClusterStatusEntity) (*gorm.DB, error) {
@@ -762,6 +1194,47 @@ func (i *DefaultInventory) StatusChanges(runtimeID string, offset time.Duration)
 	return statusChanges, nil
 }
 
+//ListStatusChanges returns a page of the status changes within the offset: the durations of the status changes are
+//calculated before the filter is applied. Statuses, cursor, order and limit are applied by the database.
+func (i *DefaultInventory) ListStatusChanges(runtimeID string, offset time.Duration, filter *StatusChangeFilter) (*StatusChangePage, error) {
+	if filter == nil {
+		filter = &StatusChangeFilter{}
+	}
+	scq, err := i.newStatusChangeQuery(runtimeID, offset, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := i.countStatusChanges(scq.statusTbl, scq.conds, scq.args)
+	if err != nil {
+		return nil, err
+	}
+	if total == 0 && len(filter.Statuses) > 0 {
+		//the cluster has statuses but none of them matches
+		total, err = i.countStatusChanges(scq.statusTbl, scq.conds[:1], nil)
+		if err != nil {
+			return nil, err
+		}
+		if total > 0 {
+			return &StatusChangePage{StatusChanges: []*StatusChange{}}, nil
+		}
+	}
+	if total == 0 {
+		//invalid state: there cannot be a cluster without any state
+		return nil, i.NewNotFoundError(
+			fmt.Errorf("no status found for cluster '%s'", runtimeID),
+			&model.ClusterStatusEntity{},
+			map[string]interface{}{
+				"RuntimeID": runtimeID,
+			})
+	}
+	page, err := i.selectStatusChanges(scq, filter)
+	if err != nil {
+		return nil, err
+	}
+	page.Total = total
+	return page, nil
+}
+
 func (i *DefaultInventory) GetStatusIDsBlocksToDelete(statusCleanupBatchSize int) ([][]interface{}, error) {
 	statusSelectQuery, err := db.NewQuery(i.Conn, &model.StatusCleanupEntity{}, i.Logger)
 	if err != nil {
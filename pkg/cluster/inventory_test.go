@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"github.com/google/uuid"
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/keb/test"
@@ -10,6 +11,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -138,6 +141,700 @@ func (s *clusterTestSuite) TestInventory() {
 	})
 
 }
+func (s *clusterTestSuite) TestInventoryRuntimeIDUniqueness() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	_, err = inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+
+	//runtime IDs which differ only by case are rejected
+	conflictingCluster := test.NewCluster(t, "1", 1, false, test.Production)
+	conflictingCluster.RuntimeID = strings.ToUpper(cluster.RuntimeID)
+	_, err = inventory.CreateOrUpdate(1, conflictingCluster)
+	require.True(t, IsRuntimeIDConflictError(err))
+
+	//runtime ID can be re-used after the cluster was deleted
+	require.NoError(t, inventory.Delete(cluster.RuntimeID))
+	_, err = inventory.CreateOrUpdate(1, conflictingCluster)
+	require.NoError(t, err)
+}
+
+func (s *clusterTestSuite) TestInventoryUnchangedConfiguration() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	clusterState, err := inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+	clusterState, err = inventory.UpdateStatus(clusterState, model.ClusterStatusReady)
+	require.NoError(t, err)
+
+	//same configuration with different order of components doesn't trigger a reconciliation
+	reorderedCluster := test.NewClusterFromExisting(*cluster, 1, false)
+	reorderedCluster.KymaConfig.Components = nil
+	for i := len(cluster.KymaConfig.Components) - 1; i >= 0; i-- {
+		reorderedCluster.KymaConfig.Components = append(reorderedCluster.KymaConfig.Components, cluster.KymaConfig.Components[i])
+	}
+	clusterStateNew, err := inventory.CreateOrUpdate(1, reorderedCluster)
+	require.NoError(t, err)
+	require.Equal(t, clusterState.Configuration.Version, clusterStateNew.Configuration.Version)
+	require.Equal(t, clusterState.Status.ID, clusterStateNew.Status.ID)
+	require.Equal(t, model.ClusterStatusReady, clusterStateNew.Status.Status)
+
+	//failed clusters get reconciled again
+	_, err = inventory.UpdateStatus(clusterStateNew, model.ClusterStatusReconcileError)
+	require.NoError(t, err)
+	clusterStateNew, err = inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+	require.Equal(t, clusterState.Configuration.Version, clusterStateNew.Configuration.Version)
+	require.Equal(t, model.ClusterStatusReconcilePending, clusterStateNew.Status.Status)
+}
+
+func (s *clusterTestSuite) TestInventoryConcurrentCreateOrUpdate() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	if conn.Type() != db.Postgres {
+		t.Skip("concurrent transactions require Postgres")
+	}
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	createOrUpdate := func(cluster *keb.Cluster) []*State {
+		var wg sync.WaitGroup
+		states := make([]*State, 5)
+		errs := make([]error, len(states))
+		for i := range states {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				states[i], errs[i] = inventory.CreateOrUpdate(1, cluster)
+			}(i)
+		}
+		wg.Wait()
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		return states
+	}
+
+	//simultaneous requests for a new cluster create exactly one cluster and configuration
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	states := createOrUpdate(cluster)
+	for _, state := range states {
+		require.Equal(t, states[0].Cluster.Version, state.Cluster.Version)
+		require.Equal(t, states[0].Configuration.Version, state.Configuration.Version)
+		require.Equal(t, states[0].Status.ID, state.Status.ID)
+	}
+
+	//simultaneous updates of an existing cluster create exactly one new configuration
+	updatedCluster := test.NewClusterFromExisting(*cluster, 1, true)
+	updatedStates := createOrUpdate(updatedCluster)
+	for _, state := range updatedStates {
+		require.Equal(t, updatedStates[0].Configuration.Version, state.Configuration.Version)
+		require.NotEqual(t, states[0].Configuration.Version, state.Configuration.Version)
+	}
+}
+
+func (s *clusterTestSuite) TestInventoryDeletionProtection() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	//protection is enabled by the cluster model
+	protected := true
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	cluster.DeletionProtection = &protected
+	_, err = inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+	isProtected, err := inventory.IsDeletionProtected(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.True(t, isProtected)
+
+	_, err = inventory.MarkForDeletion(cluster.RuntimeID)
+	require.True(t, IsDeletionProtectedError(err))
+
+	//updates of the cluster don't remove the protection
+	updatedCluster := test.NewClusterFromExisting(*cluster, 1, false)
+	updatedCluster.DeletionProtection = nil
+	_, err = inventory.CreateOrUpdate(1, updatedCluster)
+	require.NoError(t, err)
+	isProtected, err = inventory.IsDeletionProtected(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.True(t, isProtected)
+
+	//protections of multiple clusters are loaded at once (unknown clusters are not included)
+	unprotectedCluster := test.NewCluster(t, "2", 1, false, test.Production)
+	_, err = inventory.CreateOrUpdate(1, unprotectedCluster)
+	require.NoError(t, err)
+	protections, err := inventory.GetDeletionProtections(
+		[]string{cluster.RuntimeID, unprotectedCluster.RuntimeID, "unknown-cluster"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{cluster.RuntimeID: true, unprotectedCluster.RuntimeID: false}, protections)
+
+	//protection has to be removed explicitly
+	require.NoError(t, inventory.SetDeletionProtection(cluster.RuntimeID, false))
+	clusterState, err := inventory.MarkForDeletion(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Equal(t, model.ClusterStatusDeletePending, clusterState.Status.Status)
+
+	//unknown clusters can't be protected
+	err = inventory.SetDeletionProtection("unknown-cluster", true)
+	require.True(t, repository.IsNotFoundError(err))
+}
+
+func (s *clusterTestSuite) TestInventoryForceTakeover() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	_, err = inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+	forced, err := inventory.IsForceTakeover(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.False(t, forced)
+
+	require.NoError(t, inventory.SetForceTakeover(cluster.RuntimeID, true))
+	forced, err = inventory.IsForceTakeover(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.True(t, forced)
+
+	//forced takeover and deletion protection are independent settings
+	require.NoError(t, inventory.SetDeletionProtection(cluster.RuntimeID, true))
+	forced, err = inventory.IsForceTakeover(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.True(t, forced)
+	require.NoError(t, inventory.SetDeletionProtection(cluster.RuntimeID, false))
+
+	//updates of the cluster lock its runtime ID reservation without overwriting the settings
+	_, err = inventory.CreateOrUpdate(1, test.NewClusterFromExisting(*cluster, 1, false))
+	require.NoError(t, err)
+	forced, err = inventory.IsForceTakeover(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.True(t, forced)
+
+	require.NoError(t, inventory.SetForceTakeover(cluster.RuntimeID, false))
+	forced, err = inventory.IsForceTakeover(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.False(t, forced)
+
+	//unknown clusters are never taken over
+	err = inventory.SetForceTakeover("unknown-cluster", true)
+	require.True(t, repository.IsNotFoundError(err))
+	forced, err = inventory.IsForceTakeover("unknown-cluster")
+	require.NoError(t, err)
+	require.False(t, forced)
+}
+
+func (s *clusterTestSuite) TestInventoryProvisioned() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	cluster1 := test.NewCluster(t, "1", 1, false, test.Production)
+	_, err = inventory.CreateOrUpdate(1, cluster1)
+	require.NoError(t, err)
+	cluster2 := test.NewCluster(t, "2", 1, false, test.Production)
+	_, err = inventory.CreateOrUpdate(1, cluster2)
+	require.NoError(t, err)
+
+	//new clusters are not provisioned and unknown clusters are not included
+	require.NoError(t, inventory.SetProvisioned(cluster2.RuntimeID))
+	provisioned, err := inventory.GetProvisioned([]string{cluster1.RuntimeID, cluster2.RuntimeID, "unknown-cluster"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{cluster1.RuntimeID: false, cluster2.RuntimeID: true}, provisioned)
+
+	//updates of the cluster keep the flag
+	_, err = inventory.CreateOrUpdate(1, test.NewClusterFromExisting(*cluster2, 1, false))
+	require.NoError(t, err)
+	provisioned, err = inventory.GetProvisioned([]string{cluster2.RuntimeID})
+	require.NoError(t, err)
+	require.True(t, provisioned[cluster2.RuntimeID])
+}
+
+func (s *clusterTestSuite) TestInventoryGetAt() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	oldState, err := inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second) //ensure the status changes have different creation times
+	newState, err := inventory.CreateOrUpdate(1, test.NewClusterFromExisting(*cluster, 1, true))
+	require.NoError(t, err)
+	require.NotEqual(t, oldState.Configuration.Version, newState.Configuration.Version)
+
+	t.Run("Configuration before the update", func(t *testing.T) {
+		state, err := inventory.GetAt(cluster.RuntimeID, oldState.Status.Created)
+		require.NoError(t, err)
+		require.Equal(t, oldState.Configuration.Version, state.Configuration.Version)
+		require.Equal(t, oldState.Status.ID, state.Status.ID)
+	})
+
+	t.Run("Configuration after the update", func(t *testing.T) {
+		state, err := inventory.GetAt(cluster.RuntimeID, newState.Status.Created.Add(1*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, newState.Configuration.Version, state.Configuration.Version)
+		require.Equal(t, newState.Status.ID, state.Status.ID)
+	})
+
+	t.Run("Cluster didn't exist", func(t *testing.T) {
+		_, err := inventory.GetAt(cluster.RuntimeID, oldState.Status.Created.Add(-1*time.Hour))
+		require.True(t, repository.IsNotFoundError(err))
+	})
+}
+
+func (s *clusterTestSuite) TestInventoryKubeconfigRotation() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	oldState, err := inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+
+	//nothing to roll back before a rotation happened
+	_, err = inventory.RollbackKubeconfig(cluster.RuntimeID)
+	require.True(t, IsNoPreviousKubeconfigError(err))
+
+	//rotation replaces the kubeconfig without creating new versions
+	newKubeconfig := oldState.Cluster.Kubeconfig + "\n# rotated"
+	rotatedState, err := inventory.RotateKubeconfig(cluster.RuntimeID, newKubeconfig)
+	require.NoError(t, err)
+	require.Equal(t, newKubeconfig, rotatedState.Cluster.Kubeconfig)
+	require.Equal(t, oldState.Cluster.Kubeconfig, rotatedState.Cluster.PreviousKubeconfig)
+	require.Equal(t, oldState.Cluster.Version, rotatedState.Cluster.Version)
+	require.Equal(t, oldState.Configuration.Version, rotatedState.Configuration.Version)
+
+	//rollback restores the previous kubeconfig
+	rolledBackState, err := inventory.RollbackKubeconfig(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Equal(t, oldState.Cluster.Kubeconfig, rolledBackState.Cluster.Kubeconfig)
+	require.Empty(t, rolledBackState.Cluster.PreviousKubeconfig)
+
+	//previous kubeconfig is kept after a successful reconciliation which started before the rotation
+	rotatedState, err = inventory.RotateKubeconfig(cluster.RuntimeID, newKubeconfig)
+	require.NoError(t, err)
+	require.NoError(t, inventory.ReleasePreviousKubeconfig(cluster.RuntimeID, rotatedState.Status.ID))
+	keptState, err := inventory.GetLatest(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Equal(t, oldState.Cluster.Kubeconfig, keptState.Cluster.PreviousKubeconfig)
+
+	//previous kubeconfig is dropped after a successful reconciliation which started after the rotation
+	reconcilingState, err := inventory.UpdateStatus(rotatedState, model.ClusterStatusReconciling)
+	require.NoError(t, err)
+	require.NoError(t, inventory.ReleasePreviousKubeconfig(cluster.RuntimeID, reconcilingState.Status.ID))
+	releasedState, err := inventory.GetLatest(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Equal(t, newKubeconfig, releasedState.Cluster.Kubeconfig)
+	require.Empty(t, releasedState.Cluster.PreviousKubeconfig)
+	_, err = inventory.RollbackKubeconfig(cluster.RuntimeID)
+	require.True(t, IsNoPreviousKubeconfigError(err))
+
+	//unknown clusters can't be rotated
+	_, err = inventory.RotateKubeconfig("unknown-cluster", newKubeconfig)
+	require.True(t, repository.IsNotFoundError(err))
+}
+
+func (s *clusterTestSuite) TestInventoryComponentImages() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	clusterState, err := inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+
+	newImages := func(image string) *model.ComponentImagesEntity {
+		return &model.ComponentImagesEntity{
+			RuntimeID:     cluster.RuntimeID,
+			Component:     "istio",
+			ClusterConfig: clusterState.Configuration.Version,
+			SchedulingID:  uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			Images: []*keb.ContainerImage{
+				{Kind: "Deployment", Namespace: "istio-system", Name: "istiod", Container: "discovery", Image: image},
+			},
+		}
+	}
+
+	//images of a component are replaced by the latest report
+	require.NoError(t, inventory.UpdateComponentImages(newImages("pilot:1.0")))
+	require.NoError(t, inventory.UpdateComponentImages(newImages("pilot:1.1")))
+	images, err := inventory.GetComponentImages(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Equal(t, "pilot:1.1", images[0].Images[0].Image)
+
+	//images are removed with the cluster
+	require.NoError(t, inventory.Delete(cluster.RuntimeID))
+	images, err = inventory.GetComponentImages(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Empty(t, images)
+}
+
+func (s *clusterTestSuite) TestInventorySnapshots() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	clusterState, err := inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+
+	//only the latest snapshots are retained
+	for nodes := 1; nodes <= 3; nodes++ {
+		require.NoError(t, inventory.AddSnapshot(&model.ClusterSnapshotEntity{
+			RuntimeID:         cluster.RuntimeID,
+			ClusterConfig:     clusterState.Configuration.Version,
+			NodeCount:         int64(nodes),
+			KubernetesVersion: "v1.23.4",
+			KymaCrds:          []string{"functions.serverless.kyma-project.io"},
+			Changes:           []*keb.FactChange{},
+		}, 2))
+	}
+	snapshots, err := inventory.GetSnapshots(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	require.Equal(t, int64(3), snapshots[0].NodeCount)
+	require.Equal(t, int64(2), snapshots[1].NodeCount)
+	require.Equal(t, []string{"functions.serverless.kyma-project.io"}, snapshots[0].KymaCrds)
+
+	//snapshots are removed with the cluster
+	require.NoError(t, inventory.Delete(cluster.RuntimeID))
+	snapshots, err = inventory.GetSnapshots(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+}
+
+func (s *clusterTestSuite) TestInventoryDeleteUnassignsConfigTemplate() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	templateName := uuid.NewString()
+	defer func() {
+		removeAllClusters(t, inventory)
+		q, err := db.NewQuery(conn, &model.ConfigTemplateEntity{}, logger.NewLogger(true))
+		require.NoError(t, err)
+		_, err = q.Delete().Where(map[string]interface{}{"Name": templateName}).Exec()
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+	}()
+
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	_, err = inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+
+	templateQuery, err := db.NewQuery(conn, &model.ConfigTemplateEntity{
+		Name:       templateName,
+		Components: []*keb.Component{{Component: "istio", Namespace: "istio-system"}},
+		Updated:    time.Now().UTC(),
+	}, logger.NewLogger(true))
+	require.NoError(t, err)
+	require.NoError(t, templateQuery.Insert().Exec())
+	refQuery, err := db.NewQuery(conn, &model.ConfigTemplateRefEntity{
+		RuntimeID: cluster.RuntimeID,
+		Template:  templateName,
+		Overrides: []*keb.Component{},
+		Updated:   time.Now().UTC(),
+	}, logger.NewLogger(true))
+	require.NoError(t, err)
+	require.NoError(t, refQuery.Insert().Exec())
+
+	//the reference to the template is removed with the cluster
+	require.NoError(t, inventory.Delete(cluster.RuntimeID))
+	refQuery, err = db.NewQuery(conn, &model.ConfigTemplateRefEntity{}, logger.NewLogger(true))
+	require.NoError(t, err)
+	refs, err := refQuery.Select().Where(map[string]interface{}{"Template": templateName}).GetMany()
+	require.NoError(t, err)
+	require.Empty(t, refs)
+}
+
+func (s *clusterTestSuite) TestInventoryConfigWarnings() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	clusterState, err := inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+	configVersion := clusterState.Configuration.Version
+
+	//no warnings stored
+	warnings, err := inventory.GetConfigWarnings(configVersion)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+
+	//warnings of a configuration version are replaced
+	require.NoError(t, inventory.UpdateConfigWarnings(cluster.RuntimeID, configVersion, []string{"a"}))
+	require.NoError(t, inventory.UpdateConfigWarnings(cluster.RuntimeID, configVersion, []string{"b", "c"}))
+	warnings, err = inventory.GetConfigWarnings(configVersion)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "c"}, warnings)
+
+	//empty warnings drop the stored warnings
+	require.NoError(t, inventory.UpdateConfigWarnings(cluster.RuntimeID, configVersion, nil))
+	warnings, err = inventory.GetConfigWarnings(configVersion)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+}
+
+func (s *clusterTestSuite) TestInventoryHistory() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	cluster := test.NewCluster(t, "1", 1, false, test.Production)
+	clusterState, err := inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+	clusterState, err = inventory.UpdateStatus(clusterState, model.ClusterStatusReconciling)
+	require.NoError(t, err)
+	clusterState, err = inventory.UpdateStatus(clusterState, model.ClusterStatusReady)
+	require.NoError(t, err)
+
+	//latest statuses first and limited
+	statuses, err := inventory.StatusHistory(cluster.RuntimeID, 2)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	require.Equal(t, clusterState.Status.ID, statuses[0].ID)
+	require.Equal(t, model.ClusterStatusReconciling, statuses[1].Status)
+}
+
+func (s *clusterTestSuite) TestInventoryList() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	var states []*State
+	var clusters []*keb.Cluster
+	for i := 1; i <= 3; i++ {
+		cluster := test.NewCluster(t, strconv.Itoa(i), 1, false, test.Production)
+		if i == 1 {
+			cluster.Metadata.Labels = &map[string]string{"env": "dev"}
+		}
+		state, err := inventory.CreateOrUpdate(1, cluster)
+		require.NoError(t, err)
+		states = append(states, state)
+		clusters = append(clusters, cluster)
+	}
+	_, err = inventory.UpdateStatus(states[1], model.ClusterStatusReady)
+	require.NoError(t, err)
+
+	//all clusters are ordered by runtime ID
+	result, total, err := inventory.List(nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Len(t, result, 3)
+	require.True(t, result[0].Cluster.RuntimeID < result[1].Cluster.RuntimeID)
+
+	//filter by status
+	result, total, err = inventory.List(&ListFilter{Statuses: []model.Status{model.ClusterStatusReady}})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, states[1].Cluster.RuntimeID, result[0].Cluster.RuntimeID)
+
+	//filter by runtime ID
+	result, total, err = inventory.List(&ListFilter{RuntimeIDs: []string{states[2].Cluster.RuntimeID}})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, states[2].Cluster.RuntimeID, result[0].Cluster.RuntimeID)
+
+	//all criteria have to match
+	result, total, err = inventory.List(&ListFilter{
+		Statuses:   []model.Status{model.ClusterStatusReady},
+		RuntimeIDs: []string{states[2].Cluster.RuntimeID},
+	})
+	require.NoError(t, err)
+	require.Zero(t, total)
+	require.Empty(t, result)
+
+	//filter by label
+	result, total, err = inventory.List(&ListFilter{Labels: map[string]string{"env": "dev"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, states[0].Cluster.RuntimeID, result[0].Cluster.RuntimeID)
+
+	//labels which don't match or aren't defined exclude the clusters
+	result, total, err = inventory.List(&ListFilter{Labels: map[string]string{"env": "dev", "region": "eu"}})
+	require.NoError(t, err)
+	require.Zero(t, total)
+	require.Empty(t, result)
+
+	//pagination returns the total number of matching clusters
+	all, _, err := inventory.List(nil)
+	require.NoError(t, err)
+	result, total, err = inventory.List(&ListFilter{Offset: 1, Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Len(t, result, 1)
+	require.Equal(t, all[1].Cluster.RuntimeID, result[0].Cluster.RuntimeID)
+
+	//keyset pagination continues after the runtime ID
+	result, total, err = inventory.List(&ListFilter{After: all[0].Cluster.RuntimeID, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, []string{all[1].Cluster.RuntimeID, all[2].Cluster.RuntimeID}, runtimeIDs(result))
+
+	//the latest configuration of an updated cluster is listed
+	updatedState, err := inventory.CreateOrUpdate(1, test.NewClusterFromExisting(*clusters[0], 2, true))
+	require.NoError(t, err)
+	result, total, err = inventory.List(&ListFilter{Labels: map[string]string{"env": "dev"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, updatedState.Configuration.Version, result[0].Configuration.Version)
+
+	//a custom order can't be combined with the keyset pagination
+	_, _, err = inventory.List(&ListFilter{After: "abc", Less: func(a, b *State) bool { return false }})
+	require.Error(t, err)
+
+	//invalid runtime IDs are rejected
+	_, _, err = inventory.List(&ListFilter{RuntimeIDs: []string{"abc' OR '1'='1"}})
+	require.Error(t, err)
+}
+
+func (s *clusterTestSuite) TestInventoryGetAllStates() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+
+	removeAllClusters(t, inventory)
+	defer func() {
+		removeAllClusters(t, inventory)
+		require.NoError(t, conn.Close())
+	}()
+
+	var clusters []*keb.Cluster
+	var runtimeIDs []string
+	for i := 1; i <= 3; i++ {
+		cluster := test.NewCluster(t, strconv.Itoa(i), 1, false, test.Production)
+		_, err := inventory.CreateOrUpdate(1, cluster)
+		require.NoError(t, err)
+		clusters = append(clusters, cluster)
+		runtimeIDs = append(runtimeIDs, cluster.RuntimeID)
+	}
+	//create a second configuration version of the first cluster and update its status
+	stateV2, err := inventory.CreateOrUpdate(1, test.NewClusterFromExisting(*clusters[0], 1, true))
+	require.NoError(t, err)
+	stateV2, err = inventory.UpdateStatus(stateV2, model.ClusterStatusReady)
+	require.NoError(t, err)
+
+	//configurations and clusters of the latest states are resolved in batches
+	states, err := inventory.GetAll()
+	require.NoError(t, err)
+	require.Len(t, states, 3)
+	for _, state := range states {
+		require.Contains(t, runtimeIDs, state.Cluster.RuntimeID)
+		expected, err := inventory.GetLatest(state.Cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Equal(t, expected.Cluster.Version, state.Cluster.Version)
+		require.Equal(t, expected.Configuration.Version, state.Configuration.Version)
+		require.Equal(t, expected.Status.ID, state.Status.ID)
+		if state.Cluster.RuntimeID == stateV2.Cluster.RuntimeID {
+			require.Equal(t, stateV2.Configuration.Version, state.Configuration.Version)
+		}
+	}
+}
+
 func (s *clusterTestSuite) Test_ClustersStatusCheck() {
 	t := s.T()
 	t.Run("Get clusters with particular status", func(t *testing.T) {
@@ -223,6 +920,27 @@ func (s *clusterTestSuite) Test_StatusChange() {
 		require.ElementsMatch(t,
 			listStatusesForStatusChanges(changes),
 			clusterStatuses)
+
+		//pages filtered by the database match the pages of the status changes filtered in memory
+		for _, filter := range []*StatusChangeFilter{
+			{},
+			{Limit: 3},
+			{Cursor: changes[2].Status.ID, Limit: 3},
+			{Cursor: changes[9].Status.ID, Limit: 3, Ascending: true},
+			{Statuses: []model.Status{model.ClusterStatusReady, model.ClusterStatusReconcileError}, Limit: 1},
+			{Statuses: []model.Status{model.ClusterStatusReady}, Ascending: true},
+		} {
+			page, err := inventory.ListStatusChanges(newCluster.RuntimeID, duration, filter)
+			require.NoError(t, err)
+			expected := filter.apply(changes)
+			require.Equal(t, statusChangeIDs(expected), statusChangeIDs(page))
+			require.Equal(t, expected.Total, page.Total)
+			require.Equal(t, expected.Cursor, page.Cursor)
+		}
+
+		page, err := inventory.ListStatusChanges(newCluster.RuntimeID, duration, &StatusChangeFilter{Limit: 2})
+		require.NoError(t, err)
+		require.Equal(t, changes[1].Duration, page.StatusChanges[1].Duration)
 	})
 }
 
@@ -505,6 +1223,61 @@ func (s *clusterTestSuite) TestRemoveDeletedClustersOlderThan() {
 	require.Equal(t, 1, len(clusterStates))
 }
 
+func (s *clusterTestSuite) TestGetDeletedAndPurge() {
+	t := s.T()
+
+	//create inventory
+	conn := s.TxConnection()
+	inventory := s.newInventory(conn)
+
+	clusterState, err := inventory.CreateOrUpdate(1, test.NewCluster(t, "to-be-purged", 1, false, test.Production))
+	require.NoError(t, err)
+	runtimeID := clusterState.Cluster.RuntimeID
+
+	//reconciliations keep the original runtime ID and reference the configuration of the cluster
+	reconQuery, err := db.NewQuery(conn, &model.ReconciliationEntity{
+		Lock:                runtimeID,
+		RuntimeID:           runtimeID,
+		ClusterConfig:       clusterState.Configuration.Version,
+		ClusterConfigStatus: clusterState.Status.ID,
+		Finished:            true,
+		SchedulingID:        "purged-scheduling-id",
+		Status:              model.ClusterStatusReady,
+	}, logger.NewLogger(true))
+	require.NoError(t, err)
+	require.NoError(t, reconQuery.Insert().Exec())
+
+	deletedClusters, err := inventory.GetDeleted(runtimeID)
+	require.NoError(t, err)
+	require.Empty(t, deletedClusters, "active cluster is not deleted")
+
+	//deleted cluster is kept until it gets purged
+	require.NoError(t, inventory.Delete(runtimeID))
+	deletedClusters, err = inventory.GetDeleted(runtimeID)
+	require.NoError(t, err)
+	require.Len(t, deletedClusters, 1)
+	require.NotEqual(t, runtimeID, deletedClusters[0].RuntimeID)
+	require.WithinDuration(t, time.Now(), deletedClusters[0].Deleted, time.Minute)
+	require.Equal(t, []int64{clusterState.Configuration.Version}, deletedClusters[0].ConfigVersions)
+
+	//reconciliations have to be removed by the configurations of the deleted cluster before it gets purged
+	reconQuery, err = db.NewQuery(conn, &model.ReconciliationEntity{}, logger.NewLogger(true))
+	require.NoError(t, err)
+	removed, err := reconQuery.Delete().
+		Where(map[string]interface{}{"ClusterConfig": deletedClusters[0].ConfigVersions[0]}).
+		Exec()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), removed)
+
+	require.NoError(t, inventory.Purge(deletedClusters[0]))
+	deletedClusters, err = inventory.GetDeleted(runtimeID)
+	require.NoError(t, err)
+	require.Empty(t, deletedClusters)
+
+	//active clusters can't be purged
+	require.Error(t, inventory.Purge(&DeletedCluster{RuntimeID: runtimeID}))
+}
+
 func (s *clusterTestSuite) TestDefaultInventory_RemoveStatusesWithoutReconciliations() {
 	t := s.T()
 	//create inventory
@@ -628,14 +1401,18 @@ func compareState(t *testing.T, state *State, cluster *keb.Cluster) {
 	require.Equal(t, cluster.RuntimeID, state.Configuration.RuntimeID)
 	require.Equal(t, cluster.KymaConfig.Profile, state.Configuration.KymaProfile)
 	require.Equal(t, cluster.KymaConfig.Version, state.Configuration.KymaVersion)
-	//compare components
-	require.ElementsMatch(t, func() []*keb.Component {
-		var result []*keb.Component
-		for idx := range cluster.KymaConfig.Components {
-			result = append(result, &cluster.KymaConfig.Components[idx])
-		}
-		return result
-	}(), state.Configuration.Components)
+	//compare components (they are stored in canonical order)
+	expectedConfig := &model.ClusterConfigurationEntity{
+		Components: func() []*keb.Component {
+			var result []*keb.Component
+			for idx := range cluster.KymaConfig.Components {
+				result = append(result, &cluster.KymaConfig.Components[idx])
+			}
+			return result
+		}(),
+	}
+	expectedConfig.Normalize()
+	require.Equal(t, expectedConfig.Components, state.Configuration.Components)
 	require.Len(t, cluster.KymaConfig.Components, 7)
 
 	//compare administrators
@@ -0,0 +1,261 @@
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const defaultKeyRotationBatchSize = 100
+
+//KeyRotationResult summarises a key rotation run
+type KeyRotationResult struct {
+	Rotated         int
+	Failed          int
+	RotatedPayloads int
+	FailedPayloads  int
+}
+
+//KubeconfigKeyRotator re-encrypts the kubeconfigs (including the named kubeconfigs of further clusters) of all
+//cluster entities and the stored KEB payloads (which contain the kubeconfigs as well) with the current encryption key.
+//Rows are updated one by one, so the rotation can run while the mothership is serving requests: the
+//mothership has to be configured with the new key and with the former keys as previous keys
+//(see 'db.encryption.previousKeyFiles') until the rotation finished.
+type KubeconfigKeyRotator struct {
+	conn      db.Connection
+	batchSize int
+	logger    *zap.SugaredLogger
+}
+
+func NewKubeconfigKeyRotator(conn db.Connection, batchSize int, logger *zap.SugaredLogger) *KubeconfigKeyRotator {
+	if batchSize <= 0 {
+		batchSize = defaultKeyRotationBatchSize
+	}
+	return &KubeconfigKeyRotator{
+		conn:      conn,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+type rotationCandidate struct {
+	version     int64
+	kubeconfig  string
+	kubeconfigs string
+}
+
+type payloadRotationCandidate struct {
+	id      int64
+	payload string
+}
+
+//Rotate re-encrypts all kubeconfigs and payloads which aren't encrypted with the current key yet
+func (r *KubeconfigKeyRotator) Rotate(ctx context.Context) (*KeyRotationResult, error) {
+	result := &KeyRotationResult{}
+	if err := r.rotateKubeconfigs(ctx, result); err != nil {
+		return result, err
+	}
+	return result, r.rotatePayloads(ctx, result)
+}
+
+func (r *KubeconfigKeyRotator) rotateKubeconfigs(ctx context.Context, result *KeyRotationResult) error {
+	colHdlr, err := db.NewColumnHandler(&model.ClusterEntity{}, r.conn, r.logger)
+	if err != nil {
+		return err
+	}
+	colNames := make(map[string]string)
+	for _, field := range []string{"Version", "Kubeconfig", "Kubeconfigs", "KubeconfigKeyID"} {
+		if colNames[field], err = colHdlr.ColumnName(field); err != nil {
+			return err
+		}
+	}
+
+	keyID := r.conn.Encryptor().KeyID()
+	selectSQL := fmt.Sprintf("SELECT %s, %s, %s FROM %s WHERE %s>$1 AND (%s IS NULL OR %s<>$2) ORDER BY %s LIMIT $3",
+		colNames["Version"], colNames["Kubeconfig"], colNames["Kubeconfigs"], (&model.ClusterEntity{}).Table(),
+		colNames["Version"], colNames["KubeconfigKeyID"], colNames["KubeconfigKeyID"], colNames["Version"])
+	//the kubeconfig is part of the condition to avoid overwriting concurrent changes of the row
+	updateSQL := fmt.Sprintf("UPDATE %s SET %s=$1, %s=$2, %s=$3 WHERE %s=$4 AND %s=$5",
+		(&model.ClusterEntity{}).Table(), colNames["Kubeconfig"], colNames["Kubeconfigs"], colNames["KubeconfigKeyID"],
+		colNames["Version"], colNames["Kubeconfig"])
+
+	var lastVersion int64 //cursor: rows which failed are not selected again
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		candidates, err := r.candidates(selectSQL, keyID, lastVersion)
+		if err != nil {
+			return err
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+
+		for _, candidate := range candidates {
+			lastVersion = candidate.version
+			if err := r.rotate(updateSQL, keyID, candidate); err != nil {
+				r.logger.Errorf("Failed to rotate encryption key of kubeconfig of cluster entity with version %d: %s",
+					candidate.version, err)
+				result.Failed++
+				continue
+			}
+			result.Rotated++
+		}
+		r.logger.Infof("Encryption key rotation progress: %d kubeconfigs rotated, %d failed", result.Rotated, result.Failed)
+	}
+}
+
+func (r *KubeconfigKeyRotator) candidates(selectSQL, keyID string, lastVersion int64) ([]*rotationCandidate, error) {
+	rows, err := r.conn.Query(selectSQL, lastVersion, keyID, r.batchSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve kubeconfigs for key rotation")
+	}
+	if closer, ok := rows.(*sql.Rows); ok {
+		defer func() {
+			if err := closer.Close(); err != nil {
+				r.logger.Warnf("Failed to close rows of key rotation query: %s", err)
+			}
+		}()
+	}
+
+	var candidates []*rotationCandidate
+	for rows.Next() {
+		candidate := &rotationCandidate{}
+		if err := rows.Scan(&candidate.version, &candidate.kubeconfig, &candidate.kubeconfigs); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+func (r *KubeconfigKeyRotator) rotate(updateSQL, keyID string, candidate *rotationCandidate) error {
+	encryptor := r.conn.Encryptor()
+	kubeconfig, err := encryptor.Decrypt(candidate.kubeconfig)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decrypt kubeconfig (key ID: '%s')", db.EncryptionKeyID(candidate.kubeconfig))
+	}
+	encKubeconfig, err := encryptor.EncryptEnvelope(kubeconfig)
+	if err != nil {
+		return err
+	}
+	encKubeconfigs := candidate.kubeconfigs
+	if db.IsEnvelope(candidate.kubeconfigs) { //rows created before named kubeconfigs were introduced have no value
+		kubeconfigs, err := encryptor.Decrypt(candidate.kubeconfigs)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decrypt named kubeconfigs (key ID: '%s')",
+				db.EncryptionKeyID(candidate.kubeconfigs))
+		}
+		if encKubeconfigs, err = encryptor.EncryptEnvelope(kubeconfigs); err != nil {
+			return err
+		}
+	}
+	res, err := r.conn.Exec(updateSQL, encKubeconfig, encKubeconfigs, keyID, candidate.version, candidate.kubeconfig)
+	if err != nil {
+		return err
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		r.logger.Debugf("Cluster entity with version %d was modified during key rotation: skipping it", candidate.version)
+	}
+	return nil
+}
+
+func (r *KubeconfigKeyRotator) rotatePayloads(ctx context.Context, result *KeyRotationResult) error {
+	colHdlr, err := db.NewColumnHandler(&model.PayloadEntity{}, r.conn, r.logger)
+	if err != nil {
+		return err
+	}
+	idCol, err := colHdlr.ColumnName("ID")
+	if err != nil {
+		return err
+	}
+	payloadCol, err := colHdlr.ColumnName("Payload")
+	if err != nil {
+		return err
+	}
+
+	keyPrefix := db.EnvelopePrefix(r.conn.Encryptor().KeyID())
+	selectSQL := fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s>$1 AND %s NOT LIKE $2 ORDER BY %s LIMIT $3",
+		idCol, payloadCol, (&model.PayloadEntity{}).Table(), idCol, payloadCol, idCol)
+	//the payload is part of the condition to avoid overwriting concurrent changes of the row
+	updateSQL := fmt.Sprintf("UPDATE %s SET %s=$1 WHERE %s=$2 AND %s=$3",
+		(&model.PayloadEntity{}).Table(), payloadCol, idCol, payloadCol)
+
+	var lastID int64 //cursor: rows which failed are not selected again
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		candidates, err := r.payloadCandidates(selectSQL, keyPrefix+"%", lastID)
+		if err != nil {
+			return err
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+
+		for _, candidate := range candidates {
+			lastID = candidate.id
+			if err := r.rotatePayload(updateSQL, candidate); err != nil {
+				r.logger.Errorf("Failed to rotate encryption key of payload with ID %d: %s", candidate.id, err)
+				result.FailedPayloads++
+				continue
+			}
+			result.RotatedPayloads++
+		}
+		r.logger.Infof("Encryption key rotation progress: %d payloads rotated, %d failed",
+			result.RotatedPayloads, result.FailedPayloads)
+	}
+}
+
+func (r *KubeconfigKeyRotator) payloadCandidates(selectSQL, keyPattern string, lastID int64) ([]*payloadRotationCandidate, error) {
+	rows, err := r.conn.Query(selectSQL, lastID, keyPattern, r.batchSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve payloads for key rotation")
+	}
+	if closer, ok := rows.(*sql.Rows); ok {
+		defer func() {
+			if err := closer.Close(); err != nil {
+				r.logger.Warnf("Failed to close rows of key rotation query: %s", err)
+			}
+		}()
+	}
+
+	var candidates []*payloadRotationCandidate
+	for rows.Next() {
+		candidate := &payloadRotationCandidate{}
+		if err := rows.Scan(&candidate.id, &candidate.payload); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+func (r *KubeconfigKeyRotator) rotatePayload(updateSQL string, candidate *payloadRotationCandidate) error {
+	encryptor := r.conn.Encryptor()
+	payload, err := encryptor.Decrypt(candidate.payload)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decrypt payload (key ID: '%s')", db.EncryptionKeyID(candidate.payload))
+	}
+	encPayload, err := encryptor.EncryptEnvelope(payload)
+	if err != nil {
+		return err
+	}
+	res, err := r.conn.Exec(updateSQL, encPayload, candidate.id, candidate.payload)
+	if err != nil {
+		return err
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		r.logger.Debugf("Payload with ID %d was modified during key rotation: skipping it", candidate.id)
+	}
+	return nil
+}
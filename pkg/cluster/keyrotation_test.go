@@ -0,0 +1,77 @@
+package cluster
+
+import (
+	"context"
+	"io/ioutil"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb/test"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+//rotationConnection uses a new encryption key and the key of the test database as previous key
+type rotationConnection struct {
+	db.Connection
+	encryptor *db.Encryptor
+}
+
+func (c *rotationConnection) Encryptor() *db.Encryptor {
+	return c.encryptor
+}
+
+func (s *clusterTestSuite) TestKeyRotation() {
+	t := s.T()
+
+	conn := s.TxConnection()
+	inventory := s.newInventory(conn)
+	clusterState, err := inventory.CreateOrUpdate(1, test.NewCluster(t, "key-rotation", 1, false, test.Production))
+	require.NoError(t, err)
+
+	//payloads are encrypted with the key of the test database
+	payloadQuery, err := db.NewQuery(conn, &model.PayloadEntity{
+		RuntimeID:     clusterState.Cluster.RuntimeID,
+		ConfigVersion: clusterState.Configuration.Version,
+		Contract:      1,
+		Payload:       "compressed-payload",
+	}, logger.NewLogger(true))
+	require.NoError(t, err)
+	require.NoError(t, payloadQuery.Insert().Exec())
+
+	previousKey, err := ioutil.ReadFile(db.UnittestEncryptionKeyFile())
+	require.NoError(t, err)
+	encryptor, err := db.NewEncryptor(db.MockEncryptorKey, strings.TrimSpace(string(previousKey)))
+	require.NoError(t, err)
+	rotationConn := &rotationConnection{Connection: conn, encryptor: encryptor}
+
+	result, err := NewKubeconfigKeyRotator(rotationConn, 1, logger.NewLogger(true)).Rotate(context.Background())
+	require.NoError(t, err)
+	require.NotZero(t, result.Rotated)
+	require.NotZero(t, result.RotatedPayloads)
+	require.Zero(t, result.Failed)
+	require.Zero(t, result.FailedPayloads)
+
+	//all payloads are encrypted with the new key and can be decrypted
+	rows, err := conn.Query("SELECT payload FROM inventory_payloads")
+	require.NoError(t, err)
+	var rotated int
+	for rows.Next() {
+		var payload string
+		require.NoError(t, rows.Scan(&payload))
+		require.Equal(t, encryptor.KeyID(), db.EncryptionKeyID(payload))
+		decrypted, err := encryptor.Decrypt(payload)
+		require.NoError(t, err)
+		if decrypted == "compressed-payload" {
+			rotated++
+		}
+	}
+	require.Equal(t, 1, rotated)
+
+	//rotated entities are not rotated again
+	result, err = NewKubeconfigKeyRotator(rotationConn, 1, logger.NewLogger(true)).Rotate(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, result.Rotated)
+	require.Zero(t, result.RotatedPayloads)
+}
@@ -0,0 +1,126 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+)
+
+//NoPreviousKubeconfigError indicates that a kubeconfig rotation can't be rolled back because the previous
+//kubeconfig isn't available (anymore)
+type NoPreviousKubeconfigError struct {
+	RuntimeID string
+	Reason    string
+}
+
+func (e *NoPreviousKubeconfigError) Error() string {
+	return fmt.Sprintf("kubeconfig of cluster '%s' can't be rolled back: %s", e.RuntimeID, e.Reason)
+}
+
+func IsNoPreviousKubeconfigError(err error) bool {
+	var noPrevErr *NoPreviousKubeconfigError
+	return errors.As(err, &noPrevErr)
+}
+
+//RotateKubeconfig replaces the kubeconfig of the latest cluster entity without creating a new cluster or
+//configuration version. The replaced kubeconfig is kept for a rollback until the next successful reconciliation.
+func (i *DefaultInventory) RotateKubeconfig(runtimeID, kubeconfig string) (*State, error) {
+	return i.updateKubeconfig(runtimeID, func(iTx *DefaultInventory, clusterEntity *model.ClusterEntity) (bool, error) {
+		if clusterEntity.Kubeconfig == kubeconfig {
+			return false, nil
+		}
+		//reconciliations of the current status could still use the replaced kubeconfig
+		state, err := iTx.GetLatest(runtimeID)
+		if err != nil {
+			return false, err
+		}
+		clusterEntity.PreviousKubeconfig = clusterEntity.Kubeconfig
+		clusterEntity.Kubeconfig = kubeconfig
+		clusterEntity.KubeconfigRotated = state.Status.ID
+		return true, nil
+	}, "rotated kubeconfig")
+}
+
+//RollbackKubeconfig restores the kubeconfig which was replaced by the last rotation
+func (i *DefaultInventory) RollbackKubeconfig(runtimeID string) (*State, error) {
+	return i.updateKubeconfig(runtimeID, func(_ *DefaultInventory, clusterEntity *model.ClusterEntity) (bool, error) {
+		if clusterEntity.PreviousKubeconfig == "" {
+			return false, &NoPreviousKubeconfigError{
+				RuntimeID: runtimeID,
+				Reason:    "no rotation happened since the last successful reconciliation",
+			}
+		}
+		//value is still encrypted if its decryption failed (e.g. because its key was dropped in the meantime)
+		if db.IsEnvelope(clusterEntity.PreviousKubeconfig) {
+			return false, &NoPreviousKubeconfigError{
+				RuntimeID: runtimeID,
+				Reason: fmt.Sprintf("previous kubeconfig can't be decrypted (key ID: '%s')",
+					db.EncryptionKeyID(clusterEntity.PreviousKubeconfig)),
+			}
+		}
+		clusterEntity.Kubeconfig = clusterEntity.PreviousKubeconfig
+		clusterEntity.PreviousKubeconfig = ""
+		return true, nil
+	}, "rolled back kubeconfig")
+}
+
+//ReleasePreviousKubeconfig drops the kubeconfig which was replaced by the last rotation: called after the
+//cluster was successfully reconciled. The status is the cluster status of the successful reconciliation: the
+//kubeconfig is kept if the reconciliation started before the rotation (it could have used the replaced kubeconfig).
+func (i *DefaultInventory) ReleasePreviousKubeconfig(runtimeID string, statusID int64) error {
+	_, err := i.updateKubeconfig(runtimeID, func(_ *DefaultInventory, clusterEntity *model.ClusterEntity) (bool, error) {
+		if clusterEntity.PreviousKubeconfig == "" {
+			return false, nil
+		}
+		if statusID <= clusterEntity.KubeconfigRotated {
+			i.Logger.Debugf("Inventory keeps previous kubeconfig of cluster '%s': reconciliation of status %d "+
+				"started before the rotation", runtimeID, statusID)
+			return false, nil
+		}
+		clusterEntity.PreviousKubeconfig = ""
+		return true, nil
+	}, "released previous kubeconfig")
+	return err
+}
+
+func (i *DefaultInventory) updateKubeconfig(runtimeID string,
+	modify func(iTx *DefaultInventory, clusterEntity *model.ClusterEntity) (bool, error), action string) (*State, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		tmpiTx, err := i.WithTx(tx)
+		if err != nil {
+			return nil, err
+		}
+		iTx := tmpiTx.(*DefaultInventory)
+
+		clusterEntity, err := iTx.latestCluster(runtimeID)
+		if err != nil {
+			return nil, err
+		}
+		modified, err := modify(iTx, clusterEntity)
+		if err != nil {
+			return nil, err
+		}
+		if modified {
+			clusterEntity.KubeconfigKeyID = iTx.Conn.Encryptor().KeyID()
+			q, err := db.NewQuery(iTx.Conn, clusterEntity, iTx.Logger)
+			if err != nil {
+				return nil, err
+			}
+			if err := q.Update().
+				Where(map[string]interface{}{"Version": clusterEntity.Version}).
+				Exec(); err != nil {
+				return nil, err
+			}
+			iTx.Logger.Infof("Inventory %s of cluster '%s' (clusterVersion:%d)",
+				action, runtimeID, clusterEntity.Version)
+		}
+		return iTx.GetLatest(runtimeID)
+	}
+	state, err := db.TransactionResult(i.Conn, dbOps, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	return state.(*State), nil
+}
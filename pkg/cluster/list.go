@@ -0,0 +1,244 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+)
+
+//ListFilter restricts the clusters returned by Inventory.List: all criteria have to match
+type ListFilter struct {
+	//Statuses of the clusters (any status if empty)
+	Statuses []model.Status
+	//RuntimeIDs of the clusters (any cluster if empty)
+	RuntimeIDs []string
+	//Labels which the clusters have to provide in their metadata
+	Labels map[string]string
+	//After is the runtime ID after which the page starts (keyset pagination, the offset is applied afterwards): it's
+	//only supported if the clusters are ordered by runtime ID
+	After string
+	//Offset is the number of matching clusters which are skipped
+	Offset int
+	//Limit is the max. number of returned clusters (0 means unlimited)
+	Limit int
+	//Less orders the clusters before the page is cut (clusters are ordered by runtime ID if undefined). Ordering,
+	//offset and limit are applied by the database unless a custom order is defined: all matching clusters are
+	//loaded and ordered in memory in this case.
+	Less func(a, b *State) bool
+}
+
+//listQuery builds the SQL conditions of the filter on the latest cluster statuses
+type listQuery struct {
+	dbType      db.Type
+	statusCols  *db.ColumnHandler
+	clusterCols *db.ColumnHandler
+	statusTbl   string
+	clusterTbl  string
+	conds       []string
+	args        []interface{}
+}
+
+func (lq *listQuery) placeholder(arg interface{}) string {
+	lq.args = append(lq.args, arg)
+	return fmt.Sprintf("$%d", len(lq.args))
+}
+
+func (lq *listQuery) placeholders(args []interface{}) string {
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		result = append(result, lq.placeholder(arg))
+	}
+	return strings.Join(result, ",")
+}
+
+func (lq *listQuery) columns(names ...string) ([]string, error) {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		colName, err := lq.statusCols.ColumnName(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, colName)
+	}
+	return result, nil
+}
+
+//newListQuery returns the conditions which select the latest status of each cluster (the status of the latest
+//configuration version) matching the filter. The keyset (After) isn't part of the conditions.
+func (i *DefaultInventory) newListQuery(filter *ListFilter) (*listQuery, error) {
+	statusCols, err := db.NewColumnHandler(&model.ClusterStatusEntity{}, i.Conn, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	clusterCols, err := db.NewColumnHandler(&model.ClusterEntity{}, i.Conn, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	lq := &listQuery{
+		dbType:      i.Conn.Type(),
+		statusCols:  statusCols,
+		clusterCols: clusterCols,
+		statusTbl:   (&model.ClusterStatusEntity{}).Table(),
+		clusterTbl:  (&model.ClusterEntity{}).Table(),
+	}
+	cols, err := lq.columns("ID", "RuntimeID", "ConfigVersion", "Deleted", "Status", "ClusterVersion")
+	if err != nil {
+		return nil, err
+	}
+	idCol, runtimeIDCol, configVersionCol, deletedCol, statusCol, clusterVersionCol :=
+		cols[0], cols[1], cols[2], cols[3], cols[4], cols[5]
+
+	//latest status = newest status of the latest configuration version of the cluster
+	lq.conds = append(lq.conds, fmt.Sprintf("%s IN (SELECT MAX(s.%s) FROM %s s JOIN "+
+		"(SELECT %s, MAX(%s) AS latest_version FROM %s WHERE %s=%s GROUP BY %s) l "+
+		"ON s.%s=l.%s AND s.%s=l.latest_version WHERE s.%s=%s GROUP BY s.%s)",
+		idCol, idCol, lq.statusTbl,
+		runtimeIDCol, configVersionCol, lq.statusTbl, deletedCol, lq.placeholder(false), runtimeIDCol,
+		runtimeIDCol, runtimeIDCol, configVersionCol, deletedCol, lq.placeholder(false), runtimeIDCol))
+
+	if len(filter.Statuses) > 0 {
+		var statuses []interface{}
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		lq.conds = append(lq.conds, fmt.Sprintf("%s IN (%s)", statusCol, lq.placeholders(statuses)))
+	}
+	if len(filter.RuntimeIDs) > 0 {
+		var runtimeIDs []interface{}
+		for _, runtimeID := range filter.RuntimeIDs {
+			if err := ValidateRuntimeID(runtimeID); err != nil {
+				return nil, err
+			}
+			runtimeIDs = append(runtimeIDs, runtimeID)
+		}
+		lq.conds = append(lq.conds, fmt.Sprintf("%s IN (%s)", runtimeIDCol, lq.placeholders(runtimeIDs)))
+	}
+	if len(filter.Labels) > 0 {
+		versionCol, err := lq.clusterCols.ColumnName("Version")
+		if err != nil {
+			return nil, err
+		}
+		labelConds, err := lq.labelConds(filter.Labels)
+		if err != nil {
+			return nil, err
+		}
+		lq.conds = append(lq.conds, fmt.Sprintf("%s IN (SELECT %s FROM %s WHERE %s)",
+			clusterVersionCol, versionCol, lq.clusterTbl, strings.Join(labelConds, " AND ")))
+	}
+	return lq, nil
+}
+
+//labelConds returns the conditions on the labels of the cluster metadata (stored as JSON)
+func (lq *listQuery) labelConds(labels map[string]string) ([]string, error) {
+	metadataCol, err := lq.clusterCols.ColumnName("Metadata")
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys) //stable order of the placeholders
+	var conds []string
+	for _, key := range keys {
+		switch lq.dbType {
+		case db.Postgres:
+			conds = append(conds, fmt.Sprintf("(%s::jsonb)->'labels'->>%s = %s",
+				metadataCol, lq.placeholder(key), lq.placeholder(labels[key])))
+		case db.SQLite:
+			if strings.Contains(key, `"`) {
+				return nil, fmt.Errorf("label key '%s' is invalid: it cannot contain quotes", key)
+			}
+			conds = append(conds, fmt.Sprintf("json_extract(%s, %s) = %s",
+				metadataCol, lq.placeholder(fmt.Sprintf(`$.labels."%s"`, key)), lq.placeholder(labels[key])))
+		default:
+			return nil, fmt.Errorf("database type '%s' is not supported", lq.dbType)
+		}
+	}
+	return conds, nil
+}
+
+//countList returns the number of matching clusters
+func (i *DefaultInventory) countList(lq *listQuery) (int, error) {
+	row, err := i.Conn.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s",
+		lq.statusTbl, strings.Join(lq.conds, " AND ")), lq.args...)
+	if err != nil {
+		return 0, err
+	}
+	var total int
+	if err := row.Scan(&total); err != nil {
+		return 0, errors.Wrap(err, "failed to count clusters")
+	}
+	return total, nil
+}
+
+//selectList returns the statuses of the requested page (ordered by runtime ID) or, if the filter has a custom
+//order, of all matching clusters
+func (i *DefaultInventory) selectList(lq *listQuery, filter *ListFilter) ([]*model.ClusterStatusEntity, error) {
+	cols, err := lq.columns("ID", "RuntimeID", "ClusterVersion", "ConfigVersion", "Status", "Created", "Deleted")
+	if err != nil {
+		return nil, err
+	}
+	conds := lq.conds
+	var page string
+	if filter.Less == nil {
+		if filter.After != "" {
+			conds = append(conds, fmt.Sprintf("%s > %s", cols[1], lq.placeholder(filter.After)))
+		}
+		page = fmt.Sprintf(" ORDER BY %s", cols[1])
+		switch {
+		case filter.Limit > 0:
+			page += fmt.Sprintf(" LIMIT %s OFFSET %s", lq.placeholder(filter.Limit), lq.placeholder(filter.Offset))
+		case filter.Offset > 0 && lq.dbType == db.SQLite: //SQLite supports OFFSET only in combination with LIMIT
+			page += fmt.Sprintf(" LIMIT -1 OFFSET %s", lq.placeholder(filter.Offset))
+		case filter.Offset > 0:
+			page += fmt.Sprintf(" OFFSET %s", lq.placeholder(filter.Offset))
+		}
+	}
+	dataRows, err := i.Conn.Query(fmt.Sprintf("SELECT %s FROM %s WHERE %s%s",
+		strings.Join(cols, ", "), lq.statusTbl, strings.Join(conds, " AND "), page), lq.args...)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []*model.ClusterStatusEntity
+	for dataRows.Next() {
+		status := &model.ClusterStatusEntity{}
+		if err := dataRows.Scan(&status.ID,
+			&status.RuntimeID,
+			&status.ClusterVersion,
+			&status.ConfigVersion,
+			&status.Status,
+			&status.Created,
+			&status.Deleted); err != nil {
+			return nil, errors.Wrap(err, "failed to bind cluster statuses")
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+//apply returns the requested page of the states ordered by the custom order of the filter and the total number
+//of states
+func (f *ListFilter) apply(states []*State) ([]*State, int) {
+	result := append([]*State{}, states...)
+	sort.Slice(result, func(i, j int) bool {
+		if f.Less != nil {
+			return f.Less(result[i], result[j])
+		}
+		return result[i].Cluster.RuntimeID < result[j].Cluster.RuntimeID
+	})
+
+	total := len(result)
+	if f.Offset >= total {
+		return []*State{}, total
+	}
+	result = result[f.Offset:]
+	if f.Limit > 0 && f.Limit < len(result) {
+		result = result[:f.Limit]
+	}
+	return result, total
+}
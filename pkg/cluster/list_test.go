@@ -0,0 +1,83 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newListState(runtimeID string, healthScore int) *State {
+	return &State{
+		Cluster: &model.ClusterEntity{RuntimeID: runtimeID},
+		Status:  &model.ClusterStatusEntity{ID: int64(healthScore)},
+	}
+}
+
+func runtimeIDs(states []*State) []string {
+	result := []string{}
+	for _, state := range states {
+		result = append(result, state.Cluster.RuntimeID)
+	}
+	return result
+}
+
+func TestListFilter(t *testing.T) {
+	states := []*State{
+		newListState("c", 50),
+		newListState("a", 100),
+		newListState("b", 0),
+		newListState("d", 50),
+	}
+	byScore := func(a, b *State) bool { //the status ID is used as score
+		if a.Status.ID == b.Status.ID {
+			return a.Cluster.RuntimeID < b.Cluster.RuntimeID
+		}
+		return a.Status.ID < b.Status.ID
+	}
+
+	tests := []struct {
+		name          string
+		filter        *ListFilter
+		expected      []string
+		expectedTotal int
+	}{
+		{
+			name:          "No order",
+			filter:        &ListFilter{},
+			expected:      []string{"a", "b", "c", "d"},
+			expectedTotal: 4,
+		},
+		{
+			name:          "Custom order",
+			filter:        &ListFilter{Less: byScore},
+			expected:      []string{"b", "c", "d", "a"},
+			expectedTotal: 4,
+		},
+		{
+			name:          "Page",
+			filter:        &ListFilter{Less: byScore, Offset: 1, Limit: 2},
+			expected:      []string{"c", "d"},
+			expectedTotal: 4,
+		},
+		{
+			name:          "Last page",
+			filter:        &ListFilter{Less: byScore, Offset: 3, Limit: 2},
+			expected:      []string{"a"},
+			expectedTotal: 4,
+		},
+		{
+			name:          "Offset beyond clusters",
+			filter:        &ListFilter{Less: byScore, Offset: 4},
+			expected:      []string{},
+			expectedTotal: 4,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, total := tc.filter.apply(states)
+			require.Equal(t, tc.expected, runtimeIDs(result))
+			require.Equal(t, tc.expectedTotal, total)
+		})
+	}
+}
@@ -18,16 +18,29 @@ type MockInventory struct {
 	ClustersNotReadyResult                []*State
 	GetResult                             *State
 	GetLatestResult                       *State
+	GetAtResult                           *State
 	GetAllResult                          []*State
+	ListResult                            []*State
 	CreateOrUpdateResult                  *State
 	MarkForDeletionResult                 *State
+	DeletionProtectedResult               bool
+	ForceTakeoverResult                   bool
+	ProvisionedResult                     map[string]bool
+	RotateKubeconfigResult                *State
+	RollbackKubeconfigResult              *State
+	ComponentImagesResult                 []*model.ComponentImagesEntity
+	SnapshotsResult                       []*model.ClusterSnapshotEntity
+	ConfigWarningsResult                  []string
 	DeleteResult                          error
 	UpdateStatusResult                    *State
 	ChangesResult                         []*StatusChange
+	StatusHistoryResult                   []*model.ClusterStatusEntity
 	RetriesCount                          int
 	DeletedStatusesWoReconciliationResult int
 	DeletedStatusesOlderThanResult        int
 	DeletedClustersOlderThanResult        int
+	DeletedResult                         []*DeletedCluster
+	PurgedResult                          []*DeletedCluster
 }
 
 func (i *MockInventory) WithTx(_ *db.TxConnection) (Inventory, error) {
@@ -46,6 +59,90 @@ func (i *MockInventory) MarkForDeletion(_ string) (*State, error) {
 	return i.MarkForDeletionResult, nil
 }
 
+func (i *MockInventory) SetDeletionProtection(_ string, protected bool) error {
+	i.DeletionProtectedResult = protected
+	return nil
+}
+
+func (i *MockInventory) IsDeletionProtected(_ string) (bool, error) {
+	return i.DeletionProtectedResult, nil
+}
+
+func (i *MockInventory) GetDeletionProtections(runtimeIDs []string) (map[string]bool, error) {
+	result := make(map[string]bool, len(runtimeIDs))
+	for _, runtimeID := range runtimeIDs {
+		result[runtimeID] = i.DeletionProtectedResult
+	}
+	return result, nil
+}
+
+func (i *MockInventory) SetForceTakeover(_ string, force bool) error {
+	i.ForceTakeoverResult = force
+	return nil
+}
+
+func (i *MockInventory) IsForceTakeover(_ string) (bool, error) {
+	return i.ForceTakeoverResult, nil
+}
+
+func (i *MockInventory) SetProvisioned(runtimeID string) error {
+	if i.ProvisionedResult == nil {
+		i.ProvisionedResult = make(map[string]bool)
+	}
+	i.ProvisionedResult[runtimeID] = true
+	return nil
+}
+
+func (i *MockInventory) GetProvisioned(_ []string) (map[string]bool, error) {
+	return i.ProvisionedResult, nil
+}
+
+func (i *MockInventory) RotateKubeconfig(_, _ string) (*State, error) {
+	return i.RotateKubeconfigResult, nil
+}
+
+func (i *MockInventory) RollbackKubeconfig(_ string) (*State, error) {
+	return i.RollbackKubeconfigResult, nil
+}
+
+func (i *MockInventory) ReleasePreviousKubeconfig(_ string, _ int64) error {
+	return nil
+}
+
+func (i *MockInventory) UpdateComponentImages(_ *model.ComponentImagesEntity) error {
+	return nil
+}
+
+func (i *MockInventory) GetComponentImages(_ string) ([]*model.ComponentImagesEntity, error) {
+	return i.ComponentImagesResult, nil
+}
+
+func (i *MockInventory) AddSnapshot(_ *model.ClusterSnapshotEntity, _ int) error {
+	return nil
+}
+
+func (i *MockInventory) GetSnapshots(_ string) ([]*model.ClusterSnapshotEntity, error) {
+	return i.SnapshotsResult, nil
+}
+
+func (i *MockInventory) UpdateConfigWarnings(_ string, _ int64, _ []string) error {
+	return nil
+}
+
+func (i *MockInventory) GetConfigWarnings(_ int64) ([]string, error) {
+	return i.ConfigWarningsResult, nil
+}
+
+func (i *MockInventory) GetConfigWarningsBatch(configVersions []int64) (map[int64][]string, error) {
+	result := make(map[int64][]string, len(configVersions))
+	if len(i.ConfigWarningsResult) > 0 {
+		for _, configVersion := range configVersions {
+			result[configVersion] = i.ConfigWarningsResult
+		}
+	}
+	return result, nil
+}
+
 func (i *MockInventory) Delete(_ string) error {
 	return i.DeleteResult
 }
@@ -58,10 +155,18 @@ func (i *MockInventory) GetLatest(_ string) (*State, error) {
 	return i.GetLatestResult, nil
 }
 
+func (i *MockInventory) GetAt(_ string, _ time.Time) (*State, error) {
+	return i.GetAtResult, nil
+}
+
 func (i *MockInventory) GetAll() ([]*State, error) {
 	return i.GetAllResult, nil
 }
 
+func (i *MockInventory) List(_ *ListFilter) ([]*State, int, error) {
+	return i.ListResult, len(i.ListResult), nil
+}
+
 func (i *MockInventory) ClustersToReconcile(_ time.Duration) ([]*State, error) {
 	return i.ClustersToReconcileResult, nil
 }
@@ -74,6 +179,17 @@ func (i *MockInventory) StatusChanges(_ string, _ time.Duration) ([]*StatusChang
 	return i.ChangesResult, nil
 }
 
+func (i *MockInventory) ListStatusChanges(_ string, _ time.Duration, filter *StatusChangeFilter) (*StatusChangePage, error) {
+	if filter == nil {
+		filter = &StatusChangeFilter{}
+	}
+	return filter.apply(i.ChangesResult), nil
+}
+
+func (i *MockInventory) StatusHistory(_ string, _ int) ([]*model.ClusterStatusEntity, error) {
+	return i.StatusHistoryResult, nil
+}
+
 type MockKubeconfigProvider struct {
 	KubeconfigResult string
 }
@@ -100,3 +216,12 @@ func (i *MockInventory) RemoveStatusesWithoutReconciliations(timeout time.Durati
 func (i *MockInventory) RemoveDeletedClustersOlderThan(deadline time.Time) (int, error) {
 	return i.DeletedClustersOlderThanResult, nil
 }
+
+func (i *MockInventory) GetDeleted(_ string) ([]*DeletedCluster, error) {
+	return i.DeletedResult, nil
+}
+
+func (i *MockInventory) Purge(deletedCluster *DeletedCluster) error {
+	i.PurgedResult = append(i.PurgedResult, deletedCluster)
+	return nil
+}
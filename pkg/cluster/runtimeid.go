@@ -0,0 +1,46 @@
+package cluster
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//runtimeIDFormat accepts UUIDs (as used by KEB) and other identifiers which are valid DNS labels
+var runtimeIDFormat = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]{0,61}[a-zA-Z0-9])?$`)
+
+//NormalizeRuntimeID returns the canonical form of a runtime ID which is used to check its uniqueness:
+//runtime IDs are case-insensitive and surrounding whitespaces are ignored
+func NormalizeRuntimeID(runtimeID string) string {
+	return strings.ToLower(strings.TrimSpace(runtimeID))
+}
+
+//ValidateRuntimeID checks the format of a runtime ID
+func ValidateRuntimeID(runtimeID string) error {
+	if runtimeID == "" {
+		return errors.New("runtime ID is undefined")
+	}
+	if !runtimeIDFormat.MatchString(runtimeID) {
+		return fmt.Errorf("runtime ID '%s' is invalid: it has to start and end with an alphanumeric character, "+
+			"can contain '-', '_' and '.' and must not be longer than 63 characters", runtimeID)
+	}
+	return nil
+}
+
+//RuntimeIDConflictError indicates that a runtime ID differs only by case or whitespaces from the ID of an existing cluster
+type RuntimeIDConflictError struct {
+	RuntimeID         string
+	ExistingRuntimeID string
+}
+
+func (e *RuntimeIDConflictError) Error() string {
+	return fmt.Sprintf("runtime ID '%s' conflicts with the existing cluster '%s' (runtime IDs are case-insensitive)",
+		e.RuntimeID, e.ExistingRuntimeID)
+}
+
+func IsRuntimeIDConflictError(err error) bool {
+	var conflictErr *RuntimeIDConflictError
+	return errors.As(err, &conflictErr)
+}
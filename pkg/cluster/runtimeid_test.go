@@ -0,0 +1,29 @@
+package cluster
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeID(t *testing.T) {
+	t.Run("Normalize runtime ID", func(t *testing.T) {
+		require.Equal(t, "abc-123", NormalizeRuntimeID(" ABC-123\t"))
+		require.Equal(t, NormalizeRuntimeID("testCluster"), NormalizeRuntimeID("TestCluster "))
+	})
+
+	t.Run("Validate runtime ID", func(t *testing.T) {
+		for _, runtimeID := range []string{"e5c6c5fd-1f3a-4a4c-9a1a-2b6e7a3f1f10", "testCluster", "test_cluster.1", "1234"} {
+			require.NoError(t, ValidateRuntimeID(runtimeID), runtimeID)
+		}
+		for _, runtimeID := range []string{"", " abc", "abc ", "-abc", "abc-", "a/b", "a b", strings.Repeat("a", 64)} {
+			require.Error(t, ValidateRuntimeID(runtimeID), runtimeID)
+		}
+	})
+
+	t.Run("Detect conflict error", func(t *testing.T) {
+		require.True(t, IsRuntimeIDConflictError(&RuntimeIDConflictError{RuntimeID: "ABC", ExistingRuntimeID: "abc"}))
+		require.False(t, IsRuntimeIDConflictError(nil))
+	})
+}
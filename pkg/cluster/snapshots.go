@@ -0,0 +1,63 @@
+package cluster
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+//AddSnapshot stores a snapshot of the facts of a cluster: only the latest snapshots (retention) of the cluster are kept
+func (i *DefaultInventory) AddSnapshot(snapshot *model.ClusterSnapshotEntity, retention int) error {
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, snapshot, i.Logger)
+		if err != nil {
+			return err
+		}
+		if err := q.Insert().Exec(); err != nil {
+			return err
+		}
+		q, err = db.NewQuery(tx, &model.ClusterSnapshotEntity{}, i.Logger)
+		if err != nil {
+			return err
+		}
+		entities, err := q.Select().
+			Where(map[string]interface{}{"RuntimeID": snapshot.RuntimeID}).
+			OrderBy(map[string]string{"ID": "DESC"}).
+			GetMany()
+		if err != nil {
+			return err
+		}
+		for idx := retention; idx < len(entities); idx++ {
+			if _, err := q.Delete().
+				Where(map[string]interface{}{"ID": entities[idx].(*model.ClusterSnapshotEntity).ID}).
+				Exec(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return err
+	}
+	i.Logger.Debugf("Inventory stored snapshot of cluster '%s' (configVersion:%d)", snapshot.RuntimeID, snapshot.ClusterConfig)
+	return nil
+}
+
+//GetSnapshots returns the retained snapshots of a cluster (latest first)
+func (i *DefaultInventory) GetSnapshots(runtimeID string) ([]*model.ClusterSnapshotEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.ClusterSnapshotEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(map[string]interface{}{"RuntimeID": runtimeID}).
+		OrderBy(map[string]string{"ID": "DESC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.ClusterSnapshotEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.ClusterSnapshotEntity))
+	}
+	return result, nil
+}
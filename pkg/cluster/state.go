@@ -16,3 +16,13 @@ func (s *State) String() string {
 	return fmt.Sprintf("State [RuntimeID=%s,ClusterVersion=%d,ConfigVersion=%d,Status=%s]",
 		s.Cluster.RuntimeID, s.Cluster.Version, s.Configuration.Version, s.Status.Status)
 }
+
+//ComponentKubeconfig returns the kubeconfig of the cluster the component is applied to: components without target
+//(or which aren't part of the configuration) use the main kubeconfig
+func (s *State) ComponentKubeconfig(component string) (string, error) {
+	var target string
+	if comp := s.Configuration.GetComponent(component); comp != nil {
+		target = comp.GetTarget()
+	}
+	return s.Cluster.TargetKubeconfig(target)
+}
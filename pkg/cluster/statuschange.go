@@ -1,9 +1,14 @@
 package cluster
 
 import (
+	"database/sql"
 	"fmt"
-	"github.com/kyma-incubator/reconciler/pkg/model"
+	"strings"
 	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
 )
 
 type StatusChange struct {
@@ -14,3 +19,223 @@ type StatusChange struct {
 func (s *StatusChange) String() string {
 	return fmt.Sprintf("StatusChange [Status=%s,Duration=%s]", s.Status.Status, s.Duration)
 }
+
+//StatusChangeFilter restricts the status changes returned by Inventory.ListStatusChanges
+type StatusChangeFilter struct {
+	//Statuses of the status changes (any status if empty)
+	Statuses []model.Status
+	//Cursor is the ID of the last status change of the previous page (0 requests the first page)
+	Cursor int64
+	//Limit is the max. number of returned status changes (0 means unlimited)
+	Limit int
+	//Ascending orders the status changes from the oldest to the latest (default is latest first)
+	Ascending bool
+}
+
+//StatusChangePage is a page of status changes
+type StatusChangePage struct {
+	StatusChanges []*StatusChange
+	//Total number of status changes matching the statuses of the filter
+	Total int
+	//Cursor of the next page (0 if it's the last page)
+	Cursor int64
+}
+
+//newStatusChangeQuery returns the conditions which select the statuses of the cluster within the offset matching the
+//statuses of the filter: the first condition restricts the statuses to the cluster and the offset
+func (i *DefaultInventory) newStatusChangeQuery(runtimeID string, offset time.Duration, filter *StatusChangeFilter) (*listQuery, error) {
+	statusCols, err := db.NewColumnHandler(&model.ClusterStatusEntity{}, i.Conn, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	intervalCond, err := (&createdIntervalFilter{
+		interval:  offset,
+		runtimeID: runtimeID,
+	}).Filter(i.Conn.Type(), statusCols)
+	if err != nil {
+		return nil, err
+	}
+	scq := &listQuery{
+		dbType:     i.Conn.Type(),
+		statusCols: statusCols,
+		statusTbl:  (&model.ClusterStatusEntity{}).Table(),
+		conds:      []string{intervalCond},
+	}
+	if len(filter.Statuses) > 0 {
+		statusCol, err := statusCols.ColumnName("Status")
+		if err != nil {
+			return nil, err
+		}
+		var statuses []interface{}
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		scq.conds = append(scq.conds, fmt.Sprintf("%s IN (%s)", statusCol, scq.placeholders(statuses)))
+	}
+	return scq, nil
+}
+
+//countStatusChanges returns the number of statuses matching the conditions
+func (i *DefaultInventory) countStatusChanges(statusTbl string, conds []string, args []interface{}) (int, error) {
+	row, err := i.Conn.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s",
+		statusTbl, strings.Join(conds, " AND ")), args...)
+	if err != nil {
+		return 0, err
+	}
+	var total int
+	if err := row.Scan(&total); err != nil {
+		return 0, errors.Wrap(err, "failed to count cluster statuses")
+	}
+	return total, nil
+}
+
+//selectStatusChanges returns the requested page of the status changes. A status change lasts until the next status
+//of the cluster (independent of the statuses of the filter) or, for the latest status, until now.
+func (i *DefaultInventory) selectStatusChanges(scq *listQuery, filter *StatusChangeFilter) (*StatusChangePage, error) {
+	cols, err := scq.columns("ID", "RuntimeID", "ClusterVersion", "ConfigVersion", "Status", "Created", "Deleted")
+	if err != nil {
+		return nil, err
+	}
+	idCol, runtimeIDCol, createdCol := cols[0], cols[1], cols[5]
+
+	conds := scq.conds
+	order, cursorOp := "DESC", "<"
+	if filter.Ascending {
+		order, cursorOp = "ASC", ">"
+	}
+	if filter.Cursor > 0 {
+		conds = append(conds, fmt.Sprintf("%s %s %s", idCol, cursorOp, scq.placeholder(filter.Cursor)))
+	}
+	var limit string
+	if filter.Limit > 0 { //the additional status indicates whether a next page exists
+		limit = fmt.Sprintf(" LIMIT %s", scq.placeholder(filter.Limit+1))
+	}
+	nextIDCol := fmt.Sprintf("(SELECT MIN(n.%s) FROM %s n WHERE n.%s=s.%s AND n.%s>s.%s)",
+		idCol, scq.statusTbl, runtimeIDCol, runtimeIDCol, idCol, idCol)
+	dataRows, err := i.Conn.Query(fmt.Sprintf("SELECT %s, %s FROM %s s WHERE %s ORDER BY %s %s%s",
+		strings.Join(cols, ", "), nextIDCol, scq.statusTbl, strings.Join(conds, " AND "), idCol, order, limit), scq.args...)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []*model.ClusterStatusEntity
+	nextIDs := make(map[int64]int64)
+	for dataRows.Next() {
+		status := &model.ClusterStatusEntity{}
+		var nextID sql.NullInt64
+		if err := dataRows.Scan(&status.ID,
+			&status.RuntimeID,
+			&status.ClusterVersion,
+			&status.ConfigVersion,
+			&status.Status,
+			&status.Created,
+			&status.Deleted,
+			&nextID); err != nil {
+			return nil, errors.Wrap(err, "failed to bind cluster statuses")
+		}
+		if nextID.Valid {
+			nextIDs[status.ID] = nextID.Int64
+		}
+		statuses = append(statuses, status)
+	}
+
+	page := &StatusChangePage{StatusChanges: []*StatusChange{}}
+	if filter.Limit > 0 && len(statuses) > filter.Limit {
+		statuses = statuses[:filter.Limit]
+		page.Cursor = statuses[len(statuses)-1].ID
+	}
+
+	nextCreated, err := i.statusesCreated(idCol, createdCol, scq.statusTbl, statuses, nextIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		duration := time.Since(status.Created)
+		if nextID, ok := nextIDs[status.ID]; ok {
+			duration = nextCreated[nextID].Sub(status.Created)
+		}
+		page.StatusChanges = append(page.StatusChanges, &StatusChange{
+			Status:   status,
+			Duration: duration,
+		})
+	}
+	return page, nil
+}
+
+//statusesCreated returns the creation times of the next statuses of the page
+func (i *DefaultInventory) statusesCreated(idCol, createdCol, statusTbl string, statuses []*model.ClusterStatusEntity,
+	nextIDs map[int64]int64) (map[int64]time.Time, error) {
+	result := make(map[int64]time.Time)
+	var ids []string
+	var args []interface{}
+	for _, status := range statuses {
+		if nextID, ok := nextIDs[status.ID]; ok {
+			args = append(args, nextID)
+			ids = append(ids, fmt.Sprintf("$%d", len(args)))
+		}
+	}
+	if len(ids) == 0 {
+		return result, nil
+	}
+	dataRows, err := i.Conn.Query(fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s IN (%s)",
+		idCol, createdCol, statusTbl, idCol, strings.Join(ids, ",")), args...)
+	if err != nil {
+		return nil, err
+	}
+	for dataRows.Next() {
+		var id int64
+		var created time.Time
+		if err := dataRows.Scan(&id, &created); err != nil {
+			return nil, errors.Wrap(err, "failed to bind creation times of cluster statuses")
+		}
+		result[id] = created
+	}
+	return result, nil
+}
+
+//apply filters the status changes (ordered by ID, latest first) and returns the requested page: it's used for
+//status changes which are held in memory
+func (f *StatusChangeFilter) apply(statusChanges []*StatusChange) *StatusChangePage {
+	var matching []*StatusChange
+	for _, statusChange := range statusChanges {
+		if f.matchesStatus(statusChange) {
+			matching = append(matching, statusChange)
+		}
+	}
+	if f.Ascending {
+		for i, j := 0, len(matching)-1; i < j; i, j = i+1, j-1 {
+			matching[i], matching[j] = matching[j], matching[i]
+		}
+	}
+
+	page := &StatusChangePage{StatusChanges: []*StatusChange{}, Total: len(matching)}
+	for _, statusChange := range matching {
+		if f.Cursor > 0 && !f.afterCursor(statusChange) {
+			continue
+		}
+		if f.Limit > 0 && len(page.StatusChanges) == f.Limit {
+			page.Cursor = page.StatusChanges[len(page.StatusChanges)-1].Status.ID
+			break
+		}
+		page.StatusChanges = append(page.StatusChanges, statusChange)
+	}
+	return page
+}
+
+func (f *StatusChangeFilter) matchesStatus(statusChange *StatusChange) bool {
+	if len(f.Statuses) == 0 {
+		return true
+	}
+	for _, status := range f.Statuses {
+		if statusChange.Status.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *StatusChangeFilter) afterCursor(statusChange *StatusChange) bool {
+	if f.Ascending {
+		return statusChange.Status.ID > f.Cursor
+	}
+	return statusChange.Status.ID < f.Cursor
+}
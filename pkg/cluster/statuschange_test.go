@@ -0,0 +1,94 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func statusChangeIDs(page *StatusChangePage) []int64 {
+	result := []int64{}
+	for _, statusChange := range page.StatusChanges {
+		result = append(result, statusChange.Status.ID)
+	}
+	return result
+}
+
+func TestStatusChangeFilter(t *testing.T) {
+	//status changes are ordered by ID (latest first)
+	var statusChanges []*StatusChange
+	for _, status := range []*model.ClusterStatusEntity{
+		{ID: 5, Status: model.ClusterStatusReady},
+		{ID: 4, Status: model.ClusterStatusReconcileError},
+		{ID: 3, Status: model.ClusterStatusReconciling},
+		{ID: 2, Status: model.ClusterStatusReconcileError},
+		{ID: 1, Status: model.ClusterStatusReconcilePending},
+	} {
+		statusChanges = append(statusChanges, &StatusChange{Status: status})
+	}
+
+	tests := []struct {
+		name           string
+		filter         *StatusChangeFilter
+		expected       []int64
+		expectedTotal  int
+		expectedCursor int64
+	}{
+		{
+			name:          "No filter",
+			filter:        &StatusChangeFilter{},
+			expected:      []int64{5, 4, 3, 2, 1},
+			expectedTotal: 5,
+		},
+		{
+			name:          "Filter by status",
+			filter:        &StatusChangeFilter{Statuses: []model.Status{model.ClusterStatusReconcileError}},
+			expected:      []int64{4, 2},
+			expectedTotal: 2,
+		},
+		{
+			name:           "First page",
+			filter:         &StatusChangeFilter{Limit: 2},
+			expected:       []int64{5, 4},
+			expectedTotal:  5,
+			expectedCursor: 4,
+		},
+		{
+			name:           "Page after cursor",
+			filter:         &StatusChangeFilter{Cursor: 4, Limit: 2},
+			expected:       []int64{3, 2},
+			expectedTotal:  5,
+			expectedCursor: 2,
+		},
+		{
+			name:          "Last page",
+			filter:        &StatusChangeFilter{Cursor: 2, Limit: 2},
+			expected:      []int64{1},
+			expectedTotal: 5,
+		},
+		{
+			name:           "Ascending order",
+			filter:         &StatusChangeFilter{Cursor: 1, Limit: 2, Ascending: true},
+			expected:       []int64{2, 3},
+			expectedTotal:  5,
+			expectedCursor: 3,
+		},
+		{
+			name:          "Filter by status in ascending order",
+			filter:        &StatusChangeFilter{Statuses: []model.Status{model.ClusterStatusReconcileError}, Ascending: true},
+			expected:      []int64{2, 4},
+			expectedTotal: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := tt.filter.apply(statusChanges)
+			require.Equal(t, tt.expected, statusChangeIDs(page))
+			require.Equal(t, tt.expectedTotal, page.Total)
+			require.Equal(t, tt.expectedCursor, page.Cursor)
+			require.Len(t, statusChanges, 5, "status changes of the inventory are not modified")
+			require.Equal(t, int64(5), statusChanges[0].Status.ID, "status changes of the inventory are not reordered")
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package cluster
+
+import "sync"
+
+//StatusListener is notified after a new status of a cluster was stored. Listeners are called synchronously and
+//must not block the inventory (e.g. by sending HTTP requests).
+type StatusListener interface {
+	OnClusterStatusUpdate(state *State)
+}
+
+//StatusListeners forwards the cluster state updates to the metrics collector and to all registered listeners.
+//Listeners can be registered after the inventory was created.
+type StatusListeners struct {
+	collector metricsCollector
+	mu        sync.RWMutex
+	listeners []StatusListener
+}
+
+func NewStatusListeners(collector metricsCollector) *StatusListeners {
+	return &StatusListeners{collector: collector}
+}
+
+//Add registers a listener
+func (l *StatusListeners) Add(listener StatusListener) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.listeners = append(l.listeners, listener)
+}
+
+func (l *StatusListeners) OnClusterStateUpdate(state *State) error {
+	l.mu.RLock()
+	listeners := l.listeners
+	l.mu.RUnlock()
+	for _, listener := range listeners {
+		listener.OnClusterStatusUpdate(state)
+	}
+	return l.collector.OnClusterStateUpdate(state)
+}
+
+//statusBufferSize is the number of status updates which are buffered per subscriber
+const statusBufferSize = 16
+
+//StatusBroadcaster forwards the status updates of clusters to the subscribers of the cluster. It receives only
+//the updates of the local inventory: subscribers have to consider that other replicas update the status as well.
+type StatusBroadcaster struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan *State]bool
+}
+
+func NewStatusBroadcaster() *StatusBroadcaster {
+	return &StatusBroadcaster{subscribers: make(map[string]map[chan *State]bool)}
+}
+
+//Subscribe returns a channel which receives the status updates of the cluster and a function which cancels the
+//subscription. Updates are dropped if the subscriber doesn't consume them fast enough.
+func (b *StatusBroadcaster) Subscribe(runtimeID string) (<-chan *State, func()) {
+	updates := make(chan *State, statusBufferSize)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers[runtimeID] == nil {
+		b.subscribers[runtimeID] = make(map[chan *State]bool)
+	}
+	b.subscribers[runtimeID][updates] = true
+
+	var once sync.Once
+	return updates, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers[runtimeID], updates)
+			if len(b.subscribers[runtimeID]) == 0 {
+				delete(b.subscribers, runtimeID)
+			}
+			close(updates)
+		})
+	}
+}
+
+func (b *StatusBroadcaster) OnClusterStatusUpdate(state *State) {
+	if state == nil || state.Status == nil {
+		return
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for updates := range b.subscribers[state.Status.RuntimeID] {
+		select {
+		case updates <- state:
+		default:
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+type testMetricsCollector struct {
+	updates int
+}
+
+func (c *testMetricsCollector) OnClusterStateUpdate(_ *State) error {
+	c.updates++
+	return nil
+}
+
+func TestStatusBroadcaster(t *testing.T) {
+	newState := func(runtimeID string, status model.Status) *State {
+		return &State{Status: &model.ClusterStatusEntity{RuntimeID: runtimeID, Status: status}}
+	}
+
+	collector := &testMetricsCollector{}
+	listeners := NewStatusListeners(collector)
+	broadcaster := NewStatusBroadcaster()
+	listeners.Add(broadcaster)
+
+	abc, cancelAbc := broadcaster.Subscribe("abc")
+	xyz, cancelXyz := broadcaster.Subscribe("xyz")
+	defer cancelXyz()
+
+	require.NoError(t, listeners.OnClusterStateUpdate(newState("abc", model.ClusterStatusReconciling)))
+	require.NoError(t, listeners.OnClusterStateUpdate(newState("abc", model.ClusterStatusReady)))
+	require.Equal(t, 2, collector.updates, "metrics collector is still notified")
+
+	require.Equal(t, model.ClusterStatusReconciling, (<-abc).Status.Status)
+	require.Equal(t, model.ClusterStatusReady, (<-abc).Status.Status)
+	require.Empty(t, xyz, "updates of other clusters are not received")
+
+	t.Run("Slow subscribers miss updates", func(t *testing.T) {
+		for i := 0; i < statusBufferSize+1; i++ {
+			broadcaster.OnClusterStatusUpdate(newState("xyz", model.ClusterStatusReconciling))
+		}
+		require.Len(t, xyz, statusBufferSize)
+	})
+
+	t.Run("Cancel subscription", func(t *testing.T) {
+		cancelAbc()
+		cancelAbc()
+		_, open := <-abc
+		require.False(t, open)
+		broadcaster.OnClusterStatusUpdate(newState("abc", model.ClusterStatusReady))
+		require.NotContains(t, broadcaster.subscribers, "abc")
+	})
+}
@@ -0,0 +1,73 @@
+package configtemplate
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+//FanOutFunc creates new configuration versions for the clusters referencing the template
+type FanOutFunc func(ctx context.Context, name string) error
+
+//FanOut executes the fan-outs of template updates in the background. Only one fan-out per template is running:
+//if the template gets updated in the meantime, it's fanned out again after the running fan-out finished.
+type FanOut struct {
+	ctx     context.Context
+	fanOut  FanOutFunc
+	logger  *zap.SugaredLogger
+	mu      sync.Mutex
+	running map[string]bool
+	pending map[string]bool
+	wg      sync.WaitGroup
+}
+
+func NewFanOut(ctx context.Context, fanOut FanOutFunc, logger *zap.SugaredLogger) *FanOut {
+	if logger == nil {
+		logger = zap.NewNop().Sugar()
+	}
+	return &FanOut{
+		ctx:     ctx,
+		fanOut:  fanOut,
+		logger:  logger,
+		running: make(map[string]bool),
+		pending: make(map[string]bool),
+	}
+}
+
+//Trigger schedules the fan-out of the template and returns immediately
+func (f *FanOut) Trigger(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.running[name] {
+		f.pending[name] = true
+		return
+	}
+	f.running[name] = true
+	f.wg.Add(1)
+	go f.run(name)
+}
+
+//Wait blocks until all triggered fan-outs are finished
+func (f *FanOut) Wait() {
+	f.wg.Wait()
+}
+
+func (f *FanOut) run(name string) {
+	defer f.wg.Done()
+	for {
+		if err := f.fanOut(f.ctx, name); err != nil {
+			f.logger.Errorf("Fan-out of configuration template '%s' failed: %s", name, err)
+		}
+
+		f.mu.Lock()
+		if !f.pending[name] || f.ctx.Err() != nil {
+			delete(f.running, name)
+			delete(f.pending, name)
+			f.mu.Unlock()
+			return
+		}
+		delete(f.pending, name)
+		f.mu.Unlock()
+	}
+}
@@ -0,0 +1,64 @@
+package configtemplate
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFanOut(t *testing.T) {
+	t.Run("Updates during a running fan-out are fanned out again", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var mu sync.Mutex
+		calls := map[string]int{}
+
+		fanOut := NewFanOut(context.Background(), func(ctx context.Context, name string) error {
+			mu.Lock()
+			calls[name]++
+			first := calls[name] == 1
+			mu.Unlock()
+			if first && name == "default" {
+				close(started)
+				<-release
+			}
+			return nil
+		}, nil)
+
+		fanOut.Trigger("default")
+		<-started
+		//both updates arrive while the first fan-out is running: they are fanned out once afterwards
+		fanOut.Trigger("default")
+		fanOut.Trigger("default")
+		fanOut.Trigger("other")
+		close(release)
+		fanOut.Wait()
+
+		require.Equal(t, map[string]int{"default": 2, "other": 1}, calls)
+	})
+
+	t.Run("Pending fan-outs are dropped when the context is closed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		started := make(chan struct{})
+		calls := 0
+
+		fanOut := NewFanOut(ctx, func(ctx context.Context, name string) error {
+			calls++
+			if calls == 1 {
+				close(started)
+				<-ctx.Done()
+			}
+			return ctx.Err()
+		}, nil)
+
+		fanOut.Trigger("default")
+		<-started
+		fanOut.Trigger("default")
+		cancel()
+		fanOut.Wait()
+
+		require.Equal(t, 1, calls)
+	})
+}
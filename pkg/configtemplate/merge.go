@@ -0,0 +1,63 @@
+package configtemplate
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+//Merge applies the overrides of a cluster to the components of a template:
+// - configuration entries of an overriding component replace the template entries with the same key
+// - non-empty URL, namespace and version of an overriding component replace the template values
+// - components which aren't part of the template are appended
+//The order of the template components is kept.
+func Merge(template []*keb.Component, overrides []keb.Component) []keb.Component {
+	overridesByName := make(map[string]keb.Component, len(overrides))
+	for _, override := range overrides {
+		overridesByName[override.Component] = override
+	}
+
+	result := make([]keb.Component, 0, len(template)+len(overrides))
+	merged := make(map[string]bool, len(template))
+	for _, comp := range template {
+		if comp == nil {
+			continue
+		}
+		mergedComp := *comp
+		if override, ok := overridesByName[comp.Component]; ok {
+			mergedComp = mergeComponent(mergedComp, override)
+		}
+		result = append(result, mergedComp)
+		merged[comp.Component] = true
+	}
+	for _, override := range overrides {
+		if !merged[override.Component] {
+			result = append(result, override)
+		}
+	}
+	return result
+}
+
+func mergeComponent(comp, override keb.Component) keb.Component {
+	if override.URL != "" {
+		comp.URL = override.URL
+	}
+	if override.Namespace != "" {
+		comp.Namespace = override.Namespace
+	}
+	if override.Version != "" {
+		comp.Version = override.Version
+	}
+	comp.Configuration = append([]keb.Configuration{}, comp.Configuration...) //don't modify the template
+	keyIdx := make(map[string]int, len(comp.Configuration))
+	for idx, cfg := range comp.Configuration {
+		keyIdx[cfg.Key] = idx
+	}
+	for _, cfg := range override.Configuration {
+		if idx, ok := keyIdx[cfg.Key]; ok {
+			comp.Configuration[idx] = cfg
+			continue
+		}
+		keyIdx[cfg.Key] = len(comp.Configuration)
+		comp.Configuration = append(comp.Configuration, cfg)
+	}
+	return comp
+}
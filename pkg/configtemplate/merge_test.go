@@ -0,0 +1,74 @@
+package configtemplate
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	template := []*keb.Component{
+		{
+			Component: "istio",
+			Namespace: "istio-system",
+			Version:   "1.0",
+			Configuration: []keb.Configuration{
+				{Key: "replicas", Value: 1},
+				{Key: "tracing", Value: false},
+			},
+		},
+		{
+			Component: "serverless",
+			Namespace: "kyma-system",
+		},
+	}
+
+	t.Run("Template without overrides", func(t *testing.T) {
+		got := Merge(template, nil)
+		require.Len(t, got, 2)
+		require.Equal(t, *template[0], got[0])
+		require.Equal(t, *template[1], got[1])
+	})
+
+	t.Run("Overrides replace configuration entries and append components", func(t *testing.T) {
+		got := Merge(template, []keb.Component{
+			{
+				Component: "monitoring",
+				Namespace: "kyma-system",
+			},
+			{
+				Component: "istio",
+				Version:   "2.0",
+				Configuration: []keb.Configuration{
+					{Key: "replicas", Value: 3},
+					{Key: "mtls", Value: true},
+				},
+			},
+		})
+		require.Equal(t, []keb.Component{
+			{
+				Component: "istio",
+				Namespace: "istio-system",
+				Version:   "2.0",
+				Configuration: []keb.Configuration{
+					{Key: "replicas", Value: 3},
+					{Key: "tracing", Value: false},
+					{Key: "mtls", Value: true},
+				},
+			},
+			{
+				Component: "serverless",
+				Namespace: "kyma-system",
+			},
+			{
+				Component: "monitoring",
+				Namespace: "kyma-system",
+			},
+		}, got)
+
+		//template is not modified
+		require.Len(t, template[0].Configuration, 2)
+		require.Equal(t, 1, template[0].Configuration[0].Value)
+	})
+}
@@ -0,0 +1,227 @@
+package configtemplate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
+)
+
+//InUseError indicates that a template can't be deleted because clusters are still referencing it
+type InUseError struct {
+	Name     string
+	Clusters []string
+}
+
+func (e *InUseError) Error() string {
+	return fmt.Sprintf("configuration template '%s' is referenced by %d clusters (e.g. '%s')",
+		e.Name, len(e.Clusters), e.Clusters[0])
+}
+
+func IsInUseError(err error) bool {
+	var inUseErr *InUseError
+	return errors.As(err, &inUseErr)
+}
+
+//Repository stores the configuration templates and the clusters which are referencing them
+type Repository struct {
+	*repository.Repository
+}
+
+func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{repo}, nil
+}
+
+//CreateOrUpdate stores the components of a template (the components of an existing template are replaced)
+func (tr *Repository) CreateOrUpdate(name string, components []*keb.Component) (*model.ConfigTemplateEntity, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("name of configuration template is undefined")
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("configuration template '%s' has no components", name)
+	}
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		entity, err := tr.get(tx, name)
+		if err != nil && !repository.IsNotFoundError(err) {
+			return nil, err
+		}
+		exists := err == nil
+		if !exists {
+			entity = &model.ConfigTemplateEntity{Name: name}
+		}
+		entity.Components = components
+		entity.Updated = time.Now().UTC()
+
+		q, err := db.NewQuery(tx, entity, tr.Logger)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			err = q.Update().Where(map[string]interface{}{"Name": name}).Exec()
+		} else {
+			err = q.Insert().Exec()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return tr.get(tx, name)
+	}
+	entity, err := tr.TransactionalResult(dbOps)
+	if err != nil {
+		tr.Logger.Errorf("ConfigTemplateRepository failed to store template '%s': %s", name, err)
+		return nil, err
+	}
+	return entity.(*model.ConfigTemplateEntity), nil
+}
+
+//Get returns a template
+func (tr *Repository) Get(name string) (*model.ConfigTemplateEntity, error) {
+	return tr.get(tr.Conn, name)
+}
+
+func (tr *Repository) get(conn db.Connection, name string) (*model.ConfigTemplateEntity, error) {
+	q, err := db.NewQuery(conn, &model.ConfigTemplateEntity{}, tr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{"Name": name}
+	entity, err := q.Select().
+		Where(whereCond).
+		GetOne()
+	if err != nil {
+		return nil, tr.MapError(err, &model.ConfigTemplateEntity{}, whereCond)
+	}
+	return entity.(*model.ConfigTemplateEntity), nil
+}
+
+//GetAll returns all templates ordered by their names
+func (tr *Repository) GetAll() ([]*model.ConfigTemplateEntity, error) {
+	q, err := db.NewQuery(tr.Conn, &model.ConfigTemplateEntity{}, tr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		OrderBy(map[string]string{"Name": "ASC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.ConfigTemplateEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.ConfigTemplateEntity))
+	}
+	return result, nil
+}
+
+//Delete drops a template which isn't referenced by any cluster
+func (tr *Repository) Delete(name string) error {
+	dbOps := func(tx *db.TxConnection) error {
+		if _, err := tr.get(tx, name); err != nil {
+			return err
+		}
+		refs, err := tr.references(tx, name)
+		if err != nil {
+			return err
+		}
+		if len(refs) > 0 {
+			inUseErr := &InUseError{Name: name}
+			for _, ref := range refs {
+				inUseErr.Clusters = append(inUseErr.Clusters, ref.RuntimeID)
+			}
+			return inUseErr
+		}
+		q, err := db.NewQuery(tx, &model.ConfigTemplateEntity{}, tr.Logger)
+		if err != nil {
+			return err
+		}
+		_, err = q.Delete().Where(map[string]interface{}{"Name": name}).Exec()
+		return err
+	}
+	if err := tr.Transactional(dbOps); err != nil {
+		if !repository.IsNotFoundError(err) && !IsInUseError(err) {
+			tr.Logger.Errorf("ConfigTemplateRepository failed to delete template '%s': %s", name, err)
+		}
+		return err
+	}
+	return nil
+}
+
+//Assign stores the reference of a cluster to a template together with the components of the cluster which
+//override the template (an existing reference of the cluster is replaced)
+func (tr *Repository) Assign(runtimeID, name string, overrides []*keb.Component) error {
+	dbOps := func(tx *db.TxConnection) error {
+		if err := tr.unassign(tx, runtimeID); err != nil {
+			return err
+		}
+		if overrides == nil {
+			overrides = []*keb.Component{}
+		}
+		q, err := db.NewQuery(tx, &model.ConfigTemplateRefEntity{
+			RuntimeID: runtimeID,
+			Template:  name,
+			Overrides: overrides,
+			Updated:   time.Now().UTC(),
+		}, tr.Logger)
+		if err != nil {
+			return err
+		}
+		return q.Insert().Exec()
+	}
+	if err := tr.Transactional(dbOps); err != nil {
+		tr.Logger.Errorf("ConfigTemplateRepository failed to assign cluster '%s' to template '%s': %s",
+			runtimeID, name, err)
+		return err
+	}
+	return nil
+}
+
+//Unassign drops the reference of a cluster to its template (no-op if the cluster isn't referencing a template)
+func (tr *Repository) Unassign(runtimeID string) error {
+	if err := tr.unassign(tr.Conn, runtimeID); err != nil {
+		tr.Logger.Errorf("ConfigTemplateRepository failed to unassign cluster '%s': %s", runtimeID, err)
+		return err
+	}
+	return nil
+}
+
+func (tr *Repository) unassign(conn db.Connection, runtimeID string) error {
+	q, err := db.NewQuery(conn, &model.ConfigTemplateRefEntity{}, tr.Logger)
+	if err != nil {
+		return err
+	}
+	_, err = q.Delete().Where(map[string]interface{}{"RuntimeID": runtimeID}).Exec()
+	return err
+}
+
+//References returns the clusters which are referencing a template
+func (tr *Repository) References(name string) ([]*model.ConfigTemplateRefEntity, error) {
+	return tr.references(tr.Conn, name)
+}
+
+func (tr *Repository) references(conn db.Connection, name string) ([]*model.ConfigTemplateRefEntity, error) {
+	q, err := db.NewQuery(conn, &model.ConfigTemplateRefEntity{}, tr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(map[string]interface{}{"Template": name}).
+		OrderBy(map[string]string{"RuntimeID": "ASC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.ConfigTemplateRefEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.ConfigTemplateRefEntity))
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package configtemplate
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repo, err := NewRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+
+	name := uuid.NewString()
+	runtimeID := uuid.NewString()
+	defer func() {
+		q, err := db.NewQuery(repo.Conn, &model.ConfigTemplateRefEntity{}, repo.Logger)
+		require.NoError(t, err)
+		_, err = q.Delete().Where(map[string]interface{}{"Template": name}).Exec()
+		require.NoError(t, err)
+		q, err = db.NewQuery(repo.Conn, &model.ConfigTemplateEntity{}, repo.Logger)
+		require.NoError(t, err)
+		_, err = q.Delete().Where(map[string]interface{}{"Name": name}).Exec()
+		require.NoError(t, err)
+	}()
+
+	t.Run("Create and update template", func(t *testing.T) {
+		_, err := repo.CreateOrUpdate(name, nil)
+		require.Error(t, err)
+
+		template, err := repo.CreateOrUpdate(name, []*keb.Component{
+			{Component: "istio", Namespace: "istio-system"},
+		})
+		require.NoError(t, err)
+		require.Len(t, template.Components, 1)
+
+		template, err = repo.CreateOrUpdate(name, []*keb.Component{
+			{Component: "istio", Namespace: "istio-system"},
+			{Component: "serverless", Namespace: "kyma-system"},
+		})
+		require.NoError(t, err)
+		require.Len(t, template.Components, 2)
+
+		got, err := repo.Get(name)
+		require.NoError(t, err)
+		require.True(t, template.Equal(got))
+
+		all, err := repo.GetAll()
+		require.NoError(t, err)
+		require.Contains(t, func() []string {
+			var names []string
+			for _, tpl := range all {
+				names = append(names, tpl.Name)
+			}
+			return names
+		}(), name)
+	})
+
+	t.Run("Get unknown template", func(t *testing.T) {
+		_, err := repo.Get(uuid.NewString())
+		require.True(t, repository.IsNotFoundError(err))
+	})
+
+	t.Run("Referenced template can't be deleted", func(t *testing.T) {
+		overrides := []*keb.Component{{Component: "istio", Version: "2.0"}}
+		require.NoError(t, repo.Assign(runtimeID, name, overrides))
+		//assigning again replaces the reference
+		require.NoError(t, repo.Assign(runtimeID, name, overrides))
+
+		refs, err := repo.References(name)
+		require.NoError(t, err)
+		require.Len(t, refs, 1)
+		require.Equal(t, runtimeID, refs[0].RuntimeID)
+		require.Equal(t, overrides, refs[0].Overrides)
+
+		err = repo.Delete(name)
+		require.True(t, IsInUseError(err))
+
+		require.NoError(t, repo.Unassign(runtimeID))
+		refs, err = repo.References(name)
+		require.NoError(t, err)
+		require.Empty(t, refs)
+
+		require.NoError(t, repo.Delete(name))
+		_, err = repo.Get(name)
+		require.True(t, repository.IsNotFoundError(err))
+	})
+}
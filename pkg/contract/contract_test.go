@@ -0,0 +1,149 @@
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/redact"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, schedulingID string, recorder *Recorder) *httptest.Server {
+	router := mux.NewRouter()
+	if recorder != nil {
+		router.Use(recorder.Middleware)
+	}
+	router.HandleFunc("/v1/clusters", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NotContains(t, string(body), redact.Mask)
+		w.WriteHeader(http.StatusOK)
+		_, err = fmt.Fprintf(w, `{"runtimeID":"abc","schedulingID":"%s","statusURL":"/v1/operations/%s"}`,
+			schedulingID, schedulingID)
+		require.NoError(t, err)
+	}).Methods(http.MethodPost)
+	router.HandleFunc("/v1/operations/{schedulingID}", func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["schedulingID"] != schedulingID {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(`{"status":"ready","components":[{"name":"istio","retries":1}]}`))
+		require.NoError(t, err)
+	}).Methods(http.MethodGet)
+	return httptest.NewServer(router)
+}
+
+func TestContract(t *testing.T) {
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir, logger.NewTestLogger(t))
+	require.NoError(t, err)
+
+	server := newTestServer(t, "scheduling-id-1", recorder)
+	resp, err := http.Post(server.URL+"/v1/clusters", "application/json",
+		strings.NewReader(`{"runtimeID":"abc","kubeconfig":"apiVersion: v1"}`))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	resp, err = http.Get(server.URL + "/v1/operations/scheduling-id-1")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	server.Close()
+
+	//interactions are recorded in memory until they are flushed
+	interactions, err := Load(dir)
+	require.NoError(t, err)
+	require.Empty(t, interactions)
+	require.NoError(t, recorder.Flush())
+
+	t.Run("Golden files are sanitized", func(t *testing.T) {
+		data, err := ioutil.ReadFile(filepath.Join(dir, GoldenFile(http.MethodPost, "/v1/clusters")))
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "apiVersion: v1")
+
+		interactions, err := Load(dir)
+		require.NoError(t, err)
+		require.Len(t, interactions, 2)
+		require.Equal(t, "/v1/clusters", interactions[0].Route)
+		require.Equal(t, "/v1/operations/{schedulingID}", interactions[1].Route)
+		require.Equal(t, "/v1/operations/scheduling-id-1", interactions[1].Path)
+	})
+
+	t.Run("Verify compatible build", func(t *testing.T) {
+		interactions, err := Load(dir)
+		require.NoError(t, err)
+
+		//new build returns different scheduling IDs: they get replaced in subsequent requests
+		server := newTestServer(t, "scheduling-id-2", nil)
+		defer server.Close()
+		mismatches, err := NewVerifier(server.URL, "apiVersion: v1", nil).Verify(context.Background(), interactions)
+		require.NoError(t, err)
+		require.Empty(t, mismatches)
+	})
+
+	t.Run("Verify incompatible build", func(t *testing.T) {
+		interactions, err := Load(dir)
+		require.NoError(t, err)
+
+		router := mux.NewRouter()
+		router.HandleFunc("/v1/clusters", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		})
+		server := httptest.NewServer(router)
+		defer server.Close()
+		mismatches, err := NewVerifier(server.URL, "", nil).Verify(context.Background(), interactions)
+		require.NoError(t, err)
+		require.Len(t, mismatches, 2)
+		require.Contains(t, mismatches[0].Reason, "expected status code 200 but got 400")
+	})
+}
+
+func TestCompareShape(t *testing.T) {
+	decodeJSON := func(data string) interface{} {
+		var result interface{}
+		require.NoError(t, json.Unmarshal([]byte(data), &result))
+		return result
+	}
+
+	tests := []struct {
+		name       string
+		expected   string
+		actual     string
+		violations []string
+	}{
+		{
+			name:     "Different values",
+			expected: `{"id":"a","count":1,"items":[{"ok":true}]}`,
+			actual:   `{"id":"b","count":2,"items":[{"ok":false},{"ok":true}]}`,
+		},
+		{
+			name:     "Additional fields are allowed",
+			expected: `{"id":"a"}`,
+			actual:   `{"id":"b","new":1}`,
+		},
+		{
+			name:       "Missing field",
+			expected:   `{"id":"a","count":1}`,
+			actual:     `{"id":"b"}`,
+			violations: []string{"$.count: field is missing"},
+		},
+		{
+			name:       "Different types",
+			expected:   `{"id":"a","items":[{"ok":true}]}`,
+			actual:     `{"id":1,"items":[{"ok":"yes"}]}`,
+			violations: []string{"$.id: expected string but got number", "$.items[0].ok: expected boolean but got string"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.violations, compareShape("$", decodeJSON(tc.expected), decodeJSON(tc.actual)))
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package contract
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/redact"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+//maxInteractionsPerRoute limits the size of the golden files: only the latest interactions are kept
+const maxInteractionsPerRoute = 25
+
+//flushInterval is the interval in which changed golden files are written
+const flushInterval = 5 * time.Second
+
+var fileNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+//Interaction is a sanitized request/response pair of the mothership API
+type Interaction struct {
+	Sequence int64           `json:"sequence"` //order of the interaction within the recording
+	Route    string          `json:"route"`
+	Method   string          `json:"method"`
+	Path     string          `json:"path"` //incl. query
+	Request  json.RawMessage `json:"request,omitempty"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+//Recorder captures the interactions of each API route in a golden file. Interactions are recorded in memory:
+//the changed golden files are written by Flush.
+type Recorder struct {
+	dir          string
+	logger       *zap.SugaredLogger
+	mutex        sync.Mutex
+	flushMutex   sync.Mutex //serializes the writes of the golden files
+	sequence     int64
+	interactions map[string][]*Interaction //interactions per golden file
+	changed      map[string]bool           //golden files which weren't written since their latest interaction
+}
+
+func NewRecorder(dir string, logger *zap.SugaredLogger) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "failed to create directory for golden files '%s'", dir)
+	}
+	recorder := &Recorder{
+		dir:          dir,
+		logger:       logger,
+		interactions: make(map[string][]*Interaction),
+		changed:      make(map[string]bool),
+	}
+	//continue an existing recording
+	interactions, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, interaction := range interactions {
+		fileName := GoldenFile(interaction.Method, interaction.Route)
+		recorder.interactions[fileName] = append(recorder.interactions[fileName], interaction)
+		if interaction.Sequence > recorder.sequence {
+			recorder.sequence = interaction.Sequence
+		}
+	}
+	return recorder, nil
+}
+
+//Middleware records the request and response of each API call
+func (r *Recorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var reqBody []byte
+		if req.Body != nil {
+			var err error
+			if reqBody, err = ioutil.ReadAll(req.Body); err != nil {
+				r.logger.Warnf("Contract recorder failed to read request body of '%s': %s", req.URL.Path, err)
+			}
+			req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+		}
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, req)
+
+		route, err := mux.CurrentRoute(req).GetPathTemplate()
+		if err != nil {
+			route = req.URL.Path
+		}
+		r.record(&Interaction{
+			Route:    route,
+			Method:   req.Method,
+			Path:     req.URL.RequestURI(),
+			Request:  sanitize(reqBody),
+			Status:   recorder.status,
+			Response: sanitize(recorder.body.Bytes()),
+		})
+	})
+}
+
+func (r *Recorder) record(interaction *Interaction) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.sequence++
+	interaction.Sequence = r.sequence
+
+	fileName := GoldenFile(interaction.Method, interaction.Route)
+	interactions := append(r.interactions[fileName], interaction)
+	if len(interactions) > maxInteractionsPerRoute {
+		interactions = interactions[len(interactions)-maxInteractionsPerRoute:]
+	}
+	r.interactions[fileName] = interactions
+	r.changed[fileName] = true
+}
+
+//Run writes the changed golden files in an interval until the context gets closed (they are written a last time
+//afterwards)
+func (r *Recorder) Run(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				if err := r.Flush(); err != nil {
+					r.logger.Warnf("Contract recorder failed to store golden files: %s", err)
+				}
+				return
+			case <-ticker.C:
+				if err := r.Flush(); err != nil {
+					r.logger.Warnf("Contract recorder failed to store golden files: %s", err)
+				}
+			}
+		}
+	}()
+}
+
+//Flush writes the golden files which changed since the previous flush
+func (r *Recorder) Flush() error {
+	r.flushMutex.Lock()
+	defer r.flushMutex.Unlock()
+
+	//marshal the changed files while holding the lock but write them without blocking the recording
+	r.mutex.Lock()
+	files := make(map[string][]byte, len(r.changed))
+	for fileName := range r.changed {
+		data, err := json.MarshalIndent(r.interactions[fileName], "", "  ")
+		if err != nil {
+			r.mutex.Unlock()
+			return err
+		}
+		files[fileName] = data
+	}
+	r.changed = make(map[string]bool)
+	r.mutex.Unlock()
+
+	for fileName, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(r.dir, fileName), data, 0600); err != nil {
+			r.mutex.Lock()
+			r.changed[fileName] = true //retried with the next flush
+			r.mutex.Unlock()
+			return errors.Wrapf(err, "failed to write golden file '%s'", fileName)
+		}
+	}
+	return nil
+}
+
+//GoldenFile returns the name of the file which stores the interactions of a route
+func GoldenFile(method, route string) string {
+	name := strings.Trim(fileNameInvalidChars.ReplaceAllString(route, "_"), "_")
+	return strings.ToUpper(method) + "_" + name + ".json"
+}
+
+//Load reads all interactions stored in the golden files of a directory (ordered by their sequence)
+func Load(dir string) ([]*Interaction, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var result []*Interaction
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read golden file '%s'", file)
+		}
+		var interactions []*Interaction
+		if err := json.Unmarshal(data, &interactions); err != nil {
+			return nil, errors.Wrapf(err, "golden file '%s' is invalid", file)
+		}
+		result = append(result, interactions...)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Sequence < result[j].Sequence
+	})
+	return result, nil
+}
+
+//sanitize removes sensitive data (e.g. kubeconfigs) from a body: non-JSON bodies are stored as JSON string
+func sanitize(body []byte) json.RawMessage {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return redact.JSON(body)
+	}
+	data, err := json.Marshal(redact.String(string(body)))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+//responseRecorder keeps a copy of the response
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+//Flush supports streamed responses (e.g. server-sent events)
+func (r *responseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,239 @@
+package contract
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/redact"
+)
+
+//Mismatch describes an interaction whose replay doesn't fulfil the recorded contract
+type Mismatch struct {
+	Interaction *Interaction
+	Reason      string
+}
+
+func (m *Mismatch) String() string {
+	return fmt.Sprintf("%s %s (sequence %d): %s", m.Interaction.Method, m.Interaction.Route, m.Interaction.Sequence, m.Reason)
+}
+
+//Verifier replays recorded interactions against a running mothership. A replayed interaction fulfils the
+//contract if the status code is equal and the response has the same JSON structure as the recorded
+//response (values can differ, additional fields are allowed).
+type Verifier struct {
+	target     string
+	kubeconfig string
+	client     *http.Client
+	//values (e.g. scheduling IDs) which differ between recording and replay: they get replaced in subsequent requests
+	substitutions map[string]string
+}
+
+//NewVerifier creates a verifier for the mothership listening on the target URL. The kubeconfig replaces
+//redacted kubeconfigs in the recorded requests.
+func NewVerifier(target, kubeconfig string, client *http.Client) *Verifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Verifier{
+		target:        strings.TrimSuffix(target, "/"),
+		kubeconfig:    kubeconfig,
+		client:        client,
+		substitutions: make(map[string]string),
+	}
+}
+
+//Verify replays the interactions in their recorded order and returns all contract violations
+func (v *Verifier) Verify(ctx context.Context, interactions []*Interaction) ([]*Mismatch, error) {
+	var mismatches []*Mismatch
+	for _, interaction := range interactions {
+		status, body, err := v.replay(ctx, interaction)
+		if err != nil {
+			return mismatches, err
+		}
+		if status != interaction.Status {
+			mismatches = append(mismatches, &Mismatch{
+				Interaction: interaction,
+				Reason:      fmt.Sprintf("expected status code %d but got %d", interaction.Status, status),
+			})
+			continue
+		}
+		expected, actual, err := decode(interaction.Response, body)
+		if err != nil {
+			mismatches = append(mismatches, &Mismatch{Interaction: interaction, Reason: err.Error()})
+			continue
+		}
+		for _, violation := range compareShape("$", expected, actual) {
+			mismatches = append(mismatches, &Mismatch{Interaction: interaction, Reason: violation})
+		}
+		v.collectSubstitutions(expected, actual)
+	}
+	return mismatches, nil
+}
+
+func (v *Verifier) replay(ctx context.Context, interaction *Interaction) (int, []byte, error) {
+	var reqBody []byte
+	if len(interaction.Request) > 0 {
+		reqBody = v.substitute(v.restoreKubeconfig(interaction.Request))
+	}
+	req, err := http.NewRequestWithContext(ctx, interaction.Method,
+		v.target+string(v.substitute([]byte(interaction.Path))), bytes.NewReader(reqBody))
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(reqBody) > 0 {
+		req.Header.Set("content-type", "application/json")
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to replay %s %s: %s", interaction.Method, interaction.Path, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+//restoreKubeconfig replaces the redacted kubeconfig of a recorded request
+func (v *Verifier) restoreKubeconfig(body []byte) []byte {
+	if v.kubeconfig == "" {
+		return body
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return body
+	}
+	if payload["kubeconfig"] != redact.Mask {
+		return body
+	}
+	payload["kubeconfig"] = v.kubeconfig
+	result, err := json.Marshal(payload)
+	if err != nil {
+		return body
+	}
+	return result
+}
+
+func (v *Verifier) substitute(data []byte) []byte {
+	for recorded, replayed := range v.substitutions {
+		data = bytes.ReplaceAll(data, []byte(recorded), []byte(replayed))
+	}
+	return data
+}
+
+//collectSubstitutions remembers string values which differ between the recorded and the replayed response
+func (v *Verifier) collectSubstitutions(expected, actual interface{}) {
+	switch expectedValue := expected.(type) {
+	case map[string]interface{}:
+		actualMap, ok := actual.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for key, value := range expectedValue {
+			v.collectSubstitutions(value, actualMap[key])
+		}
+	case []interface{}:
+		actualSlice, ok := actual.([]interface{})
+		if !ok {
+			return
+		}
+		for i := 0; i < len(expectedValue) && i < len(actualSlice); i++ {
+			v.collectSubstitutions(expectedValue[i], actualSlice[i])
+		}
+	case string:
+		actualValue, ok := actual.(string)
+		//only identifiers are relevant: short values (e.g. status names) would cause wrong replacements
+		if ok && len(expectedValue) >= 8 && !strings.ContainsAny(expectedValue, " \n") &&
+			expectedValue != actualValue && expectedValue != redact.Mask {
+			v.substitutions[expectedValue] = actualValue
+		}
+	}
+}
+
+func decode(expectedBody, actualBody []byte) (interface{}, interface{}, error) {
+	var expected, actual interface{}
+	if len(expectedBody) > 0 {
+		if err := json.Unmarshal(expectedBody, &expected); err != nil {
+			return nil, nil, fmt.Errorf("recorded response is invalid: %s", err)
+		}
+	}
+	actualBody = bytes.TrimSpace(actualBody)
+	if len(actualBody) > 0 {
+		if err := json.Unmarshal(actualBody, &actual); err != nil {
+			//non-JSON responses are recorded as JSON string
+			actual = string(actualBody)
+		}
+	}
+	return expected, actual, nil
+}
+
+//compareShape verifies that the actual value has the same JSON structure as the expected value
+func compareShape(path string, expected, actual interface{}) []string {
+	if expected == nil {
+		return nil //null values carry no type information
+	}
+	switch expectedValue := expected.(type) {
+	case map[string]interface{}:
+		actualMap, ok := actual.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected object but got %s", path, jsonType(actual))}
+		}
+		keys := make([]string, 0, len(expectedValue))
+		for key := range expectedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var violations []string
+		for _, key := range keys {
+			actualValue, ok := actualMap[key]
+			if !ok {
+				violations = append(violations, fmt.Sprintf("%s.%s: field is missing", path, key))
+				continue
+			}
+			violations = append(violations, compareShape(path+"."+key, expectedValue[key], actualValue)...)
+		}
+		return violations
+	case []interface{}:
+		actualSlice, ok := actual.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: expected array but got %s", path, jsonType(actual))}
+		}
+		if len(expectedValue) == 0 || len(actualSlice) == 0 {
+			return nil
+		}
+		return compareShape(path+"[0]", expectedValue[0], actualSlice[0])
+	default:
+		if jsonType(expected) != jsonType(actual) && actual != nil {
+			return []string{fmt.Sprintf("%s: expected %s but got %s", path, jsonType(expected), jsonType(actual))}
+		}
+		return nil
+	}
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,225 @@
+package cors
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	allowAllOrigins = "*"
+
+	headerOrigin         = "Origin"
+	headerVary           = "Vary"
+	headerRequestMethod  = "Access-Control-Request-Method"
+	headerRequestHeaders = "Access-Control-Request-Headers"
+	headerAllowOrigin    = "Access-Control-Allow-Origin"
+	headerAllowMethods   = "Access-Control-Allow-Methods"
+	headerAllowHeaders   = "Access-Control-Allow-Headers"
+	headerExposeHeaders  = "Access-Control-Expose-Headers"
+	headerMaxAge         = "Access-Control-Max-Age"
+
+	//exposedHeaders are the response headers of the API which scripts of allowed origins can read
+	exposedHeaders = "ETag, Location, Retry-After, Sunset, X-Request-ID"
+)
+
+var (
+	//DefaultAllowedMethods are allowed if no methods are configured
+	DefaultAllowedMethods = []string{
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+	}
+	//DefaultAllowedHeaders are allowed if no headers are configured
+	DefaultAllowedHeaders = []string{
+		"Authorization",
+		"Content-Type",
+		"If-Match",
+		"Idempotency-Key",
+	}
+)
+
+//Config defines which browser origins can call the API (cross-origin resource sharing)
+type Config struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	MaxAge         time.Duration
+}
+
+//Enabled returns true if at least one origin is allowed to call the API
+func (c Config) Enabled() bool {
+	return len(c.AllowedOrigins) > 0
+}
+
+//Validate verifies that each origin is either '*' or a scheme with a host (and optional port)
+func (c Config) Validate() error {
+	if !c.Enabled() {
+		if len(c.AllowedMethods) > 0 || len(c.AllowedHeaders) > 0 {
+			return errors.New("CORS allowed origins must be set if allowed methods or headers are defined")
+		}
+		return nil
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin == allowAllOrigins {
+			continue
+		}
+		originURL, err := url.Parse(origin)
+		if err != nil {
+			return errors.Wrapf(err, "CORS allowed origin '%s' is invalid", origin)
+		}
+		if (originURL.Scheme != "http" && originURL.Scheme != "https") || originURL.Host == "" ||
+			strings.Contains(originURL.Host, allowAllOrigins) || originURL.User != nil ||
+			strings.TrimSuffix(originURL.Path, "/") != "" || originURL.RawQuery != "" || originURL.Fragment != "" {
+			return fmt.Errorf("CORS allowed origin '%s' has to consist of scheme, host and optional port "+
+				"(e.g. 'https://dashboard.example.com')", origin)
+		}
+	}
+	for _, method := range c.AllowedMethods {
+		if method == "" || strings.ContainsAny(method, " ,") {
+			return fmt.Errorf("CORS allowed method '%s' is invalid", method)
+		}
+	}
+	for _, header := range c.AllowedHeaders {
+		if header == "" || strings.ContainsAny(header, " ,") {
+			return fmt.Errorf("CORS allowed header '%s' is invalid", header)
+		}
+	}
+	if c.MaxAge < 0 {
+		return errors.New("CORS max age cannot be < 0")
+	}
+	return nil
+}
+
+//Handler adds the CORS headers to the responses of allowed origins and answers preflight requests of browsers
+type Handler struct {
+	allowAll     bool
+	origins      map[string]bool
+	methods      map[string]bool
+	headers      map[string]bool
+	allowMethods string
+	allowHeaders string
+	maxAge       string
+}
+
+//NewHandler returns the CORS handler or nil if no origin is allowed to call the API.
+//Methods and headers fall back to the defaults if none are configured.
+func NewHandler(c Config) (*Handler, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	methods := c.AllowedMethods
+	if len(methods) == 0 {
+		methods = DefaultAllowedMethods
+	}
+	headers := c.AllowedHeaders
+	if len(headers) == 0 {
+		headers = DefaultAllowedHeaders
+	}
+
+	h := &Handler{
+		origins: make(map[string]bool, len(c.AllowedOrigins)),
+		methods: make(map[string]bool, len(methods)),
+		headers: make(map[string]bool, len(headers)),
+		maxAge:  strconv.Itoa(int(c.MaxAge.Seconds())),
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin == allowAllOrigins {
+			h.allowAll = true
+			continue
+		}
+		h.origins[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+	allowMethods := make([]string, 0, len(methods))
+	for _, method := range methods {
+		method = strings.ToUpper(method)
+		h.methods[method] = true
+		allowMethods = append(allowMethods, method)
+	}
+	for _, header := range headers {
+		h.headers[strings.ToLower(header)] = true
+	}
+	h.allowMethods = strings.Join(allowMethods, ", ")
+	h.allowHeaders = strings.Join(headers, ", ")
+	return h, nil
+}
+
+//allowOrigin sets the allowed origin of the response and returns false if the origin isn't allowed
+func (h *Handler) allowOrigin(w http.ResponseWriter, origin string) bool {
+	//responses differ per origin: caches must not serve them to other origins
+	vary(w, headerOrigin)
+	if h.allowAll {
+		w.Header().Set(headerAllowOrigin, allowAllOrigins)
+		return true
+	}
+	if !h.origins[strings.ToLower(origin)] {
+		return false
+	}
+	w.Header().Set(headerAllowOrigin, origin)
+	return true
+}
+
+//Middleware adds the CORS headers to the responses of cross-origin requests. Requests of origins which aren't
+//allowed are still processed: the browser withholds the response from the calling script.
+func (h *Handler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get(headerOrigin); origin != "" && h.allowOrigin(w, origin) {
+			w.Header().Set(headerExposeHeaders, exposedHeaders)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+//Preflight answers the OPTIONS requests browsers send before a cross-origin request which isn't a simple request
+func (h *Handler) Preflight(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get(headerOrigin)
+	method := r.Header.Get(headerRequestMethod)
+	if origin == "" || method == "" { //not a preflight request: the API doesn't serve OPTIONS requests
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	vary(w, headerRequestMethod)
+	vary(w, headerRequestHeaders)
+	if !h.allowOrigin(w, origin) || !h.methods[strings.ToUpper(method)] || !h.allowedHeaders(r) {
+		w.Header().Del(headerAllowOrigin)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	w.Header().Set(headerAllowMethods, h.allowMethods)
+	w.Header().Set(headerAllowHeaders, h.allowHeaders)
+	w.Header().Set(headerMaxAge, h.maxAge)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//allowedHeaders checks whether all headers the browser wants to send are allowed
+func (h *Handler) allowedHeaders(r *http.Request) bool {
+	for _, requestHeaders := range r.Header.Values(headerRequestHeaders) {
+		for _, header := range strings.Split(requestHeaders, ",") {
+			header = strings.ToLower(strings.TrimSpace(header))
+			if header != "" && !h.headers[header] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//vary adds the request header to the Vary header of the response unless it's already listed
+func vary(w http.ResponseWriter, header string) {
+	for _, value := range w.Header().Values(headerVary) {
+		if value == header {
+			return
+		}
+	}
+	w.Header().Add(headerVary, header)
+}
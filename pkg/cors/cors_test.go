@@ -0,0 +1,196 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		require.False(t, Config{}.Enabled())
+		require.NoError(t, Config{}.Validate())
+		require.Error(t, Config{AllowedMethods: []string{http.MethodGet}}.Validate())
+	})
+
+	t.Run("Valid origins", func(t *testing.T) {
+		cfg := Config{AllowedOrigins: []string{"*", "https://dashboard.example.com", "http://localhost:3000/"}}
+		require.True(t, cfg.Enabled())
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("Invalid origins", func(t *testing.T) {
+		for _, origin := range []string{
+			"dashboard.example.com",
+			"ftp://dashboard.example.com",
+			"https://dashboard.example.com/path",
+			"https://user@dashboard.example.com",
+			"https://dashboard.example.com?query=1",
+			"https://*.example.com",
+		} {
+			require.Error(t, Config{AllowedOrigins: []string{origin}}.Validate(), origin)
+		}
+	})
+
+	t.Run("Invalid methods, headers and max age", func(t *testing.T) {
+		origins := []string{"https://dashboard.example.com"}
+		require.Error(t, Config{AllowedOrigins: origins, AllowedMethods: []string{"GET, POST"}}.Validate())
+		require.Error(t, Config{AllowedOrigins: origins, AllowedHeaders: []string{""}}.Validate())
+		require.Error(t, Config{AllowedOrigins: origins, MaxAge: -time.Second}.Validate())
+	})
+}
+
+func TestHandler(t *testing.T) {
+	newRequest := func(method, origin string, headers map[string]string) *http.Request {
+		req := httptest.NewRequest(method, "/v1/clusters/abc/status", nil)
+		if origin != "" {
+			req.Header.Set(headerOrigin, origin)
+		}
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		return req
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		handler, err := NewHandler(Config{})
+		require.NoError(t, err)
+		require.Nil(t, handler)
+	})
+
+	t.Run("Invalid config", func(t *testing.T) {
+		_, err := NewHandler(Config{AllowedOrigins: []string{"dashboard"}})
+		require.Error(t, err)
+	})
+
+	handler, err := NewHandler(Config{
+		AllowedOrigins: []string{"https://dashboard.example.com"},
+		MaxAge:         10 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	t.Run("Middleware", func(t *testing.T) {
+		var called int
+		next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			called++
+			w.WriteHeader(http.StatusOK)
+		})
+
+		//allowed origin
+		rec := httptest.NewRecorder()
+		handler.Middleware(next).ServeHTTP(rec, newRequest(http.MethodGet, "https://dashboard.example.com", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "https://dashboard.example.com", rec.Header().Get(headerAllowOrigin))
+		require.Equal(t, exposedHeaders, rec.Header().Get(headerExposeHeaders))
+		require.Equal(t, headerOrigin, rec.Header().Get(headerVary))
+
+		//other origin
+		rec = httptest.NewRecorder()
+		handler.Middleware(next).ServeHTTP(rec, newRequest(http.MethodGet, "https://evil.example.com", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Empty(t, rec.Header().Get(headerAllowOrigin))
+
+		//same-origin or non-browser request
+		rec = httptest.NewRecorder()
+		handler.Middleware(next).ServeHTTP(rec, newRequest(http.MethodGet, "", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Empty(t, rec.Header().Get(headerAllowOrigin))
+		require.Empty(t, rec.Header().Get(headerVary))
+
+		require.Equal(t, 3, called)
+	})
+
+	t.Run("Preflight", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			origin   string
+			headers  map[string]string
+			expected int
+		}{
+			{
+				name:     "Allowed method and headers",
+				origin:   "https://dashboard.example.com",
+				headers:  map[string]string{headerRequestMethod: "put", headerRequestHeaders: "authorization, content-type"},
+				expected: http.StatusNoContent,
+			},
+			{
+				name:     "Allowed method without headers",
+				origin:   "https://dashboard.example.com",
+				headers:  map[string]string{headerRequestMethod: http.MethodDelete},
+				expected: http.StatusNoContent,
+			},
+			{
+				name:     "Origin not allowed",
+				origin:   "https://evil.example.com",
+				headers:  map[string]string{headerRequestMethod: http.MethodGet},
+				expected: http.StatusForbidden,
+			},
+			{
+				name:     "Method not allowed",
+				origin:   "https://dashboard.example.com",
+				headers:  map[string]string{headerRequestMethod: http.MethodConnect},
+				expected: http.StatusForbidden,
+			},
+			{
+				name:     "Header not allowed",
+				origin:   "https://dashboard.example.com",
+				headers:  map[string]string{headerRequestMethod: http.MethodGet, headerRequestHeaders: "X-Custom"},
+				expected: http.StatusForbidden,
+			},
+			{
+				name:     "Not a preflight request",
+				origin:   "https://dashboard.example.com",
+				expected: http.StatusMethodNotAllowed,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				rec := httptest.NewRecorder()
+				//preflight requests pass the middleware as well
+				handler.Middleware(http.HandlerFunc(handler.Preflight)).
+					ServeHTTP(rec, newRequest(http.MethodOptions, tc.origin, tc.headers))
+				require.Equal(t, tc.expected, rec.Code)
+				if tc.expected == http.StatusForbidden {
+					require.Empty(t, rec.Header().Get(headerAllowOrigin))
+				}
+				if tc.expected != http.StatusNoContent {
+					require.Empty(t, rec.Header().Get(headerAllowMethods))
+					return
+				}
+				require.Equal(t, tc.origin, rec.Header().Get(headerAllowOrigin))
+				require.Equal(t, "GET, POST, PUT, PATCH, DELETE", rec.Header().Get(headerAllowMethods))
+				require.Equal(t, "Authorization, Content-Type, If-Match, Idempotency-Key", rec.Header().Get(headerAllowHeaders))
+				require.Equal(t, "600", rec.Header().Get(headerMaxAge))
+				require.Equal(t, []string{headerOrigin, headerRequestMethod, headerRequestHeaders}, rec.Header().Values(headerVary))
+			})
+		}
+	})
+
+	t.Run("All origins", func(t *testing.T) {
+		handler, err := NewHandler(Config{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"get"},
+			AllowedHeaders: []string{"Authorization"},
+			MaxAge:         time.Minute,
+		})
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		handler.Preflight(rec, newRequest(http.MethodOptions, "https://any.example.com", map[string]string{
+			headerRequestMethod: http.MethodGet,
+		}))
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		require.Equal(t, "*", rec.Header().Get(headerAllowOrigin))
+		require.Equal(t, "GET", rec.Header().Get(headerAllowMethods))
+		require.Equal(t, "60", rec.Header().Get(headerMaxAge))
+
+		rec = httptest.NewRecorder()
+		handler.Preflight(rec, newRequest(http.MethodOptions, "https://any.example.com", map[string]string{
+			headerRequestMethod: http.MethodPost,
+		}))
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
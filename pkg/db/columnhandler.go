@@ -19,6 +19,8 @@ const (
 	dbTagReadOnly string = "readOnly"
 	dbTagNotNull  string = "notNull"
 	dbTagEncrypt  string = "encrypt"
+	dbTagEnvelope string = "envelope" //encrypt with a data encryption key which is stored in the column (envelope encryption)
+	dbTagColumn   string = "column"   //explicit column name ('column=<name>'), the snake case of the field name is used otherwise
 )
 
 type column struct {
@@ -26,6 +28,7 @@ type column struct {
 	readOnly bool
 	notNull  bool
 	encrypt  bool
+	envelope bool
 	field    *structs.Field
 	value    interface{}
 }
@@ -57,10 +60,11 @@ func NewColumnHandler(entity DatabaseEntity, conn Connection, logger *zap.Sugare
 	//add columns to column handler instance
 	for _, field := range fields {
 		col := &column{
-			name:     strcase.ToSnake(field.Name()),
+			name:     columnName(field),
 			readOnly: hasTag(field, dbTagReadOnly),
 			notNull:  hasTag(field, dbTagNotNull),
-			encrypt:  hasTag(field, dbTagEncrypt),
+			encrypt:  hasTag(field, dbTagEncrypt) || hasTag(field, dbTagEnvelope),
+			envelope: hasTag(field, dbTagEnvelope),
 			field:    field,
 			value:    marshalledValues[field.Name()],
 		}
@@ -71,6 +75,15 @@ func NewColumnHandler(entity DatabaseEntity, conn Connection, logger *zap.Sugare
 	return colHdlr, nil
 }
 
+func columnName(field *structs.Field) string {
+	for _, t := range strings.Split(field.Tag(dbTag), ",") {
+		if keyValue := strings.SplitN(strings.TrimSpace(t), "=", 2); len(keyValue) == 2 && keyValue[0] == dbTagColumn {
+			return strings.TrimSpace(keyValue[1])
+		}
+	}
+	return strcase.ToSnake(field.Name())
+}
+
 func hasTag(field *structs.Field, tag string) bool {
 	tags := strings.Split(field.Tag(dbTag), ",")
 	for _, t := range tags {
@@ -273,6 +286,9 @@ func (ch *ColumnHandler) serializeValue(col *column) (string, error) {
 	default:
 		value = fmt.Sprintf("%v", col.value)
 	}
+	if col.envelope {
+		return ch.encryptor.EncryptEnvelope(value)
+	}
 	if col.encrypt {
 		return ch.encryptor.Encrypt(value)
 	}
@@ -96,6 +96,16 @@ func (s *DbTestSuite) TestColumnHandler() {
 		require.Equal(t, "col_2", colNameStr)
 	})
 
+	t.Run("Get explicit column name", func(t *testing.T) {
+		colHdr, err := NewColumnHandler(&renameMe{}, s.TxConnection(), testLogger)
+		require.NoError(t, err)
+
+		colName, err := colHdr.ColumnName("KeyID")
+		require.NoError(t, err)
+		require.Equal(t, "encryption_key", colName)
+		require.True(t, colHdr.columns[0].notNull)
+	})
+
 	t.Run("Get column names as CSV", func(t *testing.T) {
 		require.ElementsMatch(t, []string{"col_1", "col_2", "col_3"}, splitAndTrimCsv(colHdr.ColumnNamesCsv(false)))
 		require.ElementsMatch(t, []string{"col_1", "col_3"}, splitAndTrimCsv(colHdr.ColumnNamesCsv(true)))
@@ -201,3 +211,27 @@ func (fake *validateMe) Equal(_ DatabaseEntity) bool {
 func (fake *validateMe) Marshaller() *EntityMarshaller {
 	return NewEntityMarshaller(&fake)
 }
+
+type renameMe struct {
+	KeyID string `db:"notNull,column=encryption_key"`
+}
+
+func (fake *renameMe) String() string {
+	return "I'm just used for testing explicit column names"
+}
+
+func (fake *renameMe) New() DatabaseEntity {
+	return &renameMe{}
+}
+
+func (fake *renameMe) Table() string {
+	return "renameMe"
+}
+
+func (fake *renameMe) Equal(_ DatabaseEntity) bool {
+	return false
+}
+
+func (fake *renameMe) Marshaller() *EntityMarshaller {
+	return NewEntityMarshaller(&fake)
+}
@@ -18,12 +18,21 @@ import (
 const keyIDLength = 15
 const KeyLength = 32
 
+//envelopePrefix marks data which was encrypted with a data encryption key (DEK). The DEK is stored next to the
+//data and is encrypted with the key encryption key (KEK) whose ID follows the prefix:
+//	env1:<KEK-ID>:<encrypted DEK>:<encrypted data>
+const envelopePrefix = "env1:"
+
 type Encryptor struct {
 	keyID [16]byte
 	aead  cipher.AEAD
+	//previous contains AEADs of former keys (indexed by key ID) which are only used for decryption
+	previous map[string]cipher.AEAD
 }
 
-func NewEncryptor(key string) (*Encryptor, error) {
+//NewEncryptor creates an encryptor which encrypts with the given key. Previous keys are only used to decrypt data
+//which was encrypted before the key was rotated.
+func NewEncryptor(key string, previousKeys ...string) (*Encryptor, error) {
 	if len(key) == 0 {
 		return nil, fmt.Errorf("cannot create new encryptor instance because encryption key was an empty string")
 	}
@@ -33,10 +42,23 @@ func NewEncryptor(key string) (*Encryptor, error) {
 		return nil, err
 	}
 
-	return &Encryptor{
-		aead:  aead,
-		keyID: md5.Sum([]byte(key)), //nolint: gosec //using MD5 just for generating a checksum of the key
-	}, nil
+	encryptor := &Encryptor{
+		aead:     aead,
+		keyID:    md5.Sum([]byte(key)), //nolint: gosec //using MD5 just for generating a checksum of the key
+		previous: make(map[string]cipher.AEAD, len(previousKeys)),
+	}
+	for _, previousKey := range previousKeys {
+		previousAEAD, err := newAEAD(previousKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid previous encryption key")
+		}
+		encryptor.previous[checksumKeyID(previousKey)] = previousAEAD
+	}
+	return encryptor, nil
+}
+
+func checksumKeyID(key string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(key)))[:keyIDLength] //nolint: gosec //using MD5 just for generating a checksum of the key
 }
 
 //NewEncryptionKey generates a random 32 byte key for AES-256
@@ -64,28 +86,50 @@ func (e *Encryptor) KeyID() string {
 }
 
 func (e *Encryptor) Encrypt(data string) (string, error) {
-	nonce := make([]byte, e.aead.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+	enc, err := seal(e.aead, []byte(data))
+	if err != nil {
 		return "", err
 	}
-	enc := e.aead.Seal(nonce, nonce, []byte(data), nil)
 	return fmt.Sprintf("%s%x", e.KeyID(), enc), nil //add keyID as prefix to the encrypted data
 }
 
+//EncryptEnvelope encrypts the data with a random data encryption key which gets encrypted with the current key
+func (e *Encryptor) EncryptEnvelope(data string) (string, error) {
+	dek, err := NewEncryptionKey()
+	if err != nil {
+		return "", err
+	}
+	dekAEAD, err := newAEAD(dek)
+	if err != nil {
+		return "", err
+	}
+	encData, err := seal(dekAEAD, []byte(data))
+	if err != nil {
+		return "", err
+	}
+	encDEK, err := seal(e.aead, []byte(dek))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%s:%x:%x", envelopePrefix, e.KeyID(), encDEK, encData), nil
+}
+
+//Decrypt decrypts data encrypted by Encrypt or EncryptEnvelope using the current or a previous key
 func (e *Encryptor) Decrypt(encData string) (string, error) {
 	if !e.Decryptable(encData) {
 		return "", fmt.Errorf("data cannot be decrypted because encryption key does not match")
 	}
+	if IsEnvelope(encData) {
+		return e.decryptEnvelope(encData)
+	}
 
-	enc, err := hex.DecodeString(strings.TrimPrefix(encData, e.KeyID())) //remove keyID from encrypted data
+	keyID := EncryptionKeyID(encData)
+	enc, err := hex.DecodeString(strings.TrimPrefix(encData, keyID)) //remove keyID from encrypted data
 	if err != nil {
 		return "", fmt.Errorf("failed to decode HEX string to bytes")
 	}
 
-	nonceSize := e.aead.NonceSize()
-	nonce, cipherText := enc[:nonceSize], enc[nonceSize:]
-
-	data, err := e.aead.Open(nil, nonce, cipherText, nil)
+	data, err := open(e.keyAEAD(keyID), enc)
 	if err != nil {
 		return "", err
 	}
@@ -93,9 +137,92 @@ func (e *Encryptor) Decrypt(encData string) (string, error) {
 	return string(data), nil
 }
 
+func (e *Encryptor) decryptEnvelope(encData string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(encData, envelopePrefix), ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("envelope encrypted data is malformed")
+	}
+	encDEK, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("failed to decode HEX string of data encryption key to bytes")
+	}
+	enc, err := hex.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("failed to decode HEX string to bytes")
+	}
+
+	dek, err := open(e.keyAEAD(parts[0]), encDEK)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decrypt data encryption key")
+	}
+	dekAEAD, err := newAEAD(string(dek))
+	if err != nil {
+		return "", err
+	}
+	data, err := open(dekAEAD, enc)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (e *Encryptor) keyAEAD(keyID string) cipher.AEAD {
+	if keyID == e.KeyID() {
+		return e.aead
+	}
+	return e.previous[keyID]
+}
+
+func seal(aead cipher.AEAD, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+func open(aead cipher.AEAD, enc []byte) ([]byte, error) {
+	nonceSize := aead.NonceSize()
+	if len(enc) < nonceSize {
+		return nil, fmt.Errorf("encrypted data is too short")
+	}
+	nonce, cipherText := enc[:nonceSize], enc[nonceSize:]
+	return aead.Open(nil, nonce, cipherText, nil)
+}
+
 //Decryptable verifies whether the encrypted data can be decrypted by this Encryptor instance
 func (e *Encryptor) Decryptable(encData string) bool {
-	return strings.HasPrefix(encData, e.KeyID()) //KeyID prefix of encrypted data has to match with current KeyID
+	keyID := EncryptionKeyID(encData)
+	if keyID == "" {
+		return false
+	}
+	_, isPrevious := e.previous[keyID]
+	return keyID == e.KeyID() || isPrevious //KeyID prefix of encrypted data has to match with a known KeyID
+}
+
+//IsEnvelope returns true if the data was encrypted by EncryptEnvelope
+func IsEnvelope(encData string) bool {
+	return strings.HasPrefix(encData, envelopePrefix)
+}
+
+//EnvelopePrefix returns the prefix of all data which was encrypted by EncryptEnvelope with the key
+func EnvelopePrefix(keyID string) string {
+	return fmt.Sprintf("%s%s:", envelopePrefix, keyID)
+}
+
+//EncryptionKeyID returns the ID of the key which was used to encrypt the data
+func EncryptionKeyID(encData string) string {
+	if IsEnvelope(encData) {
+		envelope := strings.TrimPrefix(encData, envelopePrefix)
+		if idx := strings.Index(envelope, ":"); idx > 0 {
+			return envelope[:idx]
+		}
+		return ""
+	}
+	if len(encData) < keyIDLength {
+		return ""
+	}
+	return encData[:keyIDLength]
 }
 
 func readKeyFile(encKeyFile string) (string, error) {
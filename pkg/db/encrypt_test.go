@@ -72,6 +72,67 @@ func TestEncryptor(t *testing.T) {
 		require.Equal(t, decData1, decData2)
 	})
 
+	t.Run("Envelope encrypt and decrypt", func(t *testing.T) {
+		enc := newEncryptor(t)
+
+		encData, err := enc.EncryptEnvelope(data)
+		require.NoError(t, err)
+		require.True(t, IsEnvelope(encData))
+		require.Equal(t, enc.KeyID(), EncryptionKeyID(encData))
+		require.True(t, enc.Decryptable(encData))
+		require.False(t, newEncryptor(t).Decryptable(encData))
+
+		decData, err := enc.Decrypt(encData)
+		require.NoError(t, err)
+		require.Equal(t, data, decData)
+
+		//each envelope uses its own data encryption key
+		encData2, err := enc.EncryptEnvelope(data)
+		require.NoError(t, err)
+		require.NotEqual(t, encData, encData2)
+	})
+
+	t.Run("Decrypt with previous keys", func(t *testing.T) {
+		oldKey, err := NewEncryptionKey()
+		require.NoError(t, err)
+		newKey, err := NewEncryptionKey()
+		require.NoError(t, err)
+
+		oldEnc, err := NewEncryptor(oldKey)
+		require.NoError(t, err)
+		encData, err := oldEnc.Encrypt(data)
+		require.NoError(t, err)
+		encEnvelope, err := oldEnc.EncryptEnvelope(data)
+		require.NoError(t, err)
+
+		rotatedEnc, err := NewEncryptor(newKey, oldKey)
+		require.NoError(t, err)
+		for _, enc := range []string{encData, encEnvelope} {
+			require.True(t, rotatedEnc.Decryptable(enc))
+			decData, err := rotatedEnc.Decrypt(enc)
+			require.NoError(t, err)
+			require.Equal(t, data, decData)
+		}
+
+		//new data is encrypted with the new key
+		encData, err = rotatedEnc.EncryptEnvelope(data)
+		require.NoError(t, err)
+		require.NotEqual(t, oldEnc.KeyID(), EncryptionKeyID(encData))
+		require.False(t, oldEnc.Decryptable(encData))
+	})
+
+	t.Run("Works not with invalid previous key", func(t *testing.T) {
+		key, err := NewEncryptionKey()
+		require.NoError(t, err)
+		_, err = NewEncryptor(key, "abc123!")
+		require.Error(t, err)
+	})
+
+	t.Run("Malformed envelope", func(t *testing.T) {
+		enc := newEncryptor(t)
+		_, err := enc.Decrypt(envelopePrefix + enc.KeyID() + ":abc")
+		require.Error(t, err)
+	})
 }
 
 func TestReadKeyFile(t *testing.T) {
@@ -1,6 +1,9 @@
 package db
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type InvalidEntityError struct {
 	errorMsg string
@@ -20,3 +23,14 @@ func IsInvalidEntityError(err error) bool {
 	_, ok := err.(*InvalidEntityError)
 	return ok
 }
+
+//IsUniqueConstraintError returns true if a statement was rejected because it violates a unique constraint
+//(e.g. a concurrent transaction inserted the same primary key)
+func IsUniqueConstraintError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "violates unique constraint") || //Postgres
+		strings.Contains(msg, "UNIQUE constraint failed") //SQLite
+}
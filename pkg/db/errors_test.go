@@ -0,0 +1,17 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsUniqueConstraintError(t *testing.T) {
+	require.False(t, IsUniqueConstraintError(nil))
+	require.False(t, IsUniqueConstraintError(errors.New("could not serialize access due to concurrent update")))
+	require.True(t, IsUniqueConstraintError(errors.Wrap(
+		errors.New(`pq: duplicate key value violates unique constraint "inventory_runtime_ids_pk"`), "insert failed")))
+	require.True(t, IsUniqueConstraintError(
+		errors.New("UNIQUE constraint failed: inventory_runtime_ids.normalized_runtime_id")))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/viper"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -20,6 +21,10 @@ func NewConnectionFactory(configFile string, migrate bool, debug bool) (Connecti
 	if err != nil {
 		return nil, err
 	}
+	prevEncKeys, err := readPreviousEncryptionKeys()
+	if err != nil {
+		return nil, err
+	}
 
 	dbToUse := viper.GetString("db.driver")
 	blockQueries := viper.GetBool("db.blockQueries")
@@ -28,6 +33,7 @@ func NewConnectionFactory(configFile string, migrate bool, debug bool) (Connecti
 	switch dbToUse {
 	case "postgres":
 		connFact := createPostgresConnectionFactory(encKey, debug, blockQueries, logQueries)
+		connFact.previousEncryptionKeys = prevEncKeys
 		return connFact, connFact.Init(migrate)
 
 	case "sqlite":
@@ -35,6 +41,7 @@ func NewConnectionFactory(configFile string, migrate bool, debug bool) (Connecti
 		if err != nil {
 			return nil, errors.Wrap(err, "error creating sqliteConnectionFactory")
 		}
+		connFact.previousEncryptionKeys = prevEncKeys
 		return connFact, connFact.Init(migrate)
 
 	default:
@@ -163,6 +170,36 @@ func readEncryptionKey() (string, error) {
 	return readKeyFile(encKeyFile)
 }
 
+//readPreviousEncryptionKeys returns the keys which were used before the encryption key was rotated:
+//they are only used to decrypt data which wasn't re-encrypted with the current key yet
+func readPreviousEncryptionKeys() ([]string, error) {
+	encKeyFiles := viper.GetStringSlice("db.encryption.previousKeyFiles")
+	for idx, encKeyFile := range encKeyFiles {
+		if !filepath.IsAbs(encKeyFile) {
+			//define absolute path relative to Config-file directory
+			encKeyFiles[idx] = filepath.Join(filepath.Dir(viper.ConfigFileUsed()), encKeyFile)
+		}
+	}
+
+	//overwrite encKeyFiles if env-var if defined
+	if viper.IsSet("DATABASE_ENCRYPTION_PREVIOUS_KEYFILES") {
+		encKeyFiles = strings.Split(viper.GetString("DATABASE_ENCRYPTION_PREVIOUS_KEYFILES"), ",")
+	}
+
+	var encKeys []string
+	for _, encKeyFile := range encKeyFiles {
+		if encKeyFile = strings.TrimSpace(encKeyFile); encKeyFile == "" {
+			continue
+		}
+		encKey, err := readKeyFile(encKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		encKeys = append(encKeys, encKey)
+	}
+	return encKeys, nil
+}
+
 func createPostgresConnectionFactory(encKey string, debug bool, blockQueries, logQueries bool) *postgresConnectionFactory {
 
 	env := getPostgresEnvironment()
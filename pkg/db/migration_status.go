@@ -0,0 +1,88 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+//schemaMigrationsTable is the table in which the migrator stores the schema version of the database
+const schemaMigrationsTable = "schema_migrations"
+
+var upMigrationFile = regexp.MustCompile(`^(\d+)_.+\.up\.sql$`)
+
+//MigrationStatus compares the schema version of a database with the migrations of the migrations directory
+type MigrationStatus struct {
+	//Version is the schema version of the database (0 if no migration was applied yet)
+	Version uint
+	//Dirty indicates a migration which failed and requires a manual fix
+	Dirty bool
+	//Latest is the version of the latest migration in the migrations directory
+	Latest uint
+}
+
+//Pending checks whether migrations still have to be applied to the database
+func (s *MigrationStatus) Pending() bool {
+	return s.Dirty || s.Version < s.Latest
+}
+
+func (s *MigrationStatus) String() string {
+	return fmt.Sprintf("MigrationStatus [Version=%d,Dirty=%t,Latest=%d]", s.Version, s.Dirty, s.Latest)
+}
+
+//LatestMigration returns the version of the latest up-migration in the migrations directory
+func LatestMigration(migrationsDir string) (uint, error) {
+	files, err := ioutil.ReadDir(migrationsDir)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read migrations directory '%s'", migrationsDir)
+	}
+	var latest uint64
+	for _, file := range files {
+		match := upMigrationFile.FindStringSubmatch(file.Name())
+		if file.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil {
+			return 0, errors.Wrapf(err, "version of migration '%s' is invalid", file.Name())
+		}
+		if version > latest {
+			latest = version
+		}
+	}
+	return uint(latest), nil
+}
+
+//GetMigrationStatus returns the migration status of a Postgres database
+func GetMigrationStatus(conn Connection, migrationsDir string) (*MigrationStatus, error) {
+	if conn.Type() != Postgres {
+		return nil, fmt.Errorf("migration status is only tracked for '%s' databases but got '%s'", Postgres, conn.Type())
+	}
+	latest, err := LatestMigration(migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+	status := &MigrationStatus{Latest: latest}
+	row, err := conn.QueryRow(fmt.Sprintf("SELECT version, dirty FROM %s", schemaMigrationsTable))
+	if err != nil {
+		return nil, err
+	}
+	var version int64
+	if err := row.Scan(&version, &status.Dirty); err != nil {
+		if errors.Is(err, sql.ErrNoRows) { //no migration was applied yet
+			return status, nil
+		}
+		return nil, errors.Wrap(err, "failed to retrieve schema version of database")
+	}
+	status.Version = uint(version)
+	return status, nil
+}
+
+//MigrationsDir returns the configured directory of the Postgres migrations
+func MigrationsDir() string {
+	return getPostgresEnvironment().migrationsDir
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestMigration(t *testing.T) {
+	t.Run("Latest up-migration", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, file := range []string{
+			"000001_init.up.sql",
+			"000001_init.down.sql",
+			"000012_add_table.up.sql",
+			"000013_add_column.down.sql", //incomplete migration is ignored
+			"README.md",
+		} {
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, file), []byte{}, 0600))
+		}
+		latest, err := LatestMigration(dir)
+		require.NoError(t, err)
+		require.Equal(t, uint(12), latest)
+	})
+
+	t.Run("Default migrations", func(t *testing.T) {
+		latest, err := LatestMigration(DefaultMigrations())
+		require.NoError(t, err)
+		upMigrations, err := filepath.Glob(filepath.Join(DefaultMigrations(), "*.up.sql"))
+		require.NoError(t, err)
+		require.Equal(t, uint(len(upMigrations)), latest, "migration versions are consecutive")
+	})
+
+	t.Run("Missing directory", func(t *testing.T) {
+		_, err := LatestMigration(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+	})
+}
+
+func TestMigrationStatus(t *testing.T) {
+	require.False(t, (&MigrationStatus{Version: 12, Latest: 12}).Pending())
+	require.True(t, (&MigrationStatus{Version: 11, Latest: 12}).Pending())
+	require.True(t, (&MigrationStatus{Version: 12, Dirty: true, Latest: 12}).Pending())
+}
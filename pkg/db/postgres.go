@@ -55,10 +55,10 @@ type postgresConnection struct {
 	logger    *zap.SugaredLogger
 }
 
-func newPostgresConnection(db *sql.DB, encryptionKey string, debug bool, blockQueries bool) (*postgresConnection, error) {
+func newPostgresConnection(db *sql.DB, encryptionKey string, previousEncryptionKeys []string, debug bool, blockQueries bool) (*postgresConnection, error) {
 	logger := log.NewLogger(debug)
 
-	encryptor, err := NewEncryptor(encryptionKey)
+	encryptor, err := NewEncryptor(encryptionKey, previousEncryptionKeys...)
 	if err != nil {
 		return nil, err
 	}
@@ -173,6 +173,8 @@ type postgresConnectionFactory struct {
 	blockQueries  bool
 	logQueries    bool
 
+	previousEncryptionKeys []string
+
 	maxOpenConns    int
 	maxIdleConns    int
 	connMaxLifetime time.Duration
@@ -225,7 +227,7 @@ func (pcf *postgresConnectionFactory) NewConnection() (Connection, error) {
 		return nil, err
 	}
 
-	return newPostgresConnection(db, pcf.encryptionKey, pcf.logQueries, pcf.blockQueries)
+	return newPostgresConnection(db, pcf.encryptionKey, pcf.previousEncryptionKeys, pcf.logQueries, pcf.blockQueries)
 }
 
 func (pcf *postgresConnectionFactory) checkPostgresIsolationLevel() error {
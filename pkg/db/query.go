@@ -9,6 +9,13 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	//TimeFormat is the format of timestamps which are compared with timestamp columns in query conditions
+	TimeFormat = "2006-01-02 15:04:05.000"
+	//TimeFormatMicros is the format of timestamps which are compared with timestamp columns in microsecond precision
+	TimeFormatMicros = "2006-01-02 15:04:05.000000"
+)
+
 type Query struct {
 	Conn          Connection
 	entity        DatabaseEntity
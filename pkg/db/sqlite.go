@@ -24,10 +24,10 @@ type sqliteConnection struct {
 	logger    *zap.SugaredLogger
 }
 
-func newSqliteConnection(db *sql.DB, encKey string, debug bool, blockQueries bool) (*sqliteConnection, error) {
+func newSqliteConnection(db *sql.DB, encKey string, prevEncKeys []string, debug bool, blockQueries bool) (*sqliteConnection, error) {
 	logger := log.NewLogger(debug)
 
-	encryptor, err := NewEncryptor(encKey)
+	encryptor, err := NewEncryptor(encKey, prevEncKeys...)
 	if err != nil {
 		return nil, err
 	}
@@ -132,6 +132,8 @@ type sqliteConnectionFactory struct {
 	encryptionKey string
 	blockQueries  bool
 	logQueries    bool
+
+	previousEncryptionKeys []string
 }
 
 func (scf *sqliteConnectionFactory) Init(_ bool) error {
@@ -175,7 +177,7 @@ func (scf *sqliteConnectionFactory) NewConnection() (Connection, error) {
 		return nil, err
 	}
 
-	return newSqliteConnection(db, scf.encryptionKey, scf.logQueries, scf.blockQueries) //connection ready to use
+	return newSqliteConnection(db, scf.encryptionKey, scf.previousEncryptionKeys, scf.logQueries, scf.blockQueries) //connection ready to use
 }
 
 func (scf *sqliteConnectionFactory) resetFile() error {
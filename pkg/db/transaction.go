@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"gorm.io/gorm"
@@ -19,12 +20,24 @@ const txMaxJitter = 350
 const txMinJitter = 25
 
 func TransactionResult(conn Connection, dbOps func(tx *TxConnection) (interface{}, error), logger *zap.SugaredLogger) (interface{}, error) {
+	return TransactionResultWithContext(context.Background(), conn, dbOps, logger)
+}
+
+//TransactionResultWithContext executes the DB operations within a transaction like TransactionResult but stops
+//retrying colliding transactions when the context gets closed
+func TransactionResultWithContext(ctx context.Context, conn Connection, dbOps func(tx *TxConnection) (interface{}, error), logger *zap.SugaredLogger) (interface{}, error) {
 	var result interface{}
 	var err error
 	var allErr error
 
 	txCtxID := uuid.NewString()
 	for retries := 0; retries < txMaxRetries; retries++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if allErr == nil {
+				return result, ctxErr
+			}
+			return result, errors.Wrap(ctxErr, allErr.Error())
+		}
 		result, err = execTransaction(conn, dbOps, logger)
 		if err == nil {
 			if retries > 0 {
@@ -56,7 +69,10 @@ func TransactionResult(conn Connection, dbOps func(tx *TxConnection) (interface{
 				txCtxID,
 				conn.ID(),
 				delay.Milliseconds())
-			time.Sleep(delay)
+			select {
+			case <-ctx.Done():
+			case <-time.After(delay):
+			}
 			continue
 		}
 
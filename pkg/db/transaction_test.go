@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -14,3 +15,18 @@ func TestTransaction(t *testing.T) {
 		}
 	})
 }
+
+func TestTransactionResultWithContext(t *testing.T) {
+
+	t.Run("Test closed context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		executed := false
+		_, err := TransactionResultWithContext(ctx, nil, func(tx *TxConnection) (interface{}, error) {
+			executed = true
+			return nil, nil
+		}, nil)
+		require.ErrorIs(t, err, context.Canceled)
+		require.False(t, executed)
+	})
+}
@@ -0,0 +1,234 @@
+package deadletter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
+)
+
+//NotOpenError indicates that a dead letter was already requeued or acknowledged
+type NotOpenError struct {
+	DeadLetter *model.DeadLetterEntity
+}
+
+func (e *NotOpenError) Error() string {
+	return fmt.Sprintf("dead letter '%d' is already in state '%s'", e.DeadLetter.ID, e.DeadLetter.State)
+}
+
+func IsNotOpenError(err error) bool {
+	var notOpenErr *NotOpenError
+	return errors.As(err, &notOpenErr)
+}
+
+//Repository stores operations which failed permanently (dead-letter queue). Operators can requeue
+//or acknowledge them: acknowledged and requeued dead letters are kept for auditing.
+type Repository struct {
+	*repository.Repository
+}
+
+func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{repo}, nil
+}
+
+//Add moves a failed operation to the dead-letter queue. Operations which were already added are ignored.
+func (dr *Repository) Add(op *model.OperationEntity) (*model.DeadLetterEntity, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		q, err := db.NewQuery(tx, &model.DeadLetterEntity{}, dr.Logger)
+		if err != nil {
+			return nil, err
+		}
+		existing, err := q.Select().
+			Where(map[string]interface{}{"SchedulingID": op.SchedulingID, "CorrelationID": op.CorrelationID}).
+			GetMany()
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			return existing[0], nil
+		}
+
+		entity := &model.DeadLetterEntity{
+			SchedulingID:  op.SchedulingID,
+			CorrelationID: op.CorrelationID,
+			RuntimeID:     op.RuntimeID,
+			ClusterConfig: op.ClusterConfig,
+			Component:     op.Component,
+			Type:          op.Type,
+			Reason:        op.Reason,
+			Retries:       op.Retries,
+			State:         model.DeadLetterStateOpen,
+			Updated:       time.Now().UTC(),
+		}
+		q, err = db.NewQuery(tx, entity, dr.Logger)
+		if err != nil {
+			return nil, err
+		}
+		return entity, q.Insert().Exec()
+	}
+	entity, err := dr.TransactionalResult(dbOps)
+	if err != nil {
+		dr.Logger.Errorf("DeadLetterRepository failed to add operation %s: %s", op, err)
+		return nil, err
+	}
+	return entity.(*model.DeadLetterEntity), nil
+}
+
+//List returns the dead letters (latest first). The state and runtimeID filters are ignored if empty.
+func (dr *Repository) List(state model.DeadLetterState, runtimeID string) ([]*model.DeadLetterEntity, error) {
+	q, err := db.NewQuery(dr.Conn, &model.DeadLetterEntity{}, dr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{}
+	if state != "" {
+		whereCond["State"] = string(state)
+	}
+	if runtimeID != "" {
+		whereCond["RuntimeID"] = runtimeID
+	}
+	entities, err := q.Select().
+		Where(whereCond).
+		OrderBy(map[string]string{"ID": "DESC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.DeadLetterEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.DeadLetterEntity))
+	}
+	return result, nil
+}
+
+//Get returns the dead letters with the given IDs and fails if one of them does not exist
+func (dr *Repository) Get(ids []int64) ([]*model.DeadLetterEntity, error) {
+	return dr.get(dr.Conn, ids)
+}
+
+func (dr *Repository) get(conn db.Connection, ids []int64) ([]*model.DeadLetterEntity, error) {
+	var result []*model.DeadLetterEntity
+	for _, id := range ids {
+		q, err := db.NewQuery(conn, &model.DeadLetterEntity{}, dr.Logger)
+		if err != nil {
+			return nil, err
+		}
+		whereCond := map[string]interface{}{"ID": id}
+		entity, err := q.Select().Where(whereCond).GetOne()
+		if err != nil {
+			return nil, dr.NewNotFoundError(err, &model.DeadLetterEntity{}, whereCond)
+		}
+		result = append(result, entity.(*model.DeadLetterEntity))
+	}
+	return result, nil
+}
+
+//UpdateState changes the state of the dead letters with the given IDs. The update is rejected
+//if one of the dead letters does not exist.
+func (dr *Repository) UpdateState(ids []int64, state model.DeadLetterState) ([]*model.DeadLetterEntity, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		entities, err := dr.get(tx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range entities {
+			entity.State = state
+			entity.Updated = time.Now().UTC()
+			q, err := db.NewQuery(tx, entity, dr.Logger)
+			if err != nil {
+				return nil, err
+			}
+			cnt, err := q.Update().Where(map[string]interface{}{"ID": entity.ID}).ExecCount()
+			if err != nil {
+				return nil, err
+			}
+			if cnt == 0 {
+				return nil, fmt.Errorf("update of dead letter '%s' failed: no row was updated", entity)
+			}
+		}
+		return entities, nil
+	}
+	entities, err := dr.TransactionalResult(dbOps)
+	if err != nil {
+		return nil, err
+	}
+	return entities.([]*model.DeadLetterEntity), nil
+}
+
+//Resolve moves open dead letters to the given state. The action is applied to the dead letters within the same
+//transaction: the state isn't changed if the action fails and the resolution is rejected (see IsNotOpenError) if one
+//of the dead letters isn't open anymore, also if it got resolved concurrently.
+func (dr *Repository) Resolve(ids []int64, state model.DeadLetterState,
+	action func(tx *db.TxConnection, deadLetters []*model.DeadLetterEntity) error) ([]*model.DeadLetterEntity, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		entities, err := dr.get(tx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range entities {
+			if entity.State != model.DeadLetterStateOpen {
+				return nil, &NotOpenError{DeadLetter: entity}
+			}
+		}
+		if err := action(tx, entities); err != nil {
+			return nil, err
+		}
+		for _, entity := range entities {
+			entity.State = state
+			entity.Updated = time.Now().UTC()
+			q, err := db.NewQuery(tx, entity, dr.Logger)
+			if err != nil {
+				return nil, err
+			}
+			//the state condition detects concurrent resolutions of the dead letter
+			cnt, err := q.Update().
+				Where(map[string]interface{}{"ID": entity.ID, "State": string(model.DeadLetterStateOpen)}).
+				ExecCount()
+			if err != nil {
+				return nil, err
+			}
+			if cnt == 0 {
+				current, err := dr.get(tx, []int64{entity.ID})
+				if err != nil {
+					return nil, err
+				}
+				return nil, &NotOpenError{DeadLetter: current[0]}
+			}
+		}
+		return entities, nil
+	}
+	entities, err := dr.TransactionalResult(dbOps)
+	if err != nil {
+		return nil, err
+	}
+	return entities.([]*model.DeadLetterEntity), nil
+}
+
+//CountOpen returns the number of dead letters which were neither requeued nor acknowledged
+func (dr *Repository) CountOpen() (int, error) {
+	colHdr, err := db.NewColumnHandler(&model.DeadLetterEntity{}, dr.Conn, dr.Logger)
+	if err != nil {
+		return 0, err
+	}
+	stateCol, err := colHdr.ColumnName("State")
+	if err != nil {
+		return 0, err
+	}
+	row, err := dr.Conn.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s=$1",
+		(&model.DeadLetterEntity{}).Table(), stateCol), string(model.DeadLetterStateOpen))
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "failed to count open dead letters")
+	}
+	return count, nil
+}
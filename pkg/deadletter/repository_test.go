@@ -0,0 +1,112 @@
+package deadletter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repo, err := NewRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+
+	runtimeID := uuid.NewString()
+	newOperation := func(component string) *model.OperationEntity {
+		return &model.OperationEntity{
+			SchedulingID:  uuid.NewString(),
+			CorrelationID: uuid.NewString(),
+			RuntimeID:     runtimeID,
+			ClusterConfig: 1,
+			Component:     component,
+			Type:          model.OperationTypeReconcile,
+			State:         model.OperationStateError,
+			Reason:        "retries exhausted",
+			Retries:       5,
+		}
+	}
+
+	op1 := newOperation("istio")
+	deadLetter1, err := repo.Add(op1)
+	require.NoError(t, err)
+	require.True(t, deadLetter1.ID > 0)
+	require.Equal(t, model.DeadLetterStateOpen, deadLetter1.State)
+
+	//operations are dead-lettered only once
+	deadLetterDuplicate, err := repo.Add(op1)
+	require.NoError(t, err)
+	require.Equal(t, deadLetter1.ID, deadLetterDuplicate.ID)
+
+	deadLetter2, err := repo.Add(newOperation("serverless"))
+	require.NoError(t, err)
+
+	defer func() {
+		q, err := db.NewQuery(repo.Conn, &model.DeadLetterEntity{}, repo.Logger)
+		require.NoError(t, err)
+		_, err = q.Delete().Where(map[string]interface{}{"RuntimeID": runtimeID}).Exec()
+		require.NoError(t, err)
+	}()
+
+	t.Run("List dead letters", func(t *testing.T) {
+		deadLetters, err := repo.List(model.DeadLetterStateOpen, runtimeID)
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 2)
+		require.Equal(t, deadLetter2.ID, deadLetters[0].ID) //latest first
+		require.Equal(t, "retries exhausted", deadLetters[0].Reason)
+	})
+
+	t.Run("Update state of dead letters", func(t *testing.T) {
+		deadLetters, err := repo.UpdateState([]int64{deadLetter1.ID}, model.DeadLetterStateAcknowledged)
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 1)
+		require.Equal(t, model.DeadLetterStateAcknowledged, deadLetters[0].State)
+
+		deadLetters, err = repo.List(model.DeadLetterStateOpen, runtimeID)
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 1)
+		require.Equal(t, deadLetter2.ID, deadLetters[0].ID)
+	})
+
+	t.Run("Update of non-existing dead letter is rejected", func(t *testing.T) {
+		_, err := repo.UpdateState([]int64{deadLetter2.ID, -1}, model.DeadLetterStateRequeued)
+		require.True(t, repository.IsNotFoundError(err))
+
+		//no dead letter was updated
+		deadLetters, err := repo.Get([]int64{deadLetter2.ID})
+		require.NoError(t, err)
+		require.Equal(t, model.DeadLetterStateOpen, deadLetters[0].State)
+	})
+	t.Run("Resolve dead letters", func(t *testing.T) {
+		//the state isn't changed if the action fails
+		_, err := repo.Resolve([]int64{deadLetter2.ID}, model.DeadLetterStateRequeued,
+			func(tx *db.TxConnection, deadLetters []*model.DeadLetterEntity) error {
+				require.Len(t, deadLetters, 1)
+				return errors.New("requeue failed")
+			})
+		require.Error(t, err)
+		count, err := repo.CountOpen()
+		require.NoError(t, err)
+		require.True(t, count >= 1)
+
+		deadLetters, err := repo.Resolve([]int64{deadLetter2.ID}, model.DeadLetterStateRequeued,
+			func(tx *db.TxConnection, deadLetters []*model.DeadLetterEntity) error {
+				return nil
+			})
+		require.NoError(t, err)
+		require.Equal(t, model.DeadLetterStateRequeued, deadLetters[0].State)
+		newCount, err := repo.CountOpen()
+		require.NoError(t, err)
+		require.Equal(t, count-1, newCount)
+
+		//resolved dead letters can't be resolved again
+		_, err = repo.Resolve([]int64{deadLetter2.ID}, model.DeadLetterStateAcknowledged,
+			func(tx *db.TxConnection, deadLetters []*model.DeadLetterEntity) error {
+				return nil
+			})
+		require.True(t, IsNotOpenError(err))
+	})
+}
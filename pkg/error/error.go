@@ -1,5 +1,10 @@
 package error
 
+import (
+	"context"
+	"errors"
+)
+
 type ContextClosedError struct {
 	Message string
 }
@@ -7,3 +12,24 @@ type ContextClosedError struct {
 func (m *ContextClosedError) Error() string {
 	return m.Message
 }
+
+//TimeoutError indicates that an operation didn't complete within its timeout
+type TimeoutError struct {
+	Message string
+}
+
+func (m *TimeoutError) Error() string {
+	return m.Message
+}
+
+//IsTimeoutError checks whether the error (or the last error of a retried call) is a TimeoutError or
+//caused by an exceeded context deadline
+func IsTimeoutError(err error) bool {
+	if retryErr, ok := err.(interface{ WrappedErrors() []error }); ok { //errors of retry-go don't support unwrapping
+		if wrapped := retryErr.WrappedErrors(); len(wrapped) > 0 {
+			err = wrapped[len(wrapped)-1]
+		}
+	}
+	var timeoutErr *TimeoutError
+	return errors.As(err, &timeoutErr) || errors.Is(err, context.DeadlineExceeded)
+}
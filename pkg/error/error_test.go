@@ -0,0 +1,34 @@
+package error
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/avast/retry-go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTimeoutError(t *testing.T) {
+	require.True(t, IsTimeoutError(&TimeoutError{Message: "progress tracker reached timeout"}))
+	require.True(t, IsTimeoutError(errors.Wrap(context.DeadlineExceeded, "failed to deploy")))
+	require.True(t, IsTimeoutError(fmt.Errorf("installation failed: %w", &TimeoutError{})))
+	require.False(t, IsTimeoutError(errors.New("waiting for webhook timed out")))
+	require.False(t, IsTimeoutError(nil))
+
+	t.Run("Last error of retried call", func(t *testing.T) {
+		err := retry.Do(func() error { return context.DeadlineExceeded }, retry.Attempts(2), retry.Delay(0))
+		require.True(t, IsTimeoutError(err))
+
+		attempt := 0
+		err = retry.Do(func() error {
+			attempt++
+			if attempt == 1 {
+				return context.DeadlineExceeded
+			}
+			return errors.New("installation failed")
+		}, retry.Attempts(2), retry.Delay(0))
+		require.False(t, IsTimeoutError(err))
+	})
+}
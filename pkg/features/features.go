@@ -1,7 +1,9 @@
 package features
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -12,6 +14,10 @@ const (
 	WorkerpoolOccupancyTracking
 	LogIstioOperator
 	DebugLogForSpecificOperations
+	AdoptExistingResources
+	ComponentLeases
+	UninstallConfirmation
+	CompliancePreCheck
 )
 
 //define the mapping between feature name and env var name
@@ -20,12 +26,54 @@ var featureEnVarMap = map[Feature]string{
 	WorkerpoolOccupancyTracking:   "WORKERPOOL_OCCUPANCY_TRACKING_ENABLED",
 	LogIstioOperator:              "LOG_ISTIO_OPERATOR",
 	DebugLogForSpecificOperations: "DEBUG_LOGGING_FOR_SPECIFIC_OPERATIONS",
+	AdoptExistingResources:        "ADOPT_EXISTING_RESOURCES_ENABLED",
+	ComponentLeases:               "COMPONENT_LEASES_ENABLED",
+	UninstallConfirmation:         "UNINSTALL_CONFIRMATION_ENABLED",
+	CompliancePreCheck:            "COMPLIANCE_PRECHECK_ENABLED",
 }
 
 func Enabled(feature Feature) bool {
 	return checkEnvVar(envVar(feature))
 }
 
+//Toggle enables or disables a feature at runtime by its env var name
+func Toggle(envVarName string, enabled bool) error {
+	if !Known(envVarName) {
+		return fmt.Errorf("feature '%s' is unknown", envVarName)
+	}
+	return os.Setenv(envVarName, strconv.FormatBool(enabled))
+}
+
+//States returns whether the known features are enabled (key is the env var name of the feature)
+func States() map[string]bool {
+	states := make(map[string]bool, len(featureEnVarMap))
+	for _, envVarName := range featureEnVarMap {
+		states[envVarName] = checkEnvVar(envVarName)
+	}
+	return states
+}
+
+//Overrides are feature flags (referenced by their env var name) which apply only to particular clusters:
+//they take precedence over the feature flags defined by env vars
+type Overrides map[string]bool
+
+func (o Overrides) Enabled(feature Feature) bool {
+	if enabled, ok := o[envVar(feature)]; ok {
+		return enabled
+	}
+	return Enabled(feature)
+}
+
+//Known checks whether an env var name refers to a feature flag
+func Known(envVarName string) bool {
+	for _, knownEnvVar := range featureEnVarMap {
+		if knownEnvVar == envVarName {
+			return true
+		}
+	}
+	return false
+}
+
 func envVar(feature Feature) string {
 	return featureEnVarMap[feature]
 }
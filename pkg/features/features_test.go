@@ -43,3 +43,24 @@ func TestFeatures(t *testing.T) {
 	}
 
 }
+
+func TestToggle(t *testing.T) {
+	require.NoError(t, Toggle(envVar(LogIstioOperator), true))
+	require.True(t, Enabled(LogIstioOperator))
+	require.NoError(t, Toggle(envVar(LogIstioOperator), false))
+	require.False(t, Enabled(LogIstioOperator))
+	require.Error(t, Toggle("I_DO_NOT_EXIST", true))
+	require.NoError(t, os.Unsetenv(envVar(LogIstioOperator)))
+}
+
+func TestStates(t *testing.T) {
+	require.NoError(t, Toggle(envVar(LogIstioOperator), true))
+	defer func() {
+		require.NoError(t, os.Unsetenv(envVar(LogIstioOperator)))
+	}()
+
+	states := States()
+	require.Len(t, states, len(featureEnVarMap))
+	require.True(t, states[envVar(LogIstioOperator)])
+	require.False(t, states[envVar(ComponentLeases)])
+}
@@ -0,0 +1,163 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	ServiceName = "reconciler.mothership.Mothership"
+
+	methodRegisterCluster    = "/" + ServiceName + "/RegisterCluster"
+	methodGetClusterStatus   = "/" + ServiceName + "/GetClusterStatus"
+	methodWatchStatusChanges = "/" + ServiceName + "/WatchStatusChanges"
+)
+
+//MothershipServer is the server API of the mothership service
+type MothershipServer interface {
+	RegisterCluster(ctx context.Context, req *RegisterClusterRequest) (*ClusterStatus, error)
+	GetClusterStatus(ctx context.Context, req *GetClusterStatusRequest) (*ClusterStatus, error)
+	WatchStatusChanges(req *WatchStatusChangesRequest, stream StatusChangeSender) error
+}
+
+//StatusChangeSender streams status changes to the client
+type StatusChangeSender interface {
+	Send(change *StatusChange) error
+	grpc.ServerStream
+}
+
+//StatusChangeReceiver receives the streamed status changes
+type StatusChangeReceiver interface {
+	Recv() (*StatusChange, error)
+	grpc.ClientStream
+}
+
+//ServiceDesc describes the mothership service (equivalent of the descriptor generated by protoc-gen-go-grpc)
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*MothershipServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterCluster",
+			Handler:    registerClusterHandler,
+		},
+		{
+			MethodName: "GetClusterStatus",
+			Handler:    getClusterStatusHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchStatusChanges",
+			Handler:       watchStatusChangesHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mothership.proto",
+}
+
+//RegisterMothershipServer registers the service implementation at the gRPC server
+func RegisterMothershipServer(s grpc.ServiceRegistrar, srv MothershipServer) {
+	s.RegisterService(&ServiceDesc, srv)
+}
+
+func registerClusterHandler(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := &RegisterClusterRequest{}
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MothershipServer).RegisterCluster(ctx, req.(*RegisterClusterRequest))
+	}
+	if interceptor == nil {
+		return handler(ctx, req)
+	}
+	return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: methodRegisterCluster}, handler)
+}
+
+func getClusterStatusHandler(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := &GetClusterStatusRequest{}
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MothershipServer).GetClusterStatus(ctx, req.(*GetClusterStatusRequest))
+	}
+	if interceptor == nil {
+		return handler(ctx, req)
+	}
+	return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: methodGetClusterStatus}, handler)
+}
+
+func watchStatusChangesHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := &WatchStatusChangesRequest{}
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	return srv.(MothershipServer).WatchStatusChanges(req, &statusChangeStream{stream})
+}
+
+type statusChangeStream struct {
+	grpc.ServerStream
+}
+
+func (s *statusChangeStream) Send(change *StatusChange) error {
+	return s.ServerStream.SendMsg(change)
+}
+
+//MothershipClient calls the mothership service
+type MothershipClient struct {
+	conn grpc.ClientConnInterface
+}
+
+func NewMothershipClient(conn grpc.ClientConnInterface) *MothershipClient {
+	return &MothershipClient{conn: conn}
+}
+
+func (c *MothershipClient) RegisterCluster(ctx context.Context, req *RegisterClusterRequest,
+	opts ...grpc.CallOption) (*ClusterStatus, error) {
+	resp := &ClusterStatus{}
+	if err := c.conn.Invoke(ctx, methodRegisterCluster, req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *MothershipClient) GetClusterStatus(ctx context.Context, req *GetClusterStatusRequest,
+	opts ...grpc.CallOption) (*ClusterStatus, error) {
+	resp := &ClusterStatus{}
+	if err := c.conn.Invoke(ctx, methodGetClusterStatus, req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *MothershipClient) WatchStatusChanges(ctx context.Context, req *WatchStatusChangesRequest,
+	opts ...grpc.CallOption) (StatusChangeReceiver, error) {
+	stream, err := c.conn.NewStream(ctx, &ServiceDesc.Streams[0], methodWatchStatusChanges, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &statusChangeReceiver{stream}, nil
+}
+
+type statusChangeReceiver struct {
+	grpc.ClientStream
+}
+
+func (r *statusChangeReceiver) Recv() (*StatusChange, error) {
+	change := &StatusChange{}
+	if err := r.ClientStream.RecvMsg(change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
@@ -0,0 +1,127 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type testServer struct {
+	changes []*StatusChange
+}
+
+func (s *testServer) RegisterCluster(_ context.Context, req *RegisterClusterRequest) (*ClusterStatus, error) {
+	if len(req.Cluster) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "cluster is undefined")
+	}
+	return &ClusterStatus{RuntimeID: string(req.Cluster), ConfigVersion: req.ContractVersion, Status: "reconcile_pending"}, nil
+}
+
+func (s *testServer) GetClusterStatus(_ context.Context, req *GetClusterStatusRequest) (*ClusterStatus, error) {
+	return &ClusterStatus{
+		RuntimeID:     req.RuntimeID,
+		ConfigVersion: req.ConfigVersion,
+		Status:        "error",
+		Failures:      []*Failure{{Component: "istio", Reason: "timeout"}, {Component: "serverless"}},
+	}, nil
+}
+
+func (s *testServer) WatchStatusChanges(req *WatchStatusChangesRequest, stream StatusChangeSender) error {
+	for _, change := range s.changes {
+		if change.RuntimeID != req.RuntimeID {
+			continue
+		}
+		if err := stream.Send(change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestMessages(t *testing.T) {
+	messages := []proto.Message{
+		&RegisterClusterRequest{ContractVersion: 2, Cluster: []byte(`{"runtimeID":"abc"}`)},
+		&GetClusterStatusRequest{RuntimeID: "abc", ConfigVersion: 3},
+		&ClusterStatus{RuntimeID: "abc", ClusterVersion: 1, ConfigVersion: 3, Status: "error", StatusURL: "http://x",
+			Failures: []*Failure{{Component: "istio", Reason: "timeout"}}},
+		&WatchStatusChangesRequest{RuntimeID: "abc", Cursor: "42"},
+		&StatusChange{RuntimeID: "abc", ConfigVersion: 3, Kind: "reconciliation", Status: "ready",
+			SchedulingID: "123", Created: 1650000000000, Cursor: "43"},
+	}
+	empty := []proto.Message{
+		&RegisterClusterRequest{}, &GetClusterStatusRequest{}, &ClusterStatus{}, &WatchStatusChangesRequest{}, &StatusChange{},
+	}
+	for i, msg := range messages {
+		b, err := proto.Marshal(msg)
+		require.NoError(t, err)
+		require.NoError(t, proto.Unmarshal(b, empty[i]))
+		require.True(t, proto.Equal(msg, empty[i]), msg)
+	}
+
+	t.Run("Invalid message", func(t *testing.T) {
+		require.Error(t, proto.Unmarshal([]byte{0x0a, 0xff}, &ClusterStatus{}))
+	})
+}
+
+func TestService(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	RegisterMothershipServer(srv, &testServer{changes: []*StatusChange{
+		{RuntimeID: "abc", Status: "reconciling", Cursor: "1"},
+		{RuntimeID: "xyz", Status: "reconciling", Cursor: "2"},
+		{RuntimeID: "abc", Status: "ready", Cursor: "3"},
+	}})
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+	client := NewMothershipClient(conn)
+	ctx := context.Background()
+
+	t.Run("Register cluster", func(t *testing.T) {
+		resp, err := client.RegisterCluster(ctx, &RegisterClusterRequest{ContractVersion: 2, Cluster: []byte("abc")})
+		require.NoError(t, err)
+		require.True(t, proto.Equal(&ClusterStatus{RuntimeID: "abc", ConfigVersion: 2, Status: "reconcile_pending"}, resp))
+
+		_, err = client.RegisterCluster(ctx, &RegisterClusterRequest{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Get cluster status", func(t *testing.T) {
+		resp, err := client.GetClusterStatus(ctx, &GetClusterStatusRequest{RuntimeID: "abc", ConfigVersion: 3})
+		require.NoError(t, err)
+		require.Equal(t, "error", resp.Status)
+		require.Len(t, resp.Failures, 2)
+		require.True(t, proto.Equal(&Failure{Component: "serverless"}, resp.Failures[1]))
+	})
+
+	t.Run("Watch status changes", func(t *testing.T) {
+		stream, err := client.WatchStatusChanges(ctx, &WatchStatusChangesRequest{RuntimeID: "abc"})
+		require.NoError(t, err)
+		var cursors []string
+		for {
+			change, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			cursors = append(cursors, change.Cursor)
+		}
+		require.Equal(t, []string{"1", "3"}, cursors)
+	})
+}
@@ -0,0 +1,127 @@
+package idempotency
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+)
+
+//Retention is the time the response of a completed request is stored: requests with an expired key are processed again
+var Retention = 24 * time.Hour
+
+//ReservationTimeout is the time a key stays reserved by a request which didn't complete (e.g. because the mothership
+//was restarted while processing it): retries are rejected until it expires
+var ReservationTimeout = 5 * time.Minute
+
+//Repository stores the responses of cluster mutations per idempotency key: retried requests get the stored
+//response instead of being processed twice
+type Repository struct {
+	*repository.Repository
+}
+
+func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{repo}, nil
+}
+
+//Reserve assigns the key to the request with the fingerprint. If the key is already used by another request,
+//the existing entity is returned and the request must not be processed.
+func (ir *Repository) Reserve(key, fingerprint string) (*model.IdempotencyKeyEntity, bool, error) {
+	if err := ir.removeExpired(); err != nil {
+		return nil, false, err
+	}
+	entity := &model.IdempotencyKeyEntity{
+		Key:         key,
+		Fingerprint: fingerprint,
+	}
+	q, err := db.NewQuery(ir.Conn, entity, ir.Logger)
+	if err != nil {
+		return nil, false, err
+	}
+	if insertErr := q.Insert().Exec(); insertErr != nil {
+		//insert fails if the key exists already (e.g. if a retry was sent in parallel)
+		existing, err := ir.Get(key)
+		if err != nil {
+			if repository.IsNotFoundError(err) {
+				return nil, false, insertErr
+			}
+			return nil, false, err
+		}
+		return existing, false, nil
+	}
+	return entity, true, nil
+}
+
+//Get returns the entity of an idempotency key
+func (ir *Repository) Get(key string) (*model.IdempotencyKeyEntity, error) {
+	q, err := db.NewQuery(ir.Conn, &model.IdempotencyKeyEntity{}, ir.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{"Key": key}
+	entity, err := q.Select().
+		Where(whereCond).
+		GetOne()
+	if err != nil {
+		return nil, ir.MapError(err, &model.IdempotencyKeyEntity{}, whereCond)
+	}
+	return entity.(*model.IdempotencyKeyEntity), nil
+}
+
+//Complete stores the response of the request which reserved the key
+func (ir *Repository) Complete(key string, statusCode int, response []byte) error {
+	entity, err := ir.Get(key)
+	if err != nil {
+		return err
+	}
+	entity.StatusCode = int64(statusCode)
+	entity.Response = string(response)
+	q, err := db.NewQuery(ir.Conn, entity, ir.Logger)
+	if err != nil {
+		return err
+	}
+	return q.Update().Where(map[string]interface{}{"Key": key}).Exec()
+}
+
+//Release removes the reservation of a key: requests which failed are processed again when they get retried
+func (ir *Repository) Release(key string) error {
+	q, err := db.NewQuery(ir.Conn, &model.IdempotencyKeyEntity{}, ir.Logger)
+	if err != nil {
+		return err
+	}
+	_, err = q.Delete().Where(map[string]interface{}{"Key": key}).Exec()
+	return err
+}
+
+func (ir *Repository) removeExpired() error {
+	colHandler, err := db.NewColumnHandler(&model.IdempotencyKeyEntity{}, ir.Conn, ir.Logger)
+	if err != nil {
+		return err
+	}
+	createdCol, err := colHandler.ColumnName("Created")
+	if err != nil {
+		return err
+	}
+	statusCodeCol, err := colHandler.ColumnName("StatusCode")
+	if err != nil {
+		return err
+	}
+	q, err := db.NewQuery(ir.Conn, &model.IdempotencyKeyEntity{}, ir.Logger)
+	if err != nil {
+		return err
+	}
+	deleteQ := q.Delete()
+	placeholder := deleteQ.NextPlaceholderCount()
+	now := time.Now().UTC()
+	_, err = deleteQ.
+		WhereRaw(fmt.Sprintf("%s<$%d OR (%s=0 AND %s<$%d)", createdCol, placeholder, statusCodeCol, createdCol, placeholder+1),
+			now.Add(-Retention).Format(db.TimeFormat), now.Add(-ReservationTimeout).Format(db.TimeFormat)).
+		Exec()
+	return err
+}
@@ -0,0 +1,75 @@
+package idempotency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repo, err := NewRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+
+	t.Run("Reserve, complete and release keys", func(t *testing.T) {
+		key := uuid.NewString()
+		entity, reserved, err := repo.Reserve(key, "fingerprint")
+		require.NoError(t, err)
+		require.True(t, reserved)
+		require.False(t, entity.Completed())
+
+		//key is in use as long as the request is processed
+		existing, reserved, err := repo.Reserve(key, "fingerprint")
+		require.NoError(t, err)
+		require.False(t, reserved)
+		require.False(t, existing.Completed())
+
+		require.NoError(t, repo.Complete(key, 200, []byte(`{"cluster":"abc"}`)))
+		existing, reserved, err = repo.Reserve(key, "other fingerprint")
+		require.NoError(t, err)
+		require.False(t, reserved)
+		require.True(t, existing.Completed())
+		require.Equal(t, "fingerprint", existing.Fingerprint)
+		require.Equal(t, int64(200), existing.StatusCode)
+		require.Equal(t, `{"cluster":"abc"}`, existing.Response)
+
+		require.NoError(t, repo.Release(key))
+		_, err = repo.Get(key)
+		require.True(t, repository.IsNotFoundError(err))
+		_, reserved, err = repo.Reserve(key, "other fingerprint")
+		require.NoError(t, err)
+		require.True(t, reserved, "released keys can be reserved again")
+		require.NoError(t, repo.Release(key))
+	})
+
+	t.Run("Incomplete reservations expire", func(t *testing.T) {
+		reservationTimeout := ReservationTimeout
+		defer func() {
+			ReservationTimeout = reservationTimeout
+		}()
+
+		incomplete := uuid.NewString()
+		completed := uuid.NewString()
+		for _, key := range []string{incomplete, completed} {
+			_, reserved, err := repo.Reserve(key, "fingerprint")
+			require.NoError(t, err)
+			require.True(t, reserved)
+		}
+		require.NoError(t, repo.Complete(completed, 200, []byte(`{}`)))
+
+		ReservationTimeout = -time.Minute
+		_, reserved, err := repo.Reserve(incomplete, "fingerprint")
+		require.NoError(t, err)
+		require.True(t, reserved, "expired reservation can be reserved again")
+		existing, reserved, err := repo.Reserve(completed, "fingerprint")
+		require.NoError(t, err)
+		require.False(t, reserved, "completed responses are kept for the retention")
+		require.True(t, existing.Completed())
+
+		require.NoError(t, repo.Release(incomplete))
+		require.NoError(t, repo.Release(completed))
+	})
+}
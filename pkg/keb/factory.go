@@ -8,6 +8,20 @@ import (
 	"github.com/mitchellh/mapstructure"
 )
 
+//UnsupportedContractVersionError is returned if the ModelFactory doesn't know the payloads of a contract version
+type UnsupportedContractVersionError struct {
+	Version int64
+}
+
+func (e *UnsupportedContractVersionError) Error() string {
+	return fmt.Sprintf("contract version '%d' not supported", e.Version)
+}
+
+func IsUnsupportedContractVersionError(err error) bool {
+	_, ok := err.(*UnsupportedContractVersionError)
+	return ok
+}
+
 type ModelFactory struct {
 	version int64
 }
@@ -19,11 +33,11 @@ func NewModelFactory(contractV int64) *ModelFactory {
 func (mf *ModelFactory) load(model interface{}, data io.Reader) (interface{}, error) {
 	decoder := json.NewDecoder(data)
 	switch mf.version { //add here further case statement if multiple contract versions have to be supported
-	case 1:
+	case 1, 2: //v2 uses the same payloads as v1 except for clusters
 		err := decoder.Decode(&model)
 		return model, err
 	default:
-		return nil, fmt.Errorf("contract version '%d' not supported", mf.version)
+		return nil, &UnsupportedContractVersionError{Version: mf.version}
 	}
 }
 
@@ -52,6 +66,13 @@ func (mf *ModelFactory) Runtime(data io.Reader) (*RuntimeInput, error) {
 }
 
 func (mf *ModelFactory) Cluster(data io.Reader) (*Cluster, error) {
+	if mf.version == 2 {
+		model, err := mf.load(&ClusterV2{}, data)
+		if err != nil {
+			return nil, err
+		}
+		return model.(*ClusterV2).ToCluster()
+	}
 	model, err := mf.load(&Cluster{}, data)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,85 @@
+package keb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelFactory(t *testing.T) {
+	t.Run("Cluster of contract v1", func(t *testing.T) {
+		cluster, err := NewModelFactory(1).Cluster(strings.NewReader(`{
+			"runtimeID": "abc",
+			"kymaConfig": {
+				"version": "2.0.0",
+				"profile": "production",
+				"administrators": ["admin@kyma.io"],
+				"components": [{"component": "istio", "configuration": [{"key": "a", "value": 1, "secret": false}]}]
+			}
+		}`))
+		require.NoError(t, err)
+		require.Equal(t, "abc", cluster.RuntimeID)
+		require.Equal(t, []Configuration{{Key: "a", Value: float64(1)}}, cluster.KymaConfig.Components[0].Configuration)
+	})
+
+	t.Run("Cluster of contract v2", func(t *testing.T) {
+		cluster, err := NewModelFactory(2).Cluster(strings.NewReader(`{
+			"runtimeID": "abc",
+			"metadata": {"region": "eu", "labels": {"cohort": "canary", "team": "a"}},
+			"labels": {"cohort": "prod"},
+			"kymaConfig": {
+				"version": "2.0.0",
+				"profile": "production",
+				"oidc": {"clientID": "client", "issuerURL": "https://issuer.kyma.io", "signingAlgs": ["RS256"]},
+				"components": [
+					{
+						"component": "istio",
+						"namespace": "istio-system",
+						"version": "1.0.0",
+						"configuration": {"global": {"domainName": "kyma.io"}, "replicas": 2, "global.oidc.clientID": "istio"},
+						"secrets": {"password": "secret"},
+						"executionHints": {"maxRetries": 3}
+					},
+					{"component": "serverless", "namespace": "kyma-system", "version": "1.0.0", "URL": "https://charts.kyma.io"}
+				]
+			}
+		}`))
+		require.NoError(t, err)
+		require.Equal(t, "abc", cluster.RuntimeID)
+		require.Equal(t, "2.0.0", cluster.KymaConfig.Version)
+		require.Equal(t, []string{}, cluster.KymaConfig.Administrators)
+		require.Equal(t, map[string]string{"cohort": "prod", "team": "a"}, *cluster.Metadata.Labels)
+		require.Equal(t, "eu", cluster.Metadata.Region)
+
+		require.Len(t, cluster.KymaConfig.Components, 2)
+		istio := cluster.KymaConfig.Components[0]
+		require.Equal(t, "istio-system", istio.Namespace)
+		require.Equal(t, 3, istio.ExecutionHints.GetMaxRetries(1))
+		require.Equal(t, []Configuration{
+			{Key: "global.domainName", Value: "kyma.io"},
+			{Key: "global.oidc.clientID", Value: "istio"},
+			{Key: "global.oidc.issuerURL", Value: "https://issuer.kyma.io"},
+			{Key: "global.oidc.signingAlgs", Value: []string{"RS256"}},
+			{Key: "password", Value: "secret", Secret: true},
+			{Key: "replicas", Value: float64(2)},
+		}, istio.Configuration)
+		require.Equal(t, "https://charts.kyma.io", cluster.KymaConfig.Components[1].URL)
+		require.Len(t, cluster.KymaConfig.Components[1].Configuration, 3, "OIDC settings are added to all components")
+	})
+
+	t.Run("Cluster of contract v2 with conflicting secret", func(t *testing.T) {
+		_, err := NewModelFactory(2).Cluster(strings.NewReader(`{"kymaConfig": {"components": [
+			{"component": "istio", "configuration": {"password": "a"}, "secrets": {"password": "b"}}
+		]}}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "'istio'")
+	})
+
+	t.Run("Unsupported contract version", func(t *testing.T) {
+		_, err := NewModelFactory(3).Cluster(strings.NewReader(`{}`))
+		require.True(t, IsUnsupportedContractVersionError(err))
+		_, err = NewModelFactory(3).Status(strings.NewReader(`{}`))
+		require.True(t, IsUnsupportedContractVersionError(err))
+	})
+}
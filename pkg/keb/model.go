@@ -1,5 +1,16 @@
 package keb
 
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+//targetNamePattern restricts the names of the kubeconfigs of further clusters of a runtime (DNS label)
+var targetNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
 //ConfigurationAsMap flattens the list of configuration entities to a map.
 //Component struct is generated from OpenAPI.
 func (c Component) ConfigurationAsMap() map[string]interface{} {
@@ -9,3 +20,127 @@ func (c Component) ConfigurationAsMap() map[string]interface{} {
 	}
 	return result
 }
+
+//GetTarget returns the name of the kubeconfig of the cluster the component is applied to: an empty string
+//stands for the main cluster of the runtime
+func (c Component) GetTarget() string {
+	if c.Target == nil {
+		return ""
+	}
+	return *c.Target
+}
+
+//GetKubeconfigs returns the named kubeconfigs of the further clusters of the runtime (nil if there are none)
+func (c *Cluster) GetKubeconfigs() map[string]string {
+	if c.Kubeconfigs == nil || len(*c.Kubeconfigs) == 0 {
+		return nil
+	}
+	return *c.Kubeconfigs
+}
+
+//ValidateTargets checks the names of the further kubeconfigs of the cluster and verifies that each component
+//targets either the main cluster or one of the further kubeconfigs
+func (c *Cluster) ValidateTargets() error {
+	kubeconfigs := c.GetKubeconfigs()
+	names := make([]string, 0, len(kubeconfigs))
+	for name := range kubeconfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !targetNamePattern.MatchString(name) {
+			return fmt.Errorf("kubeconfig name '%s' is invalid: it can only contain lowercase letters, digits "+
+				"and '-' and has to start and end with a letter or digit (max. 63 characters)", name)
+		}
+		if strings.TrimSpace(kubeconfigs[name]) == "" {
+			return fmt.Errorf("kubeconfig '%s' is empty", name)
+		}
+	}
+	for _, component := range c.KymaConfig.Components {
+		target := component.GetTarget()
+		if target == "" {
+			continue
+		}
+		if _, ok := kubeconfigs[target]; !ok {
+			return fmt.Errorf("target '%s' of component '%s' is not defined in the kubeconfigs of the cluster",
+				target, component.Component)
+		}
+	}
+	return nil
+}
+
+//specific error codes of problem responses: other errors use the HTTP status in camel case (e.g. "notFound")
+const (
+	ErrorCodeIdempotencyKeyInProgress   = "idempotencyKeyInProgress"
+	ErrorCodeIdempotencyKeyReused       = "idempotencyKeyReused"
+	ErrorCodeKubeconfigRejected         = "kubeconfigRejected"
+	ErrorCodeUnsupportedContractVersion = "unsupportedContractVersion"
+)
+
+//bounds of the execution hints of a component
+const (
+	MinExecutionRetries = 1
+	MaxExecutionRetries = 50
+	MinExecutionTimeout = time.Minute
+	MaxExecutionTimeout = 12 * time.Hour
+)
+
+//Validate checks that the execution hints are within the bounds accepted by the scheduler
+func (h *ExecutionHints) Validate() error {
+	if h == nil {
+		return nil
+	}
+	if h.MaxRetries != nil && (*h.MaxRetries < MinExecutionRetries || *h.MaxRetries > MaxExecutionRetries) {
+		return fmt.Errorf("max. retries has to be between %d and %d (was %d)",
+			MinExecutionRetries, MaxExecutionRetries, *h.MaxRetries)
+	}
+	if h.Timeout != nil {
+		timeout, err := time.ParseDuration(*h.Timeout)
+		if err != nil {
+			return fmt.Errorf("timeout '%s' is not a valid duration: %s", *h.Timeout, err)
+		}
+		if timeout < MinExecutionTimeout || timeout > MaxExecutionTimeout {
+			return fmt.Errorf("timeout has to be between %s and %s (was %s)",
+				MinExecutionTimeout, MaxExecutionTimeout, timeout)
+		}
+	}
+	return nil
+}
+
+//GetMaxRetries returns the max. retries of the hints or the default if no max. retries are hinted
+func (h *ExecutionHints) GetMaxRetries(defaultMaxRetries int) int {
+	if h == nil || h.MaxRetries == nil {
+		return defaultMaxRetries
+	}
+	return int(*h.MaxRetries)
+}
+
+//GetTimeout returns the hinted timeout or 0 if no (valid) timeout is hinted
+func (h *ExecutionHints) GetTimeout() time.Duration {
+	if h == nil || h.Timeout == nil {
+		return 0
+	}
+	timeout, err := time.ParseDuration(*h.Timeout)
+	if err != nil {
+		return 0
+	}
+	return timeout
+}
+
+//GetCritical returns the hinted critical flag and whether the flag was hinted at all
+func (h *ExecutionHints) GetCritical() (critical bool, ok bool) {
+	if h == nil || h.Critical == nil {
+		return false, false
+	}
+	return *h.Critical, true
+}
+
+//ValidateExecutionHints checks the execution hints of all components
+func ValidateExecutionHints(components []Component) error {
+	for _, component := range components {
+		if err := component.ExecutionHints.Validate(); err != nil {
+			return fmt.Errorf("invalid execution hints of component '%s': %s", component.Component, err)
+		}
+	}
+	return nil
+}
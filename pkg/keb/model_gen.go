@@ -7,6 +7,87 @@ import (
 	"time"
 )
 
+// Defines values for AnomalyEventType.
+const (
+	AnomalyEventTypeDurationRegression AnomalyEventType = "durationRegression"
+
+	AnomalyEventTypeFailureSpike AnomalyEventType = "failureSpike"
+)
+
+// Defines values for ChangeKind.
+const (
+	ChangeKindCluster ChangeKind = "cluster"
+
+	ChangeKindReconciliation ChangeKind = "reconciliation"
+)
+
+// Defines values for ConditionStatus.
+const (
+	ConditionStatusFalse ConditionStatus = "False"
+
+	ConditionStatusTrue ConditionStatus = "True"
+
+	ConditionStatusUnknown ConditionStatus = "Unknown"
+)
+
+// Defines values for ConditionType.
+const (
+	ConditionTypeComponentsHealthy ConditionType = "ComponentsHealthy"
+
+	ConditionTypeKubeconfigValid ConditionType = "KubeconfigValid"
+
+	ConditionTypeReady ConditionType = "Ready"
+
+	ConditionTypeReconciling ConditionType = "Reconciling"
+)
+
+// Defines values for DeadLetterState.
+const (
+	DeadLetterStateAcknowledged DeadLetterState = "acknowledged"
+
+	DeadLetterStateOpen DeadLetterState = "open"
+
+	DeadLetterStateRequeued DeadLetterState = "requeued"
+)
+
+// Defines values for DeprecationKind.
+const (
+	DeprecationKindContractVersion DeprecationKind = "contractVersion"
+
+	DeprecationKindField DeprecationKind = "field"
+
+	DeprecationKindRoute DeprecationKind = "route"
+)
+
+// Defines values for ExplanationFindingSeverity.
+const (
+	ExplanationFindingSeverityBlocking ExplanationFindingSeverity = "blocking"
+
+	ExplanationFindingSeverityInfo ExplanationFindingSeverity = "info"
+
+	ExplanationFindingSeverityWarning ExplanationFindingSeverity = "warning"
+)
+
+// Defines values for FactChangeFact.
+const (
+	FactChangeFactKubernetesVersion FactChangeFact = "kubernetesVersion"
+
+	FactChangeFactKymaCRD FactChangeFact = "kymaCRD"
+
+	FactChangeFactNodeCount FactChangeFact = "nodeCount"
+)
+
+// Defines values for HTTPKubeconfigErrorResponseReason.
+const (
+	HTTPKubeconfigErrorResponseReasonForbidden HTTPKubeconfigErrorResponseReason = "forbidden"
+
+	HTTPKubeconfigErrorResponseReasonInvalid HTTPKubeconfigErrorResponseReason = "invalid"
+
+	HTTPKubeconfigErrorResponseReasonUnauthorized HTTPKubeconfigErrorResponseReason = "unauthorized"
+
+	HTTPKubeconfigErrorResponseReasonUnreachable HTTPKubeconfigErrorResponseReason = "unreachable"
+)
+
 // Defines values for Status.
 const (
 	StatusDeleteError Status = "delete_error"
@@ -32,17 +113,142 @@ const (
 	StatusReconciling Status = "reconciling"
 )
 
+// Defines values for TimelineEventType.
+const (
+	TimelineEventTypeOperationCallback TimelineEventType = "operation_callback"
+
+	TimelineEventTypeOperationCreated TimelineEventType = "operation_created"
+
+	TimelineEventTypeOperationPickedUp TimelineEventType = "operation_picked_up"
+
+	TimelineEventTypeReconciliationFinished TimelineEventType = "reconciliation_finished"
+
+	TimelineEventTypeReconciliationStarted TimelineEventType = "reconciliation_started"
+
+	TimelineEventTypeStatusChange TimelineEventType = "status_change"
+)
+
+// HTTPCapabilitiesResponse defines model for HTTPCapabilitiesResponse.
+type HTTPCapabilitiesResponse struct {
+	// Supported authentication modes of the API (e.g. "none")
+	AuthModes []string `json:"authModes"`
+
+	// Supported contract versions
+	ContractVersions []int64 `json:"contractVersions"`
+
+	// Deprecated contract versions (still supported but clients have to migrate)
+	DeprecatedContractVersions []int64 `json:"deprecatedContractVersions"`
+
+	// Features supported and enabled by the mothership: features which aren't listed are not available
+	Features []string `json:"features"`
+}
+
+// HTTPChangesResponse defines model for HTTPChangesResponse.
+type HTTPChangesResponse struct {
+	// Changes after the requested cursor (oldest first)
+	Changes []Change `json:"changes"`
+
+	// Cursor of the last returned change: use it to request the next changes
+	Cursor string `json:"cursor"`
+}
+
 // HTTPClusterConfig defines model for HTTPClusterConfig.
 type HTTPClusterConfig KymaConfig
 
+// HTTPClusterCostResponse defines model for HTTPClusterCostResponse.
+type HTTPClusterCostResponse struct {
+	Cluster         string               `json:"cluster"`
+	GlobalAccountID *string              `json:"globalAccountID,omitempty"`
+	Reconciliations []ReconciliationCost `json:"reconciliations"`
+	Total           Cost                 `json:"total"`
+}
+
+// HTTPClusterDeletionStatusResponse defines model for HTTPClusterDeletionStatusResponse.
+type HTTPClusterDeletionStatusResponse struct {
+	Cluster string `json:"cluster"`
+
+	// Configuration version of the cluster when the deletion was requested
+	DeletionID int64 `json:"deletionID"`
+
+	// Reconciliation which uninstalls the components (undefined until the deletion was scheduled)
+	SchedulingID *string           `json:"schedulingID,omitempty"`
+	Status       Status            `json:"status"`
+	Finished     bool              `json:"finished"`
+	Progress     *DeletionProgress `json:"progress,omitempty"`
+	Failures     *[]Failure        `json:"failures,omitempty"`
+}
+
+// HTTPClusterExplainResponse defines model for HTTPClusterExplainResponse.
+type HTTPClusterExplainResponse struct {
+	Cluster string `json:"cluster"`
+	Status  Status `json:"status"`
+	Ready   bool   `json:"ready"`
+
+	// Human readable summary of what is holding the cluster back
+	Summary string `json:"summary"`
+
+	// Findings of the diagnostic checks ranked by their severity (the most severe finding first)
+	Findings []ExplanationFinding `json:"findings"`
+}
+
+// HTTPClusterImagesResponse defines model for HTTPClusterImagesResponse.
+type HTTPClusterImagesResponse struct {
+	Cluster string `json:"cluster"`
+
+	// Container images of the components (bill of materials)
+	Components []ComponentImages `json:"components"`
+}
+
+// HTTPClusterPurgeResponse defines model for HTTPClusterPurgeResponse.
+type HTTPClusterPurgeResponse struct {
+	// Deleted clusters which were removed physically
+	Purged []DeletedCluster `json:"purged"`
+
+	// Deleted clusters which are kept because their retention period isn't over
+	Retained []DeletedCluster `json:"retained"`
+}
+
 // HTTPClusterResponse defines model for HTTPClusterResponse.
 type HTTPClusterResponse struct {
-	Cluster              string     `json:"cluster"`
-	ClusterVersion       int64      `json:"clusterVersion"`
-	ConfigurationVersion int64      `json:"configurationVersion"`
-	Failures             *[]Failure `json:"failures,omitempty"`
-	Status               Status     `json:"status"`
-	StatusURL            string     `json:"statusURL"`
+	Cluster        string `json:"cluster"`
+	ClusterVersion int64  `json:"clusterVersion"`
+
+	// Hash of the normalized configuration (independent of the order of components, configuration entries and administrators)
+	ConfigurationHash    *string `json:"configurationHash,omitempty"`
+	ConfigurationVersion int64   `json:"configurationVersion"`
+
+	// Identifier of an accepted deletion (only set by cluster deletions)
+	DeletionID *int64 `json:"deletionID,omitempty"`
+
+	// Cluster can't be deleted until the protection is removed
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+
+	// URL of the deletion progress (only set by cluster deletions)
+	DeletionStatusURL *string      `json:"deletionStatusURL,omitempty"`
+	Conditions        *[]Condition `json:"conditions,omitempty"`
+
+	// Deprecated contract versions, routes or fields used by the request
+	Deprecations *[]Deprecation `json:"deprecations,omitempty"`
+
+	// Estimated remaining time (in seconds) of a running reconciliation
+	EstimatedTimeRemaining *int64     `json:"estimatedTimeRemaining,omitempty"`
+	Failures               *[]Failure `json:"failures,omitempty"`
+
+	// Components which were excluded from the reconciliation by an annotation on the cluster
+	Skipped   *[]SkippedComponent `json:"skipped,omitempty"`
+	Status    Status              `json:"status"`
+	StatusURL string              `json:"statusURL"`
+
+	// Warnings of the validation webhook for the configuration version
+	Warnings *[]string `json:"warnings,omitempty"`
+}
+
+// HTTPClusterSnapshotsResponse defines model for HTTPClusterSnapshotsResponse.
+type HTTPClusterSnapshotsResponse struct {
+	Cluster string `json:"cluster"`
+
+	// Snapshots of the cluster facts (latest first)
+	Snapshots []ClusterSnapshot `json:"snapshots"`
 }
 
 // HTTPClusterStateResponse defines model for HTTPClusterStateResponse.
@@ -54,12 +260,141 @@ type HTTPClusterStateResponse struct {
 
 // HTTPClusterStatusResponse defines model for HTTPClusterStatusResponse.
 type HTTPClusterStatusResponse struct {
+	// Cursor of the next page (missing on the last page)
+	Cursor        *string        `json:"cursor,omitempty"`
 	StatusChanges []StatusChange `json:"statusChanges"`
+
+	// Total number of status changes matching the statuses
+	Total int64 `json:"total"`
+}
+
+// HTTPClusterTimelineResponse defines model for HTTPClusterTimelineResponse.
+type HTTPClusterTimelineResponse struct {
+	Events []TimelineEvent `json:"events"`
+}
+
+// HTTPClustersResponse defines model for HTTPClustersResponse.
+type HTTPClustersResponse struct {
+	// Clusters of the requested page (ordered by runtime ID)
+	Clusters []ClusterSummary `json:"clusters"`
+	Limit    int64            `json:"limit"`
+
+	// Runtime ID of the last cluster of a full page: use it as 'after' parameter to retrieve the next page (missing if the clusters are sorted by health)
+	Next   *string `json:"next,omitempty"`
+	Offset int64   `json:"offset"`
+
+	// Total number of clusters matching the filters
+	Total int64 `json:"total"`
+}
+
+// HTTPConfigTemplateResponse defines model for HTTPConfigTemplateResponse.
+type HTTPConfigTemplateResponse struct {
+	// Runtime IDs of the clusters referencing the template
+	Clusters   []string    `json:"clusters"`
+	Components []Component `json:"components"`
+	Created    time.Time   `json:"created"`
+	Name       string      `json:"name"`
+	Updated    time.Time   `json:"updated"`
 }
 
-// HTTPErrorResponse defines model for HTTPErrorResponse.
+// HTTPConfigTemplatesResponse defines model for HTTPConfigTemplatesResponse.
+type HTTPConfigTemplatesResponse []HTTPConfigTemplateResponse
+
+// HTTPDeadLetterResponse defines model for HTTPDeadLetterResponse.
+type HTTPDeadLetterResponse []DeadLetter
+
+// Problem details (RFC 7807) sent with content type application/problem+json
 type HTTPErrorResponse struct {
+	// Same as the detail (kept for backward compatibility)
 	Error string `json:"error"`
+
+	// URI identifying the type of the problem (RFC 7807), e.g. urn:reconciler:problem:notFound
+	Type *string `json:"type,omitempty"`
+
+	// Short summary of the problem type
+	Title *string `json:"title,omitempty"`
+
+	// HTTP status code of the response
+	Status *int `json:"status,omitempty"`
+
+	// Explanation of this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// URI identifying this occurrence of the problem
+	Instance *string `json:"instance,omitempty"`
+
+	// Machine readable error category: the HTTP status in camel case (e.g. badRequest, notFound) or a specific code (unsupportedContractVersion, kubeconfigRejected)
+	Code *string `json:"code,omitempty"`
+
+	// ID of an unexpected internal error: the logs of the mothership contain the details of the incident
+	IncidentID   *string        `json:"incidentID,omitempty"`
+	Deprecations *[]Deprecation `json:"deprecations,omitempty"`
+}
+
+// HTTPFlakinessResponse defines model for HTTPFlakinessResponse.
+type HTTPFlakinessResponse []ComponentFlakiness
+
+// Problem details (RFC 7807) sent with content type application/problem+json
+type HTTPKubeconfigErrorResponse struct {
+	// Same as the detail (kept for backward compatibility)
+	Error string `json:"error"`
+
+	// URI identifying the type of the problem (RFC 7807), e.g. urn:reconciler:problem:notFound
+	Type *string `json:"type,omitempty"`
+
+	// Short summary of the problem type
+	Title *string `json:"title,omitempty"`
+
+	// HTTP status code of the response
+	Status *int `json:"status,omitempty"`
+
+	// Explanation of this occurrence of the problem
+	Detail *string `json:"detail,omitempty"`
+
+	// URI identifying this occurrence of the problem
+	Instance *string `json:"instance,omitempty"`
+
+	// Machine readable error category: the HTTP status in camel case (e.g. badRequest, notFound) or a specific code (unsupportedContractVersion, kubeconfigRejected)
+	Code *string `json:"code,omitempty"`
+
+	// Why the kubeconfig was rejected: it can't be parsed (invalid), its credentials are rejected (unauthorized, forbidden) or the API server didn't respond (unreachable)
+	Reason HTTPKubeconfigErrorResponseReason `json:"reason"`
+}
+
+// HTTPKubeconfigErrorResponseReason defines model for HTTPKubeconfigErrorResponse.Reason.
+type HTTPKubeconfigErrorResponseReason string
+
+// HTTPOperationLogsResponse defines model for HTTPOperationLogsResponse.
+type HTTPOperationLogsResponse struct {
+	Component     string    `json:"component"`
+	CorrelationID string    `json:"correlationID"`
+	Created       time.Time `json:"created"`
+
+	// Latest log entries of an operation reported by its component reconciler
+	Logs         OperationLogs `json:"logs"`
+	RuntimeID    string        `json:"runtimeID"`
+	SchedulingID string        `json:"schedulingID"`
+}
+
+// HTTPOperationTraceResponse defines model for HTTPOperationTraceResponse.
+type HTTPOperationTraceResponse struct {
+	Component     string    `json:"component"`
+	CorrelationID string    `json:"correlationID"`
+	Created       time.Time `json:"created"`
+	RuntimeID     string    `json:"runtimeID"`
+	SchedulingID  string    `json:"schedulingID"`
+
+	// Detailed capture of an operation which exceeded the latency threshold of its component reconciler
+	Trace OperationTrace `json:"trace"`
+}
+
+// HTTPOperationsResponse defines model for HTTPOperationsResponse.
+type HTTPOperationsResponse []Operation
+
+// HTTPQueryResponse defines model for HTTPQueryResponse.
+type HTTPQueryResponse struct {
+	Items    []map[string]interface{} `json:"items"`
+	Resource string                   `json:"resource"`
 }
 
 // HTTPReconcilerStatus defines model for HTTPReconcilerStatus.
@@ -67,28 +402,170 @@ type HTTPReconcilerStatus []Reconciliation
 
 // HTTPReconciliationInfo defines model for HTTPReconciliationInfo.
 type HTTPReconciliationInfo struct {
-	ConfigVersion int64       `json:"configVersion"`
-	Created       time.Time   `json:"created"`
-	Finished      bool        `json:"finished"`
-	Operations    []Operation `json:"operations"`
-	RuntimeID     string      `json:"runtimeID"`
-	SchedulingID  string      `json:"schedulingID"`
-	Status        Status      `json:"status"`
-	Updated       time.Time   `json:"updated"`
+	// Policy which derives the cluster status from the operation states
+	AggregationPolicy *string   `json:"aggregationPolicy,omitempty"`
+	ConfigVersion     int64     `json:"configVersion"`
+	Created           time.Time `json:"created"`
+
+	// Estimated remaining time (in seconds) of an unfinished reconciliation
+	EstimatedTimeRemaining *int64      `json:"estimatedTimeRemaining,omitempty"`
+	Finished               bool        `json:"finished"`
+	Operations             []Operation `json:"operations"`
+	RuntimeID              string      `json:"runtimeID"`
+	SchedulingID           string      `json:"schedulingID"`
+	Status                 Status      `json:"status"`
+	Updated                time.Time   `json:"updated"`
 }
 
+// HTTPSLOResponse defines model for HTTPSLOResponse.
+type HTTPSLOResponse []ComponentSLO
+
+// HTTPSchedulerPauseResponse defines model for HTTPSchedulerPauseResponse.
+type HTTPSchedulerPauseResponse struct {
+	Paused   bool       `json:"paused"`
+	PausedBy *string    `json:"pausedBy,omitempty"`
+	Started  *time.Time `json:"started,omitempty"`
+
+	// End of the pause window (undefined if the scheduler is paused until it gets resumed)
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// HTTPSubscriptionResponse defines model for HTTPSubscriptionResponse.
+type HTTPSubscriptionResponse struct {
+	Created time.Time `json:"created"`
+	Id      string    `json:"id"`
+
+	// Runtime ID of the cluster whose status changes are delivered (undefined if the status changes of all clusters are delivered)
+	RuntimeID *string `json:"runtimeID,omitempty"`
+	Url       string  `json:"url"`
+}
+
+// HTTPSubscriptionsResponse defines model for HTTPSubscriptionsResponse.
+type HTTPSubscriptionsResponse []HTTPSubscriptionResponse
+
+// HTTPVersionResponse defines model for HTTPVersionResponse.
+type HTTPVersionResponse struct {
+	BuildDate        string  `json:"buildDate"`
+	ContractVersions []int64 `json:"contractVersions"`
+	GitCommit        string  `json:"gitCommit"`
+	GoVersion        string  `json:"goVersion"`
+}
+
+// HTTPWarmupResponse defines model for HTTPWarmupResponse.
+type HTTPWarmupResponse []WarmupNotification
+
+// AnomalyCluster defines model for anomalyCluster.
+type AnomalyCluster struct {
+	// Link to the explanation of the cluster status
+	Link      string `json:"link"`
+	RuntimeID string `json:"runtimeID"`
+}
+
+// AnomalyEvent defines model for anomalyEvent.
+type AnomalyEvent struct {
+	// Normal value of the metric: failure ratio (failureSpike) or median duration in seconds (durationRegression)
+	Baseline float64 `json:"baseline"`
+
+	// Clusters which are affected by the anomaly (limited to the most recent ones)
+	Clusters  []AnomalyCluster `json:"clusters"`
+	Component string           `json:"component"`
+	Detected  time.Time        `json:"detected"`
+
+	// Kyma version whose rollout caused the anomaly (only set for durationRegression)
+	KymaVersion *string `json:"kymaVersion,omitempty"`
+	Message     string  `json:"message"`
+
+	// Value of the metric within the detection window
+	Observed float64          `json:"observed"`
+	Type     AnomalyEventType `json:"type"`
+}
+
+// AnomalyEventType defines model for AnomalyEvent.Type.
+type AnomalyEventType string
+
+// Change defines model for change.
+type Change struct {
+	ConfigVersion int64      `json:"configVersion"`
+	Created       time.Time  `json:"created"`
+	Cursor        string     `json:"cursor"`
+	Kind          ChangeKind `json:"kind"`
+	RuntimeID     string     `json:"runtimeID"`
+
+	// Only set for changes of reconciliations
+	SchedulingID *string `json:"schedulingID,omitempty"`
+	Status       Status  `json:"status"`
+}
+
+// ChangeKind defines model for Change.Kind.
+type ChangeKind string
+
 // Cluster defines model for cluster.
 type Cluster struct {
+	// Reject deletions of the cluster (the protection can only be removed by a PATCH request)
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+
+	// valid kubeconfig to cluster
+	Kubeconfig string `json:"kubeconfig"`
+
+	// Named kubeconfigs of further clusters of the runtime (e.g. edge shoots): components are applied to them by setting their target to the name (lowercase letters, digits and '-')
+	Kubeconfigs *map[string]string `json:"kubeconfigs,omitempty"`
+	KymaConfig  KymaConfig         `json:"kymaConfig"`
+	Metadata    Metadata           `json:"metadata"`
+
+	// Case-insensitive identifier of the runtime (surrounding whitespaces are ignored)
+	RuntimeID    string       `json:"runtimeID"`
+	RuntimeInput RuntimeInput `json:"runtimeInput"`
+}
+
+// ClusterPatch defines model for clusterPatch.
+type ClusterPatch struct {
+	// Enable or remove the deletion protection of the cluster
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+
+	// Allow this mothership to take over the cluster once from another mothership (only relevant if the takeover protection is enabled)
+	ForceTakeover *bool `json:"forceTakeover,omitempty"`
+}
+
+// ClusterSnapshot defines model for clusterSnapshot.
+type ClusterSnapshot struct {
+	// Changes of the facts compared to the previous snapshot
+	Changes []FactChange `json:"changes"`
+
+	// Configuration version of the reconciliation after which the snapshot was captured
+	ConfigVersion     int64     `json:"configVersion"`
+	Created           time.Time `json:"created"`
+	KubernetesVersion string    `json:"kubernetesVersion"`
+
+	// Names of the installed CRDs of Kyma API groups
+	KymaCRDs  []string `json:"kymaCRDs"`
+	NodeCount int64    `json:"nodeCount"`
+}
+
+// ClusterV2 defines model for clusterV2.
+type ClusterV2 struct {
+	// Reject deletions of the cluster (the protection can only be removed by a PATCH request)
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+
 	// valid kubeconfig to cluster
-	Kubeconfig   string       `json:"kubeconfig"`
-	KymaConfig   KymaConfig   `json:"kymaConfig"`
-	Metadata     Metadata     `json:"metadata"`
+	Kubeconfig string `json:"kubeconfig"`
+
+	// Named kubeconfigs of further clusters of the runtime (e.g. edge shoots): components are applied to them by setting their target to the name (lowercase letters, digits and '-')
+	Kubeconfigs *map[string]string `json:"kubeconfigs,omitempty"`
+	KymaConfig  KymaConfigV2       `json:"kymaConfig"`
+
+	// Labels of the cluster (override the labels of the metadata)
+	Labels   *map[string]string `json:"labels,omitempty"`
+	Metadata Metadata           `json:"metadata"`
+
+	// Case-insensitive identifier of the runtime (surrounding whitespaces are ignored)
 	RuntimeID    string       `json:"runtimeID"`
 	RuntimeInput RuntimeInput `json:"runtimeInput"`
 }
 
 // ClusterState defines model for clusterState.
 type ClusterState struct {
+	// Cohort (e.g. rollout ring) the cluster belongs to
+	Cohort    *string       `json:"cohort,omitempty"`
 	Contract  *int64        `json:"contract,omitempty"`
 	Created   *time.Time    `json:"created,omitempty"`
 	Metadata  *Metadata     `json:"metadata,omitempty"`
@@ -105,10 +582,22 @@ type ClusterStateConfiguration struct {
 	Contract       *int64       `json:"contract,omitempty"`
 	Created        *time.Time   `json:"created,omitempty"`
 	Deleted        *bool        `json:"deleted,omitempty"`
-	KymaProfile    *string      `json:"kymaProfile,omitempty"`
-	KymaVersion    *string      `json:"kymaVersion,omitempty"`
-	RuntimeID      *string      `json:"runtimeID,omitempty"`
-	Version        *int64       `json:"version,omitempty"`
+
+	// Hash of the normalized configuration (independent of the order of components, configuration entries and administrators)
+	Hash        *string `json:"hash,omitempty"`
+	KymaProfile *string `json:"kymaProfile,omitempty"`
+	KymaVersion *string `json:"kymaVersion,omitempty"`
+	RuntimeID   *string `json:"runtimeID,omitempty"`
+	Version     *int64  `json:"version,omitempty"`
+}
+
+// ClusterStatusEvent defines model for clusterStatusEvent.
+type ClusterStatusEvent struct {
+	ClusterVersion int64     `json:"clusterVersion"`
+	ConfigVersion  int64     `json:"configVersion"`
+	Created        time.Time `json:"created"`
+	RuntimeID      string    `json:"runtimeID"`
+	Status         Status    `json:"status"`
 }
 
 // ClusterStateStatus defines model for clusterStateStatus.
@@ -122,13 +611,127 @@ type ClusterStateStatus struct {
 	Status         *Status    `json:"status,omitempty"`
 }
 
+// ClusterSummary defines model for clusterSummary.
+type ClusterSummary struct {
+	ClusterVersion       int64 `json:"clusterVersion"`
+	ConfigurationVersion int64 `json:"configurationVersion"`
+
+	// Cluster can't be deleted until the protection is removed
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+
+	// Health score of the cluster derived from its recent reconciliations (0 = needs attention, 100 = healthy). Missing if the health scoring is disabled or no reconciliation finished recently.
+	HealthScore *int64             `json:"healthScore,omitempty"`
+	KymaProfile string             `json:"kymaProfile"`
+	KymaVersion string             `json:"kymaVersion"`
+	Labels      *map[string]string `json:"labels,omitempty"`
+	RuntimeID   string             `json:"runtimeID"`
+	Status      Status             `json:"status"`
+	StatusURL   string             `json:"statusURL"`
+
+	// Time of the latest status change
+	Updated time.Time `json:"updated"`
+
+	// Warnings of the validation webhook for the configuration version
+	Warnings *[]string `json:"warnings,omitempty"`
+}
+
 // Component defines model for component.
 type Component struct {
 	URL           string          `json:"URL"`
 	Component     string          `json:"component"`
 	Configuration []Configuration `json:"configuration"`
-	Namespace     string          `json:"namespace"`
-	Version       string          `json:"version"`
+
+	// Execution hints which override the default retry and timeout budgets of the component for this cluster
+	ExecutionHints *ExecutionHints `json:"executionHints,omitempty"`
+	Namespace      string          `json:"namespace"`
+
+	// Name of the kubeconfig of the cluster the component is applied to (the main cluster of the runtime if not set)
+	Target  *string `json:"target,omitempty"`
+	Version string  `json:"version"`
+}
+
+// ComponentFlakiness defines model for componentFlakiness.
+type ComponentFlakiness struct {
+	Component string `json:"component"`
+
+	// Number of operations which succeeded only after a retry
+	FlakyOperations int64 `json:"flakyOperations"`
+
+	// Number of finished operations within the classification window
+	Operations       int64      `json:"operations"`
+	Quarantined      bool       `json:"quarantined"`
+	QuarantinedSince *time.Time `json:"quarantinedSince,omitempty"`
+	Ratio            float64    `json:"ratio"`
+}
+
+// ComponentImages defines model for componentImages.
+type ComponentImages struct {
+	Component     string           `json:"component"`
+	ConfigVersion int64            `json:"configVersion"`
+	Images        []ContainerImage `json:"images"`
+
+	// Time when the images were reported after a successful reconciliation of the component
+	Reported     time.Time `json:"reported"`
+	SchedulingID string    `json:"schedulingID"`
+}
+
+// ComponentSLO defines model for componentSLO.
+type ComponentSLO struct {
+	// Consumed error budget (a value above 1 means the objective is violated)
+	BurnRate float64 `json:"burnRate"`
+
+	// Ratio of the operations which succeeded within the latency
+	Compliance           float64 `json:"compliance"`
+	Component            string  `json:"component"`
+	ErrorBudgetRemaining float64 `json:"errorBudgetRemaining"`
+	GoodOperations       int64   `json:"goodOperations"`
+
+	// Max. processing duration of a successful operation, measured from the time a worker picked it up (0 if the duration isn't evaluated)
+	LatencySeconds int64   `json:"latencySeconds"`
+	Operations     int64   `json:"operations"`
+	Target         float64 `json:"target"`
+	WindowSeconds  int64   `json:"windowSeconds"`
+}
+
+// ComponentV2 defines model for componentV2.
+type ComponentV2 struct {
+	URL       *string `json:"URL,omitempty"`
+	Component string  `json:"component"`
+
+	// Configuration values of the component: nested objects are flattened to dot-separated keys (e.g. 'global.domainName')
+	Configuration *map[string]interface{} `json:"configuration,omitempty"`
+
+	// Execution hints which override the default retry and timeout budgets of the component for this cluster
+	ExecutionHints *ExecutionHints `json:"executionHints,omitempty"`
+	Namespace      string          `json:"namespace"`
+
+	// Secret configuration values of the component (flattened like the configuration)
+	Secrets *map[string]interface{} `json:"secrets,omitempty"`
+
+	// Name of the kubeconfig of the cluster the component is applied to (the main cluster of the runtime if not set)
+	Target  *string `json:"target,omitempty"`
+	Version string  `json:"version"`
+}
+
+// Condition defines model for condition.
+type Condition struct {
+	LastTransitionTime time.Time       `json:"lastTransitionTime"`
+	Message            *string         `json:"message,omitempty"`
+	Reason             string          `json:"reason"`
+	Status             ConditionStatus `json:"status"`
+	Type               ConditionType   `json:"type"`
+}
+
+// ConditionStatus defines model for Condition.Status.
+type ConditionStatus string
+
+// ConditionType defines model for Condition.Type.
+type ConditionType string
+
+// ConfigTemplate defines model for configTemplate.
+type ConfigTemplate struct {
+	// Components with default values (overridden by the components of the clusters referencing the template)
+	Components []Component `json:"components"`
 }
 
 // Configuration defines model for configuration.
@@ -138,6 +741,138 @@ type Configuration struct {
 	Value  interface{} `json:"value"`
 }
 
+// ContainerImage defines model for containerImage.
+type ContainerImage struct {
+	Container string `json:"container"`
+
+	// Digest of the image which is running (undefined if no pod of the workload is running)
+	Digest *string `json:"digest,omitempty"`
+	Image  string  `json:"image"`
+
+	// Kind of the workload (e.g. Deployment)
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// Cost defines model for cost.
+type Cost struct {
+	// Requests sent to the API server of the cluster by the component reconcilers
+	ApiCalls int64 `json:"apiCalls"`
+
+	// Size of the manifests deployed on the cluster by the component reconcilers
+	ManifestBytes int64 `json:"manifestBytes"`
+	Operations    int64 `json:"operations"`
+
+	// Processing time (in seconds) reported by the component reconcilers
+	ProcessingTime  int64 `json:"processingTime"`
+	Reconciliations int64 `json:"reconciliations"`
+
+	// Duration (in seconds) of the reconciliations
+	WallTime int64 `json:"wallTime"`
+}
+
+// DeadLetter defines model for deadLetter.
+type DeadLetter struct {
+	ClusterConfig int64           `json:"clusterConfig"`
+	Component     string          `json:"component"`
+	CorrelationID string          `json:"correlationID"`
+	Created       time.Time       `json:"created"`
+	Id            int64           `json:"id"`
+	Reason        string          `json:"reason"`
+	Retries       int64           `json:"retries"`
+	RuntimeID     string          `json:"runtimeID"`
+	SchedulingID  string          `json:"schedulingID"`
+	State         DeadLetterState `json:"state"`
+	Type          string          `json:"type"`
+	Updated       time.Time       `json:"updated"`
+}
+
+// DeadLetterState defines model for DeadLetter.State.
+type DeadLetterState string
+
+// DeadLetterUpdate defines model for deadLetterUpdate.
+type DeadLetterUpdate struct {
+	Ids []int64 `json:"ids"`
+}
+
+// DeletedCluster defines model for deletedCluster.
+type DeletedCluster struct {
+	Deleted time.Time `json:"deleted"`
+
+	// ID the entities of the cluster were renamed to when it was deleted
+	Id string `json:"id"`
+}
+
+// DeletionProgress defines model for deletionProgress.
+type DeletionProgress struct {
+	Total   int `json:"total"`
+	Deleted int `json:"deleted"`
+	Failed  int `json:"failed"`
+}
+
+// Deprecation defines model for deprecation.
+type Deprecation struct {
+	Kind DeprecationKind `json:"kind"`
+
+	// Human readable description of the deprecation and the required change
+	Message string `json:"message"`
+
+	// Deprecated contract version (e.g. v1), route (e.g. DELETE /reconciliations/cluster/{runtimeID}) or field (JSON path, e.g. metadata.labels)
+	Name string `json:"name"`
+
+	// Contract version, route or field which has to be used instead
+	Replacement *string `json:"replacement,omitempty"`
+
+	// Date after which the deprecated element will be removed
+	Sunset time.Time `json:"sunset"`
+}
+
+// DeprecationKind defines model for Deprecation.Kind.
+type DeprecationKind string
+
+// Execution hints which override the default retry and timeout budgets of the component for this cluster
+type ExecutionHints struct {
+	// A failure of a critical component is never tolerated by the aggregation policy, a failure of a non-critical component is treated as best-effort
+	Critical *bool `json:"critical,omitempty"`
+
+	// Max. retries of an operation of the component
+	MaxRetries *int64 `json:"maxRetries,omitempty"`
+
+	// Max. execution time (Go duration, e.g. 90m) of an operation of the component
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// ExplanationFinding defines model for explanationFinding.
+type ExplanationFinding struct {
+	// Diagnostic check which reported the finding
+	Check    string                     `json:"check"`
+	Severity ExplanationFindingSeverity `json:"severity"`
+
+	// Component the finding concerns (undefined if it concerns the whole cluster)
+	Component *string `json:"component,omitempty"`
+	Message   string  `json:"message"`
+}
+
+// ExplanationFindingSeverity defines model for ExplanationFinding.Severity.
+type ExplanationFindingSeverity string
+
+// FactChange defines model for factChange.
+type FactChange struct {
+	// Current value (empty if a CRD was removed)
+	Current string `json:"current"`
+
+	// Whether the change is expected (e.g. caused by autoscaling or a configuration change)
+	Expected bool           `json:"expected"`
+	Fact     FactChangeFact `json:"fact"`
+
+	// Previous value (empty if a CRD was added)
+	Previous string `json:"previous"`
+}
+
+// FactChangeFact defines model for FactChange.Fact.
+type FactChangeFact string
+
 // Failure defines model for failure.
 type Failure struct {
 	Component string `json:"component"`
@@ -149,19 +884,54 @@ type KymaConfig struct {
 	Administrators []string    `json:"administrators"`
 	Components     []Component `json:"components"`
 	Profile        string      `json:"profile"`
-	Version        string      `json:"version"`
+
+	// Name of a configuration template: its components are merged with the components of the cluster (the components of the cluster override the template)
+	Template *string `json:"template,omitempty"`
+	Version  string  `json:"version"`
+}
+
+// KymaConfigV2 defines model for kymaConfigV2.
+type KymaConfigV2 struct {
+	Administrators *[]string     `json:"administrators,omitempty"`
+	Components     []ComponentV2 `json:"components"`
+
+	// OIDC settings of the cluster: they are passed to all components as 'global.oidc.*' configuration
+	Oidc    *OidcConfig `json:"oidc,omitempty"`
+	Profile string      `json:"profile"`
+
+	// Name of a configuration template: its components are merged with the components of the cluster (the components of the cluster override the template)
+	Template *string `json:"template,omitempty"`
+	Version  string  `json:"version"`
+}
+
+// KubeconfigRotation defines model for kubeconfigRotation.
+type KubeconfigRotation struct {
+	Kubeconfig string `json:"kubeconfig"`
 }
 
 // Metadata defines model for metadata.
 type Metadata struct {
 	GlobalAccountID string `json:"globalAccountID"`
 	InstanceID      string `json:"instanceID"`
-	Region          string `json:"region"`
-	ServiceID       string `json:"serviceID"`
-	ServicePlanID   string `json:"servicePlanID"`
-	ServicePlanName string `json:"servicePlanName"`
-	ShootName       string `json:"shootName"`
-	SubAccountID    string `json:"subAccountID"`
+
+	// Labels of the cluster (used to assign the cluster to a cohort)
+	Labels          *map[string]string `json:"labels,omitempty"`
+	Region          string             `json:"region"`
+	ServiceID       string             `json:"serviceID"`
+	ServicePlanID   string             `json:"servicePlanID"`
+	ServicePlanName string             `json:"servicePlanName"`
+	ShootName       string             `json:"shootName"`
+	SubAccountID    string             `json:"subAccountID"`
+}
+
+// OidcConfig OIDC settings of the cluster: they are passed to all components as 'global.oidc.*' configuration
+type OidcConfig struct {
+	ClientID       string    `json:"clientID"`
+	GroupsClaim    *string   `json:"groupsClaim,omitempty"`
+	IssuerURL      string    `json:"issuerURL"`
+	SigningAlgs    *[]string `json:"signingAlgs,omitempty"`
+	UsernameClaim  *string   `json:"usernameClaim,omitempty"`
+	UsernamePrefix *string   `json:"usernamePrefix,omitempty"`
 }
 
 // Operation defines model for operation.
@@ -169,12 +939,47 @@ type Operation struct {
 	Component     string    `json:"component"`
 	CorrelationID string    `json:"correlationID"`
 	Created       time.Time `json:"created"`
-	Priority      int64     `json:"priority"`
-	Reason        string    `json:"reason"`
-	SchedulingID  string    `json:"schedulingID"`
-	State         string    `json:"state"`
-	Type          string    `json:"type"`
-	Updated       time.Time `json:"updated"`
+
+	// An operator confirmed that the deletion removes the pending stateful resources
+	DeletionConfirmed *bool `json:"deletionConfirmed,omitempty"`
+
+	// Time when the operation reached its final state
+	Finished *time.Time `json:"finished,omitempty"`
+
+	// Stateful resources (e.g. PVCs or namespaces) which the deletion removes: the deletion proceeds after it was confirmed
+	PendingDeletion *[]string `json:"pendingDeletion,omitempty"`
+	Priority        int64     `json:"priority"`
+	Reason          string    `json:"reason"`
+
+	// Build (git commit) of the component reconciler which processed the operation
+	ReconcilerVersion *string `json:"reconcilerVersion,omitempty"`
+
+	// Number of attempts to process the operation (the first attempt is counted too)
+	Retries      int64  `json:"retries"`
+	SchedulingID string `json:"schedulingID"`
+
+	// Time when a worker picked up the operation
+	Started *time.Time `json:"started,omitempty"`
+	State   string     `json:"state"`
+	Type    string     `json:"type"`
+	Updated time.Time  `json:"updated"`
+}
+
+// OperationLogEntry defines model for operationLogEntry.
+type OperationLogEntry struct {
+	// Structured context of the log entry
+	Fields  *map[string]string `json:"fields,omitempty"`
+	Level   string             `json:"level"`
+	Message string             `json:"message"`
+	Time    time.Time          `json:"time"`
+}
+
+// Latest log entries of an operation reported by its component reconciler
+type OperationLogs struct {
+	Entries []OperationLogEntry `json:"entries"`
+
+	// Older entries were dropped by the component reconciler because the logs exceeded their size limit
+	Truncated bool `json:"truncated"`
 }
 
 // OperationStop defines model for operationStop.
@@ -182,6 +987,45 @@ type OperationStop struct {
 	Reason string `json:"reason"`
 }
 
+// Detailed capture of an operation which exceeded the latency threshold of its component reconciler
+type OperationTrace struct {
+	ApiCalls []TraceAPICall `json:"apiCalls"`
+	Logs     []TraceLog     `json:"logs"`
+	Phases   []TracePhase   `json:"phases"`
+
+	// Goroutine stacks sampled while the operation was suspected to be stuck
+	Stacks *[]TraceStack `json:"stacks,omitempty"`
+
+	// Latency (in milliseconds) after which the operation was switched to detailed capture
+	Threshold int64 `json:"threshold"`
+
+	// API calls, logs or stacks were dropped because the trace exceeded its size limit
+	Truncated bool `json:"truncated"`
+}
+
+// Query defines model for query.
+type Query struct {
+	// Either a condition (field, op, value) or a group of filters combined by 'and' or 'or'
+	Filter *QueryFilter `json:"filter,omitempty"`
+
+	// Maximum number of returned entities (default 100, at most 1000)
+	Limit *int `json:"limit,omitempty"`
+
+	// Queried resource: clusters (latest status of each cluster), reconciliations or operations
+	Resource string `json:"resource"`
+}
+
+// Either a condition (field, op, value) or a group of filters combined by 'and' or 'or'
+type QueryFilter struct {
+	And   *[]QueryFilter `json:"and,omitempty"`
+	Field *string        `json:"field,omitempty"`
+
+	// Operator of the condition: eq, ne, lt, le, gt, ge or in (requires a list of values)
+	Op    *string        `json:"op,omitempty"`
+	Or    *[]QueryFilter `json:"or,omitempty"`
+	Value *interface{}   `json:"value,omitempty"`
+}
+
 // ReconcilerStatus defines model for reconcilerStatus.
 type ReconcilerStatus struct {
 	Cluster  string    `json:"cluster"`
@@ -201,12 +1045,25 @@ type Reconciliation struct {
 	Updated      time.Time `json:"updated"`
 }
 
+// ReconciliationCost defines model for reconciliationCost.
+type ReconciliationCost struct {
+	Cost         Cost      `json:"cost"`
+	Created      time.Time `json:"created"`
+	SchedulingID string    `json:"schedulingID"`
+}
+
 // RuntimeInput defines model for runtimeInput.
 type RuntimeInput struct {
 	Description string `json:"description"`
 	Name        string `json:"name"`
 }
 
+// SkippedComponent defines model for skippedComponent.
+type SkippedComponent struct {
+	Component string `json:"component"`
+	Reason    string `json:"reason"`
+}
+
 // Status defines model for status.
 type Status string
 
@@ -222,12 +1079,101 @@ type StatusUpdate struct {
 	Status Status `json:"status"`
 }
 
+// Subscription defines model for subscription.
+type Subscription struct {
+	// Runtime ID of the cluster whose status changes are delivered (the status changes of all clusters are delivered if undefined)
+	RuntimeID *string `json:"runtimeID,omitempty"`
+
+	// Secret which signs the deliveries (at least 16 characters)
+	Secret string `json:"secret"`
+
+	// HTTP(S) URL which receives the status changes
+	Url string `json:"url"`
+}
+
+// TimelineEvent defines model for timelineEvent.
+type TimelineEvent struct {
+	Component     *string           `json:"component,omitempty"`
+	CorrelationID *string           `json:"correlationID,omitempty"`
+	Reason        *string           `json:"reason,omitempty"`
+	SchedulingID  *string           `json:"schedulingID,omitempty"`
+	State         *string           `json:"state,omitempty"`
+	Time          time.Time         `json:"time"`
+	Type          TimelineEventType `json:"type"`
+}
+
+// TimelineEventType defines model for TimelineEvent.Type.
+type TimelineEventType string
+
+// TraceAPICall defines model for traceAPICall.
+type TraceAPICall struct {
+	// Duration in milliseconds
+	Duration int64     `json:"duration"`
+	Method   string    `json:"method"`
+	Path     string    `json:"path"`
+	Started  time.Time `json:"started"`
+
+	// HTTP status code of the response (0 if no response was received)
+	StatusCode int `json:"statusCode"`
+}
+
+// TraceLog defines model for traceLog.
+type TraceLog struct {
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// TracePhase defines model for tracePhase.
+type TracePhase struct {
+	// Duration in milliseconds
+	Duration int64     `json:"duration"`
+	Error    *string   `json:"error,omitempty"`
+	Name     string    `json:"name"`
+	Started  time.Time `json:"started"`
+}
+
+// TraceStack defines model for traceStack.
+type TraceStack struct {
+	// Number of goroutines of the operation
+	Goroutines int `json:"goroutines"`
+
+	// Runtime (in milliseconds) of the operation when the stack was sampled
+	Running int64     `json:"running"`
+	Sampled time.Time `json:"sampled"`
+	Stack   string    `json:"stack"`
+}
+
+// Warmup defines model for warmup.
+type Warmup struct {
+	// Kyma versions of a planned rollout whose charts are pre-fetched by the component reconcilers
+	KymaVersions []string `json:"kymaVersions"`
+}
+
+// WarmupNotification defines model for warmupNotification.
+type WarmupNotification struct {
+	Component string `json:"component"`
+
+	// Reason why the component reconciler couldn't be notified
+	Error    *string `json:"error,omitempty"`
+	Notified bool    `json:"notified"`
+
+	// Warm-up endpoint of the component reconciler
+	Url string `json:"url"`
+}
+
 // BadRequest defines model for BadRequest.
 type BadRequest HTTPErrorResponse
 
+// Conflict defines model for Conflict.
+type Conflict HTTPErrorResponse
+
 // InternalError defines model for InternalError.
 type InternalError HTTPErrorResponse
 
+// Locked defines model for Locked.
+type Locked HTTPErrorResponse
+
 // NotFoundResponse defines model for NotFoundResponse.
 type NotFoundResponse HTTPErrorResponse
 
@@ -244,10 +1190,19 @@ type ReconciliationInfoOKResponse HTTPReconciliationInfo
 type ConfigurationOkResponse HTTPClusterConfig
 
 // PostClustersJSONBody defines parameters for PostClusters.
-type PostClustersJSONBody Cluster
+type PostClustersJSONBody interface{}
 
 // PutClustersJSONBody defines parameters for PutClusters.
-type PutClustersJSONBody Cluster
+type PutClustersJSONBody interface{}
+
+// DeleteClustersRuntimeIDParams defines parameters for DeleteClustersRuntimeID.
+type DeleteClustersRuntimeIDParams struct {
+	ExpectedConfigVersion *int64  `json:"expectedConfigVersion,omitempty"`
+	IfMatch               *string `json:"If-Match,omitempty"`
+}
+
+// PatchClustersRuntimeIDJSONBody defines parameters for PatchClustersRuntimeID.
+type PatchClustersRuntimeIDJSONBody ClusterPatch
 
 // GetClustersStateParams defines parameters for GetClustersState.
 type GetClustersStateParams struct {
@@ -256,6 +1211,32 @@ type GetClustersStateParams struct {
 	CorrelationID *string `json:"correlationID,omitempty"`
 }
 
+// GetClustersRuntimeIDConfigsParams defines parameters for GetClustersRuntimeIDConfigs.
+type GetClustersRuntimeIDConfigsParams struct {
+	At time.Time `json:"at"`
+}
+
+// DeleteClustersRuntimeIDPurgeParams defines parameters for DeleteClustersRuntimeIDPurge.
+type DeleteClustersRuntimeIDPurgeParams struct {
+	// Min. time since the deletion of a cluster before it's purged (default is 720h)
+	Retention *string `json:"retention,omitempty"`
+}
+
+// GetClustersRuntimeIDTimelineParams defines parameters for GetClustersRuntimeIDTimeline.
+type GetClustersRuntimeIDTimelineParams struct {
+	Offset    *string              `json:"offset,omitempty"`
+	Type      *[]TimelineEventType `json:"type,omitempty"`
+	Component *string              `json:"component,omitempty"`
+}
+
+// GetClustersRuntimeIDCostsParams defines parameters for GetClustersRuntimeIDCosts.
+type GetClustersRuntimeIDCostsParams struct {
+	Offset *string `json:"offset,omitempty"`
+}
+
+// PutClustersRuntimeIDKubeconfigJSONBody defines parameters for PutClustersRuntimeIDKubeconfig.
+type PutClustersRuntimeIDKubeconfigJSONBody KubeconfigRotation
+
 // PutClustersRuntimeIDStatusJSONBody defines parameters for PutClustersRuntimeIDStatus.
 type PutClustersRuntimeIDStatusJSONBody StatusUpdate
 
@@ -271,14 +1252,81 @@ type GetReconciliationsParams struct {
 	Status    *[]Status  `json:"status,omitempty"`
 }
 
+// GetDeadletterParams defines parameters for GetDeadletter.
+type GetDeadletterParams struct {
+	State     *DeadLetterState `json:"state,omitempty"`
+	RuntimeID *string          `json:"runtimeID,omitempty"`
+}
+
+// PostDeadletterAcknowledgeJSONBody defines parameters for PostDeadletterAcknowledge.
+type PostDeadletterAcknowledgeJSONBody DeadLetterUpdate
+
+// PostDeadletterRequeueJSONBody defines parameters for PostDeadletterRequeue.
+type PostDeadletterRequeueJSONBody DeadLetterUpdate
+
+// PostQueryJSONBody defines parameters for PostQuery.
+type PostQueryJSONBody Query
+
+// GetChangesParams defines parameters for GetChanges.
+type GetChangesParams struct {
+	// Cursor of the last processed change, the feed starts with the oldest retained change if undefined
+	Cursor *string `json:"cursor,omitempty"`
+	Limit  *int    `json:"limit,omitempty"`
+}
+
+// PostAdminPauseParams defines parameters for PostAdminPause.
+type PostAdminPauseParams struct {
+	// Duration of the pause (e.g. 2h), the scheduler is paused until it gets resumed if undefined
+	For *string `json:"for,omitempty"`
+}
+
+// PutAdminTemplatesNameJSONBody defines parameters for PutAdminTemplatesName.
+type PutAdminTemplatesNameJSONBody ConfigTemplate
+
+// PutAdminTemplatesNameParams defines parameters for PutAdminTemplatesName.
+type PutAdminTemplatesNameParams struct {
+	// Create new configuration versions for all clusters referencing the template
+	FanOut *bool `json:"fanOut,omitempty"`
+}
+
+// PostAdminWarmupJSONBody defines parameters for PostAdminWarmup.
+type PostAdminWarmupJSONBody Warmup
+
+// PostSubscriptionsJSONBody defines parameters for PostSubscriptions.
+type PostSubscriptionsJSONBody Subscription
+
 // PostClustersJSONRequestBody defines body for PostClusters for application/json ContentType.
 type PostClustersJSONRequestBody PostClustersJSONBody
 
 // PutClustersJSONRequestBody defines body for PutClusters for application/json ContentType.
 type PutClustersJSONRequestBody PutClustersJSONBody
 
+// PatchClustersRuntimeIDJSONRequestBody defines body for PatchClustersRuntimeID for application/json ContentType.
+type PatchClustersRuntimeIDJSONRequestBody PatchClustersRuntimeIDJSONBody
+
+// PutClustersRuntimeIDKubeconfigJSONRequestBody defines body for PutClustersRuntimeIDKubeconfig for application/json ContentType.
+type PutClustersRuntimeIDKubeconfigJSONRequestBody PutClustersRuntimeIDKubeconfigJSONBody
+
 // PutClustersRuntimeIDStatusJSONRequestBody defines body for PutClustersRuntimeIDStatus for application/json ContentType.
 type PutClustersRuntimeIDStatusJSONRequestBody PutClustersRuntimeIDStatusJSONBody
 
 // PostOperationsSchedulingIDCorrelationIDStopJSONRequestBody defines body for PostOperationsSchedulingIDCorrelationIDStop for application/json ContentType.
 type PostOperationsSchedulingIDCorrelationIDStopJSONRequestBody PostOperationsSchedulingIDCorrelationIDStopJSONBody
+
+// PostDeadletterAcknowledgeJSONRequestBody defines body for PostDeadletterAcknowledge for application/json ContentType.
+type PostDeadletterAcknowledgeJSONRequestBody PostDeadletterAcknowledgeJSONBody
+
+// PostDeadletterRequeueJSONRequestBody defines body for PostDeadletterRequeue for application/json ContentType.
+type PostDeadletterRequeueJSONRequestBody PostDeadletterRequeueJSONBody
+
+// PostQueryJSONRequestBody defines body for PostQuery for application/json ContentType.
+type PostQueryJSONRequestBody PostQueryJSONBody
+
+// PutAdminTemplatesNameJSONRequestBody defines body for PutAdminTemplatesName for application/json ContentType.
+type PutAdminTemplatesNameJSONRequestBody PutAdminTemplatesNameJSONBody
+
+// PostAdminWarmupJSONRequestBody defines body for PostAdminWarmup for application/json ContentType.
+type PostAdminWarmupJSONRequestBody PostAdminWarmupJSONBody
+
+// PostSubscriptionsJSONRequestBody defines body for PostSubscriptions for application/json ContentType.
+type PostSubscriptionsJSONRequestBody PostSubscriptionsJSONBody
@@ -3,6 +3,7 @@ package keb
 import (
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestContract(t *testing.T) {
@@ -25,4 +26,62 @@ func TestContract(t *testing.T) {
 			"test2": "value2",
 		}, comp.ConfigurationAsMap())
 	})
+
+	t.Run("Execution hints", func(t *testing.T) {
+		var noHints *ExecutionHints
+		require.NoError(t, noHints.Validate())
+		require.Equal(t, 5, noHints.GetMaxRetries(5))
+		require.Zero(t, noHints.GetTimeout())
+		_, ok := noHints.GetCritical()
+		require.False(t, ok)
+
+		maxRetries, timeout, critical := int64(20), "90m", true
+		hints := &ExecutionHints{MaxRetries: &maxRetries, Timeout: &timeout, Critical: &critical}
+		require.NoError(t, hints.Validate())
+		require.Equal(t, 20, hints.GetMaxRetries(5))
+		require.Equal(t, 90*time.Minute, hints.GetTimeout())
+		critical, ok = hints.GetCritical()
+		require.True(t, ok)
+		require.True(t, critical)
+	})
+
+	t.Run("Execution hints out of bounds", func(t *testing.T) {
+		tooManyRetries, tooLong, invalid := int64(MaxExecutionRetries+1), "24h", "forever"
+		require.Error(t, (&ExecutionHints{MaxRetries: &tooManyRetries}).Validate())
+		require.Error(t, (&ExecutionHints{Timeout: &tooLong}).Validate())
+		require.Error(t, (&ExecutionHints{Timeout: &invalid}).Validate())
+
+		err := ValidateExecutionHints([]Component{
+			{Component: "istio"},
+			{Component: "serverless", ExecutionHints: &ExecutionHints{Timeout: &tooLong}},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "'serverless'")
+	})
+
+	t.Run("Targets", func(t *testing.T) {
+		edge, unknown, main := "edge-1", "edge-2", ""
+		cluster := &Cluster{
+			Kubeconfig:  "main kubeconfig",
+			Kubeconfigs: &map[string]string{"edge-1": "edge kubeconfig"},
+			KymaConfig: KymaConfig{Components: []Component{
+				{Component: "istio"},
+				{Component: "serverless", Target: &main},
+				{Component: "edge-agent", Target: &edge},
+			}},
+		}
+		require.NoError(t, cluster.ValidateTargets())
+		require.Equal(t, "", cluster.KymaConfig.Components[0].GetTarget())
+		require.Equal(t, "edge-1", cluster.KymaConfig.Components[2].GetTarget())
+
+		cluster.KymaConfig.Components[2].Target = &unknown
+		err := cluster.ValidateTargets()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "'edge-agent'")
+
+		require.Error(t, (&Cluster{Kubeconfigs: &map[string]string{"Edge_1": "edge kubeconfig"}}).ValidateTargets())
+		require.Error(t, (&Cluster{Kubeconfigs: &map[string]string{"edge-1": " "}}).ValidateTargets())
+		require.NoError(t, (&Cluster{Kubeconfigs: &map[string]string{}}).ValidateTargets())
+		require.Nil(t, (&Cluster{Kubeconfigs: &map[string]string{}}).GetKubeconfigs())
+	})
 }
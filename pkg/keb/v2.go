@@ -0,0 +1,143 @@
+package keb
+
+import (
+	"fmt"
+	"sort"
+)
+
+//oidcConfigPrefix is the configuration key prefix of the OIDC settings of a cluster
+const oidcConfigPrefix = "global.oidc."
+
+//ToCluster converts the v2 payload of a cluster to the cluster model which is used by all contract versions
+func (c *ClusterV2) ToCluster() (*Cluster, error) {
+	oidcConfig := c.KymaConfig.Oidc.configuration()
+	components := make([]Component, 0, len(c.KymaConfig.Components))
+	for _, componentV2 := range c.KymaConfig.Components {
+		component, err := componentV2.toComponent(oidcConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration of component '%s': %s", componentV2.Component, err)
+		}
+		components = append(components, *component)
+	}
+
+	administrators := []string{}
+	if c.KymaConfig.Administrators != nil {
+		administrators = *c.KymaConfig.Administrators
+	}
+
+	metadata := c.Metadata
+	if c.Labels != nil {
+		labels := make(map[string]string)
+		if metadata.Labels != nil {
+			for key, value := range *metadata.Labels {
+				labels[key] = value
+			}
+		}
+		for key, value := range *c.Labels {
+			labels[key] = value
+		}
+		metadata.Labels = &labels
+	}
+
+	return &Cluster{
+		DeletionProtection: c.DeletionProtection,
+		Kubeconfig:         c.Kubeconfig,
+		Kubeconfigs:        c.Kubeconfigs,
+		KymaConfig: KymaConfig{
+			Administrators: administrators,
+			Components:     components,
+			Profile:        c.KymaConfig.Profile,
+			Template:       c.KymaConfig.Template,
+			Version:        c.KymaConfig.Version,
+		},
+		Metadata:     metadata,
+		RuntimeID:    c.RuntimeID,
+		RuntimeInput: c.RuntimeInput,
+	}, nil
+}
+
+//toComponent flattens the configuration and secrets of the component: the OIDC settings of the cluster are added
+//unless the component overrides them
+func (c *ComponentV2) toComponent(oidcConfig map[string]interface{}) (*Component, error) {
+	values := make(map[string]interface{})
+	if c.Configuration != nil {
+		flatten("", *c.Configuration, values)
+	}
+	secrets := make(map[string]interface{})
+	if c.Secrets != nil {
+		flatten("", *c.Secrets, secrets)
+	}
+	for key, value := range oidcConfig {
+		_, isValue := values[key]
+		_, isSecret := secrets[key]
+		if !isValue && !isSecret {
+			values[key] = value
+		}
+	}
+
+	configuration := make([]Configuration, 0, len(values)+len(secrets))
+	for key, value := range values {
+		if _, ok := secrets[key]; ok {
+			return nil, fmt.Errorf("key '%s' is defined as configuration and as secret", key)
+		}
+		configuration = append(configuration, Configuration{Key: key, Value: value})
+	}
+	for key, value := range secrets {
+		configuration = append(configuration, Configuration{Key: key, Value: value, Secret: true})
+	}
+	//the order of map entries is random: sort the keys to get the same configuration for the same payload
+	sort.Slice(configuration, func(i, j int) bool {
+		return configuration[i].Key < configuration[j].Key
+	})
+
+	component := &Component{
+		Component:      c.Component,
+		Configuration:  configuration,
+		ExecutionHints: c.ExecutionHints,
+		Namespace:      c.Namespace,
+		Target:         c.Target,
+		Version:        c.Version,
+	}
+	if c.URL != nil {
+		component.URL = *c.URL
+	}
+	return component, nil
+}
+
+//configuration returns the OIDC settings as configuration values
+func (o *OidcConfig) configuration() map[string]interface{} {
+	if o == nil {
+		return nil
+	}
+	result := map[string]interface{}{
+		oidcConfigPrefix + "clientID":  o.ClientID,
+		oidcConfigPrefix + "issuerURL": o.IssuerURL,
+	}
+	if o.GroupsClaim != nil {
+		result[oidcConfigPrefix+"groupsClaim"] = *o.GroupsClaim
+	}
+	if o.UsernameClaim != nil {
+		result[oidcConfigPrefix+"usernameClaim"] = *o.UsernameClaim
+	}
+	if o.UsernamePrefix != nil {
+		result[oidcConfigPrefix+"usernamePrefix"] = *o.UsernamePrefix
+	}
+	if o.SigningAlgs != nil {
+		result[oidcConfigPrefix+"signingAlgs"] = *o.SigningAlgs
+	}
+	return result
+}
+
+//flatten converts nested objects to dot-separated keys (e.g. {"global":{"domainName":"x"}} to "global.domainName")
+func flatten(prefix string, values map[string]interface{}, result map[string]interface{}) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flatten(key, nested, result)
+			continue
+		}
+		result[key] = value
+	}
+}
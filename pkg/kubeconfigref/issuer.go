@@ -0,0 +1,126 @@
+package kubeconfigref
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	//TokenHeader transports the token when a component reconciler fetches the kubeconfig
+	TokenHeader = "X-Reconciler-Kubeconfig-Token"
+
+	defaultTokenTTL = 10 * time.Minute
+	minKeyLength    = 32
+)
+
+//InvalidTokenError is returned if a token is malformed, wasn't signed by the mothership or is expired
+type InvalidTokenError struct {
+	Reason string
+}
+
+func (e *InvalidTokenError) Error() string {
+	return fmt.Sprintf("kubeconfig token is invalid: %s", e.Reason)
+}
+
+func IsInvalidTokenError(err error) bool {
+	var tokenErr *InvalidTokenError
+	return errors.As(err, &tokenErr)
+}
+
+//Claims identify the operation whose component reconciler is allowed to fetch the kubeconfig of the cluster
+type Claims struct {
+	RuntimeID     string `json:"runtimeID"`
+	ConfigVersion int64  `json:"configVersion"`
+	SchedulingID  string `json:"schedulingID"`
+	CorrelationID string `json:"correlationID"`
+	//Expires is the unix timestamp (in seconds) until the token is accepted
+	Expires int64 `json:"exp"`
+}
+
+//Issuer signs and verifies the tokens which reference kubeconfigs. The tokens are stateless: each mothership
+//replica which uses the same signing key accepts them.
+type Issuer struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+//NewIssuer returns the issuer or nil if the kubeconfigs are embedded in the tasks
+func NewIssuer(cfg config.KubeconfigDeliveryConfig) (*Issuer, error) {
+	if !cfg.ByReference {
+		return nil, nil
+	}
+	ttl := defaultTokenTTL
+	if cfg.TokenTTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(cfg.TokenTTL); err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("kubeconfig token TTL '%s' is invalid: it has to be a positive duration", cfg.TokenTTL)
+		}
+	}
+	if cfg.SigningKeyFile == "" {
+		return nil, errors.New("signing key file is required if kubeconfigs are delivered by reference")
+	}
+	key, err := ioutil.ReadFile(cfg.SigningKeyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read signing key file '%s'", cfg.SigningKeyFile)
+	}
+	return newIssuer([]byte(strings.TrimSpace(string(key))), ttl)
+}
+
+func newIssuer(key []byte, ttl time.Duration) (*Issuer, error) {
+	if len(key) < minKeyLength {
+		return nil, fmt.Errorf("signing key of kubeconfig tokens has to contain at least %d bytes", minKeyLength)
+	}
+	return &Issuer{key: key, ttl: ttl, now: time.Now}, nil
+}
+
+//Issue returns a token for the claims and the time until it's valid (the expiry of the claims is overwritten)
+func (i *Issuer) Issue(claims Claims) (string, time.Time, error) {
+	expires := i.now().Add(i.ttl).Truncate(time.Second)
+	claims.Expires = expires.Unix()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "failed to encode claims of kubeconfig token")
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + i.sign(encoded), expires, nil
+}
+
+//Verify returns the claims of a valid token
+func (i *Issuer) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, &InvalidTokenError{Reason: "token is malformed"}
+	}
+	if !hmac.Equal([]byte(parts[1]), []byte(i.sign(parts[0]))) {
+		return nil, &InvalidTokenError{Reason: "signature doesn't match"}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, &InvalidTokenError{Reason: "claims are not base64 encoded"}
+	}
+	claims := &Claims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, &InvalidTokenError{Reason: "claims are not JSON encoded"}
+	}
+	if i.now().Unix() > claims.Expires {
+		return nil, &InvalidTokenError{Reason: fmt.Sprintf("token expired at %s",
+			time.Unix(claims.Expires, 0).UTC().Format(time.RFC3339))}
+	}
+	return claims, nil
+}
+
+func (i *Issuer) sign(encodedClaims string) string {
+	mac := hmac.New(sha256.New, i.key)
+	mac.Write([]byte(encodedClaims))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,86 @@
+package kubeconfigref
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssuer(t *testing.T) {
+	key := []byte(strings.Repeat("k", minKeyLength))
+	claims := Claims{RuntimeID: "abc", ConfigVersion: 3, SchedulingID: "s1", CorrelationID: "c1"}
+
+	t.Run("Issue and verify token", func(t *testing.T) {
+		issuer, err := newIssuer(key, time.Minute)
+		require.NoError(t, err)
+		token, expires, err := issuer.Issue(claims)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(time.Minute), expires, 2*time.Second)
+
+		verified, err := issuer.Verify(token)
+		require.NoError(t, err)
+		claims.Expires = expires.Unix()
+		require.Equal(t, &claims, verified)
+
+		//tokens are accepted by all issuers with the same key (e.g. other mothership replicas)
+		otherReplica, err := newIssuer(key, time.Hour)
+		require.NoError(t, err)
+		_, err = otherReplica.Verify(token)
+		require.NoError(t, err)
+	})
+
+	t.Run("Reject invalid tokens", func(t *testing.T) {
+		issuer, err := newIssuer(key, time.Minute)
+		require.NoError(t, err)
+		token, _, err := issuer.Issue(claims)
+		require.NoError(t, err)
+
+		otherKey, err := newIssuer([]byte(strings.Repeat("x", minKeyLength)), time.Minute)
+		require.NoError(t, err)
+		_, err = otherKey.Verify(token)
+		require.True(t, IsInvalidTokenError(err))
+
+		parts := strings.Split(token, ".")
+		forged, _, err := otherKey.Issue(Claims{RuntimeID: "xyz"})
+		require.NoError(t, err)
+		_, err = issuer.Verify(strings.Split(forged, ".")[0] + "." + parts[1])
+		require.True(t, IsInvalidTokenError(err), "claims were replaced")
+
+		for _, malformed := range []string{"", "abc", "a.b.c"} {
+			_, err = issuer.Verify(malformed)
+			require.True(t, IsInvalidTokenError(err))
+		}
+
+		issuer.now = func() time.Time {
+			return time.Now().Add(2 * time.Minute)
+		}
+		_, err = issuer.Verify(token)
+		require.True(t, IsInvalidTokenError(err))
+		require.Contains(t, err.Error(), "expired")
+	})
+
+	t.Run("Configuration", func(t *testing.T) {
+		issuer, err := NewIssuer(config.KubeconfigDeliveryConfig{})
+		require.NoError(t, err)
+		require.Nil(t, issuer, "kubeconfigs are embedded by default")
+
+		keyFile := filepath.Join(t.TempDir(), "key")
+		require.NoError(t, ioutil.WriteFile(keyFile, append(key, '\n'), 0600))
+		issuer, err = NewIssuer(config.KubeconfigDeliveryConfig{ByReference: true, SigningKeyFile: keyFile})
+		require.NoError(t, err)
+		require.Equal(t, defaultTokenTTL, issuer.ttl)
+		require.Equal(t, key, issuer.key)
+
+		_, err = NewIssuer(config.KubeconfigDeliveryConfig{ByReference: true})
+		require.Error(t, err)
+		_, err = NewIssuer(config.KubeconfigDeliveryConfig{ByReference: true, SigningKeyFile: keyFile, TokenTTL: "-1m"})
+		require.Error(t, err)
+		_, err = newIssuer([]byte("short"), time.Minute)
+		require.Error(t, err)
+	})
+}
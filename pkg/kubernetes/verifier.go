@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"fmt"
+	"time"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+//KubeconfigErrorReason explains why a kubeconfig was rejected
+type KubeconfigErrorReason string
+
+const (
+	//KubeconfigInvalid kubeconfig can't be parsed or doesn't define a usable cluster
+	KubeconfigInvalid KubeconfigErrorReason = "invalid"
+	//KubeconfigUnauthorized credentials of the kubeconfig were not accepted by the API server
+	KubeconfigUnauthorized KubeconfigErrorReason = "unauthorized"
+	//KubeconfigForbidden credentials are valid but not permitted to access the API server
+	KubeconfigForbidden KubeconfigErrorReason = "forbidden"
+	//KubeconfigUnreachable API server didn't respond successfully within the timeout
+	KubeconfigUnreachable KubeconfigErrorReason = "unreachable"
+)
+
+type KubeconfigError struct {
+	Reason KubeconfigErrorReason
+	Host   string
+	err    error
+}
+
+func (e *KubeconfigError) Error() string {
+	if e.Host == "" {
+		return fmt.Sprintf("kubeconfig is %s: %s", e.Reason, e.err)
+	}
+	return fmt.Sprintf("kubeconfig of cluster %s is %s: %s", e.Host, e.Reason, e.err)
+}
+
+func (e *KubeconfigError) Unwrap() error {
+	return e.err
+}
+
+func IsKubeconfigError(err error) bool {
+	_, ok := err.(*KubeconfigError)
+	return ok
+}
+
+//ValidateKubeconfig checks the structure of the kubeconfig (the current context has to refer to a cluster with a
+//server) without calling the API server
+func ValidateKubeconfig(kubeconfig string) error {
+	_, err := restConfig(kubeconfig)
+	return err
+}
+
+func restConfig(kubeconfig string) (*rest.Config, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeconfig))
+	if err != nil {
+		return nil, &KubeconfigError{Reason: KubeconfigInvalid, err: err}
+	}
+	return config, nil
+}
+
+//VerifyKubeconfig performs a cheap authenticated call (retrieval of the server version) with the credentials of the
+//kubeconfig. In contrast to the validation of the ClientBuilder, the call isn't retried: a returned KubeconfigError
+//explains why the kubeconfig was rejected.
+func VerifyKubeconfig(kubeconfig string, timeout time.Duration) error {
+	config, err := restConfig(kubeconfig)
+	if err != nil {
+		return err
+	}
+	config.Timeout = timeout
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return &KubeconfigError{Reason: KubeconfigInvalid, Host: config.Host, err: err}
+	}
+	if _, err := discoveryClient.ServerVersion(); err != nil {
+		reason := KubeconfigUnreachable
+		if k8serr.IsUnauthorized(err) {
+			reason = KubeconfigUnauthorized
+		} else if k8serr.IsForbidden(err) {
+			reason = KubeconfigForbidden
+		}
+		return &KubeconfigError{Reason: reason, Host: config.Host, err: err}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyKubeconfig(t *testing.T) {
+	t.Parallel()
+
+	newKubeconfig := func(server, token string) string {
+		return fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: %s
+`, server, token)
+	}
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Header.Get("Authorization") {
+		case "Bearer valid":
+			_, _ = w.Write([]byte(`{"major":"1","minor":"23","gitVersion":"v1.23.4"}`))
+		case "Bearer forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Unauthorized","code":401}`))
+		}
+	}))
+	defer apiServer.Close()
+
+	requireReason := func(t *testing.T, err error, reason KubeconfigErrorReason) {
+		require.Error(t, err)
+		require.True(t, IsKubeconfigError(err))
+		require.Equal(t, reason, err.(*KubeconfigError).Reason)
+	}
+
+	t.Run("Valid credentials", func(t *testing.T) {
+		require.NoError(t, VerifyKubeconfig(newKubeconfig(apiServer.URL, "valid"), time.Second))
+	})
+
+	t.Run("Invalid kubeconfig", func(t *testing.T) {
+		requireReason(t, VerifyKubeconfig("not a kubeconfig", time.Second), KubeconfigInvalid)
+	})
+
+	t.Run("Validate structure", func(t *testing.T) {
+		require.NoError(t, ValidateKubeconfig(newKubeconfig("https://0.0.0.0:12345", "valid")))
+		requireReason(t, ValidateKubeconfig("not a kubeconfig"), KubeconfigInvalid)
+		requireReason(t, ValidateKubeconfig("apiVersion: v1\nkind: Config\n"), KubeconfigInvalid)
+	})
+
+	t.Run("Rejected credentials", func(t *testing.T) {
+		requireReason(t, VerifyKubeconfig(newKubeconfig(apiServer.URL, "expired"), time.Second), KubeconfigUnauthorized)
+		requireReason(t, VerifyKubeconfig(newKubeconfig(apiServer.URL, "forbidden"), time.Second), KubeconfigForbidden)
+	})
+
+	t.Run("Unreachable API server", func(t *testing.T) {
+		err := VerifyKubeconfig(newKubeconfig("https://0.0.0.0:12345", "valid"), time.Second)
+		requireReason(t, err, KubeconfigUnreachable)
+		require.Contains(t, err.Error(), "https://0.0.0.0:12345")
+	})
+}
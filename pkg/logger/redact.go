@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/redact"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+//redactingCore removes sensitive data from log messages and log fields before they are written
+type redactingCore struct {
+	zapcore.Core
+}
+
+func newRedactingCore(core zapcore.Core) zapcore.Core {
+	return &redactingCore{core}
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{c.Core.With(redactFields(fields))}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = redact.String(entry.Message)
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	redactor := redact.Default()
+	result := make([]zapcore.Field, 0, len(fields))
+	for _, field := range fields {
+		if field.Type != zapcore.BoolType && redactor.IsSensitiveKey(field.Key) {
+			result = append(result, zap.String(field.Key, redact.Mask))
+			continue
+		}
+		switch field.Type {
+		case zapcore.StringType:
+			field.String = redactor.String(field.String)
+		case zapcore.ErrorType:
+			if err, ok := field.Interface.(error); ok {
+				field = zap.NamedError(field.Key, redactor.Error(err))
+			}
+		case zapcore.StringerType:
+			if stringer, ok := field.Interface.(fmt.Stringer); ok {
+				field = zap.String(field.Key, redactor.String(stringer.String()))
+			}
+		case zapcore.ReflectType:
+			field = zap.Any(field.Key, redactor.Value(field.Key, field.Interface))
+		}
+		result = append(result, field)
+	}
+	return result
+}
@@ -26,7 +26,7 @@ func NewLogger(debug bool) *zap.SugaredLogger {
 }
 
 func NewTestLogger(t *testing.T) *zap.SugaredLogger {
-	return zaptest.NewLogger(t).Sugar()
+	return zaptest.NewLogger(t, zaptest.WrapOptions(zap.WrapCore(newRedactingCore))).Sugar()
 }
 
 func newLogger(logLevel zapcore.Level) *zap.Logger {
@@ -49,11 +49,11 @@ func newLogger(logLevel zapcore.Level) *zap.Logger {
 	}
 
 	return zap.New(
-		zapcore.NewCore(
+		newRedactingCore(zapcore.NewCore(
 			encoder,
 			zapcore.Lock(os.Stderr),
 			zap.NewAtomicLevelAt(logLevel),
-		),
+		)),
 		zap.ErrorOutput(os.Stderr))
 }
 
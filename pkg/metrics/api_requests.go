@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// APIRequestsMetric tracks requests of the mothership API per contract version:
+// - reconciler_api_requests_total - amount of handled requests
+// - reconciler_api_request_duration_seconds - duration of handled requests
+type APIRequestsMetric struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	logger   *zap.SugaredLogger
+}
+
+func NewAPIRequestsMetric(logger *zap.SugaredLogger) *APIRequestsMetric {
+	labels := []string{"contract_version", "route", "method", "code"}
+	return &APIRequestsMetric{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "api_requests_total",
+			Help:      "Requests handled by the mothership API per contract version",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "api_request_duration_seconds",
+			Help:      "Duration of requests handled by the mothership API per contract version",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+		logger: logger,
+	}
+}
+
+func (c *APIRequestsMetric) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+}
+
+func (c *APIRequestsMetric) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+}
+
+func (c *APIRequestsMetric) ExposeRequest(contractVersion, route, method string, code int, duration time.Duration) {
+	labels := []string{contractVersion, route, method, strconv.Itoa(code)}
+	counter, err := c.requests.GetMetricWithLabelValues(labels...)
+	if err != nil {
+		c.logger.Errorf("APIRequestsMetric: unable to retrieve request counter with labels=%v: %s", labels, err)
+		return
+	}
+	counter.Inc()
+	histogram, err := c.duration.GetMetricWithLabelValues(labels...)
+	if err != nil {
+		c.logger.Errorf("APIRequestsMetric: unable to retrieve request duration with labels=%v: %s", labels, err)
+		return
+	}
+	histogram.Observe(duration.Seconds())
+}
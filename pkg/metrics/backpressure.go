@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/backpressure"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// BackpressureCollector provides the state of the backpressure which throttles the mothership while its database is slow:
+// - reconciler_db_backpressure_dispatch_ratio - share of the free workers which receive operations
+// - reconciler_db_backpressure_latency_seconds - average latency of the database operations of the latest check
+// - reconciler_db_backpressure_error_ratio - ratio of the failed database operations of the latest check
+// - reconciler_db_backpressure_throttled - 1 if the mothership is throttled, otherwise 0
+// - reconciler_db_backpressure_skipped_status_writes_total - amount of interim operation statuses which weren't written
+type BackpressureCollector struct {
+	controller *backpressure.Controller
+	logger     *zap.SugaredLogger
+
+	dispatchRatioDesc       *prometheus.Desc
+	latencyDesc             *prometheus.Desc
+	errorRatioDesc          *prometheus.Desc
+	throttledDesc           *prometheus.Desc
+	skippedStatusWritesDesc *prometheus.Desc
+}
+
+func NewBackpressureCollector(controller *backpressure.Controller, logger *zap.SugaredLogger) *BackpressureCollector {
+	return &BackpressureCollector{
+		controller: controller,
+		logger:     logger,
+		dispatchRatioDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "db_backpressure_dispatch_ratio"),
+			"Share of the free workers which receive operations (1 if the mothership isn't throttled)",
+			nil,
+			nil),
+		latencyDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "db_backpressure_latency_seconds"),
+			"Average latency of the database operations observed by the latest backpressure check",
+			nil,
+			nil),
+		errorRatioDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "db_backpressure_error_ratio"),
+			"Ratio of the failed database operations observed by the latest backpressure check",
+			nil,
+			nil),
+		throttledDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "db_backpressure_throttled"),
+			"Indicates whether the mothership is throttled because its database is slow or failing",
+			nil,
+			nil),
+		skippedStatusWritesDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "db_backpressure_skipped_status_writes_total"),
+			"Unchanged interim operation statuses which weren't written because the mothership was throttled",
+			nil,
+			nil),
+	}
+}
+
+func (c *BackpressureCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.dispatchRatioDesc
+	ch <- c.latencyDesc
+	ch <- c.errorRatioDesc
+	ch <- c.throttledDesc
+	ch <- c.skippedStatusWritesDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *BackpressureCollector) Collect(ch chan<- prometheus.Metric) {
+	state := c.controller.State()
+	var throttled float64
+	if state.Throttled() {
+		throttled = 1
+	}
+	c.collect(ch, c.dispatchRatioDesc, prometheus.GaugeValue, state.DispatchRatio)
+	c.collect(ch, c.latencyDesc, prometheus.GaugeValue, state.Latency.Seconds())
+	c.collect(ch, c.errorRatioDesc, prometheus.GaugeValue, state.ErrorRatio)
+	c.collect(ch, c.throttledDesc, prometheus.GaugeValue, throttled)
+	c.collect(ch, c.skippedStatusWritesDesc, prometheus.CounterValue, float64(state.SkippedStatusWrites))
+}
+
+func (c *BackpressureCollector) collect(ch chan<- prometheus.Metric, desc *prometheus.Desc, valueType prometheus.ValueType, value float64) {
+	m, err := prometheus.NewConstMetric(desc, valueType, value)
+	if err != nil {
+		c.logger.Errorf("unable to register metric %s", err.Error())
+		return
+	}
+	ch <- m
+}
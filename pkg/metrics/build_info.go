@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/version"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// BuildInfoCollector provides build information of the running binary:
+// - reconciler_build_info - constant 1, labelled with git commit, build date, Go version and supported contract versions
+type BuildInfoCollector struct {
+	logger *zap.SugaredLogger
+
+	buildInfoDesc *prometheus.Desc
+}
+
+func NewBuildInfoCollector(logger *zap.SugaredLogger) *BuildInfoCollector {
+	return &BuildInfoCollector{
+		logger: logger,
+		buildInfoDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "build_info"),
+			"Build information of the running reconciler",
+			[]string{"git_commit", "build_date", "go_version", "contract_versions"},
+			nil),
+	}
+}
+
+func (c *BuildInfoCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.buildInfoDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *BuildInfoCollector) Collect(ch chan<- prometheus.Metric) {
+	info := version.Get()
+	m, err := prometheus.NewConstMetric(c.buildInfoDesc, prometheus.GaugeValue, 1,
+		info.GitCommit, info.BuildDate, info.GoVersion, info.ContractVersionsString())
+	if err != nil {
+		c.logger.Errorf("unable to register metric %s", err.Error())
+		return
+	}
+
+	ch <- m
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	//CallbackIgnoredDuplicate is used for callbacks which were already processed
+	CallbackIgnoredDuplicate = "duplicate"
+	//CallbackIgnoredStale is used for callbacks which arrived after a newer callback of the same operation
+	CallbackIgnoredStale = "stale"
+	//CallbackIgnoredTerminalState is used for callbacks which would change the final state of an operation
+	CallbackIgnoredTerminalState = "terminal_state"
+	//CallbackIgnoredAborted is used for callbacks of operations whose reconciliation was cancelled
+	CallbackIgnoredAborted = "aborted"
+	//CallbackIgnoredThrottled is used for unchanged interim statuses which weren't written while the database is slow
+	CallbackIgnoredThrottled = "throttled"
+)
+
+// IgnoredCallbacksMetric counts callbacks of component reconcilers which were not applied to their operation:
+// - reconciler_callbacks_ignored_total - amount of ignored callbacks per component and reason
+type IgnoredCallbacksMetric struct {
+	callbacks *prometheus.CounterVec
+	logger    *zap.SugaredLogger
+}
+
+func NewIgnoredCallbacksMetric(logger *zap.SugaredLogger) *IgnoredCallbacksMetric {
+	return &IgnoredCallbacksMetric{
+		callbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "callbacks_ignored_total",
+			Help:      "Duplicated or outdated callbacks of component reconcilers which were ignored by the mothership",
+		}, []string{"component", "reason"}),
+		logger: logger,
+	}
+}
+
+func (c *IgnoredCallbacksMetric) Describe(ch chan<- *prometheus.Desc) {
+	c.callbacks.Describe(ch)
+}
+
+func (c *IgnoredCallbacksMetric) Collect(ch chan<- prometheus.Metric) {
+	c.callbacks.Collect(ch)
+}
+
+func (c *IgnoredCallbacksMetric) ExposeIgnoredCallback(component, reason string) {
+	counter, err := c.callbacks.GetMetricWithLabelValues(component, reason)
+	if err != nil {
+		c.logger.Errorf("IgnoredCallbacksMetric: unable to retrieve counter with labels=[%s %s]: %s",
+			component, reason, err)
+		return
+	}
+	counter.Inc()
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+//clusterCostWindow is the time range of the reconciliations which are considered for the cost of a cluster
+const clusterCostWindow = 24 * time.Hour
+
+// ClusterCostCollector provides the resource usage of the reconciliations of the last 24h per cluster. The global
+// account of the cluster is added as label to support the aggregation per tenant:
+// - reconciler_cluster_cost_wall_time_seconds - duration of the reconciliations
+// - reconciler_cluster_cost_processing_time_seconds - processing time reported by the component reconcilers
+// - reconciler_cluster_cost_api_calls - requests sent to the API server of the cluster
+// - reconciler_cluster_cost_manifest_bytes - size of the manifests deployed on the cluster
+type ClusterCostCollector struct {
+	reconRepo reconciliation.Repository
+	inventory cluster.Inventory
+	logger    *zap.SugaredLogger
+
+	wallTimeDesc       *prometheus.Desc
+	processingTimeDesc *prometheus.Desc
+	apiCallsDesc       *prometheus.Desc
+	manifestBytesDesc  *prometheus.Desc
+}
+
+func NewClusterCostCollector(reconciliations reconciliation.Repository, inventory cluster.Inventory, logger *zap.SugaredLogger) *ClusterCostCollector {
+	labels := []string{"runtime_id", "global_account_id"}
+	return &ClusterCostCollector{
+		reconRepo: reconciliations,
+		inventory: inventory,
+		logger:    logger,
+		wallTimeDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "cluster_cost_wall_time_seconds"),
+			"Duration of the reconciliations of a cluster within the last 24h",
+			labels,
+			nil),
+		processingTimeDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "cluster_cost_processing_time_seconds"),
+			"Processing time reported by the component reconcilers for a cluster within the last 24h",
+			labels,
+			nil),
+		apiCallsDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "cluster_cost_api_calls"),
+			"Requests sent to the API server of a cluster by the component reconcilers within the last 24h",
+			labels,
+			nil),
+		manifestBytesDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "cluster_cost_manifest_bytes"),
+			"Size of the manifests deployed on a cluster by the component reconcilers within the last 24h",
+			labels,
+			nil),
+	}
+}
+
+func (c *ClusterCostCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.wallTimeDesc
+	ch <- c.processingTimeDesc
+	ch <- c.apiCallsDesc
+	ch <- c.manifestBytesDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *ClusterCostCollector) Collect(ch chan<- prometheus.Metric) {
+	now := time.Now().UTC()
+	costs, err := reconciliation.CostPerCluster(c.reconRepo, now.Add(-clusterCostWindow), now)
+	if err != nil {
+		c.logger.Errorf("unable to retrieve cost of clusters: %s", err)
+		return
+	}
+	if len(costs) == 0 {
+		return
+	}
+
+	globalAccounts := make(map[string]string)
+	states, err := c.inventory.GetAll()
+	if err != nil {
+		c.logger.Warnf("unable to retrieve global accounts of clusters: %s", err)
+	}
+	for _, state := range states {
+		if state.Cluster != nil && state.Cluster.Metadata != nil {
+			globalAccounts[state.Cluster.RuntimeID] = state.Cluster.Metadata.GlobalAccountID
+		}
+	}
+
+	for runtimeID, cost := range costs {
+		labels := []string{runtimeID, globalAccounts[runtimeID]}
+		c.collect(ch, c.wallTimeDesc, cost.WallTime.Seconds(), labels)
+		c.collect(ch, c.processingTimeDesc, cost.ProcessingTime.Seconds(), labels)
+		c.collect(ch, c.apiCallsDesc, float64(cost.APICalls), labels)
+		c.collect(ch, c.manifestBytesDesc, float64(cost.ManifestBytes), labels)
+	}
+}
+
+func (c *ClusterCostCollector) collect(ch chan<- prometheus.Metric, desc *prometheus.Desc, value float64, labels []string) {
+	m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+	if err != nil {
+		c.logger.Errorf("unable to register metric %s", err.Error())
+		return
+	}
+	ch <- m
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/deadletter"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// DeadLettersCollector provides the size of the dead-letter queue:
+// - reconciler_deadletter_operations - number of open dead letters per component and operation type
+type DeadLettersCollector struct {
+	deadLetterRepo *deadletter.Repository
+	logger         *zap.SugaredLogger
+
+	deadLettersDesc *prometheus.Desc
+}
+
+func NewDeadLettersCollector(deadLetters *deadletter.Repository, logger *zap.SugaredLogger) *DeadLettersCollector {
+	return &DeadLettersCollector{
+		deadLetterRepo: deadLetters,
+		logger:         logger,
+		deadLettersDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "deadletter_operations"),
+			"Number of operations which failed permanently and were neither requeued nor acknowledged",
+			[]string{"component", "type"},
+			nil),
+	}
+}
+
+func (c *DeadLettersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.deadLettersDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *DeadLettersCollector) Collect(ch chan<- prometheus.Metric) {
+	deadLetters, err := c.deadLetterRepo.List(model.DeadLetterStateOpen, "")
+	if err != nil {
+		c.logger.Errorf("unable to retrieve open dead letters: %s", err)
+		return
+	}
+
+	type key struct {
+		component string
+		opType    model.OperationType
+	}
+	counts := make(map[key]int)
+	for _, deadLetter := range deadLetters {
+		counts[key{component: deadLetter.Component, opType: deadLetter.Type}]++
+	}
+	for k, count := range counts {
+		m, err := prometheus.NewConstMetric(c.deadLettersDesc, prometheus.GaugeValue, float64(count), k.component, string(k.opType))
+		if err != nil {
+			c.logger.Errorf("unable to register metric %s", err.Error())
+			continue
+		}
+		ch <- m
+	}
+}
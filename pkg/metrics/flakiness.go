@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/flaky"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// FlakinessCollector provides the flakiness of the components across the fleet:
+// - reconciler_component_flakiness_ratio - ratio of the operations which succeeded only after a retry
+// - reconciler_component_quarantined - 1 if the component is quarantined, otherwise 0
+type FlakinessCollector struct {
+	classifier *flaky.Classifier
+	logger     *zap.SugaredLogger
+
+	ratioDesc       *prometheus.Desc
+	quarantinedDesc *prometheus.Desc
+}
+
+func NewFlakinessCollector(classifier *flaky.Classifier, logger *zap.SugaredLogger) *FlakinessCollector {
+	labels := []string{"component"}
+	return &FlakinessCollector{
+		classifier: classifier,
+		logger:     logger,
+		ratioDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "component_flakiness_ratio"),
+			"Ratio of the operations of a component which succeeded only after a retry",
+			labels,
+			nil),
+		quarantinedDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "component_quarantined"),
+			"Indicates whether a component is quarantined because of its flakiness",
+			labels,
+			nil),
+	}
+}
+
+func (c *FlakinessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ratioDesc
+	ch <- c.quarantinedDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *FlakinessCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, classification := range c.classifier.Classifications() {
+		var quarantined float64
+		if classification.Quarantined {
+			quarantined = 1
+		}
+		c.collect(ch, c.ratioDesc, classification.Ratio, classification.Component)
+		c.collect(ch, c.quarantinedDesc, quarantined, classification.Component)
+	}
+}
+
+func (c *FlakinessCollector) collect(ch chan<- prometheus.Metric, desc *prometheus.Desc, value float64, labels ...string) {
+	m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+	if err != nil {
+		c.logger.Errorf("unable to register metric %s", err.Error())
+		return
+	}
+	ch <- m
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/health"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// HealthCollector provides the health of the clusters:
+// - reconciler_cluster_health_score - health score of a cluster between 0 (needs attention) and 100 (healthy)
+type HealthCollector struct {
+	scorer *health.Scorer
+	logger *zap.SugaredLogger
+
+	scoreDesc *prometheus.Desc
+}
+
+func NewHealthCollector(scorer *health.Scorer, logger *zap.SugaredLogger) *HealthCollector {
+	return &HealthCollector{
+		scorer: scorer,
+		logger: logger,
+		scoreDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "cluster_health_score"),
+			"Health score of a cluster derived from its recent reconciliations (0 = needs attention, 100 = healthy)",
+			[]string{"runtime_id"},
+			nil),
+	}
+}
+
+func (c *HealthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.scoreDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *HealthCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, score := range c.scorer.Scores() {
+		m, err := prometheus.NewConstMetric(c.scoreDesc, prometheus.GaugeValue, float64(score.Score), score.RuntimeID)
+		if err != nil {
+			c.logger.Errorf("unable to register metric %s", err.Error())
+			continue
+		}
+		ch <- m
+	}
+}
@@ -1,79 +1,163 @@
 package metrics
 
 import (
+	"fmt"
+
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/deadletter"
 	"github.com/kyma-incubator/reconciler/pkg/features"
+	"github.com/kyma-incubator/reconciler/pkg/ratelimit"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/watchdog"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/backpressure"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/flaky"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/health"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/occupancy"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/slo"
+	"github.com/kyma-incubator/reconciler/pkg/subscription"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
+//register registers the collector: an already registered collector is skipped with a warning (the name
+//describes the metrics of the collector)
+func register(collector prometheus.Collector, name string, logger *zap.SugaredLogger) error {
+	err := prometheus.Register(collector)
+	switch err := err.(type) {
+	case prometheus.AlreadyRegisteredError:
+		logger.Warnf("skipping registration of %s as they were already registered, existing: %v",
+			name, err.ExistingCollector)
+		return nil
+	}
+	return err
+}
+
+//registerOrExisting registers the collector or returns the already registered collector
+func registerOrExisting(collector prometheus.Collector) (prometheus.Collector, error) {
+	err := prometheus.Register(collector)
+	if alreadyRegisteredErr, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		return alreadyRegisteredErr.ExistingCollector, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return collector, nil
+}
+
 func RegisterProcessingDuration(reconciliations reconciliation.Repository, logger *zap.SugaredLogger) error {
 	if features.Enabled(features.ProcessingDurationMetric) {
-		processingDurationCollector := NewProcessingDurationCollector(reconciliations, logger)
-		err := prometheus.Register(processingDurationCollector)
-		switch err := err.(type) {
-		case prometheus.AlreadyRegisteredError:
-			logger.Warnf("skipping registration of processing duration metrics as they were already registered, existing: %v",
-				err.ExistingCollector)
-			return nil
-		}
-		if err != nil {
-			return err
-		}
+		return register(NewProcessingDurationCollector(reconciliations, logger), "processing duration metrics", logger)
 	}
 	return nil
 }
 
 func RegisterWaitingAndNotReadyReconciliations(inventory cluster.Inventory, logger *zap.SugaredLogger) error {
-	reconciliationWaitingCollector := NewReconciliationWaitingCollector(inventory, logger)
-	reconciliationNotReadyCollector := NewReconciliationNotReadyCollector(inventory, logger)
-	err := prometheus.Register(reconciliationWaitingCollector)
-	if err == nil {
-		err = prometheus.Register(reconciliationNotReadyCollector)
-	}
-	switch err := err.(type) {
-	case prometheus.AlreadyRegisteredError:
-		logger.Warnf("skipping registration of waiting/ready metrics as they were already registered, existing: %v",
-			err.ExistingCollector)
-		return nil
-	}
-	if err != nil {
+	if err := register(NewReconciliationWaitingCollector(inventory, logger), "waiting metrics", logger); err != nil {
 		return err
 	}
-	return nil
+	return register(NewReconciliationNotReadyCollector(inventory, logger), "not-ready metrics", logger)
+}
+
+func RegisterOperationResults(reconciliations reconciliation.Repository, logger *zap.SugaredLogger) error {
+	return register(NewOperationResultsCollector(reconciliations, logger), "operation results metrics", logger)
+}
+
+func RegisterClusterCost(reconciliations reconciliation.Repository, inventory cluster.Inventory, logger *zap.SugaredLogger) error {
+	return register(NewClusterCostCollector(reconciliations, inventory, logger), "cluster cost metrics", logger)
+}
+
+func RegisterSLOs(reconciliations reconciliation.Repository, objectives []*slo.Objective, logger *zap.SugaredLogger) error {
+	return register(NewSLOCollector(reconciliations, objectives, logger), "SLO metrics", logger)
+}
+
+func RegisterFlakiness(classifier *flaky.Classifier, logger *zap.SugaredLogger) error {
+	return register(NewFlakinessCollector(classifier, logger), "flakiness metrics", logger)
+}
+
+func RegisterHealth(scorer *health.Scorer, logger *zap.SugaredLogger) error {
+	return register(NewHealthCollector(scorer, logger), "health metrics", logger)
+}
+
+func RegisterUpdateRateLimit(limiter *ratelimit.UpdateLimiter, logger *zap.SugaredLogger) error {
+	return register(NewUpdateRateLimitCollector(limiter, logger), "update rate limit metrics", logger)
+}
+
+func RegisterBackpressure(controller *backpressure.Controller, logger *zap.SugaredLogger) error {
+	return register(NewBackpressureCollector(controller, logger), "backpressure metrics", logger)
+}
+
+func RegisterSubscriptions(notifier *subscription.Notifier, logger *zap.SugaredLogger) error {
+	return register(NewSubscriptionsCollector(notifier, logger), "subscription metrics", logger)
+}
+
+func RegisterReconciliationETA(reconciliations reconciliation.Repository, logger *zap.SugaredLogger) error {
+	return register(NewReconciliationETACollector(reconciliations, logger), "reconciliation ETA metrics", logger)
+}
+
+func RegisterDeadLetters(deadLetters *deadletter.Repository, logger *zap.SugaredLogger) error {
+	return register(NewDeadLettersCollector(deadLetters, logger), "dead-letter metrics", logger)
 }
 
 func RegisterDbPool(connPool db.Connection, logger *zap.SugaredLogger) error {
-	dbPoolMetricsCollector := NewDbPoolCollector(connPool, logger)
-	err := prometheus.Register(dbPoolMetricsCollector)
-	switch err := err.(type) {
-	case prometheus.AlreadyRegisteredError:
-		logger.Warnf("skipping registration of occupancy metrics as they were already registered, existing: %v",
-			err.ExistingCollector)
-		return nil
-	}
-	if err != nil {
-		return err
-	}
-	return nil
+	return register(NewDbPoolCollector(connPool, logger), "database pool metrics", logger)
 }
 
 func RegisterOccupancy(occupancyRepo occupancy.Repository, reconcilers map[string]config.ComponentReconciler, logger *zap.SugaredLogger) error {
 	if features.Enabled(features.WorkerpoolOccupancyTracking) {
-		err := prometheus.Register(NewWorkerPoolOccupancyCollector(occupancyRepo, reconcilers, logger))
-		switch err := err.(type) {
-		case prometheus.AlreadyRegisteredError:
-			logger.Warnf("skipping registration of occupancy metrics as they were already registered, existing: %v",
-				err.ExistingCollector)
-			return nil
-		}
-		if err != nil {
-			return err
-		}
+		return register(NewWorkerPoolOccupancyCollector(occupancyRepo, reconcilers, logger), "occupancy metrics", logger)
 	}
 	return nil
 }
+
+func RegisterBuildInfo(logger *zap.SugaredLogger) error {
+	return register(NewBuildInfoCollector(logger), "build info metrics", logger)
+}
+
+func RegisterSandboxes(logger *zap.SugaredLogger) error {
+	return register(NewSandboxesCollector(logger), "sandbox metrics", logger)
+}
+
+func RegisterStuckOperations(wd *watchdog.Watchdog, logger *zap.SugaredLogger) error {
+	return register(NewStuckOperationsCollector(wd, logger), "stuck operation metrics", logger)
+}
+
+//RegisterAPIRequests returns the registered API requests metric (an already registered instance is re-used)
+func RegisterAPIRequests(logger *zap.SugaredLogger) (*APIRequestsMetric, error) {
+	collector, err := registerOrExisting(NewAPIRequestsMetric(logger))
+	if err != nil {
+		return nil, err
+	}
+	apiRequestsMetric, ok := collector.(*APIRequestsMetric)
+	if !ok {
+		return nil, fmt.Errorf("API requests metric is already registered by a collector of type %T", collector)
+	}
+	return apiRequestsMetric, nil
+}
+
+//RegisterIgnoredCallbacks returns the registered ignored callbacks metric (an already registered instance is re-used)
+func RegisterIgnoredCallbacks(logger *zap.SugaredLogger) (*IgnoredCallbacksMetric, error) {
+	collector, err := registerOrExisting(NewIgnoredCallbacksMetric(logger))
+	if err != nil {
+		return nil, err
+	}
+	ignoredCallbacksMetric, ok := collector.(*IgnoredCallbacksMetric)
+	if !ok {
+		return nil, fmt.Errorf("ignored callbacks metric is already registered by a collector of type %T", collector)
+	}
+	return ignoredCallbacksMetric, nil
+}
+
+//RegisterPanics returns the registered panics metric (an already registered instance is re-used)
+func RegisterPanics(logger *zap.SugaredLogger) (*PanicsMetric, error) {
+	collector, err := registerOrExisting(NewPanicsMetric(logger))
+	if err != nil {
+		return nil, err
+	}
+	panicsMetric, ok := collector.(*PanicsMetric)
+	if !ok {
+		return nil, fmt.Errorf("panics metric is already registered by a collector of type %T", collector)
+	}
+	return panicsMetric, nil
+}
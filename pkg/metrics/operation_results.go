@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	//operationResultsSampleSize is the number of the latest finished operations per component which are evaluated
+	operationResultsSampleSize = 100
+	unknownReconcilerVersion   = "unknown"
+)
+
+// OperationResultsCollector provides the results of the latest finished operations per component reconciler build:
+// - reconciler_operation_results - number of the latest 100 finished operations of a component per reconciler version and state
+type OperationResultsCollector struct {
+	reconRepo reconciliation.Repository
+	logger    *zap.SugaredLogger
+
+	resultsDesc *prometheus.Desc
+}
+
+func NewOperationResultsCollector(reconciliations reconciliation.Repository, logger *zap.SugaredLogger) *OperationResultsCollector {
+	return &OperationResultsCollector{
+		reconRepo: reconciliations,
+		logger:    logger,
+		resultsDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "operation_results"),
+			"Number of the latest finished operations of a component per reconciler version and state",
+			[]string{"component", "reconciler_version", "state"},
+			nil),
+	}
+}
+
+func (c *OperationResultsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.resultsDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *OperationResultsCollector) Collect(ch chan<- prometheus.Metric) {
+	components, err := c.reconRepo.GetAllComponents()
+	if err != nil {
+		c.logger.Warnf("Could not receive componentList from db: %s", err)
+		return
+	}
+
+	type resultKey struct {
+		reconcilerVersion string
+		state             model.OperationState
+	}
+	for _, component := range components {
+		ops, err := c.reconRepo.GetOperations(&operation.FilterMixer{Filters: []operation.Filter{
+			&operation.WithComponentName{Component: component},
+			&operation.WithStates{States: []model.OperationState{model.OperationStateDone, model.OperationStateError}},
+			&operation.LimitByLastUpdate{Count: operationResultsSampleSize},
+		}})
+		if err != nil {
+			c.logger.Errorf("unable to retrieve finished operations of component '%s': %s", component, err)
+			continue
+		}
+
+		results := make(map[resultKey]int)
+		for _, op := range ops {
+			reconcilerVersion := op.ReconcilerVersion
+			if reconcilerVersion == "" {
+				reconcilerVersion = unknownReconcilerVersion
+			}
+			results[resultKey{reconcilerVersion: reconcilerVersion, state: op.State}]++
+		}
+		for key, count := range results {
+			m, err := prometheus.NewConstMetric(c.resultsDesc, prometheus.GaugeValue, float64(count),
+				component, key.reconcilerVersion, string(key.state))
+			if err != nil {
+				c.logger.Errorf("unable to register metric %s", err.Error())
+				continue
+			}
+			ch <- m
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// PanicsMetric counts panics of HTTP handlers which were recovered and answered with an internal server error:
+// - reconciler_api_panics_total - amount of recovered panics per route
+type PanicsMetric struct {
+	panics *prometheus.CounterVec
+	logger *zap.SugaredLogger
+}
+
+func NewPanicsMetric(logger *zap.SugaredLogger) *PanicsMetric {
+	return &PanicsMetric{
+		panics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "api_panics_total",
+			Help:      "Panics of mothership API handlers which were recovered and answered with an internal server error",
+		}, []string{"route", "method"}),
+		logger: logger,
+	}
+}
+
+func (c *PanicsMetric) Describe(ch chan<- *prometheus.Desc) {
+	c.panics.Describe(ch)
+}
+
+func (c *PanicsMetric) Collect(ch chan<- prometheus.Metric) {
+	c.panics.Collect(ch)
+}
+
+func (c *PanicsMetric) ExposePanic(route, method string) {
+	counter, err := c.panics.GetMetricWithLabelValues(route, method)
+	if err != nil {
+		c.logger.Errorf("PanicsMetric: unable to retrieve counter with labels=[%s %s]: %s", route, method, err)
+		return
+	}
+	counter.Inc()
+}
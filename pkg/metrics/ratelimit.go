@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/ratelimit"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// UpdateRateLimitCollector provides the configuration updates which were rejected by the rate limit:
+// - reconciler_cluster_updates_rate_limited_total - amount of rejected configuration updates per cluster
+type UpdateRateLimitCollector struct {
+	limiter *ratelimit.UpdateLimiter
+	logger  *zap.SugaredLogger
+
+	rejectionsDesc *prometheus.Desc
+}
+
+func NewUpdateRateLimitCollector(limiter *ratelimit.UpdateLimiter, logger *zap.SugaredLogger) *UpdateRateLimitCollector {
+	return &UpdateRateLimitCollector{
+		limiter: limiter,
+		logger:  logger,
+		rejectionsDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "cluster_updates_rate_limited_total"),
+			"Configuration updates of a cluster which were rejected because the cluster exceeded its update rate limit",
+			[]string{"runtime_id"},
+			nil),
+	}
+}
+
+func (c *UpdateRateLimitCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.rejectionsDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *UpdateRateLimitCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, rejections := range c.limiter.Rejections() {
+		m, err := prometheus.NewConstMetric(c.rejectionsDesc, prometheus.CounterValue, float64(rejections.Count),
+			rejections.RuntimeID)
+		if err != nil {
+			c.logger.Errorf("unable to register metric %s", err.Error())
+			continue
+		}
+		ch <- m
+	}
+}
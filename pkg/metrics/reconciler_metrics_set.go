@@ -2,8 +2,13 @@ package metrics
 
 type ReconcilerMetricsSet struct {
 	ComponentProcessingDurationCollector *ComponentProcessingDurationMetric
+	ThrottledClustersCollector           *ThrottledClustersMetric
 }
 
-func NewReconcilerMetricsSet(componentProcessingDurationCollector *ComponentProcessingDurationMetric) *ReconcilerMetricsSet {
-	return &ReconcilerMetricsSet{ComponentProcessingDurationCollector: componentProcessingDurationCollector}
+func NewReconcilerMetricsSet(componentProcessingDurationCollector *ComponentProcessingDurationMetric,
+	throttledClustersCollector *ThrottledClustersMetric) *ReconcilerMetricsSet {
+	return &ReconcilerMetricsSet{
+		ComponentProcessingDurationCollector: componentProcessingDurationCollector,
+		ThrottledClustersCollector:           throttledClustersCollector,
+	}
 }
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// etaCacheInterval is the interval in which the estimates are refreshed: scrapes in between are served from the
+// cache to avoid querying the operations of all running reconciliations on each scrape
+const etaCacheInterval = 30 * time.Second
+
+// ReconciliationETACollector provides the estimated remaining time of running reconciliations:
+// - reconciler_reconciliation_eta_seconds - estimated remaining time of a running reconciliation per runtime
+type ReconciliationETACollector struct {
+	reconRepo reconciliation.Repository
+	logger    *zap.SugaredLogger
+
+	m         sync.Mutex
+	estimates []*reconciliationETA
+	refreshed time.Time
+
+	etaDesc *prometheus.Desc
+}
+
+type reconciliationETA struct {
+	runtimeID    string
+	schedulingID string
+	eta          time.Duration
+}
+
+func NewReconciliationETACollector(reconciliations reconciliation.Repository, logger *zap.SugaredLogger) *ReconciliationETACollector {
+	return &ReconciliationETACollector{
+		reconRepo: reconciliations,
+		logger:    logger,
+		etaDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "reconciliation_eta_seconds"),
+			"Estimated remaining time of a running reconciliation",
+			[]string{"runtime_id", "scheduling_id"},
+			nil),
+	}
+}
+
+func (c *ReconciliationETACollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.etaDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *ReconciliationETACollector) Collect(ch chan<- prometheus.Metric) {
+	for _, estimate := range c.cachedEstimates(time.Now().UTC()) {
+		m, err := prometheus.NewConstMetric(c.etaDesc, prometheus.GaugeValue, estimate.eta.Seconds(),
+			estimate.runtimeID, estimate.schedulingID)
+		if err != nil {
+			c.logger.Errorf("unable to register metric %s", err.Error())
+			continue
+		}
+		ch <- m
+	}
+}
+
+// cachedEstimates returns the estimates and refreshes them if they are older than the cache interval. The previous
+// estimates are returned if the refresh fails: it's retried with the next scrape.
+func (c *ReconciliationETACollector) cachedEstimates(now time.Time) []*reconciliationETA {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if !c.refreshed.IsZero() && now.Sub(c.refreshed) < etaCacheInterval {
+		return c.estimates
+	}
+	estimates, err := c.estimate(now)
+	if err != nil {
+		c.logger.Errorf("unable to retrieve running reconciliations: %s", err)
+		return c.estimates
+	}
+	c.estimates = estimates
+	c.refreshed = now
+	return estimates
+}
+
+func (c *ReconciliationETACollector) estimate(now time.Time) ([]*reconciliationETA, error) {
+	recons, err := c.reconRepo.GetReconciliations(&reconciliation.CurrentlyReconciling{})
+	if err != nil {
+		return nil, err
+	}
+
+	estimator := reconciliation.NewETAEstimator(c.reconRepo)
+	estimates := make([]*reconciliationETA, 0, len(recons))
+	for _, recon := range recons {
+		ops, err := c.reconRepo.GetOperations(&operation.WithSchedulingID{SchedulingID: recon.SchedulingID})
+		if err != nil {
+			c.logger.Errorf("unable to retrieve operations of reconciliation '%s': %s", recon.SchedulingID, err)
+			continue
+		}
+		eta, err := estimator.Estimate(ops, now)
+		if err != nil {
+			c.logger.Errorf("unable to estimate remaining time of reconciliation '%s': %s", recon.SchedulingID, err)
+			continue
+		}
+		estimates = append(estimates, &reconciliationETA{
+			runtimeID:    recon.RuntimeID,
+			schedulingID: recon.SchedulingID,
+			eta:          eta,
+		})
+	}
+	return estimates, nil
+}
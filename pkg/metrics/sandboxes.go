@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/file"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// SandboxesCollector provides insights into the temporary-file sandboxes of operations:
+// - reconciler_sandboxes_active - sandboxes of operations which are currently running
+// - reconciler_sandboxes_cleanup_failed_total - sandboxes which couldn't be removed after their operation ended
+// - reconciler_sandboxes_stale_removed_total - sandboxes of terminated processes which were removed
+type SandboxesCollector struct {
+	logger *zap.SugaredLogger
+
+	activeDesc        *prometheus.Desc
+	cleanupFailedDesc *prometheus.Desc
+	staleRemovedDesc  *prometheus.Desc
+}
+
+func NewSandboxesCollector(logger *zap.SugaredLogger) *SandboxesCollector {
+	return &SandboxesCollector{
+		logger: logger,
+		activeDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "sandboxes_active"),
+			"Temporary-file sandboxes of running operations", nil, nil),
+		cleanupFailedDesc: prometheus.NewDesc(
+			prometheus.BuildFQName("", prometheusSubsystem, "sandboxes_cleanup_failed_total"),
+			"Temporary-file sandboxes which couldn't be removed after their operation ended", nil, nil),
+		staleRemovedDesc: prometheus.NewDesc(
+			prometheus.BuildFQName("", prometheusSubsystem, "sandboxes_stale_removed_total"),
+			"Temporary-file sandboxes of terminated processes which were removed", nil, nil),
+	}
+}
+
+func (c *SandboxesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.activeDesc
+	ch <- c.cleanupFailedDesc
+	ch <- c.staleRemovedDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *SandboxesCollector) Collect(ch chan<- prometheus.Metric) {
+	active, err := prometheus.NewConstMetric(c.activeDesc, prometheus.GaugeValue, float64(file.ActiveSandboxes()))
+	if err != nil {
+		c.logger.Errorf("unable to register metric %s", err.Error())
+		return
+	}
+	ch <- active
+
+	cleanupFailed, err := prometheus.NewConstMetric(c.cleanupFailedDesc, prometheus.CounterValue,
+		float64(file.FailedCleanups()))
+	if err != nil {
+		c.logger.Errorf("unable to register metric %s", err.Error())
+		return
+	}
+	ch <- cleanupFailed
+
+	staleRemoved, err := prometheus.NewConstMetric(c.staleRemovedDesc, prometheus.CounterValue,
+		float64(file.RemovedStaleSandboxes()))
+	if err != nil {
+		c.logger.Errorf("unable to register metric %s", err.Error())
+		return
+	}
+	ch <- staleRemoved
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/slo"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// SLOCollector provides the compliance of the components with their service level objectives:
+// - reconciler_slo_compliance_ratio - ratio of the operations which succeeded (within the latency)
+// - reconciler_slo_burn_rate - consumed error budget (a value above 1 means the objective is violated)
+// - reconciler_slo_error_budget_remaining - remaining error budget (negative if the objective is violated)
+type SLOCollector struct {
+	reconRepo  reconciliation.Repository
+	objectives []*slo.Objective
+	logger     *zap.SugaredLogger
+
+	complianceDesc  *prometheus.Desc
+	burnRateDesc    *prometheus.Desc
+	errorBudgetDesc *prometheus.Desc
+}
+
+func NewSLOCollector(reconciliations reconciliation.Repository, objectives []*slo.Objective, logger *zap.SugaredLogger) *SLOCollector {
+	labels := []string{"component"}
+	return &SLOCollector{
+		reconRepo:  reconciliations,
+		objectives: objectives,
+		logger:     logger,
+		complianceDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "slo_compliance_ratio"),
+			"Ratio of the operations of a component which succeeded within the latency of its SLO",
+			labels,
+			nil),
+		burnRateDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "slo_burn_rate"),
+			"Consumed error budget of the SLO of a component",
+			labels,
+			nil),
+		errorBudgetDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "slo_error_budget_remaining"),
+			"Remaining error budget of the SLO of a component",
+			labels,
+			nil),
+	}
+}
+
+func (c *SLOCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.complianceDesc
+	ch <- c.burnRateDesc
+	ch <- c.errorBudgetDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *SLOCollector) Collect(ch chan<- prometheus.Metric) {
+	reports, err := slo.EvaluateAll(c.objectives, c.reconRepo, time.Now().UTC())
+	if err != nil {
+		c.logger.Errorf("unable to evaluate SLOs of components: %s", err)
+		return
+	}
+	for _, report := range reports {
+		c.collect(ch, c.complianceDesc, report.Compliance, report.Component)
+		c.collect(ch, c.burnRateDesc, report.BurnRate, report.Component)
+		c.collect(ch, c.errorBudgetDesc, report.ErrorBudgetRemaining, report.Component)
+	}
+}
+
+func (c *SLOCollector) collect(ch chan<- prometheus.Metric, desc *prometheus.Desc, value float64, labels ...string) {
+	m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+	if err != nil {
+		c.logger.Errorf("unable to register metric %s", err.Error())
+		return
+	}
+	ch <- m
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/watchdog"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// StuckOperationsCollector provides insights into operations which are running far beyond their expected duration:
+// - reconciler_stuck_operations - operations per component which are currently suspected to be stuck
+// - reconciler_stuck_operations_detected_total - operations which were detected as stuck
+type StuckOperationsCollector struct {
+	watchdog *watchdog.Watchdog
+	logger   *zap.SugaredLogger
+
+	stuckDesc    *prometheus.Desc
+	detectedDesc *prometheus.Desc
+}
+
+func NewStuckOperationsCollector(wd *watchdog.Watchdog, logger *zap.SugaredLogger) *StuckOperationsCollector {
+	return &StuckOperationsCollector{
+		watchdog: wd,
+		logger:   logger,
+		stuckDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "stuck_operations"),
+			"Operations which are currently suspected to be stuck", []string{"component"}, nil),
+		detectedDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "stuck_operations_detected_total"),
+			"Operations which were detected as stuck", nil, nil),
+	}
+}
+
+func (c *StuckOperationsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.stuckDesc
+	ch <- c.detectedDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *StuckOperationsCollector) Collect(ch chan<- prometheus.Metric) {
+	for component, count := range c.watchdog.StuckOperations() {
+		m, err := prometheus.NewConstMetric(c.stuckDesc, prometheus.GaugeValue, float64(count), component)
+		if err != nil {
+			c.logger.Errorf("unable to register metric %s", err.Error())
+			continue
+		}
+		ch <- m
+	}
+
+	detected, err := prometheus.NewConstMetric(c.detectedDesc, prometheus.CounterValue, float64(c.watchdog.DetectedStuckOperations()))
+	if err != nil {
+		c.logger.Errorf("unable to register metric %s", err.Error())
+		return
+	}
+	ch <- detected
+}
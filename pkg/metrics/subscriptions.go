@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/subscription"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// SubscriptionsCollector provides the events of the subscription notifier which were not delivered:
+// - reconciler_subscription_events_dropped_total - amount of events per kind which were dropped because the
+// delivery queue of the replica was full
+type SubscriptionsCollector struct {
+	notifier *subscription.Notifier
+	logger   *zap.SugaredLogger
+
+	droppedDesc *prometheus.Desc
+}
+
+func NewSubscriptionsCollector(notifier *subscription.Notifier, logger *zap.SugaredLogger) *SubscriptionsCollector {
+	return &SubscriptionsCollector{
+		notifier: notifier,
+		logger:   logger,
+		droppedDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "subscription_events_dropped_total"),
+			"Events which were not delivered to the subscriptions because the delivery queue of the replica was full",
+			[]string{"event"},
+			nil),
+	}
+}
+
+func (c *SubscriptionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.droppedDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *SubscriptionsCollector) Collect(ch chan<- prometheus.Metric) {
+	dropped := c.notifier.Dropped()
+	for _, event := range []string{subscription.EventClusterStatus, subscription.EventAnomaly} {
+		m, err := prometheus.NewConstMetric(c.droppedDesc, prometheus.CounterValue, float64(dropped[event]), event)
+		if err != nil {
+			c.logger.Errorf("unable to register metric %s", err.Error())
+			continue
+		}
+		ch <- m
+	}
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// ThrottledClustersMetric indicates clusters whose API server is currently throttling the component reconciler:
+// - reconciler_throttled_clusters - 1 while requests of the component reconciler are rejected with HTTP 429
+type ThrottledClustersMetric struct {
+	Collector *prometheus.GaugeVec
+	logger    *zap.SugaredLogger
+}
+
+func NewThrottledClustersMetric(logger *zap.SugaredLogger) *ThrottledClustersMetric {
+	return &ThrottledClustersMetric{
+		Collector: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "throttled_clusters",
+			Help:      "Clusters whose API server is throttling requests of the component reconciler",
+		}, []string{"cluster", "component"}),
+		logger: logger,
+	}
+}
+
+func (c *ThrottledClustersMetric) ExposeThrottling(cluster, component string, throttled bool) {
+	if !throttled {
+		c.Collector.DeleteLabelValues(cluster, component)
+		return
+	}
+	m, err := c.Collector.GetMetricWithLabelValues(cluster, component)
+	if err != nil {
+		c.logger.Errorf("ThrottledClustersMetric: unable to retrieve metric with label=%s: %s", cluster, err.Error())
+		return
+	}
+	m.Set(1)
+}
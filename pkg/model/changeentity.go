@@ -0,0 +1,88 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblChanges string = "inventory_changes"
+
+type ChangeKind string
+
+const (
+	ChangeKindCluster        ChangeKind = "cluster"
+	ChangeKindReconciliation ChangeKind = "reconciliation"
+)
+
+func NewChangeKind(kind string) (ChangeKind, error) {
+	switch ChangeKind(kind) {
+	case ChangeKindCluster, ChangeKindReconciliation:
+		return ChangeKind(kind), nil
+	default:
+		return "", fmt.Errorf("change kind '%s' is not supported", kind)
+	}
+}
+
+//ChangeEntity is an entry of the change feed: it records a status change of a cluster or reconciliation
+type ChangeEntity struct {
+	ID int64 `db:"readOnly"`
+	//FeedPosition is assigned in commit order after the recording transaction was committed (0 until then)
+	FeedPosition  int64      `db:"readOnly"`
+	Kind          ChangeKind `db:"notNull"`
+	RuntimeID     string     `db:"notNull"`
+	ConfigVersion int64      `db:"notNull"`
+	SchedulingID  string     `db:""` //only set for reconciliation changes
+	Status        Status     `db:"notNull"`
+	Created       time.Time  `db:"readOnly"`
+}
+
+func (c *ChangeEntity) String() string {
+	return fmt.Sprintf("ChangeEntity [ID=%d,FeedPosition=%d,Kind=%s,RuntimeID=%s,ConfigVersion=%d,SchedulingID=%s,Status=%s]",
+		c.ID, c.FeedPosition, c.Kind, c.RuntimeID, c.ConfigVersion, c.SchedulingID, c.Status)
+}
+
+func (c *ChangeEntity) New() db.DatabaseEntity {
+	return &ChangeEntity{}
+}
+
+func (c *ChangeEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&c)
+	marshaller.AddMarshaller("Kind", func(value interface{}) (interface{}, error) {
+		return fmt.Sprintf("%s", value), nil
+	})
+	marshaller.AddUnmarshaller("Kind", func(value interface{}) (interface{}, error) {
+		return NewChangeKind(fmt.Sprintf("%s", value))
+	})
+	marshaller.AddUnmarshaller("FeedPosition", func(value interface{}) (interface{}, error) {
+		if value == nil {
+			return int64(0), nil
+		}
+		return value, nil
+	})
+	marshaller.AddUnmarshaller("SchedulingID", func(value interface{}) (interface{}, error) {
+		if value == nil {
+			return "", nil
+		}
+		return fmt.Sprintf("%s", value), nil
+	})
+	marshaller.AddUnmarshaller("Status", convertStringToStatus)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (c *ChangeEntity) Table() string {
+	return tblChanges
+}
+
+func (c *ChangeEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherChange, ok := other.(*ChangeEntity)
+	if !ok {
+		return false
+	}
+	return c.ID == otherChange.ID
+}
@@ -1,9 +1,12 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
@@ -91,6 +94,74 @@ func (c *ClusterConfigurationEntity) Equal(other db.DatabaseEntity) bool {
 	return false
 }
 
+//Normalize orders the components by name, the configuration entries of each component by key and the
+//administrators alphabetically: normalized configurations are stored with a deterministic JSON encoding
+func (c *ClusterConfigurationEntity) Normalize() {
+	c.Components = normalizeComponents(c.Components)
+	if c.Administrators != nil {
+		administrators := append([]string{}, c.Administrators...)
+		sort.Strings(administrators)
+		c.Administrators = administrators
+	}
+}
+
+//normalizeComponents returns ordered copies of the components (the passed components stay unchanged)
+func normalizeComponents(components []*keb.Component) []*keb.Component {
+	var result []*keb.Component
+	for _, comp := range components {
+		if comp == nil {
+			continue
+		}
+		normalized := *comp
+		normalized.Configuration = append([]keb.Configuration{}, comp.Configuration...)
+		sort.SliceStable(normalized.Configuration, func(i, j int) bool {
+			return normalized.Configuration[i].Key < normalized.Configuration[j].Key
+		})
+		result = append(result, &normalized)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Component < result[j].Component
+	})
+	return result
+}
+
+//Hash returns a hash of the normalized configuration: it's independent of the order of components,
+//component configuration entries and administrators and identifies the content of a configuration version
+//(e.g. for no-op detection or as cache key)
+func (c *ClusterConfigurationEntity) Hash() (string, error) {
+	normalized := &ClusterConfigurationEntity{Components: c.Components, Administrators: c.Administrators}
+	normalized.Normalize()
+	components := append([]*keb.Component{}, normalized.Components...)
+	administrators := append([]string{}, normalized.Administrators...)
+
+	//JSON encoding sorts map keys which normalizes nested configuration values
+	data, err := json.Marshal(struct {
+		KymaVersion    string
+		KymaProfile    string
+		Components     []*keb.Component
+		Administrators []string
+		Contract       int64
+	}{c.KymaVersion, c.KymaProfile, components, administrators, c.Contract})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+//SemanticallyEqual checks whether both configurations have the same content regardless of their ordering
+func (c *ClusterConfigurationEntity) SemanticallyEqual(other *ClusterConfigurationEntity) bool {
+	if other == nil || c.RuntimeID != other.RuntimeID || c.ClusterVersion != other.ClusterVersion {
+		return false
+	}
+	hash, err := c.Hash()
+	if err != nil {
+		return false
+	}
+	otherHash, err := other.Hash()
+	return err == nil && hash == otherHash
+}
+
 func (c *ClusterConfigurationEntity) GetComponent(component string) *keb.Component {
 	if component == CRDComponent { //CRD is an artificial component which doesn't exist in the component list of any cluster
 		return crdComponent
@@ -121,6 +192,14 @@ type ReconciliationSequenceConfig struct {
 	PreComponents        [][]string
 	DeleteStrategy       string
 	ReconciliationStatus Status
+	RemovedComponents    *RemovedComponents //components to uninstall after all other components were reconciled
+}
+
+//RemovedComponents are components which were part of a previous configuration version of a cluster
+//but are missing in the current configuration version
+type RemovedComponents struct {
+	ConfigVersion int64 //configuration version which still includes the components
+	Components    []string
 }
 
 func newReconciliationSequence(cfg *ReconciliationSequenceConfig) *ReconciliationSequence {
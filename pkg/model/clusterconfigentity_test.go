@@ -135,6 +135,89 @@ func TestClusterConfigEntity(t *testing.T) {
 		}
 	})
 
+	t.Run("Validate SemanticallyEqual", func(t *testing.T) {
+		entity1 := &ClusterConfigurationEntity{
+			RuntimeID:      "1234",
+			ClusterVersion: 1,
+			KymaVersion:    "1.2.3",
+			Components: []*keb.Component{
+				{
+					Component: "comp1",
+					Configuration: []keb.Configuration{
+						{Key: "a", Value: map[string]interface{}{"x": 1, "y": 2}},
+						{Key: "b", Value: "b"},
+					},
+				},
+				{Component: "comp2"},
+			},
+			Administrators: []string{"admin1", "admin2"},
+		}
+		entity2 := &ClusterConfigurationEntity{
+			RuntimeID:      "1234",
+			ClusterVersion: 1,
+			KymaVersion:    "1.2.3",
+			Components: []*keb.Component{
+				{Component: "comp2", Configuration: []keb.Configuration{}},
+				{
+					Component: "comp1",
+					Configuration: []keb.Configuration{
+						{Key: "b", Value: "b"},
+						{Key: "a", Value: map[string]interface{}{"y": 2, "x": 1}},
+					},
+				},
+			},
+			Administrators: []string{"admin2", "admin1"},
+		}
+		require.False(t, entity1.Equal(entity2))
+		require.True(t, entity1.SemanticallyEqual(entity2))
+
+		entity2.Components[1].Configuration[0].Value = "c"
+		require.False(t, entity1.SemanticallyEqual(entity2))
+	})
+
+	t.Run("Validate Normalize", func(t *testing.T) {
+		components := []*keb.Component{
+			{
+				Component: "comp2",
+				Configuration: []keb.Configuration{
+					{Key: "b", Value: "b"},
+					{Key: "a", Value: "a"},
+				},
+			},
+			{Component: "comp1"},
+		}
+		entity1 := &ClusterConfigurationEntity{
+			RuntimeID:      "1234",
+			Components:     components,
+			Administrators: []string{"admin2", "admin1"},
+		}
+		entity2 := &ClusterConfigurationEntity{
+			RuntimeID: "1234",
+			Components: []*keb.Component{
+				{Component: "comp1", Configuration: []keb.Configuration{}},
+				{
+					Component: "comp2",
+					Configuration: []keb.Configuration{
+						{Key: "a", Value: "a"},
+						{Key: "b", Value: "b"},
+					},
+				},
+			},
+			Administrators: []string{"admin1", "admin2"},
+		}
+		hash, err := entity1.Hash()
+		require.NoError(t, err)
+
+		entity1.Normalize()
+		require.True(t, entity1.Equal(entity2))
+		require.Equal(t, "comp2", components[0].Component, "normalized components are copies")
+		require.Equal(t, "b", components[0].Configuration[0].Key, "normalized configurations are copies")
+
+		normalizedHash, err := entity1.Hash()
+		require.NoError(t, err)
+		require.Equal(t, hash, normalizedHash)
+	})
+
 }
 
 func TestReconciliationSequence(t *testing.T) {
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblConfigWarnings string = "inventory_cluster_config_warnings"
+
+//ClusterConfigWarningsEntity stores the warnings which the validation webhook returned for a configuration version
+type ClusterConfigWarningsEntity struct {
+	ConfigVersion int64     `db:"notNull"`
+	RuntimeID     string    `db:"notNull"`
+	Warnings      []string  `db:"notNull"`
+	Created       time.Time `db:"readOnly"`
+}
+
+func (c *ClusterConfigWarningsEntity) String() string {
+	return fmt.Sprintf("ClusterConfigWarningsEntity [ConfigVersion=%d,RuntimeID=%s,Warnings=%d]",
+		c.ConfigVersion, c.RuntimeID, len(c.Warnings))
+}
+
+func (c *ClusterConfigWarningsEntity) New() db.DatabaseEntity {
+	return &ClusterConfigWarningsEntity{}
+}
+
+func (c *ClusterConfigWarningsEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&c)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Warnings", func(value interface{}) (interface{}, error) {
+		var warnings []string
+		err := json.Unmarshal([]byte(value.(string)), &warnings)
+		return warnings, err
+	})
+	marshaller.AddMarshaller("Warnings", convertInterfaceToJSONString)
+	return marshaller
+}
+
+func (c *ClusterConfigWarningsEntity) Table() string {
+	return tblConfigWarnings
+}
+
+func (c *ClusterConfigWarningsEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherWarnings, ok := other.(*ClusterConfigWarningsEntity)
+	if ok {
+		return c.ConfigVersion == otherWarnings.ConfigVersion &&
+			c.RuntimeID == otherWarnings.RuntimeID &&
+			reflect.DeepEqual(c.Warnings, otherWarnings.Warnings)
+	}
+	return false
+}
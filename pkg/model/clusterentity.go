@@ -13,14 +13,18 @@ import (
 const tblCluster string = "inventory_clusters"
 
 type ClusterEntity struct {
-	Version    int64             `db:"readOnly"`
-	RuntimeID  string            `db:"notNull"`
-	Runtime    *keb.RuntimeInput `db:"notNull"`
-	Metadata   *keb.Metadata     `db:"notNull"`
-	Kubeconfig string            `db:"notNull,encrypt"`
-	Contract   int64             `db:"notNull"`
-	Deleted    bool              `db:"notNull"`
-	Created    time.Time         `db:"readOnly"`
+	Version            int64             `db:"readOnly"`
+	RuntimeID          string            `db:"notNull"`
+	Runtime            *keb.RuntimeInput `db:"notNull"`
+	Metadata           *keb.Metadata     `db:"notNull"`
+	Kubeconfig         string            `db:"notNull,envelope"`
+	KubeconfigKeyID    string            `db:"column=kubeconfig_key_id"` //ID of the key used to encrypt the data encryption key of the kubeconfig
+	PreviousKubeconfig string            `db:"envelope"`                 //replaced kubeconfig: kept for a rollback until the next successful reconciliation
+	KubeconfigRotated  int64             `db:""`                         //ID of the latest cluster status when the kubeconfig was rotated: only reconciliations of newer statuses used the rotated kubeconfig
+	Kubeconfigs        map[string]string `db:"envelope"`                 //named kubeconfigs of further clusters of the runtime (e.g. edge shoots)
+	Contract           int64             `db:"notNull"`
+	Deleted            bool              `db:"notNull"`
+	Created            time.Time         `db:"readOnly"`
 }
 
 func (c *ClusterEntity) String() string {
@@ -45,11 +49,43 @@ func (c *ClusterEntity) Marshaller() *db.EntityMarshaller {
 		return metadata, err
 	})
 
+	marshaller.AddUnmarshaller("Kubeconfigs", func(value interface{}) (interface{}, error) {
+		var kubeconfigs map[string]string
+		encoded := fmt.Sprintf("%v", value)
+		if encoded == "" {
+			return kubeconfigs, nil
+		}
+		if db.IsEnvelope(encoded) { //decryption failed
+			return nil, fmt.Errorf("kubeconfigs can't be decrypted (key ID: '%s')", db.EncryptionKeyID(encoded))
+		}
+		err := json.Unmarshal([]byte(encoded), &kubeconfigs)
+		return kubeconfigs, err
+	})
+
 	marshaller.AddMarshaller("Runtime", convertInterfaceToJSONString)
 	marshaller.AddMarshaller("Metadata", convertInterfaceToJSONString)
+	marshaller.AddMarshaller("Kubeconfigs", func(value interface{}) (interface{}, error) {
+		if kubeconfigs, ok := value.(map[string]string); ok && len(kubeconfigs) == 0 {
+			return "", nil
+		}
+		return convertInterfaceToJSONString(value)
+	})
 	return marshaller
 }
 
+//TargetKubeconfig returns the kubeconfig of the cluster a component is applied to: the kubeconfig of the main
+//cluster is returned if the target is empty
+func (c *ClusterEntity) TargetKubeconfig(target string) (string, error) {
+	if target == "" {
+		return c.Kubeconfig, nil
+	}
+	kubeconfig, ok := c.Kubeconfigs[target]
+	if !ok {
+		return "", fmt.Errorf("cluster '%s' has no kubeconfig for target '%s'", c.RuntimeID, target)
+	}
+	return kubeconfig, nil
+}
+
 func (c *ClusterEntity) Table() string {
 	return tblCluster
 }
@@ -63,6 +99,8 @@ func (c *ClusterEntity) Equal(other db.DatabaseEntity) bool {
 		return c.RuntimeID == otherClProp.RuntimeID &&
 			reflect.DeepEqual(c.Runtime, otherClProp.Runtime) &&
 			reflect.DeepEqual(c.Metadata, otherClProp.Metadata) &&
+			(len(c.Kubeconfigs) == 0 && len(otherClProp.Kubeconfigs) == 0 ||
+				reflect.DeepEqual(c.Kubeconfigs, otherClProp.Kubeconfigs)) &&
 			c.Contract == otherClProp.Contract
 	}
 	return false
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterEntity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Target kubeconfig", func(t *testing.T) {
+		entity := &ClusterEntity{
+			RuntimeID:   "1234",
+			Kubeconfig:  "main",
+			Kubeconfigs: map[string]string{"edge-1": "edge"},
+		}
+		kubeconfig, err := entity.TargetKubeconfig("")
+		require.NoError(t, err)
+		require.Equal(t, "main", kubeconfig)
+
+		kubeconfig, err = entity.TargetKubeconfig("edge-1")
+		require.NoError(t, err)
+		require.Equal(t, "edge", kubeconfig)
+
+		_, err = entity.TargetKubeconfig("edge-2")
+		require.Error(t, err)
+	})
+
+	t.Run("Marshal kubeconfigs", func(t *testing.T) {
+		entity := &ClusterEntity{Kubeconfigs: map[string]string{"edge-1": "edge"}}
+		values, err := entity.Marshaller().Marshal()
+		require.NoError(t, err)
+		require.Equal(t, `{"edge-1":"edge"}`, values["Kubeconfigs"])
+
+		values, err = (&ClusterEntity{}).Marshaller().Marshal()
+		require.NoError(t, err)
+		require.Equal(t, "", values["Kubeconfigs"])
+	})
+
+	t.Run("Equal with kubeconfigs", func(t *testing.T) {
+		entity1 := &ClusterEntity{RuntimeID: "1234", Contract: 1}
+		entity2 := &ClusterEntity{RuntimeID: "1234", Contract: 1, Kubeconfigs: map[string]string{}}
+		require.True(t, entity1.Equal(entity2))
+
+		entity2.Kubeconfigs["edge-1"] = "edge"
+		require.False(t, entity1.Equal(entity2))
+		require.True(t, entity2.Equal(&ClusterEntity{RuntimeID: "1234", Contract: 1,
+			Kubeconfigs: map[string]string{"edge-1": "edge"}}))
+	})
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+const tblClusterSnapshots string = "inventory_cluster_snapshots"
+
+//ClusterSnapshotEntity stores key facts of a target cluster which were captured after a reconciliation
+//and the changes of these facts compared to the previous snapshot of the cluster
+type ClusterSnapshotEntity struct {
+	ID                int64             `db:"readOnly"`
+	RuntimeID         string            `db:"notNull"`
+	ClusterConfig     int64             `db:"notNull"`
+	NodeCount         int64             `db:"notNull"`
+	KubernetesVersion string            `db:"notNull"`
+	KymaCrds          []string          `db:"notNull"`
+	Changes           []*keb.FactChange `db:"notNull"`
+	Created           time.Time         `db:"readOnly"`
+}
+
+func (c *ClusterSnapshotEntity) String() string {
+	return fmt.Sprintf("ClusterSnapshotEntity [RuntimeID=%s,ClusterConfig=%d,Nodes=%d,KubernetesVersion=%s,KymaCrds=%d]",
+		c.RuntimeID, c.ClusterConfig, c.NodeCount, c.KubernetesVersion, len(c.KymaCrds))
+}
+
+func (c *ClusterSnapshotEntity) New() db.DatabaseEntity {
+	return &ClusterSnapshotEntity{}
+}
+
+func (c *ClusterSnapshotEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&c)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("KymaCrds", func(value interface{}) (interface{}, error) {
+		var crds []string
+		err := json.Unmarshal([]byte(value.(string)), &crds)
+		return crds, err
+	})
+	marshaller.AddMarshaller("KymaCrds", convertInterfaceToJSONString)
+	marshaller.AddUnmarshaller("Changes", func(value interface{}) (interface{}, error) {
+		var changes []*keb.FactChange
+		err := json.Unmarshal([]byte(value.(string)), &changes)
+		return changes, err
+	})
+	marshaller.AddMarshaller("Changes", convertInterfaceToJSONString)
+	return marshaller
+}
+
+func (c *ClusterSnapshotEntity) Table() string {
+	return tblClusterSnapshots
+}
+
+func (c *ClusterSnapshotEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherSnapshot, ok := other.(*ClusterSnapshotEntity)
+	if ok {
+		return c.RuntimeID == otherSnapshot.RuntimeID &&
+			c.ClusterConfig == otherSnapshot.ClusterConfig &&
+			c.NodeCount == otherSnapshot.NodeCount &&
+			c.KubernetesVersion == otherSnapshot.KubernetesVersion &&
+			reflect.DeepEqual(c.KymaCrds, otherSnapshot.KymaCrds)
+	}
+	return false
+}
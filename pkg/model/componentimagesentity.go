@@ -0,0 +1,64 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+const tblComponentImages string = "inventory_component_images"
+
+//ComponentImagesEntity stores the container images which were running for the workloads of a component after
+//its last successful reconciliation (used as bill of materials of a cluster)
+type ComponentImagesEntity struct {
+	RuntimeID     string                `db:"notNull"`
+	Component     string                `db:"notNull"`
+	ClusterConfig int64                 `db:"notNull"`
+	SchedulingID  string                `db:"notNull"`
+	CorrelationID string                `db:"notNull"`
+	Images        []*keb.ContainerImage `db:"notNull"`
+	Created       time.Time             `db:"readOnly"`
+}
+
+func (c *ComponentImagesEntity) String() string {
+	return fmt.Sprintf("ComponentImagesEntity [RuntimeID=%s,Component=%s,ClusterConfig=%d,Images=%d]",
+		c.RuntimeID, c.Component, c.ClusterConfig, len(c.Images))
+}
+
+func (c *ComponentImagesEntity) New() db.DatabaseEntity {
+	return &ComponentImagesEntity{}
+}
+
+func (c *ComponentImagesEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&c)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Images", func(value interface{}) (interface{}, error) {
+		var images []*keb.ContainerImage
+		err := json.Unmarshal([]byte(value.(string)), &images)
+		return images, err
+	})
+	marshaller.AddMarshaller("Images", convertInterfaceToJSONString)
+	return marshaller
+}
+
+func (c *ComponentImagesEntity) Table() string {
+	return tblComponentImages
+}
+
+func (c *ComponentImagesEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherImages, ok := other.(*ComponentImagesEntity)
+	if ok {
+		return c.RuntimeID == otherImages.RuntimeID &&
+			c.Component == otherImages.Component &&
+			c.ClusterConfig == otherImages.ClusterConfig &&
+			reflect.DeepEqual(c.Images, otherImages.Images)
+	}
+	return false
+}
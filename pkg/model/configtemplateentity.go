@@ -0,0 +1,106 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+const (
+	tblConfigTemplates    string = "inventory_config_templates"
+	tblConfigTemplateRefs string = "inventory_config_template_refs"
+)
+
+//ConfigTemplateEntity is a named set of components with default values which can be referenced by clusters
+type ConfigTemplateEntity struct {
+	Name       string           `db:"notNull"`
+	Components []*keb.Component `db:"notNull,encrypt"`
+	Created    time.Time        `db:"readOnly"`
+	Updated    time.Time        `db:""`
+}
+
+func (c *ConfigTemplateEntity) String() string {
+	return fmt.Sprintf("ConfigTemplateEntity [Name=%s,Components=%d]", c.Name, len(c.Components))
+}
+
+func (c *ConfigTemplateEntity) New() db.DatabaseEntity {
+	return &ConfigTemplateEntity{}
+}
+
+func (c *ConfigTemplateEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&c)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Updated", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Components", unmarshalComponents)
+	marshaller.AddMarshaller("Components", convertInterfaceToJSONString)
+	return marshaller
+}
+
+func (c *ConfigTemplateEntity) Table() string {
+	return tblConfigTemplates
+}
+
+func (c *ConfigTemplateEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherTemplate, ok := other.(*ConfigTemplateEntity)
+	if ok {
+		return c.Name == otherTemplate.Name &&
+			reflect.DeepEqual(c.Components, otherTemplate.Components)
+	}
+	return false
+}
+
+//ConfigTemplateRefEntity assigns a cluster to a configuration template. The overrides are the components
+//sent by KEB which are merged with the components of the template.
+type ConfigTemplateRefEntity struct {
+	RuntimeID string           `db:"notNull"`
+	Template  string           `db:"notNull"`
+	Overrides []*keb.Component `db:"notNull,encrypt"`
+	Updated   time.Time        `db:""`
+}
+
+func (c *ConfigTemplateRefEntity) String() string {
+	return fmt.Sprintf("ConfigTemplateRefEntity [RuntimeID=%s,Template=%s,Overrides=%d]",
+		c.RuntimeID, c.Template, len(c.Overrides))
+}
+
+func (c *ConfigTemplateRefEntity) New() db.DatabaseEntity {
+	return &ConfigTemplateRefEntity{}
+}
+
+func (c *ConfigTemplateRefEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&c)
+	marshaller.AddUnmarshaller("Updated", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Overrides", unmarshalComponents)
+	marshaller.AddMarshaller("Overrides", convertInterfaceToJSONString)
+	return marshaller
+}
+
+func (c *ConfigTemplateRefEntity) Table() string {
+	return tblConfigTemplateRefs
+}
+
+func (c *ConfigTemplateRefEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherRef, ok := other.(*ConfigTemplateRefEntity)
+	if ok {
+		return c.RuntimeID == otherRef.RuntimeID &&
+			c.Template == otherRef.Template &&
+			reflect.DeepEqual(c.Overrides, otherRef.Overrides)
+	}
+	return false
+}
+
+func unmarshalComponents(value interface{}) (interface{}, error) {
+	var comps []*keb.Component
+	err := json.Unmarshal([]byte(value.(string)), &comps)
+	return comps, err
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblDeadLetters string = "scheduler_deadletters"
+
+type DeadLetterState string
+
+const (
+	DeadLetterStateOpen         DeadLetterState = "open"
+	DeadLetterStateAcknowledged DeadLetterState = "acknowledged"
+	DeadLetterStateRequeued     DeadLetterState = "requeued"
+)
+
+func NewDeadLetterState(state string) (DeadLetterState, error) {
+	switch DeadLetterState(state) {
+	case DeadLetterStateOpen, DeadLetterStateAcknowledged, DeadLetterStateRequeued:
+		return DeadLetterState(state), nil
+	default:
+		return "", fmt.Errorf("dead letter state '%s' is not supported", state)
+	}
+}
+
+//DeadLetterEntity is an operation which failed permanently (all retries are exhausted) and requires
+//an operator to requeue or acknowledge it
+type DeadLetterEntity struct {
+	ID            int64           `db:"readOnly"`
+	SchedulingID  string          `db:"notNull"`
+	CorrelationID string          `db:"notNull"`
+	RuntimeID     string          `db:"notNull"`
+	ClusterConfig int64           `db:"notNull"`
+	Component     string          `db:"notNull"`
+	Type          OperationType   `db:"notNull"`
+	Reason        string          `db:""`
+	Retries       int64           `db:"notNull"`
+	State         DeadLetterState `db:"notNull"`
+	Created       time.Time       `db:"readOnly"`
+	Updated       time.Time       `db:""`
+}
+
+func (d *DeadLetterEntity) String() string {
+	return fmt.Sprintf("DeadLetterEntity [ID=%d,SchedulingID=%s,CorrelationID=%s,RuntimeID=%s,Component=%s,State=%s]",
+		d.ID, d.SchedulingID, d.CorrelationID, d.RuntimeID, d.Component, d.State)
+}
+
+func (d *DeadLetterEntity) New() db.DatabaseEntity {
+	return &DeadLetterEntity{}
+}
+
+func (d *DeadLetterEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&d)
+	marshaller.AddMarshaller("Type", func(value interface{}) (interface{}, error) {
+		return fmt.Sprintf("%s", value), nil
+	})
+	marshaller.AddUnmarshaller("Type", func(value interface{}) (interface{}, error) {
+		return NewOperationType(fmt.Sprintf("%s", value))
+	})
+	marshaller.AddMarshaller("State", func(value interface{}) (interface{}, error) {
+		return fmt.Sprintf("%s", value), nil
+	})
+	marshaller.AddUnmarshaller("State", func(value interface{}) (interface{}, error) {
+		return NewDeadLetterState(fmt.Sprintf("%s", value))
+	})
+	marshaller.AddUnmarshaller("Reason", func(value interface{}) (interface{}, error) {
+		if value == nil {
+			return "", nil
+		}
+		return fmt.Sprintf("%s", value), nil
+	})
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Updated", convertTimestampToTime)
+	return marshaller
+}
+
+func (d *DeadLetterEntity) Table() string {
+	return tblDeadLetters
+}
+
+func (d *DeadLetterEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherDeadLetter, ok := other.(*DeadLetterEntity)
+	if !ok {
+		return false
+	}
+	return d.SchedulingID == otherDeadLetter.SchedulingID &&
+		d.CorrelationID == otherDeadLetter.CorrelationID &&
+		d.State == otherDeadLetter.State
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblIdempotencyKeys string = "inventory_idempotency_keys"
+
+//IdempotencyKeyEntity stores the response of a cluster mutation which was sent with an 'Idempotency-Key' header.
+//The status code is 0 as long as the request is processed.
+type IdempotencyKeyEntity struct {
+	Key         string    `db:"notNull"`
+	Fingerprint string    `db:"notNull"` //hash of the request which used the key first
+	StatusCode  int64     `db:"notNull"`
+	Response    string    `db:""`
+	Created     time.Time `db:"readOnly"`
+}
+
+//Completed returns true if the response of the request is available
+func (e *IdempotencyKeyEntity) Completed() bool {
+	return e.StatusCode > 0
+}
+
+func (e *IdempotencyKeyEntity) String() string {
+	return fmt.Sprintf("IdempotencyKeyEntity [Key=%s,StatusCode=%d]", e.Key, e.StatusCode)
+}
+
+func (e *IdempotencyKeyEntity) New() db.DatabaseEntity {
+	return &IdempotencyKeyEntity{}
+}
+
+func (e *IdempotencyKeyEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&e)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (e *IdempotencyKeyEntity) Table() string {
+	return tblIdempotencyKeys
+}
+
+func (e *IdempotencyKeyEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherKey, ok := other.(*IdempotencyKeyEntity)
+	if ok {
+		return e.Key == otherKey.Key &&
+			e.Fingerprint == otherKey.Fingerprint &&
+			e.StatusCode == otherKey.StatusCode &&
+			e.Response == otherKey.Response
+	}
+	return false
+}
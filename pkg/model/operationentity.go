@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -26,6 +27,13 @@ type OperationEntity struct {
 	Retries            int64          `db:""`
 	RetryID            string         `db:"notNull"`
 	Debug              bool           `db:"notNull"`
+	ReconcilerVersion  string         `db:""`
+	CallbackSequence   int64          `db:""`
+	APICalls           int64          `db:""`
+	ManifestBytes      int64          `db:""`
+	PendingDeletion    string         `db:""` //JSON list of the stateful resources which the deletion would remove
+	DeletionConfirmed  bool           `db:"notNull"`
+	TimedOut           bool           `db:"notNull"` //set if the component reconciler reported that the operation failed because of a timeout
 }
 
 func (o *OperationEntity) String() string {
@@ -61,9 +69,52 @@ func (o *OperationEntity) Marshaller() *db.EntityMarshaller {
 		}
 		return value.(int64), nil
 	})
+	marshaller.AddUnmarshaller("CallbackSequence", func(value interface{}) (interface{}, error) {
+		if value == nil {
+			return int64(0), nil
+		}
+		return value.(int64), nil
+	})
+	marshaller.AddUnmarshaller("APICalls", func(value interface{}) (interface{}, error) {
+		if value == nil {
+			return int64(0), nil
+		}
+		return value.(int64), nil
+	})
+	marshaller.AddUnmarshaller("ManifestBytes", func(value interface{}) (interface{}, error) {
+		if value == nil {
+			return int64(0), nil
+		}
+		return value.(int64), nil
+	})
+	marshaller.AddUnmarshaller("ReconcilerVersion", func(value interface{}) (interface{}, error) {
+		if value == nil {
+			return "", nil
+		}
+		return fmt.Sprintf("%s", value), nil
+	})
+	marshaller.AddUnmarshaller("PendingDeletion", func(value interface{}) (interface{}, error) {
+		if value == nil {
+			return "", nil
+		}
+		return fmt.Sprintf("%s", value), nil
+	})
 	return marshaller
 }
 
+//PendingDeletionResources returns the stateful resources which the deletion would remove (empty if the operation
+//doesn't wait for a confirmation)
+func (o *OperationEntity) PendingDeletionResources() []string {
+	var resources []string
+	if o.PendingDeletion == "" {
+		return resources
+	}
+	if err := json.Unmarshal([]byte(o.PendingDeletion), &resources); err != nil {
+		return []string{o.PendingDeletion}
+	}
+	return resources
+}
+
 func (*OperationEntity) Table() string {
 	return tblOperation
 }
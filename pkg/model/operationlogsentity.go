@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblOperationLogs string = "scheduler_operation_logs"
+
+//OperationLogsEntity stores the latest log entries which a component reconciler reported with the final
+//status of an operation
+type OperationLogsEntity struct {
+	SchedulingID  string    `db:"notNull"`
+	CorrelationID string    `db:"notNull"`
+	RuntimeID     string    `db:"notNull"`
+	Component     string    `db:"notNull"`
+	Logs          string    `db:"notNull"` //compressed JSON of the log entries
+	Created       time.Time `db:"readOnly"`
+}
+
+func (o *OperationLogsEntity) String() string {
+	return fmt.Sprintf("OperationLogsEntity [SchedulingID=%s,CorrelationID=%s,RuntimeID=%s,Component=%s]",
+		o.SchedulingID, o.CorrelationID, o.RuntimeID, o.Component)
+}
+
+func (o *OperationLogsEntity) New() db.DatabaseEntity {
+	return &OperationLogsEntity{}
+}
+
+func (o *OperationLogsEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&o)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (o *OperationLogsEntity) Table() string {
+	return tblOperationLogs
+}
+
+func (o *OperationLogsEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherLogs, ok := other.(*OperationLogsEntity)
+	if ok {
+		return o.SchedulingID == otherLogs.SchedulingID &&
+			o.CorrelationID == otherLogs.CorrelationID
+	}
+	return false
+}
@@ -15,6 +15,12 @@ const (
 	OperationStateError       OperationState = "error"
 	OperationStateFailed      OperationState = "failed"
 	OperationStateOrphan      OperationState = "orphan"
+	OperationStateSkipped     OperationState = "skipped"
+	OperationStateWaiting     OperationState = "waiting"
+	OperationStateAborted     OperationState = "aborted"
+	//OperationStatePendingConfirmation indicates a deletion which would remove stateful resources: it
+	//proceeds after an operator confirmed it
+	OperationStatePendingConfirmation OperationState = "pending_confirmation"
 )
 
 func NewOperationState(state string) (OperationState, error) {
@@ -34,6 +40,14 @@ func NewOperationState(state string) (OperationState, error) {
 		result = OperationStateFailed
 	case string(OperationStateOrphan):
 		result = OperationStateOrphan
+	case string(OperationStateSkipped):
+		result = OperationStateSkipped
+	case string(OperationStateWaiting):
+		result = OperationStateWaiting
+	case string(OperationStateAborted):
+		result = OperationStateAborted
+	case string(OperationStatePendingConfirmation):
+		result = OperationStatePendingConfirmation
 	default:
 		return "", fmt.Errorf("operation state '%s' does not exist", state)
 	}
@@ -41,11 +55,13 @@ func NewOperationState(state string) (OperationState, error) {
 }
 
 func (o OperationState) IsError() bool {
-	return o == OperationStateError || o == OperationStateFailed || o == OperationStateClientError
+	return o == OperationStateError || o == OperationStateFailed || o == OperationStateClientError ||
+		o == OperationStateAborted
 }
 
 func (o OperationState) IsFinal() bool {
-	return o == OperationStateError || o == OperationStateDone
+	return o == OperationStateError || o == OperationStateDone || o == OperationStateSkipped ||
+		o == OperationStateAborted
 }
 
 func (o OperationState) IsTemporary() bool {
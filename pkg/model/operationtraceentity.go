@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+const tblOperationTraces string = "scheduler_operation_traces"
+
+//OperationTraceEntity stores the detailed capture (phases, API calls and debug logs) of an operation which
+//exceeded the latency threshold of its component reconciler
+type OperationTraceEntity struct {
+	SchedulingID  string              `db:"notNull"`
+	CorrelationID string              `db:"notNull"`
+	RuntimeID     string              `db:"notNull"`
+	Component     string              `db:"notNull"`
+	Trace         *keb.OperationTrace `db:"notNull"`
+	Created       time.Time           `db:"readOnly"`
+}
+
+func (o *OperationTraceEntity) String() string {
+	return fmt.Sprintf("OperationTraceEntity [SchedulingID=%s,CorrelationID=%s,RuntimeID=%s,Component=%s]",
+		o.SchedulingID, o.CorrelationID, o.RuntimeID, o.Component)
+}
+
+func (o *OperationTraceEntity) New() db.DatabaseEntity {
+	return &OperationTraceEntity{}
+}
+
+func (o *OperationTraceEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&o)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Trace", func(value interface{}) (interface{}, error) {
+		trace := &keb.OperationTrace{}
+		err := json.Unmarshal([]byte(value.(string)), trace)
+		return trace, err
+	})
+	marshaller.AddMarshaller("Trace", convertInterfaceToJSONString)
+	return marshaller
+}
+
+func (o *OperationTraceEntity) Table() string {
+	return tblOperationTraces
+}
+
+func (o *OperationTraceEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherTrace, ok := other.(*OperationTraceEntity)
+	if ok {
+		return o.SchedulingID == otherTrace.SchedulingID &&
+			o.CorrelationID == otherTrace.CorrelationID
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblPayloads string = "inventory_payloads"
+
+//PayloadEntity stores the raw KEB payload which led to a cluster configuration
+type PayloadEntity struct {
+	ID            int64     `db:"readOnly"`
+	RuntimeID     string    `db:"notNull"`
+	ConfigVersion int64     `db:"notNull"`
+	Contract      int64     `db:"notNull"`
+	Payload       string    `db:"notNull,envelope"` //compressed payload
+	Created       time.Time `db:"readOnly"`
+}
+
+func (p *PayloadEntity) String() string {
+	return fmt.Sprintf("PayloadEntity [ID=%d,RuntimeID=%s,ConfigVersion=%d,Contract=%d]",
+		p.ID, p.RuntimeID, p.ConfigVersion, p.Contract)
+}
+
+func (p *PayloadEntity) New() db.DatabaseEntity {
+	return &PayloadEntity{}
+}
+
+func (p *PayloadEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&p)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (p *PayloadEntity) Table() string {
+	return tblPayloads
+}
+
+func (p *PayloadEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherPayload, ok := other.(*PayloadEntity)
+	if ok {
+		return p.RuntimeID == otherPayload.RuntimeID &&
+			p.ConfigVersion == otherPayload.ConfigVersion &&
+			p.Payload == otherPayload.Payload
+	}
+	return false
+}
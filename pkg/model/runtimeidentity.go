@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblRuntimeIDs string = "inventory_runtime_ids"
+
+//RuntimeIDEntity reserves the normalized runtime ID of a cluster to guarantee its uniqueness. It also stores
+//settings which apply to all versions of the cluster (e.g. the deletion protection).
+type RuntimeIDEntity struct {
+	NormalizedRuntimeID string `db:"notNull"`
+	RuntimeID           string `db:"notNull"`
+	DeletionProtection  bool   `db:"notNull"`
+	//ForceTakeover allows the mothership to take over the cluster once from another mothership
+	ForceTakeover bool `db:"notNull"`
+	//Provisioned is set after the first successful reconciliation: paused cohorts don't block the provisioning
+	Provisioned bool      `db:"notNull"`
+	Created     time.Time `db:"readOnly"`
+}
+
+func (r *RuntimeIDEntity) String() string {
+	return fmt.Sprintf("RuntimeIDEntity [NormalizedRuntimeID=%s,RuntimeID=%s,DeletionProtection=%t,ForceTakeover=%t,"+
+		"Provisioned=%t]", r.NormalizedRuntimeID, r.RuntimeID, r.DeletionProtection, r.ForceTakeover, r.Provisioned)
+}
+
+func (r *RuntimeIDEntity) New() db.DatabaseEntity {
+	return &RuntimeIDEntity{}
+}
+
+func (r *RuntimeIDEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&r)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (r *RuntimeIDEntity) Table() string {
+	return tblRuntimeIDs
+}
+
+func (r *RuntimeIDEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherRuntimeID, ok := other.(*RuntimeIDEntity)
+	if ok {
+		return r.NormalizedRuntimeID == otherRuntimeID.NormalizedRuntimeID &&
+			r.RuntimeID == otherRuntimeID.RuntimeID &&
+			r.DeletionProtection == otherRuntimeID.DeletionProtection &&
+			r.ForceTakeover == otherRuntimeID.ForceTakeover &&
+			r.Provisioned == otherRuntimeID.Provisioned
+	}
+	return false
+}
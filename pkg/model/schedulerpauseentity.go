@@ -0,0 +1,72 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblSchedulerPauses string = "scheduler_pauses"
+
+//SchedulerPauseEntity is a fleet-wide pause of the scheduling and dispatching of reconciliations (kill switch).
+//A pause with a duration ends automatically, otherwise it's active until it gets resumed.
+type SchedulerPauseEntity struct {
+	ID       int64     `db:"readOnly"`
+	PausedBy string    `db:"notNull"`
+	Started  time.Time `db:"notNull"`
+	Duration int64     `db:"notNull"` //in seconds, 0 = paused until it gets resumed
+	Resumed  bool      `db:"notNull"`
+	Created  time.Time `db:"readOnly"`
+	Updated  time.Time `db:""`
+}
+
+//Until returns the end of the pause window (zero if the pause has no duration)
+func (p *SchedulerPauseEntity) Until() time.Time {
+	if p.Duration <= 0 {
+		return time.Time{}
+	}
+	return p.Started.Add(time.Duration(p.Duration) * time.Second)
+}
+
+//Active returns true if the pause was neither resumed nor is its pause window over
+func (p *SchedulerPauseEntity) Active(now time.Time) bool {
+	if p.Resumed {
+		return false
+	}
+	return p.Duration <= 0 || now.Before(p.Until())
+}
+
+func (p *SchedulerPauseEntity) String() string {
+	return fmt.Sprintf("SchedulerPauseEntity [ID=%d,PausedBy=%s,Started=%s,Duration=%ds,Resumed=%t]",
+		p.ID, p.PausedBy, p.Started.Format(time.RFC3339), p.Duration, p.Resumed)
+}
+
+func (p *SchedulerPauseEntity) New() db.DatabaseEntity {
+	return &SchedulerPauseEntity{}
+}
+
+func (p *SchedulerPauseEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&p)
+	marshaller.AddUnmarshaller("Started", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Updated", convertTimestampToTime)
+	return marshaller
+}
+
+func (p *SchedulerPauseEntity) Table() string {
+	return tblSchedulerPauses
+}
+
+func (p *SchedulerPauseEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherPause, ok := other.(*SchedulerPauseEntity)
+	if !ok {
+		return false
+	}
+	return p.ID == otherPause.ID &&
+		p.Duration == otherPause.Duration &&
+		p.Resumed == otherPause.Resumed
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblSubscriptions string = "inventory_status_subscriptions"
+
+//SubscriptionEntity is a webhook which receives the status changes of all clusters or of a single cluster.
+//The secret signs the deliveries: it's never returned by the API.
+type SubscriptionEntity struct {
+	ID        string    `db:"notNull"`
+	URL       string    `db:"notNull"`
+	Secret    string    `db:"notNull,encrypt"`
+	RuntimeID string    `db:""` //empty if the status changes of all clusters are delivered
+	Created   time.Time `db:"readOnly"`
+}
+
+//Matches returns true if the status changes of the cluster are delivered to the subscription
+func (s *SubscriptionEntity) Matches(runtimeID string) bool {
+	return s.RuntimeID == "" || s.RuntimeID == runtimeID
+}
+
+func (s *SubscriptionEntity) String() string {
+	return fmt.Sprintf("SubscriptionEntity [ID=%s,URL=%s,RuntimeID=%s]", s.ID, s.URL, s.RuntimeID)
+}
+
+func (s *SubscriptionEntity) New() db.DatabaseEntity {
+	return &SubscriptionEntity{}
+}
+
+func (s *SubscriptionEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&s)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (s *SubscriptionEntity) Table() string {
+	return tblSubscriptions
+}
+
+func (s *SubscriptionEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherSubscription, ok := other.(*SubscriptionEntity)
+	if ok {
+		return s.ID == otherSubscription.ID &&
+			s.URL == otherSubscription.URL &&
+			s.Secret == otherSubscription.Secret &&
+			s.RuntimeID == otherSubscription.RuntimeID
+	}
+	return false
+}
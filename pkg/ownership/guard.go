@@ -0,0 +1,196 @@
+package ownership
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	kubeclient "github.com/kyma-incubator/reconciler/pkg/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	//MarkerName is the name of the ConfigMap which marks a cluster as owned by a mothership
+	MarkerName = "reconciler-mothership-owner"
+
+	markerKeyMothership = "mothership"
+	markerKeyRuntimeID  = "runtimeID"
+	markerKeyClaimed    = "claimed"
+
+	defaultNamespace = "kube-system"
+	defaultTimeout   = 10 * time.Second
+	//verificationTTL is the time a verified ownership is trusted before the marker is checked again
+	verificationTTL = 5 * time.Minute
+)
+
+//OwnedByOtherMothershipError indicates that a cluster is reconciled by another mothership
+type OwnedByOtherMothershipError struct {
+	RuntimeID string
+	Owner     string
+}
+
+func (e *OwnedByOtherMothershipError) Error() string {
+	return fmt.Sprintf("cluster '%s' is owned by mothership '%s': "+
+		"the takeover of the cluster has to be forced before it gets reconciled by this mothership", e.RuntimeID, e.Owner)
+}
+
+func IsOwnedByOtherMothershipError(err error) bool {
+	var ownedErr *OwnedByOtherMothershipError
+	return errors.As(err, &ownedErr)
+}
+
+//store provides the forced takeovers of clusters (implemented by the inventory)
+type store interface {
+	IsForceTakeover(runtimeID string) (bool, error)
+	SetForceTakeover(runtimeID string, force bool) error
+}
+
+//clientFactory creates a client for the cluster which is accessible with the kubeconfig
+type clientFactory func(ctx context.Context, kubeconfig string) (kubernetes.Interface, error)
+
+//Guard writes an ownership marker into each reconciled cluster and prevents the reconciliation of clusters which are
+//owned by another mothership
+type Guard struct {
+	identity  string
+	namespace string
+	timeout   time.Duration
+	store     store
+	newClient clientFactory
+	logger    *zap.SugaredLogger
+	now       func() time.Time
+
+	mu       sync.Mutex
+	verified map[string]time.Time
+}
+
+//NewGuard returns nil if the takeover protection is disabled
+func NewGuard(cfg config.TakeoverProtectionConfig, store store, logger *zap.SugaredLogger) (*Guard, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.Identity == "" {
+		return nil, fmt.Errorf("identity of the mothership is required by the takeover protection")
+	}
+	timeout := defaultTimeout
+	if cfg.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("timeout '%s' of the takeover protection is not a positive duration", cfg.Timeout)
+		}
+	}
+	guard := &Guard{
+		identity:  cfg.Identity,
+		namespace: cfg.Namespace,
+		timeout:   timeout,
+		store:     store,
+		logger:    logger,
+		now:       time.Now,
+		verified:  make(map[string]time.Time),
+		newClient: func(ctx context.Context, kubeconfig string) (kubernetes.Interface, error) {
+			return kubeclient.NewClientBuilder().WithLogger(logger).WithString(kubeconfig).Build(ctx, false)
+		},
+	}
+	if guard.namespace == "" {
+		guard.namespace = defaultNamespace
+	}
+	return guard, nil
+}
+
+//Claim verifies that the cluster is owned by this mothership: the ownership marker is written if the cluster has
+//none yet or if the takeover from another mothership was forced. An OwnedByOtherMothershipError is returned if the
+//cluster belongs to another mothership, other errors indicate that the marker couldn't be verified.
+func (g *Guard) Claim(ctx context.Context, state *cluster.State) error {
+	if g == nil {
+		return nil
+	}
+	runtimeID := state.Cluster.RuntimeID
+	if g.isVerified(runtimeID) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	client, err := g.newClient(ctx, state.Cluster.Kubeconfig)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create client for cluster '%s'", runtimeID)
+	}
+	configMaps := client.CoreV1().ConfigMaps(g.namespace)
+
+	marker, err := configMaps.Get(ctx, MarkerName, metav1.GetOptions{})
+	if k8serr.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, g.newMarker(runtimeID), metav1.CreateOptions{})
+		if err == nil {
+			g.logger.Infof("Takeover protection marked cluster '%s' as owned by mothership '%s'", runtimeID, g.identity)
+			g.markVerified(runtimeID)
+			return nil
+		}
+		if !k8serr.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to create ownership marker in cluster '%s'", runtimeID)
+		}
+		//marker was created concurrently (e.g. by another worker): its owner has to be verified
+		marker, err = configMaps.Get(ctx, MarkerName, metav1.GetOptions{})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to retrieve ownership marker of cluster '%s'", runtimeID)
+	}
+
+	owner := marker.Data[markerKeyMothership]
+	if owner == g.identity {
+		g.markVerified(runtimeID)
+		return nil
+	}
+	forced, err := g.store.IsForceTakeover(runtimeID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check whether the takeover of cluster '%s' is forced", runtimeID)
+	}
+	if !forced {
+		return &OwnedByOtherMothershipError{RuntimeID: runtimeID, Owner: owner}
+	}
+
+	marker.Data = g.newMarker(runtimeID).Data
+	if _, err := configMaps.Update(ctx, marker, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to update ownership marker of cluster '%s'", runtimeID)
+	}
+	//the forced takeover applies only once: afterwards the cluster is protected against the previous owner
+	if err := g.store.SetForceTakeover(runtimeID, false); err != nil {
+		g.logger.Warnf("Takeover protection failed to reset the forced takeover of cluster '%s': %s", runtimeID, err)
+	}
+	g.logger.Warnf("Takeover protection took over cluster '%s' from mothership '%s' (forced)", runtimeID, owner)
+	g.markVerified(runtimeID)
+	return nil
+}
+
+func (g *Guard) newMarker(runtimeID string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MarkerName,
+			Namespace: g.namespace,
+		},
+		Data: map[string]string{
+			markerKeyMothership: g.identity,
+			markerKeyRuntimeID:  runtimeID,
+			markerKeyClaimed:    g.now().UTC().Format(time.RFC3339),
+		},
+	}
+}
+
+func (g *Guard) isVerified(runtimeID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	verified, ok := g.verified[runtimeID]
+	return ok && g.now().Sub(verified) < verificationTTL
+}
+
+func (g *Guard) markVerified(runtimeID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.verified[runtimeID] = g.now()
+}
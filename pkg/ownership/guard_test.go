@@ -0,0 +1,115 @@
+package ownership
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type testStore struct {
+	forced bool
+}
+
+func (s *testStore) IsForceTakeover(_ string) (bool, error) {
+	return s.forced, nil
+}
+
+func (s *testStore) SetForceTakeover(_ string, force bool) error {
+	s.forced = force
+	return nil
+}
+
+func newTestGuard(t *testing.T, identity string, store store, clientset kubernetes.Interface) *Guard {
+	guard, err := NewGuard(config.TakeoverProtectionConfig{Enabled: true, Identity: identity}, store, logger.NewLogger(true))
+	require.NoError(t, err)
+	guard.newClient = func(_ context.Context, _ string) (kubernetes.Interface, error) {
+		return clientset, nil
+	}
+	return guard
+}
+
+func TestGuard(t *testing.T) {
+	state := &cluster.State{Cluster: &model.ClusterEntity{RuntimeID: "abc", Kubeconfig: "kubeconfig"}}
+	getOwner := func(t *testing.T, clientset kubernetes.Interface) string {
+		marker, err := clientset.CoreV1().ConfigMaps(defaultNamespace).Get(context.Background(), MarkerName, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "abc", marker.Data[markerKeyRuntimeID])
+		return marker.Data[markerKeyMothership]
+	}
+
+	t.Run("Disabled guard", func(t *testing.T) {
+		guard, err := NewGuard(config.TakeoverProtectionConfig{}, &testStore{}, logger.NewLogger(true))
+		require.NoError(t, err)
+		require.Nil(t, guard)
+		require.NoError(t, guard.Claim(context.Background(), state))
+	})
+
+	t.Run("Invalid config", func(t *testing.T) {
+		_, err := NewGuard(config.TakeoverProtectionConfig{Enabled: true}, &testStore{}, logger.NewLogger(true))
+		require.Error(t, err)
+		_, err = NewGuard(config.TakeoverProtectionConfig{Enabled: true, Identity: "prod", Timeout: "-1s"},
+			&testStore{}, logger.NewLogger(true))
+		require.Error(t, err)
+	})
+
+	t.Run("Unmarked cluster is claimed", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		guard := newTestGuard(t, "prod", &testStore{}, clientset)
+		require.NoError(t, guard.Claim(context.Background(), state))
+		require.Equal(t, "prod", getOwner(t, clientset))
+		require.NoError(t, guard.Claim(context.Background(), state), "owned cluster is accepted")
+	})
+
+	t.Run("Cluster of other mothership is rejected", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		require.NoError(t, newTestGuard(t, "staging", &testStore{}, clientset).Claim(context.Background(), state))
+
+		err := newTestGuard(t, "prod", &testStore{}, clientset).Claim(context.Background(), state)
+		require.True(t, IsOwnedByOtherMothershipError(err))
+		require.Contains(t, err.Error(), "'staging'")
+		require.Equal(t, "staging", getOwner(t, clientset))
+	})
+
+	t.Run("Forced takeover", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: MarkerName, Namespace: defaultNamespace},
+			Data:       map[string]string{markerKeyMothership: "staging", markerKeyRuntimeID: "abc"},
+		})
+		store := &testStore{forced: true}
+		require.NoError(t, newTestGuard(t, "prod", store, clientset).Claim(context.Background(), state))
+		require.Equal(t, "prod", getOwner(t, clientset))
+		require.False(t, store.forced, "forced takeover applies only once")
+
+		err := newTestGuard(t, "staging", store, clientset).Claim(context.Background(), state)
+		require.True(t, IsOwnedByOtherMothershipError(err), "previous owner is rejected after the takeover")
+	})
+
+	t.Run("Verified ownership is cached", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		guard := newTestGuard(t, "prod", &testStore{}, clientset)
+		now := time.Now()
+		guard.now = func() time.Time { return now }
+		require.NoError(t, guard.Claim(context.Background(), state))
+
+		//marker is replaced by another mothership: detected after the cached verification expired
+		marker, err := clientset.CoreV1().ConfigMaps(defaultNamespace).Get(context.Background(), MarkerName, metav1.GetOptions{})
+		require.NoError(t, err)
+		marker.Data[markerKeyMothership] = "staging"
+		_, err = clientset.CoreV1().ConfigMaps(defaultNamespace).Update(context.Background(), marker, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		require.NoError(t, guard.Claim(context.Background(), state))
+		now = now.Add(verificationTTL)
+		require.True(t, IsOwnedByOtherMothershipError(guard.Claim(context.Background(), state)))
+	})
+}
@@ -0,0 +1,128 @@
+package payload
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
+)
+
+//Repository stores the raw KEB payloads which led to a cluster configuration. Payloads can be used to
+//reproduce issues of the model conversion with production traffic.
+type Repository struct {
+	*repository.Repository
+}
+
+func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{repo}, nil
+}
+
+//Add stores the compressed payload and links it to the configuration version it led to
+func (pr *Repository) Add(runtimeID string, configVersion, contract int64, payload []byte) (*model.PayloadEntity, error) {
+	compressed, err := Compress(payload)
+	if err != nil {
+		return nil, err
+	}
+	entity := &model.PayloadEntity{
+		RuntimeID:     runtimeID,
+		ConfigVersion: configVersion,
+		Contract:      contract,
+		Payload:       compressed,
+	}
+	q, err := db.NewQuery(pr.Conn, entity, pr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.Insert().Exec(); err != nil {
+		pr.Logger.Errorf("PayloadRepository failed to store payload of cluster '%s' (config version: %d): %s",
+			runtimeID, configVersion, err)
+		return nil, err
+	}
+	return entity, nil
+}
+
+//Get returns the payload which led to the configuration version of a cluster. The most recent payload
+//of the cluster is returned if the configuration version is 0.
+func (pr *Repository) Get(runtimeID string, configVersion int64) (*model.PayloadEntity, error) {
+	q, err := db.NewQuery(pr.Conn, &model.PayloadEntity{}, pr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{"RuntimeID": runtimeID}
+	if configVersion > 0 {
+		whereCond["ConfigVersion"] = configVersion
+	}
+	entity, err := q.Select().
+		Where(whereCond).
+		OrderBy(map[string]string{"ID": "DESC"}).
+		Limit(1).
+		GetOne()
+	if err != nil {
+		return nil, pr.NewNotFoundError(err, &model.PayloadEntity{}, whereCond)
+	}
+	return entity.(*model.PayloadEntity), nil
+}
+
+//DeleteOlderThan removes all payloads which were stored before the deadline
+func (pr *Repository) DeleteOlderThan(deadline time.Time) (int64, error) {
+	q, err := db.NewQuery(pr.Conn, &model.PayloadEntity{}, pr.Logger)
+	if err != nil {
+		return 0, err
+	}
+	colHdlr, err := db.NewColumnHandler(&model.PayloadEntity{}, pr.Conn, pr.Logger)
+	if err != nil {
+		return 0, err
+	}
+	createdCol, err := colHdlr.ColumnName("Created")
+	if err != nil {
+		return 0, err
+	}
+	deleteStmt := q.Delete()
+	return deleteStmt.
+		WhereRaw(fmt.Sprintf("%s<$%d", createdCol, deleteStmt.NextPlaceholderCount()), deadline.Format(db.TimeFormat)).
+		Exec()
+}
+
+//Compress gzips the payload and encodes it as base64 string
+func Compress(payload []byte) (string, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(payload); err != nil {
+		return "", errors.Wrap(err, "failed to compress payload")
+	}
+	if err := writer.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to compress payload")
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+//Decompress restores the payload which was compressed by Compress
+func Decompress(compressed string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(compressed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode payload")
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decompress payload")
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	payload, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decompress payload")
+	}
+	return payload, nil
+}
@@ -0,0 +1,28 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompression(t *testing.T) {
+	t.Run("Roundtrip", func(t *testing.T) {
+		payload := []byte(`{"runtimeID":"abc","kubeconfig":"xyz","kymaConfig":{"version":"2.0.0"}}`)
+		compressed, err := Compress(payload)
+		require.NoError(t, err)
+		require.NotEmpty(t, compressed)
+
+		decompressed, err := Decompress(compressed)
+		require.NoError(t, err)
+		require.Equal(t, payload, decompressed)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := Decompress("not-base64!")
+		require.Error(t, err)
+
+		_, err = Decompress("aW52YWxpZA==") //'invalid' is not gzipped
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,81 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const maxResponseBytes = 1024 * 1024
+
+//Endpoint is an external admission service (policy engine or validation webhook) which evaluates JSON requests
+type Endpoint struct {
+	name   string
+	url    string
+	client *http.Client
+	logger *zap.SugaredLogger
+}
+
+//NewEndpoint returns the endpoint of an admission service. The timeout is a duration string (the default timeout
+//is used if it's empty).
+func NewEndpoint(name, url, timeout string, defaultTimeout time.Duration, logger *zap.SugaredLogger) (*Endpoint, error) {
+	timeoutDuration := defaultTimeout
+	if timeout != "" {
+		var err error
+		if timeoutDuration, err = time.ParseDuration(timeout); err != nil || timeoutDuration <= 0 {
+			return nil, fmt.Errorf("timeout '%s' of %s is not a positive duration", timeout, name)
+		}
+	}
+	return &Endpoint{
+		name:   name,
+		url:    url,
+		client: &http.Client{Timeout: timeoutDuration},
+		logger: logger,
+	}, nil
+}
+
+func (ep *Endpoint) URL() string {
+	return ep.url
+}
+
+//Post sends the request as JSON and unmarshals the response of the endpoint into the result
+func (ep *Endpoint) Post(ctx context.Context, request, result interface{}) error {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	httpResp, err := ep.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := httpResp.Body.Close(); err != nil {
+			ep.logger.Warnf("Failed to close response body of %s: %s", ep.name, err)
+		}
+	}()
+	body, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, maxResponseBytes))
+	if err != nil {
+		return err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s responded with HTTP code %d: %s", ep.name, httpResp.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal response of %s", ep.name)
+	}
+	return nil
+}
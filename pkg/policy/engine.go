@@ -0,0 +1,141 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const defaultTimeout = 5 * time.Second
+
+type Operation string
+
+const (
+	OperationCreate Operation = "create"
+	OperationUpdate Operation = "update"
+)
+
+//RejectionError indicates that a cluster configuration violates the policies
+type RejectionError struct {
+	RuntimeID  string
+	Violations []string
+}
+
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("configuration of cluster '%s' was rejected by policy: %s",
+		e.RuntimeID, strings.Join(e.Violations, "; "))
+}
+
+func IsRejectionError(err error) bool {
+	var rejectionErr *RejectionError
+	return errors.As(err, &rejectionErr)
+}
+
+//Decision is the result of a policy evaluation
+type Decision struct {
+	Violations []string
+	//Mutated is true if the policy replaced the Kyma configuration of the cluster
+	Mutated bool
+}
+
+type input struct {
+	Operation       Operation   `json:"operation"`
+	ContractVersion int64       `json:"contractVersion"`
+	Cluster         keb.Cluster `json:"cluster"`
+}
+
+type request struct {
+	Input input `json:"input"`
+}
+
+type response struct {
+	Result *struct {
+		Deny       []string        `json:"deny"`
+		KymaConfig *keb.KymaConfig `json:"kymaConfig"`
+	} `json:"result"`
+}
+
+//Engine evaluates the admission policies of cluster configurations by calling the decision API of OPA
+type Engine struct {
+	endpoint *Endpoint
+	failOpen bool
+	logger   *zap.SugaredLogger
+}
+
+//NewEngine returns the policy engine or nil if no policy engine is configured
+func NewEngine(cfg config.PolicyConfig, logger *zap.SugaredLogger) (*Engine, error) {
+	if cfg.URL == "" {
+		return nil, nil
+	}
+	endpoint, err := NewEndpoint("policy engine", cfg.URL, cfg.Timeout, defaultTimeout, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &Engine{
+		endpoint: endpoint,
+		failOpen: cfg.FailOpen,
+		logger:   logger,
+	}, nil
+}
+
+//Admit evaluates the policies for the cluster configuration: a configuration with violations is rejected by
+//returning a RejectionError, a mutated Kyma configuration replaces the configuration of the cluster.
+//All configurations are admitted if no policy engine is configured.
+func (e *Engine) Admit(ctx context.Context, op Operation, contractVersion int64, cluster *keb.Cluster) (*Decision, error) {
+	if e == nil {
+		return &Decision{}, nil
+	}
+	resp, err := e.evaluate(ctx, op, contractVersion, cluster)
+	if err != nil {
+		if e.failOpen {
+			e.logger.Warnf("Policy engine failed to evaluate configuration of cluster '%s' (admitted because "+
+				"of fail-open mode): %s", cluster.RuntimeID, err)
+			return &Decision{}, nil
+		}
+		return nil, errors.Wrap(err, "policy engine failed to evaluate configuration")
+	}
+
+	decision := &Decision{Violations: resp.Result.Deny}
+	if len(decision.Violations) > 0 {
+		e.logger.Warnf("Policy engine rejected %s of cluster '%s': %s",
+			op, cluster.RuntimeID, strings.Join(decision.Violations, "; "))
+		return decision, &RejectionError{RuntimeID: cluster.RuntimeID, Violations: decision.Violations}
+	}
+	if resp.Result.KymaConfig != nil && !reflect.DeepEqual(*resp.Result.KymaConfig, cluster.KymaConfig) {
+		cluster.KymaConfig = *resp.Result.KymaConfig
+		decision.Mutated = true
+		e.logger.Infof("Policy engine admitted %s of cluster '%s' with mutated configuration", op, cluster.RuntimeID)
+	} else {
+		e.logger.Debugf("Policy engine admitted %s of cluster '%s'", op, cluster.RuntimeID)
+	}
+	return decision, nil
+}
+
+func (e *Engine) evaluate(ctx context.Context, op Operation, contractVersion int64, cluster *keb.Cluster) (*response, error) {
+	//the kubeconfig is never passed to the policy engine
+	clusterInput := *cluster
+	clusterInput.Kubeconfig = ""
+	var resp response
+	err := e.endpoint.Post(ctx, request{
+		Input: input{
+			Operation:       op,
+			ContractVersion: contractVersion,
+			Cluster:         clusterInput,
+		},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	//OPA returns no result if the decision is undefined (e.g. the policy isn't loaded)
+	if resp.Result == nil {
+		return nil, fmt.Errorf("policy decision '%s' is undefined", e.endpoint.URL())
+	}
+	return &resp, nil
+}
@@ -0,0 +1,110 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newPolicyServer(t *testing.T, handler func(in input) interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Empty(t, req.Input.Cluster.Kubeconfig)
+		require.NoError(t, json.NewEncoder(w).Encode(handler(req.Input)))
+	}))
+}
+
+func newCluster() *keb.Cluster {
+	return &keb.Cluster{
+		RuntimeID:  "abc",
+		Kubeconfig: "secret",
+		KymaConfig: keb.KymaConfig{
+			Version:    "2.0.0",
+			Components: []keb.Component{{Component: "istio"}},
+		},
+	}
+}
+
+func TestEngine(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	t.Run("No policy engine configured", func(t *testing.T) {
+		engine, err := NewEngine(config.PolicyConfig{}, logger)
+		require.NoError(t, err)
+		require.Nil(t, engine)
+		decision, err := engine.Admit(context.Background(), OperationCreate, 1, newCluster())
+		require.NoError(t, err)
+		require.False(t, decision.Mutated)
+	})
+
+	t.Run("Invalid timeout", func(t *testing.T) {
+		_, err := NewEngine(config.PolicyConfig{URL: "http://localhost", Timeout: "soon"}, logger)
+		require.Error(t, err)
+	})
+
+	t.Run("Configuration is rejected", func(t *testing.T) {
+		srv := newPolicyServer(t, func(in input) interface{} {
+			require.Equal(t, OperationUpdate, in.Operation)
+			require.Equal(t, "abc", in.Cluster.RuntimeID)
+			return map[string]interface{}{
+				"result": map[string]interface{}{"deny": []string{"component 'monitoring' is mandatory"}},
+			}
+		})
+		defer srv.Close()
+
+		engine, err := NewEngine(config.PolicyConfig{URL: srv.URL}, logger)
+		require.NoError(t, err)
+		cluster := newCluster()
+		decision, err := engine.Admit(context.Background(), OperationUpdate, 1, cluster)
+		require.True(t, IsRejectionError(err))
+		require.Contains(t, err.Error(), "component 'monitoring' is mandatory")
+		require.Equal(t, []string{"component 'monitoring' is mandatory"}, decision.Violations)
+		require.Equal(t, "secret", cluster.Kubeconfig)
+	})
+
+	t.Run("Configuration is mutated", func(t *testing.T) {
+		srv := newPolicyServer(t, func(in input) interface{} {
+			kymaConfig := in.Cluster.KymaConfig
+			kymaConfig.Components = append(kymaConfig.Components, keb.Component{Component: "monitoring"})
+			return map[string]interface{}{
+				"result": map[string]interface{}{"kymaConfig": kymaConfig},
+			}
+		})
+		defer srv.Close()
+
+		engine, err := NewEngine(config.PolicyConfig{URL: srv.URL}, logger)
+		require.NoError(t, err)
+		cluster := newCluster()
+		decision, err := engine.Admit(context.Background(), OperationCreate, 1, cluster)
+		require.NoError(t, err)
+		require.True(t, decision.Mutated)
+		require.Len(t, cluster.KymaConfig.Components, 2)
+		require.Equal(t, "monitoring", cluster.KymaConfig.Components[1].Component)
+	})
+
+	t.Run("Undefined decision", func(t *testing.T) {
+		srv := newPolicyServer(t, func(in input) interface{} {
+			return map[string]interface{}{}
+		})
+		defer srv.Close()
+
+		engine, err := NewEngine(config.PolicyConfig{URL: srv.URL}, logger)
+		require.NoError(t, err)
+		_, err = engine.Admit(context.Background(), OperationCreate, 1, newCluster())
+		require.Error(t, err)
+		require.False(t, IsRejectionError(err))
+
+		engine, err = NewEngine(config.PolicyConfig{URL: srv.URL, FailOpen: true}, logger)
+		require.NoError(t, err)
+		_, err = engine.Admit(context.Background(), OperationCreate, 1, newCluster())
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,151 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	//maxConditions limits the complexity of a filter to protect the database
+	maxConditions = 25
+	//maxNestingLevel limits the depth of nested AND/OR groups
+	maxNestingLevel = 5
+)
+
+type Operator string
+
+const (
+	OperatorEqual          Operator = "eq"
+	OperatorNotEqual       Operator = "ne"
+	OperatorLessThan       Operator = "lt"
+	OperatorLessOrEqual    Operator = "le"
+	OperatorGreaterThan    Operator = "gt"
+	OperatorGreaterOrEqual Operator = "ge"
+	OperatorIn             Operator = "in"
+)
+
+var sqlOperators = map[Operator]string{
+	OperatorEqual:          "=",
+	OperatorNotEqual:       "<>",
+	OperatorLessThan:       "<",
+	OperatorLessOrEqual:    "<=",
+	OperatorGreaterThan:    ">",
+	OperatorGreaterOrEqual: ">=",
+	OperatorIn:             "IN",
+}
+
+//Filter is either a condition (field, op, value) or a group of filters combined by AND or OR
+type Filter struct {
+	Field string      `json:"field,omitempty"`
+	Op    Operator    `json:"op,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+	And   []*Filter   `json:"and,omitempty"`
+	Or    []*Filter   `json:"or,omitempty"`
+}
+
+//InvalidQueryError indicates that a query uses unknown resources, fields or operators or is too complex
+type InvalidQueryError struct {
+	msg string
+}
+
+func (e *InvalidQueryError) Error() string {
+	return fmt.Sprintf("query is invalid: %s", e.msg)
+}
+
+func newInvalidQueryError(format string, args ...interface{}) error {
+	return &InvalidQueryError{msg: fmt.Sprintf(format, args...)}
+}
+
+func IsInvalidQueryError(err error) bool {
+	_, ok := err.(*InvalidQueryError)
+	return ok
+}
+
+//sqlBuilder renders a filter to a SQL condition: values are never rendered into the SQL statement but
+//passed as arguments
+type sqlBuilder struct {
+	resource   *resource
+	columns    map[string]string //field name -> column name
+	plcHdrIdx  int
+	args       []interface{}
+	conditions int
+}
+
+func (b *sqlBuilder) build(filter *Filter, level int) (string, error) {
+	if level > maxNestingLevel {
+		return "", newInvalidQueryError("filter is nested deeper than %d levels", maxNestingLevel)
+	}
+	isGroup := len(filter.And) > 0 || len(filter.Or) > 0
+	switch {
+	case isGroup && (filter.Field != "" || filter.Op != ""):
+		return "", newInvalidQueryError("filter has to be either a condition or an AND/OR group")
+	case len(filter.And) > 0 && len(filter.Or) > 0:
+		return "", newInvalidQueryError("filter group can't combine AND and OR")
+	case len(filter.And) > 0:
+		return b.buildGroup(filter.And, "AND", level)
+	case len(filter.Or) > 0:
+		return b.buildGroup(filter.Or, "OR", level)
+	default:
+		return b.buildCondition(filter)
+	}
+}
+
+func (b *sqlBuilder) buildGroup(filters []*Filter, operator string, level int) (string, error) {
+	var conditions []string
+	for _, filter := range filters {
+		if filter == nil {
+			return "", newInvalidQueryError("filter group contains an empty filter")
+		}
+		condition, err := b.build(filter, level+1)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, condition)
+	}
+	return fmt.Sprintf("(%s)", strings.Join(conditions, fmt.Sprintf(" %s ", operator))), nil
+}
+
+func (b *sqlBuilder) buildCondition(filter *Filter) (string, error) {
+	b.conditions++
+	if b.conditions > maxConditions {
+		return "", newInvalidQueryError("filter contains more than %d conditions", maxConditions)
+	}
+	field, ok := b.resource.fields[filter.Field]
+	if !ok {
+		return "", newInvalidQueryError("field '%s' is not supported for resource '%s' (supported fields: %s)",
+			filter.Field, b.resource.name, strings.Join(b.resource.fieldNames(), ", "))
+	}
+	sqlOperator, ok := sqlOperators[filter.Op]
+	if !ok {
+		return "", newInvalidQueryError("operator '%s' of field '%s' is not supported", filter.Op, filter.Field)
+	}
+	column := b.columns[field.name]
+
+	if filter.Op != OperatorIn {
+		value, err := field.convert(filter.Value)
+		if err != nil {
+			return "", newInvalidQueryError("value of field '%s' is invalid: %s", filter.Field, err)
+		}
+		return fmt.Sprintf("%s%s%s", column, sqlOperator, b.placeholder(value)), nil
+	}
+
+	values, ok := filter.Value.([]interface{})
+	if !ok || len(values) == 0 {
+		return "", newInvalidQueryError("operator '%s' of field '%s' requires a non-empty list of values", filter.Op, filter.Field)
+	}
+	var plcHdrs []string
+	for _, rawValue := range values {
+		value, err := field.convert(rawValue)
+		if err != nil {
+			return "", newInvalidQueryError("value of field '%s' is invalid: %s", filter.Field, err)
+		}
+		plcHdrs = append(plcHdrs, b.placeholder(value))
+	}
+	return fmt.Sprintf("%s IN (%s)", column, strings.Join(plcHdrs, ",")), nil
+}
+
+func (b *sqlBuilder) placeholder(value interface{}) string {
+	b.args = append(b.args, value)
+	b.plcHdrIdx++
+	return fmt.Sprintf("$%d", b.plcHdrIdx)
+}
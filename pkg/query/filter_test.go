@@ -0,0 +1,87 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	columns := map[string]string{}
+	for _, field := range resources[ResourceOperations].fields {
+		columns[field.name] = "col_" + field.name
+	}
+
+	tests := []struct {
+		name      string
+		filter    string
+		condition string
+		args      []interface{}
+		invalid   bool
+	}{
+		{
+			name:      "Single condition",
+			filter:    `{"field":"component","op":"eq","value":"istio"}`,
+			condition: "col_Component=$1",
+			args:      []interface{}{"istio"},
+		},
+		{
+			name: "Nested groups",
+			filter: `{"and":[{"field":"state","op":"in","value":["error","failed"]},` +
+				`{"or":[{"field":"retries","op":"gt","value":3},{"field":"created","op":"ge","value":"2023-05-01T12:00:00Z"}]}]}`,
+			condition: "(col_State IN ($1,$2) AND (col_Retries>$3 OR col_Created>=$4))",
+			args:      []interface{}{"error", "failed", int64(3), "2023-05-01 12:00:00.000000"},
+		},
+		{
+			name:    "Unknown field",
+			filter:  `{"field":"kubeconfig","op":"eq","value":"abc"}`,
+			invalid: true,
+		},
+		{
+			name:    "Unknown operator",
+			filter:  `{"field":"component","op":"like","value":"%"}`,
+			invalid: true,
+		},
+		{
+			name:    "Invalid value type",
+			filter:  `{"field":"retries","op":"eq","value":"three"}`,
+			invalid: true,
+		},
+		{
+			name:    "IN without list",
+			filter:  `{"field":"state","op":"in","value":"error"}`,
+			invalid: true,
+		},
+		{
+			name:    "Condition and group combined",
+			filter:  `{"field":"state","op":"eq","value":"error","and":[{"field":"component","op":"eq","value":"istio"}]}`,
+			invalid: true,
+		},
+		{
+			name:    "AND and OR combined",
+			filter:  `{"and":[{"field":"state","op":"eq","value":"error"}],"or":[{"field":"component","op":"eq","value":"istio"}]}`,
+			invalid: true,
+		},
+		{
+			name:    "Too deeply nested",
+			filter:  `{"and":[{"and":[{"and":[{"and":[{"and":[{"and":[{"field":"state","op":"eq","value":"error"}]}]}]}]}]}]}`,
+			invalid: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var filter Filter
+			require.NoError(t, json.Unmarshal([]byte(tc.filter), &filter))
+			builder := &sqlBuilder{resource: resources[ResourceOperations], columns: columns}
+			condition, err := builder.build(&filter, 0)
+			if tc.invalid {
+				require.True(t, IsInvalidQueryError(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.condition, condition)
+			require.Equal(t, tc.args, builder.args)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+//Query selects entities of a resource which match the filter (all entities are selected if the filter is empty)
+type Query struct {
+	Resource Resource `json:"resource"`
+	Filter   *Filter  `json:"filter,omitempty"`
+	Limit    int      `json:"limit,omitempty"`
+}
+
+//Repository executes read-only queries over the inventory and reconciliation data. Queries can only
+//use whitelisted fields and operators and are always rendered with placeholders: they can't modify
+//data or access columns which aren't exposed.
+type Repository struct {
+	*repository.Repository
+}
+
+func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{repo}, nil
+}
+
+//Execute runs the query and returns the exposed fields of the matching entities (latest first)
+func (qr *Repository) Execute(query *Query) ([]map[string]interface{}, error) {
+	res, ok := resources[query.Resource]
+	if !ok {
+		return nil, newInvalidQueryError("resource '%s' is not supported (supported resources: %s, %s, %s)",
+			query.Resource, ResourceClusters, ResourceReconciliations, ResourceOperations)
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	} else if limit > maxLimit {
+		return nil, newInvalidQueryError("limit must not be higher than %d", maxLimit)
+	}
+
+	entity := res.entity()
+	colHandler, err := db.NewColumnHandler(entity, qr.Conn, qr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]string, len(res.fields))
+	for _, field := range res.fields {
+		if columns[field.name], err = colHandler.ColumnName(field.name); err != nil {
+			return nil, err
+		}
+	}
+
+	q, err := db.NewQuery(qr.Conn, entity, qr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	selectQ := q.Select()
+	if res.onlyLatest {
+		idCol, err := colHandler.ColumnName("ID")
+		if err != nil {
+			return nil, err
+		}
+		runtimeIDCol, err := colHandler.ColumnName("RuntimeID")
+		if err != nil {
+			return nil, err
+		}
+		deletedCol, err := colHandler.ColumnName("Deleted")
+		if err != nil {
+			return nil, err
+		}
+		selectQ.WhereRaw(fmt.Sprintf("%s IN (SELECT MAX(%s) FROM %s WHERE %s=$%d GROUP BY %s)",
+			idCol, idCol, entity.Table(), deletedCol, selectQ.NextPlaceholderCount(), runtimeIDCol), false)
+	}
+	if query.Filter != nil {
+		builder := &sqlBuilder{
+			resource:  res,
+			columns:   columns,
+			plcHdrIdx: selectQ.NextPlaceholderCount() - 1,
+		}
+		condition, err := builder.build(query.Filter, 0)
+		if err != nil {
+			return nil, err
+		}
+		selectQ.WhereRaw(condition, builder.args...)
+	}
+
+	entities, err := selectQ.OrderBy(res.order).Limit(limit).GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]map[string]interface{}, 0, len(entities))
+	for _, match := range entities {
+		result = append(result, res.toResult(match))
+	}
+	return result, nil
+}
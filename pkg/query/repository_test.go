@@ -0,0 +1,78 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb/test"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	conn := db.NewTestConnection(t)
+	repo, err := NewRepository(conn, true)
+	require.NoError(t, err)
+
+	inventory, err := cluster.NewInventory(conn, true, cluster.MetricsCollectorMock{})
+	require.NoError(t, err)
+	reconRepo, err := reconciliation.NewPersistedReconciliationRepository(conn, true)
+	require.NoError(t, err)
+
+	clusterState, err := inventory.CreateOrUpdate(1, test.NewCluster(t, "1", 1, false, test.Production))
+	require.NoError(t, err)
+	runtimeID := clusterState.Cluster.RuntimeID
+	recon, err := reconRepo.CreateReconciliation(clusterState, &model.ReconciliationSequenceConfig{})
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, reconRepo.RemoveReconciliationByRuntimeID(runtimeID))
+		require.NoError(t, inventory.Delete(runtimeID))
+	}()
+
+	newQuery := func(resource Resource, filter string) *Query {
+		query := &Query{Resource: resource}
+		if filter != "" {
+			query.Filter = &Filter{}
+			require.NoError(t, json.Unmarshal([]byte(filter), query.Filter))
+		}
+		return query
+	}
+
+	t.Run("Query clusters", func(t *testing.T) {
+		items, err := repo.Execute(newQuery(ResourceClusters,
+			`{"and":[{"field":"runtimeID","op":"eq","value":"`+runtimeID+`"},{"field":"status","op":"in","value":["reconcile_pending","reconciling"]}]}`))
+		require.NoError(t, err)
+		require.Len(t, items, 1) //only the latest status is returned
+		require.Equal(t, clusterState.Configuration.Version, items[0]["configVersion"])
+	})
+
+	t.Run("Query reconciliations", func(t *testing.T) {
+		items, err := repo.Execute(newQuery(ResourceReconciliations,
+			`{"field":"runtimeID","op":"eq","value":"`+runtimeID+`"}`))
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		require.Equal(t, recon.SchedulingID, items[0]["schedulingID"])
+	})
+
+	t.Run("Query operations", func(t *testing.T) {
+		component := clusterState.Configuration.Components[0].Component
+		items, err := repo.Execute(newQuery(ResourceOperations,
+			`{"and":[{"field":"schedulingID","op":"eq","value":"`+recon.SchedulingID+`"},{"field":"component","op":"eq","value":"`+component+`"}]}`))
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		require.Equal(t, component, items[0]["component"])
+		require.NotContains(t, items[0], "debug") //only whitelisted fields are returned
+	})
+
+	t.Run("Reject invalid queries", func(t *testing.T) {
+		_, err := repo.Execute(newQuery("kubeconfigs", ""))
+		require.True(t, IsInvalidQueryError(err))
+
+		_, err = repo.Execute(&Query{Resource: ResourceOperations, Limit: maxLimit + 1})
+		require.True(t, IsInvalidQueryError(err))
+	})
+}
@@ -0,0 +1,146 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+type Resource string
+
+const (
+	ResourceClusters        Resource = "clusters"
+	ResourceReconciliations Resource = "reconciliations"
+	ResourceOperations      Resource = "operations"
+)
+
+type fieldType int
+
+const (
+	fieldTypeString fieldType = iota
+	fieldTypeInt
+	fieldTypeBool
+	fieldTypeTime
+)
+
+//field is a queryable attribute of a resource
+type field struct {
+	name      string //name of the entity field
+	fieldType fieldType
+}
+
+func (f field) convert(value interface{}) (interface{}, error) {
+	switch f.fieldType {
+	case fieldTypeString:
+		if str, ok := value.(string); ok {
+			return str, nil
+		}
+		return nil, fmt.Errorf("string expected but got '%v'", value)
+	case fieldTypeInt:
+		if number, ok := value.(float64); ok && number == float64(int64(number)) {
+			return int64(number), nil
+		}
+		return nil, fmt.Errorf("integer expected but got '%v'", value)
+	case fieldTypeBool:
+		if flag, ok := value.(bool); ok {
+			return flag, nil
+		}
+		return nil, fmt.Errorf("boolean expected but got '%v'", value)
+	case fieldTypeTime:
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("RFC3339 timestamp expected but got '%v'", value)
+		}
+		timestamp, err := time.Parse(time.RFC3339, str)
+		if err != nil {
+			return nil, fmt.Errorf("RFC3339 timestamp expected but got '%s'", str)
+		}
+		return timestamp.UTC().Format(db.TimeFormatMicros), nil
+	default:
+		return nil, fmt.Errorf("field type '%d' is not supported", f.fieldType)
+	}
+}
+
+//resource defines which fields of an entity can be queried: fields which aren't listed are never exposed
+type resource struct {
+	name   Resource
+	entity func() db.DatabaseEntity
+	fields map[string]field //JSON name -> entity field
+	order  map[string]string
+	//onlyLatest returns only the latest entity per runtime ID (e.g. the current status of a cluster)
+	onlyLatest bool
+}
+
+func (r *resource) fieldNames() []string {
+	var names []string
+	for name := range r.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+//toResult converts an entity to a map which contains only the queryable fields
+func (r *resource) toResult(entity db.DatabaseEntity) map[string]interface{} {
+	result := make(map[string]interface{}, len(r.fields))
+	value := reflect.ValueOf(entity).Elem()
+	for name, field := range r.fields {
+		result[name] = value.FieldByName(field.name).Interface()
+	}
+	return result
+}
+
+var resources = map[Resource]*resource{
+	ResourceClusters: {
+		name:   ResourceClusters,
+		entity: func() db.DatabaseEntity { return &model.ClusterStatusEntity{} },
+		fields: map[string]field{
+			"runtimeID":      {"RuntimeID", fieldTypeString},
+			"clusterVersion": {"ClusterVersion", fieldTypeInt},
+			"configVersion":  {"ConfigVersion", fieldTypeInt},
+			"status":         {"Status", fieldTypeString},
+			"created":        {"Created", fieldTypeTime},
+		},
+		order:      map[string]string{"ID": "DESC"},
+		onlyLatest: true,
+	},
+	ResourceReconciliations: {
+		name:   ResourceReconciliations,
+		entity: func() db.DatabaseEntity { return &model.ReconciliationEntity{} },
+		fields: map[string]field{
+			"runtimeID":     {"RuntimeID", fieldTypeString},
+			"schedulingID":  {"SchedulingID", fieldTypeString},
+			"configVersion": {"ClusterConfig", fieldTypeInt},
+			"status":        {"Status", fieldTypeString},
+			"finished":      {"Finished", fieldTypeBool},
+			"created":       {"Created", fieldTypeTime},
+			"updated":       {"Updated", fieldTypeTime},
+		},
+		order: map[string]string{"Created": "DESC"},
+	},
+	ResourceOperations: {
+		name:   ResourceOperations,
+		entity: func() db.DatabaseEntity { return &model.OperationEntity{} },
+		fields: map[string]field{
+			"runtimeID":          {"RuntimeID", fieldTypeString},
+			"schedulingID":       {"SchedulingID", fieldTypeString},
+			"correlationID":      {"CorrelationID", fieldTypeString},
+			"configVersion":      {"ClusterConfig", fieldTypeInt},
+			"component":          {"Component", fieldTypeString},
+			"type":               {"Type", fieldTypeString},
+			"state":              {"State", fieldTypeString},
+			"reason":             {"Reason", fieldTypeString},
+			"priority":           {"Priority", fieldTypeInt},
+			"retries":            {"Retries", fieldTypeInt},
+			"processingDuration": {"ProcessingDuration", fieldTypeInt},
+			"reconcilerVersion":  {"ReconcilerVersion", fieldTypeString},
+			"created":            {"Created", fieldTypeTime},
+			"updated":            {"Updated", fieldTypeTime},
+		},
+		order: map[string]string{"Created": "DESC"},
+	},
+}
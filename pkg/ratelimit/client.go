@@ -0,0 +1,138 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+)
+
+//cleanupInterval defines how often buckets of idle clients are dropped
+const cleanupInterval = time.Minute
+
+type bucketLimit struct {
+	rate  float64 //tokens per second
+	burst float64
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  bucketLimit
+}
+
+//ClientLimiter limits the requests per client (caller identity or source IP) with a token bucket: each client can
+//send a burst of requests at once, afterwards the requests are limited to the refill rate of its bucket
+type ClientLimiter struct {
+	global    bucketLimit
+	overrides map[string]bucketLimit
+
+	m           sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+}
+
+//NewClientLimiter returns the limiter or nil if no client is rate limited
+func NewClientLimiter(cfg config.ClientRateLimitConfig) (*ClientLimiter, error) {
+	if cfg.RequestsPerSecond == 0 && len(cfg.Clients) == 0 {
+		return nil, nil
+	}
+	global, err := newBucketLimit(cfg.RequestsPerSecond, cfg.Burst)
+	if err != nil {
+		return nil, err
+	}
+	limiter := &ClientLimiter{
+		global:    global,
+		overrides: make(map[string]bucketLimit, len(cfg.Clients)),
+		buckets:   make(map[string]*bucket),
+	}
+	for client, override := range cfg.Clients {
+		if limiter.overrides[client], err = newBucketLimit(override.RequestsPerSecond, override.Burst); err != nil {
+			return nil, fmt.Errorf("rate limit of client '%s' is invalid: %s", client, err)
+		}
+	}
+	return limiter, nil
+}
+
+func newBucketLimit(requestsPerSecond float64, burst int) (bucketLimit, error) {
+	if requestsPerSecond < 0 {
+		return bucketLimit{}, fmt.Errorf("requests per second cannot be < 0 but was %f", requestsPerSecond)
+	}
+	if burst < 0 {
+		return bucketLimit{}, fmt.Errorf("burst cannot be < 0 but was %d", burst)
+	}
+	result := bucketLimit{rate: requestsPerSecond, burst: float64(burst)}
+	if burst == 0 {
+		result.burst = math.Ceil(requestsPerSecond)
+	}
+	return result, nil
+}
+
+//limit returns the rate limit of the client: overrides apply only to authenticated clients because unauthenticated
+//clients (identified by their source IP) could impersonate a client with a higher limit
+func (l *ClientLimiter) limit(client string, authenticated bool) bucketLimit {
+	if !authenticated {
+		return l.global
+	}
+	if override, ok := l.overrides[client]; ok {
+		return override
+	}
+	return l.global
+}
+
+//Allow consumes a token of the client's bucket: if the bucket is empty, the request is rejected and the time until
+//the next token is available is returned
+func (l *ClientLimiter) Allow(client string, authenticated bool, now time.Time) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.cleanup(now)
+
+	limit := l.limit(client, authenticated)
+	if limit.rate == 0 {
+		return true, 0
+	}
+	//authenticated clients and source IPs never share a bucket
+	key := client
+	if !authenticated {
+		key = "ip:" + client
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: limit.burst, last: now, limit: limit}
+		l.buckets[key] = b
+	}
+	b.tokens = limit.refill(b, now)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / limit.rate * float64(time.Second))
+}
+
+//refill returns the tokens of the bucket after adding the tokens which were refilled since its last request
+func (limit bucketLimit) refill(b *bucket, now time.Time) float64 {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed < 0 { //clock was adjusted backwards
+		elapsed = 0
+	}
+	return math.Min(limit.burst, b.tokens+elapsed*limit.rate)
+}
+
+//cleanup drops the buckets which are full again: a new bucket of the client would behave the same
+func (l *ClientLimiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < cleanupInterval {
+		return
+	}
+	l.lastCleanup = now
+	for key, b := range l.buckets {
+		if b.limit.refill(b, now) >= b.limit.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientLimiter(t *testing.T) {
+	limiter, err := NewClientLimiter(config.ClientRateLimitConfig{})
+	require.NoError(t, err)
+	require.Nil(t, limiter)
+
+	//disabled limiter accepts all requests
+	allowed, _ := limiter.Allow("keb", true, time.Now())
+	require.True(t, allowed)
+
+	for _, cfg := range []config.ClientRateLimitConfig{
+		{RequestsPerSecond: -1},
+		{RequestsPerSecond: 1, Burst: -1},
+		{RequestsPerSecond: 1, Clients: map[string]config.ClientRateLimitOverride{"keb": {RequestsPerSecond: -1}}},
+	} {
+		_, err := NewClientLimiter(cfg)
+		require.Error(t, err)
+	}
+}
+
+func TestClientLimiter(t *testing.T) {
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Token bucket", func(t *testing.T) {
+		limiter, err := NewClientLimiter(config.ClientRateLimitConfig{RequestsPerSecond: 2, Burst: 3})
+		require.NoError(t, err)
+
+		//burst is accepted at once
+		for i := 0; i < 3; i++ {
+			allowed, _ := limiter.Allow("keb", true, now)
+			require.True(t, allowed)
+		}
+		allowed, retryAfter := limiter.Allow("keb", true, now)
+		require.False(t, allowed)
+		require.Equal(t, 500*time.Millisecond, retryAfter)
+
+		//other clients are not affected
+		allowed, _ = limiter.Allow("10.0.0.1", false, now)
+		require.True(t, allowed)
+
+		//a token was refilled
+		allowed, _ = limiter.Allow("keb", true, now.Add(500*time.Millisecond))
+		require.True(t, allowed)
+		allowed, _ = limiter.Allow("keb", true, now.Add(500*time.Millisecond))
+		require.False(t, allowed)
+	})
+
+	t.Run("Default burst", func(t *testing.T) {
+		limiter, err := NewClientLimiter(config.ClientRateLimitConfig{RequestsPerSecond: 0.5})
+		require.NoError(t, err)
+
+		allowed, _ := limiter.Allow("keb", true, now)
+		require.True(t, allowed)
+		allowed, retryAfter := limiter.Allow("keb", true, now)
+		require.False(t, allowed)
+		require.Equal(t, 2*time.Second, retryAfter)
+	})
+
+	t.Run("Client overrides", func(t *testing.T) {
+		limiter, err := NewClientLimiter(config.ClientRateLimitConfig{
+			RequestsPerSecond: 1,
+			Clients: map[string]config.ClientRateLimitOverride{
+				"keb-e2e":  {RequestsPerSecond: 10, Burst: 10},
+				"internal": {RequestsPerSecond: 0},
+			},
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 10; i++ {
+			allowed, _ := limiter.Allow("keb-e2e", true, now)
+			require.True(t, allowed)
+			allowed, _ = limiter.Allow("internal", true, now)
+			require.True(t, allowed)
+		}
+		allowed, _ := limiter.Allow("keb-e2e", true, now)
+		require.False(t, allowed)
+
+		//overrides don't apply to unauthenticated clients (e.g. a source IP named like an override)
+		allowed, _ = limiter.Allow("internal", false, now)
+		require.True(t, allowed)
+		allowed, _ = limiter.Allow("internal", false, now)
+		require.False(t, allowed)
+	})
+
+	t.Run("Idle buckets are dropped", func(t *testing.T) {
+		limiter, err := NewClientLimiter(config.ClientRateLimitConfig{RequestsPerSecond: 1, Burst: 5})
+		require.NoError(t, err)
+
+		allowed, _ := limiter.Allow("keb", true, now)
+		require.True(t, allowed)
+		allowed, _ = limiter.Allow("10.0.0.1", false, now.Add(cleanupInterval))
+		require.True(t, allowed)
+		require.Len(t, limiter.buckets, 1)
+	})
+}
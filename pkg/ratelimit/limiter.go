@@ -0,0 +1,146 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+)
+
+const defaultPeriod = time.Minute
+
+type limit struct {
+	maxUpdates int
+	period     time.Duration
+}
+
+//Rejections is the number of rejected configuration updates of a cluster
+type Rejections struct {
+	RuntimeID string
+	Count     int
+}
+
+//UpdateLimiter limits the configuration versions which can be created per cluster within a sliding time window
+type UpdateLimiter struct {
+	global    limit
+	overrides map[string]limit
+
+	m          sync.Mutex
+	updates    map[string][]time.Time
+	rejections map[string]int
+}
+
+//NewUpdateLimiter returns the limiter or nil if no cluster is rate limited
+func NewUpdateLimiter(cfg config.UpdateRateLimitConfig) (*UpdateLimiter, error) {
+	if cfg.MaxUpdates == 0 && len(cfg.Clusters) == 0 {
+		return nil, nil
+	}
+	global, err := newLimit(cfg.MaxUpdates, cfg.Period, defaultPeriod)
+	if err != nil {
+		return nil, err
+	}
+	limiter := &UpdateLimiter{
+		global:     global,
+		overrides:  make(map[string]limit, len(cfg.Clusters)),
+		updates:    make(map[string][]time.Time),
+		rejections: make(map[string]int),
+	}
+	for runtimeID, override := range cfg.Clusters {
+		if limiter.overrides[runtimeID], err = newLimit(override.MaxUpdates, override.Period, global.period); err != nil {
+			return nil, fmt.Errorf("update rate limit of cluster '%s' is invalid: %s", runtimeID, err)
+		}
+	}
+	return limiter, nil
+}
+
+func newLimit(maxUpdates int, period string, defaultPeriod time.Duration) (limit, error) {
+	if maxUpdates < 0 {
+		return limit{}, fmt.Errorf("max. updates cannot be < 0 but was %d", maxUpdates)
+	}
+	result := limit{maxUpdates: maxUpdates, period: defaultPeriod}
+	if period != "" {
+		var err error
+		if result.period, err = time.ParseDuration(period); err != nil || result.period <= 0 {
+			return limit{}, fmt.Errorf("period '%s' is not a positive duration", period)
+		}
+	}
+	return result, nil
+}
+
+func (l *UpdateLimiter) limit(runtimeID string) limit {
+	if override, ok := l.overrides[runtimeID]; ok {
+		return override
+	}
+	return l.global
+}
+
+//Allow checks whether the cluster can accept another configuration update: if the limit is exceeded, the time
+//until the next update is accepted is returned. The check doesn't count as update (see Record).
+func (l *UpdateLimiter) Allow(runtimeID string, now time.Time) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	limit := l.limit(runtimeID)
+	if limit.maxUpdates == 0 {
+		return true, 0
+	}
+	updates := l.prune(runtimeID, limit, now)
+	if len(updates) < limit.maxUpdates {
+		return true, 0
+	}
+	l.rejections[runtimeID]++
+	//the oldest update within the window has to expire before another update is accepted
+	return false, updates[len(updates)-limit.maxUpdates].Add(limit.period).Sub(now)
+}
+
+//Record counts a configuration update of the cluster (only updates which created a new configuration version
+//have to be recorded)
+func (l *UpdateLimiter) Record(runtimeID string, now time.Time) {
+	if l == nil {
+		return
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	limit := l.limit(runtimeID)
+	if limit.maxUpdates == 0 {
+		return
+	}
+	l.updates[runtimeID] = append(l.prune(runtimeID, limit, now), now)
+}
+
+//prune drops the updates of the cluster which are outside of the window
+func (l *UpdateLimiter) prune(runtimeID string, limit limit, now time.Time) []time.Time {
+	updates := l.updates[runtimeID]
+	idx := 0
+	for idx < len(updates) && !updates[idx].After(now.Add(-limit.period)) {
+		idx++
+	}
+	updates = updates[idx:]
+	if len(updates) == 0 {
+		delete(l.updates, runtimeID)
+		return nil
+	}
+	l.updates[runtimeID] = updates
+	return updates
+}
+
+//Rejections returns the number of rejected updates per cluster (ordered by runtime ID)
+func (l *UpdateLimiter) Rejections() []Rejections {
+	if l == nil {
+		return nil
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	result := make([]Rejections, 0, len(l.rejections))
+	for runtimeID, count := range l.rejections {
+		result = append(result, Rejections{RuntimeID: runtimeID, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RuntimeID < result[j].RuntimeID
+	})
+	return result
+}
@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUpdateLimiter(t *testing.T) {
+	limiter, err := NewUpdateLimiter(config.UpdateRateLimitConfig{})
+	require.NoError(t, err)
+	require.Nil(t, limiter)
+
+	//disabled limiter accepts all updates
+	limiter.Record("abc", time.Now())
+	allowed, _ := limiter.Allow("abc", time.Now())
+	require.True(t, allowed)
+	require.Empty(t, limiter.Rejections())
+
+	for _, cfg := range []config.UpdateRateLimitConfig{
+		{MaxUpdates: -1},
+		{MaxUpdates: 1, Period: "one minute"},
+		{MaxUpdates: 1, Clusters: map[string]config.UpdateRateLimitOverride{"abc": {MaxUpdates: 1, Period: "0s"}}},
+	} {
+		_, err := NewUpdateLimiter(cfg)
+		require.Error(t, err)
+	}
+}
+
+func TestUpdateLimiter(t *testing.T) {
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Global limit", func(t *testing.T) {
+		limiter, err := NewUpdateLimiter(config.UpdateRateLimitConfig{MaxUpdates: 2})
+		require.NoError(t, err)
+
+		limiter.Record("abc", now)
+		limiter.Record("abc", now.Add(10*time.Second))
+		allowed, retryAfter := limiter.Allow("abc", now.Add(20*time.Second))
+		require.False(t, allowed)
+		require.Equal(t, 40*time.Second, retryAfter)
+
+		//other clusters are not affected
+		allowed, _ = limiter.Allow("xyz", now.Add(20*time.Second))
+		require.True(t, allowed)
+
+		//oldest update left the window
+		allowed, _ = limiter.Allow("abc", now.Add(time.Minute))
+		require.True(t, allowed)
+
+		require.Equal(t, []Rejections{{RuntimeID: "abc", Count: 1}}, limiter.Rejections())
+	})
+
+	t.Run("Cluster overrides", func(t *testing.T) {
+		limiter, err := NewUpdateLimiter(config.UpdateRateLimitConfig{
+			MaxUpdates: 1,
+			Period:     "1h",
+			Clusters: map[string]config.UpdateRateLimitOverride{
+				"automated": {MaxUpdates: 3},
+				"unlimited": {MaxUpdates: 0},
+			},
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			allowed, _ := limiter.Allow("automated", now)
+			require.True(t, allowed)
+			limiter.Record("automated", now)
+			limiter.Record("unlimited", now)
+		}
+		allowed, retryAfter := limiter.Allow("automated", now.Add(time.Minute))
+		require.False(t, allowed)
+		//override inherits the global period
+		require.Equal(t, 59*time.Minute, retryAfter)
+
+		allowed, _ = limiter.Allow("unlimited", now)
+		require.True(t, allowed)
+	})
+}
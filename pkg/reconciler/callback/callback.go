@@ -1,9 +1,82 @@
 package callback
 
 import (
+	"errors"
+	"fmt"
+	"sync"
+
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/redact"
+	"github.com/kyma-incubator/reconciler/pkg/version"
 )
 
 type Handler interface {
 	Callback(msg *reconciler.CallbackMessage) error
 }
+
+//OperationAbortedError is returned if the mothership rejected a callback because the reconciliation of the
+//operation was cancelled: the component reconciler has to stop processing the operation
+type OperationAbortedError struct {
+	Reason string
+}
+
+func (err *OperationAbortedError) Error() string {
+	return fmt.Sprintf("operation was aborted by the mothership: %s", err.Reason)
+}
+
+func IsOperationAbortedError(err error) bool {
+	var abortedErr *OperationAbortedError
+	return errors.As(err, &abortedErr)
+}
+
+//redactMessage returns a copy of the callback message which doesn't expose sensitive data in the error message
+func redactMessage(msg *reconciler.CallbackMessage) *reconciler.CallbackMessage {
+	redacted := *msg
+	redacted.Error = redact.String(msg.Error)
+	return &redacted
+}
+
+//withReconcilerVersion adds the build of this component reconciler to the callback message: the mothership
+//tracks which reconciler build processed an operation
+func withReconcilerVersion(msg *reconciler.CallbackMessage) *reconciler.CallbackMessage {
+	if msg.ReconcilerVersion == nil {
+		reconcilerVersion := version.Get().GitCommit
+		msg.ReconcilerVersion = &reconcilerVersion
+	}
+	return msg
+}
+
+//sequencingHandler numbers the callbacks upwards from the callback sequence which the mothership assigned to the
+//dispatch of the operation: the mothership uses it to ignore duplicated or outdated callbacks (e.g. a retried
+//callback which arrives after a newer one or a callback of a previous dispatch)
+type sequencingHandler struct {
+	handler Handler
+	mu      sync.Mutex
+	last    int64
+}
+
+//WithSequence numbers the callbacks of the handler upwards from the callback sequence of the task. The callbacks
+//aren't numbered if the task has no callback sequence (it was dispatched by a mothership of an older version).
+func WithSequence(handler Handler, callbackSequence int64) Handler {
+	if callbackSequence <= 0 {
+		return handler
+	}
+	return &sequencingHandler{
+		handler: handler,
+		last:    callbackSequence,
+	}
+}
+
+func (h *sequencingHandler) Callback(msg *reconciler.CallbackMessage) error {
+	if msg.Sequence == nil {
+		h.mu.Lock()
+		h.last++
+		next := h.last
+		h.mu.Unlock()
+
+		numbered := *msg
+		numbered.Sequence = &next
+		msg = &numbered
+	}
+	return h.handler.Callback(msg)
+}
@@ -7,6 +7,7 @@ import (
 	log "github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/test"
+	"github.com/kyma-incubator/reconciler/pkg/version"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,4 +58,65 @@ func TestLocalCallbackHandler(t *testing.T) {
 			Status: reconciler.StatusRunning,
 		}))
 	})
+	t.Run("Test sensitive data is redacted", func(t *testing.T) {
+		var received *reconciler.CallbackMessage
+		rcb, err := NewLocalCallbackHandler(func(msg *reconciler.CallbackMessage) error {
+			received = msg
+			return nil
+		}, logger)
+		require.NoError(t, err)
+		require.NoError(t, rcb.Callback(&reconciler.CallbackMessage{
+			Status: reconciler.StatusFailed,
+			Error:  "request failed: Authorization: Bearer abc.def-123",
+		}))
+		require.NotContains(t, received.Error, "abc.def-123")
+	})
+
+	t.Run("Test reconciler version is reported", func(t *testing.T) {
+		var received *reconciler.CallbackMessage
+		rcb, err := NewLocalCallbackHandler(func(msg *reconciler.CallbackMessage) error {
+			received = msg
+			return nil
+		}, logger)
+		require.NoError(t, err)
+		require.NoError(t, rcb.Callback(&reconciler.CallbackMessage{
+			Status: reconciler.StatusSuccess,
+		}))
+		require.NotNil(t, received.ReconcilerVersion)
+		require.Equal(t, version.Get().GitCommit, *received.ReconcilerVersion)
+	})
+
+	t.Run("Test sequence is increasing", func(t *testing.T) {
+		var received []*reconciler.CallbackMessage
+		rcb, err := NewLocalCallbackHandler(func(msg *reconciler.CallbackMessage) error {
+			received = append(received, msg)
+			return nil
+		}, logger)
+		require.NoError(t, err)
+		rcb = WithSequence(rcb, 100)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, rcb.Callback(&reconciler.CallbackMessage{
+				Status: reconciler.StatusRunning,
+			}))
+		}
+		require.Len(t, received, 3)
+		require.Equal(t, int64(101), *received[0].Sequence)
+		for i := 1; i < len(received); i++ {
+			require.Greater(t, *received[i].Sequence, *received[i-1].Sequence)
+		}
+	})
+
+	t.Run("Test sequence is missing without callback sequence of the task", func(t *testing.T) {
+		var received *reconciler.CallbackMessage
+		rcb, err := NewLocalCallbackHandler(func(msg *reconciler.CallbackMessage) error {
+			received = msg
+			return nil
+		}, logger)
+		require.NoError(t, err)
+		rcb = WithSequence(rcb, 0)
+		require.NoError(t, rcb.Callback(&reconciler.CallbackMessage{
+			Status: reconciler.StatusRunning,
+		}))
+		require.Nil(t, received.Sequence)
+	})
 }
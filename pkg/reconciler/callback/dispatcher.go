@@ -0,0 +1,325 @@
+package callback
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/callback/client"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultBatchInterval     = 500 * time.Millisecond
+	defaultMaxBatchSize      = 50
+	defaultRequestsPerSecond = 10
+	defaultMaxQueueSize      = 1000
+)
+
+//callbackURLRegex extracts the batch endpoint, scheduling ID and correlation ID from a callback URL
+var callbackURLRegex = regexp.MustCompile(`^(.+/v\d+/operations)/([^/]+)/callback/([^/]+)$`)
+
+//OverflowError indicates that a callback was rejected because too many callbacks are waiting to be sent
+type OverflowError struct {
+	queueSize int
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("callback rejected: %d callbacks are already waiting to be sent", e.queueSize)
+}
+
+func IsOverflowError(err error) bool {
+	var overflowErr *OverflowError
+	return errors.As(err, &overflowErr)
+}
+
+type DispatcherConfig struct {
+	BatchInterval     time.Duration
+	MaxBatchSize      int
+	RequestsPerSecond int
+	MaxQueueSize      int
+	//Client used to call the mothership (the shared default client is used if nil)
+	Client *client.Client
+}
+
+func (c *DispatcherConfig) validate() error {
+	switch {
+	case c.BatchInterval < 0:
+		return fmt.Errorf("callback batch interval cannot be < 0 (got %s)", c.BatchInterval)
+	case c.MaxBatchSize < 0:
+		return fmt.Errorf("callback batch size cannot be < 0 (got %d)", c.MaxBatchSize)
+	case c.RequestsPerSecond < 0:
+		return fmt.Errorf("callback requests per second cannot be < 0 (got %d)", c.RequestsPerSecond)
+	case c.MaxQueueSize < 0:
+		return fmt.Errorf("callback queue size cannot be < 0 (got %d)", c.MaxQueueSize)
+	}
+	if c.BatchInterval == 0 {
+		c.BatchInterval = defaultBatchInterval
+	}
+	if c.MaxBatchSize == 0 {
+		c.MaxBatchSize = defaultMaxBatchSize
+	}
+	if c.RequestsPerSecond == 0 {
+		c.RequestsPerSecond = defaultRequestsPerSecond
+	}
+	if c.MaxQueueSize == 0 {
+		c.MaxQueueSize = defaultMaxQueueSize
+	}
+	if c.Client == nil {
+		c.Client = client.Default()
+	}
+	return nil
+}
+
+type pendingCallback struct {
+	callbackURL string
+	msg         *reconciler.CallbackMessage
+	waiters     []chan error
+}
+
+func (p *pendingCallback) finish(err error) {
+	for _, waiter := range p.waiters {
+		waiter <- err
+	}
+}
+
+//Dispatcher throttles the callbacks of all operations processed by a component reconciler. Callbacks are collected
+//and sent in batches to the mothership (if the mothership supports batches), repeated status updates of an operation
+//are combined and the number of requests per second is limited. Callbacks are rejected if the queue is full.
+type Dispatcher struct {
+	config      DispatcherConfig
+	logger      *zap.SugaredLogger
+	client      *client.Client
+	mutex       sync.Mutex
+	queue       []*pendingCallback
+	stopped     bool
+	lastRequest time.Time
+	//batch endpoints which are not supported by the mothership (older versions)
+	unsupportedBatchURLs map[string]bool
+}
+
+func NewDispatcher(ctx context.Context, config DispatcherConfig, logger *zap.SugaredLogger) (*Dispatcher, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	dispatcher := &Dispatcher{
+		config:               config,
+		logger:               logger,
+		client:               config.Client,
+		unsupportedBatchURLs: make(map[string]bool),
+	}
+	go dispatcher.run(ctx)
+	return dispatcher, nil
+}
+
+//Send queues the callback and blocks until it was delivered to the mothership
+func (d *Dispatcher) Send(callbackURL string, msg *reconciler.CallbackMessage) error {
+	done := make(chan error, 1)
+	if err := d.enqueue(callbackURL, msg, done); err != nil {
+		d.logger.Warnf("Callback dispatcher rejected status '%s' for '%s': %s", msg.Status, callbackURL, err)
+		return err
+	}
+	return <-done
+}
+
+func (d *Dispatcher) enqueue(callbackURL string, msg *reconciler.CallbackMessage, done chan error) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.stopped {
+		return fmt.Errorf("callback dispatcher is stopped")
+	}
+
+	//repeated status updates of an operation (e.g. heartbeats) are combined: only the latest one is sent
+	for i := len(d.queue) - 1; i >= 0; i-- {
+		pending := d.queue[i]
+		if pending.callbackURL != callbackURL {
+			continue
+		}
+		if pending.msg.Status == msg.Status && pending.msg.RetryID == msg.RetryID {
+			pending.msg = msg
+			pending.waiters = append(pending.waiters, done)
+			return nil
+		}
+		break
+	}
+
+	if len(d.queue) >= d.config.MaxQueueSize {
+		return &OverflowError{queueSize: len(d.queue)}
+	}
+	d.queue = append(d.queue, &pendingCallback{
+		callbackURL: callbackURL,
+		msg:         msg,
+		waiters:     []chan error{done},
+	})
+	return nil
+}
+
+func (d *Dispatcher) drain(stop bool) []*pendingCallback {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	pendings := d.queue
+	d.queue = nil
+	d.stopped = d.stopped || stop
+	return pendings
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.BatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			for _, pending := range d.drain(true) {
+				pending.finish(fmt.Errorf("callback dispatcher stopped: %s", ctx.Err()))
+			}
+			return
+		case <-ticker.C:
+			d.flush(ctx)
+		}
+	}
+}
+
+func (d *Dispatcher) flush(ctx context.Context) {
+	pendings := d.drain(false)
+	if len(pendings) == 0 {
+		return
+	}
+
+	//group the callbacks by their batch endpoint (the order of the callbacks is kept)
+	var requests [][]*pendingCallback
+	batches := make(map[string]int) //batch URL -> index of the request which is filled
+	for _, pending := range pendings {
+		batchURL, _, _ := parseCallbackURL(pending.callbackURL)
+		if batchURL == "" || d.unsupportedBatchURLs[batchURL] {
+			requests = append(requests, []*pendingCallback{pending})
+			continue
+		}
+		idx, ok := batches[batchURL]
+		if !ok || len(requests[idx]) >= d.config.MaxBatchSize {
+			requests = append(requests, nil)
+			idx = len(requests) - 1
+			batches[batchURL] = idx
+		}
+		requests[idx] = append(requests[idx], pending)
+	}
+
+	for i, request := range requests {
+		if err := d.throttle(ctx); err != nil {
+			for _, remaining := range requests[i:] {
+				for _, pending := range remaining {
+					pending.finish(err)
+				}
+			}
+			return
+		}
+		if len(request) == 1 {
+			request[0].finish(sendCallback(d.client, request[0].callbackURL, request[0].msg, d.logger))
+			continue
+		}
+		d.sendBatch(request)
+	}
+}
+
+//throttle ensures the configured requests per second are not exceeded
+func (d *Dispatcher) throttle(ctx context.Context) error {
+	wait := time.Until(d.lastRequest.Add(time.Second / time.Duration(d.config.RequestsPerSecond)))
+	if wait > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("callback dispatcher stopped: %s", ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+	d.lastRequest = time.Now()
+	return nil
+}
+
+func (d *Dispatcher) sendBatch(pendings []*pendingCallback) {
+	batchURL, _, _ := parseCallbackURL(pendings[0].callbackURL)
+	batch := reconciler.CallbackBatch{}
+	for _, pending := range pendings {
+		_, schedulingID, correlationID := parseCallbackURL(pending.callbackURL)
+		batch.Callbacks = append(batch.Callbacks, reconciler.BatchedCallbackMessage{
+			CorrelationID: correlationID,
+			Message:       *pending.msg,
+			SchedulingID:  schedulingID,
+		})
+	}
+
+	results, err := d.postBatch(batchURL, batch)
+	if err == errBatchUnsupported {
+		d.logger.Infof("Callback dispatcher falls back to single requests: "+
+			"mothership doesn't support callback batches on '%s'", batchURL)
+		d.unsupportedBatchURLs[batchURL] = true
+		for _, pending := range pendings {
+			pending.finish(sendCallback(d.client, pending.callbackURL, pending.msg, d.logger))
+		}
+		return
+	}
+	if err == nil && len(results) != len(pendings) {
+		err = fmt.Errorf("mothership returned %d results for a batch of %d callbacks", len(results), len(pendings))
+	}
+	if err != nil {
+		d.logger.Warnf("Callback dispatcher failed to send batch of %d callbacks: %s", len(pendings), err)
+		for _, pending := range pendings {
+			pending.finish(err)
+		}
+		return
+	}
+
+	d.logger.Debugf("Callback dispatcher sent batch of %d callbacks", len(pendings))
+	for i, result := range results {
+		if result.StatusCode == http.StatusOK {
+			pendings[i].finish(nil)
+			continue
+		}
+		var reason string
+		if result.Error != nil {
+			reason = *result.Error
+		}
+		if result.StatusCode == http.StatusGone { //the reconciliation of the operation was cancelled
+			pendings[i].finish(&OperationAbortedError{Reason: reason})
+			continue
+		}
+		pendings[i].finish(fmt.Errorf("mothership rejected callback [HTTP response code: %d]: %s",
+			result.StatusCode, reason))
+	}
+}
+
+var errBatchUnsupported = errors.New("callback batches are not supported")
+
+func (d *Dispatcher) postBatch(batchURL string, batch reconciler.CallbackBatch) ([]reconciler.CallbackResult, error) {
+	resp, err := d.client.Post(context.Background(), batchURL, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var batchResp reconciler.CallbackBatchResponse
+		if err := resp.Decode(&batchResp); err != nil {
+			return nil, errors.Wrap(err, "failed to decode response of callback batch")
+		}
+		return batchResp.Results, nil
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		return nil, errBatchUnsupported
+	default:
+		return nil, fmt.Errorf("mothership rejected callback batch [HTTP response code: %d]", resp.StatusCode)
+	}
+}
+
+//parseCallbackURL returns the batch endpoint, scheduling ID and correlation ID of a callback URL
+//(the batch endpoint is empty if the URL doesn't follow the callback contract)
+func parseCallbackURL(callbackURL string) (string, string, string) {
+	matches := callbackURLRegex.FindStringSubmatch(callbackURL)
+	if matches == nil {
+		return "", "", ""
+	}
+	return matches[1] + "/callbacks", matches[2], matches[3]
+}
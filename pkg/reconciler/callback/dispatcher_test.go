@@ -0,0 +1,222 @@
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+type callbackServer struct {
+	batchSupported bool
+	mutex          sync.Mutex
+	batches        [][]reconciler.BatchedCallbackMessage
+	singles        []reconciler.CallbackMessage
+}
+
+func (cs *callbackServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/operations/callbacks", func(w http.ResponseWriter, r *http.Request) {
+		if !cs.batchSupported {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var batch reconciler.CallbackBatch
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		cs.mutex.Lock()
+		cs.batches = append(cs.batches, batch.Callbacks)
+		cs.mutex.Unlock()
+
+		resp := reconciler.CallbackBatchResponse{}
+		for _, callback := range batch.Callbacks {
+			result := reconciler.CallbackResult{
+				CorrelationID: callback.CorrelationID,
+				SchedulingID:  callback.SchedulingID,
+				StatusCode:    http.StatusOK,
+			}
+			if callback.CorrelationID == "unknown" {
+				errMsg := "operation not found"
+				result.StatusCode = http.StatusNotFound
+				result.Error = &errMsg
+			}
+			if callback.CorrelationID == "aborted" {
+				errMsg := "reconciliation was cancelled"
+				result.StatusCode = http.StatusGone
+				result.Error = &errMsg
+			}
+			resp.Results = append(resp.Results, result)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/unknown") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/aborted") {
+			w.WriteHeader(http.StatusGone)
+			_ = json.NewEncoder(w).Encode(reconciler.HTTPErrorResponse{Error: "reconciliation was cancelled"})
+			return
+		}
+		var msg reconciler.CallbackMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		cs.mutex.Lock()
+		cs.singles = append(cs.singles, msg)
+		cs.mutex.Unlock()
+	})
+	return mux
+}
+
+func sendParallel(dispatcher *Dispatcher, callbackURLs []string, status reconciler.Status) []error {
+	errs := make([]error, len(callbackURLs))
+	var wg sync.WaitGroup
+	for i, callbackURL := range callbackURLs {
+		wg.Add(1)
+		go func(i int, callbackURL string) {
+			defer wg.Done()
+			errs[i] = dispatcher.Send(callbackURL, &reconciler.CallbackMessage{Status: status, RetryID: "1"})
+		}(i, callbackURL)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestDispatcher(t *testing.T) {
+	logger := log.NewLogger(true)
+
+	t.Run("Callbacks are sent in batches", func(t *testing.T) {
+		cs := &callbackServer{batchSupported: true}
+		server := httptest.NewServer(cs.handler())
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		dispatcher, err := NewDispatcher(ctx, DispatcherConfig{BatchInterval: 200 * time.Millisecond, MaxBatchSize: 2}, logger)
+		require.NoError(t, err)
+
+		errs := sendParallel(dispatcher, []string{
+			server.URL + "/v1/operations/s1/callback/c1",
+			server.URL + "/v1/operations/s1/callback/c2",
+			server.URL + "/v1/operations/s1/callback/unknown",
+		}, reconciler.StatusRunning)
+		require.NoError(t, errs[0])
+		require.NoError(t, errs[1])
+		require.Error(t, errs[2])
+
+		cs.mutex.Lock()
+		defer cs.mutex.Unlock()
+		total := len(cs.singles)
+		for _, batch := range cs.batches {
+			require.LessOrEqual(t, len(batch), 2)
+			total += len(batch)
+		}
+		require.Equal(t, 3, total)
+		require.NotEmpty(t, cs.batches)
+	})
+
+	t.Run("Repeated status updates are combined", func(t *testing.T) {
+		cs := &callbackServer{batchSupported: true}
+		server := httptest.NewServer(cs.handler())
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		dispatcher, err := NewDispatcher(ctx, DispatcherConfig{BatchInterval: 500 * time.Millisecond}, logger)
+		require.NoError(t, err)
+
+		callbackURL := server.URL + "/v1/operations/s1/callback/c1"
+		for _, err := range sendParallel(dispatcher, []string{callbackURL, callbackURL, callbackURL}, reconciler.StatusRunning) {
+			require.NoError(t, err)
+		}
+
+		cs.mutex.Lock()
+		defer cs.mutex.Unlock()
+		require.Empty(t, cs.batches)
+		require.Len(t, cs.singles, 1)
+	})
+
+	t.Run("Fall back to single requests", func(t *testing.T) {
+		cs := &callbackServer{batchSupported: false}
+		server := httptest.NewServer(cs.handler())
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		dispatcher, err := NewDispatcher(ctx, DispatcherConfig{BatchInterval: 500 * time.Millisecond}, logger)
+		require.NoError(t, err)
+
+		for _, err := range sendParallel(dispatcher, []string{
+			server.URL + "/v1/operations/s1/callback/c1",
+			server.URL + "/v1/operations/s1/callback/c2",
+		}, reconciler.StatusSuccess) {
+			require.NoError(t, err)
+		}
+
+		cs.mutex.Lock()
+		defer cs.mutex.Unlock()
+		require.Len(t, cs.singles, 2)
+	})
+
+	t.Run("Callbacks of aborted operations are rejected", func(t *testing.T) {
+		for _, batchSupported := range []bool{true, false} {
+			cs := &callbackServer{batchSupported: batchSupported}
+			server := httptest.NewServer(cs.handler())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			dispatcher, err := NewDispatcher(ctx, DispatcherConfig{BatchInterval: 200 * time.Millisecond}, logger)
+			require.NoError(t, err)
+
+			errs := sendParallel(dispatcher, []string{
+				server.URL + "/v1/operations/s1/callback/c1",
+				server.URL + "/v1/operations/s1/callback/aborted",
+			}, reconciler.StatusRunning)
+			require.NoError(t, errs[0])
+			require.True(t, IsOperationAbortedError(errs[1]))
+			require.Contains(t, errs[1].Error(), "reconciliation was cancelled")
+
+			cancel()
+			server.Close()
+		}
+	})
+
+	t.Run("Callbacks are rejected if queue is full", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		dispatcher, err := NewDispatcher(ctx, DispatcherConfig{BatchInterval: time.Hour, MaxQueueSize: 1}, logger)
+		require.NoError(t, err)
+
+		done := make(chan error, 1)
+		require.NoError(t, dispatcher.enqueue("http://localhost/v1/operations/s1/callback/c1",
+			&reconciler.CallbackMessage{Status: reconciler.StatusRunning}, done))
+		err = dispatcher.Send("http://localhost/v1/operations/s1/callback/c2",
+			&reconciler.CallbackMessage{Status: reconciler.StatusRunning})
+		require.True(t, IsOverflowError(err))
+
+		//pending callbacks fail if the dispatcher stops
+		cancel()
+		require.Error(t, <-done)
+	})
+}
+
+func TestParseCallbackURL(t *testing.T) {
+	batchURL, schedulingID, correlationID := parseCallbackURL("http://mothership:8080/v1/operations/s1/callback/c1")
+	require.Equal(t, "http://mothership:8080/v1/operations/callbacks", batchURL)
+	require.Equal(t, "s1", schedulingID)
+	require.Equal(t, "c1", correlationID)
+
+	batchURL, _, _ = parseCallbackURL("http://mothership:8080/custom/callback")
+	require.Empty(t, batchURL)
+}
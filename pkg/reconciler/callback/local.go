@@ -18,7 +18,7 @@ func NewLocalCallbackHandler(callbackFunc func(msg *reconciler.CallbackMessage)
 }
 
 func (cb *LocalCallbackHandler) Callback(msg *reconciler.CallbackMessage) error {
-	err := cb.callbackFunc(msg)
+	err := cb.callbackFunc(withReconcilerVersion(redactMessage(msg)))
 	if err != nil {
 		cb.logger.Errorf("Calling local callback function failed: %s", err)
 	}
@@ -1,13 +1,12 @@
 package callback
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 
+	"github.com/kyma-incubator/reconciler/pkg/callback/client"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"go.uber.org/zap"
 )
@@ -15,9 +14,16 @@ import (
 type RemoteCallbackHandler struct {
 	logger      *zap.SugaredLogger
 	callbackURL string
+	client      *client.Client
+	dispatcher  *Dispatcher
 }
 
 func NewRemoteCallbackHandler(callbackURL string, logger *zap.SugaredLogger) (Handler, error) {
+	return NewRemoteCallbackHandlerWithClient(callbackURL, client.Default(), logger)
+}
+
+//NewRemoteCallbackHandlerWithClient creates a remote callback handler which uses the given client to call the mothership
+func NewRemoteCallbackHandlerWithClient(callbackURL string, mothershipClient *client.Client, logger *zap.SugaredLogger) (Handler, error) {
 	//validate URL
 	if callbackURL != "" { //empty URLs are allowed (used in some test cases)
 		if _, err := url.ParseRequestURI(callbackURL); err != nil {
@@ -29,38 +35,60 @@ func NewRemoteCallbackHandler(callbackURL string, logger *zap.SugaredLogger) (Ha
 	return &RemoteCallbackHandler{
 		logger:      logger,
 		callbackURL: callbackURL,
+		client:      mothershipClient,
 	}, nil
 }
 
+//NewDispatchingCallbackHandler creates a remote callback handler which sends its callbacks throttled and batched
+//through the dispatcher
+func NewDispatchingCallbackHandler(callbackURL string, dispatcher *Dispatcher, logger *zap.SugaredLogger) (Handler, error) {
+	handler, err := NewRemoteCallbackHandler(callbackURL, logger)
+	if err != nil {
+		return nil, err
+	}
+	handler.(*RemoteCallbackHandler).dispatcher = dispatcher
+	return handler, nil
+}
+
 func (cb *RemoteCallbackHandler) Callback(msg *reconciler.CallbackMessage) error {
 	if cb.callbackURL == "" { //test cases often don't provide a callback URL
 		cb.logger.Warn("Remote callback handler got an empty callback-URL provided: remote callback not executed")
 		return nil
 	}
 
-	requestBody, err := json.Marshal(msg)
-	if err != nil {
-		return err
+	msg = withReconcilerVersion(redactMessage(msg))
+	if cb.dispatcher != nil {
+		return cb.dispatcher.Send(cb.callbackURL, msg)
 	}
+	return sendCallback(cb.client, cb.callbackURL, msg, cb.logger)
+}
 
-	resp, err := http.Post(cb.callbackURL, "application/json", bytes.NewBuffer(requestBody))
+func sendCallback(mothershipClient *client.Client, callbackURL string, msg *reconciler.CallbackMessage, logger *zap.SugaredLogger) error {
+	resp, err := mothershipClient.Post(context.Background(), callbackURL, msg)
+	if client.IsCircuitOpenError(err) {
+		logger.Warnf("Remote callback handler didn't send callback: %s", err)
+		return err
+	}
 	if err != nil {
-		cb.logger.Errorf("Remote callback handler failed to send HTTP request: %s", err)
+		logger.Errorf("Remote callback handler failed to send HTTP request: %s", err)
 		return err
 	}
-	defer resp.Body.Close()
-	//dump request for debugging purposes
-	dumpResp, dumpErr := httputil.DumpResponse(resp, true)
-	if dumpErr == nil {
-		cb.logger.Debugf("Remote callback handler is dumping HTTP response dump: %s", string(dumpResp))
-	} else {
-		cb.logger.Debugf("Remote callback handler failed to generate HTTP response dump: %s", dumpErr)
+	logger.Debugf("Remote callback handler received HTTP response [HTTP response code: %d]: %s",
+		resp.StatusCode, string(resp.Body))
+
+	if resp.StatusCode == http.StatusGone { //the reconciliation of the operation was cancelled
+		var errResp reconciler.HTTPErrorResponse
+		if err := resp.Decode(&errResp); err != nil {
+			logger.Debugf("Remote callback handler failed to decode reason of aborted operation: %s", err)
+		}
+		logger.Infof("Remote callback handler got informed that operation was aborted: %s", errResp.Error)
+		return &OperationAbortedError{Reason: errResp.Error}
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		msg := fmt.Sprintf("Remote callack handler failed to send request [HTTP response code: %d]: %s",
 			resp.StatusCode, msg)
-		cb.logger.Info(msg)
+		logger.Info(msg)
 		return fmt.Errorf(msg)
 	}
 
@@ -51,6 +51,10 @@ type DefaultFactory struct {
 	mutexGet          sync.Mutex
 	mutexGetComponent sync.Mutex
 	kymaRepository    *reconciler.Repository
+	//cache of Kyma workspaces:
+	mutexCache     sync.Mutex
+	cacheSize      int      //max. number of cached Kyma versions (0 = unlimited)
+	cachedVersions []string //least recently used version first
 }
 
 func NewFactory(repo *reconciler.Repository, storageDir string, logger *zap.SugaredLogger) (*DefaultFactory, error) {
@@ -115,6 +119,7 @@ func (f *DefaultFactory) Get(version string) (*KymaWorkspace, error) {
 	wsReadyFile := filepath.Join(wsDir, wsReadyIndicatorFile)
 	if file.Exists(wsReadyFile) {
 		f.logger.Debugf("Workspace '%s' already exists", wsDir)
+		f.cacheWorkspace(version)
 		return newKymaWorkspace(wsDir)
 	}
 
@@ -128,10 +133,48 @@ func (f *DefaultFactory) Get(version string) (*KymaWorkspace, error) {
 	if err := f.clone(version, wsDir, wsDir, f.kymaRepository); err != nil {
 		return nil, err
 	}
+	f.cacheWorkspace(version)
 
 	return newKymaWorkspace(wsDir)
 }
 
+//SetCacheSize limits the number of Kyma versions whose workspaces are kept in the storage directory (0 disables
+//the limit): the workspaces of the least recently used versions are deleted when the next workspace is requested.
+//Only workspaces which were requested since the factory was created are considered.
+func (f *DefaultFactory) SetCacheSize(size int) {
+	f.mutexCache.Lock()
+	defer f.mutexCache.Unlock()
+	f.cacheSize = size
+}
+
+//cacheWorkspace marks the workspace of a Kyma version as most recently used and deletes the workspaces
+//of the least recently used versions which exceed the cache size
+func (f *DefaultFactory) cacheWorkspace(version string) {
+	f.mutexCache.Lock()
+	f.cachedVersions = append(removeVersion(f.cachedVersions, version), version)
+	cacheSize := f.cacheSize
+	var evicted []string
+	if cacheSize > 0 && len(f.cachedVersions) > cacheSize {
+		evicted = append(evicted, f.cachedVersions[:len(f.cachedVersions)-cacheSize]...)
+	}
+	f.mutexCache.Unlock()
+
+	for _, evictedVersion := range evicted {
+		f.logger.Debugf("Evicting workspace of Kyma version '%s' from cache (cache size: %d)", evictedVersion, cacheSize)
+		_ = f.Delete(evictedVersion) //failures are logged by Delete
+	}
+}
+
+func removeVersion(versions []string, version string) []string {
+	result := make([]string, 0, len(versions))
+	for _, v := range versions {
+		if v != version {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 func (f *DefaultFactory) GetExternalComponent(component *Component) (*Workspace, error) {
 	f.mutexGetComponent.Lock()
 	defer f.mutexGetComponent.Unlock()
@@ -322,6 +365,10 @@ func (f *DefaultFactory) Delete(version string) error {
 	if err := f.validate(); err != nil {
 		return err
 	}
+	f.mutexCache.Lock()
+	f.cachedVersions = removeVersion(f.cachedVersions, version)
+	f.mutexCache.Unlock()
+
 	wsDir := f.workspaceDir(version)
 	f.logger.Infof("Deleting workspace '%s'", wsDir)
 	err := os.RemoveAll(wsDir)
@@ -231,6 +231,48 @@ func TestWorkspaceFactory(t *testing.T) {
 
 		defer clearWorkspaces(t, wss)
 	})
+
+	t.Run("Evict least recently used workspaces", func(t *testing.T) {
+		cacheDir := filepath.Join(storageDir, "cache")
+		require.NoError(t, os.RemoveAll(cacheDir))
+		defer func() {
+			require.NoError(t, os.RemoveAll(cacheDir))
+		}()
+
+		wsf, err := NewFactory(nil, cacheDir, logger)
+		require.NoError(t, err)
+
+		//prepare workspaces which don't require a clone
+		versions := []string{"1.0.0", "2.0.0", "3.0.0"}
+		for _, v := range versions {
+			require.NoError(t, os.MkdirAll(filepath.Join(wsf.workspaceDir(v), instResCrdDir), 0700))
+			require.NoError(t, os.MkdirAll(filepath.Join(wsf.workspaceDir(v), resDir), 0700))
+			require.NoError(t, wsf.createReadyMarker(wsf.workspaceDir(v)))
+		}
+
+		//unlimited cache
+		for _, v := range versions {
+			_, err := wsf.Get(v)
+			require.NoError(t, err)
+		}
+		for _, v := range versions {
+			require.True(t, file.DirExists(wsf.workspaceDir(v)))
+		}
+
+		//version 1.0.0 becomes the most recently used version
+		wsf.SetCacheSize(2)
+		_, err = wsf.Get("1.0.0")
+		require.NoError(t, err)
+		require.True(t, file.DirExists(wsf.workspaceDir("1.0.0")))
+		require.False(t, file.DirExists(wsf.workspaceDir("2.0.0")))
+		require.True(t, file.DirExists(wsf.workspaceDir("3.0.0")))
+
+		wsf.SetCacheSize(1)
+		_, err = wsf.Get("3.0.0")
+		require.NoError(t, err)
+		require.False(t, file.DirExists(wsf.workspaceDir("1.0.0")))
+		require.True(t, file.DirExists(wsf.workspaceDir("3.0.0")))
+	})
 }
 
 func doGetExternalComponent(factory Factory, fi os.FileInfo, version, url string) (*Workspace, string, error) {
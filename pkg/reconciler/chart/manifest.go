@@ -3,6 +3,9 @@ package chart
 import (
 	"bytes"
 	"fmt"
+	"sync"
+
+	reconcilerK8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
 )
 
 type ManifestType string
@@ -16,6 +19,20 @@ type Manifest struct {
 	Type     ManifestType
 	Name     string
 	Manifest string
+
+	m      sync.Mutex
+	parsed *reconcilerK8s.Manifest
+}
+
+//Parsed returns the manifest with its parsed resources: the manifest is parsed only once and re-used by
+//all callers as long as the raw text of the manifest isn't modified
+func (m *Manifest) Parsed() *reconcilerK8s.Manifest {
+	m.m.Lock()
+	defer m.m.Unlock()
+	if m.parsed == nil || m.parsed.String() != m.Manifest {
+		m.parsed = reconcilerK8s.NewManifest(m.Manifest)
+	}
+	return m.parsed
 }
 
 func MergeManifests(manifests ...*Manifest) string {
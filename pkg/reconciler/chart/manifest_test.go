@@ -0,0 +1,25 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestParsed(t *testing.T) {
+	manifest := &Manifest{
+		Type:     HelmChart,
+		Name:     "test",
+		Manifest: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: test\n",
+	}
+	parsed := manifest.Parsed()
+	require.Same(t, parsed, manifest.Parsed())
+
+	//modified manifests are parsed again
+	manifest.Manifest = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: modified\n"
+	require.NotSame(t, parsed, manifest.Parsed())
+	unstructs, err := manifest.Parsed().Unstructs()
+	require.NoError(t, err)
+	require.Len(t, unstructs, 1)
+	require.Equal(t, "modified", unstructs[0].GetName())
+}
@@ -21,18 +21,26 @@ func CreateTempFileWith(content string) (resPath string, cf CleanupFunc, err err
 		return "", nil, err
 	}
 
-	cf = func() error {
+	cf = removeFunc(resPath)
+
+	return
+}
+
+func removeFunc(resPath string) CleanupFunc {
+	return func() error {
 		if _, err := os.Stat(resPath); err == nil {
 			return os.Remove(resPath)
 		}
 		return nil
 	}
-
-	return
 }
 
 func createTemporaryFile(content string) (string, error) {
-	tmpFile, err := ioutil.TempFile(os.TempDir(), temporaryFilePattern)
+	return createTemporaryFileIn(os.TempDir(), content)
+}
+
+func createTemporaryFileIn(dir, content string) (string, error) {
+	tmpFile, err := ioutil.TempFile(dir, temporaryFilePattern)
 	if err != nil {
 		return "", errors.Wrap(err, "Failed to generate a temporary file")
 	}
@@ -0,0 +1,170 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//sandboxPrefix is used to identify sandbox directories when searching for leaked sandboxes
+const sandboxPrefix = "reconciler-op-"
+
+var (
+	sandboxNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9-]+`)
+	//sandboxOwner identifies the process which created a sandbox: the start time distinguishes processes which got
+	//the same PID (e.g. PID 1 of a restarted container)
+	sandboxOwner          = fmt.Sprintf("%d.%x", os.Getpid(), time.Now().UnixNano())
+	activeSandboxes       int64
+	failedCleanups        int64
+	removedStaleSandboxes int64
+)
+
+type sandboxContextKey struct{}
+
+//Sandbox is an isolated directory for the temporary files of a single operation (e.g. rendered values or
+//kubeconfigs): concurrent operations can't collide and all files are removed together when the operation ends.
+type Sandbox struct {
+	dir       string
+	cleanOnce sync.Once
+	cleanErr  error
+}
+
+//NewSandbox creates a sandbox for an operation below the base directory (the OS temp-dir is used if the base
+//directory is empty). Callers have to ensure that Cleanup is called, ideally using a `defer` statement which
+//also covers panics and cancelled contexts.
+func NewSandbox(baseDir, operationID string) (*Sandbox, error) {
+	if baseDir == "" {
+		baseDir = os.TempDir()
+	}
+	name := strings.Trim(sandboxNameInvalidChars.ReplaceAllString(operationID, "-"), "-")
+	dir, err := ioutil.TempDir(baseDir, sandboxPrefix+sandboxOwner+"-"+name+"-")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create sandbox for operation '%s'", operationID)
+	}
+	atomic.AddInt64(&activeSandboxes, 1)
+	return &Sandbox{dir: dir}, nil
+}
+
+//Dir returns the directory of the sandbox
+func (s *Sandbox) Dir() string {
+	return s.dir
+}
+
+//CreateTempFileWith returns a path to a generated temporary file within the sandbox. The file is removed
+//by the returned CleanupFunc or at the latest when the sandbox gets cleaned up. Without sandbox (nil), the
+//file is created in the OS temp-dir.
+func (s *Sandbox) CreateTempFileWith(content string) (string, CleanupFunc, error) {
+	if s == nil {
+		return CreateTempFileWith(content)
+	}
+	resPath, err := createTemporaryFileIn(s.dir, content)
+	if err != nil {
+		return "", nil, err
+	}
+	return resPath, removeFunc(resPath), nil
+}
+
+//Cleanup removes the sandbox including all its files. It can be called multiple times.
+//Sandboxes which couldn't be removed are counted as failed cleanups.
+func (s *Sandbox) Cleanup() error {
+	s.cleanOnce.Do(func() {
+		atomic.AddInt64(&activeSandboxes, -1)
+		if err := os.RemoveAll(s.dir); err != nil {
+			atomic.AddInt64(&failedCleanups, 1)
+			s.cleanErr = errors.Wrapf(err, "failed to remove sandbox '%s'", s.dir)
+		}
+	})
+	return s.cleanErr
+}
+
+//ActiveSandboxes returns the number of sandboxes which are not cleaned up yet
+func ActiveSandboxes() int64 {
+	return atomic.LoadInt64(&activeSandboxes)
+}
+
+//FailedCleanups returns the number of sandboxes which couldn't be removed when their operation ended
+func FailedCleanups() int64 {
+	return atomic.LoadInt64(&failedCleanups)
+}
+
+//RemovedStaleSandboxes returns the number of sandboxes of terminated processes which were removed
+func RemovedStaleSandboxes() int64 {
+	return atomic.LoadInt64(&removedStaleSandboxes)
+}
+
+//RemoveLeakedSandboxes deletes the sandboxes below the base directory which aren't owned by a running process
+//(e.g. left behind by a crashed process) and returns the number of removed sandboxes. Sandboxes of other running
+//processes (e.g. sharing the temp-dir on the same host) and sandboxes of unknown owners are only removed if they
+//are older than the max age.
+func RemoveLeakedSandboxes(baseDir string, maxAge time.Duration) (int, error) {
+	if baseDir == "" {
+		baseDir = os.TempDir()
+	}
+	dirs, err := filepath.Glob(filepath.Join(baseDir, sandboxPrefix+"*"))
+	if err != nil {
+		return 0, err
+	}
+	var removed int
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() || !isStale(filepath.Base(dir), time.Since(info.ModTime()) >= maxAge) {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return removed, errors.Wrapf(err, "failed to remove leaked sandbox '%s'", dir)
+		}
+		atomic.AddInt64(&removedStaleSandboxes, 1)
+		removed++
+	}
+	return removed, nil
+}
+
+//isStale returns true if the sandbox isn't used by a running process anymore
+func isStale(sandboxName string, expired bool) bool {
+	owner := strings.SplitN(strings.TrimPrefix(sandboxName, sandboxPrefix), "-", 2)[0]
+	if owner == sandboxOwner {
+		return false
+	}
+	ownerPID := strings.SplitN(owner, ".", 2)
+	if len(ownerPID) != 2 {
+		return expired //sandbox of an older version without owner
+	}
+	pid, err := strconv.Atoi(ownerPID[0])
+	if err != nil {
+		return expired
+	}
+	if pid == os.Getpid() {
+		return true //a previous process with the same PID (e.g. in a restarted container)
+	}
+	return expired || !isRunning(pid)
+}
+
+func isRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+//WithSandbox returns a context which carries the sandbox of the operation
+func WithSandbox(ctx context.Context, sandbox *Sandbox) context.Context {
+	return context.WithValue(ctx, sandboxContextKey{}, sandbox)
+}
+
+//SandboxFromContext returns the sandbox of the operation or nil if the context doesn't carry a sandbox
+func SandboxFromContext(ctx context.Context) *Sandbox {
+	sandbox, _ := ctx.Value(sandboxContextKey{}).(*Sandbox)
+	return sandbox
+}
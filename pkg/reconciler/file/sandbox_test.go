@@ -0,0 +1,112 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandbox(t *testing.T) {
+	t.Run("Sandboxes of concurrent operations are isolated", func(t *testing.T) {
+		baseDir := t.TempDir()
+		activeBefore := ActiveSandboxes()
+
+		sandbox1, err := NewSandbox(baseDir, "runtime--1234")
+		require.NoError(t, err)
+		sandbox2, err := NewSandbox(baseDir, "runtime--1234")
+		require.NoError(t, err)
+		require.NotEqual(t, sandbox1.Dir(), sandbox2.Dir())
+		require.Equal(t, activeBefore+2, ActiveSandboxes())
+
+		path, cleanup, err := sandbox1.CreateTempFileWith("content")
+		require.NoError(t, err)
+		require.Equal(t, sandbox1.Dir(), filepath.Dir(path))
+		content, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, "content", string(content))
+		require.NoError(t, cleanup())
+		require.NoFileExists(t, path)
+
+		//files which were not cleaned up are removed together with the sandbox
+		path, _, err = sandbox2.CreateTempFileWith("content")
+		require.NoError(t, err)
+		require.NoError(t, sandbox2.Cleanup())
+		require.NoError(t, sandbox2.Cleanup()) //cleanup is idempotent
+		require.NoFileExists(t, path)
+		require.NoDirExists(t, sandbox2.Dir())
+
+		require.NoError(t, sandbox1.Cleanup())
+		require.Equal(t, activeBefore, ActiveSandboxes())
+	})
+
+	t.Run("Sandbox is cleaned up on panic", func(t *testing.T) {
+		sandbox, err := NewSandbox(t.TempDir(), "panic")
+		require.NoError(t, err)
+		require.Panics(t, func() {
+			defer func() {
+				require.NoError(t, sandbox.Cleanup())
+			}()
+			panic("operation failed")
+		})
+		require.NoDirExists(t, sandbox.Dir())
+	})
+
+	t.Run("Sandbox is carried by context", func(t *testing.T) {
+		require.Nil(t, SandboxFromContext(context.Background()))
+		sandbox := &Sandbox{dir: "test"}
+		require.Equal(t, sandbox, SandboxFromContext(WithSandbox(context.Background(), sandbox)))
+	})
+
+	t.Run("Remove leaked sandboxes", func(t *testing.T) {
+		baseDir := t.TempDir()
+		staleBefore := RemovedStaleSandboxes()
+		oldTime := time.Now().Add(-2 * time.Hour)
+		newSandboxDir := func(name string, old bool) string {
+			dir := filepath.Join(baseDir, sandboxPrefix+name)
+			require.NoError(t, os.Mkdir(dir, 0700))
+			if old {
+				require.NoError(t, os.Chtimes(dir, oldTime, oldTime))
+			}
+			return dir
+		}
+
+		//sandboxes of this process are never removed
+		active, err := NewSandbox(baseDir, "active")
+		require.NoError(t, err)
+		require.NoError(t, os.Chtimes(active.Dir(), oldTime, oldTime))
+		defer func() {
+			require.NoError(t, active.Cleanup())
+		}()
+		//sandboxes of terminated processes are removed independent of their age
+		restarted := newSandboxDir(fmt.Sprintf("%d.1-restarted-1", os.Getpid()), false)
+		terminated := newSandboxDir(fmt.Sprintf("%d.1-terminated-1", math.MaxInt32), false)
+		//sandboxes of unknown owners are removed when they expired
+		expired := newSandboxDir("unknown-1", true)
+		unknown := newSandboxDir("unknown-2", false)
+
+		removed, err := RemoveLeakedSandboxes(baseDir, time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 3, removed)
+		require.DirExists(t, active.Dir())
+		require.NoDirExists(t, restarted)
+		require.NoDirExists(t, terminated)
+		require.NoDirExists(t, expired)
+		require.DirExists(t, unknown)
+		require.Equal(t, staleBefore+3, RemovedStaleSandboxes())
+	})
+
+	t.Run("Temporary files without sandbox", func(t *testing.T) {
+		var sandbox *Sandbox
+		path, cleanup, err := sandbox.CreateTempFileWith("content")
+		require.NoError(t, err)
+		require.Equal(t, filepath.Clean(os.TempDir()), filepath.Dir(path))
+		require.NoError(t, cleanup())
+	})
+}
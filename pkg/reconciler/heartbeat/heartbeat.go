@@ -9,17 +9,28 @@ import (
 	e "github.com/kyma-incubator/reconciler/pkg/error"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	cb "github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
 const (
 	defaultHeartbeatSenderInterval = 30 * time.Second
 	defaultHeartbeatSenderTimeout  = 1 * time.Hour
+
+	//stableHeartbeats is the number of unchanged running heartbeats after which the interval starts to grow
+	stableHeartbeats = 4
+	//deadlineHeartbeats is the min. number of heartbeats which are sent within the remaining time of the operation
+	deadlineHeartbeats = 4
 )
 
 type Config struct {
 	Interval time.Duration
 	Timeout  time.Duration
+	//MinInterval is the densest interval: the interval shrinks towards it if the deadline of the operation comes
+	//closer. Intervals are adaptive if MinInterval or MaxInterval is set.
+	MinInterval time.Duration
+	//MaxInterval is the sparsest interval: the interval grows towards it during long stable waits
+	MaxInterval time.Duration
 }
 
 func (su *Config) validate() error {
@@ -40,9 +51,27 @@ func (su *Config) validate() error {
 		return fmt.Errorf("timeout cannot be <= interval (%.1f secs <= %.1f secs)",
 			su.Timeout.Seconds(), su.Interval.Seconds())
 	}
+	if su.MinInterval < 0 || su.MaxInterval < 0 {
+		return fmt.Errorf("min. and max. interval cannot be < 0 but were %.1f secs and %.1f secs",
+			su.MinInterval.Seconds(), su.MaxInterval.Seconds())
+	}
+	if !su.adaptive() {
+		return nil
+	}
+	//the interval can be tuned at runtime: the bounds always include it
+	if su.MinInterval == 0 || su.MinInterval > su.Interval {
+		su.MinInterval = su.Interval
+	}
+	if su.MaxInterval < su.Interval {
+		su.MaxInterval = su.Interval
+	}
 	return nil
 }
 
+func (su *Config) adaptive() bool {
+	return su.MinInterval > 0 || su.MaxInterval > 0
+}
+
 type Sender struct {
 	ctx             context.Context
 	ctxClosed       bool //indicate whether the process was interrupted by parent context
@@ -52,6 +81,13 @@ type Sender struct {
 	restartInterval chan bool         //trigger for callback-handler to inform reconciler-controller
 	m               sync.Mutex
 	logger          *zap.SugaredLogger
+	usage           func() *reconciler.OperationUsage //provides the consumed resources which are reported with each status update
+	images          *[]reconciler.ContainerImage      //running container images which are reported with the success status
+	trace           func() *reconciler.OperationTrace //provides the detailed capture which is reported with the final status
+	logs            func() *reconciler.OperationLogs  //provides the latest log entries which are reported with the final status
+	warning         *string                           //early signal of a running attempt (e.g. upcoming timeout)
+	pendingDeletion *[]string                         //stateful resources which are reported with the pending confirmation status
+	onAbort         func()                            //called if the mothership aborted the operation
 }
 
 func NewHeartbeatSender(ctx context.Context, callback cb.Handler, logger *zap.SugaredLogger, config Config) (*Sender, error) {
@@ -95,7 +131,19 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 			}(rootCause),
 			RetryID:            retryID,
 			ProcessingDuration: int(processingDuration.Milliseconds()),
+			Usage:              su.currentUsage(),
+			Images:             su.currentImages(status),
+			Trace:              su.currentTrace(status),
+			Logs:               su.currentLogs(status),
+			Warning:            su.currentWarning(status),
+			PendingDeletion:    su.currentPendingDeletion(status),
+			TimedOut:           timedOut(status, rootCause),
 		})
+		if cb.IsOperationAbortedError(err) { //no further status updates are accepted by the mothership
+			su.logger.Infof("Heartbeat stops communicating status '%s': %s", status, err)
+			su.abort()
+			return nil
+		}
 		if err == nil {
 			su.logger.Debugf("Heartbeat communicated status '%s' successfully to mothership-reconciler", status)
 		} else {
@@ -111,12 +159,16 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 			return
 		}
 
+		since := time.Now()
 		for {
+			next := time.NewTimer(su.nextInterval(status, onlyOnce, since, time.Now()))
 			select {
 			case <-su.restartInterval:
+				next.Stop()
 				su.logger.Debugf("Heartbeat stops sending status '%s'", status)
 				return
 			case <-su.ctx.Done():
+				next.Stop()
 				su.closeContext()
 
 				//send error resonse
@@ -151,7 +203,7 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 						return
 					}
 				}
-			case <-time.NewTicker(interval).C:
+			case <-next.C:
 				err := task(status, rootCause)
 				if err != nil {
 					su.logger.Warnf("Heartbeat failed to communicate status '%s' "+
@@ -168,12 +220,172 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 	su.status = status
 }
 
+//nextInterval returns the interval until the next heartbeat of the status which is sent since the given time.
+//Adaptive intervals depend on the phase of the operation: waiting and long stable running phases get sparser
+//heartbeats (the interval doubles up to the max. interval), heartbeats get denser if the deadline of the operation
+//comes closer (down to the min. interval). Retries of final statuses always use the configured interval.
+func (su *Sender) nextInterval(status reconciler.Status, onlyOnce bool, since, now time.Time) time.Duration {
+	interval := su.config.Interval
+	if !su.config.adaptive() || onlyOnce {
+		return interval
+	}
+
+	stableAfter := stableHeartbeats * su.config.Interval
+	if status == reconciler.StatusWaiting { //waits are stable from the start
+		stableAfter = su.config.Interval
+	}
+	for stable := now.Sub(since); stable >= stableAfter && interval < su.config.MaxInterval; stable -= stableAfter {
+		interval *= 2
+	}
+	if interval > su.config.MaxInterval {
+		interval = su.config.MaxInterval
+	}
+
+	if deadline, ok := su.ctx.Deadline(); ok {
+		if remaining := deadline.Sub(now) / deadlineHeartbeats; remaining < interval {
+			interval = remaining
+		}
+	}
+	if interval < su.config.MinInterval {
+		interval = su.config.MinInterval
+	}
+	return interval
+}
+
+//ReportUsage adds the resources consumed by the operation to the status updates
+func (su *Sender) ReportUsage(usage func() *reconciler.OperationUsage) {
+	su.m.Lock()
+	defer su.m.Unlock()
+	su.usage = usage
+}
+
+func (su *Sender) currentUsage() *reconciler.OperationUsage {
+	su.m.Lock()
+	defer su.m.Unlock()
+	if su.usage == nil {
+		return nil
+	}
+	return su.usage()
+}
+
+//ReportImages adds the container images running for the workloads of the component to the success status
+func (su *Sender) ReportImages(images []reconciler.ContainerImage) {
+	su.m.Lock()
+	defer su.m.Unlock()
+	su.images = &images
+}
+
+func (su *Sender) currentImages(status reconciler.Status) *[]reconciler.ContainerImage {
+	if status != reconciler.StatusSuccess {
+		return nil
+	}
+	su.m.Lock()
+	defer su.m.Unlock()
+	return su.images
+}
+
+//ReportTrace adds the detailed capture of the operation to the final status
+func (su *Sender) ReportTrace(trace func() *reconciler.OperationTrace) {
+	su.m.Lock()
+	defer su.m.Unlock()
+	su.trace = trace
+}
+
+func (su *Sender) currentTrace(status reconciler.Status) *reconciler.OperationTrace {
+	if status != reconciler.StatusSuccess && status != reconciler.StatusError {
+		return nil
+	}
+	su.m.Lock()
+	defer su.m.Unlock()
+	if su.trace == nil {
+		return nil
+	}
+	return su.trace()
+}
+
+//ReportLogs adds the latest log entries of the operation to the final status
+func (su *Sender) ReportLogs(logs func() *reconciler.OperationLogs) {
+	su.m.Lock()
+	defer su.m.Unlock()
+	su.logs = logs
+}
+
+func (su *Sender) currentLogs(status reconciler.Status) *reconciler.OperationLogs {
+	if status != reconciler.StatusSuccess && status != reconciler.StatusError {
+		return nil
+	}
+	su.m.Lock()
+	defer su.m.Unlock()
+	if su.logs == nil {
+		return nil
+	}
+	return su.logs()
+}
+
+//ReportWarning adds a warning to the heartbeats of the running attempt (a new attempt resets the warning)
+func (su *Sender) ReportWarning(warning string) {
+	su.m.Lock()
+	defer su.m.Unlock()
+	su.warning = &warning
+}
+
+func (su *Sender) currentWarning(status reconciler.Status) *string {
+	if status != reconciler.StatusRunning {
+		return nil
+	}
+	su.m.Lock()
+	defer su.m.Unlock()
+	return su.warning
+}
+
+//timedOut flags failures which were caused by a timeout (the mothership can tolerate them, see aggregation policy)
+func timedOut(status reconciler.Status, rootCause error) *bool {
+	if (status != reconciler.StatusFailed && status != reconciler.StatusError) || !e.IsTimeoutError(rootCause) {
+		return nil
+	}
+	timedOut := true
+	return &timedOut
+}
+
+func (su *Sender) currentPendingDeletion(status reconciler.Status) *[]string {
+	if status != reconciler.StatusPendingConfirmation {
+		return nil
+	}
+	su.m.Lock()
+	defer su.m.Unlock()
+	return su.pendingDeletion
+}
+
+func (su *Sender) resetWarning() {
+	su.m.Lock()
+	defer su.m.Unlock()
+	su.warning = nil
+}
+
+//OnAbort registers a function which is called once if the mothership aborted the operation
+//(e.g. because its reconciliation was cancelled)
+func (su *Sender) OnAbort(fn func()) {
+	su.m.Lock()
+	defer su.m.Unlock()
+	su.onAbort = fn
+}
+
+func (su *Sender) abort() {
+	su.m.Lock()
+	onAbort := su.onAbort
+	su.onAbort = nil
+	su.m.Unlock()
+	if onAbort != nil {
+		onAbort()
+	}
+}
+
 func (su *Sender) CurrentStatus() reconciler.Status {
 	return su.status
 }
 
 func (su *Sender) stopJob() {
-	if su.status == reconciler.StatusRunning || su.status == reconciler.StatusFailed {
+	if su.status == reconciler.StatusRunning || su.status == reconciler.StatusFailed || su.status == reconciler.StatusWaiting {
 		su.restartInterval <- true
 	}
 }
@@ -182,6 +394,7 @@ func (su *Sender) Running(retryID string) error {
 	if err := su.statusChangeAllowed(reconciler.StatusRunning); err != nil {
 		return err
 	}
+	su.resetWarning()
 	su.sendUpdate(reconciler.StatusRunning, nil, false, retryID, 0) //Running is an interim status: use interval to send heartbeat-request to reconciler-controller
 	return nil
 }
@@ -210,13 +423,44 @@ func (su *Sender) Error(err error, retryID string, processingDuration time.Durat
 	return nil
 }
 
+func (su *Sender) Skipped(reason string, retryID string) error {
+	if err := su.statusChangeAllowed(reconciler.StatusSkipped); err != nil {
+		return err
+	}
+	su.sendUpdate(reconciler.StatusSkipped, errors.New(reason), true, retryID, 0) //Skipped is a final status: the reason is reported as error message
+	return nil
+}
+
+func (su *Sender) Waiting(reason string, retryID string) error {
+	if err := su.statusChangeAllowed(reconciler.StatusWaiting); err != nil {
+		return err
+	}
+	su.sendUpdate(reconciler.StatusWaiting, errors.New(reason), false, retryID, 0) //Waiting is an interim status: the reason is reported as error message
+	return nil
+}
+
+//PendingConfirmation reports the stateful resources a deletion would remove: the operation stops and is dispatched
+//again after an operator confirmed the deletion
+func (su *Sender) PendingConfirmation(resources []string, retryID string) error {
+	if err := su.statusChangeAllowed(reconciler.StatusPendingConfirmation); err != nil {
+		return err
+	}
+	su.m.Lock()
+	su.pendingDeletion = &resources
+	su.m.Unlock()
+	reason := fmt.Errorf("deletion of %d stateful resources has to be confirmed", len(resources))
+	su.sendUpdate(reconciler.StatusPendingConfirmation, reason, true, retryID, 0) //PendingConfirmation is a final status of this dispatch
+	return nil
+}
+
 func (su *Sender) statusChangeAllowed(status reconciler.Status) error {
 	if su.isContextClosed() {
 		return &e.ContextClosedError{
 			Message: fmt.Sprintf("Cannot change status to '%s' because context of heartbeat sender is closed", status),
 		}
 	}
-	if su.status == reconciler.StatusError || su.status == reconciler.StatusSuccess {
+	if su.status == reconciler.StatusError || su.status == reconciler.StatusSuccess || su.status == reconciler.StatusSkipped ||
+		su.status == reconciler.StatusPendingConfirmation {
 		return fmt.Errorf("cannot switch in '%s' status because we are already in final status '%s'", status, su.status)
 	}
 	return nil
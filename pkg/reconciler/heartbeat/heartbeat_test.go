@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 
 	log "github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	cb "github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
 	"github.com/stretchr/testify/require"
 )
 
@@ -170,4 +172,180 @@ func TestHeartbeatSender(t *testing.T) { //DO NOT RUN THIS TEST CASES IN PARALLE
 		require.Equal(t, retryID, callbackHdlr.RetryID())
 	})
 
+	t.Run("Test heartbeat sender with skipped status", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		callbackHdlr := newTestCallbackHandler(t)
+		retryID := "retryID"
+		heartbeatSender, err := NewHeartbeatSender(ctx, callbackHdlr, logger, Config{
+			Interval: 500 * time.Millisecond,
+			Timeout:  10 * time.Second,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, heartbeatSender.Skipped("component is marked to be skipped", retryID))
+		require.Equal(t, heartbeatSender.CurrentStatus(), reconciler.StatusSkipped)
+		time.Sleep(500 * time.Millisecond)
+
+		//skipped is a final status
+		require.Error(t, heartbeatSender.Running(retryID))
+		require.Equal(t, []reconciler.Status{reconciler.StatusSkipped}, callbackHdlr.Statuses())
+		require.Equal(t, retryID, callbackHdlr.RetryID())
+	})
+
+	t.Run("Test heartbeat sender with pending confirmation status", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		callbackHdlr := newTestCallbackHandler(t)
+		retryID := "retryID"
+		heartbeatSender, err := NewHeartbeatSender(ctx, callbackHdlr, logger, Config{
+			Interval: 500 * time.Millisecond,
+			Timeout:  10 * time.Second,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, heartbeatSender.PendingConfirmation([]string{"PersistentVolumeClaim/kyma-system/data"}, retryID))
+		require.Equal(t, heartbeatSender.CurrentStatus(), reconciler.StatusPendingConfirmation)
+		time.Sleep(500 * time.Millisecond)
+
+		//the operation stops until the deletion gets confirmed
+		require.Error(t, heartbeatSender.Running(retryID))
+		require.Equal(t, []reconciler.Status{reconciler.StatusPendingConfirmation}, callbackHdlr.Statuses())
+	})
+
+	t.Run("Test heartbeat sender with waiting status", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		callbackHdlr := newTestCallbackHandler(t)
+		heartbeatSender, err := NewHeartbeatSender(ctx, callbackHdlr, logger, Config{
+			Interval: 500 * time.Millisecond,
+			Timeout:  10 * time.Second,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, heartbeatSender.Waiting("lease is held by kyma-cli", "waitingID"))
+		require.Equal(t, heartbeatSender.CurrentStatus(), reconciler.StatusWaiting)
+		time.Sleep(1200 * time.Millisecond) //waiting is an interim status: heartbeat is repeated
+
+		//waiting is not a final status
+		require.NoError(t, heartbeatSender.Running("retryID"))
+		require.Equal(t, heartbeatSender.CurrentStatus(), reconciler.StatusRunning)
+		time.Sleep(250 * time.Millisecond)
+
+		statuses := callbackHdlr.Statuses()
+		require.GreaterOrEqual(t, len(statuses), 3)
+		require.Equal(t, reconciler.StatusWaiting, statuses[0])
+		require.Equal(t, reconciler.StatusWaiting, statuses[1])
+		require.Equal(t, reconciler.StatusRunning, statuses[len(statuses)-1])
+	})
+
+	t.Run("Test heartbeat sender with aborted operation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var callbacks int32
+		callbackHdlr, err := cb.NewLocalCallbackHandler(func(msg *reconciler.CallbackMessage) error {
+			atomic.AddInt32(&callbacks, 1)
+			return &cb.OperationAbortedError{Reason: "reconciliation was cancelled"}
+		}, logger)
+		require.NoError(t, err)
+		heartbeatSender, err := NewHeartbeatSender(ctx, callbackHdlr, logger, Config{
+			Interval: 500 * time.Millisecond,
+			Timeout:  10 * time.Second,
+		})
+		require.NoError(t, err)
+		heartbeatSender.OnAbort(cancel)
+
+		require.NoError(t, heartbeatSender.Running("retryID"))
+		time.Sleep(1200 * time.Millisecond)
+
+		//the operation context is cancelled and no further status updates are sent
+		require.Error(t, ctx.Err())
+		require.Error(t, heartbeatSender.Failed(errors.New("cancelled"), "retryID"))
+		require.Equal(t, int32(2), atomic.LoadInt32(&callbacks)) //running status and failed status after the context got closed
+	})
+
+}
+
+func TestHeartbeatSenderInterval(t *testing.T) {
+	logger := log.NewLogger(true)
+	now := time.Now()
+
+	newSender := func(t *testing.T, ctx context.Context, config Config) *Sender {
+		heartbeatSender, err := NewHeartbeatSender(ctx, newTestCallbackHandler(t), logger, config)
+		require.NoError(t, err)
+		return heartbeatSender
+	}
+
+	t.Run("Invalid bounds", func(t *testing.T) {
+		_, err := NewHeartbeatSender(context.Background(), newTestCallbackHandler(t), logger, Config{
+			Interval:    30 * time.Second,
+			MinInterval: -1 * time.Second,
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("Fixed interval", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(10*time.Second))
+		defer cancel()
+
+		heartbeatSender := newSender(t, ctx, Config{Interval: 30 * time.Second})
+		require.Equal(t, 30*time.Second, heartbeatSender.nextInterval(reconciler.StatusWaiting, false, now.Add(-time.Hour), now))
+		require.Equal(t, 30*time.Second, heartbeatSender.nextInterval(reconciler.StatusRunning, false, now, now))
+	})
+
+	t.Run("Adaptive interval", func(t *testing.T) {
+		heartbeatSender := newSender(t, context.Background(), Config{
+			Interval:    30 * time.Second,
+			Timeout:     time.Hour,
+			MinInterval: 5 * time.Second,
+			MaxInterval: 5 * time.Minute,
+		})
+
+		//running operations get sparser heartbeats after a few unchanged heartbeats
+		require.Equal(t, 30*time.Second, heartbeatSender.nextInterval(reconciler.StatusRunning, false, now, now))
+		require.Equal(t, 30*time.Second, heartbeatSender.nextInterval(reconciler.StatusRunning, false, now.Add(-time.Minute), now))
+		require.Equal(t, time.Minute, heartbeatSender.nextInterval(reconciler.StatusRunning, false, now.Add(-2*time.Minute), now))
+		require.Equal(t, 2*time.Minute, heartbeatSender.nextInterval(reconciler.StatusRunning, false, now.Add(-4*time.Minute), now))
+		require.Equal(t, 5*time.Minute, heartbeatSender.nextInterval(reconciler.StatusRunning, false, now.Add(-time.Hour), now))
+
+		//waiting operations get sparser heartbeats from the start
+		require.Equal(t, 30*time.Second, heartbeatSender.nextInterval(reconciler.StatusWaiting, false, now, now))
+		require.Equal(t, time.Minute, heartbeatSender.nextInterval(reconciler.StatusWaiting, false, now.Add(-30*time.Second), now))
+		require.Equal(t, 5*time.Minute, heartbeatSender.nextInterval(reconciler.StatusWaiting, false, now.Add(-10*time.Minute), now))
+
+		//retries of final statuses use the configured interval
+		require.Equal(t, 30*time.Second, heartbeatSender.nextInterval(reconciler.StatusSuccess, true, now.Add(-time.Hour), now))
+	})
+
+	t.Run("Adaptive interval close to deadline", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(time.Minute))
+		defer cancel()
+
+		heartbeatSender := newSender(t, ctx, Config{
+			Interval:    30 * time.Second,
+			Timeout:     time.Hour,
+			MinInterval: 5 * time.Second,
+			MaxInterval: 5 * time.Minute,
+		})
+
+		//heartbeats get denser if the deadline comes closer
+		require.Equal(t, 15*time.Second, heartbeatSender.nextInterval(reconciler.StatusWaiting, false, now.Add(-time.Hour), now))
+		require.Equal(t, 10*time.Second, heartbeatSender.nextInterval(reconciler.StatusRunning, false, now, now.Add(20*time.Second)))
+		require.Equal(t, 5*time.Second, heartbeatSender.nextInterval(reconciler.StatusRunning, false, now, now.Add(50*time.Second)))
+	})
+
+	t.Run("Bounds include tuned interval", func(t *testing.T) {
+		heartbeatSender := newSender(t, context.Background(), Config{
+			Interval:    10 * time.Minute,
+			Timeout:     time.Hour,
+			MinInterval: 5 * time.Second,
+			MaxInterval: 5 * time.Minute,
+		})
+		require.Equal(t, 10*time.Minute, heartbeatSender.config.MaxInterval)
+		require.Equal(t, 10*time.Minute, heartbeatSender.nextInterval(reconciler.StatusWaiting, false, now.Add(-time.Hour), now))
+	})
 }
@@ -14,3 +14,13 @@ type HTTPOccupancyRequest struct {
 	RunningWorkers int    `json:"runningWorkers"`
 	PoolSize       int    `json:"poolSize"`
 }
+
+//HTTPWarmupRequest announces upcoming Kyma versions whose charts the component reconciler should pre-fetch
+type HTTPWarmupRequest struct {
+	Versions []string `json:"versions"`
+}
+
+//HTTPWarmupResponse contains the Kyma versions which were scheduled for the warm-up
+type HTTPWarmupResponse struct {
+	Versions []string `json:"versions"`
+}
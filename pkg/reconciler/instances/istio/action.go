@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/file"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/actions"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/manifest"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
@@ -19,7 +20,7 @@ const (
 	istioNamespace = "istio-system"
 )
 
-type bootstrapIstioPerformer func(logger *zap.SugaredLogger) (actions.IstioPerformer, error)
+type bootstrapIstioPerformer func(sandbox *file.Sandbox, logger *zap.SugaredLogger) (actions.IstioPerformer, error)
 
 type StatusPreAction struct {
 	getIstioPerformer bootstrapIstioPerformer
@@ -32,7 +33,7 @@ func NewStatusPreAction(getIstioPerformer bootstrapIstioPerformer) *StatusPreAct
 func (a *StatusPreAction) Run(context *service.ActionContext) error {
 	context.Logger.Debug("Pre reconcile action of istio triggered")
 
-	performer, err := a.getIstioPerformer(context.Logger)
+	performer, err := a.getIstioPerformer(context.Sandbox, context.Logger)
 	if err != nil {
 		return err
 	}
@@ -66,7 +67,7 @@ func NewIstioMainReconcileAction(getIstioPerformer bootstrapIstioPerformer) *Mai
 func (a *MainReconcileAction) Run(context *service.ActionContext) error {
 	context.Logger.Debug("Reconcile action of istio triggered")
 
-	performer, err := a.getIstioPerformer(context.Logger)
+	performer, err := a.getIstioPerformer(context.Sandbox, context.Logger)
 	if err != nil {
 		return err
 	}
@@ -140,7 +141,7 @@ func NewProxyResetPostAction(getIstioPerformer bootstrapIstioPerformer) *ProxyRe
 func (a *ProxyResetPostAction) Run(context *service.ActionContext) error {
 	context.Logger.Debug("Proxy reset post action of istio triggered")
 
-	performer, err := a.getIstioPerformer(context.Logger)
+	performer, err := a.getIstioPerformer(context.Sandbox, context.Logger)
 	if err != nil {
 		return err
 	}
@@ -178,7 +179,7 @@ func NewUninstallAction(getIstioPerformer bootstrapIstioPerformer) *UninstallAct
 func (a *UninstallAction) Run(context *service.ActionContext) error {
 	context.Logger.Debug("Uninstall action of istio triggered")
 
-	performer, err := a.getIstioPerformer(context.Logger)
+	performer, err := a.getIstioPerformer(context.Sandbox, context.Logger)
 	if err != nil {
 		return err
 	}
@@ -224,7 +225,7 @@ func NewReconcileIstioConfigurationAction(getIstioPerformer bootstrapIstioPerfor
 func (a *ReconcileIstioConfigurationAction) Run(context *service.ActionContext) error {
 	context.Logger.Debug("Reconcile action of istio-configuration triggered")
 
-	performer, err := a.getIstioPerformer(context.Logger)
+	performer, err := a.getIstioPerformer(context.Sandbox, context.Logger)
 	if err != nil {
 		return err
 	}
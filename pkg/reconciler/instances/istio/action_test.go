@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/file"
 	actionsmocks "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/actions/mocks"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/manifest"
 	"github.com/pkg/errors"
@@ -183,7 +184,7 @@ func Test_helperVersion_compare(t *testing.T) {
 func TestStatusPreAction_Run(t *testing.T) {
 
 	performerCreatorFn := func(p actions.IstioPerformer) bootstrapIstioPerformer {
-		return func(logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
+		return func(sandbox *file.Sandbox, logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
 			return p, nil
 		}
 	}
@@ -223,13 +224,13 @@ func TestStatusPreAction_Run(t *testing.T) {
 func Test_ReconcileAction_Run(t *testing.T) {
 
 	performerCreatorFn := func(p actions.IstioPerformer) bootstrapIstioPerformer {
-		return func(logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
+		return func(sandbox *file.Sandbox, logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
 			return p, nil
 		}
 	}
 
 	performerCreatorErrorFn := func(p actions.IstioPerformer) bootstrapIstioPerformer {
-		return func(logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
+		return func(sandbox *file.Sandbox, logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
 			return p, errors.New("Performer error")
 		}
 	}
@@ -510,7 +511,7 @@ func Test_ReconcileAction_Run(t *testing.T) {
 func Test_ReconcileIstioConfigurationAction_Run(t *testing.T) {
 
 	performerCreatorFn := func(p actions.IstioPerformer) bootstrapIstioPerformer {
-		return func(logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
+		return func(sandbox *file.Sandbox, logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
 			return p, nil
 		}
 	}
@@ -891,7 +892,7 @@ func newFakeKubeClient() *k8smocks.Client {
 
 func Test_UninstallAction(t *testing.T) {
 	performerCreatorFn := func(p actions.IstioPerformer) bootstrapIstioPerformer {
-		return func(logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
+		return func(sandbox *file.Sandbox, logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
 			return p, nil
 		}
 	}
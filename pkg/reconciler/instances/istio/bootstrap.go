@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/file"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/actions"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/clientset"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/istioctl"
@@ -21,9 +22,10 @@ const (
 
 // IstioPerformer instance should be created only once in the Istio Reconciler life.
 // Due to current Reconciler limitations - lack of well defined reconciler instances lifetime - we have to initialize it once per reconcile/delete action.
-func istioPerformerCreator(istioProxyReset proxy.IstioProxyReset, provider clientset.Provider, name string) bootstrapIstioPerformer {
+// Temporary files of the performer are created in the sandbox of the operation.
+func istioPerformerCreator(istioProxyReset proxy.IstioProxyReset, name string) bootstrapIstioPerformer {
 
-	res := func(logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
+	res := func(sandbox *file.Sandbox, logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
 		pathsConfig := os.Getenv(istioctlBinaryPathEnvKey)
 		if len(pathsConfig) > istioctlBinaryPathMaxLen {
 			return nil, fmt.Errorf("%s env variable exceeds the maximum istio path limit of %d characters", istioctlBinaryPathEnvKey, istioctlSingleBinaryPathMaxLen)
@@ -39,13 +41,13 @@ func istioPerformerCreator(istioProxyReset proxy.IstioProxyReset, provider clien
 			return nil, err
 		}
 
-		resolver, err := newDefaultCommanderResolver(istioctlPaths, logger)
+		resolver, err := newDefaultCommanderResolver(istioctlPaths, sandbox, logger)
 		if err != nil {
 			logger.Errorf("Could not create '%s' component reconciler: Error creating DefaultCommanderResolver with istioctlPaths '%s': %s", name, istioctlPaths, err.Error())
 			return nil, err
 		}
 
-		return actions.NewDefaultIstioPerformer(resolver, istioProxyReset, provider), nil
+		return actions.NewDefaultIstioPerformer(resolver, istioProxyReset, &clientset.DefaultProvider{Sandbox: sandbox}), nil
 	}
 	return res
 }
@@ -55,6 +57,7 @@ func istioPerformerCreator(istioProxyReset proxy.IstioProxyReset, provider clien
 type defaultCommanderResolver struct {
 	log                 *zap.SugaredLogger
 	paths               []string
+	sandbox             *file.Sandbox
 	istioBinaryResolver istioctl.ExecutableResolver
 }
 
@@ -66,11 +69,11 @@ func (dcr *defaultCommanderResolver) GetCommander(version istioctl.Version) (ist
 
 	dcr.log.Debugf("Resolved istioctl binary: Requested istio version: %s, Found: %s", version.String(), istioBinary.Version().String())
 
-	res := istioctl.NewDefaultCommander(*istioBinary)
+	res := istioctl.NewDefaultCommander(*istioBinary, dcr.sandbox)
 	return &res, nil
 }
 
-func newDefaultCommanderResolver(paths []string, log *zap.SugaredLogger) (actions.CommanderResolver, error) {
+func newDefaultCommanderResolver(paths []string, sandbox *file.Sandbox, log *zap.SugaredLogger) (actions.CommanderResolver, error) {
 
 	istioBinaryResolver, err := istioctl.NewDefaultIstioctlResolver(paths, istioctl.DefaultVersionChecker{})
 	if err != nil {
@@ -80,6 +83,7 @@ func newDefaultCommanderResolver(paths []string, log *zap.SugaredLogger) (action
 	return &defaultCommanderResolver{
 		log:                 log,
 		paths:               paths,
+		sandbox:             sandbox,
 		istioBinaryResolver: istioBinaryResolver,
 	}, nil
 }
@@ -15,10 +15,13 @@ type Provider interface {
 }
 
 // DefaultProvider provides a default implementation of Provider.
-type DefaultProvider struct{}
+type DefaultProvider struct {
+	//Sandbox of the operation which contains the temporary kubeconfig file (the OS temp-dir is used if nil)
+	Sandbox *file.Sandbox
+}
 
 func (c *DefaultProvider) RetrieveFrom(kubeConfig string, log *zap.SugaredLogger) (kubernetes.Interface, error) {
-	kubeconfigPath, kubeconfigCf, err := file.CreateTempFileWith(kubeConfig)
+	kubeconfigPath, kubeconfigCf, err := c.Sandbox.CreateTempFileWith(kubeConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -3,7 +3,6 @@ package istio
 import (
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/actions"
-	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/clientset"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/reset/data"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/reset/pod"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/reset/pod/reset"
@@ -29,11 +28,10 @@ func init() {
 
 	gatherer := data.NewDefaultGatherer()
 	matcher := pod.NewParentKindMatcher()
-	provider := clientset.DefaultProvider{}
 	action := reset.NewDefaultPodsResetAction(matcher)
 	istioProxyReset := proxy.NewDefaultIstioProxyReset(gatherer, action)
 
-	istioPerformerCreatorFn := istioPerformerCreator(istioProxyReset, &provider, ReconcilerNameIstio)
+	istioPerformerCreatorFn := istioPerformerCreator(istioProxyReset, ReconcilerNameIstio)
 	reconcilerIstio.
 		WithPreReconcileAction(NewStatusPreAction(istioPerformerCreatorFn)).
 		WithReconcileAction(NewIstioMainReconcileAction(istioPerformerCreatorFn)).
@@ -48,7 +46,7 @@ func init() {
 		log.Fatalf("Could not create '%s' component reconciler: %s", ReconcilerNameIstioConfiguration, err)
 	}
 
-	istioConfigurationPerformerCreatorFn := istioPerformerCreator(istioProxyReset, &provider, ReconcilerNameIstioConfiguration)
+	istioConfigurationPerformerCreatorFn := istioPerformerCreator(istioProxyReset, ReconcilerNameIstioConfiguration)
 	reconcilerIstioConfiguration.WithReconcileAction(NewReconcileIstioConfigurationAction(istioConfigurationPerformerCreatorFn)).
 		WithDeleteAction(NewUninstallAction(istioConfigurationPerformerCreatorFn))
 
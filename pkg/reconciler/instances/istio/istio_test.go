@@ -7,6 +7,7 @@ import (
 	log "github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/file"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/actions"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio/clientset"
@@ -142,8 +143,8 @@ const (
 // TODO(piotrkpc): here we are testing particular action's behaviour not Istio reconciler. Consider moving those to action_test.go
 func Test_RunUpdateAction(t *testing.T) {
 
-	performerCreatorFn := func(p *actions.DefaultIstioPerformer) func(logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
-		return func(logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
+	performerCreatorFn := func(p *actions.DefaultIstioPerformer) func(sandbox *file.Sandbox, logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
+		return func(sandbox *file.Sandbox, logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
 			return p, nil
 		}
 	}
@@ -238,8 +239,8 @@ func Test_RunUpdateAction(t *testing.T) {
 
 func Test_RunUninstallAction(t *testing.T) {
 
-	performerCreatorFn := func(p *actions.DefaultIstioPerformer) func(logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
-		return func(logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
+	performerCreatorFn := func(p *actions.DefaultIstioPerformer) func(sandbox *file.Sandbox, logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
+		return func(sandbox *file.Sandbox, logger *zap.SugaredLogger) (actions.IstioPerformer, error) {
 			return p, nil
 		}
 	}
@@ -32,17 +32,19 @@ type Commander interface {
 var execCommand = exec.Command
 
 // DefaultCommander provides a default implementation of Commander.
+// Temporary files (e.g. the kubeconfig) are created in the sandbox of the operation.
 type DefaultCommander struct {
 	istioctl Executable
+	sandbox  *file.Sandbox
 }
 
-func NewDefaultCommander(istioctl Executable) DefaultCommander {
-	return DefaultCommander{istioctl}
+func NewDefaultCommander(istioctl Executable, sandbox *file.Sandbox) DefaultCommander {
+	return DefaultCommander{istioctl, sandbox}
 }
 
 func (c *DefaultCommander) Uninstall(kubeconfig string, logger *zap.SugaredLogger) error {
 
-	kubeconfigPath, kubeconfigCf, err := file.CreateTempFileWith(kubeconfig)
+	kubeconfigPath, kubeconfigCf, err := c.sandbox.CreateTempFileWith(kubeconfig)
 	if err != nil {
 		return err
 	}
@@ -61,7 +63,7 @@ func (c *DefaultCommander) Uninstall(kubeconfig string, logger *zap.SugaredLogge
 
 func (c *DefaultCommander) Install(istioOperator, kubeconfig string, logger *zap.SugaredLogger) error {
 
-	kubeconfigPath, kubeconfigCf, err := file.CreateTempFileWith(kubeconfig)
+	kubeconfigPath, kubeconfigCf, err := c.sandbox.CreateTempFileWith(kubeconfig)
 	logger.Debugf("Created kubeconfig temp file on %s ", kubeconfigPath)
 	if err != nil {
 		return err
@@ -74,7 +76,7 @@ func (c *DefaultCommander) Install(istioOperator, kubeconfig string, logger *zap
 		}
 	}()
 
-	istioOperatorPath, istioOperatorCf, err := file.CreateTempFileWith(istioOperator)
+	istioOperatorPath, istioOperatorCf, err := c.sandbox.CreateTempFileWith(istioOperator)
 	logger.Debugf("Created IstioOperator temp file on %s ", istioOperatorPath)
 	if err != nil {
 		return err
@@ -105,7 +107,7 @@ func (c *DefaultCommander) Upgrade(istioOperator, kubeconfig string, logger *zap
 
 func (c *DefaultCommander) Version(kubeconfig string, logger *zap.SugaredLogger) ([]byte, error) {
 
-	kubeconfigPath, kubeconfigCf, err := file.CreateTempFileWith(kubeconfig)
+	kubeconfigPath, kubeconfigCf, err := c.sandbox.CreateTempFileWith(kubeconfig)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -17,6 +17,8 @@ import (
 	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/eventing"
 	//import required to register component reconciler 'istio' in reconciler registry
 	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio"
+	//import required to register component reconciler 'mothership' in reconciler registry
+	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/mothership"
 	//import required to register component reconciler 'ory' in reconciler registry
 	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/ory"
 	//import required to register component reconciler 'rafter' in reconciler registry
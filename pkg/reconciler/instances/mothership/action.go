@@ -0,0 +1,43 @@
+package mothership
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+)
+
+//ReconcileAction deploys the supporting resources of the reconciler deployment
+type ReconcileAction struct {
+}
+
+func (a *ReconcileAction) Run(context *service.ActionContext) error {
+	manifest, err := renderManifest(context.Task.Namespace, context.Task.Configuration)
+	if err != nil {
+		return err
+	}
+	resources, err := context.KubeClient.Deploy(context.Context, manifest, context.Task.Namespace,
+		&service.LabelsInterceptor{Version: context.Task.Version},
+		&service.AnnotationsInterceptor{})
+	if err != nil {
+		return err
+	}
+	context.Logger.Debugf("Deployed %d supporting resources of the reconciler in namespace '%s'",
+		len(resources), context.Task.Namespace)
+	return nil
+}
+
+//DeleteAction removes the supporting resources of the reconciler deployment
+type DeleteAction struct {
+}
+
+func (a *DeleteAction) Run(context *service.ActionContext) error {
+	manifest, err := renderManifest(context.Task.Namespace, context.Task.Configuration)
+	if err != nil {
+		return err
+	}
+	resources, err := context.KubeClient.Delete(context.Context, manifest, context.Task.Namespace)
+	if err != nil {
+		return err
+	}
+	context.Logger.Debugf("Deleted %d supporting resources of the reconciler in namespace '%s'",
+		len(resources), context.Task.Namespace)
+	return nil
+}
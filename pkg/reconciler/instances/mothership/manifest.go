@@ -0,0 +1,241 @@
+package mothership
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+//configuration keys of the component (all keys are optional)
+const (
+	keyPodLabel               = "podSelector.app"
+	keyPriorityClassName      = "priorityClass.name"
+	keyPriorityClassValue     = "priorityClass.value"
+	keyNetworkPolicyEnabled   = "networkPolicy.enabled"
+	keyMonitoringNamespace    = "networkPolicy.monitoringNamespace"
+	keyReconcilerSelector     = "networkPolicy.reconcilerSelector"
+	keyServiceMonitorEnabled  = "serviceMonitor.enabled"
+	keyServiceMonitorPort     = "serviceMonitor.port"
+	keyServiceMonitorPath     = "serviceMonitor.path"
+	keyServiceMonitorInterval = "serviceMonitor.interval"
+)
+
+//defaultReconcilerSelector selects the pods of the component reconcilers (in any namespace) which send the
+//operation callbacks to the mothership
+const defaultReconcilerSelector = "reconciler.kyma-project.io/component-reconciler=true"
+
+type values struct {
+	Namespace              string
+	PodLabel               string
+	PriorityClassName      string
+	PriorityClassValue     int64
+	NetworkPolicyEnabled   bool
+	MonitoringNamespace    string
+	ReconcilerSelector     map[string]string
+	ServiceMonitorEnabled  bool
+	ServiceMonitorPort     string
+	ServiceMonitorPath     string
+	ServiceMonitorInterval string
+}
+
+//serviceMonitor is the subset of the ServiceMonitor resource of the Prometheus operator used by the reconciler
+type serviceMonitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              serviceMonitorSpec `json:"spec"`
+}
+
+type serviceMonitorSpec struct {
+	Selector  metav1.LabelSelector     `json:"selector"`
+	Endpoints []serviceMonitorEndpoint `json:"endpoints"`
+}
+
+type serviceMonitorEndpoint struct {
+	Port     string `json:"port"`
+	Path     string `json:"path"`
+	Interval string `json:"interval"`
+}
+
+//renderManifest renders the supporting resources of the reconciler deployment with the configuration of the
+//component. The resources are marshalled from their API types: configuration values can't inject YAML.
+func renderManifest(namespace string, configuration map[string]interface{}) (string, error) {
+	if namespace == "" {
+		return "", fmt.Errorf("namespace of component '%s' is undefined", ReconcilerName)
+	}
+	vals := values{Namespace: namespace}
+	var err error
+	if vals.PodLabel, err = stringValue(configuration, keyPodLabel, "mothership-reconciler"); err != nil {
+		return "", err
+	}
+	if vals.PriorityClassName, err = stringValue(configuration, keyPriorityClassName, "reconciler-priority"); err != nil {
+		return "", err
+	}
+	if vals.PriorityClassValue, err = intValue(configuration, keyPriorityClassValue, 2000000); err != nil {
+		return "", err
+	}
+	if vals.PriorityClassValue < math.MinInt32 || vals.PriorityClassValue > math.MaxInt32 {
+		return "", fmt.Errorf("configuration '%s' has to be a 32-bit integer but was '%d'",
+			keyPriorityClassValue, vals.PriorityClassValue)
+	}
+	if vals.NetworkPolicyEnabled, err = boolValue(configuration, keyNetworkPolicyEnabled, true); err != nil {
+		return "", err
+	}
+	if vals.MonitoringNamespace, err = stringValue(configuration, keyMonitoringNamespace, "kyma-system"); err != nil {
+		return "", err
+	}
+	reconcilerSelector, err := stringValue(configuration, keyReconcilerSelector, defaultReconcilerSelector)
+	if err != nil {
+		return "", err
+	}
+	if vals.ReconcilerSelector, err = selectorValue(keyReconcilerSelector, reconcilerSelector); err != nil {
+		return "", err
+	}
+	if vals.ServiceMonitorEnabled, err = boolValue(configuration, keyServiceMonitorEnabled, true); err != nil {
+		return "", err
+	}
+	if vals.ServiceMonitorPort, err = stringValue(configuration, keyServiceMonitorPort, "http"); err != nil {
+		return "", err
+	}
+	if vals.ServiceMonitorPath, err = stringValue(configuration, keyServiceMonitorPath, "/metrics"); err != nil {
+		return "", err
+	}
+	if vals.ServiceMonitorInterval, err = stringValue(configuration, keyServiceMonitorInterval, "30s"); err != nil {
+		return "", err
+	}
+
+	resources := []interface{}{newPriorityClass(vals)}
+	if vals.NetworkPolicyEnabled {
+		resources = append(resources, newNetworkPolicy(vals))
+	}
+	if vals.ServiceMonitorEnabled {
+		resources = append(resources, newServiceMonitor(vals))
+	}
+	var manifest strings.Builder
+	for _, resource := range resources {
+		data, err := yaml.Marshal(resource)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to render manifest of component '%s'", ReconcilerName)
+		}
+		manifest.WriteString("---\n")
+		manifest.Write(data)
+	}
+	return manifest.String(), nil
+}
+
+func newPriorityClass(vals values) *schedulingv1.PriorityClass {
+	return &schedulingv1.PriorityClass{
+		TypeMeta:      metav1.TypeMeta{APIVersion: "scheduling.k8s.io/v1", Kind: "PriorityClass"},
+		ObjectMeta:    metav1.ObjectMeta{Name: vals.PriorityClassName},
+		Value:         int32(vals.PriorityClassValue),
+		GlobalDefault: false,
+		Description:   "Priority of the reconciler pods",
+	}
+}
+
+//newNetworkPolicy allows the ingress traffic of the pods in the namespace, of the monitoring and of the component
+//reconcilers (operation callbacks)
+func newNetworkPolicy(vals values) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: vals.PodLabel, Namespace: vals.Namespace},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": vals.PodLabel}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}},
+				{From: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"kubernetes.io/metadata.name": vals.MonitoringNamespace},
+				}}}},
+				{From: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{},
+					PodSelector:       &metav1.LabelSelector{MatchLabels: vals.ReconcilerSelector},
+				}}},
+			},
+		},
+	}
+}
+
+func newServiceMonitor(vals values) *serviceMonitor {
+	return &serviceMonitor{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "monitoring.coreos.com/v1", Kind: "ServiceMonitor"},
+		ObjectMeta: metav1.ObjectMeta{Name: vals.PodLabel, Namespace: vals.Namespace},
+		Spec: serviceMonitorSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": vals.PodLabel}},
+			Endpoints: []serviceMonitorEndpoint{{
+				Port:     vals.ServiceMonitorPort,
+				Path:     vals.ServiceMonitorPath,
+				Interval: vals.ServiceMonitorInterval,
+			}},
+		},
+	}
+}
+
+//selectorValue parses a label selector defined as 'key=value' (multiple labels are separated by commas)
+func selectorValue(key, selector string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, label := range strings.Split(selector, ",") {
+		keyValue := strings.SplitN(strings.TrimSpace(label), "=", 2)
+		if len(keyValue) != 2 || keyValue[0] == "" {
+			return nil, fmt.Errorf("configuration '%s' has to be a label selector ('key=value') but was '%s'",
+				key, selector)
+		}
+		result[keyValue[0]] = keyValue[1]
+	}
+	return result, nil
+}
+
+func stringValue(configuration map[string]interface{}, key, defaultValue string) (string, error) {
+	value, ok := configuration[key]
+	if !ok {
+		return defaultValue, nil
+	}
+	if str, ok := value.(string); ok && str != "" {
+		return str, nil
+	}
+	return "", fmt.Errorf("configuration '%s' has to be a non-empty string but was '%v'", key, value)
+}
+
+func intValue(configuration map[string]interface{}, key string, defaultValue int64) (int64, error) {
+	value, ok := configuration[key]
+	if !ok {
+		return defaultValue, nil
+	}
+	switch number := value.(type) {
+	case int:
+		return int64(number), nil
+	case int64:
+		return number, nil
+	case float64:
+		if number == float64(int64(number)) {
+			return int64(number), nil
+		}
+	case string:
+		if result, err := strconv.ParseInt(number, 10, 64); err == nil {
+			return result, nil
+		}
+	}
+	return 0, fmt.Errorf("configuration '%s' has to be an integer but was '%v'", key, value)
+}
+
+func boolValue(configuration map[string]interface{}, key string, defaultValue bool) (bool, error) {
+	value, ok := configuration[key]
+	if !ok {
+		return defaultValue, nil
+	}
+	switch flag := value.(type) {
+	case bool:
+		return flag, nil
+	case string:
+		if result, err := strconv.ParseBool(flag); err == nil {
+			return result, nil
+		}
+	}
+	return false, fmt.Errorf("configuration '%s' has to be a boolean but was '%v'", key, value)
+}
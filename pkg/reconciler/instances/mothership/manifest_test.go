@@ -0,0 +1,124 @@
+package mothership
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRenderManifest(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespace     string
+		configuration map[string]interface{}
+		kinds         []string
+		contains      []string
+		invalid       bool
+	}{
+		{
+			name:      "Default configuration",
+			namespace: "reconciler",
+			kinds:     []string{"PriorityClass", "NetworkPolicy", "ServiceMonitor"},
+			contains: []string{"value: 2000000", "namespace: reconciler", "app: mothership-reconciler", "interval: 30s",
+				"reconciler.kyma-project.io/component-reconciler: \"true\""},
+		},
+		{
+			name:      "Custom component reconciler selector",
+			namespace: "reconciler",
+			configuration: map[string]interface{}{
+				keyReconcilerSelector: "app.kubernetes.io/part-of=reconciler, tier=component",
+			},
+			kinds:    []string{"PriorityClass", "NetworkPolicy", "ServiceMonitor"},
+			contains: []string{"app.kubernetes.io/part-of: reconciler", "tier: component"},
+		},
+		{
+			name:      "Values cannot inject resources",
+			namespace: "reconciler",
+			configuration: map[string]interface{}{
+				keyPodLabel:               "x\n---\napiVersion: v1\nkind: Secret",
+				keyServiceMonitorInterval: "30s\nkind: Secret",
+			},
+			kinds: []string{"PriorityClass", "NetworkPolicy", "ServiceMonitor"},
+		},
+		{
+			name:      "Custom configuration",
+			namespace: "kcp-system",
+			configuration: map[string]interface{}{
+				keyPriorityClassValue:     float64(1000),
+				keyNetworkPolicyEnabled:   "false",
+				keyServiceMonitorInterval: "1m",
+			},
+			kinds:    []string{"PriorityClass", "ServiceMonitor"},
+			contains: []string{"value: 1000", "namespace: kcp-system", "interval: 1m"},
+		},
+		{
+			name:      "All optional resources disabled",
+			namespace: "reconciler",
+			configuration: map[string]interface{}{
+				keyNetworkPolicyEnabled:  false,
+				keyServiceMonitorEnabled: false,
+			},
+			kinds: []string{"PriorityClass"},
+		},
+		{
+			name:      "Invalid value",
+			namespace: "reconciler",
+			configuration: map[string]interface{}{
+				keyPriorityClassValue: "high",
+			},
+			invalid: true,
+		},
+		{
+			name:      "Invalid component reconciler selector",
+			namespace: "reconciler",
+			configuration: map[string]interface{}{
+				keyReconcilerSelector: "component-reconciler",
+			},
+			invalid: true,
+		},
+		{
+			name:      "Priority exceeds 32 bit",
+			namespace: "reconciler",
+			configuration: map[string]interface{}{
+				keyPriorityClassValue: "4294967296",
+			},
+			invalid: true,
+		},
+		{
+			name:    "Undefined namespace",
+			invalid: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			manifest, err := renderManifest(tc.namespace, tc.configuration)
+			if tc.invalid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.kinds, manifestKinds(t, manifest))
+			for _, expected := range tc.contains {
+				require.Contains(t, manifest, expected)
+			}
+		})
+	}
+}
+
+//manifestKinds returns the kinds of the resources in the manifest
+func manifestKinds(t *testing.T, manifest string) []string {
+	var kinds []string
+	for _, doc := range strings.Split("\n"+manifest, "\n---\n") {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		resource := struct {
+			Kind string `json:"kind"`
+		}{}
+		require.NoError(t, yaml.Unmarshal([]byte(doc), &resource))
+		kinds = append(kinds, resource.Kind)
+	}
+	return kinds
+}
@@ -0,0 +1,26 @@
+package mothership
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+)
+
+//ReconcilerName of the component reconciler which manages the supporting resources of the reconciler deployment
+//itself (self-management): the cluster which hosts the reconciler has to be registered with this component
+const ReconcilerName = "mothership"
+
+//nolint:gochecknoinits //usage of init() is intended to register reconciler-instances in centralized registry
+func init() {
+	log := logger.NewLogger(false)
+
+	log.Debugf("Initializing component reconciler '%s'", ReconcilerName)
+	reconciler, err := service.NewComponentReconciler(ReconcilerName)
+	if err != nil {
+		log.Fatalf("Could not create '%s' component reconciler: %s", ReconcilerName, err)
+	}
+
+	//the resources are rendered by the reconciler and not loaded from a chart
+	reconciler.
+		WithReconcileAction(&ReconcileAction{}).
+		WithDeleteAction(&DeleteAction{})
+}
@@ -1,7 +1,6 @@
 package kubernetes
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"github.com/avast/retry-go"
@@ -11,7 +10,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
-	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
@@ -45,14 +43,15 @@ metadata:
 )
 
 type kubeClientAdapter struct {
-	kubeconfig    string
-	logger        *zap.SugaredLogger
-	config        *Config
-	restConfig    *rest.Config
-	mapper        *restmapper.DeferredDiscoveryRESTMapper
-	helmClient    *kube.Client
-	dynamicClient dynamic.Interface
-	apixClient    apixV1ClientSet.ApiextensionsV1Interface
+	kubeconfig      string
+	logger          *zap.SugaredLogger
+	config          *Config
+	restConfig      *rest.Config
+	mapper          *restmapper.DeferredDiscoveryRESTMapper
+	discoveryClient discovery.CachedDiscoveryInterface
+	helmClient      *kube.Client
+	dynamicClient   dynamic.Interface
+	apixClient      apixV1ClientSet.ApiextensionsV1Interface
 }
 
 func NewKubernetesClient(kubeconfig string, logger *zap.SugaredLogger, config *Config) (Client, error) {
@@ -67,10 +66,12 @@ func NewKubernetesClient(kubeconfig string, logger *zap.SugaredLogger, config *C
 	if err != nil {
 		return nil, err
 	}
-	mapper, err := getDiscoveryMapper(restConfig)
+	discoveryClient, err := discoveryClients.get(kubeconfig, restConfig, config.DiscoveryCacheTTL)
 	if err != nil {
 		return nil, err
 	}
+	//the discovery client is shared between operations: only the requests of this client are tracked
+	restConfig = config.Usage.track(restConfig)
 	dynamicClient, err := dynamic.NewForConfig(restConfig)
 	if err != nil {
 		return nil, err
@@ -79,7 +80,7 @@ func NewKubernetesClient(kubeconfig string, logger *zap.SugaredLogger, config *C
 	if err != nil {
 		return nil, err
 	}
-	return adapt(kubeconfig, logger, config, restConfig, mapper, dynamicClient, apixClient), err
+	return adapt(kubeconfig, logger, config, restConfig, discoveryClient, dynamicClient, apixClient), err
 }
 
 func NewInClusterClientSet(logger *zap.SugaredLogger) (kubernetes.Interface, error) {
@@ -91,16 +92,17 @@ func NewInClusterClientSet(logger *zap.SugaredLogger) (kubernetes.Interface, err
 	return kubernetes.NewForConfig(inClusterConfig)
 }
 
-func adapt(kubeconfig string, logger *zap.SugaredLogger, config *Config, restConfig *rest.Config, mapper *restmapper.DeferredDiscoveryRESTMapper, dynamicClient dynamic.Interface, apixClient *apixV1ClientSet.ApiextensionsV1Client) *kubeClientAdapter {
+func adapt(kubeconfig string, logger *zap.SugaredLogger, config *Config, restConfig *rest.Config, discoveryClient discovery.CachedDiscoveryInterface, dynamicClient dynamic.Interface, apixClient *apixV1ClientSet.ApiextensionsV1Client) *kubeClientAdapter {
 	return &kubeClientAdapter{
-		kubeconfig:    kubeconfig,
-		logger:        logger,
-		config:        config,
-		restConfig:    restConfig,
-		mapper:        mapper,
-		dynamicClient: dynamicClient,
-		helmClient:    kube.New(NewRESTClientGetter(restConfig)),
-		apixClient:    apixClient,
+		kubeconfig:      kubeconfig,
+		logger:          logger,
+		config:          config,
+		restConfig:      restConfig,
+		mapper:          restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient),
+		discoveryClient: discoveryClient,
+		dynamicClient:   dynamicClient,
+		helmClient:      kube.New(NewCachedRESTClientGetter(restConfig, discoveryClient)),
+		apixClient:      apixClient,
 	}
 }
 
@@ -148,15 +150,16 @@ func (g *kubeClientAdapter) DeployByCompareWithOriginal(ctx context.Context, man
 	if namespace == "" {
 		namespace = defaultNamespace
 	}
+	g.config.Usage.addManifest(manifestTarget)
 
-	resourceInfoOriginal, err := g.helmClient.Build(bytes.NewBuffer([]byte(manifestOriginal)), false)
+	resourceInfoOriginal, err := g.helmClient.Build(strings.NewReader(manifestOriginal), false)
 	if err != nil {
 		g.logger.Errorf("Failed to process original manifest data for deploy: %s", err)
 		g.logger.Debugf("Manifest data: %s", manifestOriginal)
 		return nil, err
 	}
 
-	unstructsTarget, err := g.applyInterceptors(manifestTarget, namespace, interceptors)
+	unstructsTarget, err := g.applyInterceptors(NewManifest(manifestTarget), namespace, interceptors)
 	if err != nil {
 		g.logger.Errorf("Failed to process target manifest data for deploy: %s", err)
 		g.logger.Debugf("Manifest data: %s", manifestTarget)
@@ -181,9 +184,14 @@ func (g *kubeClientAdapter) DeployByCompareWithOriginal(ctx context.Context, man
 }
 
 func (g *kubeClientAdapter) Deploy(ctx context.Context, manifestTarget, namespace string, interceptors ...ResourceInterceptor) ([]*Resource, error) {
+	return g.DeployManifest(ctx, NewManifest(manifestTarget), namespace, interceptors...)
+}
+
+func (g *kubeClientAdapter) DeployManifest(ctx context.Context, manifestTarget *Manifest, namespace string, interceptors ...ResourceInterceptor) ([]*Resource, error) {
 	if namespace == "" {
 		namespace = defaultNamespace
 	}
+	g.config.Usage.addManifest(manifestTarget.String())
 
 	unstructsTarget, err := g.applyInterceptors(manifestTarget, namespace, interceptors)
 	if err != nil {
@@ -211,7 +219,7 @@ func (g *kubeClientAdapter) Deploy(ctx context.Context, manifestTarget, namespac
 	return deployedResources, err
 }
 
-func (g *kubeClientAdapter) applyInterceptors(manifestTarget string, namespace string, interceptors []ResourceInterceptor) ([]*unstructured.Unstructured, error) {
+func (g *kubeClientAdapter) applyInterceptors(manifestTarget *Manifest, namespace string, interceptors []ResourceInterceptor) ([]*unstructured.Unstructured, error) {
 
 	unstructsTarget, err := g.manifestToUnstructured(manifestTarget)
 	if err != nil {
@@ -274,8 +282,8 @@ func (g *kubeClientAdapter) getUpdateStrategy(infoTarget *resource.Info) (Update
 	return strategy, err
 }
 
-func (g *kubeClientAdapter) manifestToUnstructured(manifest string) ([]*unstructured.Unstructured, error) {
-	unstructs, err := ToUnstructured([]byte(manifest), true)
+func (g *kubeClientAdapter) manifestToUnstructured(manifest *Manifest) ([]*unstructured.Unstructured, error) {
+	unstructs, err := manifest.Unstructs()
 	if err != nil {
 		g.logger.Errorf("Failed to process manifest data to unstructured: %s", err)
 		g.logger.Debugf("Manifest data: %s", manifest)
@@ -297,17 +305,6 @@ func (g *kubeClientAdapter) addWatchableResourceInfoToProgressTracker(info *reso
 	return res
 }
 
-func getDiscoveryMapper(restConfig *rest.Config) (*restmapper.DeferredDiscoveryRESTMapper, error) {
-	// Prepare a RESTMapper to find GVR
-	dc, err := discovery.NewDiscoveryClientForConfig(restConfig)
-	if err != nil {
-		return nil, errors.Wrap(err, "Failed to create new discovery client")
-	}
-
-	discoveryMapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
-	return discoveryMapper, nil
-}
-
 func getRestConfig(kubeconfig string) (*rest.Config, error) {
 	return clientcmd.BuildConfigFromKubeconfigGetter("", func() (config *clientcmdapi.Config, e error) {
 		return clientcmd.Load([]byte(kubeconfig))
@@ -630,6 +627,10 @@ func (g *kubeClientAdapter) deleteResourceByKindAndNameAndNamespace(context cont
 }
 
 func (g *kubeClientAdapter) Delete(ctx context.Context, manifestTarget, namespace string) ([]*Resource, error) {
+	return g.DeleteManifest(ctx, NewManifest(manifestTarget), namespace)
+}
+
+func (g *kubeClientAdapter) DeleteManifest(ctx context.Context, manifestTarget *Manifest, namespace string) ([]*Resource, error) {
 	if namespace == "" {
 		namespace = defaultNamespace
 	}
@@ -680,7 +681,7 @@ func (g *kubeClientAdapter) Delete(ctx context.Context, manifestTarget, namespac
 }
 
 func (g *kubeClientAdapter) DeleteNamespace(ctx context.Context, namespace string) error {
-	r := cmdutil.NewFactory(NewRESTClientGetter(g.restConfig)).NewBuilder().
+	r := cmdutil.NewFactory(NewCachedRESTClientGetter(g.restConfig, g.discoveryClient)).NewBuilder().
 		Unstructured().
 		NamespaceParam(namespace).DefaultNamespace().
 		LabelSelectorParam("").
@@ -758,8 +759,10 @@ func (g *kubeClientAdapter) newProgressTracker() (*progress.Tracker, error) {
 		return nil, err
 	}
 	return progress.NewProgressTracker(clientSet, g.logger, progress.Config{
-		Interval: g.config.ProgressInterval,
-		Timeout:  g.config.ProgressTimeout,
+		Interval:    g.config.ProgressInterval,
+		Timeout:     g.config.ProgressTimeout,
+		Escalations: g.config.ProgressEscalations,
+		Notifier:    g.config.ProgressNotifier,
 	})
 }
 
@@ -3,6 +3,8 @@ package kubernetes
 import (
 	"fmt"
 	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
 )
 
 const (
@@ -10,6 +12,7 @@ const (
 	progressTrackerTimeout  = 2 * time.Minute
 	maxRetries              = 10
 	retryDelay              = 1 * time.Second
+	discoveryCacheTTL       = 10 * time.Minute
 )
 
 type Config struct {
@@ -17,6 +20,14 @@ type Config struct {
 	ProgressTimeout  time.Duration
 	MaxRetries       int
 	RetryDelay       time.Duration
+	//DiscoveryCacheTTL defines how long discovered API resources of a cluster are re-used by subsequent operations
+	DiscoveryCacheTTL time.Duration
+	//Usage collects the API calls and deployed manifests of the client (optional)
+	Usage *Usage
+	//ProgressEscalations are applied by the progress tracker before the ProgressTimeout is reached (optional)
+	ProgressEscalations []progress.Escalation
+	//ProgressNotifier receives the warnings of escalations with the notify action (optional)
+	ProgressNotifier func(warning string)
 }
 
 func (c *Config) validate() error {
@@ -30,6 +41,8 @@ func (c *Config) validate() error {
 		return fmt.Errorf("config ProgressInterval cannot be < 0 (got %d)", c.ProgressInterval)
 	case c.ProgressTimeout < 0:
 		return fmt.Errorf("config ProgressTimeout cannot be < 0 (got %d)", c.ProgressTimeout)
+	case c.DiscoveryCacheTTL < 0:
+		return fmt.Errorf("config DiscoveryCacheTTL cannot be < 0 (got %d)", c.DiscoveryCacheTTL)
 	}
 
 	if c.MaxRetries == 0 {
@@ -44,5 +57,8 @@ func (c *Config) validate() error {
 	if c.ProgressTimeout == 0 {
 		c.ProgressTimeout = progressTrackerTimeout
 	}
+	if c.DiscoveryCacheTTL == 0 {
+		c.DiscoveryCacheTTL = discoveryCacheTTL
+	}
 	return nil
 }
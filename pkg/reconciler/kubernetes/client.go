@@ -23,9 +23,12 @@ type Client interface {
 	Deploy(ctx context.Context, manifestTarget, namespace string, interceptors ...ResourceInterceptor) ([]*Resource, error)
 	DeployByCompareWithOriginal(ctx context.Context, manifestOriginal, manifestTarget, namespace string, interceptors ...ResourceInterceptor) ([]*Resource, error)
 	Delete(ctx context.Context, manifest, namespace string) ([]*Resource, error)
+	DeployManifest(ctx context.Context, manifestTarget *Manifest, namespace string, interceptors ...ResourceInterceptor) ([]*Resource, error)
+	DeleteManifest(ctx context.Context, manifest *Manifest, namespace string) ([]*Resource, error)
 	PatchUsingStrategy(ctx context.Context, kind, name, namespace string, p []byte, strategy types.PatchType) error
 	Clientset() (kubernetes.Interface, error)
 
+	Get(kind, name, namespace string) (*unstructured.Unstructured, error)
 	GetDeployment(ctx context.Context, name, namespace string) (*v1apps.Deployment, error)
 	GetStatefulSet(ctx context.Context, name, namespace string) (*v1apps.StatefulSet, error)
 	GetSecret(ctx context.Context, name, namespace string) (*v1.Secret, error)
@@ -0,0 +1,115 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+)
+
+//discoveryMinRefreshInterval avoids repeated discovery calls if resources are requested whose kind doesn't exist in the cluster
+const discoveryMinRefreshInterval = 10 * time.Second
+
+//discoveryClients is shared by all Kubernetes clients of the process: operations on the same cluster re-use the
+//discovered API resources and server version instead of querying them again
+var discoveryClients = &discoveryCache{entries: make(map[string]*discoveryCacheEntry)}
+
+type discoveryCache struct {
+	mutex   sync.Mutex
+	entries map[string]*discoveryCacheEntry //key is the hash of the kubeconfig
+}
+
+type discoveryCacheEntry struct {
+	client  *cachedDiscoveryClient
+	created time.Time
+}
+
+//get returns the cached discovery client of a cluster. Discovery results older than the TTL are invalidated.
+func (dc *discoveryCache) get(kubeconfig string, restConfig *rest.Config, ttl time.Duration) (*cachedDiscoveryClient, error) {
+	key := discoveryCacheKey(kubeconfig)
+
+	dc.mutex.Lock()
+	defer dc.mutex.Unlock()
+
+	now := time.Now()
+	for entryKey, entry := range dc.entries {
+		if entryKey != key && now.Sub(entry.created) > ttl { //drop clusters which weren't reconciled for a while
+			delete(dc.entries, entryKey)
+		}
+	}
+
+	if entry, ok := dc.entries[key]; ok {
+		if now.Sub(entry.created) > ttl {
+			entry.client.Invalidate()
+			entry.created = now
+		}
+		return entry.client, nil
+	}
+
+	//the more API groups a cluster has, the more discovery requests are required
+	config := rest.CopyConfig(restConfig)
+	config.Burst = 100
+	client, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create new discovery client")
+	}
+	cachedClient := newCachedDiscoveryClient(memory.NewMemCacheClient(client))
+	dc.entries[key] = &discoveryCacheEntry{
+		client:  cachedClient,
+		created: now,
+	}
+	return cachedClient, nil
+}
+
+func discoveryCacheKey(kubeconfig string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(kubeconfig)))
+}
+
+//cachedDiscoveryClient caches the server version in addition to the API resources. The cache is reported as
+//stale shortly after its last refresh: REST mappers invalidate it if a kind is not found (e.g. a new CRD).
+type cachedDiscoveryClient struct {
+	discovery.CachedDiscoveryInterface
+	mutex       sync.Mutex
+	version     *version.Info
+	invalidated time.Time
+}
+
+func newCachedDiscoveryClient(client discovery.CachedDiscoveryInterface) *cachedDiscoveryClient {
+	return &cachedDiscoveryClient{
+		CachedDiscoveryInterface: client,
+		invalidated:              time.Now(),
+	}
+}
+
+func (c *cachedDiscoveryClient) ServerVersion() (*version.Info, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.version == nil {
+		serverVersion, err := c.CachedDiscoveryInterface.ServerVersion()
+		if err != nil {
+			return nil, err
+		}
+		c.version = serverVersion
+	}
+	return c.version, nil
+}
+
+func (c *cachedDiscoveryClient) Fresh() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return time.Since(c.invalidated) < discoveryMinRefreshInterval
+}
+
+func (c *cachedDiscoveryClient) Invalidate() {
+	c.mutex.Lock()
+	c.version = nil
+	c.invalidated = time.Now()
+	c.mutex.Unlock()
+	c.CachedDiscoveryInterface.Invalidate()
+}
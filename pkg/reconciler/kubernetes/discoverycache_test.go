@@ -0,0 +1,71 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestDiscoveryCache(t *testing.T) {
+	cache := &discoveryCache{entries: make(map[string]*discoveryCacheEntry)}
+	restConfig := &rest.Config{Host: "https://localhost:6443"}
+
+	t.Run("Clients are shared per kubeconfig", func(t *testing.T) {
+		client1, err := cache.get("kubeconfig1", restConfig, time.Minute)
+		require.NoError(t, err)
+		client1Again, err := cache.get("kubeconfig1", restConfig, time.Minute)
+		require.NoError(t, err)
+		require.Same(t, client1, client1Again)
+
+		client2, err := cache.get("kubeconfig2", restConfig, time.Minute)
+		require.NoError(t, err)
+		require.NotSame(t, client1, client2)
+	})
+
+	t.Run("Expired clients are invalidated", func(t *testing.T) {
+		client, err := cache.get("kubeconfig3", restConfig, time.Minute)
+		require.NoError(t, err)
+		cache.entries[discoveryCacheKey("kubeconfig3")].created = time.Now().Add(-2 * time.Minute)
+		client.invalidated = time.Now().Add(-time.Hour)
+		require.False(t, client.Fresh())
+
+		clientAgain, err := cache.get("kubeconfig3", restConfig, time.Minute)
+		require.NoError(t, err)
+		require.Same(t, client, clientAgain)
+		require.True(t, client.Fresh())
+	})
+
+	t.Run("Expired clients of other clusters are dropped", func(t *testing.T) {
+		cache.entries[discoveryCacheKey("kubeconfig1")].created = time.Now().Add(-2 * time.Minute)
+		_, err := cache.get("kubeconfig2", restConfig, time.Minute)
+		require.NoError(t, err)
+		require.NotContains(t, cache.entries, discoveryCacheKey("kubeconfig1"))
+	})
+}
+
+func TestCachedDiscoveryClient(t *testing.T) {
+	fake := &fakediscovery.FakeDiscovery{
+		Fake:               &clienttesting.Fake{},
+		FakedServerVersion: &version.Info{GitVersion: "v1.23.6"},
+	}
+	client := newCachedDiscoveryClient(memory.NewMemCacheClient(fake))
+
+	for i := 0; i < 3; i++ {
+		serverVersion, err := client.ServerVersion()
+		require.NoError(t, err)
+		require.Equal(t, "v1.23.6", serverVersion.GitVersion)
+	}
+	require.Len(t, fake.Actions(), 1)
+
+	//server version is requested again after invalidation
+	client.Invalidate()
+	_, err := client.ServerVersion()
+	require.NoError(t, err)
+	require.Len(t, fake.Actions(), 2)
+}
@@ -0,0 +1,42 @@
+package kubernetes
+
+import (
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+//Manifest holds the raw text of a rendered manifest together with its parsed resources: the manifest is parsed
+//only once and the parsed resources are re-used by all phases of an operation (e.g. deploy and delete)
+type Manifest struct {
+	raw       string
+	once      sync.Once
+	unstructs []*unstructured.Unstructured
+	err       error
+}
+
+func NewManifest(raw string) *Manifest {
+	return &Manifest{raw: raw}
+}
+
+//String returns the raw text of the manifest
+func (m *Manifest) String() string {
+	return m.raw
+}
+
+//Unstructs returns copies of the parsed resources: callers (e.g. interceptors) can modify them without
+//side effects on later phases which use the same manifest
+func (m *Manifest) Unstructs() ([]*unstructured.Unstructured, error) {
+	m.once.Do(func() {
+		m.unstructs, m.err = decodeAll(NewManifestDecoder(strings.NewReader(m.raw)))
+	})
+	if m.err != nil {
+		return nil, m.err
+	}
+	result := make([]*unstructured.Unstructured, 0, len(m.unstructs))
+	for _, unstruct := range m.unstructs {
+		result = append(result, unstruct.DeepCopy())
+	}
+	return result, nil
+}
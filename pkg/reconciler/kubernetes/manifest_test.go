@@ -0,0 +1,32 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifest(t *testing.T) {
+
+	t.Run("Resources are parsed once and returned as copies", func(t *testing.T) {
+		manifest := NewManifest(multiDocManifest)
+		require.Equal(t, multiDocManifest, manifest.String())
+
+		unstructs, err := manifest.Unstructs()
+		require.NoError(t, err)
+		require.Len(t, unstructs, 2)
+		unstructs[0].SetName("modified")
+
+		unstructs, err = manifest.Unstructs()
+		require.NoError(t, err)
+		require.Equal(t, "kyma-system", unstructs[0].GetName())
+	})
+
+	t.Run("Parse error is returned to all callers", func(t *testing.T) {
+		manifest := NewManifest("metadata:\n  name: no-kind\n")
+		_, err := manifest.Unstructs()
+		require.Error(t, err)
+		_, err = manifest.Unstructs()
+		require.Error(t, err)
+	})
+}
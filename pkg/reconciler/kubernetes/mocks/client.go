@@ -75,6 +75,29 @@ func (_m *Client) Delete(ctx context.Context, manifest string, namespace string)
 	return r0, r1
 }
 
+// DeleteManifest provides a mock function with given fields: ctx, manifest, namespace
+func (_m *Client) DeleteManifest(ctx context.Context, manifest *reconcilerkubernetes.Manifest, namespace string) ([]*reconcilerkubernetes.Resource, error) {
+	ret := _m.Called(ctx, manifest, namespace)
+
+	var r0 []*reconcilerkubernetes.Resource
+	if rf, ok := ret.Get(0).(func(context.Context, *reconcilerkubernetes.Manifest, string) []*reconcilerkubernetes.Resource); ok {
+		r0 = rf(ctx, manifest, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*reconcilerkubernetes.Resource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *reconcilerkubernetes.Manifest, string) error); ok {
+		r1 = rf(ctx, manifest, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteResource provides a mock function with given fields: ctx, kind, name, namespace
 func (_m *Client) DeleteResource(ctx context.Context, kind string, name string, namespace string) (*reconcilerkubernetes.Resource, error) {
 	ret := _m.Called(ctx, kind, name, namespace)
@@ -158,6 +181,59 @@ func (_m *Client) DeployByCompareWithOriginal(ctx context.Context, manifestOrigi
 	return r0, r1
 }
 
+// DeployManifest provides a mock function with given fields: ctx, manifestTarget, namespace, interceptors
+func (_m *Client) DeployManifest(ctx context.Context, manifestTarget *reconcilerkubernetes.Manifest, namespace string, interceptors ...reconcilerkubernetes.ResourceInterceptor) ([]*reconcilerkubernetes.Resource, error) {
+	_va := make([]interface{}, len(interceptors))
+	for _i := range interceptors {
+		_va[_i] = interceptors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, manifestTarget, namespace)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []*reconcilerkubernetes.Resource
+	if rf, ok := ret.Get(0).(func(context.Context, *reconcilerkubernetes.Manifest, string, ...reconcilerkubernetes.ResourceInterceptor) []*reconcilerkubernetes.Resource); ok {
+		r0 = rf(ctx, manifestTarget, namespace, interceptors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*reconcilerkubernetes.Resource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *reconcilerkubernetes.Manifest, string, ...reconcilerkubernetes.ResourceInterceptor) error); ok {
+		r1 = rf(ctx, manifestTarget, namespace, interceptors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: kind, name, namespace
+func (_m *Client) Get(kind string, name string, namespace string) (*unstructured.Unstructured, error) {
+	ret := _m.Called(kind, name, namespace)
+
+	var r0 *unstructured.Unstructured
+	if rf, ok := ret.Get(0).(func(string, string, string) *unstructured.Unstructured); ok {
+		r0 = rf(kind, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*unstructured.Unstructured)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(kind, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDeployment provides a mock function with given fields: ctx, name, namespace
 func (_m *Client) GetDeployment(ctx context.Context, name string, namespace string) (*v1.Deployment, error) {
 	ret := _m.Called(ctx, name, namespace)
@@ -0,0 +1,181 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type EscalationAction string
+
+const (
+	//EscalationWarn logs a warning and dumps the resources which are not in the target state
+	EscalationWarn EscalationAction = "warn"
+	//EscalationNotify sends a warning to the notifier (e.g. the mothership) to signal an upcoming timeout
+	EscalationNotify EscalationAction = "notify"
+)
+
+//Escalation is applied when the progress tracking exceeded the threshold (fraction of the timeout)
+type Escalation struct {
+	Threshold float64
+	Action    EscalationAction
+}
+
+func (e Escalation) String() string {
+	return fmt.Sprintf("%g=%s", e.Threshold, e.Action)
+}
+
+func (e Escalation) validate() error {
+	if e.Threshold <= 0 || e.Threshold >= 1 {
+		return fmt.Errorf("threshold of progress tracker escalation '%s' has to be > 0 and < 1", e)
+	}
+	switch e.Action {
+	case EscalationWarn, EscalationNotify:
+		return nil
+	default:
+		return fmt.Errorf("action of progress tracker escalation '%s' is unknown: supported actions are '%s' and '%s'",
+			e, EscalationWarn, EscalationNotify)
+	}
+}
+
+//ParseEscalations parses a comma separated list of escalations (e.g. "0.5=warn,0.8=notify")
+func ParseEscalations(value string) ([]Escalation, error) {
+	var result []Escalation
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("progress tracker escalation '%s' has to be defined as 'threshold=action'", item)
+		}
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("threshold of progress tracker escalation '%s' is not a number: %s", item, err)
+		}
+		escalation := Escalation{
+			Threshold: threshold,
+			Action:    EscalationAction(strings.TrimSpace(parts[1])),
+		}
+		if err := escalation.validate(); err != nil {
+			return nil, err
+		}
+		result = append(result, escalation)
+	}
+	return sortEscalations(result), nil
+}
+
+func sortEscalations(escalations []Escalation) []Escalation {
+	if len(escalations) == 0 {
+		return nil
+	}
+	result := append([]Escalation{}, escalations...)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Threshold < result[j].Threshold
+	})
+	return result
+}
+
+//escalationTimer fires once per escalation when its share of the timeout has elapsed
+type escalationTimer struct {
+	escalations []Escalation
+	timeout     time.Duration
+	started     time.Time
+	idx         int
+	timer       *time.Timer
+}
+
+func newEscalationTimer(escalations []Escalation, timeout time.Duration) *escalationTimer {
+	et := &escalationTimer{
+		escalations: escalations,
+		timeout:     timeout,
+		started:     time.Now(),
+	}
+	et.schedule()
+	return et
+}
+
+func (et *escalationTimer) schedule() {
+	if et.idx >= len(et.escalations) {
+		et.timer = nil
+		return
+	}
+	due := time.Duration(float64(et.timeout) * et.escalations[et.idx].Threshold)
+	et.timer = time.NewTimer(time.Until(et.started.Add(due)))
+}
+
+//C returns the channel of the upcoming escalation (nil channel blocks forever if no escalation is left)
+func (et *escalationTimer) C() <-chan time.Time {
+	if et.timer == nil {
+		return nil
+	}
+	return et.timer.C
+}
+
+//next returns the fired escalation and schedules the following one
+func (et *escalationTimer) next() Escalation {
+	escalation := et.escalations[et.idx]
+	et.idx++
+	et.schedule()
+	return escalation
+}
+
+func (et *escalationTimer) stop() {
+	if et.timer != nil {
+		et.timer.Stop()
+	}
+}
+
+func (pt *Tracker) escalate(ctx context.Context, escalation Escalation, targetState State) {
+	pending := pt.pendingResources(ctx, targetState)
+	if len(pending) == 0 {
+		return
+	}
+	names := make([]string, 0, len(pending))
+	for _, rs := range pending {
+		names = append(names, rs.String())
+	}
+	warning := fmt.Sprintf("Resource transition to state '%s' exceeded %.0f%% of the progress timeout (%.0f secs): "+
+		"waiting for %s", targetState, escalation.Threshold*100, pt.timeout.Seconds(), strings.Join(names, ", "))
+
+	switch escalation.Action {
+	case EscalationWarn:
+		pt.logger.Warn(warning)
+		for _, rs := range pending {
+			buf, err := pt.resourceJSON(ctx, rs)
+			if err != nil {
+				pt.logger.Warnf("Failed to get resource %v: %s", rs, err)
+				continue
+			}
+			pt.logger.Infof("Resource %v is not in state '%s' yet: %s", rs, targetState, buf)
+		}
+	case EscalationNotify:
+		pt.logger.Warn(warning)
+		if pt.notifier != nil {
+			pt.notifier(warning)
+		}
+	}
+}
+
+//pendingResources returns the resources which haven't reached the target state yet
+func (pt *Tracker) pendingResources(ctx context.Context, targetState State) []*trackerResource {
+	var result []*trackerResource
+	for _, object := range pt.objects {
+		var done bool
+		var err error
+		switch targetState {
+		case ReadyState:
+			done, err = pt.isReady(ctx, object)
+		case TerminatedState:
+			done, err = pt.isTerminated(ctx, object)
+		}
+		if err != nil || !done {
+			result = append(result, object)
+		}
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+package progress
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseEscalations(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []Escalation
+		wantErr bool
+	}{
+		{
+			name:  "Empty value",
+			value: "",
+			want:  nil,
+		},
+		{
+			name:  "Escalations are sorted",
+			value: "0.8=notify, 0.5=warn",
+			want:  []Escalation{{Threshold: 0.5, Action: EscalationWarn}, {Threshold: 0.8, Action: EscalationNotify}},
+		},
+		{
+			name:    "Action is missing",
+			value:   "0.5",
+			wantErr: true,
+		},
+		{
+			name:    "Threshold is not a number",
+			value:   "half=warn",
+			wantErr: true,
+		},
+		{
+			name:    "Threshold reaches the timeout",
+			value:   "1=warn",
+			wantErr: true,
+		},
+		{
+			name:    "Action is unknown",
+			value:   "0.5=fail",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEscalations(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEscalation(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "kyma-system"},
+		Status:     v1.PodStatus{Phase: v1.PodPending},
+	}
+
+	t.Run("Notifier is called before timeout", func(t *testing.T) {
+		var m sync.Mutex
+		var warnings []string
+		pt, err := NewProgressTracker(fake.NewSimpleClientset(pod), zap.NewNop().Sugar(), Config{
+			Interval: 10 * time.Millisecond,
+			Timeout:  200 * time.Millisecond,
+			Escalations: []Escalation{
+				{Threshold: 0.8, Action: EscalationNotify},
+				{Threshold: 0.5, Action: EscalationWarn},
+			},
+			Notifier: func(warning string) {
+				m.Lock()
+				defer m.Unlock()
+				warnings = append(warnings, warning)
+			},
+		})
+		require.NoError(t, err)
+		pt.AddResource(Pod, pod.Namespace, pod.Name)
+
+		require.Error(t, pt.Watch(context.Background(), ReadyState))
+		m.Lock()
+		defer m.Unlock()
+		require.Len(t, warnings, 1)
+		require.Contains(t, warnings[0], "exceeded 80% of the progress timeout")
+		require.Contains(t, warnings[0], "Pod [namespace:kyma-system|name:foo]")
+	})
+
+	t.Run("Invalid escalation is rejected", func(t *testing.T) {
+		_, err := NewProgressTracker(fake.NewSimpleClientset(), zap.NewNop().Sugar(), Config{
+			Escalations: []Escalation{{Threshold: 0.5, Action: "fail"}},
+		})
+		require.Error(t, err)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/cli-runtime/pkg/resource"
 
 	e "github.com/kyma-incubator/reconciler/pkg/error"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/throttle"
 	"go.uber.org/zap"
 	"k8s.io/client-go/kubernetes"
 )
@@ -39,6 +40,10 @@ func (o *trackerResource) String() string {
 type Config struct {
 	Interval time.Duration
 	Timeout  time.Duration
+	//Escalations are applied before the timeout is reached (the timeout fails the transition)
+	Escalations []Escalation
+	//Notifier receives the warnings of escalations with the notify action (optional)
+	Notifier func(warning string)
 }
 
 func (ptc *Config) validate() error {
@@ -58,15 +63,22 @@ func (ptc *Config) validate() error {
 		return fmt.Errorf("progress tracker will never run because configured timeout "+
 			"is <= as the check interval :%.0f secs <= %.0f secs", ptc.Timeout.Seconds(), ptc.Interval.Seconds())
 	}
+	for _, escalation := range ptc.Escalations {
+		if err := escalation.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 type Tracker struct {
-	objects  []*trackerResource
-	client   kubernetes.Interface
-	interval time.Duration
-	timeout  time.Duration
-	logger   *zap.SugaredLogger
+	objects     []*trackerResource
+	client      kubernetes.Interface
+	interval    time.Duration
+	timeout     time.Duration
+	escalations []Escalation
+	notifier    func(warning string)
+	logger      *zap.SugaredLogger
 }
 
 func NewProgressTracker(client kubernetes.Interface, logger *zap.SugaredLogger, config Config) (*Tracker, error) {
@@ -75,10 +87,12 @@ func NewProgressTracker(client kubernetes.Interface, logger *zap.SugaredLogger,
 	}
 
 	return &Tracker{
-		client:   client,
-		interval: config.Interval,
-		timeout:  config.Timeout,
-		logger:   logger,
+		client:      client,
+		interval:    config.Interval,
+		timeout:     config.Timeout,
+		escalations: sortEscalations(config.Escalations),
+		notifier:    config.Notifier,
+		logger:      logger,
 	}, nil
 }
 
@@ -103,10 +117,27 @@ func (pt *Tracker) Watch(ctx context.Context, targetState State) error {
 	//start verifying the installation status in an interval
 	timer := time.NewTicker(pt.interval)
 	timeout := time.After(pt.timeout)
+	escalations := newEscalationTimer(pt.escalations, pt.timeout)
+	defer escalations.stop()
+	backoff := &throttle.Backoff{Initial: 2 * pt.interval}
 	for {
 		select {
+		case <-escalations.C():
+			pt.escalate(ctx, escalations.next(), targetState)
 		case <-timer.C:
 			inState, err := pt.allWatchableInState(ctx, targetState)
+			if throttle.IsThrottled(err) {
+				//stretch the check interval while the API server is throttling requests
+				delay := backoff.Next(err)
+				pt.logger.Warnf("API server is throttling progress checks of resource transition to state '%s': "+
+					"next check in %.0f secs", targetState, delay.Seconds())
+				timer.Reset(delay)
+				continue
+			}
+			if backoff.Throttled() {
+				backoff.Reset()
+				timer.Reset(pt.interval)
+			}
 			if err != nil {
 				pt.logger.Warnf("Failed to check progress of resource transition to state '%s' "+
 					"but will retry until timeout is reached: %s", targetState, err)
@@ -125,9 +156,11 @@ func (pt *Tracker) Watch(ctx context.Context, targetState State) error {
 					"transition is treated as failed", targetState),
 			}
 		case <-timeout:
-			err := fmt.Errorf("progress tracker reached timeout (%.0f secs): "+
-				"stop checking progress of resource transition to state '%s'",
-				pt.timeout.Seconds(), targetState)
+			err := &e.TimeoutError{
+				Message: fmt.Sprintf("progress tracker reached timeout (%.0f secs): "+
+					"stop checking progress of resource transition to state '%s'",
+					pt.timeout.Seconds(), targetState),
+			}
 			pt.logger.Warn(err.Error())
 			pt.dumpWatchableResourcesAsInfo(ctx)
 			return err
@@ -165,30 +198,7 @@ func (pt *Tracker) allWatchableInState(ctx context.Context, targetState State) (
 
 func (pt *Tracker) isInReadyState(ctx context.Context) (bool, error) {
 	for _, object := range pt.objects {
-		var err error
-		ready := true
-
-		switch object.kind {
-		case Pod:
-			ready, err = isPodReady(ctx, pt.client, object)
-		case Deployment:
-			ready, err = isDeploymentReady(ctx, pt.client, object)
-		case DaemonSet:
-			ready, err = isDaemonSetReady(ctx, pt.client, object)
-		case StatefulSet:
-			ready, err = isStatefulSetReady(ctx, pt.client, object)
-		case Job:
-			ready, err = isJobReady(ctx, pt.client, object)
-		case CustomResourceDefinition:
-			if object.info == nil {
-				return false, fmt.Errorf("please use AddResourceWithInfo instead of AddResource for progress tracking CRD resources")
-			}
-			ready, err = isCRDReady(ctx, object)
-			if err != nil {
-				ready, err = isCRDBetaReady(ctx, object)
-			}
-		}
-
+		ready, err := pt.isReady(ctx, object)
 		if err != nil {
 			pt.logger.Errorf("Failed to get resource of %v: %s", object, err)
 			return false, err
@@ -204,43 +214,77 @@ func (pt *Tracker) isInReadyState(ctx context.Context) (bool, error) {
 
 }
 
+func (pt *Tracker) isReady(ctx context.Context, object *trackerResource) (bool, error) {
+	switch object.kind {
+	case Pod:
+		return isPodReady(ctx, pt.client, object)
+	case Deployment:
+		return isDeploymentReady(ctx, pt.client, object)
+	case DaemonSet:
+		return isDaemonSetReady(ctx, pt.client, object)
+	case StatefulSet:
+		return isStatefulSetReady(ctx, pt.client, object)
+	case Job:
+		return isJobReady(ctx, pt.client, object)
+	case CustomResourceDefinition:
+		if object.info == nil {
+			return false, fmt.Errorf("please use AddResourceWithInfo instead of AddResource for progress tracking CRD resources")
+		}
+		ready, err := isCRDReady(ctx, object)
+		if err != nil {
+			ready, err = isCRDBetaReady(ctx, object)
+		}
+		return ready, err
+	}
+	return true, nil
+}
+
 func (pt *Tracker) isInTerminatedState(ctx context.Context) (bool, error) {
 	for _, object := range pt.objects {
-		var err error
-
-		switch object.kind {
-		case Pod:
-			_, err = pt.client.CoreV1().Pods(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
-		case Deployment:
-			_, err = pt.client.AppsV1().Deployments(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
-		case DaemonSet:
-			_, err = pt.client.AppsV1().DaemonSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
-		case StatefulSet:
-			_, err = pt.client.AppsV1().StatefulSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
-		case Job:
-			_, err = pt.client.BatchV1().Jobs(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
-		case CustomResourceDefinition:
-			if object.info == nil {
-				err = fmt.Errorf("please use AddResourceWithInfo instead of AddResource for progress tracking CRD resources")
-			} else {
-				err = object.info.Get()
-			}
+		terminated, err := pt.isTerminated(ctx, object)
+		if err != nil {
+			pt.logger.Errorf("Failed to get resource %v: %s", object, err)
+			return false, err
 		}
-
-		if err == nil {
+		if !terminated {
 			pt.logger.Debugf("Termination of %s is still ongoing", object.name)
 			return false, nil
 		}
-		if !errors.IsNotFound(err) {
-			pt.logger.Errorf("Failed to get resource %v: %s", object, err)
-			return false, err
-		}
 	}
 
 	pt.logger.Debug("All resources are terminated")
 	return true, nil
 }
 
+func (pt *Tracker) isTerminated(ctx context.Context, object *trackerResource) (bool, error) {
+	var err error
+	switch object.kind {
+	case Pod:
+		_, err = pt.client.CoreV1().Pods(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case Deployment:
+		_, err = pt.client.AppsV1().Deployments(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case DaemonSet:
+		_, err = pt.client.AppsV1().DaemonSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case StatefulSet:
+		_, err = pt.client.AppsV1().StatefulSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case Job:
+		_, err = pt.client.BatchV1().Jobs(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case CustomResourceDefinition:
+		if object.info == nil {
+			err = fmt.Errorf("please use AddResourceWithInfo instead of AddResource for progress tracking CRD resources")
+		} else {
+			err = object.info.Get()
+		}
+	}
+	if err == nil {
+		return false, nil
+	}
+	if errors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
 func (pt Tracker) dumpWatchableResourcesAsInfo(ctx context.Context) {
 	for _, rs := range pt.objects {
 		buf, err := pt.resourceJSON(ctx, rs)
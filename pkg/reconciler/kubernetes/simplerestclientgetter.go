@@ -10,7 +10,8 @@ import (
 )
 
 type SimpleRESTClientGetter struct {
-	config *rest.Config
+	config          *rest.Config
+	discoveryClient discovery.CachedDiscoveryInterface
 }
 
 func NewRESTClientGetter(config *rest.Config) *SimpleRESTClientGetter {
@@ -19,11 +20,23 @@ func NewRESTClientGetter(config *rest.Config) *SimpleRESTClientGetter {
 	}
 }
 
+//NewCachedRESTClientGetter re-uses the given discovery client instead of discovering the cluster again
+func NewCachedRESTClientGetter(config *rest.Config, discoveryClient discovery.CachedDiscoveryInterface) *SimpleRESTClientGetter {
+	return &SimpleRESTClientGetter{
+		config:          config,
+		discoveryClient: discoveryClient,
+	}
+}
+
 func (c *SimpleRESTClientGetter) ToRESTConfig() (*rest.Config, error) {
 	return c.config, nil
 }
 
 func (c *SimpleRESTClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	if c.discoveryClient != nil {
+		return c.discoveryClient, nil
+	}
+
 	config, err := c.ToRESTConfig()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,59 @@
+package throttle
+
+import (
+	"time"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	defaultInitialDelay = 5 * time.Second
+	defaultMaxDelay     = 2 * time.Minute
+)
+
+//IsThrottled returns true if the API server of the target cluster rejected the request with HTTP 429 (too many requests)
+func IsThrottled(err error) bool {
+	return err != nil && k8serr.IsTooManyRequests(err)
+}
+
+//Backoff calculates the delay to wait after the API server of a cluster throttled a request.
+//A Retry-After value sent by the API server is respected, otherwise the delay is doubled with each throttled request.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	current time.Duration
+}
+
+func (b *Backoff) Next(err error) time.Duration {
+	if b.Initial <= 0 {
+		b.Initial = defaultInitialDelay
+	}
+	if b.Max <= 0 {
+		b.Max = defaultMaxDelay
+	}
+
+	if b.current == 0 {
+		b.current = b.Initial
+	} else {
+		b.current *= 2
+	}
+	if seconds, ok := k8serr.SuggestsClientDelay(err); ok && seconds > 0 {
+		if retryAfter := time.Duration(seconds) * time.Second; retryAfter > b.current {
+			b.current = retryAfter
+		}
+	}
+	if b.current > b.Max {
+		b.current = b.Max
+	}
+	return b.current
+}
+
+//Reset has to be called as soon as the API server is no longer throttling requests
+func (b *Backoff) Reset() {
+	b.current = 0
+}
+
+//Throttled returns true if the backoff is currently active
+func (b *Backoff) Throttled() bool {
+	return b.current > 0
+}
@@ -0,0 +1,38 @@
+package throttle
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestThrottle(t *testing.T) {
+	t.Run("Detect throttling", func(t *testing.T) {
+		require.False(t, IsThrottled(nil))
+		require.False(t, IsThrottled(fmt.Errorf("any error")))
+		require.True(t, IsThrottled(k8serr.NewTooManyRequests("slow down", 0)))
+		require.True(t, IsThrottled(k8serr.NewTooManyRequestsError("slow down")))
+	})
+
+	t.Run("Backoff is doubled and capped", func(t *testing.T) {
+		backoff := &Backoff{Initial: 1 * time.Second, Max: 3 * time.Second}
+		err := k8serr.NewTooManyRequestsError("slow down")
+		require.False(t, backoff.Throttled())
+		require.Equal(t, 1*time.Second, backoff.Next(err))
+		require.Equal(t, 2*time.Second, backoff.Next(err))
+		require.Equal(t, 3*time.Second, backoff.Next(err))
+		require.True(t, backoff.Throttled())
+		backoff.Reset()
+		require.False(t, backoff.Throttled())
+		require.Equal(t, 1*time.Second, backoff.Next(err))
+	})
+
+	t.Run("Backoff respects Retry-After", func(t *testing.T) {
+		backoff := &Backoff{Initial: 1 * time.Second, Max: 1 * time.Minute}
+		require.Equal(t, 10*time.Second, backoff.Next(k8serr.NewTooManyRequests("slow down", 10)))
+		require.Equal(t, 20*time.Second, backoff.Next(k8serr.NewTooManyRequests("slow down", 5)))
+	})
+}
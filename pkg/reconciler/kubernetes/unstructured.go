@@ -13,76 +13,62 @@ import (
 )
 
 // ToUnstructured Unmarshalls given manifest in YAML format into k8s.io Unstructured data type.
+//The async flag is kept for compatibility: the documents of the manifest are always decoded as stream.
 func ToUnstructured(manifest []byte, async bool) ([]*unstructured.Unstructured, error) {
-	var result []*unstructured.Unstructured
-	var err error
+	return decodeAll(NewManifestDecoder(bytes.NewReader(manifest)))
+}
 
-	chanMes, chanErr := readYaml(manifest, async)
-Loop:
-	for {
-		select {
-		case yamlData, ok := <-chanMes:
-			if !ok {
-				//channel closed
-				break Loop
-			}
+//ManifestDecoder decodes a multi-document YAML manifest document by document: only the currently
+//decoded document is kept in memory and not the whole manifest.
+type ManifestDecoder struct {
+	reader *utilyaml.YAMLReader
+}
 
-			//convert YAML to JSON
-			jsonData, err := yamlToJson.YAMLToJSON(yamlData)
-			if err != nil {
-				break Loop
-			}
+func NewManifestDecoder(reader io.Reader) *ManifestDecoder {
+	return &ManifestDecoder{
+		reader: utilyaml.NewYAMLReader(bufio.NewReader(reader)),
+	}
+}
 
-			if string(jsonData) == "null" {
-				//YAML didn't contain any valuable JSON data (e.g. just comments)
-				continue
+//Next returns the next Kubernetes resource of the manifest or io.EOF if all documents were decoded.
+//Documents without data (e.g. just comments) are skipped.
+func (d *ManifestDecoder) Next() (*unstructured.Unstructured, error) {
+	for {
+		yamlData, err := d.reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				return nil, err
 			}
+			return nil, errors.Wrap(err, "failed to read yaml data")
+		}
 
-			//get unstructured entity from JSON and intercept
-			unstruct, err := newUnstructured(jsonData)
-			if err != nil {
-				break Loop
-			}
-			result = append(result, unstruct)
+		//convert YAML to JSON
+		jsonData, err := yamlToJson.YAMLToJSON(yamlData)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert yaml data to json")
+		}
 
-		case err = <-chanErr:
-			break Loop
+		if string(jsonData) == "null" {
+			//YAML didn't contain any valuable JSON data (e.g. just comments)
+			continue
 		}
+
+		return newUnstructured(jsonData)
 	}
-	return result, err
 }
 
-func readYaml(data []byte, async bool) (<-chan []byte, <-chan error) {
-	var (
-		chanErr        = make(chan error)
-		chanBytes      = make(chan []byte)
-		multidocReader = utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
-	)
-
-	readFct := func() {
-		defer close(chanErr)
-		defer close(chanBytes)
-
-		for {
-			buf, err := multidocReader.Read()
-			if err != nil {
-				if err == io.EOF {
-					return
-				}
-				chanErr <- errors.Wrap(err, "failed to read yaml data")
-				return
-			}
-			chanBytes <- buf
+func decodeAll(decoder *ManifestDecoder) ([]*unstructured.Unstructured, error) {
+	var result []*unstructured.Unstructured
+	for {
+		unstruct, err := decoder.Next()
+		if err == io.EOF {
+			return result, nil
 		}
+		if err != nil {
+			return result, err
+		}
+		result = append(result, unstruct)
 	}
-
-	if async {
-		go readFct()
-	} else {
-		readFct()
-	}
-
-	return chanBytes, chanErr
 }
 
 //newUnstructured converts a map[string]interface{} to a kubernetes unstructured.Unstructured
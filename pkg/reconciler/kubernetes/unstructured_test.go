@@ -0,0 +1,59 @@
+package kubernetes
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const multiDocManifest = `
+# comment only document
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: kyma-system
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: kyma-system
+data:
+  key: value
+---
+`
+
+func TestManifestDecoder(t *testing.T) {
+
+	t.Run("Documents are decoded one by one", func(t *testing.T) {
+		decoder := NewManifestDecoder(strings.NewReader(multiDocManifest))
+
+		unstruct, err := decoder.Next()
+		require.NoError(t, err)
+		require.Equal(t, "Namespace", unstruct.GetKind())
+		require.Equal(t, "kyma-system", unstruct.GetName())
+
+		unstruct, err = decoder.Next()
+		require.NoError(t, err)
+		require.Equal(t, "ConfigMap", unstruct.GetKind())
+		require.Equal(t, "config", unstruct.GetName())
+
+		_, err = decoder.Next()
+		require.Equal(t, io.EOF, err)
+	})
+
+	t.Run("All documents are converted to unstructs", func(t *testing.T) {
+		unstructs, err := ToUnstructured([]byte(multiDocManifest), true)
+		require.NoError(t, err)
+		require.Len(t, unstructs, 2)
+	})
+
+	t.Run("Invalid document fails", func(t *testing.T) {
+		unstructs, err := ToUnstructured([]byte(multiDocManifest+"metadata:\n  name: no-kind\n"), false)
+		require.Error(t, err)
+		require.Len(t, unstructs, 2)
+	})
+}
@@ -0,0 +1,96 @@
+package kubernetes
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+//APICall is a request which was sent to the API server of the target cluster
+type APICall struct {
+	Method     string
+	Path       string
+	StatusCode int //0 if no response was received
+	Started    time.Time
+	Duration   time.Duration
+}
+
+//Usage collects the resources a Kubernetes client consumed on the target cluster
+type Usage struct {
+	apiCalls      int64
+	manifestBytes int64
+	m             sync.Mutex
+	recorder      func(call APICall) //receives each API call if defined
+}
+
+//RecordAPICalls passes each following API call to the recorder (nil stops the recording)
+func (u *Usage) RecordAPICalls(recorder func(call APICall)) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.recorder = recorder
+}
+
+func (u *Usage) apiCallRecorder() func(call APICall) {
+	u.m.Lock()
+	defer u.m.Unlock()
+	return u.recorder
+}
+
+//APICalls returns the number of requests sent to the API server
+func (u *Usage) APICalls() int64 {
+	return atomic.LoadInt64(&u.apiCalls)
+}
+
+//ManifestBytes returns the size of the deployed manifests
+func (u *Usage) ManifestBytes() int64 {
+	return atomic.LoadInt64(&u.manifestBytes)
+}
+
+func (u *Usage) addManifest(manifest string) {
+	if u == nil {
+		return
+	}
+	atomic.AddInt64(&u.manifestBytes, int64(len(manifest)))
+}
+
+//track returns a copy of the REST config whose requests are counted as API calls
+func (u *Usage) track(restConfig *rest.Config) *rest.Config {
+	if u == nil {
+		return restConfig
+	}
+	trackedConfig := rest.CopyConfig(restConfig)
+	trackedConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &usageRoundTripper{delegate: rt, usage: u}
+	})
+	return trackedConfig
+}
+
+type usageRoundTripper struct {
+	delegate http.RoundTripper
+	usage    *Usage
+}
+
+func (rt *usageRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt64(&rt.usage.apiCalls, 1)
+	recorder := rt.usage.apiCallRecorder()
+	if recorder == nil {
+		return rt.delegate.RoundTrip(req)
+	}
+
+	started := time.Now()
+	resp, err := rt.delegate.RoundTrip(req)
+	call := APICall{
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Started:  started,
+		Duration: time.Since(started),
+	}
+	if resp != nil {
+		call.StatusCode = resp.StatusCode
+	}
+	recorder(call)
+	return resp, err
+}
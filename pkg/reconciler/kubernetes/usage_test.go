@@ -0,0 +1,84 @@
+package kubernetes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Run("API calls are counted", func(t *testing.T) {
+		usage := &Usage{}
+		restConfig := &rest.Config{Host: server.URL}
+		trackedConfig := usage.track(restConfig)
+		require.NotSame(t, restConfig, trackedConfig)
+
+		transport, err := rest.TransportFor(trackedConfig)
+		require.NoError(t, err)
+		client := &http.Client{Transport: transport}
+		for i := 0; i < 3; i++ {
+			resp, err := client.Get(server.URL)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+		}
+		require.Equal(t, int64(3), usage.APICalls())
+
+		//requests of the original config are not counted
+		transport, err = rest.TransportFor(restConfig)
+		require.NoError(t, err)
+		client = &http.Client{Transport: transport}
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, int64(3), usage.APICalls())
+	})
+
+	t.Run("API calls are recorded", func(t *testing.T) {
+		usage := &Usage{}
+		transport, err := rest.TransportFor(usage.track(&rest.Config{Host: server.URL}))
+		require.NoError(t, err)
+		client := &http.Client{Transport: transport}
+
+		var calls []APICall
+		usage.RecordAPICalls(func(call APICall) {
+			calls = append(calls, call)
+		})
+		resp, err := client.Get(server.URL + "/api/v1/namespaces")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Len(t, calls, 1)
+		require.Equal(t, http.MethodGet, calls[0].Method)
+		require.Equal(t, "/api/v1/namespaces", calls[0].Path)
+		require.Equal(t, http.StatusOK, calls[0].StatusCode)
+
+		//stopped recording doesn't stop counting
+		usage.RecordAPICalls(nil)
+		resp, err = client.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Len(t, calls, 1)
+		require.Equal(t, int64(2), usage.APICalls())
+	})
+
+	t.Run("Deployed manifests are summed up", func(t *testing.T) {
+		usage := &Usage{}
+		usage.addManifest("abc")
+		usage.addManifest("defg")
+		require.Equal(t, int64(7), usage.ManifestBytes())
+	})
+
+	t.Run("Usage is optional", func(t *testing.T) {
+		var usage *Usage
+		restConfig := &rest.Config{Host: server.URL}
+		require.Same(t, restConfig, usage.track(restConfig))
+		usage.addManifest("abc")
+	})
+}
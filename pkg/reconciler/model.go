@@ -3,7 +3,9 @@ package reconciler
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/features"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 )
@@ -23,8 +25,14 @@ func NewStatus(status string) (Status, error) {
 		return StatusError, nil
 	case string(StatusRunning):
 		return StatusRunning, nil
+	case string(StatusSkipped):
+		return StatusSkipped, nil
 	case string(StatusSuccess):
 		return StatusSuccess, nil
+	case string(StatusWaiting):
+		return StatusWaiting, nil
+	case string(StatusPendingConfirmation):
+		return StatusPendingConfirmation, nil
 	default:
 		return "", fmt.Errorf("status '%s' not found", status)
 	}
@@ -33,6 +41,10 @@ func NewStatus(status string) (Status, error) {
 type ComponentConfiguration struct {
 	MaxRetries int  `json:"maxRetries"`
 	Debug      bool `json:"debug"`
+	//Features overrides feature flags of the component reconciler for this task (e.g. defined by the cluster cohort)
+	Features features.Overrides `json:"features,omitempty"`
+	//TimeoutSecs overrides the execution timeout of the component reconciler for this task (0 if not overridden)
+	TimeoutSecs int64 `json:"timeoutSecs,omitempty"`
 }
 
 //Task the reconciler has to complete when called
@@ -51,6 +63,10 @@ type Task struct {
 	Repository             *Repository            `json:"repository"`
 	Type                   model.OperationType    `json:"type"` // Supported task types are: reconcile, delete
 	ComponentConfiguration ComponentConfiguration `json:"componentConfiguration"`
+	DeletionConfirmed      bool                   `json:"deletionConfirmed,omitempty"` //DeletionConfirmed is set if an operator confirmed the removal of stateful resources
+	KubeconfigRef          *KubeconfigRef         `json:"kubeconfigRef,omitempty"`     //KubeconfigRef replaces the Kubeconfig if kubeconfigs are delivered by reference
+	Target                 string                 `json:"target,omitempty"`            //Target is the named kubeconfig of the cluster the Kubeconfig belongs to (empty for the main cluster)
+	CallbackSequence       int64                  `json:"callbackSequence,omitempty"`  //CallbackSequence is the first sequence of this dispatch: callbacks are numbered upwards from it
 
 	//These fields are not part of HTTP request coming from reconciler-controller:
 	CallbackFunc func(msg *CallbackMessage) error `json:"-"` //CallbackFunc is mandatory when component-reconciler runs embedded in another process
@@ -73,8 +89,8 @@ func (r *Task) Validate() error {
 		errFields = append(errFields, "Namespace")
 	}
 	r.Kubeconfig = strings.TrimSpace(r.Kubeconfig)
-	if r.Kubeconfig == "" {
-		errFields = append(errFields, "Kubeconfig")
+	if r.Kubeconfig == "" && (r.KubeconfigRef == nil || r.KubeconfigRef.URL == "" || r.KubeconfigRef.Token == "") {
+		errFields = append(errFields, "Kubeconfig or KubeconfigRef")
 	}
 	r.CallbackURL = strings.TrimSpace(r.CallbackURL)
 	if r.CallbackFunc == nil && r.CallbackURL == "" {
@@ -95,6 +111,14 @@ func (r *Task) Validate() error {
 	return err
 }
 
+//KubeconfigRef is a short-lived reference to the kubeconfig of the cluster: the component reconciler fetches the
+//kubeconfig with the token from the mothership before it starts the operation
+type KubeconfigRef struct {
+	URL     string    `json:"url"`
+	Token   string    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
 type Repository struct {
 	URL string `json:"url"`
 }
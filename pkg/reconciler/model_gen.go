@@ -3,6 +3,10 @@
 // Code generated by github.com/deepmap/oapi-codegen version v1.8.2 DO NOT EDIT.
 package reconciler
 
+import (
+	"time"
+)
+
 // Defines values for Status.
 const (
 	StatusError Status = "error"
@@ -11,25 +15,201 @@ const (
 
 	StatusNotstarted Status = "notstarted"
 
+	StatusPendingConfirmation Status = "pending_confirmation"
+
 	StatusRunning Status = "running"
 
+	StatusSkipped Status = "skipped"
+
 	StatusSuccess Status = "success"
+
+	StatusWaiting Status = "waiting"
 )
 
+// BatchedCallbackMessage defines model for batchedCallbackMessage.
+type BatchedCallbackMessage struct {
+	CorrelationID string          `json:"correlationID"`
+	Message       CallbackMessage `json:"message"`
+	SchedulingID  string          `json:"schedulingID"`
+}
+
+// CallbackBatch defines model for callbackBatch.
+type CallbackBatch struct {
+	Callbacks []BatchedCallbackMessage `json:"callbacks"`
+}
+
+// CallbackBatchResponse defines model for callbackBatchResponse.
+type CallbackBatchResponse struct {
+
+	// Results of the callbacks (same order as the callbacks of the batch)
+	Results []CallbackResult `json:"results"`
+}
+
 // CallbackMessage defines model for callbackMessage.
 type CallbackMessage struct {
-	Error              string  `json:"error"`
-	Manifest           *string `json:"manifest,omitempty"`
-	ProcessingDuration int     `json:"processingDuration"`
-	RetryID            string  `json:"retryID"`
-	Status             Status  `json:"status"`
+	Error string `json:"error"`
+
+	// Container images running for the workloads of the component (only reported by successful reconciliations)
+	Images *[]ContainerImage `json:"images,omitempty"`
+
+	// Latest log entries of the operation (only reported with the final status)
+	Logs     *OperationLogs `json:"logs,omitempty"`
+	Manifest *string        `json:"manifest,omitempty"`
+
+	// Stateful resources (e.g. PVCs or namespaces) which a deletion would remove: the deletion has to be confirmed before it proceeds (only reported with the pending_confirmation status)
+	PendingDeletion    *[]string `json:"pendingDeletion,omitempty"`
+	ProcessingDuration int       `json:"processingDuration"`
+
+	// Build (git commit) of the component reconciler which processed the operation
+	ReconcilerVersion *string `json:"reconcilerVersion,omitempty"`
+	RetryID           string  `json:"retryID"`
+
+	// Monotonically increasing number of the callback (counted upwards from the callback sequence of the task): the mothership ignores callbacks with an outdated sequence
+	Sequence *int64 `json:"sequence,omitempty"`
+	Status   Status `json:"status"`
+
+	// Set if the operation failed because of a timeout (only reported with the failed and error status)
+	TimedOut *bool `json:"timedOut,omitempty"`
+
+	// Detailed capture of an operation which exceeded the latency threshold (only reported with the final status)
+	Trace *OperationTrace `json:"trace,omitempty"`
+	Usage *OperationUsage `json:"usage,omitempty"`
+
+	// Early signal of the running attempt, e.g. resources which are trending towards the progress timeout (only reported with the running status)
+	Warning *string `json:"warning,omitempty"`
+}
+
+// CallbackResult defines model for callbackResult.
+type CallbackResult struct {
+	CorrelationID string  `json:"correlationID"`
+	Error         *string `json:"error,omitempty"`
+	SchedulingID  string  `json:"schedulingID"`
+
+	// HTTP status code the callback would have received as single request
+	StatusCode int `json:"statusCode"`
+}
+
+// ContainerImage defines model for containerImage.
+type ContainerImage struct {
+	Container string `json:"container"`
+
+	// Digest of the image which is running (undefined if no pod of the workload is running)
+	Digest *string `json:"digest,omitempty"`
+	Image  string  `json:"image"`
+
+	// Kind of the workload (e.g. Deployment)
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// KubeconfigResponse defines model for kubeconfigResponse.
+type KubeconfigResponse struct {
+	Kubeconfig string `json:"kubeconfig"`
+}
+
+// OperationLogEntry defines model for operationLogEntry.
+type OperationLogEntry struct {
+	// Structured context of the log entry
+	Fields  *map[string]string `json:"fields,omitempty"`
+	Level   string             `json:"level"`
+	Message string             `json:"message"`
+	Time    time.Time          `json:"time"`
+}
+
+// Latest log entries of the operation (only reported with the final status)
+type OperationLogs struct {
+	Entries []OperationLogEntry `json:"entries"`
+
+	// Older entries were dropped because the logs exceeded their size limit
+	Truncated bool `json:"truncated"`
+}
+
+// Detailed capture of an operation which exceeded the latency threshold (only reported with the final status)
+type OperationTrace struct {
+	ApiCalls []TraceAPICall `json:"apiCalls"`
+	Logs     []TraceLog     `json:"logs"`
+	Phases   []TracePhase   `json:"phases"`
+
+	// Goroutine stacks sampled while the operation was suspected to be stuck
+	Stacks *[]TraceStack `json:"stacks,omitempty"`
+
+	// Latency (in milliseconds) after which the operation was switched to detailed capture
+	Threshold int64 `json:"threshold"`
+
+	// API calls, logs or stacks were dropped because the trace exceeded its size limit
+	Truncated bool `json:"truncated"`
+}
+
+// Resources the component reconciler consumed on the target cluster while processing the operation
+type OperationUsage struct {
+	// Number of requests sent to the API server of the target cluster
+	ApiCalls int64 `json:"apiCalls"`
+
+	// Size of the deployed manifests
+	ManifestBytes int64 `json:"manifestBytes"`
 }
 
 // Status defines model for status.
 type Status string
 
+// TraceAPICall defines model for traceAPICall.
+type TraceAPICall struct {
+	// Duration in milliseconds
+	Duration int64     `json:"duration"`
+	Method   string    `json:"method"`
+	Path     string    `json:"path"`
+	Started  time.Time `json:"started"`
+
+	// HTTP status code of the response (0 if no response was received)
+	StatusCode int `json:"statusCode"`
+}
+
+// TraceLog defines model for traceLog.
+type TraceLog struct {
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// TracePhase defines model for tracePhase.
+type TracePhase struct {
+	// Duration in milliseconds
+	Duration int64     `json:"duration"`
+	Error    *string   `json:"error,omitempty"`
+	Name     string    `json:"name"`
+	Started  time.Time `json:"started"`
+}
+
+// TraceStack defines model for traceStack.
+type TraceStack struct {
+	// Number of goroutines of the operation
+	Goroutines int `json:"goroutines"`
+
+	// Runtime (in milliseconds) of the operation when the stack was sampled
+	Running int64     `json:"running"`
+	Sampled time.Time `json:"sampled"`
+	Stack   string    `json:"stack"`
+}
+
+// PostOccupancyPoolIDJSONBody defines parameters for PostOccupancyPoolID.
+type PostOccupancyPoolIDJSONBody struct {
+	Component      string `json:"component"`
+	PoolSize       int    `json:"poolSize"`
+	RunningWorkers int    `json:"runningWorkers"`
+}
+
+// PostOperationsCallbacksJSONBody defines parameters for PostOperationsCallbacks.
+type PostOperationsCallbacksJSONBody CallbackBatch
+
 // PostOperationsSchedulingIDCallbackCorrelationIDJSONBody defines parameters for PostOperationsSchedulingIDCallbackCorrelationID.
 type PostOperationsSchedulingIDCallbackCorrelationIDJSONBody CallbackMessage
 
+// PostOccupancyPoolIDJSONRequestBody defines body for PostOccupancyPoolID for application/json ContentType.
+type PostOccupancyPoolIDJSONRequestBody PostOccupancyPoolIDJSONBody
+
+// PostOperationsCallbacksJSONRequestBody defines body for PostOperationsCallbacks for application/json ContentType.
+type PostOperationsCallbacksJSONRequestBody PostOperationsCallbacksJSONBody
+
 // PostOperationsSchedulingIDCallbackCorrelationIDJSONRequestBody defines body for PostOperationsSchedulingIDCallbackCorrelationID for application/json ContentType.
 type PostOperationsSchedulingIDCallbackCorrelationIDJSONRequestBody PostOperationsSchedulingIDCallbackCorrelationIDJSONBody
@@ -0,0 +1,32 @@
+package reconciler
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/pkg/errors"
+)
+
+//PanicError is a panic which was recovered while an operation was processed: it includes the stack trace
+//of the panicking goroutine
+type PanicError struct {
+	Value interface{}
+	Stack string
+}
+
+//NewPanicError has to be called within the deferred function which recovered the panic to capture the correct stack
+func NewPanicError(value interface{}) *PanicError {
+	return &PanicError{
+		Value: value,
+		Stack: string(debug.Stack()),
+	}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("operation panicked: %v\n%s", e.Value, e.Stack)
+}
+
+func IsPanicError(err error) bool {
+	var panicErr *PanicError
+	return errors.As(err, &panicErr)
+}
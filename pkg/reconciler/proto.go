@@ -0,0 +1,127 @@
+package reconciler
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/taskpb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ContentTypeJSON = "application/json"
+	//ContentTypeProtobuf is the content type of tasks encoded as protobuf message (see taskpb/task.proto)
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+//MarshalProto encodes the task as protobuf message. Configuration values and the metadata are embedded as JSON
+//because their structure isn't fixed.
+func (r *Task) MarshalProto() ([]byte, error) {
+	msg := &taskpb.Task{
+		ComponentsReady:   r.ComponentsReady,
+		Component:         r.Component,
+		Namespace:         r.Namespace,
+		Version:           r.Version,
+		Url:               r.URL,
+		Profile:           r.Profile,
+		Kubeconfig:        r.Kubeconfig,
+		CallbackURL:       r.CallbackURL,
+		CorrelationID:     r.CorrelationID,
+		Type:              string(r.Type),
+		DeletionConfirmed: r.DeletionConfirmed,
+		Target:            r.Target,
+	}
+	if len(r.Configuration) > 0 {
+		msg.Configuration = make(map[string][]byte, len(r.Configuration))
+		for key, value := range r.Configuration {
+			jsonValue, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode configuration value of key '%s': %s", key, err)
+			}
+			msg.Configuration[key] = jsonValue
+		}
+	}
+	metadata, err := json.Marshal(r.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %s", err)
+	}
+	msg.Metadata = metadata
+	if r.Repository != nil {
+		msg.Repository = &taskpb.Repository{Url: r.Repository.URL}
+	}
+	compConfig := r.ComponentConfiguration
+	if compConfig.MaxRetries != 0 || compConfig.Debug || len(compConfig.Features) > 0 || compConfig.TimeoutSecs != 0 {
+		msg.ComponentConfiguration = &taskpb.ComponentConfiguration{
+			MaxRetries:  int64(compConfig.MaxRetries),
+			Debug:       compConfig.Debug,
+			Features:    compConfig.Features,
+			TimeoutSecs: compConfig.TimeoutSecs,
+		}
+	}
+	if r.KubeconfigRef != nil {
+		msg.KubeconfigRef = &taskpb.KubeconfigRef{
+			Url:   r.KubeconfigRef.URL,
+			Token: r.KubeconfigRef.Token,
+		}
+		if !r.KubeconfigRef.Expires.IsZero() {
+			msg.KubeconfigRef.Expires = r.KubeconfigRef.Expires.Unix()
+		}
+	}
+	return proto.Marshal(msg)
+}
+
+//UnmarshalProto decodes a task which was encoded with MarshalProto. Unknown fields are skipped.
+func (r *Task) UnmarshalProto(b []byte) error {
+	msg := &taskpb.Task{}
+	if err := proto.Unmarshal(b, msg); err != nil {
+		return fmt.Errorf("failed to decode protobuf message: %s", err)
+	}
+
+	r.ComponentsReady = msg.ComponentsReady
+	r.Component = msg.Component
+	r.Namespace = msg.Namespace
+	r.Version = msg.Version
+	r.URL = msg.Url
+	r.Profile = msg.Profile
+	r.Kubeconfig = msg.Kubeconfig
+	r.CallbackURL = msg.CallbackURL
+	r.CorrelationID = msg.CorrelationID
+	r.Type = model.OperationType(msg.Type)
+	r.DeletionConfirmed = msg.DeletionConfirmed
+	r.Target = msg.Target
+	for key, jsonValue := range msg.Configuration {
+		var value interface{}
+		if err := json.Unmarshal(jsonValue, &value); err != nil {
+			return fmt.Errorf("failed to decode configuration value of key '%s': %s", key, err)
+		}
+		if r.Configuration == nil {
+			r.Configuration = make(map[string]interface{})
+		}
+		r.Configuration[key] = value
+	}
+	if len(msg.Metadata) > 0 {
+		if err := json.Unmarshal(msg.Metadata, &r.Metadata); err != nil {
+			return fmt.Errorf("failed to decode metadata: %s", err)
+		}
+	}
+	if msg.Repository != nil {
+		r.Repository = &Repository{URL: msg.Repository.Url}
+	}
+	if compConfig := msg.ComponentConfiguration; compConfig != nil {
+		r.ComponentConfiguration = ComponentConfiguration{
+			MaxRetries:  int(compConfig.MaxRetries),
+			Debug:       compConfig.Debug,
+			Features:    compConfig.Features,
+			TimeoutSecs: compConfig.TimeoutSecs,
+		}
+	}
+	if ref := msg.KubeconfigRef; ref != nil {
+		r.KubeconfigRef = &KubeconfigRef{URL: ref.Url, Token: ref.Token}
+		if ref.Expires != 0 {
+			r.KubeconfigRef.Expires = time.Unix(ref.Expires, 0).UTC()
+		}
+	}
+	return nil
+}
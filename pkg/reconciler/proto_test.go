@@ -0,0 +1,92 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestTaskProto(t *testing.T) {
+	t.Run("Roundtrip", func(t *testing.T) {
+		labels := map[string]string{"landscape": "canary"}
+		task := &Task{
+			ComponentsReady: []string{"cluster-essentials", "istio"},
+			Component:       "serverless",
+			Namespace:       "kyma-system",
+			Version:         "2.0.0",
+			Profile:         "evaluation",
+			Configuration: map[string]interface{}{
+				"global.domainName": "kyma.local",
+				"replicas":          float64(2),
+				"nested":            map[string]interface{}{"enabled": true},
+			},
+			Kubeconfig:    "apiVersion: v1\nkind: Config",
+			Metadata:      keb.Metadata{GlobalAccountID: "ga", Labels: &labels},
+			CallbackURL:   "https://mothership/v1/operations/1/callback/2",
+			CorrelationID: "2",
+			Repository:    &Repository{URL: "https://github.com/kyma-project/kyma"},
+			Type:          model.OperationTypeDelete,
+			ComponentConfiguration: ComponentConfiguration{
+				MaxRetries:  5,
+				Debug:       true,
+				Features:    map[string]bool{"COMPLIANCE_PRECHECK_ENABLED": true, "TRACE_ENABLED": false},
+				TimeoutSecs: 5400,
+			},
+			DeletionConfirmed: true,
+			Target:            "edge-1",
+		}
+		data, err := task.MarshalProto()
+		require.NoError(t, err)
+
+		decoded := &Task{}
+		require.NoError(t, decoded.UnmarshalProto(data))
+		require.Equal(t, task, decoded)
+	})
+
+	t.Run("Kubeconfig reference", func(t *testing.T) {
+		task := &Task{
+			Component: "istio",
+			KubeconfigRef: &KubeconfigRef{
+				URL:     "https://mothership/v1/operations/1/2/kubeconfig",
+				Token:   "abc.def",
+				Expires: time.Unix(1650000000, 0).UTC(),
+			},
+		}
+		data, err := task.MarshalProto()
+		require.NoError(t, err)
+
+		decoded := &Task{}
+		require.NoError(t, decoded.UnmarshalProto(data))
+		require.Equal(t, task, decoded)
+	})
+
+	t.Run("Empty task", func(t *testing.T) {
+		data, err := (&Task{}).MarshalProto()
+		require.NoError(t, err)
+
+		decoded := &Task{}
+		require.NoError(t, decoded.UnmarshalProto(data))
+		require.Equal(t, &Task{}, decoded)
+	})
+
+	t.Run("Unknown fields are skipped", func(t *testing.T) {
+		data := protowire.AppendTag(nil, 99, protowire.VarintType)
+		data = protowire.AppendVarint(data, 1)
+		data = protowire.AppendTag(data, 2, protowire.BytesType) //component
+		data = protowire.AppendString(data, "istio")
+
+		decoded := &Task{}
+		require.NoError(t, decoded.UnmarshalProto(data))
+		require.Equal(t, "istio", decoded.Component)
+	})
+
+	t.Run("Truncated message", func(t *testing.T) {
+		data, err := (&Task{Component: "istio"}).MarshalProto()
+		require.NoError(t, err)
+		require.Error(t, (&Task{}).UnmarshalProto(data[:len(data)-1]))
+	})
+}
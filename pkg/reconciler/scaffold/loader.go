@@ -0,0 +1,86 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	instancesImportPath = "github.com/kyma-incubator/reconciler/pkg/reconciler/instances"
+	loaderFile          = "loader.go"
+	examplePackage      = "example"
+	registrationCall    = "service.NewComponentReconciler("
+)
+
+//UpdateLoader regenerates the loader.go file in the instances directory: it imports all packages which
+//register a component reconciler (the 'example' package is skipped).
+func UpdateLoader(instancesDir string) error {
+	content, err := renderLoader(instancesDir)
+	if err != nil {
+		return err
+	}
+	loader := filepath.Join(instancesDir, loaderFile)
+	//nolint:gosec //source files are readable by everyone
+	return errors.Wrapf(os.WriteFile(loader, content, 0644), "failed to write loader '%s'", loader)
+}
+
+func renderLoader(instancesDir string) ([]byte, error) {
+	entries, err := os.ReadDir(instancesDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read instances directory '%s'", instancesDir)
+	}
+
+	var packages []string
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == examplePackage {
+			continue
+		}
+		registers, err := registersReconciler(filepath.Join(instancesDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if registers {
+			packages = append(packages, entry.Name())
+		}
+	}
+	sort.Strings(packages)
+
+	var buffer bytes.Buffer
+	buffer.WriteString("// This file is generated: manual changes will be overwritten!!!\n\npackage instances\n\nimport (\n")
+	for _, pkg := range packages {
+		fmt.Fprintf(&buffer, "\t//import required to register component reconciler '%s' in reconciler registry\n", pkg)
+		fmt.Fprintf(&buffer, "\t_ \"%s/%s\"\n", instancesImportPath, pkg)
+	}
+	buffer.WriteString(")\n")
+
+	content, err := format.Source(buffer.Bytes())
+	return content, errors.Wrap(err, "failed to format loader")
+}
+
+//registersReconciler checks whether a non-test file of the package creates a component reconciler
+func registersReconciler(pkgDir string) (bool, error) {
+	goFiles, err := filepath.Glob(filepath.Join(pkgDir, "*.go"))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list Go files of package '%s'", pkgDir)
+	}
+	for _, goFile := range goFiles {
+		if strings.HasSuffix(goFile, "_test.go") {
+			continue
+		}
+		content, err := os.ReadFile(goFile)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to read file '%s'", goFile)
+		}
+		if bytes.Contains(content, []byte(registrationCall)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
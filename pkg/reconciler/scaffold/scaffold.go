@@ -0,0 +1,112 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9.\-_]*[a-z0-9]$`)
+
+//files maps the templates to the files generated in the package of the component reconciler
+var files = []struct {
+	template string
+	file     func(c *Component) string
+}{
+	{template: "component.go.tmpl", file: func(c *Component) string { return c.Package + ".go" }},
+	{template: "action.go.tmpl", file: func(c *Component) string { return "action.go" }},
+	{template: "action_test.go.tmpl", file: func(c *Component) string { return "action_test.go" }},
+	{template: "Dockerfile.tmpl", file: func(c *Component) string { return "Dockerfile" }},
+}
+
+//Component describes a component reconciler which gets scaffolded
+type Component struct {
+	Name       string //name of the component (used as reconciler name)
+	Package    string //name of the Go package
+	Dockerfile string //path of the generated Dockerfile
+}
+
+//NewComponent returns the component reconciler for a Kyma component: the package name is derived
+//from the component name by dropping all separators (e.g. 'cluster-essentials' becomes 'clusteressentials')
+func NewComponent(name string) (*Component, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("component name '%s' is invalid: it has to start with a lowercase letter "+
+			"and can only contain lowercase letters, digits, '.', '-' and '_'", name)
+	}
+	return &Component{
+		Name: name,
+		Package: strings.Map(func(r rune) rune {
+			if r == '.' || r == '-' || r == '_' {
+				return -1
+			}
+			return r
+		}, name),
+	}, nil
+}
+
+//Generate creates the package of the component reconciler in the instances directory and returns the paths of
+//the generated files. An already existing package is never overwritten.
+func (c *Component) Generate(instancesDir string) ([]string, error) {
+	pkgDir := filepath.Join(instancesDir, c.Package)
+	if _, err := os.Stat(pkgDir); err == nil {
+		return nil, fmt.Errorf("package '%s' already exists: choose a different component name", pkgDir)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "failed to verify whether package '%s' exists", pkgDir)
+	}
+
+	c.Dockerfile = filepath.ToSlash(filepath.Join(pkgDir, "Dockerfile"))
+
+	rendered := make(map[string][]byte, len(files))
+	for _, f := range files {
+		file := filepath.Join(pkgDir, f.file(c))
+		content, err := c.render(f.template)
+		if err != nil {
+			return nil, err
+		}
+		rendered[file] = content
+	}
+
+	//write files only after all templates were rendered successfully to avoid half-generated packages
+	if err := os.MkdirAll(pkgDir, 0755); err != nil { //nolint:gosec //source packages are readable by everyone
+		return nil, errors.Wrapf(err, "failed to create package '%s'", pkgDir)
+	}
+	var generated []string
+	for _, f := range files {
+		file := filepath.Join(pkgDir, f.file(c))
+		if err := os.WriteFile(file, rendered[file], 0644); err != nil { //nolint:gosec //source files are readable by everyone
+			return generated, errors.Wrapf(err, "failed to write file '%s'", file)
+		}
+		generated = append(generated, file)
+	}
+	return generated, nil
+}
+
+func (c *Component) render(name string) ([]byte, error) {
+	tpl, err := template.ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse template '%s'", name)
+	}
+	var buffer bytes.Buffer
+	if err := tpl.Execute(&buffer, c); err != nil {
+		return nil, errors.Wrapf(err, "failed to render template '%s'", name)
+	}
+	if !strings.HasSuffix(name, ".go.tmpl") {
+		return buffer.Bytes(), nil
+	}
+	formatted, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "rendered template '%s' is not valid Go code", name)
+	}
+	return formatted, nil
+}
@@ -0,0 +1,111 @@
+package scaffold
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewComponent(t *testing.T) {
+	t.Run("Derive package name", func(t *testing.T) {
+		for name, pkg := range map[string]string{
+			"istio":              "istio",
+			"cluster-essentials": "clusteressentials",
+			"sap_btp.operator":   "sapbtpoperator",
+		} {
+			component, err := NewComponent(name)
+			require.NoError(t, err)
+			require.Equal(t, name, component.Name)
+			require.Equal(t, pkg, component.Package)
+		}
+	})
+
+	t.Run("Invalid names", func(t *testing.T) {
+		for _, name := range []string{"", "a", "Istio", "1istio", "istio-", "my component", "../istio"} {
+			_, err := NewComponent(name)
+			require.Error(t, err, name)
+		}
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	instancesDir := t.TempDir()
+	component, err := NewComponent("cluster-essentials")
+	require.NoError(t, err)
+
+	generated, err := component.Generate(instancesDir)
+	require.NoError(t, err)
+
+	pkgDir := filepath.Join(instancesDir, "clusteressentials")
+	require.ElementsMatch(t, []string{
+		filepath.Join(pkgDir, "clusteressentials.go"),
+		filepath.Join(pkgDir, "action.go"),
+		filepath.Join(pkgDir, "action_test.go"),
+		filepath.Join(pkgDir, "Dockerfile"),
+	}, generated)
+
+	t.Run("Go files are valid", func(t *testing.T) {
+		for _, file := range generated[:3] {
+			parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.AllErrors)
+			require.NoError(t, err, file)
+			require.Equal(t, "clusteressentials", parsed.Name.Name)
+		}
+		content, err := os.ReadFile(filepath.Join(pkgDir, "clusteressentials.go"))
+		require.NoError(t, err)
+		require.Contains(t, string(content), `const ReconcilerName = "cluster-essentials"`)
+	})
+
+	t.Run("Dockerfile starts component reconciler", func(t *testing.T) {
+		content, err := os.ReadFile(filepath.Join(pkgDir, "Dockerfile"))
+		require.NoError(t, err)
+		require.Contains(t, string(content), `CMD ["/bin/reconciler", "start", "cluster-essentials"]`)
+	})
+
+	t.Run("Existing package is not overwritten", func(t *testing.T) {
+		_, err := component.Generate(instancesDir)
+		require.Error(t, err)
+	})
+}
+
+func TestUpdateLoader(t *testing.T) {
+	t.Run("Import registering packages", func(t *testing.T) {
+		instancesDir := t.TempDir()
+		for _, name := range []string{"istio", "cluster-essentials", "example"} {
+			component, err := NewComponent(name)
+			require.NoError(t, err)
+			_, err = component.Generate(instancesDir)
+			require.NoError(t, err)
+		}
+		require.NoError(t, os.Mkdir(filepath.Join(instancesDir, "utils"), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(instancesDir, "utils", "utils.go"), []byte("package utils\n"), 0600))
+
+		require.NoError(t, UpdateLoader(instancesDir))
+
+		content, err := os.ReadFile(filepath.Join(instancesDir, loaderFile))
+		require.NoError(t, err)
+		require.Equal(t, `// This file is generated: manual changes will be overwritten!!!
+
+package instances
+
+import (
+	//import required to register component reconciler 'clusteressentials' in reconciler registry
+	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/clusteressentials"
+	//import required to register component reconciler 'istio' in reconciler registry
+	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/istio"
+)
+`, string(content))
+	})
+
+	t.Run("Loader of component reconcilers is up to date", func(t *testing.T) {
+		instancesDir := filepath.Join("..", "instances")
+		expected, err := os.ReadFile(filepath.Join(instancesDir, loaderFile))
+		require.NoError(t, err)
+		content, err := renderLoader(instancesDir)
+		require.NoError(t, err)
+		require.Equal(t, string(expected), string(content))
+	})
+}
@@ -0,0 +1,303 @@
+package reconciler
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+)
+
+//PayloadVersion of the tasks sent by the mothership: it's declared by the payload version header of the callback
+//client (client.PayloadVersionHeader)
+const PayloadVersion = "1"
+
+//SchemaViolationError is returned if a task payload doesn't match the schema of its contract version
+type SchemaViolationError struct {
+	Version    string
+	Violations []string
+}
+
+func (e *SchemaViolationError) Error() string {
+	return fmt.Sprintf("task payload violates schema of contract version '%s': %s",
+		e.Version, strings.Join(e.Violations, "; "))
+}
+
+func IsSchemaViolationError(err error) bool {
+	var schemaErr *SchemaViolationError
+	return errors.As(err, &schemaErr)
+}
+
+//UnsupportedPayloadVersionError is returned if no schema is registered for a contract version or the declared
+//payload version isn't compatible with it
+type UnsupportedPayloadVersionError struct {
+	Version  string
+	Declared string
+}
+
+func (e *UnsupportedPayloadVersionError) Error() string {
+	if e.Declared != "" {
+		return fmt.Sprintf("payload version '%s' is not compatible with contract version '%s' (supported: %s)",
+			e.Declared, e.Version, strings.Join(SupportedPayloadVersions(), ", "))
+	}
+	return fmt.Sprintf("contract version '%s' is not supported (supported: %s)",
+		e.Version, strings.Join(SupportedPayloadVersions(), ", "))
+}
+
+func IsUnsupportedPayloadVersionError(err error) bool {
+	var versionErr *UnsupportedPayloadVersionError
+	return errors.As(err, &versionErr)
+}
+
+type jsonType string
+
+const (
+	jsonString  jsonType = "string"
+	jsonNumber  jsonType = "number"
+	jsonInteger jsonType = "integer"
+	jsonBool    jsonType = "boolean"
+	jsonObject  jsonType = "object"
+	jsonArray   jsonType = "array"
+)
+
+type fieldSchema struct {
+	name     string
+	typ      jsonType
+	required bool     //required fields have to be defined and non-empty
+	enum     []string //allowed values of string fields
+	fields   []fieldSchema
+}
+
+//PayloadSchema describes the task payload of a contract version
+type PayloadSchema struct {
+	Version string
+	//Compatible lists the payload versions which a caller can declare when it uses this contract version
+	Compatible []string
+	fields     []fieldSchema
+}
+
+var payloadSchemas = map[string]*PayloadSchema{
+	"1": {
+		Version:    "1",
+		Compatible: []string{"1"},
+		fields: []fieldSchema{
+			{name: "component", typ: jsonString, required: true},
+			{name: "namespace", typ: jsonString, required: true},
+			{name: "version", typ: jsonString},
+			{name: "url", typ: jsonString},
+			{name: "profile", typ: jsonString},
+			{name: "configuration", typ: jsonObject},
+			{name: "kubeconfig", typ: jsonString},
+			{name: "metadata", typ: jsonObject},
+			{name: "callbackURL", typ: jsonString, required: true},
+			{name: "correlationID", typ: jsonString, required: true},
+			{name: "componentsReady", typ: jsonArray},
+			{name: "repository", typ: jsonObject, fields: []fieldSchema{
+				{name: "url", typ: jsonString},
+			}},
+			{name: "type", typ: jsonString, required: true, enum: []string{
+				string(model.OperationTypeReconcile), string(model.OperationTypeDelete),
+			}},
+			{name: "componentConfiguration", typ: jsonObject, fields: []fieldSchema{
+				{name: "maxRetries", typ: jsonInteger},
+				{name: "debug", typ: jsonBool},
+				{name: "features", typ: jsonObject},
+				{name: "timeoutSecs", typ: jsonInteger},
+			}},
+			{name: "deletionConfirmed", typ: jsonBool},
+			{name: "kubeconfigRef", typ: jsonObject, fields: []fieldSchema{
+				{name: "url", typ: jsonString, required: true},
+				{name: "token", typ: jsonString, required: true},
+				{name: "expires", typ: jsonString},
+			}},
+			{name: "target", typ: jsonString},
+		},
+	},
+}
+
+//SupportedPayloadVersions returns the contract versions which have a registered payload schema
+func SupportedPayloadVersions() []string {
+	var versions []string
+	for version := range payloadSchemas {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+//PayloadSchemaForVersion returns the schema of the contract version. If the caller declared a payload version,
+//it has to be compatible with the contract version.
+func PayloadSchemaForVersion(version, declared string) (*PayloadSchema, error) {
+	schema, ok := payloadSchemas[version]
+	if !ok {
+		return nil, &UnsupportedPayloadVersionError{Version: version}
+	}
+	if declared == "" {
+		return schema, nil
+	}
+	for _, compatible := range schema.Compatible {
+		if compatible == declared {
+			return schema, nil
+		}
+	}
+	return nil, &UnsupportedPayloadVersionError{Version: version, Declared: declared}
+}
+
+//Decode validates the payload against the schema and unmarshals it into a task
+func (s *PayloadSchema) Decode(payload []byte) (*Task, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, &SchemaViolationError{
+			Version:    s.Version,
+			Violations: []string{fmt.Sprintf("payload is not a JSON object: %s", err)},
+		}
+	}
+	violations := validateFields("", s.fields, raw)
+	violations = append(violations, validateKubeconfig(raw)...)
+	violations = append(violations, validateConfiguration(raw["configuration"])...)
+	if len(violations) > 0 {
+		return nil, &SchemaViolationError{Version: s.Version, Violations: violations}
+	}
+
+	task := &Task{}
+	if err := json.Unmarshal(payload, task); err != nil {
+		return nil, &SchemaViolationError{Version: s.Version, Violations: []string{err.Error()}}
+	}
+	if task.Configuration == nil {
+		task.Configuration = map[string]interface{}{}
+	}
+	return task, nil
+}
+
+//DecodeProto unmarshals a protobuf encoded task and validates it against the schema. The types of the fields are
+//enforced by the protobuf message: only mandatory fields and allowed values are verified.
+func (s *PayloadSchema) DecodeProto(payload []byte) (*Task, error) {
+	task := &Task{}
+	if err := task.UnmarshalProto(payload); err != nil {
+		return nil, &SchemaViolationError{Version: s.Version, Violations: []string{err.Error()}}
+	}
+
+	values := map[string]interface{}{
+		"component":     task.Component,
+		"namespace":     task.Namespace,
+		"version":       task.Version,
+		"url":           task.URL,
+		"profile":       task.Profile,
+		"kubeconfig":    task.Kubeconfig,
+		"callbackURL":   task.CallbackURL,
+		"correlationID": task.CorrelationID,
+		"type":          string(task.Type),
+		"target":        task.Target,
+	}
+	if task.Configuration != nil {
+		values["configuration"] = task.Configuration
+	}
+	if task.KubeconfigRef != nil {
+		values["kubeconfigRef"] = map[string]interface{}{
+			"url":   task.KubeconfigRef.URL,
+			"token": task.KubeconfigRef.Token,
+		}
+	}
+	violations := validateFields("", s.fields, values)
+	violations = append(violations, validateKubeconfig(values)...)
+	violations = append(violations, validateConfiguration(values["configuration"])...)
+	if len(violations) > 0 {
+		return nil, &SchemaViolationError{Version: s.Version, Violations: violations}
+	}
+
+	if task.Configuration == nil {
+		task.Configuration = map[string]interface{}{}
+	}
+	return task, nil
+}
+
+func validateFields(prefix string, fields []fieldSchema, raw map[string]interface{}) []string {
+	var violations []string
+	for _, field := range fields {
+		path := prefix + field.name
+		value, ok := raw[field.name]
+		if !ok || value == nil {
+			if field.required {
+				violations = append(violations, fmt.Sprintf("field '%s' is mandatory", path))
+			}
+			continue
+		}
+		if actual := typeOf(value); !field.typ.accepts(actual, value) {
+			violations = append(violations, fmt.Sprintf("field '%s' has to be of type %s but was %s",
+				path, field.typ, actual))
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			if field.required && strings.TrimSpace(v) == "" {
+				violations = append(violations, fmt.Sprintf("field '%s' is mandatory", path))
+			} else if len(field.enum) > 0 && !contains(field.enum, strings.ToLower(v)) {
+				violations = append(violations, fmt.Sprintf("field '%s' has to be one of [%s] but was '%s'",
+					path, strings.Join(field.enum, ", "), v))
+			}
+		case map[string]interface{}:
+			violations = append(violations, validateFields(path+".", field.fields, v)...)
+		}
+	}
+	return violations
+}
+
+//validateKubeconfig verifies that the task contains either the kubeconfig or a reference to it
+func validateKubeconfig(raw map[string]interface{}) []string {
+	if kubeconfig, ok := raw["kubeconfig"].(string); ok && strings.TrimSpace(kubeconfig) != "" {
+		return nil
+	}
+	if raw["kubeconfigRef"] != nil {
+		return nil
+	}
+	return []string{"field 'kubeconfig' is mandatory"}
+}
+
+//validateConfiguration verifies that all configuration entries have a key: values of empty keys can't be
+//addressed in the charts
+func validateConfiguration(configuration interface{}) []string {
+	entries, ok := configuration.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for key := range entries {
+		if strings.TrimSpace(key) == "" {
+			return []string{"configuration contains an entry without key"}
+		}
+	}
+	return nil
+}
+
+func typeOf(value interface{}) jsonType {
+	switch value.(type) {
+	case string:
+		return jsonString
+	case float64:
+		return jsonNumber
+	case bool:
+		return jsonBool
+	case []interface{}:
+		return jsonArray
+	default:
+		return jsonObject
+	}
+}
+
+func (t jsonType) accepts(actual jsonType, value interface{}) bool {
+	if t == jsonInteger && actual == jsonNumber {
+		number := value.(float64)
+		return number == float64(int64(number))
+	}
+	return t == actual
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadSchemaForVersion(t *testing.T) {
+	schema, err := PayloadSchemaForVersion("1", "")
+	require.NoError(t, err)
+	require.Equal(t, "1", schema.Version)
+
+	_, err = PayloadSchemaForVersion("1", "1")
+	require.NoError(t, err)
+
+	_, err = PayloadSchemaForVersion("2", "")
+	require.True(t, IsUnsupportedPayloadVersionError(err))
+
+	_, err = PayloadSchemaForVersion("1", "2")
+	require.True(t, IsUnsupportedPayloadVersionError(err))
+	require.Contains(t, err.Error(), "payload version '2' is not compatible")
+}
+
+func TestPayloadSchemaDecode(t *testing.T) {
+	schema, err := PayloadSchemaForVersion("1", "")
+	require.NoError(t, err)
+
+	t.Run("Valid payload", func(t *testing.T) {
+		task, err := schema.Decode([]byte(`{
+			"component": "istio",
+			"namespace": "istio-system",
+			"kubeconfig": "abc",
+			"callbackURL": "https://mothership/callback",
+			"correlationID": "123",
+			"type": "reconcile",
+			"configuration": {"global.domainName": "kyma.local", "replicas": 2},
+			"componentConfiguration": {"maxRetries": 3, "features": {"COMPLIANCE_PRECHECK_ENABLED": true}}
+		}`))
+		require.NoError(t, err)
+		require.Equal(t, "istio", task.Component)
+		require.Equal(t, 3, task.ComponentConfiguration.MaxRetries)
+		require.Equal(t, "kyma.local", task.Configuration["global.domainName"])
+	})
+
+	t.Run("Valid payload with kubeconfig reference", func(t *testing.T) {
+		task, err := schema.Decode([]byte(`{
+			"component": "istio",
+			"namespace": "istio-system",
+			"kubeconfigRef": {"url": "https://mothership/kubeconfig", "token": "abc", "expires": "2022-04-15T12:00:00Z"},
+			"callbackURL": "https://mothership/callback",
+			"correlationID": "123",
+			"type": "reconcile"
+		}`))
+		require.NoError(t, err)
+		require.Empty(t, task.Kubeconfig)
+		require.Equal(t, "abc", task.KubeconfigRef.Token)
+		require.Equal(t, 2022, task.KubeconfigRef.Expires.Year())
+	})
+
+	tests := []struct {
+		name      string
+		payload   string
+		violation string
+	}{
+		{
+			name:      "No JSON object",
+			payload:   `["istio"]`,
+			violation: "payload is not a JSON object",
+		},
+		{
+			name: "Missing kubeconfig",
+			payload: `{"component": "istio", "namespace": "istio-system", "kubeconfig": " ",
+				"callbackURL": "https://mothership/callback", "correlationID": "123", "type": "reconcile"}`,
+			violation: "field 'kubeconfig' is mandatory",
+		},
+		{
+			name: "Kubeconfig reference without token",
+			payload: `{"component": "istio", "namespace": "istio-system", "kubeconfigRef": {"url": "https://mothership"},
+				"callbackURL": "https://mothership/callback", "correlationID": "123", "type": "reconcile"}`,
+			violation: "field 'kubeconfigRef.token' is mandatory",
+		},
+		{
+			name: "Invalid configuration type",
+			payload: `{"component": "istio", "namespace": "istio-system", "kubeconfig": "abc",
+				"callbackURL": "https://mothership/callback", "correlationID": "123", "type": "reconcile",
+				"configuration": [{"key": "replicas", "value": 2}]}`,
+			violation: "field 'configuration' has to be of type object but was array",
+		},
+		{
+			name: "Configuration entry without key",
+			payload: `{"component": "istio", "namespace": "istio-system", "kubeconfig": "abc",
+				"callbackURL": "https://mothership/callback", "correlationID": "123", "type": "reconcile",
+				"configuration": {"": "abc"}}`,
+			violation: "configuration contains an entry without key",
+		},
+		{
+			name: "Invalid nested field",
+			payload: `{"component": "istio", "namespace": "istio-system", "kubeconfig": "abc",
+				"callbackURL": "https://mothership/callback", "correlationID": "123", "type": "reconcile",
+				"componentConfiguration": {"maxRetries": 1.5}}`,
+			violation: "field 'componentConfiguration.maxRetries' has to be of type integer but was number",
+		},
+		{
+			name: "Unknown operation type",
+			payload: `{"component": "istio", "namespace": "istio-system", "kubeconfig": "abc",
+				"callbackURL": "https://mothership/callback", "correlationID": "123", "type": "upgrade"}`,
+			violation: "field 'type' has to be one of [reconcile, delete] but was 'upgrade'",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := schema.Decode([]byte(tc.payload))
+			require.True(t, IsSchemaViolationError(err))
+			require.Contains(t, err.Error(), tc.violation)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/file"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
 	"go.uber.org/zap"
 )
@@ -17,6 +18,7 @@ type ActionContext struct {
 	Logger           *zap.SugaredLogger
 	Task             *reconciler.Task
 	ChartProvider    chart.Provider
+	Sandbox          *file.Sandbox //isolated directory for temporary files of the operation
 }
 
 type Action interface {
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+const (
+	//AdoptedFromAnnotation marks resources which were installed by another tool (e.g. Helm or the Kyma installer)
+	//before the reconciler took them over. The value is the name of the previous manager.
+	AdoptedFromAnnotation = "reconciler.kyma-project.io/adopted-from"
+	//AppliedResourcesConfigMap is the applied-resource inventory: it lists per component the resources
+	//which are managed by the reconciler
+	AppliedResourcesConfigMap = "reconciler-applied-resources"
+
+	helmManagedByLabel   = "app.kubernetes.io/managed-by"
+	helmHeritageLabel    = "heritage"
+	helmReleaseNameAnno  = "meta.helm.sh/release-name"
+	unknownPreviousOwner = "unknown"
+)
+
+//AdoptionInterceptor discovers resources which already exist in the cluster but aren't managed by the reconciler
+//yet. Such resources are marked as adopted: the deployment updates them in-place (it never deletes or
+//re-creates them) and adds the reconciler's ownership labels.
+type AdoptionInterceptor struct {
+	kubeClient kubernetes.Client
+	logger     *zap.SugaredLogger
+	adopted    []*kubernetes.Resource
+}
+
+func NewAdoptionInterceptor(kubeClient kubernetes.Client, logger *zap.SugaredLogger) *AdoptionInterceptor {
+	return &AdoptionInterceptor{
+		kubeClient: kubeClient,
+		logger:     logger,
+	}
+}
+
+func (a *AdoptionInterceptor) Intercept(resources *kubernetes.ResourceCacheList, namespace string) error {
+	interceptorFunc := func(u *unstructured.Unstructured) error {
+		resNamespace := kubernetes.ResolveNamespace(u, namespace) //ignored for cluster-scoped resources
+		liveRes, err := a.kubeClient.Get(u.GetKind(), u.GetName(), resNamespace)
+		if err != nil {
+			if k8serr.IsNotFound(err) {
+				return nil //resource will be created
+			}
+			//abort the deployment: an unchecked resource could be installed twice or overwritten
+			return errors.Wrapf(err, "adoption failed to retrieve %s '%s' (namespace: %s)",
+				u.GetKind(), u.GetName(), resNamespace)
+		}
+		if liveRes == nil || liveRes.GetLabels()[ManagedByLabel] == LabelReconcilerValue {
+			return nil
+		}
+
+		previousOwner := previousOwner(liveRes)
+		annotations := u.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[AdoptedFromAnnotation] = previousOwner
+		u.SetAnnotations(annotations)
+
+		a.logger.Infof("Adopting %s '%s' (namespace: %s) previously managed by '%s'",
+			u.GetKind(), u.GetName(), resNamespace, previousOwner)
+		a.adopted = append(a.adopted, &kubernetes.Resource{
+			Kind:      u.GetKind(),
+			Name:      u.GetName(),
+			Namespace: liveRes.GetNamespace(),
+		})
+		return nil
+	}
+
+	return resources.Visit(interceptorFunc)
+}
+
+//Adopted returns the resources which were adopted during the last deployment
+func (a *AdoptionInterceptor) Adopted() []*kubernetes.Resource {
+	return a.adopted
+}
+
+func previousOwner(u *unstructured.Unstructured) string {
+	if owner, ok := u.GetLabels()[helmManagedByLabel]; ok && owner != "" {
+		return owner
+	}
+	if owner, ok := u.GetLabels()[helmHeritageLabel]; ok && owner != "" { //Helm 2 / Kyma installer
+		return owner
+	}
+	if _, ok := u.GetAnnotations()[helmReleaseNameAnno]; ok {
+		return "Helm"
+	}
+	return unknownPreviousOwner
+}
+
+type appliedResource struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Adopted   bool   `json:"adopted,omitempty"`
+}
+
+func (r *appliedResource) key() string {
+	return fmt.Sprintf("%s/%s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+//AppliedResourcesInventory tracks the resources which were applied by the reconciler per component
+type AppliedResourcesInventory struct {
+	clientset k8s.Interface
+	namespace string
+}
+
+func NewAppliedResourcesInventory(clientset k8s.Interface, namespace string) *AppliedResourcesInventory {
+	return &AppliedResourcesInventory{
+		clientset: clientset,
+		namespace: namespace,
+	}
+}
+
+//Import stores the deployed resources of a component. Resources which were adopted once stay flagged as adopted.
+func (i *AppliedResourcesInventory) Import(ctx context.Context, component string, deployed, adopted []*kubernetes.Resource) error {
+	cm, err := i.clientset.CoreV1().ConfigMaps(i.namespace).Get(ctx, AppliedResourcesConfigMap, metav1.GetOptions{})
+	exists := err == nil
+	if err != nil {
+		if !k8serr.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to retrieve applied-resource inventory '%s/%s'",
+				i.namespace, AppliedResourcesConfigMap)
+		}
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      AppliedResourcesConfigMap,
+				Namespace: i.namespace,
+				Labels:    map[string]string{ManagedByLabel: LabelReconcilerValue},
+			},
+		}
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+
+	entries := make(map[string]*appliedResource)
+	if data, ok := cm.Data[component]; ok {
+		var existing []*appliedResource
+		if err := json.Unmarshal([]byte(data), &existing); err != nil {
+			return errors.Wrapf(err, "failed to parse applied-resource inventory of component '%s'", component)
+		}
+		for _, entry := range existing {
+			entries[entry.key()] = entry
+		}
+	}
+	merge := func(resources []*kubernetes.Resource, adopted bool) {
+		for _, res := range resources {
+			entry := &appliedResource{Kind: res.Kind, Name: res.Name, Namespace: res.Namespace}
+			if existing, ok := entries[entry.key()]; ok {
+				entry.Adopted = existing.Adopted
+			}
+			entry.Adopted = entry.Adopted || adopted
+			entries[entry.key()] = entry
+		}
+	}
+	merge(deployed, false)
+	merge(adopted, true)
+
+	var result []*appliedResource
+	for _, entry := range entries {
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].key() < result[b].key()
+	})
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	cm.Data[component] = string(data)
+
+	if exists {
+		_, err = i.clientset.CoreV1().ConfigMaps(i.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+	} else {
+		_, err = i.clientset.CoreV1().ConfigMaps(i.namespace).Create(ctx, cm, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to store applied-resource inventory '%s/%s'", i.namespace, AppliedResourcesConfigMap)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/stretchr/testify/require"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAdoptionInterceptor(t *testing.T) {
+	newUnstruct := func(name string, labels map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("apps/v1")
+		u.SetKind("Deployment")
+		u.SetName(name)
+		u.SetNamespace("kyma-system")
+		u.SetLabels(labels)
+		return u
+	}
+
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Get", "Deployment", "new", "kyma-system").
+		Return(nil, k8serr.NewNotFound(schema.GroupResource{Resource: "deployments"}, "new"))
+	kubeClient.On("Get", "Deployment", "managed", "kyma-system").
+		Return(newUnstruct("managed", map[string]string{ManagedByLabel: LabelReconcilerValue}), nil)
+	kubeClient.On("Get", "Deployment", "helm", "kyma-system").
+		Return(newUnstruct("helm", map[string]string{helmManagedByLabel: "Helm"}), nil)
+
+	resources := kubernetes.NewResourceList([]*unstructured.Unstructured{
+		newUnstruct("new", nil),
+		newUnstruct("managed", nil),
+		newUnstruct("helm", nil),
+	})
+
+	interceptor := NewAdoptionInterceptor(kubeClient, logger.NewLogger(true))
+	require.NoError(t, interceptor.Intercept(resources, "kyma-system"))
+
+	require.Equal(t, []*kubernetes.Resource{
+		{Kind: "Deployment", Name: "helm", Namespace: "kyma-system"},
+	}, interceptor.Adopted())
+	require.Equal(t, "Helm", resources.Get("Deployment", "helm", "kyma-system").GetAnnotations()[AdoptedFromAnnotation])
+	require.Empty(t, resources.Get("Deployment", "new", "kyma-system").GetAnnotations())
+	require.Empty(t, resources.Get("Deployment", "managed", "kyma-system").GetAnnotations())
+}
+
+func TestAppliedResourcesInventory(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	inventory := NewAppliedResourcesInventory(clientset, "kyma-system")
+
+	deployed := []*kubernetes.Resource{
+		{Kind: "Deployment", Name: "a", Namespace: "kyma-system"},
+		{Kind: "Deployment", Name: "b", Namespace: "kyma-system"},
+	}
+	adopted := []*kubernetes.Resource{
+		{Kind: "Deployment", Name: "b", Namespace: "kyma-system"},
+	}
+
+	read := func() []*appliedResource {
+		cm, err := clientset.CoreV1().ConfigMaps("kyma-system").Get(context.Background(), AppliedResourcesConfigMap, metav1.GetOptions{})
+		require.NoError(t, err)
+		var result []*appliedResource
+		require.NoError(t, json.Unmarshal([]byte(cm.Data["component"]), &result))
+		return result
+	}
+
+	//initial import creates the inventory
+	require.NoError(t, inventory.Import(context.Background(), "component", deployed, adopted))
+	require.Equal(t, []*appliedResource{
+		{Kind: "Deployment", Name: "a", Namespace: "kyma-system"},
+		{Kind: "Deployment", Name: "b", Namespace: "kyma-system", Adopted: true},
+	}, read())
+
+	//adopted flag is kept when the resource is deployed again
+	require.NoError(t, inventory.Import(context.Background(), "component", deployed, nil))
+	require.Equal(t, []*appliedResource{
+		{Kind: "Deployment", Name: "a", Namespace: "kyma-system"},
+		{Kind: "Deployment", Name: "b", Namespace: "kyma-system", Adopted: true},
+	}, read())
+}
@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+)
+
+const (
+	//PodSecurityEnforceLabel defines the Pod Security Standard level which the admission enforces in a namespace
+	PodSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+
+	podSecurityPrivileged = "privileged"
+	podSecurityBaseline   = "baseline"
+	podSecurityRestricted = "restricted"
+)
+
+//baselineCapabilities can be added to containers by the 'baseline' level
+var baselineCapabilities = map[v1.Capability]bool{
+	"AUDIT_WRITE": true, "CHOWN": true, "DAC_OVERRIDE": true, "FOWNER": true, "FSETID": true, "KILL": true,
+	"MKNOD": true, "NET_BIND_SERVICE": true, "SETFCAP": true, "SETGID": true, "SETPCAP": true, "SETUID": true,
+	"SYS_CHROOT": true,
+}
+
+//workloadTemplatePaths are the fields of the workload kinds which contain a pod template
+var workloadTemplatePaths = map[string][]string{
+	"Deployment":  {"spec", "template"},
+	"StatefulSet": {"spec", "template"},
+	"DaemonSet":   {"spec", "template"},
+	"ReplicaSet":  {"spec", "template"},
+	"Job":         {"spec", "template"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template"},
+}
+
+//ComplianceError is returned by the pre-flight check if the target cluster would reject the workloads of a
+//component or isolate them from the network
+type ComplianceError struct {
+	Violations []string
+}
+
+func (e *ComplianceError) Error() string {
+	return fmt.Sprintf("pre-flight check failed: the target cluster doesn't allow the workloads of the component "+
+		"to run (%d violations): %s", len(e.Violations), strings.Join(e.Violations, "; "))
+}
+
+type workload struct {
+	kind      string
+	name      string
+	namespace string
+	template  *v1.PodTemplateSpec
+}
+
+func (w *workload) String() string {
+	return fmt.Sprintf("%s '%s/%s'", w.kind, w.namespace, w.name)
+}
+
+//verifyCompliance checks whether the Pod Security admission and the network policies of the target cluster allow
+//the workloads of the manifest to run. Violations are returned as ComplianceError.
+func verifyCompliance(ctx context.Context, clientset kubernetes.Interface, unstructs []*unstructured.Unstructured,
+	namespace string) error {
+	violations, err := complianceViolations(ctx, clientset, unstructs, namespace)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &ComplianceError{Violations: violations}
+	}
+	return nil
+}
+
+func complianceViolations(ctx context.Context, clientset kubernetes.Interface, unstructs []*unstructured.Unstructured,
+	namespace string) ([]string, error) {
+	workloads, err := toWorkloads(unstructs, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	//namespaces and network policies of the manifest are deployed together with the workloads
+	manifestNamespaces := make(map[string]map[string]string)
+	manifestPolicies := make(map[string][]networkingv1.NetworkPolicy)
+	for _, u := range unstructs {
+		switch u.GetKind() {
+		case "Namespace":
+			manifestNamespaces[u.GetName()] = u.GetLabels()
+		case "NetworkPolicy":
+			policy := networkingv1.NetworkPolicy{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &policy); err != nil {
+				return nil, errors.Wrapf(err, "failed to convert network policy '%s'", u.GetName())
+			}
+			policyNamespace := k8s.ResolveNamespace(u, namespace)
+			manifestPolicies[policyNamespace] = append(manifestPolicies[policyNamespace], policy)
+		}
+	}
+
+	var result []string
+	levels := make(map[string]string)
+	policies := make(map[string][]networkingv1.NetworkPolicy)
+	for _, w := range workloads {
+		level, ok := levels[w.namespace]
+		if !ok {
+			level, err = podSecurityLevel(ctx, clientset, w.namespace, manifestNamespaces)
+			if err != nil {
+				return nil, err
+			}
+			levels[w.namespace] = level
+		}
+		for _, violation := range podSecurityViolations(&w.template.Spec, level) {
+			result = append(result, fmt.Sprintf("%s: %s which the Pod Security level '%s' of namespace '%s' forbids",
+				w, violation, level, w.namespace))
+		}
+
+		nsPolicies, ok := policies[w.namespace]
+		if !ok {
+			nsPolicies, err = networkPolicies(ctx, clientset, w.namespace, manifestPolicies[w.namespace])
+			if err != nil {
+				return nil, err
+			}
+			policies[w.namespace] = nsPolicies
+		}
+		for _, violation := range networkPolicyViolations(w.template, nsPolicies) {
+			result = append(result, fmt.Sprintf("%s: %s", w, violation))
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func toWorkloads(unstructs []*unstructured.Unstructured, namespace string) ([]*workload, error) {
+	var result []*workload
+	for _, u := range unstructs {
+		template := &v1.PodTemplateSpec{}
+		if u.GetKind() == "Pod" {
+			pod := &v1.Pod{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, pod); err != nil {
+				return nil, errors.Wrapf(err, "failed to convert pod '%s'", u.GetName())
+			}
+			template.ObjectMeta = pod.ObjectMeta
+			template.Spec = pod.Spec
+		} else {
+			path, ok := workloadTemplatePaths[u.GetKind()]
+			if !ok {
+				continue
+			}
+			templateObj, found, err := unstructured.NestedMap(u.Object, path...)
+			if err != nil || !found {
+				continue
+			}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(templateObj, template); err != nil {
+				return nil, errors.Wrapf(err, "failed to convert pod template of %s '%s'", u.GetKind(), u.GetName())
+			}
+		}
+		result = append(result, &workload{
+			kind:      u.GetKind(),
+			name:      u.GetName(),
+			namespace: k8s.ResolveNamespace(u, namespace),
+			template:  template,
+		})
+	}
+	return result, nil
+}
+
+//podSecurityLevel returns the enforced Pod Security level of a namespace: the labels of a namespace defined in the
+//manifest take precedence because it gets deployed before the workloads
+func podSecurityLevel(ctx context.Context, clientset kubernetes.Interface, namespace string,
+	manifestNamespaces map[string]map[string]string) (string, error) {
+	nsLabels, ok := manifestNamespaces[namespace]
+	if !ok {
+		ns, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			if k8serr.IsNotFound(err) {
+				return podSecurityPrivileged, nil
+			}
+			return "", errors.Wrapf(err, "failed to retrieve namespace '%s'", namespace)
+		}
+		nsLabels = ns.GetLabels()
+	}
+	switch nsLabels[PodSecurityEnforceLabel] {
+	case podSecurityBaseline:
+		return podSecurityBaseline, nil
+	case podSecurityRestricted:
+		return podSecurityRestricted, nil
+	default:
+		return podSecurityPrivileged, nil
+	}
+}
+
+//podSecurityViolations checks the controls of the Pod Security Standards which workloads violate most often
+func podSecurityViolations(spec *v1.PodSpec, level string) []string {
+	if level == podSecurityPrivileged {
+		return nil
+	}
+	var result []string
+	if spec.HostNetwork || spec.HostPID || spec.HostIPC {
+		result = append(result, "pod uses host namespaces")
+	}
+	for _, volume := range spec.Volumes {
+		if volume.HostPath != nil {
+			result = append(result, fmt.Sprintf("volume '%s' is a hostPath volume", volume.Name))
+		} else if level == podSecurityRestricted && !restrictedVolumeType(volume) {
+			result = append(result, fmt.Sprintf("volume '%s' uses a volume type", volume.Name))
+		}
+	}
+
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		sc := c.SecurityContext
+		if sc == nil {
+			sc = &v1.SecurityContext{}
+		}
+		if sc.Privileged != nil && *sc.Privileged {
+			result = append(result, fmt.Sprintf("container '%s' is privileged", c.Name))
+		}
+		for _, port := range c.Ports {
+			if port.HostPort != 0 {
+				result = append(result, fmt.Sprintf("container '%s' uses host port %d", c.Name, port.HostPort))
+			}
+		}
+		if sc.Capabilities != nil {
+			for _, capability := range sc.Capabilities.Add {
+				if !baselineCapabilities[capability] || (level == podSecurityRestricted && capability != "NET_BIND_SERVICE") {
+					result = append(result, fmt.Sprintf("container '%s' adds capability '%s'", c.Name, capability))
+				}
+			}
+		}
+		if level != podSecurityRestricted {
+			continue
+		}
+		if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			result = append(result, fmt.Sprintf("container '%s' doesn't disallow privilege escalation", c.Name))
+		}
+		if !runsAsNonRoot(spec.SecurityContext, sc) {
+			result = append(result, fmt.Sprintf("container '%s' doesn't enforce to run as non-root user", c.Name))
+		}
+		if !dropsAllCapabilities(sc) {
+			result = append(result, fmt.Sprintf("container '%s' doesn't drop all capabilities", c.Name))
+		}
+		if !hasSeccompProfile(spec.SecurityContext, sc) {
+			result = append(result, fmt.Sprintf("container '%s' doesn't define a seccomp profile", c.Name))
+		}
+	}
+	return result
+}
+
+//restrictedVolumeType returns true if the volume type is allowed by the 'restricted' level
+func restrictedVolumeType(volume v1.Volume) bool {
+	return volume.ConfigMap != nil || volume.CSI != nil || volume.DownwardAPI != nil || volume.EmptyDir != nil ||
+		volume.Ephemeral != nil || volume.PersistentVolumeClaim != nil || volume.Projected != nil || volume.Secret != nil
+}
+
+func runsAsNonRoot(podSC *v1.PodSecurityContext, sc *v1.SecurityContext) bool {
+	if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+		return false
+	}
+	if sc.RunAsNonRoot != nil {
+		return *sc.RunAsNonRoot
+	}
+	if podSC != nil && podSC.RunAsUser != nil && *podSC.RunAsUser == 0 && sc.RunAsUser == nil {
+		return false
+	}
+	return podSC != nil && podSC.RunAsNonRoot != nil && *podSC.RunAsNonRoot
+}
+
+func dropsAllCapabilities(sc *v1.SecurityContext) bool {
+	if sc.Capabilities == nil {
+		return false
+	}
+	for _, capability := range sc.Capabilities.Drop {
+		if capability == "ALL" {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSeccompProfile(podSC *v1.PodSecurityContext, sc *v1.SecurityContext) bool {
+	allowed := func(profile *v1.SeccompProfile) bool {
+		return profile.Type == v1.SeccompProfileTypeRuntimeDefault || profile.Type == v1.SeccompProfileTypeLocalhost
+	}
+	if sc.SeccompProfile != nil {
+		return allowed(sc.SeccompProfile)
+	}
+	return podSC != nil && podSC.SeccompProfile != nil && allowed(podSC.SeccompProfile)
+}
+
+//networkPolicies returns the network policies of a namespace after the manifest got deployed
+func networkPolicies(ctx context.Context, clientset kubernetes.Interface, namespace string,
+	manifestPolicies []networkingv1.NetworkPolicy) ([]networkingv1.NetworkPolicy, error) {
+	policyList, err := clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list network policies of namespace '%s'", namespace)
+	}
+	result := append([]networkingv1.NetworkPolicy{}, manifestPolicies...)
+	for _, policy := range policyList.Items {
+		overridden := false
+		for _, manifestPolicy := range manifestPolicies {
+			if manifestPolicy.Name == policy.Name {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			result = append(result, policy)
+		}
+	}
+	return result, nil
+}
+
+//networkPolicyViolations detects pods which are isolated by network policies without any rule allowing traffic:
+//without egress they can't reach the DNS or the API server, without ingress their ports aren't reachable
+func networkPolicyViolations(template *v1.PodTemplateSpec, policies []networkingv1.NetworkPolicy) []string {
+	var ingressIsolated, ingressAllowed, egressIsolated, egressAllowed bool
+	for i := range policies {
+		policy := &policies[i]
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil || !selector.Matches(labels.Set(template.Labels)) {
+			continue
+		}
+		for _, policyType := range policyTypes(policy) {
+			switch policyType {
+			case networkingv1.PolicyTypeIngress:
+				ingressIsolated = true
+				ingressAllowed = ingressAllowed || len(policy.Spec.Ingress) > 0
+			case networkingv1.PolicyTypeEgress:
+				egressIsolated = true
+				egressAllowed = egressAllowed || len(policy.Spec.Egress) > 0
+			}
+		}
+	}
+
+	var result []string
+	if egressIsolated && !egressAllowed {
+		result = append(result, "network policies deny all egress traffic of its pods (DNS and API server aren't reachable)")
+	}
+	if ingressIsolated && !ingressAllowed && exposesPorts(&template.Spec) {
+		result = append(result, "network policies deny all ingress traffic to the ports of its pods")
+	}
+	return result
+}
+
+//policyTypes returns the types of a network policy: if none are defined, Kubernetes derives them from the rules
+func policyTypes(policy *networkingv1.NetworkPolicy) []networkingv1.PolicyType {
+	if len(policy.Spec.PolicyTypes) > 0 {
+		return policy.Spec.PolicyTypes
+	}
+	result := []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	if len(policy.Spec.Egress) > 0 {
+		result = append(result, networkingv1.PolicyTypeEgress)
+	}
+	return result
+}
+
+func exposesPorts(spec *v1.PodSpec) bool {
+	for _, c := range spec.Containers {
+		if len(c.Ports) > 0 {
+			return true
+		}
+	}
+	return false
+}
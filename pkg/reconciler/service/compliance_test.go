@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const complianceManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: privileged
+spec:
+  selector:
+    matchLabels:
+      app: privileged
+  template:
+    metadata:
+      labels:
+        app: privileged
+    spec:
+      hostNetwork: true
+      containers:
+      - name: agent
+        image: agent
+        securityContext:
+          privileged: true
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: restricted
+  namespace: secured
+spec:
+  selector:
+    matchLabels:
+      app: restricted
+  template:
+    metadata:
+      labels:
+        app: restricted
+    spec:
+      securityContext:
+        runAsNonRoot: true
+        seccompProfile:
+          type: RuntimeDefault
+      containers:
+      - name: server
+        image: server
+        ports:
+        - containerPort: 8080
+        securityContext:
+          allowPrivilegeEscalation: false
+          capabilities:
+            drop: ["ALL"]
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: secured
+  labels:
+    pod-security.kubernetes.io/enforce: restricted
+`
+
+func TestComplianceViolations(t *testing.T) {
+	unstructs, err := k8s.ToUnstructured([]byte(complianceManifest), true)
+	require.NoError(t, err)
+
+	t.Run("Privileged namespaces and no network policies", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kyma-system"}})
+		violations, err := complianceViolations(context.Background(), clientset, unstructs, "kyma-system")
+		require.NoError(t, err)
+		require.Empty(t, violations)
+		require.NoError(t, verifyCompliance(context.Background(), clientset, unstructs, "kyma-system"))
+	})
+
+	t.Run("Pod Security violations", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "kyma-system",
+			Labels: map[string]string{PodSecurityEnforceLabel: podSecurityBaseline},
+		}})
+		violations, err := complianceViolations(context.Background(), clientset, unstructs, "kyma-system")
+		require.NoError(t, err)
+		//the namespace of the manifest is restricted but the daemon set complies with it
+		require.Equal(t, []string{
+			"Deployment 'kyma-system/privileged': container 'agent' is privileged which the Pod Security level " +
+				"'baseline' of namespace 'kyma-system' forbids",
+			"Deployment 'kyma-system/privileged': pod uses host namespaces which the Pod Security level " +
+				"'baseline' of namespace 'kyma-system' forbids",
+		}, violations)
+
+		err = verifyCompliance(context.Background(), clientset, unstructs, "kyma-system")
+		require.IsType(t, &ComplianceError{}, err)
+		require.Len(t, err.(*ComplianceError).Violations, 2)
+	})
+
+	t.Run("Network policies without rules", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kyma-system"}},
+			&networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-all", Namespace: "secured"},
+				Spec: networkingv1.NetworkPolicySpec{
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+				},
+			},
+			&networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-ingress", Namespace: "kyma-system"},
+				Spec: networkingv1.NetworkPolicySpec{
+					PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "privileged"}},
+				},
+			},
+		)
+		violations, err := complianceViolations(context.Background(), clientset, unstructs, "kyma-system")
+		require.NoError(t, err)
+		//ingress isolation of pods without ports is ignored
+		require.Equal(t, []string{
+			"DaemonSet 'secured/restricted': network policies deny all egress traffic of its pods (DNS and API " +
+				"server aren't reachable)",
+			"DaemonSet 'secured/restricted': network policies deny all ingress traffic to the ports of its pods",
+		}, violations)
+	})
+}
+
+func TestPodSecurityViolations(t *testing.T) {
+	trueVal := true
+	spec := &v1.PodSpec{
+		Volumes: []v1.Volume{
+			{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			{Name: "nfs", VolumeSource: v1.VolumeSource{NFS: &v1.NFSVolumeSource{}}},
+		},
+		Containers: []v1.Container{{
+			Name: "app",
+			SecurityContext: &v1.SecurityContext{
+				Capabilities: &v1.Capabilities{Add: []v1.Capability{"CHOWN", "NET_ADMIN"}},
+			},
+		}},
+	}
+
+	t.Run("Privileged", func(t *testing.T) {
+		require.Empty(t, podSecurityViolations(spec, podSecurityPrivileged))
+	})
+
+	t.Run("Baseline", func(t *testing.T) {
+		require.Equal(t, []string{"container 'app' adds capability 'NET_ADMIN'"},
+			podSecurityViolations(spec, podSecurityBaseline))
+	})
+
+	t.Run("Restricted", func(t *testing.T) {
+		require.Equal(t, []string{
+			"volume 'nfs' uses a volume type",
+			"container 'app' adds capability 'CHOWN'",
+			"container 'app' adds capability 'NET_ADMIN'",
+			"container 'app' doesn't disallow privilege escalation",
+			"container 'app' doesn't enforce to run as non-root user",
+			"container 'app' doesn't drop all capabilities",
+			"container 'app' doesn't define a seccomp profile",
+		}, podSecurityViolations(spec, podSecurityRestricted))
+
+		compliant := &v1.PodSpec{
+			SecurityContext: &v1.PodSecurityContext{
+				RunAsNonRoot:   &trueVal,
+				SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault},
+			},
+			Containers: []v1.Container{{
+				Name: "app",
+				SecurityContext: &v1.SecurityContext{
+					AllowPrivilegeEscalation: new(bool),
+					Capabilities: &v1.Capabilities{
+						Add:  []v1.Capability{"NET_BIND_SERVICE"},
+						Drop: []v1.Capability{"ALL"},
+					},
+				},
+			}},
+		}
+		require.Empty(t, podSecurityViolations(compliant, podSecurityRestricted))
+	})
+}
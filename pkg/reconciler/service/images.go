@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+//deployedResources records the resources which were deployed by an operation (also by custom actions which
+//use the default install action)
+type deployedResources struct {
+	sync.Mutex
+	resources map[string]*k8s.Resource
+}
+
+type deployedResourcesContextKey struct{}
+
+func withDeployedResources(ctx context.Context) (context.Context, *deployedResources) {
+	recorder := &deployedResources{resources: make(map[string]*k8s.Resource)}
+	return context.WithValue(ctx, deployedResourcesContextKey{}, recorder), recorder
+}
+
+//recordDeployedResources adds the resources to the recorder of the context (no-op if the context has no recorder)
+func recordDeployedResources(ctx context.Context, resources []*k8s.Resource) {
+	recorder, ok := ctx.Value(deployedResourcesContextKey{}).(*deployedResources)
+	if !ok {
+		return
+	}
+	recorder.Lock()
+	defer recorder.Unlock()
+	for _, resource := range resources { //retries deploy the same resources again
+		recorder.resources[resource.String()] = resource
+	}
+}
+
+func (d *deployedResources) list() []*k8s.Resource {
+	d.Lock()
+	defer d.Unlock()
+	var result []*k8s.Resource
+	for _, resource := range d.resources {
+		result = append(result, resource)
+	}
+	return result
+}
+
+//workload is a deployed resource which runs pods
+type workload struct {
+	resource   *k8s.Resource
+	selector   *metav1.LabelSelector
+	containers []v1.Container
+}
+
+//collectImages returns the container images of the deployed workloads. The digests are taken from the status of a
+//running pod of each workload: they are undefined if no pod is running.
+func collectImages(ctx context.Context, clientset kubernetes.Interface, resources []*k8s.Resource) ([]reconciler.ContainerImage, error) {
+	var images []reconciler.ContainerImage
+	for _, resource := range resources {
+		workload, err := getWorkload(ctx, clientset, resource)
+		if err != nil {
+			return nil, err
+		}
+		if workload == nil {
+			continue
+		}
+		digests, err := runningDigests(ctx, clientset, workload)
+		if err != nil {
+			return nil, err
+		}
+		for _, container := range workload.containers {
+			image := reconciler.ContainerImage{
+				Kind:      resource.Kind,
+				Namespace: resource.Namespace,
+				Name:      resource.Name,
+				Container: container.Name,
+				Image:     container.Image,
+			}
+			if digest, ok := digests[container.Name]; ok {
+				image.Digest = &digest
+			}
+			images = append(images, image)
+		}
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return fmt.Sprintf("%s/%s/%s/%s", images[i].Kind, images[i].Namespace, images[i].Name, images[i].Container) <
+			fmt.Sprintf("%s/%s/%s/%s", images[j].Kind, images[j].Namespace, images[j].Name, images[j].Container)
+	})
+	return images, nil
+}
+
+//getWorkload returns nil if the resource doesn't run pods or doesn't exist anymore
+func getWorkload(ctx context.Context, clientset kubernetes.Interface, resource *k8s.Resource) (*workload, error) {
+	var result *workload
+	var err error
+	switch resource.Kind {
+	case "Deployment":
+		deployment, getErr := clientset.AppsV1().Deployments(resource.Namespace).Get(ctx, resource.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			result = &workload{resource, deployment.Spec.Selector, deployment.Spec.Template.Spec.Containers}
+		}
+	case "StatefulSet":
+		statefulSet, getErr := clientset.AppsV1().StatefulSets(resource.Namespace).Get(ctx, resource.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			result = &workload{resource, statefulSet.Spec.Selector, statefulSet.Spec.Template.Spec.Containers}
+		}
+	case "DaemonSet":
+		daemonSet, getErr := clientset.AppsV1().DaemonSets(resource.Namespace).Get(ctx, resource.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			result = &workload{resource, daemonSet.Spec.Selector, daemonSet.Spec.Template.Spec.Containers}
+		}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to retrieve %s", resource)
+	}
+	return result, nil
+}
+
+//runningDigests returns the image digests of the containers of a running pod of the workload
+func runningDigests(ctx context.Context, clientset kubernetes.Interface, workload *workload) (map[string]string, error) {
+	digests := make(map[string]string)
+	if workload.selector == nil {
+		return digests, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(workload.selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert pod selector of %s", workload.resource)
+	}
+	pods, err := clientset.CoreV1().Pods(workload.resource.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list pods of %s", workload.resource)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != v1.PodRunning {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if digest := imageDigest(status.ImageID); digest != "" {
+				digests[status.Name] = digest
+			}
+		}
+		if len(digests) > 0 {
+			break
+		}
+	}
+	return digests, nil
+}
+
+//imageDigest extracts the digest from the image ID of a container status
+//(e.g. 'docker-pullable://eu.gcr.io/kyma-project/istio@sha256:abc...' or 'sha256:abc...')
+func imageDigest(imageID string) string {
+	if idx := strings.LastIndex(imageID, "@"); idx >= 0 {
+		return imageID[idx+1:]
+	}
+	if strings.HasPrefix(imageID, "sha256:") {
+		return imageID
+	}
+	return ""
+}
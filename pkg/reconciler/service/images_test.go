@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCollectImages(t *testing.T) {
+	labels := map[string]string{"app": "istiod"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "istiod", Namespace: "istio-system"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{
+				{Name: "discovery", Image: "eu.gcr.io/kyma-project/pilot:1.0"},
+			}}},
+		},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "istiod-abc", Namespace: "istio-system", Labels: labels},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "discovery", ImageID: "docker-pullable://eu.gcr.io/kyma-project/pilot@sha256:123"},
+			},
+		},
+	}
+	statefulSet := &appsv1.StatefulSet{ //no pod is running
+		ObjectMeta: metav1.ObjectMeta{Name: "logging", Namespace: "kyma-system"},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "logging"}},
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{
+				{Name: "loki", Image: "grafana/loki:2.0"},
+			}}},
+		},
+	}
+	clientset := fake.NewSimpleClientset([]runtime.Object{deployment, pod, statefulSet}...)
+
+	images, err := collectImages(context.Background(), clientset, []*k8s.Resource{
+		{Kind: "StatefulSet", Namespace: "kyma-system", Name: "logging"},
+		{Kind: "Deployment", Namespace: "istio-system", Name: "istiod"},
+		{Kind: "ConfigMap", Namespace: "istio-system", Name: "istio"},    //doesn't run pods
+		{Kind: "Deployment", Namespace: "istio-system", Name: "removed"}, //doesn't exist anymore
+	})
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+
+	require.Equal(t, "Deployment", images[0].Kind)
+	require.Equal(t, "discovery", images[0].Container)
+	require.Equal(t, "eu.gcr.io/kyma-project/pilot:1.0", images[0].Image)
+	require.NotNil(t, images[0].Digest)
+	require.Equal(t, "sha256:123", *images[0].Digest)
+
+	require.Equal(t, "StatefulSet", images[1].Kind)
+	require.Equal(t, "grafana/loki:2.0", images[1].Image)
+	require.Nil(t, images[1].Digest)
+}
+
+func TestImageDigest(t *testing.T) {
+	require.Equal(t, "sha256:123", imageDigest("docker-pullable://eu.gcr.io/kyma-project/pilot@sha256:123"))
+	require.Equal(t, "sha256:123", imageDigest("sha256:123"))
+	require.Empty(t, imageDigest(""))
+}
+
+func TestRecordDeployedResources(t *testing.T) {
+	resource := &k8s.Resource{Kind: "Deployment", Namespace: "istio-system", Name: "istiod"}
+
+	//contexts without recorder are ignored
+	recordDeployedResources(context.Background(), []*k8s.Resource{resource})
+
+	ctx, deployed := withDeployedResources(context.Background())
+	recordDeployedResources(ctx, []*k8s.Resource{resource})
+	recordDeployedResources(ctx, []*k8s.Resource{resource}) //retry deploys the same resource again
+	require.Equal(t, []*k8s.Resource{resource}, deployed.list())
+}
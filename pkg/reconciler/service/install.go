@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"sync"
 
+	"github.com/kyma-incubator/reconciler/pkg/features"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
@@ -12,6 +14,18 @@ import (
 	"go.uber.org/zap"
 )
 
+//renderedManifest keeps the parsed manifest of an operation to share it between its steps
+type renderedManifest struct {
+	sync.Mutex
+	manifest *kubernetes.Manifest
+}
+
+type renderedManifestContextKey struct{}
+
+func withRenderedManifest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, renderedManifestContextKey{}, &renderedManifest{})
+}
+
 type Install struct {
 	logger *zap.SugaredLogger
 }
@@ -26,19 +40,14 @@ type Operation interface {
 }
 
 func (r *Install) Invoke(ctx context.Context, chartProvider chart.Provider, task *reconciler.Task, kubeClient kubernetes.Client) error {
-	var err error
-	var manifest string
-	if task.Component == model.CRDComponent {
-		manifest, err = r.renderCRDs(chartProvider, task)
-	} else if task.Component != model.CleanupComponent { // TODO add better support for components that do not have manifests
-		manifest, err = r.renderManifest(chartProvider, task)
-	}
+	//the manifest is rendered and parsed once per operation and re-used by all following steps and retries
+	manifest, err := r.manifest(ctx, chartProvider, task)
 	if err != nil {
 		return err
 	}
 
 	if task.Type == model.OperationTypeDelete {
-		resources, err := kubeClient.Delete(ctx, manifest, task.Namespace)
+		resources, err := kubeClient.DeleteManifest(ctx, manifest, task.Namespace)
 		if err == nil {
 			r.logger.Debugf("Deletion of manifest finished successfully: %d resources deleted", len(resources))
 		} else {
@@ -49,7 +58,12 @@ func (r *Install) Invoke(ctx context.Context, chartProvider chart.Provider, task
 		if task.Component == model.CleanupComponent {
 			return nil
 		}
-		resources, err := kubeClient.Deploy(ctx, manifest, task.Namespace,
+		if task.ComponentConfiguration.Features.Enabled(features.CompliancePreCheck) {
+			if err := r.verifyCompliance(ctx, kubeClient, manifest, task.Namespace); err != nil {
+				return err
+			}
+		}
+		interceptors := []kubernetes.ResourceInterceptor{
 			&LabelsInterceptor{
 				Version: task.Version,
 			},
@@ -59,18 +73,104 @@ func (r *Install) Invoke(ctx context.Context, chartProvider chart.Provider, task
 			},
 			newClusterWideResourceInterceptor(),
 			&NamespaceInterceptor{},
-		)
+		}
+		var adoptionInterceptor *AdoptionInterceptor
+		if task.ComponentConfiguration.Features.Enabled(features.AdoptExistingResources) {
+			adoptionInterceptor = NewAdoptionInterceptor(kubeClient, r.logger)
+			interceptors = append(interceptors, adoptionInterceptor)
+		}
+		resources, err := kubeClient.DeployManifest(ctx, manifest, task.Namespace, interceptors...)
 		if err == nil {
 			r.logger.Debugf("Deployment of manifest finished successfully: %d resources deployed", len(resources))
+			recordDeployedResources(ctx, resources)
 		} else {
 			r.logger.Warnf("Failed to deploy manifests on target cluster: %s", err)
 			return err
 		}
+		if adoptionInterceptor != nil {
+			return r.importAppliedResources(ctx, kubeClient, task, resources, adoptionInterceptor.Adopted())
+		}
+	}
+	return nil
+}
+
+//verifyCompliance runs the pre-flight check of the workloads before any resource is deployed
+func (r *Install) verifyCompliance(ctx context.Context, kubeClient kubernetes.Client, manifest *kubernetes.Manifest,
+	namespace string) error {
+	unstructs, err := manifest.Unstructs()
+	if err != nil {
+		return err
+	}
+	clientset, err := kubeClient.Clientset()
+	if err != nil {
+		return err
+	}
+	if err := verifyCompliance(ctx, clientset, unstructs, namespace); err != nil {
+		r.logger.Warnf("Pre-flight check of component failed: %s", err)
+		return err
+	}
+	return nil
+}
+
+func (r *Install) importAppliedResources(ctx context.Context, kubeClient kubernetes.Client, task *reconciler.Task,
+	deployed, adopted []*kubernetes.Resource) error {
+	clientset, err := kubeClient.Clientset()
+	if err != nil {
+		return err
 	}
+	if err := NewAppliedResourcesInventory(clientset, task.Namespace).Import(ctx, task.Component, deployed, adopted); err != nil {
+		r.logger.Warnf("Failed to import resources of component '%s' into applied-resource inventory: %s",
+			task.Component, err)
+		return err
+	}
+	r.logger.Debugf("Imported %d resources (%d adopted) of component '%s' into applied-resource inventory",
+		len(deployed), len(adopted), task.Component)
 	return nil
 }
 
+//manifest returns the parsed manifest of the task: if the context carries a manifest of an earlier step of the
+//operation (e.g. the verification of stateful resources or a previous attempt), it's re-used without rendering
+//and parsing it again
+func (r *Install) manifest(ctx context.Context, chartProvider chart.Provider, task *reconciler.Task) (*kubernetes.Manifest, error) {
+	rendered, ok := ctx.Value(renderedManifestContextKey{}).(*renderedManifest)
+	if ok {
+		rendered.Lock()
+		defer rendered.Unlock()
+		if rendered.manifest != nil {
+			return rendered.manifest, nil
+		}
+	}
+
+	manifest := kubernetes.NewManifest("")
+	if task.Component == model.CRDComponent {
+		crdManifest, err := r.renderCRDs(chartProvider, task)
+		if err != nil {
+			return nil, err
+		}
+		manifest = kubernetes.NewManifest(crdManifest)
+	} else if task.Component != model.CleanupComponent { // TODO add better support for components that do not have manifests
+		chartManifest, err := r.renderChartManifest(chartProvider, task)
+		if err != nil {
+			return nil, err
+		}
+		manifest = chartManifest.Parsed()
+	}
+
+	if ok {
+		rendered.manifest = manifest
+	}
+	return manifest, nil
+}
+
 func (r *Install) renderManifest(chartProvider chart.Provider, model *reconciler.Task) (string, error) {
+	chartManifest, err := r.renderChartManifest(chartProvider, model)
+	if err != nil {
+		return "", err
+	}
+	return chartManifest.Manifest, nil
+}
+
+func (r *Install) renderChartManifest(chartProvider chart.Provider, model *reconciler.Task) (*chart.Manifest, error) {
 	component := chart.NewComponentBuilder(model.Version, model.Component).
 		WithProfile(model.Profile).
 		WithNamespace(model.Namespace).
@@ -88,10 +188,10 @@ func (r *Install) renderManifest(chartProvider chart.Provider, model *reconciler
 				model.URL)
 		}
 		r.logger.Errorf("%s: %s", msg, err)
-		return "", errors.Wrap(err, msg)
+		return nil, errors.Wrap(err, msg)
 	}
 
-	return chartManifest.Manifest, nil
+	return chartManifest, nil
 }
 
 func (r *Install) renderCRDs(chartProvider chart.Provider, model *reconciler.Task) (string, error) {
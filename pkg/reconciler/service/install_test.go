@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	chartmocks "github.com/kyma-incubator/reconciler/pkg/reconciler/chart/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallManifest(t *testing.T) {
+	newProvider := func() *chartmocks.Provider {
+		provider := &chartmocks.Provider{}
+		provider.On("RenderManifest", mock.Anything).Return(&chart.Manifest{Manifest: statefulManifest}, nil)
+		return provider
+	}
+	task := &reconciler.Task{Component: "component", Version: "1.0.0"}
+	install := NewInstall(logger.NewLogger(true))
+
+	t.Run("Manifest is rendered and parsed once per operation", func(t *testing.T) {
+		provider := newProvider()
+		ctx := withRenderedManifest(context.Background())
+
+		manifest, err := install.manifest(ctx, provider, task)
+		require.NoError(t, err)
+		unstructs, err := manifest.Unstructs()
+		require.NoError(t, err)
+		require.Len(t, unstructs, 5)
+		unstructs[0].SetName("modified") //modifications of a step don't affect later steps
+
+		manifestAgain, err := install.manifest(ctx, provider, task)
+		require.NoError(t, err)
+		require.Same(t, manifest, manifestAgain)
+		unstructs, err = manifestAgain.Unstructs()
+		require.NoError(t, err)
+		require.Equal(t, "monitoring", unstructs[0].GetName())
+		provider.AssertNumberOfCalls(t, "RenderManifest", 1)
+	})
+
+	t.Run("Manifest is rendered for each call without operation context", func(t *testing.T) {
+		provider := newProvider()
+		_, err := install.manifest(context.Background(), provider, task)
+		require.NoError(t, err)
+		_, err = install.manifest(context.Background(), provider, task)
+		require.NoError(t, err)
+		provider.AssertNumberOfCalls(t, "RenderManifest", 2)
+	})
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	//LeaseNamespace is the namespace of the component leases on the target cluster
+	LeaseNamespace = "kube-system"
+	//LeaseNamePrefix is the prefix of component leases: other tools (e.g. the Kyma CLI) have to acquire
+	//a lease with the name '<prefix><component>' before they apply changes to a component
+	LeaseNamePrefix = "kyma-component-"
+
+	defaultLeaseDuration = 60 * time.Second
+)
+
+//LeaseConflictError indicates that the lease of a component is held by another holder
+type LeaseConflictError struct {
+	Component string
+	Holder    string
+}
+
+func (e *LeaseConflictError) Error() string {
+	return fmt.Sprintf("lease of component '%s' is held by '%s'", e.Component, e.Holder)
+}
+
+func IsLeaseConflictError(err error) bool {
+	_, ok := errors.Cause(err).(*LeaseConflictError)
+	return ok
+}
+
+//componentLease coordinates changes of a component with other controllers using a Lease on the target cluster
+type componentLease struct {
+	clientset kubernetes.Interface
+	component string
+	holder    string
+	duration  time.Duration
+	logger    *zap.SugaredLogger
+}
+
+func newComponentLease(clientset kubernetes.Interface, component, holder string, logger *zap.SugaredLogger) *componentLease {
+	return &componentLease{
+		clientset: clientset,
+		component: component,
+		holder:    holder,
+		duration:  defaultLeaseDuration,
+		logger:    logger,
+	}
+}
+
+func (l *componentLease) name() string {
+	return LeaseNamePrefix + l.component
+}
+
+//Acquire takes the lease if it is free, expired or already held by this holder.
+//A LeaseConflictError is returned if another holder owns the lease.
+func (l *componentLease) Acquire(ctx context.Context) error {
+	leases := l.clientset.CoordinationV1().Leases(LeaseNamespace)
+	now := metav1.NewMicroTime(time.Now())
+	durationSecs := int32(l.duration.Seconds())
+
+	lease, err := leases.Get(ctx, l.name(), metav1.GetOptions{})
+	if err != nil {
+		if !k8serr.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to retrieve lease '%s/%s'", LeaseNamespace, l.name())
+		}
+		_, err = leases.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      l.name(),
+				Namespace: LeaseNamespace,
+				Labels:    map[string]string{ManagedByLabel: LabelReconcilerValue},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &l.holder,
+				LeaseDurationSeconds: &durationSecs,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}, metav1.CreateOptions{})
+		if k8serr.IsAlreadyExists(err) { //another holder was faster
+			return &LeaseConflictError{Component: l.component, Holder: "unknown"}
+		}
+		return err
+	}
+
+	holder := ""
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+	}
+	if holder != "" && holder != l.holder && !leaseExpired(lease, now.Time) {
+		return &LeaseConflictError{Component: l.component, Holder: holder}
+	}
+
+	if holder != l.holder {
+		l.logger.Debugf("Acquiring lease '%s/%s' (previous holder: '%s')", LeaseNamespace, l.name(), holder)
+		lease.Spec.AcquireTime = &now
+		transitions := int32(1)
+		if lease.Spec.LeaseTransitions != nil {
+			transitions += *lease.Spec.LeaseTransitions
+		}
+		lease.Spec.LeaseTransitions = &transitions
+	}
+	lease.Spec.HolderIdentity = &l.holder
+	lease.Spec.LeaseDurationSeconds = &durationSecs
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	if k8serr.IsConflict(err) { //lease was modified concurrently
+		return &LeaseConflictError{Component: l.component, Holder: "unknown"}
+	}
+	return err
+}
+
+//KeepAlive renews the lease periodically until the context gets closed
+func (l *componentLease) KeepAlive(ctx context.Context) {
+	ticker := time.NewTicker(l.duration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.Acquire(ctx); err != nil && ctx.Err() == nil {
+				l.logger.Warnf("Failed to renew lease '%s/%s': %s", LeaseNamespace, l.name(), err)
+			}
+		}
+	}
+}
+
+//Release frees the lease if it is still held by this holder
+func (l *componentLease) Release(ctx context.Context) error {
+	leases := l.clientset.CoordinationV1().Leases(LeaseNamespace)
+	lease, err := leases.Get(ctx, l.name(), metav1.GetOptions{})
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != l.holder {
+		return nil
+	}
+	lease.Spec.HolderIdentity = nil
+	lease.Spec.RenewTime = nil
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+func leaseExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.After(expiry)
+}
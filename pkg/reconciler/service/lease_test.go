@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestComponentLease(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger(true)
+
+	t.Run("Acquire and release lease", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		lease := newComponentLease(clientset, "istio", "reconciler", log)
+		require.NoError(t, lease.Acquire(ctx))
+		require.NoError(t, lease.Acquire(ctx)) //renewal by same holder
+
+		//another holder has to wait
+		err := newComponentLease(clientset, "istio", "kyma-cli", log).Acquire(ctx)
+		require.True(t, IsLeaseConflictError(err))
+		require.Equal(t, "lease of component 'istio' is held by 'reconciler'", err.Error())
+
+		//other components are not affected
+		require.NoError(t, newComponentLease(clientset, "serverless", "kyma-cli", log).Acquire(ctx))
+
+		//released lease can be acquired by another holder
+		require.NoError(t, lease.Release(ctx))
+		require.NoError(t, newComponentLease(clientset, "istio", "kyma-cli", log).Acquire(ctx))
+		require.True(t, IsLeaseConflictError(lease.Acquire(ctx)))
+	})
+
+	t.Run("Take over expired lease", func(t *testing.T) {
+		holder := "kyma-cli"
+		duration := int32(10)
+		renewTime := metav1.NewMicroTime(time.Now().Add(-1 * time.Minute))
+		clientset := fake.NewSimpleClientset(&coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: LeaseNamePrefix + "istio", Namespace: LeaseNamespace},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &holder,
+				LeaseDurationSeconds: &duration,
+				RenewTime:            &renewTime,
+			},
+		})
+
+		require.NoError(t, newComponentLease(clientset, "istio", "reconciler", log).Acquire(ctx))
+
+		lease, err := clientset.CoordinationV1().Leases(LeaseNamespace).Get(ctx, LeaseNamePrefix+"istio", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "reconciler", *lease.Spec.HolderIdentity)
+		require.Equal(t, int32(1), *lease.Spec.LeaseTransitions)
+	})
+}
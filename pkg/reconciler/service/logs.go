@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/redact"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	maxOperationLogEntries    = 200
+	maxOperationLogMessageLen = 4096
+)
+
+//operationLogs collects the latest log entries (info level and above) of an operation which are reported with
+//the final status: the oldest entries are dropped if the limit is exceeded
+type operationLogs struct {
+	sync.Mutex
+	entries   []reconciler.OperationLogEntry
+	truncated bool
+}
+
+func newOperationLogs() *operationLogs {
+	return &operationLogs{}
+}
+
+func (l *operationLogs) record(entry reconciler.OperationLogEntry) {
+	l.Lock()
+	defer l.Unlock()
+	if len(l.entries) >= maxOperationLogEntries {
+		l.entries = l.entries[1:]
+		l.truncated = true
+	}
+	l.entries = append(l.entries, entry)
+}
+
+//logger returns a logger which also writes its entries into the collected logs
+func (l *operationLogs) logger(logger *zap.SugaredLogger) *zap.SugaredLogger {
+	core := &logsCore{logs: l}
+	return logger.Desugar().WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(c, core)
+	})).Sugar()
+}
+
+//result returns the collected log entries (nil if nothing was logged)
+func (l *operationLogs) result() *reconciler.OperationLogs {
+	l.Lock()
+	defer l.Unlock()
+	if len(l.entries) == 0 {
+		return nil
+	}
+	return &reconciler.OperationLogs{
+		Entries:   append([]reconciler.OperationLogEntry{}, l.entries...),
+		Truncated: l.truncated,
+	}
+}
+
+//logsCore writes log entries of info level and above with their structured context into the collected logs
+type logsCore struct {
+	logs   *operationLogs
+	fields []zapcore.Field
+}
+
+func (c *logsCore) Enabled(level zapcore.Level) bool {
+	return level >= zapcore.InfoLevel
+}
+
+func (c *logsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &logsCore{
+		logs:   c.logs,
+		fields: append(append([]zapcore.Field{}, c.fields...), fields...),
+	}
+}
+
+func (c *logsCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *logsCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	logEntry := reconciler.OperationLogEntry{
+		Time:    entry.Time,
+		Level:   entry.Level.String(),
+		Message: truncateLogMessage(redact.String(entry.Message)),
+	}
+	if len(c.fields)+len(fields) > 0 {
+		encoder := zapcore.NewMapObjectEncoder()
+		for _, field := range append(append([]zapcore.Field{}, c.fields...), fields...) {
+			field.AddTo(encoder)
+		}
+		entryFields := make(map[string]string, len(encoder.Fields))
+		for key, value := range encoder.Fields {
+			entryFields[key] = truncateLogMessage(redact.String(fmt.Sprint(value)))
+		}
+		logEntry.Fields = &entryFields
+	}
+	c.logs.record(logEntry)
+	return nil
+}
+
+func (c *logsCore) Sync() error {
+	return nil
+}
+
+func truncateLogMessage(msg string) string {
+	if len(msg) <= maxOperationLogMessageLen {
+		return msg
+	}
+	return msg[:maxOperationLogMessageLen] + "...(truncated)"
+}
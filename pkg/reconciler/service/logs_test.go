@@ -0,0 +1,51 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationLogs(t *testing.T) {
+
+	t.Run("No logs collected", func(t *testing.T) {
+		logs := newOperationLogs()
+		logs.logger(logger.NewLogger(false)).Debug("debug logs are not collected")
+		require.Nil(t, logs.result())
+	})
+
+	t.Run("Logs are collected with their context", func(t *testing.T) {
+		logs := newOperationLogs()
+		log := logs.logger(logger.NewLogger(false)).With("component", "istio")
+		log.Infof("deploying version '%s'", "1.2.3")
+		log.Warnw("deployment not ready", "deployment", "istiod")
+
+		result := logs.result()
+		require.NotNil(t, result)
+		require.False(t, result.Truncated)
+		require.Len(t, result.Entries, 2)
+		require.Equal(t, "info", result.Entries[0].Level)
+		require.Equal(t, "deploying version '1.2.3'", result.Entries[0].Message)
+		require.Equal(t, map[string]string{"component": "istio"}, *result.Entries[0].Fields)
+		require.Equal(t, "warn", result.Entries[1].Level)
+		require.Equal(t, map[string]string{"component": "istio", "deployment": "istiod"}, *result.Entries[1].Fields)
+	})
+
+	t.Run("Latest logs are kept", func(t *testing.T) {
+		logs := newOperationLogs()
+		log := logs.logger(logger.NewLogger(false))
+		for i := 0; i < maxOperationLogEntries+10; i++ {
+			log.Infof("entry %d", i)
+		}
+		log.Error(strings.Repeat("x", maxOperationLogMessageLen+1))
+
+		result := logs.result()
+		require.True(t, result.Truncated)
+		require.Len(t, result.Entries, maxOperationLogEntries)
+		require.Equal(t, fmt.Sprintf("entry %d", 11), result.Entries[0].Message)
+		require.True(t, strings.HasSuffix(result.Entries[maxOperationLogEntries-1].Message, "...(truncated)"))
+	})
+}
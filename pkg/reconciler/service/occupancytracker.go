@@ -1,10 +1,9 @@
 package service
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"github.com/kyma-incubator/reconciler/pkg/callback/client"
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"go.uber.org/zap"
@@ -25,13 +24,15 @@ type OccupancyTracker struct {
 	occupancyID          string
 	occupancyCallbackURL string
 	ticker               *time.Ticker
+	client               *client.Client
 	sync.Mutex
 }
 
-func newOccupancyTracker(debug bool) *OccupancyTracker {
+func newOccupancyTracker(debug bool, mothershipClient *client.Client) *OccupancyTracker {
 	return &OccupancyTracker{
 		logger: logger.NewLogger(debug),
 		ticker: time.NewTicker(defaultInterval),
+		client: mothershipClient,
 	}
 }
 
@@ -73,22 +74,11 @@ func (t *OccupancyTracker) createOrUpdateComponentReconcilerOccupancy(reconciler
 		RunningWorkers: runningWorkers,
 		PoolSize:       poolSize,
 	}
-	jsonPayload, err := json.Marshal(httpOccupancyUpdateRequest)
+	resp, err := t.client.Post(context.Background(), t.occupancyCallbackURL, httpOccupancyUpdateRequest)
 	if err != nil {
-		t.logger.Errorf("occupancy tracker failed to marshal HTTP payload to update occupancy of service '%s': %s", t.occupancyID, err)
+		t.logger.Errorf("occupancy tracker failed to update occupancy of service '%s': %s", t.occupancyID, err)
 		return
 	}
-	resp, err := http.Post(t.occupancyCallbackURL, "application/json", bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		t.logger.Error(err.Error())
-		return
-	}
-	defer func() {
-		err = resp.Body.Close()
-		if err != nil {
-			t.logger.Infof("occupancy tracker failed to close HTTP response body: %s", err)
-		}
-	}()
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode > 299 {
 		if resp.StatusCode == http.StatusNotFound {
@@ -106,15 +96,7 @@ func (t *OccupancyTracker) createOrUpdateComponentReconcilerOccupancy(reconciler
 }
 
 func (t *OccupancyTracker) deleteWorkerPoolOccupancy() {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-	req, err := http.NewRequest(http.MethodDelete, t.occupancyCallbackURL, nil)
-	if err != nil {
-		t.logger.Error(err.Error())
-		return
-	}
-	_, err = client.Do(req)
+	_, err := t.client.Delete(context.Background(), t.occupancyCallbackURL)
 	if err != nil {
 		t.logger.Error(err.Error())
 		return
@@ -8,10 +8,13 @@ import (
 	"sync"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/callback/client"
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/watchdog"
 	"go.uber.org/zap"
 )
 
@@ -24,8 +27,9 @@ const (
 )
 
 var (
-	wsFactory chart.Factory //singleton
-	m         sync.Mutex
+	wsFactory   chart.Factory //singleton
+	wsCacheSize int           //max. number of Kyma versions cached by the workspace factory (0 = unlimited)
+	m           sync.Mutex
 )
 
 type ComponentReconciler struct {
@@ -43,6 +47,10 @@ type ComponentReconciler struct {
 	postDeleteAction Action
 	//retry:
 	retryDelay time.Duration
+	//detailed capture of slow operations (disabled if 0):
+	traceThreshold time.Duration
+	//detection of stuck operations (optional):
+	watchdog *watchdog.Watchdog
 	//worker pool:
 	timeout              time.Duration
 	workers              int
@@ -50,16 +58,24 @@ type ComponentReconciler struct {
 	debug                bool
 	mu                   sync.Mutex
 	reconcilerMetricsSet *metrics.ReconcilerMetricsSet
+	//callbacks:
+	callbackDispatcherConfig *callback.DispatcherConfig
+	callbackClientConfig     *client.Config
+	//pre-fetching of charts:
+	warmer warmer
 }
 
 type heartbeatSenderConfig struct {
-	interval time.Duration
-	timeout  time.Duration
+	interval    time.Duration
+	timeout     time.Duration
+	minInterval time.Duration
+	maxInterval time.Duration
 }
 
 type progressTrackerConfig struct {
-	interval time.Duration
-	timeout  time.Duration
+	interval    time.Duration
+	timeout     time.Duration
+	escalations []progress.Escalation
 }
 
 func NewComponentReconciler(reconcilerName string) (*ComponentReconciler, error) {
@@ -112,7 +128,11 @@ func (r *ComponentReconciler) workspaceFactory(repo *reconciler.Repository) (*ch
 	var err error
 	if wsFactory == nil {
 		r.logger.Debugf("Creating new workspace factory using storage directory '%s'", r.workspace)
-		wsFactory, err = chart.NewFactory(repo, r.workspace, r.logger)
+		var factory *chart.DefaultFactory
+		if factory, err = chart.NewFactory(repo, r.workspace, r.logger); err == nil {
+			factory.SetCacheSize(wsCacheSize)
+			wsFactory = factory
+		}
 	}
 
 	return &wsFactory, err
@@ -136,6 +156,10 @@ func (r *ComponentReconciler) validate() error {
 	if r.heartbeatSenderConfig.timeout == 0 {
 		r.heartbeatSenderConfig.timeout = defaultTimeout
 	}
+	if r.heartbeatSenderConfig.minInterval < 0 || r.heartbeatSenderConfig.maxInterval < 0 {
+		return fmt.Errorf("heartbeat min. and max. interval cannot be < 0 (got %.1f secs and %.1f secs)",
+			r.heartbeatSenderConfig.minInterval.Seconds(), r.heartbeatSenderConfig.maxInterval.Seconds())
+	}
 	if r.progressTrackerConfig.interval < 0 {
 		return fmt.Errorf("progress tracker interval cannot be < 0 (got %.1f secs)",
 			r.progressTrackerConfig.interval.Seconds())
@@ -156,6 +180,9 @@ func (r *ComponentReconciler) validate() error {
 	if r.retryDelay == 0 {
 		r.retryDelay = defaultRetryDelay
 	}
+	if r.traceThreshold < 0 {
+		return fmt.Errorf("trace threshold cannot be < 0 (got %.1f secs)", r.traceThreshold.Seconds())
+	}
 	if r.workers < 0 {
 		return fmt.Errorf("workers count cannot be < 0 (got %d)", r.workers)
 	}
@@ -192,6 +219,18 @@ func (r *ComponentReconciler) WithRetryDelay(retryDelay time.Duration) *Componen
 	return r
 }
 
+//WithTraceThreshold enables the detailed capture of operations which are running longer than the threshold
+func (r *ComponentReconciler) WithTraceThreshold(threshold time.Duration) *ComponentReconciler {
+	r.traceThreshold = threshold
+	return r
+}
+
+//WithWatchdog enables the detection of stuck operations
+func (r *ComponentReconciler) WithWatchdog(wd *watchdog.Watchdog) *ComponentReconciler {
+	r.watchdog = wd
+	return r
+}
+
 func (r *ComponentReconciler) WithWorkers(workers int, timeout time.Duration) *ComponentReconciler {
 	r.workers = workers
 	r.timeout = timeout
@@ -234,12 +273,38 @@ func (r *ComponentReconciler) WithHeartbeatSenderConfig(interval, timeout time.D
 	return r
 }
 
+//WithAdaptiveHeartbeats lets the heartbeat interval adapt to the phase of the operation: heartbeats get denser
+//(down to minInterval) if the operation timeout comes closer and sparser (up to maxInterval) during long stable waits
+func (r *ComponentReconciler) WithAdaptiveHeartbeats(minInterval, maxInterval time.Duration) *ComponentReconciler {
+	r.heartbeatSenderConfig.minInterval = minInterval
+	r.heartbeatSenderConfig.maxInterval = maxInterval
+	return r
+}
+
 func (r *ComponentReconciler) WithProgressTrackerConfig(interval, timeout time.Duration) *ComponentReconciler {
 	r.progressTrackerConfig.interval = interval
 	r.progressTrackerConfig.timeout = timeout
 	return r
 }
 
+//WithProgressEscalations defines the escalation steps of the progress tracker before its timeout is reached
+func (r *ComponentReconciler) WithProgressEscalations(escalations []progress.Escalation) *ComponentReconciler {
+	r.progressTrackerConfig.escalations = escalations
+	return r
+}
+
+//WithCallbackDispatcherConfig enables throttled and batched callbacks to the mothership
+func (r *ComponentReconciler) WithCallbackDispatcherConfig(config callback.DispatcherConfig) *ComponentReconciler {
+	r.callbackDispatcherConfig = &config
+	return r
+}
+
+//WithCallbackClientConfig configures the client used to call the mothership (e.g. authentication and retries)
+func (r *ComponentReconciler) WithCallbackClientConfig(config client.Config) *ComponentReconciler {
+	r.callbackClientConfig = &config
+	return r
+}
+
 func (r *ComponentReconciler) StartLocal(ctx context.Context, model *reconciler.Task, logger *zap.SugaredLogger) error {
 	//ensure model is valid
 	if err := model.Validate(); err != nil {
@@ -254,6 +319,7 @@ func (r *ComponentReconciler) StartLocal(ctx context.Context, model *reconciler.
 	if err != nil {
 		return err
 	}
+	localCbh = callback.WithSequence(localCbh, model.CallbackSequence)
 
 	runnerFunc := r.newRunnerFunc(ctx, model, localCbh, logger)
 	return runnerFunc()
@@ -263,23 +329,62 @@ func (r *ComponentReconciler) StartRemote(ctx context.Context, reconcilerName st
 	if err := r.validate(); err != nil {
 		return nil, nil, err
 	}
-	workerPool, err := newWorkerPoolBuilder(r.newRunnerFunc).WithPoolSize(r.workers).WithDebug(r.debug).Build(ctx)
+	mothershipClient := client.Default()
+	if r.callbackClientConfig != nil {
+		var err error
+		if mothershipClient, err = client.NewClient(*r.callbackClientConfig, r.logger); err != nil {
+			return nil, nil, err
+		}
+	}
+	poolBuilder := newWorkerPoolBuilder(r.newRunnerFunc).WithPoolSize(r.workers).WithDebug(r.debug).
+		WithCallbackClient(mothershipClient)
+	if r.callbackDispatcherConfig != nil {
+		dispatcherConfig := *r.callbackDispatcherConfig
+		dispatcherConfig.Client = mothershipClient
+		dispatcher, err := callback.NewDispatcher(ctx, dispatcherConfig, r.logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		poolBuilder.WithCallbackDispatcher(dispatcher)
+	}
+	workerPool, err := poolBuilder.Build(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 	//start occupancy tracker to track worker pool
-	tracker := newOccupancyTracker(r.debug)
+	tracker := newOccupancyTracker(r.debug, mothershipClient)
 	tracker.Track(ctx, workerPool, reconcilerName)
 
 	return workerPool, tracker, nil
 }
 
 func (r *ComponentReconciler) newRunnerFunc(ctx context.Context, model *reconciler.Task, callback callback.Handler, logger *zap.SugaredLogger) func() error {
-	r.logger.Debugf("Creating new runner closure with execution timeout of %.1f secs", r.timeout.Seconds())
-	return func() error {
-		timeoutCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	timeout := r.tunables().timeout
+	if model.ComponentConfiguration.TimeoutSecs > 0 { //execution timeout of the component was overridden for the cluster
+		timeout = time.Duration(model.ComponentConfiguration.TimeoutSecs) * time.Second
+	}
+	r.logger.Debugf("Creating new runner closure with execution timeout of %.1f secs", timeout.Seconds())
+	return func() (err error) {
+		timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
-		return (&runner{r, NewInstall(logger), logger}).Run(timeoutCtx, model, callback, r.reconcilerMetricsSet)
+		//panics outside of the actions (e.g. while sending heartbeats) are reported as failed operation
+		defer func() {
+			if p := recover(); p != nil {
+				err = reconciler.NewPanicError(p)
+				logger.Errorf("Runner of '%s' panicked: %s", model.Component, err)
+				if cbErr := callback.Callback(&reconciler.CallbackMessage{
+					Error:  err.Error(),
+					Status: reconciler.StatusError,
+				}); cbErr != nil {
+					logger.Errorf("Failed to report panic of runner of '%s': %s", model.Component, cbErr)
+				}
+			}
+		}()
+		//goroutines of the operation are labelled to find their stacks if the operation gets stuck
+		r.watchdog.Watch(timeoutCtx, model.CorrelationID, model.Component, func(ctx context.Context) {
+			err = (&runner{r, NewInstall(logger), logger}).Run(ctx, model, callback, r.reconcilerMetricsSet)
+		})
+		return err
 	}
 }
 
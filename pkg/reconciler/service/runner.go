@@ -6,11 +6,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/features"
+	kubeclient "github.com/kyma-incubator/reconciler/pkg/kubernetes"
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
 	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/throttle"
 
 	"github.com/google/uuid"
 
@@ -20,7 +23,9 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/file"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/heartbeat"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/watchdog"
 	"github.com/pkg/errors"
 )
 
@@ -66,13 +71,105 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 		return err
 	}
 
+	//the operation gets cancelled if the mothership aborts it (e.g. an operator cancelled the reconciliation)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx = withRenderedManifest(ctx)
+
+	settings := r.tunables()
 	heartbeatSender, err := heartbeat.NewHeartbeatSender(ctx, callback, r.logger, heartbeat.Config{
-		Interval: r.heartbeatSenderConfig.interval,
-		Timeout:  r.heartbeatSenderConfig.timeout,
+		Interval:    settings.heartbeatSenderConfig.interval,
+		Timeout:     settings.heartbeatSenderConfig.timeout,
+		MinInterval: settings.heartbeatSenderConfig.minInterval,
+		MaxInterval: settings.heartbeatSenderConfig.maxInterval,
 	})
 	if err != nil {
 		return err
 	}
+	heartbeatSender.OnAbort(func() {
+		r.logger.Warnf("Runner: cancelling operation of '%s' because it was aborted by the mothership", task.Component)
+		cancel()
+	})
+	if task.Type == model.OperationTypeReconcile {
+		reason, err := r.skipReason(ctx, task)
+		if err != nil { //don't block the reconciliation if the marker can't be verified
+			r.logger.Warnf("Runner: failed to verify whether reconciliation of '%s' has to be skipped: %s",
+				task.Component, err)
+		} else if reason != "" {
+			r.logger.Infof("Runner: skipping reconciliation of '%s' for version '%s': %s",
+				task.Component, task.Version, reason)
+			return heartbeatSender.Skipped(reason, uuid.NewString())
+		}
+	}
+	if task.Type == model.OperationTypeDelete && !task.DeletionConfirmed &&
+		task.ComponentConfiguration.Features.Enabled(features.UninstallConfirmation) {
+		resources, err := r.pendingDeletion(ctx, task)
+		if err != nil { //stateful resources are never removed without verification
+			err = errors.Wrapf(err, "failed to verify stateful resources of '%s'", task.Component)
+			if heartbeatErr := heartbeatSender.Error(err, uuid.NewString(), 0); heartbeatErr != nil {
+				return errors.Wrap(err, heartbeatErr.Error())
+			}
+			return err
+		}
+		if len(resources) > 0 {
+			r.logger.Infof("Runner: deletion of '%s' for version '%s' has to be confirmed because it would remove "+
+				"stateful resources: %s", task.Component, task.Version, strings.Join(resources, ", "))
+			return heartbeatSender.PendingConfirmation(resources, uuid.NewString())
+		}
+	}
+	if task.ComponentConfiguration.Features.Enabled(features.ComponentLeases) {
+		release, err := r.acquireLease(ctx, task, heartbeatSender)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	//isolated directory for temporary files of the operation: it's removed also if the operation panics or gets cancelled
+	sandbox, err := file.NewSandbox("", task.CorrelationID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := sandbox.Cleanup(); err != nil {
+			r.logger.Warnf("Runner: failed to clean up sandbox of '%s': %s", task.Component, err)
+		}
+	}()
+	ctx = file.WithSandbox(ctx, sandbox)
+	ctx, deployed := withDeployedResources(ctx)
+
+	//resources consumed on the target cluster by all attempts of the operation
+	usage := &k8s.Usage{}
+	heartbeatSender.ReportUsage(func() *reconciler.OperationUsage {
+		return &reconciler.OperationUsage{
+			ApiCalls:      usage.APICalls(),
+			ManifestBytes: usage.ManifestBytes(),
+		}
+	})
+
+	//the latest logs of the operation are reported with the final status
+	logs := newOperationLogs()
+	r.logger = logs.logger(r.logger)
+	r.install = NewInstall(r.logger)
+	heartbeatSender.ReportLogs(logs.result)
+
+	//operations which exceed the trace threshold are captured in detail and the capture is reported with the final status
+	ctx, trace := withOperationTrace(ctx, settings.traceThreshold)
+	defer trace.stop()
+	if trace != nil {
+		r.logger = trace.logger(r.logger)
+		r.install = NewInstall(r.logger)
+		usage.RecordAPICalls(trace.recordAPICall)
+		heartbeatSender.ReportTrace(trace.result)
+	}
+
+	//stuck operations report their goroutine stacks with the trace and signal the mothership early
+	r.watchdog.OnStuck(ctx, func(report *watchdog.Report) {
+		trace.recordStack(report)
+		heartbeatSender.ReportWarning(fmt.Sprintf("Operation is suspected to be stuck: running since %.0f secs "+
+			"in %d goroutines", report.Running.Seconds(), report.Goroutines))
+	})
+
 	var retryID string
 	retryable := func() error {
 		retryID = uuid.NewString()
@@ -80,13 +177,18 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 			r.logger.Warnf("Runner: failed to start status updater: %s", err)
 			return err
 		}
-		err := r.reconcile(ctx, task)
+		endAttempt := trace.phase("attempt")
+		err := r.reconcileWithThrottlingBackoff(ctx, task, usage, heartbeatSender.ReportWarning, reconcilerMetricsSet)
+		endAttempt(err)
 		if err != nil {
 			r.logger.Warnf("Runner: failing reconciliation of '%s' in version '%s' with profile '%s': %s",
 				task.Component, task.Version, task.Profile, err)
 			if heartbeatErr := heartbeatSender.Failed(err, retryID); heartbeatErr != nil {
 				err = errors.Wrap(err, heartbeatErr.Error())
 			}
+			if reconciler.IsPanicError(err) { //a panic won't disappear by retrying
+				return retry.Unrecoverable(err)
+			}
 		}
 		return err
 	}
@@ -95,7 +197,7 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 	//retry the reconciliation in case of an error
 	err = retry.Do(retryable,
 		retry.Attempts(uint(task.ComponentConfiguration.MaxRetries)),
-		retry.Delay(r.retryDelay),
+		retry.Delay(settings.retryDelay),
 		retry.LastErrorOnly(false),
 		retry.Context(ctx))
 
@@ -104,6 +206,11 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 		r.logger.Debugf("Runner: reconciliation of component '%s' for version '%s' finished successfully",
 			task.Component, task.Version)
 		r.exposeProcessingDuration(reconcilerMetricsSet, task, model.OperationStateDone, processingDuration)
+		if task.Type == model.OperationTypeReconcile {
+			endImages := trace.phase("images")
+			r.reportImages(ctx, task, deployed, heartbeatSender)
+			endImages(nil)
+		}
 		if err := heartbeatSender.Success(retryID, processingDuration); err != nil {
 			return err
 		} // TODO: enrich heartbeat with processduration
@@ -124,6 +231,79 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 	return err
 }
 
+//reportImages adds the container images of the deployed workloads to the success status: a failed collection
+//doesn't fail the operation
+func (r *runner) reportImages(ctx context.Context, task *reconciler.Task, deployed *deployedResources, heartbeatSender *heartbeat.Sender) {
+	clientset, err := kubeclient.NewClientBuilder().WithLogger(r.logger).WithString(task.Kubeconfig).Build(ctx, false)
+	if err != nil {
+		r.logger.Warnf("Runner: failed to create client for collecting images of '%s': %s", task.Component, err)
+		return
+	}
+	images, err := collectImages(ctx, clientset, deployed.list())
+	if err != nil {
+		r.logger.Warnf("Runner: failed to collect images of '%s': %s", task.Component, err)
+		return
+	}
+	r.logger.Debugf("Runner: reporting %d container images of '%s'", len(images), task.Component)
+	heartbeatSender.ReportImages(images)
+}
+
+func (r *runner) skipReason(ctx context.Context, task *reconciler.Task) (string, error) {
+	clientset, err := kubeclient.NewClientBuilder().WithLogger(r.logger).WithString(task.Kubeconfig).Build(ctx, false)
+	if err != nil {
+		return "", err
+	}
+	return skipReason(ctx, clientset, task.Component, task.Namespace)
+}
+
+//acquireLease waits until the lease of the component on the target cluster is available: lease conflicts are
+//reported as waiting status. The returned function releases the lease.
+func (r *runner) acquireLease(ctx context.Context, task *reconciler.Task, heartbeatSender *heartbeat.Sender) (func(), error) {
+	noop := func() {}
+	clientset, err := kubeclient.NewClientBuilder().WithLogger(r.logger).WithString(task.Kubeconfig).Build(ctx, false)
+	if err != nil { //don't block the reconciliation if the lease can't be verified
+		r.logger.Warnf("Runner: failed to create client for lease of '%s': reconciling without lease: %s",
+			task.Component, err)
+		return noop, nil
+	}
+
+	lease := newComponentLease(clientset, task.Component, fmt.Sprintf("kyma-reconciler/%s", task.CorrelationID), r.logger)
+	waitingID := uuid.NewString()
+	for {
+		err := lease.Acquire(ctx)
+		if err == nil {
+			break
+		}
+		if !IsLeaseConflictError(err) {
+			r.logger.Warnf("Runner: failed to acquire lease of '%s': reconciling without lease: %s",
+				task.Component, err)
+			return noop, nil
+		}
+		if heartbeatSender.CurrentStatus() != reconciler.StatusWaiting {
+			if err := heartbeatSender.Waiting(err.Error(), waitingID); err != nil {
+				return noop, err
+			}
+		}
+		r.logger.Infof("Runner: waiting for lease of '%s': %s", task.Component, err)
+		select {
+		case <-ctx.Done():
+			return noop, ctx.Err()
+		case <-time.After(r.tunables().retryDelay):
+		}
+	}
+
+	leaseCtx, cancel := context.WithCancel(ctx)
+	go lease.KeepAlive(leaseCtx)
+	return func() {
+		cancel()
+		releaseCtx, releaseCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer releaseCancel()
+		if err := lease.Release(releaseCtx); err != nil {
+			r.logger.Warnf("Runner: failed to release lease of '%s': %s", task.Component, err)
+		}
+	}, nil
+}
+
 func (r *runner) exposeProcessingDuration(reconcilerMetricsSet *metrics.ReconcilerMetricsSet, task *reconciler.Task, state model.OperationState, processingDuration time.Duration) {
 	if reconcilerMetricsSet == nil {
 		r.logger.Warnf("Reconciler Metrics not initialized")
@@ -136,10 +316,56 @@ func (r *runner) exposeProcessingDuration(reconcilerMetricsSet *metrics.Reconcil
 	reconcilerMetricsSet.ComponentProcessingDurationCollector.ExposeProcessingDuration(task.Component, state, processingDuration)
 }
 
-func (r *runner) reconcile(ctx context.Context, task *reconciler.Task) error {
+//reconcileWithThrottlingBackoff repeats the reconciliation as long as the API server of the target cluster
+//throttles requests: these attempts are not counted as failed reconciliations
+func (r *runner) reconcileWithThrottlingBackoff(ctx context.Context, task *reconciler.Task, usage *k8s.Usage, progressNotifier func(warning string), reconcilerMetricsSet *metrics.ReconcilerMetricsSet) error {
+	backoff := &throttle.Backoff{Initial: r.tunables().retryDelay}
+	for {
+		err := r.reconcile(ctx, task, usage, progressNotifier)
+		if !throttle.IsThrottled(err) {
+			if backoff.Throttled() {
+				r.exposeThrottling(reconcilerMetricsSet, task, false)
+			}
+			return err
+		}
+
+		r.exposeThrottling(reconcilerMetricsSet, task, true)
+		delay := backoff.Next(err)
+		r.logger.Warnf("Runner: API server of cluster '%s' is throttling reconciliation of '%s': "+
+			"retrying in %.0f secs: %s", task.Metadata.ShootName, task.Component, delay.Seconds(), err)
+		select {
+		case <-ctx.Done():
+			r.exposeThrottling(reconcilerMetricsSet, task, false)
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (r *runner) exposeThrottling(reconcilerMetricsSet *metrics.ReconcilerMetricsSet, task *reconciler.Task, throttled bool) {
+	if reconcilerMetricsSet == nil || reconcilerMetricsSet.ThrottledClustersCollector == nil {
+		return
+	}
+	reconcilerMetricsSet.ThrottledClustersCollector.ExposeThrottling(task.Metadata.ShootName, task.Component, throttled)
+}
+
+func (r *runner) reconcile(ctx context.Context, task *reconciler.Task, usage *k8s.Usage, progressNotifier func(warning string)) (err error) {
+	//a panicking action fails only the operation and not the whole reconciler
+	defer func() {
+		if p := recover(); p != nil {
+			err = reconciler.NewPanicError(p)
+			r.logger.Errorf("Runner: %s of '%s' with version '%s' panicked: %s",
+				task.Type, task.Component, task.Version, err)
+		}
+	}()
+
+	progressTrackerConfig := r.tunables().progressTrackerConfig
 	kubeClient, err := k8s.NewKubernetesClient(task.Kubeconfig, r.logger, &k8s.Config{
-		ProgressInterval: r.progressTrackerConfig.interval,
-		ProgressTimeout:  r.progressTrackerConfig.timeout,
+		ProgressInterval:    progressTrackerConfig.interval,
+		ProgressTimeout:     progressTrackerConfig.timeout,
+		ProgressEscalations: progressTrackerConfig.escalations,
+		ProgressNotifier:    progressNotifier,
+		Usage:               usage,
 	})
 	if err != nil {
 		return err
@@ -162,6 +388,7 @@ func (r *runner) reconcile(ctx context.Context, task *reconciler.Task) error {
 		Logger:           r.logger,
 		ChartProvider:    chartProvider,
 		Task:             task,
+		Sandbox:          file.SandboxFromContext(ctx),
 	}
 
 	// Identify the right action set to use (reconcile/delete)
@@ -170,22 +397,31 @@ func (r *runner) reconcile(ctx context.Context, task *reconciler.Task) error {
 		pre, act, post = r.preDeleteAction, r.deleteAction, r.postDeleteAction
 	}
 
+	trace := operationTraceFromContext(ctx)
 	if pre != nil {
-		if err := pre.Run(actionHelper); err != nil {
+		endPhase := trace.phase(fmt.Sprintf("pre-%s", task.Type))
+		err := pre.Run(actionHelper)
+		endPhase(err)
+		if err != nil {
 			r.logger.Debugf("Runner: Pre-%s action of '%s' with version '%s' failed: %s",
 				task.Type, task.Component, task.Version, err)
 			return err
 		}
 	}
 
+	endPhase := trace.phase(string(task.Type))
 	if act == nil {
-		if err := r.install.Invoke(ctx, chartProvider, task, kubeClient); err != nil {
+		err := r.install.Invoke(ctx, chartProvider, task, kubeClient)
+		endPhase(err)
+		if err != nil {
 			r.logger.Debugf("Runner: Default-%s action of '%s' with version '%s' failed: %s",
 				task.Type, task.Component, task.Version, err)
 			return err
 		}
 	} else {
-		if err := act.Run(actionHelper); err != nil {
+		err := act.Run(actionHelper)
+		endPhase(err)
+		if err != nil {
 			r.logger.Debugf("Runner: %s action of '%s' with version '%s' failed: %s",
 				cases.Title(language.English).String(string(task.Type)), task.Component, task.Version, err)
 			return err
@@ -193,7 +429,10 @@ func (r *runner) reconcile(ctx context.Context, task *reconciler.Task) error {
 	}
 
 	if post != nil {
-		if err := post.Run(actionHelper); err != nil {
+		endPhase := trace.phase(fmt.Sprintf("post-%s", task.Type))
+		err := post.Run(actionHelper)
+		endPhase(err)
+		if err != nil {
 			r.logger.Debugf("Runner: Post-%s action of '%s' with version '%s' failed: %s",
 				task.Type, task.Component, task.Version, err)
 			return err
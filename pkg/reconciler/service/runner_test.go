@@ -33,6 +33,7 @@ type TestAction struct {
 	delay           time.Duration
 	fail            bool
 	failAlways      bool
+	panic           bool
 }
 
 func (a *TestAction) Run(context *ActionContext) error {
@@ -50,6 +51,10 @@ func (a *TestAction) Run(context *ActionContext) error {
 		time.Sleep(a.delay)
 	}
 
+	if a.panic {
+		panic(fmt.Sprintf("action '%s' is panicking", a.name))
+	}
+
 	if a.fail {
 		if !a.failAlways {
 			a.fail = false //in next retry it won't fail again
@@ -221,6 +226,36 @@ func TestRunner(t *testing.T) {
 		require.Equal(t, kymaVersion, postAct.receivedVersion)
 	})
 
+	t.Run("Run with panicking reconcile-action for cluster-users component", func(t *testing.T) {
+		SetWorkspaceFactoryForHomeDir(t)
+
+		//create actions
+		reconcileAct := &TestAction{
+			name:  "reconcile",
+			panic: true,
+		}
+
+		runner := newRunner(t, nil, reconcileAct, nil, 10*time.Second, 1*time.Minute)
+		model := newModel(t, clusterUsersComponent, kymaVersion)
+		model.ComponentConfiguration.MaxRetries = 3
+		var errorMsg string
+		cbh, err := callback.NewLocalCallbackHandler(func(msg *reconciler.CallbackMessage) error {
+			if msg.Status == reconciler.StatusError {
+				errorMsg = msg.Error
+			}
+			return nil
+		}, logger.NewLogger(true))
+		require.NoError(t, err)
+
+		//panic is converted into a failed operation which isn't retried
+		err = runner.Run(context.Background(), model, cbh, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "#1: operation panicked")
+		require.NotContains(t, err.Error(), "#2:")
+		require.Contains(t, errorMsg, "action 'reconcile' is panicking")
+		require.Contains(t, errorMsg, "goroutine") //stack trace
+	})
+
 	t.Run("Run with exceeded timeout", func(t *testing.T) {
 		wsf, err := chart.NewFactory(nil, workspaceInProjectDir, logger.NewLogger(true))
 		require.NoError(t, err)
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	//SkipAnnotation excludes components temporarily from reconciliation. The value is either 'true' (all components
+	//deployed into the namespace are skipped) or a comma-separated list of component names.
+	SkipAnnotation = "reconciler.kyma-project.io/skip"
+	//SkipConfigMap is the marker ConfigMap which can be annotated instead of the namespace
+	SkipConfigMap = "reconciler-skip"
+)
+
+//skipReason verifies whether the cluster admin marked the component to be skipped: the returned reason
+//is empty if the component has to be reconciled
+func skipReason(ctx context.Context, clientset kubernetes.Interface, component, namespace string) (string, error) {
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil && !k8serr.IsNotFound(err) {
+		return "", errors.Wrapf(err, "failed to retrieve namespace '%s'", namespace)
+	}
+	if err == nil && skipsComponent(ns.GetAnnotations(), component) {
+		return fmt.Sprintf("component '%s' is skipped because namespace '%s' is annotated with '%s'",
+			component, namespace, SkipAnnotation), nil
+	}
+
+	cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, SkipConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to retrieve ConfigMap '%s' in namespace '%s'", SkipConfigMap, namespace)
+	}
+	if skipsComponent(cm.GetAnnotations(), component) {
+		return fmt.Sprintf("component '%s' is skipped because ConfigMap '%s/%s' is annotated with '%s'",
+			component, namespace, SkipConfigMap, SkipAnnotation), nil
+	}
+	return "", nil
+}
+
+func skipsComponent(annotations map[string]string, component string) bool {
+	value, ok := annotations[SkipAnnotation]
+	if !ok {
+		return false
+	}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if strings.EqualFold(entry, "true") || strings.EqualFold(entry, component) {
+			return true
+		}
+	}
+	return false
+}
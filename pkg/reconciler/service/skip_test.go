@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSkipReason(t *testing.T) {
+	newNamespace := func(annotations map[string]string) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kyma-system", Annotations: annotations}}
+	}
+	newConfigMap := func(annotations map[string]string) *v1.ConfigMap {
+		return &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: SkipConfigMap, Namespace: "kyma-system", Annotations: annotations}}
+	}
+
+	tests := []struct {
+		name       string
+		component  string
+		namespace  string
+		namespaces []*v1.Namespace
+		configMap  *v1.ConfigMap
+		skipped    bool
+	}{
+		{
+			name:       "No marker",
+			component:  "istio",
+			namespace:  "kyma-system",
+			namespaces: []*v1.Namespace{newNamespace(nil)},
+		},
+		{
+			name:      "Namespace does not exist",
+			component: "istio",
+			namespace: "istio-system",
+		},
+		{
+			name:       "Namespace skips all components",
+			component:  "istio",
+			namespace:  "kyma-system",
+			namespaces: []*v1.Namespace{newNamespace(map[string]string{SkipAnnotation: "true"})},
+			skipped:    true,
+		},
+		{
+			name:       "Namespace skips other components",
+			component:  "istio",
+			namespace:  "kyma-system",
+			namespaces: []*v1.Namespace{newNamespace(map[string]string{SkipAnnotation: "serverless, eventing"})},
+		},
+		{
+			name:       "ConfigMap skips component",
+			component:  "istio",
+			namespace:  "kyma-system",
+			namespaces: []*v1.Namespace{newNamespace(nil)},
+			configMap:  newConfigMap(map[string]string{SkipAnnotation: "serverless, Istio"}),
+			skipped:    true,
+		},
+		{
+			name:       "ConfigMap with disabled marker",
+			component:  "istio",
+			namespace:  "kyma-system",
+			namespaces: []*v1.Namespace{newNamespace(nil)},
+			configMap:  newConfigMap(map[string]string{SkipAnnotation: "false"}),
+		},
+	}
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset()
+			for _, ns := range tt.namespaces {
+				_, err := clientset.CoreV1().Namespaces().Create(context.Background(), ns, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			if tt.configMap != nil {
+				_, err := clientset.CoreV1().ConfigMaps(tt.configMap.Namespace).Create(context.Background(), tt.configMap, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			reason, err := skipReason(context.Background(), clientset, tt.component, tt.namespace)
+			require.NoError(t, err)
+			require.Equal(t, tt.skipped, reason != "")
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/watchdog"
+	"github.com/kyma-incubator/reconciler/pkg/redact"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	maxTraceLogs     = 1000
+	maxTraceAPICalls = 1000
+	maxTraceStacks   = 10
+)
+
+//operationTrace captures an operation in detail if it runs longer than the threshold: phases are always timed
+//but debug logs and API calls are only recorded after the threshold was exceeded
+type operationTrace struct {
+	threshold time.Duration
+	active    int32
+	timer     *time.Timer
+
+	sync.Mutex
+	phases    []reconciler.TracePhase
+	apiCalls  []reconciler.TraceAPICall
+	logs      []reconciler.TraceLog
+	stacks    []reconciler.TraceStack
+	truncated bool
+}
+
+type operationTraceContextKey struct{}
+
+//withOperationTrace adds a trace to the context which gets activated when the threshold is exceeded
+//(no trace is added if the threshold is 0)
+func withOperationTrace(ctx context.Context, threshold time.Duration) (context.Context, *operationTrace) {
+	if threshold <= 0 {
+		return ctx, nil
+	}
+	trace := &operationTrace{threshold: threshold}
+	trace.timer = time.AfterFunc(threshold, func() {
+		atomic.StoreInt32(&trace.active, 1)
+	})
+	return context.WithValue(ctx, operationTraceContextKey{}, trace), trace
+}
+
+//operationTraceFromContext returns the trace of the context (nil if the context has no trace)
+func operationTraceFromContext(ctx context.Context) *operationTrace {
+	trace, _ := ctx.Value(operationTraceContextKey{}).(*operationTrace)
+	return trace
+}
+
+func (t *operationTrace) isActive() bool {
+	return t != nil && atomic.LoadInt32(&t.active) == 1
+}
+
+//stop ends the capture: an operation which finished before the threshold isn't traced
+func (t *operationTrace) stop() {
+	if t == nil {
+		return
+	}
+	t.timer.Stop()
+}
+
+//phase starts the timing of a phase: the returned function ends the phase
+func (t *operationTrace) phase(name string) func(err error) {
+	if t == nil {
+		return func(error) {}
+	}
+	started := time.Now()
+	return func(err error) {
+		phase := reconciler.TracePhase{
+			Name:     name,
+			Started:  started,
+			Duration: time.Since(started).Milliseconds(),
+		}
+		if err != nil {
+			errMsg := redact.String(err.Error())
+			phase.Error = &errMsg
+		}
+		t.Lock()
+		defer t.Unlock()
+		t.phases = append(t.phases, phase)
+	}
+}
+
+func (t *operationTrace) recordAPICall(call k8s.APICall) {
+	if !t.isActive() {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	if len(t.apiCalls) >= maxTraceAPICalls {
+		t.truncated = true
+		return
+	}
+	t.apiCalls = append(t.apiCalls, reconciler.TraceAPICall{
+		Method:     call.Method,
+		Path:       call.Path,
+		StatusCode: call.StatusCode,
+		Started:    call.Started,
+		Duration:   call.Duration.Milliseconds(),
+	})
+}
+
+func (t *operationTrace) recordLog(log reconciler.TraceLog) {
+	t.Lock()
+	defer t.Unlock()
+	if len(t.logs) >= maxTraceLogs {
+		t.truncated = true
+		return
+	}
+	t.logs = append(t.logs, log)
+}
+
+//recordStack adds the sampled goroutine stacks of a stuck operation (only the latest samples are kept)
+func (t *operationTrace) recordStack(report *watchdog.Report) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	if len(t.stacks) >= maxTraceStacks {
+		t.stacks = t.stacks[1:]
+		t.truncated = true
+	}
+	t.stacks = append(t.stacks, reconciler.TraceStack{
+		Sampled:    report.Sampled,
+		Running:    report.Running.Milliseconds(),
+		Goroutines: report.Goroutines,
+		Stack:      report.Stack,
+	})
+}
+
+//logger returns a logger which also writes its debug logs into the trace while the trace is active
+func (t *operationTrace) logger(logger *zap.SugaredLogger) *zap.SugaredLogger {
+	if t == nil {
+		return logger
+	}
+	core := &traceCore{
+		trace: t,
+		encoder: zapcore.NewConsoleEncoder(zapcore.EncoderConfig{
+			MessageKey:     "msg",
+			EncodeDuration: zapcore.StringDurationEncoder,
+		}),
+	}
+	return logger.Desugar().WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(c, core)
+	})).Sugar()
+}
+
+//result returns the captured details (nil if the operation didn't exceed the threshold)
+func (t *operationTrace) result() *reconciler.OperationTrace {
+	if !t.isActive() {
+		return nil
+	}
+	t.Lock()
+	defer t.Unlock()
+	result := &reconciler.OperationTrace{
+		Threshold: t.threshold.Milliseconds(),
+		Phases:    append([]reconciler.TracePhase{}, t.phases...),
+		ApiCalls:  append([]reconciler.TraceAPICall{}, t.apiCalls...),
+		Logs:      append([]reconciler.TraceLog{}, t.logs...),
+		Truncated: t.truncated,
+	}
+	if len(t.stacks) > 0 {
+		stacks := append([]reconciler.TraceStack{}, t.stacks...)
+		result.Stacks = &stacks
+	}
+	return result
+}
+
+//traceCore writes log entries of all levels into an active trace
+type traceCore struct {
+	trace   *operationTrace
+	encoder zapcore.Encoder
+}
+
+func (c *traceCore) Enabled(zapcore.Level) bool {
+	return c.trace.isActive()
+}
+
+func (c *traceCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &traceCore{trace: c.trace, encoder: c.encoder.Clone()}
+	for i := range fields {
+		fields[i].AddTo(clone.encoder)
+	}
+	return clone
+}
+
+func (c *traceCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *traceCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+	c.trace.recordLog(reconciler.TraceLog{
+		Time:    entry.Time,
+		Level:   entry.Level.String(),
+		Message: redact.String(strings.TrimSpace(buf.String())),
+	})
+	return nil
+}
+
+func (c *traceCore) Sync() error {
+	return nil
+}
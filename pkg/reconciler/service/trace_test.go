@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/watchdog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationTrace(t *testing.T) {
+
+	t.Run("Trace is disabled without threshold", func(t *testing.T) {
+		ctx, trace := withOperationTrace(context.Background(), 0)
+		require.Nil(t, trace)
+		require.Nil(t, operationTraceFromContext(ctx))
+		trace.phase("reconcile")(nil)
+		trace.stop()
+		require.Nil(t, trace.result())
+	})
+
+	t.Run("Fast operation is not traced", func(t *testing.T) {
+		ctx, trace := withOperationTrace(context.Background(), time.Hour)
+		require.Same(t, trace, operationTraceFromContext(ctx))
+		trace.logger(logger.NewLogger(false)).Debug("not captured")
+		trace.phase("reconcile")(nil)
+		trace.stop()
+		require.Nil(t, trace.result())
+	})
+
+	t.Run("Slow operation is traced", func(t *testing.T) {
+		_, trace := withOperationTrace(context.Background(), 10*time.Millisecond)
+		defer trace.stop()
+		log := trace.logger(logger.NewLogger(false))
+
+		endPhase := trace.phase("pre-reconcile")
+		log.Debug("before threshold")
+		trace.recordAPICall(k8s.APICall{Method: "GET", Path: "/api"})
+		require.Eventually(t, trace.isActive, time.Second, 5*time.Millisecond)
+		endPhase(fmt.Errorf("pre-action failed"))
+
+		log.Debugf("debug log of component '%s'", "istio")
+		trace.recordAPICall(k8s.APICall{Method: "PATCH", Path: "/apis/apps/v1/deployments", StatusCode: 200})
+
+		result := trace.result()
+		require.NotNil(t, result)
+		require.Equal(t, int64(10), result.Threshold)
+		require.Len(t, result.Phases, 1)
+		require.Equal(t, "pre-reconcile", result.Phases[0].Name)
+		require.Equal(t, "pre-action failed", *result.Phases[0].Error)
+		require.Len(t, result.ApiCalls, 1)
+		require.Equal(t, "PATCH", result.ApiCalls[0].Method)
+		require.Equal(t, 200, result.ApiCalls[0].StatusCode)
+		require.Len(t, result.Logs, 1)
+		require.Equal(t, "debug", result.Logs[0].Level)
+		require.Equal(t, "debug log of component 'istio'", result.Logs[0].Message)
+		require.False(t, result.Truncated)
+	})
+
+	t.Run("Trace is truncated", func(t *testing.T) {
+		_, trace := withOperationTrace(context.Background(), time.Millisecond)
+		defer trace.stop()
+		require.Eventually(t, trace.isActive, time.Second, time.Millisecond)
+
+		for i := 0; i <= maxTraceAPICalls; i++ {
+			trace.recordAPICall(k8s.APICall{Method: "GET", Path: "/api"})
+		}
+		result := trace.result()
+		require.Len(t, result.ApiCalls, maxTraceAPICalls)
+		require.True(t, result.Truncated)
+	})
+
+	t.Run("Stacks of stuck operation are traced", func(t *testing.T) {
+		_, trace := withOperationTrace(context.Background(), time.Millisecond)
+		defer trace.stop()
+		require.Eventually(t, trace.isActive, time.Second, time.Millisecond)
+
+		for i := 1; i <= maxTraceStacks+1; i++ {
+			trace.recordStack(&watchdog.Report{Running: time.Duration(i) * time.Minute, Goroutines: 1, Stack: "main.work"})
+		}
+		result := trace.result()
+		require.NotNil(t, result.Stacks)
+		require.Len(t, *result.Stacks, maxTraceStacks)
+		require.Equal(t, (2 * time.Minute).Milliseconds(), (*result.Stacks)[0].Running) //oldest sample was dropped
+		require.True(t, result.Truncated)
+	})
+
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/features"
+)
+
+const (
+	tuningKeyWorkerCount      = "workerCount"
+	tuningKeyWorkerTimeout    = "workerTimeout"
+	tuningKeyRetryDelay       = "retryDelay"
+	tuningKeyStatusInterval   = "statusInterval"
+	tuningKeyProgressInterval = "progressInterval"
+	tuningKeyTraceThreshold   = "traceThreshold"
+	tuningKeyChartCacheSize   = "chartCacheSize"
+	tuningKeyFeaturePrefix    = "feature."
+)
+
+// Tuning contains the settings of a component reconciler which can be changed at runtime.
+// Settings which are not defined (nil) are not changed.
+type Tuning struct {
+	Workers          *int
+	WorkerTimeout    *time.Duration
+	RetryDelay       *time.Duration
+	StatusInterval   *time.Duration
+	ProgressInterval *time.Duration
+	TraceThreshold   *time.Duration  //0 disables the detailed capture of slow operations
+	ChartCacheSize   *int            //max. number of cached Kyma versions (0 = unlimited)
+	Features         map[string]bool //key is the env var name of the feature
+}
+
+// NewTuning parses the tuning settings from the data of a ConfigMap, e.g.:
+//
+//	workerCount: "20"
+//	workerTimeout: "15m"
+//	chartCacheSize: "5"
+//	feature.LOG_ISTIO_OPERATOR: "true"
+func NewTuning(data map[string]string) (*Tuning, error) {
+	tuning := &Tuning{Features: make(map[string]bool)}
+	for key, value := range data {
+		value = strings.TrimSpace(value)
+		var err error
+		switch {
+		case key == tuningKeyWorkerCount:
+			var workers int
+			if workers, err = strconv.Atoi(value); err == nil && workers <= 0 {
+				err = fmt.Errorf("worker count has to be > 0")
+			}
+			tuning.Workers = &workers
+		case key == tuningKeyWorkerTimeout:
+			tuning.WorkerTimeout, err = parsePositiveDuration(value)
+		case key == tuningKeyRetryDelay:
+			tuning.RetryDelay, err = parsePositiveDuration(value)
+		case key == tuningKeyStatusInterval:
+			tuning.StatusInterval, err = parsePositiveDuration(value)
+		case key == tuningKeyProgressInterval:
+			tuning.ProgressInterval, err = parsePositiveDuration(value)
+		case key == tuningKeyTraceThreshold:
+			var threshold time.Duration
+			if threshold, err = time.ParseDuration(value); err == nil && threshold < 0 {
+				err = fmt.Errorf("duration has to be >= 0")
+			}
+			tuning.TraceThreshold = &threshold
+		case key == tuningKeyChartCacheSize:
+			var cacheSize int
+			if cacheSize, err = strconv.Atoi(value); err == nil && cacheSize < 0 {
+				err = fmt.Errorf("chart cache size has to be >= 0")
+			}
+			tuning.ChartCacheSize = &cacheSize
+		case strings.HasPrefix(key, tuningKeyFeaturePrefix):
+			var enabled bool
+			enabled, err = strconv.ParseBool(value)
+			tuning.Features[strings.TrimPrefix(key, tuningKeyFeaturePrefix)] = enabled
+		default:
+			err = fmt.Errorf("setting is not supported")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid tuning setting '%s' (value: '%s'): %s", key, value, err)
+		}
+	}
+	return tuning, nil
+}
+
+func parsePositiveDuration(value string) (*time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, err
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("duration has to be > 0")
+	}
+	return &duration, nil
+}
+
+// Tune applies the tuning settings to the component reconciler: changes take effect for newly started reconciliations
+func (r *ComponentReconciler) Tune(tuning *Tuning) error {
+	for envVarName, enabled := range tuning.Features {
+		if err := features.Toggle(envVarName, enabled); err != nil {
+			return err
+		}
+	}
+	if tuning.ChartCacheSize != nil {
+		setChartCacheSize(*tuning.ChartCacheSize)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tuning.Workers != nil {
+		r.workers = *tuning.Workers
+	}
+	if tuning.WorkerTimeout != nil {
+		r.timeout = *tuning.WorkerTimeout
+		//heartbeat sender and progress tracker timeouts are coupled to the worker timeout
+		r.heartbeatSenderConfig.timeout = *tuning.WorkerTimeout
+		r.progressTrackerConfig.timeout = *tuning.WorkerTimeout
+	}
+	if tuning.RetryDelay != nil {
+		r.retryDelay = *tuning.RetryDelay
+	}
+	if tuning.StatusInterval != nil {
+		r.heartbeatSenderConfig.interval = *tuning.StatusInterval
+	}
+	if tuning.ProgressInterval != nil {
+		r.progressTrackerConfig.interval = *tuning.ProgressInterval
+	}
+	if tuning.TraceThreshold != nil {
+		r.traceThreshold = *tuning.TraceThreshold
+	}
+	return nil
+}
+
+// currentTuning returns the current settings of the component reconciler (all settings are defined):
+// it's used to restore the settings when the tuning gets removed
+func (r *ComponentReconciler) currentTuning() *Tuning {
+	chartCacheSize := getChartCacheSize()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	workers := r.workers
+	timeout := r.timeout
+	retryDelay := r.retryDelay
+	statusInterval := r.heartbeatSenderConfig.interval
+	progressInterval := r.progressTrackerConfig.interval
+	traceThreshold := r.traceThreshold
+	return &Tuning{
+		Workers:          &workers,
+		WorkerTimeout:    &timeout,
+		RetryDelay:       &retryDelay,
+		StatusInterval:   &statusInterval,
+		ProgressInterval: &progressInterval,
+		TraceThreshold:   &traceThreshold,
+		ChartCacheSize:   &chartCacheSize,
+		Features:         features.States(),
+	}
+}
+
+// chartCache is implemented by workspace factories which limit the number of cached Kyma versions
+type chartCache interface {
+	SetCacheSize(size int)
+}
+
+// setChartCacheSize changes the cache size of the global workspace factory: the size is also applied
+// to the workspace factory if it gets created later
+func setChartCacheSize(size int) {
+	m.Lock()
+	defer m.Unlock()
+
+	wsCacheSize = size
+	if cache, ok := wsFactory.(chartCache); ok {
+		cache.SetCacheSize(size)
+	}
+}
+
+func getChartCacheSize() int {
+	m.Lock()
+	defer m.Unlock()
+
+	return wsCacheSize
+}
+
+// tunables contains a consistent snapshot of the settings which can be changed by tuning the component reconciler
+type tunables struct {
+	timeout               time.Duration
+	retryDelay            time.Duration
+	heartbeatSenderConfig heartbeatSenderConfig
+	progressTrackerConfig progressTrackerConfig
+	traceThreshold        time.Duration
+}
+
+func (r *ComponentReconciler) tunables() tunables {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return tunables{
+		timeout:               r.timeout,
+		retryDelay:            r.retryDelay,
+		heartbeatSenderConfig: r.heartbeatSenderConfig,
+		progressTrackerConfig: r.progressTrackerConfig,
+		traceThreshold:        r.traceThreshold,
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/features"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTuning(t *testing.T) {
+
+	t.Run("Parse tuning settings", func(t *testing.T) {
+		tuning, err := NewTuning(map[string]string{
+			"workerCount":                "20",
+			"workerTimeout":              "15m",
+			"retryDelay":                 "10s",
+			"statusInterval":             "20s",
+			"progressInterval":           "5s",
+			"traceThreshold":             "0",
+			"chartCacheSize":             "5",
+			"feature.LOG_ISTIO_OPERATOR": "true",
+		})
+		require.NoError(t, err)
+		require.Equal(t, 20, *tuning.Workers)
+		require.Equal(t, 15*time.Minute, *tuning.WorkerTimeout)
+		require.Equal(t, 10*time.Second, *tuning.RetryDelay)
+		require.Equal(t, 20*time.Second, *tuning.StatusInterval)
+		require.Equal(t, 5*time.Second, *tuning.ProgressInterval)
+		require.Equal(t, time.Duration(0), *tuning.TraceThreshold)
+		require.Equal(t, 5, *tuning.ChartCacheSize)
+		require.Equal(t, map[string]bool{"LOG_ISTIO_OPERATOR": true}, tuning.Features)
+	})
+
+	t.Run("Reject invalid tuning settings", func(t *testing.T) {
+		for _, data := range []map[string]string{
+			{"workerCount": "0"},
+			{"workerCount": "abc"},
+			{"workerTimeout": "-1m"},
+			{"retryDelay": "soon"},
+			{"traceThreshold": "-5m"},
+			{"chartCacheSize": "-1"},
+			{"feature.LOG_ISTIO_OPERATOR": "maybe"},
+			{"unknownSetting": "1"},
+		} {
+			_, err := NewTuning(data)
+			require.Error(t, err, "expected error for %v", data)
+		}
+	})
+
+	t.Run("Apply tuning to component reconciler", func(t *testing.T) {
+		recon, err := NewComponentReconciler("unittest")
+		require.NoError(t, err)
+		recon.WithWorkers(10, 5*time.Minute).
+			WithRetryDelay(30*time.Second).
+			WithHeartbeatSenderConfig(30*time.Second, 5*time.Minute).
+			WithProgressTrackerConfig(15*time.Second, 5*time.Minute)
+
+		tuning, err := NewTuning(map[string]string{
+			"workerTimeout":              "15m",
+			"progressInterval":           "5s",
+			"traceThreshold":             "5m",
+			"feature.LOG_ISTIO_OPERATOR": "true",
+		})
+		require.NoError(t, err)
+		require.NoError(t, recon.Tune(tuning))
+		defer func() {
+			require.NoError(t, os.Unsetenv("LOG_ISTIO_OPERATOR"))
+		}()
+
+		settings := recon.tunables()
+		require.Equal(t, 15*time.Minute, settings.timeout)
+		require.Equal(t, 15*time.Minute, settings.heartbeatSenderConfig.timeout)
+		require.Equal(t, 15*time.Minute, settings.progressTrackerConfig.timeout)
+		require.Equal(t, 5*time.Second, settings.progressTrackerConfig.interval)
+		require.Equal(t, 5*time.Minute, settings.traceThreshold)
+		require.Equal(t, 30*time.Second, settings.heartbeatSenderConfig.interval) //unchanged
+		require.Equal(t, 30*time.Second, settings.retryDelay)                     //unchanged
+		require.Equal(t, 10, recon.workers)                                       //unchanged
+		require.True(t, features.Enabled(features.LogIstioOperator))
+	})
+
+	t.Run("Apply chart cache size to workspace factory", func(t *testing.T) {
+		m.Lock()
+		previousFactory := wsFactory
+		m.Unlock()
+		cache := &chartCacheStub{}
+		require.NoError(t, RefreshGlobalWorkspaceFactory(cache))
+		defer func() {
+			setChartCacheSize(0)
+			require.NoError(t, RefreshGlobalWorkspaceFactory(previousFactory))
+		}()
+
+		recon, err := NewComponentReconciler("unittest")
+		require.NoError(t, err)
+		tuning, err := NewTuning(map[string]string{"chartCacheSize": "3"})
+		require.NoError(t, err)
+		require.NoError(t, recon.Tune(tuning))
+
+		require.Equal(t, 3, cache.size)
+		require.Equal(t, 3, *recon.currentTuning().ChartCacheSize)
+	})
+
+}
+
+type chartCacheStub struct {
+	chart.Factory
+	size int
+}
+
+func (c *chartCacheStub) SetCacheSize(size int) {
+	c.size = size
+}
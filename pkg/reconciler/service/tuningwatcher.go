@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+//TuningWatcher watches a ConfigMap on the cluster the component reconciler is running on
+//and re-tunes the component reconciler and its worker pool whenever the ConfigMap changes.
+//If the ConfigMap gets deleted, the settings the component reconciler was started with are restored.
+type TuningWatcher struct {
+	client     kubernetes.Interface
+	namespace  string
+	name       string
+	recon      *ComponentReconciler
+	workerPool *WorkerPool
+	startup    *Tuning //settings before the first tuning was applied
+	logger     *zap.SugaredLogger
+}
+
+//NewInClusterTuningWatcher creates a tuning watcher which uses the service account of the component reconciler
+func NewInClusterTuningWatcher(namespace, name string, recon *ComponentReconciler, workerPool *WorkerPool, logger *zap.SugaredLogger) (*TuningWatcher, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return NewTuningWatcher(client, namespace, name, recon, workerPool, logger), nil
+}
+
+func NewTuningWatcher(client kubernetes.Interface, namespace, name string, recon *ComponentReconciler, workerPool *WorkerPool, logger *zap.SugaredLogger) *TuningWatcher {
+	return &TuningWatcher{
+		client:     client,
+		namespace:  namespace,
+		name:       name,
+		recon:      recon,
+		workerPool: workerPool,
+		logger:     logger,
+	}
+}
+
+//Run starts watching the ConfigMap until the context gets closed (non-blocking)
+func (w *TuningWatcher) Run(ctx context.Context) {
+	w.startup = w.recon.currentTuning()
+
+	factory := informers.NewSharedInformerFactoryWithOptions(w.client, 0,
+		informers.WithNamespace(w.namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", w.name).String()
+		}))
+
+	factory.Core().V1().ConfigMaps().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.apply(obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			w.apply(newObj)
+		},
+		DeleteFunc: func(_ interface{}) {
+			w.reset()
+		},
+	})
+
+	w.logger.Infof("Watching ConfigMap '%s' in namespace '%s' for component reconciler tuning", w.name, w.namespace)
+	factory.Start(ctx.Done())
+}
+
+func (w *TuningWatcher) apply(obj interface{}) {
+	configMap, ok := obj.(*v1.ConfigMap)
+	if !ok {
+		return
+	}
+
+	tuning, err := NewTuning(configMap.Data)
+	if err != nil {
+		w.logger.Errorf("Ignoring tuning ConfigMap '%s' (namespace: %s): %s", w.name, w.namespace, err)
+		return
+	}
+	if err := w.tune(tuning); err != nil {
+		w.logger.Errorf("Failed to tune component reconciler using ConfigMap '%s' (namespace: %s): %s",
+			w.name, w.namespace, err)
+		return
+	}
+	w.logger.Infof("Component reconciler tuned using ConfigMap '%s' (namespace: %s, resource version: %s)",
+		w.name, w.namespace, configMap.ResourceVersion)
+}
+
+//reset restores the settings the component reconciler had before the ConfigMap was applied
+func (w *TuningWatcher) reset() {
+	if err := w.tune(w.startup); err != nil {
+		w.logger.Errorf("Failed to restore startup settings of component reconciler after ConfigMap '%s' "+
+			"(namespace: %s) was deleted: %s", w.name, w.namespace, err)
+		return
+	}
+	w.logger.Infof("Startup settings of component reconciler restored because ConfigMap '%s' (namespace: %s) "+
+		"was deleted", w.name, w.namespace)
+}
+
+func (w *TuningWatcher) tune(tuning *Tuning) error {
+	if err := w.recon.Tune(tuning); err != nil {
+		return err
+	}
+	if tuning.Workers != nil && w.workerPool != nil {
+		w.workerPool.Resize(*tuning.Workers)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTuningWatcher(t *testing.T) {
+	const (
+		namespace = "kyma-system"
+		name      = "reconciler-tuning"
+	)
+
+	recon, err := NewComponentReconciler("unittest")
+	require.NoError(t, err)
+	recon.WithWorkers(10, 5*time.Minute).
+		WithRetryDelay(30 * time.Second)
+
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data: map[string]string{
+			"workerCount":   "20",
+			"workerTimeout": "15m",
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	NewTuningWatcher(client, namespace, name, recon, nil, zap.NewNop().Sugar()).Run(ctx)
+
+	//ConfigMap is applied
+	require.Eventually(t, func() bool {
+		return recon.tunables().timeout == 15*time.Minute
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 20, *recon.currentTuning().Workers)
+
+	//startup settings are restored when the ConfigMap gets deleted
+	require.NoError(t, client.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool {
+		return recon.tunables().timeout == 5*time.Minute
+	}, 5*time.Second, 10*time.Millisecond)
+	settings := recon.currentTuning()
+	require.Equal(t, 10, *settings.Workers)
+	require.Equal(t, 30*time.Second, *settings.RetryDelay)
+	require.Equal(t, 0, *settings.ChartCacheSize)
+}
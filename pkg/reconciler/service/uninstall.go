@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	kubeclient "github.com/kyma-incubator/reconciler/pkg/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+)
+
+//statefulResources returns the resources of the manifest which contain user data and exist on the target cluster:
+//PVCs and namespaces which contain PVCs. Their deletion has to be confirmed by an operator.
+func statefulResources(ctx context.Context, clientset kubernetes.Interface, unstructs []*unstructured.Unstructured,
+	namespace string) ([]string, error) {
+	var result []string
+	for _, u := range unstructs {
+		switch u.GetKind() {
+		case "PersistentVolumeClaim":
+			pvcNamespace := k8s.ResolveNamespace(u, namespace)
+			_, err := clientset.CoreV1().PersistentVolumeClaims(pvcNamespace).Get(ctx, u.GetName(), metav1.GetOptions{})
+			if err != nil {
+				if k8serr.IsNotFound(err) {
+					continue
+				}
+				return nil, errors.Wrapf(err, "failed to retrieve PVC '%s/%s'", pvcNamespace, u.GetName())
+			}
+			result = append(result, fmt.Sprintf("PersistentVolumeClaim/%s/%s", pvcNamespace, u.GetName()))
+		case "Namespace":
+			pvcs, err := clientset.CoreV1().PersistentVolumeClaims(u.GetName()).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to list PVCs of namespace '%s'", u.GetName())
+			}
+			if len(pvcs.Items) > 0 {
+				result = append(result, fmt.Sprintf("Namespace/%s", u.GetName()))
+			}
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+//pendingDeletion returns the stateful resources which the uninstall of the component would remove. Only the default
+//uninstall is verified because custom delete actions decide on their own which resources they remove.
+func (r *runner) pendingDeletion(ctx context.Context, task *reconciler.Task) ([]string, error) {
+	if r.deleteAction != nil || task.Component == model.CRDComponent || task.Component == model.CleanupComponent {
+		return nil, nil
+	}
+	chartProvider, err := r.newChartProvider(task.Repository)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create chart provider instance")
+	}
+	manifest, err := r.install.manifest(ctx, chartProvider, task)
+	if err != nil {
+		return nil, err
+	}
+	unstructs, err := manifest.Unstructs()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubeclient.NewClientBuilder().WithLogger(r.logger).WithString(task.Kubeconfig).Build(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	return statefulResources(ctx, clientset, unstructs, task.Namespace)
+}
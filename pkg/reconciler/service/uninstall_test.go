@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const statefulManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: monitoring
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: empty
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: storage
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: missing
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`
+
+func TestStatefulResources(t *testing.T) {
+	unstructs, err := k8s.ToUnstructured([]byte(statefulManifest), true)
+	require.NoError(t, err)
+
+	t.Run("Existing stateful resources", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitoring"}},
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "empty"}},
+			&v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "monitoring"}},
+			&v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "storage", Namespace: "kyma-system"}},
+			&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "kyma-system"}},
+		)
+		resources, err := statefulResources(context.Background(), clientset, unstructs, "kyma-system")
+		require.NoError(t, err)
+		//namespaces without PVCs and missing PVCs don't require a confirmation
+		require.Equal(t, []string{"Namespace/monitoring", "PersistentVolumeClaim/kyma-system/storage"}, resources)
+	})
+
+	t.Run("No stateful resources", func(t *testing.T) {
+		resources, err := statefulResources(context.Background(), fake.NewSimpleClientset(), unstructs, "kyma-system")
+		require.NoError(t, err)
+		require.Empty(t, resources)
+	})
+}
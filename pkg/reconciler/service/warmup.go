@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+//warmer prepares the workspaces of Kyma versions in the background (one version at a time)
+type warmer struct {
+	mu      sync.Mutex
+	pending map[string]bool
+}
+
+//start prepares the versions which aren't already prepared by a previous warm-up: it returns the versions
+//which were scheduled without waiting for their preparation
+func (w *warmer) start(ctx context.Context, versions []string, prepare func(version string) error, logger *zap.SugaredLogger) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.pending == nil {
+		w.pending = make(map[string]bool)
+	}
+
+	scheduled := []string{}
+	for _, version := range versions {
+		version = strings.TrimSpace(version)
+		if version == "" || version == chart.VersionLocal || w.pending[version] {
+			continue
+		}
+		w.pending[version] = true
+		scheduled = append(scheduled, version)
+	}
+	if len(scheduled) == 0 {
+		return scheduled
+	}
+
+	go func() {
+		for _, version := range scheduled {
+			if ctx.Err() == nil {
+				if err := prepare(version); err != nil {
+					logger.Warnf("Warm-up of Kyma version '%s' failed: %s", version, err)
+				} else {
+					logger.Infof("Warm-up of Kyma version '%s' finished", version)
+				}
+			}
+			w.done(version)
+		}
+	}()
+	return scheduled
+}
+
+func (w *warmer) done(version string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.pending, version)
+}
+
+//Warmup pre-fetches the charts of upcoming Kyma versions and renders their CRDs: the first operations of a new
+//version don't have to wait for the download of the charts anymore. The versions are prepared in the background,
+//the returned versions are the ones which were scheduled for the warm-up.
+func (r *ComponentReconciler) Warmup(ctx context.Context, versions []string) []string {
+	return r.warmer.start(ctx, versions, func(version string) error {
+		//the default Kyma repository is used (same as for tasks which don't define a repository)
+		chartProvider, err := r.newChartProvider(nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to create chart provider")
+		}
+		r.logger.Debugf("Warming up workspace of Kyma version '%s'", version)
+		if _, err := chartProvider.RenderCRD(version); err != nil {
+			return errors.Wrapf(err, "failed to render CRDs of Kyma version '%s'", version)
+		}
+		return nil
+	}, r.logger)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarmer(t *testing.T) {
+	t.Run("Versions are prepared in the background", func(t *testing.T) {
+		w := &warmer{}
+		var mu sync.Mutex
+		var prepared []string
+		scheduled := w.start(context.Background(), []string{"2.0.0", " ", chart.VersionLocal, "2.1.0", "2.0.0"},
+			func(version string) error {
+				mu.Lock()
+				defer mu.Unlock()
+				prepared = append(prepared, version)
+				if version == "2.0.0" {
+					return errors.New("download failed") //failures don't stop the warm-up of other versions
+				}
+				return nil
+			}, logger.NewLogger(true))
+		require.Equal(t, []string{"2.0.0", "2.1.0"}, scheduled)
+
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(prepared) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, []string{"2.0.0", "2.1.0"}, prepared)
+	})
+
+	t.Run("Pending versions are not scheduled twice", func(t *testing.T) {
+		w := &warmer{}
+		release := make(chan struct{})
+		prepare := func(version string) error {
+			<-release
+			return nil
+		}
+		require.Equal(t, []string{"2.0.0"}, w.start(context.Background(), []string{"2.0.0"}, prepare, logger.NewLogger(true)))
+		require.Empty(t, w.start(context.Background(), []string{"2.0.0"}, prepare, logger.NewLogger(true)))
+		close(release)
+
+		//version can be warmed up again after its preparation finished
+		require.Eventually(t, func() bool {
+			return len(w.start(context.Background(), []string{"2.0.0"}, prepare, logger.NewLogger(true))) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Cancelled warm-up", func(t *testing.T) {
+		w := &warmer{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var called bool
+		require.Len(t, w.start(ctx, []string{"2.0.0"}, func(version string) error {
+			called = true
+			return nil
+		}, logger.NewLogger(true)), 1)
+		require.Eventually(t, func() bool {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			return len(w.pending) == 0
+		}, 5*time.Second, 10*time.Millisecond)
+		require.False(t, called)
+	})
+}
@@ -2,10 +2,16 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/callback/client"
+	"github.com/kyma-incubator/reconciler/pkg/kubeconfigref"
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
 	"github.com/panjf2000/ants/v2"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -16,10 +22,12 @@ type workPoolBuilder struct {
 }
 
 type WorkerPool struct {
-	debug        bool
-	logger       *zap.SugaredLogger
-	antsPool     *ants.Pool
-	newRunnerFct func(context.Context, *reconciler.Task, callback.Handler, *zap.SugaredLogger) func() error
+	debug              bool
+	logger             *zap.SugaredLogger
+	antsPool           *ants.Pool
+	newRunnerFct       func(context.Context, *reconciler.Task, callback.Handler, *zap.SugaredLogger) func() error
+	callbackDispatcher *callback.Dispatcher
+	callbackClient     *client.Client
 }
 
 func newWorkerPoolBuilder(newRunnerFct func(context.Context, *reconciler.Task, callback.Handler, *zap.SugaredLogger) func() error) *workPoolBuilder {
@@ -41,6 +49,16 @@ func (pb *workPoolBuilder) WithDebug(debug bool) *workPoolBuilder {
 	return pb
 }
 
+func (pb *workPoolBuilder) WithCallbackDispatcher(dispatcher *callback.Dispatcher) *workPoolBuilder {
+	pb.workerPool.callbackDispatcher = dispatcher
+	return pb
+}
+
+func (pb *workPoolBuilder) WithCallbackClient(mothershipClient *client.Client) *workPoolBuilder {
+	pb.workerPool.callbackClient = mothershipClient
+	return pb
+}
+
 func (pb *workPoolBuilder) Build(ctx context.Context) (*WorkerPool, error) {
 	//add logger
 	log := logger.NewLogger(pb.workerPool.debug)
@@ -72,16 +90,37 @@ func (wa *WorkerPool) AssignWorker(ctx context.Context, model *reconciler.Task)
 		zap.Field{Key: "component-name", Type: zapcore.StringType, String: model.Component})
 
 	//create callback handler
-	remoteCbh, err := callback.NewRemoteCallbackHandler(model.CallbackURL, loggerNew)
+	var remoteCbh callback.Handler
+	var err error
+	if wa.callbackDispatcher != nil {
+		remoteCbh, err = callback.NewDispatchingCallbackHandler(model.CallbackURL, wa.callbackDispatcher, loggerNew)
+	} else if wa.callbackClient != nil {
+		remoteCbh, err = callback.NewRemoteCallbackHandlerWithClient(model.CallbackURL, wa.callbackClient, loggerNew)
+	} else {
+		remoteCbh, err = callback.NewRemoteCallbackHandler(model.CallbackURL, loggerNew)
+	}
 	if err != nil {
 		wa.logger.Errorf("Failed to start reconciliation of model '%s'! "+
 			"Could not create remote callback handler - not able to process : %s", model, err)
 		return err
 	}
+	remoteCbh = callback.WithSequence(remoteCbh, model.CallbackSequence)
 
 	//assign runner to worker
 	err = wa.antsPool.Submit(func() {
 		wa.logger.Debugf("Runner for model '%s' is assigned to worker", model)
+		if model.KubeconfigRef != nil {
+			if errFetch := wa.fetchKubeconfig(ctx, model); errFetch != nil {
+				wa.logger.Warnf("Runner not started for model '%s': %v", model, errFetch)
+				if errCb := remoteCbh.Callback(&reconciler.CallbackMessage{
+					Error:  errFetch.Error(),
+					Status: reconciler.StatusError,
+				}); errCb != nil {
+					wa.logger.Errorf("Failed to report kubeconfig failure of model '%s': %v", model, errCb)
+				}
+				return
+			}
+		}
 		runnerFunc := wa.newRunnerFct(ctx, model, remoteCbh, loggerNew)
 		if errRunner := runnerFunc(); errRunner != nil {
 			wa.logger.Warnf("Runner failed for model '%s': %v", model, errRunner)
@@ -91,6 +130,30 @@ func (wa *WorkerPool) AssignWorker(ctx context.Context, model *reconciler.Task)
 	return err
 }
 
+//fetchKubeconfig resolves the kubeconfig reference of the task: the mothership hands out the kubeconfig only during
+//the operation and only to callers which authenticate with a client certificate
+func (wa *WorkerPool) fetchKubeconfig(ctx context.Context, model *reconciler.Task) error {
+	mothershipClient := wa.callbackClient
+	if mothershipClient == nil {
+		mothershipClient = client.Default()
+	}
+	resp, err := mothershipClient.Get(ctx, model.KubeconfigRef.URL, http.Header{
+		kubeconfigref.TokenHeader: {model.KubeconfigRef.Token},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch kubeconfig from mothership: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch kubeconfig from mothership: HTTP response code %d", resp.StatusCode)
+	}
+	kubeconfigResp := &reconciler.KubeconfigResponse{}
+	if err := resp.Decode(kubeconfigResp); err != nil || kubeconfigResp.Kubeconfig == "" {
+		return errors.New("mothership returned an invalid kubeconfig response")
+	}
+	model.Kubeconfig = kubeconfigResp.Kubeconfig
+	return nil
+}
+
 func (wa *WorkerPool) IsClosed() bool {
 	if wa.antsPool == nil {
 		return true
@@ -109,3 +172,12 @@ func (wa *WorkerPool) Size() int {
 func (wa *WorkerPool) IsFull() bool {
 	return wa.RunningWorkers() >= wa.Size()
 }
+
+//Resize changes the number of workers at runtime
+func (wa *WorkerPool) Resize(size int) {
+	if size <= 0 || wa.antsPool == nil {
+		return
+	}
+	wa.logger.Infof("Resizing worker pool from %d to %d workers", wa.antsPool.Cap(), size)
+	wa.antsPool.Tune(size)
+}
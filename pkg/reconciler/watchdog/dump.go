@@ -0,0 +1,37 @@
+package watchdog
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/signal"
+	"runtime/pprof"
+
+	"go.uber.org/zap"
+)
+
+//DumpOnSignal logs the stacks of all goroutines whenever the process receives a dump signal (SIGUSR1 on
+//Unix systems) until the context gets closed
+func DumpOnSignal(ctx context.Context, logger *zap.SugaredLogger) {
+	if len(dumpSignals) == 0 {
+		return
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, dumpSignals...)
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				var buf bytes.Buffer
+				if err := pprof.Lookup("goroutine").WriteTo(&buf, 2); err != nil {
+					logger.Warnf("Failed to dump goroutine stacks: %s", err)
+					continue
+				}
+				logger.Infof("Goroutine dump:\n%s", buf.String())
+			}
+		}
+	}()
+}
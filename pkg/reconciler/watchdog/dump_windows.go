@@ -0,0 +1,9 @@
+//go:build windows
+// +build windows
+
+package watchdog
+
+import "os"
+
+//dump signals are not supported on Windows
+var dumpSignals []os.Signal
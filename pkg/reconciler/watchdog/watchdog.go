@@ -0,0 +1,251 @@
+package watchdog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	labelCorrelationID = "correlationID"
+	labelComponent     = "component"
+	minSampleInterval  = time.Second
+	maxStackSize       = 64 * 1024
+)
+
+//Report describes an operation which is suspected to be stuck
+type Report struct {
+	CorrelationID string
+	Component     string
+	Running       time.Duration
+	Goroutines    int
+	Stack         string
+	Sampled       time.Time
+}
+
+type operation struct {
+	correlationID string
+	component     string
+	started       time.Time
+	stuck         bool
+	onStuck       []func(report *Report)
+}
+
+type operationContextKey struct{}
+
+//Watchdog detects operations which are running far beyond their expected duration and samples the goroutine
+//stacks of these operations: goroutines of an operation are identified by their pprof labels
+type Watchdog struct {
+	threshold time.Duration
+	interval  time.Duration
+	logger    *zap.SugaredLogger
+
+	m          sync.Mutex
+	operations map[string]*operation
+	detected   int64
+}
+
+//New returns a watchdog which treats operations running longer than the threshold as stuck
+func New(threshold time.Duration, logger *zap.SugaredLogger) (*Watchdog, error) {
+	if threshold <= 0 {
+		return nil, fmt.Errorf("threshold of watchdog has to be > 0 (got %.1f secs)", threshold.Seconds())
+	}
+	interval := threshold / 4
+	if interval < minSampleInterval {
+		interval = minSampleInterval
+	}
+	return &Watchdog{
+		threshold:  threshold,
+		interval:   interval,
+		logger:     logger,
+		operations: make(map[string]*operation),
+	}, nil
+}
+
+//Watch runs the operation with pprof labels (inherited by all goroutines started by the operation) and
+//observes it until the function returns
+func (w *Watchdog) Watch(ctx context.Context, correlationID, component string, fn func(ctx context.Context)) {
+	if w == nil {
+		fn(ctx)
+		return
+	}
+	op := &operation{
+		correlationID: correlationID,
+		component:     component,
+		started:       time.Now(),
+	}
+	w.m.Lock()
+	w.operations[correlationID] = op
+	w.m.Unlock()
+	defer func() {
+		w.m.Lock()
+		defer w.m.Unlock()
+		delete(w.operations, correlationID)
+	}()
+
+	ctx = context.WithValue(ctx, operationContextKey{}, op)
+	pprof.Do(ctx, pprof.Labels(labelCorrelationID, correlationID, labelComponent, component), fn)
+}
+
+//OnStuck registers a function which receives the reports of the watched operation of the context
+//(no-op if the context doesn't belong to a watched operation)
+func (w *Watchdog) OnStuck(ctx context.Context, fn func(report *Report)) {
+	if w == nil {
+		return
+	}
+	op, ok := ctx.Value(operationContextKey{}).(*operation)
+	if !ok {
+		return
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	op.onStuck = append(op.onStuck, fn)
+}
+
+//Run samples the running operations in an interval until the context gets closed
+func (w *Watchdog) Run(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.check(time.Now())
+			}
+		}
+	}()
+}
+
+func (w *Watchdog) check(now time.Time) {
+	type suspect struct {
+		op         operation
+		newlyStuck bool
+	}
+	var suspects []suspect
+	w.m.Lock()
+	for _, op := range w.operations {
+		if now.Sub(op.started) < w.threshold {
+			continue
+		}
+		suspects = append(suspects, suspect{op: *op, newlyStuck: !op.stuck})
+		if !op.stuck {
+			op.stuck = true
+			w.detected++
+		}
+	}
+	w.m.Unlock()
+
+	if len(suspects) == 0 {
+		return
+	}
+	profile, err := goroutineProfile()
+	if err != nil {
+		w.logger.Warnf("Watchdog failed to sample goroutine stacks: %s", err)
+		return
+	}
+	for _, s := range suspects {
+		report := &Report{
+			CorrelationID: s.op.correlationID,
+			Component:     s.op.component,
+			Running:       now.Sub(s.op.started),
+			Sampled:       now,
+		}
+		report.Goroutines, report.Stack = filterStacks(profile, s.op.correlationID)
+		if s.newlyStuck {
+			w.logger.Warnf("Watchdog detected operation '%s' of component '%s' which is running since %.0f secs "+
+				"(threshold: %.0f secs) in %d goroutines:\n%s", report.CorrelationID, report.Component,
+				report.Running.Seconds(), w.threshold.Seconds(), report.Goroutines, report.Stack)
+		} else {
+			w.logger.Debugf("Watchdog sampled operation '%s' of component '%s' which is running since %.0f secs",
+				report.CorrelationID, report.Component, report.Running.Seconds())
+		}
+		for _, onStuck := range s.op.onStuck {
+			onStuck(report)
+		}
+	}
+}
+
+//StuckOperations returns the number of currently stuck operations per component
+func (w *Watchdog) StuckOperations() map[string]int {
+	w.m.Lock()
+	defer w.m.Unlock()
+	result := make(map[string]int)
+	for _, op := range w.operations {
+		if op.stuck {
+			result[op.component]++
+		}
+	}
+	return result
+}
+
+//DetectedStuckOperations returns the number of operations which were detected as stuck since the start
+func (w *Watchdog) DetectedStuckOperations() int64 {
+	w.m.Lock()
+	defer w.m.Unlock()
+	return w.detected
+}
+
+func goroutineProfile() (string, error) {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+//filterStacks returns the stacks of the goroutines labelled with the correlation ID: the profile contains
+//blocks of goroutines with identical stacks separated by empty lines
+func filterStacks(profile, correlationID string) (int, string) {
+	label := fmt.Sprintf(`"%s":"%s"`, labelCorrelationID, correlationID)
+	var goroutines int
+	var stacks strings.Builder
+	for _, block := range strings.Split(profile, "\n\n") {
+		if !hasLabel(block, label) {
+			continue
+		}
+		goroutines += goroutineCount(block)
+		if stacks.Len()+len(block) > maxStackSize {
+			stacks.WriteString("...truncated\n")
+			break
+		}
+		stacks.WriteString(block)
+		stacks.WriteString("\n\n")
+	}
+	return goroutines, strings.TrimSpace(stacks.String())
+}
+
+func hasLabel(block, label string) bool {
+	scanner := bufio.NewScanner(strings.NewReader(block))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "# labels:") && strings.Contains(line, label) {
+			return true
+		}
+	}
+	return false
+}
+
+//goroutineCount returns the number of goroutines of a block which starts with "<count> @ <addresses>"
+//(the first block is preceded by the header of the profile)
+func goroutineCount(block string) int {
+	for _, line := range strings.Split(block, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[1] != "@" {
+			continue
+		}
+		if count, err := strconv.Atoi(fields[0]); err == nil {
+			return count
+		}
+	}
+	return 0
+}
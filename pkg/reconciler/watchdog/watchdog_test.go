@@ -0,0 +1,103 @@
+package watchdog
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func blockedOperation(release <-chan struct{}) {
+	<-release
+}
+
+func TestWatchdog(t *testing.T) {
+
+	t.Run("Threshold is required", func(t *testing.T) {
+		_, err := New(0, zap.NewNop().Sugar())
+		require.Error(t, err)
+	})
+
+	t.Run("Stuck operation is detected", func(t *testing.T) {
+		wd, err := New(time.Hour, zap.NewNop().Sugar())
+		require.NoError(t, err)
+
+		var m sync.Mutex
+		var reports []*Report
+		release := make(chan struct{})
+		watching := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			wd.Watch(context.Background(), "abc-123", "istio", func(ctx context.Context) {
+				wd.OnStuck(ctx, func(report *Report) {
+					m.Lock()
+					defer m.Unlock()
+					reports = append(reports, report)
+				})
+				close(watching)
+				blockedOperation(release)
+			})
+		}()
+		<-watching
+
+		//operation is below threshold
+		wd.check(time.Now())
+		require.Empty(t, wd.StuckOperations())
+
+		//operation exceeded threshold
+		wd.check(time.Now().Add(2 * time.Hour))
+		require.Equal(t, map[string]int{"istio": 1}, wd.StuckOperations())
+		require.Equal(t, int64(1), wd.DetectedStuckOperations())
+
+		//repeated samples don't count the operation twice
+		wd.check(time.Now().Add(3 * time.Hour))
+		require.Equal(t, int64(1), wd.DetectedStuckOperations())
+
+		m.Lock()
+		require.Len(t, reports, 2)
+		require.Equal(t, "abc-123", reports[0].CorrelationID)
+		require.Equal(t, "istio", reports[0].Component)
+		require.Equal(t, 1, reports[0].Goroutines)
+		require.Contains(t, reports[0].Stack, "blockedOperation")
+		m.Unlock()
+
+		close(release)
+		<-done
+		require.Empty(t, wd.StuckOperations())
+		require.Equal(t, int64(1), wd.DetectedStuckOperations())
+	})
+
+	t.Run("Watchdog is optional", func(t *testing.T) {
+		var wd *Watchdog
+		var called bool
+		wd.Watch(context.Background(), "abc-123", "istio", func(ctx context.Context) {
+			wd.OnStuck(ctx, func(report *Report) {})
+			called = true
+		})
+		require.True(t, called)
+	})
+}
+
+func TestFilterStacks(t *testing.T) {
+	profile := `goroutine profile: total 4
+2 @ 0x1 0x2
+# labels: {"component":"istio", "correlationID":"abc"}
+#	0x1	main.work+0x1	main.go:10
+
+1 @ 0x3
+# labels: {"component":"istio", "correlationID":"abcd"}
+#	0x3	main.other+0x1	main.go:20
+
+1 @ 0x4
+#	0x4	main.idle+0x1	main.go:30
+`
+	goroutines, stack := filterStacks(profile, "abc")
+	require.Equal(t, 2, goroutines)
+	require.Contains(t, stack, "main.work")
+	require.NotContains(t, stack, "main.other")
+	require.NotContains(t, stack, "main.idle")
+}
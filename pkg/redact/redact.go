@@ -0,0 +1,236 @@
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+//Mask replaces sensitive data
+const Mask = "***REDACTED***"
+
+//defaultKeys are (case-insensitive) fragments of keys whose values are always treated as sensitive
+var defaultKeys = []string{
+	"kubeconfig",
+	"token",
+	"password",
+	"passwd",
+	"secret",
+	"credential",
+	"apikey",
+	"privatekey",
+	"authorization",
+}
+
+type pattern struct {
+	regex       *regexp.Regexp
+	replacement string
+}
+
+//defaultPatterns detect sensitive data in free text (e.g. error messages or log messages)
+var defaultPatterns = []pattern{
+	//PEM encoded private keys
+	{regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`), Mask},
+	//bearer tokens (e.g. in dumped HTTP headers)
+	{regexp.MustCompile(`(?i)(bearer\s+)[a-z0-9\-._~+/]+=*`), "${1}" + Mask},
+	//JSON web tokens (e.g. service account tokens)
+	{regexp.MustCompile(`eyJ[a-zA-Z0-9_\-]+\.[a-zA-Z0-9_\-]+\.[a-zA-Z0-9_\-]*`), Mask},
+	//credentials of kubeconfigs and YAML/JSON/property formatted key-value pairs
+	{regexp.MustCompile(`(?i)((?:client-key-data|token|id-token|refresh-token|access-token|password|client-secret)["']?\s*[:=]\s*["']?)[^\s"',}]+`), "${1}" + Mask},
+	//basic-auth credentials in URLs
+	{regexp.MustCompile(`(://[^:/\s@]+:)[^@/\s]+@`), "${1}" + Mask + "@"},
+}
+
+//Redactor removes sensitive data from strings, errors and structured data
+type Redactor struct {
+	keys     []string
+	patterns []pattern
+}
+
+//NewRedactor creates a redactor which uses the default keys and patterns extended by the given keys and regular expressions
+func NewRedactor(keys []string, patterns []string) (*Redactor, error) {
+	redactor := &Redactor{
+		keys:     append([]string{}, defaultKeys...),
+		patterns: append([]pattern{}, defaultPatterns...),
+	}
+	for _, key := range keys {
+		if key = normalizeKey(key); key != "" {
+			redactor.keys = append(redactor.keys, key)
+		}
+	}
+	for _, expr := range patterns {
+		regex, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern '%s': %s", expr, err)
+		}
+		redactor.patterns = append(redactor.patterns, pattern{regex, Mask})
+	}
+	return redactor, nil
+}
+
+//IsSensitiveKey returns true if values of the key have to be redacted
+func (r *Redactor) IsSensitiveKey(key string) bool {
+	key = normalizeKey(key)
+	if key == "" {
+		return false
+	}
+	for _, sensitiveKey := range r.keys {
+		if strings.Contains(key, sensitiveKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeKey(key string) string {
+	return strings.NewReplacer("-", "", "_", "", ".", "", " ", "").Replace(strings.ToLower(key))
+}
+
+//String replaces all sensitive data found in the text
+func (r *Redactor) String(text string) string {
+	for _, p := range r.patterns {
+		text = p.regex.ReplaceAllString(text, p.replacement)
+	}
+	return text
+}
+
+//Error returns an error with a redacted message which still wraps the original error
+func (r *Redactor) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*redactedError); ok {
+		return err
+	}
+	return &redactedError{
+		err: err,
+		msg: r.String(err.Error()),
+	}
+}
+
+//Value redacts a value which is stored under the given key: structured data (maps and slices) are redacted recursively
+func (r *Redactor) Value(key string, value interface{}) interface{} {
+	if _, ok := value.(bool); !ok && value != nil && r.IsSensitiveKey(key) {
+		return Mask
+	}
+	switch typedValue := value.(type) {
+	case string:
+		return r.String(typedValue)
+	case error:
+		return r.Error(typedValue)
+	case map[string]interface{}:
+		return r.Map(typedValue)
+	case map[string]string:
+		result := make(map[string]string, len(typedValue))
+		for k, v := range typedValue {
+			result[k] = r.Value(k, v).(string)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(typedValue))
+		for _, v := range typedValue {
+			result = append(result, r.Value("", v))
+		}
+		return result
+	case []string:
+		result := make([]string, 0, len(typedValue))
+		for _, v := range typedValue {
+			result = append(result, r.String(v))
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+//Map returns a redacted copy of the map. Configuration entries flagged as secret (e.g. {"key": ..., "value": ...,
+//"secret": true}) get their value masked.
+func (r *Redactor) Map(data map[string]interface{}) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		result[k] = r.Value(k, v)
+	}
+	if secret, ok := data["secret"].(bool); ok && secret {
+		if _, ok := data["value"]; ok {
+			result["value"] = Mask
+		}
+	}
+	return result
+}
+
+//JSON redacts a JSON document (e.g. a callback payload or a debug dump). Data which is not valid JSON is
+//redacted as plain text.
+func (r *Redactor) JSON(data []byte) []byte {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return []byte(r.String(string(data)))
+	}
+	result, err := json.Marshal(r.Value("", doc))
+	if err != nil {
+		return []byte(r.String(string(data)))
+	}
+	return result
+}
+
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+var (
+	defaultRedactor = &Redactor{keys: defaultKeys, patterns: defaultPatterns}
+	mu              sync.RWMutex
+)
+
+//Configure replaces the default redactor by a redactor which considers the given keys and regular expressions in
+//addition to the built-in defaults
+func Configure(keys []string, patterns []string) error {
+	redactor, err := NewRedactor(keys, patterns)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	defaultRedactor = redactor
+	return nil
+}
+
+//Default returns the redactor used by the package-level functions
+func Default() *Redactor {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultRedactor
+}
+
+//String redacts the text using the default redactor
+func String(text string) string {
+	return Default().String(text)
+}
+
+//Error redacts the error message using the default redactor
+func Error(err error) error {
+	return Default().Error(err)
+}
+
+//Value redacts the value using the default redactor
+func Value(key string, value interface{}) interface{} {
+	return Default().Value(key, value)
+}
+
+//JSON redacts the JSON document using the default redactor
+func JSON(data []byte) []byte {
+	return Default().JSON(data)
+}
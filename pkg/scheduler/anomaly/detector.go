@@ -0,0 +1,387 @@
+package anomaly
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultInterval         = 15 * time.Minute
+	defaultWindow           = time.Hour
+	defaultBaselineWindow   = 7 * 24 * time.Hour
+	defaultMinOperations    = 10
+	defaultFailureDeviation = 3
+	defaultDurationFactor   = 2
+
+	//maxClusters is the number of affected clusters which are linked in an alert
+	maxClusters = 20
+)
+
+//Alerter raises the alerts of detected anomalies
+type Alerter interface {
+	OnAnomaly(event *keb.AnomalyEvent)
+}
+
+//KymaVersionResolver returns the Kyma version of a configuration of a cluster
+type KymaVersionResolver func(runtimeID string, configVersion int64) (string, error)
+
+type configKey struct {
+	runtimeID     string
+	configVersion int64
+}
+
+//Detector compares the recent operations of each component with a statistical baseline of its operations and
+//raises an alert for each new anomaly: a spike of the failure ratio or a median duration which increased by the
+//duration factor after the rollout of a Kyma version
+type Detector struct {
+	interval         time.Duration
+	window           time.Duration
+	baselineWindow   time.Duration
+	minOperations    int
+	failureDeviation float64
+	durationFactor   float64
+	baseURL          string
+	repo             reconciliation.Repository
+	kymaVersions     KymaVersionResolver
+	alerter          Alerter
+	logger           *zap.SugaredLogger
+
+	//Kyma versions of the cluster configurations (configurations are immutable)
+	versionCache map[configKey]string
+	//active anomalies which were already alerted
+	active map[string]*keb.AnomalyEvent
+}
+
+//NewDetector returns the detector or nil if the anomaly detection is disabled
+func NewDetector(cfg config.AnomalyConfig, repo reconciliation.Repository, kymaVersions KymaVersionResolver,
+	alerter Alerter, logger *zap.SugaredLogger) (*Detector, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	detector := &Detector{
+		interval:         defaultInterval,
+		window:           defaultWindow,
+		baselineWindow:   defaultBaselineWindow,
+		minOperations:    defaultMinOperations,
+		failureDeviation: defaultFailureDeviation,
+		durationFactor:   defaultDurationFactor,
+		baseURL:          strings.TrimSuffix(cfg.BaseURL, "/"),
+		repo:             repo,
+		kymaVersions:     kymaVersions,
+		alerter:          alerter,
+		logger:           logger,
+		versionCache:     make(map[configKey]string),
+		active:           make(map[string]*keb.AnomalyEvent),
+	}
+	var err error
+	if cfg.Interval != "" {
+		if detector.interval, err = time.ParseDuration(cfg.Interval); err != nil || detector.interval <= 0 {
+			return nil, fmt.Errorf("anomaly detection interval '%s' is not a positive duration", cfg.Interval)
+		}
+	}
+	if cfg.Window != "" {
+		if detector.window, err = time.ParseDuration(cfg.Window); err != nil || detector.window <= 0 {
+			return nil, fmt.Errorf("anomaly detection window '%s' is not a positive duration", cfg.Window)
+		}
+	}
+	if cfg.BaselineWindow != "" {
+		if detector.baselineWindow, err = time.ParseDuration(cfg.BaselineWindow); err != nil || detector.baselineWindow <= 0 {
+			return nil, fmt.Errorf("anomaly detection baseline window '%s' is not a positive duration", cfg.BaselineWindow)
+		}
+	}
+	if detector.baselineWindow <= detector.window {
+		return nil, fmt.Errorf("anomaly detection baseline window (%s) has to be longer than the window (%s)",
+			detector.baselineWindow, detector.window)
+	}
+	if cfg.MinOperations < 0 || cfg.FailureDeviation < 0 || cfg.DurationFactor < 0 {
+		return nil, fmt.Errorf("min. operations, failure deviation and duration factor of anomaly detection " +
+			"cannot be < 0")
+	}
+	if cfg.MinOperations > 0 {
+		detector.minOperations = cfg.MinOperations
+	}
+	if cfg.FailureDeviation > 0 {
+		detector.failureDeviation = cfg.FailureDeviation
+	}
+	if cfg.DurationFactor > 0 {
+		if cfg.DurationFactor <= 1 {
+			return nil, fmt.Errorf("anomaly detection duration factor has to be > 1 but was %v", cfg.DurationFactor)
+		}
+		detector.durationFactor = cfg.DurationFactor
+	}
+	return detector, nil
+}
+
+//Run detects anomalies in an interval until the context gets closed
+func (d *Detector) Run(ctx context.Context) {
+	if d == nil {
+		return
+	}
+	d.logger.Infof("Starting anomaly detection each %.0f secs (window: %.0f secs, baseline window: %.0f secs)",
+		d.interval.Seconds(), d.window.Seconds(), d.baselineWindow.Seconds())
+	go func() {
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+		for {
+			if err := d.Detect(time.Now().UTC()); err != nil {
+				d.logger.Warnf("Failed to detect anomalies: %s", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+//Detect checks the operations which were created within the baseline window for anomalies and alerts the
+//anomalies which weren't alerted before
+func (d *Detector) Detect(now time.Time) error {
+	ops, err := d.repo.GetOperations(&operation.FilterMixer{Filters: []operation.Filter{
+		&operation.WithStates{States: []model.OperationState{model.OperationStateDone, model.OperationStateError}},
+		&operation.WithCreationDateAfter{Time: now.Add(-d.baselineWindow)},
+	}})
+	if err != nil {
+		return err
+	}
+	opsByComponent := make(map[string][]*model.OperationEntity)
+	for _, op := range ops {
+		opsByComponent[op.Component] = append(opsByComponent[op.Component], op)
+	}
+
+	var anomalies []*keb.AnomalyEvent
+	recentSince := now.Add(-d.window)
+	versions := make(map[configKey]string) //cached versions of configurations which are still in the baseline window
+	for component, componentOps := range opsByComponent {
+		if anomaly := d.failureSpike(component, componentOps, recentSince, now); anomaly != nil {
+			anomalies = append(anomalies, anomaly)
+		}
+		anomalies = append(anomalies, d.durationRegressions(component, componentOps, versions, recentSince, now)...)
+	}
+	d.versionCache = versions
+	d.update(anomalies)
+	return nil
+}
+
+//failureSpike compares the failure ratio within the window with the failure ratio of the operations before the
+//window: the ratio is a spike if it exceeds the baseline by the failure deviation (in standard deviations)
+func (d *Detector) failureSpike(component string, ops []*model.OperationEntity, recentSince, now time.Time) *keb.AnomalyEvent {
+	var recent, recentFailed []*model.OperationEntity
+	var baseline, baselineFailed int
+	for _, op := range ops {
+		if op.Created.Before(recentSince) {
+			baseline++
+			if op.State == model.OperationStateError {
+				baselineFailed++
+			}
+			continue
+		}
+		recent = append(recent, op)
+		if op.State == model.OperationStateError {
+			recentFailed = append(recentFailed, op)
+		}
+	}
+	if len(recent) < d.minOperations || baseline < d.minOperations {
+		return nil
+	}
+
+	//the smoothed baseline ratio keeps the deviation > 0 for components which never failed
+	expected := float64(baselineFailed+1) / float64(baseline+2)
+	observed := float64(len(recentFailed)) / float64(len(recent))
+	deviation := math.Sqrt(expected * (1 - expected) / float64(len(recent)))
+	if observed <= expected || (observed-expected)/deviation < d.failureDeviation {
+		return nil
+	}
+	baselineRatio := float64(baselineFailed) / float64(baseline)
+	return &keb.AnomalyEvent{
+		Type:      keb.AnomalyEventTypeFailureSpike,
+		Component: component,
+		Message: fmt.Sprintf("Failure ratio of component '%s' rose to %.2f (%d of %d operations failed within "+
+			"the last %s) from a baseline of %.2f", component, observed, len(recentFailed), len(recent),
+			d.window, baselineRatio),
+		Baseline: baselineRatio,
+		Observed: observed,
+		Detected: now,
+		Clusters: d.clusters(recentFailed),
+	}
+}
+
+//durationRegressions compares the median duration of the successful operations of each Kyma version which is
+//currently rolled out with the median duration of the other Kyma versions
+func (d *Detector) durationRegressions(component string, ops []*model.OperationEntity, versions map[configKey]string,
+	recentSince, now time.Time) []*keb.AnomalyEvent {
+	opsByVersion := make(map[string][]*model.OperationEntity)
+	for _, op := range ops {
+		if op.State != model.OperationStateDone {
+			continue
+		}
+		version, ok := d.kymaVersion(op, versions)
+		if !ok {
+			continue
+		}
+		opsByVersion[version] = append(opsByVersion[version], op)
+	}
+	if len(opsByVersion) < 2 {
+		return nil
+	}
+
+	var result []*keb.AnomalyEvent
+	for version, versionOps := range opsByVersion {
+		var recent bool
+		for _, op := range versionOps {
+			recent = recent || !op.Created.Before(recentSince)
+		}
+		if !recent || len(versionOps) < d.minOperations {
+			continue
+		}
+		var otherOps []*model.OperationEntity
+		for otherVersion, otherVersionOps := range opsByVersion {
+			if otherVersion != version {
+				otherOps = append(otherOps, otherVersionOps...)
+			}
+		}
+		if len(otherOps) < d.minOperations {
+			continue
+		}
+
+		baseline := median(otherOps)
+		observed := median(versionOps)
+		if baseline <= 0 || float64(observed) < d.durationFactor*float64(baseline) {
+			continue
+		}
+		var slowOps []*model.OperationEntity
+		for _, op := range versionOps {
+			if !op.Created.Before(recentSince) && float64(duration(op)) >= d.durationFactor*float64(baseline) {
+				slowOps = append(slowOps, op)
+			}
+		}
+		kymaVersion := version
+		result = append(result, &keb.AnomalyEvent{
+			Type:        keb.AnomalyEventTypeDurationRegression,
+			Component:   component,
+			KymaVersion: &kymaVersion,
+			Message: fmt.Sprintf("Median duration of component '%s' on Kyma version '%s' is %s (%.1fx the "+
+				"median duration of %s on other Kyma versions)", component, version, observed.Round(time.Second),
+				float64(observed)/float64(baseline), baseline.Round(time.Second)),
+			Baseline: baseline.Seconds(),
+			Observed: observed.Seconds(),
+			Detected: now,
+			Clusters: d.clusters(slowOps),
+		})
+	}
+	return result
+}
+
+//kymaVersion returns the Kyma version of the cluster configuration the operation belongs to
+func (d *Detector) kymaVersion(op *model.OperationEntity, versions map[configKey]string) (string, bool) {
+	key := configKey{runtimeID: op.RuntimeID, configVersion: op.ClusterConfig}
+	if version, ok := versions[key]; ok {
+		return version, true
+	}
+	if version, ok := d.versionCache[key]; ok {
+		versions[key] = version
+		return version, true
+	}
+	version, err := d.kymaVersions(op.RuntimeID, op.ClusterConfig)
+	if err != nil {
+		d.logger.Debugf("Anomaly detection ignores operation '%s': failed to resolve Kyma version of "+
+			"configuration %d of cluster '%s': %s", op.CorrelationID, op.ClusterConfig, op.RuntimeID, err)
+		return "", false
+	}
+	versions[key] = version
+	return version, true
+}
+
+//clusters returns the links to the clusters of the operations (most recent operation first)
+func (d *Detector) clusters(ops []*model.OperationEntity) []keb.AnomalyCluster {
+	sorted := make([]*model.OperationEntity, len(ops))
+	copy(sorted, ops)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Created.After(sorted[j].Created)
+	})
+	result := []keb.AnomalyCluster{}
+	added := make(map[string]bool)
+	for _, op := range sorted {
+		if added[op.RuntimeID] {
+			continue
+		}
+		if len(result) >= maxClusters {
+			break
+		}
+		added[op.RuntimeID] = true
+		result = append(result, keb.AnomalyCluster{
+			RuntimeID: op.RuntimeID,
+			Link:      fmt.Sprintf("%s/v1/clusters/%s/explain", d.baseURL, op.RuntimeID),
+		})
+	}
+	return result
+}
+
+//update alerts the new anomalies and logs the anomalies which disappeared
+func (d *Detector) update(anomalies []*keb.AnomalyEvent) {
+	sort.Slice(anomalies, func(i, j int) bool {
+		return key(anomalies[i]) < key(anomalies[j])
+	})
+	active := make(map[string]*keb.AnomalyEvent, len(anomalies))
+	for _, anomaly := range anomalies {
+		active[key(anomaly)] = anomaly
+		if _, ok := d.active[key(anomaly)]; ok {
+			continue
+		}
+		d.logger.Errorf("Anomaly detected: %s (%d clusters affected)", anomaly.Message, len(anomaly.Clusters))
+		if d.alerter != nil {
+			d.alerter.OnAnomaly(anomaly)
+		}
+	}
+	for anomalyKey, anomaly := range d.active {
+		if _, ok := active[anomalyKey]; !ok {
+			d.logger.Infof("Anomaly '%s' of component '%s' disappeared", anomaly.Type, anomaly.Component)
+		}
+	}
+	d.active = active
+}
+
+//key identifies an anomaly: an anomaly is alerted only once as long as it's detected
+func key(anomaly *keb.AnomalyEvent) string {
+	key := fmt.Sprintf("%s/%s", anomaly.Type, anomaly.Component)
+	if anomaly.KymaVersion != nil {
+		key = fmt.Sprintf("%s/%s", key, *anomaly.KymaVersion)
+	}
+	return key
+}
+
+//duration of an operation since it was picked up by a worker
+func duration(op *model.OperationEntity) time.Duration {
+	start := op.PickedUp
+	if start.IsZero() {
+		start = op.Created
+	}
+	return op.Updated.Sub(start)
+}
+
+func median(ops []*model.OperationEntity) time.Duration {
+	durations := make([]time.Duration, 0, len(ops))
+	for _, op := range ops {
+		durations = append(durations, duration(op))
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	middle := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[middle-1] + durations[middle]) / 2
+	}
+	return durations[middle]
+}
@@ -0,0 +1,170 @@
+package anomaly
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testAlerter struct {
+	events []*keb.AnomalyEvent
+}
+
+func (a *testAlerter) OnAnomaly(event *keb.AnomalyEvent) {
+	a.events = append(a.events, event)
+}
+
+//newOps returns finished operations of a component which were created at the given time and ran for the duration
+func newOps(component string, count int, state model.OperationState, created time.Time, configVersion int64,
+	duration time.Duration) []*model.OperationEntity {
+	var ops []*model.OperationEntity
+	for i := 0; i < count; i++ {
+		ops = append(ops, &model.OperationEntity{
+			RuntimeID:     fmt.Sprintf("runtime-%s-%d-%d", component, configVersion, i),
+			ClusterConfig: configVersion,
+			Component:     component,
+			State:         state,
+			Created:       created,
+			PickedUp:      created.Add(time.Second),
+			Updated:       created.Add(time.Second + duration),
+		})
+	}
+	return ops
+}
+
+//kymaVersions resolves the Kyma version from the configuration version (1 = "2.0.0", 2 = "2.1.0")
+func kymaVersions(_ string, configVersion int64) (string, error) {
+	if configVersion <= 0 {
+		return "", fmt.Errorf("configuration %d not found", configVersion)
+	}
+	return fmt.Sprintf("2.%d.0", configVersion-1), nil
+}
+
+func TestNewDetector(t *testing.T) {
+	detector, err := NewDetector(config.AnomalyConfig{}, &reconciliation.MockRepository{}, kymaVersions, nil, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	require.Nil(t, detector)
+
+	for _, cfg := range []config.AnomalyConfig{
+		{Enabled: true, Interval: "often"},
+		{Enabled: true, Window: "-1h"},
+		{Enabled: true, Window: "2h", BaselineWindow: "1h"},
+		{Enabled: true, MinOperations: -1},
+		{Enabled: true, DurationFactor: 0.5},
+	} {
+		_, err = NewDetector(cfg, &reconciliation.MockRepository{}, kymaVersions, nil, zap.NewNop().Sugar())
+		require.Error(t, err, cfg)
+	}
+
+	detector, err = NewDetector(config.AnomalyConfig{Enabled: true, BaseURL: "https://reconciler.example.com/"},
+		&reconciliation.MockRepository{}, kymaVersions, nil, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	require.Equal(t, defaultWindow, detector.window)
+	require.Equal(t, float64(defaultDurationFactor), detector.durationFactor)
+	require.Equal(t, "https://reconciler.example.com", detector.baseURL)
+}
+
+func TestDetector(t *testing.T) {
+	now := time.Now().UTC()
+	baselineTime := now.Add(-24 * time.Hour)
+	recentTime := now.Add(-10 * time.Minute)
+
+	t.Run("Failure spike", func(t *testing.T) {
+		var ops []*model.OperationEntity
+		//istio fails usually in 5% of the operations but 8 of the recent 20 operations failed
+		ops = append(ops, newOps("istio", 95, model.OperationStateDone, baselineTime, 1, time.Minute)...)
+		ops = append(ops, newOps("istio", 5, model.OperationStateError, baselineTime, 1, time.Minute)...)
+		ops = append(ops, newOps("istio", 12, model.OperationStateDone, recentTime, 1, time.Minute)...)
+		ops = append(ops, newOps("istio", 8, model.OperationStateError, recentTime, 2, time.Minute)...)
+		//serverless fails as often as usual
+		ops = append(ops, newOps("serverless", 90, model.OperationStateDone, baselineTime, 1, time.Minute)...)
+		ops = append(ops, newOps("serverless", 10, model.OperationStateError, baselineTime, 1, time.Minute)...)
+		ops = append(ops, newOps("serverless", 18, model.OperationStateDone, recentTime, 1, time.Minute)...)
+		ops = append(ops, newOps("serverless", 2, model.OperationStateError, recentTime, 1, time.Minute)...)
+		//eventing has too few recent operations
+		ops = append(ops, newOps("eventing", 100, model.OperationStateDone, baselineTime, 1, time.Minute)...)
+		ops = append(ops, newOps("eventing", 3, model.OperationStateError, recentTime, 1, time.Minute)...)
+
+		alerter := &testAlerter{}
+		detector, err := NewDetector(config.AnomalyConfig{Enabled: true}, &reconciliation.MockRepository{
+			GetOperationsResult: ops,
+		}, kymaVersions, alerter, zap.NewNop().Sugar())
+		require.NoError(t, err)
+
+		require.NoError(t, detector.Detect(now))
+		require.Len(t, alerter.events, 1)
+		event := alerter.events[0]
+		require.Equal(t, keb.AnomalyEventTypeFailureSpike, event.Type)
+		require.Equal(t, "istio", event.Component)
+		require.Nil(t, event.KymaVersion)
+		require.InDelta(t, 0.05, event.Baseline, 0.001)
+		require.InDelta(t, 0.4, event.Observed, 0.001)
+		require.Len(t, event.Clusters, 8)
+		require.Equal(t, "/v1/clusters/runtime-istio-2-0/explain", event.Clusters[0].Link)
+
+		//an active anomaly is alerted only once
+		require.NoError(t, detector.Detect(now))
+		require.Len(t, alerter.events, 1)
+	})
+
+	t.Run("Duration regression after Kyma version rollout", func(t *testing.T) {
+		var ops []*model.OperationEntity
+		//istio took 1 min on Kyma 2.0.0 but takes 3 mins on Kyma 2.1.0
+		ops = append(ops, newOps("istio", 30, model.OperationStateDone, baselineTime, 1, time.Minute)...)
+		ops = append(ops, newOps("istio", 10, model.OperationStateDone, baselineTime, 2, 3*time.Minute)...)
+		ops = append(ops, newOps("istio", 5, model.OperationStateDone, recentTime, 2, 3*time.Minute)...)
+		//serverless takes as long as before
+		ops = append(ops, newOps("serverless", 30, model.OperationStateDone, baselineTime, 1, time.Minute)...)
+		ops = append(ops, newOps("serverless", 15, model.OperationStateDone, recentTime, 2, 70*time.Second)...)
+		//operations of unknown configurations are ignored
+		ops = append(ops, newOps("eventing", 30, model.OperationStateDone, baselineTime, 1, time.Minute)...)
+		ops = append(ops, newOps("eventing", 15, model.OperationStateDone, recentTime, 0, 10*time.Minute)...)
+
+		alerter := &testAlerter{}
+		detector, err := NewDetector(config.AnomalyConfig{Enabled: true, BaseURL: "https://reconciler.example.com"},
+			&reconciliation.MockRepository{GetOperationsResult: ops}, kymaVersions, alerter, zap.NewNop().Sugar())
+		require.NoError(t, err)
+
+		require.NoError(t, detector.Detect(now))
+		require.Len(t, alerter.events, 1)
+		event := alerter.events[0]
+		require.Equal(t, keb.AnomalyEventTypeDurationRegression, event.Type)
+		require.Equal(t, "istio", event.Component)
+		require.Equal(t, "2.1.0", *event.KymaVersion)
+		require.Equal(t, 60.0, event.Baseline)
+		require.Equal(t, 180.0, event.Observed)
+		require.Len(t, event.Clusters, 5, "only clusters with recent slow operations are affected")
+		require.Equal(t, "https://reconciler.example.com/v1/clusters/runtime-istio-2-0/explain", event.Clusters[0].Link)
+		require.Len(t, detector.versionCache, 115, "configurations of all successful operations are cached")
+
+		//anomaly disappears if the operations are fast again
+		detector.repo = &reconciliation.MockRepository{
+			GetOperationsResult: newOps("istio", 30, model.OperationStateDone, recentTime, 2, time.Minute),
+		}
+		require.NoError(t, detector.Detect(now))
+		require.Empty(t, detector.active)
+		require.Len(t, detector.versionCache, 30, "configurations which left the baseline window are evicted")
+	})
+
+	t.Run("Affected clusters are limited", func(t *testing.T) {
+		ops := newOps("istio", 100, model.OperationStateDone, baselineTime, 1, time.Minute)
+		ops = append(ops, newOps("istio", 50, model.OperationStateError, recentTime, 2, time.Minute)...)
+
+		alerter := &testAlerter{}
+		detector, err := NewDetector(config.AnomalyConfig{Enabled: true}, &reconciliation.MockRepository{
+			GetOperationsResult: ops,
+		}, kymaVersions, alerter, zap.NewNop().Sugar())
+		require.NoError(t, err)
+
+		require.NoError(t, detector.Detect(now))
+		require.Len(t, alerter.events, 1)
+		require.Len(t, alerter.events[0].Clusters, maxClusters)
+	})
+}
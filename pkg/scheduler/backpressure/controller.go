@@ -0,0 +1,213 @@
+package backpressure
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultInterval            = 10 * time.Second
+	defaultLatencyThreshold    = 250 * time.Millisecond
+	defaultErrorThreshold      = 0.1
+	defaultMinDispatchRatio    = 0.1
+	defaultStatusWriteInterval = 2 * time.Minute
+
+	//recoveryStep is added to the dispatch ratio for each check which found the database recovered
+	recoveryStep = 0.25
+)
+
+//State is the result of the latest latency check
+type State struct {
+	//DispatchRatio is the share of the free workers which receive operations (1 if the mothership isn't throttled)
+	DispatchRatio float64
+	//Latency is the average latency of the database operations since the previous check
+	Latency time.Duration
+	//ErrorRatio is the ratio of failed database operations since the previous check
+	ErrorRatio float64
+	//SkippedStatusWrites is the number of interim operation statuses which weren't written because of the throttling
+	SkippedStatusWrites int
+}
+
+//Throttled returns true if the dispatching of operations is reduced
+func (s State) Throttled() bool {
+	return s.DispatchRatio < 1
+}
+
+//Controller monitors the latency and the error ratio of the database operations of the mothership: while the
+//database is slow or failing, the dispatching of operations and the writing of interim operation statuses are
+//throttled to let the database recover instead of piling up more work. The dispatch ratio is halved by each check
+//which exceeds a threshold and recovers stepwise once the latency dropped clearly below the threshold.
+type Controller struct {
+	interval            time.Duration
+	latencyThreshold    time.Duration
+	errorThreshold      float64
+	minDispatchRatio    float64
+	statusWriteInterval time.Duration
+	probe               func() error
+	logger              *zap.SugaredLogger
+
+	m            sync.Mutex
+	observations int
+	failures     int
+	totalLatency time.Duration
+	state        State
+}
+
+//NewController returns the controller or nil if the backpressure is disabled. The probe is a cheap database
+//operation (e.g. a ping) which is measured by each check: it keeps the latency up-to-date while the mothership is idle.
+func NewController(cfg config.BackpressureConfig, probe func() error, logger *zap.SugaredLogger) (*Controller, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	controller := &Controller{
+		interval:            defaultInterval,
+		latencyThreshold:    defaultLatencyThreshold,
+		errorThreshold:      defaultErrorThreshold,
+		minDispatchRatio:    defaultMinDispatchRatio,
+		statusWriteInterval: defaultStatusWriteInterval,
+		probe:               probe,
+		logger:              logger,
+		state:               State{DispatchRatio: 1},
+	}
+	var err error
+	if cfg.Interval != "" {
+		if controller.interval, err = time.ParseDuration(cfg.Interval); err != nil || controller.interval <= 0 {
+			return nil, fmt.Errorf("backpressure interval '%s' is not a positive duration", cfg.Interval)
+		}
+	}
+	if cfg.LatencyThreshold != "" {
+		if controller.latencyThreshold, err = time.ParseDuration(cfg.LatencyThreshold); err != nil || controller.latencyThreshold <= 0 {
+			return nil, fmt.Errorf("backpressure latency threshold '%s' is not a positive duration", cfg.LatencyThreshold)
+		}
+	}
+	if cfg.StatusWriteInterval != "" {
+		if controller.statusWriteInterval, err = time.ParseDuration(cfg.StatusWriteInterval); err != nil || controller.statusWriteInterval < 0 {
+			return nil, fmt.Errorf("backpressure status write interval '%s' is not a duration >= 0", cfg.StatusWriteInterval)
+		}
+	}
+	if cfg.ErrorThreshold != 0 {
+		if cfg.ErrorThreshold < 0 || cfg.ErrorThreshold >= 1 {
+			return nil, fmt.Errorf("backpressure error threshold has to be > 0 and < 1 but was %v", cfg.ErrorThreshold)
+		}
+		controller.errorThreshold = cfg.ErrorThreshold
+	}
+	if cfg.MinDispatchRatio != 0 {
+		if cfg.MinDispatchRatio < 0 || cfg.MinDispatchRatio > 1 {
+			return nil, fmt.Errorf("backpressure min. dispatch ratio has to be > 0 and <= 1 but was %v", cfg.MinDispatchRatio)
+		}
+		controller.minDispatchRatio = cfg.MinDispatchRatio
+	}
+	return controller, nil
+}
+
+//Run probes the database and checks the latency in an interval until the context gets closed
+func (c *Controller) Run(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	c.logger.Infof("Starting backpressure checks of database latency each %.0f secs (latency threshold: %d ms, "+
+		"error threshold: %.2f)", c.interval.Seconds(), c.latencyThreshold.Milliseconds(), c.errorThreshold)
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if c.probe != nil {
+				start := time.Now()
+				err := c.probe()
+				c.Observe(time.Since(start), err)
+			}
+			c.Check()
+		}
+	}()
+}
+
+//Observe records the latency and the result of a database operation
+func (c *Controller) Observe(latency time.Duration, err error) {
+	if c == nil {
+		return
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.observations++
+	c.totalLatency += latency
+	if err != nil {
+		c.failures++
+	}
+}
+
+//Check evaluates the database operations observed since the previous check and adjusts the dispatch ratio
+func (c *Controller) Check() State {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.observations == 0 { //nothing observed: keep the current throttling
+		return c.state
+	}
+	previous := c.state
+	c.state.Latency = c.totalLatency / time.Duration(c.observations)
+	c.state.ErrorRatio = float64(c.failures) / float64(c.observations)
+	c.observations, c.failures, c.totalLatency = 0, 0, 0
+
+	switch {
+	case c.state.Latency > c.latencyThreshold || c.state.ErrorRatio > c.errorThreshold:
+		c.state.DispatchRatio = math.Max(c.minDispatchRatio, c.state.DispatchRatio/2)
+	case c.state.Latency <= c.latencyThreshold/2 && c.state.ErrorRatio <= c.errorThreshold/2:
+		c.state.DispatchRatio = math.Min(1, c.state.DispatchRatio+recoveryStep)
+	}
+
+	if c.state.DispatchRatio < previous.DispatchRatio {
+		c.logger.Warnf("Backpressure reduced dispatching of operations to %.0f%% of the free workers: database "+
+			"latency is %d ms (threshold: %d ms) and error ratio is %.2f (threshold: %.2f)",
+			c.state.DispatchRatio*100, c.state.Latency.Milliseconds(), c.latencyThreshold.Milliseconds(),
+			c.state.ErrorRatio, c.errorThreshold)
+	} else if c.state.DispatchRatio > previous.DispatchRatio {
+		c.logger.Infof("Backpressure increased dispatching of operations to %.0f%% of the free workers: database "+
+			"latency recovered to %d ms", c.state.DispatchRatio*100, c.state.Latency.Milliseconds())
+	}
+	return c.state
+}
+
+//State returns the result of the latest check
+func (c *Controller) State() State {
+	if c == nil {
+		return State{DispatchRatio: 1}
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.state
+}
+
+//DispatchLimit returns how many of the free workers can receive an operation: at least one operation is dispatched
+//to keep the reconciliations progressing
+func (c *Controller) DispatchLimit(freeWorkers int) int {
+	state := c.State()
+	if !state.Throttled() || freeWorkers <= 1 {
+		return freeWorkers
+	}
+	return int(math.Max(1, math.Floor(float64(freeWorkers)*state.DispatchRatio)))
+}
+
+//SkipStatusWrite checks whether an unchanged interim status of an operation which was written at the given time
+//doesn't have to be written again: while throttled, such statuses are written at most once per status write interval
+func (c *Controller) SkipStatusWrite(lastWrite, now time.Time) bool {
+	if c == nil {
+		return false
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	if !c.state.Throttled() || now.Sub(lastWrite) >= c.statusWriteInterval {
+		return false
+	}
+	c.state.SkippedStatusWrites++
+	return true
+}
@@ -0,0 +1,119 @@
+package backpressure
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewController(t *testing.T) {
+	controller, err := NewController(config.BackpressureConfig{}, nil, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	require.Nil(t, controller)
+
+	//disabled controller doesn't throttle
+	require.Equal(t, 10, controller.DispatchLimit(10))
+	require.False(t, controller.SkipStatusWrite(time.Now(), time.Now()))
+	controller.Observe(time.Second, nil)
+
+	for _, cfg := range []config.BackpressureConfig{
+		{Enabled: true, Interval: "often"},
+		{Enabled: true, LatencyThreshold: "0s"},
+		{Enabled: true, StatusWriteInterval: "-1m"},
+		{Enabled: true, ErrorThreshold: 1},
+		{Enabled: true, MinDispatchRatio: 1.5},
+	} {
+		_, err = NewController(cfg, nil, zap.NewNop().Sugar())
+		require.Error(t, err, cfg)
+	}
+
+	controller, err = NewController(config.BackpressureConfig{Enabled: true}, nil, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	require.Equal(t, defaultLatencyThreshold, controller.latencyThreshold)
+	require.Equal(t, defaultMinDispatchRatio, controller.minDispatchRatio)
+	require.False(t, controller.State().Throttled())
+}
+
+func TestController(t *testing.T) {
+	newController := func(t *testing.T) *Controller {
+		controller, err := NewController(config.BackpressureConfig{
+			Enabled:             true,
+			LatencyThreshold:    "100ms",
+			ErrorThreshold:      0.2,
+			MinDispatchRatio:    0.2,
+			StatusWriteInterval: "1m",
+		}, nil, zap.NewNop().Sugar())
+		require.NoError(t, err)
+		return controller
+	}
+
+	t.Run("Slow database", func(t *testing.T) {
+		controller := newController(t)
+
+		controller.Observe(50*time.Millisecond, nil)
+		controller.Observe(350*time.Millisecond, nil)
+		state := controller.Check()
+		require.Equal(t, 200*time.Millisecond, state.Latency)
+		require.Equal(t, 0.5, state.DispatchRatio)
+		require.Equal(t, 5, controller.DispatchLimit(10))
+		require.Equal(t, 1, controller.DispatchLimit(1))
+
+		//dispatch ratio doesn't drop below the min. ratio
+		for i := 0; i < 5; i++ {
+			controller.Observe(time.Second, nil)
+			controller.Check()
+		}
+		require.Equal(t, 0.2, controller.State().DispatchRatio)
+		require.Equal(t, 2, controller.DispatchLimit(10))
+		require.Equal(t, 1, controller.DispatchLimit(3))
+	})
+
+	t.Run("Failing database", func(t *testing.T) {
+		controller := newController(t)
+
+		controller.Observe(time.Millisecond, nil)
+		controller.Observe(time.Millisecond, nil)
+		controller.Observe(time.Millisecond, errors.New("connection refused"))
+		state := controller.Check()
+		require.InDelta(t, 0.33, state.ErrorRatio, 0.01)
+		require.True(t, state.Throttled())
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		controller := newController(t)
+		controller.Observe(time.Second, nil)
+		require.Equal(t, 0.5, controller.Check().DispatchRatio)
+
+		//no observations: throttling is kept
+		require.Equal(t, 0.5, controller.Check().DispatchRatio)
+
+		//latency below the threshold but not clearly recovered: throttling is kept
+		controller.Observe(80*time.Millisecond, nil)
+		require.Equal(t, 0.5, controller.Check().DispatchRatio)
+
+		//latency recovered: dispatching increases stepwise
+		controller.Observe(10*time.Millisecond, nil)
+		require.Equal(t, 0.75, controller.Check().DispatchRatio)
+		controller.Observe(10*time.Millisecond, nil)
+		require.Equal(t, 1.0, controller.Check().DispatchRatio)
+		controller.Observe(10*time.Millisecond, nil)
+		require.Equal(t, 1.0, controller.Check().DispatchRatio)
+		require.Equal(t, 10, controller.DispatchLimit(10))
+	})
+
+	t.Run("Status writes", func(t *testing.T) {
+		controller := newController(t)
+		now := time.Now()
+		require.False(t, controller.SkipStatusWrite(now.Add(-10*time.Second), now), "not throttled")
+
+		controller.Observe(time.Second, nil)
+		controller.Check()
+		require.True(t, controller.SkipStatusWrite(now.Add(-10*time.Second), now))
+		require.False(t, controller.SkipStatusWrite(now.Add(-time.Minute), now), "status write interval exceeded")
+		require.Equal(t, 1, controller.State().SkippedStatusWrites)
+	})
+}
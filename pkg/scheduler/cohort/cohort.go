@@ -0,0 +1,161 @@
+package cohort
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/features"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const timeOfDayFormat = "15:04"
+
+//Cohort is a group of clusters (e.g. a rollout ring) which is selected by the cluster labels
+type Cohort struct {
+	Name     string
+	Paused   bool
+	Features features.Overrides
+	selector labels.Selector
+	window   *maintenanceWindow
+}
+
+//Reconcilable checks whether changes can be rolled out to the clusters of the cohort at the given time
+func (c *Cohort) Reconcilable(t time.Time) bool {
+	if c.Paused {
+		return false
+	}
+	return c.window == nil || c.window.contains(t)
+}
+
+//MaintenanceWindow returns the daily maintenance window of the cohort in UTC (empty if the cohort has none)
+func (c *Cohort) MaintenanceWindow() string {
+	if c.window == nil {
+		return ""
+	}
+	return c.window.String()
+}
+
+//maintenanceWindow is a daily time range in UTC (the end can be on the next day)
+type maintenanceWindow struct {
+	start time.Duration //offset since midnight
+	end   time.Duration
+}
+
+func newMaintenanceWindow(window string) (*maintenanceWindow, error) {
+	times := strings.Split(window, "-")
+	if len(times) != 2 {
+		return nil, fmt.Errorf("maintenance window '%s' is invalid: expected format is 'HH:MM-HH:MM'", window)
+	}
+	var offsets []time.Duration
+	for _, timeOfDay := range times {
+		t, err := time.Parse(timeOfDayFormat, strings.TrimSpace(timeOfDay))
+		if err != nil {
+			return nil, errors.Wrapf(err, "maintenance window '%s' is invalid", window)
+		}
+		offsets = append(offsets, time.Duration(t.Hour())*time.Hour+time.Duration(t.Minute())*time.Minute)
+	}
+	return &maintenanceWindow{start: offsets[0], end: offsets[1]}, nil
+}
+
+func (w *maintenanceWindow) contains(t time.Time) bool {
+	t = t.UTC()
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if w.start <= w.end {
+		return offset >= w.start && offset < w.end
+	}
+	return offset >= w.start || offset < w.end //window ends on the next day
+}
+
+func (w *maintenanceWindow) String() string {
+	timeOfDay := func(offset time.Duration) string {
+		return time.Time{}.Add(offset).Format(timeOfDayFormat)
+	}
+	return fmt.Sprintf("%s-%s", timeOfDay(w.start), timeOfDay(w.end))
+}
+
+//Resolver assigns clusters to the configured cohorts
+type Resolver struct {
+	cohorts []*Cohort
+}
+
+func NewResolver(cfgs []config.Cohort) (*Resolver, error) {
+	resolver := &Resolver{}
+	names := make(map[string]bool)
+	for _, cfg := range cfgs {
+		if cfg.Name == "" {
+			return nil, errors.New("name of cohort is undefined")
+		}
+		if names[cfg.Name] {
+			return nil, fmt.Errorf("cohort '%s' is defined multiple times", cfg.Name)
+		}
+		names[cfg.Name] = true
+
+		selector, err := labels.Parse(cfg.Selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "label selector of cohort '%s' is invalid", cfg.Name)
+		}
+		cohort := &Cohort{
+			Name:     cfg.Name,
+			Paused:   cfg.Paused,
+			Features: features.Overrides{},
+			selector: selector,
+		}
+		if cfg.MaintenanceWindow != "" {
+			if cohort.window, err = newMaintenanceWindow(cfg.MaintenanceWindow); err != nil {
+				return nil, errors.Wrapf(err, "cohort '%s' is invalid", cfg.Name)
+			}
+		}
+		for feature, enabled := range cfg.Features {
+			if !features.Known(feature) {
+				return nil, fmt.Errorf("cohort '%s' overrides unknown feature '%s'", cfg.Name, feature)
+			}
+			cohort.Features[feature] = enabled
+		}
+		resolver.cohorts = append(resolver.cohorts, cohort)
+	}
+	return resolver, nil
+}
+
+//Resolve returns the first cohort which matches the cluster labels (nil if the cluster belongs to no cohort or
+//no resolver is defined)
+func (r *Resolver) Resolve(metadata *keb.Metadata) *Cohort {
+	if r == nil {
+		return nil
+	}
+	clusterLabels := Labels(metadata)
+	for _, cohort := range r.cohorts {
+		if cohort.selector.Matches(clusterLabels) {
+			return cohort
+		}
+	}
+	return nil
+}
+
+//Labels returns the labels of a cluster: besides the labels provided by KEB, a few metadata fields
+//are available as labels (region, servicePlanName, globalAccountID and subAccountID)
+func Labels(metadata *keb.Metadata) labels.Set {
+	result := labels.Set{}
+	if metadata == nil {
+		return result
+	}
+	for key, value := range map[string]string{
+		"region":          metadata.Region,
+		"servicePlanName": metadata.ServicePlanName,
+		"globalAccountID": metadata.GlobalAccountID,
+		"subAccountID":    metadata.SubAccountID,
+	} {
+		if value != "" {
+			result[key] = value
+		}
+	}
+	if metadata.Labels != nil {
+		for key, value := range *metadata.Labels {
+			result[key] = value
+		}
+	}
+	return result
+}
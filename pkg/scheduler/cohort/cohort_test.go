@@ -0,0 +1,108 @@
+package cohort
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver(t *testing.T) {
+	t.Run("Resolve first matching cohort", func(t *testing.T) {
+		resolver, err := NewResolver([]config.Cohort{
+			{Name: "ring0", Selector: "ring=internal"},
+			{Name: "ring1", Selector: "region in (westeurope,eastus)"},
+			{Name: "ring2", Selector: ""},
+		})
+		require.NoError(t, err)
+
+		internalLabels := map[string]string{"ring": "internal"}
+		require.Equal(t, "ring0", resolver.Resolve(&keb.Metadata{Region: "westeurope", Labels: &internalLabels}).Name)
+		require.Equal(t, "ring1", resolver.Resolve(&keb.Metadata{Region: "westeurope"}).Name)
+		require.Equal(t, "ring2", resolver.Resolve(&keb.Metadata{Region: "centralus"}).Name)
+		require.Equal(t, "ring2", resolver.Resolve(nil).Name)
+	})
+
+	t.Run("Cluster without cohort", func(t *testing.T) {
+		resolver, err := NewResolver([]config.Cohort{{Name: "ring0", Selector: "ring=internal"}})
+		require.NoError(t, err)
+		require.Nil(t, resolver.Resolve(&keb.Metadata{Region: "westeurope"}))
+	})
+
+	t.Run("Invalid cohorts", func(t *testing.T) {
+		for _, cfgs := range [][]config.Cohort{
+			{{Selector: "ring=internal"}},
+			{{Name: "ring0"}, {Name: "ring0"}},
+			{{Name: "ring0", Selector: "ring in internal"}},
+			{{Name: "ring0", MaintenanceWindow: "22:00"}},
+			{{Name: "ring0", MaintenanceWindow: "22:00-25:00"}},
+			{{Name: "ring0", Features: map[string]bool{"NOT_EXISTING_FEATURE": true}}},
+		} {
+			_, err := NewResolver(cfgs)
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("Feature overrides", func(t *testing.T) {
+		resolver, err := NewResolver([]config.Cohort{
+			{Name: "ring0", Features: map[string]bool{"ADOPT_EXISTING_RESOURCES_ENABLED": true}},
+		})
+		require.NoError(t, err)
+		require.True(t, resolver.Resolve(nil).Features["ADOPT_EXISTING_RESOURCES_ENABLED"])
+	})
+}
+
+func TestCohortReconcilable(t *testing.T) {
+	at := func(timeOfDay string) time.Time {
+		result, err := time.Parse(time.RFC3339, "2022-03-01T"+timeOfDay+":00Z")
+		require.NoError(t, err)
+		return result
+	}
+
+	resolver, err := NewResolver([]config.Cohort{
+		{Name: "paused", Selector: "ring=paused", Paused: true},
+		{Name: "day", Selector: "ring=day", MaintenanceWindow: "08:00-16:00"},
+		{Name: "night", Selector: "ring=night", MaintenanceWindow: "22:00-04:00"},
+		{Name: "always", Selector: ""},
+	})
+	require.NoError(t, err)
+	cohortOf := func(ring string) *Cohort {
+		return resolver.Resolve(&keb.Metadata{Labels: &map[string]string{"ring": ring}})
+	}
+
+	require.False(t, cohortOf("paused").Reconcilable(at("12:00")))
+	require.True(t, cohortOf("always").Reconcilable(at("12:00")))
+
+	require.True(t, cohortOf("day").Reconcilable(at("08:00")))
+	require.True(t, cohortOf("day").Reconcilable(at("15:59")))
+	require.False(t, cohortOf("day").Reconcilable(at("16:00")))
+	require.False(t, cohortOf("day").Reconcilable(at("23:00")))
+
+	//window wraps midnight
+	require.True(t, cohortOf("night").Reconcilable(at("23:30")))
+	require.True(t, cohortOf("night").Reconcilable(at("03:59")))
+	require.False(t, cohortOf("night").Reconcilable(at("04:00")))
+	require.False(t, cohortOf("night").Reconcilable(at("12:00")))
+
+	require.Equal(t, "22:00-04:00", cohortOf("night").MaintenanceWindow())
+	require.Empty(t, cohortOf("always").MaintenanceWindow())
+}
+
+func TestLabels(t *testing.T) {
+	require.Empty(t, Labels(nil))
+
+	kebLabels := map[string]string{"ring": "canary", "region": "override"}
+	result := Labels(&keb.Metadata{
+		Region:          "westeurope",
+		ServicePlanName: "azure",
+		SubAccountID:    "sub-account",
+		Labels:          &kebLabels,
+	})
+	require.Equal(t, "override", result["region"])
+	require.Equal(t, "azure", result["servicePlanName"])
+	require.Equal(t, "sub-account", result["subAccountID"])
+	require.Equal(t, "canary", result["ring"])
+	require.False(t, result.Has("globalAccountID"))
+}
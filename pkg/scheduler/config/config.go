@@ -10,19 +10,302 @@ const FallbackComponentReconciler = "base"
 
 type ComponentReconciler struct {
 	URL string
+	//FallbackURLs are alternative endpoints of the same component reconciler which are called if URL is unhealthy
+	FallbackURLs []string
+}
+
+//Endpoints returns the URLs of the component reconciler in the order they have to be called
+func (c ComponentReconciler) Endpoints() []string {
+	return append([]string{c.URL}, c.FallbackURLs...)
+}
+
+//AggregationConfig defines how the cluster status is derived from the operation states of a reconciliation
+type AggregationConfig struct {
+	//Policy is either 'strict' (default: any failed operation fails the cluster) or 'tolerant'
+	Policy string
+	//BestEffortComponents are the components whose failures are tolerated (if empty, all components are best-effort)
+	BestEffortComponents []string
+	//MaxBestEffortFailures is the number of failed best-effort components which are tolerated per reconciliation
+	MaxBestEffortFailures int
+	//TimeoutsAsDegraded tolerates operations which failed because of a timeout
+	TimeoutsAsDegraded bool
+}
+
+//Cohort groups clusters by their labels (e.g. ring0=internal, ring1=canary customers, ring2=all clusters)
+type Cohort struct {
+	Name string
+	//Selector is a Kubernetes label selector which is matched against the cluster labels (empty selector matches all)
+	Selector string
+	//Paused stops rolling out changes to the clusters of the cohort
+	Paused bool
+	//MaintenanceWindow restricts the reconciliations of the cohort to a daily time range in UTC (e.g. "22:00-04:00")
+	MaintenanceWindow string
+	//Features overrides feature flags (referenced by their env var name) for the clusters of the cohort
+	Features map[string]bool
+}
+
+//DeadLetterConfig defines the handling of operations which failed permanently (all retries are exhausted)
+type DeadLetterConfig struct {
+	//Enabled moves permanently failed operations to the dead-letter queue
+	Enabled bool
+	//AlertThreshold is the number of open dead letters which triggers an alert (0 disables alerts)
+	AlertThreshold int
+}
+
+//SLO is the service level objective of a component (e.g. 99% of the operations succeed within 10 minutes)
+type SLO struct {
+	Component string
+	//Target is the ratio of operations which have to succeed (e.g. 0.99)
+	Target float64
+	//Latency is the max. processing duration of a successful operation (e.g. "10m"), measured from the time a worker
+	//picked it up (queue time excluded). The duration isn't evaluated if empty.
+	Latency string
+	//Window is the time range of the evaluated operations (default is "168h")
+	Window string
 }
 
 type SchedulerConfig struct {
 	PreComponents  [][]string
 	Reconcilers    map[string]ComponentReconciler
 	DeleteStrategy string
+	Aggregation    AggregationConfig
+	//Cohorts are evaluated in their defined order: a cluster belongs to the first matching cohort
+	Cohorts    []Cohort
+	DeadLetter DeadLetterConfig
+	SLOs       []SLO
+	//ComponentWeights are the expected durations of the components (e.g. "10m"): within a reconciliation, the
+	//longest-running components are started first (after their dependencies)
+	ComponentWeights map[string]string
+	Flakiness        FlakinessConfig
+	Health           HealthConfig
+	Anomalies        AnomalyConfig
+	Backpressure     BackpressureConfig
+	PayloadEncoding  PayloadEncodingConfig
+	//KubeconfigDelivery defines how the kubeconfig of the cluster is passed to the component reconcilers
+	KubeconfigDelivery KubeconfigDeliveryConfig
+}
+
+//KubeconfigDeliveryConfig replaces the kubeconfig in the tasks by a reference: the component reconcilers fetch the
+//kubeconfig from the mothership when they start the operation. Kubeconfigs aren't exposed in task payloads, logs and
+//queues anymore.
+type KubeconfigDeliveryConfig struct {
+	//ByReference sends a short-lived token instead of the kubeconfig (the mothership has to verify client certificates)
+	ByReference bool
+	//TokenTTL is the validity of a token (default is "10m")
+	TokenTTL string
+	//SigningKeyFile contains the secret which signs the tokens: it has to be shared by all mothership replicas
+	SigningKeyFile string
+}
+
+//PayloadEncodingConfig defines how the tasks are encoded which are sent to the component reconcilers. The encodings
+//are only used if a component reconciler announced that it accepts them.
+type PayloadEncodingConfig struct {
+	//Gzip compresses the payloads which are larger than GzipMinSize
+	Gzip bool
+	//GzipMinSize in bytes (default is 32768)
+	GzipMinSize int
+	//Protobuf sends the tasks as protobuf messages instead of JSON
+	Protobuf bool
+}
+
+//HealthConfig defines the scoring of the cluster health
+type HealthConfig struct {
+	//Disabled turns the health scoring off
+	Disabled bool
+	//Window of reconciliations which are considered (default is "24h")
+	Window string
+	//Interval of the scoring (default is "5m")
+	Interval string
+}
+
+//AnomalyConfig defines the detection of fleet-wide anomalies in the operations of a component: the operations within
+//the window are compared with the operations within the baseline window
+type AnomalyConfig struct {
+	//Enabled turns the anomaly detection on
+	Enabled bool
+	//Interval of the detection (default is "15m")
+	Interval string
+	//Window of recent operations which are checked for anomalies (default is "1h")
+	Window string
+	//BaselineWindow of operations which define the normal behaviour of a component (default is "168h")
+	BaselineWindow string
+	//MinOperations a component requires within the window and the baseline window to be checked (default is 10)
+	MinOperations int
+	//FailureDeviation is the number of standard deviations the failure ratio has to exceed the baseline (default is 3)
+	FailureDeviation float64
+	//DurationFactor is the factor the median duration of a Kyma version has to exceed the median duration of the
+	//other Kyma versions (default is 2)
+	DurationFactor float64
+	//BaseURL of the mothership API used for the links to the affected clusters (links are relative if empty)
+	BaseURL string
+}
+
+//BackpressureConfig throttles the dispatching of operations and the writing of interim operation statuses while
+//the database of the mothership is slow or failing
+type BackpressureConfig struct {
+	//Enabled turns the backpressure on
+	Enabled bool
+	//Interval of the latency checks (default is "10s")
+	Interval string
+	//LatencyThreshold is the average latency of the database operations above which the mothership gets throttled
+	//(default is "250ms")
+	LatencyThreshold string
+	//ErrorThreshold is the ratio of failed database operations above which the mothership gets throttled
+	//(default is 0.1)
+	ErrorThreshold float64
+	//MinDispatchRatio is the share of the free workers which still receive operations at the max. throttling
+	//(default is 0.1)
+	MinDispatchRatio float64
+	//StatusWriteInterval is the min. interval between two writes of an unchanged interim operation status while
+	//throttled: it has to be lower than the orphan timeout of operations (default is "2m")
+	StatusWriteInterval string
+}
+
+//FlakinessConfig defines when a component is classified as flaky and quarantined
+type FlakinessConfig struct {
+	//Threshold is the ratio of operations which succeeded only after a retry (0 disables the classification)
+	Threshold float64
+	//Window of finished operations which are considered (default is "24h")
+	Window string
+	//Interval of the classification (default is "5m")
+	Interval string
+	//MinOperations is the number of finished operations a component requires before it can be quarantined (default is 10)
+	MinOperations int
+	//ExtraRetries are granted to the operations of a quarantined component (default is 5)
+	ExtraRetries int
+	//MaxParallelOperations limits the operations of a quarantined component which run fleet-wide (default is 5)
+	MaxParallelOperations int
+}
+
+//PolicyConfig defines the policy engine (OPA) which admits the cluster configurations sent by KEB
+type PolicyConfig struct {
+	//URL of the OPA decision (e.g. "http://localhost:8181/v1/data/reconciler/admission"), policies are disabled if empty
+	URL string
+	//Timeout of a policy evaluation (default is "5s")
+	Timeout string
+	//FailOpen admits configurations if the policy engine can't be reached (by default they are rejected)
+	FailOpen bool
+}
+
+//ValidationWebhookConfig defines the external webhook which validates the cluster configurations sent by KEB
+//before they are stored
+type ValidationWebhookConfig struct {
+	//URL of the validation webhook, the validation is disabled if empty
+	URL string
+	//Timeout of a webhook call (default is "10s")
+	Timeout string
+	//FailurePolicy is either 'Fail' (default: requests are rejected if the webhook fails) or 'Ignore'
+	FailurePolicy string
+}
+
+//SubscriptionsConfig tunes the delivery of the cluster status changes to the webhooks of the subscriptions
+type SubscriptionsConfig struct {
+	//Timeout of a delivery (default is "10s")
+	Timeout string
+	//MaxRetries of a delivery which failed with a network error or a temporary server error (default is 3)
+	MaxRetries int
+	//QueueSize is the number of status changes waiting for delivery: further changes are dropped (default is 1000)
+	QueueSize int
+	//Workers deliver the status changes in parallel (default is 5)
+	Workers int
+	//CacheTTL is the interval after which the subscriptions are reloaded from the database (default is "30s")
+	CacheTTL string
+	//AllowPrivateTargets permits deliveries to private, loopback and link-local addresses (e.g. webhooks running
+	//inside the cluster of the mothership)
+	AllowPrivateTargets bool
+}
+
+//SnapshotsConfig enables the capturing of key facts of the clusters (node count, Kubernetes version, installed
+//Kyma CRDs) after each successful reconciliation
+type SnapshotsConfig struct {
+	Enabled bool
+	//Retention is the number of snapshots kept per cluster (default is 10)
+	Retention int
+	//Timeout of capturing a snapshot (default is "30s")
+	Timeout string
+	//Workers capture the snapshots in parallel (default is 2)
+	Workers int
+}
+
+//TakeoverProtectionConfig marks the reconciled clusters as owned by this mothership: clusters owned by another
+//mothership (e.g. staging and production pointed at the same cluster) aren't reconciled unless forced
+type TakeoverProtectionConfig struct {
+	Enabled bool
+	//Identity of this mothership (e.g. "prod-eu") which is written into the ownership marker
+	Identity string
+	//Namespace of the ownership marker (default is "kube-system")
+	Namespace string
+	//Timeout of reading and writing the ownership marker (default is "10s")
+	Timeout string
+}
+
+//UpdateRateLimitConfig limits the configuration updates of a cluster (e.g. caused by runaway automation)
+type UpdateRateLimitConfig struct {
+	//MaxUpdates is the number of configuration versions which can be created per cluster within the period
+	//(0 disables the limit)
+	MaxUpdates int
+	//Period of the rate limit (default is "1m")
+	Period string
+	//Clusters overrides the rate limit per runtime ID (an override with MaxUpdates 0 disables the limit of the cluster)
+	Clusters map[string]UpdateRateLimitOverride
+}
+
+//UpdateRateLimitOverride is the rate limit of a single cluster
+type UpdateRateLimitOverride struct {
+	MaxUpdates int
+	//Period of the rate limit (the global period is used if empty)
+	Period string
+}
+
+//ClientRateLimitConfig limits the API requests per caller (e.g. a misbehaving KEB integration) by a token bucket
+type ClientRateLimitConfig struct {
+	//RequestsPerSecond which are refilled into the bucket of each caller (0 disables the limit)
+	RequestsPerSecond float64
+	//Burst is the size of the bucket: the number of requests a caller can send at once (default is the
+	//RequestsPerSecond rounded up)
+	Burst int
+	//Clients overrides the rate limit per authenticated caller identity (JWT subject or client certificate CN): an
+	//override with RequestsPerSecond 0 disables the limit of the caller. Source IPs can't be overridden because
+	//they can be spoofed.
+	Clients map[string]ClientRateLimitOverride
+	//TrustForwardedFor uses the X-Forwarded-For header as source IP (only behind a trusted proxy)
+	TrustForwardedFor bool
+	//TrustedProxies is the number of trusted proxies in front of the mothership which append the address of their
+	//peer to the X-Forwarded-For header (default is 1): the source IP is taken from the right end of the header
+	//because all entries left of the trusted proxies are controlled by the client
+	TrustedProxies int
+}
+
+//ForwardedForHops returns the position (from the right) of the source IP in the X-Forwarded-For header or 0 if
+//the header isn't trusted
+func (c ClientRateLimitConfig) ForwardedForHops() int {
+	if !c.TrustForwardedFor {
+		return 0
+	}
+	if c.TrustedProxies < 1 {
+		return 1
+	}
+	return c.TrustedProxies
+}
+
+//ClientRateLimitOverride is the rate limit of a single caller
+type ClientRateLimitOverride struct {
+	RequestsPerSecond float64
+	Burst             int
 }
 
 type Config struct {
-	Scheme    string
-	Host      string
-	Port      int
-	Scheduler SchedulerConfig
+	Scheme             string
+	Host               string
+	Port               int
+	Scheduler          SchedulerConfig
+	Policy             PolicyConfig
+	Validation         ValidationWebhookConfig
+	UpdateRateLimit    UpdateRateLimitConfig
+	ClientRateLimit    ClientRateLimitConfig
+	Subscriptions      SubscriptionsConfig
+	Snapshots          SnapshotsConfig
+	TakeoverProtection TakeoverProtectionConfig
 }
 
 func (c *Config) Validate() error {
@@ -41,5 +324,8 @@ func (c *Config) Validate() error {
 	if len(c.Scheduler.PreComponents) == 0 {
 		return errors.New("pre-components for mothership scheduler are not configured")
 	}
+	if c.Scheduler.KubeconfigDelivery.ByReference && c.Scheduler.KubeconfigDelivery.SigningKeyFile == "" {
+		return errors.New("signing key file is required if kubeconfigs are delivered by reference")
+	}
 	return nil
 }
@@ -19,3 +19,8 @@ func TestConfig(t *testing.T) {
 	require.NoError(t, viper.UnmarshalKey("mothership", cfg))
 	require.NotEmpty(t, cfg.Scheduler.Reconcilers[FallbackComponentReconciler])
 }
+
+func TestComponentReconcilerEndpoints(t *testing.T) {
+	require.Equal(t, []string{"a"}, ComponentReconciler{URL: "a"}.Endpoints())
+	require.Equal(t, []string{"a", "b", "c"}, ComponentReconciler{URL: "a", FallbackURLs: []string{"b", "c"}}.Endpoints())
+}
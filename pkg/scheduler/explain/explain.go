@@ -0,0 +1,399 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/cohort"
+)
+
+//Severity ranks the findings: blocking findings hold the cluster back until they are resolved, warnings
+//slow it down or let reconciliations fail, infos describe the progress
+type Severity int
+
+const (
+	SeverityBlocking Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityBlocking:
+		return "blocking"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+//Finding is a human-readable reason why a cluster isn't ready
+type Finding struct {
+	Check    string
+	Severity Severity
+	//Component is empty if the finding concerns the whole cluster
+	Component string
+	Message   string
+}
+
+//Input is the diagnostic data of a cluster
+type Input struct {
+	Cluster *model.ClusterEntity
+	Status  *model.ClusterStatusEntity
+	//Operations of the latest reconciliation of the cluster
+	Operations []*model.OperationEntity
+	//History contains the operations of the previous reconciliations (used to detect recurring error classes)
+	History []*model.OperationEntity
+	//DeadLetters are the open dead letters of the cluster
+	DeadLetters []*model.DeadLetterEntity
+	//Pause is the active fleet-wide pause of the scheduler (nil if the scheduler isn't paused)
+	Pause *model.SchedulerPauseEntity
+	//Cohort of the cluster (nil if the cluster belongs to no cohort)
+	Cohort *cohort.Cohort
+	//Quarantined checks whether a component is quarantined because of its flakiness (optional)
+	Quarantined func(component string) bool
+}
+
+//Explanation summarizes what is holding a cluster back
+type Explanation struct {
+	Ready   bool
+	Summary string
+	//Findings are ranked by their severity (the most severe finding first)
+	Findings []*Finding
+}
+
+type check func(in *Input, now time.Time) []*Finding
+
+//checks is the diagnostic pipeline: findings of the same severity are ranked in the order of the checks
+var checks = []check{
+	checkStatus,
+	checkKubeconfig,
+	checkSchedulerPause,
+	checkCohort,
+	checkPendingConfirmations,
+	checkDeadLetters,
+	checkOperations,
+	checkErrorClasses,
+	checkQuarantine,
+}
+
+//Explain runs the diagnostic pipeline and returns the ranked findings of a cluster
+func Explain(in *Input, now time.Time) *Explanation {
+	var findings []*Finding
+	for _, check := range checks {
+		findings = append(findings, check(in, now)...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity < findings[j].Severity
+	})
+
+	result := &Explanation{
+		Ready:    in.Status != nil && in.Status.Status == model.ClusterStatusReady,
+		Findings: findings,
+	}
+	switch {
+	case result.Ready:
+		result.Summary = "Cluster is ready"
+	case len(findings) > 0 && findings[0].Severity == SeverityBlocking:
+		result.Summary = findings[0].Message
+	case len(findings) > 0:
+		result.Summary = fmt.Sprintf("Nothing blocks the cluster: %s", findings[0].Message)
+	default:
+		result.Summary = "Nothing blocks the cluster"
+	}
+	return result
+}
+
+func checkStatus(in *Input, _ time.Time) []*Finding {
+	if in.Status == nil {
+		return nil
+	}
+	finding := &Finding{Check: "status"}
+	switch in.Status.Status {
+	case model.ClusterStatusReconcileDisabled:
+		finding.Severity = SeverityBlocking
+		finding.Message = "Reconciliation of the cluster is disabled"
+	case model.ClusterStatusReconcilePending:
+		finding.Severity = SeverityInfo
+		finding.Message = "Cluster waits to be picked up by the scheduler"
+	case model.ClusterStatusReconciling:
+		finding.Severity = SeverityInfo
+		finding.Message = "Cluster is being reconciled"
+	case model.ClusterStatusReconcileErrorRetryable, model.ClusterStatusDeleteErrorRetryable:
+		finding.Severity = SeverityWarning
+		finding.Message = "Latest reconciliation failed: the cluster gets reconciled again"
+	case model.ClusterStatusReconcileError, model.ClusterStatusDeleteError:
+		finding.Severity = SeverityBlocking
+		finding.Message = "Latest reconciliation failed and is not retried: the cluster requires a configuration " +
+			"update or a manual intervention"
+	case model.ClusterStatusDeletePending, model.ClusterStatusDeleting, model.ClusterStatusDeleted:
+		finding.Severity = SeverityInfo
+		finding.Message = fmt.Sprintf("Cluster is being deleted (status '%s')", in.Status.Status)
+	default:
+		return nil
+	}
+	return []*Finding{finding}
+}
+
+func checkKubeconfig(in *Input, _ time.Time) []*Finding {
+	if in.Cluster == nil || in.Cluster.Kubeconfig != "" {
+		return nil
+	}
+	return []*Finding{{
+		Check:    "kubeconfig",
+		Severity: SeverityBlocking,
+		Message:  "Cluster has no kubeconfig: the component reconcilers cannot access it",
+	}}
+}
+
+func checkSchedulerPause(in *Input, now time.Time) []*Finding {
+	if in.Pause == nil || !in.Pause.Active(now) {
+		return nil
+	}
+	msg := fmt.Sprintf("Scheduler was paused fleet-wide by '%s' at %s", in.Pause.PausedBy,
+		in.Pause.Started.Format(time.RFC3339))
+	if until := in.Pause.Until(); !until.IsZero() {
+		msg = fmt.Sprintf("%s until %s", msg, until.Format(time.RFC3339))
+	} else {
+		msg = fmt.Sprintf("%s until it gets resumed", msg)
+	}
+	return []*Finding{{
+		Check:    "schedulerPause",
+		Severity: SeverityBlocking,
+		Message:  msg,
+	}}
+}
+
+func checkCohort(in *Input, now time.Time) []*Finding {
+	if in.Cohort == nil || in.Cohort.Reconcilable(now) {
+		return nil
+	}
+	finding := &Finding{
+		Check:    "cohort",
+		Severity: SeverityBlocking,
+	}
+	if in.Cohort.Paused {
+		finding.Message = fmt.Sprintf("Rollouts to cohort '%s' of the cluster are paused", in.Cohort.Name)
+	} else {
+		finding.Message = fmt.Sprintf("Maintenance window %s (UTC) of cohort '%s' is closed: the cluster gets "+
+			"reconciled when the window opens", in.Cohort.MaintenanceWindow(), in.Cohort.Name)
+	}
+	return []*Finding{finding}
+}
+
+func checkPendingConfirmations(in *Input, _ time.Time) []*Finding {
+	var findings []*Finding
+	for _, op := range in.Operations {
+		if op.State != model.OperationStatePendingConfirmation {
+			continue
+		}
+		findings = append(findings, &Finding{
+			Check:     "pendingConfirmation",
+			Severity:  SeverityBlocking,
+			Component: op.Component,
+			Message: fmt.Sprintf("Deletion of component '%s' waits for a confirmation because it would remove "+
+				"the stateful resources: %s", op.Component, strings.Join(pendingDeletion(op), ", ")),
+		})
+	}
+	return findings
+}
+
+//pendingDeletion returns the stateful resources which the deletion of an operation would remove
+func pendingDeletion(op *model.OperationEntity) []string {
+	var resources []string
+	if err := json.Unmarshal([]byte(op.PendingDeletion), &resources); err != nil && op.PendingDeletion != "" {
+		return []string{op.PendingDeletion}
+	}
+	return resources
+}
+
+func checkDeadLetters(in *Input, _ time.Time) []*Finding {
+	var findings []*Finding
+	for _, deadLetter := range in.DeadLetters {
+		findings = append(findings, &Finding{
+			Check:     "deadLetter",
+			Severity:  SeverityBlocking,
+			Component: deadLetter.Component,
+			Message: fmt.Sprintf("Operation of component '%s' failed permanently after %d retries (dead letter %d): "+
+				"it has to be requeued or acknowledged", deadLetter.Component, deadLetter.Retries, deadLetter.ID),
+		})
+	}
+	return findings
+}
+
+func checkOperations(in *Input, now time.Time) []*Finding {
+	var findings []*Finding
+	var waiting []string
+	for _, op := range in.Operations {
+		switch {
+		case op.State == model.OperationStateNew || op.State == model.OperationStateWaiting:
+			waiting = append(waiting, op.Component)
+		case op.State == model.OperationStateInProgress:
+			pickedUp := op.PickedUp
+			if pickedUp.IsZero() {
+				pickedUp = op.Created
+			}
+			findings = append(findings, &Finding{
+				Check:     "operation",
+				Severity:  SeverityInfo,
+				Component: op.Component,
+				Message: fmt.Sprintf("Component '%s' is being reconciled for %s (%d retries)",
+					op.Component, now.Sub(pickedUp).Round(time.Second), op.Retries),
+			})
+		case op.State == model.OperationStateOrphan:
+			findings = append(findings, &Finding{
+				Check:     "operation",
+				Severity:  SeverityWarning,
+				Component: op.Component,
+				Message: fmt.Sprintf("Component reconciler of '%s' stopped reporting progress (e.g. it was restarted "+
+					"or cannot reach the mothership): the operation gets retried", op.Component),
+			})
+		case op.State.IsError():
+			finding := &Finding{
+				Check:     "operation",
+				Severity:  SeverityBlocking,
+				Component: op.Component,
+				Message:   fmt.Sprintf("Component '%s' failed (state '%s')", op.Component, op.State),
+			}
+			if op.Reason != "" {
+				finding.Message = fmt.Sprintf("%s: %s", finding.Message, op.Reason)
+			}
+			if !op.State.IsFinal() {
+				finding.Severity = SeverityWarning
+			}
+			findings = append(findings, finding)
+		}
+	}
+	if len(waiting) > 0 {
+		sort.Strings(waiting)
+		findings = append(findings, &Finding{
+			Check:    "operation",
+			Severity: SeverityInfo,
+			Message: fmt.Sprintf("%d components wait for their dependencies or a free worker: %s",
+				len(waiting), strings.Join(waiting, ", ")),
+		})
+	}
+	return findings
+}
+
+//errorClass groups failed operations by the cause of their failure
+type errorClass struct {
+	name     string
+	keywords []string
+	hint     string
+}
+
+//errorClasses are matched in this order against the reasons of failed operations
+var errorClasses = []errorClass{
+	{
+		name:     "progressTracking",
+		keywords: []string{"progress tracker"},
+		hint:     "resources didn't become ready in time (e.g. pending pods or failing readiness probes)",
+	},
+	{
+		name:     "circuitBreaker",
+		keywords: []string{"mothership is not called"},
+		hint:     "the component reconciler cannot report to the mothership (its circuit breaker is open)",
+	},
+	{
+		name:     "timeout",
+		keywords: []string{"deadline exceeded", "timeout", "timed out"},
+		hint:     "the component reconciler ran into a timeout",
+	},
+	{
+		name:     "authorization",
+		keywords: []string{"unauthorized", "forbidden"},
+		hint:     "the kubeconfig of the cluster expired or lacks permissions",
+	},
+	{
+		name:     "connectivity",
+		keywords: []string{"connection refused", "connection reset", "no such host", "unreachable"},
+		hint:     "the API server of the cluster isn't reachable",
+	},
+	{
+		name:     "conflict",
+		keywords: []string{"conflict", "the object has been modified"},
+		hint:     "resources were modified concurrently",
+	},
+	{
+		name:     "capacity",
+		keywords: []string{"exceeded quota", "insufficient"},
+		hint:     "the cluster lacks capacity (quotas or node resources)",
+	},
+}
+
+func classify(op *model.OperationEntity) *errorClass {
+	reason := strings.ToLower(op.Reason)
+	for i := range errorClasses {
+		for _, keyword := range errorClasses[i].keywords {
+			if strings.Contains(reason, keyword) {
+				return &errorClasses[i]
+			}
+		}
+	}
+	return nil
+}
+
+//checkErrorClasses reports the recurring causes of the failures of the recent reconciliations
+func checkErrorClasses(in *Input, _ time.Time) []*Finding {
+	failures := make(map[string]int)
+	components := make(map[string]map[string]bool)
+	for _, op := range append(append([]*model.OperationEntity{}, in.Operations...), in.History...) {
+		if !op.State.IsError() && op.State != model.OperationStateOrphan {
+			continue
+		}
+		class := classify(op)
+		if class == nil {
+			continue
+		}
+		failures[class.name]++
+		if components[class.name] == nil {
+			components[class.name] = make(map[string]bool)
+		}
+		components[class.name][op.Component] = true
+	}
+
+	var findings []*Finding
+	for _, class := range errorClasses {
+		if failures[class.name] == 0 {
+			continue
+		}
+		var classComponents []string
+		for component := range components[class.name] {
+			classComponents = append(classComponents, component)
+		}
+		sort.Strings(classComponents)
+		findings = append(findings, &Finding{
+			Check:    "errorClass",
+			Severity: SeverityWarning,
+			Message: fmt.Sprintf("%d operations failed recently because %s (components: %s)",
+				failures[class.name], class.hint, strings.Join(classComponents, ", ")),
+		})
+	}
+	return findings
+}
+
+func checkQuarantine(in *Input, _ time.Time) []*Finding {
+	if in.Quarantined == nil {
+		return nil
+	}
+	var findings []*Finding
+	for _, op := range in.Operations {
+		if op.State.IsFinal() || !in.Quarantined(op.Component) {
+			continue
+		}
+		findings = append(findings, &Finding{
+			Check:     "quarantine",
+			Severity:  SeverityWarning,
+			Component: op.Component,
+			Message: fmt.Sprintf("Component '%s' is quarantined because of its flakiness: only a limited number "+
+				"of its operations runs in parallel across the fleet", op.Component),
+		})
+	}
+	return findings
+}
@@ -0,0 +1,115 @@
+package explain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/cohort"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	cluster := &model.ClusterEntity{RuntimeID: "runtime", Kubeconfig: "kubeconfig"}
+
+	t.Run("Ready cluster", func(t *testing.T) {
+		explanation := Explain(&Input{
+			Cluster: cluster,
+			Status:  &model.ClusterStatusEntity{Status: model.ClusterStatusReady},
+		}, now)
+		require.True(t, explanation.Ready)
+		require.Equal(t, "Cluster is ready", explanation.Summary)
+		require.Empty(t, explanation.Findings)
+	})
+
+	t.Run("Findings are ranked by severity", func(t *testing.T) {
+		explanation := Explain(&Input{
+			Cluster: cluster,
+			Status:  &model.ClusterStatusEntity{Status: model.ClusterStatusReconciling},
+			Operations: []*model.OperationEntity{
+				{Component: "base", State: model.OperationStateDone},
+				{Component: "istio", State: model.OperationStateInProgress, PickedUp: now.Add(-2 * time.Minute)},
+				{Component: "serverless", State: model.OperationStateNew},
+				{Component: "eventing", State: model.OperationStateFailed,
+					Reason: "progress tracker reached timeout (600 secs)"},
+				{Component: "monitoring", State: model.OperationStatePendingConfirmation,
+					PendingDeletion: `["PersistentVolumeClaim monitoring/prometheus"]`},
+			},
+			History: []*model.OperationEntity{
+				{Component: "eventing", State: model.OperationStateError,
+					Reason: "progress tracker reached timeout (600 secs)"},
+				{Component: "istio", State: model.OperationStateError, Reason: "Unauthorized"},
+			},
+			Quarantined: func(component string) bool {
+				return component == "eventing"
+			},
+		}, now)
+		require.False(t, explanation.Ready)
+
+		var checks []string
+		for i, finding := range explanation.Findings {
+			if i > 0 {
+				require.LessOrEqual(t, explanation.Findings[i-1].Severity, finding.Severity)
+			}
+			checks = append(checks, finding.Check)
+		}
+		require.Equal(t, []string{
+			"pendingConfirmation", //blocking
+			"operation",           //warning: eventing failed but gets retried
+			"errorClass",          //warning: progress tracking
+			"errorClass",          //warning: authorization
+			"quarantine",          //warning
+			"status",              //info
+			"operation",           //info: istio in progress
+			"operation",           //info: serverless waits
+		}, checks)
+
+		require.Equal(t, explanation.Findings[0].Message, explanation.Summary)
+		require.Contains(t, explanation.Summary, "PersistentVolumeClaim monitoring/prometheus")
+		require.Equal(t, "monitoring", explanation.Findings[0].Component)
+		require.Contains(t, explanation.Findings[2].Message, "2 operations failed recently")
+		require.Contains(t, explanation.Findings[2].Message, "components: eventing")
+		require.Contains(t, explanation.Findings[3].Message, "kubeconfig")
+		require.Contains(t, explanation.Findings[6].Message, "2m0s")
+	})
+
+	t.Run("Scheduler pause and cohort", func(t *testing.T) {
+		cohorts, err := cohort.NewResolver([]config.Cohort{
+			{Name: "night", MaintenanceWindow: "22:00-04:00"},
+		})
+		require.NoError(t, err)
+
+		explanation := Explain(&Input{
+			Cluster: &model.ClusterEntity{RuntimeID: "runtime"},
+			Status:  &model.ClusterStatusEntity{Status: model.ClusterStatusReconcilePending},
+			Pause: &model.SchedulerPauseEntity{
+				PausedBy: "operator",
+				Started:  now.Add(-time.Hour),
+			},
+			Cohort: cohorts.Resolve(nil),
+			DeadLetters: []*model.DeadLetterEntity{
+				{ID: 7, Component: "istio", Retries: 5},
+			},
+		}, now)
+
+		var checks []string
+		for _, finding := range explanation.Findings {
+			checks = append(checks, finding.Check)
+		}
+		require.Equal(t, []string{"kubeconfig", "schedulerPause", "cohort", "deadLetter", "status"}, checks)
+		require.Contains(t, explanation.Findings[1].Message, "until it gets resumed")
+		require.Contains(t, explanation.Findings[2].Message, "22:00-04:00")
+		require.Contains(t, explanation.Findings[3].Message, "dead letter 7")
+	})
+
+	t.Run("Nothing blocks the cluster", func(t *testing.T) {
+		explanation := Explain(&Input{
+			Cluster: cluster,
+			Status:  &model.ClusterStatusEntity{Status: model.ClusterStatusReconcilePending},
+		}, now)
+		require.Equal(t, "Nothing blocks the cluster: Cluster waits to be picked up by the scheduler",
+			explanation.Summary)
+	})
+}
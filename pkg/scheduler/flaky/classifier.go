@@ -0,0 +1,267 @@
+package flaky
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWindow                = 24 * time.Hour
+	defaultInterval              = 5 * time.Minute
+	defaultMinOperations         = 10
+	defaultExtraRetries          = 5
+	defaultMaxParallelOperations = 5
+)
+
+//Classification is the flakiness of a component across the fleet
+type Classification struct {
+	Component string
+	//Operations is the number of finished operations within the window
+	Operations int
+	//FlakyOperations is the number of operations which succeeded only after a retry
+	FlakyOperations int
+	Ratio           float64
+	Quarantined     bool
+	//Since is the time when the component was quarantined (zero if it isn't quarantined)
+	Since time.Time
+}
+
+//Classifier tracks the flakiness of the components (operations which failed but succeeded after a retry) and
+//quarantines components whose flakiness exceeds the threshold: operations of quarantined components get extra
+//retries and only a limited number of them runs in parallel across the fleet
+type Classifier struct {
+	threshold             float64
+	window                time.Duration
+	interval              time.Duration
+	minOperations         int
+	extraRetries          int
+	maxParallelOperations int
+	repo                  reconciliation.Repository
+	logger                *zap.SugaredLogger
+
+	m               sync.RWMutex
+	classifications map[string]*Classification
+}
+
+//NewClassifier returns the classifier or nil if the classification is disabled
+func NewClassifier(cfg config.FlakinessConfig, repo reconciliation.Repository, logger *zap.SugaredLogger) (*Classifier, error) {
+	if cfg.Threshold == 0 {
+		return nil, nil
+	}
+	if cfg.Threshold < 0 || cfg.Threshold > 1 {
+		return nil, fmt.Errorf("flakiness threshold has to be > 0 and <= 1 but was %v", cfg.Threshold)
+	}
+	classifier := &Classifier{
+		threshold:             cfg.Threshold,
+		window:                defaultWindow,
+		interval:              defaultInterval,
+		minOperations:         defaultMinOperations,
+		extraRetries:          defaultExtraRetries,
+		maxParallelOperations: defaultMaxParallelOperations,
+		repo:                  repo,
+		logger:                logger,
+		classifications:       make(map[string]*Classification),
+	}
+	var err error
+	if cfg.Window != "" {
+		if classifier.window, err = time.ParseDuration(cfg.Window); err != nil || classifier.window <= 0 {
+			return nil, fmt.Errorf("flakiness window '%s' is not a positive duration", cfg.Window)
+		}
+	}
+	if cfg.Interval != "" {
+		if classifier.interval, err = time.ParseDuration(cfg.Interval); err != nil || classifier.interval <= 0 {
+			return nil, fmt.Errorf("flakiness interval '%s' is not a positive duration", cfg.Interval)
+		}
+	}
+	if cfg.MinOperations < 0 || cfg.ExtraRetries < 0 || cfg.MaxParallelOperations < 0 {
+		return nil, fmt.Errorf("min. operations, extra retries and max. parallel operations of flakiness " +
+			"classification cannot be < 0")
+	}
+	if cfg.MinOperations > 0 {
+		classifier.minOperations = cfg.MinOperations
+	}
+	if cfg.ExtraRetries > 0 {
+		classifier.extraRetries = cfg.ExtraRetries
+	}
+	if cfg.MaxParallelOperations > 0 {
+		classifier.maxParallelOperations = cfg.MaxParallelOperations
+	}
+	return classifier, nil
+}
+
+//Run classifies the components in an interval until the context gets closed
+func (c *Classifier) Run(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	c.logger.Infof("Starting flakiness classification of components each %.0f secs (threshold: %v, window: %.0f secs)",
+		c.interval.Seconds(), c.threshold, c.window.Seconds())
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			if err := c.Classify(time.Now().UTC()); err != nil {
+				c.logger.Warnf("Failed to classify flakiness of components: %s", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+//Classify calculates the flakiness of the components from the operations which were finished within the window
+func (c *Classifier) Classify(now time.Time) error {
+	ops, err := c.repo.GetOperations(&operation.FilterMixer{Filters: []operation.Filter{
+		&operation.WithStates{States: []model.OperationState{model.OperationStateDone, model.OperationStateError}},
+		&operation.WithCreationDateAfter{Time: now.Add(-c.window)},
+	}})
+	if err != nil {
+		return err
+	}
+	c.update(c.classify(ops), now)
+	return nil
+}
+
+func (c *Classifier) classify(ops []*model.OperationEntity) map[string]*Classification {
+	result := make(map[string]*Classification)
+	for _, op := range ops {
+		classification, ok := result[op.Component]
+		if !ok {
+			classification = &Classification{Component: op.Component}
+			result[op.Component] = classification
+		}
+		classification.Operations++
+		if isFlaky(op) {
+			classification.FlakyOperations++
+		}
+	}
+	for _, classification := range result {
+		classification.Ratio = float64(classification.FlakyOperations) / float64(classification.Operations)
+		classification.Quarantined = classification.Operations >= c.minOperations &&
+			classification.Ratio >= c.threshold
+	}
+	return result
+}
+
+//isFlaky checks whether the operation succeeded after a retry (the first attempt is counted as retry too)
+func isFlaky(op *model.OperationEntity) bool {
+	return op.State == model.OperationStateDone && op.Retries > 1
+}
+
+func (c *Classifier) update(classifications map[string]*Classification, now time.Time) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for component, classification := range classifications {
+		previous, ok := c.classifications[component]
+		wasQuarantined := ok && previous.Quarantined
+		switch {
+		case classification.Quarantined && wasQuarantined:
+			classification.Since = previous.Since
+		case classification.Quarantined:
+			classification.Since = now
+			c.logger.Errorf("Component '%s' is quarantined: %d of %d operations (ratio %.2f) succeeded only after a "+
+				"retry within the last %.0f secs (threshold: %v)", component, classification.FlakyOperations,
+				classification.Operations, classification.Ratio, c.window.Seconds(), c.threshold)
+		case wasQuarantined:
+			c.logger.Infof("Component '%s' was released from quarantine: flakiness ratio dropped to %.2f",
+				component, classification.Ratio)
+		}
+	}
+	for component, previous := range c.classifications {
+		if _, ok := classifications[component]; !ok && previous.Quarantined {
+			c.logger.Infof("Component '%s' was released from quarantine: no operations finished within the window",
+				component)
+		}
+	}
+	c.classifications = classifications
+}
+
+//Classifications returns the latest classification of all components (ordered by component name)
+func (c *Classifier) Classifications() []*Classification {
+	if c == nil {
+		return nil
+	}
+	c.m.RLock()
+	defer c.m.RUnlock()
+	result := make([]*Classification, 0, len(c.classifications))
+	for _, classification := range c.classifications {
+		classificationCopy := *classification
+		result = append(result, &classificationCopy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Component < result[j].Component
+	})
+	return result
+}
+
+//Quarantined checks whether the component is currently quarantined
+func (c *Classifier) Quarantined(component string) bool {
+	if c == nil {
+		return false
+	}
+	c.m.RLock()
+	defer c.m.RUnlock()
+	classification, ok := c.classifications[component]
+	return ok && classification.Quarantined
+}
+
+//MaxRetries returns the max. retries of the operations of a component: quarantined components get extra retries
+func (c *Classifier) MaxRetries(component string, maxRetries int) int {
+	if c.Quarantined(component) {
+		return maxRetries + c.extraRetries
+	}
+	return maxRetries
+}
+
+//Isolate drops the processable operations of quarantined components which would exceed the max. number of
+//operations running in parallel for these components across the fleet
+func (c *Classifier) Isolate(ops []*model.OperationEntity) ([]*model.OperationEntity, error) {
+	if c == nil {
+		return ops, nil
+	}
+	quarantined := make(map[string]int) //free capacity per quarantined component
+	for _, op := range ops {
+		if c.Quarantined(op.Component) {
+			quarantined[op.Component] = c.maxParallelOperations
+		}
+	}
+	if len(quarantined) == 0 {
+		return ops, nil
+	}
+
+	runningOps, err := c.repo.GetReconcilingOperations()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range runningOps {
+		if _, ok := quarantined[op.Component]; ok && op.State == model.OperationStateInProgress {
+			quarantined[op.Component]--
+		}
+	}
+
+	result := make([]*model.OperationEntity, 0, len(ops))
+	for _, op := range ops {
+		if freeCapacity, ok := quarantined[op.Component]; ok {
+			if freeCapacity <= 0 {
+				c.logger.Debugf("Operation '%s' of quarantined component '%s' is deferred: max. parallel "+
+					"operations (%d) reached", op, op.Component, c.maxParallelOperations)
+				continue
+			}
+			quarantined[op.Component]--
+		}
+		result = append(result, op)
+	}
+	return result, nil
+}
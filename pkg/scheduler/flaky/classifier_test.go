@@ -0,0 +1,106 @@
+package flaky
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newOps(component string, count int, state model.OperationState, retries int64) []*model.OperationEntity {
+	var ops []*model.OperationEntity
+	for i := 0; i < count; i++ {
+		ops = append(ops, &model.OperationEntity{Component: component, State: state, Retries: retries})
+	}
+	return ops
+}
+
+func TestNewClassifier(t *testing.T) {
+	classifier, err := NewClassifier(config.FlakinessConfig{}, &reconciliation.MockRepository{}, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	require.Nil(t, classifier)
+
+	_, err = NewClassifier(config.FlakinessConfig{Threshold: 1.5}, &reconciliation.MockRepository{}, zap.NewNop().Sugar())
+	require.Error(t, err)
+
+	_, err = NewClassifier(config.FlakinessConfig{Threshold: 0.2, Window: "one day"}, &reconciliation.MockRepository{}, zap.NewNop().Sugar())
+	require.Error(t, err)
+
+	//disabled classifier quarantines nothing
+	require.False(t, classifier.Quarantined("istio"))
+	require.Equal(t, 5, classifier.MaxRetries("istio", 5))
+	ops := newOps("istio", 2, model.OperationStateNew, 0)
+	isolated, err := classifier.Isolate(ops)
+	require.NoError(t, err)
+	require.Equal(t, ops, isolated)
+}
+
+func TestClassifier(t *testing.T) {
+	var ops []*model.OperationEntity
+	ops = append(ops, newOps("istio", 6, model.OperationStateDone, 1)...)
+	ops = append(ops, newOps("istio", 4, model.OperationStateDone, 3)...)      //flaky
+	ops = append(ops, newOps("serverless", 9, model.OperationStateDone, 1)...) //below threshold
+	ops = append(ops, newOps("serverless", 1, model.OperationStateError, 5)...)
+	ops = append(ops, newOps("eventing", 3, model.OperationStateDone, 2)...) //too few operations
+	repo := &reconciliation.MockRepository{GetOperationsResult: ops}
+
+	classifier, err := NewClassifier(config.FlakinessConfig{
+		Threshold:             0.3,
+		MinOperations:         5,
+		ExtraRetries:          3,
+		MaxParallelOperations: 2,
+	}, repo, zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	require.NoError(t, classifier.Classify(now))
+
+	t.Run("Classify components", func(t *testing.T) {
+		classifications := classifier.Classifications()
+		require.Len(t, classifications, 3)
+		require.Equal(t, "eventing", classifications[0].Component)
+		require.False(t, classifications[0].Quarantined)
+		require.Equal(t, 1.0, classifications[0].Ratio)
+
+		require.Equal(t, "istio", classifications[1].Component)
+		require.Equal(t, 10, classifications[1].Operations)
+		require.Equal(t, 4, classifications[1].FlakyOperations)
+		require.True(t, classifications[1].Quarantined)
+		require.Equal(t, now, classifications[1].Since)
+
+		require.Equal(t, "serverless", classifications[2].Component)
+		require.Equal(t, 0, classifications[2].FlakyOperations)
+		require.False(t, classifications[2].Quarantined)
+	})
+
+	t.Run("Quarantined component gets extra retries", func(t *testing.T) {
+		require.Equal(t, 8, classifier.MaxRetries("istio", 5))
+		require.Equal(t, 5, classifier.MaxRetries("serverless", 5))
+	})
+
+	t.Run("Quarantined component is isolated", func(t *testing.T) {
+		repo.GetReconcilingOperationsResult = newOps("istio", 1, model.OperationStateInProgress, 1)
+		processable := append(newOps("istio", 3, model.OperationStateNew, 0),
+			newOps("serverless", 3, model.OperationStateNew, 0)...)
+		isolated, err := classifier.Isolate(processable)
+		require.NoError(t, err)
+		require.Len(t, isolated, 4)
+		require.Equal(t, processable[0], isolated[0])
+		require.Equal(t, processable[3:], isolated[1:])
+	})
+
+	t.Run("Quarantine start is kept", func(t *testing.T) {
+		require.NoError(t, classifier.Classify(now.Add(time.Hour)))
+		require.Equal(t, now, classifier.Classifications()[1].Since)
+
+		//component is released
+		repo.GetOperationsResult = newOps("istio", 10, model.OperationStateDone, 1)
+		require.NoError(t, classifier.Classify(now.Add(2*time.Hour)))
+		require.False(t, classifier.Quarantined("istio"))
+		require.True(t, classifier.Classifications()[0].Since.IsZero())
+	})
+}
@@ -0,0 +1,259 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWindow   = 24 * time.Hour
+	defaultInterval = 5 * time.Minute
+
+	MaxScore = 100
+
+	//weights of the penalties (they sum up to MaxScore)
+	failureWeight     = 40
+	retryWeight       = 20
+	driftWeight       = 15
+	timeInErrorWeight = 25
+
+	//number of drifts within the window which results in the full drift penalty
+	maxDrifts = 3
+)
+
+//Score is the health of a cluster derived from its reconciliations within the window
+type Score struct {
+	RuntimeID string
+	//Score is between 0 (needs attention) and 100 (healthy)
+	Score int
+	//Reconciliations is the number of finished reconciliations within the window
+	Reconciliations int
+	//FailedReconciliations is the number of reconciliations which finished with an error
+	FailedReconciliations int
+	//Operations is the number of finished operations within the window
+	Operations int
+	//RetriedOperations is the number of operations which required more than one attempt
+	RetriedOperations int
+	//Drifts is the number of reconciliations which failed although the configuration didn't change since the
+	//previous successful reconciliation (the cluster drifted away from its desired state)
+	Drifts int
+	//TimeInError is the time the cluster spent in an error status within the window
+	TimeInError time.Duration
+}
+
+//Scorer calculates a health score for each cluster from its recent reconciliation outcomes, operation retries,
+//drifts and time in error: it allows operators to find the clusters which need attention
+type Scorer struct {
+	window   time.Duration
+	interval time.Duration
+	repo     reconciliation.Repository
+	logger   *zap.SugaredLogger
+
+	m      sync.RWMutex
+	scores map[string]*Score
+}
+
+//NewScorer returns the scorer or nil if the health scoring is disabled
+func NewScorer(cfg config.HealthConfig, repo reconciliation.Repository, logger *zap.SugaredLogger) (*Scorer, error) {
+	if cfg.Disabled {
+		return nil, nil
+	}
+	scorer := &Scorer{
+		window:   defaultWindow,
+		interval: defaultInterval,
+		repo:     repo,
+		logger:   logger,
+		scores:   make(map[string]*Score),
+	}
+	var err error
+	if cfg.Window != "" {
+		if scorer.window, err = time.ParseDuration(cfg.Window); err != nil || scorer.window <= 0 {
+			return nil, fmt.Errorf("health window '%s' is not a positive duration", cfg.Window)
+		}
+	}
+	if cfg.Interval != "" {
+		if scorer.interval, err = time.ParseDuration(cfg.Interval); err != nil || scorer.interval <= 0 {
+			return nil, fmt.Errorf("health interval '%s' is not a positive duration", cfg.Interval)
+		}
+	}
+	return scorer, nil
+}
+
+//Run scores the clusters in an interval until the context gets closed
+func (s *Scorer) Run(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	s.logger.Infof("Starting health scoring of clusters each %.0f secs (window: %.0f secs)",
+		s.interval.Seconds(), s.window.Seconds())
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			if err := s.Score(time.Now().UTC()); err != nil {
+				s.logger.Warnf("Failed to score health of clusters: %s", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+//Score calculates the health of the clusters from the reconciliations and operations within the window
+func (s *Scorer) Score(now time.Time) error {
+	since := now.Add(-s.window)
+	recons, err := s.repo.GetReconciliations(&reconciliation.WithCreationDateAfter{Time: since})
+	if err != nil {
+		return err
+	}
+	ops, err := s.repo.GetOperations(&operation.FilterMixer{Filters: []operation.Filter{
+		&operation.WithStates{States: []model.OperationState{
+			model.OperationStateDone, model.OperationStateError, model.OperationStateAborted,
+		}},
+		&operation.WithCreationDateAfter{Time: since},
+	}})
+	if err != nil {
+		return err
+	}
+	scores := score(recons, ops, since, now)
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.scores = scores
+	return nil
+}
+
+func score(recons []*model.ReconciliationEntity, ops []*model.OperationEntity, since, now time.Time) map[string]*Score {
+	reconsByCluster := make(map[string][]*model.ReconciliationEntity)
+	for _, recon := range recons {
+		if recon.Finished {
+			reconsByCluster[recon.RuntimeID] = append(reconsByCluster[recon.RuntimeID], recon)
+		}
+	}
+
+	result := make(map[string]*Score, len(reconsByCluster))
+	for runtimeID, clusterRecons := range reconsByCluster {
+		result[runtimeID] = scoreReconciliations(runtimeID, clusterRecons, since, now)
+	}
+	for _, op := range ops {
+		score, ok := result[op.RuntimeID]
+		if !ok {
+			continue
+		}
+		score.Operations++
+		//the first attempt is counted as retry too
+		if op.Retries > 1 {
+			score.RetriedOperations++
+		}
+	}
+	for _, score := range result {
+		score.Score = score.calculate(now.Sub(since))
+	}
+	return result
+}
+
+func scoreReconciliations(runtimeID string, recons []*model.ReconciliationEntity, since, now time.Time) *Score {
+	sort.Slice(recons, func(i, j int) bool {
+		return recons[i].Updated.Before(recons[j].Updated)
+	})
+	score := &Score{
+		RuntimeID:       runtimeID,
+		Reconciliations: len(recons),
+	}
+	var lastSuccess *model.ReconciliationEntity
+	var errorSince time.Time
+	for _, recon := range recons {
+		if !isFailed(recon.Status) {
+			if !errorSince.IsZero() {
+				score.TimeInError += recon.Updated.Sub(errorSince)
+				errorSince = time.Time{}
+			}
+			lastSuccess = recon
+			continue
+		}
+		score.FailedReconciliations++
+		if lastSuccess != nil && lastSuccess.ClusterConfig == recon.ClusterConfig {
+			score.Drifts++
+		}
+		lastSuccess = nil
+		if errorSince.IsZero() {
+			errorSince = recon.Updated
+			if errorSince.Before(since) {
+				errorSince = since
+			}
+		}
+	}
+	if !errorSince.IsZero() {
+		score.TimeInError += now.Sub(errorSince)
+	}
+	return score
+}
+
+func isFailed(status model.Status) bool {
+	return status.IsFinal() && !status.IsFinalStable()
+}
+
+//calculate subtracts the weighted penalties from the max. score
+func (s *Score) calculate(window time.Duration) int {
+	var penalty float64
+	if s.Reconciliations > 0 {
+		penalty += failureWeight * float64(s.FailedReconciliations) / float64(s.Reconciliations)
+	}
+	if s.Operations > 0 {
+		penalty += retryWeight * float64(s.RetriedOperations) / float64(s.Operations)
+	}
+	penalty += driftWeight * math.Min(float64(s.Drifts)/maxDrifts, 1)
+	if window > 0 {
+		penalty += timeInErrorWeight * math.Min(float64(s.TimeInError)/float64(window), 1)
+	}
+	return int(math.Max(math.Round(MaxScore-penalty), 0))
+}
+
+//Scores returns the latest scores of all scored clusters (ordered by score, least healthy clusters first)
+func (s *Scorer) Scores() []*Score {
+	if s == nil {
+		return nil
+	}
+	s.m.RLock()
+	defer s.m.RUnlock()
+	result := make([]*Score, 0, len(s.scores))
+	for _, score := range s.scores {
+		scoreCopy := *score
+		result = append(result, &scoreCopy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score == result[j].Score {
+			return result[i].RuntimeID < result[j].RuntimeID
+		}
+		return result[i].Score < result[j].Score
+	})
+	return result
+}
+
+//Get returns the latest score of a cluster: false is returned if the cluster wasn't scored (e.g. no reconciliation
+//finished within the window)
+func (s *Scorer) Get(runtimeID string) (Score, bool) {
+	if s == nil {
+		return Score{}, false
+	}
+	s.m.RLock()
+	defer s.m.RUnlock()
+	score, ok := s.scores[runtimeID]
+	if !ok {
+		return Score{}, false
+	}
+	return *score, true
+}
@@ -0,0 +1,104 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewScorer(t *testing.T) {
+	scorer, err := NewScorer(config.HealthConfig{Disabled: true}, &reconciliation.MockRepository{}, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	require.Nil(t, scorer)
+
+	//disabled scorer scores nothing
+	require.Empty(t, scorer.Scores())
+	_, ok := scorer.Get("abc")
+	require.False(t, ok)
+
+	_, err = NewScorer(config.HealthConfig{Window: "one day"}, &reconciliation.MockRepository{}, zap.NewNop().Sugar())
+	require.Error(t, err)
+
+	_, err = NewScorer(config.HealthConfig{Interval: "-1m"}, &reconciliation.MockRepository{}, zap.NewNop().Sugar())
+	require.Error(t, err)
+}
+
+func TestScorer(t *testing.T) {
+	now := time.Date(2022, 1, 2, 12, 0, 0, 0, time.UTC)
+	newRecon := func(runtimeID string, configVersion int64, status model.Status, hoursAgo int) *model.ReconciliationEntity {
+		return &model.ReconciliationEntity{
+			RuntimeID:     runtimeID,
+			ClusterConfig: configVersion,
+			Status:        status,
+			Finished:      true,
+			Updated:       now.Add(-time.Duration(hoursAgo) * time.Hour),
+		}
+	}
+	newOp := func(runtimeID string, retries int64) *model.OperationEntity {
+		return &model.OperationEntity{RuntimeID: runtimeID, State: model.OperationStateDone, Retries: retries}
+	}
+
+	repo := &reconciliation.MockRepository{
+		GetReconciliationsResult: []*model.ReconciliationEntity{
+			newRecon("healthy", 1, model.ClusterStatusReady, 12),
+			newRecon("healthy", 1, model.ClusterStatusReady, 6),
+			//drifted: the unchanged configuration failed after a successful reconciliation
+			newRecon("drifted", 1, model.ClusterStatusReady, 12),
+			newRecon("drifted", 1, model.ClusterStatusReconcileError, 6),
+			newRecon("drifted", 1, model.ClusterStatusReady, 0),
+			//broken: a new configuration failed and the cluster is still in error
+			newRecon("broken", 1, model.ClusterStatusReady, 24),
+			newRecon("broken", 2, model.ClusterStatusReconcileError, 12),
+			newRecon("broken", 2, model.ClusterStatusReconcileError, 6),
+			//unfinished reconciliations are ignored
+			{RuntimeID: "running", Status: model.ClusterStatusReconciling},
+		},
+		GetOperationsResult: []*model.OperationEntity{
+			newOp("healthy", 1),
+			newOp("healthy", 1),
+			newOp("drifted", 3),
+			newOp("drifted", 1),
+			newOp("broken", 5),
+			newOp("unknown", 5),
+		},
+	}
+	scorer, err := NewScorer(config.HealthConfig{}, repo, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	require.NoError(t, scorer.Score(now))
+
+	scores := scorer.Scores()
+	require.Len(t, scores, 3)
+	//least healthy clusters first
+	require.Equal(t, "broken", scores[0].RuntimeID)
+	require.Equal(t, "drifted", scores[1].RuntimeID)
+	require.Equal(t, "healthy", scores[2].RuntimeID)
+
+	healthy, ok := scorer.Get("healthy")
+	require.True(t, ok)
+	require.Equal(t, Score{RuntimeID: "healthy", Score: MaxScore, Reconciliations: 2, Operations: 2}, healthy)
+
+	drifted, ok := scorer.Get("drifted")
+	require.True(t, ok)
+	require.Equal(t, 1, drifted.FailedReconciliations)
+	require.Equal(t, 1, drifted.Drifts)
+	require.Equal(t, 1, drifted.RetriedOperations)
+	require.Equal(t, 6*time.Hour, drifted.TimeInError)
+	//100 - 40*1/3 - 20*1/2 - 15*1/3 - 25*6/24
+	require.Equal(t, 65, drifted.Score)
+
+	broken, ok := scorer.Get("broken")
+	require.True(t, ok)
+	require.Equal(t, 2, broken.FailedReconciliations)
+	require.Zero(t, broken.Drifts)
+	require.Equal(t, 12*time.Hour, broken.TimeInError)
+	//100 - 40*2/3 - 20*1/1 - 0 - 25*12/24
+	require.Equal(t, 41, broken.Score)
+
+	_, ok = scorer.Get("running")
+	require.False(t, ok)
+}
@@ -0,0 +1,103 @@
+package invoker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+)
+
+const defaultGzipMinSize = 32 * 1024
+
+//acceptedEncodings of a component reconciler endpoint: they are announced by the Accept-Encoding and Accept-Post
+//headers of its responses
+type acceptedEncodings struct {
+	gzip     bool
+	protobuf bool
+}
+
+//encodedPayload is the HTTP body of a task
+type encodedPayload struct {
+	body            []byte
+	contentType     string
+	contentEncoding string
+}
+
+//payloadEncoder encodes the tasks in the most efficient representation a component reconciler endpoint accepts.
+//Until an endpoint responded, tasks are sent as plain JSON which is accepted by all component reconcilers.
+type payloadEncoder struct {
+	config    config.PayloadEncodingConfig
+	endpoints sync.Map //endpoint URL -> acceptedEncodings
+}
+
+func newPayloadEncoder(cfg config.PayloadEncodingConfig) *payloadEncoder {
+	if cfg.GzipMinSize <= 0 {
+		cfg.GzipMinSize = defaultGzipMinSize
+	}
+	return &payloadEncoder{config: cfg}
+}
+
+func (e *payloadEncoder) encode(url string, task *reconciler.Task) (*encodedPayload, error) {
+	var accepted acceptedEncodings
+	if value, ok := e.endpoints.Load(url); ok {
+		accepted = value.(acceptedEncodings)
+	}
+
+	payload := &encodedPayload{}
+	var err error
+	if e.config.Protobuf && accepted.protobuf {
+		payload.contentType = reconciler.ContentTypeProtobuf
+		payload.body, err = task.MarshalProto()
+	} else {
+		payload.contentType = reconciler.ContentTypeJSON
+		payload.body, err = json.Marshal(task)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encode task as '%s'", payload.contentType)
+	}
+
+	if e.config.Gzip && accepted.gzip && len(payload.body) >= e.config.GzipMinSize {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(payload.body); err != nil {
+			return nil, errors.Wrap(err, "failed to compress task")
+		}
+		if err := writer.Close(); err != nil {
+			return nil, errors.Wrap(err, "failed to compress task")
+		}
+		payload.body = buf.Bytes()
+		payload.contentEncoding = "gzip"
+	}
+	return payload, nil
+}
+
+//learn stores the encodings which the endpoint announced in its response
+func (e *payloadEncoder) learn(url string, resp *http.Response) {
+	e.endpoints.Store(url, acceptedEncodings{
+		gzip:     headerContains(resp.Header, "Accept-Encoding", "gzip"),
+		protobuf: headerContains(resp.Header, "Accept-Post", reconciler.ContentTypeProtobuf),
+	})
+}
+
+//forget falls back to plain JSON for the endpoint (e.g. it was downgraded to a version without protobuf support)
+func (e *payloadEncoder) forget(url string) {
+	e.endpoints.Delete(url)
+}
+
+func headerContains(header http.Header, name, value string) bool {
+	for _, values := range header.Values(name) {
+		for _, v := range strings.Split(values, ",") {
+			//ignore parameters like quality values ("gzip;q=1.0")
+			if strings.EqualFold(strings.TrimSpace(strings.Split(v, ";")[0]), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
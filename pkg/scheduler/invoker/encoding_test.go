@@ -0,0 +1,82 @@
+package invoker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadEncoder(t *testing.T) {
+	const url = "http://base-reconciler/v1/run"
+	task := &reconciler.Task{Component: "istio", Kubeconfig: strings.Repeat("x", 1024)}
+	acceptAll := &http.Response{Header: http.Header{
+		"Accept-Encoding": []string{"gzip;q=1.0, identity"},
+		"Accept-Post":     []string{"application/json, application/x-protobuf"},
+	}}
+
+	t.Run("Plain JSON until the endpoint announced its encodings", func(t *testing.T) {
+		encoder := newPayloadEncoder(config.PayloadEncodingConfig{Gzip: true, GzipMinSize: 10, Protobuf: true})
+		payload, err := encoder.encode(url, task)
+		require.NoError(t, err)
+		require.Equal(t, reconciler.ContentTypeJSON, payload.contentType)
+		require.Empty(t, payload.contentEncoding)
+
+		//older component reconcilers don't announce any encodings
+		encoder.learn(url, &http.Response{Header: http.Header{}})
+		payload, err = encoder.encode(url, task)
+		require.NoError(t, err)
+		require.Equal(t, reconciler.ContentTypeJSON, payload.contentType)
+		require.Empty(t, payload.contentEncoding)
+	})
+
+	t.Run("Negotiated encodings", func(t *testing.T) {
+		encoder := newPayloadEncoder(config.PayloadEncodingConfig{Gzip: true, GzipMinSize: 10, Protobuf: true})
+		encoder.learn(url, acceptAll)
+
+		payload, err := encoder.encode(url, task)
+		require.NoError(t, err)
+		require.Equal(t, reconciler.ContentTypeProtobuf, payload.contentType)
+		require.Equal(t, "gzip", payload.contentEncoding)
+
+		reader, err := gzip.NewReader(bytes.NewReader(payload.body))
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		decoded := &reconciler.Task{}
+		require.NoError(t, decoded.UnmarshalProto(data))
+		require.Equal(t, task.Kubeconfig, decoded.Kubeconfig)
+
+		//other endpoints are not affected
+		payload, err = encoder.encode("http://istio-reconciler/v1/run", task)
+		require.NoError(t, err)
+		require.Equal(t, reconciler.ContentTypeJSON, payload.contentType)
+
+		encoder.forget(url)
+		payload, err = encoder.encode(url, task)
+		require.NoError(t, err)
+		require.Equal(t, reconciler.ContentTypeJSON, payload.contentType)
+		require.Empty(t, payload.contentEncoding)
+	})
+
+	t.Run("Encodings disabled or payload too small", func(t *testing.T) {
+		encoder := newPayloadEncoder(config.PayloadEncodingConfig{Gzip: true})
+		encoder.learn(url, acceptAll)
+
+		payload, err := encoder.encode(url, task)
+		require.NoError(t, err)
+		require.Equal(t, reconciler.ContentTypeJSON, payload.contentType)
+		require.Empty(t, payload.contentEncoding, "payload is smaller than the default min size")
+
+		decoded := &reconciler.Task{}
+		require.NoError(t, json.Unmarshal(payload.body, decoded))
+		require.Equal(t, "istio", decoded.Component)
+	})
+}
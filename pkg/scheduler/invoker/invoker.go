@@ -23,21 +23,29 @@ type Params struct {
 	MaxOperationRetries  int
 	Type                 model.OperationType
 	Debug                bool
+	DeletionConfirmed    bool
+	CallbackSequence     int64
 }
 
-func (p *Params) newLocalTask(callbackFunc func(msg *reconciler.CallbackMessage) error) *reconciler.Task {
-	task := p.newTask()
+func (p *Params) newLocalTask(callbackFunc func(msg *reconciler.CallbackMessage) error) (*reconciler.Task, error) {
+	task, err := p.newTask()
+	if err != nil {
+		return nil, err
+	}
 	task.CallbackFunc = callbackFunc
-	return task
+	return task, nil
 }
 
-func (p *Params) newRemoteTask(callbackURL string) *reconciler.Task {
-	task := p.newTask()
+func (p *Params) newRemoteTask(callbackURL string) (*reconciler.Task, error) {
+	task, err := p.newTask()
+	if err != nil {
+		return nil, err
+	}
 	task.CallbackURL = callbackURL
-	return task
+	return task, nil
 }
 
-func (p *Params) newTask() *reconciler.Task {
+func (p *Params) newTask() (*reconciler.Task, error) {
 	version := p.ClusterState.Configuration.KymaVersion
 	// version := p.ComponentToReconcile.Version
 	url := p.ComponentToReconcile.URL
@@ -47,6 +55,13 @@ func (p *Params) newTask() *reconciler.Task {
 		version = p.ComponentToReconcile.Version
 	}
 
+	//components with a target are applied to the named kubeconfig of the cluster instead of the main kubeconfig
+	target := p.ComponentToReconcile.GetTarget()
+	kubeconfig, err := p.ClusterState.Cluster.TargetKubeconfig(target)
+	if err != nil {
+		return nil, err
+	}
+
 	return &reconciler.Task{
 		ComponentsReady: p.ComponentsReady,
 		Component:       p.ComponentToReconcile.Component,
@@ -55,16 +70,20 @@ func (p *Params) newTask() *reconciler.Task {
 		URL:             url,
 		Profile:         p.ClusterState.Configuration.KymaProfile,
 		Configuration:   p.ComponentToReconcile.ConfigurationAsMap(),
-		Kubeconfig:      p.ClusterState.Cluster.Kubeconfig,
+		Kubeconfig:      kubeconfig,
+		Target:          target,
 		Metadata:        *p.ClusterState.Cluster.Metadata,
 		CorrelationID:   p.CorrelationID,
 		Repository: &reconciler.Repository{
 			URL: url,
 		},
-		Type: p.Type,
+		Type:              p.Type,
+		DeletionConfirmed: p.DeletionConfirmed,
+		CallbackSequence:  p.CallbackSequence,
 		ComponentConfiguration: reconciler.ComponentConfiguration{
-			MaxRetries: p.MaxOperationRetries,
-			Debug:      p.Debug,
+			MaxRetries:  p.MaxOperationRetries,
+			Debug:       p.Debug,
+			TimeoutSecs: int64(p.ComponentToReconcile.ExecutionHints.GetTimeout().Seconds()),
 		},
-	}
+	}, nil
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInvoker(t *testing.T) {
@@ -32,6 +33,40 @@ func TestInvoker(t *testing.T) {
 		Type:                model.OperationTypeDelete,
 	}
 
-	task := params.newTask()
+	task, err := params.newTask()
+	require.NoError(t, err)
 	assert.Equal(t, model.OperationTypeDelete, task.Type, "Task type should equal operation type")
+	assert.Equal(t, int64(0), task.ComponentConfiguration.TimeoutSecs, "Timeout should not be overridden without hints")
+
+	timeout := "90m"
+	params.ComponentToReconcile.ExecutionHints = &keb.ExecutionHints{Timeout: &timeout}
+	task, err = params.newTask()
+	require.NoError(t, err)
+	assert.Equal(t, int64(5400), task.ComponentConfiguration.TimeoutSecs, "Timeout should be taken from execution hints")
+
+	t.Run("Target kubeconfig", func(t *testing.T) {
+		clusterState := *clusterStateMock
+		cluster := *clusterStateMock.Cluster
+		cluster.Kubeconfigs = map[string]string{"edge-1": "edge..."}
+		clusterState.Cluster = &cluster
+		targetParams := params
+		targetParams.ClusterState = &clusterState
+
+		targetParams.ComponentToReconcile = &keb.Component{Component: "TestComp1"}
+		task, err := targetParams.newTask()
+		require.NoError(t, err)
+		assert.Equal(t, "abc...", task.Kubeconfig, "Components without target use the main kubeconfig")
+		assert.Empty(t, task.Target)
+
+		target := "edge-1"
+		targetParams.ComponentToReconcile = &keb.Component{Component: "TestComp1", Target: &target}
+		task, err = targetParams.newTask()
+		require.NoError(t, err)
+		assert.Equal(t, "edge...", task.Kubeconfig, "Components with target use the kubeconfig of the target")
+		assert.Equal(t, "edge-1", task.Target)
+
+		target = "edge-2"
+		_, err = targetParams.newTask()
+		require.Error(t, err, "Unknown targets are rejected")
+	})
 }
@@ -58,7 +58,10 @@ func (i *LocalReconcilerInvoker) Invoke(ctx context.Context, params *Params) err
 	i.logger.Debugf("Local invoker is calling reconciler for component '%s' (schedulingID:%s/correlationID:%s)",
 		component, params.SchedulingID, params.CorrelationID)
 
-	reconModel := params.newLocalTask(i.newCallbackFunc(params))
+	reconModel, err := params.newLocalTask(i.newCallbackFunc(params))
+	if err != nil {
+		return err
+	}
 
 	return compRecon.StartLocal(ctx, reconModel, i.logger)
 }
@@ -89,6 +92,16 @@ func (i *LocalReconcilerInvoker) newCallbackFunc(params *Params) func(msg *recon
 			return i.updateOperationState(msg, params, model.OperationStateError)
 		case reconciler.StatusSuccess:
 			return i.updateOperationState(msg, params, model.OperationStateDone)
+		case reconciler.StatusSkipped:
+			return i.updateOperationState(msg, params, model.OperationStateSkipped)
+		case reconciler.StatusWaiting:
+			return i.updateOperationState(msg, params, model.OperationStateWaiting)
+		case reconciler.StatusPendingConfirmation:
+			var resources []string
+			if msg.PendingDeletion != nil {
+				resources = *msg.PendingDeletion
+			}
+			return i.reconRepo.UpdateOperationPendingDeletion(params.SchedulingID, params.CorrelationID, resources, msg.Error)
 		default:
 			i.logger.Debugf("Local invoker reported operation status '%s' but will not propagate "+
 				"it as new state to operation (schedulingID:%s/correlationID:%s)",
@@ -114,5 +127,26 @@ func (i *LocalReconcilerInvoker) updateOperationState(msg *reconciler.CallbackMe
 			"(schedulingID:%s/correlationID:%s) to state '%s'",
 			params.SchedulingID, params.CorrelationID, state))
 	}
+	if state.IsFinal() && msg.ReconcilerVersion != nil {
+		err = i.reconRepo.UpdateOperationReconcilerVersion(params.SchedulingID, params.CorrelationID, *msg.ReconcilerVersion)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("local invoker failed to update reconciler version of operation "+
+				"(schedulingID:%s/correlationID:%s)", params.SchedulingID, params.CorrelationID))
+		}
+	}
+	if state.IsFinal() && msg.Usage != nil {
+		err = i.reconRepo.UpdateOperationUsage(params.SchedulingID, params.CorrelationID, msg.Usage.ApiCalls, msg.Usage.ManifestBytes)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("local invoker failed to update usage of operation "+
+				"(schedulingID:%s/correlationID:%s)", params.SchedulingID, params.CorrelationID))
+		}
+	}
+	if state.IsFinal() {
+		err = i.reconRepo.UpdateOperationTimedOut(params.SchedulingID, params.CorrelationID, msg.TimedOut != nil && *msg.TimedOut)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("local invoker failed to update timeout flag of operation "+
+				"(schedulingID:%s/correlationID:%s)", params.SchedulingID, params.CorrelationID))
+		}
+	}
 	return nil
 }
@@ -5,8 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/kyma-incubator/reconciler/pkg/callback/client"
+	"github.com/kyma-incubator/reconciler/pkg/kubeconfigref"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/cohort"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/pkg/errors"
@@ -17,12 +20,18 @@ import (
 	"strings"
 )
 
-const callbackURLTemplate = "%s://%s:%d/v1/operations/%s/callback/%s"
+const (
+	callbackURLTemplate   = "%s://%s:%d/v1/operations/%s/callback/%s"
+	kubeconfigURLTemplate = "%s://%s:%d/v1/operations/%s/%s/kubeconfig"
+)
 
 type RemoteReconcilerInvoker struct {
 	reconRepo reconciliation.Repository
 	config    *config.Config
 	logger    *zap.SugaredLogger
+	encoder   *payloadEncoder
+	issuer    *kubeconfigref.Issuer
+	cohorts   *cohort.Resolver
 }
 
 func NewRemoteReconcilerInvoker(reconRepo reconciliation.Repository, cfg *config.Config, logger *zap.SugaredLogger) *RemoteReconcilerInvoker {
@@ -30,9 +39,23 @@ func NewRemoteReconcilerInvoker(reconRepo reconciliation.Repository, cfg *config
 		reconRepo: reconRepo,
 		config:    cfg,
 		logger:    logger,
+		encoder:   newPayloadEncoder(cfg.Scheduler.PayloadEncoding),
 	}
 }
 
+//WithKubeconfigIssuer replaces the kubeconfig in the tasks by a short-lived reference: the component reconcilers
+//fetch the kubeconfig from the mothership when they start processing the task
+func (i *RemoteReconcilerInvoker) WithKubeconfigIssuer(issuer *kubeconfigref.Issuer) *RemoteReconcilerInvoker {
+	i.issuer = issuer
+	return i
+}
+
+//WithCohorts passes the feature overrides of the cohort of a cluster to the component reconcilers
+func (i *RemoteReconcilerInvoker) WithCohorts(cohorts *cohort.Resolver) *RemoteReconcilerInvoker {
+	i.cohorts = cohorts
+	return i
+}
+
 func (i *RemoteReconcilerInvoker) Invoke(_ context.Context, params *Params) error {
 	if err := i.ensureOperationNotInProgress(params); err != nil {
 		return err
@@ -112,11 +135,17 @@ func (i *RemoteReconcilerInvoker) sendHTTPRequest(params *Params) (*http.Respons
 		i.config.Port,
 		params.SchedulingID,
 		params.CorrelationID)
-	payload := params.newRemoteTask(callbackURL)
-
-	jsonPayload, err := json.Marshal(payload)
+	payload, err := params.newRemoteTask(callbackURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal HTTP payload to call reconciler of component '%s': %s", component, err)
+		return nil, err
+	}
+	if i.issuer != nil {
+		if err := i.referenceKubeconfig(payload, params); err != nil {
+			return nil, err
+		}
+	}
+	if clusterCohort := i.cohorts.Resolve(params.ClusterState.Cluster.Metadata); clusterCohort != nil {
+		payload.ComponentConfiguration.Features = clusterCohort.Features
 	}
 
 	compRecon, ok := i.config.Scheduler.Reconcilers[component]
@@ -133,11 +162,66 @@ func (i *RemoteReconcilerInvoker) sendHTTPRequest(params *Params) (*http.Respons
 		}
 	}
 
+	endpoints := compRecon.Endpoints()
+	var resp *http.Response
+	for idx, url := range endpoints {
+		resp, err = i.post(url, payload, params)
+		if idx == len(endpoints)-1 || !isUnhealthyEndpoint(resp, err) {
+			break
+		}
+		i.logger.Warnf("Remote invoker detected unhealthy reconciler endpoint '%s' for component '%s' "+
+			"(schedulingID:%s/correlationID:%s): retrying with alternative endpoint '%s'",
+			url, component, params.SchedulingID, params.CorrelationID, endpoints[idx+1])
+		if resp != nil {
+			if err := resp.Body.Close(); err != nil {
+				i.logger.Errorf("Error while closing HTTP response body: %s", err)
+			}
+		}
+	}
+	return resp, err
+}
+
+func (i *RemoteReconcilerInvoker) referenceKubeconfig(task *reconciler.Task, params *Params) error {
+	token, expires, err := i.issuer.Issue(kubeconfigref.Claims{
+		RuntimeID:     params.ClusterState.Cluster.RuntimeID,
+		ConfigVersion: params.ClusterState.Configuration.Version,
+		SchedulingID:  params.SchedulingID,
+		CorrelationID: params.CorrelationID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to issue kubeconfig token")
+	}
+	task.Kubeconfig = ""
+	task.KubeconfigRef = &reconciler.KubeconfigRef{
+		URL: fmt.Sprintf(kubeconfigURLTemplate,
+			i.config.Scheme,
+			i.config.Host,
+			i.config.Port,
+			params.SchedulingID,
+			params.CorrelationID),
+		Token:   token,
+		Expires: expires,
+	}
+	return nil
+}
+
+func (i *RemoteReconcilerInvoker) post(url string, task *reconciler.Task, params *Params) (*http.Response, error) {
 	i.logger.Debugf("Remote invoker is calling remote reconciler via HTTP (URL: %s) "+
 		"for component '%s' (schedulingID:%s/correlationID:%s)",
-		compRecon.URL, params.ComponentToReconcile.Component, params.SchedulingID, params.CorrelationID)
+		url, params.ComponentToReconcile.Component, params.SchedulingID, params.CorrelationID)
 
-	resp, err := http.Post(compRecon.URL, "application/json", bytes.NewBuffer(jsonPayload))
+	resp, encoded, err := i.send(url, task)
+	if err == nil && resp.StatusCode == http.StatusUnsupportedMediaType &&
+		(encoded.contentType != reconciler.ContentTypeJSON || encoded.contentEncoding != "") {
+		//the endpoint doesn't accept the negotiated encoding anymore (e.g. it was downgraded): retry with plain JSON
+		i.logger.Warnf("Remote invoker detected that reconciler endpoint '%s' rejected payload encoding "+
+			"'%s' (content-encoding: '%s'): retrying with plain JSON", url, encoded.contentType, encoded.contentEncoding)
+		if err := resp.Body.Close(); err != nil {
+			i.logger.Errorf("Error while closing HTTP response body: %s", err)
+		}
+		i.encoder.forget(url)
+		resp, _, err = i.send(url, task)
+	}
 	if err == nil {
 		respDump, err := httputil.DumpResponse(resp, true)
 		if err == nil {
@@ -150,16 +234,49 @@ func (i *RemoteReconcilerInvoker) sendHTTPRequest(params *Params) (*http.Respons
 		}
 	} else {
 		i.logger.Warnf("Remote invoker failed to send HTTP request to component reconciler '%s': %s",
-			compRecon.URL, err)
-		return resp, errors.Wrap(err, fmt.Sprintf("failed to call remote reconciler (URL: %s)", compRecon.URL))
+			url, err)
+		return resp, errors.Wrap(err, fmt.Sprintf("failed to call remote reconciler (URL: %s)", url))
 	}
 
 	i.logger.Debugf("Remote invoker triggered reconciliation of component '%s' on remote component reconciler '%s': %d",
-		component, compRecon.URL, resp.StatusCode)
+		params.ComponentToReconcile.Component, url, resp.StatusCode)
 
 	return resp, nil
 }
 
+//send encodes the task for the endpoint and learns the encodings the endpoint accepts from its response
+func (i *RemoteReconcilerInvoker) send(url string, task *reconciler.Task) (*http.Response, *encodedPayload, error) {
+	encoded, err := i.encoder.encode(url, task)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(encoded.body))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to create request for remote reconciler (URL: %s)", url))
+	}
+	req.Header.Set("Content-Type", encoded.contentType)
+	if encoded.contentEncoding != "" {
+		req.Header.Set("Content-Encoding", encoded.contentEncoding)
+	}
+	req.Header.Set(client.PayloadVersionHeader, reconciler.PayloadVersion)
+	resp, err := http.DefaultClient.Do(req)
+	if err == nil {
+		i.encoder.learn(url, resp)
+	}
+	return resp, encoded, err
+}
+
+//isUnhealthyEndpoint returns true if the component reconciler endpoint was not reachable or
+//a proxy in front of it indicated that the endpoint is currently not available
+func isUnhealthyEndpoint(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusBadGateway ||
+		resp.StatusCode == http.StatusServiceUnavailable ||
+		resp.StatusCode == http.StatusGatewayTimeout
+}
+
 func (i *RemoteReconcilerInvoker) unmarshalHTTPResponse(body []byte, respModel interface{}, params *Params) error {
 	if err := json.Unmarshal(body, respModel); err != nil {
 		i.logger.Errorf("Remote invoker failed to unmarshal HTTP response of reconciler for component '%s': %s",
@@ -3,13 +3,17 @@ package invoker
 import (
 	"context"
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/kyma-incubator/reconciler/internal/cli/test"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/kubeconfigref"
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
@@ -158,6 +162,57 @@ func TestRemoteInvoker(t *testing.T) {
 
 		requireOperationState(t, reconRepo, opEntities[5], model.OperationStateClientError)
 	})
+
+	t.Run("Invoke component-reconciler: fallback to alternative endpoint", func(t *testing.T) {
+		cfg := &config.Config{
+			Scheme: "https",
+			Host:   "mothership-reconciler",
+			Port:   443,
+			Scheduler: config.SchedulerConfig{
+				PreComponents: nil,
+				Reconcilers: map[string]config.ComponentReconciler{
+					"base": {
+						URL:          "http://127.0.0.1:5555/503",
+						FallbackURLs: []string{"http://127.0.0.1:5555/200"},
+					},
+				},
+			},
+		}
+		err := invokeRemoteInvoker(reconRepo, opEntities[2], cfg)
+		require.NoError(t, err)
+
+		requireOperationState(t, reconRepo, opEntities[2], model.OperationStateInProgress)
+	})
+}
+
+func TestReferenceKubeconfig(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte(strings.Repeat("k", 32)), 0600))
+	issuer, err := kubeconfigref.NewIssuer(config.KubeconfigDeliveryConfig{ByReference: true, SigningKeyFile: keyFile})
+	require.NoError(t, err)
+
+	cfg := &config.Config{Scheme: "https", Host: "mothership-reconciler", Port: 443}
+	invoker := NewRemoteReconcilerInvoker(reconciliation.NewInMemoryReconciliationRepository(), cfg, logger.NewLogger(true)).
+		WithKubeconfigIssuer(issuer)
+	params := &Params{
+		ComponentToReconcile: &keb.Component{Component: "istio"},
+		ClusterState:         clusterStateMock,
+		SchedulingID:         "1",
+		CorrelationID:        "2",
+	}
+	task, err := params.newRemoteTask("https://mothership-reconciler:443/v1/operations/1/callback/2")
+	require.NoError(t, err)
+	require.NotEmpty(t, task.Kubeconfig)
+
+	require.NoError(t, invoker.referenceKubeconfig(task, params))
+	require.Empty(t, task.Kubeconfig, "kubeconfig is not part of the payload")
+	require.Equal(t, "https://mothership-reconciler:443/v1/operations/1/2/kubeconfig", task.KubeconfigRef.URL)
+	claims, err := issuer.Verify(task.KubeconfigRef.Token)
+	require.NoError(t, err)
+	require.Equal(t, clusterStateMock.Cluster.RuntimeID, claims.RuntimeID)
+	require.Equal(t, clusterStateMock.Configuration.Version, claims.ConfigVersion)
+	require.Equal(t, "2", claims.CorrelationID)
+	require.Equal(t, task.KubeconfigRef.Expires.Unix(), claims.Expires)
 }
 
 func invokeRemoteInvoker(reconRepo reconciliation.Repository, op *model.OperationEntity, cfg *config.Config) error {
@@ -222,6 +277,13 @@ func startServer(ctx context.Context, t *testing.T) {
 			}).
 			Methods("PUT", "POST")
 
+		router.HandleFunc(
+			"/503",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}).
+			Methods("PUT", "POST")
+
 		router.HandleFunc(
 			"/500bad",
 			func(w http.ResponseWriter, r *http.Request) {
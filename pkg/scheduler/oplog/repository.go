@@ -0,0 +1,117 @@
+package oplog
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/payload"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
+)
+
+//Repository stores the latest log entries which the component reconcilers reported with the final status
+//of an operation (the log entries are stored compressed)
+type Repository struct {
+	*repository.Repository
+}
+
+func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{repo}, nil
+}
+
+//Store adds the logs of an operation (existing logs of the operation are replaced)
+func (lr *Repository) Store(op *model.OperationEntity, logs *keb.OperationLogs) error {
+	data, err := json.Marshal(logs)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal operation logs")
+	}
+	compressed, err := payload.Compress(data)
+	if err != nil {
+		return err
+	}
+	entity := &model.OperationLogsEntity{
+		SchedulingID:  op.SchedulingID,
+		CorrelationID: op.CorrelationID,
+		RuntimeID:     op.RuntimeID,
+		Component:     op.Component,
+		Logs:          compressed,
+	}
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, entity, lr.Logger)
+		if err != nil {
+			return err
+		}
+		_, err = q.Delete().
+			Where(map[string]interface{}{
+				"SchedulingID":  entity.SchedulingID,
+				"CorrelationID": entity.CorrelationID,
+			}).
+			Exec()
+		if err != nil {
+			return err
+		}
+		insertQ, err := db.NewQuery(tx, entity, lr.Logger)
+		if err != nil {
+			return err
+		}
+		return insertQ.Insert().Exec()
+	}
+	return lr.Transactional(dbOps)
+}
+
+//Get returns the stored logs of an operation and their decompressed log entries
+func (lr *Repository) Get(schedulingID, correlationID string) (*model.OperationLogsEntity, *keb.OperationLogs, error) {
+	q, err := db.NewQuery(lr.Conn, &model.OperationLogsEntity{}, lr.Logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	whereCond := map[string]interface{}{
+		"SchedulingID":  schedulingID,
+		"CorrelationID": correlationID,
+	}
+	entity, err := q.Select().
+		Where(whereCond).
+		GetOne()
+	if err != nil {
+		return nil, nil, lr.NewNotFoundError(err, entity, whereCond)
+	}
+	logsEntity := entity.(*model.OperationLogsEntity)
+
+	data, err := payload.Decompress(logsEntity.Logs)
+	if err != nil {
+		return nil, nil, err
+	}
+	logs := &keb.OperationLogs{}
+	if err := json.Unmarshal(data, logs); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal operation logs")
+	}
+	return logsEntity, logs, nil
+}
+
+//RemoveLogsOlderThan purges the logs which were stored before the deadline
+func (lr *Repository) RemoveLogsOlderThan(deadline time.Time) (int64, error) {
+	colHandler, err := db.NewColumnHandler(&model.OperationLogsEntity{}, lr.Conn, lr.Logger)
+	if err != nil {
+		return 0, err
+	}
+	createdCol, err := colHandler.ColumnName("Created")
+	if err != nil {
+		return 0, err
+	}
+	q, err := db.NewQuery(lr.Conn, &model.OperationLogsEntity{}, lr.Logger)
+	if err != nil {
+		return 0, err
+	}
+	deleteQ := q.Delete()
+	return deleteQ.
+		WhereRaw(fmt.Sprintf("%s<$%d", createdCol, deleteQ.NextPlaceholderCount()), deadline.Format(db.TimeFormat)).
+		Exec()
+}
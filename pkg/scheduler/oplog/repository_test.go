@@ -0,0 +1,70 @@
+package oplog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repo, err := NewRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+
+	op := &model.OperationEntity{
+		SchedulingID:  uuid.NewString(),
+		CorrelationID: uuid.NewString(),
+		RuntimeID:     uuid.NewString(),
+		Component:     "istio",
+	}
+	defer func() {
+		q, err := db.NewQuery(repo.Conn, &model.OperationLogsEntity{}, repo.Logger)
+		require.NoError(t, err)
+		_, err = q.Delete().Where(map[string]interface{}{"RuntimeID": op.RuntimeID}).Exec()
+		require.NoError(t, err)
+	}()
+
+	logs := &keb.OperationLogs{
+		Entries: []keb.OperationLogEntry{
+			{Level: "info", Message: "deploying istio", Time: time.Now().UTC().Truncate(time.Second)},
+			{
+				Level:   "error",
+				Message: "deployment not ready",
+				Time:    time.Now().UTC().Truncate(time.Second),
+				Fields:  &map[string]string{"deployment": "istiod"},
+			},
+		},
+	}
+
+	t.Run("Logs of operation not found", func(t *testing.T) {
+		_, _, err := repo.Get(op.SchedulingID, op.CorrelationID)
+		require.True(t, repository.IsNotFoundError(err))
+	})
+
+	t.Run("Store and get logs", func(t *testing.T) {
+		require.NoError(t, repo.Store(op, logs))
+		entity, stored, err := repo.Get(op.SchedulingID, op.CorrelationID)
+		require.NoError(t, err)
+		require.Equal(t, "istio", entity.Component)
+		require.Equal(t, logs, stored)
+
+		//logs get replaced
+		logs.Truncated = true
+		require.NoError(t, repo.Store(op, logs))
+		_, stored, err = repo.Get(op.SchedulingID, op.CorrelationID)
+		require.NoError(t, err)
+		require.True(t, stored.Truncated)
+	})
+
+	t.Run("Remove logs older than deadline", func(t *testing.T) {
+		_, err := repo.RemoveLogsOlderThan(time.Now().UTC().Add(-24 * time.Hour))
+		require.NoError(t, err)
+		_, _, err = repo.Get(op.SchedulingID, op.CorrelationID)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,117 @@
+package pause
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+)
+
+//Repository stores the fleet-wide pauses of the scheduler (kill switch). Pauses are never deleted
+//to keep the pause windows traceable.
+type Repository struct {
+	*repository.Repository
+}
+
+func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{repo}, nil
+}
+
+//Pause stops the scheduling and dispatching of reconciliations for the given duration (0 = until it gets resumed).
+//An already active pause is replaced by the new one.
+func (pr *Repository) Pause(duration time.Duration, pausedBy string) (*model.SchedulerPauseEntity, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		if _, err := pr.resume(tx); err != nil {
+			return nil, err
+		}
+		now := time.Now().UTC()
+		entity := &model.SchedulerPauseEntity{
+			PausedBy: pausedBy,
+			Started:  now,
+			Duration: int64(duration.Seconds()),
+			Updated:  now,
+		}
+		q, err := db.NewQuery(tx, entity, pr.Logger)
+		if err != nil {
+			return nil, err
+		}
+		if err := q.Insert().Exec(); err != nil {
+			return nil, err
+		}
+		return pr.latest(tx)
+	}
+	entity, err := pr.TransactionalResult(dbOps)
+	if err != nil {
+		pr.Logger.Errorf("PauseRepository failed to pause the scheduler: %s", err)
+		return nil, err
+	}
+	return entity.(*model.SchedulerPauseEntity), nil
+}
+
+//Resume ends the active pause before its pause window is over. It returns nil if no pause was active.
+func (pr *Repository) Resume() (*model.SchedulerPauseEntity, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		return pr.resume(tx)
+	}
+	entity, err := pr.TransactionalResult(dbOps)
+	if err != nil {
+		pr.Logger.Errorf("PauseRepository failed to resume the scheduler: %s", err)
+		return nil, err
+	}
+	return entity.(*model.SchedulerPauseEntity), nil
+}
+
+func (pr *Repository) resume(conn db.Connection) (*model.SchedulerPauseEntity, error) {
+	entity, err := pr.active(conn)
+	if err != nil || entity == nil {
+		return nil, err
+	}
+	entity.Resumed = true
+	entity.Updated = time.Now().UTC()
+	q, err := db.NewQuery(conn, entity, pr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.Update().Where(map[string]interface{}{"ID": entity.ID}).Exec(); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+//Active returns the currently active pause or nil if the scheduler isn't paused. Pauses whose pause
+//window is over are no longer active: the scheduler resumes automatically.
+func (pr *Repository) Active() (*model.SchedulerPauseEntity, error) {
+	return pr.active(pr.Conn)
+}
+
+func (pr *Repository) active(conn db.Connection) (*model.SchedulerPauseEntity, error) {
+	entity, err := pr.latest(conn)
+	if err != nil || entity == nil {
+		return nil, err
+	}
+	if !entity.Active(time.Now().UTC()) {
+		return nil, nil
+	}
+	return entity, nil
+}
+
+func (pr *Repository) latest(conn db.Connection) (*model.SchedulerPauseEntity, error) {
+	q, err := db.NewQuery(conn, &model.SchedulerPauseEntity{}, pr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(map[string]interface{}{"Resumed": false}).
+		OrderBy(map[string]string{"ID": "DESC"}).
+		Limit(1).
+		GetMany()
+	if err != nil || len(entities) == 0 {
+		return nil, err
+	}
+	return entities[0].(*model.SchedulerPauseEntity), nil
+}
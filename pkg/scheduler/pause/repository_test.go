@@ -0,0 +1,72 @@
+package pause
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repo, err := NewRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+
+	pausedBy := uuid.NewString()
+	defer func() {
+		q, err := db.NewQuery(repo.Conn, &model.SchedulerPauseEntity{}, repo.Logger)
+		require.NoError(t, err)
+		_, err = q.Delete().Where(map[string]interface{}{"PausedBy": pausedBy}).Exec()
+		require.NoError(t, err)
+	}()
+
+	t.Run("Pause until resumed", func(t *testing.T) {
+		pause, err := repo.Pause(0, pausedBy)
+		require.NoError(t, err)
+		require.True(t, pause.Until().IsZero())
+
+		active, err := repo.Active()
+		require.NoError(t, err)
+		require.Equal(t, pause.ID, active.ID)
+
+		resumed, err := repo.Resume()
+		require.NoError(t, err)
+		require.Equal(t, pause.ID, resumed.ID)
+
+		active, err = repo.Active()
+		require.NoError(t, err)
+		require.Nil(t, active)
+
+		//resuming a scheduler which isn't paused is a no-op
+		resumed, err = repo.Resume()
+		require.NoError(t, err)
+		require.Nil(t, resumed)
+	})
+
+	t.Run("Pause replaces active pause", func(t *testing.T) {
+		pause1, err := repo.Pause(0, pausedBy)
+		require.NoError(t, err)
+		pause2, err := repo.Pause(2*time.Hour, pausedBy)
+		require.NoError(t, err)
+		require.NotEqual(t, pause1.ID, pause2.ID)
+		require.Equal(t, pause2.Started.Add(2*time.Hour), pause2.Until())
+
+		active, err := repo.Active()
+		require.NoError(t, err)
+		require.Equal(t, pause2.ID, active.ID)
+
+		_, err = repo.Resume()
+		require.NoError(t, err)
+	})
+
+	t.Run("Pause ends automatically", func(t *testing.T) {
+		_, err := repo.Pause(1*time.Second, pausedBy)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			active, err := repo.Active()
+			return err == nil && active == nil
+		}, 5*time.Second, 250*time.Millisecond)
+	})
+}
@@ -0,0 +1,129 @@
+package reconciliation
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+)
+
+//Cost is the resource usage caused by reconciliations
+type Cost struct {
+	Reconciliations int64
+	Operations      int64
+	//WallTime is the sum of the reconciliation durations (running reconciliations are considered until now)
+	WallTime time.Duration
+	//ProcessingTime is the sum of the processing durations reported by the component reconcilers
+	ProcessingTime time.Duration
+	//APICalls is the number of requests the component reconcilers sent to the API server of the cluster
+	APICalls int64
+	//ManifestBytes is the size of the manifests the component reconcilers deployed on the cluster
+	ManifestBytes int64
+}
+
+func (c *Cost) add(other *Cost) {
+	c.Reconciliations += other.Reconciliations
+	c.Operations += other.Operations
+	c.WallTime += other.WallTime
+	c.ProcessingTime += other.ProcessingTime
+	c.APICalls += other.APICalls
+	c.ManifestBytes += other.ManifestBytes
+}
+
+//ReconciliationCost is the resource usage of a single reconciliation
+type ReconciliationCost struct {
+	Cost
+	RuntimeID    string
+	SchedulingID string
+	Created      time.Time
+}
+
+//ClusterCost returns the accumulated cost of the reconciliations of a cluster which were created after the
+//given time and the cost of each of these reconciliations (latest first)
+func ClusterCost(repo Repository, runtimeID string, since, now time.Time) (*Cost, []*ReconciliationCost, error) {
+	recons, err := repo.GetReconciliations(&FilterMixer{Filters: []Filter{
+		&WithRuntimeID{RuntimeID: runtimeID},
+		&WithCreationDateAfter{Time: since},
+	}})
+	if err != nil {
+		return nil, nil, err
+	}
+	ops, err := repo.GetOperations(&operation.FilterMixer{Filters: []operation.Filter{
+		&operation.WithRuntimeID{RuntimeID: runtimeID},
+		&operation.WithCreationDateAfter{Time: since},
+	}})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reconCosts := reconciliationCosts(recons, ops, now)
+	total := &Cost{}
+	for _, reconCost := range reconCosts {
+		total.add(&reconCost.Cost)
+	}
+	return total, reconCosts, nil
+}
+
+//CostPerCluster returns the accumulated cost of the reconciliations which were created after the given time
+//per cluster (key is the runtime ID)
+func CostPerCluster(repo Repository, since, now time.Time) (map[string]*Cost, error) {
+	recons, err := repo.GetReconciliations(&WithCreationDateAfter{Time: since})
+	if err != nil {
+		return nil, err
+	}
+	ops, err := repo.GetOperations(&operation.WithCreationDateAfter{Time: since})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*Cost)
+	for _, reconCost := range reconciliationCosts(recons, ops, now) {
+		clusterCost, ok := result[reconCost.RuntimeID]
+		if !ok {
+			clusterCost = &Cost{}
+			result[reconCost.RuntimeID] = clusterCost
+		}
+		clusterCost.add(&reconCost.Cost)
+	}
+	return result, nil
+}
+
+//reconciliationCosts calculates the cost of each reconciliation: operations of other reconciliations are ignored
+func reconciliationCosts(recons []*model.ReconciliationEntity, ops []*model.OperationEntity, now time.Time) []*ReconciliationCost {
+	reconCosts := make(map[string]*ReconciliationCost, len(recons))
+	result := make([]*ReconciliationCost, 0, len(recons))
+	for _, recon := range recons {
+		end := now
+		if recon.Finished {
+			end = recon.Updated
+		}
+		reconCost := &ReconciliationCost{
+			Cost:         Cost{Reconciliations: 1},
+			RuntimeID:    recon.RuntimeID,
+			SchedulingID: recon.SchedulingID,
+			Created:      recon.Created,
+		}
+		if end.After(recon.Created) {
+			reconCost.WallTime = end.Sub(recon.Created)
+		}
+		reconCosts[recon.SchedulingID] = reconCost
+		result = append(result, reconCost)
+	}
+
+	for _, op := range ops {
+		reconCost, ok := reconCosts[op.SchedulingID]
+		if !ok {
+			continue
+		}
+		reconCost.Operations++
+		reconCost.ProcessingTime += time.Duration(op.ProcessingDuration) * time.Millisecond
+		reconCost.APICalls += op.APICalls
+		reconCost.ManifestBytes += op.ManifestBytes
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Created.After(result[j].Created)
+	})
+	return result
+}
@@ -0,0 +1,68 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCost(t *testing.T) {
+	now := time.Now().UTC()
+	recons := []*model.ReconciliationEntity{
+		{RuntimeID: "runtime1", SchedulingID: "recon1", Finished: true,
+			Created: now.Add(-time.Hour), Updated: now.Add(-50 * time.Minute)},
+		{RuntimeID: "runtime1", SchedulingID: "recon2", Created: now.Add(-5 * time.Minute)},
+		{RuntimeID: "runtime2", SchedulingID: "recon3", Finished: true,
+			Created: now.Add(-30 * time.Minute), Updated: now.Add(-28 * time.Minute)},
+	}
+	ops := []*model.OperationEntity{
+		{RuntimeID: "runtime1", SchedulingID: "recon1", ProcessingDuration: 60000, APICalls: 100, ManifestBytes: 2048},
+		{RuntimeID: "runtime1", SchedulingID: "recon1", ProcessingDuration: 30000, APICalls: 50, ManifestBytes: 1024},
+		{RuntimeID: "runtime1", SchedulingID: "recon2", ProcessingDuration: 0, APICalls: 10},
+		{RuntimeID: "runtime2", SchedulingID: "recon3", ProcessingDuration: 1000, APICalls: 5, ManifestBytes: 512},
+		{RuntimeID: "runtime2", SchedulingID: "unknown", APICalls: 1000}, //reconciliation is outside of the time window
+	}
+
+	t.Run("Cost of a cluster", func(t *testing.T) {
+		repo := &MockRepository{
+			GetReconciliationsResult: recons[:2],
+			GetOperationsResult:      ops[:3],
+		}
+		total, reconCosts, err := ClusterCost(repo, "runtime1", now.Add(-24*time.Hour), now)
+		require.NoError(t, err)
+		require.Equal(t, &Cost{
+			Reconciliations: 2,
+			Operations:      3,
+			WallTime:        15 * time.Minute,
+			ProcessingTime:  90 * time.Second,
+			APICalls:        160,
+			ManifestBytes:   3072,
+		}, total)
+
+		require.Len(t, reconCosts, 2)
+		require.Equal(t, "recon2", reconCosts[0].SchedulingID) //latest first
+		require.Equal(t, 5*time.Minute, reconCosts[0].WallTime)
+		require.Equal(t, int64(150), reconCosts[1].APICalls)
+	})
+
+	t.Run("Cost per cluster", func(t *testing.T) {
+		repo := &MockRepository{
+			GetReconciliationsResult: recons,
+			GetOperationsResult:      ops,
+		}
+		costs, err := CostPerCluster(repo, now.Add(-24*time.Hour), now)
+		require.NoError(t, err)
+		require.Len(t, costs, 2)
+		require.Equal(t, int64(160), costs["runtime1"].APICalls)
+		require.Equal(t, &Cost{
+			Reconciliations: 1,
+			Operations:      1,
+			WallTime:        2 * time.Minute,
+			ProcessingTime:  time.Second,
+			APICalls:        5,
+			ManifestBytes:   512,
+		}, costs["runtime2"])
+	})
+}
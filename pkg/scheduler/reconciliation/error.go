@@ -5,6 +5,7 @@ import (
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
 )
 
 type DuplicateClusterReconciliationError struct {
@@ -47,3 +48,22 @@ func IsEmptyComponentsReconciliationError(err error) bool {
 	_, ok := err.(*EmptyComponentsReconciliationError)
 	return ok
 }
+
+type FinishedReconciliationError struct {
+	entity *model.ReconciliationEntity
+}
+
+func (err *FinishedReconciliationError) Error() string {
+	return fmt.Sprintf("reconciliation '%s' is already finished", err.entity)
+}
+
+func NewFinishedReconciliationError(entity *model.ReconciliationEntity) error {
+	return &FinishedReconciliationError{
+		entity: entity,
+	}
+}
+
+func IsFinishedReconciliationError(err error) bool {
+	_, ok := errors.Cause(err).(*FinishedReconciliationError)
+	return ok
+}
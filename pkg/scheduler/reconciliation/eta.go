@@ -0,0 +1,86 @@
+package reconciliation
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+)
+
+//etaSampleSize is the number of the latest successful operations per component which are used for the estimation
+const etaSampleSize = 20
+
+//ETAEstimator estimates the remaining time of a running reconciliation by the mean processing durations
+//of the latest successful operations per component
+type ETAEstimator struct {
+	repo      Repository
+	durations map[string]time.Duration //cached mean duration per component
+}
+
+func NewETAEstimator(repo Repository) *ETAEstimator {
+	return &ETAEstimator{
+		repo:      repo,
+		durations: make(map[string]time.Duration),
+	}
+}
+
+//Estimate returns the expected remaining time of the reconciliation the operations belong to.
+//Operations with the same priority are processed in parallel: the remaining time is the sum
+//of the slowest unfinished operation per priority. Components without history are estimated with 0.
+func (e *ETAEstimator) Estimate(ops []*model.OperationEntity, now time.Time) (time.Duration, error) {
+	remainingByPrio := make(map[int64]time.Duration)
+	for _, op := range ops {
+		if op.State.IsFinal() {
+			continue
+		}
+		remaining, err := e.meanDuration(op.Component)
+		if err != nil {
+			return 0, err
+		}
+		if op.State != model.OperationStateNew && op.State != model.OperationStateOrphan && !op.PickedUp.IsZero() {
+			remaining -= now.Sub(op.PickedUp)
+		}
+		if remaining < 0 { //operation is slower than usual
+			remaining = 0
+		}
+		if remaining > remainingByPrio[op.Priority] {
+			remainingByPrio[op.Priority] = remaining
+		}
+	}
+
+	var eta time.Duration
+	for _, remaining := range remainingByPrio {
+		eta += remaining
+	}
+	return eta, nil
+}
+
+func (e *ETAEstimator) meanDuration(component string) (time.Duration, error) {
+	if duration, ok := e.durations[component]; ok {
+		return duration, nil
+	}
+	ops, err := e.repo.GetOperations(&operation.FilterMixer{Filters: []operation.Filter{
+		&operation.WithComponentName{Component: component},
+		&operation.WithStates{States: []model.OperationState{model.OperationStateDone}},
+		&operation.LimitByLastUpdate{Count: etaSampleSize},
+	}})
+	if err != nil {
+		return 0, err
+	}
+
+	var total time.Duration
+	var count int64
+	for _, op := range ops {
+		if op.PickedUp.IsZero() || op.Updated.Before(op.PickedUp) {
+			continue
+		}
+		total += op.Updated.Sub(op.PickedUp)
+		count++
+	}
+	var duration time.Duration
+	if count > 0 {
+		duration = total / time.Duration(count)
+	}
+	e.durations[component] = duration
+	return duration, nil
+}
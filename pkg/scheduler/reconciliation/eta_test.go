@@ -0,0 +1,56 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETAEstimator(t *testing.T) {
+	now := time.Now().UTC()
+	newOp := func(component string, prio int64, state model.OperationState, pickedUp time.Time) *model.OperationEntity {
+		return &model.OperationEntity{
+			Component: component,
+			Priority:  prio,
+			State:     state,
+			PickedUp:  pickedUp,
+		}
+	}
+
+	estimator := NewETAEstimator(&MockRepository{
+		GetOperationsResult: []*model.OperationEntity{
+			{State: model.OperationStateDone, PickedUp: now.Add(-5 * time.Minute), Updated: now.Add(-4 * time.Minute)},
+			{State: model.OperationStateDone, PickedUp: now.Add(-10 * time.Minute), Updated: now.Add(-7 * time.Minute)},
+		},
+	}) //mean duration of each component is 2 minutes
+
+	t.Run("Finished reconciliation", func(t *testing.T) {
+		eta, err := estimator.Estimate([]*model.OperationEntity{
+			newOp("istio", 1, model.OperationStateDone, now),
+			newOp("serverless", 2, model.OperationStateError, now),
+		}, now)
+		require.NoError(t, err)
+		require.Equal(t, time.Duration(0), eta)
+	})
+
+	t.Run("Running reconciliation", func(t *testing.T) {
+		eta, err := estimator.Estimate([]*model.OperationEntity{
+			newOp("istio", 1, model.OperationStateInProgress, now.Add(-90*time.Second)),
+			newOp("serverless", 2, model.OperationStateNew, time.Time{}),
+			newOp("eventing", 2, model.OperationStateNew, time.Time{}),
+		}, now)
+		require.NoError(t, err)
+		//30 secs for prio 1 + 2 minutes for parallel operations with prio 2
+		require.Equal(t, 150*time.Second, eta)
+	})
+
+	t.Run("Operation slower than usual", func(t *testing.T) {
+		eta, err := estimator.Estimate([]*model.OperationEntity{
+			newOp("istio", 1, model.OperationStateInProgress, now.Add(-5*time.Minute)),
+		}, now)
+		require.NoError(t, err)
+		require.Equal(t, time.Duration(0), eta)
+	})
+}
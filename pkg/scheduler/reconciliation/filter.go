@@ -280,6 +280,38 @@ func toInterfaceSlice(args []string) []interface{} {
 	return result
 }
 
+type WithClusterConfigs struct {
+	ClusterConfigs []int64
+}
+
+func (wc *WithClusterConfigs) FilterByQuery(q *db.Select) error {
+	if len(wc.ClusterConfigs) < 1 {
+		return nil
+	}
+
+	var values string
+	var args []interface{}
+	argsOffset := q.NextPlaceholderCount()
+	for i, clusterConfig := range wc.ClusterConfigs {
+		if i > 0 {
+			values += ","
+		}
+		values = fmt.Sprintf("%s$%d", values, i+argsOffset)
+		args = append(args, clusterConfig)
+	}
+	q.WhereIn("ClusterConfig", values, args...)
+	return nil
+}
+
+func (wc *WithClusterConfigs) FilterByInstance(i *model.ReconciliationEntity) *model.ReconciliationEntity {
+	for _, clusterConfig := range wc.ClusterConfigs {
+		if i.ClusterConfig == clusterConfig {
+			return i
+		}
+	}
+	return nil
+}
+
 type WithClusterConfigStatus struct {
 	ClusterConfigStatus int64
 }
@@ -1,6 +1,7 @@
 package reconciliation
 
 import (
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -17,6 +18,7 @@ type InMemoryReconciliationRepository struct {
 	reconciliations map[string]*model.ReconciliationEntity       //key: clusterName
 	operations      map[string]map[string]*model.OperationEntity //key1:schedulingID, key2:correlationID
 	status          map[int64]*model.ClusterStatusEntity         //key1:schedulingID, key2:correlationID
+	callbackSeq     int64
 	mu              sync.Mutex
 }
 
@@ -148,6 +150,29 @@ func (r *InMemoryReconciliationRepository) CreateReconciliation(state *cluster.S
 		}
 	}
 
+	//components removed from the cluster configuration get deleted at the very end
+	if cfg.RemovedComponents != nil {
+		priority := len(sequence.Queue) + 1
+		for _, component := range cfg.RemovedComponents.Components {
+			correlationID := fmt.Sprintf("%s--%s", state.Cluster.RuntimeID, uuid.NewString())
+
+			r.operations[reconEntity.SchedulingID][correlationID] = &model.OperationEntity{
+				Priority:      int64(priority),
+				SchedulingID:  reconEntity.SchedulingID,
+				CorrelationID: correlationID,
+				RuntimeID:     reconEntity.RuntimeID,
+				ClusterConfig: cfg.RemovedComponents.ConfigVersion,
+				Component:     component,
+				State:         model.OperationStateNew,
+				Type:          model.OperationTypeDelete,
+				Retries:       0,
+				RetryID:       uuid.NewString(),
+				Created:       time.Now().UTC(),
+				Updated:       time.Now().UTC(),
+			}
+		}
+	}
+
 	// cluster statuses
 	statusEntity := &model.ClusterStatusEntity{
 		ID:             state.Status.ID,
@@ -304,12 +329,12 @@ func (r *InMemoryReconciliationRepository) GetOperation(schedulingID, correlatio
 	return op, nil
 }
 
-func (r *InMemoryReconciliationRepository) GetProcessableOperations(maxParallelOpsPerRecon int) ([]*model.OperationEntity, error) {
+func (r *InMemoryReconciliationRepository) GetProcessableOperations(maxParallelOpsPerRecon int, weights ComponentWeights) ([]*model.OperationEntity, error) {
 	allOps, err := r.GetReconcilingOperations()
 	if err != nil {
 		return nil, err
 	}
-	return findProcessableOperations(allOps, maxParallelOpsPerRecon), nil
+	return findProcessableOperations(allOps, maxParallelOpsPerRecon, weights), nil
 }
 
 func (r *InMemoryReconciliationRepository) GetReconcilingOperations() ([]*model.OperationEntity, error) {
@@ -434,6 +459,187 @@ func (r *InMemoryReconciliationRepository) UpdateComponentOperationProcessingDur
 	return nil
 }
 
+func (r *InMemoryReconciliationRepository) UpdateOperationReconcilerVersion(schedulingID, correlationID, reconcilerVersion string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.operations[schedulingID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+	op, ok := r.operations[schedulingID][correlationID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+
+	// copy the operation to avoid having data races while writing
+	opCopy := *op
+
+	opCopy.ReconcilerVersion = reconcilerVersion
+	r.operations[schedulingID][correlationID] = &opCopy
+
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) UpdateOperationCallbackSequence(schedulingID, correlationID string, sequence int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.operations[schedulingID]
+	if !ok {
+		return false, &repository.EntityNotFoundError{}
+	}
+	op, ok := r.operations[schedulingID][correlationID]
+	if !ok {
+		return false, &repository.EntityNotFoundError{}
+	}
+	if op.CallbackSequence >= sequence {
+		return false, nil
+	}
+
+	// copy the operation to avoid having data races while writing
+	opCopy := *op
+
+	opCopy.CallbackSequence = sequence
+	r.operations[schedulingID][correlationID] = &opCopy
+
+	return true, nil
+}
+
+func (r *InMemoryReconciliationRepository) StartOperationCallbackSequence(schedulingID, correlationID string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.operations[schedulingID]
+	if !ok {
+		return 0, &repository.EntityNotFoundError{}
+	}
+	op, ok := r.operations[schedulingID][correlationID]
+	if !ok {
+		return 0, &repository.EntityNotFoundError{}
+	}
+
+	r.callbackSeq++
+	sequence := r.callbackSeq * callbackSequenceStride
+
+	// copy the operation to avoid having data races while writing
+	opCopy := *op
+
+	opCopy.CallbackSequence = sequence
+	r.operations[schedulingID][correlationID] = &opCopy
+
+	return sequence, nil
+}
+
+func (r *InMemoryReconciliationRepository) UpdateOperationUsage(schedulingID, correlationID string, apiCalls, manifestBytes int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.operations[schedulingID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+	op, ok := r.operations[schedulingID][correlationID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+
+	// copy the operation to avoid having data races while writing
+	opCopy := *op
+
+	opCopy.APICalls = apiCalls
+	opCopy.ManifestBytes = manifestBytes
+	r.operations[schedulingID][correlationID] = &opCopy
+
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) UpdateOperationTimedOut(schedulingID, correlationID string, timedOut bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.operations[schedulingID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+	op, ok := r.operations[schedulingID][correlationID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+
+	// copy the operation to avoid having data races while writing
+	opCopy := *op
+
+	opCopy.TimedOut = timedOut
+	r.operations[schedulingID][correlationID] = &opCopy
+
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) UpdateOperationPendingDeletion(schedulingID, correlationID string, resources []string, reason string) error {
+	pendingDeletion, err := json.Marshal(resources)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.operations[schedulingID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+	op, ok := r.operations[schedulingID][correlationID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+
+	// copy the operation to avoid having data races while writing
+	opCopy := *op
+
+	if opCopy.State.IsFinal() {
+		return fmt.Errorf("cannot update state of operation for component '%s' (schedulingID:%s/correlationID:'%s) "+
+			"to new state '%s' because operation is already in final state '%s'", opCopy.Component,
+			opCopy.SchedulingID, opCopy.CorrelationID, model.OperationStatePendingConfirmation, opCopy.State)
+	}
+
+	opCopy.State = model.OperationStatePendingConfirmation
+	opCopy.Reason = reason
+	opCopy.PendingDeletion = string(pendingDeletion)
+	opCopy.Updated = time.Now().UTC()
+	r.operations[schedulingID][correlationID] = &opCopy
+
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) ConfirmOperationDeletion(schedulingID, correlationID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.operations[schedulingID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+	op, ok := r.operations[schedulingID][correlationID]
+	if !ok {
+		return &repository.EntityNotFoundError{}
+	}
+	if op.State != model.OperationStatePendingConfirmation {
+		return fmt.Errorf("cannot confirm deletion of operation '%s' because it is in state '%s'", op, op.State)
+	}
+
+	// copy the operation to avoid having data races while writing
+	opCopy := *op
+
+	opCopy.State = model.OperationStateNew
+	opCopy.Reason = ""
+	opCopy.DeletionConfirmed = true
+	opCopy.Updated = time.Now().UTC()
+	r.operations[schedulingID][correlationID] = &opCopy
+
+	return nil
+}
+
 func (r *InMemoryReconciliationRepository) GetComponentOperationProcessingDuration(component string, state model.OperationState) (int64, error) {
 	operations, err := r.GetOperations(&operation.FilterMixer{
 		Filters: []operation.Filter{
@@ -504,6 +710,21 @@ func (r *InMemoryReconciliationRepository) GetAllComponents() ([]string, error)
 	return components, nil
 }
 
+func (r *InMemoryReconciliationRepository) CountFinishedOperations(component string, createdAfter time.Time, latency time.Duration) (*OperationCount, error) {
+	operations, err := r.GetOperations(&operation.FilterMixer{Filters: []operation.Filter{
+		&operation.WithComponentName{Component: component},
+		&operation.WithCreationDateAfter{Time: createdAfter},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	count := &OperationCount{}
+	for _, op := range operations {
+		count.countFinished(op, latency)
+	}
+	return count, nil
+}
+
 func unique(slice []string) []string {
 	keys := make(map[string]bool)
 	list := []string{}
@@ -25,12 +25,22 @@ type MockRepository struct {
 	UpdateOperationRetryIDResult                        error
 	UpdateOperationPickedUpResult                       error
 	UpdateComponentOperationProcessingDurationResult    error
+	UpdateOperationReconcilerVersionResult              error
+	UpdateOperationCallbackSequenceResult               bool
+	UpdateOperationCallbackSequenceResultError          error
+	StartOperationCallbackSequenceResult                int64
+	StartOperationCallbackSequenceResultError           error
+	UpdateOperationUsageResult                          error
+	UpdateOperationTimedOutResult                       error
+	UpdateOperationPendingDeletionResult                error
+	ConfirmOperationDeletionResult                      error
 	GetComponentOperationProcessingDurationResult       int64
 	GetComponentOperationProcessingDurationResultError  error
 	GetMothershipOperationProcessingDurationResult      int64
 	GetMothershipOperationProcessingDurationResultError error
 	GetAllComponentsResult                              []string
 	GetAllComponentsResultError                         error
+	CountFinishedOperationsResult                       *OperationCount
 	EnableDebugLoggingResult                            error
 	GetStatusIDsOlderThanDeadlineResult                 map[int64]bool
 }
@@ -110,7 +120,7 @@ func (mr *MockRepository) GetOperation(schedulingID, correlationID string) (*mod
 	return mr.GetOperationResult, nil
 }
 
-func (mr *MockRepository) GetProcessableOperations(maxParallelOpsPerRecon int) ([]*model.OperationEntity, error) {
+func (mr *MockRepository) GetProcessableOperations(maxParallelOpsPerRecon int, weights ComponentWeights) ([]*model.OperationEntity, error) {
 	return mr.GetProcessableOperationsResult, nil
 }
 
@@ -138,6 +148,34 @@ func (mr *MockRepository) UpdateComponentOperationProcessingDuration(schedulingI
 	return mr.UpdateComponentOperationProcessingDurationResult
 }
 
+func (mr *MockRepository) UpdateOperationReconcilerVersion(schedulingID, correlationID, reconcilerVersion string) error {
+	return mr.UpdateOperationReconcilerVersionResult
+}
+
+func (mr *MockRepository) UpdateOperationCallbackSequence(schedulingID, correlationID string, sequence int64) (bool, error) {
+	return mr.UpdateOperationCallbackSequenceResult, mr.UpdateOperationCallbackSequenceResultError
+}
+
+func (mr *MockRepository) StartOperationCallbackSequence(schedulingID, correlationID string) (int64, error) {
+	return mr.StartOperationCallbackSequenceResult, mr.StartOperationCallbackSequenceResultError
+}
+
+func (mr *MockRepository) UpdateOperationUsage(schedulingID, correlationID string, apiCalls, manifestBytes int64) error {
+	return mr.UpdateOperationUsageResult
+}
+
+func (mr *MockRepository) UpdateOperationTimedOut(schedulingID, correlationID string, timedOut bool) error {
+	return mr.UpdateOperationTimedOutResult
+}
+
+func (mr *MockRepository) UpdateOperationPendingDeletion(schedulingID, correlationID string, resources []string, reason string) error {
+	return mr.UpdateOperationPendingDeletionResult
+}
+
+func (mr *MockRepository) ConfirmOperationDeletion(schedulingID, correlationID string) error {
+	return mr.ConfirmOperationDeletionResult
+}
+
 func (mr *MockRepository) GetComponentOperationProcessingDuration(component string, state model.OperationState) (int64, error) {
 	return mr.GetComponentOperationProcessingDurationResult, mr.GetComponentOperationProcessingDurationResultError
 }
@@ -149,3 +187,10 @@ func (mr *MockRepository) GetMothershipOperationProcessingDuration(component str
 func (mr *MockRepository) GetAllComponents() ([]string, error) {
 	return mr.GetAllComponentsResult, mr.GetAllComponentsResultError
 }
+
+func (mr *MockRepository) CountFinishedOperations(component string, createdAfter time.Time, latency time.Duration) (*OperationCount, error) {
+	if mr.CountFinishedOperationsResult == nil {
+		return &OperationCount{}, nil
+	}
+	return mr.CountFinishedOperationsResult, nil
+}
@@ -3,6 +3,7 @@ package operation
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/model"
@@ -160,3 +161,46 @@ func (l *LimitByLastUpdate) FilterByInstance(re *model.OperationEntity) *model.O
 	}
 	return nil
 }
+
+type WithRuntimeID struct {
+	RuntimeID string
+}
+
+func (wr *WithRuntimeID) FilterByQuery(q *db.Select) error {
+	q.Where(map[string]interface{}{
+		"RuntimeID": wr.RuntimeID,
+	})
+	return nil
+}
+
+func (wr *WithRuntimeID) FilterByInstance(i *model.OperationEntity) *model.OperationEntity {
+	if i.RuntimeID == wr.RuntimeID {
+		return i
+	}
+	return nil
+}
+
+type WithCreationDateAfter struct {
+	Time time.Time
+}
+
+func (wd *WithCreationDateAfter) FilterByQuery(q *db.Select) error {
+	colHandler, err := db.NewColumnHandler(&model.OperationEntity{}, q.Conn, q.Logger)
+	if err != nil {
+		return err
+	}
+	column, err := colHandler.ColumnName("Created")
+	if err != nil {
+		return err
+	}
+
+	q.WhereRaw(fmt.Sprintf("%s>$%d", column, q.NextPlaceholderCount()), wd.Time.Format("2006-01-02 15:04:05.000"))
+	return nil
+}
+
+func (wd *WithCreationDateAfter) FilterByInstance(i *model.OperationEntity) *model.OperationEntity {
+	if i.Created.After(wd.Time) {
+		return i
+	}
+	return nil
+}
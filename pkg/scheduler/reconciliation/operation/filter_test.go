@@ -2,6 +2,7 @@ package operation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/model"
@@ -48,6 +49,15 @@ func TestFilterMixer_FilterByQuery(t *testing.T) {
 			wantErr:   false,
 			wantQuery: " WHERE scheduling_id=$1 AND correlation_id=$2 AND state IN ($3,$4) AND component=$5 ORDER BY created DESC LIMIT 1",
 		},
+		{
+			name: "ok with runtimeID and creation date filter",
+			filters: []Filter{
+				&WithRuntimeID{RuntimeID: "test-runtime-id"},
+				&WithCreationDateAfter{Time: time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)},
+			},
+			wantErr:   false,
+			wantQuery: " WHERE runtime_id=$1 AND (created>$2)",
+		},
 	}
 	for i := range tests {
 		tt := tests[i]
@@ -3,6 +3,7 @@ package reconciliation
 import (
 	"bytes"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/changes"
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/model"
@@ -147,6 +149,9 @@ func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster
 		}
 		r.Logger.Debugf("ReconRepo created new reconciliation for runtime '%s' with schedulingID '%s'",
 			state.Cluster.RuntimeID, reconEntity.SchedulingID)
+		if err := recordChange(tx, reconEntity, r.Logger); err != nil {
+			return nil, err
+		}
 
 		opType := model.OperationTypeReconcile
 		if state.Status.Status.IsDeletionInProgress() {
@@ -193,6 +198,41 @@ func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster
 			}
 		}
 
+		//components removed from the cluster configuration get deleted at the very end: their operations refer
+		//to the configuration version which still includes them
+		if cfg.RemovedComponents != nil {
+			priority := len(sequence.Queue) + 1
+			for _, component := range cfg.RemovedComponents.Components {
+				createOpQ, err := db.NewQuery(tx, &model.OperationEntity{
+					Priority:      int64(priority),
+					SchedulingID:  reconEntity.SchedulingID,
+					CorrelationID: fmt.Sprintf("%s--%s", state.Cluster.RuntimeID, uuid.NewString()),
+					RuntimeID:     reconEntity.RuntimeID,
+					ClusterConfig: cfg.RemovedComponents.ConfigVersion,
+					Component:     component,
+					State:         model.OperationStateNew,
+					Type:          model.OperationTypeDelete,
+					RetryID:       uuid.NewString(),
+					Updated:       time.Now().UTC(),
+				}, r.Logger)
+				if err != nil {
+					return nil, err
+				}
+
+				if err := createOpQ.Insert().Exec(); err != nil {
+					r.Logger.Errorf("ReconRepo failed to create delete operation for removed component '%s' "+
+						"(schedulingID:%s/runtimeID:%s): %s",
+						component, reconEntity.SchedulingID, state.Cluster.RuntimeID, err)
+					return nil, err
+				}
+
+				if opsList.Len() > 0 {
+					opsList.WriteRune(',')
+				}
+				opsList.WriteString(fmt.Sprintf("%s(removed)[%d]", component, priority))
+			}
+		}
+
 		r.Logger.Debugf("ReconRepo created reconciliation (schedulingID:%s) for cluster '%s' including following operations: %s",
 			reconEntity.SchedulingID, reconEntity.RuntimeID, opsList.String())
 
@@ -363,11 +403,22 @@ func (r *PersistentReconciliationRepository) FinishReconciliation(schedulingID s
 				"(maybe updated by parallel running process)", schedulingID)
 		}
 
-		return nil
+		return recordChange(tx, reconEntity, r.Logger)
 	}
 	return db.Transaction(r.Conn, dbOps, r.Logger)
 }
 
+//recordChange adds the status of the reconciliation to the change feed
+func recordChange(tx *db.TxConnection, reconEntity *model.ReconciliationEntity, logger *zap.SugaredLogger) error {
+	return changes.Record(tx, &model.ChangeEntity{
+		Kind:          model.ChangeKindReconciliation,
+		RuntimeID:     reconEntity.RuntimeID,
+		ConfigVersion: reconEntity.ClusterConfig,
+		SchedulingID:  reconEntity.SchedulingID,
+		Status:        reconEntity.Status,
+	}, logger)
+}
+
 func (r *PersistentReconciliationRepository) GetReconciliations(filter Filter) ([]*model.ReconciliationEntity, error) {
 	q, err := db.NewQuery(r.Conn, &model.ReconciliationEntity{}, r.Logger)
 	if err != nil {
@@ -440,12 +491,12 @@ func (r *PersistentReconciliationRepository) GetOperation(schedulingID, correlat
 	return opEntity.(*model.OperationEntity), nil
 }
 
-func (r *PersistentReconciliationRepository) GetProcessableOperations(maxParallelOpsPerRecon int) ([]*model.OperationEntity, error) {
+func (r *PersistentReconciliationRepository) GetProcessableOperations(maxParallelOpsPerRecon int, weights ComponentWeights) ([]*model.OperationEntity, error) {
 	opEntities, err := r.GetReconcilingOperations()
 	if err != nil {
 		return nil, err
 	}
-	return findProcessableOperations(opEntities, maxParallelOpsPerRecon), nil
+	return findProcessableOperations(opEntities, maxParallelOpsPerRecon, weights), nil
 }
 
 func (r *PersistentReconciliationRepository) GetReconcilingOperations() ([]*model.OperationEntity, error) {
@@ -676,6 +727,331 @@ func (r *PersistentReconciliationRepository) UpdateComponentOperationProcessingD
 	return db.Transaction(r.Conn, dbOps, r.Logger)
 }
 
+func (r *PersistentReconciliationRepository) UpdateOperationReconcilerVersion(schedulingID, correlationID, reconcilerVersion string) error {
+	dbOps := func(tx *db.TxConnection) error {
+		rTx, err := r.WithTx(tx)
+		if err != nil {
+			return err
+		}
+		op, err := rTx.GetOperation(schedulingID, correlationID)
+		if err != nil {
+			return err
+		}
+		if op.ReconcilerVersion == reconcilerVersion {
+			return nil
+		}
+		op.ReconcilerVersion = reconcilerVersion
+
+		//prepare update query
+		q, err := db.NewQuery(tx, op, r.Logger)
+		if err != nil {
+			return err
+		}
+		whereCond := map[string]interface{}{
+			"CorrelationID": correlationID,
+			"SchedulingID":  schedulingID,
+		}
+		cnt, err := q.Update().
+			Where(whereCond).
+			ExecCount()
+		if cnt == 0 {
+			return fmt.Errorf("update of operation '%s' reconcilerVersion failed: no row was updated", op)
+		}
+		return err
+	}
+	return db.Transaction(r.Conn, dbOps, r.Logger)
+}
+
+func (r *PersistentReconciliationRepository) UpdateOperationUsage(schedulingID, correlationID string, apiCalls, manifestBytes int64) error {
+	dbOps := func(tx *db.TxConnection) error {
+		rTx, err := r.WithTx(tx)
+		if err != nil {
+			return err
+		}
+		op, err := rTx.GetOperation(schedulingID, correlationID)
+		if err != nil {
+			return err
+		}
+		if op.APICalls == apiCalls && op.ManifestBytes == manifestBytes {
+			return nil
+		}
+		op.APICalls = apiCalls
+		op.ManifestBytes = manifestBytes
+
+		//prepare update query
+		q, err := db.NewQuery(tx, op, r.Logger)
+		if err != nil {
+			return err
+		}
+		whereCond := map[string]interface{}{
+			"CorrelationID": correlationID,
+			"SchedulingID":  schedulingID,
+		}
+		cnt, err := q.Update().
+			Where(whereCond).
+			ExecCount()
+		if cnt == 0 {
+			return fmt.Errorf("update of operation '%s' usage failed: no row was updated", op)
+		}
+		return err
+	}
+	return db.Transaction(r.Conn, dbOps, r.Logger)
+}
+
+func (r *PersistentReconciliationRepository) UpdateOperationTimedOut(schedulingID, correlationID string, timedOut bool) error {
+	dbOps := func(tx *db.TxConnection) error {
+		rTx, err := r.WithTx(tx)
+		if err != nil {
+			return err
+		}
+		op, err := rTx.GetOperation(schedulingID, correlationID)
+		if err != nil {
+			return err
+		}
+		if op.TimedOut == timedOut {
+			return nil
+		}
+		op.TimedOut = timedOut
+
+		//prepare update query
+		q, err := db.NewQuery(tx, op, r.Logger)
+		if err != nil {
+			return err
+		}
+		whereCond := map[string]interface{}{
+			"CorrelationID": correlationID,
+			"SchedulingID":  schedulingID,
+		}
+		cnt, err := q.Update().
+			Where(whereCond).
+			ExecCount()
+		if cnt == 0 {
+			return fmt.Errorf("update of operation '%s' timeout flag failed: no row was updated", op)
+		}
+		return err
+	}
+	return db.Transaction(r.Conn, dbOps, r.Logger)
+}
+
+func (r *PersistentReconciliationRepository) UpdateOperationPendingDeletion(schedulingID, correlationID string, resources []string, reason string) error {
+	pendingDeletion, err := json.Marshal(resources)
+	if err != nil {
+		return err
+	}
+	dbOps := func(tx *db.TxConnection) error {
+		rTx, err := r.WithTx(tx)
+		if err != nil {
+			return err
+		}
+		op, err := rTx.GetOperation(schedulingID, correlationID)
+		if err != nil {
+			return err
+		}
+		if op.State.IsFinal() {
+			return fmt.Errorf("cannot update state of operation '%s' to new state '%s' "+
+				"because operation is already in final state '%s'", op.Component, model.OperationStatePendingConfirmation, op.State)
+		}
+
+		opStateOld := op.State //required in where-condition later on
+		op.State = model.OperationStatePendingConfirmation
+		op.Reason = reason
+		op.PendingDeletion = string(pendingDeletion)
+		op.Updated = time.Now().UTC()
+
+		//prepare update query
+		q, err := db.NewQuery(tx, op, r.Logger)
+		if err != nil {
+			return err
+		}
+		whereCond := map[string]interface{}{
+			"CorrelationID": correlationID,
+			"SchedulingID":  schedulingID,
+			"State":         opStateOld, //ensure update will affect only operations which were not updated in between
+		}
+		cnt, err := q.Update().
+			Where(whereCond).
+			ExecCount()
+		if err != nil {
+			return err
+		}
+		if cnt == 0 {
+			return fmt.Errorf("update of operation '%s' to state '%s' failed: no row was updated "+
+				"(probably race-condition: operation does no longer match where-conditions)",
+				op, model.OperationStatePendingConfirmation)
+		}
+		return nil
+	}
+	return db.Transaction(r.Conn, dbOps, r.Logger)
+}
+
+func (r *PersistentReconciliationRepository) ConfirmOperationDeletion(schedulingID, correlationID string) error {
+	dbOps := func(tx *db.TxConnection) error {
+		rTx, err := r.WithTx(tx)
+		if err != nil {
+			return err
+		}
+		op, err := rTx.GetOperation(schedulingID, correlationID)
+		if err != nil {
+			return err
+		}
+		if op.State != model.OperationStatePendingConfirmation {
+			return fmt.Errorf("cannot confirm deletion of operation '%s' because it is in state '%s'", op, op.State)
+		}
+
+		op.State = model.OperationStateNew
+		op.Reason = ""
+		op.DeletionConfirmed = true
+		op.Updated = time.Now().UTC()
+
+		//prepare update query
+		q, err := db.NewQuery(tx, op, r.Logger)
+		if err != nil {
+			return err
+		}
+		whereCond := map[string]interface{}{
+			"CorrelationID": correlationID,
+			"SchedulingID":  schedulingID,
+			"State":         model.OperationStatePendingConfirmation, //ensure the deletion is confirmed only once
+		}
+		cnt, err := q.Update().
+			Where(whereCond).
+			ExecCount()
+		if err != nil {
+			return err
+		}
+		if cnt == 0 {
+			return fmt.Errorf("confirmation of operation '%s' failed: no row was updated "+
+				"(probably race-condition: operation does no longer match where-conditions)", op)
+		}
+		return nil
+	}
+	return db.Transaction(r.Conn, dbOps, r.Logger)
+}
+
+func (r *PersistentReconciliationRepository) UpdateOperationCallbackSequence(schedulingID, correlationID string, sequence int64) (bool, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		rTx, err := r.WithTx(tx)
+		if err != nil {
+			return false, err
+		}
+		op, err := rTx.GetOperation(schedulingID, correlationID)
+		if err != nil {
+			return false, err
+		}
+		if op.CallbackSequence >= sequence {
+			return false, nil
+		}
+		sequenceOld := op.CallbackSequence //required in where-condition later on
+		op.CallbackSequence = sequence
+
+		//prepare update query
+		q, err := db.NewQuery(tx, op, r.Logger)
+		if err != nil {
+			return false, err
+		}
+		whereCond := map[string]interface{}{
+			"CorrelationID":    correlationID,
+			"SchedulingID":     schedulingID,
+			"CallbackSequence": sequenceOld, //ensure concurrent callbacks of the same operation don't overwrite each other
+		}
+		cnt, err := q.Update().
+			Where(whereCond).
+			ExecCount()
+		if err != nil {
+			return false, err
+		}
+		if cnt == 0 { //a concurrent callback of the operation stored its sequence in the meantime
+			return false, nil
+		}
+		return true, nil
+	}
+	updated, err := r.TransactionalResult(dbOps)
+	if err != nil {
+		return false, err
+	}
+	return updated.(bool), nil
+}
+
+func (r *PersistentReconciliationRepository) StartOperationCallbackSequence(schedulingID, correlationID string) (int64, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		next, err := nextCallbackSequence(tx)
+		if err != nil {
+			return int64(0), err
+		}
+		sequence := next * callbackSequenceStride
+
+		op := &model.OperationEntity{}
+		columnHandler, err := db.NewColumnHandler(op, tx, r.Logger)
+		if err != nil {
+			return int64(0), err
+		}
+		sequenceColumn, err := columnHandler.ColumnName("CallbackSequence")
+		if err != nil {
+			return int64(0), err
+		}
+		schedulingIDColumn, err := columnHandler.ColumnName("SchedulingID")
+		if err != nil {
+			return int64(0), err
+		}
+		correlationIDColumn, err := columnHandler.ColumnName("CorrelationID")
+		if err != nil {
+			return int64(0), err
+		}
+		//only the sequence is updated: concurrent updates of the operation aren't overwritten
+		result, err := tx.Exec(fmt.Sprintf("UPDATE %s SET %s=$1 WHERE %s=$2 AND %s=$3",
+			op.Table(), sequenceColumn, schedulingIDColumn, correlationIDColumn), sequence, schedulingID, correlationID)
+		if err != nil {
+			return int64(0), err
+		}
+		cnt, err := result.RowsAffected()
+		if err != nil {
+			return int64(0), err
+		}
+		if cnt == 0 {
+			return int64(0), r.NewNotFoundError(sql.ErrNoRows, op, map[string]interface{}{
+				"SchedulingID":  schedulingID,
+				"CorrelationID": correlationID,
+			})
+		}
+		return sequence, nil
+	}
+	sequence, err := r.TransactionalResult(dbOps)
+	if err != nil {
+		return 0, err
+	}
+	return sequence.(int64), nil
+}
+
+//nextCallbackSequence returns the next value of the callback sequence
+func nextCallbackSequence(tx *db.TxConnection) (int64, error) {
+	var next int64
+	switch tx.Type() {
+	case db.Postgres:
+		row, err := tx.QueryRow("SELECT nextval('scheduler_callback_sequence')")
+		if err != nil {
+			return 0, err
+		}
+		if err := row.Scan(&next); err != nil {
+			return 0, errors.Wrap(err, "failed to retrieve next callback sequence")
+		}
+	case db.SQLite:
+		//the table emulates the sequence: AUTOINCREMENT never reuses IDs, so only the latest row has to be kept
+		result, err := tx.Exec("INSERT INTO scheduler_callback_sequence DEFAULT VALUES")
+		if err != nil {
+			return 0, err
+		}
+		if next, err = result.LastInsertId(); err != nil {
+			return 0, errors.Wrap(err, "failed to retrieve next callback sequence")
+		}
+		if _, err := tx.Exec("DELETE FROM scheduler_callback_sequence WHERE id < $1", next); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("database type '%s' is not supported", tx.Type())
+	}
+	return next, nil
+}
+
 func (r *PersistentReconciliationRepository) GetComponentOperationProcessingDuration(component string, state model.OperationState) (int64, error) {
 	if state != model.OperationStateDone && state != model.OperationStateError {
 		return 0, errors.Errorf("Unsupported Operation State %s for component %s", state, component)
@@ -758,6 +1134,58 @@ func (r *PersistentReconciliationRepository) GetAllComponents() ([]string, error
 	return components, nil
 }
 
+func (r *PersistentReconciliationRepository) CountFinishedOperations(component string, createdAfter time.Time, latency time.Duration) (*OperationCount, error) {
+	opEntity := &model.OperationEntity{}
+	colHdr, err := db.NewColumnHandler(opEntity, r.Conn, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	var cols []string
+	for _, field := range []string{"Component", "State", "Created", "Updated", "PickedUp"} {
+		col, err := colHdr.ColumnName(field)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+	componentCol, stateCol, createdCol, updatedCol, pickedUpCol := cols[0], cols[1], cols[2], cols[3], cols[4]
+
+	args := []interface{}{
+		component,
+		string(model.OperationStateDone),
+		string(model.OperationStateError),
+		createdAfter.Format(db.TimeFormat),
+	}
+	goodCond := fmt.Sprintf("%s=$2", stateCol)
+	if latency > 0 {
+		//operations which were never picked up count from their creation
+		start := fmt.Sprintf("CASE WHEN %s > %s THEN %s ELSE %s END", pickedUpCol, createdCol, pickedUpCol, createdCol)
+		var durationSecs string
+		switch r.Conn.Type() {
+		case db.Postgres:
+			durationSecs = fmt.Sprintf("EXTRACT(EPOCH FROM (%s - %s))", updatedCol, start)
+		case db.SQLite:
+			durationSecs = fmt.Sprintf("(julianday(%s) - julianday(%s)) * 86400", updatedCol, start)
+		default:
+			return nil, fmt.Errorf("database type '%s' is not supported", r.Conn.Type())
+		}
+		goodCond = fmt.Sprintf("%s AND %s <= $5", goodCond, durationSecs)
+		args = append(args, latency.Seconds())
+	}
+
+	row, err := r.Conn.QueryRow(fmt.Sprintf("SELECT COUNT(*), COALESCE(SUM(CASE WHEN %s THEN 1 ELSE 0 END), 0) "+
+		"FROM %s WHERE %s=$1 AND %s IN ($2, $3) AND %s>$4",
+		goodCond, opEntity.Table(), componentCol, stateCol, createdCol), args...)
+	if err != nil {
+		return nil, err
+	}
+	count := &OperationCount{}
+	if err := row.Scan(&count.Finished, &count.Good); err != nil {
+		return nil, errors.Wrap(err, "failed to count finished operations")
+	}
+	return count, nil
+}
+
 func getRemoveReconciliationOpFn(field string, value string, logger *zap.SugaredLogger) func(tx *db.TxConnection) error {
 	return func(tx *db.TxConnection) error {
 		whereCond := map[string]interface{}{
@@ -19,6 +19,34 @@ const (
 	PickedUp
 )
 
+//callbackSequenceStride is the number of callback sequences reserved for one dispatch of an operation: the component
+//reconciler numbers its callbacks upwards from the first sequence of the dispatch
+const callbackSequenceStride = 1 << 20
+
+//OperationCount is the number of finished operations of a component: good operations succeeded within the latency
+type OperationCount struct {
+	Finished int
+	Good     int
+}
+
+//countFinished adds the operation to the count if it's finished
+func (c *OperationCount) countFinished(op *model.OperationEntity, latency time.Duration) {
+	if op.State != model.OperationStateDone && op.State != model.OperationStateError {
+		return
+	}
+	c.Finished++
+	if op.State != model.OperationStateDone {
+		return
+	}
+	start := op.PickedUp
+	if !start.After(op.Created) { //operations which were never picked up count from their creation
+		start = op.Created
+	}
+	if latency == 0 || op.Updated.Sub(start) <= latency {
+		c.Good++
+	}
+}
+
 type Repository interface {
 	CreateReconciliation(state *cluster.State, cfg *model.ReconciliationSequenceConfig) (*model.ReconciliationEntity, error)
 	RemoveReconciliationByRuntimeID(runtimeID string) error
@@ -31,8 +59,9 @@ type Repository interface {
 	FinishReconciliation(schedulingID string, status *model.ClusterStatusEntity) error
 	GetOperations(filter operation.Filter) ([]*model.OperationEntity, error)
 	GetOperation(schedulingID, correlationID string) (*model.OperationEntity, error)
-	//GetProcessableOperations returns all operations which can be assigned to a worker
-	GetProcessableOperations(maxParallelOpsPerRecon int) ([]*model.OperationEntity, error)
+	//GetProcessableOperations returns all operations which can be assigned to a worker: within a priority group,
+	//operations of components with a higher weight are returned first
+	GetProcessableOperations(maxParallelOpsPerRecon int, weights ComponentWeights) ([]*model.OperationEntity, error)
 	//GetReconcilingOperations returns all operations which are part of currently running reconciliations
 	GetReconcilingOperations() ([]*model.OperationEntity, error)
 	UpdateOperationState(schedulingID, correlationID string, state model.OperationState, allowInState bool, reasons ...string) error
@@ -40,9 +69,31 @@ type Repository interface {
 	UpdateOperationRetryID(schedulingID, correlationID, retryID string) error
 	UpdateOperationPickedUp(schedulingID, correlationID string) error
 	UpdateComponentOperationProcessingDuration(schedulingID, correlationID string, processingDuration int) error
+	//UpdateOperationReconcilerVersion stores the build of the component reconciler which processed the operation
+	UpdateOperationReconcilerVersion(schedulingID, correlationID, reconcilerVersion string) error
+	//UpdateOperationCallbackSequence stores the sequence of the latest processed callback of an operation. It returns
+	//false if a callback with the same or a higher sequence was already processed.
+	UpdateOperationCallbackSequence(schedulingID, correlationID string, sequence int64) (bool, error)
+	//StartOperationCallbackSequence allocates the first callback sequence of a new dispatch of an operation from a
+	//database sequence and stores it as the latest processed callback sequence: callbacks of previous dispatches of
+	//the operation are outdated afterwards.
+	StartOperationCallbackSequence(schedulingID, correlationID string) (int64, error)
+	//UpdateOperationUsage stores the resources the component reconciler consumed on the target cluster
+	UpdateOperationUsage(schedulingID, correlationID string, apiCalls, manifestBytes int64) error
+	//UpdateOperationTimedOut stores whether the latest attempt of the operation failed because of a timeout
+	UpdateOperationTimedOut(schedulingID, correlationID string, timedOut bool) error
+	//UpdateOperationPendingDeletion moves the operation into state 'pending_confirmation' and stores the stateful
+	//resources which its deletion would remove
+	UpdateOperationPendingDeletion(schedulingID, correlationID string, resources []string, reason string) error
+	//ConfirmOperationDeletion marks a pending deletion as confirmed: the operation gets processed again
+	ConfirmOperationDeletion(schedulingID, correlationID string) error
 	GetComponentOperationProcessingDuration(component string, state model.OperationState) (int64, error)
 	GetMothershipOperationProcessingDuration(component string, state model.OperationState, startTime metricStartTime) (int64, error)
 	GetAllComponents() ([]string, error)
+	//CountFinishedOperations counts the finished operations of the component which were created after the given
+	//time and how many of them succeeded within the latency (0 = the duration isn't evaluated). The latency is
+	//measured from the time a worker picked up the operation: the time it was queued isn't included.
+	CountFinishedOperations(component string, createdAfter time.Time, latency time.Duration) (*OperationCount, error)
 	EnableDebugLogging(schedulingID string, correlationID ...string) error
 }
 
@@ -51,7 +102,9 @@ type Repository interface {
 //An operation with a high priority has first to be finished before operations with a lower priority
 //are considered as processable.
 // For deletion operations, the priority is reversed, as deletion has to be done backwards.
-func findProcessableOperations(ops []*model.OperationEntity, maxParallelOpsPerRecon int) []*model.OperationEntity {
+//Processable operations within a priority group are ordered by the weight of their component (longest-running first)
+//before the amount of parallel processed operations is throttled.
+func findProcessableOperations(ops []*model.OperationEntity, maxParallelOpsPerRecon int, weights ComponentWeights) []*model.OperationEntity {
 	//group ops per reconciliation and their prio
 	groupedByReconAndPrio := make(map[string]map[int64][]*model.OperationEntity) //key1:schedulingID, key2:prio
 	for _, op := range ops {
@@ -75,7 +128,7 @@ func findProcessableOperations(ops []*model.OperationEntity, maxParallelOpsPerRe
 	for _, opsWithSamePrio := range groupedByReconAndPrio { //iterate of reconciliations
 		reverse := opGroupType(opsWithSamePrio) == model.OperationTypeDelete // in case of deletion priorities are reversed.
 		for _, prio := range prios(opsWithSamePrio, reverse) {               //iterate over prio-groups
-			processable, checkNextGroup := findProcessableOperationsInGroup(opsWithSamePrio[prio], maxParallelOpsPerRecon)
+			processable, checkNextGroup := findProcessableOperationsInGroup(opsWithSamePrio[prio], maxParallelOpsPerRecon, weights)
 			if checkNextGroup {
 				continue
 			}
@@ -104,14 +157,19 @@ func prios(opsByPrio map[int64][]*model.OperationEntity, reverse bool) []int64 {
 }
 
 //opGroupType finds out the operation type on a group of operations with the same scheduling ID.
-// Since priorities can be arbitrary keys, a key can't be hardcoded and the map needs to be iterated and immediately return after the first iteration.
+//A group is only of type delete if all its operations are delete operations: reconciliations can include
+//delete operations for components which were removed from the cluster configuration.
 func opGroupType(opsByPrio map[int64][]*model.OperationEntity) model.OperationType {
+	opType := model.OperationTypeReconcile
 	for _, ops := range opsByPrio {
-		if len(ops) > 0 {
-			return ops[0].Type
+		for _, op := range ops {
+			if op.Type != model.OperationTypeDelete {
+				return model.OperationTypeReconcile
+			}
+			opType = model.OperationTypeDelete
 		}
 	}
-	return model.OperationTypeReconcile
+	return opType
 }
 
 //findProcessableOperationsInGroup returns all operations in the group which are processable.
@@ -119,21 +177,23 @@ func opGroupType(opsByPrio map[int64][]*model.OperationEntity) model.OperationTy
 // * true: all operations of the current group were successfully completed and next group shoud be evaluated.
 // * false: next group should not be evaluated. This is the case when either the current group
 //          is still in progress or >= 1 operations of the current group are in error state.
-func findProcessableOperationsInGroup(ops []*model.OperationEntity, maxParallelOpsPerRecon int) ([]*model.OperationEntity, bool) {
+func findProcessableOperationsInGroup(ops []*model.OperationEntity, maxParallelOpsPerRecon int, weights ComponentWeights) ([]*model.OperationEntity, bool) {
 	var opsInProgress int
 	var processables []*model.OperationEntity
 
 	for _, op := range ops {
-		//if one of the components is in error state, stop processing of remaining tasks
-		if op.State == model.OperationStateError {
+		//if one of the components is in error state (or was aborted), stop processing of remaining tasks
+		if op.State == model.OperationStateError || op.State == model.OperationStateAborted {
 			return nil, false
 		}
-		//ignore component which were already successfully processed
-		if op.State == model.OperationStateDone {
+		//ignore component which were already successfully processed or skipped
+		if op.State == model.OperationStateDone || op.State == model.OperationStateSkipped {
 			continue
 		}
-		//ignore operations which are currently in progress
-		if op.State == model.OperationStateInProgress || op.State == model.OperationStateFailed {
+		//ignore operations which are currently in progress (or waiting for a lease on the target cluster or for the
+		//confirmation of their deletion)
+		if op.State == model.OperationStateInProgress || op.State == model.OperationStateFailed ||
+			op.State == model.OperationStateWaiting || op.State == model.OperationStatePendingConfirmation {
 			opsInProgress++
 			continue
 		}
@@ -141,6 +201,9 @@ func findProcessableOperationsInGroup(ops []*model.OperationEntity, maxParallelO
 		processables = append(processables, op)
 	}
 
+	//start the longest-running components first
+	weights.sort(processables)
+
 	//throttle amount of parallel processed ops in a reconciliation
 	if maxParallelOpsPerRecon > 0 {
 		if (len(processables) + opsInProgress) > maxParallelOpsPerRecon { //start throttling
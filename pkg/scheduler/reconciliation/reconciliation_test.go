@@ -142,13 +142,13 @@ func (s *reconciliationTestSuite) TestReconciliationFindProcessableOps() {
 
 	testCases := map[string]func(t *testing.T){
 		"Find reconcile prio1 and delete prio 3": func(t *testing.T) {
-			opsGot := findProcessableOperations(ops, 0)
+			opsGot := findProcessableOperations(ops, 0, nil)
 			require.Len(t, opsGot, 3)
 			require.ElementsMatch(t, []*model.OperationEntity{ops[0], ops[6], ops[11]}, opsGot)
 		},
 		"Find reconcile prio1 and delete prio 3 with failure": func(t *testing.T) {
 			ops[0].State = model.OperationStateOrphan
-			opsGot := findProcessableOperations(ops, 0)
+			opsGot := findProcessableOperations(ops, 0, nil)
 			require.Len(t, opsGot, 3)
 			require.ElementsMatch(t, []*model.OperationEntity{ops[0], ops[6], ops[11]}, opsGot)
 		},
@@ -157,7 +157,7 @@ func (s *reconciliationTestSuite) TestReconciliationFindProcessableOps() {
 			ops[4].State = model.OperationStateDone
 			ops[6].State = model.OperationStateDone
 			ops[11].State = model.OperationStateDone
-			opsGot := findProcessableOperations(ops, 0)
+			opsGot := findProcessableOperations(ops, 0, nil)
 			require.Len(t, opsGot, 4)
 			require.ElementsMatch(t, []*model.OperationEntity{ops[1], ops[7], ops[8], ops[10]}, opsGot)
 		},
@@ -171,7 +171,7 @@ func (s *reconciliationTestSuite) TestReconciliationFindProcessableOps() {
 			ops[8].State = model.OperationStateInProgress
 			ops[10].State = model.OperationStateInProgress
 			ops[11].State = model.OperationStateDone
-			opsGot := findProcessableOperations(ops, 0)
+			opsGot := findProcessableOperations(ops, 0, nil)
 			require.Empty(t, opsGot)
 		},
 		"Find reconcile prio3 and delete prio 1": func(t *testing.T) {
@@ -182,7 +182,7 @@ func (s *reconciliationTestSuite) TestReconciliationFindProcessableOps() {
 			ops[8].State = model.OperationStateDone
 			ops[10].State = model.OperationStateDone
 			ops[11].State = model.OperationStateDone
-			opsGot := findProcessableOperations(ops, 0)
+			opsGot := findProcessableOperations(ops, 0, nil)
 			require.Len(t, opsGot, 5)
 			require.ElementsMatch(t, []*model.OperationEntity{ops[2], ops[3], ops[4], ops[5], ops[9]}, opsGot)
 		},
@@ -195,19 +195,19 @@ func (s *reconciliationTestSuite) TestReconciliationFindProcessableOps() {
 			ops[10].State = model.OperationStateDone
 			ops[11].State = model.OperationStateDone
 
-			opsGot4 := findProcessableOperations(ops, 4)
+			opsGot4 := findProcessableOperations(ops, 4, nil)
 			require.Len(t, opsGot4, 5)
 			require.ElementsMatch(t, []*model.OperationEntity{ops[2], ops[3], ops[4], ops[5], ops[9]}, opsGot4)
 
-			opsGot3 := findProcessableOperations(ops, 3)
+			opsGot3 := findProcessableOperations(ops, 3, nil)
 			require.Len(t, opsGot3, 4)
 			require.ElementsMatch(t, []*model.OperationEntity{ops[2], ops[3], ops[4], ops[9]}, opsGot3)
 
-			opsGot2 := findProcessableOperations(ops, 2)
+			opsGot2 := findProcessableOperations(ops, 2, nil)
 			require.Len(t, opsGot2, 3)
 			require.ElementsMatch(t, []*model.OperationEntity{ops[2], ops[3], ops[9]}, opsGot2)
 
-			opsGot1 := findProcessableOperations(ops, 1)
+			opsGot1 := findProcessableOperations(ops, 1, nil)
 			require.Len(t, opsGot1, 2)
 			require.ElementsMatch(t, []*model.OperationEntity{ops[2], ops[9]}, opsGot1)
 		},
@@ -215,7 +215,7 @@ func (s *reconciliationTestSuite) TestReconciliationFindProcessableOps() {
 			ops[0].State = model.OperationStateError
 			ops[6].State = model.OperationStateError
 			ops[11].State = model.OperationStateError
-			opsGot := findProcessableOperations(ops, 0)
+			opsGot := findProcessableOperations(ops, 0, nil)
 			require.Empty(t, opsGot)
 		},
 		"Find with error at reconcile prio 2 and delete prio2": func(t *testing.T) {
@@ -225,7 +225,7 @@ func (s *reconciliationTestSuite) TestReconciliationFindProcessableOps() {
 			ops[7].State = model.OperationStateError
 			ops[10].State = model.OperationStateError
 			ops[11].State = model.OperationStateDone
-			opsGot := findProcessableOperations(ops, 0)
+			opsGot := findProcessableOperations(ops, 0, nil)
 			require.Empty(t, opsGot)
 		},
 		"Find with error at reconcile prio 3 and delete prio 1": func(t *testing.T) {
@@ -238,7 +238,7 @@ func (s *reconciliationTestSuite) TestReconciliationFindProcessableOps() {
 			ops[9].State = model.OperationStateError
 			ops[10].State = model.OperationStateDone
 			ops[11].State = model.OperationStateDone
-			opsGot := findProcessableOperations(ops, 0)
+			opsGot := findProcessableOperations(ops, 0, nil)
 			require.Empty(t, opsGot)
 		},
 	}
@@ -250,6 +250,42 @@ func (s *reconciliationTestSuite) TestReconciliationFindProcessableOps() {
 
 }
 
+func (s *reconciliationTestSuite) TestReconciliationFindProcessableOpsWithRemovedComponents() {
+	t := s.T()
+	ops := []*model.OperationEntity{
+		{
+			Priority:      1,
+			SchedulingID:  "1",
+			CorrelationID: "1.1",
+			Component:     "1a",
+			State:         model.OperationStateNew,
+			Type:          model.OperationTypeReconcile,
+		},
+		{
+			Priority:      2,
+			SchedulingID:  "1",
+			CorrelationID: "1.2",
+			Component:     "2a",
+			State:         model.OperationStateNew,
+			Type:          model.OperationTypeReconcile,
+		},
+		{
+			Priority:      3,
+			SchedulingID:  "1",
+			CorrelationID: "1.3",
+			Component:     "removed",
+			State:         model.OperationStateNew,
+			Type:          model.OperationTypeDelete,
+		},
+	}
+
+	//removed components are deleted after all other components were reconciled
+	require.ElementsMatch(t, []*model.OperationEntity{ops[0]}, findProcessableOperations(ops, 0, nil))
+	ops[0].State = model.OperationStateDone
+	require.ElementsMatch(t, []*model.OperationEntity{ops[1]}, findProcessableOperations(ops, 0, nil))
+	ops[1].State = model.OperationStateDone
+	require.ElementsMatch(t, []*model.OperationEntity{ops[2]}, findProcessableOperations(ops, 0, nil))
+}
 func resetOperationState(ops []*model.OperationEntity) {
 	for _, op := range ops {
 		op.State = model.OperationStateNew
@@ -488,7 +524,7 @@ func (s *reconciliationTestSuite) TestReconciliationRepository() {
 				require.Len(t, opsEntities, 4)
 
 				//only the operation with prio 1 has to be returned
-				opsEntitiesPrio1, err := reconRepo.GetProcessableOperations(0)
+				opsEntitiesPrio1, err := reconRepo.GetProcessableOperations(0, nil)
 				require.NoError(t, err)
 
 				require.Len(t, opsEntitiesPrio1, 1)
@@ -499,7 +535,7 @@ func (s *reconciliationTestSuite) TestReconciliationRepository() {
 					require.NoError(t, reconRepo.UpdateOperationState(op.SchedulingID, op.CorrelationID, model.OperationStateDone, false))
 				}
 
-				opsEntitiesPrio2, err := reconRepo.GetProcessableOperations(0)
+				opsEntitiesPrio2, err := reconRepo.GetProcessableOperations(0, nil)
 				require.NoError(t, err)
 				require.Len(t, opsEntitiesPrio2, 1)
 				require.ElementsMatch(t, findOperationsByPrio(opsEntities, 2), opsEntitiesPrio2)
@@ -510,7 +546,7 @@ func (s *reconciliationTestSuite) TestReconciliationRepository() {
 				}
 
 				//one of the previous operations is in error state: no further operations have to be processed
-				opsEntitiesPrio, err := reconRepo.GetProcessableOperations(0)
+				opsEntitiesPrio, err := reconRepo.GetProcessableOperations(0, nil)
 				require.NoError(t, err)
 				require.Empty(t, opsEntitiesPrio)
 			},
@@ -536,7 +572,7 @@ func (s *reconciliationTestSuite) TestReconciliationRepository() {
 				require.Len(t, opsEntities2, 2)
 
 				//only the operation with prio 1 has to be returned
-				opsEntitiesPrio1, err := reconRepo.GetProcessableOperations(0)
+				opsEntitiesPrio1, err := reconRepo.GetProcessableOperations(0, nil)
 
 				var expectedOpsPrio1 []*model.OperationEntity
 				expectedOpsPrio1 = append(expectedOpsPrio1, findOperationsByPrio(opsEntities1, 1)...)
@@ -550,7 +586,7 @@ func (s *reconciliationTestSuite) TestReconciliationRepository() {
 					require.NoError(t, reconRepo.UpdateOperationState(op.SchedulingID, op.CorrelationID, model.OperationStateDone, false))
 				}
 
-				opsEntitiesPrio2, err := reconRepo.GetProcessableOperations(0)
+				opsEntitiesPrio2, err := reconRepo.GetProcessableOperations(0, nil)
 				var expectedOpsPrio2 []*model.OperationEntity
 				expectedOpsPrio2 = append(expectedOpsPrio2, findOperationsByPrio(opsEntities1, 2)...)
 				expectedOpsPrio2 = append(expectedOpsPrio2, findOperationsByPrio(opsEntities2, 2)...)
@@ -564,7 +600,7 @@ func (s *reconciliationTestSuite) TestReconciliationRepository() {
 				}
 
 				//one of the previous operations is in error state: no further operations have to be processed
-				opsEntitiesPrio, err := reconRepo.GetProcessableOperations(0)
+				opsEntitiesPrio, err := reconRepo.GetProcessableOperations(0, nil)
 				require.NoError(t, err)
 				require.Empty(t, opsEntitiesPrio)
 			},
@@ -676,6 +712,122 @@ func (s *reconciliationTestSuite) TestReconciliationRepository() {
 				}
 			},
 		},
+		{
+			name: "Update operation-callback-sequence",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, &model.ReconciliationSequenceConfig{})
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+				op := opsEntities[0]
+
+				updated, err := reconRepo.UpdateOperationCallbackSequence(op.SchedulingID, op.CorrelationID, 20)
+				require.NoError(t, err)
+				require.True(t, updated)
+
+				//duplicated and outdated sequences are rejected
+				updated, err = reconRepo.UpdateOperationCallbackSequence(op.SchedulingID, op.CorrelationID, 20)
+				require.NoError(t, err)
+				require.False(t, updated)
+				updated, err = reconRepo.UpdateOperationCallbackSequence(op.SchedulingID, op.CorrelationID, 10)
+				require.NoError(t, err)
+				require.False(t, updated)
+
+				opEntity, err := reconRepo.GetOperation(op.SchedulingID, op.CorrelationID)
+				require.NoError(t, err)
+				require.Equal(t, int64(20), opEntity.CallbackSequence)
+			},
+		},
+		{
+			name: "Start operation-callback-sequence",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, &model.ReconciliationSequenceConfig{})
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+				op := opsEntities[0]
+
+				first, err := reconRepo.StartOperationCallbackSequence(op.SchedulingID, op.CorrelationID)
+				require.NoError(t, err)
+				updated, err := reconRepo.UpdateOperationCallbackSequence(op.SchedulingID, op.CorrelationID, first+1)
+				require.NoError(t, err)
+				require.True(t, updated)
+
+				//callbacks of the previous dispatch are outdated after the next dispatch
+				second, err := reconRepo.StartOperationCallbackSequence(op.SchedulingID, op.CorrelationID)
+				require.NoError(t, err)
+				require.Greater(t, second, first+1)
+				updated, err = reconRepo.UpdateOperationCallbackSequence(op.SchedulingID, op.CorrelationID, first+2)
+				require.NoError(t, err)
+				require.False(t, updated)
+				updated, err = reconRepo.UpdateOperationCallbackSequence(op.SchedulingID, op.CorrelationID, second+1)
+				require.NoError(t, err)
+				require.True(t, updated)
+			},
+		},
+		{
+			name: "Update operation-usage",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, &model.ReconciliationSequenceConfig{})
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+
+				for _, op := range opsEntities {
+					err := reconRepo.UpdateOperationUsage(op.SchedulingID, op.CorrelationID, 42, 1024)
+					require.NoError(t, err)
+				}
+
+				opsEntities, err = reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+				for _, op := range opsEntities {
+					require.Equal(t, int64(42), op.APICalls)
+					require.Equal(t, int64(1024), op.ManifestBytes)
+				}
+			},
+		},
+		{
+			name: "Confirm pending deletion of operation",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, &model.ReconciliationSequenceConfig{})
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+				op := opsEntities[0]
+
+				//only pending deletions can be confirmed
+				require.Error(t, reconRepo.ConfirmOperationDeletion(op.SchedulingID, op.CorrelationID))
+
+				resources := []string{"Namespace/monitoring", "PersistentVolumeClaim/kyma-system/storage"}
+				require.NoError(t, reconRepo.UpdateOperationPendingDeletion(op.SchedulingID, op.CorrelationID, resources, "confirmation required"))
+				op, err = reconRepo.GetOperation(op.SchedulingID, op.CorrelationID)
+				require.NoError(t, err)
+				require.Equal(t, model.OperationStatePendingConfirmation, op.State)
+				require.Equal(t, resources, op.PendingDeletionResources())
+				require.False(t, op.DeletionConfirmed)
+
+				require.NoError(t, reconRepo.ConfirmOperationDeletion(op.SchedulingID, op.CorrelationID))
+				op, err = reconRepo.GetOperation(op.SchedulingID, op.CorrelationID)
+				require.NoError(t, err)
+				require.Equal(t, model.OperationStateNew, op.State)
+				require.True(t, op.DeletionConfirmed)
+				require.Error(t, reconRepo.ConfirmOperationDeletion(op.SchedulingID, op.CorrelationID))
+			},
+		},
 		{
 			name: "Get mean component-operation-processing-duration",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
@@ -721,6 +873,45 @@ func (s *reconciliationTestSuite) TestReconciliationRepository() {
 				require.GreaterOrEqual(t, meanDuration, int64(1000))
 			},
 		},
+		{
+			name: "Count finished operations",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, &model.ReconciliationSequenceConfig{})
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+				require.True(t, len(opsEntities) >= 3)
+				pickedUpOp, notPickedUpOp, failedOp := opsEntities[0], opsEntities[1], opsEntities[2]
+
+				time.Sleep(1 * time.Second) //time the operations are queued
+				require.NoError(t, reconRepo.UpdateOperationPickedUp(pickedUpOp.SchedulingID, pickedUpOp.CorrelationID))
+				require.NoError(t, reconRepo.UpdateOperationState(pickedUpOp.SchedulingID, pickedUpOp.CorrelationID,
+					model.OperationStateDone, true))
+				require.NoError(t, reconRepo.UpdateOperationState(notPickedUpOp.SchedulingID, notPickedUpOp.CorrelationID,
+					model.OperationStateDone, true))
+				require.NoError(t, reconRepo.UpdateOperationState(failedOp.SchedulingID, failedOp.CorrelationID,
+					model.OperationStateError, true, "failed"))
+
+				createdAfter := time.Now().UTC().Add(-time.Hour)
+				for _, tc := range []struct {
+					op       *model.OperationEntity
+					latency  time.Duration
+					expected *OperationCount
+				}{
+					{op: pickedUpOp, latency: 500 * time.Millisecond, expected: &OperationCount{Finished: 1, Good: 1}},
+					{op: notPickedUpOp, latency: 500 * time.Millisecond, expected: &OperationCount{Finished: 1, Good: 0}},
+					{op: notPickedUpOp, latency: 0, expected: &OperationCount{Finished: 1, Good: 1}},
+					{op: failedOp, latency: 0, expected: &OperationCount{Finished: 1, Good: 0}},
+				} {
+					count, err := reconRepo.CountFinishedOperations(tc.op.Component, createdAfter, tc.latency)
+					require.NoError(t, err)
+					require.Equal(t, tc.expected, count, "component %s", tc.op.Component)
+				}
+			},
+		},
 		{
 			name: "Enable debug logging for a reconciliation",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
@@ -0,0 +1,37 @@
+package reconciliation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+//ComponentWeights are the expected durations of the components: operations of components with a higher weight
+//are started first to minimize the total duration of a reconciliation
+type ComponentWeights map[string]time.Duration
+
+//NewComponentWeights parses the expected durations (e.g. "10m") per component
+func NewComponentWeights(durations map[string]string) (ComponentWeights, error) {
+	weights := make(ComponentWeights, len(durations))
+	for component, duration := range durations {
+		weight, err := time.ParseDuration(duration)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("weight '%s' of component '%s' is not a positive duration", duration, component)
+		}
+		weights[component] = weight
+	}
+	return weights, nil
+}
+
+//sort orders the operations by descending weight: components without weight are ordered last
+//and keep their order
+func (w ComponentWeights) sort(ops []*model.OperationEntity) {
+	if len(w) == 0 {
+		return
+	}
+	sort.SliceStable(ops, func(i, j int) bool {
+		return w[ops[i].Component] > w[ops[j].Component]
+	})
+}
@@ -0,0 +1,80 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentWeights(t *testing.T) {
+
+	t.Run("Parse weights", func(t *testing.T) {
+		weights, err := NewComponentWeights(map[string]string{"istio": "10m", "serverless": "90s"})
+		require.NoError(t, err)
+		require.Equal(t, ComponentWeights{"istio": 10 * time.Minute, "serverless": 90 * time.Second}, weights)
+
+		_, err = NewComponentWeights(map[string]string{"istio": "ten minutes"})
+		require.Error(t, err)
+		_, err = NewComponentWeights(map[string]string{"istio": "-1m"})
+		require.Error(t, err)
+		_, err = NewComponentWeights(map[string]string{"istio": "0s"})
+		require.Error(t, err)
+	})
+
+	newOp := func(component string, prio int64, state model.OperationState) *model.OperationEntity {
+		return &model.OperationEntity{
+			SchedulingID:  "1",
+			CorrelationID: component,
+			Component:     component,
+			Priority:      prio,
+			State:         state,
+			Type:          model.OperationTypeReconcile,
+		}
+	}
+	weights := ComponentWeights{
+		"istio":      10 * time.Minute,
+		"serverless": 5 * time.Minute,
+		"eventing":   time.Minute,
+	}
+
+	t.Run("Longest-running components are started first", func(t *testing.T) {
+		ops := []*model.OperationEntity{
+			newOp("cluster-essentials", 1, model.OperationStateDone),
+			newOp("logging", 2, model.OperationStateNew),
+			newOp("eventing", 2, model.OperationStateNew),
+			newOp("monitoring", 2, model.OperationStateNew),
+			newOp("istio", 2, model.OperationStateNew),
+			newOp("serverless", 2, model.OperationStateNew),
+		}
+		require.Equal(t, []*model.OperationEntity{ops[4], ops[5], ops[2], ops[1], ops[3]},
+			findProcessableOperations(ops, 0, weights))
+	})
+
+	t.Run("Throttling keeps longest-running components", func(t *testing.T) {
+		ops := []*model.OperationEntity{
+			newOp("logging", 1, model.OperationStateNew),
+			newOp("eventing", 1, model.OperationStateInProgress),
+			newOp("serverless", 1, model.OperationStateNew),
+			newOp("istio", 1, model.OperationStateNew),
+		}
+		require.Equal(t, []*model.OperationEntity{ops[3], ops[2]}, findProcessableOperations(ops, 3, weights))
+	})
+
+	t.Run("Weights don't override priorities", func(t *testing.T) {
+		ops := []*model.OperationEntity{
+			newOp("logging", 1, model.OperationStateNew),
+			newOp("istio", 2, model.OperationStateNew),
+		}
+		require.Equal(t, []*model.OperationEntity{ops[0]}, findProcessableOperations(ops, 0, weights))
+	})
+
+	t.Run("Order is kept without weights", func(t *testing.T) {
+		ops := []*model.OperationEntity{
+			newOp("serverless", 1, model.OperationStateNew),
+			newOp("istio", 1, model.OperationStateNew),
+		}
+		require.Equal(t, ops, findProcessableOperations(ops, 0, nil))
+	})
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	AggregationPolicyStrict   = "strict"
+	AggregationPolicyTolerant = "tolerant"
+)
+
+//AggregationPolicy derives the cluster status from the operation states of a reconciliation
+type AggregationPolicy interface {
+	//Aggregate returns the cluster status and the failed operations which were tolerated by the policy
+	Aggregate(rs *ReconciliationResult) (model.Status, []*model.OperationEntity)
+	//String describes the policy and its settings (reported in diagnostics)
+	String() string
+}
+
+func NewAggregationPolicy(cfg config.AggregationConfig) (AggregationPolicy, error) {
+	switch strings.ToLower(cfg.Policy) {
+	case "", AggregationPolicyStrict: // return default if empty
+		return &strictAggregationPolicy{}, nil
+	case AggregationPolicyTolerant:
+		if cfg.MaxBestEffortFailures < 0 {
+			return nil, errors.New("max best-effort failures of aggregation policy cannot be < 0")
+		}
+		return &tolerantAggregationPolicy{
+			bestEffortComponents:  cfg.BestEffortComponents,
+			maxBestEffortFailures: cfg.MaxBestEffortFailures,
+			timeoutsAsDegraded:    cfg.TimeoutsAsDegraded,
+		}, nil
+	default:
+		return nil, errors.Errorf("Aggregation policy %s not supported", cfg.Policy)
+	}
+}
+
+//strictAggregationPolicy marks a cluster as failed as soon as one operation failed
+type strictAggregationPolicy struct {
+}
+
+func (p *strictAggregationPolicy) Aggregate(rs *ReconciliationResult) (model.Status, []*model.OperationEntity) {
+	return aggregate(rs.isDelete(), len(rs.error), len(rs.running), len(rs.new), len(rs.done)), nil
+}
+
+func (p *strictAggregationPolicy) String() string {
+	return AggregationPolicyStrict
+}
+
+//tolerantAggregationPolicy ignores failures of best-effort components (up to a limit) and optionally of
+//timed out operations: such clusters are marked as ready but report the failures as conditions (degraded).
+//Components hinted as critical by KEB are never tolerated, components hinted as non-critical are best-effort.
+type tolerantAggregationPolicy struct {
+	bestEffortComponents  []string //if empty, all components are best-effort
+	maxBestEffortFailures int
+	timeoutsAsDegraded    bool
+}
+
+func (p *tolerantAggregationPolicy) Aggregate(rs *ReconciliationResult) (model.Status, []*model.OperationEntity) {
+	var tolerated []*model.OperationEntity
+	bestEffortFailures := 0
+	for _, op := range rs.error {
+		critical, hinted := rs.critical[op.Component]
+		if hinted && critical {
+			continue
+		}
+		if p.timeoutsAsDegraded && op.TimedOut {
+			tolerated = append(tolerated, op)
+			continue
+		}
+		if (hinted || p.isBestEffort(op.Component)) && bestEffortFailures < p.maxBestEffortFailures {
+			bestEffortFailures++
+			tolerated = append(tolerated, op)
+		}
+	}
+	errCnt := len(rs.error) - len(tolerated)
+	return aggregate(rs.isDelete(), errCnt, len(rs.running), len(rs.new), len(rs.done)+len(tolerated)), tolerated
+}
+
+func (p *tolerantAggregationPolicy) isBestEffort(component string) bool {
+	if len(p.bestEffortComponents) == 0 {
+		return true
+	}
+	for _, bestEffortComponent := range p.bestEffortComponents {
+		if bestEffortComponent == component {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *tolerantAggregationPolicy) String() string {
+	bestEffortComponents := "all"
+	if len(p.bestEffortComponents) > 0 {
+		bestEffortComponents = strings.Join(p.bestEffortComponents, ",")
+	}
+	return fmt.Sprintf("%s(bestEffortComponents=%s,maxBestEffortFailures=%d,timeoutsAsDegraded=%t)",
+		AggregationPolicyTolerant, bestEffortComponents, p.maxBestEffortFailures, p.timeoutsAsDegraded)
+}
+
+func aggregate(isDelete bool, errCnt, runningCnt, newCnt, doneCnt int) model.Status {
+	//this if-clause has always to be evaluated first:
+	//as soon as one operation is in an error state the cluster is marked to be in error-state if no other ops are running
+	//(new and orphaned operations don't prevent the error-state)
+	if errCnt > 0 && runningCnt == 0 {
+		if isDelete {
+			return model.ClusterStatusDeleteError
+		}
+		return model.ClusterStatusReconcileError
+	}
+
+	//this if-clause has always to be evaluated as second condition:
+	//if one operation is not in a final state, the cluster is still in reconciling-state
+	if runningCnt > 0 || newCnt > 0 {
+		if isDelete {
+			return model.ClusterStatusDeleting
+		}
+		return model.ClusterStatusReconciling
+	}
+	//only if no operations are ongoing or in an error state, a cluster can be set to ready-state
+	if doneCnt > 0 {
+		if isDelete {
+			return model.ClusterStatusDeleted
+		}
+		return model.ClusterStatusReady
+	}
+	// this should never be returned
+	return model.ClusterStatusReconcileError
+}
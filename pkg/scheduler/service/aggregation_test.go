@@ -0,0 +1,160 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *serviceTestSuite) TestAggregationPolicy() {
+	t := s.T()
+
+	newResult := func(policy AggregationPolicy, ops ...*model.OperationEntity) *ReconciliationResult {
+		reconResult := newReconciliationResult(&model.ReconciliationEntity{
+			RuntimeID:    "runtimeID",
+			SchedulingID: "schedulingID",
+		}, logger.NewLogger(true))
+		reconResult.policy = policy
+		require.NoError(t, reconResult.AddOperations(ops))
+		return reconResult
+	}
+	newOp := func(component string, state model.OperationState, reason string) *model.OperationEntity {
+		return &model.OperationEntity{
+			SchedulingID:  "schedulingID",
+			CorrelationID: component,
+			Component:     component,
+			State:         state,
+			Reason:        reason,
+		}
+	}
+	newTimedOutOp := func(component string) *model.OperationEntity {
+		op := newOp(component, model.OperationStateError, "context deadline exceeded")
+		op.TimedOut = true
+		return op
+	}
+
+	t.Run("Create policies", func(t *testing.T) {
+		policy, err := NewAggregationPolicy(config.AggregationConfig{})
+		require.NoError(t, err)
+		require.Equal(t, AggregationPolicyStrict, policy.String())
+
+		policy, err = NewAggregationPolicy(config.AggregationConfig{
+			Policy:                "Tolerant",
+			BestEffortComponents:  []string{"tracing", "kiali"},
+			MaxBestEffortFailures: 1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "tolerant(bestEffortComponents=tracing,kiali,maxBestEffortFailures=1,timeoutsAsDegraded=false)",
+			policy.String())
+
+		_, err = NewAggregationPolicy(config.AggregationConfig{Policy: "tolerant", MaxBestEffortFailures: -1})
+		require.Error(t, err)
+		_, err = NewAggregationPolicy(config.AggregationConfig{Policy: "lenient"})
+		require.Error(t, err)
+	})
+
+	t.Run("Strict policy fails on any error", func(t *testing.T) {
+		reconResult := newResult(&strictAggregationPolicy{},
+			newOp("istio", model.OperationStateDone, ""),
+			newOp("tracing", model.OperationStateError, "failed"))
+		require.Equal(t, model.ClusterStatusReconcileError, reconResult.GetResult())
+		require.Empty(t, reconResult.GetTolerated())
+
+		//new and orphaned operations don't prevent the error-state
+		reconResult = newResult(&strictAggregationPolicy{},
+			newOp("istio", model.OperationStateNew, ""),
+			newOp("tracing", model.OperationStateError, "failed"),
+			newOp("kiali", model.OperationStateOrphan, ""),
+			newOp("serverless", model.OperationStateDone, ""))
+		require.Equal(t, model.ClusterStatusReconcileError, reconResult.GetResult())
+
+		//running operations do
+		reconResult = newResult(&strictAggregationPolicy{},
+			newOp("istio", model.OperationStateInProgress, ""),
+			newOp("tracing", model.OperationStateError, "failed"))
+		require.Equal(t, model.ClusterStatusReconciling, reconResult.GetResult())
+	})
+
+	t.Run("Tolerant policy tolerates best-effort failures up to limit", func(t *testing.T) {
+		policy := &tolerantAggregationPolicy{
+			bestEffortComponents:  []string{"tracing", "kiali"},
+			maxBestEffortFailures: 1,
+		}
+
+		reconResult := newResult(policy,
+			newOp("istio", model.OperationStateDone, ""),
+			newOp("tracing", model.OperationStateError, "failed"))
+		require.Equal(t, model.ClusterStatusReady, reconResult.GetResult())
+		require.Len(t, reconResult.GetTolerated(), 1)
+
+		//limit of best-effort failures exceeded
+		reconResult = newResult(policy,
+			newOp("tracing", model.OperationStateError, "failed"),
+			newOp("kiali", model.OperationStateError, "failed"))
+		require.Equal(t, model.ClusterStatusReconcileError, reconResult.GetResult())
+
+		//istio isn't a best-effort component
+		reconResult = newResult(policy,
+			newOp("istio", model.OperationStateError, "failed"))
+		require.Equal(t, model.ClusterStatusReconcileError, reconResult.GetResult())
+	})
+
+	t.Run("Tolerant policy treats timeouts as degraded", func(t *testing.T) {
+		policy := &tolerantAggregationPolicy{
+			bestEffortComponents: []string{"tracing"},
+			timeoutsAsDegraded:   true,
+		}
+
+		reconResult := newResult(policy,
+			newTimedOutOp("istio"),
+			newOp("serverless", model.OperationStateDone, ""))
+		require.Equal(t, model.ClusterStatusReady, reconResult.GetResult())
+		require.Len(t, reconResult.GetTolerated(), 1)
+
+		reconResult = newResult(policy,
+			newOp("istio", model.OperationStateError, "installation failed"))
+		require.Equal(t, model.ClusterStatusReconcileError, reconResult.GetResult())
+
+		//the reason text doesn't mark an operation as timed out
+		reconResult = newResult(policy,
+			newOp("istio", model.OperationStateError, "waiting for webhook timed out"))
+		require.Equal(t, model.ClusterStatusReconcileError, reconResult.GetResult())
+	})
+
+	t.Run("Tolerant policy considers critical flags of execution hints", func(t *testing.T) {
+		policy := &tolerantAggregationPolicy{
+			bestEffortComponents:  []string{"tracing"},
+			maxBestEffortFailures: 2,
+			timeoutsAsDegraded:    true,
+		}
+		critical, nonCritical := true, false
+		hints := []*keb.Component{
+			{Component: "tracing", ExecutionHints: &keb.ExecutionHints{Critical: &critical}},
+			{Component: "serverless", ExecutionHints: &keb.ExecutionHints{Critical: &nonCritical}},
+			{Component: "istio"},
+		}
+
+		//critical components are never tolerated
+		reconResult := newResult(policy,
+			newTimedOutOp("tracing"))
+		reconResult.setExecutionHints(hints)
+		require.Equal(t, model.ClusterStatusReconcileError, reconResult.GetResult())
+
+		//non-critical components are best-effort
+		reconResult = newResult(policy,
+			newOp("serverless", model.OperationStateError, "failed"))
+		reconResult.setExecutionHints(hints)
+		require.Equal(t, model.ClusterStatusReady, reconResult.GetResult())
+		require.Len(t, reconResult.GetTolerated(), 1)
+
+		//components without hints are handled by the policy settings
+		reconResult = newResult(policy,
+			newOp("istio", model.OperationStateError, "failed"))
+		reconResult.setExecutionHints(hints)
+		require.Equal(t, model.ClusterStatusReconcileError, reconResult.GetResult())
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
@@ -30,6 +31,9 @@ type BookkeeperConfig struct {
 	OrphanOperationTimeout  time.Duration
 	MaxReconcileErrRetries  int
 	MaxDeleteErrRetries     int
+	AggregationPolicy       AggregationPolicy
+	//DeadLetterAlertThreshold is the number of open dead letters which triggers an alert (0 disables alerts)
+	DeadLetterAlertThreshold int
 }
 
 func (wc *BookkeeperConfig) validate() error {
@@ -57,13 +61,20 @@ func (wc *BookkeeperConfig) validate() error {
 	if wc.MaxDeleteErrRetries == 0 {
 		wc.MaxDeleteErrRetries = defaultMaxDeleteErrRetries
 	}
+	if wc.DeadLetterAlertThreshold < 0 {
+		return errors.New("dead-letter alert threshold cannot be < 0")
+	}
+	if wc.AggregationPolicy == nil {
+		wc.AggregationPolicy = &strictAggregationPolicy{}
+	}
 	return nil
 }
 
 type bookkeeper struct {
-	config *BookkeeperConfig
-	logger *zap.SugaredLogger
-	repo   reconciliation.Repository
+	config    *BookkeeperConfig
+	logger    *zap.SugaredLogger
+	repo      reconciliation.Repository
+	inventory cluster.Inventory
 }
 
 func newBookkeeper(repo reconciliation.Repository, config *BookkeeperConfig, logger *zap.SugaredLogger) *bookkeeper {
@@ -77,14 +88,20 @@ func newBookkeeper(repo reconciliation.Repository, config *BookkeeperConfig, log
 	}
 }
 
+//withInventory lets the aggregation policy consider the execution hints of the components (e.g. critical flags)
+func (bk *bookkeeper) withInventory(inventory cluster.Inventory) *bookkeeper {
+	bk.inventory = inventory
+	return bk
+}
+
 func (bk *bookkeeper) Run(ctx context.Context, tasks ...BookkeepingTask) error {
 	if err := bk.config.validate(); err != nil {
 		return err
 	}
 
 	bk.logger.Infof("Starting bookkeeper: interval for updating reconciliation statuses and orphan operations "+
-		"is %.1f secs / timeout for orphan operations is %.1f secs",
-		bk.config.OperationsWatchInterval.Seconds(), bk.config.OrphanOperationTimeout.Seconds())
+		"is %.1f secs / timeout for orphan operations is %.1f secs / cluster status aggregation policy is '%s'",
+		bk.config.OperationsWatchInterval.Seconds(), bk.config.OrphanOperationTimeout.Seconds(), bk.config.AggregationPolicy)
 
 	//IMPORTANT:
 	//Bookkeeper is not allowed to run directly when Run-fct is called: is has to wait until the first ticker was fired!
@@ -112,6 +129,11 @@ func (bk *bookkeeper) Run(ctx context.Context, tasks ...BookkeepingTask) error {
 						bk.componentList(reconResult.error, true),
 						bk.componentList(reconResult.new, false),
 						bk.componentList(reconResult.running, true))
+					if tolerated := reconResult.GetTolerated(); len(tolerated) > 0 {
+						bk.logger.Warnf("Bookkeeper tolerated failed operations of reconciliation (schedulingID:%s) "+
+							"for cluster '%s' (cluster is degraded): %s",
+							recon.SchedulingID, recon.RuntimeID, bk.componentList(tolerated, true))
+					}
 				} else {
 					bk.logger.Errorf("Bookkeeper failed to retrieve operations for reconciliation '%s' "+
 						"(but will continue processing): %s", recon, err)
@@ -140,9 +162,19 @@ func (bk *bookkeeper) newReconciliationResult(recon *model.ReconciliationEntity)
 		return nil, err
 	}
 	reconResult := newReconciliationResult(recon, bk.logger)
+	reconResult.policy = bk.config.AggregationPolicy
 	if err := reconResult.AddOperations(ops); err != nil {
 		return nil, err
 	}
+	if bk.inventory != nil && len(reconResult.error) > 0 { //execution hints are only relevant for failed operations
+		clusterState, err := bk.inventory.Get(recon.RuntimeID, recon.ClusterConfig)
+		if err == nil {
+			reconResult.setExecutionHints(clusterState.Configuration.Components)
+		} else {
+			bk.logger.Warnf("Bookkeeper failed to retrieve execution hints of reconciliation '%s' "+
+				"(failures are aggregated without hints): %s", recon, err)
+		}
+	}
 	return reconResult, nil
 }
 
@@ -55,7 +55,7 @@ func (s *serviceTestSuite) TestBookkeeper() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second) //stop bookkeeper after 5 sec
 	defer cancel()
 
-	transition := newClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
+	transition := NewClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
 	start := time.Now()
 	require.NoError(t, bk.Run(ctx,
 		markOrphanOperation{transition: transition, logger: transition.logger},
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/deadletter"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -39,8 +40,9 @@ func (oo markOrphanOperation) Apply(reconResult *ReconciliationResult, config *B
 }
 
 type finishOperation struct {
-	transition *ClusterStatusTransition
-	logger     *zap.SugaredLogger
+	transition  *ClusterStatusTransition
+	logger      *zap.SugaredLogger
+	deadLetters *deadletter.Repository //optional: failed operations are only dead-lettered if defined
 }
 
 func (fo finishOperation) Apply(reconResult *ReconciliationResult, config *BookkeeperConfig) []error {
@@ -79,9 +81,47 @@ func (fo finishOperation) Apply(reconResult *ReconciliationResult, config *Bookk
 	if err == nil {
 		fo.logger.Debugf("BookkeeperTask finishOperation: updated cluster '%s' to status '%s' (schedulingID:%s)",
 			recon.RuntimeID, newClusterStatus, recon.SchedulingID)
+		if newClusterStatus == model.ClusterStatusReconcileError || newClusterStatus == model.ClusterStatusDeleteError {
+			fo.deadLetter(reconResult, config)
+		}
 		return nil
 	}
 
 	return []error{errors.Errorf("BookkeeperTask finishOperation: failed to update cluster '%s' to status '%s' "+
 		"(schedulingID:%s): %s", recon.RuntimeID, newClusterStatus, recon.SchedulingID, err)}
 }
+
+//deadLetter moves the failed operations of a reconciliation which exhausted all retries to the dead-letter queue
+func (fo finishOperation) deadLetter(reconResult *ReconciliationResult, config *BookkeeperConfig) {
+	if fo.deadLetters == nil {
+		return
+	}
+
+	tolerated := make(map[string]bool)
+	for _, op := range reconResult.GetTolerated() {
+		tolerated[op.CorrelationID] = true
+	}
+	for _, op := range reconResult.error {
+		if tolerated[op.CorrelationID] {
+			continue
+		}
+		if _, err := fo.deadLetters.Add(op); err == nil {
+			fo.logger.Infof("BookkeeperTask finishOperation: moved operation '%s' to dead-letter queue", op)
+		} else {
+			fo.logger.Errorf("BookkeeperTask finishOperation: failed to move operation '%s' to dead-letter queue: %s", op, err)
+		}
+	}
+
+	if config.DeadLetterAlertThreshold == 0 {
+		return
+	}
+	openCnt, err := fo.deadLetters.CountOpen()
+	if err != nil {
+		fo.logger.Errorf("BookkeeperTask finishOperation: failed to count open dead letters: %s", err)
+		return
+	}
+	if openCnt >= config.DeadLetterAlertThreshold {
+		fo.logger.Errorf("Dead-letter queue contains %d open operations (alert threshold is %d): "+
+			"requeue or acknowledge them", openCnt, config.DeadLetterAlertThreshold)
+	}
+}
@@ -77,7 +77,7 @@ func (s *serviceTestSuite) TestBookkeepingTask() {
 			}
 
 			//setup bookkeeper task
-			transition := newClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
+			transition := NewClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
 
 			//initialize bookkeeper
 			bk := newBookkeeper(
@@ -204,7 +204,7 @@ func (s *serviceTestSuite) TestBookkeepingTaskParallel() {
 			}
 
 			//setup bookkeeper task
-			transition := newClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
+			transition := NewClusterStatusTransition(dbConn, inventory, reconRepo, logger.NewLogger(true))
 
 			//initialize bookkeeper
 			bk := newBookkeeper(
@@ -20,6 +20,8 @@ type CleanerConfig struct {
 	MaxReconciliationsAgeDays  uint
 	MaxInventoryAgeDays        uint
 	StatusCleanupBatchSize     uint
+	//KeepDeletedClusters excludes deleted clusters from the cleanup: they are kept for audits until they get purged
+	KeepDeletedClusters bool
 }
 
 func (c *CleanerConfig) retainReconciliationsCount() int {
@@ -92,7 +94,11 @@ func (c *cleaner) clusterEntityCleanup(transition *ClusterStatusTransition, conf
 		startClusterEntities := time.Now()
 
 		deadline := beginningOfTheDay(time.Now().UTC()).AddDate(0, 0, -1*clusterInventoryCleanupDays)
-		if err := transition.CleanStatusesAndDeletedClustersOlderThan(deadline, config.statusCleanupBatchSize(), time.Second*5); err != nil {
+		cleanOlderThan := transition.CleanStatusesAndDeletedClustersOlderThan
+		if config.KeepDeletedClusters {
+			cleanOlderThan = transition.CleanStatusesOlderThan
+		}
+		if err := cleanOlderThan(deadline, config.statusCleanupBatchSize(), time.Second*5); err != nil {
 			c.logger.Errorf("%s Failed (%s): to remove inventory clusters and intermediary statuses %v", CleanerPrefix, cleanerProcessUUID, err)
 		}
 		c.logger.Infof("%s Process finished (%s): Cluster entities cleanup, took %.2f minutes", CleanerPrefix, cleanerProcessUUID, time.Since(startClusterEntities).Minutes())
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//maxMissedBeats is the number of watch intervals the inventory watcher can miss before the scheduler is unhealthy
+const maxMissedBeats = 3
+
+//Heartbeat tracks whether the scheduler is alive: the inventory watcher beats after each watch cycle. A scheduler
+//which didn't start yet or whose watch cycles got stuck (e.g. on a full scheduling queue) doesn't beat.
+type Heartbeat struct {
+	mu       sync.RWMutex
+	last     time.Time
+	interval time.Duration
+}
+
+func NewHeartbeat() *Heartbeat {
+	return &Heartbeat{}
+}
+
+func (h *Heartbeat) beat(now time.Time, interval time.Duration) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.last = now
+	h.interval = interval
+}
+
+//Check returns an error if the scheduler didn't start yet or missed too many watch cycles
+func (h *Heartbeat) Check(now time.Time) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.last.IsZero() {
+		return errors.New("scheduler didn't start yet")
+	}
+	if since := now.Sub(h.last); since > maxMissedBeats*h.interval {
+		return fmt.Errorf("scheduler didn't finish a watch cycle for %s (watch interval is %s)",
+			since.Round(time.Second), h.interval)
+	}
+	return nil
+}
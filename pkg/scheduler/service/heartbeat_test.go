@@ -0,0 +1,23 @@
+package service
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *serviceTestSuite) TestHeartbeat() {
+	t := s.T()
+	now := time.Now()
+
+	var heartbeat *Heartbeat
+	heartbeat.beat(now, time.Second) //nil-safe if the scheduler runs without heartbeat
+
+	heartbeat = NewHeartbeat()
+	require.Error(t, heartbeat.Check(now), "scheduler didn't start yet")
+
+	heartbeat.beat(now, time.Minute)
+	require.NoError(t, heartbeat.Check(now.Add(2*time.Minute)))
+	require.NoError(t, heartbeat.Check(now.Add(maxMissedBeats*time.Minute)))
+	require.Error(t, heartbeat.Check(now.Add(maxMissedBeats*time.Minute+time.Second)), "scheduler got stuck")
+}
@@ -33,11 +33,13 @@ func (w *inventoryWatcher) Run(ctx context.Context, queue inventoryQueue) error
 		w.config.InventoryWatchInterval.Seconds())
 
 	w.processClustersToReconcile(queue) //check for clusters now, otherwise first check would be trigger by ticker
+	w.config.Heartbeat.beat(time.Now(), w.config.InventoryWatchInterval)
 	ticker := time.NewTicker(w.config.InventoryWatchInterval)
 	for {
 		select {
 		case <-ticker.C:
 			w.processClustersToReconcile(queue)
+			w.config.Heartbeat.beat(time.Now(), w.config.InventoryWatchInterval)
 		case <-ctx.Done():
 			w.logger.Info("Stopping inventory watcher because parent context got closed")
 			ticker.Stop()
@@ -46,7 +48,68 @@ func (w *inventoryWatcher) Run(ctx context.Context, queue inventoryQueue) error
 	}
 }
 
+//gated returns the clusters whose cohort doesn't allow a rollout right now. Deletions and the provisioning of new
+//clusters (which were never successfully reconciled) are never gated.
+func (w *inventoryWatcher) gated(clusterStates []*cluster.State) (map[string]bool, error) {
+	result := make(map[string]bool)
+	if w.config.Cohorts == nil {
+		return result, nil
+	}
+	now := time.Now()
+	cohorts := make(map[string]string)
+	var runtimeIDs []string
+	for _, clusterState := range clusterStates {
+		if clusterState == nil || clusterState.Status.Status.IsDeleteCandidate() {
+			continue
+		}
+		clusterCohort := w.config.Cohorts.Resolve(clusterState.Cluster.Metadata)
+		if clusterCohort == nil || clusterCohort.Reconcilable(now) {
+			continue
+		}
+		cohorts[clusterState.Cluster.RuntimeID] = clusterCohort.Name
+		runtimeIDs = append(runtimeIDs, clusterState.Cluster.RuntimeID)
+	}
+	if len(runtimeIDs) == 0 {
+		return result, nil
+	}
+	provisioned, err := w.inventory.GetProvisioned(runtimeIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, runtimeID := range runtimeIDs {
+		if isProvisioned, ok := provisioned[runtimeID]; ok && !isProvisioned {
+			w.logger.Debugf("Inventory watcher ignores gate of cohort '%s' for runtime '%s': "+
+				"cluster is not provisioned yet", cohorts[runtimeID], runtimeID)
+			continue
+		}
+		w.logger.Debugf("Inventory watcher skipped runtime '%s': rollouts to cohort '%s' are paused "+
+			"or outside of the maintenance window", runtimeID, cohorts[runtimeID])
+		result[runtimeID] = true
+	}
+	return result, nil
+}
+
+//paused checks whether the scheduler is paused fleet-wide (no cluster gets enqueued while paused)
+func (w *inventoryWatcher) paused() bool {
+	if w.config.Pauses == nil {
+		return false
+	}
+	activePause, err := w.config.Pauses.Active()
+	if err != nil {
+		w.logger.Errorf("Inventory watcher failed to check whether the scheduler is paused: %s", err)
+		return true
+	}
+	if activePause != nil {
+		w.logger.Infof("Inventory watcher skipped check for clusters to reconcile: scheduler is paused (%s)", activePause)
+		return true
+	}
+	return false
+}
+
 func (w *inventoryWatcher) processClustersToReconcile(queue inventoryQueue) {
+	if w.paused() {
+		return
+	}
 	clusterStates, err := w.inventory.ClustersToReconcile(w.config.ClusterReconcileInterval)
 	if err != nil {
 		w.logger.Errorf("Inventory watchers failed to fetch clusters to reconcile from inventory "+
@@ -56,11 +119,19 @@ func (w *inventoryWatcher) processClustersToReconcile(queue inventoryQueue) {
 	}
 
 	w.logger.Debugf("Inventory watcher found %d clusters which require a reconciliation", len(clusterStates))
+	gated, err := w.gated(clusterStates)
+	if err != nil {
+		w.logger.Errorf("Inventory watcher failed to check the cohorts of the clusters to reconcile: %s", err)
+		return
+	}
 	for _, clusterState := range clusterStates {
 		if clusterState == nil {
 			w.logger.Warn("Inventory watcher found nil cluster state when processing the list of clusters to reconcile")
 			continue
 		}
+		if gated[clusterState.Cluster.RuntimeID] {
+			continue
+		}
 		w.logger.Debugf("Inventory watcher added runtime '%s' to scheduling queue "+
 			"(clusterVersion:%d/configVersion:%d/status:%s)",
 			clusterState.Cluster.RuntimeID,
@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/cohort"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -75,3 +78,28 @@ func (s *serviceTestSuite) TestInventoryWatch_ShouldStopOnCtxClose() {
 	require.NoError(t, inventoryWatch.Run(ctx, queue))
 	require.WithinDuration(t, startTime, time.Now(), 2*time.Second)
 }
+
+func (s *serviceTestSuite) TestInventoryWatch_PausedCohort() {
+	t := s.T()
+	cohorts, err := cohort.NewResolver([]config.Cohort{{Name: "paused", Paused: true}})
+	require.NoError(t, err)
+
+	newState := func(runtimeID string) *cluster.State {
+		return &cluster.State{
+			Cluster:       &model.ClusterEntity{RuntimeID: runtimeID, Metadata: &keb.Metadata{}},
+			Configuration: &model.ClusterConfigurationEntity{RuntimeID: runtimeID},
+			Status:        &model.ClusterStatusEntity{RuntimeID: runtimeID, Status: model.ClusterStatusReconcilePending},
+		}
+	}
+	inventory := &cluster.MockInventory{
+		ClustersToReconcileResult: []*cluster.State{newState("provisioned"), newState("new")},
+		ProvisionedResult:         map[string]bool{"provisioned": true, "new": false},
+	}
+	inventoryWatch := newInventoryWatch(inventory, logger.NewLogger(true), &SchedulerConfig{Cohorts: cohorts})
+
+	//only the new cluster is provisioned: rollouts to the existing clusters of the cohort are paused
+	queue := make(chan *cluster.State, 2)
+	inventoryWatch.processClustersToReconcile(queue)
+	require.Len(t, queue, 1)
+	require.Equal(t, "new", (<-queue).Cluster.RuntimeID)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -12,10 +13,12 @@ import (
 type ReconciliationResult struct {
 	logger      *zap.SugaredLogger
 	reconEntity *model.ReconciliationEntity
+	policy      AggregationPolicy
 	done        []*model.OperationEntity
 	error       []*model.OperationEntity
 	running     []*model.OperationEntity
 	new         []*model.OperationEntity
+	critical    map[string]bool //critical flags hinted by KEB per component
 }
 
 func newReconciliationResult(reconEntity *model.ReconciliationEntity, logger *zap.SugaredLogger) *ReconciliationResult {
@@ -45,9 +48,9 @@ func (rs *ReconciliationResult) AddOperation(op *model.OperationEntity) error {
 	}
 
 	switch op.State {
-	case model.OperationStateDone:
+	case model.OperationStateDone, model.OperationStateSkipped: //skipped components don't block the cluster
 		rs.done = append(rs.done, op)
-	case model.OperationStateError:
+	case model.OperationStateError, model.OperationStateAborted:
 		rs.error = append(rs.error, op)
 	case model.OperationStateNew:
 		rs.new = append(rs.new, op)
@@ -60,6 +63,16 @@ func (rs *ReconciliationResult) AddOperation(op *model.OperationEntity) error {
 	return nil
 }
 
+//setExecutionHints applies the critical flags of the execution hints which were supplied by KEB for the components
+func (rs *ReconciliationResult) setExecutionHints(components []*keb.Component) {
+	rs.critical = make(map[string]bool)
+	for _, component := range components {
+		if critical, ok := component.ExecutionHints.GetCritical(); ok {
+			rs.critical[component.Component] = critical
+		}
+	}
+}
+
 func (rs *ReconciliationResult) GetOperations() []*model.OperationEntity {
 	var result []*model.OperationEntity
 	result = append(result, rs.new...)
@@ -69,44 +82,38 @@ func (rs *ReconciliationResult) GetOperations() []*model.OperationEntity {
 }
 
 func (rs *ReconciliationResult) GetResult() model.Status {
-	isDelete := true
-	for _, op := range rs.GetOperations() {
-		if op.Type != model.OperationTypeDelete {
-			isDelete = false
-			break
-		}
-	}
-	//this if-clause has always to be evaluated first:
-	//as soon as one operation is in an error state the cluster is marked to be in error-state if no other ops are running
-	if len(rs.error) > 0 && len(rs.running) == 0 {
-		if isDelete {
-			return model.ClusterStatusDeleteError
-		}
-		return model.ClusterStatusReconcileError
-	}
+	status, _ := rs.aggregationPolicy().Aggregate(rs)
+	return status
+}
 
-	//this if-clause has always to be evaluated as second condition:
-	//if one operation is not in a final state, the cluster is still in reconciling-state
-	if len(rs.running) > 0 || len(rs.new) > 0 {
-		if isDelete {
-			return model.ClusterStatusDeleting
-		}
-		return model.ClusterStatusReconciling
+//GetTolerated returns the failed operations which don't affect the cluster status (see AggregationPolicy)
+func (rs *ReconciliationResult) GetTolerated() []*model.OperationEntity {
+	_, tolerated := rs.aggregationPolicy().Aggregate(rs)
+	return tolerated
+}
+
+func (rs *ReconciliationResult) aggregationPolicy() AggregationPolicy {
+	if rs.policy == nil {
+		return &strictAggregationPolicy{}
 	}
-	//only if no operations are ongoing or in an error state, a cluster can be set to ready-state
-	if len(rs.done) > 0 {
-		if isDelete {
-			return model.ClusterStatusDeleted
+	return rs.policy
+}
+
+func (rs *ReconciliationResult) isDelete() bool {
+	for _, op := range rs.GetOperations() {
+		if op.Type != model.OperationTypeDelete {
+			return false
 		}
-		return model.ClusterStatusReady
 	}
-	// this should never be returned
-	return model.ClusterStatusReconcileError
+	return true
 }
 
 func (rs *ReconciliationResult) GetOrphans(timeout time.Duration) []*model.OperationEntity {
 	var orphaned []*model.OperationEntity
 	for _, op := range rs.running {
+		if op.State == model.OperationStatePendingConfirmation { //no worker processes it until the deletion is confirmed
+			continue
+		}
 		lastUpdateAgo := time.Now().UTC().Sub(op.Updated)
 		if lastUpdateAgo >= timeout {
 			rs.logger.Debugf("Reconciliation result detected orphan operation '%s': "+
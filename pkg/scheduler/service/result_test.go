@@ -221,6 +221,24 @@ func (s *serviceTestSuite) TestReconciliationResult() {
 			expectedResultReconcile: model.ClusterStatusReady,
 			expectedResultDelete:    model.ClusterStatusDeleted,
 		},
+		{
+			operations: []*model.OperationEntity{
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.1",
+					State:         model.OperationStateDone,
+				},
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.2",
+					State:         model.OperationStateSkipped,
+				},
+			},
+			expectedResultReconcile: model.ClusterStatusReady,
+			expectedResultDelete:    model.ClusterStatusDeleted,
+		},
 		{
 			operations: []*model.OperationEntity{
 				{
@@ -299,3 +317,32 @@ func (s *serviceTestSuite) TestReconciliationResult() {
 		})
 	}
 }
+
+func (s *serviceTestSuite) TestReconciliationResultPendingConfirmation() {
+	t := s.T()
+	reconResult := newReconciliationResult(&model.ReconciliationEntity{
+		RuntimeID:    "runtimeID",
+		SchedulingID: "schedulingID",
+	}, logger.NewLogger(true))
+
+	require.NoError(t, reconResult.AddOperations([]*model.OperationEntity{
+		{
+			Priority:      1,
+			SchedulingID:  "schedulingID",
+			CorrelationID: "1.1",
+			Type:          model.OperationTypeDelete,
+			State:         model.OperationStateDone,
+		},
+		{
+			Priority:      1,
+			SchedulingID:  "schedulingID",
+			CorrelationID: "1.2",
+			Type:          model.OperationTypeDelete,
+			State:         model.OperationStatePendingConfirmation,
+			Updated:       time.Now().Add(-1 * time.Hour),
+		},
+	}))
+	//the deletion waits for the confirmation: it's neither finished nor orphaned
+	require.Equal(t, model.ClusterStatusDeleting, reconResult.GetResult())
+	require.Empty(t, reconResult.GetOrphans(1*time.Second))
+}
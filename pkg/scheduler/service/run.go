@@ -11,8 +11,14 @@ import (
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/deadletter"
+	"github.com/kyma-incubator/reconciler/pkg/kubeconfigref"
+	"github.com/kyma-incubator/reconciler/pkg/ownership"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/backpressure"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/flaky"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/invoker"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/pause"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/worker"
@@ -166,6 +172,12 @@ type RunRemote struct {
 	schedulerConfig  *SchedulerConfig
 	bookkeeperConfig *BookkeeperConfig
 	cleanerConfig    *CleanerConfig
+	deadLetters      *deadletter.Repository
+	pauses           *pause.Repository
+	classifier       *flaky.Classifier
+	kubeconfigIssuer *kubeconfigref.Issuer
+	takeoverGuard    *ownership.Guard
+	backpressure     *backpressure.Controller
 }
 
 func (r *RunRemote) logger() *zap.SugaredLogger { //convenient function
@@ -196,29 +208,72 @@ func (r *RunRemote) WithCleanerConfig(cfg *CleanerConfig) *RunRemote {
 	return r
 }
 
+//WithDeadLetterRepository enables the dead-letter queue for operations which failed permanently
+func (r *RunRemote) WithDeadLetterRepository(repo *deadletter.Repository) *RunRemote {
+	r.deadLetters = repo
+	return r
+}
+
+//WithPauseRepository allows to pause the scheduling and dispatching of reconciliations fleet-wide
+func (r *RunRemote) WithPauseRepository(repo *pause.Repository) *RunRemote {
+	r.pauses = repo
+	return r
+}
+
+//WithFlakinessClassifier enables the quarantine of flaky components
+func (r *RunRemote) WithFlakinessClassifier(classifier *flaky.Classifier) *RunRemote {
+	r.classifier = classifier
+	return r
+}
+
+//WithKubeconfigIssuer lets the component reconcilers fetch the kubeconfigs by reference instead of embedding them
+//in the tasks
+func (r *RunRemote) WithKubeconfigIssuer(issuer *kubeconfigref.Issuer) *RunRemote {
+	r.kubeconfigIssuer = issuer
+	return r
+}
+
+//WithTakeoverGuard protects clusters which are owned by another mothership against reconciliations
+func (r *RunRemote) WithTakeoverGuard(guard *ownership.Guard) *RunRemote {
+	r.takeoverGuard = guard
+	return r
+}
+
+//WithBackpressure throttles the dispatching of operations while the database is slow or failing
+func (r *RunRemote) WithBackpressure(controller *backpressure.Controller) *RunRemote {
+	r.backpressure = controller
+	return r
+}
+
 func (r *RunRemote) Run(ctx context.Context) error {
 	if err := r.config.Validate(); err != nil {
 		return err
 	}
 	//start bookkeeper
 	go func() {
-		transition := newClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
-		if err := newBookkeeper(transition.reconRepo, r.bookkeeperConfig, r.logger()).Run(ctx,
+		transition := NewClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
+		if err := newBookkeeper(transition.reconRepo, r.bookkeeperConfig, r.logger()).withInventory(r.inventory).Run(ctx,
 			markOrphanOperation{transition: transition, logger: r.logger()},
-			finishOperation{transition: transition, logger: r.logger()}); err != nil {
+			finishOperation{transition: transition, logger: r.logger(), deadLetters: r.deadLetters}); err != nil {
 			r.logger().Fatalf("Bookkeeper returned an error: %s", err)
 		}
 	}()
 
 	//start worker pool
 	go func() {
-		remoteInvoker := invoker.NewRemoteReconcilerInvoker(r.reconciliationRepository(), r.config, r.logger())
+		remoteInvoker := invoker.NewRemoteReconcilerInvoker(r.reconciliationRepository(), r.config, r.logger()).
+			WithKubeconfigIssuer(r.kubeconfigIssuer).
+			WithCohorts(r.schedulerConfig.Cohorts)
 		workerPool, err := r.runtimeBuilder.newWorkerPool(&worker.InventoryRetriever{Inventory: r.inventory}, remoteInvoker)
 		if err == nil {
 			r.logger().Info("Worker pool created")
 		} else {
 			r.logger().Fatalf("Failed to create worker pool: %s", err)
 		}
+		workerPool.WithPauseRepository(r.pauses).
+			WithFlakinessClassifier(r.classifier).
+			WithTakeoverGuard(r.takeoverGuard).
+			WithBackpressure(r.backpressure)
 		if features.Enabled(features.WorkerpoolOccupancyTracking) {
 			//start occupancy tracker to track worker pool
 			err = NewOccupancyTracker(workerPool, r.occupancyRepo, r.config.Scheduler.Reconcilers, r.logger()).Run(ctx)
@@ -235,8 +290,9 @@ func (r *RunRemote) Run(ctx context.Context) error {
 	}()
 
 	//start scheduler
+	r.schedulerConfig.Pauses = r.pauses
 	go func() {
-		transition := newClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
+		transition := NewClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
 		if err := r.runtimeBuilder.newScheduler().Run(ctx, transition, r.schedulerConfig); err != nil {
 			r.logger().Fatalf("Remote scheduler returned an error: %s", err)
 		}
@@ -244,7 +300,7 @@ func (r *RunRemote) Run(ctx context.Context) error {
 
 	//start cleaner
 	go func() {
-		transition := newClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
+		transition := NewClusterStatusTransition(r.conn, r.inventory, r.reconciliationRepository(), r.logger())
 		if err := r.runtimeBuilder.newCleaner().Run(ctx, transition, r.cleanerConfig); err != nil {
 			r.logger().Fatalf("Cleaner returned an error: %s", err)
 		}
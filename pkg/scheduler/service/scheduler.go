@@ -7,6 +7,8 @@ import (
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/cohort"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/pause"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -42,6 +44,9 @@ type SchedulerConfig struct {
 	ClusterReconcileInterval time.Duration
 	ClusterQueueSize         int
 	DeleteStrategy           DeleteStrategy
+	Cohorts                  *cohort.Resolver
+	Pauses                   *pause.Repository
+	Heartbeat                *Heartbeat
 }
 
 func (wc *SchedulerConfig) validate() error {
@@ -2,12 +2,19 @@ package service
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/changes"
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/oplog"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/trace"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -19,7 +26,7 @@ type ClusterStatusTransition struct {
 	logger    *zap.SugaredLogger
 }
 
-func newClusterStatusTransition(
+func NewClusterStatusTransition(
 	conn db.Connection,
 	inventory cluster.Inventory,
 	reconRepo reconciliation.Repository,
@@ -75,6 +82,14 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 		//set cluster status to reconciling or deleting depending on previous state
 		var targetState model.Status
 		if oldClusterState.Status.Status.IsDeleteCandidate() {
+			//the protection could have been enabled after the deletion was accepted
+			protected, err := inventoryTx.IsDeletionProtected(runtimeID)
+			if err != nil {
+				return err
+			}
+			if protected {
+				return &cluster.DeletionProtectedError{RuntimeID: runtimeID}
+			}
 			targetState = model.ClusterStatusDeleting
 		} else if oldClusterState.Status.Status.IsReconcileCandidate() {
 			targetState = model.ClusterStatusReconciling
@@ -92,11 +107,23 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 		t.logger.Debugf("Starting reconciliation for cluster '%s': set cluster status to '%s'",
 			newClusterState.Cluster.RuntimeID, newClusterState.Status.Status)
 
+		//uninstall components which were removed since the last successful reconciliation
+		var removedComponents *model.RemovedComponents
+		if !newClusterState.Status.Status.IsDeletionInProgress() {
+			removedComponents, err = t.removedComponents(inventoryTx, reconRepoTx, newClusterState)
+			if err != nil {
+				t.logger.Errorf("Starting reconciliation for cluster '%s' failed: could not determine removed components: %s",
+					newClusterState.Cluster.RuntimeID, err)
+				return err
+			}
+		}
+
 		//create reconciliation entity
 		reconEntity, err := reconRepoTx.CreateReconciliation(newClusterState, &model.ReconciliationSequenceConfig{
 			PreComponents:        cfg.PreComponents,
 			DeleteStrategy:       string(cfg.DeleteStrategy),
 			ReconciliationStatus: newClusterState.Status.Status,
+			RemovedComponents:    removedComponents,
 		})
 		if err == nil {
 			t.logger.Debugf("Starting reconciliation for cluster '%s' succeeded: reconciliation successfully enqueued "+
@@ -129,6 +156,51 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 	return err
 }
 
+//removedComponents returns the components which were part of the configuration of the last successful
+//reconciliation but are missing in the current configuration of the cluster
+func (t *ClusterStatusTransition) removedComponents(inventory cluster.Inventory, reconRepo reconciliation.Repository,
+	state *cluster.State) (*model.RemovedComponents, error) {
+	recons, err := reconRepo.GetReconciliations(&reconciliation.FilterMixer{Filters: []reconciliation.Filter{
+		&reconciliation.WithRuntimeID{RuntimeID: state.Cluster.RuntimeID},
+		&reconciliation.WithStatuses{Statuses: []string{string(model.ClusterStatusReady)}},
+		&reconciliation.Limit{Count: 1},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	if len(recons) == 0 || recons[0].ClusterConfig == state.Configuration.Version {
+		return nil, nil
+	}
+
+	lastState, err := inventory.Get(state.Cluster.RuntimeID, recons[0].ClusterConfig)
+	if err != nil {
+		if repository.IsNotFoundError(err) {
+			t.logger.Warnf("Cannot determine removed components of cluster '%s': configuration version %d "+
+				"of last successful reconciliation no longer exists", state.Cluster.RuntimeID, recons[0].ClusterConfig)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var removed []string
+	for _, comp := range lastState.Configuration.Components {
+		if state.Configuration.GetComponent(comp.Component) == nil {
+			removed = append(removed, comp.Component)
+		}
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+	sort.Strings(removed)
+
+	t.logger.Infof("Cluster '%s' has components which were removed since configuration version %d: %s",
+		state.Cluster.RuntimeID, lastState.Configuration.Version, strings.Join(removed, ", "))
+	return &model.RemovedComponents{
+		ConfigVersion: lastState.Configuration.Version,
+		Components:    removed,
+	}, nil
+}
+
 func (t *ClusterStatusTransition) FinishReconciliation(schedulingID string, status model.Status) error {
 	dbOp := func(tx *db.TxConnection) error {
 		inventory, err := t.inventory.WithTx(tx)
@@ -152,53 +224,130 @@ func (t *ClusterStatusTransition) FinishReconciliation(schedulingID string, stat
 			t.logger.Debugf("Finishing reconciliation for cluster '%s' failed: reconciliation entity (schedulingID:%s) "+
 				"is already finished (maybe finished by parallel process in between)",
 				reconEntity.RuntimeID, reconEntity.SchedulingID)
-			return fmt.Errorf("failed to finish reconciliation '%s': it is already finished", reconEntity)
+			return errors.Wrap(reconciliation.NewFinishedReconciliationError(reconEntity), "failed to finish reconciliation")
 		}
 
-		clusterState, err := inventory.Get(reconEntity.RuntimeID, reconEntity.ClusterConfig)
+		return t.finishReconciliation(inventory, reconRepo, reconEntity, status)
+	}
+	return db.Transaction(t.conn, dbOp, t.logger)
+}
+
+//AbortReconciliation cancels a running reconciliation: all operations which aren't finished yet are marked as
+//aborted and the cluster gets the error status of the reconciliation
+func (t *ClusterStatusTransition) AbortReconciliation(schedulingID, reason string) error {
+	dbOp := func(tx *db.TxConnection) error {
+		inventory, err := t.inventory.WithTx(tx)
+		if err != nil {
+			return err
+		}
+
+		reconRepo, err := t.reconRepo.WithTx(tx)
 		if err != nil {
-			t.logger.Errorf("Finishing reconciliation for cluster '%s' failed: could not get cluster state : %s", reconEntity.RuntimeID, err)
 			return err
 		}
 
-		if clusterState.Status.Status.IsInProgress() {
-			oldClusterStatus := clusterState.Status.Status
-			clusterState, err = inventory.UpdateStatus(clusterState, status)
+		reconEntity, err := reconRepo.GetReconciliation(schedulingID)
+		if err != nil {
+			return err
+		}
+		if reconEntity.Finished {
+			return errors.Wrap(reconciliation.NewFinishedReconciliationError(reconEntity), "failed to abort reconciliation")
+		}
+
+		ops, err := reconRepo.GetOperations(&operation.WithSchedulingID{SchedulingID: schedulingID})
+		if err != nil {
+			return err
+		}
+		var aborted int
+		for _, op := range ops {
+			if op.State.IsFinal() {
+				continue
+			}
+			err := reconRepo.UpdateOperationState(op.SchedulingID, op.CorrelationID, model.OperationStateAborted, false, reason)
 			if err != nil {
-				t.logger.Errorf("Finishing reconciliation for cluster '%s' failed: "+
-					"could not update cluster status from %s to '%s': %s", clusterState.Cluster.RuntimeID, oldClusterStatus, status, err)
-				return err
+				return errors.Wrapf(err, "failed to abort operation '%s'", op)
 			}
-		} else {
-			t.logger.Warnf("Finishing reconciliation for cluster '%s': skipped cluster status update: current[%s], target[%s]"+
-				"(schedulingID:%s/clusterVersion:%d/configVersion:%d)",
-				clusterState.Cluster.RuntimeID, clusterState.Status.Status, status,
-				schedulingID, clusterState.Cluster.Version, clusterState.Configuration.Version)
+			aborted++
 		}
 
-		err = reconRepo.FinishReconciliation(schedulingID, clusterState.Status)
-		if err == nil {
-			t.logger.Debugf("Finishing reconciliation for cluster '%s' succeeded "+
-				"(schedulingID:%s/clusterVersion:%d/configVersion:%d): "+
-				"new cluster status is '%s'", clusterState.Cluster.RuntimeID, schedulingID,
-				clusterState.Cluster.Version, clusterState.Configuration.Version, clusterState.Status.Status)
-		} else {
-			t.logger.Errorf("Finishing reconciliation for cluster '%s' failed "+
-				"(schedulingID:%s/clusterVersion:%d/configVersion:%d) : %s",
-				clusterState.Cluster.RuntimeID, schedulingID,
-				clusterState.Cluster.Version, clusterState.Configuration.Version, err)
+		status := model.ClusterStatusReconcileError
+		if reconEntity.Status.IsDeletionInProgress() {
+			status = model.ClusterStatusDeleteError
+		}
+		t.logger.Infof("Aborting reconciliation of cluster '%s' (schedulingID:%s): aborted %d of %d operations "+
+			"(reason: %s)", reconEntity.RuntimeID, schedulingID, aborted, len(ops), reason)
+		return t.finishReconciliation(inventory, reconRepo, reconEntity, status)
+	}
+	return db.Transaction(t.conn, dbOp, t.logger)
+}
+
+func (t *ClusterStatusTransition) finishReconciliation(inventory cluster.Inventory, reconRepo reconciliation.Repository,
+	reconEntity *model.ReconciliationEntity, status model.Status) error {
+	schedulingID := reconEntity.SchedulingID
+	clusterState, err := inventory.Get(reconEntity.RuntimeID, reconEntity.ClusterConfig)
+	if err != nil {
+		t.logger.Errorf("Finishing reconciliation for cluster '%s' failed: could not get cluster state : %s", reconEntity.RuntimeID, err)
+		return err
+	}
+
+	if clusterState.Status.Status.IsInProgress() {
+		oldClusterStatus := clusterState.Status.Status
+		clusterState, err = inventory.UpdateStatus(clusterState, status)
+		if err != nil {
+			t.logger.Errorf("Finishing reconciliation for cluster '%s' failed: "+
+				"could not update cluster status from %s to '%s': %s", clusterState.Cluster.RuntimeID, oldClusterStatus, status, err)
 			return err
 		}
+	} else {
+		t.logger.Warnf("Finishing reconciliation for cluster '%s': skipped cluster status update: current[%s], target[%s]"+
+			"(schedulingID:%s/clusterVersion:%d/configVersion:%d)",
+			clusterState.Cluster.RuntimeID, clusterState.Status.Status, status,
+			schedulingID, clusterState.Cluster.Version, clusterState.Configuration.Version)
+	}
+
+	err = reconRepo.FinishReconciliation(schedulingID, clusterState.Status)
+	if err == nil {
+		t.logger.Debugf("Finishing reconciliation for cluster '%s' succeeded "+
+			"(schedulingID:%s/clusterVersion:%d/configVersion:%d): "+
+			"new cluster status is '%s'", clusterState.Cluster.RuntimeID, schedulingID,
+			clusterState.Cluster.Version, clusterState.Configuration.Version, clusterState.Status.Status)
+	} else {
+		t.logger.Errorf("Finishing reconciliation for cluster '%s' failed "+
+			"(schedulingID:%s/clusterVersion:%d/configVersion:%d) : %s",
+			clusterState.Cluster.RuntimeID, schedulingID,
+			clusterState.Cluster.Version, clusterState.Configuration.Version, err)
+		return err
+	}
+
+	if status == model.ClusterStatusDeleted {
+		return inventory.Delete(clusterState.Cluster.RuntimeID)
+	}
 
-		if status == model.ClusterStatusDeleted {
-			return inventory.Delete(clusterState.Cluster.RuntimeID)
+	//provisioned clusters are no longer exempted from paused cohorts
+	if status == model.ClusterStatusReady {
+		if err := inventory.SetProvisioned(clusterState.Cluster.RuntimeID); err != nil {
+			return err
 		}
-		return nil
 	}
-	return db.Transaction(t.conn, dbOp, t.logger)
+
+	//the cluster was successfully reconciled with a rotated kubeconfig: a rollback is no longer required (unless the
+	//reconciliation started before the rotation)
+	if status == model.ClusterStatusReady && clusterState.Cluster.PreviousKubeconfig != "" {
+		return inventory.ReleasePreviousKubeconfig(clusterState.Cluster.RuntimeID, reconEntity.ClusterConfigStatus)
+	}
+	return nil
 }
 
 func (t *ClusterStatusTransition) CleanStatusesAndDeletedClustersOlderThan(deadline time.Time, statusCleanupBatchSize int, timeout time.Duration) error {
+	return t.cleanEntitiesOlderThan(deadline, statusCleanupBatchSize, timeout, true)
+}
+
+//CleanStatusesOlderThan keeps deleted clusters for audits: they are only removed when they get purged
+func (t *ClusterStatusTransition) CleanStatusesOlderThan(deadline time.Time, statusCleanupBatchSize int, timeout time.Duration) error {
+	return t.cleanEntitiesOlderThan(deadline, statusCleanupBatchSize, timeout, false)
+}
+
+func (t *ClusterStatusTransition) cleanEntitiesOlderThan(deadline time.Time, statusCleanupBatchSize int, timeout time.Duration, removeDeletedClusters bool) error {
 	// delete statuses without reconciliations
 	deletedStatusesCount, err := t.Inventory().RemoveStatusesWithoutReconciliations(timeout, statusCleanupBatchSize)
 	if err != nil {
@@ -207,11 +356,46 @@ func (t *ClusterStatusTransition) CleanStatusesAndDeletedClustersOlderThan(deadl
 	t.logger.Infof("%s Cleaned %d statuses successfully", CleanerPrefix, deletedStatusesCount)
 
 	// delete inventory clusters - only if reconciliations are removed successfully - foreign key constraint
-	deletedClustersCount, err := t.Inventory().RemoveDeletedClustersOlderThan(deadline)
+	if removeDeletedClusters {
+		deletedClustersCount, err := t.Inventory().RemoveDeletedClustersOlderThan(deadline)
+		if err != nil {
+			return fmt.Errorf("failed to remove deleted clusters older than %v: %w", deadline, err)
+		}
+
+		t.logger.Infof("%s Cleaned %d clusters successfully", CleanerPrefix, deletedClustersCount)
+	}
+
+	// delete entries of the change feed (consumers with an older cursor have to resync)
+	changeRepo, err := changes.NewRepository(t.conn, false)
+	if err != nil {
+		return err
+	}
+	deletedChangesCount, err := changeRepo.RemoveChangesOlderThan(deadline)
 	if err != nil {
-		return fmt.Errorf("failed to remove deleted clusters older than %v: %w", deadline, err)
+		return fmt.Errorf("failed to remove changes older than %v: %w", deadline, err)
 	}
+	t.logger.Infof("%s Cleaned %d changes successfully", CleanerPrefix, deletedChangesCount)
 
-	t.logger.Infof("%s Cleaned %d clusters successfully", CleanerPrefix, deletedClustersCount)
+	// delete traces of slow operations
+	traceRepo, err := trace.NewRepository(t.conn, false)
+	if err != nil {
+		return err
+	}
+	deletedTracesCount, err := traceRepo.RemoveTracesOlderThan(deadline)
+	if err != nil {
+		return fmt.Errorf("failed to remove operation traces older than %v: %w", deadline, err)
+	}
+	t.logger.Infof("%s Cleaned %d operation traces successfully", CleanerPrefix, deletedTracesCount)
+
+	// delete logs reported by component reconcilers
+	opLogRepo, err := oplog.NewRepository(t.conn, false)
+	if err != nil {
+		return err
+	}
+	deletedLogsCount, err := opLogRepo.RemoveLogsOlderThan(deadline)
+	if err != nil {
+		return fmt.Errorf("failed to remove operation logs older than %v: %w", deadline, err)
+	}
+	t.logger.Infof("%s Cleaned %d operation logs successfully", CleanerPrefix, deletedLogsCount)
 	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
 	"github.com/kyma-incubator/reconciler/pkg/test"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -29,7 +30,7 @@ func (s *serviceTestSuite) prepareTransitionTest(t *testing.T, count int) []*clu
 	require.NoError(t, err)
 
 	//create transition which will change cluster states
-	s.transition = newClusterStatusTransition(s.dbConn, s.inventory, s.reconRepo, logger.NewLogger(true))
+	s.transition = NewClusterStatusTransition(s.dbConn, s.inventory, s.reconRepo, logger.NewLogger(true))
 
 	clusterStates := make([]*cluster.State, 0, count)
 	for i := 0; i < count; i++ {
@@ -88,6 +89,102 @@ func (s *serviceTestSuite) TestTransitionStartReconciliation() {
 	require.Equal(t, clusterState.Status.Status, model.ClusterStatusReconciling)
 }
 
+func (s *serviceTestSuite) TestTransitionStartReconciliationWithRemovedComponents() {
+	t := s.T()
+	s.prepareTransitionTest(t, 0)
+
+	newCluster := func(runtimeID string, components ...string) *keb.Cluster {
+		kebCluster := &keb.Cluster{
+			Kubeconfig: test.ReadKubeconfig(t),
+			KymaConfig: keb.KymaConfig{
+				Version: "1.2.3",
+			},
+			RuntimeID: runtimeID,
+		}
+		for _, component := range components {
+			kebCluster.KymaConfig.Components = append(kebCluster.KymaConfig.Components, keb.Component{Component: component})
+		}
+		return kebCluster
+	}
+
+	//reconcile cluster successfully with two components
+	runtimeID := uuid.NewString()
+	s.runtimeIDsToClear = []string{runtimeID}
+	oldClusterState, err := s.inventory.CreateOrUpdate(1, newCluster(runtimeID, "TestComp1", "TestComp2"))
+	require.NoError(t, err)
+	err = s.transition.StartReconciliation(runtimeID, oldClusterState.Configuration.Version, &SchedulerConfig{})
+	require.NoError(t, err)
+	reconEntities, err := s.transition.reconRepo.GetReconciliations(&reconciliation.WithRuntimeID{RuntimeID: runtimeID})
+	require.NoError(t, err)
+	require.NoError(t, s.transition.FinishReconciliation(reconEntities[0].SchedulingID, model.ClusterStatusReady))
+
+	//remove one component from the cluster configuration
+	newClusterState, err := s.inventory.CreateOrUpdate(1, newCluster(runtimeID, "TestComp1"))
+	require.NoError(t, err)
+	err = s.transition.StartReconciliation(runtimeID, newClusterState.Configuration.Version, &SchedulerConfig{})
+	require.NoError(t, err)
+
+	reconEntities, err = s.transition.reconRepo.GetReconciliations(
+		&reconciliation.CurrentlyReconcilingWithRuntimeID{RuntimeID: runtimeID},
+	)
+	require.NoError(t, err)
+	require.Len(t, reconEntities, 1)
+	ops, err := s.transition.reconRepo.GetOperations(&operation.WithSchedulingID{SchedulingID: reconEntities[0].SchedulingID})
+	require.NoError(t, err)
+
+	//removed component is deleted after all other components using the previous configuration
+	var deleteOp *model.OperationEntity
+	var maxPriority int64
+	for _, op := range ops {
+		if op.Priority > maxPriority {
+			maxPriority = op.Priority
+		}
+		if op.Type == model.OperationTypeDelete {
+			require.Nil(t, deleteOp, "only one delete operation expected")
+			deleteOp = op
+		}
+	}
+	require.NotNil(t, deleteOp)
+	require.Equal(t, "TestComp2", deleteOp.Component)
+	require.Equal(t, oldClusterState.Configuration.Version, deleteOp.ClusterConfig)
+	require.Equal(t, maxPriority, deleteOp.Priority)
+}
+
+func (s *serviceTestSuite) TestTransitionStartDeletionOfProtectedCluster() {
+	t := s.T()
+	s.prepareTransitionTest(t, 0)
+
+	runtimeID := uuid.NewString()
+	s.runtimeIDsToClear = []string{runtimeID}
+	_, err := s.inventory.CreateOrUpdate(1, &keb.Cluster{
+		Kubeconfig: test.ReadKubeconfig(t),
+		KymaConfig: keb.KymaConfig{
+			Components: []keb.Component{{Component: "TestComp1"}},
+			Version:    "1.2.3",
+		},
+		RuntimeID: runtimeID,
+	})
+	require.NoError(t, err)
+	clusterState, err := s.inventory.MarkForDeletion(runtimeID)
+	require.NoError(t, err)
+
+	//protection was enabled after the deletion was accepted
+	require.NoError(t, s.inventory.SetDeletionProtection(runtimeID, true))
+	err = s.transition.StartReconciliation(runtimeID, clusterState.Configuration.Version, &SchedulerConfig{})
+	require.True(t, cluster.IsDeletionProtectedError(err))
+	clusterState, err = s.inventory.GetLatest(runtimeID)
+	require.NoError(t, err)
+	require.Equal(t, model.ClusterStatusDeletePending, clusterState.Status.Status)
+
+	//deletion starts after the protection was removed
+	require.NoError(t, s.inventory.SetDeletionProtection(runtimeID, false))
+	err = s.transition.StartReconciliation(runtimeID, clusterState.Configuration.Version, &SchedulerConfig{})
+	require.NoError(t, err)
+	clusterState, err = s.inventory.GetLatest(runtimeID)
+	require.NoError(t, err)
+	require.Equal(t, model.ClusterStatusDeleting, clusterState.Status.Status)
+}
+
 func (s *serviceTestSuite) TestTransitionFinishReconciliation() {
 	t := s.T()
 	clusterStates := s.prepareTransitionTest(t, 1)
@@ -159,6 +256,44 @@ func (s *serviceTestSuite) TestTransitionFinishWhenClusterNotInProgress() {
 	require.Equal(t, model.ClusterStatusDeletePending, newClusterState.Status.Status)
 }
 
+func (s *serviceTestSuite) TestTransitionAbortReconciliation() {
+	t := s.T()
+	clusterStates := s.prepareTransitionTest(t, 1)
+
+	reconEntities, err := s.transition.reconRepo.GetReconciliations(
+		&reconciliation.WithRuntimeID{RuntimeID: clusterStates[0].Cluster.RuntimeID},
+	)
+	require.NoError(t, err)
+	require.Len(t, reconEntities, 1)
+	schedulingID := reconEntities[0].SchedulingID
+
+	err = s.transition.AbortReconciliation(schedulingID, "cancelled by operator")
+	require.NoError(t, err)
+
+	//aborting a finished reconciliation is not allowed
+	err = s.transition.AbortReconciliation(schedulingID, "cancelled by operator")
+	require.True(t, reconciliation.IsFinishedReconciliationError(err))
+
+	//verify that operations were aborted
+	ops, err := s.transition.reconRepo.GetOperations(&operation.WithSchedulingID{SchedulingID: schedulingID})
+	require.NoError(t, err)
+	require.NotEmpty(t, ops)
+	for _, op := range ops {
+		require.Equal(t, model.OperationStateAborted, op.State)
+		require.Contains(t, op.Reason, "cancelled by operator")
+	}
+
+	//verify that reconciliation is finished
+	reconEntity, err := s.transition.reconRepo.GetReconciliation(schedulingID)
+	require.NoError(t, err)
+	require.True(t, reconEntity.Finished)
+
+	//verify cluster status
+	clusterState, err := s.transition.inventory.GetLatest(clusterStates[0].Cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Equal(t, model.ClusterStatusReconcileError, clusterState.Status.Status)
+}
+
 func (s *serviceTestSuite) TestCleanDeletedClusters() {
 	t := s.T()
 	clusterStates := s.prepareTransitionTest(t, 5)
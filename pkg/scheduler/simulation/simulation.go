@@ -0,0 +1,277 @@
+package simulation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	defaultHorizon = 24 * time.Hour
+	defaultStep    = time.Minute
+)
+
+//Gate decides whether a cluster can be reconciled at the given time (e.g. the maintenance window of its cohort)
+type Gate interface {
+	Reconcilable(t time.Time) bool
+}
+
+//Cluster is a cluster of the fleet whose reconciliations are simulated
+type Cluster struct {
+	RuntimeID string
+	//Cohort is the name of the cohort the cluster belongs to (empty if it belongs to no cohort)
+	Cohort string
+	//Gate restricts the times the cluster can be reconciled (nil if it can always be reconciled)
+	Gate Gate
+	//Due is the time the next reconciliation of the cluster is due
+	Due time.Time
+	//Duration is the expected duration of a reconciliation of the cluster
+	Duration time.Duration
+	//Workers is the number of workers a reconciliation of the cluster occupies
+	Workers int
+}
+
+//Config of a simulation
+type Config struct {
+	//Start of the simulated period
+	Start time.Time
+	//Horizon is the length of the simulated period (default is 24h)
+	Horizon time.Duration
+	//Interval after which a reconciled cluster is reconciled again
+	Interval time.Duration
+	//Workers is the size of the worker pool
+	Workers int
+	//Step between two scheduling rounds, equals the watch interval of the inventory (default is 1m)
+	Step time.Duration
+	//Tolerance is the delay after which a cluster counts as missing its interval (default is the step)
+	Tolerance time.Duration
+}
+
+func (c *Config) validate() error {
+	if c.Horizon == 0 {
+		c.Horizon = defaultHorizon
+	}
+	if c.Step == 0 {
+		c.Step = defaultStep
+	}
+	if c.Tolerance == 0 {
+		c.Tolerance = c.Step
+	}
+	if c.Horizon < 0 || c.Step < 0 || c.Tolerance < 0 {
+		return fmt.Errorf("horizon, step and tolerance of the simulation cannot be < 0")
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("reconcile interval has to be > 0")
+	}
+	if c.Workers <= 0 {
+		return fmt.Errorf("worker pool size has to be > 0")
+	}
+	return nil
+}
+
+//Load is the utilization of the worker pool: for periods, the maxima of all scheduling rounds are reported
+type Load struct {
+	Time time.Time `json:"time"`
+	//Started reconciliations
+	Started int `json:"started"`
+	//BusyWorkers are occupied by running reconciliations
+	BusyWorkers int `json:"busyWorkers"`
+	//Queued clusters are due but wait for free workers
+	Queued int `json:"queued"`
+	//Deferred clusters are due but wait for their maintenance window
+	Deferred int `json:"deferred"`
+}
+
+func (l *Load) max(other *Load) {
+	l.Started += other.Started
+	if other.BusyWorkers > l.BusyWorkers {
+		l.BusyWorkers = other.BusyWorkers
+	}
+	if other.Queued > l.Queued {
+		l.Queued = other.Queued
+	}
+	if other.Deferred > l.Deferred {
+		l.Deferred = other.Deferred
+	}
+}
+
+//Conflict is a reconciliation which was deferred or interrupted by the maintenance window of the cluster
+type Conflict struct {
+	RuntimeID string    `json:"runtimeID"`
+	Cohort    string    `json:"cohort"`
+	Due       time.Time `json:"due"`
+	//Deferred is the time the reconciliation waited for the maintenance window
+	Deferred time.Duration `json:"deferred"`
+	Reason   string        `json:"reason"`
+}
+
+//Miss is a reconciliation which started later than its interval (plus the tolerance) required
+type Miss struct {
+	RuntimeID string    `json:"runtimeID"`
+	Due       time.Time `json:"due"`
+	//Started is zero if the reconciliation didn't start within the simulated period
+	Started time.Time     `json:"started"`
+	Delay   time.Duration `json:"delay"`
+}
+
+//Report is the result of a simulation
+type Report struct {
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	Clusters        int       `json:"clusters"`
+	Workers         int       `json:"workers"`
+	Reconciliations int       `json:"reconciliations"`
+	//Peak is the scheduling round with the most busy workers (and queued clusters)
+	Peak *Load `json:"peak"`
+	//Hours contains the load per hour of the simulated period
+	Hours     []*Load     `json:"hours"`
+	Conflicts []*Conflict `json:"conflicts"`
+	Missed    []*Miss     `json:"missed"`
+}
+
+type simulatedCluster struct {
+	*Cluster
+	due           time.Time
+	end           time.Time
+	running       bool
+	deferredSince time.Time
+}
+
+//Run simulates the scheduling of the fleet: in each round, finished reconciliations release their workers and the
+//due clusters are started in order of their due time as long as enough workers are free. A reconciled cluster
+//is due again after the interval.
+func Run(cfg Config, clusters []*Cluster) (*Report, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	fleet := make([]*simulatedCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		if cluster.Workers > cfg.Workers {
+			return nil, fmt.Errorf("cluster '%s' requires %d workers but the worker pool has only %d",
+				cluster.RuntimeID, cluster.Workers, cfg.Workers)
+		}
+		due := cluster.Due
+		if due.Before(cfg.Start) { //overdue clusters are picked up in the first round
+			due = cfg.Start
+		}
+		fleet = append(fleet, &simulatedCluster{Cluster: cluster, due: due})
+	}
+
+	end := cfg.Start.Add(cfg.Horizon)
+	report := &Report{
+		Start:     cfg.Start,
+		End:       end,
+		Clusters:  len(clusters),
+		Workers:   cfg.Workers,
+		Peak:      &Load{Time: cfg.Start},
+		Conflicts: []*Conflict{},
+		Missed:    []*Miss{},
+	}
+	var hour *Load
+	busy := 0
+	for now := cfg.Start; now.Before(end); now = now.Add(cfg.Step) {
+		round := &Load{Time: now}
+		for _, cluster := range fleet {
+			if cluster.running && !cluster.end.After(now) {
+				cluster.running = false
+				cluster.due = cluster.end.Add(cfg.Interval)
+				busy -= cluster.workers()
+			}
+		}
+
+		queueFull := false
+		for _, cluster := range dueClusters(fleet, now) {
+			if cluster.Gate != nil && !cluster.Gate.Reconcilable(now) {
+				if cluster.deferredSince.IsZero() {
+					cluster.deferredSince = now
+				}
+				round.Deferred++
+				continue
+			}
+			if queueFull || busy+cluster.workers() > cfg.Workers {
+				queueFull = true //clusters are started in order: later clusters can't overtake
+				round.Queued++
+				continue
+			}
+			busy += cluster.workers()
+			round.Started++
+			report.Reconciliations++
+			cluster.start(now, cfg, report)
+		}
+		round.BusyWorkers = busy
+
+		if round.BusyWorkers > report.Peak.BusyWorkers ||
+			(round.BusyWorkers == report.Peak.BusyWorkers && round.Queued > report.Peak.Queued) {
+			report.Peak = round
+		}
+		if hour == nil || !now.Before(hour.Time.Add(time.Hour)) {
+			hour = &Load{Time: now}
+			report.Hours = append(report.Hours, hour)
+		}
+		hour.max(round)
+	}
+
+	for _, cluster := range dueClusters(fleet, end) {
+		if delay := end.Sub(cluster.due); delay > cfg.Tolerance {
+			report.Missed = append(report.Missed, &Miss{RuntimeID: cluster.RuntimeID, Due: cluster.due, Delay: delay})
+		}
+		if !cluster.deferredSince.IsZero() {
+			report.Conflicts = append(report.Conflicts, &Conflict{
+				RuntimeID: cluster.RuntimeID,
+				Cohort:    cluster.Cohort,
+				Due:       cluster.due,
+				Deferred:  end.Sub(cluster.deferredSince),
+				Reason:    "not reconciled within the simulated period: cohort is paused or outside of its maintenance window",
+			})
+		}
+	}
+	return report, nil
+}
+
+//dueClusters returns the clusters which wait for a reconciliation ordered by their due time
+func dueClusters(fleet []*simulatedCluster, now time.Time) []*simulatedCluster {
+	var result []*simulatedCluster
+	for _, cluster := range fleet {
+		if !cluster.running && !cluster.due.After(now) {
+			result = append(result, cluster)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].due.Before(result[j].due)
+	})
+	return result
+}
+
+func (c *simulatedCluster) workers() int {
+	if c.Workers <= 0 {
+		return 1
+	}
+	return c.Workers
+}
+
+func (c *simulatedCluster) start(now time.Time, cfg Config, report *Report) {
+	c.running = true
+	c.end = now.Add(c.Duration)
+	if delay := now.Sub(c.due); delay > cfg.Tolerance {
+		report.Missed = append(report.Missed, &Miss{RuntimeID: c.RuntimeID, Due: c.due, Started: now, Delay: delay})
+	}
+	if !c.deferredSince.IsZero() {
+		report.Conflicts = append(report.Conflicts, &Conflict{
+			RuntimeID: c.RuntimeID,
+			Cohort:    c.Cohort,
+			Due:       c.due,
+			Deferred:  now.Sub(c.deferredSince),
+			Reason:    "deferred until the maintenance window opened",
+		})
+		c.deferredSince = time.Time{}
+	}
+	if c.Gate != nil && c.Duration > 0 && !c.Gate.Reconcilable(c.end.Add(-time.Nanosecond)) {
+		report.Conflicts = append(report.Conflicts, &Conflict{
+			RuntimeID: c.RuntimeID,
+			Cohort:    c.Cohort,
+			Due:       c.due,
+			Reason: fmt.Sprintf("reconciliation started at %s is expected to exceed the maintenance window",
+				now.UTC().Format(time.RFC3339)),
+		})
+	}
+}
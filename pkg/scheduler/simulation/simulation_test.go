@@ -0,0 +1,92 @@
+package simulation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+//testWindow is a daily time range in UTC (the end can be on the next day)
+type testWindow struct {
+	start, end int //hours
+}
+
+func (w testWindow) Reconcilable(t time.Time) bool {
+	hour := t.UTC().Hour()
+	if w.start <= w.end {
+		return hour >= w.start && hour < w.end
+	}
+	return hour >= w.start || hour < w.end
+}
+
+func TestRun(t *testing.T) {
+	start := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Enough capacity", func(t *testing.T) {
+		report, err := Run(Config{Start: start, Interval: time.Hour, Workers: 10}, []*Cluster{
+			{RuntimeID: "abc", Due: start, Duration: 10 * time.Minute, Workers: 5},
+			{RuntimeID: "xyz", Due: start.Add(-time.Hour), Duration: 10 * time.Minute, Workers: 5},
+		})
+		require.NoError(t, err)
+		require.Len(t, report.Hours, 24)
+		require.Equal(t, 2*21, report.Reconciliations, "each cluster is reconciled every 70 minutes")
+		require.Equal(t, 10, report.Peak.BusyWorkers)
+		require.Equal(t, start, report.Peak.Time)
+		require.Empty(t, report.Missed)
+		require.Empty(t, report.Conflicts)
+	})
+
+	t.Run("Capacity exceeded", func(t *testing.T) {
+		var clusters []*Cluster
+		for i := 0; i < 3; i++ {
+			clusters = append(clusters, &Cluster{
+				RuntimeID: fmt.Sprintf("cluster-%d", i),
+				Due:       start,
+				Duration:  30 * time.Minute,
+				Workers:   4,
+			})
+		}
+		report, err := Run(Config{Start: start, Horizon: time.Hour, Interval: time.Hour, Workers: 8}, clusters)
+		require.NoError(t, err)
+		require.Equal(t, 3, report.Reconciliations)
+		require.Equal(t, 1, report.Peak.Queued)
+		require.Equal(t, 8, report.Peak.BusyWorkers)
+		require.Len(t, report.Missed, 1)
+		require.Equal(t, "cluster-2", report.Missed[0].RuntimeID)
+		require.Equal(t, 30*time.Minute, report.Missed[0].Delay)
+		require.Equal(t, &Load{Time: start, Started: 3, BusyWorkers: 8, Queued: 1}, report.Hours[0])
+	})
+
+	t.Run("Maintenance windows", func(t *testing.T) {
+		report, err := Run(Config{Start: start, Horizon: 12 * time.Hour, Interval: 24 * time.Hour, Workers: 10}, []*Cluster{
+			{RuntimeID: "abc", Cohort: "canary", Gate: testWindow{start: 2, end: 4}, Due: start, Duration: time.Hour},
+			{RuntimeID: "def", Cohort: "prod", Gate: testWindow{start: 3, end: 4}, Due: start, Duration: 2 * time.Hour},
+			{RuntimeID: "xyz", Cohort: "paused", Gate: testWindow{start: 0, end: 0}, Due: start, Duration: time.Hour},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, report.Reconciliations)
+		require.Equal(t, 3, report.Hours[0].Deferred)
+
+		require.Len(t, report.Conflicts, 4)
+		require.Equal(t, "abc", report.Conflicts[0].RuntimeID)
+		require.Equal(t, 2*time.Hour, report.Conflicts[0].Deferred)
+		require.Equal(t, "def", report.Conflicts[1].RuntimeID)
+		require.Equal(t, 3*time.Hour, report.Conflicts[1].Deferred)
+		require.Contains(t, report.Conflicts[2].Reason, "exceed the maintenance window")
+		require.Equal(t, "xyz", report.Conflicts[3].RuntimeID)
+		require.Equal(t, 12*time.Hour, report.Conflicts[3].Deferred)
+
+		require.Len(t, report.Missed, 3, "deferred clusters miss their interval")
+	})
+
+	t.Run("Invalid configuration", func(t *testing.T) {
+		_, err := Run(Config{Start: start, Workers: 10}, nil)
+		require.Error(t, err)
+		_, err = Run(Config{Start: start, Interval: time.Hour}, nil)
+		require.Error(t, err)
+		_, err = Run(Config{Start: start, Interval: time.Hour, Workers: 1}, []*Cluster{{RuntimeID: "abc", Workers: 2}})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,106 @@
+package slo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+)
+
+const defaultWindow = 7 * 24 * time.Hour
+
+//Objective is the service level objective of a component: the target ratio of operations which
+//have to succeed (within the latency) in the time window
+type Objective struct {
+	Component string
+	Target    float64
+	Latency   time.Duration //0 = duration of operations isn't evaluated
+	Window    time.Duration
+}
+
+//Report is the compliance of a component with its objective. The burn rate is the ratio of the consumed
+//error budget: a burn rate above 1 means the objective is violated.
+type Report struct {
+	*Objective
+	Operations           int //finished operations within the window
+	GoodOperations       int //succeeded operations (within the latency, measured from the processing start)
+	Compliance           float64
+	BurnRate             float64
+	ErrorBudgetRemaining float64
+}
+
+func NewObjectives(cfgs []config.SLO) ([]*Objective, error) {
+	var result []*Objective
+	components := make(map[string]bool, len(cfgs))
+	for _, cfg := range cfgs {
+		if cfg.Component == "" {
+			return nil, fmt.Errorf("component of SLO is undefined")
+		}
+		if components[cfg.Component] {
+			return nil, fmt.Errorf("SLO of component '%s' is defined multiple times", cfg.Component)
+		}
+		components[cfg.Component] = true
+
+		if cfg.Target <= 0 || cfg.Target >= 1 {
+			return nil, fmt.Errorf("target of SLO of component '%s' has to be > 0 and < 1 but was %v",
+				cfg.Component, cfg.Target)
+		}
+		objective := &Objective{
+			Component: cfg.Component,
+			Target:    cfg.Target,
+			Window:    defaultWindow,
+		}
+		var err error
+		if cfg.Latency != "" {
+			if objective.Latency, err = time.ParseDuration(cfg.Latency); err != nil || objective.Latency <= 0 {
+				return nil, fmt.Errorf("latency '%s' of SLO of component '%s' is invalid", cfg.Latency, cfg.Component)
+			}
+		}
+		if cfg.Window != "" {
+			if objective.Window, err = time.ParseDuration(cfg.Window); err != nil || objective.Window <= 0 {
+				return nil, fmt.Errorf("window '%s' of SLO of component '%s' is invalid", cfg.Window, cfg.Component)
+			}
+		}
+		result = append(result, objective)
+	}
+	return result, nil
+}
+
+//Evaluate calculates the compliance of the component with the objective from the operations which
+//were created within the window. The operations are counted by the repository (the database aggregates them).
+func (o *Objective) Evaluate(repo reconciliation.Repository, now time.Time) (*Report, error) {
+	count, err := repo.CountFinishedOperations(o.Component, now.Add(-o.Window), o.Latency)
+	if err != nil {
+		return nil, err
+	}
+	return o.report(count), nil
+}
+
+func (o *Objective) report(count *reconciliation.OperationCount) *Report {
+	report := &Report{
+		Objective:      o,
+		Operations:     count.Finished,
+		GoodOperations: count.Good,
+		Compliance:     1,
+	}
+	if report.Operations > 0 {
+		report.Compliance = float64(report.GoodOperations) / float64(report.Operations)
+	}
+	report.BurnRate = (1 - report.Compliance) / (1 - o.Target)
+	report.ErrorBudgetRemaining = 1 - report.BurnRate
+	return report
+}
+
+//EvaluateAll calculates the compliance of all components with their objectives
+func EvaluateAll(objectives []*Objective, repo reconciliation.Repository, now time.Time) ([]*Report, error) {
+	result := make([]*Report, 0, len(objectives))
+	for _, objective := range objectives {
+		report, err := objective.Evaluate(repo, now)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, report)
+	}
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewObjectives(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfgs    []config.SLO
+		want    []*Objective
+		wantErr bool
+	}{
+		{
+			name: "Objective with defaults",
+			cfgs: []config.SLO{{Component: "istio", Target: 0.99}},
+			want: []*Objective{{Component: "istio", Target: 0.99, Window: 7 * 24 * time.Hour}},
+		},
+		{
+			name: "Objective with latency and window",
+			cfgs: []config.SLO{{Component: "istio", Target: 0.95, Latency: "10m", Window: "24h"}},
+			want: []*Objective{{Component: "istio", Target: 0.95, Latency: 10 * time.Minute, Window: 24 * time.Hour}},
+		},
+		{
+			name:    "Component is missing",
+			cfgs:    []config.SLO{{Target: 0.99}},
+			wantErr: true,
+		},
+		{
+			name:    "Component is defined twice",
+			cfgs:    []config.SLO{{Component: "istio", Target: 0.99}, {Component: "istio", Target: 0.9}},
+			wantErr: true,
+		},
+		{
+			name:    "Target is out of range",
+			cfgs:    []config.SLO{{Component: "istio", Target: 1}},
+			wantErr: true,
+		},
+		{
+			name:    "Latency is invalid",
+			cfgs:    []config.SLO{{Component: "istio", Target: 0.99, Latency: "abc"}},
+			wantErr: true,
+		},
+		{
+			name:    "Window is negative",
+			cfgs:    []config.SLO{{Component: "istio", Target: 0.99, Window: "-1h"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewObjectives(tt.cfgs)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Now().UTC()
+	repo := &reconciliation.MockRepository{
+		CountFinishedOperationsResult: &reconciliation.OperationCount{Finished: 4, Good: 2},
+	}
+
+	t.Run("Compliance with violated objective", func(t *testing.T) {
+		objective := &Objective{Component: "istio", Target: 0.9, Latency: 10 * time.Minute, Window: time.Hour}
+		report, err := objective.Evaluate(repo, now)
+		require.NoError(t, err)
+		require.Equal(t, 4, report.Operations)
+		require.Equal(t, 2, report.GoodOperations)
+		require.InDelta(t, 0.5, report.Compliance, 0.0001)
+		require.InDelta(t, 5, report.BurnRate, 0.0001)
+		require.InDelta(t, -4, report.ErrorBudgetRemaining, 0.0001)
+	})
+
+	t.Run("Compliance with fulfilled objective", func(t *testing.T) {
+		objective := &Objective{Component: "istio", Target: 0.25, Window: time.Hour}
+		report, err := objective.Evaluate(repo, now)
+		require.NoError(t, err)
+		require.InDelta(t, 0.5, report.Compliance, 0.0001)
+		require.InDelta(t, 0.6667, report.BurnRate, 0.0001)
+		require.InDelta(t, 0.3333, report.ErrorBudgetRemaining, 0.0001)
+	})
+
+	t.Run("Full compliance without operations", func(t *testing.T) {
+		objective := &Objective{Component: "unknown", Target: 0.99, Window: time.Hour}
+		reports, err := EvaluateAll([]*Objective{objective}, &reconciliation.MockRepository{}, now)
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		require.Equal(t, 0, reports[0].Operations)
+		require.Equal(t, float64(1), reports[0].Compliance)
+		require.Equal(t, float64(0), reports[0].BurnRate)
+		require.Equal(t, float64(1), reports[0].ErrorBudgetRemaining)
+	})
+}
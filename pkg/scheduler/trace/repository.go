@@ -0,0 +1,88 @@
+package trace
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+)
+
+//Repository stores the detailed captures of operations which exceeded the latency threshold of their
+//component reconciler
+type Repository struct {
+	*repository.Repository
+}
+
+func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{repo}, nil
+}
+
+//Store adds the trace of an operation (an existing trace of the operation is replaced)
+func (tr *Repository) Store(trace *model.OperationTraceEntity) error {
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, trace, tr.Logger)
+		if err != nil {
+			return err
+		}
+		_, err = q.Delete().
+			Where(map[string]interface{}{
+				"SchedulingID":  trace.SchedulingID,
+				"CorrelationID": trace.CorrelationID,
+			}).
+			Exec()
+		if err != nil {
+			return err
+		}
+		insertQ, err := db.NewQuery(tx, trace, tr.Logger)
+		if err != nil {
+			return err
+		}
+		return insertQ.Insert().Exec()
+	}
+	return tr.Transactional(dbOps)
+}
+
+//Get returns the trace of an operation
+func (tr *Repository) Get(schedulingID, correlationID string) (*model.OperationTraceEntity, error) {
+	q, err := db.NewQuery(tr.Conn, &model.OperationTraceEntity{}, tr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{
+		"SchedulingID":  schedulingID,
+		"CorrelationID": correlationID,
+	}
+	entity, err := q.Select().
+		Where(whereCond).
+		GetOne()
+	if err != nil {
+		return nil, tr.NewNotFoundError(err, entity, whereCond)
+	}
+	return entity.(*model.OperationTraceEntity), nil
+}
+
+//RemoveTracesOlderThan purges the traces which were stored before the deadline
+func (tr *Repository) RemoveTracesOlderThan(deadline time.Time) (int64, error) {
+	colHandler, err := db.NewColumnHandler(&model.OperationTraceEntity{}, tr.Conn, tr.Logger)
+	if err != nil {
+		return 0, err
+	}
+	createdCol, err := colHandler.ColumnName("Created")
+	if err != nil {
+		return 0, err
+	}
+	q, err := db.NewQuery(tr.Conn, &model.OperationTraceEntity{}, tr.Logger)
+	if err != nil {
+		return 0, err
+	}
+	deleteQ := q.Delete()
+	return deleteQ.
+		WhereRaw(fmt.Sprintf("%s<$%d", createdCol, deleteQ.NextPlaceholderCount()), deadline.Format(db.TimeFormat)).
+		Exec()
+}
@@ -0,0 +1,70 @@
+package trace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repo, err := NewRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+
+	runtimeID := uuid.NewString()
+	defer func() {
+		q, err := db.NewQuery(repo.Conn, &model.OperationTraceEntity{}, repo.Logger)
+		require.NoError(t, err)
+		_, err = q.Delete().Where(map[string]interface{}{"RuntimeID": runtimeID}).Exec()
+		require.NoError(t, err)
+	}()
+
+	entity := &model.OperationTraceEntity{
+		SchedulingID:  uuid.NewString(),
+		CorrelationID: uuid.NewString(),
+		RuntimeID:     runtimeID,
+		Component:     "istio",
+		Trace: &keb.OperationTrace{
+			Threshold: 60000,
+			Phases: []keb.TracePhase{
+				{Name: "reconcile", Started: time.Now().UTC().Truncate(time.Second), Duration: 90000},
+			},
+			ApiCalls: []keb.TraceAPICall{},
+			Logs: []keb.TraceLog{
+				{Level: "debug", Message: "waiting for deployment", Time: time.Now().UTC().Truncate(time.Second)},
+			},
+		},
+	}
+
+	t.Run("Trace of operation not found", func(t *testing.T) {
+		_, err := repo.Get(entity.SchedulingID, entity.CorrelationID)
+		require.True(t, repository.IsNotFoundError(err))
+	})
+
+	t.Run("Store and get trace", func(t *testing.T) {
+		require.NoError(t, repo.Store(entity))
+		stored, err := repo.Get(entity.SchedulingID, entity.CorrelationID)
+		require.NoError(t, err)
+		require.Equal(t, "istio", stored.Component)
+		require.Equal(t, entity.Trace, stored.Trace)
+
+		//trace gets replaced
+		entity.Trace.Truncated = true
+		require.NoError(t, repo.Store(entity))
+		stored, err = repo.Get(entity.SchedulingID, entity.CorrelationID)
+		require.NoError(t, err)
+		require.True(t, stored.Trace.Truncated)
+	})
+
+	t.Run("Remove traces older than deadline", func(t *testing.T) {
+		_, err := repo.RemoveTracesOlderThan(time.Now().UTC().Add(-24 * time.Hour))
+		require.NoError(t, err)
+		_, err = repo.Get(entity.SchedulingID, entity.CorrelationID)
+		require.NoError(t, err)
+	})
+}
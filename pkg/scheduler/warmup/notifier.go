@@ -0,0 +1,126 @@
+package warmup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	runPathSuffix    = "/run"
+	warmupPathSuffix = "/warmup"
+	defaultTimeout   = 10 * time.Second
+)
+
+//Result of the notification of a component reconciler
+type Result struct {
+	Component string
+	URL       string
+	Err       error
+}
+
+//Notifier announces upcoming Kyma versions to the component reconcilers: they pre-fetch the charts of these versions
+//before the first operations of the rollout arrive
+type Notifier struct {
+	reconcilers map[string]config.ComponentReconciler
+	client      *http.Client
+	logger      *zap.SugaredLogger
+}
+
+func NewNotifier(reconcilers map[string]config.ComponentReconciler, logger *zap.SugaredLogger) *Notifier {
+	return &Notifier{
+		reconcilers: reconcilers,
+		client:      &http.Client{Timeout: defaultTimeout},
+		logger:      logger,
+	}
+}
+
+//Notify sends the versions to all configured component reconcilers (in parallel). The results are sorted by
+//component: a failed notification doesn't affect the rollout, the component reconciler fetches the charts when the
+//first operation arrives.
+func (n *Notifier) Notify(ctx context.Context, versions []string) []Result {
+	payload, err := json.Marshal(&reconciler.HTTPWarmupRequest{Versions: versions})
+	if err != nil { //can't happen for a string slice
+		return nil
+	}
+
+	results := make([]Result, 0, len(n.reconcilers))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for component, compRecon := range n.reconcilers {
+		wg.Add(1)
+		go func(component string, compRecon config.ComponentReconciler) {
+			defer wg.Done()
+			result := n.notify(ctx, component, compRecon, payload)
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, result)
+		}(component, compRecon)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Component < results[j].Component
+	})
+	return results
+}
+
+//notify calls the endpoints of the component reconciler until one of them accepted the warm-up
+func (n *Notifier) notify(ctx context.Context, component string, compRecon config.ComponentReconciler, payload []byte) Result {
+	var result Result
+	for _, endpoint := range compRecon.Endpoints() {
+		result = Result{Component: component}
+		result.URL, result.Err = URL(endpoint)
+		if result.Err == nil {
+			result.Err = n.post(ctx, result.URL, payload)
+		}
+		if result.Err == nil {
+			n.logger.Debugf("Warm-up notifier announced upcoming Kyma versions to component reconciler '%s' (URL: %s)",
+				component, result.URL)
+			return result
+		}
+		n.logger.Warnf("Warm-up notifier failed to notify component reconciler '%s': %s", component, result.Err)
+	}
+	return result
+}
+
+func (n *Notifier) post(ctx context.Context, url string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create warm-up request (URL: %s)", url)
+	}
+	req.Header.Set("Content-Type", reconciler.ContentTypeJSON)
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to send warm-up request (URL: %s)", url)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			n.logger.Warnf("Error while closing HTTP response body: %s", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("warm-up request was rejected with status %d (URL: %s)", resp.StatusCode, url)
+	}
+	return nil
+}
+
+//URL returns the warm-up endpoint of a component reconciler which is located next to its run endpoint
+//(e.g. 'http://istio:8080/v1/run' becomes 'http://istio:8080/v1/warmup')
+func URL(runURL string) (string, error) {
+	if !strings.HasSuffix(runURL, runPathSuffix) {
+		return "", fmt.Errorf("URL '%s' of the component reconciler doesn't end with '%s'", runURL, runPathSuffix)
+	}
+	return strings.TrimSuffix(runURL, runPathSuffix) + warmupPathSuffix, nil
+}
@@ -0,0 +1,60 @@
+package warmup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURL(t *testing.T) {
+	url, err := URL("http://istio:8080/v1/run")
+	require.NoError(t, err)
+	require.Equal(t, "http://istio:8080/v1/warmup", url)
+
+	_, err = URL("http://istio:8080/v1/reconcile")
+	require.Error(t, err)
+}
+
+func TestNotifier(t *testing.T) {
+	var received []string
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/warmup", r.URL.Path)
+		warmupReq := &reconciler.HTTPWarmupRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(warmupReq))
+		received = append(received, warmupReq.Versions...)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	notifier := NewNotifier(map[string]config.ComponentReconciler{
+		"istio": {
+			URL:          unhealthy.URL + "/v1/run",
+			FallbackURLs: []string{healthy.URL + "/v1/run"},
+		},
+		"base": {
+			URL: unhealthy.URL + "/v1/run",
+		},
+	}, logger.NewLogger(true))
+
+	results := notifier.Notify(context.Background(), []string{"2.1.0"})
+	require.Len(t, results, 2)
+
+	require.Equal(t, "base", results[0].Component)
+	require.Error(t, results[0].Err)
+
+	require.Equal(t, "istio", results[1].Component)
+	require.NoError(t, results[1].Err, "fallback endpoint is notified if the primary endpoint is unhealthy")
+	require.Equal(t, healthy.URL+"/v1/warmup", results[1].URL)
+	require.Equal(t, []string{"2.1.0"}, received)
+}
@@ -3,6 +3,8 @@ package worker
 import (
 	"fmt"
 	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 )
 
 const (
@@ -21,6 +23,7 @@ type Config struct {
 	InvokerMaxRetries      int
 	InvokerRetryDelay      time.Duration
 	MaxOperationRetries    int
+	ComponentWeights       reconciliation.ComponentWeights //expected durations of components (longest-running are started first)
 }
 
 func (c *Config) validate() error {
@@ -44,6 +44,11 @@ func (w *worker) run(ctx context.Context, clusterState *cluster.State, op *model
 
 	retryable := func() error {
 		w.logger.Debugf("Worker calls invoker for operation '%s' (in retryable function)", op)
+		//each dispatch gets its own range of callback sequences: callbacks of previous dispatches become outdated
+		callbackSeq, err := w.reconRepo.StartOperationCallbackSequence(op.SchedulingID, op.CorrelationID)
+		if err != nil {
+			return err
+		}
 		return w.invoker.Invoke(ctx, &invoker.Params{
 			ComponentToReconcile: comp,
 			ComponentsReady:      compsReady,
@@ -53,6 +58,8 @@ func (w *worker) run(ctx context.Context, clusterState *cluster.State, op *model
 			MaxOperationRetries:  maxOpRetries,
 			Type:                 op.Type,
 			Debug:                op.Debug,
+			DeletionConfirmed:    op.DeletionConfirmed,
+			CallbackSequence:     callbackSeq,
 		})
 	}
 
@@ -61,7 +68,10 @@ func (w *worker) run(ctx context.Context, clusterState *cluster.State, op *model
 		retry.Attempts(uint(w.maxRetries)),
 		retry.Delay(w.retryDelay),
 		retry.LastErrorOnly(false),
-		retry.Context(ctx))
+		retry.Context(ctx),
+		retry.RetryIf(func(err error) bool {
+			return !w.isAborted(op)
+		}))
 
 	if err == nil {
 		w.logger.Debugf("Worker finished processing of operation '%s' successfully", op)
@@ -89,8 +99,26 @@ func (w *worker) componentsReady(op *model.OperationEntity) ([]string, error) {
 	return result, nil
 }
 
+//isAborted returns true if the reconciliation of the operation was cancelled in the meantime
+func (w *worker) isAborted(op *model.OperationEntity) bool {
+	currentOp, err := w.reconRepo.GetOperation(op.SchedulingID, op.CorrelationID)
+	if err != nil {
+		w.logger.Warnf("Worker failed to retrieve current state of operation '%s': %s", op, err)
+		return false
+	}
+	if currentOp != nil && currentOp.State == model.OperationStateAborted {
+		w.logger.Infof("Worker stops retrying operation '%s' because it was aborted", op)
+		return true
+	}
+	return false
+}
+
 func (w *worker) isProcessable(op *model.OperationEntity) bool {
 	return op.State != model.OperationStateDone &&
 		op.State != model.OperationStateError &&
-		op.State != model.OperationStateInProgress
+		op.State != model.OperationStateSkipped &&
+		op.State != model.OperationStateInProgress &&
+		op.State != model.OperationStateWaiting &&
+		op.State != model.OperationStatePendingConfirmation &&
+		op.State != model.OperationStateAborted
 }
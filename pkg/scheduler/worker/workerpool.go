@@ -7,9 +7,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/ownership"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/backpressure"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/flaky"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/invoker"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/pause"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/panjf2000/ants/v2"
 	"github.com/pkg/errors"
@@ -24,6 +30,10 @@ type Pool struct {
 	logger            *zap.SugaredLogger
 	antsPool          *ants.PoolWithFunc
 	occupancyObserver occupancy.Observer
+	pauses            *pause.Repository
+	classifier        *flaky.Classifier
+	guard             *ownership.Guard
+	backpressure      *backpressure.Controller
 }
 
 func NewWorkerPool(retriever ClusterStateRetriever, reconRepo reconciliation.Repository, invoker invoker.Invoker, config *Config, logger *zap.SugaredLogger) (*Pool, error) {
@@ -45,6 +55,31 @@ func NewWorkerPool(retriever ClusterStateRetriever, reconRepo reconciliation.Rep
 	}, nil
 }
 
+//WithPauseRepository stops the dispatching of operations while the scheduler is paused fleet-wide
+func (w *Pool) WithPauseRepository(repo *pause.Repository) *Pool {
+	w.pauses = repo
+	return w
+}
+
+//WithFlakinessClassifier grants extra retries to operations of quarantined components and limits their
+//parallel operations fleet-wide
+func (w *Pool) WithFlakinessClassifier(classifier *flaky.Classifier) *Pool {
+	w.classifier = classifier
+	return w
+}
+
+//WithTakeoverGuard prevents the dispatching of operations of clusters which are owned by another mothership
+func (w *Pool) WithTakeoverGuard(guard *ownership.Guard) *Pool {
+	w.guard = guard
+	return w
+}
+
+//WithBackpressure reduces the dispatching of operations while the database of the mothership is slow or failing
+func (w *Pool) WithBackpressure(controller *backpressure.Controller) *Pool {
+	w.backpressure = controller
+	return w
+}
+
 func (w *Pool) RunOnce(ctx context.Context) error {
 	return w.run(ctx, true)
 }
@@ -108,6 +143,18 @@ func (w *Pool) startWorkerPool(ctx context.Context) error {
 }
 
 func (w *Pool) assignWorker(ctx context.Context, opEntity *model.OperationEntity) {
+	//a panicking worker fails only its operation: other operations are processed as usual
+	defer func() {
+		if p := recover(); p != nil {
+			panicErr := reconciler.NewPanicError(p)
+			w.logger.Errorf("Worker assigned to operation '%s' panicked: %s", opEntity, panicErr)
+			if err := w.reconRepo.UpdateOperationState(opEntity.SchedulingID, opEntity.CorrelationID,
+				model.OperationStateError, false, panicErr.Error()); err != nil {
+				w.logger.Errorf("Error updating state of panicked operation '%s': %s", opEntity, err)
+			}
+		}
+	}()
+
 	clusterState, err := w.retriever.Get(opEntity)
 	if err != nil {
 		if repository.IsNotFoundError(err) { // discard the orphaned operation, it will never succeed if the cluster is gone
@@ -124,8 +171,22 @@ func (w *Pool) assignWorker(ctx context.Context, opEntity *model.OperationEntity
 		return
 	}
 
+	if err := w.guard.Claim(ctx, clusterState); err != nil {
+		if ownership.IsOwnedByOtherMothershipError(err) {
+			w.logger.Errorf("Worker pool rejected operation '%s': %s", opEntity, err)
+			if err := w.reconRepo.UpdateOperationState(opEntity.SchedulingID, opEntity.CorrelationID,
+				model.OperationStateError, false, err.Error()); err != nil {
+				w.logger.Errorf("Error updating state of rejected operation '%s': %s", opEntity, err)
+			}
+		} else { //operation stays processable and is dispatched again by the next check
+			w.logger.Warnf("Worker pool postponed operation '%s' because the ownership of cluster '%s' "+
+				"could not be verified: %s", opEntity, opEntity.RuntimeID, err)
+		}
+		return
+	}
+
 	w.logger.Debugf("Worker pool is assigning operation '%s' to worker", opEntity)
-	maxOpRetries := w.config.MaxOperationRetries - int(opEntity.Retries)
+	maxOpRetries := w.maxOperationRetries(opEntity, clusterState) - int(opEntity.Retries)
 	err = (&worker{
 		reconRepo:  w.reconRepo,
 		invoker:    w.invoker,
@@ -138,16 +199,44 @@ func (w *Pool) assignWorker(ctx context.Context, opEntity *model.OperationEntity
 	}
 }
 
+//paused checks whether the dispatching of operations is paused fleet-wide. If the pause state
+//can't be retrieved, the operations aren't dispatched: the next check will retry it.
+func (w *Pool) paused() bool {
+	if w.pauses == nil {
+		return false
+	}
+	activePause, err := w.pauses.Active()
+	if err != nil {
+		w.logger.Warnf("Worker pool skipped dispatching of operations: could not check whether dispatching is paused: %s", err)
+		return true
+	}
+	if activePause != nil {
+		w.logger.Infof("Worker pool skipped dispatching of operations: dispatching is paused (%s)", activePause)
+		return true
+	}
+	return false
+}
+
 func (w *Pool) invokeProcessableOps() (int, error) {
+	if w.paused() {
+		return 0, nil
+	}
 	w.logger.Debugf("Worker pool is checking for processable operations (max parallel ops per cluster: %d)",
 		w.config.MaxParallelOperations)
-	ops, err := w.reconRepo.GetProcessableOperations(w.config.MaxParallelOperations)
+	start := time.Now()
+	ops, err := w.reconRepo.GetProcessableOperations(w.config.MaxParallelOperations, w.config.ComponentWeights)
+	w.backpressure.Observe(time.Since(start), err)
 	if err != nil {
 		w.logger.Warnf("Worker pool failed to retrieve processable operations: %s", err)
 		return 0, err
 	}
 
 	ops = w.filterProcessableOpsByMaxRetries(ops)
+	ops, err = w.classifier.Isolate(ops)
+	if err != nil {
+		w.logger.Warnf("Worker pool failed to isolate operations of quarantined components: %s", err)
+		return 0, err
+	}
 	opsCnt := len(ops)
 	w.logger.Debugf("Worker pool found %d processable operations: %s", opsCnt, func() string {
 		var opNames []string
@@ -161,7 +250,13 @@ func (w *Pool) invokeProcessableOps() (int, error) {
 	}
 
 	idx := 0
+	limit := w.backpressure.DispatchLimit(w.antsPool.Free())
 	for idx < opsCnt {
+		if idx >= limit && w.antsPool.Free() > 0 { //remaining operations are dispatched by the next check
+			w.logger.Warnf("Worker pool postponed %d operations because of backpressure of the database: "+
+				"dispatching is limited to %d operations", opsCnt-idx, limit)
+			break
+		}
 		if w.antsPool.Free() == 0 {
 			remainingOpsCnt := opsCnt - idx
 			w.logger.Warnf("could not assign %d operations to workers because workerpool capacity reached: capacity=%d", remainingOpsCnt, w.antsPool.Cap())
@@ -188,8 +283,19 @@ func (w *Pool) invokeProcessableOps() (int, error) {
 func (w *Pool) filterProcessableOpsByMaxRetries(ops []*model.OperationEntity) []*model.OperationEntity {
 	var filteredOps []*model.OperationEntity
 	for _, op := range ops {
-		if op.Retries >= int64(w.config.MaxOperationRetries) {
-			err := w.reconRepo.UpdateOperationState(op.SchedulingID, op.CorrelationID, model.OperationStateError, true, fmt.Sprintf("operation exceeds max. operation retries limit (maxOperationRetries:%d)", w.config.MaxOperationRetries))
+		maxOperationRetries := w.config.MaxOperationRetries
+		if op.Retries > 0 { //retries hinted for the component are only relevant for retried operations
+			if clusterState, err := w.retriever.Get(op); err == nil {
+				maxOperationRetries = w.maxOperationRetries(op, clusterState)
+			} else {
+				w.logger.Warnf("Worker pool could not retrieve execution hints of operation '%s' "+
+					"(using default max. retries): %s", op, err)
+			}
+		}
+		//quarantined components get extra retries
+		maxOperationRetries = w.classifier.MaxRetries(op.Component, maxOperationRetries)
+		if op.Retries >= int64(maxOperationRetries) {
+			err := w.reconRepo.UpdateOperationState(op.SchedulingID, op.CorrelationID, model.OperationStateError, true, fmt.Sprintf("operation exceeds max. operation retries limit (maxOperationRetries:%d)", maxOperationRetries))
 			if err != nil {
 				w.logger.Warnf("could not update operation state with schedulingID %s and correlationID %s to %v state", op.SchedulingID, op.CorrelationID, model.OperationStateError)
 			}
@@ -200,6 +306,18 @@ func (w *Pool) filterProcessableOpsByMaxRetries(ops []*model.OperationEntity) []
 	return filteredOps
 }
 
+//maxOperationRetries returns the max. retries of the operation: the execution hints of the component
+//(supplied by KEB per cluster) override the configured max. retries
+func (w *Pool) maxOperationRetries(op *model.OperationEntity, clusterState *cluster.State) int {
+	maxRetries := w.config.MaxOperationRetries
+	if clusterState != nil && clusterState.Configuration != nil {
+		if comp := clusterState.Configuration.GetComponent(op.Component); comp != nil {
+			maxRetries = comp.ExecutionHints.GetMaxRetries(maxRetries)
+		}
+	}
+	return maxRetries
+}
+
 func (w *Pool) invokeProcessableOpsWithInterval(ctx context.Context) error {
 	w.logger.Debugf("Worker pool starts watching for processable operations each %.1f secs",
 		w.config.OperationCheckInterval.Seconds())
@@ -81,7 +81,7 @@ func TestWorkerPool(t *testing.T) {
 
 	reconEntity, err := testInvoker.reconRepo.CreateReconciliation(clusterState, &model.ReconciliationSequenceConfig{})
 	require.NoError(t, err)
-	opsProcessable, err := testInvoker.reconRepo.GetProcessableOperations(0)
+	opsProcessable, err := testInvoker.reconRepo.GetProcessableOperations(0, nil)
 	require.Len(t, opsProcessable, 1)
 	require.NoError(t, err)
 
@@ -146,7 +146,7 @@ func TestWorkerPoolMaxOpRetriesReached(t *testing.T) {
 
 	maxParallelOps := 25
 	numberOfProcessableOps := 1
-	opsProcessable, err := testInvoker.reconRepo.GetProcessableOperations(maxParallelOps)
+	opsProcessable, err := testInvoker.reconRepo.GetProcessableOperations(maxParallelOps, nil)
 	require.Len(t, opsProcessable, numberOfProcessableOps)
 	require.NoError(t, err)
 
@@ -186,6 +186,24 @@ func TestWorkerPoolMaxOpRetriesReached(t *testing.T) {
 
 }
 
+func TestWorkerPoolMaxOpRetriesHinted(t *testing.T) {
+	workerPool, err := NewWorkerPool(nil, nil, nil, &Config{MaxOperationRetries: 5}, logger.NewLogger(true))
+	require.NoError(t, err)
+
+	maxRetries := int64(20)
+	clusterState := &cluster.State{
+		Configuration: &model.ClusterConfigurationEntity{
+			Components: []*keb.Component{
+				{Component: "istio", ExecutionHints: &keb.ExecutionHints{MaxRetries: &maxRetries}},
+				{Component: "serverless"},
+			},
+		},
+	}
+	require.Equal(t, 20, workerPool.maxOperationRetries(&model.OperationEntity{Component: "istio"}, clusterState))
+	require.Equal(t, 5, workerPool.maxOperationRetries(&model.OperationEntity{Component: "serverless"}, clusterState))
+	require.Equal(t, 5, workerPool.maxOperationRetries(&model.OperationEntity{Component: "istio"}, nil))
+}
+
 func requireOpsProcessableExists(t *testing.T, opsProcessable []*model.OperationEntity, correlationID string) {
 	for i := range opsProcessable {
 		if opsProcessable[i].CorrelationID == correlationID {
@@ -250,7 +268,7 @@ func TestWorkerPoolParallel(t *testing.T) {
 			}
 		}()
 
-		opsProcessable, err := testInvoker.reconRepo.GetProcessableOperations(0)
+		opsProcessable, err := testInvoker.reconRepo.GetProcessableOperations(0, nil)
 		require.Len(t, opsProcessable, countOperations) // only first priority
 		require.NoError(t, err)
 
@@ -2,8 +2,12 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+	"unicode"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
 	"github.com/kyma-incubator/reconciler/pkg/keb"
@@ -11,15 +15,23 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/repository"
 )
 
+//ProblemContentType is the content type of error responses (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+//problemTypePrefix is the prefix of the URI which identifies the type of a problem
+const problemTypePrefix = "urn:reconciler:problem:"
+
+//SendHTTPError sends the response as problem details (RFC 7807): the fields of the response are kept as extension
+//members (e.g. 'error') and the standard members are derived from the HTTP code unless the response defines them
 func SendHTTPError(w http.ResponseWriter, httpCode int, resp interface{}) {
 	log := logger.NewLogger(false)
 
-	payload, err := json.Marshal(resp)
+	payload, err := problemPayload(httpCode, resp)
 	if err != nil {
 		err = errors.Wrap(err, "failed to encode HTTP error response to JSON")
 	} else {
+		w.Header().Set("content-type", ProblemContentType)
 		w.WriteHeader(httpCode)
-		w.Header().Set("content-type", "application/json")
 		if _, err = w.Write(payload); err == nil {
 			log.Warnf("Sending HTTP error response (httpCode %d): %s", httpCode, payload)
 			return
@@ -33,6 +45,58 @@ func SendHTTPError(w http.ResponseWriter, httpCode int, resp interface{}) {
 	http.Error(w, err.Error(), http.StatusInternalServerError)
 }
 
+//problemPayload converts the response to problem details: the instance identifies the occurrence of the problem
+//(it's based on the incident ID if the response has one)
+func problemPayload(httpCode int, resp interface{}) ([]byte, error) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	problem := make(map[string]interface{})
+	if err := json.Unmarshal(data, &problem); err != nil { //response isn't a JSON object
+		problem = map[string]interface{}{"error": resp}
+	}
+
+	code, ok := problem["code"].(string)
+	if !ok || code == "" {
+		code = ErrorCode(httpCode)
+		problem["code"] = code
+	}
+	setDefault(problem, "type", problemTypePrefix+code)
+	setDefault(problem, "title", http.StatusText(httpCode))
+	setDefault(problem, "status", httpCode)
+	if detail, ok := problem["error"].(string); ok {
+		setDefault(problem, "detail", detail)
+	}
+	if incidentID, ok := problem["incidentID"].(string); ok && incidentID != "" {
+		setDefault(problem, "instance", "urn:uuid:"+incidentID)
+	} else {
+		setDefault(problem, "instance", "urn:uuid:"+uuid.NewString())
+	}
+	return json.Marshal(problem)
+}
+
+func setDefault(problem map[string]interface{}, key string, value interface{}) {
+	if current, ok := problem[key]; !ok || current == nil || current == "" {
+		problem[key] = value
+	}
+}
+
+//ErrorCode returns the default error code of a HTTP code: it's the HTTP status text in camel case (e.g. "notFound")
+func ErrorCode(httpCode int) string {
+	words := strings.FieldsFunc(http.StatusText(httpCode), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return fmt.Sprintf("http%d", httpCode)
+	}
+	code := strings.ToLower(words[0])
+	for _, word := range words[1:] {
+		code += strings.ToUpper(word[:1]) + strings.ToLower(word[1:])
+	}
+	return code
+}
+
 func SendHTTPErrorMap(w http.ResponseWriter, err error) {
 	statusCode := http.StatusInternalServerError
 	var resp interface{} = &keb.InternalError{Error: err.Error()}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendHTTPError(t *testing.T) {
+	t.Run("Problem details are derived from HTTP code", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{Error: "cluster not found"})
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, ProblemContentType, w.Header().Get("content-type"))
+
+		resp := &keb.HTTPErrorResponse{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+		require.Equal(t, "cluster not found", resp.Error)
+		require.Equal(t, "cluster not found", *resp.Detail)
+		require.Equal(t, "notFound", *resp.Code)
+		require.Equal(t, "urn:reconciler:problem:notFound", *resp.Type)
+		require.Equal(t, "Not Found", *resp.Title)
+		require.Equal(t, http.StatusNotFound, *resp.Status)
+		require.True(t, strings.HasPrefix(*resp.Instance, "urn:uuid:"))
+	})
+
+	t.Run("Specific code is kept", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		code := keb.ErrorCodeUnsupportedContractVersion
+		SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{Error: "v9 not supported", Code: &code})
+
+		resp := &keb.HTTPErrorResponse{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+		require.Equal(t, code, *resp.Code)
+		require.Equal(t, "urn:reconciler:problem:"+code, *resp.Type)
+	})
+
+	t.Run("Non-object response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		SendHTTPError(w, http.StatusBadRequest, "invalid payload")
+
+		resp := &keb.HTTPErrorResponse{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+		require.Equal(t, "invalid payload", resp.Error)
+		require.Equal(t, "badRequest", *resp.Code)
+	})
+}
+
+func TestErrorCode(t *testing.T) {
+	require.Equal(t, "internalServerError", ErrorCode(http.StatusInternalServerError))
+	require.Equal(t, "requestEntityTooLarge", ErrorCode(http.StatusRequestEntityTooLarge))
+	require.Equal(t, "preconditionFailed", ErrorCode(http.StatusPreconditionFailed))
+	require.Equal(t, "http599", ErrorCode(599))
+}
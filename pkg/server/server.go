@@ -2,21 +2,64 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/ssl"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
+type connContextKey struct{}
+
+//Limits protect the webserver against slow clients (slow-loris) and oversized requests: a zero value disables a limit
+type Limits struct {
+	//ReadHeaderTimeout is the max. time to read the request headers
+	ReadHeaderTimeout time.Duration
+	//ReadTimeout is the max. time to read the entire request including its body
+	ReadTimeout time.Duration
+	//WriteTimeout is the max. time from the end of the request headers until the response is written
+	WriteTimeout time.Duration
+	//IdleTimeout is the max. time a keep-alive connection waits for the next request
+	IdleTimeout time.Duration
+	//MaxBodyBytes is the max. size of a request body: larger requests are rejected with HTTP 413
+	MaxBodyBytes int64
+}
+
+func (l Limits) Validate() error {
+	if l.ReadHeaderTimeout < 0 || l.ReadTimeout < 0 || l.WriteTimeout < 0 || l.IdleTimeout < 0 {
+		return errors.New("webserver timeouts cannot be < 0")
+	}
+	if l.ReadTimeout > 0 && l.ReadHeaderTimeout > l.ReadTimeout {
+		return fmt.Errorf("webserver read header timeout (%s) cannot be longer than the read timeout (%s)",
+			l.ReadHeaderTimeout, l.ReadTimeout)
+	}
+	if l.MaxBodyBytes < 0 {
+		return errors.New("max. size of request bodies cannot be < 0")
+	}
+	return nil
+}
+
 type Webserver struct {
-	Logger     *zap.SugaredLogger
+	Logger *zap.SugaredLogger
+	//Host is the interface the webserver is listening on (all interfaces if empty)
+	Host       string
 	Port       int
 	SSLCrtFile string
 	SSLKeyFile string
-	Router     *mux.Router
-	server     *http.Server
+	TLSConfig  *tls.Config
+	//CertReloadInterval is the interval for checking the SSL certificate and key file for changes: a renewed
+	//key pair is used for new connections without a restart (0 disables the reload)
+	CertReloadInterval time.Duration
+	Limits             Limits
+	Router             *mux.Router
+	server             *http.Server
 }
 
 func (s *Webserver) logger() *zap.SugaredLogger {
@@ -27,20 +70,60 @@ func (s *Webserver) logger() *zap.SugaredLogger {
 }
 
 func (s *Webserver) Start(ctx context.Context) error {
+	tlsConfig, err := s.tlsConfig(ctx)
+	if err != nil {
+		return err
+	}
 	s.logger().Infof("Webserver starting and listening on port %d", s.Port)
-	s.startServer(s.Router)
+	s.startServer(s.Router, tlsConfig)
 	<-ctx.Done()
 	s.logger().Info("Webserver stopping (context got closed)")
 	return s.stopServer()
 }
 
-func (s *Webserver) startServer(router *mux.Router) {
+//tlsConfig returns the TLS configuration which provides the SSL key pair: the key pair gets reloaded
+//in the background until the context gets closed
+func (s *Webserver) tlsConfig(ctx context.Context) (*tls.Config, error) {
+	if !s.useTLS() {
+		return s.TLSConfig, nil
+	}
+	certReloader, err := ssl.NewCertReloader(s.SSLCrtFile, s.SSLKeyFile, s.logger())
+	if err != nil {
+		return nil, err
+	}
+	if s.CertReloadInterval > 0 {
+		go certReloader.Watch(ctx, s.CertReloadInterval)
+	}
+	var tlsConfig *tls.Config
+	if s.TLSConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	} else {
+		tlsConfig = s.TLSConfig.Clone()
+	}
+	tlsConfig.GetCertificate = certReloader.GetCertificate
+	return tlsConfig, nil
+}
+
+func (s *Webserver) useTLS() bool {
+	return s.SSLCrtFile != "" && s.SSLKeyFile != ""
+}
+
+func (s *Webserver) startServer(router *mux.Router, tlsConfig *tls.Config) {
 	//start server
-	s.server = &http.Server{Addr: fmt.Sprintf(":%d", s.Port), Handler: router}
+	s.server = &http.Server{
+		Addr:              net.JoinHostPort(s.Host, strconv.Itoa(s.Port)),
+		Handler:           limitBody(router, s.Limits.MaxBodyBytes),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: s.Limits.ReadHeaderTimeout,
+		ReadTimeout:       s.Limits.ReadTimeout,
+		WriteTimeout:      s.Limits.WriteTimeout,
+		IdleTimeout:       s.Limits.IdleTimeout,
+		ConnContext:       connContext,
+	}
 	go func() {
 		var err error
-		if s.SSLCrtFile != "" && s.SSLKeyFile != "" {
-			err = s.server.ListenAndServeTLS(s.SSLCrtFile, s.SSLKeyFile)
+		if s.useTLS() {
+			err = s.server.ListenAndServeTLS("", "") //key pair is provided by the TLS configuration
 		} else {
 			err = s.server.ListenAndServe()
 		}
@@ -65,3 +148,40 @@ func (s *Webserver) stopServer() error {
 	}
 	return err
 }
+
+//limitBody rejects requests whose body exceeds the max. size: requests with a declared content length are rejected
+//immediately, the bodies of all other requests fail to be read beyond the limit
+func limitBody(handler http.Handler, maxBytes int64) http.Handler {
+	if maxBytes <= 0 {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxBytes {
+			SendHTTPError(w, http.StatusRequestEntityTooLarge, &keb.HTTPErrorResponse{
+				Error: fmt.Sprintf("Request body exceeds the max. size of %d bytes", maxBytes),
+			})
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		handler.ServeHTTP(w, r)
+	})
+}
+
+//connContext makes the connection accessible for the handlers of its requests
+func connContext(ctx context.Context, conn net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, conn)
+}
+
+//DisableTimeouts removes the read and write deadlines of the connection serving the request: long-living responses
+//(e.g. server-sent events) call it to be exempted from the timeouts of the webserver. It returns false if the
+//deadlines can't be removed: HTTP/2 requests share their connection and stay bound to the write timeout.
+func DisableTimeouts(r *http.Request) bool {
+	if r.ProtoMajor != 1 {
+		return false
+	}
+	conn, ok := r.Context().Value(connContextKey{}).(net.Conn)
+	if !ok {
+		return false
+	}
+	return conn.SetDeadline(time.Time{}) == nil
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimits(t *testing.T) {
+	t.Run("Validate", func(t *testing.T) {
+		require.NoError(t, Limits{}.Validate())
+		require.NoError(t, Limits{ReadHeaderTimeout: time.Second, ReadTimeout: time.Minute, MaxBodyBytes: 1024}.Validate())
+		require.Error(t, Limits{WriteTimeout: -time.Second}.Validate())
+		require.Error(t, Limits{ReadHeaderTimeout: time.Minute, ReadTimeout: time.Second}.Validate())
+		require.Error(t, Limits{MaxBodyBytes: -1}.Validate())
+	})
+}
+
+func TestLimitBody(t *testing.T) {
+	handler := limitBody(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := ioutil.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}), 10)
+
+	t.Run("Body within limit", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789")))
+		require.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Declared content length exceeds limit", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789A")))
+		require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+		require.Equal(t, ProblemContentType, recorder.Header().Get("content-type"))
+	})
+
+	t.Run("Streamed body exceeds limit", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0123456789A"))
+		req.ContentLength = -1
+		handler.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestDisableTimeouts(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/stream" && !DisableTimeouts(r) {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		time.Sleep(300 * time.Millisecond)
+		_, _ = w.Write([]byte("done"))
+	}))
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Config.ConnContext = connContext
+	srv.Start()
+	defer srv.Close()
+
+	t.Run("Write timeout applies", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/list")
+		if err == nil {
+			defer resp.Body.Close()
+			_, err = ioutil.ReadAll(resp.Body)
+		}
+		require.Error(t, err)
+	})
+
+	t.Run("Write timeout disabled", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/stream")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "done", string(body))
+	})
+
+	t.Run("Request without connection", func(t *testing.T) {
+		require.False(t, DisableTimeouts(httptest.NewRequest(http.MethodGet, "/", nil)))
+	})
+}
@@ -0,0 +1,76 @@
+package snapshot
+
+import (
+	"strconv"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+//Diff returns the changes of the facts between two snapshots of a cluster:
+//  - node count changes are expected (autoscaling) unless the cluster has no nodes anymore
+//  - Kubernetes version changes are unexpected: the Kubernetes version isn't managed by the reconciler
+//  - added or removed Kyma CRDs are only expected if the cluster configuration changed in between
+func Diff(previous, current *model.ClusterSnapshotEntity) []*keb.FactChange {
+	changes := []*keb.FactChange{}
+	if previous == nil {
+		return changes
+	}
+	if previous.NodeCount != current.NodeCount {
+		changes = append(changes, &keb.FactChange{
+			Fact:     keb.FactChangeFactNodeCount,
+			Previous: strconv.FormatInt(previous.NodeCount, 10),
+			Current:  strconv.FormatInt(current.NodeCount, 10),
+			Expected: current.NodeCount > 0,
+		})
+	}
+	if previous.KubernetesVersion != current.KubernetesVersion {
+		changes = append(changes, &keb.FactChange{
+			Fact:     keb.FactChangeFactKubernetesVersion,
+			Previous: previous.KubernetesVersion,
+			Current:  current.KubernetesVersion,
+		})
+	}
+	configChanged := previous.ClusterConfig != current.ClusterConfig
+	for _, crd := range missing(previous.KymaCrds, current.KymaCrds) {
+		changes = append(changes, &keb.FactChange{
+			Fact:     keb.FactChangeFactKymaCRD,
+			Previous: crd,
+			Expected: configChanged,
+		})
+	}
+	for _, crd := range missing(current.KymaCrds, previous.KymaCrds) {
+		changes = append(changes, &keb.FactChange{
+			Fact:     keb.FactChangeFactKymaCRD,
+			Current:  crd,
+			Expected: configChanged,
+		})
+	}
+	return changes
+}
+
+//Unexpected filters the unexpected changes
+func Unexpected(changes []*keb.FactChange) []*keb.FactChange {
+	var result []*keb.FactChange
+	for _, change := range changes {
+		if !change.Expected {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+//missing returns the entries of the first list which are missing in the second list
+func missing(list, other []string) []string {
+	contained := make(map[string]bool, len(other))
+	for _, entry := range other {
+		contained[entry] = true
+	}
+	var result []string
+	for _, entry := range list {
+		if !contained[entry] {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package snapshot
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+)
+
+//kymaAPIGroup is the suffix of the API groups whose CRDs are considered as Kyma CRDs
+const kymaAPIGroup = "kyma-project.io"
+
+//Facts are the key facts of a target cluster
+type Facts struct {
+	NodeCount         int64
+	KubernetesVersion string
+	//KymaCRDs are the names of the installed CRDs of Kyma API groups (sorted)
+	KymaCRDs []string
+}
+
+//Collect captures the facts of the cluster
+func Collect(ctx context.Context, clientset kubernetes.Interface) (*Facts, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list nodes")
+	}
+	version, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve Kubernetes version")
+	}
+	_, resourceLists, err := clientset.Discovery().ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) { //unavailable API services don't fail the snapshot
+		return nil, errors.Wrap(err, "failed to discover API resources")
+	}
+
+	crds := make(map[string]bool)
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil || !isKymaGroup(groupVersion.Group) {
+			continue
+		}
+		for _, resource := range resourceList.APIResources {
+			if strings.Contains(resource.Name, "/") { //subresource
+				continue
+			}
+			crds[resource.Name+"."+groupVersion.Group] = true
+		}
+	}
+	facts := &Facts{
+		NodeCount:         int64(len(nodes.Items)),
+		KubernetesVersion: version.GitVersion,
+		KymaCRDs:          make([]string, 0, len(crds)),
+	}
+	for crd := range crds {
+		facts.KymaCRDs = append(facts.KymaCRDs, crd)
+	}
+	sort.Strings(facts.KymaCRDs)
+	return facts, nil
+}
+
+func isKymaGroup(group string) bool {
+	return group == kymaAPIGroup || strings.HasSuffix(group, "."+kymaAPIGroup)
+}
@@ -0,0 +1,165 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	kubeclient "github.com/kyma-incubator/reconciler/pkg/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRetention = 10
+	defaultTimeout   = 30 * time.Second
+	defaultWorkers   = 2
+	defaultQueueSize = 1000
+)
+
+//store persists the snapshots (implemented by the inventory)
+type store interface {
+	AddSnapshot(snapshot *model.ClusterSnapshotEntity, retention int) error
+	GetSnapshots(runtimeID string) ([]*model.ClusterSnapshotEntity, error)
+}
+
+//collector captures the facts of the cluster which is accessible with the kubeconfig
+type collector func(ctx context.Context, kubeconfig string) (*Facts, error)
+
+//Recorder captures a snapshot of the facts of a cluster after each successful reconciliation. Snapshots are
+//captured asynchronously: the inventory isn't blocked by slow or unreachable clusters.
+type Recorder struct {
+	store     store
+	collect   collector
+	logger    *zap.SugaredLogger
+	retention int
+	timeout   time.Duration
+	workers   int
+	queue     chan *cluster.State
+}
+
+//NewRecorder returns nil if the capturing of snapshots is disabled
+func NewRecorder(cfg config.SnapshotsConfig, store store, logger *zap.SugaredLogger) (*Recorder, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	timeout := defaultTimeout
+	if cfg.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("timeout '%s' of cluster snapshots is not a positive duration", cfg.Timeout)
+		}
+	}
+	if cfg.Retention < 0 || cfg.Workers < 0 {
+		return nil, fmt.Errorf("retention and workers of cluster snapshots cannot be < 0")
+	}
+	recorder := &Recorder{
+		store:     store,
+		logger:    logger,
+		retention: cfg.Retention,
+		timeout:   timeout,
+		workers:   cfg.Workers,
+		queue:     make(chan *cluster.State, defaultQueueSize),
+	}
+	recorder.collect = func(ctx context.Context, kubeconfig string) (*Facts, error) {
+		clientset, err := kubeclient.NewClientBuilder().WithLogger(logger).WithString(kubeconfig).Build(ctx, false)
+		if err != nil {
+			return nil, err
+		}
+		return Collect(ctx, clientset)
+	}
+	if recorder.retention == 0 {
+		recorder.retention = defaultRetention
+	}
+	if recorder.workers == 0 {
+		recorder.workers = defaultWorkers
+	}
+	return recorder, nil
+}
+
+//OnClusterStatusUpdate queues the cluster for a snapshot if its reconciliation succeeded
+func (r *Recorder) OnClusterStatusUpdate(state *cluster.State) {
+	if r == nil || state == nil || state.Status == nil || state.Cluster == nil ||
+		state.Status.Status != model.ClusterStatusReady {
+		return
+	}
+	select {
+	case r.queue <- state:
+	default:
+		r.logger.Warnf("Snapshot recorder dropped snapshot of cluster '%s': queue is full", state.Status.RuntimeID)
+	}
+}
+
+//Run captures the queued snapshots until the context is closed
+func (r *Recorder) Run(ctx context.Context) {
+	if r == nil {
+		return
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case state := <-r.queue:
+					if err := r.record(ctx, state); err != nil {
+						r.logger.Warnf("Snapshot recorder failed to capture snapshot of cluster '%s': %s",
+							state.Status.RuntimeID, err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (r *Recorder) record(ctx context.Context, state *cluster.State) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	facts, err := r.collect(ctx, state.Cluster.Kubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to collect facts")
+	}
+	snapshots, err := r.store.GetSnapshots(state.Status.RuntimeID)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve previous snapshot")
+	}
+	var previous *model.ClusterSnapshotEntity
+	if len(snapshots) > 0 {
+		previous = snapshots[0]
+	}
+
+	snapshot := &model.ClusterSnapshotEntity{
+		RuntimeID:         state.Status.RuntimeID,
+		ClusterConfig:     state.Status.ConfigVersion,
+		NodeCount:         facts.NodeCount,
+		KubernetesVersion: facts.KubernetesVersion,
+		KymaCrds:          facts.KymaCRDs,
+	}
+	snapshot.Changes = Diff(previous, snapshot)
+	if err := r.store.AddSnapshot(snapshot, r.retention); err != nil {
+		return errors.Wrap(err, "failed to store snapshot")
+	}
+	if unexpected := Unexpected(snapshot.Changes); len(unexpected) > 0 {
+		r.logger.Errorf("Facts of cluster '%s' changed unexpectedly since the previous reconciliation "+
+			"(configVersion:%d): %s", snapshot.RuntimeID, snapshot.ClusterConfig, describe(unexpected))
+	}
+	return nil
+}
+
+func describe(changes []*keb.FactChange) string {
+	descriptions := make([]string, 0, len(changes))
+	for _, change := range changes {
+		descriptions = append(descriptions, fmt.Sprintf("%s: '%s' -> '%s'", change.Fact, change.Previous, change.Current))
+	}
+	return strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,148 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type testStore struct {
+	snapshots []*model.ClusterSnapshotEntity
+}
+
+func (s *testStore) AddSnapshot(snapshot *model.ClusterSnapshotEntity, _ int) error {
+	s.snapshots = append([]*model.ClusterSnapshotEntity{snapshot}, s.snapshots...)
+	return nil
+}
+
+func (s *testStore) GetSnapshots(_ string) ([]*model.ClusterSnapshotEntity, error) {
+	return s.snapshots, nil
+}
+
+func TestCollect(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}})
+	discovery := clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.FakedServerVersion = &version.Info{GitVersion: "v1.23.4"}
+	discovery.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "serverless.kyma-project.io/v1alpha1", APIResources: []metav1.APIResource{
+			{Name: "functions"}, {Name: "functions/status"}, {Name: "gitrepositories"},
+		}},
+		{GroupVersion: "operator.kyma-project.io/v1alpha1", APIResources: []metav1.APIResource{{Name: "kymas"}}},
+		{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{{Name: "deployments"}}},
+	}
+
+	facts, err := Collect(context.Background(), clientset)
+	require.NoError(t, err)
+	require.Equal(t, &Facts{
+		NodeCount:         2,
+		KubernetesVersion: "v1.23.4",
+		KymaCRDs: []string{
+			"functions.serverless.kyma-project.io",
+			"gitrepositories.serverless.kyma-project.io",
+			"kymas.operator.kyma-project.io",
+		},
+	}, facts)
+}
+
+func TestDiff(t *testing.T) {
+	previous := &model.ClusterSnapshotEntity{
+		ClusterConfig:     1,
+		NodeCount:         3,
+		KubernetesVersion: "v1.23.4",
+		KymaCrds:          []string{"functions.serverless.kyma-project.io", "kymas.operator.kyma-project.io"},
+	}
+
+	t.Run("First snapshot", func(t *testing.T) {
+		require.Empty(t, Diff(nil, previous))
+	})
+
+	t.Run("Unchanged facts", func(t *testing.T) {
+		require.Empty(t, Diff(previous, previous))
+	})
+
+	t.Run("Changes without configuration change", func(t *testing.T) {
+		changes := Diff(previous, &model.ClusterSnapshotEntity{
+			ClusterConfig:     1,
+			NodeCount:         5,
+			KubernetesVersion: "v1.24.1",
+			KymaCrds:          []string{"functions.serverless.kyma-project.io", "tracepipelines.telemetry.kyma-project.io"},
+		})
+		require.Equal(t, []*keb.FactChange{
+			{Fact: keb.FactChangeFactNodeCount, Previous: "3", Current: "5", Expected: true},
+			{Fact: keb.FactChangeFactKubernetesVersion, Previous: "v1.23.4", Current: "v1.24.1"},
+			{Fact: keb.FactChangeFactKymaCRD, Previous: "kymas.operator.kyma-project.io"},
+			{Fact: keb.FactChangeFactKymaCRD, Current: "tracepipelines.telemetry.kyma-project.io"},
+		}, changes)
+		require.Len(t, Unexpected(changes), 3)
+	})
+
+	t.Run("CRD changes caused by configuration change", func(t *testing.T) {
+		changes := Diff(previous, &model.ClusterSnapshotEntity{
+			ClusterConfig:     2,
+			NodeCount:         0,
+			KubernetesVersion: "v1.23.4",
+			KymaCrds:          []string{"functions.serverless.kyma-project.io"},
+		})
+		require.Len(t, changes, 2)
+		require.Equal(t, []*keb.FactChange{
+			{Fact: keb.FactChangeFactNodeCount, Previous: "3", Current: "0"},
+		}, Unexpected(changes), "clusters without nodes are unexpected")
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	store := &testStore{}
+	recorder := &Recorder{
+		store:     store,
+		logger:    logger.NewLogger(true),
+		retention: defaultRetention,
+		timeout:   defaultTimeout,
+		collect: func(ctx context.Context, kubeconfig string) (*Facts, error) {
+			require.Equal(t, "kubeconfig", kubeconfig)
+			return &Facts{NodeCount: 3, KubernetesVersion: "v1.23.4"}, nil
+		},
+		queue: make(chan *cluster.State, 1),
+	}
+	newState := func(status model.Status, configVersion int64) *cluster.State {
+		return &cluster.State{
+			Cluster: &model.ClusterEntity{RuntimeID: "abc", Kubeconfig: "kubeconfig"},
+			Status:  &model.ClusterStatusEntity{RuntimeID: "abc", ConfigVersion: configVersion, Status: status},
+		}
+	}
+
+	t.Run("Queue only reconciled clusters", func(t *testing.T) {
+		recorder.OnClusterStatusUpdate(newState(model.ClusterStatusReconciling, 1))
+		require.Len(t, recorder.queue, 0)
+		recorder.OnClusterStatusUpdate(newState(model.ClusterStatusReady, 1))
+		require.Len(t, recorder.queue, 1)
+		recorder.OnClusterStatusUpdate(newState(model.ClusterStatusReady, 1)) //queue is full: snapshot is dropped
+		require.Len(t, recorder.queue, 1)
+		<-recorder.queue
+	})
+
+	t.Run("Record snapshots", func(t *testing.T) {
+		require.NoError(t, recorder.record(context.Background(), newState(model.ClusterStatusReady, 1)))
+		require.NoError(t, recorder.record(context.Background(), newState(model.ClusterStatusReady, 2)))
+		require.Len(t, store.snapshots, 2)
+		require.Equal(t, int64(2), store.snapshots[0].ClusterConfig)
+		require.Empty(t, store.snapshots[0].Changes)
+	})
+
+	t.Run("Disabled recorder", func(t *testing.T) {
+		var disabled *Recorder
+		disabled.OnClusterStatusUpdate(newState(model.ClusterStatusReady, 1))
+		disabled.Run(context.Background())
+	})
+}
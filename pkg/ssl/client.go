@@ -0,0 +1,111 @@
+package ssl
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+//ClientAuthConfig defines the verification of client certificates (mutual TLS)
+type ClientAuthConfig struct {
+	CAFile      string
+	Required    bool
+	AllowedCNs  []string
+	AllowedSANs []string
+}
+
+//Enabled returns true if client certificates are verified
+func (c ClientAuthConfig) Enabled() bool {
+	return c.CAFile != ""
+}
+
+//Validate verifies that the client CA can be loaded and the server provides a certificate
+func (c ClientAuthConfig) Validate(sslCrtFile, sslKeyFile string) error {
+	if !c.Enabled() {
+		if c.Required || len(c.AllowedCNs) > 0 || len(c.AllowedSANs) > 0 {
+			return errors.New("client CA must be set if client certificates are required or an allow list is defined")
+		}
+		return nil
+	}
+	if sslCrtFile == "" || sslKeyFile == "" {
+		return errors.New("SSL certificate and key must be set if client certificates are verified")
+	}
+	_, err := c.certPool()
+	return err
+}
+
+func (c ClientAuthConfig) certPool() (*x509.CertPool, error) {
+	caPEM, err := ioutil.ReadFile(c.CAFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read client CA file '%s'", c.CAFile)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("client CA file '%s' doesn't contain any PEM encoded certificate", c.CAFile)
+	}
+	return pool, nil
+}
+
+//NewServerTLSConfig returns the TLS configuration which verifies client certificates against the client CA and
+//the CN/SAN allow lists. It returns nil if client certificates aren't verified.
+func NewServerTLSConfig(c ClientAuthConfig) (*tls.Config, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+	pool, err := c.certPool()
+	if err != nil {
+		return nil, err
+	}
+	clientAuth := tls.VerifyClientCertIfGiven
+	if c.Required {
+		clientAuth = tls.RequireAndVerifyClientCert
+	}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientCAs:  pool,
+		ClientAuth: clientAuth,
+		VerifyPeerCertificate: func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+			//without a client certificate no chain got verified (only possible if certificates are optional)
+			if len(verifiedChains) == 0 || len(verifiedChains[0]) == 0 {
+				return nil
+			}
+			return c.verifyAllowed(verifiedChains[0][0])
+		},
+	}, nil
+}
+
+//verifyAllowed accepts the certificate if no allow list is defined or its CN or one of its SANs is allowed
+func (c ClientAuthConfig) verifyAllowed(cert *x509.Certificate) error {
+	if len(c.AllowedCNs) == 0 && len(c.AllowedSANs) == 0 {
+		return nil
+	}
+	for _, cn := range c.AllowedCNs {
+		if cert.Subject.CommonName == cn {
+			return nil
+		}
+	}
+	for _, san := range certSANs(cert) {
+		for _, allowedSAN := range c.AllowedSANs {
+			if san == allowedSAN {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("client certificate '%s' is not allowed: neither its CN nor its SANs are part of the allow list",
+		cert.Subject.CommonName)
+}
+
+func certSANs(cert *x509.Certificate) []string {
+	sans := append([]string{}, cert.DNSNames...)
+	sans = append(sans, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
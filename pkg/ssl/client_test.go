@@ -0,0 +1,152 @@
+package ssl
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) writePEM(t *testing.T) string {
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600))
+	return caFile
+}
+
+func (ca *testCA) issue(t *testing.T, cn string, dnsNames ...string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     dnsNames,
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestClientAuthConfig(t *testing.T) {
+	caFile := newTestCA(t).writePEM(t)
+
+	t.Run("Disabled", func(t *testing.T) {
+		require.NoError(t, ClientAuthConfig{}.Validate("", ""))
+		require.Error(t, ClientAuthConfig{Required: true}.Validate("", ""))
+		require.Error(t, ClientAuthConfig{AllowedCNs: []string{"keb"}}.Validate("", ""))
+		tlsConfig, err := NewServerTLSConfig(ClientAuthConfig{})
+		require.NoError(t, err)
+		require.Nil(t, tlsConfig)
+	})
+
+	t.Run("Server certificate missing", func(t *testing.T) {
+		require.Error(t, ClientAuthConfig{CAFile: caFile}.Validate("", ""))
+	})
+
+	t.Run("Invalid CA file", func(t *testing.T) {
+		invalidFile := filepath.Join(t.TempDir(), "invalid.crt")
+		require.NoError(t, ioutil.WriteFile(invalidFile, []byte("invalid"), 0600))
+		require.Error(t, ClientAuthConfig{CAFile: invalidFile}.Validate("server.crt", "server.key"))
+		require.Error(t, ClientAuthConfig{CAFile: "/does/not/exist"}.Validate("server.crt", "server.key"))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, ClientAuthConfig{CAFile: caFile}.Validate("server.crt", "server.key"))
+	})
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+	serverCert := ca.issue(t, "mothership", "localhost")
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+
+	call := func(t *testing.T, cfg ClientAuthConfig, clientCerts ...tls.Certificate) error {
+		tlsConfig, err := NewServerTLSConfig(cfg)
+		require.NoError(t, err)
+		tlsConfig.Certificates = []tls.Certificate{serverCert}
+
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		srv.TLS = tlsConfig
+		srv.StartTLS()
+		defer srv.Close()
+
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: rootCAs, Certificates: clientCerts, MinVersion: tls.VersionTLS12},
+		}}
+		resp, err := client.Get(srv.URL)
+		if err == nil {
+			require.NoError(t, resp.Body.Close())
+		}
+		return err
+	}
+
+	caFile := ca.writePEM(t)
+
+	t.Run("Optional client certificate", func(t *testing.T) {
+		cfg := ClientAuthConfig{CAFile: caFile}
+		require.NoError(t, call(t, cfg))
+		require.NoError(t, call(t, cfg, ca.issue(t, "keb")))
+		require.Error(t, call(t, cfg, otherCA.issue(t, "keb")))
+	})
+
+	t.Run("Required client certificate", func(t *testing.T) {
+		cfg := ClientAuthConfig{CAFile: caFile, Required: true}
+		require.Error(t, call(t, cfg))
+		require.NoError(t, call(t, cfg, ca.issue(t, "keb")))
+	})
+
+	t.Run("Allow lists", func(t *testing.T) {
+		cfg := ClientAuthConfig{
+			CAFile:      caFile,
+			Required:    true,
+			AllowedCNs:  []string{"keb"},
+			AllowedSANs: []string{"component-reconciler.kyma-system"},
+		}
+		require.NoError(t, call(t, cfg, ca.issue(t, "keb")))
+		require.NoError(t, call(t, cfg, ca.issue(t, "istio", "component-reconciler.kyma-system")))
+		require.Error(t, call(t, cfg, ca.issue(t, "intruder", "intruder.kyma-system")))
+	})
+}
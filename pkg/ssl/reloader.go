@@ -0,0 +1,105 @@
+package ssl
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+//CertReloader provides the SSL key pair of a server and reloads it when the certificate or key file changed on disk
+//(e.g. after cert-manager renewed the certificate): new TLS connections use the renewed key pair without a restart
+type CertReloader struct {
+	crtFile string
+	keyFile string
+	logger  *zap.SugaredLogger
+
+	mu     sync.RWMutex
+	cert   *tls.Certificate
+	crtPEM []byte
+	keyPEM []byte
+}
+
+func NewCertReloader(crtFile, keyFile string, logger *zap.SugaredLogger) (*CertReloader, error) {
+	if logger == nil {
+		logger = zap.NewNop().Sugar()
+	}
+	reloader := &CertReloader{
+		crtFile: crtFile,
+		keyFile: keyFile,
+		logger:  logger,
+	}
+	if _, err := reloader.Reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+//GetCertificate returns the current key pair (to be used as GetCertificate function of a TLS configuration)
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+//Reload loads the key pair if the content of the certificate or key file changed and returns true if
+//the key pair was replaced. An invalid key pair is rejected and the current key pair is kept.
+func (r *CertReloader) Reload() (bool, error) {
+	crtPEM, err := ioutil.ReadFile(r.crtFile)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read SSL certificate file '%s'", r.crtFile)
+	}
+	keyPEM, err := ioutil.ReadFile(r.keyFile)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read SSL key file '%s'", r.keyFile)
+	}
+
+	r.mu.RLock()
+	unchanged := bytes.Equal(crtPEM, r.crtPEM) && bytes.Equal(keyPEM, r.keyPEM)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.X509KeyPair(crtPEM, keyPEM)
+	if err != nil {
+		return false, errors.Wrap(err,
+			fmt.Sprintf("Provided TLS certificate '%s' and key '%s' is invalid", r.crtFile, r.keyFile))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.crtPEM = crtPEM
+	r.keyPEM = keyPEM
+	return true, nil
+}
+
+//Watch checks the certificate and key file for changes in the given interval until the context gets closed
+func (r *CertReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.Reload()
+			if err != nil {
+				//certificate and key file are often not updated at the same time: retry with the next check
+				r.logger.Warnf("Failed to reload SSL key pair (keeping the current key pair): %s", err)
+				continue
+			}
+			if reloaded {
+				r.logger.Infof("Reloaded SSL key pair from certificate file '%s' and key file '%s'",
+					r.crtFile, r.keyFile)
+			}
+		}
+	}
+}
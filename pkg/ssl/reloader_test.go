@@ -0,0 +1,73 @@
+package ssl
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertReloader(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	crtFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	writeKeyPair := func(t *testing.T, cert tls.Certificate) {
+		keyDER, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(crtFile,
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0600))
+		require.NoError(t, ioutil.WriteFile(keyFile,
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	}
+	servedCert := func(t *testing.T, reloader *CertReloader) []byte {
+		cert, err := reloader.GetCertificate(nil)
+		require.NoError(t, err)
+		return cert.Certificate[0]
+	}
+
+	initialCert := ca.issue(t, "mothership")
+	writeKeyPair(t, initialCert)
+	reloader, err := NewCertReloader(crtFile, keyFile, nil)
+	require.NoError(t, err)
+	require.Equal(t, initialCert.Certificate[0], servedCert(t, reloader))
+
+	t.Run("Unchanged files", func(t *testing.T) {
+		reloaded, err := reloader.Reload()
+		require.NoError(t, err)
+		require.False(t, reloaded)
+	})
+
+	t.Run("Invalid key pair is rejected", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(crtFile, []byte("invalid"), 0600))
+		reloaded, err := reloader.Reload()
+		require.Error(t, err)
+		require.False(t, reloaded)
+		require.Equal(t, initialCert.Certificate[0], servedCert(t, reloader))
+	})
+
+	t.Run("Renewed key pair is watched", func(t *testing.T) {
+		renewedCert := ca.issue(t, "mothership")
+		writeKeyPair(t, renewedCert)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go reloader.Watch(ctx, 10*time.Millisecond)
+		require.Eventually(t, func() bool {
+			cert, err := reloader.GetCertificate(nil)
+			return err == nil && string(cert.Certificate[0]) == string(renewedCert.Certificate[0])
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Missing files", func(t *testing.T) {
+		_, err := NewCertReloader(filepath.Join(dir, "missing.crt"), keyFile, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,118 @@
+package ssl
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const DefaultMinVersion = "1.2"
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+//ProtocolConfig restricts the TLS versions and cipher suites a server negotiates with its clients
+type ProtocolConfig struct {
+	//MinVersion is the min. accepted TLS version ('1.2' or '1.3', default is '1.2')
+	MinVersion string
+	//CipherSuites are the names of the accepted TLS 1.2 cipher suites (default are the secure suites of Go):
+	//the cipher suites of TLS 1.3 aren't configurable
+	CipherSuites []string
+}
+
+func (p ProtocolConfig) Validate() error {
+	_, err := p.Apply(nil)
+	return err
+}
+
+//Apply returns a copy of the TLS configuration (or a new configuration if it's nil) which is restricted
+//to the TLS version and cipher suites of the protocol configuration
+func (p ProtocolConfig) Apply(tlsConfig *tls.Config) (*tls.Config, error) {
+	minVersion, err := p.minVersion()
+	if err != nil {
+		return nil, err
+	}
+	cipherSuites, err := p.cipherSuites()
+	if err != nil {
+		return nil, err
+	}
+	if minVersion == tls.VersionTLS13 && len(cipherSuites) > 0 {
+		return nil, fmt.Errorf("cipher suites can only be restricted if TLS 1.2 is accepted " +
+			"(TLS 1.3 cipher suites aren't configurable)")
+	}
+
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{} //nolint:gosec //min. version is set below
+	} else {
+		tlsConfig = tlsConfig.Clone()
+	}
+	tlsConfig.MinVersion = minVersion
+	tlsConfig.CipherSuites = cipherSuites
+	return tlsConfig, nil
+}
+
+func (p ProtocolConfig) minVersion() (uint16, error) {
+	version := p.MinVersion
+	if version == "" {
+		version = DefaultMinVersion
+	}
+	minVersion, ok := tlsVersions[version]
+	if !ok {
+		return 0, fmt.Errorf("TLS version '%s' is not supported: use '1.2' or '1.3'", version)
+	}
+	return minVersion, nil
+}
+
+func (p ProtocolConfig) cipherSuites() ([]uint16, error) {
+	if len(p.CipherSuites) == 0 {
+		return nil, nil
+	}
+	supported := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		if supportsTLS12(suite) {
+			supported[suite.Name] = suite.ID
+		}
+	}
+	insecure := make(map[string]bool)
+	for _, suite := range tls.InsecureCipherSuites() {
+		insecure[suite.Name] = true
+	}
+
+	var cipherSuites []uint16
+	for _, name := range p.CipherSuites {
+		if insecure[name] {
+			return nil, fmt.Errorf("cipher suite '%s' is insecure", name)
+		}
+		id, ok := supported[name]
+		if !ok {
+			return nil, fmt.Errorf("cipher suite '%s' is not supported (supported are: '%s')",
+				name, strings.Join(SupportedCipherSuites(), "', '"))
+		}
+		cipherSuites = append(cipherSuites, id)
+	}
+	return cipherSuites, nil
+}
+
+//SupportedCipherSuites returns the names of the secure TLS 1.2 cipher suites which can be accepted
+func SupportedCipherSuites() []string {
+	var names []string
+	for _, suite := range tls.CipherSuites() {
+		if supportsTLS12(suite) {
+			names = append(names, suite.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func supportsTLS12(suite *tls.CipherSuite) bool {
+	for _, version := range suite.SupportedVersions {
+		if version == tls.VersionTLS12 {
+			return true
+		}
+	}
+	return false
+}
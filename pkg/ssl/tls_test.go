@@ -0,0 +1,46 @@
+package ssl
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtocolConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		tlsConfig, err := ProtocolConfig{}.Apply(nil)
+		require.NoError(t, err)
+		require.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+		require.Empty(t, tlsConfig.CipherSuites)
+	})
+
+	t.Run("Restrict version and cipher suites", func(t *testing.T) {
+		clientAuth := &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert} //nolint:gosec //test config
+		tlsConfig, err := ProtocolConfig{
+			MinVersion:   "1.2",
+			CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+		}.Apply(clientAuth)
+		require.NoError(t, err)
+		require.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384}, tlsConfig.CipherSuites)
+		require.Equal(t, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+		require.Zero(t, clientAuth.MinVersion, "passed configuration is not modified")
+
+		tlsConfig, err = ProtocolConfig{MinVersion: "1.3"}.Apply(nil)
+		require.NoError(t, err)
+		require.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+	})
+
+	t.Run("Invalid configurations", func(t *testing.T) {
+		for _, cfg := range []ProtocolConfig{
+			{MinVersion: "1.1"},
+			{MinVersion: "TLS12"},
+			{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},           //insecure
+			{CipherSuites: []string{"TLS_AES_128_GCM_SHA256"}},             //TLS 1.3 only
+			{CipherSuites: []string{"TLS_DOES_NOT_EXIST"}},                 //unknown
+			{MinVersion: "1.3", CipherSuites: SupportedCipherSuites()[:1]}, //not configurable for TLS 1.3
+		} {
+			require.Error(t, cfg.Validate(), cfg)
+		}
+	})
+}
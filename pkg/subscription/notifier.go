@@ -0,0 +1,404 @@
+package subscription
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	//SignatureHeader contains the HMAC-SHA256 (hex encoded, prefixed with 'sha256=') of the timestamp header,
+	//a '.' and the body of the delivery, signed with the secret of the subscription
+	SignatureHeader = "X-Reconciler-Signature"
+	//TimestampHeader contains the unix timestamp (in seconds) of the delivery: receivers should reject outdated
+	//deliveries to prevent replays
+	TimestampHeader = "X-Reconciler-Timestamp"
+	//DeliveryHeader contains a unique ID of the delivery (it's kept for retries)
+	DeliveryHeader = "X-Reconciler-Delivery"
+	//EventHeader contains the kind of the delivered event
+	EventHeader = "X-Reconciler-Event"
+
+	EventClusterStatus = "clusterStatus"
+	EventAnomaly       = "anomaly"
+
+	defaultTimeout    = 10 * time.Second
+	defaultMaxRetries = 3
+	defaultQueueSize  = 1000
+	defaultWorkers    = 5
+	defaultRetryDelay = time.Second
+	defaultCacheTTL   = 30 * time.Second
+)
+
+//ForbiddenTargetError is returned if a webhook resolves to an address which isn't reachable for deliveries
+//(private, loopback and link-local addresses, see SubscriptionsConfig.AllowPrivateTargets)
+type ForbiddenTargetError struct {
+	Address string
+}
+
+func (e *ForbiddenTargetError) Error() string {
+	return fmt.Sprintf("webhook address '%s' is not allowed: private, loopback and link-local addresses "+
+		"are rejected", e.Address)
+}
+
+func IsForbiddenTargetError(err error) bool {
+	var targetErr *ForbiddenTargetError
+	return errors.As(err, &targetErr)
+}
+
+//lister returns the subscriptions which receive the status changes
+type lister interface {
+	GetAll() ([]*model.SubscriptionEntity, error)
+}
+
+//Notifier delivers the status changes of clusters and the detected anomalies to the webhooks of the subscriptions.
+//Events are queued and delivered asynchronously: the inventory isn't blocked by slow or unavailable webhooks.
+//
+//The queue is kept in memory of the replica which processed the event: each replica delivers only its own events,
+//events are dropped if the queue is full (see Dropped) and queued events are lost if the replica stops. Subscribers
+//have to treat the deliveries as hints and reconcile with the cluster status API.
+type Notifier struct {
+	subscriptions lister
+	client        *http.Client
+	logger        *zap.SugaredLogger
+	maxRetries    int
+	retryDelay    time.Duration
+	workers       int
+	queue         chan *keb.ClusterStatusEvent
+	anomalies     chan *keb.AnomalyEvent
+	now           func() time.Time
+
+	cacheTTL  time.Duration
+	cacheMu   sync.Mutex
+	cache     []*model.SubscriptionEntity
+	cacheTime time.Time
+
+	droppedMu sync.Mutex
+	dropped   map[string]int64
+}
+
+func NewNotifier(cfg config.SubscriptionsConfig, subscriptions lister, logger *zap.SugaredLogger) (*Notifier, error) {
+	timeout := defaultTimeout
+	if cfg.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("timeout '%s' of subscription deliveries is not a positive duration", cfg.Timeout)
+		}
+	}
+	cacheTTL := defaultCacheTTL
+	if cfg.CacheTTL != "" {
+		var err error
+		if cacheTTL, err = time.ParseDuration(cfg.CacheTTL); err != nil || cacheTTL <= 0 {
+			return nil, fmt.Errorf("cache TTL '%s' of subscriptions is not a positive duration", cfg.CacheTTL)
+		}
+	}
+	if cfg.MaxRetries < 0 || cfg.QueueSize < 0 || cfg.Workers < 0 {
+		return nil, fmt.Errorf("retries, queue size and workers of subscription deliveries cannot be < 0")
+	}
+	notifier := &Notifier{
+		subscriptions: subscriptions,
+		client:        newClient(timeout, cfg.AllowPrivateTargets),
+		logger:        logger,
+		maxRetries:    cfg.MaxRetries,
+		retryDelay:    defaultRetryDelay,
+		workers:       cfg.Workers,
+		now:           time.Now,
+		cacheTTL:      cacheTTL,
+		dropped:       make(map[string]int64),
+	}
+	if notifier.maxRetries == 0 {
+		notifier.maxRetries = defaultMaxRetries
+	}
+	if notifier.workers == 0 {
+		notifier.workers = defaultWorkers
+	}
+	queueSize := cfg.QueueSize
+	if queueSize == 0 {
+		queueSize = defaultQueueSize
+	}
+	notifier.queue = make(chan *keb.ClusterStatusEvent, queueSize)
+	notifier.anomalies = make(chan *keb.AnomalyEvent, queueSize)
+	return notifier, nil
+}
+
+//newClient returns the HTTP client of the deliveries: the addresses of the webhooks are verified when the
+//connection is established (after the DNS resolution) and redirects aren't followed, otherwise a webhook could
+//point the mothership to internal services
+func newClient(timeout time.Duration, allowPrivateTargets bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateTargets {
+		dialer.Control = checkTarget
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil //a proxy would connect to the webhook on behalf of the mothership
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+//checkTarget rejects connections to private, loopback and link-local addresses
+func checkTarget(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return &ForbiddenTargetError{Address: address}
+	}
+	return nil
+}
+
+//Dropped returns the amount of events per kind (EventClusterStatus or EventAnomaly) which were dropped because
+//the queue was full
+func (n *Notifier) Dropped() map[string]int64 {
+	n.droppedMu.Lock()
+	defer n.droppedMu.Unlock()
+	dropped := make(map[string]int64, len(n.dropped))
+	for event, count := range n.dropped {
+		dropped[event] = count
+	}
+	return dropped
+}
+
+func (n *Notifier) drop(event string) {
+	n.droppedMu.Lock()
+	defer n.droppedMu.Unlock()
+	n.dropped[event]++
+}
+
+//InvalidateSubscriptions enforces a reload of the subscriptions before the next delivery: it's called after
+//subscriptions were created or deleted (other replicas pick them up after the cache TTL)
+func (n *Notifier) InvalidateSubscriptions() {
+	n.cacheMu.Lock()
+	defer n.cacheMu.Unlock()
+	n.cache = nil
+}
+
+//getSubscriptions returns the cached subscriptions and reloads them if the cache TTL expired. If the reload fails,
+//the cached subscriptions are used until the next reload succeeds.
+func (n *Notifier) getSubscriptions() ([]*model.SubscriptionEntity, error) {
+	n.cacheMu.Lock()
+	defer n.cacheMu.Unlock()
+	if n.cache != nil && n.now().Sub(n.cacheTime) < n.cacheTTL {
+		return n.cache, nil
+	}
+	subscriptions, err := n.subscriptions.GetAll()
+	if err != nil {
+		if n.cache != nil {
+			n.logger.Warnf("Subscription notifier failed to reload subscriptions, using cached subscriptions: %s", err)
+			return n.cache, nil
+		}
+		return nil, err
+	}
+	if subscriptions == nil {
+		subscriptions = []*model.SubscriptionEntity{}
+	}
+	n.cache = subscriptions
+	n.cacheTime = n.now()
+	return subscriptions, nil
+}
+
+//OnClusterStatusUpdate queues the new status of the cluster for delivery. The status change is dropped if the
+//queue is full.
+func (n *Notifier) OnClusterStatusUpdate(state *cluster.State) {
+	if state == nil || state.Status == nil {
+		return
+	}
+	status, err := state.Status.GetKEBClusterStatus()
+	if err != nil {
+		n.logger.Warnf("Subscription notifier cannot deliver status '%s' of cluster '%s': %s",
+			state.Status.Status, state.Status.RuntimeID, err)
+		return
+	}
+	event := &keb.ClusterStatusEvent{
+		RuntimeID:      state.Status.RuntimeID,
+		ClusterVersion: state.Status.ClusterVersion,
+		ConfigVersion:  state.Status.ConfigVersion,
+		Status:         status,
+		Created:        state.Status.Created,
+	}
+	select {
+	case n.queue <- event:
+	default:
+		n.drop(EventClusterStatus)
+		n.logger.Warnf("Subscription notifier dropped status '%s' of cluster '%s': delivery queue is full",
+			event.Status, event.RuntimeID)
+	}
+}
+
+//OnAnomaly queues the anomaly for delivery. The anomaly is dropped if the queue is full.
+func (n *Notifier) OnAnomaly(event *keb.AnomalyEvent) {
+	select {
+	case n.anomalies <- event:
+	default:
+		n.drop(EventAnomaly)
+		n.logger.Warnf("Subscription notifier dropped anomaly '%s' of component '%s': delivery queue is full",
+			event.Type, event.Component)
+	}
+}
+
+//Run delivers the queued events until the context gets closed
+func (n *Notifier) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < n.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event := <-n.queue:
+					n.notify(ctx, event)
+				case event := <-n.anomalies:
+					n.notifyAnomaly(ctx, event)
+				}
+			}
+		}()
+	}
+	n.logger.Infof("Subscription notifier started with %d workers", n.workers)
+	wg.Wait()
+	n.logger.Info("Subscription notifier stopped (context got closed)")
+}
+
+func (n *Notifier) notify(ctx context.Context, event *keb.ClusterStatusEvent) {
+	subscriptions, err := n.getSubscriptions()
+	if err != nil {
+		n.logger.Errorf("Subscription notifier failed to retrieve subscriptions: status '%s' of cluster '%s' "+
+			"is not delivered: %s", event.Status, event.RuntimeID, err)
+		return
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		n.logger.Errorf("Subscription notifier failed to encode status change of cluster '%s': %s", event.RuntimeID, err)
+		return
+	}
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event.RuntimeID) {
+			continue
+		}
+		if err := n.deliver(ctx, subscription, EventClusterStatus, body); err != nil {
+			n.logger.Warnf("Subscription notifier failed to deliver status '%s' of cluster '%s' to subscription '%s': %s",
+				event.Status, event.RuntimeID, subscription.ID, err)
+		}
+	}
+}
+
+//notifyAnomaly delivers the anomaly to the subscriptions of all clusters and to the subscriptions of the affected
+//clusters
+func (n *Notifier) notifyAnomaly(ctx context.Context, event *keb.AnomalyEvent) {
+	subscriptions, err := n.getSubscriptions()
+	if err != nil {
+		n.logger.Errorf("Subscription notifier failed to retrieve subscriptions: anomaly '%s' of component '%s' "+
+			"is not delivered: %s", event.Type, event.Component, err)
+		return
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		n.logger.Errorf("Subscription notifier failed to encode anomaly '%s' of component '%s': %s",
+			event.Type, event.Component, err)
+		return
+	}
+	for _, subscription := range subscriptions {
+		if !affects(subscription, event) {
+			continue
+		}
+		if err := n.deliver(ctx, subscription, EventAnomaly, body); err != nil {
+			n.logger.Warnf("Subscription notifier failed to deliver anomaly '%s' of component '%s' to subscription '%s': %s",
+				event.Type, event.Component, subscription.ID, err)
+		}
+	}
+}
+
+func affects(subscription *model.SubscriptionEntity, event *keb.AnomalyEvent) bool {
+	if subscription.RuntimeID == "" {
+		return true
+	}
+	for _, affected := range event.Clusters {
+		if subscription.Matches(affected.RuntimeID) {
+			return true
+		}
+	}
+	return false
+}
+
+//deliver sends the event to the webhook and retries network errors and temporary server errors
+func (n *Notifier) deliver(ctx context.Context, subscription *model.SubscriptionEntity, event string, body []byte) error {
+	deliveryID := uuid.NewString()
+	for attempt := 0; ; attempt++ {
+		retryable, err := n.send(ctx, subscription, event, deliveryID, body)
+		if err == nil || !retryable || attempt >= n.maxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(n.retryDelay << uint(attempt)):
+		}
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, subscription *model.SubscriptionEntity, event, deliveryID string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	timestamp := strconv.FormatInt(n.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		if err := resp.Body.Close(); err != nil {
+			n.logger.Warnf("Subscription notifier failed to close response body of '%s': %s", subscription.URL, err)
+		}
+	}()
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+	return retryable, fmt.Errorf("webhook responded with HTTP code %d", resp.StatusCode)
+}
+
+//Sign returns the value of the signature header: receivers verify a delivery by comparing the signature
+//header with the signature of the received timestamp header and body
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,253 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+type testLister struct {
+	subscriptions []*model.SubscriptionEntity
+	err           error
+	calls         int
+}
+
+func (l *testLister) GetAll() ([]*model.SubscriptionEntity, error) {
+	l.calls++
+	return l.subscriptions, l.err
+}
+
+type testWebhook struct {
+	server    *httptest.Server
+	mu        sync.Mutex
+	requests  []*http.Request
+	bodies    [][]byte
+	failFirst int
+}
+
+func newTestWebhook(t *testing.T, failFirst int) *testWebhook {
+	webhook := &testWebhook{failFirst: failFirst}
+	webhook.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		webhook.mu.Lock()
+		defer webhook.mu.Unlock()
+		webhook.requests = append(webhook.requests, r)
+		webhook.bodies = append(webhook.bodies, body)
+		if len(webhook.requests) <= webhook.failFirst {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(webhook.server.Close)
+	return webhook
+}
+
+func (w *testWebhook) received() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.requests)
+}
+
+func newTestState(runtimeID string, status model.Status) *cluster.State {
+	return &cluster.State{
+		Status: &model.ClusterStatusEntity{
+			RuntimeID:      runtimeID,
+			ClusterVersion: 1,
+			ConfigVersion:  2,
+			Status:         status,
+			Created:        time.Unix(1650000000, 0).UTC(),
+		},
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	secret := "0123456789abcdef"
+	//the test webhooks are listening on the loopback interface
+	localCfg := config.SubscriptionsConfig{AllowPrivateTargets: true}
+
+	t.Run("Deliver signed status changes", func(t *testing.T) {
+		all := newTestWebhook(t, 0)
+		single := newTestWebhook(t, 0)
+		lister := &testLister{subscriptions: []*model.SubscriptionEntity{
+			{ID: "1", URL: all.server.URL, Secret: secret},
+			{ID: "2", URL: single.server.URL, Secret: secret, RuntimeID: "xyz"},
+		}}
+		notifier, err := NewNotifier(localCfg, lister, logger.NewLogger(true))
+		require.NoError(t, err)
+		notifier.now = func() time.Time {
+			return time.Unix(1650000042, 0)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go notifier.Run(ctx)
+
+		notifier.OnClusterStatusUpdate(newTestState("abc", model.ClusterStatusReconciling))
+		notifier.OnClusterStatusUpdate(newTestState("xyz", model.ClusterStatusReady))
+		require.Eventually(t, func() bool {
+			return all.received() == 2 && single.received() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		req := single.requests[0]
+		require.Equal(t, "1650000042", req.Header.Get(TimestampHeader))
+		require.Equal(t, EventClusterStatus, req.Header.Get(EventHeader))
+		require.NotEmpty(t, req.Header.Get(DeliveryHeader))
+		require.Equal(t, Sign(secret, "1650000042", single.bodies[0]), req.Header.Get(SignatureHeader))
+		require.NotEqual(t, Sign("other-secret-1234", "1650000042", single.bodies[0]), req.Header.Get(SignatureHeader))
+
+		event := &keb.ClusterStatusEvent{}
+		require.NoError(t, json.Unmarshal(single.bodies[0], event))
+		require.Equal(t, "xyz", event.RuntimeID)
+		require.Equal(t, keb.StatusReady, event.Status)
+		require.Equal(t, int64(2), event.ConfigVersion)
+	})
+
+	t.Run("Deliver anomalies to subscriptions of all clusters and of affected clusters", func(t *testing.T) {
+		all := newTestWebhook(t, 0)
+		affected := newTestWebhook(t, 0)
+		unaffected := newTestWebhook(t, 0)
+		notifier, err := NewNotifier(localCfg, &testLister{subscriptions: []*model.SubscriptionEntity{
+			{ID: "1", URL: all.server.URL, Secret: secret},
+			{ID: "2", URL: affected.server.URL, Secret: secret, RuntimeID: "abc"},
+			{ID: "3", URL: unaffected.server.URL, Secret: secret, RuntimeID: "xyz"},
+		}}, logger.NewLogger(true))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go notifier.Run(ctx)
+
+		notifier.OnAnomaly(&keb.AnomalyEvent{
+			Type:      keb.AnomalyEventTypeFailureSpike,
+			Component: "istio",
+			Clusters:  []keb.AnomalyCluster{{RuntimeID: "abc", Link: "/v1/clusters/abc/explain"}},
+		})
+		require.Eventually(t, func() bool {
+			return all.received() == 1 && affected.received() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, 0, unaffected.received())
+
+		req := affected.requests[0]
+		require.Equal(t, EventAnomaly, req.Header.Get(EventHeader))
+		require.Equal(t, Sign(secret, req.Header.Get(TimestampHeader), affected.bodies[0]), req.Header.Get(SignatureHeader))
+		event := &keb.AnomalyEvent{}
+		require.NoError(t, json.Unmarshal(affected.bodies[0], event))
+		require.Equal(t, "istio", event.Component)
+		require.Equal(t, "abc", event.Clusters[0].RuntimeID)
+	})
+
+	t.Run("Retry temporary failures", func(t *testing.T) {
+		webhook := newTestWebhook(t, 2)
+		notifier, err := NewNotifier(config.SubscriptionsConfig{MaxRetries: 2, AllowPrivateTargets: true}, &testLister{
+			subscriptions: []*model.SubscriptionEntity{{ID: "1", URL: webhook.server.URL, Secret: secret}},
+		}, logger.NewLogger(true))
+		require.NoError(t, err)
+		notifier.retryDelay = time.Millisecond
+
+		event := &keb.ClusterStatusEvent{RuntimeID: "abc", Status: keb.StatusReady}
+		notifier.notify(context.Background(), event)
+		require.Equal(t, 3, webhook.received())
+		require.Equal(t, webhook.requests[0].Header.Get(DeliveryHeader), webhook.requests[2].Header.Get(DeliveryHeader),
+			"delivery ID is kept for retries")
+
+		webhook = newTestWebhook(t, 3)
+		notifier.subscriptions = &testLister{
+			subscriptions: []*model.SubscriptionEntity{{ID: "1", URL: webhook.server.URL, Secret: secret}},
+		}
+		notifier.InvalidateSubscriptions()
+		notifier.notify(context.Background(), event)
+		require.Equal(t, 3, webhook.received(), "retries are limited")
+	})
+
+	t.Run("Drop status changes if queue is full", func(t *testing.T) {
+		notifier, err := NewNotifier(config.SubscriptionsConfig{QueueSize: 1}, &testLister{}, logger.NewLogger(true))
+		require.NoError(t, err)
+		notifier.OnClusterStatusUpdate(newTestState("abc", model.ClusterStatusReconciling))
+		notifier.OnClusterStatusUpdate(newTestState("abc", model.ClusterStatusReady))
+		require.Len(t, notifier.queue, 1)
+		require.Equal(t, keb.StatusReconciling, (<-notifier.queue).Status)
+		require.Equal(t, map[string]int64{EventClusterStatus: 1}, notifier.Dropped())
+	})
+
+	t.Run("Reject private targets and redirects", func(t *testing.T) {
+		webhook := newTestWebhook(t, 0)
+		notifier, err := NewNotifier(config.SubscriptionsConfig{}, &testLister{}, logger.NewLogger(true))
+		require.NoError(t, err)
+		_, err = notifier.send(context.Background(), &model.SubscriptionEntity{ID: "1", URL: webhook.server.URL, Secret: secret},
+			EventClusterStatus, "1", []byte("{}"))
+		require.True(t, IsForbiddenTargetError(err))
+		require.Equal(t, 0, webhook.received())
+
+		for _, address := range []string{"10.0.0.1:80", "169.254.169.254:80", "[::1]:443", "0.0.0.0:80"} {
+			require.Error(t, checkTarget("tcp", address, nil), address)
+		}
+		require.NoError(t, checkTarget("tcp", "203.0.113.10:443", nil))
+
+		redirect := httptest.NewServer(http.RedirectHandler(webhook.server.URL, http.StatusTemporaryRedirect))
+		defer redirect.Close()
+		notifier, err = NewNotifier(localCfg, &testLister{}, logger.NewLogger(true))
+		require.NoError(t, err)
+		_, err = notifier.send(context.Background(), &model.SubscriptionEntity{ID: "1", URL: redirect.URL, Secret: secret},
+			EventClusterStatus, "1", []byte("{}"))
+		require.Error(t, err)
+		require.Equal(t, 0, webhook.received(), "redirects are not followed")
+	})
+
+	t.Run("Cache subscriptions", func(t *testing.T) {
+		webhook := newTestWebhook(t, 0)
+		lister := &testLister{subscriptions: []*model.SubscriptionEntity{{ID: "1", URL: webhook.server.URL, Secret: secret}}}
+		notifier, err := NewNotifier(config.SubscriptionsConfig{CacheTTL: "1m", AllowPrivateTargets: true}, lister,
+			logger.NewLogger(true))
+		require.NoError(t, err)
+		now := time.Unix(1650000000, 0)
+		notifier.now = func() time.Time {
+			return now
+		}
+
+		event := &keb.ClusterStatusEvent{RuntimeID: "abc", Status: keb.StatusReady}
+		notifier.notify(context.Background(), event)
+		notifier.notify(context.Background(), event)
+		require.Equal(t, 1, lister.calls)
+		require.Equal(t, 2, webhook.received())
+
+		now = now.Add(2 * time.Minute)
+		lister.err = errors.New("database unavailable")
+		notifier.notify(context.Background(), event)
+		require.Equal(t, 2, lister.calls)
+		require.Equal(t, 3, webhook.received(), "cached subscriptions are used if the reload fails")
+
+		lister.err = nil
+		notifier.InvalidateSubscriptions()
+		notifier.notify(context.Background(), event)
+		require.Equal(t, 3, lister.calls)
+	})
+
+	t.Run("Configuration", func(t *testing.T) {
+		notifier, err := NewNotifier(config.SubscriptionsConfig{}, &testLister{}, logger.NewLogger(true))
+		require.NoError(t, err)
+		require.Equal(t, defaultWorkers, notifier.workers)
+		require.Equal(t, defaultMaxRetries, notifier.maxRetries)
+		require.Equal(t, defaultQueueSize, cap(notifier.queue))
+		require.Equal(t, defaultTimeout, notifier.client.Timeout)
+
+		_, err = NewNotifier(config.SubscriptionsConfig{Timeout: "abc"}, &testLister{}, logger.NewLogger(true))
+		require.Error(t, err)
+		_, err = NewNotifier(config.SubscriptionsConfig{Workers: -1}, &testLister{}, logger.NewLogger(true))
+		require.Error(t, err)
+		_, err = NewNotifier(config.SubscriptionsConfig{CacheTTL: "0s"}, &testLister{}, logger.NewLogger(true))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,125 @@
+package subscription
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
+)
+
+const minSecretLength = 16
+
+//InvalidSubscriptionError is returned if the URL or the secret of a subscription is not accepted
+type InvalidSubscriptionError struct {
+	Reason string
+}
+
+func (e *InvalidSubscriptionError) Error() string {
+	return fmt.Sprintf("subscription is invalid: %s", e.Reason)
+}
+
+func IsInvalidSubscriptionError(err error) bool {
+	var subscriptionErr *InvalidSubscriptionError
+	return errors.As(err, &subscriptionErr)
+}
+
+//Repository stores the webhooks which receive the status changes of clusters
+type Repository struct {
+	*repository.Repository
+}
+
+func NewRepository(conn db.Connection, debug bool) (*Repository, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{repo}, nil
+}
+
+//Create registers a webhook: the status changes of the cluster (or of all clusters if the runtime ID is empty)
+//are sent to the URL and signed with the secret
+func (sr *Repository) Create(webhookURL, secret, runtimeID string) (*model.SubscriptionEntity, error) {
+	if err := validateURL(webhookURL); err != nil {
+		return nil, err
+	}
+	if len(secret) < minSecretLength {
+		return nil, &InvalidSubscriptionError{
+			Reason: fmt.Sprintf("secret has to contain at least %d characters", minSecretLength),
+		}
+	}
+	entity := &model.SubscriptionEntity{
+		ID:        uuid.NewString(),
+		URL:       webhookURL,
+		Secret:    secret,
+		RuntimeID: runtimeID,
+	}
+	q, err := db.NewQuery(sr.Conn, entity, sr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.Insert().Exec(); err != nil {
+		sr.Logger.Errorf("SubscriptionRepository failed to create subscription for URL '%s': %s", webhookURL, err)
+		return nil, err
+	}
+	return sr.Get(entity.ID)
+}
+
+//Get returns a subscription
+func (sr *Repository) Get(id string) (*model.SubscriptionEntity, error) {
+	q, err := db.NewQuery(sr.Conn, &model.SubscriptionEntity{}, sr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{"ID": id}
+	entity, err := q.Select().
+		Where(whereCond).
+		GetOne()
+	if err != nil {
+		return nil, sr.MapError(err, &model.SubscriptionEntity{}, whereCond)
+	}
+	return entity.(*model.SubscriptionEntity), nil
+}
+
+//GetAll returns all subscriptions ordered by their creation
+func (sr *Repository) GetAll() ([]*model.SubscriptionEntity, error) {
+	q, err := db.NewQuery(sr.Conn, &model.SubscriptionEntity{}, sr.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		OrderBy(map[string]string{"Created": "ASC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.SubscriptionEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.SubscriptionEntity))
+	}
+	return result, nil
+}
+
+//Delete removes a subscription: no status changes are sent to its URL anymore
+func (sr *Repository) Delete(id string) error {
+	if _, err := sr.Get(id); err != nil {
+		return err
+	}
+	q, err := db.NewQuery(sr.Conn, &model.SubscriptionEntity{}, sr.Logger)
+	if err != nil {
+		return err
+	}
+	_, err = q.Delete().Where(map[string]interface{}{"ID": id}).Exec()
+	return err
+}
+
+func validateURL(webhookURL string) error {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &InvalidSubscriptionError{Reason: fmt.Sprintf("URL '%s' is not an absolute HTTP(S) URL", webhookURL)}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package subscription
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repo, err := NewRepository(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+	secret := "0123456789abcdef"
+
+	t.Run("Reject invalid subscriptions", func(t *testing.T) {
+		for _, webhookURL := range []string{"", "/relative", "ftp://host/path", "http://"} {
+			_, err := repo.Create(webhookURL, secret, "")
+			require.True(t, IsInvalidSubscriptionError(err), webhookURL)
+		}
+		_, err := repo.Create("https://host/path", "short", "")
+		require.True(t, IsInvalidSubscriptionError(err))
+	})
+
+	t.Run("Create, get and delete subscriptions", func(t *testing.T) {
+		all, err := repo.Create("https://host/all", secret, "")
+		require.NoError(t, err)
+		single, err := repo.Create("https://host/single", secret, "abc")
+		require.NoError(t, err)
+		require.True(t, all.Matches("xyz"))
+		require.False(t, single.Matches("xyz"))
+
+		got, err := repo.Get(single.ID)
+		require.NoError(t, err)
+		require.True(t, single.Equal(got))
+		require.Equal(t, secret, got.Secret, "secret is decrypted")
+
+		subscriptions, err := repo.GetAll()
+		require.NoError(t, err)
+		var ids []string
+		for _, subscription := range subscriptions {
+			ids = append(ids, subscription.ID)
+		}
+		require.Contains(t, ids, all.ID)
+		require.Contains(t, ids, single.ID)
+
+		require.NoError(t, repo.Delete(all.ID))
+		require.NoError(t, repo.Delete(single.ID))
+		_, err = repo.Get(single.ID)
+		require.True(t, repository.IsNotFoundError(err))
+		require.True(t, repository.IsNotFoundError(repo.Delete(single.ID)))
+	})
+}
@@ -0,0 +1,128 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/policy"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const defaultTimeout = 10 * time.Second
+
+type FailurePolicy string
+
+const (
+	//FailurePolicyFail rejects the request if the webhook can't be called or returns an invalid response
+	FailurePolicyFail FailurePolicy = "Fail"
+	//FailurePolicyIgnore accepts the request if the webhook can't be called or returns an invalid response
+	FailurePolicyIgnore FailurePolicy = "Ignore"
+)
+
+//RejectionError indicates that the webhook rejected a cluster configuration
+type RejectionError struct {
+	RuntimeID string
+	Reason    string
+}
+
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("configuration of cluster '%s' was rejected by validation webhook: %s", e.RuntimeID, e.Reason)
+}
+
+func IsRejectionError(err error) bool {
+	var rejectionErr *RejectionError
+	return errors.As(err, &rejectionErr)
+}
+
+//Request is sent to the webhook (the kubeconfig of the cluster is never passed to the webhook)
+type Request struct {
+	ContractVersion int64       `json:"contractVersion"`
+	Cluster         keb.Cluster `json:"cluster"`
+}
+
+//Response is expected from the webhook
+type Response struct {
+	Allowed  bool     `json:"allowed"`
+	Reason   string   `json:"reason,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+//Webhook calls an external validation webhook for the cluster configurations sent by KEB
+type Webhook struct {
+	endpoint      *policy.Endpoint
+	failurePolicy FailurePolicy
+	logger        *zap.SugaredLogger
+}
+
+//NewWebhook returns the validation webhook or nil if no webhook is configured
+func NewWebhook(cfg config.ValidationWebhookConfig, logger *zap.SugaredLogger) (*Webhook, error) {
+	if cfg.URL == "" {
+		return nil, nil
+	}
+	endpoint, err := policy.NewEndpoint("validation webhook", cfg.URL, cfg.Timeout, defaultTimeout, logger)
+	if err != nil {
+		return nil, err
+	}
+	failurePolicy := FailurePolicyFail
+	if cfg.FailurePolicy != "" {
+		failurePolicy = FailurePolicy(cfg.FailurePolicy)
+	}
+	if failurePolicy != FailurePolicyFail && failurePolicy != FailurePolicyIgnore {
+		return nil, fmt.Errorf("failure policy '%s' of validation webhook is unknown: supported policies are '%s' and '%s'",
+			failurePolicy, FailurePolicyFail, FailurePolicyIgnore)
+	}
+	return &Webhook{
+		endpoint:      endpoint,
+		failurePolicy: failurePolicy,
+		logger:        logger,
+	}, nil
+}
+
+//Validate calls the webhook with the cluster configuration and returns its warnings. A configuration which isn't
+//allowed by the webhook is rejected by returning a RejectionError. All configurations are accepted without
+//warnings if no webhook is configured.
+func (wh *Webhook) Validate(ctx context.Context, contractVersion int64, cluster *keb.Cluster) ([]string, error) {
+	if wh == nil {
+		return nil, nil
+	}
+	resp, err := wh.call(ctx, contractVersion, cluster)
+	if err != nil {
+		if wh.failurePolicy == FailurePolicyIgnore {
+			wh.logger.Warnf("Validation webhook failed for configuration of cluster '%s' (ignored because of "+
+				"failure policy '%s'): %s", cluster.RuntimeID, wh.failurePolicy, err)
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "validation webhook failed")
+	}
+	if !resp.Allowed {
+		reason := resp.Reason
+		if reason == "" {
+			reason = "no reason provided"
+		}
+		wh.logger.Warnf("Validation webhook rejected configuration of cluster '%s': %s", cluster.RuntimeID, reason)
+		return resp.Warnings, &RejectionError{RuntimeID: cluster.RuntimeID, Reason: reason}
+	}
+	if len(resp.Warnings) > 0 {
+		wh.logger.Infof("Validation webhook accepted configuration of cluster '%s' with %d warnings",
+			cluster.RuntimeID, len(resp.Warnings))
+	}
+	return resp.Warnings, nil
+}
+
+func (wh *Webhook) call(ctx context.Context, contractVersion int64, cluster *keb.Cluster) (*Response, error) {
+	clusterReq := *cluster
+	clusterReq.Kubeconfig = ""
+	var resp Response
+	err := wh.endpoint.Post(ctx, Request{
+		ContractVersion: contractVersion,
+		Cluster:         clusterReq,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
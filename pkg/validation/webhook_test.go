@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newWebhookServer(t *testing.T, resp *Response, delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "abc", req.Cluster.RuntimeID)
+		require.Empty(t, req.Cluster.Kubeconfig)
+		time.Sleep(delay)
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+}
+
+func TestWebhook(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	cluster := &keb.Cluster{RuntimeID: "abc", Kubeconfig: "secret"}
+
+	t.Run("No webhook configured", func(t *testing.T) {
+		webhook, err := NewWebhook(config.ValidationWebhookConfig{}, logger)
+		require.NoError(t, err)
+		require.Nil(t, webhook)
+		warnings, err := webhook.Validate(context.Background(), 1, cluster)
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+	})
+
+	t.Run("Invalid failure policy", func(t *testing.T) {
+		_, err := NewWebhook(config.ValidationWebhookConfig{URL: "http://localhost", FailurePolicy: "Retry"}, logger)
+		require.Error(t, err)
+	})
+
+	t.Run("Configuration is accepted with warnings", func(t *testing.T) {
+		srv := newWebhookServer(t, &Response{Allowed: true, Warnings: []string{"component 'tracing' is deprecated"}}, 0)
+		defer srv.Close()
+
+		webhook, err := NewWebhook(config.ValidationWebhookConfig{URL: srv.URL}, logger)
+		require.NoError(t, err)
+		warnings, err := webhook.Validate(context.Background(), 1, cluster)
+		require.NoError(t, err)
+		require.Equal(t, []string{"component 'tracing' is deprecated"}, warnings)
+		require.Equal(t, "secret", cluster.Kubeconfig)
+	})
+
+	t.Run("Configuration is rejected", func(t *testing.T) {
+		srv := newWebhookServer(t, &Response{Allowed: false, Reason: "profile 'evaluation' not allowed"}, 0)
+		defer srv.Close()
+
+		webhook, err := NewWebhook(config.ValidationWebhookConfig{URL: srv.URL}, logger)
+		require.NoError(t, err)
+		_, err = webhook.Validate(context.Background(), 1, cluster)
+		require.True(t, IsRejectionError(err))
+		require.Contains(t, err.Error(), "profile 'evaluation' not allowed")
+	})
+
+	t.Run("Failure policy is applied on timeout", func(t *testing.T) {
+		srv := newWebhookServer(t, &Response{Allowed: false}, 200*time.Millisecond)
+		defer srv.Close()
+
+		webhook, err := NewWebhook(config.ValidationWebhookConfig{URL: srv.URL, Timeout: "50ms"}, logger)
+		require.NoError(t, err)
+		_, err = webhook.Validate(context.Background(), 1, cluster)
+		require.Error(t, err)
+		require.False(t, IsRejectionError(err))
+
+		webhook, err = NewWebhook(config.ValidationWebhookConfig{
+			URL:           srv.URL,
+			Timeout:       "50ms",
+			FailurePolicy: string(FailurePolicyIgnore),
+		}, logger)
+		require.NoError(t, err)
+		warnings, err := webhook.Validate(context.Background(), 1, cluster)
+		require.NoError(t, err)
+		require.Empty(t, warnings)
+	})
+}
@@ -0,0 +1,43 @@
+package version
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Values are injected at build time, e.g.:
+// go build -ldflags "-X github.com/kyma-incubator/reconciler/pkg/version.GitCommit=$(git rev-parse HEAD)"
+var (
+	GitCommit = "unknown"
+	BuildDate = "unknown"
+)
+
+// ContractVersions lists the KEB contract versions the mothership is able to serve
+// (keep in sync with the contract versions handled by keb.ModelFactory)
+var ContractVersions = []int64{1, 2}
+
+type Info struct {
+	GitCommit        string
+	BuildDate        string
+	GoVersion        string
+	ContractVersions []int64
+}
+
+func Get() Info {
+	return Info{
+		GitCommit:        GitCommit,
+		BuildDate:        BuildDate,
+		GoVersion:        runtime.Version(),
+		ContractVersions: ContractVersions,
+	}
+}
+
+// ContractVersionsString returns the supported contract versions as comma-separated list (e.g. "1,2")
+func (i Info) ContractVersionsString() string {
+	versions := make([]string, 0, len(i.ContractVersions))
+	for _, v := range i.ContractVersions {
+		versions = append(versions, strconv.FormatInt(v, 10))
+	}
+	return strings.Join(versions, ",")
+}
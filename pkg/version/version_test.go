@@ -0,0 +1,23 @@
+package version
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion(t *testing.T) {
+	t.Run("Get build info", func(t *testing.T) {
+		info := Get()
+		require.Equal(t, GitCommit, info.GitCommit)
+		require.Equal(t, BuildDate, info.BuildDate)
+		require.Equal(t, runtime.Version(), info.GoVersion)
+		require.NotEmpty(t, info.ContractVersions)
+	})
+
+	t.Run("Contract versions as string", func(t *testing.T) {
+		require.Equal(t, "1,2", Info{ContractVersions: []int64{1, 2}}.ContractVersionsString())
+		require.Equal(t, "", Info{}.ContractVersionsString())
+	})
+}
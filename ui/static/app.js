@@ -0,0 +1,300 @@
+// Read-only UI of the mothership: all data is retrieved from the mothership API of the same origin.
+(function () {
+  'use strict';
+
+  const API = '/v2';
+  const PAGE_SIZE = 50;
+  const CLUSTER_STATUSES = [
+    'reconcile_pending', 'reconciling', 'ready', 'error', 'reconcile_error_retryable', 'reconcile_disabled',
+    'delete_pending', 'deleting', 'deleted', 'delete_error', 'delete_error_retryable',
+  ];
+  const TOKEN_KEY = 'reconciler-ui-token';
+
+  const view = document.getElementById('view');
+  const breadcrumbs = document.getElementById('breadcrumbs');
+  const errorBox = document.getElementById('error');
+
+  // el creates a DOM element: text is always added as text node (API data is never interpreted as HTML)
+  function el(tag, attrs, ...children) {
+    const node = document.createElement(tag);
+    Object.entries(attrs || {}).forEach(([key, value]) => {
+      if (value === undefined || value === null || value === false) {
+        return;
+      }
+      if (key.startsWith('on')) {
+        node.addEventListener(key.substring(2), value);
+      } else {
+        node.setAttribute(key, value === true ? '' : value);
+      }
+    });
+    children.flat().forEach((child) => {
+      if (child === undefined || child === null) {
+        return;
+      }
+      node.appendChild(child instanceof Node ? child : document.createTextNode(String(child)));
+    });
+    return node;
+  }
+
+  function badge(status) {
+    return el('span', {class: 'badge ' + statusClass(status)}, status || '-');
+  }
+
+  function statusClass(status) {
+    switch (status) {
+      case 'ready':
+      case 'done':
+      case 'success':
+      case 'deleted':
+        return 'ok';
+      case 'error':
+      case 'failed':
+      case 'delete_error':
+      case 'client_error':
+      case 'orphan':
+        return 'failed';
+      case 'reconcile_error_retryable':
+      case 'delete_error_retryable':
+      case 'waiting':
+      case 'pending_confirmation':
+        return 'warning';
+      case 'reconcile_disabled':
+      case 'skipped':
+      case 'aborted':
+        return 'inactive';
+      default:
+        return 'progress';
+    }
+  }
+
+  function formatTime(value) {
+    if (!value) {
+      return '-';
+    }
+    const date = new Date(value);
+    return isNaN(date.getTime()) || date.getFullYear() < 2 ? '-' : date.toLocaleString();
+  }
+
+  function formatDuration(from, to) {
+    if (!from || !to) {
+      return '-';
+    }
+    const secs = Math.round((new Date(to) - new Date(from)) / 1000);
+    if (isNaN(secs) || secs < 0) {
+      return '-';
+    }
+    if (secs < 60) {
+      return secs + 's';
+    }
+    return Math.floor(secs / 60) + 'm ' + (secs % 60) + 's';
+  }
+
+  function link(href, text) {
+    return el('a', {href: href}, text);
+  }
+
+  function clusterHref(runtimeID) {
+    return '#/clusters/' + encodeURIComponent(runtimeID);
+  }
+
+  function reconciliationHref(runtimeID, schedulingID) {
+    return clusterHref(runtimeID) + '/reconciliations/' + encodeURIComponent(schedulingID);
+  }
+
+  function table(headers, rows, emptyText) {
+    if (rows.length === 0) {
+      return el('p', {class: 'empty'}, emptyText);
+    }
+    return el('table', {},
+      el('thead', {}, el('tr', {}, headers.map((header) => el('th', {}, header)))),
+      el('tbody', {}, rows.map((cells) => el('tr', {}, cells.map((cell) => el('td', {}, cell))))));
+  }
+
+  async function api(path, params) {
+    const url = new URL(API + path, window.location.origin);
+    Object.entries(params || {}).forEach(([key, value]) => {
+      [].concat(value).filter((v) => v !== undefined && v !== '').forEach((v) => url.searchParams.append(key, v));
+    });
+    const headers = {Accept: 'application/json'};
+    const token = sessionStorage.getItem(TOKEN_KEY);
+    if (token) {
+      headers.Authorization = 'Bearer ' + token;
+    }
+    const resp = await fetch(url, {headers: headers, credentials: 'same-origin'});
+    if (!resp.ok) {
+      let detail = resp.statusText;
+      try {
+        const problem = await resp.json();
+        detail = problem.detail || problem.error || detail;
+      } catch (e) {
+        // response without problem details
+      }
+      throw new Error(`${resp.status} ${detail} (${url.pathname})`);
+    }
+    return resp.json();
+  }
+
+  function setBreadcrumbs(...items) {
+    breadcrumbs.replaceChildren(...items.flatMap((item, idx) => idx === 0 ? [item] : [el('span', {}, ' / '), item]));
+  }
+
+  function render(...nodes) {
+    view.replaceChildren(...nodes);
+  }
+
+  // views
+
+  async function clustersView(query) {
+    const offset = Math.max(0, parseInt(query.get('offset') || '0', 10) || 0);
+    const status = query.get('status') || '';
+    const runtimeID = query.get('runtimeID') || '';
+    const label = query.get('label') || '';
+    setBreadcrumbs(el('span', {}, 'Clusters'));
+
+    const resp = await api('/clusters', {
+      status: status, runtimeID: runtimeID, label: label, offset: offset, limit: PAGE_SIZE,
+    });
+
+    const filter = el('form', {class: 'filter', onsubmit: (event) => {
+      event.preventDefault();
+      const data = new FormData(event.target);
+      const params = new URLSearchParams();
+      ['status', 'runtimeID', 'label'].forEach((key) => data.get(key) && params.set(key, data.get(key)));
+      window.location.hash = '#/?' + params.toString();
+    }},
+    el('select', {name: 'status', 'aria-label': 'Status'},
+      el('option', {value: ''}, 'All statuses'),
+      CLUSTER_STATUSES.map((s) => el('option', {value: s, selected: s === status}, s))),
+    el('input', {name: 'runtimeID', placeholder: 'Runtime ID', value: runtimeID}),
+    el('input', {name: 'label', placeholder: 'Label (key=value)', value: label}),
+    el('button', {type: 'submit'}, 'Filter'));
+
+    const rows = resp.clusters.map((c) => [
+      link(clusterHref(c.runtimeID), c.runtimeID),
+      badge(c.status),
+      c.kymaVersion,
+      c.kymaProfile,
+      c.healthScore === undefined ? '-' : String(c.healthScore),
+      formatTime(c.updated),
+    ]);
+
+    const pageParams = (newOffset) => {
+      const params = new URLSearchParams(query);
+      params.set('offset', newOffset);
+      return '#/?' + params.toString();
+    };
+    const paging = el('div', {class: 'paging'},
+      offset > 0 ? link(pageParams(Math.max(0, offset - PAGE_SIZE)), '« Previous') : null,
+      el('span', {}, resp.total === 0 ? '0 clusters' :
+        `${offset + 1}-${Math.min(offset + PAGE_SIZE, resp.total)} of ${resp.total} clusters`),
+      offset + PAGE_SIZE < resp.total ? link(pageParams(offset + PAGE_SIZE), 'Next »') : null);
+
+    render(el('h1', {}, 'Clusters'), filter,
+      table(['Runtime ID', 'Status', 'Kyma version', 'Profile', 'Health', 'Updated'], rows, 'No clusters found.'),
+      paging);
+  }
+
+  async function clusterView(runtimeID, query) {
+    const offset = query.get('offset') || '24h';
+    setBreadcrumbs(link('#/', 'Clusters'), el('span', {}, runtimeID));
+
+    const [list, reconciliations, timeline] = await Promise.all([
+      api('/clusters', {runtimeID: runtimeID}),
+      api('/clusters/' + encodeURIComponent(runtimeID) + '/reconciliations', {last: 20}),
+      api('/clusters/' + encodeURIComponent(runtimeID) + '/timeline', {offset: offset}),
+    ]);
+    const summary = list.clusters[0];
+    if (!summary) {
+      throw new Error(`Cluster '${runtimeID}' not found`);
+    }
+
+    const details = el('dl', {class: 'details'},
+      el('dt', {}, 'Status'), el('dd', {}, badge(summary.status)),
+      el('dt', {}, 'Kyma version'), el('dd', {}, summary.kymaVersion),
+      el('dt', {}, 'Profile'), el('dd', {}, summary.kymaProfile || '-'),
+      el('dt', {}, 'Cluster / config version'), el('dd', {}, `${summary.clusterVersion} / ${summary.configurationVersion}`),
+      el('dt', {}, 'Health score'), el('dd', {}, summary.healthScore === undefined ? '-' : String(summary.healthScore)),
+      el('dt', {}, 'Labels'), el('dd', {}, Object.entries(summary.labels || {}).map(([k, v]) => el('code', {}, `${k}=${v}`))),
+      el('dt', {}, 'Updated'), el('dd', {}, formatTime(summary.updated)));
+
+    const reconRows = reconciliations.map((r) => [
+      link(reconciliationHref(runtimeID, r.schedulingID), r.schedulingID),
+      badge(r.status),
+      formatTime(r.created),
+      r.finished ? formatDuration(r.created, r.updated) : 'running',
+    ]);
+
+    const offsets = ['1h', '24h', '168h'].map((o) => o === offset ? el('strong', {}, o) :
+      link(clusterHref(runtimeID) + '?offset=' + o, o));
+    const eventRows = timeline.events.slice().reverse().map((e) => [
+      formatTime(e.time),
+      e.type.replace(/_/g, ' '),
+      e.component || '',
+      e.state ? badge(e.state) : '',
+      e.schedulingID ? link(reconciliationHref(runtimeID, e.schedulingID), e.schedulingID.substring(0, 8)) : '',
+      el('span', {class: 'reason'}, e.reason || ''),
+    ]);
+
+    render(el('h1', {}, runtimeID), details,
+      el('h2', {}, 'Reconciliations'),
+      table(['Scheduling ID', 'Status', 'Created', 'Duration'], reconRows, 'No reconciliations found.'),
+      el('h2', {}, 'Timeline ', el('span', {class: 'offsets'}, offsets)),
+      table(['Time', 'Event', 'Component', 'State', 'Reconciliation', 'Reason'], eventRows, 'No events in this period.'));
+  }
+
+  async function reconciliationView(runtimeID, schedulingID) {
+    setBreadcrumbs(link('#/', 'Clusters'), link(clusterHref(runtimeID), runtimeID), el('span', {}, schedulingID));
+
+    const ops = await api('/clusters/' + encodeURIComponent(runtimeID) + '/reconciliations/' +
+      encodeURIComponent(schedulingID) + '/operations');
+    const rows = ops.map((op) => [
+      String(op.priority),
+      op.component,
+      op.type,
+      badge(op.state),
+      String(op.retries),
+      formatTime(op.started),
+      formatDuration(op.started, op.finished),
+      op.reconcilerVersion || '-',
+      el('span', {class: 'reason'}, op.reason || ''),
+    ]);
+    render(el('h1', {}, 'Reconciliation ' + schedulingID),
+      table(['Priority', 'Component', 'Type', 'State', 'Attempts', 'Started', 'Duration', 'Reconciler', 'Reason'],
+        rows, 'No operations found.'));
+  }
+
+  async function route() {
+    const hash = window.location.hash.replace(/^#/, '') || '/';
+    const [path, rawQuery] = hash.split('?');
+    const query = new URLSearchParams(rawQuery || '');
+    const parts = path.split('/').filter((p) => p !== '').map(decodeURIComponent);
+    errorBox.hidden = true;
+    try {
+      if (parts.length === 2 && parts[0] === 'clusters') {
+        await clusterView(parts[1], query);
+      } else if (parts.length === 4 && parts[0] === 'clusters' && parts[2] === 'reconciliations') {
+        await reconciliationView(parts[1], parts[3]);
+      } else {
+        await clustersView(query);
+      }
+    } catch (err) {
+      errorBox.textContent = err.message;
+      errorBox.hidden = false;
+      render();
+    }
+  }
+
+  const tokenInput = document.getElementById('token');
+  tokenInput.value = sessionStorage.getItem(TOKEN_KEY) || '';
+  document.getElementById('token-form').addEventListener('submit', (event) => {
+    event.preventDefault();
+    if (tokenInput.value) {
+      sessionStorage.setItem(TOKEN_KEY, tokenInput.value.trim());
+    } else {
+      sessionStorage.removeItem(TOKEN_KEY);
+    }
+    route();
+  });
+  window.addEventListener('hashchange', route);
+  route();
+})();
@@ -0,0 +1,33 @@
+//Package ui embeds a small read-only web UI of the mothership. It shows the registered clusters, their reconciliation
+//timelines and the details of their operations by calling the mothership API from the browser.
+package ui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed static
+var static embed.FS
+
+//contentSecurityPolicy allows only the embedded assets and calls of the mothership API (no inline scripts)
+const contentSecurityPolicy = "default-src 'self'; script-src 'self'; style-src 'self'; img-src 'self' data:; " +
+	"connect-src 'self'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+
+//Handler serves the embedded assets: the handler has to be mounted with a stripped path prefix (e.g. '/ui/')
+func Handler() http.Handler {
+	assets, err := fs.Sub(static, "static")
+	if err != nil { //can't happen: the directory is embedded
+		panic(err)
+	}
+	fileServer := http.FileServer(http.FS(assets))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", contentSecurityPolicy)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+		//assets are updated with the mothership: browsers have to revalidate them
+		w.Header().Set("Cache-Control", "no-cache")
+		fileServer.ServeHTTP(w, r)
+	})
+}
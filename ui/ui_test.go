@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	handler := Handler()
+	serve := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	t.Run("Index", func(t *testing.T) {
+		recorder := serve("/")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Contains(t, recorder.Header().Get("Content-Type"), "text/html")
+		require.Equal(t, contentSecurityPolicy, recorder.Header().Get("Content-Security-Policy"))
+		require.Contains(t, recorder.Body.String(), `<script src="app.js"></script>`)
+	})
+
+	t.Run("Assets", func(t *testing.T) {
+		for _, asset := range []string{"/app.js", "/style.css"} {
+			recorder := serve(asset)
+			require.Equal(t, http.StatusOK, recorder.Code, asset)
+			require.NotEmpty(t, recorder.Body.String(), asset)
+		}
+	})
+
+	t.Run("Unknown asset", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, serve("/admin.js").Code)
+	})
+}